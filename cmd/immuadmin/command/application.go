@@ -0,0 +1,53 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package immuadmin
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+func (cl *commandline) application(cmd *cobra.Command) {
+	ccmd := &cobra.Command{
+		Use:               "application",
+		Short:             "Issue commands on the requests of the applications, identified by the name clients tag their requests with",
+		Aliases:           []string{"app"},
+		PersistentPostRun: cl.disconnect,
+		ValidArgs:         []string{"cancel"},
+	}
+
+	ccancel := &cobra.Command{
+		Use:               "cancel",
+		Short:             "Cancel the in-flight requests of an application",
+		PersistentPreRunE: cl.ConfigChain(cl.connect),
+		PersistentPostRun: cl.disconnect,
+		Example:           "cancel {application_name}",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resp, err := cl.immuClient.CancelApplicationRequests(cl.context, args[0])
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%d request(s) of application %s successfully cancelled\n", resp.Cancelled, args[0])
+			return nil
+		},
+		Args: cobra.ExactArgs(1),
+	}
+
+	ccmd.AddCommand(ccancel)
+	cmd.AddCommand(ccmd)
+}
@@ -20,8 +20,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	stdos "os"
 	"path"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"time"
@@ -40,11 +42,12 @@ import (
 )
 
 type backupper struct {
-	daemon daem.Daemon
-	os     immuos.OS
-	copier fs.Copier
-	tarer  fs.Tarer
-	ziper  fs.Ziper
+	daemon    daem.Daemon
+	os        immuos.OS
+	copier    fs.Copier
+	tarer     fs.Tarer
+	ziper     fs.Ziper
+	encrypter fs.Encrypter
 }
 
 func newBackupper(os immuos.OS) (*backupper, error) {
@@ -53,11 +56,12 @@ func newBackupper(os immuos.OS) (*backupper, error) {
 		return nil, err
 	}
 	return &backupper{
-			daemon: d,
-			os:     os,
-			copier: fs.NewStandardCopier(),
-			tarer:  fs.NewStandardTarer(),
-			ziper:  fs.NewStandardZiper()},
+			daemon:    d,
+			os:        os,
+			copier:    fs.NewStandardCopier(),
+			tarer:     fs.NewStandardTarer(),
+			ziper:     fs.NewStandardZiper(),
+			encrypter: fs.NewStandardEncrypter()},
 		nil
 }
 
@@ -65,8 +69,9 @@ func newBackupper(os immuos.OS) (*backupper, error) {
 type Backupper interface {
 	mustNotBeWorkingDir(p string) error
 	stopImmudbService() (func(), error)
-	offlineBackup(src string, uncompressed bool, manualStopStart bool) (string, error)
-	offlineRestore(src string, dst string, manualStopStart bool) (string, error)
+	offlineBackup(src string, uncompressed bool, manualStopStart bool, recipients []string) (string, error)
+	offlineRestore(src string, dst string, manualStopStart bool, identityFile string) (string, error)
+	decryptArchive(src string, identityFile string) (string, func(), error)
 }
 
 type commandlineBck struct {
@@ -94,6 +99,8 @@ func (clb *commandlineBck) Register(rootCmd *cobra.Command) *cobra.Command {
 	clb.dumpToFile(rootCmd)
 	clb.backup(rootCmd)
 	clb.restore(rootCmd)
+	clb.exportTxs(rootCmd)
+	clb.applyTxs(rootCmd)
 	return rootCmd
 }
 
@@ -151,13 +158,117 @@ func (cl *commandlineBck) dumpToFile(cmd *cobra.Command) {
 	cmd.AddCommand(ccmd)
 }
 
+func (cl *commandlineBck) exportTxs(cmd *cobra.Command) {
+	ccmd := &cobra.Command{
+		Use:   "export-txs [file] [--since-tx] [--up-to-tx] [--recipient]",
+		Short: "Export the transactions committed in a range to an archive file",
+		Long: "Export to a compressed archive the transactions of the selected database committed after " +
+			"--since-tx up to --up-to-tx (or the latest one). Applying the archive onto a backup holding " +
+			"--since-tx as its latest transaction brings it up to date, thus backups can be incrementally updated. " +
+			"The archive is encrypted by the server to each --recipient (age public key) when any is provided.",
+		PersistentPreRunE: cl.ConfigChain(cl.checkLoggedInAndConnect),
+		PersistentPostRun: cl.disconnect,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sinceTx, err := cmd.Flags().GetUint64("since-tx")
+			if err != nil {
+				cl.quit(err)
+				return nil
+			}
+			upToTx, err := cmd.Flags().GetUint64("up-to-tx")
+			if err != nil {
+				cl.quit(err)
+				return nil
+			}
+			recipients, err := cmd.Flags().GetStringArray("recipient")
+			if err != nil {
+				cl.quit(err)
+				return nil
+			}
+			filename := fmt.Sprint("immudb_txs_" + time.Now().Format("2006-01-02_15-04-05") + ".bkp")
+			if len(args) > 0 {
+				filename = args[0]
+			}
+			file, err := cl.os.Create(filename)
+			if err != nil {
+				cl.quit(err)
+				return nil
+			}
+			defer file.Close()
+			n, err := cl.immuClient.ExportTxRange(cl.context, sinceTx, upToTx, file, recipients...)
+			if err != nil {
+				cl.os.Remove(filename)
+				cl.quit(err)
+				return nil
+			}
+			fmt.Printf("SUCCESS: transactions after tx %d were exported to file %s (%d bytes)\n", sinceTx, filename, n)
+			return nil
+		},
+		Args: cobra.MaximumNArgs(1),
+	}
+	ccmd.Flags().Uint64("since-tx", 0, "transactions committed after it are exported")
+	ccmd.Flags().Uint64("up-to-tx", 0, "latest exported transaction, all the committed transactions are exported when 0")
+	ccmd.Flags().StringArray("recipient", nil, "age public key (age1...) the archive is encrypted to, it can be repeated")
+	cmd.AddCommand(ccmd)
+}
+
+func (cl *commandlineBck) applyTxs(cmd *cobra.Command) {
+	ccmd := &cobra.Command{
+		Use:   "apply-txs file [--identity]",
+		Short: "Apply the transactions exported to an archive file",
+		Long: "Commit into the selected database the transactions exported with export-txs. " +
+			"The latest transaction of the database must be the one the archive was exported since. " +
+			"Encrypted archives are decrypted with the age private keys held by the --identity file.",
+		PersistentPreRunE: cl.ConfigChain(cl.checkLoggedInAndConnect),
+		PersistentPostRun: cl.disconnect,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			identityFile, err := cmd.Flags().GetString("identity")
+			if err != nil {
+				cl.quit(err)
+				return nil
+			}
+			archivePath := args[0]
+			if identityFile != "" {
+				decryptedPath, cleanup, err := cl.decryptArchive(archivePath, identityFile)
+				if err != nil {
+					cl.quit(err)
+					return nil
+				}
+				defer cleanup()
+				archivePath = decryptedPath
+			}
+			fi, err := cl.os.Stat(archivePath)
+			if err != nil {
+				cl.quit(err)
+				return nil
+			}
+			file, err := cl.os.Open(archivePath)
+			if err != nil {
+				cl.quit(err)
+				return nil
+			}
+			defer file.Close()
+			txRange, err := cl.immuClient.ApplyTxRange(cl.context, file, int(fi.Size()))
+			if err != nil {
+				cl.quit(err)
+				return nil
+			}
+			fmt.Printf("SUCCESS: transactions %d to %d were applied\n", txRange.SinceTx+1, txRange.UpToTx)
+			return nil
+		},
+		Args: cobra.ExactArgs(1),
+	}
+	ccmd.Flags().String("identity", "", "file holding the age private keys the archive is decrypted with")
+	cmd.AddCommand(ccmd)
+}
+
 func (cl *commandlineBck) backup(cmd *cobra.Command) {
 	defaultDbDir := server.DefaultOptions().Dir
 	ccmd := &cobra.Command{
-		Use:   "backup [--dbdir] [--manual-stop-start] [--uncompressed]",
+		Use:   "backup [--dbdir] [--manual-stop-start] [--uncompressed] [--recipient]",
 		Short: "Make a copy of the database files and folders",
 		Long: "Pause the immudb server, create and save on the server machine a snapshot " +
-			"of the database files and folders (zip on Windows, tar.gz on Linux or uncompressed).",
+			"of the database files and folders (zip on Windows, tar.gz on Linux or uncompressed). " +
+			"The snapshot archive is encrypted to each --recipient (age public key) when any is provided.",
 		PersistentPreRunE: cl.ConfigChain(nil),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			dbDir, err := cmd.Flags().GetString("dbdir")
@@ -179,11 +290,20 @@ func (cl *commandlineBck) backup(cmd *cobra.Command) {
 				cl.quit(err)
 				return nil
 			}
+			recipients, err := cmd.Flags().GetStringArray("recipient")
+			if err != nil {
+				cl.quit(err)
+				return nil
+			}
+			if err = checkBackupRecipients(recipients, uncompressed); err != nil {
+				cl.quit(err)
+				return nil
+			}
 			if err := cl.askUserConfirmation("backup", manualStopStart); err != nil {
 				cl.quit(err)
 				return nil
 			}
-			backupPath, err := cl.offlineBackup(dbDir, uncompressed, manualStopStart)
+			backupPath, err := cl.offlineBackup(dbDir, uncompressed, manualStopStart, recipients)
 			if err != nil {
 				cl.quit(err)
 				return nil
@@ -196,16 +316,18 @@ func (cl *commandlineBck) backup(cmd *cobra.Command) {
 	ccmd.Flags().String("dbdir", defaultDbDir, fmt.Sprintf("path to the server database directory to backup (default %s)", defaultDbDir))
 	ccmd.Flags().Bool("manual-stop-start", false, "server stop before and restart after the backup are to be handled manually by the user (default false)")
 	ccmd.Flags().BoolP("uncompressed", "u", false, "create an uncompressed backup (i.e. make just a copy of the db directory)")
+	ccmd.Flags().StringArray("recipient", nil, "age public key (age1...) the backup is encrypted to, it can be repeated")
 	cmd.AddCommand(ccmd)
 }
 
 func (cl *commandlineBck) restore(cmd *cobra.Command) {
 	defaultDbDir := server.DefaultOptions().Dir
 	ccmd := &cobra.Command{
-		Use:   "restore snapshot-path [--dbdir] [--manual-stop-start]",
+		Use:   "restore snapshot-path [--dbdir] [--manual-stop-start] [--identity]",
 		Short: "Restore the database from a snapshot archive or folder",
 		Long: "Pause the immudb server and restore the database files and folders from a snapshot " +
-			"file (zip or tar.gz) or folder (uncompressed) residing on the server machine.",
+			"file (zip or tar.gz) or folder (uncompressed) residing on the server machine. " +
+			"Encrypted snapshots (.age) are decrypted with the age private keys held by the --identity file.",
 		PersistentPreRunE: cl.ConfigChain(nil),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			snapshotPath := args[0]
@@ -219,11 +341,16 @@ func (cl *commandlineBck) restore(cmd *cobra.Command) {
 				cl.quit(err)
 				return nil
 			}
+			identityFile, err := cmd.Flags().GetString("identity")
+			if err != nil {
+				cl.quit(err)
+				return nil
+			}
 			if err := cl.askUserConfirmation("restore", manualStopStart); err != nil {
 				cl.quit(err)
 				return nil
 			}
-			autoBackupPath, err := cl.offlineRestore(snapshotPath, dbDir, manualStopStart)
+			autoBackupPath, err := cl.offlineRestore(snapshotPath, dbDir, manualStopStart, identityFile)
 			if err != nil {
 				cl.quit(err)
 				return nil
@@ -236,6 +363,7 @@ func (cl *commandlineBck) restore(cmd *cobra.Command) {
 	}
 	ccmd.Flags().String("dbdir", defaultDbDir, fmt.Sprintf("path to the server database directory which will be replaced by the backup (default %s)", defaultDbDir))
 	ccmd.Flags().Bool("manual-stop-start", false, "server stop before and restart after the backup are to be handled manually by the user (default false)")
+	ccmd.Flags().String("identity", "", "file holding the age private keys an encrypted snapshot is decrypted with")
 	cmd.AddCommand(ccmd)
 }
 
@@ -301,7 +429,22 @@ func (b *backupper) stopImmudbService() (func(), error) {
 	}, nil
 }
 
-func (b *backupper) offlineBackup(src string, uncompressed bool, manualStopStart bool) (string, error) {
+func checkBackupRecipients(recipients []string, uncompressed bool) error {
+	if len(recipients) == 0 {
+		return nil
+	}
+	if uncompressed {
+		return errors.New("an uncompressed backup can not be encrypted, remove either --uncompressed or --recipient")
+	}
+	_, err := fs.ParseRecipients(recipients)
+	return err
+}
+
+func (b *backupper) offlineBackup(src string, uncompressed bool, manualStopStart bool, recipients []string) (string, error) {
+	if err := checkBackupRecipients(recipients, uncompressed); err != nil {
+		return "", err
+	}
+
 	srcInfo, err := b.os.Stat(src)
 	if err != nil {
 		return "", err
@@ -360,6 +503,22 @@ func (b *backupper) offlineBackup(src string, uncompressed bool, manualStopStart
 			snapshotPath, archivePath, err)
 	}
 
+	if len(recipients) > 0 {
+		encryptedPath := archivePath + fs.EncryptedExt
+		encryptErr := b.encrypter.EncryptIt(archivePath, encryptedPath, recipients)
+		// the unencrypted archive is not kept, even if its encryption failed
+		if err = b.os.Remove(archivePath); err != nil {
+			fmt.Fprintf(stdos.Stderr,
+				"error removing unencrypted db archive %s: %v", archivePath, err)
+		}
+		if encryptErr != nil {
+			return "", fmt.Errorf(
+				"database compressed successfully to %s, but encryption to %s failed: %v",
+				archivePath, encryptedPath, encryptErr)
+		}
+		archivePath = encryptedPath
+	}
+
 	absArchivePath, err := b.os.Abs(archivePath)
 	if err != nil {
 		fmt.Fprintf(stdos.Stderr,
@@ -371,12 +530,23 @@ func (b *backupper) offlineBackup(src string, uncompressed bool, manualStopStart
 	return absArchivePath, nil
 }
 
-func (b *backupper) offlineRestore(src string, dst string, manualStopStart bool) (string, error) {
+func (b *backupper) offlineRestore(src string, dst string, manualStopStart bool, identityFile string) (string, error) {
 	snapshotPath := src
 	_, err := b.os.Stat(snapshotPath)
 	if err != nil {
 		return "", err
 	}
+	if strings.ToLower(b.os.Ext(snapshotPath)) == fs.EncryptedExt {
+		if identityFile == "" {
+			return "", fmt.Errorf("snapshot %s is encrypted, provide the file holding the private key to decrypt it", snapshotPath)
+		}
+		decryptedPath, cleanup, err := b.decryptArchive(snapshotPath, identityFile)
+		if err != nil {
+			return "", err
+		}
+		defer cleanup()
+		snapshotPath = decryptedPath
+	}
 	snapshotExt := b.os.Ext(snapshotPath)
 	snapshotName := b.os.Base(snapshotPath)
 	snapshotNameNoExt := strings.TrimSuffix(snapshotName, snapshotExt)
@@ -440,3 +610,24 @@ func (b *backupper) offlineRestore(src string, dst string, manualStopStart bool)
 
 	return dbDirAutoBackupPath, nil
 }
+
+// decryptArchive decrypts src into a temporary folder, the returned function removes it
+func (b *backupper) decryptArchive(src string, identityFile string) (string, func(), error) {
+	tmpDir, err := ioutil.TempDir("", "immudb_decrypted_")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := func() {
+		if err := b.os.RemoveAll(tmpDir); err != nil {
+			fmt.Fprintf(stdos.Stderr, "error removing decrypted archive folder %s: %v", tmpDir, err)
+		}
+	}
+
+	dst := filepath.Join(tmpDir, strings.TrimSuffix(b.os.Base(src), fs.EncryptedExt))
+	if err = b.encrypter.DecryptIt(src, dst, identityFile); err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("archive %s can not be decrypted: %v", src, err)
+	}
+
+	return dst, cleanup, nil
+}
@@ -35,6 +35,7 @@ type Commandline interface {
 	stats(cmd *cobra.Command)
 	serverConfig(cmd *cobra.Command)
 	database(cmd *cobra.Command)
+	application(cmd *cobra.Command)
 	ConfigChain(post func(cmd *cobra.Command, args []string) error) func(cmd *cobra.Command, args []string) (err error)
 }
 
@@ -91,6 +92,7 @@ func (cl *commandline) Register(rootCmd *cobra.Command) *cobra.Command {
 	cl.stats(rootCmd)
 	cl.serverConfig(rootCmd)
 	cl.database(rootCmd)
+	cl.application(rootCmd)
 	return rootCmd
 }
 
@@ -18,6 +18,7 @@ package immuadmin
 
 import (
 	"fmt"
+	"time"
 
 	c "github.com/codenotary/immudb/cmd/helper"
 	"github.com/codenotary/immudb/pkg/api/schema"
@@ -32,7 +33,7 @@ func (cl *commandline) database(cmd *cobra.Command) {
 		Aliases: []string{"d"},
 		//PersistentPreRunE: cl.ConfigChain(cl.connect),
 		PersistentPostRun: cl.disconnect,
-		ValidArgs:         []string{"list", "create", "use", "clean"},
+		ValidArgs:         []string{"list", "create", "update", "delete", "undelete", "deleted", "shrink", "opened-files", "close-files", "use", "clean"},
 	}
 	ccd := &cobra.Command{
 		Use:               "list",
@@ -68,19 +69,212 @@ func (cl *commandline) database(cmd *cobra.Command) {
 		Short:             "Create a new database",
 		PersistentPreRunE: cl.ConfigChain(cl.connect),
 		PersistentPostRun: cl.disconnect,
-		Example:           "create {database_name}",
+		Example:           "create {database_name} [--max-key-len 256] [--max-value-len 1048576] [--max-tx-entries 1024]",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if err := cl.immuClient.CreateDatabase(cl.context, &schema.Database{
+			settings, err := databaseSettingsFromFlags(cmd, args[0])
+			if err != nil {
+				return err
+			}
+			if err := cl.immuClient.CreateDatabaseWith(cl.context, settings); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "database successfully created\n")
+			return nil
+		},
+		Args: cobra.ExactArgs(1),
+	}
+	cc.Flags().Uint32("max-key-len", 0, "max length of keys, the server default is used when 0")
+	cc.Flags().Uint32("max-value-len", 0, "max length of values, the server default is used when 0")
+	cc.Flags().Uint32("max-tx-entries", 0, "max number of entries per transaction, the server default is used when 0")
+
+	cupd := &cobra.Command{
+		Use:               "update",
+		Short:             "Update the settings of a database, only the max length of values can be increased once created",
+		PersistentPreRunE: cl.ConfigChain(cl.connect),
+		PersistentPostRun: cl.disconnect,
+		Example:           "update {database_name} --max-value-len 4194304",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			maxValueLen, err := cmd.Flags().GetUint32("max-value-len")
+			if err != nil {
+				return err
+			}
+			if err := cl.immuClient.UpdateDatabase(cl.context, &schema.DatabaseSettings{
 				DatabaseName: args[0],
+				MaxValueLen:  maxValueLen,
 			}); err != nil {
 				return err
 			}
-			fmt.Fprintf(cmd.OutOrStdout(), "database successfully created\n")
+			fmt.Fprintf(cmd.OutOrStdout(), "database successfully updated\n")
+			return nil
+		},
+		Args: cobra.ExactArgs(1),
+	}
+	cupd.Flags().Uint32("max-value-len", 0, "max length of values, it can only be increased")
+
+	cdel := &cobra.Command{
+		Use:               "delete",
+		Short:             "Delete a database, it can be restored during the retention period configured on the server",
+		PersistentPreRunE: cl.ConfigChain(cl.connect),
+		PersistentPostRun: cl.disconnect,
+		Example:           "delete {database_name}",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cl.immuClient.DeleteDatabase(cl.context, &schema.Database{
+				DatabaseName: args[0],
+			}); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "database successfully deleted\n")
+			return nil
+		},
+		Args: cobra.ExactArgs(1),
+	}
+
+	cundel := &cobra.Command{
+		Use:               "undelete",
+		Short:             "Restore a deleted database",
+		PersistentPreRunE: cl.ConfigChain(cl.connect),
+		PersistentPostRun: cl.disconnect,
+		Example:           "undelete {database_name}",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cl.immuClient.UndeleteDatabase(cl.context, &schema.Database{
+				DatabaseName: args[0],
+			}); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "database successfully restored\n")
+			return nil
+		},
+		Args: cobra.ExactArgs(1),
+	}
+
+	cdl := &cobra.Command{
+		Use:               "deleted",
+		Short:             "List deleted databases which can still be restored",
+		PersistentPreRunE: cl.ConfigChain(cl.connect),
+		PersistentPostRun: cl.disconnect,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resp, err := cl.immuClient.DeletedDatabaseList(cl.context)
+			if err != nil {
+				return err
+			}
+			c.PrintTable(
+				cmd.OutOrStdout(),
+				[]string{"Database Name", "Deleted At", "Purged At"},
+				len(resp.Databases),
+				func(i int) []string {
+					db := resp.Databases[i]
+					purgeAt := "never"
+					if db.PurgeAt > 0 {
+						purgeAt = time.Unix(db.PurgeAt, 0).String()
+					}
+					return []string{db.DatabaseName, time.Unix(db.DeletedAt, 0).String(), purgeAt}
+				},
+				fmt.Sprintf("%d deleted database(s)", len(resp.Databases)),
+			)
+			return nil
+		},
+		Args: cobra.ExactArgs(0),
+	}
+
+	cshr := &cobra.Command{
+		Use:               "shrink",
+		Short:             "Rewrite a database into a compacted copy, reclaiming the space taken by values out of the retention period",
+		PersistentPreRunE: cl.ConfigChain(cl.connect),
+		PersistentPostRun: cl.disconnect,
+		Example:           "shrink {database_name}",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cl.immuClient.ShrinkDatabase(cl.context, &schema.Database{
+				DatabaseName: args[0],
+			}); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "database successfully shrunk\n")
 			return nil
 		},
 		Args: cobra.ExactArgs(1),
 	}
 
+	crot := &cobra.Command{
+		Use:               "rotate-keys",
+		Short:             "Wrap the encryption key of every database with the current master key of the key provider",
+		PersistentPreRunE: cl.ConfigChain(cl.connect),
+		PersistentPostRun: cl.disconnect,
+		Example:           "rotate-keys",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resp, err := cl.immuClient.RotateEncryptionKeys(cl.context)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%d encryption key(s) successfully rotated\n", resp.Rotated)
+			return nil
+		},
+		Args: cobra.ExactArgs(0),
+	}
+
+	cof := &cobra.Command{
+		Use:               "opened-files",
+		Short:             "List the files opened by a database, or by all the loaded databases when no name is given",
+		PersistentPreRunE: cl.ConfigChain(cl.connect),
+		PersistentPostRun: cl.disconnect,
+		Example:           "opened-files [database_name]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req := &schema.Database{}
+			if len(args) > 0 {
+				req.DatabaseName = args[0]
+			}
+
+			resp, err := cl.immuClient.OpenedFiles(cl.context, req)
+			if err != nil {
+				return err
+			}
+
+			var rows [][]string
+
+			for _, db := range resp.Databases {
+				for _, l := range db.Logs {
+					rows = append(rows, []string{
+						db.Database,
+						l.Path,
+						fmt.Sprintf("%d", len(l.Files)),
+						fmt.Sprintf("%d", l.MaxOpenedFiles),
+					})
+				}
+			}
+
+			c.PrintTable(
+				cmd.OutOrStdout(),
+				[]string{"Database Name", "Log", "Opened Files", "Max Cached Files"},
+				len(rows),
+				func(i int) []string { return rows[i] },
+				fmt.Sprintf("process opened files: %d, limit: %d", resp.ProcessOpenedFiles, resp.ProcessMaxOpenedFiles),
+			)
+			return nil
+		},
+		Args: cobra.MaximumNArgs(1),
+	}
+
+	ccf := &cobra.Command{
+		Use:               "close-files",
+		Short:             "Close the files kept open for reading by a database, or by all the loaded databases when no name is given",
+		PersistentPreRunE: cl.ConfigChain(cl.connect),
+		PersistentPostRun: cl.disconnect,
+		Example:           "close-files [database_name]",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req := &schema.Database{}
+			if len(args) > 0 {
+				req.DatabaseName = args[0]
+			}
+
+			resp, err := cl.immuClient.CloseCachedFiles(cl.context, req)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%d file(s) successfully closed\n", resp.ClosedFiles)
+			return nil
+		},
+		Args: cobra.MaximumNArgs(1),
+	}
+
 	ccu := &cobra.Command{
 		Use:               "use command",
 		Short:             "Select database",
@@ -135,5 +329,37 @@ func (cl *commandline) database(cmd *cobra.Command) {
 	ccmd.AddCommand(ccu)
 	ccmd.AddCommand(ccd)
 	ccmd.AddCommand(cc)
+	ccmd.AddCommand(cupd)
+	ccmd.AddCommand(cdel)
+	ccmd.AddCommand(cundel)
+	ccmd.AddCommand(cdl)
+	ccmd.AddCommand(cshr)
+	ccmd.AddCommand(crot)
+	ccmd.AddCommand(cof)
+	ccmd.AddCommand(ccf)
 	cmd.AddCommand(ccmd)
 }
+
+func databaseSettingsFromFlags(cmd *cobra.Command, dbname string) (*schema.DatabaseSettings, error) {
+	maxKeyLen, err := cmd.Flags().GetUint32("max-key-len")
+	if err != nil {
+		return nil, err
+	}
+
+	maxValueLen, err := cmd.Flags().GetUint32("max-value-len")
+	if err != nil {
+		return nil, err
+	}
+
+	maxTxEntries, err := cmd.Flags().GetUint32("max-tx-entries")
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema.DatabaseSettings{
+		DatabaseName: dbname,
+		MaxKeyLen:    maxKeyLen,
+		MaxValueLen:  maxValueLen,
+		MaxTxEntries: maxTxEntries,
+	}, nil
+}
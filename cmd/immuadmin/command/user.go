@@ -20,8 +20,11 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"time"
 
 	c "github.com/codenotary/immudb/cmd/helper"
 	"github.com/codenotary/immudb/pkg/api/schema"
@@ -126,12 +129,57 @@ immuadmin user create user1 admin mydb`,
 		},
 		Args: cobra.ExactValidArgs(4),
 	}
+	userExport := &cobra.Command{
+		Use:     "export {file}",
+		Short:   "Export all users and their permissions to a JSON file",
+		Long:    "Export all users but the system admin, along with their permissions, to a JSON file. Passwords are not exported",
+		Example: "immuadmin user export users.json",
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			var resp string
+			if resp, err = cl.exportUsers(args[0]); err == nil {
+				fmt.Fprint(cmd.OutOrStdout(), resp)
+			}
+			return err
+		},
+		Args: cobra.ExactArgs(1),
+	}
+	userImport := &cobra.Command{
+		Use:   "import {file}",
+		Short: "Import users and their permissions from a JSON file",
+		Long: `Import the users exported by the export command. Permissions and status of existing users are replaced,
+while new users are given a password reset token, to be used within its expiration to choose their password`,
+		Example: "immuadmin user import users.json",
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			var resp string
+			if resp, err = cl.importUsers(args[0]); err == nil {
+				fmt.Fprint(cmd.OutOrStdout(), resp)
+			}
+			return err
+		},
+		Args: cobra.ExactArgs(1),
+	}
+	userResetPassword := &cobra.Command{
+		Use:     "resetpassword {username} {token}",
+		Short:   "Choose the password of an imported user by means of its password reset token",
+		Example: "immuadmin user resetpassword user1 8c5e0f...",
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			var resp string
+			if resp, err = cl.resetUserPassword(args[0], args[1]); err == nil {
+				fmt.Fprint(cmd.OutOrStdout(), resp)
+			}
+			return err
+		},
+		Args: cobra.ExactArgs(2),
+	}
 	ccmd.AddCommand(userListCmd)
 	ccmd.AddCommand(userCreate)
 	ccmd.AddCommand(userChangePassword)
 	ccmd.AddCommand(userActivate)
 	ccmd.AddCommand(userDeactivate)
 	ccmd.AddCommand(userPermission)
+	ccmd.AddCommand(userExport)
+	ccmd.AddCommand(userImport)
+	ccmd.AddCommand(userResetPassword)
 	cmd.AddCommand(ccmd)
 }
 
@@ -156,6 +204,131 @@ func (cl *commandline) changeUserPassword(username string, oldpassword []byte) (
 	return fmt.Sprintf("%s's password has been changed", username), newpass, nil
 }
 
+// usersExport is the content of the files written by the export command
+type usersExport struct {
+	Users []exportedUser `json:"users"`
+}
+
+type exportedUser struct {
+	Username    string               `json:"username"`
+	Active      bool                 `json:"active"`
+	Permissions []exportedPermission `json:"permissions"`
+}
+
+type exportedPermission struct {
+	Database   string `json:"database"`
+	Permission string `json:"permission"` // read, readwrite or admin
+}
+
+func (cl *commandline) exportUsers(filename string) (string, error) {
+	userlist, err := cl.immuClient.ExportUsers(cl.context)
+	if err != nil {
+		return "", err
+	}
+
+	export := usersExport{Users: make([]exportedUser, len(userlist.Users))}
+
+	for i, u := range userlist.Users {
+		export.Users[i] = exportedUser{
+			Username:    string(u.User),
+			Active:      u.Active,
+			Permissions: make([]exportedPermission, len(u.Permissions)),
+		}
+
+		for j, p := range u.Permissions {
+			export.Users[i].Permissions[j] = exportedPermission{
+				Database:   p.Database,
+				Permission: auth.PermissionName(p.Permission),
+			}
+		}
+	}
+
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	err = ioutil.WriteFile(filename, data, 0600)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("Exported %d user(s) to %s\n", len(export.Users), filename), nil
+}
+
+func (cl *commandline) importUsers(filename string) (string, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return "", err
+	}
+
+	var export usersExport
+
+	err = json.Unmarshal(data, &export)
+	if err != nil {
+		return "", fmt.Errorf("invalid users file %s: %v", filename, err)
+	}
+
+	userlist := &schema.UserList{Users: make([]*schema.User, len(export.Users))}
+
+	for i, u := range export.Users {
+		userlist.Users[i] = &schema.User{
+			User:        []byte(u.Username),
+			Active:      u.Active,
+			Permissions: make([]*schema.Permission, len(u.Permissions)),
+		}
+
+		for j, p := range u.Permissions {
+			permission, err := permissionFromString(p.Permission)
+			if err != nil {
+				return "", fmt.Errorf("user %s: %v", u.Username, err)
+			}
+
+			userlist.Users[i].Permissions[j] = &schema.Permission{Database: p.Database, Permission: permission}
+		}
+	}
+
+	res, err := cl.immuClient.ImportUsers(cl.context, userlist)
+	if err != nil {
+		return "", err
+	}
+
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "Imported %d user(s)\n", len(export.Users))
+
+	if len(res.Tokens) > 0 {
+		fmt.Fprintf(&b, "New users must choose their password using 'immuadmin user resetpassword {username} {token}':\n")
+
+		for _, t := range res.Tokens {
+			fmt.Fprintf(&b, "%s\t%s\t(expires at %s)\n", t.User, t.Token, time.Unix(t.ExpiresAt, 0).Format(time.RFC3339))
+		}
+	}
+
+	return b.String(), nil
+}
+
+func (cl *commandline) resetUserPassword(username string, token string) (string, error) {
+	newpass, err := cl.passwordReader.Read(fmt.Sprintf("Choose a password for %s:", username))
+	if err != nil {
+		return "", errors.New("Error Reading Password")
+	}
+	if err = auth.IsStrongPassword(string(newpass)); err != nil {
+		return "", errors.New("password does not meet the requirements. It must contain upper and lower case letters, digits, punctuation mark or symbol")
+	}
+	pass2, err := cl.passwordReader.Read("Confirm password:")
+	if err != nil {
+		return "", errors.New("Error Reading Password")
+	}
+	if !bytes.Equal(newpass, pass2) {
+		return "", errors.New("Passwords don't match")
+	}
+	if err := cl.immuClient.ResetPassword(cl.context, []byte(username), token, newpass); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s's password has been set", username), nil
+}
+
 func (cl *commandline) userList(args []string) (string, error) {
 	userlist, err := cl.immuClient.ListUsers(cl.context)
 	if err != nil {
@@ -0,0 +1,110 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package immuadmin
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	"github.com/codenotary/immudb/pkg/client"
+	"github.com/stretchr/testify/require"
+)
+
+type usersClientMock struct {
+	client.ImmuClient
+
+	users *schema.UserList
+}
+
+func (m *usersClientMock) ExportUsers(ctx context.Context) (*schema.UserList, error) {
+	return m.users, nil
+}
+
+func (m *usersClientMock) ImportUsers(ctx context.Context, users *schema.UserList) (*schema.ImportUsersResponse, error) {
+	m.users = users
+
+	res := &schema.ImportUsersResponse{}
+	for _, u := range users.Users {
+		res.Tokens = append(res.Tokens, &schema.PasswordResetToken{User: u.User, Token: "token", ExpiresAt: time.Now().Unix()})
+	}
+
+	return res, nil
+}
+
+func TestUserExportImport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "users")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	users := &schema.UserList{Users: []*schema.User{
+		{
+			User:   []byte("user1"),
+			Active: true,
+			Permissions: []*schema.Permission{
+				{Database: "db1", Permission: auth.PermissionR},
+				{Database: "db2", Permission: auth.PermissionAdmin},
+			},
+			Createdby: auth.SysAdminUsername,
+		},
+		{
+			User:        []byte("user2"),
+			Permissions: []*schema.Permission{{Database: "db1", Permission: auth.PermissionRW}},
+		},
+	}}
+
+	clientMock := &usersClientMock{users: users}
+	cl := &commandline{immuClient: clientMock, context: context.Background()}
+
+	filename := filepath.Join(dir, "users.json")
+
+	resp, err := cl.exportUsers(filename)
+	require.NoError(t, err)
+	require.Contains(t, resp, "Exported 2 user(s)")
+
+	data, err := ioutil.ReadFile(filename)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"permission": "readwrite"`)
+
+	clientMock.users = nil
+
+	resp, err = cl.importUsers(filename)
+	require.NoError(t, err)
+	require.Contains(t, resp, "Imported 2 user(s)")
+	require.Contains(t, resp, "user2\ttoken")
+
+	require.Len(t, clientMock.users.Users, 2)
+	for i, u := range clientMock.users.Users {
+		require.Equal(t, users.Users[i].User, u.User)
+		require.Equal(t, users.Users[i].Active, u.Active)
+		require.Equal(t, users.Users[i].Permissions, u.Permissions)
+	}
+
+	err = ioutil.WriteFile(filename, []byte(`{"users":[{"username":"user1","permissions":[{"database":"db1","permission":"owner"}]}]}`), 0600)
+	require.NoError(t, err)
+
+	_, err = cl.importUsers(filename)
+	require.Error(t, err)
+
+	_, err = cl.importUsers(filepath.Join(dir, "missing.json"))
+	require.Error(t, err)
+}
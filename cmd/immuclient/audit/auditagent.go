@@ -181,6 +181,13 @@ func options() *client.Options {
 		WithPrometheusHost(prometheusHost).
 		WithLogFileName(logfilename).
 		WithServerSigningPubKey(serverSigningPubKey)
+
+	trustAnchors, err := client.ParseTrustAnchors(viper.GetString("trust-anchors"))
+	if err != nil {
+		c.QuitToStdErr(err)
+	}
+	options.WithTrustAnchors(trustAnchors)
+
 	if mtls {
 		// todo https://golang.org/src/crypto/x509/root_linux.go
 		options.MTLsOptions = client.DefaultMTLsOptions().
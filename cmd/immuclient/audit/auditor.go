@@ -119,6 +119,7 @@ func (cAgent *auditAgent) InitAgent() (AuditAgent, error) {
 		auditPassword,
 		auditDatabases,
 		pk,
+		cliOpts.TrustAnchors,
 		auditor.AuditNotificationConfig{
 			URL:            auditNotificationURL,
 			Username:       auditNotificationUsername,
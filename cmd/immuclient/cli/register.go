@@ -49,8 +49,8 @@ func (cli *cli) initCommands() {
 	cli.Register(&command{"safereference", "Add and verify new reference to an existing key", cli.safereference, []string{"refkey", "key"}, false})
 
 	// Scannner commands
-	cli.Register(&command{"scan", "Iterate over keys having the specified prefix", cli.scan, []string{"prefix"}, false})
-	cli.Register(&command{"zscan", "Iterate over a sorted set", cli.zScan, []string{"prefix"}, false})
+	cli.Register(&command{"scan", "Iterate over keys having the specified prefix (--desc for descending order)", cli.scan, []string{"prefix"}, false})
+	cli.Register(&command{"zscan", "Iterate over a sorted set (--desc for descending order)", cli.zScan, []string{"prefix"}, false})
 	cli.Register(&command{"count", "Count keys having the specified prefix", cli.count, []string{"prefix"}, false})
 
 	// Misc commands
@@ -63,4 +63,6 @@ func (cli *cli) initCommands() {
 	cli.Register(&command{"query", "Query sql statement", cli.sqlQuery, []string{"statement"}, false})
 	cli.Register(&command{"describe", "Describe table", cli.describeTable, []string{"table"}, false})
 	cli.Register(&command{"tables", "List tables", cli.listTables, nil, false})
+	cli.Register(&command{"schema-export", "Export the SQL schema of the database as DDL statements", cli.exportSQLSchema, nil, false})
+	cli.Register(&command{"schema-import", "Apply the DDL statements of an exported SQL schema", cli.importSQLSchema, []string{"file"}, false})
 }
@@ -32,6 +32,14 @@ func (cli *cli) listTables(args []string) (string, error) {
 	return cli.immucl.ListTables()
 }
 
+func (cli *cli) exportSQLSchema(args []string) (string, error) {
+	return cli.immucl.ExportSQLSchema()
+}
+
+func (cli *cli) importSQLSchema(args []string) (string, error) {
+	return cli.immucl.ImportSQLSchema(args)
+}
+
 func (cli *cli) useDatabase(args []string) (string, error) {
 	return cli.immucl.UseDatabase(args)
 }
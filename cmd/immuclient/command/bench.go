@@ -0,0 +1,75 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package immuclient
+
+import (
+	"fmt"
+
+	"github.com/codenotary/immudb/cmd/immuclient/immuc"
+	"github.com/spf13/cobra"
+)
+
+func (cl *commandline) bench(cmd *cobra.Command) {
+	defaults := immuc.DefaultBenchOptions()
+
+	ccmd := &cobra.Command{
+		Use:   "bench",
+		Short: "Generate synthetic KV or SQL load and report throughput and latency percentiles",
+		Long: `Generate synthetic KV or SQL load and report throughput and latency percentiles.
+In kv mode entries are written with set and read with get, in sql mode rows are upserted into
+and selected by primary key from a table created if it does not exist.`,
+		PersistentPreRunE: cl.ConfigChain(cl.connect),
+		PersistentPostRun: cl.disconnect,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resp, err := cl.immucl.Bench(benchOptions(cmd))
+			if err != nil {
+				cl.quit(err)
+			}
+			fmt.Fprint(cmd.OutOrStdout(), resp)
+			return nil
+		},
+		Args: cobra.NoArgs,
+	}
+	ccmd.Flags().String("mode", defaults.Mode, "type of load to generate: kv or sql")
+	ccmd.Flags().Int("requests", defaults.Requests, "total number of operations")
+	ccmd.Flags().Int("concurrency", defaults.Concurrency, "number of concurrent workers")
+	ccmd.Flags().Int("keys", defaults.Keys, "size of the key space")
+	ccmd.Flags().String("distribution", defaults.Distribution, "how keys are picked: uniform, zipf or sequential")
+	ccmd.Flags().Int("min-value-size", defaults.MinValueSize, "minimum size in bytes of written values")
+	ccmd.Flags().Int("max-value-size", defaults.MaxValueSize, "maximum size in bytes of written values")
+	ccmd.Flags().Float64("read-ratio", defaults.ReadRatio, "fraction of the operations being reads, between 0 and 1")
+	ccmd.Flags().String("key-prefix", defaults.KeyPrefix, "prefix of the generated keys (kv mode)")
+	ccmd.Flags().String("table", defaults.Table, "table receiving the generated rows (sql mode)")
+	ccmd.Flags().Int64("seed", defaults.Seed, "seed of the random generators to replay the same load, a random one is used if zero")
+	cmd.AddCommand(ccmd)
+}
+
+func benchOptions(cmd *cobra.Command) *immuc.BenchOptions {
+	opts := immuc.DefaultBenchOptions()
+	opts.Mode, _ = cmd.Flags().GetString("mode")
+	opts.Requests, _ = cmd.Flags().GetInt("requests")
+	opts.Concurrency, _ = cmd.Flags().GetInt("concurrency")
+	opts.Keys, _ = cmd.Flags().GetInt("keys")
+	opts.Distribution, _ = cmd.Flags().GetString("distribution")
+	opts.MinValueSize, _ = cmd.Flags().GetInt("min-value-size")
+	opts.MaxValueSize, _ = cmd.Flags().GetInt("max-value-size")
+	opts.ReadRatio, _ = cmd.Flags().GetFloat64("read-ratio")
+	opts.KeyPrefix, _ = cmd.Flags().GetString("key-prefix")
+	opts.Table, _ = cmd.Flags().GetString("table")
+	opts.Seed, _ = cmd.Flags().GetInt64("seed")
+	return opts
+}
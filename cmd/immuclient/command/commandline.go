@@ -71,6 +71,11 @@ func (cl *commandline) Register(rootCmd *cobra.Command) *cobra.Command {
 	cl.sqlQuery(rootCmd)
 	cl.listTables(rootCmd)
 	cl.describeTable(rootCmd)
+	cl.exportSQLSchema(rootCmd)
+	cl.importSQLSchema(rootCmd)
+	cl.exportParquet(rootCmd)
+
+	cl.bench(rootCmd)
 
 	return rootCmd
 }
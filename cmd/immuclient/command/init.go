@@ -56,6 +56,8 @@ func (cl *commandline) configureFlags(cmd *cobra.Command) error {
 	cmd.PersistentFlags().String("audit-notification-username", "", "Username used to authenticate when publishing audit result to 'audit-notification-url'.")
 	cmd.PersistentFlags().String("audit-notification-password", "", "Password used to authenticate when publishing audit result to 'audit-notification-url'.")
 	cmd.PersistentFlags().String("server-signing-pub-key", "", "Path to the public key to verify signatures when presents")
+	cmd.PersistentFlags().String("trust-anchors", "", "comma-separated list of states known to be genuine, as database:txID:txHash, the history of each database on the server must be consistent with")
+	cmd.PersistentFlags().String("application-name", "", "name the requests are tagged with, used by the server for per-application metrics, quotas and cancellation")
 
 	viper.BindPFlag("immudb-port", cmd.PersistentFlags().Lookup("immudb-port"))
 	viper.BindPFlag("immudb-address", cmd.PersistentFlags().Lookup("immudb-address"))
@@ -81,6 +83,8 @@ func (cl *commandline) configureFlags(cmd *cobra.Command) error {
 	viper.BindPFlag("audit-notification-username", cmd.PersistentFlags().Lookup("audit-notification-username"))
 	viper.BindPFlag("audit-notification-password", cmd.PersistentFlags().Lookup("audit-notification-password"))
 	viper.BindPFlag("server-signing-pub-key", cmd.PersistentFlags().Lookup("server-signing-pub-key"))
+	viper.BindPFlag("trust-anchors", cmd.PersistentFlags().Lookup("trust-anchors"))
+	viper.BindPFlag("application-name", cmd.PersistentFlags().Lookup("application-name"))
 
 	viper.SetDefault("immudb-port", client.DefaultOptions().Port)
 	viper.SetDefault("immudb-address", client.DefaultOptions().Address)
@@ -105,6 +109,8 @@ func (cl *commandline) configureFlags(cmd *cobra.Command) error {
 	viper.SetDefault("audit-notification-username", "")
 	viper.SetDefault("audit-notification-password", "")
 	viper.SetDefault("server-signing-pub-key", "")
+	viper.SetDefault("trust-anchors", "")
+	viper.SetDefault("application-name", "")
 	viper.SetDefault("dir", os.TempDir())
 	return nil
 }
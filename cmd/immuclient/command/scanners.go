@@ -30,7 +30,7 @@ func (cl *commandline) zScan(cmd *cobra.Command) {
 		PersistentPreRunE: cl.ConfigChain(cl.connect),
 		PersistentPostRun: cl.disconnect,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			resp, err := cl.immucl.ZScan(args)
+			resp, err := cl.immucl.ZScan(withDescFlag(cmd, args))
 			if err != nil {
 				cl.quit(err)
 			}
@@ -39,6 +39,7 @@ func (cl *commandline) zScan(cmd *cobra.Command) {
 		},
 		Args: cobra.ExactArgs(1),
 	}
+	ccmd.Flags().Bool("desc", false, "return entries in descending order")
 	cmd.AddCommand(ccmd)
 }
 
@@ -50,7 +51,7 @@ func (cl *commandline) scan(cmd *cobra.Command) {
 		PersistentPreRunE: cl.ConfigChain(cl.connect),
 		PersistentPostRun: cl.disconnect,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			resp, err := cl.immucl.Scan(args)
+			resp, err := cl.immucl.Scan(withDescFlag(cmd, args))
 			if err != nil {
 				cl.quit(err)
 			}
@@ -59,9 +60,19 @@ func (cl *commandline) scan(cmd *cobra.Command) {
 		},
 		Args: cobra.ExactArgs(1),
 	}
+	ccmd.Flags().Bool("desc", false, "return entries in descending order")
 	cmd.AddCommand(ccmd)
 }
 
+// withDescFlag appends the desc flag to the positional arguments, as expected by scanning commands
+func withDescFlag(cmd *cobra.Command, args []string) []string {
+	desc, _ := cmd.Flags().GetBool("desc")
+	if desc {
+		return append(args, "--desc")
+	}
+	return args
+}
+
 func (cl *commandline) count(cmd *cobra.Command) {
 	ccmd := &cobra.Command{
 		Use:               "count keys",
@@ -102,3 +102,71 @@ func (cl *commandline) describeTable(cmd *cobra.Command) {
 	}
 	cmd.AddCommand(ccmd)
 }
+
+func (cl *commandline) exportSQLSchema(cmd *cobra.Command) {
+	ccmd := &cobra.Command{
+		Use:               "schema-export",
+		Short:             "Export the SQL schema of the database as DDL statements",
+		PersistentPreRunE: cl.ConfigChain(cl.connect),
+		PersistentPostRun: cl.disconnect,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resp, err := cl.immucl.ExportSQLSchema()
+			if err != nil {
+				cl.quit(err)
+			}
+			_, err = fmt.Fprint(cmd.OutOrStdout(), resp)
+			return err
+		},
+		Args: cobra.ExactArgs(0),
+	}
+	cmd.AddCommand(ccmd)
+}
+
+func (cl *commandline) importSQLSchema(cmd *cobra.Command) {
+	ccmd := &cobra.Command{
+		Use:               "schema-import file",
+		Short:             "Apply the DDL statements of an exported SQL schema",
+		PersistentPreRunE: cl.ConfigChain(cl.connect),
+		PersistentPostRun: cl.disconnect,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resp, err := cl.immucl.ImportSQLSchema(args)
+			if err != nil {
+				cl.quit(err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), resp+"\n")
+			return nil
+		},
+		Args: cobra.ExactArgs(1),
+	}
+	cmd.AddCommand(ccmd)
+}
+
+func (cl *commandline) exportParquet(cmd *cobra.Command) {
+	ccmd := &cobra.Command{
+		Use:   "parquet-export file",
+		Short: "Export a table or the result of a query into a Parquet file",
+		Long: `Export a table or the result of a query into a Parquet file.
+Tables are exported as of the last tx when the export starts, or as of the tx given with --as-of-tx.
+The result of a query is limited to the number of rows the server returns per query, tables may be
+queried as they were at a given tx with the BEFORE TX clause.`,
+		PersistentPreRunE: cl.ConfigChain(cl.connect),
+		PersistentPostRun: cl.disconnect,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			table, _ := cmd.Flags().GetString("table")
+			query, _ := cmd.Flags().GetString("query")
+			asOfTx, _ := cmd.Flags().GetUint64("as-of-tx")
+
+			resp, err := cl.immucl.ExportParquet(args[0], table, query, asOfTx)
+			if err != nil {
+				cl.quit(err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), resp+"\n")
+			return nil
+		},
+		Args: cobra.ExactArgs(1),
+	}
+	ccmd.Flags().String("table", "", "table to be exported")
+	ccmd.Flags().String("query", "", "query whose result is exported")
+	ccmd.Flags().Uint64("as-of-tx", 0, "export the table as it was right after the given tx was committed")
+	cmd.AddCommand(ccmd)
+}
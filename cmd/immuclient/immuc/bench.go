@@ -0,0 +1,306 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package immuc
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/client"
+)
+
+const (
+	BenchModeKV  = "kv"
+	BenchModeSQL = "sql"
+
+	BenchDistributionUniform    = "uniform"
+	BenchDistributionZipf       = "zipf"
+	BenchDistributionSequential = "sequential"
+)
+
+// BenchOptions describes the synthetic load generated by Bench
+type BenchOptions struct {
+	Mode         string  // kv or sql
+	Requests     int     // total number of operations
+	Concurrency  int     // number of concurrent workers
+	Keys         int     // size of the key space
+	Distribution string  // how keys are picked: uniform, zipf or sequential
+	MinValueSize int     // minimum size in bytes of written values
+	MaxValueSize int     // maximum size in bytes of written values
+	ReadRatio    float64 // fraction of the operations being reads
+	KeyPrefix    string  // prefix of the generated keys (kv mode)
+	Table        string  // table receiving the generated rows (sql mode)
+	Seed         int64   // seed of the random generators, a random one is used if zero
+}
+
+// DefaultBenchOptions ...
+func DefaultBenchOptions() *BenchOptions {
+	return &BenchOptions{
+		Mode:         BenchModeKV,
+		Requests:     10000,
+		Concurrency:  4,
+		Keys:         1000,
+		Distribution: BenchDistributionUniform,
+		MinValueSize: 256,
+		MaxValueSize: 256,
+		ReadRatio:    0,
+		KeyPrefix:    "bench:",
+		Table:        "bench",
+	}
+}
+
+// Validate ...
+func (o *BenchOptions) Validate() error {
+	if o.Mode != BenchModeKV && o.Mode != BenchModeSQL {
+		return fmt.Errorf("unsupported mode '%s', use %s or %s", o.Mode, BenchModeKV, BenchModeSQL)
+	}
+	if o.Distribution != BenchDistributionUniform &&
+		o.Distribution != BenchDistributionZipf &&
+		o.Distribution != BenchDistributionSequential {
+		return fmt.Errorf("unsupported key distribution '%s', use %s, %s or %s",
+			o.Distribution, BenchDistributionUniform, BenchDistributionZipf, BenchDistributionSequential)
+	}
+	if o.Requests < 1 || o.Concurrency < 1 || o.Keys < 1 {
+		return fmt.Errorf("requests, concurrency and keys must be greater than zero")
+	}
+	if o.MinValueSize < 0 || o.MaxValueSize < o.MinValueSize {
+		return fmt.Errorf("invalid value size range [%d, %d]", o.MinValueSize, o.MaxValueSize)
+	}
+	if o.ReadRatio < 0 || o.ReadRatio > 1 {
+		return fmt.Errorf("read ratio must be between 0 and 1")
+	}
+	if o.Mode == BenchModeSQL && o.Table == "" {
+		return fmt.Errorf("a table name is required in %s mode", BenchModeSQL)
+	}
+	return nil
+}
+
+type benchWorkerResult struct {
+	writes    []time.Duration
+	reads     []time.Duration
+	misses    int
+	errors    int
+	lastError error
+}
+
+// Bench generates synthetic load against the server and reports throughput and latency percentiles
+func (i *immuc) Bench(opts *BenchOptions) (string, error) {
+	if opts == nil {
+		return "", client.ErrIllegalArguments
+	}
+
+	err := opts.Validate()
+	if err != nil {
+		return "", err
+	}
+
+	seed := opts.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	ctx := context.Background()
+
+	if opts.Mode == BenchModeSQL {
+		_, err = i.Execute(func(immuClient client.ImmuClient) (interface{}, error) {
+			return immuClient.SQLExec(ctx, fmt.Sprintf(
+				"CREATE TABLE IF NOT EXISTS %s (id INTEGER, payload BLOB, PRIMARY KEY id)", opts.Table), nil)
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+
+	var issued, seq int64
+
+	results := make([]*benchWorkerResult, opts.Concurrency)
+
+	var wg sync.WaitGroup
+
+	start := time.Now()
+
+	for w := 0; w < opts.Concurrency; w++ {
+		wg.Add(1)
+
+		go func(w int) {
+			defer wg.Done()
+			results[w] = i.benchWorker(ctx, opts, rand.New(rand.NewSource(seed+int64(w))), &issued, &seq)
+		}(w)
+	}
+
+	wg.Wait()
+
+	return benchReport(opts, seed, results, time.Since(start)), nil
+}
+
+func (i *immuc) benchWorker(ctx context.Context, opts *BenchOptions, rnd *rand.Rand, issued, seq *int64) *benchWorkerResult {
+	res := &benchWorkerResult{}
+
+	var zipf *rand.Zipf
+	if opts.Distribution == BenchDistributionZipf && opts.Keys > 1 {
+		zipf = rand.NewZipf(rnd, 1.1, 1, uint64(opts.Keys-1))
+	}
+
+	for atomic.AddInt64(issued, 1) <= int64(opts.Requests) {
+		var k uint64
+
+		switch opts.Distribution {
+		case BenchDistributionSequential:
+			k = uint64((atomic.AddInt64(seq, 1) - 1) % int64(opts.Keys))
+		case BenchDistributionZipf:
+			if zipf != nil {
+				k = zipf.Uint64()
+			}
+		default:
+			k = uint64(rnd.Intn(opts.Keys))
+		}
+
+		read := rnd.Float64() < opts.ReadRatio
+
+		var value []byte
+		if !read {
+			value = make([]byte, opts.MinValueSize+rnd.Intn(opts.MaxValueSize-opts.MinValueSize+1))
+			rnd.Read(value)
+		}
+
+		start := time.Now()
+
+		found, err := i.benchOp(ctx, opts, k, read, value)
+
+		latency := time.Since(start)
+
+		if err != nil {
+			res.errors++
+			res.lastError = err
+			continue
+		}
+
+		if read {
+			res.reads = append(res.reads, latency)
+			if !found {
+				res.misses++
+			}
+		} else {
+			res.writes = append(res.writes, latency)
+		}
+	}
+
+	return res
+}
+
+// benchOp reads or writes the entry for the key k, reads report whether the entry was found
+func (i *immuc) benchOp(ctx context.Context, opts *BenchOptions, k uint64, read bool, value []byte) (bool, error) {
+	if opts.Mode == BenchModeSQL {
+		params := map[string]interface{}{"id": k}
+
+		if read {
+			res, err := i.ImmuClient.SQLQuery(ctx, fmt.Sprintf("SELECT id, payload FROM %s WHERE id = @id", opts.Table), params, false)
+			if err != nil {
+				return false, err
+			}
+			return len(res.Rows) > 0, nil
+		}
+
+		params["payload"] = value
+
+		_, err := i.ImmuClient.SQLExec(ctx, fmt.Sprintf("UPSERT INTO %s (id, payload) VALUES (@id, @payload)", opts.Table), params)
+		return false, err
+	}
+
+	key := []byte(fmt.Sprintf("%s%d", opts.KeyPrefix, k))
+
+	if read {
+		_, err := i.ImmuClient.Get(ctx, key)
+		if err != nil && strings.Contains(err.Error(), "key not found") {
+			return false, nil
+		}
+		return err == nil, err
+	}
+
+	_, err := i.ImmuClient.Set(ctx, key, value)
+	return false, err
+}
+
+func benchReport(opts *BenchOptions, seed int64, results []*benchWorkerResult, elapsed time.Duration) string {
+	var writes, reads []time.Duration
+	var misses, errors int
+	var lastError error
+
+	for _, res := range results {
+		writes = append(writes, res.writes...)
+		reads = append(reads, res.reads...)
+		misses += res.misses
+		errors += res.errors
+		if res.lastError != nil {
+			lastError = res.lastError
+		}
+	}
+
+	completed := len(writes) + len(reads)
+
+	var str strings.Builder
+	str.WriteString(fmt.Sprintf("mode:\t\t%s\n", opts.Mode))
+	str.WriteString(fmt.Sprintf("distribution:\t%s over %d keys\n", opts.Distribution, opts.Keys))
+	str.WriteString(fmt.Sprintf("value size:\t%d-%d bytes\n", opts.MinValueSize, opts.MaxValueSize))
+	str.WriteString(fmt.Sprintf("concurrency:\t%d\n", opts.Concurrency))
+	str.WriteString(fmt.Sprintf("seed:\t\t%d\n", seed))
+	str.WriteString(fmt.Sprintf("requests:\t%d (%d errors)\n", opts.Requests, errors))
+	str.WriteString(fmt.Sprintf("elapsed:\t%s\n", elapsed.Round(time.Millisecond)))
+	str.WriteString(fmt.Sprintf("throughput:\t%.2f ops/s\n", float64(completed)/elapsed.Seconds()))
+	str.WriteString(fmt.Sprintf("writes:\t\t%s\n", latencySummary(writes)))
+	str.WriteString(fmt.Sprintf("reads:\t\t%s", latencySummary(reads)))
+	if len(reads) > 0 {
+		str.WriteString(fmt.Sprintf(" (%d not found)", misses))
+	}
+	str.WriteString("\n")
+	if lastError != nil {
+		str.WriteString(fmt.Sprintf("last error:\t%v\n", lastError))
+	}
+
+	return str.String()
+}
+
+func latencySummary(latencies []time.Duration) string {
+	if len(latencies) == 0 {
+		return "0"
+	}
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+	return fmt.Sprintf("%d\tp50 %s\tp90 %s\tp99 %s\tmax %s",
+		len(latencies),
+		percentile(latencies, 50),
+		percentile(latencies, 90),
+		percentile(latencies, 99),
+		latencies[len(latencies)-1])
+}
+
+// percentile expects latencies to be sorted
+func percentile(latencies []time.Duration, p float64) time.Duration {
+	i := int(math.Ceil(p/100*float64(len(latencies)))) - 1
+	if i < 0 {
+		i = 0
+	}
+	return latencies[i]
+}
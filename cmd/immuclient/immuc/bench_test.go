@@ -0,0 +1,89 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package immuc_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/codenotary/immudb/cmd/immuclient/immuc"
+	test "github.com/codenotary/immudb/cmd/immuclient/immuclienttest"
+	"github.com/codenotary/immudb/pkg/client"
+	"github.com/codenotary/immudb/pkg/server"
+	"github.com/codenotary/immudb/pkg/server/servertest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBench(t *testing.T) {
+	options := server.DefaultOptions().WithAuth(true)
+	bs := servertest.NewBufconnServer(options)
+
+	bs.Start()
+	defer bs.Stop()
+
+	defer os.RemoveAll(options.Dir)
+	defer os.Remove(".state-")
+
+	ts := client.NewTokenService().WithTokenFileName("testTokenFile").WithHds(&test.HomedirServiceMock{})
+	ic := test.NewClientTest(&test.PasswordReader{
+		Pass: []string{"immudb"},
+	}, ts).WithOptions(client.DefaultOptions())
+	ic.
+		Connect(bs.Dialer)
+	ic.Login("immudb")
+
+	_, err := ic.Imc.Bench(nil)
+	require.Equal(t, client.ErrIllegalArguments, err)
+
+	opts := immuc.DefaultBenchOptions()
+	opts.Mode = "graph"
+	_, err = ic.Imc.Bench(opts)
+	require.Error(t, err)
+
+	opts = immuc.DefaultBenchOptions()
+	opts.MinValueSize = 10
+	opts.MaxValueSize = 5
+	_, err = ic.Imc.Bench(opts)
+	require.Error(t, err)
+
+	for _, distribution := range []string{immuc.BenchDistributionUniform, immuc.BenchDistributionZipf, immuc.BenchDistributionSequential} {
+		opts = immuc.DefaultBenchOptions()
+		opts.Requests = 50
+		opts.Keys = 10
+		opts.Distribution = distribution
+		opts.MinValueSize = 1
+		opts.MaxValueSize = 32
+		opts.ReadRatio = 0.5
+		opts.Seed = 1
+
+		msg, err := ic.Imc.Bench(opts)
+		require.NoError(t, err)
+		require.Contains(t, msg, "requests:\t50 (0 errors)")
+		require.Contains(t, msg, "p99")
+	}
+
+	opts = immuc.DefaultBenchOptions()
+	opts.Mode = immuc.BenchModeSQL
+	opts.Requests = 20
+	opts.Concurrency = 2
+	opts.ReadRatio = 0.5
+
+	msg, err := ic.Imc.Bench(opts)
+	require.NoError(t, err)
+	require.Contains(t, msg, "mode:\t\tsql")
+	require.Contains(t, msg, "requests:\t20 (0 errors)")
+}
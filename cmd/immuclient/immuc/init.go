@@ -73,6 +73,10 @@ type Client interface {
 	SQLQuery(args []string) (string, error)
 	ListTables() (string, error)
 	DescribeTable(args []string) (string, error)
+	ExportSQLSchema() (string, error)
+	ImportSQLSchema(args []string) (string, error)
+	ExportParquet(file, table, query string, asOfTx uint64) (string, error)
+	Bench(opts *BenchOptions) (string, error)
 }
 
 // Init ...
@@ -166,7 +170,14 @@ func Options() *client.Options {
 		WithTokenFileName(viper.GetString("tokenfile")).
 		WithMTLs(viper.GetBool("mtls")).
 		WithTokenService(client.NewTokenService().WithTokenFileName(viper.GetString("tokenfile")).WithHds(client.NewHomedirService())).
-		WithServerSigningPubKey(viper.GetString("server-signing-pub-key"))
+		WithServerSigningPubKey(viper.GetString("server-signing-pub-key")).
+		WithApplicationName(viper.GetString("application-name"))
+
+	trustAnchors, err := client.ParseTrustAnchors(viper.GetString("trust-anchors"))
+	if err != nil {
+		c.QuitToStdErr(err)
+	}
+	options.WithTrustAnchors(trustAnchors)
 
 	if viper.GetBool("mtls") {
 		// todo https://golang.org/src/crypto/x509/root_linux.go
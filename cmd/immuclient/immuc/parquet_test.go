@@ -0,0 +1,91 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package immuc_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	test "github.com/codenotary/immudb/cmd/immuclient/immuclienttest"
+	"github.com/codenotary/immudb/pkg/client"
+	"github.com/codenotary/immudb/pkg/parquet"
+	"github.com/codenotary/immudb/pkg/server"
+	"github.com/codenotary/immudb/pkg/server/servertest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportParquet(t *testing.T) {
+	options := server.DefaultOptions().WithAuth(true)
+	bs := servertest.NewBufconnServer(options)
+
+	bs.Start()
+	defer bs.Stop()
+
+	defer os.RemoveAll(options.Dir)
+	defer os.Remove(".state-")
+
+	ts := client.NewTokenService().WithTokenFileName("testTokenFile").WithHds(&test.HomedirServiceMock{})
+	ic := test.NewClientTest(&test.PasswordReader{
+		Pass: []string{"immudb"},
+	}, ts).WithOptions(client.DefaultOptions())
+	ic.
+		Connect(bs.Dialer)
+	ic.Login("immudb")
+
+	_, err := ic.Imc.SQLExec([]string{"CREATE TABLE table1(id INTEGER, title VARCHAR, PRIMARY KEY id)"})
+	require.NoError(t, err)
+
+	_, err = ic.Imc.SQLExec([]string{"INSERT INTO table1(id, title) VALUES (1, 'title1'), (2, 'title2')"})
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "parquet_export")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "table1.parquet")
+
+	_, err = ic.Imc.ExportParquet(file, "", "", 0)
+	require.Equal(t, client.ErrIllegalArguments, err)
+
+	_, err = ic.Imc.ExportParquet(file, "table1", "SELECT id FROM table1", 0)
+	require.Equal(t, client.ErrIllegalArguments, err)
+
+	_, err = ic.Imc.ExportParquet(file, "", "SELECT id FROM table1", 1)
+	require.Equal(t, client.ErrIllegalArguments, err)
+
+	_, err = ic.Imc.ExportParquet(file, "table2", "", 0)
+	require.Error(t, err)
+	require.NoFileExists(t, file)
+
+	msg, err := ic.Imc.ExportParquet(file, "table1", "", 0)
+	require.NoError(t, err)
+	require.Equal(t, "2 row(s) exported to "+file, msg)
+
+	b, err := ioutil.ReadFile(file)
+	require.NoError(t, err)
+
+	f, err := parquet.Read(bytes.NewReader(b), int64(len(b)))
+	require.NoError(t, err)
+	require.Equal(t, [][]interface{}{{uint64(1), "title1"}, {uint64(2), "title2"}}, f.Rows)
+
+	msg, err = ic.Imc.ExportParquet(file, "", "SELECT title FROM table1 WHERE id > 1", 0)
+	require.NoError(t, err)
+	require.Equal(t, "1 row(s) exported to "+file, msg)
+}
@@ -73,6 +73,9 @@ func PrintTx(tx *schema.Tx, verified bool) string {
 	str.WriteString(fmt.Sprintf("time:		%s\n", time.Unix(int64(tx.Metadata.Ts), 0)))
 	str.WriteString(fmt.Sprintf("entries:	%d\n", tx.Metadata.Nentries))
 	str.WriteString(fmt.Sprintf("hash:		%x\n", schema.TxMetadataFrom(tx.Metadata).Alh()))
+	if tx.Metadata.Attributes != nil && len(tx.Metadata.Attributes.TraceID) > 0 {
+		str.WriteString(fmt.Sprintf("trace id:	%x\n", tx.Metadata.Attributes.TraceID))
+	}
 	if verified {
 		str.WriteString(fmt.Sprintf("verified:	%t \n", verified))
 	}
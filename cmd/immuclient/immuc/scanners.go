@@ -25,12 +25,25 @@ import (
 	"github.com/codenotary/immudb/pkg/client"
 )
 
+// descFlag is accepted after the positional arguments of scanning commands to get entries in descending order
+const descFlag = "--desc"
+
+func descOrder(args []string) bool {
+	for _, arg := range args[1:] {
+		if arg == descFlag {
+			return true
+		}
+	}
+	return false
+}
+
 func (i *immuc) ZScan(args []string) (string, error) {
 	set := []byte(args[0])
+	desc := descOrder(args)
 	ctx := context.Background()
 
 	response, err := i.Execute(func(immuClient client.ImmuClient) (interface{}, error) {
-		return immuClient.ZScan(ctx, &schema.ZScanRequest{Set: set, SinceTx: math.MaxUint64, NoWait: true})
+		return immuClient.ZScan(ctx, &schema.ZScanRequest{Set: set, Desc: desc, SinceTx: math.MaxUint64, NoWait: true})
 	})
 	if err != nil {
 		rpcerrors := strings.SplitAfter(err.Error(), "=")
@@ -60,11 +73,12 @@ func (i *immuc) ZScan(args []string) (string, error) {
 
 func (i *immuc) Scan(args []string) (res string, err error) {
 	prefix := []byte(args[0])
+	desc := descOrder(args)
 
 	ctx := context.Background()
 
 	response, err := i.Execute(func(immuClient client.ImmuClient) (interface{}, error) {
-		return immuClient.Scan(ctx, &schema.ScanRequest{Prefix: prefix, SinceTx: math.MaxUint64, NoWait: true})
+		return immuClient.Scan(ctx, &schema.ScanRequest{Prefix: prefix, Desc: desc, SinceTx: math.MaxUint64, NoWait: true})
 	})
 	if err != nil {
 		rpcerrors := strings.SplitAfter(err.Error(), "=")
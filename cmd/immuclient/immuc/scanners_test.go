@@ -18,6 +18,7 @@ package immuc_test
 
 import (
 	"os"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -118,6 +119,40 @@ func TestScan(t *testing.T) {
 	if !strings.Contains(msg, "hash") {
 		t.Fatalf("Scan failed: %s", msg)
 	}
+
+	_, err = ic.Imc.Set([]string{"key2", "val2"})
+	if err != nil {
+		t.Fatal("Set fail", err)
+	}
+
+	msg, err = ic.Imc.Scan([]string{"k"})
+	if err != nil {
+		t.Fatal("Scan fail", err)
+	}
+	if keys := scannedKeys(msg); !reflect.DeepEqual(keys, []string{"key", "key2"}) {
+		t.Fatalf("Scan failed to return entries in ascending order: %v", keys)
+	}
+
+	msg, err = ic.Imc.Scan([]string{"k", "--desc"})
+	if err != nil {
+		t.Fatal("Scan fail", err)
+	}
+	if keys := scannedKeys(msg); !reflect.DeepEqual(keys, []string{"key2", "key"}) {
+		t.Fatalf("Scan failed to return entries in descending order: %v", keys)
+	}
+}
+
+// scannedKeys returns the keys printed by a scan, in the order they were returned
+func scannedKeys(msg string) []string {
+	var keys []string
+
+	for _, line := range strings.Split(msg, "\n") {
+		if strings.HasPrefix(line, "key:") {
+			keys = append(keys, strings.TrimSpace(strings.TrimPrefix(line, "key:")))
+		}
+	}
+
+	return keys
 }
 
 func _TestCount(t *testing.T) {
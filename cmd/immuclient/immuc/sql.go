@@ -22,6 +22,8 @@ import (
 	"github.com/codenotary/immudb/pkg/api/schema"
 	"github.com/codenotary/immudb/pkg/client"
 	"github.com/olekukonko/tablewriter"
+	"io/ioutil"
+	"os"
 	"strings"
 )
 
@@ -90,6 +92,72 @@ func (i *immuc) DescribeTable(args []string) (string, error) {
 	return response.(string), nil
 }
 
+func (i *immuc) ExportSQLSchema() (string, error) {
+	ctx := context.Background()
+	response, err := i.Execute(func(immuClient client.ImmuClient) (interface{}, error) {
+		return immuClient.ExportSQLSchema(ctx)
+	})
+	if err != nil {
+		return "", err
+	}
+	return response.(string), nil
+}
+
+func (i *immuc) ImportSQLSchema(args []string) (string, error) {
+	if len(args) != 1 {
+		return "", client.ErrIllegalArguments
+	}
+
+	ddl, err := ioutil.ReadFile(args[0])
+	if err != nil {
+		return "", err
+	}
+
+	ctx := context.Background()
+	response, err := i.Execute(func(immuClient client.ImmuClient) (interface{}, error) {
+		return immuClient.ImportSQLSchema(ctx, string(ddl))
+	})
+	if err != nil {
+		return "", err
+	}
+
+	txMetas := response.(*schema.SQLExecResult)
+
+	return fmt.Sprintf("schema imported, Ctxs: %d", len(txMetas.Ctxs)), nil
+}
+
+// ExportParquet writes either the rows of a table, optionally as they were right after the given tx
+// was committed, or the result of a query into a Parquet file
+func (i *immuc) ExportParquet(file, table, query string, asOfTx uint64) (string, error) {
+	if file == "" || (table == "") == (query == "") || (query != "" && asOfTx > 0) {
+		return "", client.ErrIllegalArguments
+	}
+
+	f, err := os.Create(file)
+	if err != nil {
+		return "", err
+	}
+
+	ctx := context.Background()
+	response, err := i.Execute(func(immuClient client.ImmuClient) (interface{}, error) {
+		if table != "" {
+			return immuClient.ExportTableParquet(ctx, table, asOfTx, f)
+		}
+		return immuClient.ExportQueryParquet(ctx, query, nil, f)
+	})
+	if err == nil {
+		err = f.Close()
+	} else {
+		f.Close()
+	}
+	if err != nil {
+		os.Remove(file)
+		return "", err
+	}
+
+	return fmt.Sprintf("%d row(s) exported to %s", response.(uint64), file), nil
+}
+
 func renderTableResult(resp *schema.SQLQueryResult) string {
 	if resp == nil {
 		return ""
@@ -55,6 +55,10 @@ Environment variables:
   IMMUDB_TOKEN_EXPIRY_TIME=1440
   IMMUDB_PGSQL_SERVER=true
   IMMUDB_PGSQL_SERVER_PORT=5432
+  IMMUDB_PGSQL_SERVER_CERTIFICATE=
+  IMMUDB_PGSQL_SERVER_PKEY=
+  IMMUDB_PGSQL_SERVER_REQUIRE_SSL=false
+  IMMUDB_PGSQL_SERVER_AUTH_METHOD=password
   LOG_LEVEL={debug|info|warning|error}
 `,
 		DisableAutoGenTag: true,
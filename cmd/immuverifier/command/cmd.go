@@ -0,0 +1,172 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package immuverifier
+
+import (
+	"context"
+	"net/http"
+	"os"
+
+	c "github.com/codenotary/immudb/cmd/helper"
+	"github.com/codenotary/immudb/cmd/version"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/client/cache"
+	"github.com/codenotary/immudb/pkg/logger"
+	"github.com/codenotary/immudb/pkg/signer"
+	"github.com/codenotary/immudb/pkg/verifier"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+)
+
+// Execute runs the immuverifier command
+func Execute() {
+	version.App = "immuverifier"
+
+	cmd, err := NewCmd()
+	if err != nil {
+		c.QuitWithUserError(err)
+	}
+
+	if err := cmd.Execute(); err != nil {
+		c.QuitWithUserError(err)
+	}
+}
+
+// NewCmd creates a new immuverifier command
+func NewCmd() (*cobra.Command, error) {
+	config := c.Config{Name: "immuverifier"}
+
+	cmd := &cobra.Command{
+		Use:   "immuverifier",
+		Short: "immuverifier - lightweight verification node for immudb",
+		Long: `immuverifier keeps the latest verified state of the databases of an immudb server, without keeping any value.
+Every new state of the server is accepted only once the server proves it extends the verified one.
+Clients can then check their own states against the ones verified by this node:
+
+  GET  /state?db=<db>  returns the latest verified state of the database
+  POST /verify         verifies the state sent as {"db": "<db>", "txId": <tx>, "txHash": "<hex>"}
+
+Environment variables:
+  IMMUVERIFIER_IMMUDB_ADDRESS=127.0.0.1
+  IMMUVERIFIER_IMMUDB_PORT=3322
+  IMMUVERIFIER_USERNAME=
+  IMMUVERIFIER_PASSWORD=
+  IMMUVERIFIER_DATABASES=
+  IMMUVERIFIER_DIR=./verifier
+  IMMUVERIFIER_SYNC_INTERVAL=1m
+  IMMUVERIFIER_SERVER_SIGNING_PUB_KEY=
+  IMMUVERIFIER_ADDRESS=0.0.0.0
+  IMMUVERIFIER_PORT=3325
+`,
+		DisableAutoGenTag: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return config.LoadConfig(cmd)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts, err := parseOptions()
+			if err != nil {
+				return err
+			}
+
+			return run(opts)
+		},
+	}
+
+	setupFlags(cmd, verifier.DefaultOptions())
+
+	if err := viper.BindPFlags(cmd.Flags()); err != nil {
+		return nil, err
+	}
+
+	cmd.AddCommand(version.VersionCmd())
+
+	return cmd, nil
+}
+
+func setupFlags(cmd *cobra.Command, options *verifier.Options) {
+	cmd.Flags().String("config", "", "config file (default path is configs or $HOME. Default filename is immuverifier.toml)")
+	cmd.Flags().String("immudb-address", options.ServerAddress, "address of the verified immudb server")
+	cmd.Flags().Int("immudb-port", options.ServerPort, "port of the verified immudb server")
+	cmd.Flags().String("username", options.Username, "user the verifier logs in with, it must be allowed to read the verified databases")
+	cmd.Flags().String("password", options.Password, "password of the user the verifier logs in with")
+	cmd.Flags().StringSlice("databases", options.Databases, "comma-separated list of verified databases (all the databases the user has access to if empty)")
+	cmd.Flags().String("dir", options.Dir, "folder holding the verified states")
+	cmd.Flags().Duration("sync-interval", options.SyncInterval, "time between two consecutive verifications of the databases")
+	cmd.Flags().String("server-signing-pub-key", "", "path to the public key used to check the signature of the states of the server")
+	cmd.Flags().String("address", options.Address, "address verification requests are served on")
+	cmd.Flags().Int("port", options.Port, "port verification requests are served on")
+}
+
+func parseOptions() (*verifier.Options, error) {
+	opts := verifier.DefaultOptions().
+		WithServerAddress(viper.GetString("immudb-address")).
+		WithServerPort(viper.GetInt("immudb-port")).
+		WithUsername(viper.GetString("username")).
+		WithPassword(viper.GetString("password")).
+		WithDatabases(viper.GetStringSlice("databases")...).
+		WithDir(viper.GetString("dir")).
+		WithSyncInterval(viper.GetDuration("sync-interval")).
+		WithAddress(viper.GetString("address")).
+		WithPort(viper.GetInt("port"))
+
+	if pubKeyPath := viper.GetString("server-signing-pub-key"); pubKeyPath != "" {
+		pubKey, err := signer.ParsePublicKeyFile(pubKeyPath)
+		if err != nil {
+			return nil, err
+		}
+
+		opts.WithServerSigningPubKey(pubKey)
+	}
+
+	return opts, nil
+}
+
+func run(opts *verifier.Options) error {
+	log := logger.NewSimpleLogger("immuverifier ", os.Stderr)
+
+	err := os.MkdirAll(opts.Dir, 0700)
+	if err != nil {
+		return err
+	}
+
+	conn, err := grpc.Dial(opts.ServerBind(), grpc.WithInsecure())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	v, err := verifier.NewVerifier(schema.NewImmuServiceClient(conn), cache.NewFileCache(opts.Dir), opts, log)
+	if err != nil {
+		return err
+	}
+
+	err = v.Open(context.Background())
+	if err != nil {
+		return err
+	}
+	defer v.Close()
+
+	stopc := make(chan struct{})
+	defer close(stopc)
+
+	go v.Run(stopc)
+
+	log.Infof("Verifying %s, serving verification requests at %s", opts.ServerBind(), opts.Bind())
+
+	return http.ListenAndServe(opts.Bind(), v.Handler())
+}
@@ -25,6 +25,7 @@ import (
 	"sync"
 
 	"github.com/codenotary/immudb/embedded/appendable"
+	"github.com/codenotary/immudb/embedded/appendable/memapp"
 	"github.com/codenotary/immudb/embedded/appendable/multiapp"
 	"github.com/codenotary/immudb/embedded/cache"
 	"github.com/codenotary/immudb/embedded/multierr"
@@ -37,7 +38,7 @@ var ErrorCorruptedData = errors.New("data log is corrupted")
 var ErrorCorruptedDigests = errors.New("hash log is corrupted")
 var ErrAlreadyClosed = errors.New("already closed")
 var ErrEmptyTree = errors.New("empty tree")
-var ErrReadOnly = errors.New("cannot append when openned in read-only mode")
+var ErrReadOnly = errors.New("cannot sync when openned in read-only mode")
 var ErrUnexistentData = errors.New("attempt to read unexistent data")
 
 const LeafPrefix = byte(0)
@@ -81,7 +82,8 @@ func Open(path string, opts *Options) (*AHtree, error) {
 
 	finfo, err := os.Stat(path)
 	if err != nil {
-		if os.IsNotExist(err) {
+		// nothing is created in read-only mode
+		if os.IsNotExist(err) && !opts.readOnly {
 			err = os.Mkdir(path, opts.fileMode)
 			if err != nil {
 				return nil, err
@@ -141,6 +143,24 @@ func OpenWith(pLog, dLog, cLog appendable.Appendable, opts *Options) (*AHtree, e
 		return nil, ErrCorruptedCLog
 	}
 
+	if opts.readOnly {
+		// nothing is written in read-only mode, appended data is only kept in memory
+		pLog, err = memapp.Open(pLog)
+		if err != nil {
+			return nil, err
+		}
+
+		dLog, err = memapp.Open(dLog)
+		if err != nil {
+			return nil, err
+		}
+
+		cLog, err = memapp.Open(cLog)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	dLogSize, err := dLog.Size()
 	if err != nil {
 		return nil, err
@@ -206,11 +226,6 @@ func (t *AHtree) Append(d []byte) (n uint64, h [sha256.Size]byte, err error) {
 		return
 	}
 
-	if t.readOnly {
-		err = ErrReadOnly
-		return
-	}
-
 	if d == nil {
 		err = ErrIllegalArguments
 		return
@@ -238,9 +238,14 @@ func TestReadOnly(t *testing.T) {
 	_, err := Open("ahtree_test", DefaultOptions().WithReadOnly(true))
 	defer os.RemoveAll("ahtree_test")
 	require.Error(t, err)
+	require.NoDirExists(t, "ahtree_test")
 
 	tree, err := Open("ahtree_test", DefaultOptions().WithReadOnly(false))
 	require.NoError(t, err)
+
+	_, _, err = tree.Append([]byte{1})
+	require.NoError(t, err)
+
 	err = tree.Close()
 	require.NoError(t, err)
 
@@ -248,13 +253,32 @@ func TestReadOnly(t *testing.T) {
 	require.NoError(t, err)
 
 	_, _, err = tree.Append(nil)
-	require.Equal(t, ErrReadOnly, err)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	// appended data is only kept in memory
+	n, root, err := tree.Append([]byte{2})
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), n)
+
+	_, r, err := tree.Root()
+	require.NoError(t, err)
+	require.Equal(t, root, r)
+
+	_, err = tree.InclusionProof(1, 2)
+	require.NoError(t, err)
 
 	err = tree.Sync()
 	require.Equal(t, ErrReadOnly, err)
 
 	err = tree.Close()
 	require.NoError(t, err)
+
+	tree, err = Open("ahtree_test", DefaultOptions().WithReadOnly(true))
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), tree.Size())
+
+	err = tree.Close()
+	require.NoError(t, err)
 }
 
 func TestAppend(t *testing.T) {
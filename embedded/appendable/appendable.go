@@ -46,4 +46,5 @@ type Appendable interface {
 	ReadAt(bs []byte, off int64) (int, error)
 	Close() error
 	Copy(dstPath string) error
+	DiscardUpTo(off int64) error
 }
@@ -0,0 +1,215 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package memapp
+
+import (
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/codenotary/immudb/embedded/appendable"
+)
+
+var ErrIllegalArguments = errors.New("illegal arguments")
+var ErrAlreadyClosed = errors.New("already closed")
+var ErrNotSupported = errors.New("not supported by in-memory appendables")
+
+// MemAppendable exposes the content of an underlying appendable, which is never written,
+// followed by the data appended to it which is only kept in memory.
+// It makes it possible to append to an appendable opened in read-only mode.
+type MemAppendable struct {
+	base appendable.Appendable
+
+	// only the data of the underlying appendable up to baseSize is exposed,
+	// what comes after it may have been overwritten in memory
+	baseSize int64
+	buf      []byte
+
+	offset int64
+
+	closed bool
+	mutex  sync.Mutex
+}
+
+func Open(base appendable.Appendable) (*MemAppendable, error) {
+	if base == nil {
+		return nil, ErrIllegalArguments
+	}
+
+	baseSize, err := base.Size()
+	if err != nil {
+		return nil, err
+	}
+
+	return &MemAppendable{
+		base:     base,
+		baseSize: baseSize,
+		offset:   baseSize,
+	}, nil
+}
+
+func (a *MemAppendable) Metadata() []byte {
+	return a.base.Metadata()
+}
+
+func (a *MemAppendable) Size() (int64, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.closed {
+		return 0, ErrAlreadyClosed
+	}
+
+	return a.size(), nil
+}
+
+func (a *MemAppendable) size() int64 {
+	return a.baseSize + int64(len(a.buf))
+}
+
+func (a *MemAppendable) Offset() int64 {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	return a.offset
+}
+
+func (a *MemAppendable) SetOffset(off int64) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.closed {
+		return ErrAlreadyClosed
+	}
+
+	if off < 0 || off > a.size() {
+		return ErrIllegalArguments
+	}
+
+	a.offset = off
+
+	return nil
+}
+
+func (a *MemAppendable) Append(bs []byte) (off int64, n int, err error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.closed {
+		return 0, 0, ErrAlreadyClosed
+	}
+
+	if len(bs) == 0 {
+		return 0, 0, ErrIllegalArguments
+	}
+
+	// data after the current offset is overwritten
+	if a.offset < a.baseSize {
+		a.baseSize = a.offset
+		a.buf = nil
+	} else {
+		a.buf = a.buf[:a.offset-a.baseSize]
+	}
+
+	off = a.offset
+
+	a.buf = append(a.buf, bs...)
+	a.offset += int64(len(bs))
+
+	return off, len(bs), nil
+}
+
+func (a *MemAppendable) ReadAt(bs []byte, off int64) (int, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.closed {
+		return 0, ErrAlreadyClosed
+	}
+
+	if len(bs) == 0 || off < 0 {
+		return 0, ErrIllegalArguments
+	}
+
+	r := 0
+
+	if off < a.baseSize {
+		available := a.baseSize - off
+		if available > int64(len(bs)) {
+			available = int64(len(bs))
+		}
+
+		rn, err := a.base.ReadAt(bs[:available], off)
+		r += rn
+
+		if err != nil {
+			return r, err
+		}
+	}
+
+	if r < len(bs) {
+		bufOff := off + int64(r) - a.baseSize
+
+		if bufOff < int64(len(a.buf)) {
+			r += copy(bs[r:], a.buf[bufOff:])
+		}
+	}
+
+	if r < len(bs) {
+		return r, io.EOF
+	}
+
+	return r, nil
+}
+
+// Flush and Sync have no effect as appended data is only kept in memory
+func (a *MemAppendable) Flush() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.closed {
+		return ErrAlreadyClosed
+	}
+
+	return nil
+}
+
+func (a *MemAppendable) Sync() error {
+	return a.Flush()
+}
+
+func (a *MemAppendable) Copy(dstPath string) error {
+	return ErrNotSupported
+}
+
+func (a *MemAppendable) DiscardUpTo(off int64) error {
+	return ErrNotSupported
+}
+
+// Close discards the data kept in memory and closes the underlying appendable
+func (a *MemAppendable) Close() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.closed {
+		return ErrAlreadyClosed
+	}
+
+	a.closed = true
+	a.buf = nil
+
+	return a.base.Close()
+}
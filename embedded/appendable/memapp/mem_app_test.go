@@ -0,0 +1,167 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package memapp
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"github.com/codenotary/immudb/embedded/appendable"
+	"github.com/codenotary/immudb/embedded/appendable/multiapp"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemApp(t *testing.T) {
+	_, err := Open(nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	md := appendable.NewMetadata(nil)
+	md.PutInt("mkey1", 1)
+
+	base, err := multiapp.Open("testdata", multiapp.DefaultOptions().WithMetadata(md.Bytes()))
+	defer os.RemoveAll("testdata")
+	require.NoError(t, err)
+
+	_, _, err = base.Append([]byte{1, 2, 3, 4})
+	require.NoError(t, err)
+
+	err = base.Close()
+	require.NoError(t, err)
+
+	base, err = multiapp.Open("testdata", multiapp.DefaultOptions().WithReadOnly(true))
+	require.NoError(t, err)
+
+	a, err := Open(base)
+	require.NoError(t, err)
+
+	mkey1, found := appendable.NewMetadata(a.Metadata()).GetInt("mkey1")
+	require.True(t, found)
+	require.Equal(t, 1, mkey1)
+
+	sz, err := a.Size()
+	require.NoError(t, err)
+	require.Equal(t, int64(4), sz)
+	require.Equal(t, int64(4), a.Offset())
+
+	_, _, err = a.Append(nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	off, n, err := a.Append([]byte{5, 6})
+	require.NoError(t, err)
+	require.Equal(t, int64(4), off)
+	require.Equal(t, 2, n)
+
+	err = a.Flush()
+	require.NoError(t, err)
+
+	err = a.Sync()
+	require.NoError(t, err)
+
+	bs := make([]byte, 6)
+	n, err = a.ReadAt(bs, 0)
+	require.NoError(t, err)
+	require.Equal(t, 6, n)
+	require.Equal(t, []byte{1, 2, 3, 4, 5, 6}, bs)
+
+	n, err = a.ReadAt(bs[:3], 3)
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+	require.Equal(t, []byte{4, 5, 6}, bs[:3])
+
+	n, err = a.ReadAt(bs, 3)
+	require.Equal(t, io.EOF, err)
+	require.Equal(t, 3, n)
+
+	_, err = a.ReadAt(nil, 0)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	err = a.SetOffset(7)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	// data in memory is overwritten
+	err = a.SetOffset(5)
+	require.NoError(t, err)
+
+	_, _, err = a.Append([]byte{7})
+	require.NoError(t, err)
+
+	n, err = a.ReadAt(bs, 0)
+	require.NoError(t, err)
+	require.Equal(t, 6, n)
+	require.Equal(t, []byte{1, 2, 3, 4, 5, 7}, bs)
+
+	// data of the underlying appendable is overwritten as well, only in memory
+	err = a.SetOffset(2)
+	require.NoError(t, err)
+
+	off, _, err = a.Append([]byte{8, 9, 10})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), off)
+
+	sz, err = a.Size()
+	require.NoError(t, err)
+	require.Equal(t, int64(5), sz)
+
+	n, err = a.ReadAt(bs[:5], 0)
+	require.NoError(t, err)
+	require.Equal(t, 5, n)
+	require.Equal(t, []byte{1, 2, 8, 9, 10}, bs[:5])
+
+	err = a.Copy("testdata_copy")
+	require.Equal(t, ErrNotSupported, err)
+
+	err = a.DiscardUpTo(1)
+	require.Equal(t, ErrNotSupported, err)
+
+	err = a.Close()
+	require.NoError(t, err)
+
+	err = a.Close()
+	require.Equal(t, ErrAlreadyClosed, err)
+
+	_, err = a.Size()
+	require.Equal(t, ErrAlreadyClosed, err)
+
+	_, _, err = a.Append([]byte{1})
+	require.Equal(t, ErrAlreadyClosed, err)
+
+	_, err = a.ReadAt(bs, 0)
+	require.Equal(t, ErrAlreadyClosed, err)
+
+	err = a.SetOffset(0)
+	require.Equal(t, ErrAlreadyClosed, err)
+
+	err = a.Flush()
+	require.Equal(t, ErrAlreadyClosed, err)
+
+	// nothing was written
+	base, err = multiapp.Open("testdata", multiapp.DefaultOptions().WithReadOnly(true))
+	require.NoError(t, err)
+
+	sz, err = base.Size()
+	require.NoError(t, err)
+	require.Equal(t, int64(4), sz)
+
+	n, err = base.ReadAt(bs[:4], 0)
+	require.NoError(t, err)
+	require.Equal(t, 4, n)
+	require.Equal(t, []byte{1, 2, 3, 4}, bs[:4])
+
+	err = base.Close()
+	require.NoError(t, err)
+}
@@ -26,6 +26,7 @@ type MockedAppendable struct {
 	ReadAtFn    func(bs []byte, off int64) (int, error)
 	CopyFn      func(dstPath string) error
 	CloseFn     func() error
+	DiscardFn   func(off int64) error
 }
 
 func (a *MockedAppendable) Metadata() []byte {
@@ -36,6 +37,10 @@ func (a *MockedAppendable) Copy(dstPath string) error {
 	return a.CopyFn(dstPath)
 }
 
+func (a *MockedAppendable) DiscardUpTo(off int64) error {
+	return a.DiscardFn(off)
+}
+
 func (a *MockedAppendable) Size() (int64, error) {
 	return a.SizeFn()
 }
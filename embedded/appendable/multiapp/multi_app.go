@@ -23,6 +23,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -30,12 +31,14 @@ import (
 	"github.com/codenotary/immudb/embedded/appendable"
 	"github.com/codenotary/immudb/embedded/appendable/singleapp"
 	"github.com/codenotary/immudb/embedded/cache"
+	"github.com/codenotary/immudb/embedded/fileutil"
 )
 
 var ErrorPathIsNotADirectory = errors.New("path is not a directory")
 var ErrIllegalArguments = errors.New("illegal arguments")
 var ErrAlreadyClosed = errors.New("multi-appendable already closed")
 var ErrReadOnly = errors.New("cannot append when openned in read-only mode")
+var ErrDiscarded = errors.New("data has been discarded")
 
 const (
 	metaFileSize    = "FILE_SIZE"
@@ -48,6 +51,9 @@ type MultiFileAppendable struct {
 	currAppID int64
 	currApp   *singleapp.AppendableFile
 
+	// appendables with lower ids were discarded
+	firstAppID int64
+
 	path     string
 	readOnly bool
 	synced   bool
@@ -65,6 +71,12 @@ func Open(path string, opts *Options) (*MultiFileAppendable, error) {
 		return nil, ErrIllegalArguments
 	}
 
+	// paths exceeding the length limit of the platform, if any, are supported
+	path, err := fileutil.LongPath(path)
+	if err != nil {
+		return nil, err
+	}
+
 	finfo, err := os.Stat(path)
 	if err != nil {
 		if !os.IsNotExist(err) || opts.readOnly {
@@ -99,8 +111,14 @@ func Open(path string, opts *Options) (*MultiFileAppendable, error) {
 		WithMetadata(m.Bytes())
 
 	var filename string
+	var firstAppID int64
 
 	if len(fis) > 0 {
+		firstAppID, err = strconv.ParseInt(strings.TrimSuffix(fis[0].Name(), filepath.Ext(fis[0].Name())), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
 		filename = fis[len(fis)-1].Name()
 
 		currAppID, err = strconv.ParseInt(strings.TrimSuffix(filename, filepath.Ext(filename)), 10, 64)
@@ -127,6 +145,7 @@ func Open(path string, opts *Options) (*MultiFileAppendable, error) {
 		appendables: cache,
 		currAppID:   currAppID,
 		currApp:     currApp,
+		firstAppID:  firstAppID,
 		path:        path,
 		readOnly:    opts.readOnly,
 		synced:      opts.synced,
@@ -184,13 +203,13 @@ func (mf *MultiFileAppendable) Copy(dstPath string) error {
 }
 
 func copyFile(srcPath, dstPath string) (int64, error) {
-	dstFile, err := os.Create(dstPath)
+	dstFile, err := fileutil.Create(dstPath)
 	if err != nil {
 		return 0, err
 	}
 	defer dstFile.Close()
 
-	srcFile, err := os.Open(srcPath)
+	srcFile, err := fileutil.Open(srcPath)
 	if err != nil {
 		return 0, err
 	}
@@ -256,18 +275,11 @@ func (mf *MultiFileAppendable) Append(bs []byte) (off int64, n int, err error) {
 		available := mf.fileSize - int(mf.currApp.Offset())
 
 		if available <= 0 {
-			_, ejectedApp, err := mf.appendables.Put(mf.currAppID, mf.currApp)
+			err = mf.cacheAppendable(mf.currAppID, mf.currApp)
 			if err != nil {
 				return off, n, err
 			}
 
-			if ejectedApp != nil {
-				err = ejectedApp.(*singleapp.AppendableFile).Close()
-				if err != nil {
-					return off, n, err
-				}
-			}
-
 			mf.currAppID++
 			currApp, err := mf.openAppendable(appendableName(mf.currAppID, mf.fileExt))
 			if err != nil {
@@ -332,24 +344,23 @@ func (mf *MultiFileAppendable) SetOffset(off int64) error {
 
 	appID := appendableID(off, mf.fileSize)
 
+	if appID < mf.firstAppID {
+		return ErrDiscarded
+	}
+
 	if mf.currAppID != appID {
 		app, err := mf.openAppendable(appendableName(appID, mf.fileExt))
 		if err != nil {
 			return err
 		}
 
-		_, ejectedApp, err := mf.appendables.Put(appID, app)
+		// the appendable being replaced is kept as any other one, the new one must not be cached
+		// otherwise it would be closed twice
+		err = mf.cacheAppendable(mf.currAppID, mf.currApp)
 		if err != nil {
 			return err
 		}
 
-		if ejectedApp != nil {
-			err = ejectedApp.(*singleapp.AppendableFile).Close()
-			if err != nil {
-				return err
-			}
-		}
-
 		mf.currAppID = appID
 		mf.currApp = app
 	}
@@ -367,6 +378,10 @@ func (mf *MultiFileAppendable) appendableFor(off int64) (*singleapp.AppendableFi
 
 	appID := appendableID(off, mf.fileSize)
 
+	if appID < mf.firstAppID {
+		return nil, ErrDiscarded
+	}
+
 	app, err := mf.appendables.Get(appID)
 
 	if err != nil {
@@ -379,20 +394,161 @@ func (mf *MultiFileAppendable) appendableFor(off int64) (*singleapp.AppendableFi
 			return nil, err
 		}
 
-		_, ejectedApp, err := mf.appendables.Put(appID, app)
+		err = mf.cacheAppendable(appID, app.(*singleapp.AppendableFile))
 		if err != nil {
 			return nil, err
 		}
+	}
 
-		if ejectedApp != nil {
-			err = ejectedApp.(*singleapp.AppendableFile).Close()
-			if err != nil {
-				return nil, err
-			}
+	return app.(*singleapp.AppendableFile), nil
+}
+
+// cacheAppendable keeps the appendable open for further reads. The appendable it replaces,
+// e.g. one opened for reading the file which was being appended, and the one ejected
+// from the cache are closed, otherwise their files would be left open
+func (mf *MultiFileAppendable) cacheAppendable(appID int64, app *singleapp.AppendableFile) error {
+	replacedApp, err := mf.appendables.Pop(appID)
+	if err != nil && err != cache.ErrKeyNotFound {
+		return err
+	}
+
+	if err == nil && replacedApp != app {
+		err = replacedApp.(*singleapp.AppendableFile).Close()
+		if err != nil {
+			return err
 		}
 	}
 
-	return app.(*singleapp.AppendableFile), nil
+	_, ejectedApp, err := mf.appendables.Put(appID, app)
+	if err != nil {
+		return err
+	}
+
+	if ejectedApp != nil {
+		return ejectedApp.(*singleapp.AppendableFile).Close()
+	}
+
+	return nil
+}
+
+// Path returns the directory holding the files of the appendable
+func (mf *MultiFileAppendable) Path() string {
+	return mf.path
+}
+
+// MaxOpenedFiles returns the max number of files kept open for reading besides the one being appended
+func (mf *MultiFileAppendable) MaxOpenedFiles() int {
+	return mf.appendables.Size()
+}
+
+// OpenedFiles returns the names of the files currently open, sorted by name
+func (mf *MultiFileAppendable) OpenedFiles() ([]string, error) {
+	mf.mutex.Lock()
+	defer mf.mutex.Unlock()
+
+	if mf.closed {
+		return nil, ErrAlreadyClosed
+	}
+
+	appIDs := map[int64]struct{}{mf.currAppID: {}}
+
+	err := mf.appendables.Apply(func(k interface{}, v interface{}) error {
+		appIDs[k.(int64)] = struct{}{}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]string, 0, len(appIDs))
+
+	for appID := range appIDs {
+		files = append(files, appendableName(appID, mf.fileExt))
+	}
+
+	sort.Strings(files)
+
+	return files, nil
+}
+
+// CloseCachedFiles closes all the files kept open for reading, the file being appended is kept open.
+// Files are reopened when read again, reads already in progress on them fail
+func (mf *MultiFileAppendable) CloseCachedFiles() (int, error) {
+	mf.mutex.Lock()
+	defer mf.mutex.Unlock()
+
+	if mf.closed {
+		return 0, ErrAlreadyClosed
+	}
+
+	var appIDs []int64
+
+	err := mf.appendables.Apply(func(k interface{}, v interface{}) error {
+		appIDs = append(appIDs, k.(int64))
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	closed := 0
+
+	for _, appID := range appIDs {
+		app, err := mf.appendables.Pop(appID)
+		if err != nil {
+			return closed, err
+		}
+
+		err = app.(*singleapp.AppendableFile).Close()
+		if err != nil {
+			return closed, err
+		}
+
+		closed++
+	}
+
+	return closed, nil
+}
+
+// DiscardUpTo removes the files holding data strictly before the given offset,
+// only whole files are removed and the file currently being appended is never removed
+func (mf *MultiFileAppendable) DiscardUpTo(off int64) error {
+	mf.mutex.Lock()
+	defer mf.mutex.Unlock()
+
+	if mf.closed {
+		return ErrAlreadyClosed
+	}
+
+	if mf.readOnly {
+		return ErrReadOnly
+	}
+
+	if off < 0 {
+		return ErrIllegalArguments
+	}
+
+	appID := appendableID(off, mf.fileSize)
+	if appID > mf.currAppID {
+		appID = mf.currAppID
+	}
+
+	for ; mf.firstAppID < appID; mf.firstAppID++ {
+		app, err := mf.appendables.Pop(mf.firstAppID)
+		if err == nil {
+			err = app.(*singleapp.AppendableFile).Close()
+		}
+		if err != nil && err != cache.ErrKeyNotFound {
+			return err
+		}
+
+		err = fileutil.Remove(filepath.Join(mf.path, appendableName(mf.firstAppID, mf.fileExt)))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func (mf *MultiFileAppendable) ReadAt(bs []byte, off int64) (int, error) {
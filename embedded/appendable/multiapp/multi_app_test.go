@@ -16,7 +16,6 @@ limitations under the License.
 package multiapp
 
 import (
-	"io"
 	"os"
 	"path/filepath"
 	"testing"
@@ -141,9 +140,10 @@ func TestMultiAppClosedAndDeletedFiles(t *testing.T) {
 	a, err = Open("testdata", DefaultOptions().WithFileSize(1).WithMaxOpenedFiles(1))
 	require.NoError(t, err)
 
+	// leading files which are missing are considered as discarded
 	b := make([]byte, n)
 	_, err = a.ReadAt(b, 0)
-	require.Equal(t, io.EOF, err)
+	require.Equal(t, ErrDiscarded, err)
 }
 
 func TestMultiAppClosedFiles(t *testing.T) {
@@ -163,6 +163,84 @@ func TestMultiAppClosedFiles(t *testing.T) {
 	require.Equal(t, singleapp.ErrAlreadyClosed, err)
 }
 
+func TestMultiAppReplacedFilesAreClosed(t *testing.T) {
+	a, err := Open("testdata", DefaultOptions().WithFileSize(2).WithMaxOpenedFiles(2))
+	defer os.RemoveAll("testdata")
+	require.NoError(t, err)
+
+	_, _, err = a.Append([]byte{0})
+	require.NoError(t, err)
+
+	err = a.Flush()
+	require.NoError(t, err)
+
+	// reading the file being appended opens it once more
+	app, err := a.appendableFor(0)
+	require.NoError(t, err)
+
+	_, _, err = a.Append([]byte{1, 2})
+	require.NoError(t, err)
+
+	_, err = app.ReadAt(make([]byte, 1), 0)
+	require.Equal(t, singleapp.ErrAlreadyClosed, err)
+
+	files, err := a.OpenedFiles()
+	require.NoError(t, err)
+	require.Equal(t, []string{"00000000.aof", "00000001.aof"}, files)
+
+	err = a.Close()
+	require.NoError(t, err)
+}
+
+func TestMultiAppOpenedFiles(t *testing.T) {
+	a, err := Open("testdata", DefaultOptions().WithFileSize(1).WithMaxOpenedFiles(2))
+	defer os.RemoveAll("testdata")
+	require.NoError(t, err)
+
+	require.Equal(t, "testdata", a.Path())
+	require.Equal(t, 2, a.MaxOpenedFiles())
+
+	_, _, err = a.Append([]byte{0, 1, 2, 3, 4})
+	require.NoError(t, err)
+
+	files, err := a.OpenedFiles()
+	require.NoError(t, err)
+	require.Equal(t, []string{"00000002.aof", "00000003.aof", "00000004.aof"}, files)
+
+	err = a.Flush()
+	require.NoError(t, err)
+
+	_, err = a.ReadAt(make([]byte, 1), 0)
+	require.NoError(t, err)
+
+	files, err = a.OpenedFiles()
+	require.NoError(t, err)
+	require.Equal(t, []string{"00000000.aof", "00000003.aof", "00000004.aof"}, files)
+
+	closed, err := a.CloseCachedFiles()
+	require.NoError(t, err)
+	require.Equal(t, 2, closed)
+
+	files, err = a.OpenedFiles()
+	require.NoError(t, err)
+	require.Equal(t, []string{"00000004.aof"}, files)
+
+	// closed files are reopened when read
+	b := make([]byte, 5)
+	_, err = a.ReadAt(b, 0)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0, 1, 2, 3, 4}, b)
+
+	err = a.Close()
+	require.NoError(t, err)
+
+	_, err = a.OpenedFiles()
+	require.Equal(t, ErrAlreadyClosed, err)
+
+	_, err = a.CloseCachedFiles()
+	require.Equal(t, ErrAlreadyClosed, err)
+}
+
 func TestMultiAppReOpening(t *testing.T) {
 	a, err := Open("testdata", DefaultOptions().WithFileSize(1))
 	defer os.RemoveAll("testdata")
@@ -286,3 +364,77 @@ func TestMultiAppCompression(t *testing.T) {
 	err = a.Close()
 	require.NoError(t, err)
 }
+
+func TestMultiAppDiscardUpTo(t *testing.T) {
+	path := "testdata_discard"
+	defer os.RemoveAll(path)
+
+	opts := DefaultOptions().WithFileSize(4)
+
+	a, err := Open(path, opts)
+	require.NoError(t, err)
+
+	err = a.DiscardUpTo(-1)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	for i := 0; i < 10; i++ {
+		_, _, err = a.Append([]byte{byte(i), byte(i), byte(i)})
+		require.NoError(t, err)
+	}
+
+	err = a.Flush()
+	require.NoError(t, err)
+
+	bs := make([]byte, 3)
+	_, err = a.ReadAt(bs, 0)
+	require.NoError(t, err)
+
+	// data at offset 9 lives in the third file, thus only the first two can be removed
+	err = a.DiscardUpTo(9)
+	require.NoError(t, err)
+
+	_, err = a.ReadAt(bs, 0)
+	require.Equal(t, ErrDiscarded, err)
+
+	_, err = a.ReadAt(bs, 7)
+	require.Equal(t, ErrDiscarded, err)
+
+	_, err = a.ReadAt(bs, 9)
+	require.NoError(t, err)
+	require.Equal(t, []byte{3, 3, 3}, bs)
+
+	_, err = os.Stat(filepath.Join(path, appendableName(0, opts.fileExt)))
+	require.True(t, os.IsNotExist(err))
+
+	_, err = os.Stat(filepath.Join(path, appendableName(2, opts.fileExt)))
+	require.NoError(t, err)
+
+	// the file being appended is never removed
+	err = a.DiscardUpTo(1000)
+	require.NoError(t, err)
+
+	off, _, err := a.Append([]byte{10, 10, 10})
+	require.NoError(t, err)
+
+	err = a.Close()
+	require.NoError(t, err)
+
+	err = a.DiscardUpTo(0)
+	require.Equal(t, ErrAlreadyClosed, err)
+
+	a, err = Open(path, opts)
+	require.NoError(t, err)
+
+	_, err = a.ReadAt(bs, 9)
+	require.Equal(t, ErrDiscarded, err)
+
+	err = a.SetOffset(0)
+	require.Equal(t, ErrDiscarded, err)
+
+	_, err = a.ReadAt(bs, off)
+	require.NoError(t, err)
+	require.Equal(t, []byte{10, 10, 10}, bs)
+
+	err = a.Close()
+	require.NoError(t, err)
+}
@@ -64,6 +64,14 @@ func TestReader(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, uint32(256), n32)
 
+	a.ReadAtFn = func(bs []byte, off int64) (int, error) {
+		binary.BigEndian.PutUint16(bs, 128)
+		return 2, nil
+	}
+	n16, err := r.ReadUint16()
+	require.NoError(t, err)
+	require.Equal(t, uint16(128), n16)
+
 	a.ReadAtFn = func(bs []byte, off int64) (int, error) {
 		binary.BigEndian.PutUint64(bs, 1024)
 		return 8, nil
@@ -29,6 +29,7 @@ import (
 	"sync"
 
 	"github.com/codenotary/immudb/embedded/appendable"
+	"github.com/codenotary/immudb/embedded/fileutil"
 )
 
 var ErrorPathIsNotADirectory = errors.New("path is not a directory")
@@ -84,7 +85,7 @@ func Open(fileName string, opts *Options) (*AppendableFile, error) {
 		return nil, err
 	}
 
-	f, err := os.OpenFile(fileName, flag, opts.fileMode)
+	f, err := fileutil.OpenFile(fileName, flag, opts.fileMode)
 	if err != nil {
 		return nil, err
 	}
@@ -195,7 +196,7 @@ func (aof *AppendableFile) Copy(dstPath string) error {
 		return ErrAlreadyClosed
 	}
 
-	dstFile, err := os.Create(dstPath)
+	dstFile, err := fileutil.Create(dstPath)
 	if err != nil {
 		return err
 	}
@@ -219,6 +220,23 @@ func (aof *AppendableFile) Copy(dstPath string) error {
 	return dstFile.Sync()
 }
 
+// DiscardUpTo is a no-op as a single file can not be partially discarded,
+// previously appended data remains readable
+func (aof *AppendableFile) DiscardUpTo(off int64) error {
+	aof.mutex.Lock()
+	defer aof.mutex.Unlock()
+
+	if aof.closed {
+		return ErrAlreadyClosed
+	}
+
+	if off < 0 {
+		return ErrIllegalArguments
+	}
+
+	return nil
+}
+
 func (aof *AppendableFile) CompressionFormat() int {
 	return aof.compressionFormat
 }
@@ -261,6 +279,14 @@ func (aof *AppendableFile) SetOffset(off int64) error {
 		return ErrAlreadyClosed
 	}
 
+	// buffered data must be written at the current offset, otherwise it would be written at the new one
+	if !aof.readOnly {
+		err := aof.w.Flush()
+		if err != nil {
+			return err
+		}
+	}
+
 	_, err := aof.f.Seek(off+aof.baseOffset, io.SeekStart)
 	if err != nil {
 		return err
@@ -94,6 +94,40 @@ func TestSingleApp(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestSingleAppSetOffsetWithBufferedData(t *testing.T) {
+	a, err := Open("testdata_setoffset.aof", DefaultOptions())
+	defer os.Remove("testdata_setoffset.aof")
+	require.NoError(t, err)
+
+	_, _, err = a.Append([]byte{1, 2, 3})
+	require.NoError(t, err)
+
+	err = a.Flush()
+	require.NoError(t, err)
+
+	_, _, err = a.Append([]byte{4, 5, 6})
+	require.NoError(t, err)
+
+	// data appended after the new offset is overwritten, even if it was not flushed yet
+	err = a.SetOffset(3)
+	require.NoError(t, err)
+
+	off, _, err := a.Append([]byte{7, 8})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), off)
+
+	err = a.Flush()
+	require.NoError(t, err)
+
+	bs := make([]byte, 5)
+	_, err = a.ReadAt(bs, 0)
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 2, 3, 7, 8}, bs)
+
+	err = a.Close()
+	require.NoError(t, err)
+}
+
 func TestSingleAppReOpening(t *testing.T) {
 	a, err := Open("testdata.aof", DefaultOptions())
 	defer os.Remove("testdata.aof")
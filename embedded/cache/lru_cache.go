@@ -101,6 +101,25 @@ func (c *LRUCache) Get(key interface{}) (interface{}, error) {
 	return e.value, nil
 }
 
+func (c *LRUCache) Pop(key interface{}) (interface{}, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if key == nil {
+		return nil, ErrIllegalArguments
+	}
+
+	e, ok := c.data[key]
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+
+	delete(c.data, key)
+	c.lruList.Remove(e.order)
+
+	return e.value, nil
+}
+
 func (c *LRUCache) Size() int {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
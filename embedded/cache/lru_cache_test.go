@@ -100,3 +100,36 @@ func TestApply(t *testing.T) {
 	})
 	require.Error(t, err)
 }
+
+func TestPop(t *testing.T) {
+	cache, err := NewLRUCache(2)
+	require.NoError(t, err)
+
+	_, err = cache.Pop(nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = cache.Pop(1)
+	require.Equal(t, ErrKeyNotFound, err)
+
+	_, _, err = cache.Put(1, 10)
+	require.NoError(t, err)
+
+	_, _, err = cache.Put(2, 20)
+	require.NoError(t, err)
+
+	v, err := cache.Pop(1)
+	require.NoError(t, err)
+	require.Equal(t, 10, v)
+
+	_, err = cache.Get(1)
+	require.Equal(t, ErrKeyNotFound, err)
+
+	// room was made for a new entry, so nothing gets evicted
+	rkey, _, err := cache.Put(3, 30)
+	require.NoError(t, err)
+	require.Nil(t, rkey)
+
+	v, err = cache.Get(2)
+	require.NoError(t, err)
+	require.Equal(t, 20, v)
+}
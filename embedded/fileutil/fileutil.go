@@ -0,0 +1,124 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fileutil provides the file operations used by the embedded storage, behaving alike on every platform:
+// paths exceeding the length limit of Windows are supported, opened files can be renamed and removed as on unix
+// systems, and operations failing due to transient errors e.g. a file being scanned by an antivirus, are retried.
+package fileutil
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+var ErrLocked = errors.New("already locked, it may be in use by another process")
+
+const maxAttempts = 8
+const firstRetryDelay = 5 * time.Millisecond
+
+// transient reports whether an operation failing with the error may succeed when retried
+var transient = isTransient
+
+// retry runs the operation until it succeeds, fails with a non-transient error or the attempts are exhausted
+func retry(op func() error) error {
+	delay := firstRetryDelay
+
+	for attempt := 1; ; attempt++ {
+		err := op()
+		if err == nil || attempt == maxAttempts || !transient(err) {
+			return err
+		}
+
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// OpenFile is like os.OpenFile, but files can be renamed and removed while they are opened
+func OpenFile(name string, flag int, perm os.FileMode) (f *os.File, err error) {
+	err = retry(func() error {
+		f, err = openFile(name, flag, perm)
+		return err
+	})
+
+	return f, err
+}
+
+func Open(name string) (*os.File, error) {
+	return OpenFile(name, os.O_RDONLY, 0)
+}
+
+func Create(name string) (*os.File, error) {
+	return OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0666)
+}
+
+func Rename(oldpath, newpath string) error {
+	return retry(func() error {
+		return os.Rename(oldpath, newpath)
+	})
+}
+
+func Remove(name string) error {
+	return retry(func() error {
+		return os.Remove(name)
+	})
+}
+
+func RemoveAll(path string) error {
+	return retry(func() error {
+		return os.RemoveAll(path)
+	})
+}
+
+// Flock is an exclusive lock over a file, held until it's unlocked or the process exits
+type Flock struct {
+	f *os.File
+}
+
+// Lock takes the lock over the file, which is created if it does not exist.
+// ErrLocked is returned without waiting if the lock is already taken, even by the same process
+func Lock(name string, perm os.FileMode) (*Flock, error) {
+	f, err := OpenFile(name, os.O_RDWR|os.O_CREATE, perm)
+	if err != nil {
+		return nil, err
+	}
+
+	err = lockFile(f)
+	if err != nil {
+		f.Close()
+
+		if errors.Is(err, ErrLocked) {
+			return nil, fmt.Errorf("%w: %s", ErrLocked, name)
+		}
+
+		return nil, err
+	}
+
+	return &Flock{f: f}, nil
+}
+
+// Unlock releases the lock, the file is kept
+func (l *Flock) Unlock() error {
+	err := unlockFile(l.f)
+	if err != nil {
+		l.f.Close()
+		return err
+	}
+
+	return l.f.Close()
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package fileutil
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetry(t *testing.T) {
+	errTransient := errors.New("transient error")
+
+	transient = func(err error) bool {
+		return err == errTransient
+	}
+	defer func() { transient = isTransient }()
+
+	attempts := 0
+	err := retry(func() error {
+		attempts++
+		if attempts < 3 {
+			return errTransient
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, attempts)
+
+	attempts = 0
+	err = retry(func() error {
+		attempts++
+		return errTransient
+	})
+	require.Equal(t, errTransient, err)
+	require.Equal(t, maxAttempts, attempts)
+
+	errOther := errors.New("other error")
+
+	attempts = 0
+	err = retry(func() error {
+		attempts++
+		return errOther
+	})
+	require.Equal(t, errOther, err)
+	require.Equal(t, 1, attempts)
+}
+
+func TestOpenedFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fileutil")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	name, err := LongPath(filepath.Join(dir, "file"))
+	require.NoError(t, err)
+
+	f, err := Create(name)
+	require.NoError(t, err)
+	defer f.Close()
+
+	_, err = f.Write([]byte{1, 2, 3})
+	require.NoError(t, err)
+
+	err = Rename(name, name+".renamed")
+	require.NoError(t, err)
+
+	r, err := Open(name + ".renamed")
+	require.NoError(t, err)
+	defer r.Close()
+
+	b, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 2, 3}, b)
+
+	err = Remove(name + ".renamed")
+	require.NoError(t, err)
+
+	_, err = Open(name + ".renamed")
+	require.True(t, os.IsNotExist(err))
+
+	err = RemoveAll(dir)
+	require.NoError(t, err)
+}
+
+func TestLock(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fileutil")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	name := filepath.Join(dir, "LOCK")
+
+	l, err := Lock(name, 0700)
+	require.NoError(t, err)
+
+	_, err = Lock(name, 0700)
+	require.True(t, errors.Is(err, ErrLocked))
+
+	err = l.Unlock()
+	require.NoError(t, err)
+
+	l, err = Lock(name, 0700)
+	require.NoError(t, err)
+
+	err = l.Unlock()
+	require.NoError(t, err)
+
+	_, err = Lock(filepath.Join(dir, "missing", "LOCK"), 0700)
+	require.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,142 @@
+// +build windows
+
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fileutil
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/sys/windows"
+)
+
+// paths from this length on exceed MAX_PATH once a file name is appended to them
+const maxShortPathLen = 248
+
+// LongPath returns the path to be used when it may exceed the length limit of the platform.
+// The limit is lifted by the os package only for absolute paths, thus the absolute path is returned
+func LongPath(path string) (string, error) {
+	return filepath.Abs(path)
+}
+
+// extendedPath returns the path prefixed as required by the Windows API to exceed MAX_PATH
+func extendedPath(path string) string {
+	if len(path) < maxShortPathLen || strings.HasPrefix(path, `\\?\`) {
+		return path
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+
+	if strings.HasPrefix(abs, `\\`) {
+		// UNC path i.e. \\server\share
+		return `\\?\UNC\` + abs[2:]
+	}
+
+	return `\\?\` + abs
+}
+
+// openFile opens the file sharing it for deletion, thus it can be renamed and removed while opened,
+// the flags are interpreted as done by syscall.Open
+func openFile(name string, flag int, perm os.FileMode) (*os.File, error) {
+	if name == "" {
+		return nil, &os.PathError{Op: "open", Path: name, Err: windows.ERROR_FILE_NOT_FOUND}
+	}
+
+	pathp, err := windows.UTF16PtrFromString(extendedPath(name))
+	if err != nil {
+		return nil, &os.PathError{Op: "open", Path: name, Err: err}
+	}
+
+	var access uint32
+
+	switch flag & (os.O_RDONLY | os.O_WRONLY | os.O_RDWR) {
+	case os.O_RDONLY:
+		access = windows.GENERIC_READ
+	case os.O_WRONLY:
+		access = windows.GENERIC_WRITE
+	case os.O_RDWR:
+		access = windows.GENERIC_READ | windows.GENERIC_WRITE
+	}
+
+	if flag&os.O_CREATE != 0 {
+		access |= windows.GENERIC_WRITE
+	}
+
+	if flag&os.O_APPEND != 0 {
+		access &^= windows.GENERIC_WRITE
+		access |= windows.FILE_APPEND_DATA
+	}
+
+	shareMode := uint32(windows.FILE_SHARE_READ | windows.FILE_SHARE_WRITE | windows.FILE_SHARE_DELETE)
+
+	var createMode uint32
+
+	switch {
+	case flag&(os.O_CREATE|os.O_EXCL) == (os.O_CREATE | os.O_EXCL):
+		createMode = windows.CREATE_NEW
+	case flag&(os.O_CREATE|os.O_TRUNC) == (os.O_CREATE | os.O_TRUNC):
+		createMode = windows.CREATE_ALWAYS
+	case flag&os.O_CREATE == os.O_CREATE:
+		createMode = windows.OPEN_ALWAYS
+	case flag&os.O_TRUNC == os.O_TRUNC:
+		createMode = windows.TRUNCATE_EXISTING
+	default:
+		createMode = windows.OPEN_EXISTING
+	}
+
+	attrs := uint32(windows.FILE_ATTRIBUTE_NORMAL)
+	if perm&0200 == 0 {
+		attrs = windows.FILE_ATTRIBUTE_READONLY
+	}
+
+	h, err := windows.CreateFile(pathp, access, shareMode, nil, createMode, attrs, 0)
+	if err != nil {
+		return nil, &os.PathError{Op: "open", Path: name, Err: err}
+	}
+
+	return os.NewFile(uintptr(h), name), nil
+}
+
+// isTransient reports whether the error may be caused by another process, e.g. an antivirus or an indexing service,
+// briefly opening the file without sharing it
+func isTransient(err error) bool {
+	return errors.Is(err, windows.ERROR_SHARING_VIOLATION) ||
+		errors.Is(err, windows.ERROR_LOCK_VIOLATION) ||
+		errors.Is(err, windows.ERROR_ACCESS_DENIED)
+}
+
+func lockFile(f *os.File) error {
+	ol := new(windows.Overlapped)
+
+	err := windows.LockFileEx(windows.Handle(f.Fd()), windows.LOCKFILE_EXCLUSIVE_LOCK|windows.LOCKFILE_FAIL_IMMEDIATELY, 0, 1, 0, ol)
+	if errors.Is(err, windows.ERROR_LOCK_VIOLATION) {
+		return ErrLocked
+	}
+
+	return err
+}
+
+func unlockFile(f *os.File) error {
+	ol := new(windows.Overlapped)
+	return windows.UnlockFileEx(windows.Handle(f.Fd()), 0, 1, 0, ol)
+}
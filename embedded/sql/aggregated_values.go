@@ -19,6 +19,7 @@ package sql
 type AggregatedValue interface {
 	TypedValue
 	updateWith(val TypedValue) error
+	mergeWith(aggV AggregatedValue) error
 	Selector() string
 	ColBounded() bool
 }
@@ -67,9 +68,85 @@ func (v *CountValue) updateWith(val TypedValue) error {
 	return nil
 }
 
+func (v *CountValue) mergeWith(aggV AggregatedValue) error {
+	cv, ok := aggV.(*CountValue)
+	if !ok {
+		return ErrNotComparableValues
+	}
+
+	v.c += cv.c
+
+	return nil
+}
+
+// CountDistinctValue counts the distinct non-null values, which are kept by their encoding
+type CountDistinctValue struct {
+	vals map[string]struct{}
+	sel  string
+}
+
+func (v *CountDistinctValue) Selector() string {
+	return v.sel
+}
+
+func (v *CountDistinctValue) ColBounded() bool {
+	return true
+}
+
+func (v *CountDistinctValue) Type() SQLValueType {
+	return IntegerType
+}
+
+func (v *CountDistinctValue) Value() interface{} {
+	return uint64(len(v.vals))
+}
+
+func (v *CountDistinctValue) Compare(val TypedValue) (int, error) {
+	return (&CountValue{c: uint64(len(v.vals))}).Compare(val)
+}
+
+func (v *CountDistinctValue) updateWith(val TypedValue) error {
+	if isNull(val) {
+		return nil
+	}
+
+	encVal, err := EncodeValue(val, val.Type(), false)
+	if err != nil {
+		return err
+	}
+
+	if v.vals == nil {
+		v.vals = make(map[string]struct{})
+	}
+
+	v.vals[string(encVal)] = struct{}{}
+
+	return nil
+}
+
+func (v *CountDistinctValue) mergeWith(aggV AggregatedValue) error {
+	cv, ok := aggV.(*CountDistinctValue)
+	if !ok {
+		return ErrNotComparableValues
+	}
+
+	for encVal := range cv.vals {
+		if v.vals == nil {
+			v.vals = make(map[string]struct{})
+		}
+
+		v.vals[encVal] = struct{}{}
+	}
+
+	return nil
+}
+
+// SumValue sums integer values, or float values when the summed column is a float one
 type SumValue struct {
-	s   uint64
-	sel string
+	s        uint64
+	f        float64
+	floating bool
+	sel      string
 }
 
 func (v *SumValue) Selector() string {
@@ -81,14 +158,25 @@ func (v *SumValue) ColBounded() bool {
 }
 
 func (v *SumValue) Type() SQLValueType {
+	if v.floating {
+		return FloatType
+	}
 	return IntegerType
 }
 
 func (v *SumValue) Value() interface{} {
+	if v.floating {
+		return v.f
+	}
 	return v.s
 }
 
 func (v *SumValue) Compare(val TypedValue) (int, error) {
+	if v.floating || val.Type() == FloatType {
+		f, _ := asFloat(v)
+		return (&Float{val: f}).Compare(val)
+	}
+
 	if val.Type() != IntegerType {
 		return 0, ErrNotComparableValues
 	}
@@ -107,15 +195,38 @@ func (v *SumValue) Compare(val TypedValue) (int, error) {
 }
 
 func (v *SumValue) updateWith(val TypedValue) error {
-	if val.Type() != IntegerType {
+	if val.Type() != IntegerType && (!v.floating || val.Type() != FloatType) {
 		return ErrNotComparableValues
 	}
 
+	if isNull(val) {
+		// null values are not taken into account
+		return nil
+	}
+
+	if v.floating {
+		f, _ := asFloat(val)
+		v.f += f
+		return nil
+	}
+
 	v.s += val.Value().(uint64)
 
 	return nil
 }
 
+func (v *SumValue) mergeWith(aggV AggregatedValue) error {
+	sv, ok := aggV.(*SumValue)
+	if !ok || sv.floating != v.floating {
+		return ErrNotComparableValues
+	}
+
+	v.s += sv.s
+	v.f += sv.f
+
+	return nil
+}
+
 type MinValue struct {
 	val TypedValue
 	sel string
@@ -142,11 +253,16 @@ func (v *MinValue) Compare(val TypedValue) (int, error) {
 }
 
 func (v *MinValue) updateWith(val TypedValue) error {
-	if v.val == nil {
+	if v.val == nil || isNull(v.val) {
+		// a null value is kept until a non-null one is found
 		v.val = val
 		return nil
 	}
 
+	if isNull(val) {
+		return nil
+	}
+
 	cmp, err := v.val.Compare(val)
 	if err != nil {
 		return err
@@ -159,6 +275,19 @@ func (v *MinValue) updateWith(val TypedValue) error {
 	return nil
 }
 
+func (v *MinValue) mergeWith(aggV AggregatedValue) error {
+	mv, ok := aggV.(*MinValue)
+	if !ok {
+		return ErrNotComparableValues
+	}
+
+	if mv.val == nil {
+		return nil
+	}
+
+	return v.updateWith(mv.val)
+}
+
 type MaxValue struct {
 	val TypedValue
 	sel string
@@ -185,11 +314,16 @@ func (v *MaxValue) Compare(val TypedValue) (int, error) {
 }
 
 func (v *MaxValue) updateWith(val TypedValue) error {
-	if v.val == nil {
+	if v.val == nil || isNull(v.val) {
+		// a null value is kept until a non-null one is found
 		v.val = val
 		return nil
 	}
 
+	if isNull(val) {
+		return nil
+	}
+
 	cmp, err := v.val.Compare(val)
 	if err != nil {
 		return err
@@ -202,10 +336,26 @@ func (v *MaxValue) updateWith(val TypedValue) error {
 	return nil
 }
 
+func (v *MaxValue) mergeWith(aggV AggregatedValue) error {
+	mv, ok := aggV.(*MaxValue)
+	if !ok {
+		return ErrNotComparableValues
+	}
+
+	if mv.val == nil {
+		return nil
+	}
+
+	return v.updateWith(mv.val)
+}
+
+// AVGValue averages integer values, or float values when the averaged column is a float one
 type AVGValue struct {
-	s   uint64
-	c   uint64
-	sel string
+	s        uint64
+	f        float64
+	c        uint64
+	floating bool
+	sel      string
 }
 
 func (v *AVGValue) Selector() string {
@@ -217,19 +367,39 @@ func (v *AVGValue) ColBounded() bool {
 }
 
 func (v *AVGValue) Type() SQLValueType {
+	if v.floating {
+		return FloatType
+	}
 	return IntegerType
 }
 
 func (v *AVGValue) Value() interface{} {
+	if v.floating {
+		if v.c == 0 {
+			return float64(0)
+		}
+
+		return v.f / float64(v.c)
+	}
+
+	if v.c == 0 {
+		return uint64(0)
+	}
+
 	return v.s / v.c
 }
 
 func (v *AVGValue) Compare(val TypedValue) (int, error) {
+	if v.floating || val.Type() == FloatType {
+		f, _ := asFloat(v)
+		return (&Float{val: f}).Compare(val)
+	}
+
 	if val.Type() != IntegerType {
 		return 0, ErrNotComparableValues
 	}
 
-	avg := v.s / v.c
+	avg := v.Value().(uint64)
 	nv := val.Value().(uint64)
 
 	if avg == nv {
@@ -244,12 +414,40 @@ func (v *AVGValue) Compare(val TypedValue) (int, error) {
 }
 
 func (v *AVGValue) updateWith(val TypedValue) error {
-	if val.Type() != IntegerType {
+	if val.Type() != IntegerType && (!v.floating || val.Type() != FloatType) {
 		return ErrNotComparableValues
 	}
 
-	v.s += val.Value().(uint64)
+	if isNull(val) {
+		return nil
+	}
+
+	if v.floating {
+		f, _ := asFloat(val)
+		v.f += f
+	} else {
+		v.s += val.Value().(uint64)
+	}
+
 	v.c++
 
 	return nil
 }
+
+func (v *AVGValue) mergeWith(aggV AggregatedValue) error {
+	av, ok := aggV.(*AVGValue)
+	if !ok || av.floating != v.floating {
+		return ErrNotComparableValues
+	}
+
+	v.s += av.s
+	v.f += av.f
+	v.c += av.c
+
+	return nil
+}
+
+func isNull(val TypedValue) bool {
+	_, isNull := val.(*NullValue)
+	return isNull
+}
@@ -178,3 +178,86 @@ func TestAVGValue(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, -1, cmp)
 }
+
+func TestAggregatedValuesWithNulls(t *testing.T) {
+	sval := &SumValue{sel: "db1.table1.amount"}
+	err := sval.updateWith(&NullValue{t: IntegerType})
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), sval.Value())
+
+	aval := &AVGValue{sel: "db1.table1.amount"}
+	err = aval.updateWith(&NullValue{t: IntegerType})
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), aval.Value())
+
+	err = aval.updateWith(&Number{val: 4})
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), aval.Value())
+
+	mval := &MinValue{sel: "db1.table1.amount"}
+	err = mval.updateWith(&NullValue{t: IntegerType})
+	require.NoError(t, err)
+	require.Equal(t, IntegerType, mval.Type())
+	require.Nil(t, mval.Value())
+
+	err = mval.updateWith(&Number{val: 3})
+	require.NoError(t, err)
+
+	err = mval.updateWith(&NullValue{t: IntegerType})
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), mval.Value())
+
+	xval := &MaxValue{sel: "db1.table1.amount"}
+	err = xval.updateWith(&Number{val: 3})
+	require.NoError(t, err)
+
+	err = xval.updateWith(&NullValue{t: IntegerType})
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), xval.Value())
+}
+
+func TestMergeAggregatedValues(t *testing.T) {
+	cval := &CountValue{c: 2}
+	err := cval.mergeWith(&CountValue{c: 3})
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), cval.Value())
+
+	err = cval.mergeWith(&SumValue{})
+	require.Equal(t, ErrNotComparableValues, err)
+
+	sval := &SumValue{s: 2}
+	err = sval.mergeWith(&SumValue{s: 3})
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), sval.Value())
+
+	err = sval.mergeWith(&CountValue{})
+	require.Equal(t, ErrNotComparableValues, err)
+
+	mval := &MinValue{val: &Number{val: 3}}
+	err = mval.mergeWith(&MinValue{val: &Number{val: 1}})
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), mval.Value())
+
+	err = mval.mergeWith(&MinValue{})
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), mval.Value())
+
+	err = mval.mergeWith(&MaxValue{})
+	require.Equal(t, ErrNotComparableValues, err)
+
+	xval := &MaxValue{val: &Number{val: 3}}
+	err = xval.mergeWith(&MaxValue{val: &Number{val: 7}})
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), xval.Value())
+
+	err = xval.mergeWith(&MinValue{})
+	require.Equal(t, ErrNotComparableValues, err)
+
+	aval := &AVGValue{s: 10, c: 2}
+	err = aval.mergeWith(&AVGValue{s: 8, c: 1})
+	require.NoError(t, err)
+	require.Equal(t, uint64(6), aval.Value())
+
+	err = aval.mergeWith(&SumValue{})
+	require.Equal(t, ErrNotComparableValues, err)
+}
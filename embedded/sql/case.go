@@ -0,0 +1,310 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package sql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CaseWhenExp is a conditional expression, either searched e.g. CASE WHEN age < 18 THEN 'minor' ELSE 'adult' END,
+// or simple e.g. CASE country WHEN 'ES' THEN 'Spain' WHEN 'IT' THEN 'Italy' END.
+// It's reduced to the result of the first matching branch, or the ELSE result, or NULL when there is none
+type CaseWhenExp struct {
+	// the value compared to the values of the branches of a simple expression, nil in searched expressions
+	exp     ValueExp
+	whens   []*whenThen
+	elseExp ValueExp
+	as      string
+}
+
+type whenThen struct {
+	when ValueExp
+	then ValueExp
+}
+
+func (c *CaseWhenExp) resolve(implicitDB, implicitTable string) (aggFn, db, table, col string) {
+	// values are named as aggregations, thus there is no column
+	return "", implicitDB, implicitTable, ""
+}
+
+func (c *CaseWhenExp) alias() string {
+	return c.as
+}
+
+func (c *CaseWhenExp) setAlias(alias string) {
+	c.as = alias
+}
+
+func (c *CaseWhenExp) jointColumnTo(col *Column, tableAlias string) (*ColSelector, error) {
+	return nil, ErrJointColumnNotFound
+}
+
+func (c *CaseWhenExp) substitute(params map[string]interface{}) (ValueExp, error) {
+	rc := &CaseWhenExp{whens: make([]*whenThen, len(c.whens)), as: c.as}
+
+	var err error
+
+	if c.exp != nil {
+		rc.exp, err = c.exp.substitute(params)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for i, w := range c.whens {
+		when, err := w.when.substitute(params)
+		if err != nil {
+			return nil, err
+		}
+
+		then, err := w.then.substitute(params)
+		if err != nil {
+			return nil, err
+		}
+
+		rc.whens[i] = &whenThen{when: when, then: then}
+	}
+
+	if c.elseExp != nil {
+		rc.elseExp, err = c.elseExp.substitute(params)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return rc, nil
+}
+
+func (c *CaseWhenExp) reduce(catalog *Catalog, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
+	var val TypedValue
+
+	if c.exp != nil {
+		v, err := c.exp.reduce(catalog, row, implicitDB, implicitTable)
+		if err != nil {
+			return nil, err
+		}
+
+		val = v
+	}
+
+	for _, w := range c.whens {
+		matches, err := c.matches(val, w.when, catalog, row, implicitDB, implicitTable)
+		if err != nil {
+			return nil, err
+		}
+
+		if matches {
+			return w.then.reduce(catalog, row, implicitDB, implicitTable)
+		}
+	}
+
+	if c.elseExp == nil {
+		return &NullValue{}, nil
+	}
+
+	return c.elseExp.reduce(catalog, row, implicitDB, implicitTable)
+}
+
+// matches returns whether the branch applies. In simple expressions the value must be equal to the one of the branch,
+// otherwise the condition of the branch must be satisfied. Nulls and unknown conditions never match
+func (c *CaseWhenExp) matches(val TypedValue, when ValueExp, catalog *Catalog, row *Row, implicitDB, implicitTable string) (bool, error) {
+	r, err := when.reduce(catalog, row, implicitDB, implicitTable)
+	if err != nil {
+		return false, err
+	}
+
+	if c.exp != nil {
+		if val.Value() == nil || r.Value() == nil {
+			return false, nil
+		}
+
+		cmp, err := val.Compare(r)
+		if err != nil {
+			return false, err
+		}
+
+		return cmp == 0, nil
+	}
+
+	if isUnknown(r) {
+		return false, nil
+	}
+
+	satisfies, isBool := r.(*Bool)
+	if !isBool {
+		return false, ErrInvalidCondition
+	}
+
+	return satisfies.val, nil
+}
+
+// valType returns the type of the results of the expression. Integer and float results are returned as floats,
+// and as text when all the results are null, as there is nothing else to infer the type from
+func (c *CaseWhenExp) valType(cols map[string]*ColDescriptor, implicitDB, implicitTable string) (SQLValueType, error) {
+	results := make([]ValueExp, 0, len(c.whens)+1)
+
+	for _, w := range c.whens {
+		results = append(results, w.then)
+	}
+
+	if c.elseExp != nil {
+		results = append(results, c.elseExp)
+	}
+
+	resultTypes := make([]SQLValueType, len(results))
+
+	for i, r := range results {
+		rt, err := expType(r, cols, implicitDB, implicitTable)
+		if err != nil {
+			return "", err
+		}
+
+		resultTypes[i] = rt
+	}
+
+	t, err := commonType(resultTypes, ErrInvalidCaseTypes)
+	if err != nil {
+		return "", err
+	}
+
+	if t == "" {
+		return VarcharType, nil
+	}
+
+	return t, nil
+}
+
+// commonType returns the type values of the given types can be returned as, integers are promoted to floats
+// when mixed with them. Empty types of nulls are ignored, and the result is empty when there are only nulls
+func commonType(types []SQLValueType, mismatchErr error) (SQLValueType, error) {
+	var t SQLValueType
+
+	for _, rt := range types {
+		if rt == "" || rt == t {
+			continue
+		}
+
+		if t == "" {
+			t = rt
+			continue
+		}
+
+		if (t == IntegerType || t == FloatType) && (rt == IntegerType || rt == FloatType) {
+			t = FloatType
+			continue
+		}
+
+		return "", fmt.Errorf("%w: %s and %s", mismatchErr, t, rt)
+	}
+
+	return t, nil
+}
+
+// reduceAs reduces the expression into a value of the given type, as returned by valType
+func (c *CaseWhenExp) reduceAs(t SQLValueType, catalog *Catalog, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
+	v, err := c.reduce(catalog, row, implicitDB, implicitTable)
+	if err != nil {
+		return nil, err
+	}
+
+	return valueAs(v, t), nil
+}
+
+// valueAs returns the value as a value of type t, for values of the type or integers when t is float
+func valueAs(v TypedValue, t SQLValueType) TypedValue {
+	if v.Value() == nil {
+		return &NullValue{t: t}
+	}
+
+	n, isNumber := v.(*Number)
+	if isNumber && t == FloatType {
+		return &Float{val: float64(n.val)}
+	}
+
+	return v
+}
+
+// expType returns the type of the values of the expression, or an empty type for null values.
+// Parameters are expected to be already substituted
+func expType(exp ValueExp, cols map[string]*ColDescriptor, implicitDB, implicitTable string) (SQLValueType, error) {
+	switch e := exp.(type) {
+	case TypedValue:
+		{
+			return e.Type(), nil
+		}
+	case *ColSelector:
+		{
+			colDesc, ok := cols[EncodeSelector(e.resolve(implicitDB, implicitTable))]
+			if !ok {
+				return "", fmt.Errorf("%w: %s", ErrColumnDoesNotExist, e.col)
+			}
+
+			return colDesc.Type, nil
+		}
+	case *AggColSelector:
+		{
+			colDesc, ok := cols[EncodeSelector(e.resolve(implicitDB, implicitTable))]
+			if !ok {
+				return "", fmt.Errorf("%w: %s", ErrColumnDoesNotExist, e.col)
+			}
+
+			return colDesc.Type, nil
+		}
+	case *JSONSelector:
+		{
+			return e.valType(), nil
+		}
+	case *SysFn:
+		{
+			if strings.ToUpper(e.fn) == "NOW" {
+				return TimestampType, nil
+			}
+		}
+	case *NumExp:
+		{
+			lt, err := expType(e.left, cols, implicitDB, implicitTable)
+			if err != nil {
+				return "", err
+			}
+
+			rt, err := expType(e.right, cols, implicitDB, implicitTable)
+			if err != nil {
+				return "", err
+			}
+
+			if lt == FloatType || rt == FloatType {
+				return FloatType, nil
+			}
+
+			return IntegerType, nil
+		}
+	case *CmpBoolExp, *BinBoolExp, *NotBoolExp, *IsNullBoolExp, *LikeBoolExp, *InListExp:
+		{
+			return BooleanType, nil
+		}
+	case *CaseWhenExp:
+		{
+			return e.valType(cols, implicitDB, implicitTable)
+		}
+	case *FnCall:
+		{
+			return e.valType(cols, implicitDB, implicitTable)
+		}
+	}
+
+	return "", ErrInvalidValue
+}
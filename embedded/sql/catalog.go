@@ -15,6 +15,12 @@ limitations under the License.
 */
 package sql
 
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
 type Catalog struct {
 	dbsByID   map[uint64]*Database
 	dbsByName map[string]*Database
@@ -25,6 +31,9 @@ type Database struct {
 	name         string
 	tablesByID   map[uint64]*Table
 	tablesByName map[string]*Table
+	// ids of dropped tables are not reused as their rows are kept in the store
+	maxTableID  uint64
+	viewsByName map[string]*View
 }
 
 type Table struct {
@@ -33,16 +42,35 @@ type Table struct {
 	name       string
 	colsByID   map[uint64]*Column
 	colsByName map[string]*Column
-	pk         *Column
-	indexes    map[uint64]struct{}
+	pk         *Column             // leading column of the primary key, its id is part of the keys of the entries holding the rows
+	pkCols     []*Column           // columns of the primary key in the order their values are encoded within keys
+	indexes    map[uint64]*Index   // indexes by their leading column
+	uniques    map[uint64]struct{} // indexed columns which values can not be repeated among rows
+	policies   map[string]*Policy
+	checks     map[string]*Check
+	// last value assigned to the auto incremental primary key, loaded from the data store on first use
+	seq       uint64
+	seqLoaded bool
 }
 
 type Column struct {
-	table   *Table
-	id      uint64
-	colName string
-	colType SQLValueType
-	notNull bool
+	table         *Table
+	id            uint64
+	colName       string
+	colType       SQLValueType
+	autoIncrement bool
+	notNull       bool
+	// value given to the column when it's not included in an insertion, either a constant or NOW()
+	defaultValue ValueExp
+}
+
+// Policy restricts the rows of the table accessible within a user session to the ones satisfying the filter
+type Policy struct {
+	table  *Table
+	name   string
+	users  []string // users or roles the policy applies to, every user when empty
+	filter ValueExp
+	cols   map[string]struct{} // columns referenced by the filter
 }
 
 func newCatalog() *Catalog {
@@ -60,7 +88,7 @@ func (c *Catalog) ExistDatabase(db string) bool {
 func (c *Catalog) newDatabase(name string) (*Database, error) {
 	exists := c.ExistDatabase(name)
 	if exists {
-		return nil, ErrDatabaseAlreadyExists
+		return nil, fmt.Errorf("%w: %s", ErrDatabaseAlreadyExists, name)
 	}
 
 	id := len(c.dbsByID) + 1
@@ -70,6 +98,7 @@ func (c *Catalog) newDatabase(name string) (*Database, error) {
 		name:         name,
 		tablesByID:   map[uint64]*Table{},
 		tablesByName: map[string]*Table{},
+		viewsByName:  map[string]*View{},
 	}
 
 	c.dbsByID[db.id] = db
@@ -93,7 +122,7 @@ func (c *Catalog) Databases() []*Database {
 func (c *Catalog) GetDatabaseByName(name string) (*Database, error) {
 	db, exists := c.dbsByName[name]
 	if !exists {
-		return nil, ErrDatabaseDoesNotExist
+		return nil, fmt.Errorf("%w: %s", ErrDatabaseDoesNotExist, name)
 	}
 	return db, nil
 }
@@ -142,7 +171,7 @@ func (db *Database) GetTables() []*Table {
 func (db *Database) GetTableByName(name string) (*Table, error) {
 	table, exists := db.tablesByName[name]
 	if !exists {
-		return nil, ErrTableDoesNotExist
+		return nil, fmt.Errorf("%w: %s", ErrTableDoesNotExist, name)
 	}
 	return table, nil
 }
@@ -175,24 +204,67 @@ func (t *Table) Name() string {
 	return t.name
 }
 
+// PrimaryKey returns the leading column of the primary key
 func (t *Table) PrimaryKey() *Column {
 	return t.pk
 }
 
+// PrimaryKeyCols returns the columns of the primary key in the order they were given
+func (t *Table) PrimaryKeyCols() []*Column {
+	return t.pkCols
+}
+
+// HasPolicies returns whether rows of the table are restricted by policies
+func (t *Table) HasPolicies() bool {
+	return len(t.policies) > 0
+}
+
+// GetIndexes returns the indexes of the table sorted by their leading column
+func (t *Table) GetIndexes() []*Index {
+	idxs := make([]*Index, 0, len(t.indexes))
+	for _, idx := range t.indexes {
+		idxs = append(idxs, idx)
+	}
+	sort.Slice(idxs, func(i, j int) bool { return idxs[i].id() < idxs[j].id() })
+
+	return idxs
+}
+
+// IsPrimaryKey returns whether the column is one of the columns of the primary key
+func (t *Table) IsPrimaryKey(colName string) bool {
+	for _, col := range t.pkCols {
+		if col.colName == colName {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (t *Table) IsIndexed(colName string) (bool, error) {
 	c, exists := t.colsByName[colName]
 	if !exists {
-		return false, ErrColumnDoesNotExist
+		return false, fmt.Errorf("%w: %s", ErrColumnDoesNotExist, colName)
 	}
 
 	_, indexed := t.indexes[c.id]
 	return indexed, nil
 }
 
+func (t *Table) IsUnique(colName string) (bool, error) {
+	c, exists := t.colsByName[colName]
+	if !exists {
+		return false, fmt.Errorf("%w: %s", ErrColumnDoesNotExist, colName)
+	}
+
+	_, unique := t.uniques[c.id]
+	return unique, nil
+}
+
 func (t *Table) GetColumnByName(name string) (*Column, error) {
 	col, exists := t.colsByName[name]
 	if !exists {
-		return nil, ErrColumnDoesNotExist
+		return nil, fmt.Errorf("%w: %s", ErrColumnDoesNotExist, name)
 	}
 	return col, nil
 }
@@ -205,27 +277,47 @@ func (t *Table) GetColumnByID(id uint64) (*Column, error) {
 	return col, nil
 }
 
-func (db *Database) newTable(name string, colsSpec []*ColSpec, pk string) (*Table, error) {
+func (db *Database) newTable(name string, colsSpec []*ColSpec, checks []*CheckSpec, pk []string) (*Table, error) {
 	if len(name) == 0 || len(colsSpec) == 0 || len(pk) == 0 {
 		return nil, ErrIllegalArguments
 	}
 
 	exists := db.ExistTable(name)
 	if exists {
-		return nil, ErrTableAlreadyExists
+		return nil, fmt.Errorf("%w: %s", ErrTableAlreadyExists, name)
+	}
+
+	if db.ExistView(name) {
+		return nil, fmt.Errorf("%w: %s", ErrViewAlreadyExists, name)
 	}
 
-	id := len(db.tablesByID) + 1
+	id := db.maxTableID + 1
 
 	table := &Table{
-		id:         uint64(id),
+		id:         id,
 		db:         db,
 		name:       name,
 		colsByID:   make(map[uint64]*Column, 0),
 		colsByName: make(map[string]*Column, 0),
-		indexes:    make(map[uint64]struct{}, 0),
+		indexes:    make(map[uint64]*Index, 0),
+		uniques:    make(map[uint64]struct{}, 0),
+		policies:   make(map[string]*Policy, 0),
+		checks:     make(map[string]*Check, 0),
 	}
 
+	pkPos := make(map[string]int, len(pk))
+
+	for i, colName := range pk {
+		_, duplicated := pkPos[colName]
+		if duplicated {
+			return nil, ErrDuplicatedColumn
+		}
+
+		pkPos[colName] = i
+	}
+
+	table.pkCols = make([]*Column, len(pk))
+
 	for _, cs := range colsSpec {
 		_, colExists := table.colsByName[cs.colName]
 		if colExists {
@@ -234,32 +326,346 @@ func (db *Database) newTable(name string, colsSpec []*ColSpec, pk string) (*Tabl
 
 		id := len(table.colsByID) + 1
 
+		pos, isPK := pkPos[cs.colName]
+
+		// values are only generated for integer primary keys made of a single column
+		if cs.autoIncrement && (!isPK || len(pk) > 1 || cs.colType != IntegerType) {
+			return nil, ErrLimitedAutoIncrement
+		}
+
 		col := &Column{
-			id:      uint64(id),
-			table:   table,
-			colName: cs.colName,
-			colType: cs.colType,
-			notNull: cs.notNull || cs.colName == pk,
+			id:            uint64(id),
+			table:         table,
+			colName:       cs.colName,
+			colType:       cs.colType,
+			autoIncrement: cs.autoIncrement,
+			notNull:       cs.notNull || isPK,
+		}
+
+		err := col.setDefault(cs.defaultValue)
+		if err != nil {
+			return nil, err
 		}
 
 		table.colsByID[col.id] = col
 		table.colsByName[col.colName] = col
 
-		if pk == col.colName {
-			table.pk = col
+		if isPK {
+			if col.colType == JSONType {
+				return nil, ErrInvalidPK
+			}
+
+			table.pkCols[pos] = col
+		}
+	}
+
+	for _, col := range table.pkCols {
+		if col == nil {
+			return nil, ErrInvalidPK
+		}
+	}
+
+	table.pk = table.pkCols[0]
+
+	for _, cs := range colsSpec {
+		if !cs.unique {
+			continue
+		}
+
+		_, err := table.newIndex([]*IndexColSpec{{colName: cs.colName}})
+		if err != nil {
+			return nil, err
 		}
+
+		table.uniques[table.colsByName[cs.colName].id] = struct{}{}
 	}
 
-	if table.pk == nil {
-		return nil, ErrInvalidPK
+	for i, cs := range checks {
+		name := cs.name
+		if name == "" {
+			name = fmt.Sprintf("%s_check%d", table.name, i+1)
+		}
+
+		_, err := table.newCheck(name, cs.exp)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	db.tablesByID[table.id] = table
 	db.tablesByName[table.name] = table
+	db.maxTableID = table.id
+
+	return table, nil
+}
+
+func (db *Database) dropTable(name string) (*Table, error) {
+	table, err := db.GetTableByName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	delete(db.tablesByID, table.id)
+	delete(db.tablesByName, table.name)
 
 	return table, nil
 }
 
+// truncateTable replaces the table with an empty version of it, the new version keeps the name, columns,
+// indexes, policies and check constraints of the table but gets a new id, thus rows of the truncated version are no longer reachable
+// and auto incremental values start over
+func (db *Database) truncateTable(name string) (truncated *Table, table *Table, err error) {
+	truncated, err = db.dropTable(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	table = &Table{
+		id:         db.maxTableID + 1,
+		db:         db,
+		name:       truncated.name,
+		colsByID:   make(map[uint64]*Column, len(truncated.colsByID)),
+		colsByName: make(map[string]*Column, len(truncated.colsByName)),
+		indexes:    make(map[uint64]*Index, len(truncated.indexes)),
+		uniques:    make(map[uint64]struct{}, len(truncated.uniques)),
+		policies:   make(map[string]*Policy, len(truncated.policies)),
+		checks:     make(map[string]*Check, len(truncated.checks)),
+	}
+
+	for _, c := range truncated.colsByID {
+		col := &Column{
+			id:            c.id,
+			table:         table,
+			colName:       c.colName,
+			colType:       c.colType,
+			autoIncrement: c.autoIncrement,
+			notNull:       c.notNull,
+			defaultValue:  c.defaultValue,
+		}
+
+		table.colsByID[col.id] = col
+		table.colsByName[col.colName] = col
+	}
+
+	table.pkCols = make([]*Column, len(truncated.pkCols))
+
+	for i, c := range truncated.pkCols {
+		table.pkCols[i] = table.colsByID[c.id]
+	}
+
+	table.pk = table.pkCols[0]
+
+	for id, idx := range truncated.indexes {
+		cols := make([]*Column, len(idx.cols))
+
+		for i, c := range idx.cols {
+			cols[i] = table.colsByID[c.id]
+		}
+
+		table.indexes[id] = &Index{table: table, cols: cols, desc: idx.desc}
+	}
+
+	for colID := range truncated.uniques {
+		table.uniques[colID] = struct{}{}
+	}
+
+	for name, p := range truncated.policies {
+		table.policies[name] = &Policy{
+			table:  table,
+			name:   p.name,
+			users:  p.users,
+			filter: p.filter,
+			cols:   p.cols,
+		}
+	}
+
+	for name, c := range truncated.checks {
+		table.checks[name] = &Check{
+			table: table,
+			name:  c.name,
+			exp:   c.exp,
+			cols:  c.cols,
+		}
+	}
+
+	db.tablesByID[table.id] = table
+	db.tablesByName[table.name] = table
+	db.maxTableID = table.id
+
+	return truncated, table, nil
+}
+
+func (t *Table) newColumn(spec *ColSpec) (*Column, error) {
+	_, exists := t.colsByName[spec.colName]
+	if exists {
+		return nil, fmt.Errorf("%w: %s", ErrColumnAlreadyExists, spec.colName)
+	}
+
+	// existing rows don't hold a value for the new column, thus it's read as null
+	if spec.notNull {
+		return nil, ErrNewColumnMustBeNullable
+	}
+
+	if spec.autoIncrement {
+		return nil, ErrLimitedAutoIncrement
+	}
+
+	// existing rows would be read as null rather than with the default value
+	if spec.defaultValue != nil {
+		return nil, ErrLimitedDefault
+	}
+
+	// indexes can only be created on empty tables
+	if spec.unique {
+		return nil, ErrLimitedIndex
+	}
+
+	id := len(t.colsByID) + 1
+
+	col := &Column{
+		id:      uint64(id),
+		table:   t,
+		colName: spec.colName,
+		colType: spec.colType,
+		notNull: spec.notNull,
+	}
+
+	t.colsByID[col.id] = col
+	t.colsByName[col.colName] = col
+
+	return col, nil
+}
+
+func (t *Table) renameColumn(oldName, newName string) (*Column, error) {
+	col, err := t.GetColumnByName(oldName)
+	if err != nil {
+		return nil, err
+	}
+
+	_, exists := t.colsByName[newName]
+	if exists {
+		return nil, fmt.Errorf("%w: %s", ErrColumnAlreadyExists, newName)
+	}
+
+	for _, p := range t.policies {
+		_, used := p.cols[oldName]
+		if used {
+			return nil, fmt.Errorf("%w: %s", ErrColumnInUseByPolicy, p.name)
+		}
+	}
+
+	for _, c := range t.checks {
+		_, used := c.cols[oldName]
+		if used {
+			return nil, fmt.Errorf("%w: %s", ErrColumnInUseByCheck, c.name)
+		}
+	}
+
+	delete(t.colsByName, oldName)
+
+	col.colName = newName
+	t.colsByName[newName] = col
+
+	return col, nil
+}
+
+// indexOn returns the index on the given columns, which must be the columns of the index in the same order
+func (t *Table) indexOn(colNames []string) (*Index, error) {
+	if len(colNames) == 0 {
+		return nil, ErrIllegalArguments
+	}
+
+	col, err := t.GetColumnByName(colNames[0])
+	if err != nil {
+		return nil, err
+	}
+
+	idx, indexed := t.indexes[col.id]
+	if !indexed || len(idx.cols) != len(colNames) {
+		return nil, fmt.Errorf("%w: %s", ErrColumnNotIndexed, strings.Join(colNames, ", "))
+	}
+
+	for i, c := range idx.cols {
+		if c.colName != colNames[i] {
+			return nil, fmt.Errorf("%w: %s", ErrColumnNotIndexed, strings.Join(colNames, ", "))
+		}
+	}
+
+	return idx, nil
+}
+
+func (t *Table) dropIndex(colNames []string) (*Index, error) {
+	idx, err := t.indexOn(colNames)
+	if err != nil {
+		return nil, err
+	}
+
+	delete(t.indexes, idx.id())
+	delete(t.uniques, idx.id())
+
+	return idx, nil
+}
+
+func (t *Table) newPolicy(name string, users []string, filter ValueExp) (*Policy, error) {
+	_, exists := t.policies[name]
+	if exists {
+		return nil, fmt.Errorf("%w: %s", ErrPolicyAlreadyExists, name)
+	}
+
+	p := &Policy{
+		table:  t,
+		name:   name,
+		users:  users,
+		filter: filter,
+		cols:   make(map[string]struct{}),
+	}
+
+	// the filter is formatted to validate it can be persisted and re-parsed as it is
+	_, err := formatPolicyFilter(filter, p.cols)
+	if err != nil {
+		return nil, err
+	}
+
+	for colName := range p.cols {
+		_, err := t.GetColumnByName(colName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	t.policies[name] = p
+
+	return p, nil
+}
+
+func (t *Table) dropPolicy(name string) (*Policy, error) {
+	p, exists := t.policies[name]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrPolicyDoesNotExist, name)
+	}
+
+	delete(t.policies, name)
+
+	return p, nil
+}
+
+// String returns the statement creating the policy
+func (p *Policy) String() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "CREATE POLICY %s ON %s", p.name, p.table.name)
+
+	if len(p.users) > 0 {
+		fmt.Fprintf(&b, " TO %s", strings.Join(p.users, ", "))
+	}
+
+	filter, _ := formatPolicyFilter(p.filter, nil)
+
+	fmt.Fprintf(&b, " USING (%s)", filter)
+
+	return b.String()
+}
+
 func (c *Column) ID() uint64 {
 	return c.id
 }
@@ -275,3 +681,142 @@ func (c *Column) Type() SQLValueType {
 func (c *Column) IsNullable() bool {
 	return !c.notNull
 }
+
+func (c *Column) IsAutoIncremental() bool {
+	return c.autoIncrement
+}
+
+// DefaultValue returns the value given to the column when it's not included in an insertion, nil if there is none
+func (c *Column) DefaultValue() ValueExp {
+	return c.defaultValue
+}
+
+// setDefault sets the default value of the column, which must be a constant of the type of the column or
+// a function evaluated on each insertion. Null defaults are the same as having none
+func (c *Column) setDefault(def ValueExp) error {
+	if def == nil {
+		return nil
+	}
+
+	if c.autoIncrement {
+		return fmt.Errorf("%w: %s", ErrInvalidDefault, c.colName)
+	}
+
+	_, isConstant := constantValue(def)
+	_, isFn := def.(*SysFn)
+
+	if !isConstant && !isFn {
+		return fmt.Errorf("%w: %s", ErrInvalidDefault, c.colName)
+	}
+
+	val, err := def.reduce(nil, nil, "", "")
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidDefault, c.colName)
+	}
+
+	if isNull(val) {
+		if c.notNull {
+			return fmt.Errorf("%w: %s", ErrInvalidDefault, c.colName)
+		}
+
+		return nil
+	}
+
+	_, err = EncodeValue(val, c.colType, !asKey)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidDefault, c.colName)
+	}
+
+	c.defaultValue = def
+
+	return nil
+}
+
+// DDL returns the statements re-creating the schema of the database, one per line and in
+// an order they can be executed against an empty database. Columns are declared in the
+// order they were created, so the same column ids are assigned when the statements are applied
+func (db *Database) DDL() string {
+	tableIDs := make([]uint64, 0, len(db.tablesByID))
+	for id := range db.tablesByID {
+		tableIDs = append(tableIDs, id)
+	}
+	sort.Slice(tableIDs, func(i, j int) bool { return tableIDs[i] < tableIDs[j] })
+
+	var b strings.Builder
+
+	for _, id := range tableIDs {
+		db.tablesByID[id].writeDDL(&b)
+	}
+
+	db.writeViewsDDL(&b)
+
+	return b.String()
+}
+
+func (t *Table) writeDDL(b *strings.Builder) {
+	colIDs := make([]uint64, 0, len(t.colsByID))
+	for id := range t.colsByID {
+		colIDs = append(colIDs, id)
+	}
+	sort.Slice(colIDs, func(i, j int) bool { return colIDs[i] < colIDs[j] })
+
+	fmt.Fprintf(b, "CREATE TABLE %s (", t.name)
+
+	for _, id := range colIDs {
+		col := t.colsByID[id]
+
+		fmt.Fprintf(b, "%s %s", col.colName, col.colType)
+
+		if col.autoIncrement {
+			b.WriteString(" AUTO_INCREMENT")
+		}
+
+		if col.notNull {
+			b.WriteString(" NOT NULL")
+		}
+
+		if col.defaultValue != nil {
+			def, _ := formatExp(col.defaultValue, nil, false)
+			b.WriteString(" DEFAULT " + def)
+		}
+
+		_, unique := t.uniques[id]
+		if unique {
+			b.WriteString(" UNIQUE")
+		}
+
+		b.WriteString(", ")
+	}
+
+	for _, c := range t.GetChecks() {
+		fmt.Fprintf(b, "CONSTRAINT %s CHECK (%s), ", c.name, c)
+	}
+
+	if len(t.pkCols) == 1 {
+		fmt.Fprintf(b, "PRIMARY KEY %s);\n", t.pk.colName)
+	} else {
+		pkCols := make([]string, len(t.pkCols))
+		for i, col := range t.pkCols {
+			pkCols[i] = col.colName
+		}
+
+		fmt.Fprintf(b, "PRIMARY KEY (%s));\n", strings.Join(pkCols, ", "))
+	}
+
+	for _, idx := range t.GetIndexes() {
+		// unique columns are indexed when the table is created
+		if !idx.IsUnique() {
+			fmt.Fprintf(b, "CREATE INDEX ON %s(%s);\n", t.name, idx)
+		}
+	}
+
+	names := make([]string, 0, len(t.policies))
+	for name := range t.policies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(b, "%s;\n", t.policies[name])
+	}
+}
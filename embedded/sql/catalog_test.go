@@ -16,6 +16,7 @@ limitations under the License.
 package sql
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -31,13 +32,14 @@ func TestFromEmptyCatalog(t *testing.T) {
 	require.False(t, exists)
 
 	_, err := catalog.GetDatabaseByID(1)
-	require.Equal(t, ErrDatabaseDoesNotExist, err)
+	require.True(t, errors.Is(err, ErrDatabaseDoesNotExist))
 
 	_, err = catalog.GetDatabaseByName("db1")
-	require.Equal(t, ErrDatabaseDoesNotExist, err)
+	require.True(t, errors.Is(err, ErrDatabaseDoesNotExist))
+	require.Equal(t, "database does not exist: db1", err.Error())
 
 	_, err = catalog.GetTableByName("db1", "table1")
-	require.Equal(t, ErrDatabaseDoesNotExist, err)
+	require.True(t, errors.Is(err, ErrDatabaseDoesNotExist))
 
 	db, err := catalog.newDatabase("db1")
 	require.NoError(t, err)
@@ -54,36 +56,36 @@ func TestFromEmptyCatalog(t *testing.T) {
 	require.NoError(t, err)
 
 	_, err = catalog.newDatabase("db1")
-	require.Equal(t, ErrDatabaseAlreadyExists, err)
+	require.True(t, errors.Is(err, ErrDatabaseAlreadyExists))
 
 	exists = db.ExistTable("table1")
 	require.False(t, exists)
 
 	_, err = db.GetTableByID(1)
-	require.Equal(t, ErrTableDoesNotExist, err)
+	require.True(t, errors.Is(err, ErrTableDoesNotExist))
 
 	_, err = db.GetTableByName("table1")
-	require.Equal(t, ErrTableDoesNotExist, err)
+	require.True(t, errors.Is(err, ErrTableDoesNotExist))
 
-	_, err = db.newTable("", nil, "")
+	_, err = db.newTable("", nil, nil, nil)
 	require.Equal(t, ErrIllegalArguments, err)
 
-	_, err = db.newTable("table1", nil, "")
+	_, err = db.newTable("table1", nil, nil, nil)
 	require.Equal(t, ErrIllegalArguments, err)
 
-	_, err = db.newTable("table1", []*ColSpec{}, "")
+	_, err = db.newTable("table1", []*ColSpec{}, nil, nil)
 	require.Equal(t, ErrIllegalArguments, err)
 
-	_, err = db.newTable("table1", []*ColSpec{{colName: "id", colType: IntegerType}}, "")
+	_, err = db.newTable("table1", []*ColSpec{{colName: "id", colType: IntegerType}}, nil, nil)
 	require.Equal(t, ErrIllegalArguments, err)
 
-	_, err = db.newTable("table1", []*ColSpec{{colName: "id", colType: IntegerType}}, "id1")
+	_, err = db.newTable("table1", []*ColSpec{{colName: "id", colType: IntegerType}}, nil, []string{"id1"})
 	require.Equal(t, ErrInvalidPK, err)
 
-	_, err = db.newTable("table1", []*ColSpec{{colName: "id", colType: IntegerType}, {colName: "id", colType: IntegerType}}, "id")
+	_, err = db.newTable("table1", []*ColSpec{{colName: "id", colType: IntegerType}, {colName: "id", colType: IntegerType}}, nil, []string{"id"})
 	require.Equal(t, ErrDuplicatedColumn, err)
 
-	table, err := db.newTable("table1", []*ColSpec{{colName: "id", colType: IntegerType}, {colName: "title", colType: IntegerType}}, "id")
+	table, err := db.newTable("table1", []*ColSpec{{colName: "id", colType: IntegerType}, {colName: "title", colType: IntegerType}}, nil, []string{"id"})
 	require.NoError(t, err)
 	require.Equal(t, "table1", table.Name())
 
@@ -99,17 +101,17 @@ func TestFromEmptyCatalog(t *testing.T) {
 	require.NoError(t, err)
 
 	_, err = db.GetTableByID(2)
-	require.Equal(t, ErrTableDoesNotExist, err)
+	require.True(t, errors.Is(err, ErrTableDoesNotExist))
 
-	_, err = db.newTable("table1", []*ColSpec{{colName: "id", colType: IntegerType}, {colName: "title", colType: IntegerType}}, "id")
-	require.Equal(t, ErrTableAlreadyExists, err)
+	_, err = db.newTable("table1", []*ColSpec{{colName: "id", colType: IntegerType}, {colName: "title", colType: IntegerType}}, nil, []string{"id"})
+	require.True(t, errors.Is(err, ErrTableAlreadyExists))
 
 	indexed, err := table.IsIndexed("id")
 	require.NoError(t, err)
 	require.False(t, indexed)
 
 	_, err = table.IsIndexed("id1")
-	require.Equal(t, ErrColumnDoesNotExist, err)
+	require.True(t, errors.Is(err, ErrColumnDoesNotExist))
 
 	pk := table.PrimaryKey()
 	require.NotNil(t, pk)
@@ -125,5 +127,5 @@ func TestFromEmptyCatalog(t *testing.T) {
 	require.Equal(t, c.Name(), "title")
 
 	_, err = table.GetColumnByID(3)
-	require.Equal(t, ErrColumnDoesNotExist, err)
+	require.True(t, errors.Is(err, ErrColumnDoesNotExist))
 }
@@ -0,0 +1,181 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sql
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/codenotary/immudb/embedded/store"
+)
+
+// CheckSpec is a check constraint as it's given when the table is created, unnamed constraints get a name
+// made of the name of the table and their position
+type CheckSpec struct {
+	name string
+	exp  ValueExp
+}
+
+// Check is a condition every row of the table must satisfy when it's written. As in standard SQL,
+// rows are only rejected when the condition is false, thus null values satisfy it
+type Check struct {
+	table *Table
+	name  string
+	exp   ValueExp
+	cols  map[string]struct{} // columns referenced by the condition
+}
+
+func (c *Check) Name() string {
+	return c.name
+}
+
+// String returns the condition as it's given within the constraint
+func (c *Check) String() string {
+	exp, _ := formatExp(c.exp, nil, false)
+	return exp
+}
+
+func (t *Table) newCheck(name string, exp ValueExp) (*Check, error) {
+	_, exists := t.checks[name]
+	if exists {
+		return nil, fmt.Errorf("%w: %s", ErrCheckAlreadyExists, name)
+	}
+
+	c := &Check{
+		table: t,
+		name:  name,
+		exp:   exp,
+		cols:  make(map[string]struct{}),
+	}
+
+	// the condition is formatted to validate it can be persisted and re-parsed as it is
+	_, err := formatExp(exp, c.cols, false)
+	if errors.Is(err, ErrInvalidPolicyFilter) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCheck, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for colName := range c.cols {
+		_, err := t.GetColumnByName(colName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	t.checks[name] = c
+
+	return c, nil
+}
+
+// GetChecks returns the check constraints of the table sorted by name
+func (t *Table) GetChecks() []*Check {
+	checks := make([]*Check, 0, len(t.checks))
+	for _, c := range t.checks {
+		checks = append(checks, c)
+	}
+	sort.Slice(checks, func(i, j int) bool { return checks[i].name < checks[j].name })
+
+	return checks
+}
+
+// checkConstraints returns ErrCheckConstraintViolation unless the row values satisfy every check constraint of the table,
+// missing values are read as null
+func (e *Engine) checkConstraints(table *Table, values map[uint64]TypedValue) error {
+	if len(table.checks) == 0 {
+		return nil
+	}
+
+	row := tableRow(table, values)
+
+	for _, c := range table.GetChecks() {
+		r, err := c.exp.reduce(e.catalog, row, table.db.name, table.name)
+		if err != nil {
+			return err
+		}
+
+		if isNull(r) {
+			continue
+		}
+
+		satisfies, isBool := r.(*Bool)
+		if !isBool {
+			return fmt.Errorf("%w: %s", ErrInvalidCheck, c.name)
+		}
+
+		if !satisfies.val {
+			return fmt.Errorf("%w: %s", ErrCheckConstraintViolation, c.name)
+		}
+	}
+
+	return nil
+}
+
+func (e *Engine) checkEntry(c *Check, deleted bool) *store.KV {
+	kv := &store.KV{
+		Key:   e.mapKey(catalogCheckPrefix, EncodeID(c.table.db.id), EncodeID(c.table.id), []byte(c.name)),
+		Value: []byte(c.String()),
+	}
+
+	if deleted {
+		kv.Metadata = store.NewKVMetadata().AsDeleted(true)
+	}
+
+	return kv
+}
+
+func (e *Engine) loadChecks(table *Table, snap *store.Snapshot) error {
+	initialKey := e.mapKey(catalogCheckPrefix, EncodeID(table.db.id), EncodeID(table.id))
+
+	checkReader, err := snap.NewKeyReader(&store.KeyReaderSpec{
+		SeekKey: initialKey,
+		Prefix:  initialKey,
+	})
+	if err != nil {
+		return err
+	}
+	defer checkReader.Close()
+
+	for {
+		mkey, vref, _, _, err := checkReader.Read()
+		if err == store.ErrNoMoreEntries {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		v, err := vref.Resolve()
+		if err != nil {
+			return err
+		}
+
+		exp, err := parseCondition(string(v))
+		if err != nil {
+			return ErrCorruptedData
+		}
+
+		_, err = table.newCheck(string(mkey[len(initialKey):]), exp)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
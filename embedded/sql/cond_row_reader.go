@@ -21,6 +21,8 @@ type conditionalRowReader struct {
 	rowReader RowReader
 
 	condition ValueExp
+	// the condition with its parameters substituted, it's done once as the parameters don't change while reading rows
+	substitutedCond ValueExp
 
 	params map[string]interface{}
 }
@@ -57,18 +59,20 @@ func (cr *conditionalRowReader) Read() (*Row, error) {
 			return nil, err
 		}
 
-		cond, err := cr.condition.substitute(cr.params)
-		if err != nil {
-			return nil, err
+		if cr.substitutedCond == nil {
+			cr.substitutedCond, err = cr.condition.substitute(cr.params)
+			if err != nil {
+				return nil, err
+			}
 		}
 
-		r, err := cond.reduce(cr.e.catalog, row, cr.rowReader.ImplicitDB(), cr.rowReader.ImplicitTable())
+		r, err := cr.substitutedCond.reduce(cr.e.catalog, row, cr.rowReader.ImplicitDB(), cr.rowReader.ImplicitTable())
 		if err != nil {
 			return nil, err
 		}
 
-		nval, isNull := r.(*NullValue)
-		if isNull && nval.Type() == BooleanType {
+		// rows are filtered out when the condition is unknown
+		if isUnknown(r) {
 			continue
 		}
 
@@ -0,0 +1,150 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sql
+
+// distinctRowReader returns the rows of the underlying reader skipping the ones equal to a previous row,
+// so the order of the underlying reader is preserved. Rows are identified by the encoding of their values,
+// which is kept in memory for every returned row. Null values are taken as equal to each other
+type distinctRowReader struct {
+	rowReader RowReader
+
+	cols []*ColDescriptor
+
+	seen map[string]struct{}
+
+	limit uint64
+	read  uint64
+}
+
+func (e *Engine) newDistinctRowReader(rowReader RowReader, limit uint64) (*distinctRowReader, error) {
+	if rowReader == nil {
+		return nil, ErrIllegalArguments
+	}
+
+	cols, err := rowReader.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	return &distinctRowReader{
+		rowReader: rowReader,
+		cols:      cols,
+		seen:      make(map[string]struct{}),
+		limit:     limit,
+	}, nil
+}
+
+func (dr *distinctRowReader) ImplicitDB() string {
+	return dr.rowReader.ImplicitDB()
+}
+
+func (dr *distinctRowReader) ImplicitTable() string {
+	return dr.rowReader.ImplicitTable()
+}
+
+func (dr *distinctRowReader) Columns() ([]*ColDescriptor, error) {
+	return dr.rowReader.Columns()
+}
+
+func (dr *distinctRowReader) colsBySelector() (map[string]*ColDescriptor, error) {
+	return dr.rowReader.colsBySelector()
+}
+
+func (dr *distinctRowReader) Read() (*Row, error) {
+	if dr.limit > 0 && dr.read == dr.limit {
+		return nil, ErrNoMoreRows
+	}
+
+	for {
+		row, err := dr.rowReader.Read()
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := dr.rowKey(row)
+		if err != nil {
+			return nil, err
+		}
+
+		_, seen := dr.seen[key]
+		if seen {
+			continue
+		}
+
+		dr.seen[key] = struct{}{}
+		dr.read++
+
+		return row, nil
+	}
+}
+
+// rowKey encodes the values of the row in the order of the columns
+func (dr *distinctRowReader) rowKey(row *Row) (string, error) {
+	var key []byte
+
+	for _, col := range dr.cols {
+		val, ok := row.Values[col.Selector]
+		if !ok {
+			return "", ErrColumnDoesNotExist
+		}
+
+		val = distinctValue(val)
+
+		if isNull(val) {
+			key = append(key, 0)
+			continue
+		}
+
+		encVal, err := EncodeValue(val, val.Type(), false)
+		if err != nil {
+			return "", err
+		}
+
+		key = append(key, 1)
+		key = append(key, encVal...)
+	}
+
+	return string(key), nil
+}
+
+// distinctValue returns the value held by an aggregated value, so it can be encoded as any other value
+func distinctValue(val TypedValue) TypedValue {
+	switch v := val.(type) {
+	case *MinValue:
+		if v.val == nil {
+			return &NullValue{}
+		}
+		return v.val
+	case *MaxValue:
+		if v.val == nil {
+			return &NullValue{}
+		}
+		return v.val
+	case AggregatedValue:
+		if v.Type() == FloatType {
+			return &Float{val: v.Value().(float64)}
+		}
+
+		return &Number{val: v.Value().(uint64)}
+	}
+
+	return val
+}
+
+func (dr *distinctRowReader) Close() error {
+	return dr.rowReader.Close()
+}
@@ -17,12 +17,15 @@ package sql
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
 	"errors"
+	"fmt"
 	"io"
 	"math"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/codenotary/immudb/embedded/store"
 	"github.com/codenotary/immudb/embedded/tbtree"
@@ -38,10 +41,15 @@ var ErrTableAlreadyExists = errors.New("table already exists")
 var ErrTableDoesNotExist = errors.New("table does not exist")
 var ErrColumnDoesNotExist = errors.New("column does not exist")
 var ErrColumnNotIndexed = errors.New("column is not indexed")
+var ErrColumnAlreadyExists = errors.New("column already exists")
+var ErrNewColumnMustBeNullable = errors.New("new column must be nullable")
+var ErrInvalidDefault = errors.New("invalid default value")
+var ErrLimitedDefault = errors.New("default values can only be given when the table is created")
 var ErrInvalidPK = errors.New("primary key of invalid type. Supported types are: INTEGER, STRING[256], TIMESTAMP OR BLOB[256]")
 var ErrDuplicatedColumn = errors.New("duplicated column")
 var ErrInvalidColumn = errors.New("invalid column")
 var ErrPKCanNotBeNull = errors.New("primary key can not be null")
+var ErrPKCanNotBeUpdated = errors.New("primary key can not be updated")
 var ErrNotNullableColumnCannotBeNull = errors.New("not nullable column can not be null")
 var ErrIndexedColumnCanNotBeNull = errors.New("indexed column can not be null")
 var ErrIndexAlreadyExists = errors.New("index already exists")
@@ -62,13 +70,43 @@ var ErrNotComparableValues = errors.New("values are not comparable")
 var ErrUnexpected = errors.New("unexpected error")
 var ErrMaxKeyLengthExceeded = errors.New("max key length exceeded")
 var ErrColumnIsNotAnAggregation = errors.New("column is not an aggregation")
-var ErrLimitedCount = errors.New("only unbounded counting is supported i.e. COUNT()")
+var ErrLimitedCount = errors.New("only unbounded or distinct counting is supported i.e. COUNT() or COUNT(DISTINCT col)")
 var ErrTxDoesNotExist = errors.New("tx does not exist")
 var ErrDivisionByZero = errors.New("division by zero")
 var ErrMissingParameter = errors.New("missing paramter")
 var ErrUnsupportedParameter = errors.New("unsupported parameter")
 var ErrLimitedIndex = errors.New("index creation is only supported on empty tables")
 var ErrAlreadyClosed = errors.New("sql engine already closed")
+var ErrPolicyAlreadyExists = errors.New("policy already exists")
+var ErrPolicyDoesNotExist = errors.New("policy does not exist")
+var ErrInvalidPolicyFilter = errors.New("invalid policy filter. Only literals, functions and unqualified columns of the table are supported")
+var ErrPolicyChangeNotAllowed = errors.New("policies can not be changed within a user session")
+var ErrPolicyViolation = errors.New("row does not satisfy the policies of the table")
+var ErrColumnInUseByPolicy = errors.New("column is used by a policy")
+var ErrCheckAlreadyExists = errors.New("check constraint already exists")
+var ErrInvalidCheck = errors.New("invalid check constraint. Only boolean conditions made of literals, functions and unqualified columns of the table are supported")
+var ErrCheckConstraintViolation = errors.New("check constraint violation")
+var ErrColumnInUseByCheck = errors.New("column is used by a check constraint")
+var ErrLimitedSubqueries = errors.New("subqueries are limited to WHERE and HAVING clauses")
+var ErrSubqueryColumns = errors.New("subquery must return a single column")
+var ErrSubqueryRows = errors.New("subquery used as an expression returned more than one row")
+var ErrInvalidJSON = errors.New("invalid JSON value")
+var ErrInvalidJSONPath = errors.New("invalid JSON path")
+var ErrExpectingJSONColumn = errors.New("illegal extraction. JSON column expected")
+var ErrNonIndexableType = errors.New("columns of this type can not be indexed")
+var ErrLimitedAutoIncrement = errors.New("only INTEGER primary keys can be auto incremental")
+var ErrUniqueConstraintViolation = errors.New("duplicated value violates unique constraint")
+var ErrInvalidPattern = errors.New("invalid LIKE pattern")
+var ErrInvalidCaseTypes = errors.New("results of CASE expression must be of the same type")
+var ErrFunctionDoesNotExist = errors.New("function does not exist")
+var ErrInvalidFunctionParams = errors.New("invalid function parameters")
+var ErrUnsupportedCast = errors.New("unsupported conversion between types")
+var ErrViewAlreadyExists = errors.New("view already exists")
+var ErrViewDoesNotExist = errors.New("view does not exist")
+var ErrRecursiveView = errors.New("view can not read itself")
+var ErrSessionTxStmt = errors.New("BEGIN, COMMIT and ROLLBACK statements must be handled by the session executing them")
+var ErrLimitedIndexOrder = errors.New("only the columns following the leading one can be sorted in descending order within an index")
+var ErrInferredMultipleTypes = errors.New("inferred multiple types for the same parameter")
 
 var mKeyVal = [32]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
 
@@ -77,6 +115,22 @@ const asKey = true
 const EncIDLen = 8
 const EncLenLen = 4
 
+// DefaultMaxGroupsInMemory is the number of groups kept in memory by GROUP BY queries before spilling to disk
+const DefaultMaxGroupsInMemory = 100000
+
+// DefaultSortBufferSize is the amount of bytes of rows sorted in memory by ORDER BY queries before spilling to disk
+const DefaultSortBufferSize = 32 << 20
+
+// IsolationLevel determines which committed data is visible to queries
+type IsolationLevel int
+
+const (
+	// SnapshotIsolation queries read from the snapshot in use, it's only renewed when explicitly requested
+	SnapshotIsolation IsolationLevel = iota
+	// ReadCommitted queries read every tx committed before they start, thus the snapshot gets renewed on every query
+	ReadCommitted
+)
+
 type Engine struct {
 	catalogStore *store.ImmuStore
 	dataStore    *store.ImmuStore
@@ -92,9 +146,17 @@ type Engine struct {
 	snapshot       *store.Snapshot
 	snapAsBeforeTx uint64
 
+	isolationLevel IsolationLevel
+
+	maxGroupsInMem int
+	sortBufferSize int
+
 	closed bool
 
 	mutex sync.Mutex
+
+	// sequences of auto incremental primary keys are assigned one statement at a time
+	seqMutex sync.Mutex
 }
 
 func NewEngine(catalogStore, dataStore *store.ImmuStore, prefix []byte) (*Engine, error) {
@@ -103,9 +165,11 @@ func NewEngine(catalogStore, dataStore *store.ImmuStore, prefix []byte) (*Engine
 	}
 
 	e := &Engine{
-		catalogStore: catalogStore,
-		dataStore:    dataStore,
-		prefix:       make([]byte, len(prefix)),
+		catalogStore:   catalogStore,
+		dataStore:      dataStore,
+		prefix:         make([]byte, len(prefix)),
+		maxGroupsInMem: DefaultMaxGroupsInMemory,
+		sortBufferSize: DefaultSortBufferSize,
 	}
 
 	copy(e.prefix, prefix)
@@ -122,7 +186,7 @@ func (e *Engine) loadCatalog() error {
 	e.catalog = nil
 
 	lastTxID, _ := e.catalogStore.Alh()
-	err := e.catalogStore.WaitForIndexingUpto(lastTxID, nil)
+	err := e.catalogStore.WaitForIndexingUpto(context.Background(), lastTxID)
 	if err != nil {
 		return err
 	}
@@ -215,7 +279,7 @@ func (e *Engine) useSnapshot(sinceTx uint64, asBeforeTx uint64) error {
 		return ErrTxDoesNotExist
 	}
 
-	err := e.dataStore.WaitForIndexingUpto(sinceTx, nil)
+	err := e.dataStore.WaitForIndexingUpto(context.Background(), sinceTx)
 	if err != nil {
 		return err
 	}
@@ -243,6 +307,68 @@ func (e *Engine) useSnapshot(sinceTx uint64, asBeforeTx uint64) error {
 	return nil
 }
 
+// SetMaxGroupsInMemory sets the number of groups kept in memory while evaluating a GROUP BY query,
+// groups exceeding the limit get spilled into temporary files
+func (e *Engine) SetMaxGroupsInMemory(limit int) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if e.closed {
+		return ErrAlreadyClosed
+	}
+
+	if limit < 1 {
+		return ErrIllegalArguments
+	}
+
+	e.maxGroupsInMem = limit
+
+	return nil
+}
+
+// SetSortBufferSize sets the memory budget, in bytes, of rows sorted in memory while evaluating an ORDER BY query,
+// rows exceeding the budget get sorted in temporary files
+func (e *Engine) SetSortBufferSize(size int) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if e.closed {
+		return ErrAlreadyClosed
+	}
+
+	if size < 1 {
+		return ErrIllegalArguments
+	}
+
+	e.sortBufferSize = size
+
+	return nil
+}
+
+func (e *Engine) SetIsolationLevel(level IsolationLevel) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if e.closed {
+		return ErrAlreadyClosed
+	}
+
+	if level != SnapshotIsolation && level != ReadCommitted {
+		return ErrIllegalArguments
+	}
+
+	e.isolationLevel = level
+
+	return nil
+}
+
+func (e *Engine) IsolationLevel() IsolationLevel {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	return e.isolationLevel
+}
+
 func (e *Engine) Snapshot() (*store.Snapshot, error) {
 	e.mutex.Lock()
 	defer e.mutex.Unlock()
@@ -269,11 +395,18 @@ func (e *Engine) RenewSnapshot() error {
 		return ErrAlreadyClosed
 	}
 
+	var sinceTx uint64
+
+	if e.isolationLevel == ReadCommitted {
+		// renewed snapshot must include every committed tx
+		sinceTx, _ = e.dataStore.Alh()
+	}
+
 	if e.snapshot == nil {
-		return e.useSnapshot(0, 0)
+		return e.useSnapshot(sinceTx, 0)
 	}
 
-	return e.useSnapshot(0, e.snapAsBeforeTx)
+	return e.useSnapshot(sinceTx, e.snapAsBeforeTx)
 }
 
 func (e *Engine) CloseSnapshot() error {
@@ -340,6 +473,11 @@ func (e *Engine) catalogFrom(snap *store.Snapshot) (*Catalog, error) {
 		if err != nil {
 			return nil, err
 		}
+
+		err = e.loadViews(db, snap)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return catalog, nil
@@ -349,8 +487,9 @@ func (e *Engine) loadTables(db *Database, snap *store.Snapshot) error {
 	initialKey := e.mapKey(catalogTablePrefix, EncodeID(db.id))
 
 	dbReaderSpec := &store.KeyReaderSpec{
-		SeekKey: initialKey,
-		Prefix:  initialKey,
+		SeekKey:        initialKey,
+		Prefix:         initialKey,
+		IncludeDeleted: true,
 	}
 
 	tableReader, err := snap.NewKeyReader(dbReaderSpec)
@@ -368,18 +507,24 @@ func (e *Engine) loadTables(db *Database, snap *store.Snapshot) error {
 			return err
 		}
 
-		_, tableID, pkID, err := e.unmapTableID(mkey)
+		_, tableID, pkIDs, err := e.unmapTableID(mkey)
 		if err != nil {
 			return err
 		}
 
-		colSpecs, pkName, err := e.loadColSpecs(db.id, tableID, pkID, snap)
-		if err != nil {
-			return err
+		// dropped tables are read only to prevent their ids from being reused
+		if vref.KVMetadata().Deleted() {
+			if tableID != db.maxTableID+1 {
+				return ErrCorruptedData
+			}
+
+			db.maxTableID = tableID
+			continue
 		}
 
-		if len(colSpecs) < int(pkID) {
-			return ErrCorruptedData
+		colSpecs, pkNames, err := e.loadColSpecs(db.id, tableID, pkIDs, snap)
+		if err != nil {
+			return err
 		}
 
 		v, err := vref.Resolve()
@@ -387,7 +532,7 @@ func (e *Engine) loadTables(db *Database, snap *store.Snapshot) error {
 			return err
 		}
 
-		table, err := db.newTable(string(v), colSpecs, pkName)
+		table, err := db.newTable(string(v), colSpecs, nil, pkNames)
 		if err != nil {
 			return err
 		}
@@ -396,20 +541,26 @@ func (e *Engine) loadTables(db *Database, snap *store.Snapshot) error {
 			return ErrCorruptedData
 		}
 
-		indexes, err := e.loadIndexes(db.id, tableID, snap)
+		err = e.loadIndexes(table, snap)
 		if err != nil {
 			return err
 		}
 
-		for _, colID := range indexes {
-			table.indexes[colID] = struct{}{}
+		err = e.loadPolicies(table, snap)
+		if err != nil {
+			return err
+		}
+
+		err = e.loadChecks(table, snap)
+		if err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
-func (e *Engine) loadColSpecs(dbID, tableID, pkID uint64, snap *store.Snapshot) (specs []*ColSpec, pkName string, err error) {
+func (e *Engine) loadColSpecs(dbID, tableID uint64, pkIDs []uint64, snap *store.Snapshot) (specs []*ColSpec, pkNames []string, err error) {
 	initialKey := e.mapKey(catalogColumnPrefix, EncodeID(dbID), EncodeID(tableID))
 
 	dbReaderSpec := &store.KeyReaderSpec{
@@ -419,59 +570,82 @@ func (e *Engine) loadColSpecs(dbID, tableID, pkID uint64, snap *store.Snapshot)
 
 	colSpecReader, err := snap.NewKeyReader(dbReaderSpec)
 	if err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
 	defer colSpecReader.Close()
 
 	specs = make([]*ColSpec, 0)
 
-	pkFound := false
-
 	for {
 		mkey, vref, _, _, err := colSpecReader.Read()
 		if err == store.ErrNoMoreEntries {
 			break
 		}
 		if err != nil {
-			return nil, "", err
+			return nil, nil, err
 		}
 
 		_, _, colID, colType, err := e.unmapColSpec(mkey)
 		if err != nil {
-			return nil, "", err
+			return nil, nil, err
 		}
 
 		v, err := vref.Resolve()
 		if err != nil {
-			return nil, "", err
+			return nil, nil, err
 		}
 		if len(v) < 1 {
-			return nil, "", ErrCorruptedData
+			return nil, nil, ErrCorruptedData
+		}
+
+		spec := &ColSpec{
+			colName:       string(v[1:]),
+			colType:       colType,
+			autoIncrement: v[0]&autoIncrementFlag != 0,
+			notNull:       v[0]&notNullFlag != 0,
 		}
 
-		spec := &ColSpec{colName: string(v[1:]), colType: colType, notNull: v[0] == 1}
+		if v[0]&defaultValueFlag != 0 {
+			if len(v) < 1+EncLenLen {
+				return nil, nil, ErrCorruptedData
+			}
+
+			nameLen := binary.BigEndian.Uint32(v[1:])
+			if uint32(len(v)-1-EncLenLen) < nameLen {
+				return nil, nil, ErrCorruptedData
+			}
+
+			spec.colName = string(v[1+EncLenLen : 1+EncLenLen+nameLen])
+
+			spec.defaultValue, err = parseRowValue(string(v[1+EncLenLen+nameLen:]))
+			if err != nil {
+				return nil, nil, ErrCorruptedData
+			}
+		}
 
 		specs = append(specs, spec)
 
 		if int(colID) != len(specs) {
-			return nil, "", ErrCorruptedData
+			return nil, nil, ErrCorruptedData
 		}
 
-		if colID == pkID {
-			pkName = spec.colName
-			pkFound = true
-		}
 	}
 
-	if !pkFound {
-		return nil, "", ErrCorruptedData
+	pkNames = make([]string, len(pkIDs))
+
+	for i, pkID := range pkIDs {
+		if pkID == 0 || pkID > uint64(len(specs)) {
+			return nil, nil, ErrCorruptedData
+		}
+
+		pkNames[i] = specs[pkID-1].colName
 	}
 
 	return
 }
 
-func (e *Engine) loadIndexes(dbID, tableID uint64, snap *store.Snapshot) ([]uint64, error) {
-	initialKey := e.mapKey(catalogIndexPrefix, EncodeID(dbID), EncodeID(tableID))
+func (e *Engine) loadIndexes(table *Table, snap *store.Snapshot) error {
+	initialKey := e.mapKey(catalogIndexPrefix, EncodeID(table.db.id), EncodeID(table.id))
 
 	idxReaderSpec := &store.KeyReaderSpec{
 		SeekKey: initialKey,
@@ -480,30 +654,156 @@ func (e *Engine) loadIndexes(dbID, tableID uint64, snap *store.Snapshot) ([]uint
 
 	idxSpecReader, err := snap.NewKeyReader(idxReaderSpec)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer idxSpecReader.Close()
 
-	indexes := make([]uint64, 0)
-
 	for {
 		mkey, _, _, _, err := idxSpecReader.Read()
 		if err == store.ErrNoMoreEntries {
 			break
 		}
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		_, _, colID, err := e.unmapIndex(mkey)
+		_, _, colIDs, desc, unique, err := e.unmapIndex(mkey)
 		if err != nil {
-			return nil, err
+			return err
+		}
+
+		specs := make([]*IndexColSpec, len(colIDs))
+
+		for i, colID := range colIDs {
+			col, err := table.GetColumnByID(colID)
+			if err != nil {
+				return ErrCorruptedData
+			}
+
+			specs[i] = &IndexColSpec{colName: col.colName, desc: desc[i]}
+		}
+
+		// indexes on unique columns are already created along with the table
+		_, exists := table.indexes[colIDs[0]]
+		if exists {
+			continue
 		}
 
-		indexes = append(indexes, colID)
+		_, err = table.newIndex(specs)
+		if err != nil {
+			return err
+		}
+
+		if unique {
+			table.uniques[colIDs[0]] = struct{}{}
+		}
 	}
 
-	return indexes, nil
+	return nil
+}
+
+func (e *Engine) loadPolicies(table *Table, snap *store.Snapshot) error {
+	initialKey := e.mapKey(catalogPolicyPrefix, EncodeID(table.db.id), EncodeID(table.id))
+
+	policyReaderSpec := &store.KeyReaderSpec{
+		SeekKey: initialKey,
+		Prefix:  initialKey,
+	}
+
+	policyReader, err := snap.NewKeyReader(policyReaderSpec)
+	if err != nil {
+		return err
+	}
+	defer policyReader.Close()
+
+	for {
+		_, vref, _, _, err := policyReader.Read()
+		if err == store.ErrNoMoreEntries {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		v, err := vref.Resolve()
+		if err != nil {
+			return err
+		}
+
+		stmts, err := ParseString(string(v))
+		if err != nil {
+			return ErrCorruptedData
+		}
+
+		if len(stmts) != 1 {
+			return ErrCorruptedData
+		}
+
+		stmt, ok := stmts[0].(*CreatePolicyStmt)
+		if !ok || stmt.table != table.name {
+			return ErrCorruptedData
+		}
+
+		_, err = table.newPolicy(stmt.name, stmt.users, stmt.filter)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadViews loads the views of the database, which are not checked against the tables nor the views they read
+// as they may have been dropped in the meantime
+func (e *Engine) loadViews(db *Database, snap *store.Snapshot) error {
+	initialKey := e.mapKey(catalogViewPrefix, EncodeID(db.id))
+
+	viewReaderSpec := &store.KeyReaderSpec{
+		SeekKey: initialKey,
+		Prefix:  initialKey,
+	}
+
+	viewReader, err := snap.NewKeyReader(viewReaderSpec)
+	if err != nil {
+		return err
+	}
+	defer viewReader.Close()
+
+	for {
+		_, vref, _, _, err := viewReader.Read()
+		if err == store.ErrNoMoreEntries {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		v, err := vref.Resolve()
+		if err != nil {
+			return err
+		}
+
+		stmts, err := ParseString(string(v))
+		if err != nil {
+			return ErrCorruptedData
+		}
+
+		if len(stmts) != 1 {
+			return ErrCorruptedData
+		}
+
+		stmt, ok := stmts[0].(*CreateViewStmt)
+		if !ok {
+			return ErrCorruptedData
+		}
+
+		_, err = db.newView(stmt.view, stmt.query, stmt.sql)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func (e *Engine) trimPrefix(mkey []byte, mappingPrefix []byte) ([]byte, error) {
@@ -529,19 +829,22 @@ func (e *Engine) unmapDatabaseID(mkey []byte) (dbID uint64, err error) {
 	return binary.BigEndian.Uint64(encID), nil
 }
 
-func (e *Engine) unmapTableID(mkey []byte) (dbID, tableID, pkID uint64, err error) {
+func (e *Engine) unmapTableID(mkey []byte) (dbID, tableID uint64, pkIDs []uint64, err error) {
 	encID, err := e.trimPrefix(mkey, []byte(catalogTablePrefix))
 	if err != nil {
-		return 0, 0, 0, err
+		return 0, 0, nil, err
 	}
 
-	if len(encID) != EncIDLen*3 {
-		return 0, 0, 0, ErrCorruptedData
+	if len(encID) < EncIDLen*3 || len(encID)%EncIDLen != 0 {
+		return 0, 0, nil, ErrCorruptedData
 	}
 
 	dbID = binary.BigEndian.Uint64(encID)
 	tableID = binary.BigEndian.Uint64(encID[EncIDLen:])
-	pkID = binary.BigEndian.Uint64(encID[2*EncIDLen:])
+
+	for off := 2 * EncIDLen; off < len(encID); off += EncIDLen {
+		pkIDs = append(pkIDs, binary.BigEndian.Uint64(encID[off:]))
+	}
 
 	return
 }
@@ -570,81 +873,70 @@ func (e *Engine) unmapColSpec(mkey []byte) (dbID, tableID, colID uint64, colType
 
 func asType(t string) (SQLValueType, error) {
 	if t == IntegerType ||
+		t == FloatType ||
 		t == BooleanType ||
 		t == VarcharType ||
 		t == BLOBType ||
-		t == TimestampType {
+		t == TimestampType ||
+		t == JSONType {
 		return t, nil
 	}
 
 	return t, ErrCorruptedData
 }
 
-func (e *Engine) unmapIndex(mkey []byte) (dbID, tableID, colID uint64, err error) {
+func (e *Engine) unmapIndex(mkey []byte) (dbID, tableID uint64, colIDs []uint64, desc []bool, unique bool, err error) {
 	encID, err := e.trimPrefix(mkey, []byte(catalogIndexPrefix))
 	if err != nil {
-		return 0, 0, 0, err
+		return 0, 0, nil, nil, false, err
 	}
 
 	if len(encID) < EncIDLen*3 {
-		return 0, 0, 0, ErrCorruptedData
+		return 0, 0, nil, nil, false, ErrCorruptedData
 	}
 
 	dbID = binary.BigEndian.Uint64(encID)
 	tableID = binary.BigEndian.Uint64(encID[EncIDLen:])
-	colID = binary.BigEndian.Uint64(encID[2*EncIDLen:])
+	colIDs = []uint64{binary.BigEndian.Uint64(encID[2*EncIDLen:])}
+	desc = []bool{false}
+	unique = len(encID) > EncIDLen*3 && encID[3*EncIDLen]&uniqueIndexFlag != 0
 
-	return
-}
+	// the following columns of multi-column indexes are flagged one by one
+	off := 3*EncIDLen + 1
 
-func (e *Engine) unmapIndexedRow(mkey []byte) (dbID, tableID, colID uint64, encVal, encPKVal []byte, err error) {
-	enc, err := e.trimPrefix(mkey, []byte(RowPrefix))
-	if err != nil {
-		return 0, 0, 0, nil, nil, err
+	if len(encID) > off && (len(encID)-off)%(EncIDLen+1) != 0 {
+		return 0, 0, nil, nil, false, ErrCorruptedData
 	}
 
-	if len(enc) < EncIDLen*3+2*EncLenLen {
-		return 0, 0, 0, nil, nil, ErrCorruptedData
+	for ; off < len(encID); off += EncIDLen + 1 {
+		colIDs = append(colIDs, binary.BigEndian.Uint64(encID[off:]))
+		desc = append(desc, encID[off+EncIDLen]&descIndexFlag != 0)
 	}
 
-	off := 0
-
-	dbID = binary.BigEndian.Uint64(enc[off:])
-	off += EncIDLen
-
-	tableID = binary.BigEndian.Uint64(enc[off:])
-	off += EncIDLen
-
-	colID = binary.BigEndian.Uint64(enc[off:])
-	off += EncIDLen
+	return
+}
 
-	//read index value
-	valLen := int(binary.BigEndian.Uint32(enc[off:]))
-	off += EncLenLen
+// unmapIndexedRow returns the encoded values of the indexed columns and the encoded primary key of the row
+// referenced by the entry of the index
+func (e *Engine) unmapIndexedRow(idx *Index, mkey []byte) (encVals, encPKVal []byte, err error) {
+	prefix := e.mapKey(RowPrefix, EncodeID(idx.table.db.id), EncodeID(idx.table.id), EncodeID(idx.id()))
 
-	if len(enc)-off < valLen+EncLenLen {
-		return 0, 0, 0, nil, nil, ErrCorruptedData
+	if !bytes.HasPrefix(mkey, prefix) {
+		return nil, nil, ErrCorruptedData
 	}
 
-	encVal = make([]byte, EncLenLen+valLen)
-	binary.BigEndian.PutUint32(encVal, uint32(valLen))
-	copy(encVal[EncLenLen:], enc[off:off+valLen])
-	off += int(valLen)
+	enc := mkey[len(prefix):]
 
-	// read encPKVal
-	pkValLen := int(binary.BigEndian.Uint32(enc[off:]))
-	off += EncLenLen
-
-	if len(enc)-off != pkValLen {
-		return 0, 0, 0, nil, nil, ErrCorruptedData
+	valsLen, err := idx.encodedLen(enc)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	encPKVal = make([]byte, EncLenLen+pkValLen)
-	binary.BigEndian.PutUint32(encPKVal, uint32(pkValLen))
-	copy(encPKVal[EncLenLen:], enc[off:])
-	off += len(encPKVal)
+	if len(enc) == valsLen {
+		return nil, nil, ErrCorruptedData
+	}
 
-	return
+	return enc[:valsLen], enc[valsLen:], nil
 }
 
 func (e *Engine) mapKey(mappingPrefix string, encValues ...[]byte) []byte {
@@ -684,7 +976,7 @@ func EncodeID(id uint64) []byte {
 
 func maxKeyVal(colType SQLValueType) []byte {
 	switch colType {
-	case IntegerType:
+	case IntegerType, FloatType, TimestampType:
 		{
 			return mKeyVal[:EncIDLen]
 		}
@@ -726,6 +1018,24 @@ func EncodeRawValue(val interface{}, colType SQLValueType, asKey bool) ([]byte,
 
 			return encv[:], nil
 		}
+	case FloatType:
+		{
+			floatVal, ok := val.(float64)
+			if !ok {
+				return nil, ErrInvalidValue
+			}
+
+			return encodeFloat(floatVal), nil
+		}
+	case TimestampType:
+		{
+			timeVal, ok := val.(time.Time)
+			if !ok {
+				return nil, ErrInvalidValue
+			}
+
+			return encodeTimestamp(newTimestamp(timeVal).val), nil
+		}
 	case BooleanType:
 		{
 			boolVal, ok := val.(bool)
@@ -765,11 +1075,21 @@ func EncodeRawValue(val interface{}, colType SQLValueType, asKey bool) ([]byte,
 
 			return encv[:], nil
 		}
-	}
+	case JSONType:
+		{
+			strVal, ok := val.(string)
+			if !ok {
+				return nil, ErrInvalidValue
+			}
 
-	/*
-		time
-	*/
+			jsonVal, err := parseJSON(strVal)
+			if err != nil {
+				return nil, err
+			}
+
+			return EncodeValue(jsonVal, JSONType, asKey)
+		}
+	}
 
 	return nil, ErrInvalidValue
 }
@@ -808,6 +1128,37 @@ func EncodeValue(val TypedValue, colType SQLValueType, asKey bool) ([]byte, erro
 
 			return encv[:], nil
 		}
+	case FloatType:
+		{
+			// integer values are accepted in float columns
+			switch v := val.(type) {
+			case *Float:
+				return encodeFloat(v.val), nil
+			case *Number:
+				return encodeFloat(float64(v.val)), nil
+			}
+
+			return nil, ErrInvalidValue
+		}
+	case TimestampType:
+		{
+			// strings are accepted in timestamp columns as long as they can be parsed
+			switch v := val.(type) {
+			case *Timestamp:
+				return encodeTimestamp(v.val), nil
+			case *Varchar:
+				{
+					ts, err := parseTimestamp(v.val)
+					if err != nil {
+						return nil, err
+					}
+
+					return encodeTimestamp(ts.val), nil
+				}
+			}
+
+			return nil, ErrInvalidValue
+		}
 	case BooleanType:
 		{
 			boolVal, ok := val.(*Bool)
@@ -842,15 +1193,92 @@ func EncodeValue(val TypedValue, colType SQLValueType, asKey bool) ([]byte, erro
 
 			return encv[:], nil
 		}
-	}
+	case JSONType:
+		{
+			if asKey {
+				return nil, ErrNonIndexableType
+			}
+
+			// strings are accepted in JSON columns as long as they hold a valid document
+			var jsonVal *JSON
 
-	/*
-		time
-	*/
+			switch v := val.(type) {
+			case *JSON:
+				jsonVal = v
+			case *Varchar:
+				{
+					jv, err := parseJSON(v.val)
+					if err != nil {
+						return nil, err
+					}
+
+					jsonVal = jv
+				}
+			default:
+				return nil, ErrInvalidValue
+			}
+
+			// len(v) + v
+			encv := make([]byte, EncLenLen+len(jsonVal.val))
+			binary.BigEndian.PutUint32(encv[:], uint32(len(jsonVal.val)))
+			copy(encv[EncLenLen:], []byte(jsonVal.val))
+
+			return encv, nil
+		}
+	}
 
 	return nil, ErrInvalidValue
 }
 
+// encodeFloat encodes the value so the order of encoded values matches the order of the values,
+// the sign bit is flipped for positive values while every bit is flipped for negative ones
+func encodeFloat(v float64) []byte {
+	bits := math.Float64bits(v)
+
+	if v < 0 {
+		bits = ^bits
+	} else {
+		// negative zero is encoded as zero
+		bits = math.Float64bits(math.Abs(v)) | 1<<63
+	}
+
+	// len(v) + v
+	var encv [EncLenLen + EncIDLen]byte
+	binary.BigEndian.PutUint32(encv[:], uint32(EncIDLen))
+	binary.BigEndian.PutUint64(encv[EncLenLen:], bits)
+
+	return encv[:]
+}
+
+func decodeFloat(b []byte) float64 {
+	bits := binary.BigEndian.Uint64(b)
+
+	if bits&(1<<63) != 0 {
+		return math.Float64frombits(bits &^ (1 << 63))
+	}
+
+	return math.Float64frombits(^bits)
+}
+
+// encodeTimestamp encodes the number of microseconds since the unix epoch (in UTC), the sign bit is flipped
+// so the order of encoded values matches the order of the timestamps
+func encodeTimestamp(t time.Time) []byte {
+	micros := t.Unix()*1e6 + int64(t.Nanosecond()/1e3)
+
+	// len(v) + v
+	var encv [EncLenLen + EncIDLen]byte
+	binary.BigEndian.PutUint32(encv[:], uint32(EncIDLen))
+	binary.BigEndian.PutUint64(encv[EncLenLen:], uint64(micros)^(1<<63))
+
+	return encv[:]
+}
+
+func decodeTimestamp(b []byte) time.Time {
+	micros := int64(binary.BigEndian.Uint64(b) ^ (1 << 63))
+
+	return time.Unix(micros/1e6, (micros%1e6)*1e3).UTC()
+}
+
 func DecodeValue(b []byte, colType SQLValueType) (TypedValue, int, error) {
 	if len(b) < EncLenLen {
 		return nil, 0, ErrCorruptedData
@@ -878,6 +1306,28 @@ func DecodeValue(b []byte, colType SQLValueType) (TypedValue, int, error) {
 
 			return &Number{val: v}, voff, nil
 		}
+	case FloatType:
+		{
+			if vlen != EncIDLen {
+				return nil, 0, ErrCorruptedData
+			}
+
+			v := decodeFloat(b[voff : voff+vlen])
+			voff += vlen
+
+			return &Float{val: v}, voff, nil
+		}
+	case TimestampType:
+		{
+			if vlen != EncIDLen {
+				return nil, 0, ErrCorruptedData
+			}
+
+			v := decodeTimestamp(b[voff : voff+vlen])
+			voff += vlen
+
+			return &Timestamp{val: v}, voff, nil
+		}
 	case BooleanType:
 		{
 			v := b[voff] == 1
@@ -892,6 +1342,13 @@ func DecodeValue(b []byte, colType SQLValueType) (TypedValue, int, error) {
 
 			return &Blob{val: v}, voff, nil
 		}
+	case JSONType:
+		{
+			v := string(b[voff : voff+vlen])
+			voff += vlen
+
+			return &JSON{val: v}, voff, nil
+		}
 	}
 
 	return nil, 0, ErrCorruptedData
@@ -927,15 +1384,16 @@ func (e *Engine) Query(sql io.ByteReader, params map[string]interface{}, renewSn
 		return nil, ErrExpectingDQLStmt
 	}
 
-	return e.QueryPreparedStmt(stmt, params, renewSnapshot)
+	return e.QueryPreparedStmt(context.Background(), stmt, params, renewSnapshot)
 }
 
-func (e *Engine) QueryPreparedStmt(stmt *SelectStmt, params map[string]interface{}, renewSnapshot bool) (RowReader, error) {
-	if stmt == nil {
+// QueryPreparedStmt resolves the query, rows are not read once the context gets done
+func (e *Engine) QueryPreparedStmt(ctx context.Context, stmt *SelectStmt, params map[string]interface{}, renewSnapshot bool) (RowReader, error) {
+	if ctx == nil || stmt == nil {
 		return nil, ErrIllegalArguments
 	}
 
-	if renewSnapshot {
+	if renewSnapshot || e.IsolationLevel() == ReadCommitted {
 		err := e.RenewSnapshot()
 		if err != nil && err != tbtree.ErrReadersNotClosed {
 			return nil, err
@@ -952,12 +1410,12 @@ func (e *Engine) QueryPreparedStmt(stmt *SelectStmt, params map[string]interface
 		return nil, err
 	}
 
-	_, _, _, err = stmt.CompileUsing(e, implicitDB, params)
+	_, _, _, err = stmt.CompileUsing(ctx, e, implicitDB, params)
 	if err != nil {
 		return nil, err
 	}
 
-	return stmt.Resolve(e, implicitDB, snapshot, params, nil)
+	return stmt.Resolve(ctx, e, implicitDB, snapshot, params, nil)
 }
 
 func (e *Engine) ExecStmt(sql string, params map[string]interface{}, waitForIndexing bool) (ddTxs, dmTxs []*store.TxMetadata, err error) {
@@ -977,10 +1435,35 @@ func (e *Engine) Exec(sql io.ByteReader, params map[string]interface{}, waitForI
 		return nil, nil, err
 	}
 
-	return e.ExecPreparedStmts(stmts, params, waitForIndexing)
+	return e.ExecPreparedStmts(context.Background(), stmts, params, waitForIndexing)
+}
+
+// ExecPreparedStmts executes the statements in order, remaining statements are not executed once the context gets done
+func (e *Engine) ExecPreparedStmts(ctx context.Context, stmts []SQLStmt, params map[string]interface{}, waitForIndexing bool) (ddTxs, dmTxs []*store.TxMetadata, err error) {
+	summary, err := e.ExecPreparedStmtsWithSummary(ctx, stmts, params, waitForIndexing)
+	return summary.DDTxs, summary.DMTxs, err
 }
 
-func (e *Engine) ExecPreparedStmts(stmts []SQLStmt, params map[string]interface{}, waitForIndexing bool) (ddTxs, dmTxs []*store.TxMetadata, err error) {
+// ExecSummary describes the outcome of executed statements
+type ExecSummary struct {
+	DDTxs []*store.TxMetadata
+	DMTxs []*store.TxMetadata
+	// LastInsertedPKs holds the last value assigned to the auto incremental primary key of each table by the statements
+	LastInsertedPKs map[string]uint64
+	// ReturnedRows holds a reader of the rows written by each statement with a RETURNING clause, in the order
+	// the statements were executed
+	ReturnedRows []RowReader
+}
+
+// ExecPreparedStmtsWithSummary executes the statements as ExecPreparedStmts does. The returned summary
+// includes the committed txs as well as the primary keys generated by them, even if an error occurs
+func (e *Engine) ExecPreparedStmtsWithSummary(ctx context.Context, stmts []SQLStmt, params map[string]interface{}, waitForIndexing bool) (*ExecSummary, error) {
+	summary := &ExecSummary{LastInsertedPKs: make(map[string]uint64)}
+
+	if ctx == nil {
+		return summary, ErrIllegalArguments
+	}
+
 	if includesDDL(stmts) {
 		e.catalogRWMux.Lock()
 		defer e.catalogRWMux.Unlock()
@@ -991,41 +1474,422 @@ func (e *Engine) ExecPreparedStmts(stmts []SQLStmt, params map[string]interface{
 
 	implicitDB, err := e.DatabaseInUse()
 	if err != nil {
-		return nil, nil, err
+		return summary, err
 	}
 
 	for _, stmt := range stmts {
-		centries, dentries, db, err := stmt.CompileUsing(e, implicitDB, params)
+		err := ctx.Err()
 		if err != nil {
-			return ddTxs, dmTxs, err
+			return summary, err
+		}
+
+		centries, dentries, db, err := stmt.CompileUsing(ctx, e, implicitDB, params)
+		if err != nil {
+			return summary, err
 		}
 
 		implicitDB = db
 
 		if len(centries) > 0 && len(dentries) > 0 {
-			return ddTxs, dmTxs, ErrDDLorDMLTxOnly
+			return summary, ErrDDLorDMLTxOnly
+		}
+
+		err = e.commitEntries(ctx, centries, dentries, summary, waitForIndexing)
+		if err != nil {
+			return summary, err
+		}
+
+		err = e.addReturnedRows(summary, stmt, db, dentries)
+		if err != nil {
+			return summary, err
+		}
+	}
+
+	return summary, nil
+}
+
+// addReturnedRows adds the rows written by the statement to the summary, as they were committed, when
+// the statement has a RETURNING clause
+func (e *Engine) addReturnedRows(summary *ExecSummary, stmt SQLStmt, implicitDB *Database, entries []*store.KV) error {
+	upsert, ok := stmt.(*UpsertIntoStmt)
+	if !ok {
+		return nil
+	}
+
+	r, err := upsert.returnedRows(e, implicitDB, entries)
+	if err != nil {
+		return err
+	}
+
+	if r != nil {
+		summary.ReturnedRows = append(summary.ReturnedRows, r)
+	}
+
+	return nil
+}
+
+// BatchStmt is a statement of a batch, along with the parameters it's executed with
+type BatchStmt struct {
+	Stmt   SQLStmt
+	Params map[string]interface{}
+}
+
+// StmtSummary holds the outcome of a statement executed as part of a batch
+type StmtSummary struct {
+	// UpdatedRows is the number of rows inserted, updated or deleted by the statement
+	UpdatedRows int
+	// LastInsertedPKs holds the last value assigned to the auto incremental primary key of each table by the statement
+	LastInsertedPKs map[string]uint64
+}
+
+// ExecBatch executes the statements within a single transaction, as if they were part of a transaction block,
+// but each one of them with its own parameters. As in transaction blocks, statements don't read the rows written
+// by the preceding ones. Nothing gets committed if any of the statements fails, otherwise the summary of the
+// committed txs is returned along with the outcome of each statement
+func (e *Engine) ExecBatch(ctx context.Context, batch []*BatchStmt, waitForIndexing bool) (summary *ExecSummary, stmtSummaries []*StmtSummary, err error) {
+	summary = &ExecSummary{LastInsertedPKs: make(map[string]uint64)}
+
+	if ctx == nil || len(batch) == 0 {
+		return summary, nil, ErrIllegalArguments
+	}
+
+	stmts := make([]SQLStmt, len(batch))
+
+	for i, b := range batch {
+		if b == nil || b.Stmt == nil {
+			return summary, nil, ErrIllegalArguments
+		}
+
+		stmts[i] = b.Stmt
+	}
+
+	if includesDDL(stmts) {
+		e.catalogRWMux.Lock()
+		defer e.catalogRWMux.Unlock()
+
+		// changes made to the catalog by the statements compiled before the failing one are discarded
+		defer func() {
+			if err != nil {
+				e.loadCatalog()
+			}
+		}()
+	} else {
+		e.catalogRWMux.RLock()
+		defer e.catalogRWMux.RUnlock()
+	}
+
+	implicitDB, err := e.DatabaseInUse()
+	if err != nil {
+		return summary, nil, err
+	}
+
+	var ces, des []*store.KV
+
+	stmtSummaries = make([]*StmtSummary, len(batch))
+
+	seqEntries := make(map[string]int)
+
+	// data entries of each statement, rows are returned once they're committed
+	stmtEntries := make([][]*store.KV, len(batch))
+	stmtDBs := make([]*Database, len(batch))
+
+	for i, b := range batch {
+		err := ctx.Err()
+		if err != nil {
+			return summary, nil, err
+		}
+
+		cs, ds, db, err := b.Stmt.CompileUsing(ctx, e, implicitDB, b.Params)
+		if err != nil {
+			return summary, nil, fmt.Errorf("statement %d: %w", i, err)
+		}
+
+		implicitDB = db
+
+		stmtEntries[i] = ds
+		stmtDBs[i] = db
+
+		stmtSummary := &StmtSummary{LastInsertedPKs: make(map[string]uint64)}
+
+		for _, kv := range ds {
+			if e.isRowEntry(kv) {
+				stmtSummary.UpdatedRows++
+			}
+
+			table, seq, isSeq := e.seqFrom(kv)
+			if !isSeq {
+				des = append(des, kv)
+				continue
+			}
+
+			stmtSummary.LastInsertedPKs[table] = seq
+
+			// statements inserting into the same table move its sequence forward,
+			// only the last value is written as a key can't be written twice in a tx
+			j, written := seqEntries[string(kv.Key)]
+			if written {
+				des[j] = kv
+				continue
+			}
+
+			seqEntries[string(kv.Key)] = len(des)
+			des = append(des, kv)
+		}
+
+		stmtSummaries[i] = stmtSummary
+
+		ces = append(ces, cs...)
+	}
+
+	if len(ces) > 0 && len(des) > 0 {
+		return summary, nil, ErrDDLorDMLTxOnly
+	}
+
+	err = e.commitEntries(ctx, ces, des, summary, waitForIndexing)
+	if err != nil {
+		return summary, nil, err
+	}
+
+	for i, b := range batch {
+		err = e.addReturnedRows(summary, b.Stmt, stmtDBs[i], stmtEntries[i])
+		if err != nil {
+			return summary, nil, err
+		}
+	}
+
+	return summary, stmtSummaries, nil
+}
+
+// commitEntries commits the catalog and the data entries compiled from a statement, the committed txs and
+// the primary keys generated by them are added to the summary
+func (e *Engine) commitEntries(ctx context.Context, centries, dentries []*store.KV, summary *ExecSummary, waitForIndexing bool) error {
+	if len(centries) > 0 {
+		txmd, err := e.catalogStore.Commit(centries, false)
+		if err != nil {
+			return e.loadCatalog()
 		}
 
-		if len(centries) > 0 {
-			txmd, err := e.catalogStore.Commit(centries, waitForIndexing)
+		summary.DDTxs = append(summary.DDTxs, txmd)
+
+		if waitForIndexing {
+			err = e.catalogStore.WaitForIndexingUpto(ctx, txmd.ID)
 			if err != nil {
-				return ddTxs, dmTxs, e.loadCatalog()
+				return err
 			}
+		}
+	}
 
-			ddTxs = append(ddTxs, txmd)
+	if len(dentries) > 0 {
+		txmd, err := e.dataStore.Commit(dentries, false)
+		if err == store.ErrKeyAlreadyExists {
+			return e.uniqueViolation(ctx, dentries, err)
 		}
+		if err != nil {
+			return err
+		}
+
+		summary.DMTxs = append(summary.DMTxs, txmd)
 
-		if len(dentries) > 0 {
-			txmd, err := e.dataStore.Commit(dentries, waitForIndexing)
+		for _, kv := range dentries {
+			table, seq, isSeq := e.seqFrom(kv)
+			if isSeq {
+				summary.LastInsertedPKs[table] = seq
+			}
+		}
+
+		if waitForIndexing {
+			err = e.dataStore.WaitForIndexingUpto(ctx, txmd.ID)
 			if err != nil {
-				return ddTxs, dmTxs, err
+				return err
 			}
+		}
+	}
+
+	return nil
+}
+
+// uniqueViolation returns the unique constraint violated by the entries, if any of the values they claim
+// was taken in the meantime, otherwise the original error is returned
+func (e *Engine) uniqueViolation(ctx context.Context, entries []*store.KV, err error) error {
+	txID, _ := e.dataStore.Alh()
+
+	werr := e.dataStore.WaitForIndexingUpto(ctx, txID)
+	if werr != nil {
+		return err
+	}
+
+	for _, kv := range entries {
+		if !kv.Unique {
+			continue
+		}
 
-			dmTxs = append(dmTxs, txmd)
+		table, col, isUnique := e.uniqueColumnFrom(kv)
+		if !isUnique {
+			continue
 		}
+
+		_, _, _, gerr := e.dataStore.GetRef(kv.Key)
+		if gerr == nil {
+			return fmt.Errorf("%w: %s(%s)", ErrUniqueConstraintViolation, table.name, col.colName)
+		}
+	}
+
+	return err
+}
+
+// uniqueColumnFrom returns the table and the column the entry claims a value of, if it's a unique constraint entry
+func (e *Engine) uniqueColumnFrom(kv *store.KV) (table *Table, col *Column, isUnique bool) {
+	enc, err := e.trimPrefix(kv.Key, []byte(UniquePrefix))
+	if err != nil || len(enc) < 3*EncIDLen {
+		return nil, nil, false
+	}
+
+	db, err := e.catalog.GetDatabaseByID(binary.BigEndian.Uint64(enc))
+	if err != nil {
+		return nil, nil, false
+	}
+
+	table, err = db.GetTableByID(binary.BigEndian.Uint64(enc[EncIDLen:]))
+	if err != nil {
+		return nil, nil, false
+	}
+
+	col, err = table.GetColumnByID(binary.BigEndian.Uint64(enc[2*EncIDLen:]))
+	if err != nil {
+		return nil, nil, false
+	}
+
+	return table, col, true
+}
+
+// seqFrom returns the table name and the sequence value held by the entry, if it's the sequence entry of a table
+func (e *Engine) seqFrom(kv *store.KV) (table string, seq uint64, isSeq bool) {
+	encID, err := e.trimPrefix(kv.Key, []byte(SeqPrefix))
+	if err != nil || len(encID) != 2*EncIDLen || len(kv.Value) != EncIDLen {
+		return "", 0, false
+	}
+
+	db, err := e.catalog.GetDatabaseByID(binary.BigEndian.Uint64(encID))
+	if err != nil {
+		return "", 0, false
+	}
+
+	t, err := db.GetTableByID(binary.BigEndian.Uint64(encID[EncIDLen:]))
+	if err != nil {
+		return "", 0, false
+	}
+
+	return t.name, binary.BigEndian.Uint64(kv.Value), true
+}
+
+// isRowEntry returns whether the entry is the one holding a row, entries of secondary indexes are not
+func (e *Engine) isRowEntry(kv *store.KV) bool {
+	enc, err := e.trimPrefix(kv.Key, []byte(RowPrefix))
+	if err != nil || len(enc) < 3*EncIDLen {
+		return false
+	}
+
+	db, err := e.catalog.GetDatabaseByID(binary.BigEndian.Uint64(enc))
+	if err != nil {
+		return false
+	}
+
+	t, err := db.GetTableByID(binary.BigEndian.Uint64(enc[EncIDLen:]))
+	if err != nil {
+		return false
+	}
+
+	return t.pk.id == binary.BigEndian.Uint64(enc[2*EncIDLen:])
+}
+
+// IsIdempotent returns whether executing the statement more than once has the same effect as executing it once,
+// which is the case of deletions, updates setting columns to given values and upserts of rows whose primary key
+// is given, thus they can be safely retried
+func (e *Engine) IsIdempotent(stmt SQLStmt) bool {
+	e.catalogRWMux.RLock()
+	defer e.catalogRWMux.RUnlock()
+
+	switch s := stmt.(type) {
+	case *DeleteFromStmt:
+		{
+			return true
+		}
+	case *UpdateStmt:
+		{
+			for _, u := range s.updates {
+				if !isGivenValue(u.val) {
+					return false
+				}
+			}
+
+			return true
+		}
+	case *UpsertIntoStmt:
+		{
+			if s.isInsert {
+				return false
+			}
+
+			implicitDB, err := e.DatabaseInUse()
+			if err != nil {
+				return false
+			}
+
+			table, err := s.tableRef.referencedTable(e, implicitDB)
+			if err != nil {
+				return false
+			}
+
+			for _, pkCol := range table.pkCols {
+				pkIncluded := false
+
+				for _, col := range s.cols {
+					if col == pkCol.colName {
+						pkIncluded = true
+					}
+				}
+
+				if !pkIncluded {
+					return false
+				}
+			}
+
+			for _, row := range s.rows {
+				for _, val := range row.Values {
+					if !isGivenValue(val) {
+						return false
+					}
+				}
+			}
+
+			included := make(map[string]struct{}, len(s.cols))
+			for _, col := range s.cols {
+				included[col] = struct{}{}
+			}
+
+			// default values such as NOW() are evaluated on each execution
+			for _, col := range table.colsByID {
+				_, isIncluded := included[col.colName]
+				if !isIncluded && col.defaultValue != nil && !isGivenValue(col.defaultValue) {
+					return false
+				}
+			}
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// isGivenValue returns whether the expression is a literal or a parameter, whose value doesn't depend on when it's evaluated
+func isGivenValue(exp ValueExp) bool {
+	if _, isParam := exp.(*Param); isParam {
+		return true
 	}
 
-	return ddTxs, dmTxs, nil
+	_, isConstant := constantValue(exp)
+	return isConstant
 }
 
 func includesDDL(stmts []SQLStmt) bool {
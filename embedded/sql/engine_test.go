@@ -16,9 +16,13 @@ limitations under the License.
 package sql
 
 import (
+	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"math"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -46,7 +50,7 @@ func TestCreateDatabase(t *testing.T) {
 	require.NoError(t, err)
 
 	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
-	require.Equal(t, ErrDatabaseAlreadyExists, err)
+	require.True(t, errors.Is(err, ErrDatabaseAlreadyExists))
 
 	_, _, err = engine.ExecStmt("CREATE DATABASE db2", nil, true)
 	require.NoError(t, err)
@@ -80,7 +84,7 @@ func TestUseDatabase(t *testing.T) {
 	require.NoError(t, err)
 
 	err = engine.UseDatabase("db1")
-	require.Equal(t, ErrDatabaseDoesNotExist, err)
+	require.True(t, errors.Is(err, ErrDatabaseDoesNotExist))
 
 	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
 	require.NoError(t, err)
@@ -96,7 +100,7 @@ func TestUseDatabase(t *testing.T) {
 	require.NoError(t, err)
 
 	_, _, err = engine.ExecStmt("USE DATABASE db2", nil, true)
-	require.Equal(t, ErrDatabaseDoesNotExist, err)
+	require.True(t, errors.Is(err, ErrDatabaseDoesNotExist))
 }
 
 func TestCreateTable(t *testing.T) {
@@ -130,7 +134,7 @@ func TestCreateTable(t *testing.T) {
 	require.NoError(t, err)
 
 	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id INTEGER, PRIMARY KEY id)", nil, true)
-	require.Equal(t, ErrTableAlreadyExists, err)
+	require.True(t, errors.Is(err, ErrTableAlreadyExists))
 
 	_, _, err = engine.ExecStmt("CREATE TABLE IF NOT EXISTS table1 (id INTEGER, PRIMARY KEY id)", nil, true)
 	require.NoError(t, err)
@@ -161,7 +165,73 @@ func TestAddColumn(t *testing.T) {
 	require.Equal(t, ErrInvalidPK, err)
 
 	_, _, err = engine.ExecStmt("ALTER TABLE table1 ADD COLUMN surname VARCHAR", nil, true)
-	require.Equal(t, ErrNoSupported, err)
+	require.True(t, errors.Is(err, ErrTableDoesNotExist))
+
+	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id INTEGER, name VARCHAR, PRIMARY KEY id)", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("INSERT INTO table1 (id, name) VALUES (1, 'name1')", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("ALTER TABLE table1 ADD COLUMN name VARCHAR", nil, true)
+	require.True(t, errors.Is(err, ErrColumnAlreadyExists))
+
+	_, _, err = engine.ExecStmt("ALTER TABLE table1 ADD COLUMN surname VARCHAR NOT NULL", nil, true)
+	require.Equal(t, ErrNewColumnMustBeNullable, err)
+
+	_, _, err = engine.ExecStmt("ALTER TABLE table1 ADD COLUMN surname VARCHAR", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("INSERT INTO table1 (id, name, surname) VALUES (2, 'name2', 'surname2')", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("ALTER TABLE table1 RENAME COLUMN title TO name", nil, true)
+	require.True(t, errors.Is(err, ErrColumnDoesNotExist))
+
+	_, _, err = engine.ExecStmt("ALTER TABLE table1 RENAME COLUMN name TO surname", nil, true)
+	require.True(t, errors.Is(err, ErrColumnAlreadyExists))
+
+	_, _, err = engine.ExecStmt("ALTER TABLE table1 RENAME COLUMN name TO firstname", nil, true)
+	require.NoError(t, err)
+
+	checkRows := func(engine *Engine) {
+		r, err := engine.QueryStmt("SELECT id, firstname, surname FROM table1", nil, true)
+		require.NoError(t, err)
+		defer r.Close()
+
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.Equal(t, "name1", row.Values[EncodeSelector("", "db1", "table1", "firstname")].Value())
+		require.Nil(t, row.Values[EncodeSelector("", "db1", "table1", "surname")].Value())
+
+		row, err = r.Read()
+		require.NoError(t, err)
+		require.Equal(t, "name2", row.Values[EncodeSelector("", "db1", "table1", "firstname")].Value())
+		require.Equal(t, "surname2", row.Values[EncodeSelector("", "db1", "table1", "surname")].Value())
+
+		_, err = r.Read()
+		require.Equal(t, ErrNoMoreRows, err)
+	}
+
+	checkRows(engine)
+
+	r, err := engine.QueryStmt("SELECT name FROM table1", nil, true)
+	require.NoError(t, err)
+
+	_, err = r.Read()
+	require.True(t, errors.Is(err, ErrColumnDoesNotExist))
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	// changes are loaded from the catalog
+	engine, err = NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
+
+	err = engine.UseDatabase("db1")
+	require.NoError(t, err)
+
+	checkRows(engine)
 }
 
 func TestCreateIndex(t *testing.T) {
@@ -202,7 +272,7 @@ func TestCreateIndex(t *testing.T) {
 	require.True(t, indexed)
 
 	_, _, err = engine.ExecStmt("CREATE INDEX ON table1(id)", nil, true)
-	require.Equal(t, ErrIndexAlreadyExists, err)
+	require.True(t, errors.Is(err, ErrIndexAlreadyExists))
 
 	_, _, err = engine.ExecStmt("CREATE INDEX ON table1(age)", nil, true)
 	require.NoError(t, err)
@@ -214,13 +284,13 @@ func TestCreateIndex(t *testing.T) {
 	require.True(t, indexed)
 
 	_, _, err = engine.ExecStmt("CREATE INDEX ON table1(name)", nil, true)
-	require.Equal(t, ErrIndexAlreadyExists, err)
+	require.True(t, errors.Is(err, ErrIndexAlreadyExists))
 
 	_, _, err = engine.ExecStmt("CREATE INDEX ON table2(name)", nil, true)
-	require.Equal(t, ErrTableDoesNotExist, err)
+	require.True(t, errors.Is(err, ErrTableDoesNotExist))
 
 	_, _, err = engine.ExecStmt("CREATE INDEX ON table1(title)", nil, true)
-	require.Equal(t, ErrColumnDoesNotExist, err)
+	require.True(t, errors.Is(err, ErrColumnDoesNotExist))
 
 	require.Len(t, table.indexes, 2)
 
@@ -231,673 +301,4610 @@ func TestCreateIndex(t *testing.T) {
 	require.Equal(t, ErrLimitedIndex, err)
 }
 
-func TestUpsertInto(t *testing.T) {
-	catalogStore, err := store.Open("catalog_upsert", store.DefaultOptions())
+func TestDropTable(t *testing.T) {
+	catalogStore, err := store.Open("catalog_drop_table", store.DefaultOptions())
 	require.NoError(t, err)
-	defer os.RemoveAll("catalog_upsert")
+	defer os.RemoveAll("catalog_drop_table")
 
-	dataStore, err := store.Open("sqldata_upsert", store.DefaultOptions())
+	dataStore, err := store.Open("sqldata_drop_table", store.DefaultOptions())
 	require.NoError(t, err)
-	defer os.RemoveAll("sqldata_upsert")
+	defer os.RemoveAll("sqldata_drop_table")
 
 	engine, err := NewEngine(catalogStore, dataStore, prefix)
 	require.NoError(t, err)
 
+	_, _, err = engine.ExecStmt("DROP TABLE table1", nil, true)
+	require.Equal(t, ErrNoDatabaseSelected, err)
+
 	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
 	require.NoError(t, err)
 
 	err = engine.UseDatabase("db1")
 	require.NoError(t, err)
 
-	_, _, err = engine.ExecStmt("UPSERT INTO table1 (id, title) VALUES (1, 'title1')", nil, true)
-	require.Equal(t, ErrTableDoesNotExist, err)
+	_, _, err = engine.ExecStmt("DROP TABLE table1", nil, true)
+	require.True(t, errors.Is(err, ErrTableDoesNotExist))
 
-	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id INTEGER, title VARCHAR, active BOOLEAN NOT NULL, PRIMARY KEY id)", nil, true)
+	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id INTEGER, name VARCHAR, PRIMARY KEY id)", nil, true)
 	require.NoError(t, err)
 
-	_, _, err = engine.ExecStmt("UPSERT INTO table1 (id, title) VALUES (1, 'title1')", nil, true)
-	require.Equal(t, ErrNotNullableColumnCannotBeNull, err)
-
-	_, _, err = engine.ExecStmt("UPSERT INTO table1 (id, age) VALUES (1, 50)", nil, true)
-	require.Equal(t, ErrColumnDoesNotExist, err)
+	_, _, err = engine.ExecStmt("CREATE INDEX ON table1(name)", nil, true)
+	require.NoError(t, err)
 
-	_, _, err = engine.ExecStmt("UPSERT INTO table1 (id, title) VALUES (@id, 'title1')", nil, true)
-	require.Equal(t, ErrMissingParameter, err)
+	_, _, err = engine.ExecStmt("INSERT INTO table1 (id, name) VALUES (1, 'name1'), (2, 'name2')", nil, true)
+	require.NoError(t, err)
 
-	params := make(map[string]interface{}, 1)
-	params["id"] = [4]byte{1, 2, 3, 4}
-	_, _, err = engine.ExecStmt("UPSERT INTO table1 (id, title) VALUES (@id, 'title1')", params, true)
-	require.Equal(t, ErrUnsupportedParameter, err)
+	_, _, err = engine.ExecStmt("CREATE TABLE table2 (id INTEGER, PRIMARY KEY id)", nil, true)
+	require.NoError(t, err)
 
-	params = make(map[string]interface{}, 1)
-	params["id"] = []byte{1, 2, 3}
-	_, _, err = engine.ExecStmt("UPSERT INTO table1 (id, title) VALUES (@id, 'title1')", params, true)
-	require.Equal(t, ErrInvalidValue, err)
+	_, _, err = engine.ExecStmt("DROP TABLE table1", nil, true)
+	require.NoError(t, err)
 
-	_, _, err = engine.ExecStmt("UPSERT INTO table1 (id, title) VALUES (1, @title)", nil, true)
-	require.Equal(t, ErrMissingParameter, err)
+	_, err = engine.QueryStmt("SELECT id FROM table1", nil, true)
+	require.True(t, errors.Is(err, ErrTableDoesNotExist))
 
-	params = make(map[string]interface{}, 1)
-	params["title"] = uint64(1)
-	_, _, err = engine.ExecStmt("UPSERT INTO table1 (id, title) VALUES (1, @title)", params, true)
-	require.Equal(t, ErrInvalidValue, err)
+	_, _, err = engine.ExecStmt("DROP TABLE table1", nil, true)
+	require.True(t, errors.Is(err, ErrTableDoesNotExist))
 
-	_, _, err = engine.ExecStmt("UPSERT INTO Table1 (id, active) VALUES (1, true)", nil, true)
+	// a table created with the same name gets a new id and does not see the rows of the dropped one
+	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id INTEGER, title VARCHAR, PRIMARY KEY id)", nil, true)
 	require.NoError(t, err)
 
-	_, _, err = engine.ExecStmt("UPSERT INTO table1 (Id, Title, Active) VALUES (1, 'some title', false)", nil, true)
-	require.NoError(t, err)
+	checkTables := func(engine *Engine) {
+		db, err := engine.catalog.GetDatabaseByName("db1")
+		require.NoError(t, err)
 
-	_, _, err = engine.ExecStmt("UPSERT INTO table1 (id, title, active) VALUES (2, 'another title', true)", nil, true)
-	require.NoError(t, err)
+		table1, err := db.GetTableByName("table1")
+		require.NoError(t, err)
+		require.Equal(t, uint64(3), table1.id)
+		require.Len(t, table1.indexes, 0)
 
-	_, _, err = engine.ExecStmt("UPSERT INTO table1 (id) VALUES (1, 'yat')", nil, true)
-	require.Equal(t, ErrInvalidNumberOfValues, err)
+		_, err = table1.GetColumnByName("name")
+		require.True(t, errors.Is(err, ErrColumnDoesNotExist))
 
-	_, _, err = engine.ExecStmt("UPSERT INTO table1 (id, id) VALUES (1, 2)", nil, true)
-	require.Equal(t, ErrDuplicatedColumn, err)
+		table2, err := db.GetTableByName("table2")
+		require.NoError(t, err)
+		require.Equal(t, uint64(2), table2.id)
 
-	_, _, err = engine.ExecStmt("UPSERT INTO table1 (id) VALUES ('1')", nil, true)
-	require.Equal(t, ErrInvalidValue, err)
+		r, err := engine.QueryStmt("SELECT COUNT() FROM table1", nil, true)
+		require.NoError(t, err)
 
-	_, _, err = engine.ExecStmt("UPSERT INTO table1 (id) VALUES (NULL)", nil, true)
-	require.Equal(t, ErrPKCanNotBeNull, err)
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.Equal(t, uint64(0), row.Values[EncodeSelector("", "db1", "table1", "col0")].Value())
 
-	_, _, err = engine.ExecStmt("UPSERT INTO table1 (id, title, active) VALUES (2, NULL, true)", nil, true)
-	require.NoError(t, err)
+		err = r.Close()
+		require.NoError(t, err)
+	}
 
-	_, _, err = engine.ExecStmt("UPSERT INTO table1 (title) VALUES ('interesting title')", nil, true)
-	require.Equal(t, ErrPKCanNotBeNull, err)
-}
+	checkTables(engine)
 
-func TestTransactions(t *testing.T) {
-	catalogStore, err := store.Open("catalog_tx", store.DefaultOptions())
+	// dropped tables are not loaded from the catalog and their ids are not reused
+	engine, err = NewEngine(catalogStore, dataStore, prefix)
 	require.NoError(t, err)
-	defer os.RemoveAll("catalog_tx")
 
-	dataStore, err := store.Open("sqldata_tx", store.DefaultOptions())
+	err = engine.UseDatabase("db1")
 	require.NoError(t, err)
-	defer os.RemoveAll("sqldata_tx")
 
-	engine, err := NewEngine(catalogStore, dataStore, prefix)
-	require.NoError(t, err)
+	checkTables(engine)
 
-	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
+	_, _, err = engine.ExecStmt("DROP TABLE table2", nil, true)
 	require.NoError(t, err)
 
-	err = engine.UseDatabase("db1")
+	_, _, err = engine.ExecStmt("CREATE TABLE table3 (id INTEGER, PRIMARY KEY id)", nil, true)
 	require.NoError(t, err)
 
-	_, _, err = engine.ExecStmt(`CREATE TABLE table1 (
-									id INTEGER, 
-									title VARCHAR, 
-									PRIMARY KEY id
-								)`, nil, true)
+	engine, err = NewEngine(catalogStore, dataStore, prefix)
 	require.NoError(t, err)
 
-	_, _, err = engine.ExecStmt(`
-		BEGIN TRANSACTION
-			CREATE INDEX ON table2(title)
-		COMMIT
-		`, nil, true)
-	require.Equal(t, ErrTableDoesNotExist, err)
-
-	_, _, err = engine.ExecStmt(`
-		BEGIN TRANSACTION
-			UPSERT INTO table1 (id, title) VALUES (1, 'title1');
-			UPSERT INTO table1 (id, title) VALUES (2, 'title2');
-		COMMIT
-		`, nil, true)
+	db, err := engine.catalog.GetDatabaseByName("db1")
 	require.NoError(t, err)
 
-	_, _, err = engine.ExecStmt(`
-		BEGIN TRANSACTION
-			CREATE TABLE table2 (id INTEGER, title VARCHAR, age INTEGER, PRIMARY KEY id);
-			CREATE INDEX ON table2(title);
-		COMMIT
-		`, nil, true)
+	table3, err := db.GetTableByName("table3")
 	require.NoError(t, err)
-
-	_, _, err = engine.ExecStmt(`
-		BEGIN TRANSACTION
-			CREATE INDEX ON table2(age);
-			INSERT INTO table2 (id, title, age) VALUES (1, 'title1', 40);
-		COMMIT
-		`, nil, true)
-	require.Equal(t, ErrDDLorDMLTxOnly, err)
+	require.Equal(t, uint64(4), table3.id)
 }
 
-func TestUseSnapshot(t *testing.T) {
-	catalogStore, err := store.Open("catalog_snap", store.DefaultOptions())
+func TestDropIndex(t *testing.T) {
+	catalogStore, err := store.Open("catalog_drop_index", store.DefaultOptions())
 	require.NoError(t, err)
-	defer os.RemoveAll("catalog_snap")
+	defer os.RemoveAll("catalog_drop_index")
 
-	dataStore, err := store.Open("sqldata_snap", store.DefaultOptions())
+	dataStore, err := store.Open("sqldata_drop_index", store.DefaultOptions())
 	require.NoError(t, err)
-	defer os.RemoveAll("sqldata_snap")
+	defer os.RemoveAll("sqldata_drop_index")
 
 	engine, err := NewEngine(catalogStore, dataStore, prefix)
 	require.NoError(t, err)
 
+	_, _, err = engine.ExecStmt("DROP INDEX ON table1(name)", nil, true)
+	require.Equal(t, ErrNoDatabaseSelected, err)
+
 	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
 	require.NoError(t, err)
 
 	err = engine.UseDatabase("db1")
 	require.NoError(t, err)
 
-	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id INTEGER, title VARCHAR, PRIMARY KEY id)", nil, true)
-	require.NoError(t, err)
-
-	_, _, err = engine.ExecStmt("USE SNAPSHOT SINCE TX 1", nil, true)
-	require.Equal(t, ErrNoSupported, err)
+	_, _, err = engine.ExecStmt("DROP INDEX ON table1(name)", nil, true)
+	require.True(t, errors.Is(err, ErrTableDoesNotExist))
 
-	err = engine.UseSnapshot(1, 1)
-	require.Equal(t, ErrTxDoesNotExist, err)
+	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id INTEGER, name VARCHAR, age INTEGER, PRIMARY KEY id)", nil, true)
+	require.NoError(t, err)
 
-	err = engine.UseSnapshot(0, 1)
-	require.Equal(t, ErrTxDoesNotExist, err)
+	_, _, err = engine.ExecStmt("CREATE INDEX ON table1(name)", nil, true)
+	require.NoError(t, err)
 
-	err = engine.UseSnapshot(1, 1)
-	require.Equal(t, ErrTxDoesNotExist, err)
+	_, _, err = engine.ExecStmt("CREATE INDEX ON table1(age)", nil, true)
+	require.NoError(t, err)
 
-	err = engine.UseSnapshot(1, 2)
-	require.Equal(t, ErrIllegalArguments, err)
+	_, _, err = engine.ExecStmt("DROP INDEX ON table1(title)", nil, true)
+	require.True(t, errors.Is(err, ErrColumnDoesNotExist))
 
-	_, _, err = engine.ExecStmt(`
-		BEGIN TRANSACTION
-			UPSERT INTO table1 (id, title) VALUES (1, 'title1');
-			UPSERT INTO table1 (id, title) VALUES (2, 'title2');
-		COMMIT
-		`, nil, true)
-	require.NoError(t, err)
+	_, _, err = engine.ExecStmt("DROP INDEX ON table1(id)", nil, true)
+	require.True(t, errors.Is(err, ErrColumnNotIndexed))
 
-	err = engine.UseSnapshot(1, 0)
+	_, _, err = engine.ExecStmt("INSERT INTO table1 (id, name, age) VALUES (1, 'name1', 30), (2, 'name2', 20)", nil, true)
 	require.NoError(t, err)
 
-	err = engine.CloseSnapshot()
+	_, _, err = engine.ExecStmt("DROP INDEX ON table1(name)", nil, true)
 	require.NoError(t, err)
 
-	err = engine.UseSnapshot(0, 1)
-	require.NoError(t, err)
+	_, _, err = engine.ExecStmt("DROP INDEX ON table1(name)", nil, true)
+	require.True(t, errors.Is(err, ErrColumnNotIndexed))
 
-	err = engine.UseSnapshot(1, 1)
-	require.NoError(t, err)
-}
+	checkIndexes := func(engine *Engine) {
+		// rows get sorted without the dropped index
+		r, err := engine.QueryStmt("SELECT id FROM table1 ORDER BY name DESC", nil, true)
+		require.NoError(t, err)
 
-func TestEncodeRawValue(t *testing.T) {
-	_, err := EncodeRawValue(uint64(1), IntegerType, true)
-	require.NoError(t, err)
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.Equal(t, uint64(2), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
 
-	_, err = EncodeRawValue(true, IntegerType, true)
-	require.Equal(t, ErrInvalidValue, err)
+		err = r.Close()
+		require.NoError(t, err)
 
-	_, err = EncodeRawValue(true, BooleanType, true)
-	require.NoError(t, err)
+		r, err = engine.QueryStmt("SELECT id FROM table1 ORDER BY age", nil, true)
+		require.NoError(t, err)
 
-	_, err = EncodeRawValue(uint64(1), BooleanType, true)
-	require.Equal(t, ErrInvalidValue, err)
+		row, err = r.Read()
+		require.NoError(t, err)
+		require.Equal(t, uint64(2), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
 
-	_, err = EncodeRawValue("title", VarcharType, true)
-	require.NoError(t, err)
+		err = r.Close()
+		require.NoError(t, err)
+	}
 
-	_, err = EncodeRawValue(uint64(1), VarcharType, true)
-	require.Equal(t, ErrInvalidValue, err)
+	checkIndexes(engine)
 
-	_, err = EncodeRawValue([]byte{}, BLOBType, true)
+	// dropped indexes are not loaded from the catalog
+	engine, err = NewEngine(catalogStore, dataStore, prefix)
 	require.NoError(t, err)
 
-	_, err = EncodeRawValue(nil, BLOBType, true)
+	err = engine.UseDatabase("db1")
 	require.NoError(t, err)
 
-	_, err = EncodeRawValue(uint64(1), BLOBType, true)
-	require.Equal(t, ErrInvalidValue, err)
-
-	_, err = EncodeRawValue(uint64(1), "invalid type", true)
-	require.Equal(t, ErrInvalidValue, err)
+	checkIndexes(engine)
 }
 
-func TestClosing(t *testing.T) {
-	catalogStore, err := store.Open("catalog_closing", store.DefaultOptions())
+func TestTruncateTable(t *testing.T) {
+	catalogStore, err := store.Open("catalog_truncate_table", store.DefaultOptions())
 	require.NoError(t, err)
-	defer os.RemoveAll("catalog_closing")
+	defer os.RemoveAll("catalog_truncate_table")
 
-	dataStore, err := store.Open("sqldata_closing", store.DefaultOptions())
+	dataStore, err := store.Open("sqldata_truncate_table", store.DefaultOptions())
 	require.NoError(t, err)
-	defer os.RemoveAll("sqldata_closing")
-
-	_, err = NewEngine(nil, nil, nil)
-	require.Equal(t, ErrIllegalArguments, err)
+	defer os.RemoveAll("sqldata_truncate_table")
 
 	engine, err := NewEngine(catalogStore, dataStore, prefix)
 	require.NoError(t, err)
 
-	err = engine.Close()
-	require.NoError(t, err)
+	_, _, err = engine.ExecStmt("TRUNCATE TABLE table1", nil, true)
+	require.Equal(t, ErrNoDatabaseSelected, err)
 
-	err = engine.Close()
-	require.Equal(t, ErrAlreadyClosed, err)
+	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
+	require.NoError(t, err)
 
 	err = engine.UseDatabase("db1")
-	require.Equal(t, ErrAlreadyClosed, err)
-
-	_, err = engine.DatabaseInUse()
-	require.Equal(t, ErrAlreadyClosed, err)
-
-	_, err = engine.Snapshot()
-	require.Equal(t, ErrAlreadyClosed, err)
+	require.NoError(t, err)
 
-	err = engine.RenewSnapshot()
-	require.Equal(t, ErrAlreadyClosed, err)
+	_, _, err = engine.ExecStmt("TRUNCATE TABLE table1", nil, true)
+	require.True(t, errors.Is(err, ErrTableDoesNotExist))
 
-	err = engine.CloseSnapshot()
-	require.Equal(t, ErrAlreadyClosed, err)
-}
+	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id INTEGER, name VARCHAR NOT NULL, age INTEGER, PRIMARY KEY id)", nil, true)
+	require.NoError(t, err)
 
-func TestQuery(t *testing.T) {
-	catalogStore, err := store.Open("catalog_q", store.DefaultOptions())
+	_, _, err = engine.ExecStmt("CREATE INDEX ON table1(name)", nil, true)
 	require.NoError(t, err)
-	defer os.RemoveAll("catalog_q")
 
-	dataStore, err := store.Open("sqldata_q", store.DefaultOptions())
+	_, _, err = engine.ExecStmt("CREATE POLICY adults ON table1 TO alice USING (age >= 18)", nil, true)
 	require.NoError(t, err)
-	defer os.RemoveAll("sqldata_q")
 
-	engine, err := NewEngine(catalogStore, dataStore, prefix)
+	_, _, err = engine.ExecStmt("INSERT INTO table1 (id, name, age) VALUES (1, 'name1', 30), (2, 'name2', 10)", nil, true)
 	require.NoError(t, err)
 
-	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
+	stmts, err := ParseString("TRUNCATE TABLE table1")
 	require.NoError(t, err)
 
-	_, err = engine.QueryStmt("SELECT id FROM table1", nil, true)
-	require.Equal(t, ErrNoDatabaseSelected, err)
+	// rows not accessible within the session prevent the table from being truncated
+	_, _, err = engine.ExecPreparedStmts(WithUser(context.Background(), "alice", "readwrite"), stmts, nil, true)
+	require.Equal(t, ErrPolicyViolation, err)
 
-	_, err = engine.QueryStmt("SELECT * FROM table1", nil, true)
-	require.Equal(t, ErrNoDatabaseSelected, err)
-
-	err = engine.UseDatabase("db1")
+	ddTxs, _, err := engine.ExecStmt("TRUNCATE TABLE table1", nil, true)
 	require.NoError(t, err)
+	require.Len(t, ddTxs, 1)
 
-	_, err = engine.QueryStmt("SELECT id FROM db2.table1", nil, true)
-	require.Equal(t, ErrDatabaseDoesNotExist, err)
+	countRows := func(engine *Engine) uint64 {
+		r, err := engine.QueryStmt("SELECT COUNT() FROM table1", nil, true)
+		require.NoError(t, err)
+		defer r.Close()
 
-	_, err = engine.QueryStmt("SELECT id FROM table1", nil, true)
-	require.Equal(t, ErrTableDoesNotExist, err)
+		row, err := r.Read()
+		require.NoError(t, err)
+
+		return row.Values[EncodeSelector("", "db1", "table1", "col0")].Value().(uint64)
+	}
+
+	checkTable := func(engine *Engine) {
+		db, err := engine.catalog.GetDatabaseByName("db1")
+		require.NoError(t, err)
+
+		table, err := db.GetTableByName("table1")
+		require.NoError(t, err)
+		require.Equal(t, uint64(2), table.id)
+
+		require.Equal(t, `CREATE TABLE table1 (id INTEGER NOT NULL, name VARCHAR NOT NULL, age INTEGER, PRIMARY KEY id);
+CREATE INDEX ON table1(name);
+CREATE POLICY adults ON table1 TO alice USING ((age >= 18));
+`, db.DDL())
+	}
 
-	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id INTEGER, ts INTEGER, title VARCHAR, active BOOLEAN, payload BLOB, PRIMARY KEY id)", nil, true)
+	checkTable(engine)
+	require.Equal(t, uint64(0), countRows(engine))
+
+	_, _, err = engine.ExecStmt("INSERT INTO table1 (id, name, age) VALUES (1, 'name3', 40)", nil, true)
 	require.NoError(t, err)
 
-	r, err := engine.QueryStmt("SELECT id FROM db1.table1", nil, true)
+	require.Equal(t, uint64(1), countRows(engine))
+
+	r, err := engine.QueryStmt("SELECT name FROM table1 WHERE id = 1", nil, true)
 	require.NoError(t, err)
 
-	_, err = r.Read()
-	require.Equal(t, ErrNoMoreRows, err)
+	row, err := r.Read()
+	require.NoError(t, err)
+	require.Equal(t, "name3", row.Values[EncodeSelector("", "db1", "table1", "name")].Value())
 
 	err = r.Close()
 	require.NoError(t, err)
 
-	r, err = engine.QueryStmt("SELECT * FROM db1.table1", nil, true)
+	// the truncated version is not loaded from the catalog
+	engine, err = NewEngine(catalogStore, dataStore, prefix)
 	require.NoError(t, err)
 
-	_, err = r.Read()
-	require.Equal(t, ErrNoMoreRows, err)
+	err = engine.UseDatabase("db1")
+	require.NoError(t, err)
 
-	err = r.Close()
+	checkTable(engine)
+	require.Equal(t, uint64(1), countRows(engine))
+
+	_, _, err = engine.ExecStmt("TRUNCATE TABLE table1", nil, true)
 	require.NoError(t, err)
 
-	rowCount := 10
+	// indexes can be created once the table is empty
+	_, _, err = engine.ExecStmt("CREATE INDEX ON table1(age)", nil, true)
+	require.NoError(t, err)
 
-	start := time.Now().UnixNano()
+	require.Equal(t, uint64(0), countRows(engine))
+}
 
-	for i := 0; i < rowCount; i++ {
-		encPayload := hex.EncodeToString([]byte(fmt.Sprintf("blob%d", i)))
-		_, _, err = engine.ExecStmt(fmt.Sprintf("UPSERT INTO table1 (id, ts, title, active, payload) VALUES (%d, NOW(), 'title%d', %v, x'%s')", i, i, i%2 == 0, encPayload), nil, true)
-		require.NoError(t, err)
-	}
+func TestPolicies(t *testing.T) {
+	catalogStore, err := store.Open("catalog_policies", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_policies")
 
-	_, err = engine.QueryStmt("SELECT DISTINCT id1 FROM table1", nil, true)
-	require.Equal(t, ErrNoSupported, err)
+	dataStore, err := store.Open("sqldata_policies", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_policies")
 
-	r, err = engine.QueryStmt("SELECT id1 FROM table1", nil, true)
+	engine, err := NewEngine(catalogStore, dataStore, prefix)
 	require.NoError(t, err)
 
-	row, err := r.Read()
-	require.Equal(t, ErrColumnDoesNotExist, err)
-	require.Nil(t, row)
+	_, _, err = engine.ExecStmt("CREATE POLICY acme_rows ON orders USING (tenant = 'acme')", nil, true)
+	require.Equal(t, ErrNoDatabaseSelected, err)
 
-	err = r.Close()
+	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
 	require.NoError(t, err)
 
-	r, err = engine.QueryStmt(fmt.Sprintf("SELECT t1.id AS D, ts, Title, payload, Active FROM (table1 AS T1) WHERE id >= 0 LIMIT %d AS table1", rowCount), nil, true)
+	err = engine.UseDatabase("db1")
 	require.NoError(t, err)
 
-	colsBySel, err := r.colsBySelector()
+	_, _, err = engine.ExecStmt("CREATE POLICY acme_rows ON orders USING (tenant = 'acme')", nil, true)
+	require.True(t, errors.Is(err, ErrTableDoesNotExist))
+
+	_, _, err = engine.ExecStmt("CREATE TABLE orders (id INTEGER, tenant VARCHAR, amount INTEGER, PRIMARY KEY id)", nil, true)
 	require.NoError(t, err)
-	require.Len(t, colsBySel, 5)
 
-	require.Equal(t, "db1", r.ImplicitDB())
-	require.Equal(t, "table1", r.ImplicitTable())
+	_, _, err = engine.ExecStmt("CREATE INDEX ON orders(amount)", nil, true)
+	require.NoError(t, err)
 
-	cols, err := r.Columns()
+	_, _, err = engine.ExecStmt(`
+		INSERT INTO orders (id, tenant, amount) VALUES
+			(1, 'acme', 10), (2, 'globex', 20), (3, 'acme', 300), (4, 'globex', 400)
+	`, nil, true)
 	require.NoError(t, err)
-	require.Len(t, cols, 5)
 
-	for i := 0; i < rowCount; i++ {
-		row, err := r.Read()
-		require.NoError(t, err)
-		require.NotNil(t, row)
-		require.Len(t, row.Values, 5)
-		require.Less(t, uint64(start), row.Values[EncodeSelector("", "db1", "table1", "ts")].Value())
-		require.Equal(t, uint64(i), row.Values[EncodeSelector("", "db1", "table1", "d")].Value())
-		require.Equal(t, fmt.Sprintf("title%d", i), row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
-		require.Equal(t, i%2 == 0, row.Values[EncodeSelector("", "db1", "table1", "active")].Value())
+	_, _, err = engine.ExecStmt("CREATE POLICY acme_rows ON orders TO alice USING (customer = 'acme')", nil, true)
+	require.True(t, errors.Is(err, ErrColumnDoesNotExist))
 
-		encPayload := []byte(fmt.Sprintf("blob%d", i))
-		require.Equal(t, []byte(encPayload), row.Values[EncodeSelector("", "db1", "table1", "payload")].Value())
-	}
+	_, _, err = engine.ExecStmt("CREATE POLICY acme_rows ON orders TO alice USING (tenant = @tenant)", map[string]interface{}{"tenant": "acme"}, true)
+	require.Equal(t, ErrInvalidPolicyFilter, err)
 
-	_, err = r.Read()
-	require.Equal(t, ErrNoMoreRows, err)
+	_, _, err = engine.ExecStmt("CREATE POLICY acme_rows ON orders TO alice USING (orders.tenant = 'acme')", nil, true)
+	require.True(t, errors.Is(err, ErrInvalidPolicyFilter))
 
-	err = r.Close()
+	_, _, err = engine.ExecStmt("CREATE POLICY acme_rows ON orders TO alice USING (tenant = 'acme')", nil, true)
 	require.NoError(t, err)
 
-	r, err = engine.QueryStmt("SELECT id, title, active, payload FROM table1 ORDER BY title", nil, true)
-	require.Equal(t, ErrLimitedOrderBy, err)
+	_, _, err = engine.ExecStmt("CREATE POLICY acme_rows ON orders TO bob USING (tenant = 'acme')", nil, true)
+	require.True(t, errors.Is(err, ErrPolicyAlreadyExists))
 
-	r, err = engine.QueryStmt("SELECT Id, Title, Active, payload FROM Table1 ORDER BY Id DESC", nil, true)
+	_, _, err = engine.ExecStmt("CREATE POLICY small_orders ON orders TO read USING (amount < 100)", nil, true)
 	require.NoError(t, err)
 
-	for i := 0; i < rowCount; i++ {
-		row, err := r.Read()
+	alice := WithUser(context.Background(), "alice", "readwrite")
+	bob := WithUser(context.Background(), "bob", "read")
+	carol := WithUser(context.Background(), "carol", "readwrite")
+
+	query := func(engine *Engine, ctx context.Context, sql string) []uint64 {
+		stmts, err := ParseString(sql)
 		require.NoError(t, err)
-		require.NotNil(t, row)
-		require.Len(t, row.Values, 4)
 
-		require.Equal(t, uint64(rowCount-1-i), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
-		require.Equal(t, fmt.Sprintf("title%d", rowCount-1-i), row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
-		require.Equal(t, (rowCount-1-i)%2 == 0, row.Values[EncodeSelector("", "db1", "table1", "active")].Value())
+		r, err := engine.QueryPreparedStmt(ctx, stmts[0].(*SelectStmt), nil, true)
+		require.NoError(t, err)
+		defer r.Close()
 
-		encPayload := []byte(fmt.Sprintf("blob%d", rowCount-1-i))
-		require.Equal(t, []byte(encPayload), row.Values[EncodeSelector("", "db1", "table1", "payload")].Value())
-	}
+		var ids []uint64
 
-	err = r.Close()
-	require.NoError(t, err)
+		for {
+			row, err := r.Read()
+			if err == ErrNoMoreRows {
+				break
+			}
+			require.NoError(t, err)
 
-	r, err = engine.QueryStmt("SELECT id FROM table1 WHERE id", nil, true)
-	require.NoError(t, err)
+			ids = append(ids, row.Values[EncodeSelector("", "db1", "orders", "id")].Value().(uint64))
+		}
 
-	_, err = r.Read()
-	require.Equal(t, ErrInvalidCondition, err)
+		return ids
+	}
 
-	err = r.Close()
-	require.NoError(t, err)
+	exec := func(engine *Engine, ctx context.Context, sql string) error {
+		stmts, err := ParseString(sql)
+		require.NoError(t, err)
 
-	r, err = engine.QueryStmt("SELECT id FROM table1 WHERE active = @some_param1", nil, true)
-	require.NoError(t, err)
+		_, _, err = engine.ExecPreparedStmts(ctx, stmts, nil, true)
+		return err
+	}
 
-	_, err = r.Read()
-	require.Equal(t, ErrMissingParameter, err)
+	checkPolicies := func(engine *Engine) {
+		require.Equal(t, []uint64{1, 2, 3, 4}, query(engine, context.Background(), "SELECT id FROM orders"))
+		require.Equal(t, []uint64{1, 3}, query(engine, alice, "SELECT id FROM orders"))
+		require.Equal(t, []uint64{3, 1}, query(engine, alice, "SELECT id FROM orders ORDER BY amount DESC"))
+		require.Equal(t, []uint64{1, 2}, query(engine, bob, "SELECT id FROM orders"))
+		require.Equal(t, []uint64{1, 2, 3}, query(engine, WithUser(context.Background(), "alice", "read"), "SELECT id FROM orders"))
+		require.Empty(t, query(engine, carol, "SELECT id FROM orders"))
 
-	err = r.Close()
-	require.NoError(t, err)
+		// policies are applied to every table referenced by the query
+		require.Equal(t, []uint64{1}, query(engine, alice, "SELECT id FROM (SELECT id FROM orders WHERE amount < 100)"))
+	}
 
-	params := make(map[string]interface{})
-	params["some_param"] = true
+	checkPolicies(engine)
 
-	encPayloadPrefix := hex.EncodeToString([]byte("blob"))
+	err = exec(engine, alice, "CREATE POLICY all_rows ON orders USING (TRUE)")
+	require.Equal(t, ErrPolicyChangeNotAllowed, err)
 
-	r, err = engine.QueryStmt(fmt.Sprintf("SELECT id, title, active FROM table1 WHERE active = @some_param AND title > 'title' AND payload >= x'%s' AND title LIKE 't", encPayloadPrefix), params, true)
-	require.NoError(t, err)
+	err = exec(engine, alice, "DROP POLICY acme_rows ON orders")
+	require.Equal(t, ErrPolicyChangeNotAllowed, err)
 
-	for i := 0; i < rowCount/2; i += 2 {
-		row, err := r.Read()
-		require.NoError(t, err)
-		require.NotNil(t, row)
-		require.Len(t, row.Values, 3)
+	_, _, err = engine.ExecStmt("ALTER TABLE orders RENAME COLUMN tenant TO customer", nil, true)
+	require.True(t, errors.Is(err, ErrColumnInUseByPolicy))
 
-		require.Equal(t, uint64(i), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
-		require.Equal(t, fmt.Sprintf("title%d", i), row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
-		require.Equal(t, params["some_param"], row.Values[EncodeSelector("", "db1", "table1", "active")].Value())
-	}
+	require.Equal(t, `CREATE TABLE orders (id INTEGER NOT NULL, tenant VARCHAR, amount INTEGER, PRIMARY KEY id);
+CREATE INDEX ON orders(amount);
+CREATE POLICY acme_rows ON orders TO alice USING ((tenant = 'acme'));
+CREATE POLICY small_orders ON orders TO read USING ((amount < 100));
+`, engine.catalog.dbsByName["db1"].DDL())
 
-	err = r.Close()
+	// policies are loaded from the catalog
+	engine, err = NewEngine(catalogStore, dataStore, prefix)
 	require.NoError(t, err)
 
-	r, err = engine.QueryStmt("SELECT * FROM table1 WHERE id = 0", nil, true)
+	err = engine.UseDatabase("db1")
 	require.NoError(t, err)
 
-	cols, err = r.Columns()
+	checkPolicies(engine)
+
+	// rows are changed only if they satisfy the policies both before and after the change
+	err = exec(engine, alice, "UPDATE orders SET amount = amount + 1")
 	require.NoError(t, err)
-	require.Len(t, cols, 5)
 
-	row, err = r.Read()
+	r, err := engine.QueryStmt("SELECT SUM(amount) FROM orders", nil, true)
 	require.NoError(t, err)
-	require.Len(t, row.Values, 5)
 
-	err = r.Close()
+	row, err := r.Read()
 	require.NoError(t, err)
+	require.Equal(t, uint64(732), row.Values[EncodeSelector("", "db1", "orders", "col0")].Value())
 
-	r, err = engine.QueryStmt("SELECT id, title, active FROM table1 WHERE id / 0", nil, true)
+	err = r.Close()
 	require.NoError(t, err)
 
-	_, err = r.Read()
-	require.Equal(t, ErrDivisionByZero, err)
+	err = exec(engine, alice, "UPDATE orders SET tenant = 'globex' WHERE id = 1")
+	require.Equal(t, ErrPolicyViolation, err)
 
-	err = r.Close()
+	err = exec(engine, alice, "UPSERT INTO orders (id, tenant, amount) VALUES (5, 'globex', 50)")
+	require.Equal(t, ErrPolicyViolation, err)
+
+	err = exec(engine, alice, "UPSERT INTO orders (id, tenant, amount) VALUES (2, 'acme', 50)")
+	require.Equal(t, ErrPolicyViolation, err)
+
+	err = exec(engine, alice, "INSERT INTO orders (id, tenant, amount) VALUES (5, 'acme', 50)")
 	require.NoError(t, err)
 
-	r, err = engine.QueryStmt("SELECT id, title, active FROM table1 WHERE id + 1/1 > 1 * (1 - 0)", nil, true)
+	err = exec(engine, alice, "DELETE FROM orders WHERE amount > 100")
 	require.NoError(t, err)
 
-	_, err = r.Read()
+	require.Equal(t, []uint64{1, 2, 4, 5}, query(engine, context.Background(), "SELECT id FROM orders"))
+
+	_, _, err = engine.ExecStmt("DROP POLICY acme_rows ON orders", nil, true)
 	require.NoError(t, err)
 
-	err = r.Close()
+	_, _, err = engine.ExecStmt("DROP POLICY acme_rows ON orders", nil, true)
+	require.True(t, errors.Is(err, ErrPolicyDoesNotExist))
+
+	require.Empty(t, query(engine, alice, "SELECT id FROM orders"))
+
+	_, _, err = engine.ExecStmt("DROP TABLE orders", nil, true)
 	require.NoError(t, err)
 
-	r, err = engine.QueryStmt("SELECT id, title, active FROM table1 WHERE id = 0 AND NOT active OR active", nil, true)
+	_, _, err = engine.ExecStmt("CREATE TABLE orders (id INTEGER, PRIMARY KEY id)", nil, true)
 	require.NoError(t, err)
 
-	_, err = r.Read()
+	_, _, err = engine.ExecStmt("INSERT INTO orders (id) VALUES (1)", nil, true)
 	require.NoError(t, err)
 
-	err = r.Close()
+	// policies of dropped tables are dropped as well
+	engine, err = NewEngine(catalogStore, dataStore, prefix)
 	require.NoError(t, err)
 
-	err = engine.Close()
+	err = engine.UseDatabase("db1")
 	require.NoError(t, err)
+
+	require.Equal(t, []uint64{1}, query(engine, bob, "SELECT id FROM orders"))
 }
 
-func TestQueryWithNullables(t *testing.T) {
-	catalogStore, err := store.Open("catalog_nullable", store.DefaultOptions())
+func TestViews(t *testing.T) {
+	catalogStore, err := store.Open("catalog_views", store.DefaultOptions())
 	require.NoError(t, err)
-	defer os.RemoveAll("catalog_nullable")
+	defer os.RemoveAll("catalog_views")
 
-	dataStore, err := store.Open("sqldata_nullable", store.DefaultOptions())
+	dataStore, err := store.Open("sqldata_views", store.DefaultOptions())
 	require.NoError(t, err)
-	defer os.RemoveAll("sqldata_nullable")
+	defer os.RemoveAll("sqldata_views")
 
 	engine, err := NewEngine(catalogStore, dataStore, prefix)
 	require.NoError(t, err)
 
+	_, _, err = engine.ExecStmt("CREATE VIEW acme_orders AS SELECT id FROM orders", nil, true)
+	require.Equal(t, ErrNoDatabaseSelected, err)
+
 	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
 	require.NoError(t, err)
 
 	err = engine.UseDatabase("db1")
 	require.NoError(t, err)
 
-	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id INTEGER, ts INTEGER, title VARCHAR, active BOOLEAN, PRIMARY KEY id)", nil, true)
+	_, _, err = engine.ExecStmt("CREATE VIEW acme_orders AS SELECT id FROM orders", nil, true)
+	require.True(t, errors.Is(err, ErrTableDoesNotExist))
+
+	_, _, err = engine.ExecStmt(`
+		CREATE TABLE orders (id INTEGER, tenant VARCHAR, amount INTEGER, PRIMARY KEY id);
+		CREATE TABLE tenants (name VARCHAR, country VARCHAR, PRIMARY KEY name);
+
+		INSERT INTO orders (id, tenant, amount) VALUES
+			(1, 'acme', 10), (2, 'globex', 20), (3, 'acme', 300), (4, 'globex', 400);
+
+		INSERT INTO tenants (name, country) VALUES ('acme', 'US'), ('globex', 'UK');
+	`, nil, true)
 	require.NoError(t, err)
 
-	_, _, err = engine.ExecStmt("INSERT INTO table1 (id, ts, title) VALUES (1, TIME(), 'title1')", nil, true)
-	require.Equal(t, ErrNoSupported, err)
+	_, _, err = engine.ExecStmt("CREATE VIEW acme_orders AS SELECT id, amount FROM orders WHERE tenant = 'acme'", nil, true)
+	require.NoError(t, err)
 
-	rowCount := 10
+	_, _, err = engine.ExecStmt("CREATE VIEW acme_orders AS SELECT id FROM orders", nil, true)
+	require.True(t, errors.Is(err, ErrViewAlreadyExists))
 
-	start := time.Now().UnixNano()
+	_, _, err = engine.ExecStmt("CREATE VIEW IF NOT EXISTS acme_orders AS SELECT id FROM orders", nil, true)
+	require.NoError(t, err)
 
-	for i := 0; i < rowCount; i++ {
-		_, _, err = engine.ExecStmt(fmt.Sprintf("UPSERT INTO table1 (id, ts, title) VALUES (%d, NOW(), 'title%d')", i, i), nil, true)
-		require.NoError(t, err)
-	}
+	_, _, err = engine.ExecStmt("CREATE VIEW orders AS SELECT id FROM tenants", nil, true)
+	require.True(t, errors.Is(err, ErrTableAlreadyExists))
+
+	_, _, err = engine.ExecStmt("CREATE TABLE acme_orders (id INTEGER, PRIMARY KEY id)", nil, true)
+	require.True(t, errors.Is(err, ErrViewAlreadyExists))
 
-	r, err := engine.QueryStmt("SELECT id, ts, title, active FROM table1 WHERE NOT(active != NULL)", nil, true)
+	_, _, err = engine.ExecStmt(`
+		CREATE VIEW big_acme_orders AS
+			SELECT id, amount
+			FROM acme_orders
+			WHERE amount > 100
+	`, nil, true)
 	require.NoError(t, err)
 
-	cols, err := r.Columns()
+	_, _, err = engine.ExecStmt("CREATE VIEW order_countries AS SELECT orders.id, tenants.country FROM orders INNER JOIN tenants ON orders.tenant = tenants.name", nil, true)
 	require.NoError(t, err)
-	require.Len(t, cols, 4)
 
-	for i := 0; i < rowCount; i++ {
-		row, err := r.Read()
+	_, _, err = engine.ExecStmt("CREATE VIEW v1 AS SELECT id FROM v1", nil, true)
+	require.True(t, errors.Is(err, ErrRecursiveView))
+
+	query := func(engine *Engine, sql string) [][]interface{} {
+		r, err := engine.QueryStmt(sql, nil, true)
 		require.NoError(t, err)
-		require.NotNil(t, row)
-		require.Len(t, row.Values, 4)
-		require.Less(t, uint64(start), row.Values[EncodeSelector("", "db1", "table1", "ts")].Value())
-		require.Equal(t, uint64(i), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
-		require.Equal(t, fmt.Sprintf("title%d", i), row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
-		require.Equal(t, &NullValue{t: BooleanType}, row.Values[EncodeSelector("", "db1", "table1", "active")])
+		defer r.Close()
+
+		cols, err := r.Columns()
+		require.NoError(t, err)
+
+		var rows [][]interface{}
+
+		for {
+			row, err := r.Read()
+			if err == ErrNoMoreRows {
+				break
+			}
+			require.NoError(t, err)
+
+			var vals []interface{}
+			for _, col := range cols {
+				vals = append(vals, row.Values[col.Selector].Value())
+			}
+
+			rows = append(rows, vals)
+		}
+
+		return rows
 	}
 
-	err = r.Close()
-	require.NoError(t, err)
+	checkViews := func(engine *Engine) {
+		require.Equal(t, [][]interface{}{{uint64(1), uint64(10)}, {uint64(3), uint64(300)}}, query(engine, "SELECT id, amount FROM acme_orders"))
+		require.Equal(t, [][]interface{}{{uint64(3)}, {uint64(1)}}, query(engine, "SELECT id FROM acme_orders ORDER BY amount DESC"))
+		require.Equal(t, [][]interface{}{{uint64(3)}}, query(engine, "SELECT a.id FROM (acme_orders AS a) WHERE a.amount > 100"))
+		require.Equal(t, [][]interface{}{{uint64(3), uint64(300)}}, query(engine, "SELECT id, amount FROM big_acme_orders"))
+		require.Equal(t, [][]interface{}{{uint64(2)}}, query(engine, "SELECT COUNT() FROM db1.acme_orders"))
+		require.Equal(t, [][]interface{}{{uint64(2), "UK"}, {uint64(4), "UK"}}, query(engine, "SELECT id, country FROM order_countries WHERE country = 'UK'"))
+
+		// views can be joined as any other data source
+		require.Equal(t, [][]interface{}{{uint64(1), "US"}, {uint64(3), "US"}}, query(engine, `
+			SELECT o.id, t.country
+			FROM (tenants AS t)
+			INNER JOIN (acme_orders AS o) ON t.name = 'acme'
+		`))
+	}
 
-	err = engine.Close()
-	require.NoError(t, err)
-}
+	checkViews(engine)
 
-func TestOrderBy(t *testing.T) {
-	catalogStore, err := store.Open("catalog_orderby", store.DefaultOptions())
-	require.NoError(t, err)
-	defer os.RemoveAll("catalog_orderby")
+	_, err = engine.QueryStmt("SELECT id FROM (acme_orders BEFORE TX 1)", nil, true)
+	require.True(t, errors.Is(err, ErrNoSupported))
 
-	dataStore, err := store.Open("sqldata_orderby", store.DefaultOptions())
+	_, _, err = engine.ExecStmt("UPSERT INTO orders (id, tenant, amount) VALUES (5, 'acme', 500)", nil, true)
 	require.NoError(t, err)
-	defer os.RemoveAll("sqldata_orderby")
 
-	engine, err := NewEngine(catalogStore, dataStore, prefix)
-	require.NoError(t, err)
+	// views read the rows at the time they are queried
+	require.Equal(t, [][]interface{}{{uint64(3)}, {uint64(5)}}, query(engine, "SELECT id FROM big_acme_orders"))
 
-	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
+	_, _, err = engine.ExecStmt("DELETE FROM orders WHERE id = 5", nil, true)
 	require.NoError(t, err)
 
-	_, _, err = engine.ExecStmt("CREATE INDEX ON table1(title)", nil, true)
-	require.Equal(t, ErrNoDatabaseSelected, err)
+	require.Equal(t, `CREATE TABLE orders (id INTEGER NOT NULL, tenant VARCHAR, amount INTEGER, PRIMARY KEY id);
+CREATE TABLE tenants (name VARCHAR NOT NULL, country VARCHAR, PRIMARY KEY name);
+CREATE VIEW acme_orders AS SELECT id, amount FROM orders WHERE tenant = 'acme';
+CREATE VIEW big_acme_orders AS SELECT id, amount
+			FROM acme_orders
+			WHERE amount > 100;
+CREATE VIEW order_countries AS SELECT orders.id, tenants.country FROM orders INNER JOIN tenants ON orders.tenant = tenants.name;
+`, engine.catalog.dbsByName["db1"].DDL())
 
-	err = engine.UseDatabase("db1")
+	// views are loaded from the catalog
+	engine, err = NewEngine(catalogStore, dataStore, prefix)
 	require.NoError(t, err)
 
-	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id INTEGER, title VARCHAR, age INTEGER, PRIMARY KEY id)", nil, true)
+	err = engine.UseDatabase("db1")
 	require.NoError(t, err)
 
-	_, err = engine.QueryStmt("SELECT id, title, age FROM table1 ORDER BY id, title DESC", nil, true)
-	require.Equal(t, ErrLimitedOrderBy, err)
+	checkViews(engine)
 
-	_, err = engine.QueryStmt("SELECT id, title, age FROM (SELECT id, title, age FROM table1) ORDER BY id", nil, true)
-	require.Equal(t, ErrLimitedOrderBy, err)
+	_, _, err = engine.ExecStmt("DROP VIEW order_countries", nil, true)
+	require.NoError(t, err)
 
-	_, err = engine.QueryStmt("SELECT id, title, age FROM (SELECT id, title, age FROM table1 AS t1) ORDER BY age DESC", nil, true)
-	require.Equal(t, ErrLimitedOrderBy, err)
+	_, _, err = engine.ExecStmt("DROP VIEW order_countries", nil, true)
+	require.True(t, errors.Is(err, ErrViewDoesNotExist))
 
-	_, err = engine.QueryStmt("SELECT id, title, age FROM table2 ORDER BY title", nil, true)
-	require.Equal(t, ErrTableDoesNotExist, err)
+	_, err = engine.QueryStmt("SELECT id FROM order_countries", nil, true)
+	require.True(t, errors.Is(err, ErrTableDoesNotExist))
 
-	_, err = engine.QueryStmt("SELECT id, title, age FROM table1 ORDER BY amount", nil, true)
-	require.Equal(t, ErrColumnDoesNotExist, err)
+	engine, err = NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
 
-	_, _, err = engine.ExecStmt("CREATE INDEX ON table1(title)", nil, true)
+	err = engine.UseDatabase("db1")
 	require.NoError(t, err)
 
-	_, err = engine.QueryStmt("SELECT id, title, age FROM table1 ORDER BY age", nil, true)
-	require.Equal(t, ErrLimitedOrderBy, err)
+	require.False(t, engine.catalog.dbsByName["db1"].ExistView("order_countries"))
+	require.True(t, engine.catalog.dbsByName["db1"].ExistView("big_acme_orders"))
 
-	_, _, err = engine.ExecStmt("CREATE INDEX ON table1(age)", nil, true)
+	_, _, err = engine.ExecStmt("DROP VIEW acme_orders", nil, true)
 	require.NoError(t, err)
 
-	params := make(map[string]interface{}, 1)
-	params["age"] = nil
-	_, _, err = engine.ExecStmt("UPSERT INTO table1 (id, title, age) VALUES (1, 'title', @age)", params, true)
-	require.Equal(t, ErrIndexedColumnCanNotBeNull, err)
+	_, err = engine.QueryStmt("SELECT id FROM big_acme_orders", nil, true)
+	require.True(t, errors.Is(err, ErrTableDoesNotExist))
 
-	_, _, err = engine.ExecStmt("UPSERT INTO table1 (id, title) VALUES (1, 'title')", nil, true)
-	require.Equal(t, ErrIndexedColumnCanNotBeNull, err)
+	// a view can not read itself through other views
+	_, _, err = engine.ExecStmt("CREATE VIEW acme_orders AS SELECT id, amount FROM big_acme_orders", nil, true)
+	require.True(t, errors.Is(err, ErrRecursiveView))
+}
 
-	rowCount := 1
+func TestDatabaseDDL(t *testing.T) {
+	catalogStore, err := store.Open("catalog_ddl", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_ddl")
 
-	for i := 0; i < rowCount; i++ {
-		params := make(map[string]interface{}, 3)
-		params["id"] = i
-		params["title"] = fmt.Sprintf("title%d", i)
-		params["age"] = 40 + i
+	dataStore, err := store.Open("sqldata_ddl", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_ddl")
 
-		_, _, err = engine.ExecStmt("UPSERT INTO table1 (id, title, age) VALUES (@id, @title, @age)", params, true)
-		require.NoError(t, err)
-	}
+	engine, err := NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
 
-	r, err := engine.QueryStmt("SELECT id, title, age FROM table1 ORDER BY title", nil, true)
+	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
 	require.NoError(t, err)
 
-	for i := 0; i < rowCount; i++ {
-		row, err := r.Read()
-		require.NoError(t, err)
-		require.NotNil(t, row)
-		require.Len(t, row.Values, 3)
+	db1, err := engine.Catalog().GetDatabaseByName("db1")
+	require.NoError(t, err)
+	require.Empty(t, db1.DDL())
 
-		require.Equal(t, uint64(i), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
-		require.Equal(t, fmt.Sprintf("title%d", i), row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
-		require.Equal(t, uint64(40+i), row.Values[EncodeSelector("", "db1", "table1", "age")].Value())
-	}
+	err = engine.UseDatabase("db1")
+	require.NoError(t, err)
 
-	err = r.Close()
+	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id INTEGER, title VARCHAR NOT NULL, active BOOLEAN, PRIMARY KEY id)", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE INDEX ON table1(active)", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE TABLE table2 (name VARCHAR, data BLOB, PRIMARY KEY name)", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("ALTER TABLE table2 ADD COLUMN ts TIMESTAMP", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("ALTER TABLE table2 RENAME COLUMN data TO payload", nil, true)
+	require.NoError(t, err)
+
+	ddl := db1.DDL()
+	require.Equal(t, "CREATE TABLE table1 (id INTEGER NOT NULL, title VARCHAR NOT NULL, active BOOLEAN, PRIMARY KEY id);\n"+
+		"CREATE INDEX ON table1(active);\n"+
+		"CREATE TABLE table2 (name VARCHAR NOT NULL, payload BLOB, ts TIMESTAMP, PRIMARY KEY name);\n", ddl)
+
+	_, _, err = engine.ExecStmt("CREATE DATABASE db2", nil, true)
+	require.NoError(t, err)
+
+	err = engine.UseDatabase("db2")
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt(ddl, nil, true)
+	require.NoError(t, err)
+
+	db2, err := engine.Catalog().GetDatabaseByName("db2")
+	require.NoError(t, err)
+	require.Equal(t, ddl, db2.DDL())
+}
+
+func TestUpsertInto(t *testing.T) {
+	catalogStore, err := store.Open("catalog_upsert", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_upsert")
+
+	dataStore, err := store.Open("sqldata_upsert", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_upsert")
+
+	engine, err := NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
+	require.NoError(t, err)
+
+	err = engine.UseDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("UPSERT INTO table1 (id, title) VALUES (1, 'title1')", nil, true)
+	require.True(t, errors.Is(err, ErrTableDoesNotExist))
+
+	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id INTEGER, title VARCHAR, active BOOLEAN NOT NULL, PRIMARY KEY id)", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("UPSERT INTO table1 (id, title) VALUES (1, 'title1')", nil, true)
+	require.Equal(t, ErrNotNullableColumnCannotBeNull, err)
+
+	_, _, err = engine.ExecStmt("UPSERT INTO table1 (id, age) VALUES (1, 50)", nil, true)
+	require.True(t, errors.Is(err, ErrColumnDoesNotExist))
+
+	_, _, err = engine.ExecStmt("UPSERT INTO table1 (id, title) VALUES (@id, 'title1')", nil, true)
+	require.Equal(t, ErrMissingParameter, err)
+
+	params := make(map[string]interface{}, 1)
+	params["id"] = [4]byte{1, 2, 3, 4}
+	_, _, err = engine.ExecStmt("UPSERT INTO table1 (id, title) VALUES (@id, 'title1')", params, true)
+	require.Equal(t, ErrUnsupportedParameter, err)
+
+	params = make(map[string]interface{}, 1)
+	params["id"] = []byte{1, 2, 3}
+	_, _, err = engine.ExecStmt("UPSERT INTO table1 (id, title) VALUES (@id, 'title1')", params, true)
+	require.Equal(t, ErrInvalidValue, err)
+
+	_, _, err = engine.ExecStmt("UPSERT INTO table1 (id, title) VALUES (1, @title)", nil, true)
+	require.Equal(t, ErrMissingParameter, err)
+
+	params = make(map[string]interface{}, 1)
+	params["title"] = uint64(1)
+	_, _, err = engine.ExecStmt("UPSERT INTO table1 (id, title) VALUES (1, @title)", params, true)
+	require.Equal(t, ErrInvalidValue, err)
+
+	_, _, err = engine.ExecStmt("UPSERT INTO Table1 (id, active) VALUES (1, true)", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("UPSERT INTO table1 (Id, Title, Active) VALUES (1, 'some title', false)", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("UPSERT INTO table1 (id, title, active) VALUES (2, 'another title', true)", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("UPSERT INTO table1 (id) VALUES (1, 'yat')", nil, true)
+	require.Equal(t, ErrInvalidNumberOfValues, err)
+
+	_, _, err = engine.ExecStmt("UPSERT INTO table1 (id, id) VALUES (1, 2)", nil, true)
+	require.Equal(t, ErrDuplicatedColumn, err)
+
+	_, _, err = engine.ExecStmt("UPSERT INTO table1 (id) VALUES ('1')", nil, true)
+	require.Equal(t, ErrInvalidValue, err)
+
+	_, _, err = engine.ExecStmt("UPSERT INTO table1 (id) VALUES (NULL)", nil, true)
+	require.Equal(t, ErrPKCanNotBeNull, err)
+
+	_, _, err = engine.ExecStmt("UPSERT INTO table1 (id, title, active) VALUES (2, NULL, true)", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("UPSERT INTO table1 (title) VALUES ('interesting title')", nil, true)
+	require.Equal(t, ErrPKCanNotBeNull, err)
+
+	_, _, err = engine.ExecStmt("CREATE TABLE table2 (id INTEGER, age INTEGER, PRIMARY KEY id)", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE INDEX ON table2(age)", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("UPSERT INTO table2 (id, age) VALUES (1, 10), (2, 20)", nil, true)
+	require.NoError(t, err)
+
+	// the index entry of the replaced value must be removed
+	_, _, err = engine.ExecStmt("UPSERT INTO table2 (id, age) VALUES (1, 30)", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("UPSERT INTO table2 (id, age) VALUES (2, 20)", nil, true)
+	require.NoError(t, err)
+
+	r, err := engine.QueryStmt("SELECT id, age FROM table2 ORDER BY age", nil, true)
+	require.NoError(t, err)
+
+	for _, expected := range []uint64{20, 30} {
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.Equal(t, expected, row.Values[EncodeSelector("", "db1", "table2", "age")].Value())
+	}
+
+	_, err = r.Read()
+	require.Equal(t, ErrNoMoreRows, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+}
+
+func TestDeleteFrom(t *testing.T) {
+	catalogStore, err := store.Open("catalog_delete", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_delete")
+
+	dataStore, err := store.Open("sqldata_delete", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_delete")
+
+	engine, err := NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
+	require.NoError(t, err)
+
+	err = engine.UseDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("DELETE FROM table1", nil, true)
+	require.True(t, errors.Is(err, ErrTableDoesNotExist))
+
+	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id INTEGER, title VARCHAR, active BOOLEAN, PRIMARY KEY id)", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE INDEX ON table1(active)", nil, true)
+	require.NoError(t, err)
+
+	_, dmTxs, err := engine.ExecStmt("INSERT INTO table1 (id, title, active) VALUES (1, 'title1', true), (2, 'title2', false), (3, 'title3', true)", nil, true)
+	require.NoError(t, err)
+	require.Len(t, dmTxs, 1)
+
+	queryIDs := func(sql string) []uint64 {
+		r, err := engine.QueryStmt(sql, nil, true)
+		require.NoError(t, err)
+		defer r.Close()
+
+		var ids []uint64
+		for {
+			row, err := r.Read()
+			if err == ErrNoMoreRows {
+				break
+			}
+			require.NoError(t, err)
+
+			ids = append(ids, row.Values[EncodeSelector("", "db1", "table1", "id")].Value().(uint64))
+		}
+
+		return ids
+	}
+
+	_, _, err = engine.ExecStmt("DELETE FROM table1 WHERE id = @id", nil, true)
+	require.Equal(t, ErrMissingParameter, err)
+
+	_, _, err = engine.ExecStmt("DELETE FROM table1 WHERE title", nil, true)
+	require.Equal(t, ErrInvalidCondition, err)
+
+	// no tx is committed when no row satisfies the condition
+	_, dmTxs, err = engine.ExecStmt("DELETE FROM table1 WHERE id > 3", nil, true)
+	require.NoError(t, err)
+	require.Empty(t, dmTxs)
+
+	_, dmTxs, err = engine.ExecStmt("DELETE FROM table1 WHERE id = @id", map[string]interface{}{"id": 1}, true)
+	require.NoError(t, err)
+	require.Len(t, dmTxs, 1)
+
+	deletionTx := dmTxs[0].ID
+
+	require.Equal(t, []uint64{2, 3}, queryIDs("SELECT id FROM table1"))
+	require.Equal(t, []uint64{3}, queryIDs("SELECT id FROM table1 WHERE active = true"))
+	require.Equal(t, []uint64{2, 3}, queryIDs("SELECT id FROM table1 ORDER BY active"))
+
+	// deleted rows remain part of the history
+	require.Equal(t, []uint64{1, 2, 3}, queryIDs(fmt.Sprintf("SELECT id FROM (table1 BEFORE TX %d)", deletionTx)))
+
+	_, _, err = engine.ExecStmt("DELETE FROM table1 WHERE id = 1", nil, true)
+	require.NoError(t, err)
+
+	// deleted rows can be inserted again
+	_, _, err = engine.ExecStmt("INSERT INTO table1 (id, title, active) VALUES (1, 'title1', false)", nil, true)
+	require.NoError(t, err)
+
+	require.Equal(t, []uint64{1, 2, 3}, queryIDs("SELECT id FROM table1"))
+	require.Equal(t, []uint64{1, 2}, queryIDs("SELECT id FROM table1 WHERE active = false"))
+
+	_, dmTxs, err = engine.ExecStmt("DELETE FROM db1.table1", nil, true)
+	require.NoError(t, err)
+	require.Len(t, dmTxs, 1)
+	require.Equal(t, 6, dmTxs[0].NEntries)
+
+	require.Empty(t, queryIDs("SELECT id FROM table1"))
+	require.Empty(t, queryIDs("SELECT id FROM table1 ORDER BY active DESC"))
+}
+
+func TestUpdate(t *testing.T) {
+	catalogStore, err := store.Open("catalog_update", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_update")
+
+	dataStore, err := store.Open("sqldata_update", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_update")
+
+	engine, err := NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
+	require.NoError(t, err)
+
+	err = engine.UseDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("UPDATE table1 SET title = 'title'", nil, true)
+	require.True(t, errors.Is(err, ErrTableDoesNotExist))
+
+	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id INTEGER, title VARCHAR NOT NULL, amount INTEGER, active BOOLEAN, PRIMARY KEY id)", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE INDEX ON table1(active)", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("INSERT INTO table1 (id, title, amount, active) VALUES (1, 'title1', 10, true), (2, 'title2', 20, false), (3, 'title3', NULL, true)", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("UPDATE table1 SET id = 4 WHERE id = 1", nil, true)
+	require.Equal(t, ErrPKCanNotBeUpdated, err)
+
+	_, _, err = engine.ExecStmt("UPDATE table1 SET title = 'a', title = 'b'", nil, true)
+	require.Equal(t, ErrDuplicatedColumn, err)
+
+	_, _, err = engine.ExecStmt("UPDATE table1 SET age = 1", nil, true)
+	require.True(t, errors.Is(err, ErrColumnDoesNotExist))
+
+	_, _, err = engine.ExecStmt("UPDATE table1 SET title = NULL WHERE id = 1", nil, true)
+	require.Equal(t, ErrNotNullableColumnCannotBeNull, err)
+
+	_, _, err = engine.ExecStmt("UPDATE table1 SET active = NULL WHERE id = 1", nil, true)
+	require.Equal(t, ErrIndexedColumnCanNotBeNull, err)
+
+	_, _, err = engine.ExecStmt("UPDATE table1 SET amount = 'ten' WHERE id = 1", nil, true)
+	require.Equal(t, ErrInvalidValue, err)
+
+	_, _, err = engine.ExecStmt("UPDATE table1 SET amount = @amount WHERE id = 1", nil, true)
+	require.Equal(t, ErrMissingParameter, err)
+
+	// no tx is committed when no row satisfies the condition
+	_, dmTxs, err := engine.ExecStmt("UPDATE table1 SET amount = 0 WHERE id > 3", nil, true)
+	require.NoError(t, err)
+	require.Empty(t, dmTxs)
+
+	_, dmTxs, err = engine.ExecStmt("UPDATE table1 SET amount = amount + @delta, active = false WHERE active = true AND amount >= 10", map[string]interface{}{"delta": 5}, true)
+	require.NoError(t, err)
+	require.Len(t, dmTxs, 1)
+
+	updateTx := dmTxs[0].ID
+
+	query := func(sql string) map[uint64]*Row {
+		r, err := engine.QueryStmt(sql, nil, true)
+		require.NoError(t, err)
+		defer r.Close()
+
+		rows := make(map[uint64]*Row)
+		for {
+			row, err := r.Read()
+			if err == ErrNoMoreRows {
+				break
+			}
+			require.NoError(t, err)
+
+			id := row.Values[EncodeSelector("", "db1", "table1", "id")].Value().(uint64)
+			require.NotContains(t, rows, id)
+
+			rows[id] = row
+		}
+
+		return rows
+	}
+
+	rows := query("SELECT id, title, amount, active FROM table1")
+	require.Len(t, rows, 3)
+	require.Equal(t, "title1", rows[1].Values[EncodeSelector("", "db1", "table1", "title")].Value())
+	require.Equal(t, uint64(15), rows[1].Values[EncodeSelector("", "db1", "table1", "amount")].Value())
+	require.Equal(t, false, rows[1].Values[EncodeSelector("", "db1", "table1", "active")].Value())
+	require.Equal(t, uint64(20), rows[2].Values[EncodeSelector("", "db1", "table1", "amount")].Value())
+	require.Equal(t, true, rows[3].Values[EncodeSelector("", "db1", "table1", "active")].Value())
+
+	// rows are no longer found by their previous indexed values
+	rows = query("SELECT id FROM table1 ORDER BY active DESC")
+	require.Len(t, rows, 3)
+
+	rows = query("SELECT id FROM table1 WHERE active = true")
+	require.Len(t, rows, 1)
+	require.Contains(t, rows, uint64(3))
+
+	rows = query(fmt.Sprintf("SELECT id, amount FROM (table1 BEFORE TX %d)", updateTx))
+	require.Equal(t, uint64(10), rows[1].Values[EncodeSelector("", "db1", "table1", "amount")].Value())
+
+	_, _, err = engine.ExecStmt("UPDATE db1.table1 SET title = 'untitled'", nil, true)
+	require.NoError(t, err)
+
+	rows = query("SELECT id, title FROM table1 WHERE title = 'untitled'")
+	require.Len(t, rows, 3)
+}
+
+func TestTransactions(t *testing.T) {
+	catalogStore, err := store.Open("catalog_tx", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_tx")
+
+	dataStore, err := store.Open("sqldata_tx", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_tx")
+
+	engine, err := NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
+	require.NoError(t, err)
+
+	err = engine.UseDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt(`CREATE TABLE table1 (
+									id INTEGER, 
+									title VARCHAR, 
+									PRIMARY KEY id
+								)`, nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt(`
+		BEGIN TRANSACTION
+			CREATE INDEX ON table2(title)
+		COMMIT
+		`, nil, true)
+	require.True(t, errors.Is(err, ErrTableDoesNotExist))
+
+	_, _, err = engine.ExecStmt(`
+		BEGIN TRANSACTION
+			UPSERT INTO table1 (id, title) VALUES (1, 'title1');
+			UPSERT INTO table1 (id, title) VALUES (2, 'title2');
+		COMMIT
+		`, nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt(`
+		BEGIN TRANSACTION
+			CREATE TABLE table2 (id INTEGER, title VARCHAR, age INTEGER, PRIMARY KEY id);
+			CREATE INDEX ON table2(title);
+		COMMIT
+		`, nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt(`
+		BEGIN TRANSACTION
+			CREATE INDEX ON table2(age);
+			INSERT INTO table2 (id, title, age) VALUES (1, 'title1', 40);
+		COMMIT
+		`, nil, true)
+	require.Equal(t, ErrDDLorDMLTxOnly, err)
+
+	// transactions spanning several requests are handled by sessions
+	_, _, err = engine.ExecStmt("BEGIN; UPSERT INTO table1 (id, title) VALUES (3, 'title3'); COMMIT", nil, true)
+	require.Equal(t, ErrSessionTxStmt, err)
+}
+
+func TestExecBatch(t *testing.T) {
+	catalogStore, err := store.Open("catalog_batch", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_batch")
+
+	dataStore, err := store.Open("sqldata_batch", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_batch")
+
+	engine, err := NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
+	require.NoError(t, err)
+
+	err = engine.UseDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt(`
+		CREATE TABLE table1 (id INTEGER AUTO_INCREMENT, title VARCHAR UNIQUE, PRIMARY KEY id)
+	`, nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecBatch(context.Background(), nil, true)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	insert := mustParse(t, "INSERT INTO table1 (title) VALUES (@title)")[0]
+
+	batch := []*BatchStmt{
+		{Stmt: insert, Params: map[string]interface{}{"title": "title1"}},
+		{Stmt: insert, Params: map[string]interface{}{"title": "title2"}},
+		{Stmt: mustParse(t, "INSERT INTO table1 (title) VALUES ('title3'), ('title4')")[0]},
+	}
+
+	summary, stmtSummaries, err := engine.ExecBatch(context.Background(), batch, true)
+	require.NoError(t, err)
+	require.Len(t, summary.DMTxs, 1)
+	require.Equal(t, map[string]uint64{"table1": 4}, summary.LastInsertedPKs)
+	require.Equal(t, []*StmtSummary{
+		{UpdatedRows: 1, LastInsertedPKs: map[string]uint64{"table1": 1}},
+		{UpdatedRows: 1, LastInsertedPKs: map[string]uint64{"table1": 2}},
+		{UpdatedRows: 2, LastInsertedPKs: map[string]uint64{"table1": 4}},
+	}, stmtSummaries)
+
+	batch = []*BatchStmt{
+		{Stmt: mustParse(t, "UPDATE table1 SET title = @title WHERE id = @id")[0], Params: map[string]interface{}{"id": 1, "title": "title10"}},
+		{Stmt: mustParse(t, "DELETE FROM table1 WHERE id > @id")[0], Params: map[string]interface{}{"id": 2}},
+		{Stmt: mustParse(t, "DELETE FROM table1 WHERE id > @id")[0], Params: map[string]interface{}{"id": 10}},
+	}
+
+	summary, stmtSummaries, err = engine.ExecBatch(context.Background(), batch, true)
+	require.NoError(t, err)
+	require.Len(t, summary.DMTxs, 1)
+	require.Equal(t, 1, stmtSummaries[0].UpdatedRows)
+	require.Equal(t, 2, stmtSummaries[1].UpdatedRows)
+	require.Equal(t, 0, stmtSummaries[2].UpdatedRows)
+
+	// the batch is executed as a whole or not at all
+	batch = []*BatchStmt{
+		{Stmt: insert, Params: map[string]interface{}{"title": "title5"}},
+		{Stmt: insert, Params: map[string]interface{}{"title": "title10"}},
+	}
+
+	_, _, err = engine.ExecBatch(context.Background(), batch, true)
+	require.True(t, errors.Is(err, ErrUniqueConstraintViolation))
+
+	batch = []*BatchStmt{
+		{Stmt: insert, Params: map[string]interface{}{"title": "title6"}},
+		{Stmt: insert, Params: map[string]interface{}{"ttl": "title7"}},
+	}
+
+	_, _, err = engine.ExecBatch(context.Background(), batch, true)
+	require.True(t, errors.Is(err, ErrMissingParameter))
+
+	r, err := engine.QueryStmt("SELECT COUNT() AS c FROM table1", nil, true)
+	require.NoError(t, err)
+
+	row, err := r.Read()
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), row.Values[EncodeSelector("", "db1", "table1", "c")].Value())
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	batch = []*BatchStmt{
+		{Stmt: mustParse(t, "CREATE TABLE table2 (id INTEGER, PRIMARY KEY id)")[0]},
+		{Stmt: insert, Params: map[string]interface{}{"title": "title8"}},
+	}
+
+	_, _, err = engine.ExecBatch(context.Background(), batch, true)
+	require.Equal(t, ErrDDLorDMLTxOnly, err)
+	require.False(t, engine.catalog.dbsByName["db1"].ExistTable("table2"))
+}
+
+func TestIsIdempotent(t *testing.T) {
+	catalogStore, err := store.Open("catalog_idempotent", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_idempotent")
+
+	dataStore, err := store.Open("sqldata_idempotent", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_idempotent")
+
+	engine, err := NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
+	require.NoError(t, err)
+
+	err = engine.UseDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id INTEGER AUTO_INCREMENT, title VARCHAR, amount INTEGER, PRIMARY KEY id)", nil, true)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		sql        string
+		idempotent bool
+	}{
+		{"UPSERT INTO table1 (id, title) VALUES (1, 'title1'), (@id, @title)", true},
+		{"UPSERT INTO table1 (title) VALUES ('title1')", false},
+		{"UPSERT INTO table1 (id, title) VALUES (1, NOW())", false},
+		{"UPSERT INTO table2 (id, title) VALUES (1, 'title1')", false},
+		{"INSERT INTO table1 (id, title) VALUES (1, 'title1')", false},
+		{"UPDATE table1 SET title = @title, amount = 10 WHERE id = 1", true},
+		{"UPDATE table1 SET amount = amount + 1 WHERE id = 1", false},
+		{"DELETE FROM table1 WHERE amount > 10", true},
+		{"CREATE INDEX ON table1(title)", false},
+		{"SELECT id FROM table1", false},
+	}
+
+	for i, tc := range testCases {
+		stmts, err := ParseString(tc.sql)
+		require.NoError(t, err)
+		require.Equal(t, tc.idempotent, engine.IsIdempotent(stmts[0]), fmt.Sprintf("failed on iteration %d", i))
+	}
+}
+
+func TestQueryAsOfTimestamp(t *testing.T) {
+	catalogStore, err := store.Open("catalog_as_of", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_as_of")
+
+	dataStore, err := store.Open("sqldata_as_of", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_as_of")
+
+	engine, err := NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
+	require.NoError(t, err)
+
+	err = engine.UseDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id INTEGER, title VARCHAR, PRIMARY KEY id)", nil, true)
+	require.NoError(t, err)
+
+	_, dmTxs, err := engine.ExecStmt("INSERT INTO table1 (id, title) VALUES (1, 'title1')", nil, true)
+	require.NoError(t, err)
+	require.Len(t, dmTxs, 1)
+
+	ts1 := dmTxs[0].Ts
+
+	// let the clock move on so that the next tx gets a different timestamp
+	time.Sleep(time.Until(time.Unix(ts1+1, 0)))
+
+	_, dmTxs, err = engine.ExecStmt("UPSERT INTO table1 (id, title) VALUES (1, 'title2'), (2, 'title2')", nil, true)
+	require.NoError(t, err)
+	require.Len(t, dmTxs, 1)
+	require.Greater(t, dmTxs[0].Ts, ts1)
+
+	countRows := func(sql string, params ...map[string]interface{}) int {
+		var ps map[string]interface{}
+		if len(params) > 0 {
+			ps = params[0]
+		}
+
+		r, err := engine.QueryStmt(sql, ps, true)
+		require.NoError(t, err)
+		defer r.Close()
+
+		n := 0
+		for {
+			_, err := r.Read()
+			if err == ErrNoMoreRows {
+				break
+			}
+			require.NoError(t, err)
+			n++
+		}
+
+		return n
+	}
+
+	require.Equal(t, 0, countRows(fmt.Sprintf("SELECT id FROM (table1 AS OF TIMESTAMP %d)", ts1-1)))
+	require.Equal(t, 1, countRows(fmt.Sprintf("SELECT id FROM (table1 AS OF TIMESTAMP %d)", ts1)))
+	require.Equal(t, 2, countRows(fmt.Sprintf("SELECT id FROM (table1 AS OF TIMESTAMP %d AS t)", dmTxs[0].Ts)))
+
+	// the clause doesn't need parentheses, and the timestamp may be given by any value
+	require.Equal(t, 1, countRows(fmt.Sprintf("SELECT id FROM table1 AS OF TIMESTAMP %d", ts1)))
+	require.Equal(t, 1, countRows(fmt.Sprintf("SELECT id FROM table1 AS OF TIMESTAMP %d WHERE id = 1", dmTxs[0].Ts)))
+	require.Equal(t, 2, countRows("SELECT id FROM table1 AS OF TIMESTAMP NOW()"))
+	require.Equal(t, 1, countRows("SELECT id FROM table1 AS OF TIMESTAMP @ts", map[string]interface{}{"ts": time.Unix(ts1, 0)}))
+	require.Equal(t, 0, countRows("SELECT id FROM table1 AS OF TIMESTAMP $1", map[string]interface{}{"param1": time.Unix(ts1-1, 0)}))
+	require.Equal(t, 1, countRows(fmt.Sprintf("SELECT id FROM table1 AS OF TIMESTAMP '%s'", time.Unix(ts1, 0).UTC().Format(time.RFC3339))))
+	require.Equal(t, 2, countRows(fmt.Sprintf("SELECT id FROM (table1 AS OF TIMESTAMP '%s' AS t)", time.Unix(dmTxs[0].Ts, 0).UTC().Format(time.RFC3339))))
+
+	_, err = engine.QueryStmt("SELECT id FROM table1 AS OF TIMESTAMP 'yesterday'", nil, true)
+	require.True(t, errors.Is(err, ErrInvalidValue))
+
+	_, err = engine.QueryStmt("SELECT id FROM table1 AS OF TIMESTAMP true", nil, true)
+	require.True(t, errors.Is(err, ErrInvalidValue))
+
+	_, err = engine.QueryStmt("SELECT id FROM table1 AS OF TIMESTAMP @ts", nil, true)
+	require.True(t, errors.Is(err, ErrMissingParameter))
+
+	r, err := engine.QueryStmt(fmt.Sprintf("SELECT title FROM (table1 AS OF TIMESTAMP %d AS t) WHERE id = 1", ts1), nil, true)
+	require.NoError(t, err)
+
+	row, err := r.Read()
+	require.NoError(t, err)
+	require.Equal(t, "title1", row.Values[EncodeSelector("", "db1", "t", "title")].Value())
+
+	err = r.Close()
+	require.NoError(t, err)
+}
+
+func TestQueryWithIsolationLevel(t *testing.T) {
+	catalogStore, err := store.Open("catalog_isolation", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_isolation")
+
+	dataStore, err := store.Open("sqldata_isolation", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_isolation")
+
+	engine, err := NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
+
+	require.Equal(t, SnapshotIsolation, engine.IsolationLevel())
+
+	err = engine.SetIsolationLevel(IsolationLevel(99))
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
+	require.NoError(t, err)
+
+	err = engine.UseDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id INTEGER, PRIMARY KEY id)", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("INSERT INTO table1 (id) VALUES (1)", nil, true)
+	require.NoError(t, err)
+
+	countRows := func(renewSnapshot bool) int {
+		r, err := engine.QueryStmt("SELECT id FROM table1", nil, renewSnapshot)
+		require.NoError(t, err)
+		defer r.Close()
+
+		n := 0
+		for {
+			_, err := r.Read()
+			if err == ErrNoMoreRows {
+				break
+			}
+			require.NoError(t, err)
+			n++
+		}
+
+		return n
+	}
+
+	require.Equal(t, 1, countRows(true))
+
+	_, _, err = engine.ExecStmt("INSERT INTO table1 (id) VALUES (2)", nil, false)
+	require.NoError(t, err)
+
+	// the snapshot in use is kept with snapshot isolation
+	require.Equal(t, 1, countRows(false))
+
+	err = engine.SetIsolationLevel(ReadCommitted)
+	require.NoError(t, err)
+	require.Equal(t, ReadCommitted, engine.IsolationLevel())
+
+	// every committed tx is visible with read-committed, even when indexing was not awaited
+	require.Equal(t, 2, countRows(false))
+
+	_, _, err = engine.ExecStmt("INSERT INTO table1 (id) VALUES (3)", nil, false)
+	require.NoError(t, err)
+
+	require.Equal(t, 3, countRows(false))
+
+	err = engine.SetIsolationLevel(SnapshotIsolation)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("INSERT INTO table1 (id) VALUES (4)", nil, true)
+	require.NoError(t, err)
+
+	require.Equal(t, 3, countRows(false))
+	require.Equal(t, 4, countRows(true))
+
+	err = engine.Close()
+	require.NoError(t, err)
+
+	err = engine.SetIsolationLevel(ReadCommitted)
+	require.Equal(t, ErrAlreadyClosed, err)
+}
+
+func TestUseSnapshot(t *testing.T) {
+	catalogStore, err := store.Open("catalog_snap", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_snap")
+
+	dataStore, err := store.Open("sqldata_snap", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_snap")
+
+	engine, err := NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
+	require.NoError(t, err)
+
+	err = engine.UseDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id INTEGER, title VARCHAR, PRIMARY KEY id)", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("USE SNAPSHOT SINCE TX 1", nil, true)
+	require.Equal(t, ErrNoSupported, err)
+
+	err = engine.UseSnapshot(1, 1)
+	require.Equal(t, ErrTxDoesNotExist, err)
+
+	err = engine.UseSnapshot(0, 1)
+	require.Equal(t, ErrTxDoesNotExist, err)
+
+	err = engine.UseSnapshot(1, 1)
+	require.Equal(t, ErrTxDoesNotExist, err)
+
+	err = engine.UseSnapshot(1, 2)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, _, err = engine.ExecStmt(`
+		BEGIN TRANSACTION
+			UPSERT INTO table1 (id, title) VALUES (1, 'title1');
+			UPSERT INTO table1 (id, title) VALUES (2, 'title2');
+		COMMIT
+		`, nil, true)
+	require.NoError(t, err)
+
+	err = engine.UseSnapshot(1, 0)
+	require.NoError(t, err)
+
+	err = engine.CloseSnapshot()
+	require.NoError(t, err)
+
+	err = engine.UseSnapshot(0, 1)
+	require.NoError(t, err)
+
+	err = engine.UseSnapshot(1, 1)
+	require.NoError(t, err)
+}
+
+func TestEncodeRawValue(t *testing.T) {
+	_, err := EncodeRawValue(uint64(1), IntegerType, true)
+	require.NoError(t, err)
+
+	_, err = EncodeRawValue(true, IntegerType, true)
+	require.Equal(t, ErrInvalidValue, err)
+
+	_, err = EncodeRawValue(true, BooleanType, true)
+	require.NoError(t, err)
+
+	_, err = EncodeRawValue(uint64(1), BooleanType, true)
+	require.Equal(t, ErrInvalidValue, err)
+
+	_, err = EncodeRawValue("title", VarcharType, true)
+	require.NoError(t, err)
+
+	_, err = EncodeRawValue(uint64(1), VarcharType, true)
+	require.Equal(t, ErrInvalidValue, err)
+
+	_, err = EncodeRawValue([]byte{}, BLOBType, true)
+	require.NoError(t, err)
+
+	_, err = EncodeRawValue(nil, BLOBType, true)
+	require.NoError(t, err)
+
+	_, err = EncodeRawValue(uint64(1), BLOBType, true)
+	require.Equal(t, ErrInvalidValue, err)
+
+	_, err = EncodeRawValue(float64(1.5), FloatType, true)
+	require.NoError(t, err)
+
+	_, err = EncodeRawValue(uint64(1), FloatType, true)
+	require.Equal(t, ErrInvalidValue, err)
+
+	_, err = EncodeRawValue(time.Now(), TimestampType, true)
+	require.NoError(t, err)
+
+	_, err = EncodeRawValue(uint64(1), TimestampType, true)
+	require.Equal(t, ErrInvalidValue, err)
+
+	_, err = EncodeRawValue(uint64(1), "invalid type", true)
+	require.Equal(t, ErrInvalidValue, err)
+}
+
+func TestClosing(t *testing.T) {
+	catalogStore, err := store.Open("catalog_closing", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_closing")
+
+	dataStore, err := store.Open("sqldata_closing", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_closing")
+
+	_, err = NewEngine(nil, nil, nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	engine, err := NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
+
+	err = engine.Close()
+	require.NoError(t, err)
+
+	err = engine.Close()
+	require.Equal(t, ErrAlreadyClosed, err)
+
+	err = engine.UseDatabase("db1")
+	require.Equal(t, ErrAlreadyClosed, err)
+
+	_, err = engine.DatabaseInUse()
+	require.Equal(t, ErrAlreadyClosed, err)
+
+	_, err = engine.Snapshot()
+	require.Equal(t, ErrAlreadyClosed, err)
+
+	err = engine.RenewSnapshot()
+	require.Equal(t, ErrAlreadyClosed, err)
+
+	err = engine.CloseSnapshot()
+	require.Equal(t, ErrAlreadyClosed, err)
+}
+
+func TestQuery(t *testing.T) {
+	catalogStore, err := store.Open("catalog_q", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_q")
+
+	dataStore, err := store.Open("sqldata_q", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_q")
+
+	engine, err := NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
+	require.NoError(t, err)
+
+	_, err = engine.QueryStmt("SELECT id FROM table1", nil, true)
+	require.Equal(t, ErrNoDatabaseSelected, err)
+
+	_, err = engine.QueryStmt("SELECT * FROM table1", nil, true)
+	require.Equal(t, ErrNoDatabaseSelected, err)
+
+	err = engine.UseDatabase("db1")
+	require.NoError(t, err)
+
+	_, err = engine.QueryStmt("SELECT id FROM db2.table1", nil, true)
+	require.True(t, errors.Is(err, ErrDatabaseDoesNotExist))
+
+	_, err = engine.QueryStmt("SELECT id FROM table1", nil, true)
+	require.True(t, errors.Is(err, ErrTableDoesNotExist))
+
+	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id INTEGER, ts TIMESTAMP, title VARCHAR, active BOOLEAN, payload BLOB, PRIMARY KEY id)", nil, true)
+	require.NoError(t, err)
+
+	r, err := engine.QueryStmt("SELECT id FROM db1.table1", nil, true)
+	require.NoError(t, err)
+
+	_, err = r.Read()
+	require.Equal(t, ErrNoMoreRows, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.QueryStmt("SELECT * FROM db1.table1", nil, true)
+	require.NoError(t, err)
+
+	_, err = r.Read()
+	require.Equal(t, ErrNoMoreRows, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	rowCount := 10
+
+	start := time.Now().UTC().Truncate(time.Microsecond)
+
+	for i := 0; i < rowCount; i++ {
+		encPayload := hex.EncodeToString([]byte(fmt.Sprintf("blob%d", i)))
+		_, _, err = engine.ExecStmt(fmt.Sprintf("UPSERT INTO table1 (id, ts, title, active, payload) VALUES (%d, NOW(), 'title%d', %v, x'%s')", i, i, i%2 == 0, encPayload), nil, true)
+		require.NoError(t, err)
+	}
+
+	_, err = engine.QueryStmt("SELECT DISTINCT id1 FROM table1", nil, true)
+	require.True(t, errors.Is(err, ErrColumnDoesNotExist))
+
+	r, err = engine.QueryStmt("SELECT id1 FROM table1", nil, true)
+	require.NoError(t, err)
+
+	row, err := r.Read()
+	require.True(t, errors.Is(err, ErrColumnDoesNotExist))
+	require.Nil(t, row)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.QueryStmt(fmt.Sprintf("SELECT t1.id AS D, ts, Title, payload, Active FROM (table1 AS T1) WHERE id >= 0 LIMIT %d AS table1", rowCount), nil, true)
+	require.NoError(t, err)
+
+	colsBySel, err := r.colsBySelector()
+	require.NoError(t, err)
+	require.Len(t, colsBySel, 5)
+
+	require.Equal(t, "db1", r.ImplicitDB())
+	require.Equal(t, "table1", r.ImplicitTable())
+
+	cols, err := r.Columns()
+	require.NoError(t, err)
+	require.Len(t, cols, 5)
+
+	for i := 0; i < rowCount; i++ {
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.NotNil(t, row)
+		require.Len(t, row.Values, 5)
+		require.False(t, row.Values[EncodeSelector("", "db1", "table1", "ts")].Value().(time.Time).Before(start))
+		require.Equal(t, uint64(i), row.Values[EncodeSelector("", "db1", "table1", "d")].Value())
+		require.Equal(t, fmt.Sprintf("title%d", i), row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
+		require.Equal(t, i%2 == 0, row.Values[EncodeSelector("", "db1", "table1", "active")].Value())
+
+		encPayload := []byte(fmt.Sprintf("blob%d", i))
+		require.Equal(t, []byte(encPayload), row.Values[EncodeSelector("", "db1", "table1", "payload")].Value())
+	}
+
+	_, err = r.Read()
+	require.Equal(t, ErrNoMoreRows, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.QueryStmt("SELECT id, title, active, payload FROM table1 ORDER BY title", nil, true)
+	require.NoError(t, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.QueryStmt("SELECT Id, Title, Active, payload FROM Table1 ORDER BY Id DESC", nil, true)
+	require.NoError(t, err)
+
+	for i := 0; i < rowCount; i++ {
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.NotNil(t, row)
+		require.Len(t, row.Values, 4)
+
+		require.Equal(t, uint64(rowCount-1-i), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
+		require.Equal(t, fmt.Sprintf("title%d", rowCount-1-i), row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
+		require.Equal(t, (rowCount-1-i)%2 == 0, row.Values[EncodeSelector("", "db1", "table1", "active")].Value())
+
+		encPayload := []byte(fmt.Sprintf("blob%d", rowCount-1-i))
+		require.Equal(t, []byte(encPayload), row.Values[EncodeSelector("", "db1", "table1", "payload")].Value())
+	}
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.QueryStmt("SELECT id FROM table1 WHERE id", nil, true)
+	require.NoError(t, err)
+
+	_, err = r.Read()
+	require.Equal(t, ErrInvalidCondition, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.QueryStmt("SELECT id FROM table1 WHERE active = @some_param1", nil, true)
+	require.NoError(t, err)
+
+	_, err = r.Read()
+	require.Equal(t, ErrMissingParameter, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	params := make(map[string]interface{})
+	params["some_param"] = true
+
+	encPayloadPrefix := hex.EncodeToString([]byte("blob"))
+
+	r, err = engine.QueryStmt(fmt.Sprintf("SELECT id, title, active FROM table1 WHERE active = @some_param AND title > 'title' AND payload >= x'%s' AND title LIKE 't%%'", encPayloadPrefix), params, true)
+	require.NoError(t, err)
+
+	for i := 0; i < rowCount/2; i += 2 {
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.NotNil(t, row)
+		require.Len(t, row.Values, 3)
+
+		require.Equal(t, uint64(i), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
+		require.Equal(t, fmt.Sprintf("title%d", i), row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
+		require.Equal(t, params["some_param"], row.Values[EncodeSelector("", "db1", "table1", "active")].Value())
+	}
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.QueryStmt("SELECT * FROM table1 WHERE id = 0", nil, true)
+	require.NoError(t, err)
+
+	cols, err = r.Columns()
+	require.NoError(t, err)
+	require.Len(t, cols, 5)
+
+	row, err = r.Read()
+	require.NoError(t, err)
+	require.Len(t, row.Values, 5)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.QueryStmt("SELECT id, title, active FROM table1 WHERE id / 0", nil, true)
+	require.NoError(t, err)
+
+	_, err = r.Read()
+	require.Equal(t, ErrDivisionByZero, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.QueryStmt("SELECT id, title, active FROM table1 WHERE id + 1/1 > 1 * (1 - 0)", nil, true)
+	require.NoError(t, err)
+
+	_, err = r.Read()
+	require.NoError(t, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.QueryStmt("SELECT id, title, active FROM table1 WHERE id = 0 AND NOT active OR active", nil, true)
+	require.NoError(t, err)
+
+	_, err = r.Read()
+	require.NoError(t, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	err = engine.Close()
+	require.NoError(t, err)
+}
+
+func TestQueryWithContext(t *testing.T) {
+	catalogStore, err := store.Open("catalog_ctx", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_ctx")
+
+	dataStore, err := store.Open("sqldata_ctx", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_ctx")
+
+	engine, err := NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
+	require.NoError(t, err)
+
+	err = engine.UseDatabase("db1")
+	require.NoError(t, err)
+
+	stmts, err := Parse(strings.NewReader("CREATE TABLE table1 (id INTEGER, title VARCHAR, PRIMARY KEY id)"))
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecPreparedStmts(nil, stmts, nil, true)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err = engine.ExecPreparedStmts(ctx, stmts, nil, true)
+	require.Equal(t, context.Canceled, err)
+	require.False(t, engine.catalog.dbsByName["db1"].ExistTable("table1"))
+
+	_, _, err = engine.ExecPreparedStmts(context.Background(), stmts, nil, true)
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		_, _, err = engine.ExecStmt(fmt.Sprintf("UPSERT INTO table1 (id, title) VALUES (%d, 'title%d')", i, i), nil, true)
+		require.NoError(t, err)
+	}
+
+	stmts, err = Parse(strings.NewReader("SELECT id, title FROM table1 WHERE id >= 5"))
+	require.NoError(t, err)
+
+	_, err = engine.QueryPreparedStmt(nil, stmts[0].(*SelectStmt), nil, true)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	ctx, cancel = context.WithCancel(context.Background())
+
+	r, err := engine.QueryPreparedStmt(ctx, stmts[0].(*SelectStmt), nil, true)
+	require.NoError(t, err)
+
+	row, err := r.Read()
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
+
+	cancel()
+
+	_, err = r.Read()
+	require.Equal(t, context.Canceled, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	// rows to be deleted are not read once the context gets done
+	deleteStmt := &DeleteFromStmt{tableRef: &TableRef{table: "table1"}}
+
+	_, _, _, err = deleteStmt.CompileUsing(ctx, engine, engine.catalog.dbsByName["db1"], nil)
+	require.Equal(t, context.Canceled, err)
+
+	_, des, _, err := deleteStmt.CompileUsing(context.Background(), engine, engine.catalog.dbsByName["db1"], nil)
+	require.NoError(t, err)
+	require.Len(t, des, 10)
+
+	err = engine.Close()
+	require.NoError(t, err)
+}
+
+func TestQueryWithNullables(t *testing.T) {
+	catalogStore, err := store.Open("catalog_nullable", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_nullable")
+
+	dataStore, err := store.Open("sqldata_nullable", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_nullable")
+
+	engine, err := NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
+	require.NoError(t, err)
+
+	err = engine.UseDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id INTEGER, ts TIMESTAMP, title VARCHAR, active BOOLEAN, PRIMARY KEY id)", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("INSERT INTO table1 (id, ts, title) VALUES (1, TIME(), 'title1')", nil, true)
+	require.Equal(t, ErrNoSupported, err)
+
+	rowCount := 10
+
+	start := time.Now().UTC().Truncate(time.Microsecond)
+
+	for i := 0; i < rowCount; i++ {
+		_, _, err = engine.ExecStmt(fmt.Sprintf("UPSERT INTO table1 (id, ts, title) VALUES (%d, NOW(), 'title%d')", i, i), nil, true)
+		require.NoError(t, err)
+	}
+
+	r, err := engine.QueryStmt("SELECT id, ts, title, active FROM table1 WHERE active IS NULL", nil, true)
+	require.NoError(t, err)
+
+	cols, err := r.Columns()
+	require.NoError(t, err)
+	require.Len(t, cols, 4)
+
+	for i := 0; i < rowCount; i++ {
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.NotNil(t, row)
+		require.Len(t, row.Values, 4)
+		require.False(t, row.Values[EncodeSelector("", "db1", "table1", "ts")].Value().(time.Time).Before(start))
+		require.Equal(t, uint64(i), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
+		require.Equal(t, fmt.Sprintf("title%d", i), row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
+		require.Equal(t, &NullValue{t: BooleanType}, row.Values[EncodeSelector("", "db1", "table1", "active")])
+	}
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	err = engine.Close()
+	require.NoError(t, err)
+}
+
+func TestNullSemantics(t *testing.T) {
+	catalogStore, err := store.Open("catalog_null_semantics", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_null_semantics")
+
+	dataStore, err := store.Open("sqldata_null_semantics", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_null_semantics")
+
+	engine, err := NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
+	require.NoError(t, err)
+
+	err = engine.UseDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id INTEGER, amount INTEGER, title VARCHAR, active BOOLEAN, PRIMARY KEY id)", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt(`
+		INSERT INTO table1 (id, amount, title, active)
+		VALUES (1, 10, 'title1', true), (2, NULL, 'title2', false), (3, 30, NULL, NULL), (4, NULL, NULL, true)`, nil, true)
+	require.NoError(t, err)
+
+	queryIDs := func(cond string, params map[string]interface{}) []uint64 {
+		r, err := engine.QueryStmt("SELECT id FROM table1 WHERE "+cond, params, true)
+		require.NoError(t, err)
+		defer r.Close()
+
+		var ids []uint64
+
+		for {
+			row, err := r.Read()
+			if err == ErrNoMoreRows {
+				break
+			}
+			require.NoError(t, err, cond)
+
+			ids = append(ids, row.Values[EncodeSelector("", "db1", "table1", "id")].Value().(uint64))
+		}
+
+		return ids
+	}
+
+	testCases := []struct {
+		cond string
+		ids  []uint64
+	}{
+		{cond: "amount IS NULL", ids: []uint64{2, 4}},
+		{cond: "amount IS NOT NULL", ids: []uint64{1, 3}},
+		{cond: "amount + 1 IS NULL", ids: []uint64{2, 4}},
+		{cond: "title IS NULL AND active IS NOT NULL", ids: []uint64{4}},
+		{cond: "amount = NULL", ids: nil},
+		{cond: "amount != NULL", ids: nil},
+		{cond: "NULL", ids: nil},
+		{cond: "amount > 5", ids: []uint64{1, 3}},
+		{cond: "NOT (amount > 5)", ids: nil},
+		{cond: "amount > 20 OR active", ids: []uint64{1, 3, 4}},
+		{cond: "NOT (amount > 20 OR active)", ids: nil},
+		{cond: "amount > 20 AND active", ids: nil},
+		{cond: "NOT (amount > 20 AND active)", ids: []uint64{1, 2}},
+		{cond: "title LIKE 'title%'", ids: []uint64{1, 2}},
+		{cond: "NOT (title LIKE 'title%')", ids: nil},
+		{cond: "title NOT LIKE 'title1'", ids: []uint64{2}},
+		{cond: "title LIKE NULL", ids: nil},
+		{cond: "amount IN (10, NULL)", ids: []uint64{1}},
+		{cond: "amount NOT IN (10, NULL)", ids: nil},
+		{cond: "amount NOT IN (10)", ids: []uint64{3}},
+		{cond: "(amount = NULL) IS NULL", ids: []uint64{1, 2, 3, 4}},
+		{cond: "@amount IS NULL", ids: []uint64{1, 2, 3, 4}},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.ids, queryIDs(tc.cond, map[string]interface{}{"amount": nil}), tc.cond)
+	}
+
+	r, err := engine.QueryStmt("SELECT id FROM table1 WHERE active = amount", nil, true)
+	require.NoError(t, err)
+
+	_, err = r.Read()
+	require.Equal(t, ErrNotComparableValues, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("UPDATE table1 SET amount = 0 WHERE amount IS NULL", nil, true)
+	require.NoError(t, err)
+
+	require.Equal(t, []uint64{2, 4}, queryIDs("amount = 0", nil))
+
+	_, _, err = engine.ExecStmt("DELETE FROM table1 WHERE title IS NULL", nil, true)
+	require.NoError(t, err)
+
+	require.Equal(t, []uint64{1, 2}, queryIDs("title IS NOT NULL", nil))
+
+	err = engine.Close()
+	require.NoError(t, err)
+}
+
+func TestLike(t *testing.T) {
+	catalogStore, err := store.Open("catalog_like", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_like")
+
+	dataStore, err := store.Open("sqldata_like", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_like")
+
+	engine, err := NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
+	require.NoError(t, err)
+
+	err = engine.UseDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE TABLE products (code VARCHAR, name VARCHAR NOT NULL, price INTEGER, PRIMARY KEY code)", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE INDEX ON products(name)", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt(`
+		INSERT INTO products (code, name, price)
+		VALUES ('ab', 'Zeta', 1), ('abc', 'beta', 2), ('abd', 'alpha', 3), ('b', 'ab_x', 4), ('abcdef', 'ab%x', 5), ('ac', 'gamma', 6), ('AB', 'Alpha', 7)`, nil, true)
+	require.NoError(t, err)
+
+	queryCodes := func(query string, params map[string]interface{}) []string {
+		r, err := engine.QueryStmt("SELECT code FROM products "+query, params, true)
+		require.NoError(t, err)
+		defer r.Close()
+
+		var codes []string
+
+		for {
+			row, err := r.Read()
+			if err == ErrNoMoreRows {
+				break
+			}
+			require.NoError(t, err, query)
+
+			codes = append(codes, row.Values[EncodeSelector("", "db1", "products", "code")].Value().(string))
+		}
+
+		return codes
+	}
+
+	testCases := []struct {
+		query  string
+		params map[string]interface{}
+		codes  []string
+	}{
+		// values are ordered by their length first
+		{query: "WHERE code LIKE 'ab%'", codes: []string{"ab", "abc", "abd", "abcdef"}},
+		{query: "WHERE code LIKE 'ab%' ORDER BY code DESC", codes: []string{"abcdef", "abd", "abc", "ab"}},
+		{query: "WHERE code LIKE 'ab'", codes: []string{"ab"}},
+		{query: "WHERE code LIKE 'a_'", codes: []string{"ab", "ac"}},
+		{query: "WHERE code LIKE '%d%'", codes: []string{"abd", "abcdef"}},
+		{query: "WHERE code LIKE '%'", codes: []string{"b", "AB", "ab", "ac", "abc", "abd", "abcdef"}},
+		{query: "WHERE code ILIKE 'ab%'", codes: []string{"AB", "ab", "abc", "abd", "abcdef"}},
+		{query: "WHERE code NOT LIKE 'ab%'", codes: []string{"b", "AB", "ac"}},
+		{query: "WHERE code NOT ILIKE 'ab%'", codes: []string{"b", "ac"}},
+		{query: "WHERE NOT code LIKE 'ab%' AND price > 5", codes: []string{"AB", "ac"}},
+		{query: "WHERE code LIKE @pattern", params: map[string]interface{}{"pattern": "abc%"}, codes: []string{"abc", "abcdef"}},
+		{query: "WHERE code LIKE @pattern", params: map[string]interface{}{"pattern": nil}, codes: nil},
+		{query: "WHERE code LIKE 'abcdefghijklmnopqrstuvwxyz0123456789%'", codes: nil},
+		// rows are read from the index of the column when ordered by it, reading the whole primary key of such a small table is cheaper otherwise
+		{query: "WHERE name LIKE 'a%'", codes: []string{"b", "abd", "abcdef"}},
+		{query: "WHERE name LIKE 'a%' ORDER BY name DESC", codes: []string{"abd", "b", "abcdef"}},
+		{query: "WHERE name LIKE 'a%' AND price > 3", codes: []string{"b", "abcdef"}},
+		{query: "WHERE name LIKE 'a%' OR price = 1", codes: []string{"b", "ab", "abd", "abcdef"}},
+		{query: "WHERE name LIKE 'a%' ORDER BY code", codes: []string{"b", "abd", "abcdef"}},
+		{query: "WHERE name ILIKE 'a%'", codes: []string{"b", "AB", "abd", "abcdef"}},
+		{query: "WHERE name LIKE 'ab\\_%'", codes: []string{"b"}},
+		{query: "WHERE name LIKE 'ab\\%x'", codes: []string{"abcdef"}},
+		{query: "WHERE name LIKE code", codes: nil},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.codes, queryCodes(tc.query, tc.params), tc.query)
+	}
+
+	for _, query := range []string{"WHERE price LIKE '1%'", "WHERE code LIKE 1"} {
+		r, err := engine.QueryStmt("SELECT code FROM products "+query, nil, true)
+		require.NoError(t, err)
+
+		_, err = r.Read()
+		require.True(t, errors.Is(err, ErrInvalidValue), query)
+
+		err = r.Close()
+		require.NoError(t, err)
+	}
+
+	r, err := engine.QueryStmt("SELECT code FROM products WHERE code LIKE 'ab\\'", nil, true)
+	require.NoError(t, err)
+
+	_, err = r.Read()
+	require.True(t, errors.Is(err, ErrInvalidPattern))
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	err = engine.Close()
+	require.NoError(t, err)
+}
+
+func TestInList(t *testing.T) {
+	catalogStore, err := store.Open("catalog_in_list", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_in_list")
+
+	dataStore, err := store.Open("sqldata_in_list", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_in_list")
+
+	engine, err := NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
+	require.NoError(t, err)
+
+	err = engine.UseDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE TABLE items (id INTEGER, code VARCHAR NOT NULL, qty INTEGER, PRIMARY KEY id)", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE INDEX ON items(code)", nil, true)
+	require.NoError(t, err)
+
+	for i := 1; i <= 20; i++ {
+		// codes are in the reverse order of ids
+		_, _, err = engine.ExecStmt("INSERT INTO items (id, code, qty) VALUES (@id, @code, @qty)",
+			map[string]interface{}{"id": i, "code": string(rune('a' + 20 - i)), "qty": i * 10}, true)
+		require.NoError(t, err)
+	}
+
+	queryIDs := func(query string, params map[string]interface{}) []uint64 {
+		r, err := engine.QueryStmt("SELECT id FROM items "+query, params, true)
+		require.NoError(t, err)
+		defer r.Close()
+
+		var ids []uint64
+
+		for {
+			row, err := r.Read()
+			if err == ErrNoMoreRows {
+				break
+			}
+			require.NoError(t, err, query)
+
+			ids = append(ids, row.Values[EncodeSelector("", "db1", "items", "id")].Value().(uint64))
+		}
+
+		return ids
+	}
+
+	testCases := []struct {
+		query  string
+		params map[string]interface{}
+		ids    []uint64
+	}{
+		{query: "WHERE id IN (7, 3, 5, 3)", ids: []uint64{3, 5, 7}},
+		{query: "WHERE id IN (7, 3, 5) ORDER BY id DESC", ids: []uint64{7, 5, 3}},
+		{query: "WHERE id IN (7, 3, 50)", ids: []uint64{3, 7}},
+		{query: "WHERE id IN (1, NULL, 2)", ids: []uint64{1, 2}},
+		{query: "WHERE id IN (NULL)", ids: nil},
+		{query: "WHERE id IN (7, 3) OR id = 1", ids: []uint64{1, 3, 7}},
+		{query: "WHERE id IN @ids", params: map[string]interface{}{"ids": []int{7, 3}}, ids: []uint64{3, 7}},
+		{query: "WHERE id IN (@ids)", params: map[string]interface{}{"ids": []uint64{7, 3}}, ids: []uint64{3, 7}},
+		{query: "WHERE id IN (@ids, 9)", params: map[string]interface{}{"ids": []interface{}{7, nil}}, ids: []uint64{7, 9}},
+		{query: "WHERE id IN @ids", params: map[string]interface{}{"ids": 7}, ids: []uint64{7}},
+		{query: "WHERE id IN @ids", params: map[string]interface{}{"ids": []int{}}, ids: nil},
+		{query: "WHERE id NOT IN @ids AND id < 6", params: map[string]interface{}{"ids": []int{2, 4}}, ids: []uint64{1, 3, 5}},
+		{query: "WHERE id NOT IN @ids AND id < 3", params: map[string]interface{}{"ids": []int{}}, ids: []uint64{1, 2}},
+		// rows are read from the index of the column
+		{query: "WHERE code IN ('b', 't', 'c')", ids: []uint64{19, 18, 1}},
+		{query: "WHERE code IN ('b', 't', 'c') ORDER BY code DESC", ids: []uint64{1, 18, 19}},
+		{query: "WHERE code IN ('b', 't', 'c') ORDER BY id", ids: []uint64{1, 18, 19}},
+		{query: "WHERE code IN @codes AND qty > 10", params: map[string]interface{}{"codes": []string{"t", "s", "x"}}, ids: []uint64{2}},
+		// values of long lists are looked up in a set
+		{query: "WHERE qty IN @qtys", params: map[string]interface{}{"qtys": []int{10, 30, 50, 70, 90, 110, 130, 150, 1000}}, ids: []uint64{1, 3, 5, 7, 9, 11, 13, 15}},
+		{query: "WHERE qty IN (10, 20, 30, 40, 50, 60, 70, 80, NULL) AND id > 7", ids: []uint64{8}},
+		{query: "WHERE qty NOT IN (10, 20, 30, 40, 50, 60, 70, 80, NULL)", ids: nil},
+		{query: "WHERE qty NOT IN (10, 20, 30, 40, 50, 60, 70, 80, 90, 100, 110, 120, 130, 140, 150, 160, 170, 180)", ids: []uint64{19, 20}},
+		{query: "WHERE qty IN (10.0, 20, 30, 40, 50, 60, 70, 80, 1000)", ids: []uint64{1, 2, 3, 4, 5, 6, 7, 8}},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.ids, queryIDs(tc.query, tc.params), tc.query)
+	}
+
+	r, err := engine.QueryStmt("SELECT id FROM items WHERE id IN @ids", map[string]interface{}{"ids": []interface{}{1, struct{}{}}}, true)
+	require.NoError(t, err)
+
+	_, err = r.Read()
+	require.Equal(t, ErrUnsupportedParameter, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.QueryStmt("SELECT id FROM items WHERE id IN @ids", map[string]interface{}{"ids": []string{"1"}}, true)
+	require.NoError(t, err)
+
+	_, err = r.Read()
+	require.Equal(t, ErrNotComparableValues, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	err = engine.Close()
+	require.NoError(t, err)
+}
+
+func TestInListSet(t *testing.T) {
+	ints := make([]ValueExp, inListSetThreshold)
+	for i := range ints {
+		ints[i] = &Number{val: uint64(i)}
+	}
+
+	bexp := newInListExp(&Number{val: 1}, false, ints)
+	require.Len(t, bexp.valSet, inListSetThreshold)
+	require.Equal(t, IntegerType, bexp.setType)
+	require.False(t, bexp.hasNull)
+
+	bexp = newInListExp(&Number{val: 1}, false, append(ints, &NullValue{t: IntegerType}))
+	require.Len(t, bexp.valSet, inListSetThreshold)
+	require.True(t, bexp.hasNull)
+
+	// short lists are compared one by one
+	bexp = newInListExp(&Number{val: 1}, false, ints[1:])
+	require.Nil(t, bexp.valSet)
+
+	// values of distinct types may still be equal
+	bexp = newInListExp(&Number{val: 1}, false, append(ints, &Float{val: 1}))
+	require.Nil(t, bexp.valSet)
+
+	bexp = newInListExp(&Number{val: 1}, false, append(ints, &ColSelector{col: "id"}))
+	require.Nil(t, bexp.valSet)
+
+	for _, v := range []ValueExp{&Number{val: 3}, &Float{val: 3}} {
+		r, err := newInListExp(v, false, ints).reduce(nil, nil, "", "")
+		require.NoError(t, err)
+		require.Equal(t, &Bool{val: true}, r)
+	}
+
+	r, err := newInListExp(&Number{val: 30}, true, append(ints, &NullValue{t: IntegerType})).reduce(nil, nil, "", "")
+	require.NoError(t, err)
+	require.True(t, isUnknown(r))
+}
+
+func TestCaseWhen(t *testing.T) {
+	catalogStore, err := store.Open("catalog_case_when", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_case_when")
+
+	dataStore, err := store.Open("sqldata_case_when", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_case_when")
+
+	engine, err := NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
+	require.NoError(t, err)
+
+	err = engine.UseDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE TABLE invoices (id INTEGER, customer VARCHAR, amount FLOAT, qty INTEGER, paid BOOLEAN, PRIMARY KEY id)", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt(`
+		INSERT INTO invoices (id, customer, amount, qty, paid)
+		VALUES
+			(1, 'acme', 10.5, 1, true),
+			(2, 'acme', 20.0, 3, false),
+			(3, 'globex', 5.0, 10, NULL),
+			(4, NULL, 1.0, NULL, true)`, nil, true)
+	require.NoError(t, err)
+
+	queryValues := func(query string, params map[string]interface{}) ([]*ColDescriptor, []interface{}) {
+		r, err := engine.QueryStmt(query, params, true)
+		require.NoError(t, err)
+		defer r.Close()
+
+		cols, err := r.Columns()
+		require.NoError(t, err)
+
+		var vals []interface{}
+
+		for {
+			row, err := r.Read()
+			if err == ErrNoMoreRows {
+				break
+			}
+			require.NoError(t, err, query)
+
+			vals = append(vals, row.Values[cols[len(cols)-1].Selector].Value())
+		}
+
+		return cols, vals
+	}
+
+	t.Run("searched case should be used in projections", func(t *testing.T) {
+		cols, vals := queryValues("SELECT id, CASE WHEN qty >= 3 THEN 'bulk' WHEN qty IS NULL THEN NULL ELSE 'single' END AS kind FROM invoices", nil)
+		require.Equal(t, &ColDescriptor{Selector: EncodeSelector("", "db1", "invoices", "kind"), Type: VarcharType}, cols[1])
+		require.Equal(t, []interface{}{"single", "bulk", "bulk", nil}, vals)
+	})
+
+	t.Run("simple case should be used in projections", func(t *testing.T) {
+		cols, vals := queryValues("SELECT CASE customer WHEN 'acme' THEN 1 WHEN @other THEN 2 ELSE 0 END FROM invoices", map[string]interface{}{"other": "globex"})
+		require.Equal(t, &ColDescriptor{Selector: EncodeSelector("", "db1", "invoices", "col0"), Type: IntegerType}, cols[0])
+		require.Equal(t, []interface{}{uint64(1), uint64(1), uint64(2), uint64(0)}, vals)
+	})
+
+	t.Run("integer results should be returned as floats when mixed with float results", func(t *testing.T) {
+		cols, vals := queryValues("SELECT CASE WHEN paid THEN amount ELSE 0 END AS due FROM invoices", nil)
+		require.Equal(t, FloatType, cols[0].Type)
+		require.Equal(t, []interface{}{10.5, float64(0), float64(0), 1.0}, vals)
+	})
+
+	t.Run("results should be text when all of them are null", func(t *testing.T) {
+		cols, vals := queryValues("SELECT CASE WHEN paid THEN NULL END FROM invoices", nil)
+		require.Equal(t, VarcharType, cols[0].Type)
+		require.Equal(t, []interface{}{nil, nil, nil, nil}, vals)
+	})
+
+	t.Run("case should be used in conditions and sorting", func(t *testing.T) {
+		_, vals := queryValues("SELECT id FROM invoices WHERE CASE WHEN paid THEN qty ELSE 0 END > 0", nil)
+		require.Equal(t, []interface{}{uint64(1)}, vals)
+
+		_, vals = queryValues("SELECT id FROM invoices WHERE CASE customer WHEN 'acme' THEN paid ELSE TRUE END", nil)
+		require.Equal(t, []interface{}{uint64(1), uint64(3), uint64(4)}, vals)
+
+		_, vals = queryValues("SELECT id FROM invoices ORDER BY CASE customer WHEN 'globex' THEN 0 ELSE 1 END, id DESC", nil)
+		require.Equal(t, []interface{}{uint64(3), uint64(4), uint64(2), uint64(1)}, vals)
+	})
+
+	t.Run("case should be evaluated over aggregated values", func(t *testing.T) {
+		_, vals := queryValues("SELECT customer, CASE WHEN SUM(amount) > 10 THEN 'big' ELSE 'small' END AS size FROM invoices GROUP BY customer", nil)
+		require.Equal(t, []interface{}{"big", "small", "small"}, vals)
+
+		_, vals = queryValues("SELECT COUNT(), CASE WHEN MAX(qty) > 5 THEN MAX(qty) END FROM invoices", nil)
+		require.Equal(t, []interface{}{uint64(10)}, vals)
+	})
+
+	t.Run("invalid case expressions should fail", func(t *testing.T) {
+		_, err := engine.QueryStmt("SELECT CASE WHEN paid THEN 1 ELSE 'no' END FROM invoices", nil, true)
+		require.True(t, errors.Is(err, ErrInvalidCaseTypes))
+
+		_, err = engine.QueryStmt("SELECT CASE WHEN paid THEN @v END FROM invoices", nil, true)
+		require.True(t, errors.Is(err, ErrMissingParameter))
+
+		_, err = engine.QueryStmt("SELECT CASE WHEN paid THEN unknown END FROM invoices", nil, true)
+		require.True(t, errors.Is(err, ErrColumnDoesNotExist))
+
+		r, err := engine.QueryStmt("SELECT CASE WHEN customer THEN 1 END FROM invoices", nil, true)
+		require.NoError(t, err)
+
+		_, err = r.Read()
+		require.True(t, errors.Is(err, ErrInvalidCondition))
+
+		err = r.Close()
+		require.NoError(t, err)
+	})
+}
+
+func TestScalarFunctions(t *testing.T) {
+	catalogStore, err := store.Open("catalog_scalar_fns", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_scalar_fns")
+
+	dataStore, err := store.Open("sqldata_scalar_fns", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_scalar_fns")
+
+	engine, err := NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
+	require.NoError(t, err)
+
+	err = engine.UseDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE TABLE clients (id INTEGER, name VARCHAR, nickname VARCHAR, age INTEGER, balance FLOAT, PRIMARY KEY id)", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt(`
+		INSERT INTO clients (id, name, nickname, age, balance)
+		VALUES
+			(1, 'Alice', 'ali', 30, 10.5),
+			(2, 'Bob', NULL, 25, NULL),
+			(3, 'Ñandú', NULL, NULL, 2.0)`, nil, true)
+	require.NoError(t, err)
+
+	queryValues := func(query string, params map[string]interface{}) ([]*ColDescriptor, []interface{}) {
+		r, err := engine.QueryStmt(query, params, true)
+		require.NoError(t, err)
+		defer r.Close()
+
+		cols, err := r.Columns()
+		require.NoError(t, err)
+
+		var vals []interface{}
+
+		for {
+			row, err := r.Read()
+			if err == ErrNoMoreRows {
+				break
+			}
+			require.NoError(t, err, query)
+
+			vals = append(vals, row.Values[cols[len(cols)-1].Selector].Value())
+		}
+
+		return cols, vals
+	}
+
+	t.Run("string functions should be used in projections", func(t *testing.T) {
+		cols, vals := queryValues("SELECT id, UPPER(name) AS uname FROM clients", nil)
+		require.Equal(t, &ColDescriptor{Selector: EncodeSelector("", "db1", "clients", "uname"), Type: VarcharType}, cols[1])
+		require.Equal(t, []interface{}{"ALICE", "BOB", "ÑANDÚ"}, vals)
+
+		_, vals = queryValues("SELECT LOWER(name) FROM clients", nil)
+		require.Equal(t, []interface{}{"alice", "bob", "ñandú"}, vals)
+
+		cols, vals = queryValues("SELECT LENGTH(name) FROM clients", nil)
+		require.Equal(t, &ColDescriptor{Selector: EncodeSelector("", "db1", "clients", "col0"), Type: IntegerType}, cols[0])
+		require.Equal(t, []interface{}{uint64(5), uint64(3), uint64(5)}, vals)
+
+		_, vals = queryValues("SELECT SUBSTRING(name, 2, 3) FROM clients", nil)
+		require.Equal(t, []interface{}{"lic", "ob", "and"}, vals)
+
+		_, vals = queryValues("SELECT SUBSTRING(name, @start) FROM clients", map[string]interface{}{"start": 3})
+		require.Equal(t, []interface{}{"ice", "b", "ndú"}, vals)
+
+		_, vals = queryValues("SELECT SUBSTRING(name, 0, 2) FROM clients", nil)
+		require.Equal(t, []interface{}{"A", "B", "Ñ"}, vals)
+
+		_, vals = queryValues("SELECT CONCAT(name, ' (', nickname, ') ', age) FROM clients", nil)
+		require.Equal(t, []interface{}{"Alice (ali) 30", "Bob () 25", "Ñandú () "}, vals)
+	})
+
+	t.Run("null parameters should return nulls", func(t *testing.T) {
+		cols, vals := queryValues("SELECT UPPER(nickname) FROM clients", nil)
+		require.Equal(t, VarcharType, cols[0].Type)
+		require.Equal(t, []interface{}{"ALI", nil, nil}, vals)
+
+		_, vals = queryValues("SELECT SUBSTRING(name, 1, NULL) FROM clients", nil)
+		require.Equal(t, []interface{}{nil, nil, nil}, vals)
+	})
+
+	t.Run("coalesce should return the first non-null value", func(t *testing.T) {
+		cols, vals := queryValues("SELECT COALESCE(nickname, LOWER(name)) FROM clients", nil)
+		require.Equal(t, VarcharType, cols[0].Type)
+		require.Equal(t, []interface{}{"ali", "bob", "ñandú"}, vals)
+
+		cols, vals = queryValues("SELECT COALESCE(balance, age, 0) FROM clients", nil)
+		require.Equal(t, FloatType, cols[0].Type)
+		require.Equal(t, []interface{}{10.5, float64(25), 2.0}, vals)
+
+		cols, vals = queryValues("SELECT COALESCE(NULL, NULL) FROM clients", nil)
+		require.Equal(t, VarcharType, cols[0].Type)
+		require.Equal(t, []interface{}{nil, nil, nil}, vals)
+	})
+
+	t.Run("cast should convert values between types", func(t *testing.T) {
+		cols, vals := queryValues("SELECT CAST(balance AS INTEGER) FROM clients", nil)
+		require.Equal(t, IntegerType, cols[0].Type)
+		require.Equal(t, []interface{}{uint64(10), nil, uint64(2)}, vals)
+
+		cols, vals = queryValues("SELECT CAST(age AS VARCHAR) FROM clients", nil)
+		require.Equal(t, VarcharType, cols[0].Type)
+		require.Equal(t, []interface{}{"30", "25", nil}, vals)
+
+		_, vals = queryValues("SELECT CAST(CAST(age AS VARCHAR) AS FLOAT) FROM clients", nil)
+		require.Equal(t, []interface{}{float64(30), float64(25), nil}, vals)
+
+		_, vals = queryValues("SELECT CAST(-1 AS VARCHAR) FROM clients WHERE id = 1", nil)
+		require.Equal(t, []interface{}{"-1"}, vals)
+
+		_, vals = queryValues("SELECT CAST('2021-08-20 10:30:00' AS TIMESTAMP) FROM clients WHERE id = 1", nil)
+		require.Equal(t, []interface{}{time.Date(2021, 8, 20, 10, 30, 0, 0, time.UTC)}, vals)
+
+		_, vals = queryValues("SELECT CAST(CAST('2021-08-20 10:30:00.5' AS TIMESTAMP) AS VARCHAR) FROM clients WHERE id = 1", nil)
+		require.Equal(t, []interface{}{"2021-08-20 10:30:00.5"}, vals)
+
+		_, vals = queryValues("SELECT CAST('{\"a\": 1}' AS JSON) FROM clients WHERE id = 1", nil)
+		require.Equal(t, []interface{}{`{"a":1}`}, vals)
+
+		_, vals = queryValues("SELECT CAST(age AS BOOLEAN) FROM clients", nil)
+		require.Equal(t, []interface{}{true, true, nil}, vals)
+	})
+
+	t.Run("functions should be used in conditions and over aggregated values", func(t *testing.T) {
+		_, vals := queryValues("SELECT id FROM clients WHERE UPPER(name) = 'BOB' OR LENGTH(COALESCE(nickname, '')) > 2", nil)
+		require.Equal(t, []interface{}{uint64(1), uint64(2)}, vals)
+
+		_, vals = queryValues("SELECT id FROM clients WHERE CAST(age AS VARCHAR) = @age", map[string]interface{}{"age": "25"})
+		require.Equal(t, []interface{}{uint64(2)}, vals)
+
+		_, vals = queryValues("SELECT COUNT(), CONCAT('max: ', MAX(age)) FROM clients", nil)
+		require.Equal(t, []interface{}{"max: 30"}, vals)
+	})
+
+	t.Run("invalid function calls should fail", func(t *testing.T) {
+		_, err := engine.QueryStmt("SELECT UPPER(age) FROM clients", nil, true)
+		require.True(t, errors.Is(err, ErrInvalidFunctionParams))
+
+		_, err = engine.QueryStmt("SELECT COALESCE(name, age) FROM clients", nil, true)
+		require.True(t, errors.Is(err, ErrInvalidFunctionParams))
+
+		_, err = engine.QueryStmt("SELECT CAST(balance AS TIMESTAMP) FROM clients", nil, true)
+		require.True(t, errors.Is(err, ErrUnsupportedCast))
+
+		_, err = engine.QueryStmt("SELECT LOWER(unknown) FROM clients", nil, true)
+		require.True(t, errors.Is(err, ErrColumnDoesNotExist))
+
+		_, err = engine.QueryStmt("SELECT SUBSTRING(name, @start) FROM clients", nil, true)
+		require.True(t, errors.Is(err, ErrMissingParameter))
+
+		r, err := engine.QueryStmt("SELECT id FROM clients WHERE LENGTH(age) > 1", nil, true)
+		require.NoError(t, err)
+
+		_, err = r.Read()
+		require.True(t, errors.Is(err, ErrInvalidFunctionParams))
+
+		err = r.Close()
+		require.NoError(t, err)
+
+		r, err = engine.QueryStmt("SELECT CAST(name AS INTEGER) FROM clients", nil, true)
+		require.NoError(t, err)
+
+		_, err = r.Read()
+		require.True(t, errors.Is(err, ErrInvalidValue))
+
+		err = r.Close()
+		require.NoError(t, err)
+	})
+}
+
+func TestOrderBy(t *testing.T) {
+	catalogStore, err := store.Open("catalog_orderby", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_orderby")
+
+	dataStore, err := store.Open("sqldata_orderby", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_orderby")
+
+	engine, err := NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE INDEX ON table1(title)", nil, true)
+	require.Equal(t, ErrNoDatabaseSelected, err)
+
+	err = engine.UseDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id INTEGER, title VARCHAR, age INTEGER, PRIMARY KEY id)", nil, true)
+	require.NoError(t, err)
+
+	r, err := engine.QueryStmt("SELECT id, title, age FROM table1 ORDER BY id, title DESC", nil, true)
+	require.NoError(t, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.QueryStmt("SELECT id, title, age FROM (SELECT id, title, age FROM table1) ORDER BY id", nil, true)
+	require.NoError(t, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.QueryStmt("SELECT id, title, age FROM (SELECT id, title, age FROM table1 AS t1) ORDER BY age DESC", nil, true)
+	require.NoError(t, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	_, err = engine.QueryStmt("SELECT id, title, age FROM table2 ORDER BY title", nil, true)
+	require.True(t, errors.Is(err, ErrTableDoesNotExist))
+
+	_, err = engine.QueryStmt("SELECT id, title, age FROM table1 ORDER BY amount", nil, true)
+	require.True(t, errors.Is(err, ErrColumnDoesNotExist))
+
+	_, _, err = engine.ExecStmt("CREATE INDEX ON table1(title)", nil, true)
+	require.NoError(t, err)
+
+	r, err = engine.QueryStmt("SELECT id, title, age FROM table1 ORDER BY age", nil, true)
+	require.NoError(t, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE INDEX ON table1(age)", nil, true)
+	require.NoError(t, err)
+
+	params := make(map[string]interface{}, 1)
+	params["age"] = nil
+	_, _, err = engine.ExecStmt("UPSERT INTO table1 (id, title, age) VALUES (1, 'title', @age)", params, true)
+	require.Equal(t, ErrIndexedColumnCanNotBeNull, err)
+
+	_, _, err = engine.ExecStmt("UPSERT INTO table1 (id, title) VALUES (1, 'title')", nil, true)
+	require.Equal(t, ErrIndexedColumnCanNotBeNull, err)
+
+	rowCount := 1
+
+	for i := 0; i < rowCount; i++ {
+		params := make(map[string]interface{}, 3)
+		params["id"] = i
+		params["title"] = fmt.Sprintf("title%d", i)
+		params["age"] = 40 + i
+
+		_, _, err = engine.ExecStmt("UPSERT INTO table1 (id, title, age) VALUES (@id, @title, @age)", params, true)
+		require.NoError(t, err)
+	}
+
+	r, err = engine.QueryStmt("SELECT id, title, age FROM table1 ORDER BY title", nil, true)
+	require.NoError(t, err)
+
+	for i := 0; i < rowCount; i++ {
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.NotNil(t, row)
+		require.Len(t, row.Values, 3)
+
+		require.Equal(t, uint64(i), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
+		require.Equal(t, fmt.Sprintf("title%d", i), row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
+		require.Equal(t, uint64(40+i), row.Values[EncodeSelector("", "db1", "table1", "age")].Value())
+	}
+
+	err = r.Close()
 	require.NoError(t, err)
 
 	r, err = engine.QueryStmt("SELECT id, title, age FROM table1 ORDER BY age", nil, true)
 	require.NoError(t, err)
 
-	for i := 0; i < rowCount; i++ {
-		row, err := r.Read()
-		require.NoError(t, err)
-		require.NotNil(t, row)
-		require.Len(t, row.Values, 3)
+	for i := 0; i < rowCount; i++ {
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.NotNil(t, row)
+		require.Len(t, row.Values, 3)
+
+		require.Equal(t, uint64(i), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
+		require.Equal(t, fmt.Sprintf("title%d", i), row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
+		require.Equal(t, uint64(40+i), row.Values[EncodeSelector("", "db1", "table1", "age")].Value())
+	}
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.QueryStmt("SELECT id, title, age FROM table1 ORDER BY age DESC", nil, true)
+	require.NoError(t, err)
+
+	for i := 0; i < rowCount; i++ {
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.NotNil(t, row)
+		require.Len(t, row.Values, 3)
+
+		require.Equal(t, uint64(rowCount-1-i), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
+		require.Equal(t, fmt.Sprintf("title%d", rowCount-1-i), row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
+		require.Equal(t, uint64(40-(rowCount-1-i)), row.Values[EncodeSelector("", "db1", "table1", "age")].Value())
+	}
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	err = engine.Close()
+	require.NoError(t, err)
+}
+
+func TestOrderByExpressions(t *testing.T) {
+	catalogStore, err := store.Open("catalog_orderby_exps", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_orderby_exps")
+
+	dataStore, err := store.Open("sqldata_orderby_exps", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_orderby_exps")
+
+	engine, err := NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
+
+	err = engine.SetSortBufferSize(0)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
+	require.NoError(t, err)
+
+	err = engine.UseDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id INTEGER, title VARCHAR, age INTEGER, PRIMARY KEY id)", nil, true)
+	require.NoError(t, err)
+
+	rowCount := 20
+	base := 40
+
+	for i := 0; i < rowCount; i++ {
+		params := make(map[string]interface{}, 3)
+		params["id"] = i
+		params["title"] = fmt.Sprintf("title%d", i%5)
+		params["age"] = base + (i*7)%rowCount
+
+		_, _, err = engine.ExecStmt("UPSERT INTO table1 (id, title, age) VALUES (@id, @title, @age)", params, true)
+		require.NoError(t, err)
+	}
+
+	_, _, err = engine.ExecStmt("UPSERT INTO table1 (id, title) VALUES (100, 'title9')", nil, true)
+	require.NoError(t, err)
+
+	readIDs := func(t *testing.T, query string, params map[string]interface{}) []uint64 {
+		r, err := engine.QueryStmt(query, params, true)
+		require.NoError(t, err)
+
+		var ids []uint64
+
+		for {
+			row, err := r.Read()
+			if err == ErrNoMoreRows {
+				break
+			}
+			require.NoError(t, err)
+
+			ids = append(ids, row.Values[EncodeSelector("", "db1", "table1", "id")].Value().(uint64))
+		}
+
+		err = r.Close()
+		require.NoError(t, err)
+
+		return ids
+	}
+
+	checkOrderings := func(t *testing.T) {
+		// ages are a permutation of the ids, id i has age base + (i*7)%rowCount
+		byAge := []uint64{100}
+		for a := 0; a < rowCount; a++ {
+			for i := 0; i < rowCount; i++ {
+				if (i*7)%rowCount == a {
+					byAge = append(byAge, uint64(i))
+				}
+			}
+		}
+
+		require.Equal(t, byAge, readIDs(t, "SELECT id FROM table1 ORDER BY age", nil))
+
+		byAgeDesc := make([]uint64, len(byAge))
+		for i, id := range byAge {
+			byAgeDesc[len(byAge)-1-i] = id
+		}
+
+		require.Equal(t, byAgeDesc, readIDs(t, "SELECT id, age FROM table1 ORDER BY age DESC", nil))
+		require.Equal(t, byAgeDesc[:3], readIDs(t, "SELECT id FROM table1 ORDER BY age DESC LIMIT 3", nil))
+
+		var byTitle []uint64
+		for k := 4; k >= 0; k-- {
+			for i := k; i < rowCount; i += 5 {
+				byTitle = append(byTitle, uint64(i))
+			}
+		}
+
+		require.Equal(t, append([]uint64{100}, byTitle...), readIDs(t, "SELECT id FROM table1 ORDER BY title DESC, id", nil))
+
+		var byExp []uint64
+		for i := 0; i < rowCount; i++ {
+			byExp = append(byExp, uint64((i+rowCount/2)%rowCount))
+		}
+
+		require.Equal(t, byExp, readIDs(t, "SELECT id FROM table1 WHERE id < @n ORDER BY (id + @d) / 10 DESC, id", map[string]interface{}{"n": rowCount, "d": 0}))
+
+		r, err := engine.QueryStmt("SELECT title, COUNT(), MAX(age) FROM table1 WHERE id < 100 GROUP BY title ORDER BY SUM(age) DESC, title", nil, true)
+		require.NoError(t, err)
+
+		prev := uint64(0)
+
+		for i := 0; i < 5; i++ {
+			row, err := r.Read()
+			require.NoError(t, err)
+			require.Equal(t, uint64(4), row.Values[EncodeSelector("", "db1", "table1", "col1")].Value())
+
+			title := row.Values[EncodeSelector("", "db1", "table1", "title")].Value().(string)
+
+			var sum uint64
+			for id := int(title[len(title)-1] - '0'); id < rowCount; id += 5 {
+				sum += uint64(base + (id*7)%rowCount)
+			}
+
+			if i > 0 {
+				require.LessOrEqual(t, sum, prev)
+			}
+			prev = sum
+		}
+
+		_, err = r.Read()
+		require.Equal(t, ErrNoMoreRows, err)
+
+		err = r.Close()
+		require.NoError(t, err)
+	}
+
+	t.Run("rows sorted in memory", checkOrderings)
+
+	err = engine.SetSortBufferSize(64)
+	require.NoError(t, err)
+
+	t.Run("rows sorted in temporary files", checkOrderings)
+
+	r, err := engine.QueryStmt("SELECT id FROM table1 ORDER BY id + title", nil, true)
+	require.NoError(t, err)
+
+	_, err = r.Read()
+	require.Error(t, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	err = engine.Close()
+	require.NoError(t, err)
+}
+
+func TestQueryWithRowFiltering(t *testing.T) {
+	catalogStore, err := store.Open("catalog_where", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_where")
+
+	dataStore, err := store.Open("sqldata_where", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_where")
+
+	engine, err := NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
+	require.NoError(t, err)
+
+	err = engine.UseDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id INTEGER, title VARCHAR, active BOOLEAN, payload BLOB, PRIMARY KEY id)", nil, true)
+	require.NoError(t, err)
+
+	rowCount := 10
+
+	for i := 0; i < rowCount; i++ {
+		encPayload := hex.EncodeToString([]byte(fmt.Sprintf("blob%d", i)))
+		_, _, err = engine.ExecStmt(fmt.Sprintf("UPSERT INTO table1 (id, title, active, payload) VALUES (%d, 'title%d', %v, x'%s')", i, i, i%2 == 0, encPayload), nil, true)
+		require.NoError(t, err)
+	}
+
+	r, err := engine.QueryStmt("SELECT id, title, active FROM table1 WHERE false", nil, true)
+	require.NoError(t, err)
+
+	_, err = r.Read()
+	require.Equal(t, ErrNoMoreRows, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.QueryStmt("SELECT id, title, active FROM table1 WHERE false OR true", nil, true)
+	require.NoError(t, err)
+
+	for i := 0; i < rowCount; i++ {
+		_, err := r.Read()
+		require.NoError(t, err)
+	}
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.QueryStmt("SELECT id, title, active FROM table1 WHERE 1 < 2", nil, true)
+	require.NoError(t, err)
+
+	for i := 0; i < rowCount; i++ {
+		_, err := r.Read()
+		require.NoError(t, err)
+	}
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.QueryStmt("SELECT id, title, active FROM table1 WHERE 1 >= 2", nil, true)
+	require.NoError(t, err)
+
+	_, err = r.Read()
+	require.Equal(t, ErrNoMoreRows, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.QueryStmt("SELECT id, title, active FROM table1 WHERE 1 = true", nil, true)
+	require.NoError(t, err)
+
+	_, err = r.Read()
+	require.Equal(t, ErrNotComparableValues, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.QueryStmt("SELECT id, title, active FROM table1 WHERE NOT table1.active", nil, true)
+	require.NoError(t, err)
+
+	for i := 0; i < rowCount/2; i++ {
+		_, err := r.Read()
+		require.NoError(t, err)
+	}
+
+	_, err = r.Read()
+	require.Equal(t, ErrNoMoreRows, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.QueryStmt("SELECT id, title, active FROM table1 WHERE table1.id > 4", nil, true)
+	require.NoError(t, err)
+
+	for i := 0; i < rowCount/2; i++ {
+		_, err := r.Read()
+		require.NoError(t, err)
+	}
+
+	_, err = r.Read()
+	require.Equal(t, ErrNoMoreRows, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt(fmt.Sprintf("UPSERT INTO table1 (id, title) VALUES (%d, 'title%d')", rowCount, rowCount), nil, true)
+	require.NoError(t, err)
+
+	r, err = engine.QueryStmt("SELECT id, title FROM table1 WHERE active IS NULL AND payload IS NULL", nil, true)
+	require.NoError(t, err)
+
+	_, err = r.Read()
+	require.NoError(t, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.QueryStmt("SELECT id, title FROM table1 WHERE active IS NULL AND payload IS NULL AND active = payload", nil, true)
+	require.NoError(t, err)
+
+	_, err = r.Read()
+	require.Equal(t, ErrNotComparableValues, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	err = engine.Close()
+	require.NoError(t, err)
+}
+
+func TestAggregations(t *testing.T) {
+	catalogStore, err := store.Open("catalog_agg", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_agg")
+
+	dataStore, err := store.Open("sqldata_agg", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_agg")
+
+	engine, err := NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
+	require.NoError(t, err)
+
+	err = engine.UseDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id INTEGER, title VARCHAR, age INTEGER, active BOOLEAN, payload BLOB, PRIMARY KEY id)", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE INDEX ON table1(age)", nil, true)
+	require.NoError(t, err)
+
+	rowCount := 10
+	base := 30
+
+	for i := 1; i <= rowCount; i++ {
+		params := make(map[string]interface{}, 3)
+		params["id"] = i
+		params["title"] = fmt.Sprintf("title%d", i)
+		params["age"] = base + i
+
+		_, _, err = engine.ExecStmt("UPSERT INTO table1 (id, title, age) VALUES (@id, @title, @age)", params, true)
+		require.NoError(t, err)
+	}
+
+	r, err := engine.QueryStmt("SELECT COUNT() FROM table1 WHERE id < i", nil, true)
+	require.NoError(t, err)
+
+	_, err = r.Read()
+	require.True(t, errors.Is(err, ErrColumnDoesNotExist))
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.QueryStmt("SELECT id FROM table1 WHERE false", nil, true)
+	require.NoError(t, err)
+
+	row, err := r.Read()
+	require.Equal(t, ErrNoMoreRows, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.QueryStmt("SELECT COUNT(), SUM(age), MIN(title), MAX(age), AVG(age), MIN(active), MAX(active), MIN(payload) FROM table1 WHERE false", nil, true)
+	require.NoError(t, err)
+
+	row, err = r.Read()
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), row.Values[EncodeSelector("", "db1", "table1", "col0")].Value())
+	require.Equal(t, uint64(0), row.Values[EncodeSelector("", "db1", "table1", "col1")].Value())
+	require.Equal(t, "", row.Values[EncodeSelector("", "db1", "table1", "col2")].Value())
+	require.Equal(t, uint64(0), row.Values[EncodeSelector("", "db1", "table1", "col3")].Value())
+	require.Equal(t, uint64(0), row.Values[EncodeSelector("", "db1", "table1", "col4")].Value())
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.QueryStmt("SELECT COUNT() AS c, SUM(age), MIN(age), MAX(age), AVG(age) FROM table1 AS t1", nil, true)
+	require.NoError(t, err)
+
+	cols, err := r.Columns()
+	require.NoError(t, err)
+	require.Len(t, cols, 5)
+
+	row, err = r.Read()
+	require.NoError(t, err)
+	require.NotNil(t, row)
+	require.Len(t, row.Values, 5)
+
+	require.Equal(t, uint64(rowCount), row.Values[EncodeSelector("", "db1", "t1", "c")].Value())
+
+	require.Equal(t, uint64((1+2*base+rowCount)*rowCount/2), row.Values[EncodeSelector("", "db1", "t1", "col1")].Value())
+
+	require.Equal(t, uint64(1+base), row.Values[EncodeSelector("", "db1", "t1", "col2")].Value())
+
+	require.Equal(t, uint64(base+rowCount), row.Values[EncodeSelector("", "db1", "t1", "col3")].Value())
+
+	require.Equal(t, uint64(base+rowCount/2), row.Values[EncodeSelector("", "db1", "t1", "col4")].Value())
+
+	_, err = r.Read()
+	require.Equal(t, ErrNoMoreRows, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	err = engine.Close()
+	require.NoError(t, err)
+}
+
+func TestGroupByHaving(t *testing.T) {
+	catalogStore, err := store.Open("catalog_having", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_having")
+
+	dataStore, err := store.Open("sqldata_having", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_having")
+
+	engine, err := NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
+	require.NoError(t, err)
+
+	err = engine.UseDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id INTEGER, title VARCHAR, age INTEGER, active BOOLEAN, PRIMARY KEY id)", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE INDEX ON table1(active)", nil, true)
+	require.NoError(t, err)
+
+	rowCount := 10
+	base := 40
+
+	for i := 0; i < rowCount; i++ {
+		params := make(map[string]interface{}, 4)
+		params["id"] = i
+		params["title"] = fmt.Sprintf("title%d", i)
+		params["age"] = base + i
+		params["active"] = i%2 == 0
+
+		_, _, err = engine.ExecStmt("UPSERT INTO table1 (id, title, age, active) VALUES (@id, @title, @age, @active)", params, true)
+		require.NoError(t, err)
+	}
+
+	_, err = engine.QueryStmt("SELECT active, COUNT(), SUM(age1) FROM table1 WHERE active IS NOT NULL HAVING AVG(age) >= MIN(age)", nil, true)
+	require.Equal(t, ErrHavingClauseRequiresGroupClause, err)
+
+	r, err := engine.QueryStmt("SELECT active, COUNT(), SUM(age1) FROM table1 WHERE active IS NOT NULL GROUP BY active HAVING AVG(age) >= MIN(age)", nil, true)
+	require.NoError(t, err)
+
+	_, err = r.Read()
+	require.True(t, errors.Is(err, ErrColumnDoesNotExist))
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.QueryStmt("SELECT active, COUNT(), SUM(age1) FROM table1 WHERE AVG(age) >= MIN(age) GROUP BY active", nil, true)
+	require.NoError(t, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.QueryStmt("SELECT active, COUNT(id) FROM table1 GROUP BY active", nil, true)
+	require.NoError(t, err)
+
+	_, err = r.Read()
+	require.Equal(t, ErrLimitedCount, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.QueryStmt("SELECT active, COUNT() FROM table1 GROUP BY active HAVING AVG(age) >= MIN(age1)", nil, true)
+	require.NoError(t, err)
+
+	_, err = r.Read()
+	require.True(t, errors.Is(err, ErrColumnDoesNotExist))
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.QueryStmt("SELECT active, COUNT() as c, MIN(age), MAX(age), AVG(age), SUM(age) FROM table1 GROUP BY active HAVING COUNT() <= SUM(age) AND MIN(age) <= MAX(age) AND AVG(age) <= MAX(age) AND MAX(age) < SUM(age) AND AVG(age) >= MIN(age) AND SUM(age) > 0 ORDER BY active DESC", nil, true)
+	require.NoError(t, err)
+
+	_, err = r.Columns()
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.NotNil(t, row)
+		require.Len(t, row.Values, 6)
+
+		require.Equal(t, i == 0, row.Values[EncodeSelector("", "db1", "table1", "active")].Value())
+
+		require.Equal(t, uint64(rowCount/2), row.Values[EncodeSelector("", "db1", "table1", "c")].Value())
+
+		if i%2 == 0 {
+			require.Equal(t, uint64(base), row.Values[EncodeSelector("", "db1", "table1", "col2")].Value())
+			require.Equal(t, uint64(base+rowCount-2), row.Values[EncodeSelector("", "db1", "table1", "col3")].Value())
+		} else {
+			require.Equal(t, uint64(base+1), row.Values[EncodeSelector("", "db1", "table1", "col2")].Value())
+			require.Equal(t, uint64(base+rowCount-1), row.Values[EncodeSelector("", "db1", "table1", "col3")].Value())
+		}
+	}
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	err = engine.Close()
+	require.NoError(t, err)
+}
+
+func TestGroupByExpressions(t *testing.T) {
+	catalogStore, err := store.Open("catalog_groupby_exps", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_groupby_exps")
+
+	dataStore, err := store.Open("sqldata_groupby_exps", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_groupby_exps")
+
+	engine, err := NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
+
+	err = engine.SetMaxGroupsInMemory(0)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
+	require.NoError(t, err)
+
+	err = engine.UseDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id INTEGER, title VARCHAR, age INTEGER, active BOOLEAN, PRIMARY KEY id)", nil, true)
+	require.NoError(t, err)
+
+	rowCount := 20
+	base := 40
+
+	for i := 0; i < rowCount; i++ {
+		params := make(map[string]interface{}, 4)
+		params["id"] = i
+		params["title"] = fmt.Sprintf("title%d", i%7)
+		params["age"] = base + i
+		params["active"] = i%2 == 0
+
+		_, _, err = engine.ExecStmt("UPSERT INTO table1 (id, title, age, active) VALUES (@id, @title, @age, @active)", params, true)
+		require.NoError(t, err)
+	}
+
+	_, _, err = engine.ExecStmt("UPSERT INTO table1 (id, title, active) VALUES (100, 'title7', true)", nil, true)
+	require.NoError(t, err)
+
+	t.Run("unordered rows are grouped", func(t *testing.T) {
+		r, err := engine.QueryStmt("SELECT active, COUNT(), SUM(age), MIN(age) FROM table1 WHERE id < 100 GROUP BY active", nil, true)
+		require.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			row, err := r.Read()
+			require.NoError(t, err)
+
+			require.Equal(t, i == 0, row.Values[EncodeSelector("", "db1", "table1", "active")].Value())
+			require.Equal(t, uint64(rowCount/2), row.Values[EncodeSelector("", "db1", "table1", "col1")].Value())
+			require.Equal(t, uint64(490+i*10), row.Values[EncodeSelector("", "db1", "table1", "col2")].Value())
+			require.Equal(t, uint64(base+i), row.Values[EncodeSelector("", "db1", "table1", "col3")].Value())
+		}
+
+		_, err = r.Read()
+		require.Equal(t, ErrNoMoreRows, err)
+
+		err = r.Close()
+		require.NoError(t, err)
+	})
+
+	t.Run("grouping by expressions", func(t *testing.T) {
+		params := map[string]interface{}{"d": 10}
+
+		r, err := engine.QueryStmt("SELECT COUNT() AS c, MIN(age), MAX(age), AVG(age) FROM table1 WHERE id < 100 GROUP BY age / @d, active", params, true)
+		require.NoError(t, err)
+
+		for i := 0; i < 4; i++ {
+			row, err := r.Read()
+			require.NoError(t, err)
+
+			require.Equal(t, uint64(5), row.Values[EncodeSelector("", "db1", "table1", "c")].Value())
+			require.Equal(t, uint64(base+(i/2)*10+i%2), row.Values[EncodeSelector("", "db1", "table1", "col1")].Value())
+			require.Equal(t, uint64(base+(i/2)*10+8+i%2), row.Values[EncodeSelector("", "db1", "table1", "col2")].Value())
+			require.Equal(t, uint64(base+(i/2)*10+4+i%2), row.Values[EncodeSelector("", "db1", "table1", "col3")].Value())
+		}
+
+		_, err = r.Read()
+		require.Equal(t, ErrNoMoreRows, err)
+
+		err = r.Close()
+		require.NoError(t, err)
+	})
+
+	t.Run("null values are grouped together and ignored by aggregations", func(t *testing.T) {
+		r, err := engine.QueryStmt("SELECT COUNT(), SUM(age), MAX(age) FROM table1 WHERE id >= 19 GROUP BY age", nil, true)
+		require.NoError(t, err)
+
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), row.Values[EncodeSelector("", "db1", "table1", "col0")].Value())
+		require.Equal(t, uint64(base+19), row.Values[EncodeSelector("", "db1", "table1", "col1")].Value())
+
+		row, err = r.Read()
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), row.Values[EncodeSelector("", "db1", "table1", "col0")].Value())
+		require.Equal(t, uint64(0), row.Values[EncodeSelector("", "db1", "table1", "col1")].Value())
+		require.Nil(t, row.Values[EncodeSelector("", "db1", "table1", "col2")].Value())
+
+		_, err = r.Read()
+		require.Equal(t, ErrNoMoreRows, err)
+
+		err = r.Close()
+		require.NoError(t, err)
+	})
+
+	t.Run("having filters with aggregations not in the projection", func(t *testing.T) {
+		r, err := engine.QueryStmt("SELECT active FROM table1 WHERE id < 100 GROUP BY active HAVING SUM(age) > 495 AND COUNT() = 10", nil, true)
+		require.NoError(t, err)
+
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.Len(t, row.Values, 1)
+		require.Equal(t, false, row.Values[EncodeSelector("", "db1", "table1", "active")].Value())
+
+		_, err = r.Read()
+		require.Equal(t, ErrNoMoreRows, err)
+
+		err = r.Close()
+		require.NoError(t, err)
+	})
+
+	t.Run("grouping without aggregations", func(t *testing.T) {
+		r, err := engine.QueryStmt("SELECT title FROM table1 GROUP BY title", nil, true)
+		require.NoError(t, err)
+
+		for i := 0; i < 8; i++ {
+			row, err := r.Read()
+			require.NoError(t, err)
+			require.Equal(t, fmt.Sprintf("title%d", i), row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
+		}
+
+		_, err = r.Read()
+		require.Equal(t, ErrNoMoreRows, err)
+
+		err = r.Close()
+		require.NoError(t, err)
+	})
+
+	t.Run("groups spilled to disk", func(t *testing.T) {
+		err := engine.SetMaxGroupsInMemory(3)
+		require.NoError(t, err)
+
+		r, err := engine.QueryStmt("SELECT title, COUNT(), MAX(age), MIN(age), AVG(age), SUM(age) FROM table1 WHERE id < 100 GROUP BY title HAVING COUNT() > 1", nil, true)
+		require.NoError(t, err)
+
+		for i := 0; i < 7; i++ {
+			row, err := r.Read()
+			require.NoError(t, err)
+
+			count := (rowCount - i + 6) / 7
+			first := base + i
+			last := base + i + (count-1)*7
+
+			sum := 0
+			for a := first; a <= last; a += 7 {
+				sum += a
+			}
+
+			require.Equal(t, fmt.Sprintf("title%d", i), row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
+			require.Equal(t, uint64(count), row.Values[EncodeSelector("", "db1", "table1", "col1")].Value())
+			require.Equal(t, uint64(last), row.Values[EncodeSelector("", "db1", "table1", "col2")].Value())
+			require.Equal(t, uint64(first), row.Values[EncodeSelector("", "db1", "table1", "col3")].Value())
+			require.Equal(t, uint64(sum/count), row.Values[EncodeSelector("", "db1", "table1", "col4")].Value())
+			require.Equal(t, uint64(sum), row.Values[EncodeSelector("", "db1", "table1", "col5")].Value())
+		}
+
+		_, err = r.Read()
+		require.Equal(t, ErrNoMoreRows, err)
+
+		err = r.Close()
+		require.NoError(t, err)
+	})
+
+	err = engine.Close()
+	require.NoError(t, err)
+}
+
+func TestJoins(t *testing.T) {
+	catalogStore, err := store.Open("catalog_innerjoin", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_innerjoin")
+
+	dataStore, err := store.Open("sqldata_innerjoin", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_innerjoin")
+
+	engine, err := NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
+	require.NoError(t, err)
+
+	err = engine.UseDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id INTEGER, title VARCHAR, fkid1 INTEGER, fkid2 INTEGER, PRIMARY KEY id)", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE TABLE table2 (id INTEGER, amount INTEGER, PRIMARY KEY id)", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE TABLE table3 (id INTEGER, age INTEGER, PRIMARY KEY id)", nil, true)
+	require.NoError(t, err)
+
+	rowCount := 10
+
+	for i := 0; i < rowCount; i++ {
+		_, _, err = engine.ExecStmt(fmt.Sprintf("UPSERT INTO table1 (id, title, fkid1, fkid2) VALUES (%d, 'title%d', %d, %d)", i, i, rowCount-1-i, i), nil, true)
+		require.NoError(t, err)
+
+		_, _, err = engine.ExecStmt(fmt.Sprintf("UPSERT INTO table2 (id, amount) VALUES (%d, %d)", rowCount-1-i, i*i), nil, true)
+		require.NoError(t, err)
+
+		_, _, err = engine.ExecStmt(fmt.Sprintf("UPSERT INTO table3 (id, age) VALUES (%d, %d)", i, 30+i), nil, true)
+		require.NoError(t, err)
+	}
+
+	r, err := engine.QueryStmt("SELECT id, title, table2.amount FROM table1 INNER JOIN table2 ON table1.fkid1 = table1.fkid1", nil, true)
+	require.NoError(t, err)
+
+	_, err = r.Read()
+	require.Equal(t, ErrJointColumnNotFound, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.QueryStmt("SELECT id, title, table2.amount, table3.age FROM table1 INNER JOIN table2 ON table1.fkid1 = table2.id INNER JOIN table3 ON table1.fkid2 = table3.id WHERE table1.id >= 0 AND table3.age >= 30 ORDER BY id DESC", nil, true)
+	require.NoError(t, err)
+
+	cols, err := r.Columns()
+	require.NoError(t, err)
+	require.Len(t, cols, 4)
+
+	for i := 0; i < rowCount; i++ {
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.NotNil(t, row)
+		require.Len(t, row.Values, 4)
+
+		require.Equal(t, uint64(rowCount-1-i), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
+		require.Equal(t, fmt.Sprintf("title%d", rowCount-1-i), row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
+		require.Equal(t, uint64((rowCount-1-i)*(rowCount-1-i)), row.Values[EncodeSelector("", "db1", "table2", "amount")].Value())
+		require.Equal(t, uint64(30+(rowCount-1-i)), row.Values[EncodeSelector("", "db1", "table3", "age")].Value())
+	}
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt(fmt.Sprintf("UPSERT INTO table1 (id, title, fkid1, fkid2) VALUES (%d, 'title%d', %d, %d)", rowCount, rowCount, rowCount, rowCount), nil, true)
+	require.NoError(t, err)
+
+	r, err = engine.QueryStmt("SELECT id, title, table2.amount, table3.age FROM table1 INNER JOIN table2 ON table1.fkid1 = table2.id INNER JOIN table3 ON table1.fkid2 = table3.id ORDER BY id DESC", nil, true)
+	require.NoError(t, err)
+
+	for i := 0; i < rowCount; i++ {
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.NotNil(t, row)
+		require.Len(t, row.Values, 4)
+
+		require.Equal(t, uint64(rowCount-1-i), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
+		require.Equal(t, fmt.Sprintf("title%d", rowCount-1-i), row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
+		require.Equal(t, uint64((rowCount-1-i)*(rowCount-1-i)), row.Values[EncodeSelector("", "db1", "table2", "amount")].Value())
+		require.Equal(t, uint64(30+(rowCount-1-i)), row.Values[EncodeSelector("", "db1", "table3", "age")].Value())
+	}
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	err = engine.Close()
+	require.NoError(t, err)
+}
+
+func TestNestedJoins(t *testing.T) {
+	catalogStore, err := store.Open("catalog_nestedjoins", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_nestedjoins")
+
+	dataStore, err := store.Open("sqldata_nestedjoins", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_nestedjoins")
+
+	engine, err := NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
+	require.NoError(t, err)
+
+	err = engine.UseDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id INTEGER, title VARCHAR, fkid1 INTEGER, PRIMARY KEY id)", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE TABLE table2 (id INTEGER, amount INTEGER, fkid1 INTEGER, PRIMARY KEY id)", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE TABLE table3 (id INTEGER, age INTEGER, PRIMARY KEY id)", nil, true)
+	require.NoError(t, err)
+
+	rowCount := 10
+
+	for i := 0; i < rowCount; i++ {
+		_, _, err = engine.ExecStmt(fmt.Sprintf("UPSERT INTO table1 (id, title, fkid1) VALUES (%d, 'title%d', %d)", i, i, rowCount-1-i), nil, true)
+		require.NoError(t, err)
+
+		_, _, err = engine.ExecStmt(fmt.Sprintf("UPSERT INTO table2 (id, amount, fkid1) VALUES (%d, %d, %d)", rowCount-1-i, i*i, i), nil, true)
+		require.NoError(t, err)
+
+		_, _, err = engine.ExecStmt(fmt.Sprintf("UPSERT INTO table3 (id, age) VALUES (%d, %d)", i, 30+i), nil, true)
+		require.NoError(t, err)
+	}
+
+	r, err := engine.QueryStmt("SELECT id, title, t2.amount AS total_amount, t3.age FROM (table1 AS t1) INNER JOIN (table2 as t2) ON fkid1 = t2.id INNER JOIN (table3 as t3) ON t2.fkid1 = t3.id ORDER BY id DESC", nil, true)
+	require.NoError(t, err)
+
+	cols, err := r.Columns()
+	require.NoError(t, err)
+	require.Len(t, cols, 4)
+
+	for i := 0; i < rowCount; i++ {
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.NotNil(t, row)
+		require.Len(t, row.Values, 4)
+
+		require.Equal(t, uint64(rowCount-1-i), row.Values[EncodeSelector("", "db1", "t1", "id")].Value())
+		require.Equal(t, fmt.Sprintf("title%d", rowCount-1-i), row.Values[EncodeSelector("", "db1", "t1", "title")].Value())
+		require.Equal(t, uint64((rowCount-1-i)*(rowCount-1-i)), row.Values[EncodeSelector("", "db1", "t2", "total_amount")].Value())
+		require.Equal(t, uint64(30+(rowCount-1-i)), row.Values[EncodeSelector("", "db1", "t3", "age")].Value())
+	}
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	err = engine.Close()
+	require.NoError(t, err)
+}
+
+func TestOuterJoins(t *testing.T) {
+	catalogStore, err := store.Open("catalog_outerjoins", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_outerjoins")
+
+	dataStore, err := store.Open("sqldata_outerjoins", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_outerjoins")
+
+	engine, err := NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
+	require.NoError(t, err)
+
+	err = engine.UseDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt(`
+		CREATE TABLE regions (id INTEGER, name VARCHAR, PRIMARY KEY id);
+		CREATE TABLE customers (id INTEGER, name VARCHAR, region INTEGER, PRIMARY KEY id);
+		CREATE TABLE orders (id INTEGER, customer INTEGER, amount INTEGER, PRIMARY KEY id);
+	`, nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt(`
+		INSERT INTO regions (id, name) VALUES (1, 'north');
+		INSERT INTO customers (id, name, region) VALUES (1, 'alice', 1), (2, 'bob', 2), (3, 'carol', 1);
+		INSERT INTO orders (id, customer, amount) VALUES (1, 1, 10), (2, 1, 20), (3, 4, 5);
+		INSERT INTO orders (id, amount) VALUES (4, 7);
+	`, nil, true)
+	require.NoError(t, err)
+
+	query := func(t *testing.T, sql string, sels ...string) [][]interface{} {
+		r, err := engine.QueryStmt(sql, nil, true)
+		require.NoError(t, err)
+		defer r.Close()
+
+		var rows [][]interface{}
+
+		for {
+			row, err := r.Read()
+			if err == ErrNoMoreRows {
+				break
+			}
+			require.NoError(t, err)
+
+			vals := make([]interface{}, len(sels))
+			for i, sel := range sels {
+				vals[i] = row.Values[sel].Value()
+			}
+
+			rows = append(rows, vals)
+		}
+
+		return rows
+	}
+
+	orderID := EncodeSelector("", "db1", "orders", "id")
+	customerName := EncodeSelector("", "db1", "customers", "name")
+	regionName := EncodeSelector("", "db1", "regions", "name")
+
+	t.Run("left join keeps rows without a match", func(t *testing.T) {
+		rows := query(t, "SELECT orders.id, customers.name FROM orders LEFT JOIN customers ON orders.customer = customers.id", orderID, customerName)
+
+		require.Equal(t, [][]interface{}{
+			{uint64(1), "alice"},
+			{uint64(2), "alice"},
+			{uint64(3), nil},
+			{uint64(4), nil},
+		}, rows)
+	})
+
+	t.Run("left outer join with null padded rows joined again", func(t *testing.T) {
+		rows := query(t, `
+			SELECT orders.id, customers.name, regions.name
+			FROM orders
+			LEFT OUTER JOIN customers ON orders.customer = customers.id
+			LEFT OUTER JOIN regions ON customers.region = regions.id
+			WHERE orders.amount > 5`, orderID, customerName, regionName)
+
+		require.Equal(t, [][]interface{}{
+			{uint64(1), "alice", "north"},
+			{uint64(2), "alice", "north"},
+			{uint64(4), nil, nil},
+		}, rows)
+	})
+
+	t.Run("right join keeps joined rows without a match", func(t *testing.T) {
+		rows := query(t, "SELECT orders.id, customers.name FROM orders RIGHT OUTER JOIN customers ON orders.customer = customers.id", orderID, customerName)
+
+		require.Equal(t, [][]interface{}{
+			{uint64(1), "alice"},
+			{uint64(2), "alice"},
+			{nil, "bob"},
+			{nil, "carol"},
+		}, rows)
+
+		rows = query(t, "SELECT COUNT() FROM orders RIGHT JOIN customers ON orders.customer = customers.id", EncodeSelector("", "db1", "orders", "col0"))
+		require.Equal(t, [][]interface{}{{uint64(4)}}, rows)
+	})
+
+	t.Run("rows without a match in a right join go through the following joins", func(t *testing.T) {
+		rows := query(t, `
+			SELECT orders.id, customers.name, regions.name
+			FROM orders
+			RIGHT JOIN customers ON orders.customer = customers.id
+			INNER JOIN regions ON customers.region = regions.id`, orderID, customerName, regionName)
+
+		require.Equal(t, [][]interface{}{
+			{uint64(1), "alice", "north"},
+			{uint64(2), "alice", "north"},
+			{nil, "carol", "north"},
+		}, rows)
+	})
+
+	t.Run("inner join discards rows with null values", func(t *testing.T) {
+		rows := query(t, "SELECT orders.id FROM orders INNER JOIN customers ON orders.customer = customers.id", orderID)
+		require.Equal(t, [][]interface{}{{uint64(1)}, {uint64(2)}}, rows)
+	})
+
+	err = engine.Close()
+	require.NoError(t, err)
+}
+
+func TestSubqueries(t *testing.T) {
+	catalogStore, err := store.Open("catalog_subqueries", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_subqueries")
+
+	dataStore, err := store.Open("sqldata_subqueries", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_subqueries")
+
+	engine, err := NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
+	require.NoError(t, err)
+
+	err = engine.UseDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt(`
+		CREATE TABLE customers (id INTEGER, name VARCHAR, region INTEGER, PRIMARY KEY id);
+		CREATE TABLE orders (id INTEGER, customer INTEGER, amount INTEGER, PRIMARY KEY id);
+	`, nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt(`
+		INSERT INTO customers (id, name, region) VALUES (1, 'alice', 1), (2, 'bob', 2), (3, 'carol', 1);
+		INSERT INTO orders (id, customer, amount) VALUES (1, 1, 10), (2, 1, 20), (3, 4, 5), (4, 3, 7);
+	`, nil, true)
+	require.NoError(t, err)
+
+	query := func(t *testing.T, sql string, params map[string]interface{}, sels ...string) [][]interface{} {
+		r, err := engine.QueryStmt(sql, params, true)
+		require.NoError(t, err)
+		defer r.Close()
+
+		var rows [][]interface{}
+
+		for {
+			row, err := r.Read()
+			if err == ErrNoMoreRows {
+				break
+			}
+			require.NoError(t, err)
+
+			vals := make([]interface{}, len(sels))
+			for i, sel := range sels {
+				vals[i] = row.Values[sel].Value()
+			}
+
+			rows = append(rows, vals)
+		}
+
+		return rows
+	}
+
+	customerID := EncodeSelector("", "db1", "customers", "id")
+	orderID := EncodeSelector("", "db1", "orders", "id")
+
+	t.Run("in list", func(t *testing.T) {
+		rows := query(t, "SELECT id FROM customers WHERE id IN (1, @id)", map[string]interface{}{"id": 3}, customerID)
+		require.Equal(t, [][]interface{}{{uint64(1)}, {uint64(3)}}, rows)
+
+		rows = query(t, "SELECT id FROM customers WHERE name NOT IN ('alice', 'carol')", nil, customerID)
+		require.Equal(t, [][]interface{}{{uint64(2)}}, rows)
+	})
+
+	t.Run("in sub-query", func(t *testing.T) {
+		rows := query(t, "SELECT id FROM customers WHERE id IN (SELECT customer FROM orders WHERE amount > @amount)", map[string]interface{}{"amount": 8}, customerID)
+		require.Equal(t, [][]interface{}{{uint64(1)}}, rows)
+
+		rows = query(t, "SELECT id FROM customers WHERE id NOT IN (SELECT customer FROM orders)", nil, customerID)
+		require.Equal(t, [][]interface{}{{uint64(2)}}, rows)
+	})
+
+	t.Run("scalar sub-query", func(t *testing.T) {
+		rows := query(t, "SELECT id FROM orders WHERE amount = (SELECT MAX(amount) FROM orders)", nil, orderID)
+		require.Equal(t, [][]interface{}{{uint64(2)}}, rows)
+
+		rows = query(t, "SELECT id FROM orders WHERE customer = (SELECT id FROM customers WHERE name = 'carol')", nil, orderID)
+		require.Equal(t, [][]interface{}{{uint64(4)}}, rows)
+
+		// no row is compared as null
+		rows = query(t, "SELECT id FROM orders WHERE customer = (SELECT id FROM customers WHERE id > 10)", nil, orderID)
+		require.Empty(t, rows)
+	})
+
+	t.Run("exists sub-query", func(t *testing.T) {
+		rows := query(t, "SELECT id FROM customers WHERE id < 3 AND EXISTS (SELECT id FROM orders WHERE amount > 15)", nil, customerID)
+		require.Equal(t, [][]interface{}{{uint64(1)}, {uint64(2)}}, rows)
+
+		rows = query(t, "SELECT id FROM customers WHERE NOT EXISTS (SELECT id FROM orders WHERE amount > 50)", nil, customerID)
+		require.Len(t, rows, 3)
+	})
+
+	t.Run("sub-query in having clause", func(t *testing.T) {
+		rows := query(t, `
+			SELECT customer, SUM(amount) AS total
+			FROM orders
+			GROUP BY customer
+			HAVING SUM(amount) > (SELECT amount FROM orders WHERE id = 4)`, nil,
+			EncodeSelector("", "db1", "orders", "customer"), EncodeSelector("", "db1", "orders", "total"))
+		require.Equal(t, [][]interface{}{{uint64(1), uint64(30)}}, rows)
+	})
+
+	t.Run("invalid sub-queries", func(t *testing.T) {
+		_, err := engine.QueryStmt("SELECT id FROM orders WHERE customer = (SELECT id FROM customers)", nil, true)
+		require.Equal(t, ErrSubqueryRows, err)
+
+		_, err = engine.QueryStmt("SELECT id FROM orders WHERE customer IN (SELECT id, name FROM customers)", nil, true)
+		require.Equal(t, ErrSubqueryColumns, err)
+
+		_, err = engine.QueryStmt("SELECT id FROM orders WHERE customer IN (SELECT id FROM customers2)", nil, true)
+		require.True(t, errors.Is(err, ErrTableDoesNotExist))
+
+		// sub-queries are limited to WHERE and HAVING clauses
+		r, err := engine.QueryStmt("SELECT id FROM orders ORDER BY (SELECT MAX(id) FROM customers)", nil, true)
+		require.NoError(t, err)
+
+		_, err = r.Read()
+		require.Equal(t, ErrLimitedSubqueries, err)
+
+		err = r.Close()
+		require.NoError(t, err)
+	})
+
+	t.Run("joined sub-queries", func(t *testing.T) {
+		cID := EncodeSelector("", "db1", "c", "id")
+		tCustomer := EncodeSelector("", "db1", "t", "customer")
+		tTotal := EncodeSelector("", "db1", "t", "total")
+
+		rows := query(t, `
+			SELECT c.id, t.total
+			FROM (customers AS c)
+			INNER JOIN (SELECT customer, SUM(amount) AS total FROM orders GROUP BY customer AS t) ON c.id = t.customer`, nil, cID, tTotal)
+		require.Equal(t, [][]interface{}{{uint64(1), uint64(30)}, {uint64(3), uint64(7)}}, rows)
+
+		rows = query(t, `
+			SELECT c.id, t.total
+			FROM (customers AS c)
+			LEFT JOIN (SELECT customer, SUM(amount) AS total FROM orders GROUP BY customer AS t) ON c.id = t.customer`, nil, cID, tTotal)
+		require.Equal(t, [][]interface{}{{uint64(1), uint64(30)}, {uint64(2), nil}, {uint64(3), uint64(7)}}, rows)
+
+		rows = query(t, `
+			SELECT c.id, t.customer
+			FROM (customers AS c)
+			RIGHT JOIN (SELECT customer, SUM(amount) AS total FROM orders GROUP BY customer AS t) ON c.id = t.customer`, nil, cID, tCustomer)
+		require.Equal(t, [][]interface{}{{uint64(1), uint64(1)}, {uint64(3), uint64(3)}, {nil, uint64(4)}}, rows)
+
+		// a row is repeated for every matching row of the sub-query
+		rows = query(t, `
+			SELECT c.id, o.id
+			FROM (customers AS c)
+			INNER JOIN (SELECT id, customer FROM orders WHERE amount > @amount AS o) ON c.id = o.customer`,
+			map[string]interface{}{"amount": 6}, cID, EncodeSelector("", "db1", "o", "id"))
+		require.Equal(t, [][]interface{}{{uint64(1), uint64(1)}, {uint64(1), uint64(2)}, {uint64(3), uint64(4)}}, rows)
+	})
+
+	t.Run("sub-queries in delete and update statements", func(t *testing.T) {
+		_, _, err := engine.ExecStmt("DELETE FROM orders WHERE customer NOT IN (SELECT id FROM customers)", nil, true)
+		require.NoError(t, err)
+
+		_, _, err = engine.ExecStmt("UPDATE orders SET amount = 0 WHERE customer IN (SELECT id FROM customers WHERE region = 1)", nil, true)
+		require.NoError(t, err)
+
+		rows := query(t, "SELECT id, amount FROM orders", nil, orderID, EncodeSelector("", "db1", "orders", "amount"))
+		require.Equal(t, [][]interface{}{{uint64(1), uint64(0)}, {uint64(2), uint64(0)}, {uint64(4), uint64(0)}}, rows)
+	})
+
+	err = engine.Close()
+	require.NoError(t, err)
+}
+
+func TestFloatType(t *testing.T) {
+	catalogStore, err := store.Open("catalog_float", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_float")
+
+	dataStore, err := store.Open("sqldata_float", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_float")
+
+	engine, err := NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
+	require.NoError(t, err)
+
+	err = engine.UseDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt(`
+		CREATE TABLE measures (id INTEGER, sensor INTEGER, value FLOAT, PRIMARY KEY id);
+		CREATE INDEX ON measures(value);
+	`, nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt(`
+		INSERT INTO measures (id, sensor, value)
+		VALUES (1, 1, 2.5), (2, 1, -1.25), (3, 2, 0.0), (4, 2, -10.5), (5, 1, 100.75), (6, 2, @value)
+	`, map[string]interface{}{"value": 3.0}, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("INSERT INTO measures (id, sensor, value) VALUES (7, 1, @value)", map[string]interface{}{"value": math.NaN()}, true)
+	require.Equal(t, ErrUnsupportedParameter, err)
+
+	query := func(t *testing.T, sql string, params map[string]interface{}, sel string) []interface{} {
+		r, err := engine.QueryStmt(sql, params, true)
+		require.NoError(t, err)
+		defer r.Close()
+
+		var vals []interface{}
+
+		for {
+			row, err := r.Read()
+			if err == ErrNoMoreRows {
+				break
+			}
+			require.NoError(t, err)
+
+			vals = append(vals, row.Values[sel].Value())
+		}
+
+		return vals
+	}
+
+	t.Run("float values should be ordered within indexes", func(t *testing.T) {
+		vals := query(t, "SELECT id, value FROM measures ORDER BY value", nil, "(db1.measures.value)")
+		require.Equal(t, []interface{}{-10.5, -1.25, 0.0, 2.5, 3.0, 100.75}, vals)
+
+		vals = query(t, "SELECT id, value FROM measures ORDER BY value DESC", nil, "(db1.measures.id)")
+		require.Equal(t, []interface{}{uint64(5), uint64(6), uint64(1), uint64(3), uint64(2), uint64(4)}, vals)
+	})
+
+	t.Run("float values should be compared with integers and floats", func(t *testing.T) {
+		vals := query(t, "SELECT id FROM measures WHERE value > 2", nil, "(db1.measures.id)")
+		require.Equal(t, []interface{}{uint64(1), uint64(5), uint64(6)}, vals)
+
+		vals = query(t, "SELECT id FROM measures WHERE value >= @lower AND value < -1.25", map[string]interface{}{"lower": -10.5}, "(db1.measures.id)")
+		require.Equal(t, []interface{}{uint64(4)}, vals)
+
+		vals = query(t, "SELECT id FROM measures WHERE value = 3", nil, "(db1.measures.id)")
+		require.Equal(t, []interface{}{uint64(6)}, vals)
+	})
+
+	t.Run("arithmetic with floats should produce floats", func(t *testing.T) {
+		vals := query(t, "SELECT id FROM measures WHERE value * 2 + 1 = 6.0", nil, "(db1.measures.id)")
+		require.Equal(t, []interface{}{uint64(1)}, vals)
+
+		vals = query(t, "SELECT id FROM measures WHERE value / 2 = -0.625", nil, "(db1.measures.id)")
+		require.Equal(t, []interface{}{uint64(2)}, vals)
+
+		r, err := engine.QueryStmt("SELECT id FROM measures WHERE value / 0.0 > 0", nil, true)
+		require.NoError(t, err)
+
+		_, err = r.Read()
+		require.Equal(t, ErrDivisionByZero, err)
+
+		err = r.Close()
+		require.NoError(t, err)
+	})
+
+	t.Run("float values should be aggregated as floats", func(t *testing.T) {
+		r, err := engine.QueryStmt("SELECT sensor, SUM(value), AVG(value), MIN(value), MAX(value) FROM measures GROUP BY sensor", nil, true)
+		require.NoError(t, err)
+		defer r.Close()
+
+		cols, err := r.Columns()
+		require.NoError(t, err)
+		require.Equal(t, FloatType, cols[1].Type)
+		require.Equal(t, FloatType, cols[2].Type)
+
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.Equal(t, 102.0, row.Values["(db1.measures.col1)"].Value())
+		require.Equal(t, 34.0, row.Values["(db1.measures.col2)"].Value())
+		require.Equal(t, -1.25, row.Values["(db1.measures.col3)"].Value())
+		require.Equal(t, 100.75, row.Values["(db1.measures.col4)"].Value())
+
+		row, err = r.Read()
+		require.NoError(t, err)
+		require.Equal(t, -7.5, row.Values["(db1.measures.col1)"].Value())
+		require.Equal(t, -2.5, row.Values["(db1.measures.col2)"].Value())
+
+		_, err = r.Read()
+		require.Equal(t, ErrNoMoreRows, err)
+	})
+}
+
+func TestTimestampType(t *testing.T) {
+	catalogStore, err := store.Open("catalog_timestamp", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_timestamp")
+
+	dataStore, err := store.Open("sqldata_timestamp", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_timestamp")
+
+	engine, err := NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
+	require.NoError(t, err)
+
+	err = engine.UseDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt(`
+		CREATE TABLE events (id INTEGER, ts TIMESTAMP, PRIMARY KEY id);
+		CREATE INDEX ON events(ts);
+	`, nil, true)
+	require.NoError(t, err)
+
+	ts4 := time.Date(1960, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	_, _, err = engine.ExecStmt(`
+		INSERT INTO events (id, ts)
+		VALUES (1, '2021-10-01 12:00:00+02'), (2, '2021-10-01T09:30:00Z'), (3, '2021-10-02'), (4, @ts), (5, NOW())
+	`, map[string]interface{}{"ts": ts4}, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("INSERT INTO events (id, ts) VALUES (6, 'yesterday')", nil, true)
+	require.Equal(t, ErrInvalidValue, err)
+
+	_, _, err = engine.ExecStmt("INSERT INTO events (id, ts) VALUES (6, 1)", nil, true)
+	require.Equal(t, ErrInvalidValue, err)
+
+	query := func(t *testing.T, sql string, params map[string]interface{}) []interface{} {
+		r, err := engine.QueryStmt(sql, params, true)
+		require.NoError(t, err)
+		defer r.Close()
+
+		var ids []interface{}
+
+		for {
+			row, err := r.Read()
+			if err == ErrNoMoreRows {
+				break
+			}
+			require.NoError(t, err)
+
+			ids = append(ids, row.Values["(db1.events.id)"].Value())
+		}
+
+		return ids
+	}
+
+	t.Run("timestamps should be stored in UTC", func(t *testing.T) {
+		r, err := engine.QueryStmt("SELECT ts FROM events WHERE id = 1", nil, true)
+		require.NoError(t, err)
+		defer r.Close()
+
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.Equal(t, time.Date(2021, 10, 1, 10, 0, 0, 0, time.UTC), row.Values["(db1.events.ts)"].Value())
+	})
+
+	t.Run("timestamps should be ordered within indexes", func(t *testing.T) {
+		ids := query(t, "SELECT id FROM events ORDER BY ts", nil)
+		require.Equal(t, []interface{}{uint64(4), uint64(2), uint64(1), uint64(3), uint64(5)}, ids)
+
+		ids = query(t, "SELECT id FROM events ORDER BY ts DESC", nil)
+		require.Equal(t, []interface{}{uint64(5), uint64(3), uint64(1), uint64(2), uint64(4)}, ids)
+	})
+
+	t.Run("timestamps should be compared with strings, parameters and NOW()", func(t *testing.T) {
+		ids := query(t, "SELECT id FROM events WHERE ts = '2021-10-01 10:00:00'", nil)
+		require.Equal(t, []interface{}{uint64(1)}, ids)
+
+		ids = query(t, "SELECT id FROM events WHERE ts >= @start AND ts < '2021-10-02' ORDER BY ts", map[string]interface{}{"start": time.Date(2021, 10, 1, 9, 30, 0, 0, time.UTC)})
+		require.Equal(t, []interface{}{uint64(2), uint64(1)}, ids)
+
+		ids = query(t, "SELECT id FROM events WHERE '2021-10-01' > ts", nil)
+		require.Equal(t, []interface{}{uint64(4)}, ids)
+
+		ids = query(t, "SELECT id FROM events WHERE ts < NOW() AND ts > '2021-10-01' ORDER BY ts DESC", nil)
+		require.Equal(t, []interface{}{uint64(5), uint64(3), uint64(1), uint64(2)}, ids)
+
+		_, err := engine.QueryStmt("SELECT id FROM events WHERE ts > 'tomorrow'", nil, true)
+		require.NoError(t, err)
+	})
+
+	t.Run("range comparisons should bound indexed scans", func(t *testing.T) {
+		db, err := engine.catalog.GetDatabaseByName("db1")
+		require.NoError(t, err)
+
+		stmts, err := ParseString("SELECT id FROM events WHERE ts > '2021-10-01' AND id > 0 AND ts >= @start ORDER BY ts")
+		require.NoError(t, err)
+
+		stmt := stmts[0].(*SelectStmt)
+		params := map[string]interface{}{"start": time.Date(2021, 10, 1, 9, 30, 0, 0, time.UTC)}
+
+		seekVal := stmt.seekVal(engine, db, stmt.where, stmt.indexedOrdCol(engine, db), params)
+		require.Equal(t, encodeTimestamp(time.Date(2021, 10, 1, 9, 30, 0, 0, time.UTC)), seekVal)
+
+		stmts, err = ParseString("SELECT id FROM events WHERE ts > '2021-10-01' OR ts < NOW() ORDER BY ts")
+		require.NoError(t, err)
+
+		stmt = stmts[0].(*SelectStmt)
+
+		seekVal = stmt.seekVal(engine, db, stmt.where, stmt.indexedOrdCol(engine, db), nil)
+		require.Nil(t, seekVal)
+
+		ids := query(t, "SELECT id FROM events WHERE ts <= '2021-10-01 10:00:00' ORDER BY ts DESC", nil)
+		require.Equal(t, []interface{}{uint64(1), uint64(2), uint64(4)}, ids)
+	})
+
+	t.Run("timestamps should be aggregated", func(t *testing.T) {
+		r, err := engine.QueryStmt("SELECT MIN(ts), MAX(ts) FROM events WHERE id < 5", nil, true)
+		require.NoError(t, err)
+		defer r.Close()
+
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.Equal(t, ts4, row.Values["(db1.events.col0)"].Value())
+		require.Equal(t, time.Date(2021, 10, 2, 0, 0, 0, 0, time.UTC), row.Values["(db1.events.col1)"].Value())
+	})
+}
+
+func TestAutoIncrementPK(t *testing.T) {
+	catalogStore, err := store.Open("catalog_autoincrement", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_autoincrement")
+
+	dataStore, err := store.Open("sqldata_autoincrement", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_autoincrement")
+
+	engine, err := NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
+	require.NoError(t, err)
+
+	err = engine.UseDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id VARCHAR AUTO_INCREMENT, PRIMARY KEY id)", nil, true)
+	require.Equal(t, ErrLimitedAutoIncrement, err)
+
+	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id INTEGER, n INTEGER AUTO_INCREMENT, PRIMARY KEY id)", nil, true)
+	require.Equal(t, ErrLimitedAutoIncrement, err)
+
+	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id INTEGER AUTO_INCREMENT, title VARCHAR, PRIMARY KEY id)", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("ALTER TABLE table1 ADD COLUMN n INTEGER AUTO_INCREMENT", nil, true)
+	require.Equal(t, ErrLimitedAutoIncrement, err)
+
+	_, _, err = engine.ExecStmt("CREATE TABLE table2 (id INTEGER, PRIMARY KEY id)", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("INSERT INTO table2 (id) VALUES (1)", nil, true)
+	require.NoError(t, err)
+
+	summary, err := engine.ExecPreparedStmtsWithSummary(context.Background(), mustParse(t, "INSERT INTO table1 (title) VALUES ('a'), ('b')"), nil, true)
+	require.NoError(t, err)
+	require.Len(t, summary.DMTxs, 1)
+	require.Equal(t, map[string]uint64{"table1": 2}, summary.LastInsertedPKs)
+
+	// provided values move the sequence forward
+	summary, err = engine.ExecPreparedStmtsWithSummary(context.Background(), mustParse(t, "INSERT INTO table1 (id, title) VALUES (10, 'c')"), nil, true)
+	require.NoError(t, err)
+	require.Equal(t, map[string]uint64{"table1": 10}, summary.LastInsertedPKs)
+
+	summary, err = engine.ExecPreparedStmtsWithSummary(context.Background(), mustParse(t, "UPSERT INTO table1 (id, title) VALUES (1, 'aa')"), nil, true)
+	require.NoError(t, err)
+	require.Empty(t, summary.LastInsertedPKs)
+
+	_, _, err = engine.ExecStmt("DELETE FROM table1 WHERE id = 10", nil, true)
+	require.NoError(t, err)
+
+	// the sequence is durable, thus deleted values are not generated again
+	err = engine.Close()
+	require.NoError(t, err)
+
+	engine, err = NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
+
+	err = engine.UseDatabase("db1")
+	require.NoError(t, err)
+
+	summary, err = engine.ExecPreparedStmtsWithSummary(context.Background(), mustParse(t, "UPSERT INTO table1 (title) VALUES ('d')"), nil, true)
+	require.NoError(t, err)
+	require.Equal(t, map[string]uint64{"table1": 11}, summary.LastInsertedPKs)
+
+	r, err := engine.QueryStmt("SELECT id, title FROM table1", nil, true)
+	require.NoError(t, err)
+
+	expected := []struct {
+		id    uint64
+		title string
+	}{{1, "aa"}, {2, "b"}, {11, "d"}}
+
+	for _, e := range expected {
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.Equal(t, e.id, row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
+		require.Equal(t, e.title, row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
+	}
+
+	_, err = r.Read()
+	require.Equal(t, ErrNoMoreRows, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	require.Contains(t, engine.Catalog().dbsByName["db1"].DDL(), "CREATE TABLE table1 (id INTEGER AUTO_INCREMENT NOT NULL, title VARCHAR, PRIMARY KEY id);")
+
+	_, _, err = engine.ExecStmt("TRUNCATE TABLE table1", nil, true)
+	require.NoError(t, err)
+
+	summary, err = engine.ExecPreparedStmtsWithSummary(context.Background(), mustParse(t, "INSERT INTO table1 (title) VALUES ('e')"), nil, true)
+	require.NoError(t, err)
+	require.Equal(t, map[string]uint64{"table1": 1}, summary.LastInsertedPKs)
+
+	// a pk is required when there is no sequence
+	_, _, err = engine.ExecStmt("INSERT INTO table2 (id) VALUES (NULL)", nil, true)
+	require.Equal(t, ErrPKCanNotBeNull, err)
+}
+
+func mustParse(t *testing.T, sql string) []SQLStmt {
+	stmts, err := ParseString(sql)
+	require.NoError(t, err)
+	return stmts
+}
+
+func TestJSONType(t *testing.T) {
+	catalogStore, err := store.Open("catalog_json", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_json")
+
+	dataStore, err := store.Open("sqldata_json", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_json")
+
+	engine, err := NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
+	require.NoError(t, err)
+
+	err = engine.UseDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE TABLE docs (id JSON, PRIMARY KEY id)", nil, true)
+	require.Equal(t, ErrInvalidPK, err)
+
+	_, _, err = engine.ExecStmt("CREATE TABLE people (id INTEGER, doc JSON, PRIMARY KEY id)", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE INDEX ON people(doc)", nil, true)
+	require.True(t, errors.Is(err, ErrNonIndexableType))
+
+	_, _, err = engine.ExecStmt(`
+		INSERT INTO people (id, doc)
+		VALUES
+			(1, '{"name": "alice", "age": 30, "address": {"city": "lisbon"}, "tags": ["a", "b"]}'),
+			(2, '{"name": "bob", "age": 25.5, "address": {"city": "porto"}, "tags": []}'),
+			(3, @doc),
+			(4, NULL)
+	`, map[string]interface{}{"doc": `{"name": "carol", "age": 41, "admin": true, "address": null}`}, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("INSERT INTO people (id, doc) VALUES (5, '{\"name\": ')", nil, true)
+	require.True(t, errors.Is(err, ErrInvalidJSON))
+
+	_, _, err = engine.ExecStmt("INSERT INTO people (id, doc) VALUES (5, '{} {}')", nil, true)
+	require.True(t, errors.Is(err, ErrInvalidJSON))
+
+	_, _, err = engine.ExecStmt("INSERT INTO people (id, doc) VALUES (5, 1)", nil, true)
+	require.Equal(t, ErrInvalidValue, err)
+
+	query := func(t *testing.T, sql string) []*Row {
+		r, err := engine.QueryStmt(sql, nil, true)
+		require.NoError(t, err)
+		defer r.Close()
+
+		var rows []*Row
+
+		for {
+			row, err := r.Read()
+			if err == ErrNoMoreRows {
+				break
+			}
+			require.NoError(t, err)
+
+			rows = append(rows, row)
+		}
+
+		return rows
+	}
+
+	t.Run("documents should be stored in canonical form", func(t *testing.T) {
+		rows := query(t, "SELECT doc FROM people WHERE id = 3")
+		require.Len(t, rows, 1)
+		require.Equal(t, `{"address":null,"admin":true,"age":41,"name":"carol"}`, rows[0].Values["(db1.people.doc)"].Value())
+	})
+
+	t.Run("values should be extracted as JSON or as text", func(t *testing.T) {
+		r, err := engine.QueryStmt("SELECT id, doc->'address', doc->'address'->>'city' AS city, doc->'tags'->0, JSON_VALUE(doc, '$.name') FROM people", nil, true)
+		require.NoError(t, err)
+
+		cols, err := r.Columns()
+		require.NoError(t, err)
+		require.Len(t, cols, 5)
+		require.Equal(t, JSONType, cols[1].Type)
+		require.Equal(t, VarcharType, cols[2].Type)
+		require.Equal(t, "(db1.people.city)", cols[2].Selector)
+		require.Equal(t, JSONType, cols[3].Type)
+		require.Equal(t, VarcharType, cols[4].Type)
+
+		r.Close()
+
+		rows := query(t, "SELECT id, doc->'address', doc->'address'->>'city' AS city, doc->'tags'->0, JSON_VALUE(doc, '$.name') FROM people")
+		require.Len(t, rows, 4)
+
+		require.Equal(t, `{"city":"lisbon"}`, rows[0].Values["(db1.people.col1)"].Value())
+		require.Equal(t, "lisbon", rows[0].Values["(db1.people.city)"].Value())
+		require.Equal(t, `"a"`, rows[0].Values["(db1.people.col3)"].Value())
+		require.Equal(t, "alice", rows[0].Values["(db1.people.col4)"].Value())
+
+		require.Nil(t, rows[1].Values["(db1.people.col3)"].Value())
+
+		require.Equal(t, "null", rows[2].Values["(db1.people.col1)"].Value())
+		require.Nil(t, rows[2].Values["(db1.people.city)"].Value())
+
+		for _, v := range rows[3].Values {
+			if v.Type() != IntegerType {
+				require.Nil(t, v.Value())
+			}
+		}
+	})
+
+	t.Run("JSON_VALUE should select scalar values", func(t *testing.T) {
+		rows := query(t, `SELECT JSON_VALUE(doc, '$.address'), JSON_VALUE(doc, '$.tags[1]'), JSON_VALUE(doc, '$."age"') FROM people WHERE id = 1`)
+		require.Len(t, rows, 1)
+		require.Nil(t, rows[0].Values["(db1.people.col0)"].Value())
+		require.Equal(t, "b", rows[0].Values["(db1.people.col1)"].Value())
+		require.Equal(t, "30", rows[0].Values["(db1.people.col2)"].Value())
+	})
+
+	t.Run("extracted values should be compared with other values", func(t *testing.T) {
+		ids := func(rows []*Row) []interface{} {
+			var ids []interface{}
+			for _, row := range rows {
+				ids = append(ids, row.Values["(db1.people.id)"].Value())
+			}
+			return ids
+		}
+
+		require.Equal(t, []interface{}{uint64(1), uint64(3)}, ids(query(t, "SELECT id FROM people WHERE doc->'age' >= 30")))
+		require.Equal(t, []interface{}{uint64(2)}, ids(query(t, "SELECT id FROM people WHERE id < 4 AND 26 > doc->'age'")))
+		require.Equal(t, []interface{}{uint64(2)}, ids(query(t, "SELECT id FROM people WHERE doc->'address'->>'city' = 'porto'")))
+		require.Equal(t, []interface{}{uint64(3)}, ids(query(t, "SELECT id FROM people WHERE doc->'admin' = TRUE")))
+		require.Equal(t, []interface{}{uint64(1), uint64(2)}, ids(query(t, `SELECT id FROM people WHERE doc->'address'->>'city' = 'lisbon' OR JSON_VALUE(doc, '$.name') = 'bob'`)))
+		require.Equal(t, []interface{}{uint64(3), uint64(1), uint64(2)}, ids(query(t, "SELECT id FROM people WHERE id < 4 ORDER BY doc->'age' DESC")))
+
+		r, err := engine.QueryStmt("SELECT id FROM people WHERE doc->'address' > 1", nil, true)
+		require.NoError(t, err)
+
+		_, err = r.Read()
+		require.Equal(t, ErrNotComparableValues, err)
+
+		r.Close()
+	})
+
+	t.Run("values should be extracted from JSON columns only", func(t *testing.T) {
+		r, err := engine.QueryStmt("SELECT id->'a' FROM people", nil, true)
+		require.NoError(t, err)
+
+		_, err = r.Read()
+		require.True(t, errors.Is(err, ErrExpectingJSONColumn))
+
+		r.Close()
+
+		_, err = engine.QueryStmt("SELECT doc->>'a'->'b' FROM people", nil, true)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), ErrInvalidJSONPath.Error())
+
+		_, err = engine.QueryStmt("SELECT JSON_VALUE(doc, 'address') FROM people", nil, true)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), ErrInvalidJSONPath.Error())
+	})
+}
+
+func TestReOpening(t *testing.T) {
+	catalogStore, err := store.Open("catalog_reopening", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_reopening")
+
+	dataStore, err := store.Open("sqldata_reopening", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_reopening")
+
+	engine, err := NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("USE DATABASE db1; CREATE TABLE table1 (id INTEGER, name VARCHAR, PRIMARY KEY id)", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("USE DATABASE db1; CREATE INDEX ON table1(name)", nil, true)
+	require.NoError(t, err)
+
+	engine, err = NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
+
+	exists := engine.catalog.ExistDatabase("db1")
+	require.True(t, exists)
+
+	db, err := engine.catalog.GetDatabaseByName("db1")
+	require.NoError(t, err)
+
+	exists = db.ExistTable("table1")
+	require.True(t, exists)
+
+	table, err := db.GetTableByName("table1")
+	require.NoError(t, err)
+
+	require.Equal(t, "id", table.pk.colName)
+
+	require.Len(t, table.ColsByID(), 2)
+
+	col, err := table.GetColumnByName("id")
+	require.NoError(t, err)
+	require.Equal(t, IntegerType, col.colType)
+
+	col, err = table.GetColumnByName("name")
+	require.NoError(t, err)
+	require.Equal(t, VarcharType, col.colType)
+
+	require.Len(t, table.indexes, 1)
+
+	_, indexed := table.indexes[col.id]
+	require.True(t, indexed)
+
+	err = engine.Close()
+	require.NoError(t, err)
+}
+
+func TestReOpeningAfterVacuum(t *testing.T) {
+	now := time.Now()
+
+	opts := store.DefaultOptions().
+		WithFileSize(64).
+		WithRetentionPeriod(time.Hour).
+		WithTimeFunc(func() time.Time { return now })
+
+	st, err := store.Open("sqldata_reopening_vacuum", opts)
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_reopening_vacuum")
+
+	engine, err := NewEngine(st, st, prefix)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("USE DATABASE db1; CREATE TABLE table1 (id INTEGER, name VARCHAR, PRIMARY KEY id)", nil, true)
+	require.NoError(t, err)
+
+	for i := 1; i <= 10; i++ {
+		_, _, err = engine.ExecStmt(fmt.Sprintf("USE DATABASE db1; UPSERT INTO table1 (id, name) VALUES (%d, 'name%d')", i%3, i), nil, true)
+		require.NoError(t, err)
+	}
+
+	err = engine.Close()
+	require.NoError(t, err)
+
+	// all the transactions fall out of the retention period
+	now = now.Add(2 * time.Hour)
+
+	err = st.Vacuum()
+	require.NoError(t, err)
+
+	err = st.Close()
+	require.NoError(t, err)
+
+	st, err = store.Open("sqldata_reopening_vacuum", opts)
+	require.NoError(t, err)
+
+	err = st.WaitForIndexingUpto(context.Background(), st.TxCount())
+	require.NoError(t, err)
+
+	// the catalog and the current rows are still readable
+	engine, err = NewEngine(st, st, prefix)
+	require.NoError(t, err)
+
+	err = engine.UseDatabase("db1")
+	require.NoError(t, err)
+
+	r, err := engine.QueryStmt("SELECT id, name FROM table1", nil, true)
+	require.NoError(t, err)
+
+	for _, name := range []string{"name9", "name10", "name8"} {
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.Equal(t, name, row.Values[EncodeSelector("", "db1", "table1", "name")].Value())
+	}
+
+	_, err = r.Read()
+	require.Equal(t, ErrNoMoreRows, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	err = engine.Close()
+	require.NoError(t, err)
+
+	err = st.Close()
+	require.NoError(t, err)
+}
+
+func TestSubQuery(t *testing.T) {
+	catalogStore, err := store.Open("catalog_subq", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_subq")
+
+	dataStore, err := store.Open("sqldata_subq", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_subq")
+
+	engine, err := NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
+	require.NoError(t, err)
+
+	err = engine.UseDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id INTEGER, title VARCHAR, active BOOLEAN, payload BLOB, PRIMARY KEY id)", nil, true)
+	require.NoError(t, err)
+
+	rowCount := 10
+
+	for i := 0; i < rowCount; i++ {
+		encPayload := hex.EncodeToString([]byte(fmt.Sprintf("blob%d", i)))
+		_, _, err = engine.ExecStmt(fmt.Sprintf("UPSERT INTO table1 (id, title, active, payload) VALUES (%d, 'title%d', %v, x'%s')", i, i, i%2 == 0, encPayload), nil, true)
+		require.NoError(t, err)
+	}
+
+	r, err := engine.QueryStmt("SELECT id, title AS t FROM (SELECT id, title, active FROM table1 AS table2) WHERE active AND table2.id >= 0", nil, true)
+	require.NoError(t, err)
+
+	cols, err := r.Columns()
+	require.NoError(t, err)
+	require.Len(t, cols, 2)
+
+	for i := 0; i < rowCount; i += 2 {
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.NotNil(t, row)
+		require.Len(t, row.Values, 2)
+
+		require.Equal(t, uint64(i), row.Values[EncodeSelector("", "db1", "table2", "id")].Value())
+		require.Equal(t, fmt.Sprintf("title%d", i), row.Values[EncodeSelector("", "db1", "table2", "t")].Value())
+	}
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("UPSERT INTO table1 (id, title) VALUES (0, 'title0')", nil, true)
+	require.NoError(t, err)
+
+	r, err = engine.QueryStmt("SELECT id, title, active FROM (SELECT id, title, active FROM table1) WHERE active", nil, true)
+	require.NoError(t, err)
+
+	_, err = r.Read()
+	require.NoError(t, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.QueryStmt("SELECT id, title, active FROM (SELECT id, title, active FROM table1) WHERE title", nil, true)
+	require.NoError(t, err)
+
+	_, err = r.Read()
+	require.Equal(t, ErrInvalidCondition, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+
+	err = engine.Close()
+	require.NoError(t, err)
+}
+
+func TestUniqueConstraints(t *testing.T) {
+	catalogStore, err := store.Open("catalog_unique", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_unique")
+
+	dataStore, err := store.Open("sqldata_unique", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_unique")
+
+	engine, err := NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
+	require.NoError(t, err)
+
+	err = engine.UseDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id INTEGER UNIQUE, PRIMARY KEY id)", nil, true)
+	require.True(t, errors.Is(err, ErrIndexAlreadyExists))
+
+	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id INTEGER, doc JSON UNIQUE, PRIMARY KEY id)", nil, true)
+	require.True(t, errors.Is(err, ErrNonIndexableType))
+
+	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id INTEGER, email VARCHAR NOT NULL UNIQUE, name VARCHAR, PRIMARY KEY id)", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("ALTER TABLE table1 ADD COLUMN nick VARCHAR UNIQUE", nil, true)
+	require.Equal(t, ErrLimitedIndex, err)
 
-		require.Equal(t, uint64(i), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
-		require.Equal(t, fmt.Sprintf("title%d", i), row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
-		require.Equal(t, uint64(40+i), row.Values[EncodeSelector("", "db1", "table1", "age")].Value())
-	}
+	_, _, err = engine.ExecStmt("INSERT INTO table1 (id, email, name) VALUES (1, 'a@immudb.io', 'a'), (2, 'b@immudb.io', 'b')", nil, true)
+	require.NoError(t, err)
 
-	err = r.Close()
+	_, _, err = engine.ExecStmt("INSERT INTO table1 (id, email, name) VALUES (3, 'a@immudb.io', 'c')", nil, true)
+	require.True(t, errors.Is(err, ErrUniqueConstraintViolation))
+	require.Contains(t, err.Error(), "table1(email)")
+
+	// rows written by the same statement can't share values either
+	_, _, err = engine.ExecStmt("INSERT INTO table1 (id, email, name) VALUES (3, 'c@immudb.io', 'c'), (4, 'c@immudb.io', 'd')", nil, true)
+	require.True(t, errors.Is(err, ErrUniqueConstraintViolation))
+
+	// rows keep their own values when replaced
+	_, _, err = engine.ExecStmt("UPSERT INTO table1 (id, email, name) VALUES (1, 'a@immudb.io', 'aa')", nil, true)
 	require.NoError(t, err)
 
-	r, err = engine.QueryStmt("SELECT id, title, age FROM table1 ORDER BY age DESC", nil, true)
+	_, _, err = engine.ExecStmt("UPDATE table1 SET name = 'bb' WHERE id = 2", nil, true)
 	require.NoError(t, err)
 
-	for i := 0; i < rowCount; i++ {
-		row, err := r.Read()
-		require.NoError(t, err)
-		require.NotNil(t, row)
-		require.Len(t, row.Values, 3)
+	_, _, err = engine.ExecStmt("UPDATE table1 SET email = 'a@immudb.io' WHERE id = 2", nil, true)
+	require.True(t, errors.Is(err, ErrUniqueConstraintViolation))
 
-		require.Equal(t, uint64(rowCount-1-i), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
-		require.Equal(t, fmt.Sprintf("title%d", rowCount-1-i), row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
-		require.Equal(t, uint64(40-(rowCount-1-i)), row.Values[EncodeSelector("", "db1", "table1", "age")].Value())
-	}
+	// values no longer held by a row can be taken by another one
+	_, _, err = engine.ExecStmt("UPDATE table1 SET email = 'aa@immudb.io' WHERE id = 1", nil, true)
+	require.NoError(t, err)
 
-	err = r.Close()
+	_, _, err = engine.ExecStmt("UPSERT INTO table1 (id, email, name) VALUES (2, 'a@immudb.io', 'b')", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("DELETE FROM table1 WHERE id = 1", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("INSERT INTO table1 (id, email, name) VALUES (3, 'aa@immudb.io', 'c')", nil, true)
 	require.NoError(t, err)
 
+	require.Contains(t, engine.Catalog().dbsByName["db1"].DDL(), "CREATE TABLE table1 (id INTEGER NOT NULL, email VARCHAR NOT NULL UNIQUE, name VARCHAR, PRIMARY KEY id);")
+
+	// the constraint is kept once the catalog is loaded again
 	err = engine.Close()
 	require.NoError(t, err)
+
+	engine, err = NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
+
+	err = engine.UseDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("INSERT INTO table1 (id, email, name) VALUES (4, 'b@immudb.io', 'd')", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("INSERT INTO table1 (id, email, name) VALUES (5, 'aa@immudb.io', 'e')", nil, true)
+	require.True(t, errors.Is(err, ErrUniqueConstraintViolation))
+
+	_, _, err = engine.ExecStmt("DROP INDEX ON table1(email)", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("INSERT INTO table1 (id, email, name) VALUES (5, 'aa@immudb.io', 'e')", nil, true)
+	require.NoError(t, err)
 }
 
-func TestQueryWithRowFiltering(t *testing.T) {
-	catalogStore, err := store.Open("catalog_where", store.DefaultOptions())
+func TestExplain(t *testing.T) {
+	catalogStore, err := store.Open("catalog_explain", store.DefaultOptions())
 	require.NoError(t, err)
-	defer os.RemoveAll("catalog_where")
+	defer os.RemoveAll("catalog_explain")
 
-	dataStore, err := store.Open("sqldata_where", store.DefaultOptions())
+	dataStore, err := store.Open("sqldata_explain", store.DefaultOptions())
 	require.NoError(t, err)
-	defer os.RemoveAll("sqldata_where")
+	defer os.RemoveAll("sqldata_explain")
 
 	engine, err := NewEngine(catalogStore, dataStore, prefix)
 	require.NoError(t, err)
@@ -908,127 +4915,419 @@ func TestQueryWithRowFiltering(t *testing.T) {
 	err = engine.UseDatabase("db1")
 	require.NoError(t, err)
 
-	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id INTEGER, title VARCHAR, active BOOLEAN, payload BLOB, PRIMARY KEY id)", nil, true)
+	_, _, err = engine.ExecStmt(`
+		BEGIN TRANSACTION
+			CREATE TABLE customers (id INTEGER, name VARCHAR, PRIMARY KEY id);
+			CREATE TABLE orders (id INTEGER, customer_id INTEGER, amount INTEGER, PRIMARY KEY id);
+			CREATE INDEX ON orders(amount);
+		COMMIT
+	`, nil, true)
 	require.NoError(t, err)
 
-	rowCount := 10
+	_, _, err = engine.ExecStmt("INSERT INTO customers (id, name) VALUES (1, 'acme'), (2, 'globex')", nil, true)
+	require.NoError(t, err)
 
-	for i := 0; i < rowCount; i++ {
-		encPayload := hex.EncodeToString([]byte(fmt.Sprintf("blob%d", i)))
-		_, _, err = engine.ExecStmt(fmt.Sprintf("UPSERT INTO table1 (id, title, active, payload) VALUES (%d, 'title%d', %v, x'%s')", i, i, i%2 == 0, encPayload), nil, true)
+	_, _, err = engine.ExecStmt(`
+		INSERT INTO orders (id, customer_id, amount) VALUES
+			(1, 1, 10), (2, 2, 20), (3, 1, 300), (4, 2, 400)
+	`, nil, true)
+	require.NoError(t, err)
+
+	explain := func(sql string, params map[string]interface{}) []string {
+		r, err := engine.QueryStmt(sql, params, true)
+		require.NoError(t, err)
+		defer r.Close()
+
+		cols, err := r.Columns()
 		require.NoError(t, err)
+		require.Len(t, cols, 1)
+		require.Equal(t, EncodeSelector("", "db1", "explain", PlanColumn), cols[0].Selector)
+		require.Equal(t, VarcharType, cols[0].Type)
+
+		var lines []string
+
+		for {
+			row, err := r.Read()
+			if err == ErrNoMoreRows {
+				break
+			}
+			require.NoError(t, err)
+
+			lines = append(lines, row.Values[cols[0].Selector].Value().(string))
+		}
+
+		return lines
 	}
 
-	r, err := engine.QueryStmt("SELECT id, title, active FROM table1 WHERE false", nil, true)
+	// rows are read from the index in the requested order, starting from the bound set by the condition
+	require.Equal(t, []string{
+		"PROJECT id",
+		"-> FILTER (amount >= 100)",
+		"   -> SCAN orders USING INDEX ON (amount) ASC, SEEKING THE BOUND SET BY THE CONDITION",
+	}, explain("EXPLAIN SELECT id FROM orders WHERE amount >= @lower ORDER BY amount", map[string]interface{}{"lower": 100}))
+
+	require.Equal(t, []string{
+		"PROJECT id, amount LIMIT 2",
+		"-> FILTER (amount < 100)",
+		"   -> SCAN orders USING INDEX ON (amount) DESC, SEEKING THE BOUND SET BY THE CONDITION",
+	}, explain("EXPLAIN SELECT id, amount FROM orders WHERE amount < 100 ORDER BY amount DESC LIMIT 2", nil))
+
+	require.Equal(t, []string{
+		"PROJECT id",
+		"-> FILTER (id IN (1, 4))",
+		"   -> SCAN orders USING PRIMARY KEY (id) ASC, RESTRICTED TO 2 VALUE RANGES",
+	}, explain("EXPLAIN SELECT id FROM orders WHERE id IN (1, 4)", nil))
+
+	// reading the whole primary key of such a small table is cheaper than looking up the rows read from the index
+	require.Equal(t, []string{
+		"PROJECT id",
+		"-> FILTER (amount IN (10, 400))",
+		"   -> SCAN orders USING PRIMARY KEY (id) ASC",
+	}, explain("EXPLAIN SELECT id FROM orders WHERE amount IN (10, 400)", nil))
+
+	// subqueries are materialized before the plan is chosen
+	require.Equal(t, []string{
+		"PROJECT id",
+		"-> FILTER (customer_id IN (1))",
+		"   -> SCAN orders USING PRIMARY KEY (id) ASC",
+	}, explain("EXPLAIN SELECT id FROM orders WHERE customer_id IN (SELECT id FROM customers WHERE name = 'acme')", nil))
+
+	require.Equal(t, []string{
+		"PROJECT orders.id, customers.name",
+		"-> FILTER (customers.name = 'acme')",
+		"   -> JOIN",
+		"      -> SCAN orders USING PRIMARY KEY (id) ASC",
+		"      -> INNER JOIN customers LOOKING UP PRIMARY KEY (id) BY orders.customer_id",
+	}, explain("EXPLAIN SELECT orders.id, customers.name FROM orders INNER JOIN customers ON customers.id = orders.customer_id WHERE customers.name = 'acme'", nil))
+
+	require.Equal(t, []string{
+		"PROJECT customer_id, SUM(amount) AS total",
+		"-> SORT BY customer_id DESC",
+		"   -> GROUP BY customer_id COMPUTING SUM(amount)",
+		"      -> SCAN orders USING PRIMARY KEY (id) ASC",
+	}, explain("EXPLAIN SELECT customer_id, SUM(amount) AS total FROM orders GROUP BY customer_id ORDER BY customer_id DESC", nil))
+
+	// the query is run when analyzed, so the rows returned by each step are known
+	lines := explain("EXPLAIN ANALYZE SELECT id FROM (orders AS o) WHERE amount > 15", nil)
+	require.Len(t, lines, 3)
+	require.Regexp(t, `^PROJECT id \(rows=3, time=.+\)$`, lines[0])
+	require.Regexp(t, `^-> FILTER \(amount > 15\) \(rows=3, time=.+\)$`, lines[1])
+	require.Regexp(t, `^   -> SCAN orders AS o USING PRIMARY KEY \(id\) ASC \(rows=4, time=.+\)$`, lines[2])
+
+	_, err = engine.QueryStmt("EXPLAIN SELECT id FROM invoices", nil, true)
+	require.True(t, errors.Is(err, ErrTableDoesNotExist))
+}
+
+func TestCostBasedPlans(t *testing.T) {
+	catalogStore, err := store.Open("catalog_cost_based_plans", store.DefaultOptions())
 	require.NoError(t, err)
+	defer os.RemoveAll("catalog_cost_based_plans")
 
-	_, err = r.Read()
-	require.Equal(t, ErrNoMoreRows, err)
+	dataStore, err := store.Open("sqldata_cost_based_plans", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_cost_based_plans")
 
-	err = r.Close()
+	engine, err := NewEngine(catalogStore, dataStore, prefix)
 	require.NoError(t, err)
 
-	r, err = engine.QueryStmt("SELECT id, title, active FROM table1 WHERE false OR true", nil, true)
+	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
 	require.NoError(t, err)
 
-	for i := 0; i < rowCount; i++ {
-		_, err := r.Read()
-		require.NoError(t, err)
-	}
+	err = engine.UseDatabase("db1")
+	require.NoError(t, err)
 
-	err = r.Close()
+	_, _, err = engine.ExecStmt(`
+		BEGIN TRANSACTION
+			CREATE TABLE customers (id INTEGER, name VARCHAR, PRIMARY KEY id);
+			CREATE TABLE orders (id INTEGER, customer_id INTEGER, amount INTEGER, PRIMARY KEY id);
+			CREATE INDEX ON orders(amount);
+		COMMIT
+	`, nil, true)
 	require.NoError(t, err)
 
-	r, err = engine.QueryStmt("SELECT id, title, active FROM table1 WHERE 1 < 2", nil, true)
+	_, _, err = engine.ExecStmt(`
+		INSERT INTO customers (id, name) VALUES
+			(1, 'c1'), (2, 'c2'), (3, 'c3'), (4, 'c4'), (5, 'c5'), (6, 'c6'), (7, 'c7'), (8, 'c8'), (9, 'c9'), (10, 'c10')
+	`, nil, true)
 	require.NoError(t, err)
 
-	for i := 0; i < rowCount; i++ {
-		_, err := r.Read()
+	// amounts are a permutation of the ids
+	for i := 0; i < 10; i++ {
+		var values []string
+
+		for id := i*100 + 1; id <= (i+1)*100; id++ {
+			values = append(values, fmt.Sprintf("(%d, %d, %d)", id, id%10+1, (id*7)%1000))
+		}
+
+		_, _, err = engine.ExecStmt("INSERT INTO orders (id, customer_id, amount) VALUES "+strings.Join(values, ", "), nil, true)
 		require.NoError(t, err)
 	}
 
-	err = r.Close()
+	query := func(sql string) (lines []string) {
+		r, err := engine.QueryStmt(sql, nil, true)
+		require.NoError(t, err)
+		defer r.Close()
+
+		cols, err := r.Columns()
+		require.NoError(t, err)
+
+		for {
+			row, err := r.Read()
+			if err == ErrNoMoreRows {
+				break
+			}
+			require.NoError(t, err)
+
+			vals := make([]string, len(cols))
+			for i, c := range cols {
+				vals[i] = fmt.Sprintf("%v", row.Values[c.Selector].Value())
+			}
+
+			lines = append(lines, strings.Join(vals, " "))
+		}
+
+		return lines
+	}
+
+	// only the range of the index set by the conditions is read
+	require.Equal(t, []string{
+		"PROJECT id, amount",
+		"-> FILTER ((amount >= 100) AND (amount < 110))",
+		"   -> SCAN orders USING INDEX ON (amount) ASC, SEEKING THE BOUND SET BY THE CONDITION, STOPPING AT THE BOUND SET BY THE CONDITION",
+	}, query("EXPLAIN SELECT id, amount FROM orders WHERE amount >= 100 AND amount < 110"))
+
+	require.Equal(t, []string{
+		"300 100", "443 101", "586 102", "729 103", "872 104", "15 105", "158 106", "301 107", "444 108", "587 109",
+	}, query("SELECT id, amount FROM orders WHERE amount >= 100 AND amount < 110"))
+
+	lines := query("EXPLAIN ANALYZE SELECT id FROM orders WHERE id <= 5")
+	require.Len(t, lines, 3)
+	require.Regexp(t, `^-> FILTER \(id <= 5\) \(rows=5, time=.+\)$`, lines[1])
+	require.Regexp(t, `^   -> SCAN orders USING PRIMARY KEY \(id\) ASC, STOPPING AT THE BOUND SET BY THE CONDITION \(rows=5, time=.+\)$`, lines[2])
+
+	require.Equal(t, []string{
+		"PROJECT id",
+		"-> FILTER (amount = 500)",
+		"   -> SCAN orders USING INDEX ON (amount) ASC, RESTRICTED TO 1 VALUE RANGES",
+	}, query("EXPLAIN SELECT id FROM orders WHERE amount = 500"))
+
+	require.Equal(t, []string{"500"}, query("SELECT id FROM orders WHERE amount = 500"))
+
+	// the whole primary key is read when most of the rows satisfy the conditions
+	require.Equal(t, []string{
+		"PROJECT id",
+		"-> FILTER (amount > 10)",
+		"   -> SCAN orders USING PRIMARY KEY (id) ASC",
+	}, query("EXPLAIN SELECT id FROM orders WHERE amount > 10"))
+
+	// rows read in the order of the index stop at the bound set by the conditions
+	lines = query("EXPLAIN ANALYZE SELECT id, amount FROM orders WHERE amount >= 997 ORDER BY amount DESC")
+	require.Len(t, lines, 3)
+	require.Regexp(t, `^   -> SCAN orders USING INDEX ON \(amount\) DESC, STOPPING AT THE BOUND SET BY THE CONDITION \(rows=3, time=.+\)$`, lines[2])
+
+	require.Equal(t, []string{"857 999", "714 998", "571 997"}, query("SELECT id, amount FROM orders WHERE amount >= 997 ORDER BY amount DESC"))
+
+	// the smaller table is read first when the rows of both tables can be looked up by their primary key
+	require.Equal(t, []string{
+		"PROJECT orders.id, customers.name",
+		"-> JOIN",
+		"   -> SCAN customers USING PRIMARY KEY (id) ASC",
+		"   -> INNER JOIN orders LOOKING UP PRIMARY KEY (id) BY customers.id",
+	}, query("EXPLAIN SELECT orders.id, customers.name FROM orders INNER JOIN customers ON customers.id = orders.id"))
+
+	require.Len(t, query("SELECT orders.id, customers.name FROM orders INNER JOIN customers ON customers.id = orders.id"), 10)
+
+	// conditions over the columns of the table read first are applied before joining its rows
+	require.Equal(t, []string{
+		"PROJECT orders.id, customers.name",
+		"-> FILTER ((amount < 3) AND (customers.name != 'c1'))",
+		"   -> JOIN",
+		"      -> FILTER (amount < 3)",
+		"         -> SCAN orders USING INDEX ON (amount) ASC, STOPPING AT THE BOUND SET BY THE CONDITION",
+		"      -> INNER JOIN customers LOOKING UP PRIMARY KEY (id) BY orders.customer_id",
+	}, query("EXPLAIN SELECT orders.id, customers.name FROM orders INNER JOIN customers ON customers.id = orders.customer_id WHERE amount < 3 AND customers.name != 'c1'"))
+
+	require.Equal(t, []string{"1000 c1", "143 c4", "286 c7"}, query("SELECT orders.id, customers.name FROM orders INNER JOIN customers ON customers.id = orders.customer_id WHERE amount < 3"))
+	require.Equal(t, []string{"143 c4", "286 c7"}, query("SELECT orders.id, customers.name FROM orders INNER JOIN customers ON customers.id = orders.customer_id WHERE amount < 3 AND customers.name != 'c1'"))
+}
+
+func TestDistinct(t *testing.T) {
+	catalogStore, err := store.Open("catalog_distinct", store.DefaultOptions())
 	require.NoError(t, err)
+	defer os.RemoveAll("catalog_distinct")
 
-	r, err = engine.QueryStmt("SELECT id, title, active FROM table1 WHERE 1 >= 2", nil, true)
+	dataStore, err := store.Open("sqldata_distinct", store.DefaultOptions())
 	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_distinct")
 
-	_, err = r.Read()
-	require.Equal(t, ErrNoMoreRows, err)
+	engine, err := NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
 
-	err = r.Close()
+	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
 	require.NoError(t, err)
 
-	r, err = engine.QueryStmt("SELECT id, title, active FROM table1 WHERE 1 = true", nil, true)
+	err = engine.UseDatabase("db1")
 	require.NoError(t, err)
 
-	_, err = r.Read()
-	require.Equal(t, ErrNotComparableValues, err)
+	_, _, err = engine.ExecStmt("CREATE TABLE orders (id INTEGER, country VARCHAR, customer VARCHAR, amount INTEGER, PRIMARY KEY id)", nil, true)
+	require.NoError(t, err)
 
-	err = r.Close()
+	_, _, err = engine.ExecStmt(`
+		INSERT INTO orders (id, country, customer, amount) VALUES
+			(1, 'es', 'c1', 10), (2, 'it', 'c2', 20), (3, 'es', 'c1', 30), (4, 'es', 'c3', 10), (5, 'it', 'c2', 20)
+	`, nil, true)
 	require.NoError(t, err)
 
-	r, err = engine.QueryStmt("SELECT id, title, active FROM table1 WHERE NOT table1.active", nil, true)
+	_, _, err = engine.ExecStmt("INSERT INTO orders (id, amount) VALUES (6, 40), (7, 50)", nil, true)
 	require.NoError(t, err)
 
-	for i := 0; i < rowCount/2; i++ {
-		_, err := r.Read()
+	query := func(sql string) (lines []string) {
+		r, err := engine.QueryStmt(sql, nil, true)
 		require.NoError(t, err)
-	}
+		defer r.Close()
 
-	_, err = r.Read()
-	require.Equal(t, ErrNoMoreRows, err)
+		cols, err := r.Columns()
+		require.NoError(t, err)
 
-	err = r.Close()
-	require.NoError(t, err)
+		for {
+			row, err := r.Read()
+			if err == ErrNoMoreRows {
+				break
+			}
+			require.NoError(t, err)
 
-	r, err = engine.QueryStmt("SELECT id, title, active FROM table1 WHERE table1.id > 4", nil, true)
-	require.NoError(t, err)
+			vals := make([]string, len(cols))
+			for i, c := range cols {
+				vals[i] = fmt.Sprintf("%v", row.Values[c.Selector].Value())
+			}
 
-	for i := 0; i < rowCount/2; i++ {
-		_, err := r.Read()
-		require.NoError(t, err)
+			lines = append(lines, strings.Join(vals, " "))
+		}
+
+		return lines
 	}
 
-	_, err = r.Read()
-	require.Equal(t, ErrNoMoreRows, err)
+	// rows are returned in the order they are read and null values are equal to each other
+	require.Equal(t, []string{"es", "it", "<nil>"}, query("SELECT DISTINCT country FROM orders"))
+	require.Equal(t, []string{"es c1", "it c2", "es c3", "<nil> <nil>"}, query("SELECT DISTINCT country, customer FROM orders"))
+	require.Equal(t, []string{"<nil>", "it", "es"}, query("SELECT DISTINCT country FROM orders ORDER BY id DESC"))
 
-	err = r.Close()
+	// the limit applies to distinct rows
+	require.Equal(t, []string{"es", "it"}, query("SELECT DISTINCT country FROM orders LIMIT 2"))
+
+	require.Equal(t, []string{
+		"DISTINCT LIMIT 2",
+		"-> PROJECT country",
+		"   -> SCAN orders USING PRIMARY KEY (id) ASC",
+	}, query("EXPLAIN SELECT DISTINCT country FROM orders LIMIT 2"))
+
+	// aggregated values are compared as any other value
+	require.Equal(t, []string{"3", "2"}, query("SELECT DISTINCT COUNT() FROM orders GROUP BY country"))
+
+	// null values are not counted
+	require.Equal(t, []string{"3"}, query("SELECT COUNT(DISTINCT customer) FROM orders"))
+	require.Equal(t, []string{"4"}, query("SELECT COUNT(DISTINCT amount) FROM orders WHERE id < 7"))
+	require.Equal(t, []string{"es 2", "it 1", "<nil> 0"}, query("SELECT country, COUNT(DISTINCT customer) FROM orders GROUP BY country"))
+
+	_, err = engine.QueryStmt("SELECT SUM(DISTINCT amount) FROM orders", nil, true)
+	require.Error(t, err)
+}
+
+func TestInferParameters(t *testing.T) {
+	catalogStore, err := store.Open("catalog_infer_params", store.DefaultOptions())
 	require.NoError(t, err)
+	defer os.RemoveAll("catalog_infer_params")
 
-	_, _, err = engine.ExecStmt(fmt.Sprintf("UPSERT INTO table1 (id, title) VALUES (%d, 'title%d')", rowCount, rowCount), nil, true)
+	dataStore, err := store.Open("sqldata_infer_params", store.DefaultOptions())
 	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_infer_params")
 
-	r, err = engine.QueryStmt("SELECT id, title FROM table1 WHERE active = null AND payload = null", nil, true)
+	engine, err := NewEngine(catalogStore, dataStore, prefix)
 	require.NoError(t, err)
 
-	_, err = r.Read()
+	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
 	require.NoError(t, err)
 
-	err = r.Close()
+	err = engine.UseDatabase("db1")
 	require.NoError(t, err)
 
-	r, err = engine.QueryStmt("SELECT id, title FROM table1 WHERE active = null AND payload = null AND active = payload", nil, true)
+	_, _, err = engine.ExecStmt(`
+		BEGIN TRANSACTION
+			CREATE TABLE customers (id INTEGER, name VARCHAR, active BOOLEAN, PRIMARY KEY id);
+			CREATE TABLE orders (id INTEGER, customer_id INTEGER, amount FLOAT, ts TIMESTAMP, PRIMARY KEY id);
+		COMMIT
+	`, nil, true)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		sql   string
+		types map[string]SQLValueType
+	}{
+		{
+			sql:   "SELECT id FROM customers WHERE id = $1 AND $2 = name",
+			types: map[string]SQLValueType{"param1": IntegerType, "param2": VarcharType},
+		},
+		{
+			sql:   "SELECT o.id FROM (orders AS o) INNER JOIN customers ON customers.id = o.customer_id WHERE o.amount > @min_amount AND customers.active = $1 AND o.ts < $2",
+			types: map[string]SQLValueType{"min_amount": FloatType, "param1": BooleanType, "param2": TimestampType},
+		},
+		{
+			sql:   "INSERT INTO orders (id, customer_id, amount, ts) VALUES ($1, $2, $3, $4), ($5, 1, 1.5, NOW())",
+			types: map[string]SQLValueType{"param1": IntegerType, "param2": IntegerType, "param3": FloatType, "param4": TimestampType, "param5": IntegerType},
+		},
+		{
+			sql:   "UPDATE customers SET name = $1 WHERE active AND id IN ($2, 3) AND name LIKE $3",
+			types: map[string]SQLValueType{"param1": VarcharType, "param2": IntegerType, "param3": VarcharType},
+		},
+		{
+			sql:   "DELETE FROM orders WHERE customer_id IN (SELECT id FROM customers WHERE name = $1) AND amount + $2 > 10",
+			types: map[string]SQLValueType{"param1": VarcharType, "param2": FloatType},
+		},
+		{
+			sql:   "SELECT id, CASE WHEN active THEN $1 ELSE 'inactive' END FROM customers WHERE $2 AND UPPER(name) = $3 AND $4 IS NULL",
+			types: map[string]SQLValueType{"param1": VarcharType, "param2": BooleanType, "param3": VarcharType, "param4": VarcharType},
+		},
+	}
+
+	for _, tc := range testCases {
+		types, err := engine.InferParameters(tc.sql)
+		require.NoError(t, err, tc.sql)
+		require.Equal(t, tc.types, types, tc.sql)
+	}
+
+	_, err = engine.InferParameters("SELECT id FROM customers WHERE id = $1 AND name = $1")
+	require.True(t, errors.Is(err, ErrInferredMultipleTypes))
+
+	_, err = engine.InferParameters("SELECT id FROM customers WHERE id = $0")
+	require.Error(t, err)
+
+	// positional parameters are given as named ones
+	_, _, err = engine.ExecStmt("INSERT INTO customers (id, name, active) VALUES ($1, $2, $3)", map[string]interface{}{
+		PositionalParam(1): 1,
+		PositionalParam(2): "c1",
+		PositionalParam(3): true,
+	}, true)
 	require.NoError(t, err)
 
-	_, err = r.Read()
-	require.Equal(t, ErrNotComparableValues, err)
+	r, err := engine.QueryStmt("SELECT name FROM customers WHERE id = $1", map[string]interface{}{"param1": 1}, true)
+	require.NoError(t, err)
 
-	err = r.Close()
+	row, err := r.Read()
 	require.NoError(t, err)
+	require.Equal(t, "c1", row.Values[EncodeSelector("", "db1", "customers", "name")].Value())
 
-	err = engine.Close()
+	err = r.Close()
 	require.NoError(t, err)
 }
 
-func TestAggregations(t *testing.T) {
-	catalogStore, err := store.Open("catalog_agg", store.DefaultOptions())
+func TestTemporalQueries(t *testing.T) {
+	catalogStore, err := store.Open("catalog_temporal", store.DefaultOptions())
 	require.NoError(t, err)
-	defer os.RemoveAll("catalog_agg")
+	defer os.RemoveAll("catalog_temporal")
 
-	dataStore, err := store.Open("sqldata_agg", store.DefaultOptions())
+	dataStore, err := store.Open("sqldata_temporal", store.DefaultOptions())
 	require.NoError(t, err)
-	defer os.RemoveAll("sqldata_agg")
+	defer os.RemoveAll("sqldata_temporal")
 
 	engine, err := NewEngine(catalogStore, dataStore, prefix)
 	require.NoError(t, err)
@@ -1039,203 +5338,287 @@ func TestAggregations(t *testing.T) {
 	err = engine.UseDatabase("db1")
 	require.NoError(t, err)
 
-	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id INTEGER, title VARCHAR, age INTEGER, active BOOLEAN, payload BLOB, PRIMARY KEY id)", nil, true)
+	_, _, err = engine.ExecStmt("CREATE TABLE orders (id INTEGER, amount INTEGER, PRIMARY KEY id)", nil, true)
 	require.NoError(t, err)
 
-	_, _, err = engine.ExecStmt("CREATE INDEX ON table1(age)", nil, true)
+	_, _, err = engine.ExecStmt("CREATE INDEX ON orders(amount)", nil, true)
 	require.NoError(t, err)
 
-	rowCount := 10
-	base := 30
+	_, dmTxs, err := engine.ExecStmt("INSERT INTO orders (id, amount) VALUES (1, 10), (2, 20), (3, 30)", nil, true)
+	require.NoError(t, err)
+	insertTx := dmTxs[0].ID
 
-	for i := 1; i <= rowCount; i++ {
-		params := make(map[string]interface{}, 3)
-		params["id"] = i
-		params["title"] = fmt.Sprintf("title%d", i)
-		params["age"] = base + i
+	_, dmTxs, err = engine.ExecStmt("UPDATE orders SET amount = 200 WHERE id = 2", nil, true)
+	require.NoError(t, err)
+	updateTx := dmTxs[0].ID
 
-		_, _, err = engine.ExecStmt("UPSERT INTO table1 (id, title, age) VALUES (@id, @title, @age)", params, true)
+	_, dmTxs, err = engine.ExecStmt("DELETE FROM orders WHERE id = 3", nil, true)
+	require.NoError(t, err)
+	deleteTx := dmTxs[0].ID
+
+	_, _, err = engine.ExecStmt("INSERT INTO orders (id, amount) VALUES (4, 40)", nil, true)
+	require.NoError(t, err)
+
+	query := func(sql string) [][]uint64 {
+		r, err := engine.QueryStmt(sql, nil, true)
 		require.NoError(t, err)
+		defer r.Close()
+
+		var rows [][]uint64
+
+		for {
+			row, err := r.Read()
+			if err == ErrNoMoreRows {
+				break
+			}
+			require.NoError(t, err)
+
+			rows = append(rows, []uint64{
+				row.Values[EncodeSelector("", "db1", r.ImplicitTable(), "id")].Value().(uint64),
+				row.Values[EncodeSelector("", "db1", r.ImplicitTable(), "amount")].Value().(uint64),
+			})
+		}
+
+		return rows
 	}
 
-	r, err := engine.QueryStmt("SELECT COUNT() FROM table1 WHERE id < i", nil, true)
-	require.NoError(t, err)
+	require.Equal(t, [][]uint64{{1, 10}, {2, 200}, {4, 40}}, query("SELECT id, amount FROM orders"))
 
-	_, err = r.Read()
-	require.Equal(t, ErrColumnDoesNotExist, err)
+	// rows as they were before the given tx, also when read through an index
+	require.Equal(t, [][]uint64{{1, 10}, {2, 20}, {3, 30}}, query(fmt.Sprintf("SELECT id, amount FROM orders BEFORE TX %d", updateTx)))
+	require.Equal(t, [][]uint64{{3, 30}, {2, 20}, {1, 10}}, query(fmt.Sprintf("SELECT id, amount FROM orders BEFORE TX %d ORDER BY amount DESC", updateTx)))
+	require.Equal(t, [][]uint64{{2, 200}, {3, 30}}, query(fmt.Sprintf("SELECT id, amount FROM orders BEFORE TX %d WHERE amount >= 30 ORDER BY amount DESC", deleteTx)))
+	require.Empty(t, query(fmt.Sprintf("SELECT id, amount FROM orders BEFORE TX %d", insertTx)))
 
-	err = r.Close()
-	require.NoError(t, err)
+	// rows written since the given tx, deleted rows are not included
+	require.Equal(t, [][]uint64{{2, 200}, {4, 40}}, query(fmt.Sprintf("SELECT id, amount FROM orders SINCE TX %d", updateTx)))
+	require.Equal(t, [][]uint64{{4, 40}, {2, 200}}, query(fmt.Sprintf("SELECT id, amount FROM orders SINCE TX %d ORDER BY amount", updateTx)))
+	require.Equal(t, [][]uint64{{2, 200}}, query(fmt.Sprintf("SELECT id, amount FROM orders SINCE TX %d WHERE amount > 100 ORDER BY amount", updateTx)))
 
-	r, err = engine.QueryStmt("SELECT id FROM table1 WHERE false", nil, true)
+	// rows written within a range of txs
+	require.Equal(t, [][]uint64{{1, 10}, {2, 200}, {3, 30}}, query(fmt.Sprintf("SELECT id, amount FROM (orders SINCE TX %d BEFORE TX %d AS o)", insertTx, deleteTx)))
+	require.Equal(t, [][]uint64{{2, 200}}, query(fmt.Sprintf("SELECT id, amount FROM (orders SINCE TX %d BEFORE TX %d AS o)", updateTx, deleteTx)))
+
+	_, err = engine.QueryStmt(fmt.Sprintf("SELECT id FROM orders SINCE TX %d BEFORE TX %d", deleteTx, updateTx), nil, true)
+	require.True(t, errors.Is(err, ErrIllegalArguments))
+}
+
+func TestCompositeKeys(t *testing.T) {
+	catalogStore, err := store.Open("catalog_composite_keys", store.DefaultOptions())
 	require.NoError(t, err)
+	defer os.RemoveAll("catalog_composite_keys")
 
-	row, err := r.Read()
-	require.Equal(t, ErrNoMoreRows, err)
+	dataStore, err := store.Open("sqldata_composite_keys", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_composite_keys")
 
-	err = r.Close()
+	engine, err := NewEngine(catalogStore, dataStore, prefix)
 	require.NoError(t, err)
 
-	r, err = engine.QueryStmt("SELECT COUNT(), SUM(age), MIN(title), MAX(age), AVG(age), MIN(active), MAX(active), MIN(payload) FROM table1 WHERE false", nil, true)
+	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
 	require.NoError(t, err)
 
-	row, err = r.Read()
+	err = engine.UseDatabase("db1")
 	require.NoError(t, err)
-	require.Equal(t, uint64(0), row.Values[EncodeSelector("", "db1", "table1", "col0")].Value())
-	require.Equal(t, uint64(0), row.Values[EncodeSelector("", "db1", "table1", "col1")].Value())
-	require.Equal(t, "", row.Values[EncodeSelector("", "db1", "table1", "col2")].Value())
-	require.Equal(t, uint64(0), row.Values[EncodeSelector("", "db1", "table1", "col3")].Value())
-	require.Equal(t, uint64(0), row.Values[EncodeSelector("", "db1", "table1", "col4")].Value())
 
-	err = r.Close()
+	_, _, err = engine.ExecStmt("CREATE TABLE entries (account VARCHAR, id INTEGER AUTO_INCREMENT, PRIMARY KEY (account, id))", nil, true)
+	require.Equal(t, ErrLimitedAutoIncrement, err)
+
+	_, _, err = engine.ExecStmt("CREATE TABLE entries (account VARCHAR, id INTEGER, PRIMARY KEY (account, id, account))", nil, true)
+	require.Equal(t, ErrDuplicatedColumn, err)
+
+	_, _, err = engine.ExecStmt("CREATE TABLE entries (account VARCHAR, id INTEGER, PRIMARY KEY (account, ts))", nil, true)
+	require.Equal(t, ErrInvalidPK, err)
+
+	_, _, err = engine.ExecStmt(`
+		BEGIN TRANSACTION
+			CREATE TABLE accounts (name VARCHAR, PRIMARY KEY name);
+			CREATE TABLE entries (account VARCHAR, id INTEGER, amount INTEGER, ts INTEGER, PRIMARY KEY (account, id));
+		COMMIT
+	`, nil, true)
 	require.NoError(t, err)
 
-	r, err = engine.QueryStmt("SELECT COUNT() AS c, SUM(age), MIN(age), MAX(age), AVG(age) FROM table1 AS t1", nil, true)
+	_, _, err = engine.ExecStmt("CREATE INDEX ON entries(amount DESC, ts)", nil, true)
+	require.True(t, errors.Is(err, ErrLimitedIndexOrder))
+
+	_, _, err = engine.ExecStmt("CREATE INDEX ON entries(account, amount)", nil, true)
+	require.True(t, errors.Is(err, ErrIndexAlreadyExists))
+
+	_, _, err = engine.ExecStmt("CREATE INDEX ON entries(amount, amount)", nil, true)
+	require.True(t, errors.Is(err, ErrDuplicatedColumn))
+
+	_, _, err = engine.ExecStmt("CREATE INDEX ON entries(amount, ts DESC)", nil, true)
 	require.NoError(t, err)
 
-	cols, err := r.Columns()
+	_, _, err = engine.ExecStmt("INSERT INTO entries (account, amount, ts) VALUES ('a', 10, 1)", nil, true)
+	require.Equal(t, ErrPKCanNotBeNull, err)
+
+	_, _, err = engine.ExecStmt("INSERT INTO accounts (name) VALUES ('a'), ('b')", nil, true)
 	require.NoError(t, err)
-	require.Len(t, cols, 5)
 
-	row, err = r.Read()
+	_, _, err = engine.ExecStmt(`
+		INSERT INTO entries (account, id, amount, ts) VALUES
+			('a', 1, 10, 1), ('a', 2, 20, 2), ('b', 1, 10, 3), ('b', 2, 5, 4)
+	`, nil, true)
 	require.NoError(t, err)
-	require.NotNil(t, row)
-	require.Len(t, row.Values, 5)
 
-	require.Equal(t, uint64(rowCount), row.Values[EncodeSelector("", "db1", "t1", "c")].Value())
+	_, _, err = engine.ExecStmt("INSERT INTO entries (account, id, amount, ts) VALUES ('a', 2, 30, 5)", nil, true)
+	require.True(t, errors.Is(err, store.ErrKeyAlreadyExists))
 
-	require.Equal(t, uint64((1+2*base+rowCount)*rowCount/2), row.Values[EncodeSelector("", "db1", "t1", "col1")].Value())
+	_, _, err = engine.ExecStmt("UPDATE entries SET id = 3 WHERE account = 'a'", nil, true)
+	require.Equal(t, ErrPKCanNotBeUpdated, err)
 
-	require.Equal(t, uint64(1+base), row.Values[EncodeSelector("", "db1", "t1", "col2")].Value())
+	query := func(sql string) []string {
+		r, err := engine.QueryStmt(sql, nil, true)
+		require.NoError(t, err)
+		defer r.Close()
 
-	require.Equal(t, uint64(base+rowCount), row.Values[EncodeSelector("", "db1", "t1", "col3")].Value())
+		var rows []string
 
-	require.Equal(t, uint64(base+rowCount/2), row.Values[EncodeSelector("", "db1", "t1", "col4")].Value())
+		for {
+			row, err := r.Read()
+			if err == ErrNoMoreRows {
+				break
+			}
+			require.NoError(t, err)
 
-	_, err = r.Read()
-	require.Equal(t, ErrNoMoreRows, err)
+			rows = append(rows, fmt.Sprintf("%s%d",
+				row.Values[EncodeSelector("", "db1", r.ImplicitTable(), "account")].Value(),
+				row.Values[EncodeSelector("", "db1", r.ImplicitTable(), "id")].Value(),
+			))
+		}
 
-	err = r.Close()
-	require.NoError(t, err)
+		return rows
+	}
 
-	err = engine.Close()
-	require.NoError(t, err)
-}
+	// rows are sorted by every column of the primary key
+	require.Equal(t, []string{"a1", "a2", "b1", "b2"}, query("SELECT account, id FROM entries"))
+	require.Equal(t, []string{"b2", "b1", "a2", "a1"}, query("SELECT account, id FROM entries ORDER BY account DESC"))
+	require.Equal(t, []string{"b1", "b2"}, query("SELECT account, id FROM entries WHERE account = 'b'"))
+	require.Equal(t, []string{"a2", "a1"}, query("SELECT account, id FROM entries WHERE account <= 'a' ORDER BY account DESC"))
+	require.Equal(t, []string{"a2"}, query("SELECT account, id FROM entries WHERE account = 'a' AND id = 2"))
+
+	// rows are sorted by amount and then by the most recent ts
+	require.Equal(t, []string{"b2", "b1", "a1", "a2"}, query("SELECT account, id FROM entries ORDER BY amount"))
+	require.Equal(t, []string{"a2", "a1", "b1", "b2"}, query("SELECT account, id FROM entries ORDER BY amount DESC"))
+	require.Equal(t, []string{"a1", "b1", "b2"}, query("SELECT account, id FROM entries WHERE amount <= 10 ORDER BY amount DESC"))
 
-func TestGroupByHaving(t *testing.T) {
-	catalogStore, err := store.Open("catalog_having", store.DefaultOptions())
+	_, _, err = engine.ExecStmt("UPSERT INTO entries (account, id, amount, ts) VALUES ('a', 1, 30, 5)", nil, true)
 	require.NoError(t, err)
-	defer os.RemoveAll("catalog_having")
 
-	dataStore, err := store.Open("sqldata_having", store.DefaultOptions())
+	_, _, err = engine.ExecStmt("UPDATE entries SET ts = 0 WHERE account = 'b' AND id = 1", nil, true)
 	require.NoError(t, err)
-	defer os.RemoveAll("sqldata_having")
 
-	engine, err := NewEngine(catalogStore, dataStore, prefix)
-	require.NoError(t, err)
+	require.Equal(t, []string{"b2", "b1", "a2", "a1"}, query("SELECT account, id FROM entries ORDER BY amount"))
 
-	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
+	_, _, err = engine.ExecStmt("DELETE FROM entries WHERE account = 'a' AND id = 2", nil, true)
 	require.NoError(t, err)
 
-	err = engine.UseDatabase("db1")
-	require.NoError(t, err)
+	require.Equal(t, []string{"a1", "b1", "b2"}, query("SELECT account, id FROM entries"))
+	require.Equal(t, []string{"b2", "b1", "a1"}, query("SELECT account, id FROM entries ORDER BY amount"))
 
-	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id INTEGER, title VARCHAR, age INTEGER, active BOOLEAN, PRIMARY KEY id)", nil, true)
-	require.NoError(t, err)
+	// rows of tables with composite primary keys are looked up by every column of the key
+	require.Equal(t, []string{"a1", "b1", "b2"}, query(`
+		SELECT e.account, e.id
+		FROM (entries AS e)
+		INNER JOIN (entries AS p) ON p.account = e.account AND p.id = e.id
+	`))
 
-	_, _, err = engine.ExecStmt("CREATE INDEX ON table1(active)", nil, true)
+	// the entries are read first as the rows of the accounts can be looked up by their primary key
+	r, err := engine.QueryStmt("SELECT name, entries.id FROM accounts INNER JOIN entries ON entries.account = accounts.name", nil, true)
 	require.NoError(t, err)
 
-	rowCount := 10
-	base := 40
+	cols, err := r.Columns()
+	require.NoError(t, err)
 
-	for i := 0; i < rowCount; i++ {
-		params := make(map[string]interface{}, 4)
-		params["id"] = i
-		params["title"] = fmt.Sprintf("title%d", i)
-		params["age"] = base + i
-		params["active"] = i%2 == 0
+	var joined []string
 
-		_, _, err = engine.ExecStmt("UPSERT INTO table1 (id, title, age, active) VALUES (@id, @title, @age, @active)", params, true)
+	for {
+		row, err := r.Read()
+		if err == ErrNoMoreRows {
+			break
+		}
 		require.NoError(t, err)
+
+		joined = append(joined, fmt.Sprintf("%s%d", row.Values[cols[0].Selector].Value(), row.Values[cols[1].Selector].Value()))
 	}
 
-	_, err = engine.QueryStmt("SELECT active, COUNT(), SUM(age1) FROM table1 WHERE active != null HAVING AVG(age) >= MIN(age)", nil, true)
-	require.Equal(t, ErrHavingClauseRequiresGroupClause, err)
+	require.Equal(t, []string{"a1", "b1", "b2"}, joined)
 
-	r, err := engine.QueryStmt("SELECT active, COUNT(), SUM(age1) FROM table1 WHERE active != null GROUP BY active HAVING AVG(age) >= MIN(age)", nil, true)
+	err = r.Close()
+	require.NoError(t, err)
+
+	r, err = engine.QueryStmt("SELECT name FROM accounts LEFT JOIN entries ON entries.account = accounts.name", nil, true)
 	require.NoError(t, err)
 
 	_, err = r.Read()
-	require.Equal(t, ErrColumnDoesNotExist, err)
+	require.True(t, errors.Is(err, ErrJointColumnNotFound))
 
 	err = r.Close()
 	require.NoError(t, err)
 
-	r, err = engine.QueryStmt("SELECT active, COUNT(), SUM(age1) FROM table1 WHERE AVG(age) >= MIN(age) GROUP BY active", nil, true)
+	r, err = engine.QueryStmt("EXPLAIN SELECT account, id FROM entries ORDER BY amount", nil, true)
 	require.NoError(t, err)
 
-	err = r.Close()
-	require.NoError(t, err)
+	var plan []string
 
-	r, err = engine.QueryStmt("SELECT active, COUNT(id) FROM table1 GROUP BY active", nil, true)
-	require.NoError(t, err)
+	for {
+		row, err := r.Read()
+		if err == ErrNoMoreRows {
+			break
+		}
+		require.NoError(t, err)
 
-	_, err = r.Read()
-	require.Equal(t, ErrLimitedCount, err)
+		plan = append(plan, row.Values[EncodeSelector("", "db1", "explain", PlanColumn)].Value().(string))
+	}
+
+	require.Equal(t, []string{"PROJECT account, id", "-> SCAN entries USING INDEX ON (amount, ts DESC) ASC"}, plan)
 
 	err = r.Close()
 	require.NoError(t, err)
 
-	r, err = engine.QueryStmt("SELECT active, COUNT() FROM table1 GROUP BY active HAVING AVG(age) >= MIN(age1)", nil, true)
+	db, err := engine.catalog.GetDatabaseByName("db1")
 	require.NoError(t, err)
 
-	_, err = r.Read()
-	require.Equal(t, ErrColumnDoesNotExist, err)
+	ddl := db.DDL()
+	require.Contains(t, ddl, "PRIMARY KEY (account, id));\n")
+	require.Contains(t, ddl, "CREATE INDEX ON entries(amount, ts DESC);\n")
 
-	err = r.Close()
+	// composite keys and indexes are kept when the catalog is loaded again
+	engine, err = NewEngine(catalogStore, dataStore, prefix)
 	require.NoError(t, err)
 
-	r, err = engine.QueryStmt("SELECT active, COUNT() as c, MIN(age), MAX(age), AVG(age), SUM(age) FROM table1 GROUP BY active HAVING COUNT() <= SUM(age) AND MIN(age) <= MAX(age) AND AVG(age) <= MAX(age) AND MAX(age) < SUM(age) AND AVG(age) >= MIN(age) AND SUM(age) > 0 ORDER BY active DESC", nil, true)
+	err = engine.UseDatabase("db1")
 	require.NoError(t, err)
 
-	_, err = r.Columns()
+	db, err = engine.catalog.GetDatabaseByName("db1")
 	require.NoError(t, err)
+	require.Equal(t, ddl, db.DDL())
 
-	for i := 0; i < 2; i++ {
-		row, err := r.Read()
-		require.NoError(t, err)
-		require.NotNil(t, row)
-		require.Len(t, row.Values, 6)
-
-		require.Equal(t, i == 0, row.Values[EncodeSelector("", "db1", "table1", "active")].Value())
-
-		require.Equal(t, uint64(rowCount/2), row.Values[EncodeSelector("", "db1", "table1", "c")].Value())
+	require.Equal(t, []string{"b2", "b1", "a1"}, query("SELECT account, id FROM entries ORDER BY amount"))
 
-		if i%2 == 0 {
-			require.Equal(t, uint64(base), row.Values[EncodeSelector("", "db1", "table1", "col2")].Value())
-			require.Equal(t, uint64(base+rowCount-2), row.Values[EncodeSelector("", "db1", "table1", "col3")].Value())
-		} else {
-			require.Equal(t, uint64(base+1), row.Values[EncodeSelector("", "db1", "table1", "col2")].Value())
-			require.Equal(t, uint64(base+rowCount-1), row.Values[EncodeSelector("", "db1", "table1", "col3")].Value())
-		}
-	}
+	_, _, err = engine.ExecStmt("DROP INDEX ON entries(amount)", nil, true)
+	require.True(t, errors.Is(err, ErrColumnNotIndexed))
 
-	err = r.Close()
+	_, _, err = engine.ExecStmt("DROP INDEX ON entries(amount, ts)", nil, true)
 	require.NoError(t, err)
 
+	// the index is no longer there, but indexes can only be created on empty tables
+	_, _, err = engine.ExecStmt("CREATE INDEX ON entries(amount)", nil, true)
+	require.Equal(t, ErrLimitedIndex, err)
+
 	err = engine.Close()
 	require.NoError(t, err)
 }
 
-func TestJoins(t *testing.T) {
-	catalogStore, err := store.Open("catalog_innerjoin", store.DefaultOptions())
+func TestDefaultValues(t *testing.T) {
+	catalogStore, err := store.Open("catalog_default_values", store.DefaultOptions())
 	require.NoError(t, err)
-	defer os.RemoveAll("catalog_innerjoin")
+	defer os.RemoveAll("catalog_default_values")
 
-	dataStore, err := store.Open("sqldata_innerjoin", store.DefaultOptions())
+	dataStore, err := store.Open("sqldata_default_values", store.DefaultOptions())
 	require.NoError(t, err)
-	defer os.RemoveAll("sqldata_innerjoin")
+	defer os.RemoveAll("sqldata_default_values")
 
 	engine, err := NewEngine(catalogStore, dataStore, prefix)
 	require.NoError(t, err)
@@ -1246,76 +5629,119 @@ func TestJoins(t *testing.T) {
 	err = engine.UseDatabase("db1")
 	require.NoError(t, err)
 
-	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id INTEGER, title VARCHAR, fkid1 INTEGER, fkid2 INTEGER, PRIMARY KEY id)", nil, true)
+	_, _, err = engine.ExecStmt("CREATE TABLE orders (id INTEGER AUTO_INCREMENT DEFAULT 1, PRIMARY KEY id)", nil, true)
+	require.True(t, errors.Is(err, ErrInvalidDefault))
+
+	_, _, err = engine.ExecStmt("CREATE TABLE orders (id INTEGER, status VARCHAR DEFAULT 1, PRIMARY KEY id)", nil, true)
+	require.True(t, errors.Is(err, ErrInvalidDefault))
+
+	_, _, err = engine.ExecStmt("CREATE TABLE orders (id INTEGER, status VARCHAR NOT NULL DEFAULT NULL, PRIMARY KEY id)", nil, true)
+	require.True(t, errors.Is(err, ErrInvalidDefault))
+
+	_, _, err = engine.ExecStmt("CREATE TABLE orders (id INTEGER, status VARCHAR DEFAULT @status, PRIMARY KEY id)", nil, true)
+	require.True(t, errors.Is(err, ErrInvalidDefault))
+
+	_, _, err = engine.ExecStmt("CREATE TABLE orders (id INTEGER, status VARCHAR DEFAULT UUID(), PRIMARY KEY id)", nil, true)
+	require.True(t, errors.Is(err, ErrInvalidDefault))
+
+	_, _, err = engine.ExecStmt(`
+		CREATE TABLE orders (
+			id INTEGER AUTO_INCREMENT,
+			status VARCHAR NOT NULL DEFAULT 'new',
+			amount INTEGER NOT NULL,
+			rate FLOAT DEFAULT -0.5,
+			note VARCHAR DEFAULT NULL,
+			created TIMESTAMP NOT NULL DEFAULT NOW(),
+			PRIMARY KEY id
+		)`, nil, true)
 	require.NoError(t, err)
 
-	_, _, err = engine.ExecStmt("CREATE TABLE table2 (id INTEGER, amount INTEGER, PRIMARY KEY id)", nil, true)
+	_, _, err = engine.ExecStmt("ALTER TABLE orders ADD COLUMN paid BOOLEAN DEFAULT false", nil, true)
+	require.Equal(t, ErrLimitedDefault, err)
+
+	_, _, err = engine.ExecStmt("INSERT INTO orders (status) VALUES ('paid')", nil, true)
+	require.Equal(t, ErrNotNullableColumnCannotBeNull, err)
+
+	_, _, err = engine.ExecStmt("INSERT INTO orders (status, amount) VALUES (NULL, 10)", nil, true)
+	require.Equal(t, ErrNotNullableColumnCannotBeNull, err)
+
+	_, _, err = engine.ExecStmt("INSERT INTO orders (amount) VALUES (10)", nil, true)
 	require.NoError(t, err)
 
-	_, _, err = engine.ExecStmt("CREATE TABLE table3 (id INTEGER, age INTEGER, PRIMARY KEY id)", nil, true)
+	_, _, err = engine.ExecStmt("INSERT INTO orders (amount, status, rate) VALUES (20, 'paid', 1.5)", nil, true)
 	require.NoError(t, err)
 
-	rowCount := 10
+	// replaced rows get the default values of the columns not included as well
+	_, _, err = engine.ExecStmt("UPSERT INTO orders (id, amount) VALUES (2, 30)", nil, true)
+	require.NoError(t, err)
 
-	for i := 0; i < rowCount; i++ {
-		_, _, err = engine.ExecStmt(fmt.Sprintf("UPSERT INTO table1 (id, title, fkid1, fkid2) VALUES (%d, 'title%d', %d, %d)", i, i, rowCount-1-i, i), nil, true)
-		require.NoError(t, err)
+	require.False(t, engine.IsIdempotent(&UpsertIntoStmt{tableRef: &TableRef{table: "orders"}, cols: []string{"id", "amount"}, rows: []*RowSpec{{Values: []ValueExp{&Number{val: 2}, &Number{val: 30}}}}}))
 
-		_, _, err = engine.ExecStmt(fmt.Sprintf("UPSERT INTO table2 (id, amount) VALUES (%d, %d)", rowCount-1-i, i*i), nil, true)
+	query := func() [][]interface{} {
+		r, err := engine.QueryStmt("SELECT id, status, amount, rate, note, created FROM orders", nil, true)
 		require.NoError(t, err)
+		defer r.Close()
+
+		var rows [][]interface{}
+
+		for {
+			row, err := r.Read()
+			if err == ErrNoMoreRows {
+				break
+			}
+			require.NoError(t, err)
+
+			created := row.Values[EncodeSelector("", "db1", "orders", "created")]
+			require.False(t, isNull(created))
+
+			rows = append(rows, []interface{}{
+				row.Values[EncodeSelector("", "db1", "orders", "id")].Value(),
+				row.Values[EncodeSelector("", "db1", "orders", "status")].Value(),
+				row.Values[EncodeSelector("", "db1", "orders", "amount")].Value(),
+				row.Values[EncodeSelector("", "db1", "orders", "rate")].Value(),
+				row.Values[EncodeSelector("", "db1", "orders", "note")].Value(),
+			})
+		}
 
-		_, _, err = engine.ExecStmt(fmt.Sprintf("UPSERT INTO table3 (id, age) VALUES (%d, %d)", i, 30+i), nil, true)
-		require.NoError(t, err)
+		return rows
 	}
 
-	r, err := engine.QueryStmt("SELECT id, title, table2.amount FROM table1 INNER JOIN table2 ON table1.fkid1 = table1.fkid1", nil, true)
+	expected := [][]interface{}{
+		{uint64(1), "new", uint64(10), -0.5, nil},
+		{uint64(2), "new", uint64(30), -0.5, nil},
+	}
+	require.Equal(t, expected, query())
+
+	db, err := engine.catalog.GetDatabaseByName("db1")
 	require.NoError(t, err)
 
-	_, err = r.Read()
-	require.Equal(t, ErrJointColumnNotFound, err)
+	ddl := db.DDL()
+	require.Contains(t, ddl, "status VARCHAR NOT NULL DEFAULT 'new', amount INTEGER NOT NULL, rate FLOAT DEFAULT -0.5, note VARCHAR, created TIMESTAMP NOT NULL DEFAULT NOW()")
 
-	err = r.Close()
+	// default values are kept when the catalog is loaded again, also after renaming the column
+	_, _, err = engine.ExecStmt("ALTER TABLE orders RENAME COLUMN rate TO ratio", nil, true)
 	require.NoError(t, err)
 
-	r, err = engine.QueryStmt("SELECT id, title, table2.amount, table3.age FROM table1 INNER JOIN table2 ON table1.fkid1 = table2.id INNER JOIN table3 ON table1.fkid2 = table3.id WHERE table1.id >= 0 AND table3.age >= 30 ORDER BY id DESC", nil, true)
+	engine, err = NewEngine(catalogStore, dataStore, prefix)
 	require.NoError(t, err)
 
-	cols, err := r.Columns()
+	err = engine.UseDatabase("db1")
 	require.NoError(t, err)
-	require.Len(t, cols, 4)
-
-	for i := 0; i < rowCount; i++ {
-		row, err := r.Read()
-		require.NoError(t, err)
-		require.NotNil(t, row)
-		require.Len(t, row.Values, 4)
-
-		require.Equal(t, uint64(rowCount-1-i), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
-		require.Equal(t, fmt.Sprintf("title%d", rowCount-1-i), row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
-		require.Equal(t, uint64((rowCount-1-i)*(rowCount-1-i)), row.Values[EncodeSelector("", "db1", "table2", "amount")].Value())
-		require.Equal(t, uint64(30+(rowCount-1-i)), row.Values[EncodeSelector("", "db1", "table3", "age")].Value())
-	}
 
-	err = r.Close()
+	db, err = engine.catalog.GetDatabaseByName("db1")
 	require.NoError(t, err)
+	require.Equal(t, strings.Replace(ddl, "rate FLOAT", "ratio FLOAT", 1), db.DDL())
 
-	_, _, err = engine.ExecStmt(fmt.Sprintf("UPSERT INTO table1 (id, title, fkid1, fkid2) VALUES (%d, 'title%d', %d, %d)", rowCount, rowCount, rowCount, rowCount), nil, true)
+	_, _, err = engine.ExecStmt("INSERT INTO orders (amount) VALUES (40)", nil, true)
 	require.NoError(t, err)
 
-	r, err = engine.QueryStmt("SELECT id, title, table2.amount, table3.age FROM table1 INNER JOIN table2 ON table1.fkid1 = table2.id INNER JOIN table3 ON table1.fkid2 = table3.id ORDER BY id DESC", nil, true)
+	r, err := engine.QueryStmt("SELECT status, ratio FROM orders WHERE amount = 40", nil, true)
 	require.NoError(t, err)
 
-	for i := 0; i < rowCount; i++ {
-		row, err := r.Read()
-		require.NoError(t, err)
-		require.NotNil(t, row)
-		require.Len(t, row.Values, 4)
-
-		require.Equal(t, uint64(rowCount-1-i), row.Values[EncodeSelector("", "db1", "table1", "id")].Value())
-		require.Equal(t, fmt.Sprintf("title%d", rowCount-1-i), row.Values[EncodeSelector("", "db1", "table1", "title")].Value())
-		require.Equal(t, uint64((rowCount-1-i)*(rowCount-1-i)), row.Values[EncodeSelector("", "db1", "table2", "amount")].Value())
-		require.Equal(t, uint64(30+(rowCount-1-i)), row.Values[EncodeSelector("", "db1", "table3", "age")].Value())
-	}
+	row, err := r.Read()
+	require.NoError(t, err)
+	require.Equal(t, "new", row.Values[EncodeSelector("", "db1", "orders", "status")].Value())
+	require.Equal(t, -0.5, row.Values[EncodeSelector("", "db1", "orders", "ratio")].Value())
 
 	err = r.Close()
 	require.NoError(t, err)
@@ -1324,14 +5750,14 @@ func TestJoins(t *testing.T) {
 	require.NoError(t, err)
 }
 
-func TestNestedJoins(t *testing.T) {
-	catalogStore, err := store.Open("catalog_nestedjoins", store.DefaultOptions())
+func TestCheckConstraints(t *testing.T) {
+	catalogStore, err := store.Open("catalog_check_constraints", store.DefaultOptions())
 	require.NoError(t, err)
-	defer os.RemoveAll("catalog_nestedjoins")
+	defer os.RemoveAll("catalog_check_constraints")
 
-	dataStore, err := store.Open("sqldata_nestedjoins", store.DefaultOptions())
+	dataStore, err := store.Open("sqldata_check_constraints", store.DefaultOptions())
 	require.NoError(t, err)
-	defer os.RemoveAll("sqldata_nestedjoins")
+	defer os.RemoveAll("sqldata_check_constraints")
 
 	engine, err := NewEngine(catalogStore, dataStore, prefix)
 	require.NoError(t, err)
@@ -1342,119 +5768,114 @@ func TestNestedJoins(t *testing.T) {
 	err = engine.UseDatabase("db1")
 	require.NoError(t, err)
 
-	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id INTEGER, title VARCHAR, fkid1 INTEGER, PRIMARY KEY id)", nil, true)
-	require.NoError(t, err)
+	_, _, err = engine.ExecStmt("CREATE TABLE orders (id INTEGER, amount INTEGER, CHECK (total > 0), PRIMARY KEY id)", nil, true)
+	require.True(t, errors.Is(err, ErrColumnDoesNotExist))
 
-	_, _, err = engine.ExecStmt("CREATE TABLE table2 (id INTEGER, amount INTEGER, fkid1 INTEGER, PRIMARY KEY id)", nil, true)
-	require.NoError(t, err)
+	_, _, err = engine.ExecStmt("CREATE TABLE orders (id INTEGER, amount INTEGER, CHECK (amount > @minAmount), PRIMARY KEY id)", nil, true)
+	require.True(t, errors.Is(err, ErrInvalidCheck))
 
-	_, _, err = engine.ExecStmt("CREATE TABLE table3 (id INTEGER, age INTEGER, PRIMARY KEY id)", nil, true)
-	require.NoError(t, err)
+	_, _, err = engine.ExecStmt("CREATE TABLE orders (id INTEGER, amount INTEGER, CONSTRAINT positive CHECK (amount > 0), CONSTRAINT positive CHECK (id > 0), PRIMARY KEY id)", nil, true)
+	require.True(t, errors.Is(err, ErrCheckAlreadyExists))
 
-	rowCount := 10
+	_, _, err = engine.ExecStmt(`
+		CREATE TABLE orders (
+			id INTEGER AUTO_INCREMENT,
+			amount INTEGER NOT NULL,
+			discount INTEGER,
+			status VARCHAR DEFAULT 'new',
+			CONSTRAINT positive_amount CHECK (amount > 0),
+			CHECK (discount IS NULL OR discount <= amount),
+			CHECK (status = 'new' OR status = 'paid'),
+			PRIMARY KEY id
+		)`, nil, true)
+	require.NoError(t, err)
 
-	for i := 0; i < rowCount; i++ {
-		_, _, err = engine.ExecStmt(fmt.Sprintf("UPSERT INTO table1 (id, title, fkid1) VALUES (%d, 'title%d', %d)", i, i, rowCount-1-i), nil, true)
-		require.NoError(t, err)
+	_, _, err = engine.ExecStmt("INSERT INTO orders (amount) VALUES (0)", nil, true)
+	require.True(t, errors.Is(err, ErrCheckConstraintViolation))
+	require.Contains(t, err.Error(), "positive_amount")
 
-		_, _, err = engine.ExecStmt(fmt.Sprintf("UPSERT INTO table2 (id, amount, fkid1) VALUES (%d, %d, %d)", rowCount-1-i, i*i, i), nil, true)
-		require.NoError(t, err)
+	_, _, err = engine.ExecStmt("INSERT INTO orders (amount, discount) VALUES (10, 20)", nil, true)
+	require.True(t, errors.Is(err, ErrCheckConstraintViolation))
+	require.Contains(t, err.Error(), "orders_check2")
 
-		_, _, err = engine.ExecStmt(fmt.Sprintf("UPSERT INTO table3 (id, age) VALUES (%d, %d)", i, 30+i), nil, true)
-		require.NoError(t, err)
-	}
+	_, _, err = engine.ExecStmt("INSERT INTO orders (amount, status) VALUES (10, 'shipped')", nil, true)
+	require.True(t, errors.Is(err, ErrCheckConstraintViolation))
+	require.Contains(t, err.Error(), "orders_check3")
 
-	r, err := engine.QueryStmt("SELECT id, title, t2.amount AS total_amount, t3.age FROM (table1 AS t1) INNER JOIN (table2 as t2) ON fkid1 = t2.id INNER JOIN (table3 as t3) ON t2.fkid1 = t3.id ORDER BY id DESC", nil, true)
+	// null values satisfy the constraints, as well as default values
+	_, _, err = engine.ExecStmt("INSERT INTO orders (amount) VALUES (10)", nil, true)
 	require.NoError(t, err)
 
-	cols, err := r.Columns()
+	_, _, err = engine.ExecStmt("INSERT INTO orders (amount, discount, status) VALUES (20, 5, 'paid')", nil, true)
 	require.NoError(t, err)
-	require.Len(t, cols, 4)
-
-	for i := 0; i < rowCount; i++ {
-		row, err := r.Read()
-		require.NoError(t, err)
-		require.NotNil(t, row)
-		require.Len(t, row.Values, 4)
 
-		require.Equal(t, uint64(rowCount-1-i), row.Values[EncodeSelector("", "db1", "t1", "id")].Value())
-		require.Equal(t, fmt.Sprintf("title%d", rowCount-1-i), row.Values[EncodeSelector("", "db1", "t1", "title")].Value())
-		require.Equal(t, uint64((rowCount-1-i)*(rowCount-1-i)), row.Values[EncodeSelector("", "db1", "t2", "total_amount")].Value())
-		require.Equal(t, uint64(30+(rowCount-1-i)), row.Values[EncodeSelector("", "db1", "t3", "age")].Value())
-	}
+	_, _, err = engine.ExecStmt("UPSERT INTO orders (id, amount, discount) VALUES (1, 5, 10)", nil, true)
+	require.True(t, errors.Is(err, ErrCheckConstraintViolation))
 
-	err = r.Close()
-	require.NoError(t, err)
+	_, _, err = engine.ExecStmt("UPDATE orders SET discount = amount + 1 WHERE id = 2", nil, true)
+	require.True(t, errors.Is(err, ErrCheckConstraintViolation))
 
-	err = engine.Close()
+	_, _, err = engine.ExecStmt("UPDATE orders SET discount = amount WHERE id = 2", nil, true)
 	require.NoError(t, err)
-}
 
-func TestReOpening(t *testing.T) {
-	catalogStore, err := store.Open("catalog_reopening", store.DefaultOptions())
+	r, err := engine.QueryStmt("SELECT id, amount, discount FROM orders WHERE id = 2", nil, true)
 	require.NoError(t, err)
-	defer os.RemoveAll("catalog_reopening")
 
-	dataStore, err := store.Open("sqldata_reopening", store.DefaultOptions())
+	row, err := r.Read()
 	require.NoError(t, err)
-	defer os.RemoveAll("sqldata_reopening")
+	require.Equal(t, uint64(20), row.Values[EncodeSelector("", "db1", "orders", "amount")].Value())
+	require.Equal(t, uint64(20), row.Values[EncodeSelector("", "db1", "orders", "discount")].Value())
 
-	engine, err := NewEngine(catalogStore, dataStore, prefix)
+	err = r.Close()
 	require.NoError(t, err)
 
-	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
-	require.NoError(t, err)
+	_, _, err = engine.ExecStmt("ALTER TABLE orders RENAME COLUMN discount TO rebate", nil, true)
+	require.True(t, errors.Is(err, ErrColumnInUseByCheck))
 
-	_, _, err = engine.ExecStmt("USE DATABASE db1; CREATE TABLE table1 (id INTEGER, name VARCHAR, PRIMARY KEY id)", nil, true)
+	db, err := engine.catalog.GetDatabaseByName("db1")
 	require.NoError(t, err)
 
-	_, _, err = engine.ExecStmt("USE DATABASE db1; CREATE INDEX ON table1(name)", nil, true)
-	require.NoError(t, err)
+	ddl := db.DDL()
+	require.Contains(t, ddl, "status VARCHAR DEFAULT 'new', CONSTRAINT orders_check2 CHECK (((discount IS NULL) OR (discount <= amount))), ")
 
+	// constraints are kept when the catalog is loaded again and when the table is truncated
 	engine, err = NewEngine(catalogStore, dataStore, prefix)
 	require.NoError(t, err)
 
-	exists := engine.catalog.ExistDatabase("db1")
-	require.True(t, exists)
-
-	db, err := engine.catalog.GetDatabaseByName("db1")
+	err = engine.UseDatabase("db1")
 	require.NoError(t, err)
 
-	exists = db.ExistTable("table1")
-	require.True(t, exists)
-
-	table, err := db.GetTableByName("table1")
+	db, err = engine.catalog.GetDatabaseByName("db1")
 	require.NoError(t, err)
+	require.Equal(t, ddl, db.DDL())
 
-	require.Equal(t, "id", table.pk.colName)
+	_, _, err = engine.ExecStmt("TRUNCATE TABLE orders", nil, true)
+	require.NoError(t, err)
 
-	require.Len(t, table.ColsByID(), 2)
+	_, _, err = engine.ExecStmt("INSERT INTO orders (amount) VALUES (0)", nil, true)
+	require.True(t, errors.Is(err, ErrCheckConstraintViolation))
 
-	col, err := table.GetColumnByName("id")
+	engine, err = NewEngine(catalogStore, dataStore, prefix)
 	require.NoError(t, err)
-	require.Equal(t, IntegerType, col.colType)
 
-	col, err = table.GetColumnByName("name")
+	err = engine.UseDatabase("db1")
 	require.NoError(t, err)
-	require.Equal(t, VarcharType, col.colType)
-
-	require.Len(t, table.indexes, 1)
 
-	_, indexed := table.indexes[col.id]
-	require.True(t, indexed)
+	_, _, err = engine.ExecStmt("INSERT INTO orders (amount) VALUES (0)", nil, true)
+	require.True(t, errors.Is(err, ErrCheckConstraintViolation))
 
 	err = engine.Close()
 	require.NoError(t, err)
 }
 
-func TestSubQuery(t *testing.T) {
-	catalogStore, err := store.Open("catalog_subq", store.DefaultOptions())
+func TestInsertReturning(t *testing.T) {
+	catalogStore, err := store.Open("catalog_insert_returning", store.DefaultOptions())
 	require.NoError(t, err)
-	defer os.RemoveAll("catalog_subq")
+	defer os.RemoveAll("catalog_insert_returning")
 
-	dataStore, err := store.Open("sqldata_subq", store.DefaultOptions())
+	dataStore, err := store.Open("sqldata_insert_returning", store.DefaultOptions())
 	require.NoError(t, err)
-	defer os.RemoveAll("sqldata_subq")
+	defer os.RemoveAll("sqldata_insert_returning")
 
 	engine, err := NewEngine(catalogStore, dataStore, prefix)
 	require.NoError(t, err)
@@ -1465,57 +5886,68 @@ func TestSubQuery(t *testing.T) {
 	err = engine.UseDatabase("db1")
 	require.NoError(t, err)
 
-	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id INTEGER, title VARCHAR, active BOOLEAN, payload BLOB, PRIMARY KEY id)", nil, true)
+	_, _, err = engine.ExecStmt("CREATE TABLE orders (id INTEGER AUTO_INCREMENT, amount INTEGER, status VARCHAR DEFAULT 'new', note VARCHAR, PRIMARY KEY id)", nil, true)
 	require.NoError(t, err)
 
-	rowCount := 10
+	_, err = engine.ExecPreparedStmtsWithSummary(context.Background(), mustParse(t, "INSERT INTO orders (amount) VALUES (10) RETURNING total"), nil, true)
+	require.True(t, errors.Is(err, ErrColumnDoesNotExist))
 
-	for i := 0; i < rowCount; i++ {
-		encPayload := hex.EncodeToString([]byte(fmt.Sprintf("blob%d", i)))
-		_, _, err = engine.ExecStmt(fmt.Sprintf("UPSERT INTO table1 (id, title, active, payload) VALUES (%d, 'title%d', %v, x'%s')", i, i, i%2 == 0, encPayload), nil, true)
+	readAll := func(r RowReader) [][]interface{} {
+		cols, err := r.Columns()
 		require.NoError(t, err)
-	}
-
-	r, err := engine.QueryStmt("SELECT id, title AS t FROM (SELECT id, title, active FROM table1 AS table2) WHERE active AND table2.id >= 0", nil, true)
-	require.NoError(t, err)
 
-	cols, err := r.Columns()
-	require.NoError(t, err)
-	require.Len(t, cols, 2)
+		var rows [][]interface{}
 
-	for i := 0; i < rowCount; i += 2 {
-		row, err := r.Read()
-		require.NoError(t, err)
-		require.NotNil(t, row)
-		require.Len(t, row.Values, 2)
+		for {
+			row, err := r.Read()
+			if err == ErrNoMoreRows {
+				break
+			}
+			require.NoError(t, err)
 
-		require.Equal(t, uint64(i), row.Values[EncodeSelector("", "db1", "table2", "id")].Value())
-		require.Equal(t, fmt.Sprintf("title%d", i), row.Values[EncodeSelector("", "db1", "table2", "t")].Value())
-	}
+			vals := make([]interface{}, len(cols))
+			for i, c := range cols {
+				vals[i] = row.Values[c.Selector].Value()
+			}
 
-	err = r.Close()
-	require.NoError(t, err)
+			rows = append(rows, vals)
+		}
 
-	_, _, err = engine.ExecStmt("UPSERT INTO table1 (id, title) VALUES (0, 'title0')", nil, true)
-	require.NoError(t, err)
+		return rows
+	}
 
-	r, err = engine.QueryStmt("SELECT id, title, active FROM (SELECT id, title, active FROM table1) WHERE active", nil, true)
+	summary, err := engine.ExecPreparedStmtsWithSummary(context.Background(), mustParse(t, `
+		INSERT INTO orders (amount) VALUES (10), (20) RETURNING id, status;
+		INSERT INTO orders (amount, status) VALUES (30, 'paid');
+		UPSERT INTO orders (id, amount, status) VALUES (1, 15, 'paid') RETURNING *;
+	`), nil, true)
 	require.NoError(t, err)
+	require.Len(t, summary.ReturnedRows, 2)
 
-	_, err = r.Read()
+	cols, err := summary.ReturnedRows[0].Columns()
 	require.NoError(t, err)
+	require.Equal(t, []*ColDescriptor{
+		{Selector: EncodeSelector("", "db1", "orders", "id"), Type: IntegerType},
+		{Selector: EncodeSelector("", "db1", "orders", "status"), Type: VarcharType},
+	}, cols)
 
-	err = r.Close()
-	require.NoError(t, err)
+	require.Equal(t, [][]interface{}{{uint64(1), "new"}, {uint64(2), "new"}}, readAll(summary.ReturnedRows[0]))
+	require.Equal(t, [][]interface{}{{uint64(1), uint64(15), "paid", nil}}, readAll(summary.ReturnedRows[1]))
 
-	r, err = engine.QueryStmt("SELECT id, title, active FROM (SELECT id, title, active FROM table1) WHERE title", nil, true)
+	// rows of a batch are returned once all the statements are committed
+	summary, _, err = engine.ExecBatch(context.Background(), []*BatchStmt{
+		{Stmt: mustParse(t, "INSERT INTO orders (amount) VALUES (@amount) RETURNING id")[0], Params: map[string]interface{}{"amount": 40}},
+		{Stmt: mustParse(t, "INSERT INTO orders (amount) VALUES (@amount) RETURNING id, amount")[0], Params: map[string]interface{}{"amount": 50}},
+	}, true)
 	require.NoError(t, err)
+	require.Len(t, summary.ReturnedRows, 2)
+	require.Equal(t, [][]interface{}{{uint64(4)}}, readAll(summary.ReturnedRows[0]))
+	require.Equal(t, [][]interface{}{{uint64(5), uint64(50)}}, readAll(summary.ReturnedRows[1]))
 
-	_, err = r.Read()
-	require.Equal(t, ErrInvalidCondition, err)
-
-	err = r.Close()
-	require.NoError(t, err)
+	// nothing is returned when the rows are not committed
+	summary, err = engine.ExecPreparedStmtsWithSummary(context.Background(), mustParse(t, "INSERT INTO orders (id, amount) VALUES (1, 60) RETURNING id"), nil, true)
+	require.Error(t, err)
+	require.Empty(t, summary.ReturnedRows)
 
 	err = engine.Close()
 	require.NoError(t, err)
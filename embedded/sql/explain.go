@@ -0,0 +1,419 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/codenotary/immudb/embedded/store"
+)
+
+// PlanColumn is the column holding the lines of the plan returned by EXPLAIN
+const PlanColumn = "plan"
+
+// planNode describes a step of the plan of a query, the steps it reads rows from are its children
+type planNode struct {
+	desc     string
+	children []*planNode
+
+	// rows returned by the step and time spent reading them, including the time spent by its children.
+	// Only set when the query is analyzed
+	analyzed bool
+	rows     uint64
+	elapsed  time.Duration
+}
+
+// lines returns the description of the step followed by the ones of its children, indented as they're nested
+func (n *planNode) lines(depth int) []string {
+	line := n.desc
+
+	if depth > 0 {
+		line = strings.Repeat("   ", depth-1) + "-> " + line
+	}
+
+	if n.analyzed {
+		line += fmt.Sprintf(" (rows=%d, time=%s)", n.rows, n.elapsed)
+	}
+
+	lines := []string{line}
+
+	for _, c := range n.children {
+		lines = append(lines, c.lines(depth+1)...)
+	}
+
+	return lines
+}
+
+// formatPlanExp formats the expression as it's shown within a plan, "?" is shown if it can not be formatted
+func formatPlanExp(exp ValueExp, params map[string]interface{}) string {
+	if rexp, err := exp.substitute(params); err == nil {
+		exp = rexp
+	}
+
+	s, err := formatExp(exp, nil, true)
+	if err != nil {
+		return "?"
+	}
+
+	return s
+}
+
+func joinTypeName(joinType JoinType) string {
+	for name, t := range joinTypes {
+		if t == joinType {
+			return name
+		}
+	}
+
+	return "?"
+}
+
+func (r *rawRowReader) explain() *planNode {
+	var b strings.Builder
+
+	b.WriteString("SCAN " + r.table.name)
+
+	if r.tableAlias != r.table.name {
+		b.WriteString(" AS " + r.tableAlias)
+	}
+
+	if r.col == r.table.pk.colName {
+		pkCols := make([]string, len(r.table.pkCols))
+		for i, col := range r.table.pkCols {
+			pkCols[i] = col.colName
+		}
+
+		fmt.Fprintf(&b, " USING PRIMARY KEY (%s)", strings.Join(pkCols, ", "))
+	} else {
+		fmt.Fprintf(&b, " USING INDEX ON (%s)", r.table.indexes[r.table.colsByName[r.col].id])
+	}
+
+	if r.desc {
+		b.WriteString(" DESC")
+	} else {
+		b.WriteString(" ASC")
+	}
+
+	if r.bounded {
+		b.WriteString(", SEEKING THE BOUND SET BY THE CONDITION")
+	}
+
+	if r.endKey != nil {
+		b.WriteString(", STOPPING AT THE BOUND SET BY THE CONDITION")
+	}
+
+	if r.prefixes > 0 {
+		fmt.Fprintf(&b, ", RESTRICTED TO %d VALUE RANGES", r.prefixes)
+	}
+
+	if r.sinceTx > 0 {
+		fmt.Fprintf(&b, ", SINCE TX %d", r.sinceTx)
+	}
+
+	if r.asBefore > 0 {
+		fmt.Fprintf(&b, ", AS BEFORE TX %d", r.asBefore)
+	}
+
+	return &planNode{desc: b.String()}
+}
+
+func (jointr *jointRowReader) explain() *planNode {
+	n := &planNode{
+		desc:     "JOIN",
+		children: []*planNode{jointr.rowReader.explain()},
+	}
+
+	// joins are applied in the order they're given, each one to the rows resulting from the previous ones
+	for i, jspec := range jointr.joins {
+		var desc string
+
+		switch ds := jspec.ds.(type) {
+		case *TableRef:
+			{
+				desc = fmt.Sprintf("%s JOIN %s", joinTypeName(jspec.joinType), ds.table)
+
+				if ds.as != "" {
+					desc += " AS " + ds.as
+				}
+
+				table, err := ds.referencedTable(jointr.e, jointr.implicitDB)
+				if err != nil {
+					break
+				}
+
+				fkSels, err := jointPKColumns(jspec.cond, table, ds.Alias())
+				if err != nil {
+					desc += " ON " + formatPlanExp(jspec.cond, jointr.params)
+					break
+				}
+
+				pkCols := make([]string, len(fkSels))
+				fkCols := make([]string, len(fkSels))
+
+				for i, fkSel := range fkSels {
+					pkCols[i] = table.pkCols[i].colName
+					fkCols[i] = formatPlanExp(fkSel, nil)
+				}
+
+				desc += fmt.Sprintf(" LOOKING UP PRIMARY KEY (%s) BY %s", strings.Join(pkCols, ", "), strings.Join(fkCols, ", "))
+			}
+		default:
+			{
+				name := "SUBQUERY"
+				if v, ok := ds.(*viewRef); ok {
+					name = "VIEW " + v.view.name
+				}
+
+				desc = fmt.Sprintf("%s JOIN %s AS %s", joinTypeName(jspec.joinType), name, ds.Alias())
+				desc += fmt.Sprintf(" ON %s, MATERIALIZED INTO %d ROWS", formatPlanExp(jointr.conds[i], nil), len(jointr.derived[i]))
+			}
+		}
+
+		n.children = append(n.children, &planNode{desc: desc})
+	}
+
+	return n
+}
+
+func (cr *conditionalRowReader) explain() *planNode {
+	desc := "FILTER " + formatPlanExp(cr.condition, cr.params)
+
+	// rows of tables with policies are filtered as soon as they're read
+	if _, isPolicyFilter := cr.condition.(*anyBoolExp); isPolicyFilter {
+		desc = "FILTER BY POLICIES " + formatPlanExp(cr.condition, nil)
+	}
+
+	return &planNode{
+		desc:     desc,
+		children: []*planNode{cr.rowReader.explain()},
+	}
+}
+
+func (gr *groupedRowReader) explain() *planNode {
+	desc := "AGGREGATE"
+
+	if len(gr.groupBy) > 0 {
+		exps := make([]string, len(gr.groupBy))
+
+		for i, exp := range gr.groupBy {
+			exps[i] = formatPlanExp(exp, gr.params)
+		}
+
+		desc = "GROUP BY " + strings.Join(exps, ", ")
+	}
+
+	if len(gr.aggregations) > 0 {
+		aggs := make([]string, len(gr.aggregations))
+
+		for i, agg := range gr.aggregations {
+			aggs[i] = formatPlanExp(agg, nil)
+		}
+
+		desc += " COMPUTING " + strings.Join(aggs, ", ")
+	}
+
+	return &planNode{
+		desc:     desc,
+		children: []*planNode{gr.rowReader.explain()},
+	}
+}
+
+func (sr *sortedRowReader) explain() *planNode {
+	exps := make([]string, len(sr.ordCols))
+
+	for i, ordCol := range sr.ordCols {
+		exps[i] = formatPlanExp(ordCol.exp, sr.params)
+
+		if ordCol.cmp == LowerOrEqualTo {
+			exps[i] += " DESC"
+		}
+	}
+
+	return &planNode{
+		desc:     "SORT BY " + strings.Join(exps, ", "),
+		children: []*planNode{sr.rowReader.explain()},
+	}
+}
+
+func (pr *projectedRowReader) explain() *planNode {
+	desc := "PROJECT *"
+
+	if len(pr.selectors) > 0 {
+		sels := make([]string, len(pr.selectors))
+
+		for i, sel := range pr.selectors {
+			sels[i] = formatPlanExp(sel, nil)
+
+			if sel.alias() != "" {
+				sels[i] += " AS " + sel.alias()
+			}
+		}
+
+		desc = "PROJECT " + strings.Join(sels, ", ")
+	}
+
+	if pr.limit > 0 {
+		desc += fmt.Sprintf(" LIMIT %d", pr.limit)
+	}
+
+	return &planNode{
+		desc:     desc,
+		children: []*planNode{pr.rowReader.explain()},
+	}
+}
+
+func (dr *distinctRowReader) explain() *planNode {
+	desc := "DISTINCT"
+
+	if dr.limit > 0 {
+		desc += fmt.Sprintf(" LIMIT %d", dr.limit)
+	}
+
+	return &planNode{
+		desc:     desc,
+		children: []*planNode{dr.rowReader.explain()},
+	}
+}
+
+// analyzedRowReader keeps track of the rows returned by the underlying reader and the time spent reading them
+type analyzedRowReader struct {
+	RowReader
+
+	rows    uint64
+	elapsed time.Duration
+}
+
+func (ar *analyzedRowReader) Read() (*Row, error) {
+	start := time.Now()
+
+	row, err := ar.RowReader.Read()
+
+	ar.elapsed += time.Since(start)
+
+	if err == nil {
+		ar.rows++
+	}
+
+	return row, err
+}
+
+func (ar *analyzedRowReader) explain() *planNode {
+	n := ar.RowReader.explain()
+
+	n.analyzed = true
+	n.rows = ar.rows
+	n.elapsed = ar.elapsed
+
+	return n
+}
+
+// analyzed wraps the reader when the query is analyzed, so the rows it returns and the time spent are tracked
+func (stmt *SelectStmt) analyzed(rowReader RowReader) RowReader {
+	if !stmt.analyze {
+		return rowReader
+	}
+
+	return &analyzedRowReader{RowReader: rowReader}
+}
+
+// resolvePlan returns a reader of the lines describing the plan of the query. The rows of the query are read
+// when it's analyzed, so the rows returned by each step and the time spent are included
+func (stmt *SelectStmt) resolvePlan(ctx context.Context, e *Engine, implicitDB *Database, snap *store.Snapshot, params map[string]interface{}) (RowReader, error) {
+	q := *stmt
+	q.explain = false
+
+	rowReader, err := q.Resolve(ctx, e, implicitDB, snap, params, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if q.analyze {
+		for {
+			_, err = rowReader.Read()
+			if err == ErrNoMoreRows {
+				break
+			}
+			if err != nil {
+				rowReader.Close()
+				return nil, err
+			}
+		}
+	}
+
+	lines := rowReader.explain().lines(0)
+
+	err = rowReader.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return newPlanRowReader(rowReader.ImplicitDB(), lines), nil
+}
+
+// planRowReader returns a row for each line of the plan of a query
+type planRowReader struct {
+	implicitDB string
+	col        *ColDescriptor
+
+	lines []string
+}
+
+func newPlanRowReader(implicitDB string, lines []string) *planRowReader {
+	return &planRowReader{
+		implicitDB: implicitDB,
+		col:        &ColDescriptor{Selector: EncodeSelector("", implicitDB, "explain", PlanColumn), Type: VarcharType},
+		lines:      lines,
+	}
+}
+
+func (r *planRowReader) ImplicitDB() string {
+	return r.implicitDB
+}
+
+func (r *planRowReader) ImplicitTable() string {
+	return "explain"
+}
+
+func (r *planRowReader) Columns() ([]*ColDescriptor, error) {
+	return []*ColDescriptor{r.col}, nil
+}
+
+func (r *planRowReader) colsBySelector() (map[string]*ColDescriptor, error) {
+	return map[string]*ColDescriptor{r.col.Selector: r.col}, nil
+}
+
+func (r *planRowReader) Read() (*Row, error) {
+	if len(r.lines) == 0 {
+		return nil, ErrNoMoreRows
+	}
+
+	row := &Row{Values: map[string]TypedValue{r.col.Selector: &Varchar{val: r.lines[0]}}}
+	r.lines = r.lines[1:]
+
+	return row, nil
+}
+
+func (r *planRowReader) Close() error {
+	return nil
+}
+
+func (r *planRowReader) explain() *planNode {
+	return &planNode{desc: "PLAN"}
+}
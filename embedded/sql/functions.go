@@ -0,0 +1,472 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package sql
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// scalarFunction is a built-in function computing a value out of the values of its parameters.
+// Functions are called by their name in the registry, thus new ones don't require changes to the grammar
+type scalarFunction struct {
+	minParams int
+	// maxParams is negative when the number of parameters is not limited
+	maxParams int
+
+	// acceptsNulls is false when the function returns null as soon as a parameter is null,
+	// in which case the function is only applied to non-null values
+	acceptsNulls bool
+
+	// returnType validates the parameters and returns the type of the results. Types of null parameters may be empty
+	returnType func(params []ValueExp, paramTypes []SQLValueType) (SQLValueType, error)
+
+	// apply is called with parameters already validated by returnType
+	apply func(params []TypedValue) (TypedValue, error)
+}
+
+// scalarFunctions is the registry of built-in scalar functions, by their lowercase name
+var scalarFunctions = map[string]*scalarFunction{
+	"upper": {
+		minParams:  1,
+		maxParams:  1,
+		returnType: paramTypesAre(VarcharType, VarcharType),
+		apply: func(params []TypedValue) (TypedValue, error) {
+			return &Varchar{val: strings.ToUpper(params[0].Value().(string))}, nil
+		},
+	},
+	"lower": {
+		minParams:  1,
+		maxParams:  1,
+		returnType: paramTypesAre(VarcharType, VarcharType),
+		apply: func(params []TypedValue) (TypedValue, error) {
+			return &Varchar{val: strings.ToLower(params[0].Value().(string))}, nil
+		},
+	},
+	"length": {
+		minParams: 1,
+		maxParams: 1,
+		returnType: func(params []ValueExp, paramTypes []SQLValueType) (SQLValueType, error) {
+			if paramTypes[0] != "" && paramTypes[0] != VarcharType && paramTypes[0] != BLOBType {
+				return "", fmt.Errorf("%w: LENGTH expects a %s or %s value", ErrInvalidFunctionParams, VarcharType, BLOBType)
+			}
+
+			return IntegerType, nil
+		},
+		apply: func(params []TypedValue) (TypedValue, error) {
+			// characters are counted in strings, while bytes are counted in blobs
+			s, isVarchar := params[0].Value().(string)
+			if isVarchar {
+				return &Number{val: uint64(utf8.RuneCountInString(s))}, nil
+			}
+
+			return &Number{val: uint64(len(params[0].Value().([]byte)))}, nil
+		},
+	},
+	"substring": {
+		minParams:  2,
+		maxParams:  3,
+		returnType: paramTypesAre(VarcharType, VarcharType, IntegerType, IntegerType),
+		apply:      substring,
+	},
+	"concat": {
+		minParams:    1,
+		maxParams:    -1,
+		acceptsNulls: true,
+		returnType: func(params []ValueExp, paramTypes []SQLValueType) (SQLValueType, error) {
+			for _, t := range paramTypes {
+				if !canCast(t, VarcharType) {
+					return "", fmt.Errorf("%w: %s values can not be concatenated", ErrInvalidFunctionParams, t)
+				}
+			}
+
+			return VarcharType, nil
+		},
+		apply: func(params []TypedValue) (TypedValue, error) {
+			var b strings.Builder
+
+			// null values are ignored
+			for _, p := range params {
+				if p.Value() == nil {
+					continue
+				}
+
+				s, err := castValue(p, VarcharType)
+				if err != nil {
+					return nil, err
+				}
+
+				b.WriteString(s.Value().(string))
+			}
+
+			return &Varchar{val: b.String()}, nil
+		},
+	},
+	"coalesce": {
+		minParams:    1,
+		maxParams:    -1,
+		acceptsNulls: true,
+		returnType: func(params []ValueExp, paramTypes []SQLValueType) (SQLValueType, error) {
+			return commonType(paramTypes, ErrInvalidFunctionParams)
+		},
+		apply: func(params []TypedValue) (TypedValue, error) {
+			for _, p := range params {
+				if p.Value() != nil {
+					return p, nil
+				}
+			}
+
+			return &NullValue{}, nil
+		},
+	},
+	"cast": {
+		minParams: 2,
+		maxParams: 2,
+		returnType: func(params []ValueExp, paramTypes []SQLValueType) (SQLValueType, error) {
+			t, err := castType(params[1])
+			if err != nil {
+				return "", err
+			}
+
+			if !canCast(paramTypes[0], t) {
+				return "", fmt.Errorf("%w: from %s to %s", ErrUnsupportedCast, paramTypes[0], t)
+			}
+
+			return t, nil
+		},
+		apply: func(params []TypedValue) (TypedValue, error) {
+			return castValue(params[0], SQLValueType(params[1].Value().(string)))
+		},
+	},
+}
+
+// paramTypesAre returns a validation of the types of the parameters, which must be the given ones,
+// for functions returning values of type t
+func paramTypesAre(t SQLValueType, expected ...SQLValueType) func(params []ValueExp, paramTypes []SQLValueType) (SQLValueType, error) {
+	return func(params []ValueExp, paramTypes []SQLValueType) (SQLValueType, error) {
+		for i, pt := range paramTypes {
+			if pt != "" && pt != expected[i] {
+				return "", fmt.Errorf("%w: parameter %d must be of type %s", ErrInvalidFunctionParams, i+1, expected[i])
+			}
+		}
+
+		return t, nil
+	}
+}
+
+// substring returns the characters of a string starting from a position, counted from 1,
+// and limited to the given length when there is one
+func substring(params []TypedValue) (TypedValue, error) {
+	s := []rune(params[0].Value().(string))
+
+	from := int64(params[1].Value().(uint64)) - 1
+	to := int64(len(s))
+
+	if len(params) == 3 {
+		l := int64(params[2].Value().(uint64))
+		if l < 0 {
+			return nil, fmt.Errorf("%w: negative substring length", ErrInvalidFunctionParams)
+		}
+
+		if from+l < to {
+			to = from + l
+		}
+	}
+
+	if from < 0 {
+		from = 0
+	}
+
+	if from >= to {
+		return &Varchar{val: ""}, nil
+	}
+
+	return &Varchar{val: string(s[from:to])}, nil
+}
+
+// castType returns the type of the values a CAST expression converts into
+func castType(exp ValueExp) (SQLValueType, error) {
+	v, isVarchar := exp.(*Varchar)
+	if !isVarchar {
+		return "", fmt.Errorf("%w: CAST expects a type", ErrInvalidFunctionParams)
+	}
+
+	t, ok := types[strings.ToUpper(v.val)]
+	if !ok {
+		return "", fmt.Errorf("%w: unknown type %s", ErrInvalidFunctionParams, v.val)
+	}
+
+	return t, nil
+}
+
+// timestampTextLayout is the format of timestamps converted into text
+const timestampTextLayout = "2006-01-02 15:04:05.999999"
+
+// converters holds the supported conversions by source and target types, integers are taken as signed values
+var converters = map[SQLValueType]map[SQLValueType]func(v TypedValue) (TypedValue, error){
+	IntegerType: {
+		FloatType: func(v TypedValue) (TypedValue, error) {
+			return &Float{val: float64(int64(v.Value().(uint64)))}, nil
+		},
+		VarcharType: func(v TypedValue) (TypedValue, error) {
+			return &Varchar{val: strconv.FormatInt(int64(v.Value().(uint64)), 10)}, nil
+		},
+		BooleanType: func(v TypedValue) (TypedValue, error) {
+			return &Bool{val: v.Value().(uint64) != 0}, nil
+		},
+	},
+	FloatType: {
+		IntegerType: func(v TypedValue) (TypedValue, error) {
+			return &Number{val: uint64(int64(v.Value().(float64)))}, nil
+		},
+		VarcharType: func(v TypedValue) (TypedValue, error) {
+			return &Varchar{val: strconv.FormatFloat(v.Value().(float64), 'f', -1, 64)}, nil
+		},
+	},
+	BooleanType: {
+		IntegerType: func(v TypedValue) (TypedValue, error) {
+			if v.Value().(bool) {
+				return &Number{val: 1}, nil
+			}
+
+			return &Number{val: 0}, nil
+		},
+		VarcharType: func(v TypedValue) (TypedValue, error) {
+			return &Varchar{val: strconv.FormatBool(v.Value().(bool))}, nil
+		},
+	},
+	VarcharType: {
+		IntegerType: func(v TypedValue) (TypedValue, error) {
+			n, err := strconv.ParseInt(strings.TrimSpace(v.Value().(string)), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: '%s' is not an integer", ErrInvalidValue, v.Value())
+			}
+
+			return &Number{val: uint64(n)}, nil
+		},
+		FloatType: func(v TypedValue) (TypedValue, error) {
+			f, err := strconv.ParseFloat(strings.TrimSpace(v.Value().(string)), 64)
+			if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+				return nil, fmt.Errorf("%w: '%s' is not a float", ErrInvalidValue, v.Value())
+			}
+
+			return &Float{val: f}, nil
+		},
+		BooleanType: func(v TypedValue) (TypedValue, error) {
+			b, err := strconv.ParseBool(strings.TrimSpace(v.Value().(string)))
+			if err != nil {
+				return nil, fmt.Errorf("%w: '%s' is not a boolean", ErrInvalidValue, v.Value())
+			}
+
+			return &Bool{val: b}, nil
+		},
+		TimestampType: func(v TypedValue) (TypedValue, error) {
+			ts, err := parseTimestamp(strings.TrimSpace(v.Value().(string)))
+			if err != nil {
+				return nil, fmt.Errorf("%w: '%s' is not a timestamp", ErrInvalidValue, v.Value())
+			}
+
+			return ts, nil
+		},
+		BLOBType: func(v TypedValue) (TypedValue, error) {
+			return &Blob{val: []byte(v.Value().(string))}, nil
+		},
+		JSONType: func(v TypedValue) (TypedValue, error) {
+			return parseJSON(v.Value().(string))
+		},
+	},
+	TimestampType: {
+		VarcharType: func(v TypedValue) (TypedValue, error) {
+			return &Varchar{val: v.(*Timestamp).val.Format(timestampTextLayout)}, nil
+		},
+	},
+	JSONType: {
+		VarcharType: func(v TypedValue) (TypedValue, error) {
+			return &Varchar{val: v.Value().(string)}, nil
+		},
+	},
+}
+
+// canCast returns whether values of type from can be converted into values of type to.
+// Nulls, whose type may be unknown, can be converted into any type
+func canCast(from, to SQLValueType) bool {
+	if from == "" || from == to {
+		return true
+	}
+
+	_, ok := converters[from][to]
+	return ok
+}
+
+// castValue converts a value into a value of type t, nulls are converted into nulls of type t
+func castValue(v TypedValue, t SQLValueType) (TypedValue, error) {
+	if v.Value() == nil {
+		return &NullValue{t: t}, nil
+	}
+
+	if v.Type() == t {
+		return v, nil
+	}
+
+	convert, ok := converters[v.Type()][t]
+	if !ok {
+		return nil, fmt.Errorf("%w: from %s to %s", ErrUnsupportedCast, v.Type(), t)
+	}
+
+	return convert(v)
+}
+
+// FnCall is a call to a scalar function of the registry e.g. UPPER(name) or CAST(price AS INTEGER)
+type FnCall struct {
+	fn     string
+	params []ValueExp
+	as     string
+}
+
+func newFnCall(fn string, params []ValueExp) (*FnCall, error) {
+	c := &FnCall{fn: strings.ToLower(fn), params: params}
+
+	_, err := c.function()
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// function returns the called function, as long as it accepts the given number of parameters
+func (c *FnCall) function() (*scalarFunction, error) {
+	fn, ok := scalarFunctions[c.fn]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrFunctionDoesNotExist, c.fn)
+	}
+
+	if len(c.params) < fn.minParams || (fn.maxParams >= 0 && len(c.params) > fn.maxParams) {
+		return nil, fmt.Errorf("%w: wrong number of parameters for %s", ErrInvalidFunctionParams, strings.ToUpper(c.fn))
+	}
+
+	return fn, nil
+}
+
+func (c *FnCall) resolve(implicitDB, implicitTable string) (aggFn, db, table, col string) {
+	// values are named as aggregations, thus there is no column
+	return "", implicitDB, implicitTable, ""
+}
+
+func (c *FnCall) alias() string {
+	return c.as
+}
+
+func (c *FnCall) setAlias(alias string) {
+	c.as = alias
+}
+
+func (c *FnCall) jointColumnTo(col *Column, tableAlias string) (*ColSelector, error) {
+	return nil, ErrJointColumnNotFound
+}
+
+func (c *FnCall) substitute(params map[string]interface{}) (ValueExp, error) {
+	rc := &FnCall{fn: c.fn, params: make([]ValueExp, len(c.params)), as: c.as}
+
+	for i, p := range c.params {
+		rp, err := p.substitute(params)
+		if err != nil {
+			return nil, err
+		}
+
+		rc.params[i] = rp
+	}
+
+	return rc, nil
+}
+
+func (c *FnCall) reduce(catalog *Catalog, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
+	fn, err := c.function()
+	if err != nil {
+		return nil, err
+	}
+
+	vals := make([]TypedValue, len(c.params))
+	valTypes := make([]SQLValueType, len(c.params))
+
+	for i, p := range c.params {
+		v, err := p.reduce(catalog, row, implicitDB, implicitTable)
+		if err != nil {
+			return nil, err
+		}
+
+		vals[i] = v
+		valTypes[i] = v.Type()
+	}
+
+	t, err := fn.returnType(c.params, valTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	if !fn.acceptsNulls {
+		for _, v := range vals {
+			if v.Value() == nil {
+				return &NullValue{t: t}, nil
+			}
+		}
+	}
+
+	return fn.apply(vals)
+}
+
+// valType returns the type of the results of the call
+func (c *FnCall) valType(cols map[string]*ColDescriptor, implicitDB, implicitTable string) (SQLValueType, error) {
+	fn, err := c.function()
+	if err != nil {
+		return "", err
+	}
+
+	paramTypes := make([]SQLValueType, len(c.params))
+
+	for i, p := range c.params {
+		pt, err := expType(p, cols, implicitDB, implicitTable)
+		if err != nil {
+			return "", err
+		}
+
+		paramTypes[i] = pt
+	}
+
+	t, err := fn.returnType(c.params, paramTypes)
+	if err != nil {
+		return "", err
+	}
+
+	if t == "" {
+		return VarcharType, nil
+	}
+
+	return t, nil
+}
+
+// reduceAs reduces the call into a value of the given type, as returned by valType
+func (c *FnCall) reduceAs(t SQLValueType, catalog *Catalog, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
+	v, err := c.reduce(catalog, row, implicitDB, implicitTable)
+	if err != nil {
+		return nil, err
+	}
+
+	return valueAs(v, t), nil
+}
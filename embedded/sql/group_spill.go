@@ -0,0 +1,754 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sql
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"hash/fnv"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"sort"
+
+	"github.com/codenotary/immudb/embedded/fileutil"
+	"github.com/codenotary/immudb/embedded/store"
+)
+
+const spillPartitions = 16
+
+// groupSpill keeps partially aggregated groups in temporary files.
+// Groups are distributed into partitions based on their keys, so all the partial states of a group
+// end up in the same partition. Partitions are merged one at a time and sorted by the first appearance
+// of each group, then returned by merging all the partitions.
+type groupSpill struct {
+	files   []*os.File
+	writers []*bufio.Writer
+	readers []*bufio.Reader
+
+	heads []*group
+}
+
+func newGroupSpill() (*groupSpill, error) {
+	spill := &groupSpill{
+		files:   make([]*os.File, spillPartitions),
+		writers: make([]*bufio.Writer, spillPartitions),
+		readers: make([]*bufio.Reader, spillPartitions),
+		heads:   make([]*group, spillPartitions),
+	}
+
+	for i := 0; i < spillPartitions; i++ {
+		f, err := ioutil.TempFile("", "immudb_sql_groups_")
+		if err != nil {
+			spill.close()
+			return nil, err
+		}
+
+		spill.files[i] = f
+		spill.writers[i] = bufio.NewWriter(f)
+	}
+
+	return spill, nil
+}
+
+func partitionOf(key string) int {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return int(h.Sum32() % spillPartitions)
+}
+
+func (s *groupSpill) add(g *group) error {
+	return writeGroup(s.writers[partitionOf(g.key)], g)
+}
+
+// merge combines the partial states of every group and sorts each partition by first appearance
+func (s *groupSpill) merge() error {
+	for i, f := range s.files {
+		err := s.writers[i].Flush()
+		if err != nil {
+			return err
+		}
+
+		_, err = f.Seek(0, io.SeekStart)
+		if err != nil {
+			return err
+		}
+
+		groups := make(map[string]*group)
+
+		r := bufio.NewReader(f)
+
+		for {
+			g, err := readGroup(r)
+			if err != nil {
+				return err
+			}
+			if g == nil {
+				break
+			}
+
+			pg, exists := groups[g.key]
+			if !exists {
+				groups[g.key] = g
+				continue
+			}
+
+			mg, err := mergeGroups(pg, g)
+			if err != nil {
+				return err
+			}
+
+			groups[g.key] = mg
+		}
+
+		sorted := make([]*group, 0, len(groups))
+		for _, g := range groups {
+			sorted = append(sorted, g)
+		}
+
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i].seq < sorted[j].seq })
+
+		err = f.Truncate(0)
+		if err != nil {
+			return err
+		}
+
+		_, err = f.Seek(0, io.SeekStart)
+		if err != nil {
+			return err
+		}
+
+		w := bufio.NewWriter(f)
+
+		for _, g := range sorted {
+			err = writeGroup(w, g)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = w.Flush()
+		if err != nil {
+			return err
+		}
+
+		_, err = f.Seek(0, io.SeekStart)
+		if err != nil {
+			return err
+		}
+
+		s.readers[i] = bufio.NewReader(f)
+
+		s.heads[i], err = readGroup(s.readers[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// next returns the row of the group with the earliest first appearance among all partitions
+func (s *groupSpill) next() (*Row, error) {
+	p := -1
+
+	for i, g := range s.heads {
+		if g != nil && (p < 0 || g.seq < s.heads[p].seq) {
+			p = i
+		}
+	}
+
+	if p < 0 {
+		return nil, store.ErrNoMoreEntries
+	}
+
+	row := s.heads[p].row
+
+	var err error
+
+	s.heads[p], err = readGroup(s.readers[p])
+	if err != nil {
+		return nil, err
+	}
+
+	return row, nil
+}
+
+func (s *groupSpill) close() {
+	for _, f := range s.files {
+		if f == nil {
+			continue
+		}
+
+		f.Close()
+		fileutil.Remove(f.Name())
+	}
+}
+
+// mergeGroups merges the aggregated values of two partial states of the same group,
+// values of non-aggregated columns are taken from the first appearance
+func mergeGroups(g1, g2 *group) (*group, error) {
+	if g2.seq < g1.seq {
+		g1, g2 = g2, g1
+	}
+
+	for sel, v := range g1.row.Values {
+		aggV, isAggregatedValue := v.(AggregatedValue)
+		if !isAggregatedValue {
+			continue
+		}
+
+		aggV2, ok := g2.row.Values[sel].(AggregatedValue)
+		if !ok {
+			return nil, ErrCorruptedData
+		}
+
+		err := aggV.mergeWith(aggV2)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return g1, nil
+}
+
+const (
+	spilledNull byte = iota
+	spilledNumber
+	spilledVarchar
+	spilledBool
+	spilledBlob
+	spilledCount
+	spilledSum
+	spilledMin
+	spilledMax
+	spilledAVG
+	spilledFloat
+	spilledFloatSum
+	spilledFloatAVG
+	spilledTimestamp
+	spilledJSON
+	spilledCountDistinct
+)
+
+// writeGroup writes key + seq + row
+func writeGroup(w io.Writer, g *group) error {
+	var buf bytes.Buffer
+
+	writeSpilledBytes(&buf, []byte(g.key))
+	writeSpilledUint64(&buf, g.seq)
+
+	err := writeSpilledRow(&buf, g.row)
+	if err != nil {
+		return err
+	}
+
+	return writeSpilledRecord(w, buf.Bytes())
+}
+
+// readGroup returns nil when there are no more groups to read
+func readGroup(r io.Reader) (*group, error) {
+	b, err := readSpilledRecord(r)
+	if err != nil || b == nil {
+		return nil, err
+	}
+
+	d := &spillDecoder{b: b}
+
+	key, err := d.readBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	seq, err := d.readUint64()
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := d.readRow()
+	if err != nil {
+		return nil, err
+	}
+
+	return &group{key: string(key), seq: seq, row: row}, nil
+}
+
+// writeSpilledRecord writes len(b) + b
+func writeSpilledRecord(w io.Writer, b []byte) error {
+	var blen [EncLenLen]byte
+	binary.BigEndian.PutUint32(blen[:], uint32(len(b)))
+
+	_, err := w.Write(blen[:])
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(b)
+	return err
+}
+
+// readSpilledRecord returns nil when there are no more records to read
+func readSpilledRecord(r io.Reader) ([]byte, error) {
+	var blen [EncLenLen]byte
+
+	_, err := io.ReadFull(r, blen[:])
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, binary.BigEndian.Uint32(blen[:]))
+
+	_, err = io.ReadFull(r, b)
+	if err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// writeSpilledRow encodes the row as #values + (selector + value)*
+func writeSpilledRow(buf *bytes.Buffer, row *Row) error {
+	writeSpilledUint64(buf, uint64(len(row.Values)))
+
+	for sel, v := range row.Values {
+		writeSpilledBytes(buf, []byte(sel))
+
+		err := writeSpilledValue(buf, v)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeSpilledBytes(buf *bytes.Buffer, b []byte) {
+	var blen [EncLenLen]byte
+	binary.BigEndian.PutUint32(blen[:], uint32(len(b)))
+
+	buf.Write(blen[:])
+	buf.Write(b)
+}
+
+func writeSpilledUint64(buf *bytes.Buffer, n uint64) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], n)
+
+	buf.Write(b[:])
+}
+
+func writeSpilledValue(buf *bytes.Buffer, v TypedValue) error {
+	switch tv := v.(type) {
+	case *NullValue:
+		{
+			buf.WriteByte(spilledNull)
+			writeSpilledBytes(buf, []byte(tv.t))
+		}
+	case *Number:
+		{
+			buf.WriteByte(spilledNumber)
+			writeSpilledUint64(buf, tv.val)
+		}
+	case *Float:
+		{
+			buf.WriteByte(spilledFloat)
+			writeSpilledUint64(buf, math.Float64bits(tv.val))
+		}
+	case *Timestamp:
+		{
+			buf.WriteByte(spilledTimestamp)
+			buf.Write(encodeTimestamp(tv.val)[EncLenLen:])
+		}
+	case *Varchar:
+		{
+			buf.WriteByte(spilledVarchar)
+			writeSpilledBytes(buf, []byte(tv.val))
+		}
+	case *JSON:
+		{
+			buf.WriteByte(spilledJSON)
+			writeSpilledBytes(buf, []byte(tv.val))
+		}
+	case *Bool:
+		{
+			buf.WriteByte(spilledBool)
+			if tv.val {
+				buf.WriteByte(1)
+			} else {
+				buf.WriteByte(0)
+			}
+		}
+	case *Blob:
+		{
+			buf.WriteByte(spilledBlob)
+			writeSpilledBytes(buf, tv.val)
+		}
+	case *CountValue:
+		{
+			buf.WriteByte(spilledCount)
+			writeSpilledBytes(buf, []byte(tv.sel))
+			writeSpilledUint64(buf, tv.c)
+		}
+	case *CountDistinctValue:
+		{
+			buf.WriteByte(spilledCountDistinct)
+			writeSpilledBytes(buf, []byte(tv.sel))
+			writeSpilledUint64(buf, uint64(len(tv.vals)))
+
+			for encVal := range tv.vals {
+				writeSpilledBytes(buf, []byte(encVal))
+			}
+		}
+	case *SumValue:
+		{
+			if tv.floating {
+				buf.WriteByte(spilledFloatSum)
+				writeSpilledBytes(buf, []byte(tv.sel))
+				writeSpilledUint64(buf, math.Float64bits(tv.f))
+				break
+			}
+
+			buf.WriteByte(spilledSum)
+			writeSpilledBytes(buf, []byte(tv.sel))
+			writeSpilledUint64(buf, tv.s)
+		}
+	case *MinValue:
+		{
+			buf.WriteByte(spilledMin)
+			writeSpilledBytes(buf, []byte(tv.sel))
+			return writeSpilledOptionalValue(buf, tv.val)
+		}
+	case *MaxValue:
+		{
+			buf.WriteByte(spilledMax)
+			writeSpilledBytes(buf, []byte(tv.sel))
+			return writeSpilledOptionalValue(buf, tv.val)
+		}
+	case *AVGValue:
+		{
+			if tv.floating {
+				buf.WriteByte(spilledFloatAVG)
+				writeSpilledBytes(buf, []byte(tv.sel))
+				writeSpilledUint64(buf, math.Float64bits(tv.f))
+				writeSpilledUint64(buf, tv.c)
+				break
+			}
+
+			buf.WriteByte(spilledAVG)
+			writeSpilledBytes(buf, []byte(tv.sel))
+			writeSpilledUint64(buf, tv.s)
+			writeSpilledUint64(buf, tv.c)
+		}
+	default:
+		{
+			return ErrInvalidValue
+		}
+	}
+
+	return nil
+}
+
+func writeSpilledOptionalValue(buf *bytes.Buffer, v TypedValue) error {
+	if v == nil {
+		buf.WriteByte(0)
+		return nil
+	}
+
+	buf.WriteByte(1)
+
+	return writeSpilledValue(buf, v)
+}
+
+type spillDecoder struct {
+	b   []byte
+	off int
+}
+
+func (d *spillDecoder) readByte() (byte, error) {
+	if len(d.b) < d.off+1 {
+		return 0, ErrCorruptedData
+	}
+
+	b := d.b[d.off]
+	d.off++
+
+	return b, nil
+}
+
+func (d *spillDecoder) readUint64() (uint64, error) {
+	if len(d.b) < d.off+8 {
+		return 0, ErrCorruptedData
+	}
+
+	n := binary.BigEndian.Uint64(d.b[d.off:])
+	d.off += 8
+
+	return n, nil
+}
+
+func (d *spillDecoder) readBytes() ([]byte, error) {
+	if len(d.b) < d.off+EncLenLen {
+		return nil, ErrCorruptedData
+	}
+
+	blen := int(binary.BigEndian.Uint32(d.b[d.off:]))
+	d.off += EncLenLen
+
+	if len(d.b) < d.off+blen {
+		return nil, ErrCorruptedData
+	}
+
+	b := make([]byte, blen)
+	copy(b, d.b[d.off:])
+	d.off += blen
+
+	return b, nil
+}
+
+func (d *spillDecoder) readRow() (*Row, error) {
+	valuesLen, err := d.readUint64()
+	if err != nil {
+		return nil, err
+	}
+
+	row := &Row{Values: make(map[string]TypedValue)}
+
+	for i := uint64(0); i < valuesLen; i++ {
+		sel, err := d.readBytes()
+		if err != nil {
+			return nil, err
+		}
+
+		v, err := d.readValue()
+		if err != nil {
+			return nil, err
+		}
+
+		row.Values[string(sel)] = v
+	}
+
+	return row, nil
+}
+
+func (d *spillDecoder) readValue() (TypedValue, error) {
+	tag, err := d.readByte()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tag {
+	case spilledNull:
+		{
+			t, err := d.readBytes()
+			if err != nil {
+				return nil, err
+			}
+
+			return &NullValue{t: string(t)}, nil
+		}
+	case spilledNumber:
+		{
+			n, err := d.readUint64()
+			if err != nil {
+				return nil, err
+			}
+
+			return &Number{val: n}, nil
+		}
+	case spilledTimestamp:
+		{
+			if len(d.b) < d.off+EncIDLen {
+				return nil, ErrCorruptedData
+			}
+
+			t := decodeTimestamp(d.b[d.off:])
+			d.off += EncIDLen
+
+			return &Timestamp{val: t}, nil
+		}
+	case spilledFloat:
+		{
+			n, err := d.readUint64()
+			if err != nil {
+				return nil, err
+			}
+
+			return &Float{val: math.Float64frombits(n)}, nil
+		}
+	case spilledVarchar:
+		{
+			s, err := d.readBytes()
+			if err != nil {
+				return nil, err
+			}
+
+			return &Varchar{val: string(s)}, nil
+		}
+	case spilledJSON:
+		{
+			s, err := d.readBytes()
+			if err != nil {
+				return nil, err
+			}
+
+			return &JSON{val: string(s)}, nil
+		}
+	case spilledBool:
+		{
+			b, err := d.readByte()
+			if err != nil {
+				return nil, err
+			}
+
+			return &Bool{val: b == 1}, nil
+		}
+	case spilledBlob:
+		{
+			b, err := d.readBytes()
+			if err != nil {
+				return nil, err
+			}
+
+			return &Blob{val: b}, nil
+		}
+	}
+
+	sel, err := d.readBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tag {
+	case spilledCount:
+		{
+			c, err := d.readUint64()
+			if err != nil {
+				return nil, err
+			}
+
+			return &CountValue{sel: string(sel), c: c}, nil
+		}
+	case spilledCountDistinct:
+		{
+			n, err := d.readUint64()
+			if err != nil {
+				return nil, err
+			}
+
+			var vals map[string]struct{}
+
+			for i := uint64(0); i < n; i++ {
+				encVal, err := d.readBytes()
+				if err != nil {
+					return nil, err
+				}
+
+				if vals == nil {
+					vals = make(map[string]struct{})
+				}
+
+				vals[string(encVal)] = struct{}{}
+			}
+
+			return &CountDistinctValue{sel: string(sel), vals: vals}, nil
+		}
+	case spilledSum:
+		{
+			s, err := d.readUint64()
+			if err != nil {
+				return nil, err
+			}
+
+			return &SumValue{sel: string(sel), s: s}, nil
+		}
+	case spilledFloatSum:
+		{
+			f, err := d.readUint64()
+			if err != nil {
+				return nil, err
+			}
+
+			return &SumValue{sel: string(sel), f: math.Float64frombits(f), floating: true}, nil
+		}
+	case spilledMin:
+		{
+			v, err := d.readOptionalValue()
+			if err != nil {
+				return nil, err
+			}
+
+			return &MinValue{sel: string(sel), val: v}, nil
+		}
+	case spilledMax:
+		{
+			v, err := d.readOptionalValue()
+			if err != nil {
+				return nil, err
+			}
+
+			return &MaxValue{sel: string(sel), val: v}, nil
+		}
+	case spilledAVG:
+		{
+			s, err := d.readUint64()
+			if err != nil {
+				return nil, err
+			}
+
+			c, err := d.readUint64()
+			if err != nil {
+				return nil, err
+			}
+
+			return &AVGValue{sel: string(sel), s: s, c: c}, nil
+		}
+	case spilledFloatAVG:
+		{
+			f, err := d.readUint64()
+			if err != nil {
+				return nil, err
+			}
+
+			c, err := d.readUint64()
+			if err != nil {
+				return nil, err
+			}
+
+			return &AVGValue{sel: string(sel), f: math.Float64frombits(f), c: c, floating: true}, nil
+		}
+	}
+
+	return nil, ErrCorruptedData
+}
+
+func (d *spillDecoder) readOptionalValue() (TypedValue, error) {
+	present, err := d.readByte()
+	if err != nil {
+		return nil, err
+	}
+
+	if present == 0 {
+		return nil, nil
+	}
+
+	return d.readValue()
+}
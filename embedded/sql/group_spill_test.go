@@ -0,0 +1,120 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sql
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpilledGroupEncoding(t *testing.T) {
+	g := &group{
+		key: "key1",
+		seq: 7,
+		row: &Row{Values: map[string]TypedValue{
+			"(db1.table1.id)":      &Number{val: 1},
+			"(db1.table1.title)":   &Varchar{val: "title1"},
+			"(db1.table1.active)":  &Bool{val: true},
+			"(db1.table1.payload)": &Blob{val: []byte{1, 2}},
+			"(db1.table1.age)":     &NullValue{t: IntegerType},
+			"count":                &CountValue{c: 2, sel: "s"},
+			"dcount":               &CountDistinctValue{vals: map[string]struct{}{"a": {}, "b": {}}, sel: "s"},
+			"dcount0":              &CountDistinctValue{sel: "s"},
+			"sum":                  &SumValue{s: 3, sel: "s"},
+			"min":                  &MinValue{val: &Number{val: 4}, sel: "s"},
+			"max":                  &MaxValue{sel: "s"},
+			"avg":                  &AVGValue{s: 10, c: 2, sel: "s"},
+			"(db1.table1.price)":   &Float{val: -1.5},
+			"(db1.table1.ts)":      &Timestamp{val: time.Date(1960, 1, 2, 3, 4, 5, 6000, time.UTC)},
+			"fsum":                 &SumValue{f: 2.5, floating: true, sel: "f"},
+			"favg":                 &AVGValue{f: 7.5, c: 3, floating: true, sel: "f"},
+		}},
+	}
+
+	var buf bytes.Buffer
+
+	err := writeGroup(&buf, g)
+	require.NoError(t, err)
+
+	encLen := buf.Len()
+
+	rg, err := readGroup(&buf)
+	require.NoError(t, err)
+	require.Equal(t, g, rg)
+
+	rg, err = readGroup(&buf)
+	require.NoError(t, err)
+	require.Nil(t, rg)
+
+	err = writeGroup(&buf, g)
+	require.NoError(t, err)
+
+	_, err = readGroup(bytes.NewReader(buf.Bytes()[:encLen-1]))
+	require.Error(t, err)
+
+	err = writeGroup(&buf, &group{row: &Row{Values: map[string]TypedValue{"undefined": nil}}})
+	require.Equal(t, ErrInvalidValue, err)
+
+	d := &spillDecoder{b: []byte{spilledAVG + 1, 0, 0, 0, 0}}
+	_, err = d.readValue()
+	require.Equal(t, ErrCorruptedData, err)
+}
+
+func TestGroupSpill(t *testing.T) {
+	spill, err := newGroupSpill()
+	require.NoError(t, err)
+	defer spill.close()
+
+	for i := 0; i < 2; i++ {
+		for _, key := range []string{"a", "b", "c"} {
+			err = spill.add(&group{
+				key: key,
+				seq: uint64(len(key)*int(key[0]) + i*100),
+				row: &Row{Values: map[string]TypedValue{
+					"key":   &Varchar{val: key},
+					"part":  &Number{val: uint64(i)},
+					"count": &CountValue{c: 1},
+				}},
+			})
+			require.NoError(t, err)
+		}
+	}
+
+	err = spill.merge()
+	require.NoError(t, err)
+
+	for _, key := range []string{"a", "b", "c"} {
+		row, err := spill.next()
+		require.NoError(t, err)
+		require.Equal(t, key, row.Values["key"].Value())
+		require.Equal(t, uint64(0), row.Values["part"].Value())
+		require.Equal(t, uint64(2), row.Values["count"].Value())
+	}
+
+	_, err = spill.next()
+	require.Equal(t, store.ErrNoMoreEntries, err)
+
+	_, err = mergeGroups(
+		&group{row: &Row{Values: map[string]TypedValue{"count": &CountValue{}}}},
+		&group{row: &Row{Values: map[string]TypedValue{"count": &Number{}}}},
+	)
+	require.Equal(t, ErrCorruptedData, err)
+}
@@ -15,8 +15,17 @@ limitations under the License.
 */
 package sql
 
-import "github.com/codenotary/immudb/embedded/store"
+import (
+	"fmt"
 
+	"github.com/codenotary/immudb/embedded/store"
+)
+
+// groupedRowReader implements hash aggregation: rows are assigned to groups based on the values
+// of the grouping expressions, regardless of the order in which they are read.
+// Groups are returned in order of first appearance, thus preserving the ordering of the underlying reader.
+// When the number of groups exceeds the limit set in the engine, groups are spilled to disk and merged back
+// once all the rows have been read.
 type groupedRowReader struct {
 	e *Engine
 
@@ -24,25 +33,104 @@ type groupedRowReader struct {
 
 	selectors []Selector
 
-	groupBy []*ColSelector
+	groupBy []ValueExp
+
+	// aggregations required by the selectors and other expressions e.g. having clause
+	aggregations []*AggColSelector
+
+	params map[string]interface{}
+
+	maxGroupsInMem int
+
+	groups  map[string]*group
+	ordered []*group
+	nextSeq uint64
 
-	currRow  *Row
-	nonEmpty bool
+	spill *groupSpill
+
+	aggregated bool
+	nonEmpty   bool
+}
+
+type group struct {
+	key string
+	seq uint64
+	row *Row
 }
 
-func (e *Engine) newGroupedRowReader(rowReader RowReader, selectors []Selector, groupBy []*ColSelector) (*groupedRowReader, error) {
-	if rowReader == nil || len(selectors) == 0 {
+func (e *Engine) newGroupedRowReader(rowReader RowReader, selectors []Selector, groupBy []ValueExp, aggExps []ValueExp, params map[string]interface{}) (*groupedRowReader, error) {
+	if rowReader == nil {
 		return nil, ErrIllegalArguments
 	}
 
+	var aggregations []*AggColSelector
+
+	for _, sel := range selectors {
+		aggregations = aggregationsIn(sel, aggregations)
+	}
+
+	for _, exp := range aggExps {
+		aggregations = aggregationsIn(exp, aggregations)
+	}
+
 	return &groupedRowReader{
-		e:         e,
-		rowReader: rowReader,
-		selectors: selectors,
-		groupBy:   groupBy,
+		e:              e,
+		rowReader:      rowReader,
+		selectors:      selectors,
+		groupBy:        groupBy,
+		aggregations:   aggregations,
+		params:         params,
+		maxGroupsInMem: e.maxGroupsInMem,
+		groups:         make(map[string]*group),
 	}, nil
 }
 
+// aggregationsIn appends to aggs the aggregations used in the expression
+func aggregationsIn(exp ValueExp, aggs []*AggColSelector) []*AggColSelector {
+	switch e := exp.(type) {
+	case *AggColSelector:
+		return append(aggs, e)
+	case *NumExp:
+		return aggregationsIn(e.right, aggregationsIn(e.left, aggs))
+	case *CmpBoolExp:
+		return aggregationsIn(e.right, aggregationsIn(e.left, aggs))
+	case *BinBoolExp:
+		return aggregationsIn(e.right, aggregationsIn(e.left, aggs))
+	case *NotBoolExp:
+		return aggregationsIn(e.exp, aggs)
+	case *IsNullBoolExp:
+		return aggregationsIn(e.exp, aggs)
+	case *LikeBoolExp:
+		return aggregationsIn(e.pattern, aggregationsIn(e.val, aggs))
+	case *CaseWhenExp:
+		{
+			if e.exp != nil {
+				aggs = aggregationsIn(e.exp, aggs)
+			}
+
+			for _, w := range e.whens {
+				aggs = aggregationsIn(w.then, aggregationsIn(w.when, aggs))
+			}
+
+			if e.elseExp != nil {
+				aggs = aggregationsIn(e.elseExp, aggs)
+			}
+
+			return aggs
+		}
+	case *FnCall:
+		{
+			for _, p := range e.params {
+				aggs = aggregationsIn(p, aggs)
+			}
+
+			return aggs
+		}
+	}
+
+	return aggs
+}
+
 func (gr *groupedRowReader) ImplicitDB() string {
 	return gr.rowReader.ImplicitDB()
 }
@@ -52,6 +140,10 @@ func (gr *groupedRowReader) ImplicitTable() string {
 }
 
 func (gr *groupedRowReader) Columns() ([]*ColDescriptor, error) {
+	if len(gr.selectors) == 0 {
+		return gr.rowReader.Columns()
+	}
+
 	colsBySel, err := gr.colsBySelector()
 	if err != nil {
 		return nil, err
@@ -73,13 +165,9 @@ func (gr *groupedRowReader) colsBySelector() (map[string]*ColDescriptor, error)
 		return nil, err
 	}
 
-	for _, sel := range gr.selectors {
+	for _, sel := range gr.aggregations {
 		aggFn, db, table, col := sel.resolve(gr.rowReader.ImplicitDB(), gr.rowReader.ImplicitTable())
 
-		if aggFn == "" {
-			continue
-		}
-
 		encSel := EncodeSelector(aggFn, db, table, col)
 
 		if aggFn == COUNT {
@@ -89,11 +177,14 @@ func (gr *groupedRowReader) colsBySelector() (map[string]*ColDescriptor, error)
 
 		colDesc, ok := colDescriptors[EncodeSelector("", db, table, col)]
 		if !ok {
-			return nil, ErrColumnDoesNotExist
+			return nil, fmt.Errorf("%w: %s", ErrColumnDoesNotExist, col)
 		}
 
 		if aggFn == MAX || aggFn == MIN {
 			colDescriptors[encSel] = colDesc
+		} else if colDesc.Type == FloatType {
+			// SUM, AVG of float values
+			colDescriptors[encSel] = &ColDescriptor{Selector: encSel, Type: FloatType}
 		} else {
 			// SUM, AVG
 			colDescriptors[encSel] = &ColDescriptor{Selector: encSel, Type: IntegerType}
@@ -119,6 +210,10 @@ func zeroForType(t SQLValueType) TypedValue {
 		{
 			return &Number{}
 		}
+	case FloatType:
+		{
+			return &Float{}
+		}
 	case BooleanType:
 		{
 			return &Bool{}
@@ -131,172 +226,262 @@ func zeroForType(t SQLValueType) TypedValue {
 		{
 			return &Blob{}
 		}
-		/*case TimestampType:
+	case TimestampType:
 		{
-			return &Number{}
-		}*/
+			return &Timestamp{}
+		}
 	}
 	return nil
 }
 
 func (gr *groupedRowReader) Read() (*Row, error) {
+	if !gr.aggregated {
+		err := gr.aggregate()
+		if err != nil {
+			return nil, err
+		}
+
+		gr.aggregated = true
+	}
+
+	if gr.spill != nil {
+		return gr.spill.next()
+	}
+
+	if len(gr.ordered) > 0 {
+		g := gr.ordered[0]
+		gr.ordered = gr.ordered[1:]
+
+		return g.row, nil
+	}
+
+	if !gr.nonEmpty && gr.groupBy == nil && len(gr.selectors) > 0 && allAgregations(gr.selectors) {
+		// special case when all selectors are aggregations
+		gr.nonEmpty = true
+		return gr.zeroRow()
+	}
+
+	return nil, store.ErrNoMoreEntries
+}
+
+func (gr *groupedRowReader) aggregate() error {
 	for {
 		row, err := gr.rowReader.Read()
 		if err == store.ErrNoMoreEntries {
-			if !gr.nonEmpty && allAgregations(gr.selectors) {
-				// special case when all selectors are aggregations
-				zeroRow := &Row{Values: make(map[string]TypedValue, len(gr.selectors))}
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		gr.nonEmpty = true
+
+		key, err := gr.groupKey(row)
+		if err != nil {
+			return err
+		}
+
+		g, exists := gr.groups[key]
+		if !exists {
+			err = gr.initAggregations(row)
+			if err != nil {
+				return err
+			}
+
+			g = &group{key: key, seq: gr.nextSeq, row: row}
+			gr.nextSeq++
+
+			gr.groups[key] = g
+			gr.ordered = append(gr.ordered, g)
 
-				colsBySelector, err := gr.colsBySelector()
+			if len(gr.groups) > gr.maxGroupsInMem {
+				err = gr.spillGroups()
 				if err != nil {
-					return nil, err
+					return err
 				}
+			}
 
-				for _, sel := range gr.selectors {
-					aggFn, db, table, col := sel.resolve(gr.rowReader.ImplicitDB(), gr.rowReader.ImplicitTable())
-					encSel := EncodeSelector(aggFn, db, table, col)
+			continue
+		}
 
-					var zero TypedValue
-					if aggFn == COUNT || aggFn == SUM || aggFn == AVG {
-						zero = zeroForType(IntegerType)
-					} else {
-						zero = zeroForType(colsBySelector[encSel].Type)
-					}
+		err = updateAggregations(g.row, row)
+		if err != nil {
+			return err
+		}
+	}
 
-					zeroRow.Values[encSel] = zero
-				}
+	if gr.spill == nil {
+		return nil
+	}
 
-				gr.nonEmpty = true
+	err := gr.spillGroups()
+	if err != nil {
+		return err
+	}
 
-				return zeroRow, nil
-			}
+	return gr.spill.merge()
+}
 
-			if gr.currRow == nil {
-				return nil, err
-			}
+func (gr *groupedRowReader) spillGroups() error {
+	if gr.spill == nil {
+		spill, err := newGroupSpill()
+		if err != nil {
+			return err
+		}
 
-			r := gr.currRow
-			gr.currRow = nil
+		gr.spill = spill
+	}
 
-			return r, nil
+	for _, g := range gr.ordered {
+		err := gr.spill.add(g)
+		if err != nil {
+			return err
 		}
+	}
+
+	gr.groups = make(map[string]*group)
+	gr.ordered = nil
+
+	return nil
+}
+
+// groupKey encodes the values of the grouping expressions evaluated over the row
+func (gr *groupedRowReader) groupKey(row *Row) (string, error) {
+	var key []byte
+
+	for _, exp := range gr.groupBy {
+		e, err := exp.substitute(gr.params)
 		if err != nil {
-			return nil, err
+			return "", err
 		}
 
-		gr.nonEmpty = true
+		val, err := e.reduce(gr.e.catalog, row, gr.rowReader.ImplicitDB(), gr.rowReader.ImplicitTable())
+		if err != nil {
+			return "", err
+		}
 
-		if gr.currRow == nil {
-			gr.currRow = row
-			err = gr.initAggregations()
-			if err != nil {
-				return nil, err
-			}
+		if isNull(val) {
+			key = append(key, 0)
 			continue
 		}
 
-		compatible, err := gr.currRow.Compatible(row, gr.groupBy, gr.rowReader.ImplicitDB(), gr.rowReader.ImplicitTable())
+		encVal, err := EncodeValue(val, val.Type(), false)
 		if err != nil {
-			return nil, err
+			return "", err
 		}
 
-		if !compatible {
-			r := gr.currRow
-			gr.currRow = row
+		key = append(key, 1)
+		key = append(key, encVal...)
+	}
 
-			err = gr.initAggregations()
-			if err != nil {
-				return nil, err
-			}
+	return string(key), nil
+}
 
-			return r, nil
-		}
+func (gr *groupedRowReader) zeroRow() (*Row, error) {
+	zeroRow := &Row{Values: make(map[string]TypedValue, len(gr.selectors))}
 
-		// Compatible rows get merged
-		for _, v := range gr.currRow.Values {
-			aggV, isAggregatedValue := v.(AggregatedValue)
-
-			if isAggregatedValue {
-				if aggV.ColBounded() {
-					val, exists := row.Values[aggV.Selector()]
-					if !exists {
-						return nil, ErrColumnDoesNotExist
-					}
-
-					err = aggV.updateWith(val)
-					if err != nil {
-						return nil, err
-					}
-				}
+	colsBySelector, err := gr.colsBySelector()
+	if err != nil {
+		return nil, err
+	}
 
-				if !aggV.ColBounded() {
-					err = aggV.updateWith(nil)
-					if err != nil {
-						return nil, err
-					}
-				}
-			}
+	for _, sel := range gr.selectors {
+		aggFn, db, table, col := sel.resolve(gr.rowReader.ImplicitDB(), gr.rowReader.ImplicitTable())
+		encSel := EncodeSelector(aggFn, db, table, col)
+
+		var zero TypedValue
+		if aggFn == COUNT {
+			zero = zeroForType(IntegerType)
+		} else {
+			// sums and averages are integers unless float values are aggregated
+			zero = zeroForType(colsBySelector[encSel].Type)
 		}
+
+		zeroRow.Values[encSel] = zero
 	}
+
+	return zeroRow, nil
 }
 
-func (gr *groupedRowReader) initAggregations() error {
-	// augment row with aggregated values
-	for _, sel := range gr.selectors {
+// initAggregations augments the first row of a group with the aggregated values
+func (gr *groupedRowReader) initAggregations(row *Row) error {
+	for _, sel := range gr.aggregations {
 		aggFn, db, table, col := sel.resolve(gr.rowReader.ImplicitDB(), gr.rowReader.ImplicitTable())
 
 		encSel := EncodeSelector(aggFn, db, table, col)
 
+		_, initialized := row.Values[encSel]
+		if initialized {
+			// same aggregation used more than once
+			continue
+		}
+
 		switch aggFn {
 		case COUNT:
 			{
+				if sel.distinct {
+					row.Values[encSel] = &CountDistinctValue{sel: EncodeSelector("", db, table, col)}
+					break
+				}
+
 				if col != "*" {
 					return ErrLimitedCount
 				}
 
-				gr.currRow.Values[encSel] = &CountValue{sel: EncodeSelector("", db, table, col)}
+				row.Values[encSel] = &CountValue{sel: EncodeSelector("", db, table, col)}
 			}
 		case SUM:
 			{
-				gr.currRow.Values[encSel] = &SumValue{sel: EncodeSelector("", db, table, col)}
+				row.Values[encSel] = &SumValue{sel: EncodeSelector("", db, table, col), floating: isFloatCol(row, db, table, col)}
 			}
 		case MIN:
 			{
-				gr.currRow.Values[encSel] = &MinValue{sel: EncodeSelector("", db, table, col)}
+				row.Values[encSel] = &MinValue{sel: EncodeSelector("", db, table, col)}
 			}
 		case MAX:
 			{
-				gr.currRow.Values[encSel] = &MaxValue{sel: EncodeSelector("", db, table, col)}
+				row.Values[encSel] = &MaxValue{sel: EncodeSelector("", db, table, col)}
 			}
 		case AVG:
 			{
-				gr.currRow.Values[encSel] = &AVGValue{sel: EncodeSelector("", db, table, col)}
+				row.Values[encSel] = &AVGValue{sel: EncodeSelector("", db, table, col), floating: isFloatCol(row, db, table, col)}
 			}
 		}
 	}
 
-	for _, v := range gr.currRow.Values {
-		aggV, isAggregatedValue := v.(AggregatedValue)
+	return updateAggregations(row, row)
+}
 
-		if isAggregatedValue {
-			if aggV.ColBounded() {
-				val, exists := gr.currRow.Values[aggV.Selector()]
-				if !exists {
-					return ErrColumnDoesNotExist
-				}
+func isFloatCol(row *Row, db, table, col string) bool {
+	v, ok := row.Values[EncodeSelector("", db, table, col)]
+	return ok && v.Type() == FloatType
+}
 
-				err := aggV.updateWith(val)
-				if err != nil {
-					return err
-				}
-			}
+// updateAggregations updates the aggregated values of the group row with the values of the row
+func updateAggregations(groupRow, row *Row) error {
+	for _, v := range groupRow.Values {
+		aggV, isAggregatedValue := v.(AggregatedValue)
+		if !isAggregatedValue {
+			continue
+		}
 
-			if !aggV.ColBounded() {
-				err := aggV.updateWith(nil)
-				if err != nil {
-					return err
-				}
+		if !aggV.ColBounded() {
+			err := aggV.updateWith(nil)
+			if err != nil {
+				return err
 			}
+
+			continue
+		}
+
+		val, exists := row.Values[aggV.Selector()]
+		if !exists {
+			return ErrColumnDoesNotExist
+		}
+
+		err := aggV.updateWith(val)
+		if err != nil {
+			return err
 		}
 	}
 
@@ -304,5 +489,9 @@ func (gr *groupedRowReader) initAggregations() error {
 }
 
 func (gr *groupedRowReader) Close() error {
+	if gr.spill != nil {
+		gr.spill.close()
+	}
+
 	return gr.rowReader.Close()
 }
@@ -16,6 +16,7 @@ limitations under the License.
 package sql
 
 import (
+	"context"
 	"os"
 	"testing"
 
@@ -35,22 +36,22 @@ func TestGroupedRowReader(t *testing.T) {
 	engine, err := NewEngine(catalogStore, dataStore, prefix)
 	require.NoError(t, err)
 
-	_, err = engine.newGroupedRowReader(nil, nil, nil)
+	_, err = engine.newGroupedRowReader(nil, nil, nil, nil, nil)
 	require.Equal(t, ErrIllegalArguments, err)
 
 	db, err := engine.catalog.newDatabase("db1")
 	require.NoError(t, err)
 
-	table, err := db.newTable("table1", []*ColSpec{{colName: "id", colType: IntegerType}}, "id")
+	table, err := db.newTable("table1", []*ColSpec{{colName: "id", colType: IntegerType}}, nil, []string{"id"})
 	require.NoError(t, err)
 
 	snap, err := engine.Snapshot()
 	require.NoError(t, err)
 
-	r, err := engine.newRawRowReader(db, snap, table, 0, "", "id", EqualTo, nil)
+	r, err := engine.newRawRowReader(context.Background(), db, snap, table, 0, "", "id", EqualTo, nil)
 	require.NoError(t, err)
 
-	gr, err := engine.newGroupedRowReader(r, []Selector{&ColSelector{col: "id"}}, []*ColSelector{{col: "id"}})
+	gr, err := engine.newGroupedRowReader(r, []Selector{&ColSelector{col: "id"}}, []ValueExp{&ColSelector{col: "id"}}, nil, nil)
 	require.NoError(t, err)
 
 	cols, err := gr.Columns()
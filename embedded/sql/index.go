@@ -0,0 +1,199 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package sql
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+// IndexColSpec is a column of an index as it's given when the index is created
+type IndexColSpec struct {
+	colName string
+	desc    bool
+}
+
+// Index sorts the rows of the table by the values of its columns, one column after the other. An index is
+// identified by its leading column, which can be read in both directions, thus only the following columns
+// can be sorted in descending order
+type Index struct {
+	table *Table
+	cols  []*Column
+	desc  []bool
+}
+
+func (idx *Index) id() uint64 {
+	return idx.cols[0].id
+}
+
+func (idx *Index) Cols() []*Column {
+	return idx.cols
+}
+
+func (idx *Index) IsDesc(pos int) bool {
+	return idx.desc[pos]
+}
+
+func (idx *Index) IsUnique() bool {
+	_, unique := idx.table.uniques[idx.id()]
+	return unique
+}
+
+// String returns the columns of the index as they're given when it's created
+func (idx *Index) String() string {
+	cols := make([]string, len(idx.cols))
+
+	for i, col := range idx.cols {
+		cols[i] = col.colName
+
+		if idx.desc[i] {
+			cols[i] += " DESC"
+		}
+	}
+
+	return strings.Join(cols, ", ")
+}
+
+func (t *Table) newIndex(specs []*IndexColSpec) (*Index, error) {
+	if len(specs) == 0 {
+		return nil, ErrIllegalArguments
+	}
+
+	idx := &Index{
+		table: t,
+		cols:  make([]*Column, len(specs)),
+		desc:  make([]bool, len(specs)),
+	}
+
+	for i, spec := range specs {
+		col, err := t.GetColumnByName(spec.colName)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, c := range idx.cols[:i] {
+			if c.id == col.id {
+				return nil, fmt.Errorf("%w: %s", ErrDuplicatedColumn, col.colName)
+			}
+		}
+
+		if col.colType == JSONType {
+			return nil, fmt.Errorf("%w: %s", ErrNonIndexableType, col.colName)
+		}
+
+		if spec.desc && i == 0 {
+			return nil, fmt.Errorf("%w: %s", ErrLimitedIndexOrder, col.colName)
+		}
+
+		idx.cols[i] = col
+		idx.desc[i] = spec.desc
+	}
+
+	// the entries holding the rows are prefixed by the leading column of the primary key
+	if idx.id() == t.pk.id {
+		return nil, fmt.Errorf("%w: %s", ErrIndexAlreadyExists, idx.cols[0].colName)
+	}
+
+	_, exists := t.indexes[idx.id()]
+	if exists {
+		return nil, fmt.Errorf("%w: %s", ErrIndexAlreadyExists, idx.cols[0].colName)
+	}
+
+	t.indexes[idx.id()] = idx
+
+	return idx, nil
+}
+
+// encodeKeyValues returns the encoded values of the columns one after the other, values are length-prefixed thus
+// keys made of them are sorted by the first value and then by the following ones. The encoding of values of
+// descending columns is complemented so they're sorted in reverse order
+func encodeKeyValues(cols []*Column, desc []bool, values map[uint64]TypedValue) ([]byte, error) {
+	var enc []byte
+
+	for i, col := range cols {
+		val, ok := values[col.id]
+		if !ok || isNull(val) {
+			return nil, ErrIndexedColumnCanNotBeNull
+		}
+
+		encVal, err := EncodeValue(val, col.colType, asKey)
+		if err != nil {
+			return nil, err
+		}
+
+		if desc != nil && desc[i] {
+			for j := range encVal {
+				encVal[j] = ^encVal[j]
+			}
+		}
+
+		enc = append(enc, encVal...)
+	}
+
+	return enc, nil
+}
+
+// encodePK returns the encoded values of the primary key of the row, the key of the entry holding the row is made of them
+func (t *Table) encodePK(values map[uint64]TypedValue) ([]byte, error) {
+	encPKVal, err := encodeKeyValues(t.pkCols, nil, values)
+	if err == ErrIndexedColumnCanNotBeNull {
+		return nil, ErrPKCanNotBeNull
+	}
+
+	return encPKVal, err
+}
+
+// encodeValues returns the encoded values of the indexed columns of the row
+func (idx *Index) encodeValues(values map[uint64]TypedValue) ([]byte, error) {
+	return encodeKeyValues(idx.cols, idx.desc, values)
+}
+
+// encodedLen returns the length of the encoded values of the index at the beginning of the key
+func (idx *Index) encodedLen(enc []byte) (int, error) {
+	off := 0
+
+	for i := range idx.cols {
+		if len(enc)-off < EncLenLen {
+			return 0, ErrCorruptedData
+		}
+
+		valLen := binary.BigEndian.Uint32(enc[off:])
+		if idx.desc[i] {
+			valLen = ^valLen
+		}
+
+		off += EncLenLen
+
+		if uint32(len(enc)-off) < valLen {
+			return 0, ErrCorruptedData
+		}
+
+		off += int(valLen)
+	}
+
+	return off, nil
+}
+
+// rowKey returns the key of the entry holding the row with the given primary key
+func (e *Engine) rowKey(table *Table, encPKVal []byte) []byte {
+	return e.mapKey(RowPrefix, EncodeID(table.db.id), EncodeID(table.id), EncodeID(table.pk.id), encPKVal)
+}
+
+// indexKey returns the key of the entry of the row within the index
+func (e *Engine) indexKey(idx *Index, encVals, encPKVal []byte) []byte {
+	return e.mapKey(RowPrefix, EncodeID(idx.table.db.id), EncodeID(idx.table.id), EncodeID(idx.id()), encVals, encPKVal)
+}
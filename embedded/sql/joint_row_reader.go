@@ -17,10 +17,20 @@ limitations under the License.
 package sql
 
 import (
+	"context"
+	"strconv"
+
 	"github.com/codenotary/immudb/embedded/store"
 )
 
+// jointRowReader joins the rows of the underlying reader with the rows of the joined tables.
+// Rows without a match in a left joined table are padded with null values. Rows of a right joined table
+// without a match are returned, padded with null values, once the underlying reader is exhausted,
+// primary keys of matched rows are kept in memory to identify them.
+// Joined sub-queries are materialized in memory when the reader gets created and their rows are matched
+// by evaluating the join condition, a row may then be joined with several rows of the same sub-query.
 type jointRowReader struct {
+	ctx        context.Context
 	e          *Engine
 	implicitDB *Database
 
@@ -31,39 +41,123 @@ type jointRowReader struct {
 	joins []*JoinSpec
 
 	params map[string]interface{}
+
+	// rows, columns and join condition of each joined sub-query
+	derived     [][]*Row
+	derivedCols []map[string]*ColDescriptor
+	conds       []ValueExp
+
+	// encoded primary keys (or positions, for sub-queries) of the matched rows of each right joined data source
+	matched []map[string]struct{}
+
+	// joined rows not yet returned
+	pending []*Row
+
+	exhausted bool
+
+	// right join whose rows without a match are being read
+	unmatchedJoin   int
+	unmatchedReader RowReader
+	unmatchedPos    int
 }
 
-func (e *Engine) newJointRowReader(db *Database, snap *store.Snapshot, params map[string]interface{}, rowReader RowReader, joins []*JoinSpec) (*jointRowReader, error) {
-	if db == nil || snap == nil || rowReader == nil || len(joins) == 0 {
+func (e *Engine) newJointRowReader(ctx context.Context, db *Database, snap *store.Snapshot, params map[string]interface{}, rowReader RowReader, joins []*JoinSpec) (*jointRowReader, error) {
+	if ctx == nil || db == nil || snap == nil || rowReader == nil || len(joins) == 0 {
 		return nil, ErrIllegalArguments
 	}
 
-	for _, jspec := range joins {
-		if jspec.joinType != InnerJoin {
+	matched := make([]map[string]struct{}, len(joins))
+	derived := make([][]*Row, len(joins))
+	derivedCols := make([]map[string]*ColDescriptor, len(joins))
+	conds := make([]ValueExp, len(joins))
+
+	for i, jspec := range joins {
+		if jspec.joinType != InnerJoin && jspec.joinType != LeftJoin && jspec.joinType != RightJoin {
 			return nil, ErrUnsupportedJoinType
 		}
 
-		tableRef, ok := jspec.ds.(*TableRef)
-		if !ok {
+		switch ds := jspec.ds.(type) {
+		case *TableRef:
+			{
+				_, err := ds.referencedTable(e, db)
+				if err != nil {
+					return nil, err
+				}
+			}
+		case *SelectStmt, *viewRef:
+			{
+				rows, cols, err := e.materializeRows(ctx, db, snap, params, ds)
+				if err != nil {
+					return nil, err
+				}
+
+				cond, err := jspec.cond.substitute(params)
+				if err != nil {
+					return nil, err
+				}
+
+				derived[i] = rows
+				derivedCols[i] = cols
+				conds[i] = cond
+			}
+		default:
 			return nil, ErrLimitedJoins
 		}
 
-		_, err := tableRef.referencedTable(e, db)
-		if err != nil {
-			return nil, err
+		if jspec.joinType == RightJoin {
+			matched[i] = make(map[string]struct{})
 		}
 	}
 
 	return &jointRowReader{
-		e:          e,
-		implicitDB: db,
-		snap:       snap,
-		params:     params,
-		rowReader:  rowReader,
-		joins:      joins,
+		ctx:           ctx,
+		e:             e,
+		implicitDB:    db,
+		snap:          snap,
+		params:        params,
+		rowReader:     rowReader,
+		joins:         joins,
+		derived:       derived,
+		derivedCols:   derivedCols,
+		conds:         conds,
+		matched:       matched,
+		unmatchedJoin: -1,
 	}, nil
 }
 
+// materializeRows reads every row returned by the sub-query or view
+func (e *Engine) materializeRows(ctx context.Context, db *Database, snap *store.Snapshot, params map[string]interface{}, q DataSource) ([]*Row, map[string]*ColDescriptor, error) {
+	r, err := q.Resolve(ctx, e, db, snap, params, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer r.Close()
+
+	cols, err := r.colsBySelector()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var rows []*Row
+
+	for {
+		row, err := r.Read()
+		if err == store.ErrNoMoreEntries {
+			return rows, cols, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		rows = append(rows, row)
+	}
+}
+
+func (jointr *jointRowReader) isDerived(i int) bool {
+	_, isTableRef := jointr.joins[i].ds.(*TableRef)
+	return !isTableRef
+}
+
 func (jointr *jointRowReader) ImplicitDB() string {
 	return jointr.rowReader.ImplicitDB()
 }
@@ -95,7 +189,15 @@ func (jointr *jointRowReader) colsBySelector() (map[string]*ColDescriptor, error
 		return nil, err
 	}
 
-	for _, jspec := range jointr.joins {
+	for i, jspec := range jointr.joins {
+		if jointr.isDerived(i) {
+			for sel, c := range jointr.derivedCols[i] {
+				colDescriptors[sel] = c
+			}
+
+			continue
+		}
+
 		tableRef := jspec.ds.(*TableRef)
 		table, _ := tableRef.referencedTable(jointr.e, jointr.implicitDB)
 
@@ -109,80 +211,379 @@ func (jointr *jointRowReader) colsBySelector() (map[string]*ColDescriptor, error
 }
 
 func (jointr *jointRowReader) Read() (*Row, error) {
-	for {
-		row, err := jointr.rowReader.Read()
+	for len(jointr.pending) == 0 {
+		row, from, err := jointr.nextRow()
+		if err != nil {
+			return nil, err
+		}
+
+		jointr.pending, err = jointr.join(row, from)
 		if err != nil {
 			return nil, err
 		}
+	}
 
-		unsolvedFK := false
+	row := jointr.pending[0]
+	jointr.pending = jointr.pending[1:]
 
-		for _, jspec := range jointr.joins {
-			tableRef := jspec.ds.(*TableRef)
-			table, err := tableRef.referencedTable(jointr.e, jointr.implicitDB)
-			if err != nil {
-				return nil, err
+	return row, nil
+}
+
+// nextRow returns the next row to be joined and the index of the first join to be applied to it.
+// Once the underlying reader is exhausted, rows of right joined tables without a match are returned
+func (jointr *jointRowReader) nextRow() (*Row, int, error) {
+	if !jointr.exhausted {
+		row, err := jointr.rowReader.Read()
+		if err != store.ErrNoMoreEntries {
+			return row, 0, err
+		}
+
+		jointr.exhausted = true
+	}
+
+	for {
+		if jointr.unmatchedReader == nil && (jointr.unmatchedJoin < 0 || !jointr.isDerived(jointr.unmatchedJoin) ||
+			jointr.unmatchedPos == len(jointr.derived[jointr.unmatchedJoin])) {
+
+			i := jointr.unmatchedJoin + 1
+			for i < len(jointr.joins) && jointr.joins[i].joinType != RightJoin {
+				i++
 			}
 
-			fkSel, err := jspec.cond.jointColumnTo(table.pk, tableRef.Alias())
-			if err != nil {
-				return nil, err
+			if i == len(jointr.joins) {
+				return nil, 0, store.ErrNoMoreEntries
 			}
 
-			fkVal, ok := row.Values[EncodeSelector(fkSel.resolve(jointr.rowReader.ImplicitDB(), jointr.rowReader.ImplicitTable()))]
-			if !ok {
-				return nil, ErrInvalidJointColumn
+			jointr.unmatchedJoin = i
+			jointr.unmatchedPos = 0
+
+			if jointr.isDerived(i) {
+				continue
 			}
 
-			fkEncVal, err := EncodeValue(fkVal, table.pk.colType, asKey)
+			r, err := jointr.joins[i].ds.Resolve(jointr.ctx, jointr.e, jointr.implicitDB, jointr.snap, jointr.params, nil)
 			if err != nil {
-				return nil, err
+				return nil, 0, err
 			}
 
-			pkOrd := &OrdCol{
-				sel: &ColSelector{
-					db:    table.db.name,
-					table: table.name,
-					col:   table.pk.colName,
-				},
-				initKeyVal:    fkEncVal,
-				useInitKeyVal: true,
+			jointr.unmatchedReader = r
+		}
+
+		jrow, key, err := jointr.nextUnmatchedCandidate()
+		if err == store.ErrNoMoreEntries {
+			continue
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+
+		_, matched := jointr.matched[jointr.unmatchedJoin][key]
+		if matched {
+			continue
+		}
+
+		row := &Row{Values: make(map[string]TypedValue)}
+
+		cols, err := jointr.rowReader.colsBySelector()
+		if err != nil {
+			return nil, 0, err
+		}
+
+		for sel, c := range cols {
+			row.Values[sel] = &NullValue{t: c.Type}
+		}
+
+		for i := range jointr.joins[:jointr.unmatchedJoin] {
+			err = jointr.addNullValues(row, i)
+			if err != nil {
+				return nil, 0, err
 			}
+		}
+
+		for c, v := range jrow.Values {
+			row.Values[c] = v
+		}
+
+		return row, jointr.unmatchedJoin + 1, nil
+	}
+}
+
+// nextUnmatchedCandidate returns the next row of the right join being read and the key used to identify
+// whether it was matched, store.ErrNoMoreEntries is returned once every row was read
+func (jointr *jointRowReader) nextUnmatchedCandidate() (*Row, string, error) {
+	if jointr.isDerived(jointr.unmatchedJoin) {
+		pos := jointr.unmatchedPos
+		if pos == len(jointr.derived[jointr.unmatchedJoin]) {
+			return nil, "", store.ErrNoMoreEntries
+		}
+
+		jointr.unmatchedPos++
+
+		return jointr.derived[jointr.unmatchedJoin][pos], strconv.Itoa(pos), nil
+	}
+
+	jrow, err := jointr.unmatchedReader.Read()
+	if err == store.ErrNoMoreEntries {
+		err = jointr.unmatchedReader.Close()
+		jointr.unmatchedReader = nil
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		return nil, "", store.ErrNoMoreEntries
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	tableRef := jointr.joins[jointr.unmatchedJoin].ds.(*TableRef)
+	table, err := tableRef.referencedTable(jointr.e, jointr.implicitDB)
+	if err != nil {
+		return nil, "", err
+	}
+
+	pkVals := make(map[uint64]TypedValue, len(table.pkCols))
+
+	for _, col := range table.pkCols {
+		pkVals[col.id] = jrow.Values[EncodeSelector("", table.db.name, tableRef.Alias(), col.colName)]
+	}
+
+	pkEncVal, err := table.encodePK(pkVals)
+	if err != nil {
+		return nil, "", err
+	}
 
-			jr, err := jspec.ds.Resolve(jointr.e, jointr.implicitDB, jointr.snap, jointr.params, pkOrd)
+	return jrow, string(pkEncVal), nil
+}
+
+// join adds the values of the joined rows, starting from the join at index from, and returns the resulting rows.
+// A row is discarded due to an inner or right join without a match and it's repeated for each matching row of a sub-query
+func (jointr *jointRowReader) join(row *Row, from int) ([]*Row, error) {
+	rows := []*Row{row}
+
+	for i := from; i < len(jointr.joins); i++ {
+		jspec := jointr.joins[i]
+
+		var joined []*Row
+
+		for _, r := range rows {
+			jrows, err := jointr.matches(r, i)
 			if err != nil {
 				return nil, err
 			}
 
-			jrow, err := jr.Read()
-			if err == store.ErrNoMoreEntries {
-				unsolvedFK = true
-				jr.Close()
-				break
+			if len(jrows) == 0 {
+				if jspec.joinType != LeftJoin {
+					continue
+				}
+
+				err = jointr.addNullValues(r, i)
+				if err != nil {
+					return nil, err
+				}
+
+				joined = append(joined, r)
+
+				continue
 			}
-			if err != nil {
-				jr.Close()
-				return nil, err
+
+			for _, jrow := range jrows {
+				jr := r
+
+				if len(jrows) > 1 {
+					jr = &Row{Values: make(map[string]TypedValue, len(r.Values)+len(jrow.Values))}
+
+					for c, v := range r.Values {
+						jr.Values[c] = v
+					}
+				}
+
+				// Note: by adding values this way joins behave as nested i.e. following joins will be able to seek values
+				// from previously resolved ones.
+				for c, v := range jrow.Values {
+					jr.Values[c] = v
+				}
+
+				joined = append(joined, jr)
+			}
+		}
+
+		rows = joined
+	}
+
+	return rows, nil
+}
+
+// matches returns the rows of the i-th joined data source matching the row
+func (jointr *jointRowReader) matches(row *Row, i int) ([]*Row, error) {
+	jspec := jointr.joins[i]
+
+	if jointr.isDerived(i) {
+		var jrows []*Row
+
+		for pos, drow := range jointr.derived[i] {
+			merged := &Row{Values: make(map[string]TypedValue, len(row.Values)+len(drow.Values))}
+
+			for c, v := range row.Values {
+				merged.Values[c] = v
 			}
 
-			// Note: by adding values this way joins behave as nested i.e. following joins will be able to seek values
-			// from previously resolved ones.
-			for c, v := range jrow.Values {
-				row.Values[c] = v
+			for c, v := range drow.Values {
+				merged.Values[c] = v
 			}
 
-			err = jr.Close()
+			r, err := jointr.conds[i].reduce(jointr.e.catalog, merged, jointr.rowReader.ImplicitDB(), jointr.rowReader.ImplicitTable())
 			if err != nil {
 				return nil, err
 			}
+
+			// rows are filtered out when the condition is unknown
+			if isUnknown(r) {
+				continue
+			}
+
+			satisfies, boolExp := r.(*Bool)
+			if !boolExp {
+				return nil, ErrInvalidCondition
+			}
+
+			if !satisfies.val {
+				continue
+			}
+
+			if jspec.joinType == RightJoin {
+				jointr.matched[i][strconv.Itoa(pos)] = struct{}{}
+			}
+
+			jrows = append(jrows, drow)
+		}
+
+		return jrows, nil
+	}
+
+	tableRef := jspec.ds.(*TableRef)
+	table, err := tableRef.referencedTable(jointr.e, jointr.implicitDB)
+	if err != nil {
+		return nil, err
+	}
+
+	fkSels, err := jointPKColumns(jspec.cond, table, tableRef.Alias())
+	if err != nil {
+		return nil, err
+	}
+
+	fkVals := make(map[uint64]TypedValue, len(fkSels))
+
+	for i, fkSel := range fkSels {
+		fkVal, ok := row.Values[EncodeSelector(fkSel.resolve(jointr.rowReader.ImplicitDB(), jointr.rowReader.ImplicitTable()))]
+		if !ok {
+			return nil, ErrInvalidJointColumn
 		}
 
-		if !unsolvedFK {
-			return row, nil
+		// null values do not match any row
+		if isNull(fkVal) {
+			return nil, nil
 		}
+
+		fkVals[table.pkCols[i].id] = fkVal
 	}
+
+	fkEncVal, err := table.encodePK(fkVals)
+	if err != nil {
+		return nil, err
+	}
+
+	jrow, err := jointr.lookup(jspec, table, fkEncVal)
+	if err != nil || jrow == nil {
+		return nil, err
+	}
+
+	if jspec.joinType == RightJoin {
+		jointr.matched[i][string(fkEncVal)] = struct{}{}
+	}
+
+	return []*Row{jrow}, nil
+}
+
+// jointPKColumns returns the columns the condition of the join matches with each one of the columns of the primary key of the table
+func jointPKColumns(cond ValueExp, table *Table, tableAlias string) ([]*ColSelector, error) {
+	// rows are looked up by their primary key thus the condition must consist of nothing else
+	if len(conjunctions(cond)) != len(table.pkCols) {
+		return nil, ErrJointColumnNotFound
+	}
+
+	sels := make([]*ColSelector, len(table.pkCols))
+
+	for i, col := range table.pkCols {
+		sel, err := cond.jointColumnTo(col, tableAlias)
+		if err != nil {
+			return nil, err
+		}
+
+		sels[i] = sel
+	}
+
+	return sels, nil
+}
+
+// lookup returns the row of the joined table with the given primary key, nil if there is no such row
+func (jointr *jointRowReader) lookup(jspec *JoinSpec, table *Table, pkEncVal []byte) (*Row, error) {
+	pkOrd := &OrdCol{
+		sel: &ColSelector{
+			db:    table.db.name,
+			table: table.name,
+			col:   table.pk.colName,
+		},
+		initKeyVal:    pkEncVal,
+		useInitKeyVal: true,
+	}
+
+	jr, err := jspec.ds.Resolve(jointr.ctx, jointr.e, jointr.implicitDB, jointr.snap, jointr.params, pkOrd)
+	if err != nil {
+		return nil, err
+	}
+
+	jrow, err := jr.Read()
+	if err == store.ErrNoMoreEntries {
+		return nil, jr.Close()
+	}
+	if err != nil {
+		jr.Close()
+		return nil, err
+	}
+
+	return jrow, jr.Close()
+}
+
+// addNullValues pads the row with null values for the columns of the i-th joined data source
+func (jointr *jointRowReader) addNullValues(row *Row, i int) error {
+	if jointr.isDerived(i) {
+		for sel, c := range jointr.derivedCols[i] {
+			row.Values[sel] = &NullValue{t: c.Type}
+		}
+
+		return nil
+	}
+
+	tableRef := jointr.joins[i].ds.(*TableRef)
+
+	table, err := tableRef.referencedTable(jointr.e, jointr.implicitDB)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range table.ColsByID() {
+		row.Values[EncodeSelector("", table.db.name, tableRef.Alias(), c.colName)] = &NullValue{t: c.colType}
+	}
+
+	return nil
 }
 
 func (jointr *jointRowReader) Close() error {
+	if jointr.unmatchedReader != nil {
+		jointr.unmatchedReader.Close()
+	}
+
 	return jointr.rowReader.Close()
 }
@@ -17,6 +17,8 @@ limitations under the License.
 package sql
 
 import (
+	"context"
+	"errors"
 	"os"
 	"testing"
 
@@ -36,34 +38,41 @@ func TestJointRowReader(t *testing.T) {
 	engine, err := NewEngine(catalogStore, dataStore, prefix)
 	require.NoError(t, err)
 
-	_, err = engine.newJointRowReader(nil, nil, nil, nil, nil)
+	_, err = engine.newJointRowReader(context.Background(), nil, nil, nil, nil, nil)
 	require.Equal(t, ErrIllegalArguments, err)
 
 	db, err := engine.catalog.newDatabase("db1")
 	require.NoError(t, err)
 
-	table, err := db.newTable("table1", []*ColSpec{{colName: "id", colType: IntegerType}}, "id")
+	table, err := db.newTable("table1", []*ColSpec{{colName: "id", colType: IntegerType}}, nil, []string{"id"})
 	require.NoError(t, err)
 
 	snap, err := engine.Snapshot()
 	require.NoError(t, err)
 
-	r, err := engine.newRawRowReader(db, snap, table, 0, "", "id", EqualTo, nil)
+	r, err := engine.newRawRowReader(context.Background(), db, snap, table, 0, "", "id", EqualTo, nil)
 	require.NoError(t, err)
 
-	_, err = engine.newJointRowReader(db, snap, nil, r, []*JoinSpec{{joinType: LeftJoin}})
+	_, err = engine.newJointRowReader(context.Background(), db, snap, nil, r, []*JoinSpec{{joinType: RightJoin + 1}})
 	require.Equal(t, ErrUnsupportedJoinType, err)
 
-	_, err = engine.newJointRowReader(db, snap, nil, r, []*JoinSpec{{joinType: InnerJoin, ds: &SelectStmt{}}})
-	require.Equal(t, ErrLimitedJoins, err)
+	_, err = engine.newJointRowReader(context.Background(), db, snap, nil, r, []*JoinSpec{{joinType: InnerJoin, ds: &SelectStmt{ds: &TableRef{table: "table2"}}}})
+	require.True(t, errors.Is(err, ErrTableDoesNotExist))
 
-	_, err = engine.newJointRowReader(db, snap, nil, r, []*JoinSpec{{joinType: InnerJoin, ds: &TableRef{table: "table2"}}})
-	require.Equal(t, ErrTableDoesNotExist, err)
+	_, err = engine.newJointRowReader(context.Background(), db, snap, nil, r, []*JoinSpec{{joinType: InnerJoin, ds: &TableRef{table: "table2"}}})
+	require.True(t, errors.Is(err, ErrTableDoesNotExist))
 
-	jr, err := engine.newJointRowReader(db, snap, nil, r, []*JoinSpec{{joinType: InnerJoin, ds: &TableRef{table: "table1"}}})
+	jr, err := engine.newJointRowReader(context.Background(), db, snap, nil, r, []*JoinSpec{{joinType: InnerJoin, ds: &TableRef{table: "table1"}}})
 	require.NoError(t, err)
 
 	cols, err := jr.Columns()
 	require.NoError(t, err)
 	require.Len(t, cols, 1)
+
+	jr, err = engine.newJointRowReader(context.Background(), db, snap, nil, r, []*JoinSpec{{joinType: InnerJoin, ds: &SelectStmt{ds: &TableRef{table: "table1", as: "t"}}, cond: &Bool{val: true}}})
+	require.NoError(t, err)
+
+	cols, err = jr.Columns()
+	require.NoError(t, err)
+	require.Len(t, cols, 2)
 }
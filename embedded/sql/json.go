@@ -0,0 +1,379 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// JSON is a validated JSON document. Documents are kept in a canonical text form,
+// without insignificant whitespaces and with the keys of objects sorted
+type JSON struct {
+	val string
+}
+
+func parseJSON(s string) (*JSON, error) {
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+
+	var doc interface{}
+
+	err := dec.Decode(&doc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidJSON, err)
+	}
+
+	// a single document is accepted
+	_, err = dec.Token()
+	if err != io.EOF {
+		return nil, ErrInvalidJSON
+	}
+
+	return newJSON(doc)
+}
+
+func newJSON(doc interface{}) (*JSON, error) {
+	var b bytes.Buffer
+
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+
+	err := enc.Encode(doc)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidJSON, err)
+	}
+
+	return &JSON{val: strings.TrimSuffix(b.String(), "\n")}, nil
+}
+
+func (v *JSON) doc() (interface{}, error) {
+	dec := json.NewDecoder(strings.NewReader(v.val))
+	dec.UseNumber()
+
+	var doc interface{}
+
+	err := dec.Decode(&doc)
+	if err != nil {
+		return nil, ErrCorruptedData
+	}
+
+	return doc, nil
+}
+
+func (v *JSON) Type() SQLValueType {
+	return JSONType
+}
+
+func (v *JSON) jointColumnTo(col *Column, tableAlias string) (*ColSelector, error) {
+	return nil, ErrJointColumnNotFound
+}
+
+func (v *JSON) substitute(params map[string]interface{}) (ValueExp, error) {
+	return v, nil
+}
+
+func (v *JSON) reduce(catalog *Catalog, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
+	return v, nil
+}
+
+func (v *JSON) Value() interface{} {
+	return v.val
+}
+
+// Compare compares documents by their canonical form, while scalar documents are compared
+// with values of other types as the corresponding value e.g. '{"age": 30}'->'age' = 30
+func (v *JSON) Compare(val TypedValue) (int, error) {
+	_, isNull := val.(*NullValue)
+	if isNull {
+		return 1, nil
+	}
+
+	if val.Type() == JSONType {
+		return bytes.Compare([]byte(v.val), []byte(val.Value().(string))), nil
+	}
+
+	doc, err := v.doc()
+	if err != nil {
+		return 0, err
+	}
+
+	scalar := jsonScalar(doc)
+	if scalar == nil {
+		return 0, ErrNotComparableValues
+	}
+
+	return scalar.Compare(val)
+}
+
+// jsonScalar returns the value corresponding to a scalar document,
+// nil is returned for objects and arrays
+func jsonScalar(doc interface{}) TypedValue {
+	switch d := doc.(type) {
+	case nil:
+		return &NullValue{}
+	case bool:
+		return &Bool{val: d}
+	case string:
+		return &Varchar{val: d}
+	case json.Number:
+		{
+			n, err := strconv.ParseUint(d.String(), 10, 64)
+			if err == nil {
+				return &Number{val: n}
+			}
+
+			f, err := d.Float64()
+			if err == nil {
+				return &Float{val: f}
+			}
+		}
+	}
+
+	return nil
+}
+
+// JSONSelector selects the value at a path within a JSON column e.g. doc->'address'->>'city' or JSON_VALUE(doc, '$.address.city').
+// Values are selected as JSON documents, or as text when the last step is taken with the ->> operator or with JSON_VALUE.
+// NULL is selected when there is no value at the path
+type JSONSelector struct {
+	col *ColSelector
+	// keys of objects (string) or positions within arrays (uint64)
+	path []interface{}
+	// the selected value is returned as text
+	asText bool
+	// only scalar values are selected as text, as done by JSON_VALUE
+	scalarsOnly bool
+	as          string
+}
+
+// jsonSelectorFrom returns the selector extending sel with a step of the path
+func jsonSelectorFrom(sel Selector, step interface{}, asText bool) (*JSONSelector, error) {
+	switch s := sel.(type) {
+	case *ColSelector:
+		{
+			return &JSONSelector{col: s, path: []interface{}{step}, asText: asText}, nil
+		}
+	case *JSONSelector:
+		{
+			if s.asText {
+				return nil, fmt.Errorf("%w: values can not be extracted from text", ErrInvalidJSONPath)
+			}
+
+			path := append(append([]interface{}{}, s.path...), step)
+
+			return &JSONSelector{col: s.col, path: path, asText: asText}, nil
+		}
+	}
+
+	return nil, ErrExpectingJSONColumn
+}
+
+// jsonValueSelectorFrom returns the selector of JSON_VALUE(col, path), which extracts scalar values as text
+func jsonValueSelectorFrom(params []ValueExp) (*JSONSelector, error) {
+	if len(params) != 2 {
+		return nil, fmt.Errorf("%w: JSON_VALUE expects a column and a path", ErrInvalidFunctionParams)
+	}
+
+	col, isCol := params[0].(*ColSelector)
+	if !isCol {
+		return nil, ErrExpectingJSONColumn
+	}
+
+	p, isVarchar := params[1].(*Varchar)
+	if !isVarchar {
+		return nil, fmt.Errorf("%w: JSON_VALUE expects a column and a path", ErrInvalidFunctionParams)
+	}
+
+	path, err := parseJSONPath(p.val)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JSONSelector{col: col, path: path, asText: true, scalarsOnly: true}, nil
+}
+
+// parseJSONPath parses paths such as $.address.city, $.tags[0] or $."first name"
+func parseJSONPath(s string) ([]interface{}, error) {
+	if !strings.HasPrefix(s, "$") {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidJSONPath, s)
+	}
+
+	var path []interface{}
+
+	for i := 1; i < len(s); {
+		switch s[i] {
+		case '.':
+			{
+				i++
+
+				if i < len(s) && s[i] == '"' {
+					end := strings.IndexByte(s[i+1:], '"')
+					if end < 0 {
+						return nil, fmt.Errorf("%w: %s", ErrInvalidJSONPath, s)
+					}
+
+					path = append(path, s[i+1:i+1+end])
+					i += end + 2
+					continue
+				}
+
+				start := i
+				for i < len(s) && (isLetter(s[i]) || isNumber(s[i])) {
+					i++
+				}
+
+				if start == i {
+					return nil, fmt.Errorf("%w: %s", ErrInvalidJSONPath, s)
+				}
+
+				path = append(path, s[start:i])
+			}
+		case '[':
+			{
+				end := strings.IndexByte(s[i:], ']')
+				if end < 0 {
+					return nil, fmt.Errorf("%w: %s", ErrInvalidJSONPath, s)
+				}
+
+				pos, err := strconv.ParseUint(s[i+1:i+end], 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("%w: %s", ErrInvalidJSONPath, s)
+				}
+
+				path = append(path, pos)
+				i += end + 1
+			}
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrInvalidJSONPath, s)
+		}
+	}
+
+	return path, nil
+}
+
+func (sel *JSONSelector) resolve(implicitDB, implicitTable string) (aggFn, db, table, col string) {
+	return sel.col.resolve(implicitDB, implicitTable)
+}
+
+func (sel *JSONSelector) alias() string {
+	return sel.as
+}
+
+func (sel *JSONSelector) setAlias(alias string) {
+	sel.as = alias
+}
+
+// valType returns the type of the selected values
+func (sel *JSONSelector) valType() SQLValueType {
+	if sel.asText {
+		return VarcharType
+	}
+
+	return JSONType
+}
+
+func (sel *JSONSelector) jointColumnTo(col *Column, tableAlias string) (*ColSelector, error) {
+	return nil, ErrJointColumnNotFound
+}
+
+func (sel *JSONSelector) substitute(params map[string]interface{}) (ValueExp, error) {
+	return sel, nil
+}
+
+func (sel *JSONSelector) reduce(catalog *Catalog, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
+	v, err := sel.col.reduce(catalog, row, implicitDB, implicitTable)
+	if err != nil {
+		return nil, err
+	}
+
+	return sel.extract(v)
+}
+
+// extract returns the value at the path of the selector within the JSON value
+func (sel *JSONSelector) extract(v TypedValue) (TypedValue, error) {
+	_, isNull := v.(*NullValue)
+	if isNull {
+		return &NullValue{}, nil
+	}
+
+	jv, isJSON := v.(*JSON)
+	if !isJSON {
+		return nil, ErrExpectingJSONColumn
+	}
+
+	doc, err := jv.doc()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, step := range sel.path {
+		switch s := step.(type) {
+		case string:
+			{
+				obj, isObj := doc.(map[string]interface{})
+				if !isObj {
+					return &NullValue{}, nil
+				}
+
+				field, exists := obj[s]
+				if !exists {
+					return &NullValue{}, nil
+				}
+
+				doc = field
+			}
+		case uint64:
+			{
+				arr, isArr := doc.([]interface{})
+				if !isArr || s >= uint64(len(arr)) {
+					return &NullValue{}, nil
+				}
+
+				doc = arr[s]
+			}
+		}
+	}
+
+	if !sel.asText {
+		return newJSON(doc)
+	}
+
+	switch d := doc.(type) {
+	case nil:
+		return &NullValue{}, nil
+	case string:
+		return &Varchar{val: d}, nil
+	case map[string]interface{}, []interface{}:
+		{
+			if sel.scalarsOnly {
+				return &NullValue{}, nil
+			}
+		}
+	}
+
+	jv, err = newJSON(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Varchar{val: jv.val}, nil
+}
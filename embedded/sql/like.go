@@ -0,0 +1,149 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sql
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const likeEscape = '\\'
+
+type likeTokenKind int
+
+const (
+	likeChar likeTokenKind = iota
+	likeAnyChar
+	likeAnySeq
+)
+
+type likeToken struct {
+	kind likeTokenKind
+	r    rune
+}
+
+func parseLikePattern(pattern string, caseInsensitive bool) ([]likeToken, error) {
+	var tokens []likeToken
+
+	escaped := false
+
+	for _, r := range pattern {
+		if escaped {
+			tokens = append(tokens, likeToken{kind: likeChar, r: foldRune(r, caseInsensitive)})
+			escaped = false
+			continue
+		}
+
+		switch r {
+		case likeEscape:
+			escaped = true
+		case '%':
+			{
+				// consecutive wildcards are equivalent to a single one
+				if len(tokens) == 0 || tokens[len(tokens)-1].kind != likeAnySeq {
+					tokens = append(tokens, likeToken{kind: likeAnySeq})
+				}
+			}
+		case '_':
+			tokens = append(tokens, likeToken{kind: likeAnyChar})
+		default:
+			tokens = append(tokens, likeToken{kind: likeChar, r: foldRune(r, caseInsensitive)})
+		}
+	}
+
+	if escaped {
+		return nil, fmt.Errorf("%w: pattern can not end with the escape character", ErrInvalidPattern)
+	}
+
+	return tokens, nil
+}
+
+func foldRune(r rune, caseInsensitive bool) rune {
+	if caseInsensitive {
+		return unicode.ToLower(r)
+	}
+	return r
+}
+
+// likeMatch returns true if the whole value matches the pattern
+func likeMatch(pattern, val string, caseInsensitive bool) (bool, error) {
+	tokens, err := parseLikePattern(pattern, caseInsensitive)
+	if err != nil {
+		return false, err
+	}
+
+	s := []rune(val)
+
+	// the last sequence wildcard is backtracked when the rest of the pattern does not match,
+	// previous ones can not lead to a match if that one does not
+	p, i := 0, 0
+	seqP, seqI := -1, 0
+
+	for i < len(s) {
+		if p < len(tokens) {
+			t := tokens[p]
+
+			if t.kind == likeAnySeq {
+				seqP, seqI = p, i
+				p++
+				continue
+			}
+
+			if t.kind == likeAnyChar || t.r == foldRune(s[i], caseInsensitive) {
+				p++
+				i++
+				continue
+			}
+		}
+
+		if seqP < 0 {
+			return false, nil
+		}
+
+		// the sequence wildcard takes one more character
+		seqI++
+		p, i = seqP+1, seqI
+	}
+
+	for p < len(tokens) && tokens[p].kind == likeAnySeq {
+		p++
+	}
+
+	return p == len(tokens), nil
+}
+
+// likePrefix returns the characters every value matching the pattern starts with,
+// exact is true when the pattern has no wildcards i.e. matching values are equal to the prefix
+func likePrefix(pattern string) (prefix string, exact bool, err error) {
+	tokens, err := parseLikePattern(pattern, false)
+	if err != nil {
+		return "", false, err
+	}
+
+	var b strings.Builder
+
+	for _, t := range tokens {
+		if t.kind != likeChar {
+			return b.String(), false, nil
+		}
+
+		b.WriteRune(t.r)
+	}
+
+	return b.String(), true, nil
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sql
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLikeMatch(t *testing.T) {
+	testCases := []struct {
+		pattern         string
+		val             string
+		caseInsensitive bool
+		matches         bool
+	}{
+		{pattern: "", val: "", matches: true},
+		{pattern: "", val: "a", matches: false},
+		{pattern: "%", val: "", matches: true},
+		{pattern: "%", val: "abc", matches: true},
+		{pattern: "abc", val: "abc", matches: true},
+		{pattern: "abc", val: "abcd", matches: false},
+		{pattern: "abc", val: "ABC", matches: false},
+		{pattern: "abc", val: "ABC", caseInsensitive: true, matches: true},
+		{pattern: "ab%", val: "ab", matches: true},
+		{pattern: "ab%", val: "abzzz", matches: true},
+		{pattern: "ab%", val: "zab", matches: false},
+		{pattern: "%ab", val: "zzab", matches: true},
+		{pattern: "%ab", val: "abz", matches: false},
+		{pattern: "%b%", val: "abc", matches: true},
+		{pattern: "a%c%e", val: "abcdcde", matches: true},
+		{pattern: "a%c%e", val: "abcdcd", matches: false},
+		{pattern: "a%%%b", val: "ab", matches: true},
+		{pattern: "_", val: "a", matches: true},
+		{pattern: "_", val: "", matches: false},
+		{pattern: "_", val: "ñ", matches: true},
+		{pattern: "a_c", val: "abc", matches: true},
+		{pattern: "a_c", val: "ac", matches: false},
+		{pattern: "%_%", val: "a", matches: true},
+		{pattern: "100\\%", val: "100%", matches: true},
+		{pattern: "100\\%", val: "1000", matches: false},
+		{pattern: "a\\_c", val: "abc", matches: false},
+		{pattern: "a\\_c", val: "a_c", matches: true},
+		{pattern: "a\\\\c", val: "a\\c", matches: true},
+		{pattern: "ÑA%", val: "ñandú", caseInsensitive: true, matches: true},
+		{pattern: "title\n%", val: "title\nline", matches: true},
+	}
+
+	for _, tc := range testCases {
+		matches, err := likeMatch(tc.pattern, tc.val, tc.caseInsensitive)
+		require.NoError(t, err)
+		require.Equal(t, tc.matches, matches, "'%s' LIKE '%s'", tc.val, tc.pattern)
+	}
+
+	_, err := likeMatch("abc\\", "abc", false)
+	require.True(t, errors.Is(err, ErrInvalidPattern))
+}
+
+func TestLikePrefix(t *testing.T) {
+	testCases := []struct {
+		pattern string
+		prefix  string
+		exact   bool
+	}{
+		{pattern: "", prefix: "", exact: true},
+		{pattern: "abc", prefix: "abc", exact: true},
+		{pattern: "abc%", prefix: "abc", exact: false},
+		{pattern: "ab_d", prefix: "ab", exact: false},
+		{pattern: "%abc", prefix: "", exact: false},
+		{pattern: "a\\%b%", prefix: "a%b", exact: false},
+	}
+
+	for _, tc := range testCases {
+		prefix, exact, err := likePrefix(tc.pattern)
+		require.NoError(t, err)
+		require.Equal(t, tc.prefix, prefix, tc.pattern)
+		require.Equal(t, tc.exact, exact, tc.pattern)
+	}
+
+	_, _, err := likePrefix("a%\\")
+	require.True(t, errors.Is(err, ErrInvalidPattern))
+}
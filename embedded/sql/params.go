@@ -0,0 +1,523 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// paramScope holds the tables whose columns can be referenced by the expressions of a statement, by their alias
+type paramScope struct {
+	e             *Engine
+	implicitDB    *Database
+	tables        map[string]*Table
+	implicitTable string
+	types         map[string]SQLValueType
+}
+
+// InferParameters returns the type of each parameter of the statements, as it's told by the column or value
+// the parameter is compared with, assigned to or combined with. Parameters whose type can not be told are
+// typed as VARCHAR
+func (e *Engine) InferParameters(sql string) (map[string]SQLValueType, error) {
+	stmts, err := Parse(strings.NewReader(sql))
+	if err != nil {
+		return nil, err
+	}
+
+	return e.InferParametersPreparedStmts(stmts)
+}
+
+// InferParametersPreparedStmts is the same as InferParameters but for already parsed statements
+func (e *Engine) InferParametersPreparedStmts(stmts []SQLStmt) (map[string]SQLValueType, error) {
+	implicitDB, err := e.DatabaseInUse()
+	if err != nil {
+		return nil, err
+	}
+
+	types := make(map[string]SQLValueType)
+
+	for _, stmt := range stmts {
+		err := inferStmtParams(e, implicitDB, stmt, types)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for id, t := range types {
+		if t == "" {
+			types[id] = VarcharType
+		}
+	}
+
+	return types, nil
+}
+
+func inferStmtParams(e *Engine, implicitDB *Database, stmt SQLStmt, types map[string]SQLValueType) error {
+	switch st := stmt.(type) {
+	case *TxStmt:
+		{
+			for _, s := range st.stmts {
+				err := inferStmtParams(e, implicitDB, s, types)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	case *SelectStmt:
+		{
+			scope := &paramScope{e: e, implicitDB: implicitDB, tables: make(map[string]*Table), types: types}
+			return scope.inferSelect(st)
+		}
+	case *UpsertIntoStmt:
+		{
+			scope := newTableScope(e, implicitDB, st.tableRef, types)
+
+			for _, row := range st.rows {
+				for i, val := range row.Values {
+					if i >= len(st.cols) {
+						break
+					}
+
+					err := scope.requireType(val, scope.colType("", st.cols[i]))
+					if err != nil {
+						return err
+					}
+				}
+			}
+		}
+	case *UpdateStmt:
+		{
+			scope := newTableScope(e, implicitDB, st.tableRef, types)
+
+			for _, u := range st.updates {
+				err := scope.requireType(u.val, scope.colType("", u.col))
+				if err != nil {
+					return err
+				}
+			}
+
+			return scope.requireType(st.where, BooleanType)
+		}
+	case *DeleteFromStmt:
+		{
+			scope := newTableScope(e, implicitDB, st.tableRef, types)
+			return scope.requireType(st.where, BooleanType)
+		}
+	}
+
+	return nil
+}
+
+func newTableScope(e *Engine, implicitDB *Database, tableRef *TableRef, types map[string]SQLValueType) *paramScope {
+	scope := &paramScope{e: e, implicitDB: implicitDB, tables: make(map[string]*Table), types: types}
+	scope.addDataSource(tableRef)
+
+	return scope
+}
+
+// addDataSource makes the columns of the referenced table available, columns of derived tables remain untyped
+func (s *paramScope) addDataSource(ds DataSource) {
+	if s.implicitTable == "" {
+		s.implicitTable = ds.Alias()
+	}
+
+	tableRef, ok := ds.(*TableRef)
+	if !ok {
+		return
+	}
+
+	table, err := tableRef.referencedTable(s.e, s.implicitDB)
+	if err == nil {
+		s.tables[tableRef.Alias()] = table
+	}
+}
+
+func (s *paramScope) inferSelect(stmt *SelectStmt) error {
+	q, isQuery := stmt.ds.(*SelectStmt)
+	if isQuery {
+		err := s.subScope().inferSelect(q)
+		if err != nil {
+			return err
+		}
+	}
+
+	s.addDataSource(stmt.ds)
+
+	for _, j := range stmt.joins {
+		q, isQuery := j.ds.(*SelectStmt)
+		if isQuery {
+			err := s.subScope().inferSelect(q)
+			if err != nil {
+				return err
+			}
+		}
+
+		s.addDataSource(j.ds)
+	}
+
+	for _, j := range stmt.joins {
+		err := s.requireType(j.cond, BooleanType)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, sel := range stmt.selectors {
+		_, err := s.inferType(sel)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := s.requireType(stmt.where, BooleanType)
+	if err != nil {
+		return err
+	}
+
+	for _, exp := range stmt.groupBy {
+		_, err := s.inferType(exp)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = s.requireType(stmt.having, BooleanType)
+	if err != nil {
+		return err
+	}
+
+	for _, col := range stmt.orderBy {
+		_, err := s.inferType(col.exp)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// subScope returns the scope of a subquery, where the tables of the enclosing query remain available
+func (s *paramScope) subScope() *paramScope {
+	tables := make(map[string]*Table, len(s.tables))
+	for alias, t := range s.tables {
+		tables[alias] = t
+	}
+
+	return &paramScope{e: s.e, implicitDB: s.implicitDB, tables: tables, types: s.types}
+}
+
+// colType returns the type of the column, empty if it can not be told
+func (s *paramScope) colType(table, col string) SQLValueType {
+	if table == "" {
+		table = s.implicitTable
+	}
+
+	t, ok := s.tables[table]
+	if !ok {
+		return ""
+	}
+
+	c, err := t.GetColumnByName(col)
+	if err != nil {
+		return ""
+	}
+
+	return c.colType
+}
+
+// requireType sets the type of the expression when it's a parameter, otherwise the parameters within it are inferred
+func (s *paramScope) requireType(exp ValueExp, t SQLValueType) error {
+	if exp == nil {
+		return nil
+	}
+
+	p, isParam := exp.(*Param)
+	if !isParam {
+		_, err := s.inferType(exp)
+		return err
+	}
+
+	current, ok := s.types[p.id]
+	if ok && current != "" && t != "" && current != t {
+		return fmt.Errorf("%w: parameter '%s' is used as %s and as %s", ErrInferredMultipleTypes, p.id, current, t)
+	}
+
+	if !ok || current == "" {
+		s.types[p.id] = t
+	}
+
+	return nil
+}
+
+// inferType returns the type of the expression, empty if it can not be told yet
+func (s *paramScope) inferType(exp ValueExp) (SQLValueType, error) {
+	switch e := exp.(type) {
+	case nil, *NullValue:
+		return "", nil
+	case *Number:
+		return IntegerType, nil
+	case *Float:
+		return FloatType, nil
+	case *Varchar:
+		return VarcharType, nil
+	case *Bool:
+		return BooleanType, nil
+	case *Blob:
+		return BLOBType, nil
+	case *Timestamp:
+		return TimestampType, nil
+	case *JSON:
+		return JSONType, nil
+	case *SysFn:
+		{
+			if strings.ToUpper(e.fn) == "NOW" {
+				return TimestampType, nil
+			}
+
+			return "", nil
+		}
+	case *Param:
+		{
+			t, ok := s.types[e.id]
+			if !ok {
+				s.types[e.id] = ""
+			}
+
+			return t, nil
+		}
+	case *ColSelector:
+		return s.colType(e.table, e.col), nil
+	case *AggColSelector:
+		{
+			if e.aggFn == COUNT {
+				return IntegerType, nil
+			}
+
+			return s.colType(e.table, e.col), nil
+		}
+	case *JSONSelector:
+		{
+			if e.asText {
+				return VarcharType, nil
+			}
+
+			return JSONType, nil
+		}
+	case *NumExp:
+		{
+			t, err := s.inferPair(e.left, e.right, IntegerType)
+			if err != nil {
+				return "", err
+			}
+
+			if t == FloatType {
+				return FloatType, nil
+			}
+
+			return IntegerType, nil
+		}
+	case *CmpBoolExp:
+		{
+			_, err := s.inferPair(e.left, e.right, "")
+			return BooleanType, err
+		}
+	case *BinBoolExp:
+		{
+			err := s.requireType(e.left, BooleanType)
+			if err != nil {
+				return "", err
+			}
+
+			return BooleanType, s.requireType(e.right, BooleanType)
+		}
+	case *NotBoolExp:
+		return BooleanType, s.requireType(e.exp, BooleanType)
+	case *IsNullBoolExp:
+		{
+			_, err := s.inferType(e.exp)
+			return BooleanType, err
+		}
+	case *LikeBoolExp:
+		{
+			err := s.requireType(e.val, VarcharType)
+			if err != nil {
+				return "", err
+			}
+
+			return BooleanType, s.requireType(e.pattern, VarcharType)
+		}
+	case *InListExp:
+		{
+			t, err := s.inferCommon(append([]ValueExp{e.val}, e.values...), "")
+			if err != nil {
+				return "", err
+			}
+
+			// a single parameter may hold the whole list, its type can not be told
+			if len(e.values) == 1 {
+				return BooleanType, nil
+			}
+
+			for _, v := range append([]ValueExp{e.val}, e.values...) {
+				err := s.requireType(v, t)
+				if err != nil {
+					return "", err
+				}
+			}
+
+			return BooleanType, nil
+		}
+	case *InSubQueryExp:
+		{
+			_, err := s.inferType(e.val)
+			if err != nil {
+				return "", err
+			}
+
+			return BooleanType, s.subScope().inferSelect(e.q)
+		}
+	case *ExistsBoolExp:
+		return BooleanType, s.subScope().inferSelect(e.q)
+	case *SubQueryExp:
+		return "", s.subScope().inferSelect(e.q)
+	case *CaseWhenExp:
+		return s.inferCase(e)
+	case *FnCall:
+		{
+			types := make([]SQLValueType, len(e.params))
+
+			for i, p := range e.params {
+				t, err := s.inferType(p)
+				if err != nil {
+					return "", err
+				}
+
+				types[i] = t
+			}
+
+			fn, err := e.function()
+			if err != nil {
+				return "", nil
+			}
+
+			t, err := fn.returnType(e.params, types)
+			if err != nil {
+				return "", nil
+			}
+
+			return t, nil
+		}
+	case *anyBoolExp:
+		{
+			for _, exp := range e.exps {
+				err := s.requireType(exp, BooleanType)
+				if err != nil {
+					return "", err
+				}
+			}
+
+			return BooleanType, nil
+		}
+	}
+
+	return "", nil
+}
+
+// inferPair types the parameters of a binary expression after the other operand,
+// or as the given type when neither operand can be typed
+func (s *paramScope) inferPair(left, right ValueExp, defaultType SQLValueType) (SQLValueType, error) {
+	t, err := s.inferCommon([]ValueExp{left, right}, defaultType)
+	if err != nil {
+		return "", err
+	}
+
+	err = s.requireType(left, t)
+	if err != nil {
+		return "", err
+	}
+
+	return t, s.requireType(right, t)
+}
+
+// inferCommon returns the type of the first expression whose type can be told
+func (s *paramScope) inferCommon(exps []ValueExp, defaultType SQLValueType) (SQLValueType, error) {
+	t := defaultType
+
+	found := false
+
+	for _, exp := range exps {
+		et, err := s.inferType(exp)
+		if err != nil {
+			return "", err
+		}
+
+		if et != "" && !found {
+			t = et
+			found = true
+		}
+	}
+
+	return t, nil
+}
+
+func (s *paramScope) inferCase(c *CaseWhenExp) (SQLValueType, error) {
+	whenType := BooleanType
+
+	if c.exp != nil {
+		whens := []ValueExp{c.exp}
+		for _, w := range c.whens {
+			whens = append(whens, w.when)
+		}
+
+		t, err := s.inferCommon(whens, "")
+		if err != nil {
+			return "", err
+		}
+
+		err = s.requireType(c.exp, t)
+		if err != nil {
+			return "", err
+		}
+
+		whenType = t
+	}
+
+	thens := []ValueExp{c.elseExp}
+	for _, w := range c.whens {
+		err := s.requireType(w.when, whenType)
+		if err != nil {
+			return "", err
+		}
+
+		thens = append(thens, w.then)
+	}
+
+	t, err := s.inferCommon(thens, "")
+	if err != nil {
+		return "", err
+	}
+
+	for _, then := range thens {
+		err := s.requireType(then, t)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return t, nil
+}
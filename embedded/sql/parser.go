@@ -29,48 +29,77 @@ import (
 //go:generate go run golang.org/x/tools/cmd/goyacc -l -o sql_parser.go sql_grammar.y
 
 var reservedWords = map[string]int{
-	"CREATE":      CREATE,
-	"USE":         USE,
-	"DATABASE":    DATABASE,
-	"SNAPSHOT":    SNAPSHOT,
-	"SINCE":       SINCE,
-	"UP":          UP,
-	"TO":          TO,
-	"TABLE":       TABLE,
-	"PRIMARY":     PRIMARY,
-	"KEY":         KEY,
-	"INDEX":       INDEX,
-	"ON":          ON,
-	"ALTER":       ALTER,
-	"ADD":         ADD,
-	"COLUMN":      COLUMN,
-	"INSERT":      INSERT,
-	"UPSERT":      UPSERT,
-	"INTO":        INTO,
-	"VALUES":      VALUES,
-	"BEGIN":       BEGIN,
-	"TRANSACTION": TRANSACTION,
-	"COMMIT":      COMMIT,
-	"SELECT":      SELECT,
-	"DISTINCT":    DISTINCT,
-	"FROM":        FROM,
-	"BEFORE":      BEFORE,
-	"TX":          TX,
-	"JOIN":        JOIN,
-	"HAVING":      HAVING,
-	"WHERE":       WHERE,
-	"GROUP":       GROUP,
-	"BY":          BY,
-	"LIMIT":       LIMIT,
-	"ORDER":       ORDER,
-	"AS":          AS,
-	"ASC":         ASC,
-	"DESC":        DESC,
-	"NOT":         NOT,
-	"LIKE":        LIKE,
-	"EXISTS":      EXISTS,
-	"NULL":        NULL,
-	"IF":          IF,
+	"CREATE":         CREATE,
+	"USE":            USE,
+	"DATABASE":       DATABASE,
+	"SNAPSHOT":       SNAPSHOT,
+	"SINCE":          SINCE,
+	"UP":             UP,
+	"TO":             TO,
+	"TABLE":          TABLE,
+	"PRIMARY":        PRIMARY,
+	"KEY":            KEY,
+	"INDEX":          INDEX,
+	"ON":             ON,
+	"ALTER":          ALTER,
+	"ADD":            ADD,
+	"RENAME":         RENAME,
+	"DROP":           DROP,
+	"AUTO_INCREMENT": AUTO_INCREMENT,
+	"UNIQUE":         UNIQUE,
+	"DEFAULT":        DEFAULT,
+	"CHECK":          CHECK,
+	"CONSTRAINT":     CONSTRAINT,
+	"POLICY":         POLICY,
+	"USING":          USING,
+	"TRUNCATE":       TRUNCATE,
+	"VIEW":           VIEW,
+	"COLUMN":         COLUMN,
+	"INSERT":         INSERT,
+	"UPSERT":         UPSERT,
+	"INTO":           INTO,
+	"VALUES":         VALUES,
+	"RETURNING":      RETURNING,
+	"DELETE":         DELETE,
+	"UPDATE":         UPDATE,
+	"SET":            SET,
+	"BEGIN":          BEGIN,
+	"TRANSACTION":    TRANSACTION,
+	"COMMIT":         COMMIT,
+	"ROLLBACK":       ROLLBACK,
+	"SELECT":         SELECT,
+	"EXPLAIN":        EXPLAIN,
+	"ANALYZE":        ANALYZE,
+	"DISTINCT":       DISTINCT,
+	"FROM":           FROM,
+	"BEFORE":         BEFORE,
+	"TX":             TX,
+	"OF":             OF,
+	"JOIN":           JOIN,
+	"OUTER":          OUTER,
+	"HAVING":         HAVING,
+	"WHERE":          WHERE,
+	"GROUP":          GROUP,
+	"BY":             BY,
+	"LIMIT":          LIMIT,
+	"ORDER":          ORDER,
+	"AS":             AS,
+	"ASC":            ASC,
+	"DESC":           DESC,
+	"NOT":            NOT,
+	"LIKE":           LIKE,
+	"ILIKE":          ILIKE,
+	"EXISTS":         EXISTS,
+	"IN":             IN,
+	"IS":             IS,
+	"NULL":           NULL,
+	"IF":             IF,
+	"CASE":           CASE,
+	"WHEN":           WHEN,
+	"THEN":           THEN,
+	"ELSE":           ELSE,
+	"END":            END,
+	"CAST":           CAST,
 }
 
 var joinTypes = map[string]JoinType{
@@ -81,10 +110,12 @@ var joinTypes = map[string]JoinType{
 
 var types = map[string]SQLValueType{
 	"INTEGER":   IntegerType,
+	"FLOAT":     FloatType,
 	"BOOLEAN":   BooleanType,
 	"VARCHAR":   VarcharType,
 	"BLOB":      BLOBType,
 	"TIMESTAMP": TimestampType,
+	"JSON":      JSONType,
 }
 
 var aggregateFns = map[string]AggregateFn{
@@ -118,12 +149,29 @@ type lexer struct {
 	r      *aheadByteReader
 	err    error
 	result []SQLStmt
+
+	// positions of the last tokens within the text read so far, so statements can keep the text they were parsed from
+	lastToken    int
+	tokenStart   int
+	tokenEnd     int
+	prevTokenEnd int
+
+	// token read ahead of the ones consumed by the parser, see Lex
+	ahead *lexedToken
+}
+
+type lexedToken struct {
+	tkn   int
+	lval  yySymType
+	start int
+	end   int
 }
 
 type aheadByteReader struct {
 	nextChar byte
 	nextErr  error
 	r        io.ByteReader
+	read     []byte
 }
 
 func newAheadByteReader(r io.ByteReader) *aheadByteReader {
@@ -135,6 +183,7 @@ func newAheadByteReader(r io.ByteReader) *aheadByteReader {
 func (ar *aheadByteReader) ReadByte() (byte, error) {
 	defer func() {
 		if ar.nextErr == nil {
+			ar.read = append(ar.read, ar.nextChar)
 			ar.nextChar, ar.nextErr = ar.r.ReadByte()
 		}
 	}()
@@ -159,6 +208,44 @@ func Parse(r io.ByteReader) ([]SQLStmt, error) {
 	return lexer.result, lexer.err
 }
 
+// parseRowValue parses a value as it's given within the values of an insertion
+func parseRowValue(val string) (ValueExp, error) {
+	stmts, err := ParseString(fmt.Sprintf("INSERT INTO t (c) VALUES (%s)", val))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(stmts) != 1 {
+		return nil, ErrIllegalArguments
+	}
+
+	stmt, ok := stmts[0].(*UpsertIntoStmt)
+	if !ok || len(stmt.rows) != 1 || len(stmt.rows[0].Values) != 1 {
+		return nil, ErrIllegalArguments
+	}
+
+	return stmt.rows[0].Values[0], nil
+}
+
+// parseCondition parses a condition as it's given within a WHERE clause
+func parseCondition(cond string) (ValueExp, error) {
+	stmts, err := ParseString(fmt.Sprintf("SELECT * FROM t WHERE %s", cond))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(stmts) != 1 {
+		return nil, ErrIllegalArguments
+	}
+
+	stmt, ok := stmts[0].(*SelectStmt)
+	if !ok || stmt.where == nil {
+		return nil, ErrIllegalArguments
+	}
+
+	return stmt.where, nil
+}
+
 func newLexer(r io.ByteReader) *lexer {
 	return &lexer{
 		r:   newAheadByteReader(r),
@@ -167,10 +254,72 @@ func newLexer(r io.ByteReader) *lexer {
 }
 
 func (l *lexer) Lex(lval *yySymType) int {
+	l.prevTokenEnd = l.tokenEnd
+
+	tkn, start, end := l.next(lval)
+
+	// an alias and AS OF can't be told apart with a single token of lookahead,
+	// thus AS followed by OF is given to the parser as a single token
+	if tkn == AS {
+		var aheadLval yySymType
+
+		aheadTkn, aheadStart, aheadEnd := l.next(&aheadLval)
+		if aheadTkn == OF {
+			tkn = AS_OF
+			end = aheadEnd
+		} else {
+			l.ahead = &lexedToken{tkn: aheadTkn, lval: aheadLval, start: aheadStart, end: aheadEnd}
+		}
+	}
+
+	l.lastToken = tkn
+	l.tokenStart = start
+	l.tokenEnd = end
+	lval.pos = start
+
+	return tkn
+}
+
+// next returns the token read ahead, if any, or the next one of the input, along with the positions it spans
+func (l *lexer) next(lval *yySymType) (tkn, start, end int) {
+	if l.ahead != nil {
+		ahead := l.ahead
+		l.ahead = nil
+
+		*lval = ahead.lval
+
+		return ahead.tkn, ahead.start, ahead.end
+	}
+
+	tkn = l.lex(lval)
+
+	return tkn, l.tokenStart, len(l.r.read)
+}
+
+// textFrom returns the text read from the given position up to the end of the last token consumed by the parser.
+// Statements embedding text are only followed by separators, the end of transactions or the end of the input,
+// thus the lookahead token is excluded when it's one of them
+func (l *lexer) textFrom(pos int) string {
+	end := l.tokenEnd
+
+	if l.lastToken == STMT_SEPARATOR || l.lastToken == COMMIT || l.lastToken == 0 {
+		end = l.prevTokenEnd
+	}
+
+	if pos > end {
+		return ""
+	}
+
+	return strings.TrimSpace(string(l.r.read[pos:end]))
+}
+
+func (l *lexer) lex(lval *yySymType) int {
 	var ch byte
 	var err error
 
 	for {
+		l.tokenStart = len(l.r.read)
+
 		ch, err = l.r.ReadByte()
 		if err == io.EOF {
 			return 0
@@ -305,6 +454,26 @@ func (l *lexer) Lex(lval *yySymType) int {
 			return ERROR
 		}
 
+		next, err := l.r.NextByte()
+		if err == nil && next == '.' {
+			l.r.ReadByte() // consume decimal point
+
+			decimals, err := l.readNumber()
+			if err != nil {
+				lval.err = err
+				return ERROR
+			}
+
+			val, err := strconv.ParseFloat(fmt.Sprintf("%c%s.%s", ch, tail, decimals), 64)
+			if err != nil {
+				lval.err = err
+				return ERROR
+			}
+
+			lval.float = val
+			return FLOAT
+		}
+
 		val, err := strconv.ParseUint(fmt.Sprintf("%c%s", ch, tail), 10, 64)
 		if err != nil {
 			lval.err = err
@@ -334,6 +503,18 @@ func (l *lexer) Lex(lval *yySymType) int {
 		return CMPOP
 	}
 
+	if isJSONOp(ch, l.r.nextChar) {
+		l.r.ReadByte() // consume arrow head
+
+		// values are extracted as text with ->>
+		lval.boolean = l.r.nextChar == '>'
+		if lval.boolean {
+			l.r.ReadByte()
+		}
+
+		return JSONOP
+	}
+
 	if isQuote(ch) {
 		tail, err := l.readString()
 		if err != nil {
@@ -427,6 +608,10 @@ func isComparison(ch byte) bool {
 	return '!' == ch || '<' == ch || '=' == ch || '>' == ch
 }
 
+func isJSONOp(ch, next byte) bool {
+	return '-' == ch && '>' == next
+}
+
 func isQuote(ch byte) bool {
 	return '\'' == ch
 }
@@ -43,7 +43,7 @@ func TestCreateDatabaseStmt(t *testing.T) {
 		{
 			input:          "CREATE db1",
 			expectedOutput: nil,
-			expectedError:  errors.New("syntax error: unexpected IDENTIFIER, expecting DATABASE or TABLE or INDEX"),
+			expectedError:  errors.New("syntax error: unexpected IDENTIFIER"),
 		},
 	}
 
@@ -128,7 +128,7 @@ func TestCreateTableStmt(t *testing.T) {
 					table:       "table1",
 					ifNotExists: false,
 					colsSpec:    []*ColSpec{{colName: "id", colType: IntegerType}},
-					pk:          "id",
+					pk:          []string{"id"},
 				}},
 			expectedError: nil,
 		},
@@ -139,7 +139,69 @@ func TestCreateTableStmt(t *testing.T) {
 					table:       "xtable1",
 					ifNotExists: false,
 					colsSpec:    []*ColSpec{{colName: "xid", colType: IntegerType}},
-					pk:          "xid",
+					pk:          []string{"xid"},
+				}},
+			expectedError: nil,
+		},
+		{
+			input: "CREATE TABLE table1 (id INTEGER AUTO_INCREMENT, name VARCHAR NOT NULL, PRIMARY KEY id)",
+			expectedOutput: []SQLStmt{
+				&CreateTableStmt{
+					table:       "table1",
+					ifNotExists: false,
+					colsSpec: []*ColSpec{
+						{colName: "id", colType: IntegerType, autoIncrement: true},
+						{colName: "name", colType: VarcharType, notNull: true},
+					},
+					pk: []string{"id"},
+				}},
+			expectedError: nil,
+		},
+		{
+			input: "CREATE TABLE table1 (id INTEGER, email VARCHAR NOT NULL UNIQUE, PRIMARY KEY id)",
+			expectedOutput: []SQLStmt{
+				&CreateTableStmt{
+					table:       "table1",
+					ifNotExists: false,
+					colsSpec: []*ColSpec{
+						{colName: "id", colType: IntegerType},
+						{colName: "email", colType: VarcharType, notNull: true, unique: true},
+					},
+					pk: []string{"id"},
+				}},
+			expectedError: nil,
+		},
+		{
+			input: "CREATE TABLE table1 (id INTEGER, status VARCHAR NOT NULL DEFAULT 'new' UNIQUE, rate FLOAT DEFAULT -0.5, ts TIMESTAMP DEFAULT NOW(), PRIMARY KEY id)",
+			expectedOutput: []SQLStmt{
+				&CreateTableStmt{
+					table:       "table1",
+					ifNotExists: false,
+					colsSpec: []*ColSpec{
+						{colName: "id", colType: IntegerType},
+						{colName: "status", colType: VarcharType, notNull: true, defaultValue: &Varchar{val: "new"}, unique: true},
+						{colName: "rate", colType: FloatType, defaultValue: &Float{val: -0.5}},
+						{colName: "ts", colType: TimestampType, defaultValue: &SysFn{fn: "now"}},
+					},
+					pk: []string{"id"},
+				}},
+			expectedError: nil,
+		},
+		{
+			input: "CREATE TABLE table1 (id INTEGER, amount INTEGER, CHECK (amount > 0), CONSTRAINT max_amount CHECK (amount <= 100), PRIMARY KEY id)",
+			expectedOutput: []SQLStmt{
+				&CreateTableStmt{
+					table:       "table1",
+					ifNotExists: false,
+					colsSpec: []*ColSpec{
+						{colName: "id", colType: IntegerType},
+						{colName: "amount", colType: IntegerType},
+					},
+					checks: []*CheckSpec{
+						{exp: &CmpBoolExp{op: GT, left: &ColSelector{col: "amount"}, right: &Number{val: 0}}},
+						{name: "max_amount", exp: &CmpBoolExp{op: LE, left: &ColSelector{col: "amount"}, right: &Number{val: 100}}},
+					},
+					pk: []string{"id"},
 				}},
 			expectedError: nil,
 		},
@@ -150,7 +212,7 @@ func TestCreateTableStmt(t *testing.T) {
 					table:       "table1",
 					ifNotExists: true,
 					colsSpec:    []*ColSpec{{colName: "id", colType: IntegerType}},
-					pk:          "id",
+					pk:          []string{"id"},
 				}},
 			expectedError: nil,
 		},
@@ -167,14 +229,40 @@ func TestCreateTableStmt(t *testing.T) {
 						{colName: "active", colType: BooleanType},
 						{colName: "content", colType: BLOBType},
 					},
-					pk: "id",
+					pk: []string{"id"},
+				}},
+			expectedError: nil,
+		},
+		{
+			input: "CREATE TABLE table1 (id INTEGER, price FLOAT, PRIMARY KEY id)",
+			expectedOutput: []SQLStmt{
+				&CreateTableStmt{
+					table: "table1",
+					colsSpec: []*ColSpec{
+						{colName: "id", colType: IntegerType},
+						{colName: "price", colType: FloatType},
+					},
+					pk: []string{"id"},
+				}},
+			expectedError: nil,
+		},
+		{
+			input: "CREATE TABLE table1 (account VARCHAR, id INTEGER, PRIMARY KEY (account, id))",
+			expectedOutput: []SQLStmt{
+				&CreateTableStmt{
+					table: "table1",
+					colsSpec: []*ColSpec{
+						{colName: "account", colType: VarcharType},
+						{colName: "id", colType: IntegerType},
+					},
+					pk: []string{"account", "id"},
 				}},
 			expectedError: nil,
 		},
 		{
 			input:          "CREATE table1",
 			expectedOutput: nil,
-			expectedError:  errors.New("syntax error: unexpected IDENTIFIER, expecting DATABASE or TABLE or INDEX"),
+			expectedError:  errors.New("syntax error: unexpected IDENTIFIER"),
 		},
 		{
 			input:          "CREATE TABLE table1",
@@ -206,9 +294,18 @@ func TestCreateIndexStmt(t *testing.T) {
 	}{
 		{
 			input:          "CREATE INDEX ON table1(id)",
-			expectedOutput: []SQLStmt{&CreateIndexStmt{table: "table1", col: "id"}},
+			expectedOutput: []SQLStmt{&CreateIndexStmt{table: "table1", cols: []*IndexColSpec{{colName: "id"}}}},
 			expectedError:  nil,
 		},
+		{
+			input: "CREATE INDEX ON table1(account, ts DESC, id ASC)",
+			expectedOutput: []SQLStmt{&CreateIndexStmt{table: "table1", cols: []*IndexColSpec{
+				{colName: "account"},
+				{colName: "ts", desc: true},
+				{colName: "id"},
+			}}},
+			expectedError: nil,
+		},
 		{
 			input:          "CREATE INDEX table1(id)",
 			expectedOutput: nil,
@@ -226,34 +323,46 @@ func TestCreateIndexStmt(t *testing.T) {
 	}
 }
 
-func TestAlterTableStmt(t *testing.T) {
+func TestDropStmt(t *testing.T) {
 	testCases := []struct {
 		input          string
 		expectedOutput []SQLStmt
 		expectedError  error
 	}{
 		{
-			input: "ALTER TABLE table1 ADD COLUMN title VARCHAR",
-			expectedOutput: []SQLStmt{
-				&AddColumnStmt{
-					table:   "table1",
-					colSpec: &ColSpec{colName: "title", colType: VarcharType},
-				}},
-			expectedError: nil,
+			input:          "DROP TABLE table1",
+			expectedOutput: []SQLStmt{&DropTableStmt{table: "table1"}},
+			expectedError:  nil,
 		},
 		{
-			input: "ALTER TABLE table1 ADD COLUMN title BLOB",
-			expectedOutput: []SQLStmt{
-				&AddColumnStmt{
-					table:   "table1",
-					colSpec: &ColSpec{colName: "title", colType: BLOBType},
-				}},
-			expectedError: nil,
+			input:          "DROP INDEX ON table1(title)",
+			expectedOutput: []SQLStmt{&DropIndexStmt{table: "table1", cols: []string{"title"}}},
+			expectedError:  nil,
 		},
 		{
-			input:          "ALTER TABLE table1 COLUMN title VARCHAR",
+			input:          "DROP INDEX ON table1(account, ts)",
+			expectedOutput: []SQLStmt{&DropIndexStmt{table: "table1", cols: []string{"account", "ts"}}},
+			expectedError:  nil,
+		},
+		{
+			input:          "TRUNCATE TABLE table1",
+			expectedOutput: []SQLStmt{&TruncateTableStmt{table: "table1"}},
+			expectedError:  nil,
+		},
+		{
+			input:          "TRUNCATE table1",
+			expectedOutput: nil,
+			expectedError:  errors.New("syntax error: unexpected IDENTIFIER, expecting TABLE"),
+		},
+		{
+			input:          "DROP table1",
+			expectedOutput: nil,
+			expectedError:  errors.New("syntax error: unexpected IDENTIFIER, expecting TABLE or INDEX or POLICY or VIEW"),
+		},
+		{
+			input:          "DROP INDEX table1(title)",
 			expectedOutput: nil,
-			expectedError:  errors.New("syntax error: unexpected COLUMN, expecting ADD"),
+			expectedError:  errors.New("syntax error: unexpected IDENTIFIER, expecting ON"),
 		},
 	}
 
@@ -267,61 +376,61 @@ func TestAlterTableStmt(t *testing.T) {
 	}
 }
 
-func TestInsertIntoStmt(t *testing.T) {
-	decodedBLOB, err := hex.DecodeString("AED0393F")
-	require.NoError(t, err)
-
+func TestPolicyStmt(t *testing.T) {
 	testCases := []struct {
 		input          string
 		expectedOutput []SQLStmt
 		expectedError  error
 	}{
 		{
-			input: "UPSERT INTO table1(id, time, title, active, compressed, payload, note) VALUES (2, now(), 'untitled row', TRUE, false, x'AED0393F', @param1)",
+			input: "CREATE POLICY acme_rows ON orders USING (tenant = 'acme')",
 			expectedOutput: []SQLStmt{
-				&UpsertIntoStmt{
-					tableRef: &TableRef{table: "table1"},
-					cols:     []string{"id", "time", "title", "active", "compressed", "payload", "note"},
-					rows: []*RowSpec{
-						{Values: []ValueExp{
-							&Number{val: 2},
-							&SysFn{fn: "now"},
-							&Varchar{val: "untitled row"},
-							&Bool{val: true},
-							&Bool{val: false},
-							&Blob{val: decodedBLOB},
-							&Param{id: "param1"},
-						},
-						},
+				&CreatePolicyStmt{
+					name:  "acme_rows",
+					table: "orders",
+					filter: &CmpBoolExp{
+						op:    EQ,
+						left:  &ColSelector{col: "tenant"},
+						right: &Varchar{val: "acme"},
 					},
 				},
 			},
 			expectedError: nil,
 		},
 		{
-			input: "UPSERT INTO table1(id, active) VALUES (1, false), (2, true), (3, true)",
+			input: "CREATE POLICY acme_rows ON orders TO alice, read USING (tenant = 'acme' AND NOT archived)",
 			expectedOutput: []SQLStmt{
-				&UpsertIntoStmt{
-					tableRef: &TableRef{table: "table1"},
-					cols:     []string{"id", "active"},
-					rows: []*RowSpec{
-						{Values: []ValueExp{&Number{val: 1}, &Bool{val: false}}},
-						{Values: []ValueExp{&Number{val: 2}, &Bool{val: true}}},
-						{Values: []ValueExp{&Number{val: 3}, &Bool{val: true}}},
+				&CreatePolicyStmt{
+					name:  "acme_rows",
+					table: "orders",
+					users: []string{"alice", "read"},
+					filter: &BinBoolExp{
+						op: AND,
+						left: &CmpBoolExp{
+							op:    EQ,
+							left:  &ColSelector{col: "tenant"},
+							right: &Varchar{val: "acme"},
+						},
+						right: &NotBoolExp{exp: &ColSelector{col: "archived"}},
 					},
 				},
 			},
 			expectedError: nil,
 		},
 		{
-			input:          "UPSERT INTO table1() VALUES (2, 'untitled')",
+			input:          "DROP POLICY acme_rows ON orders",
+			expectedOutput: []SQLStmt{&DropPolicyStmt{name: "acme_rows", table: "orders"}},
+			expectedError:  nil,
+		},
+		{
+			input:          "CREATE POLICY acme_rows ON orders TO alice (tenant = 'acme')",
 			expectedOutput: nil,
-			expectedError:  errors.New("syntax error: unexpected ')', expecting IDENTIFIER"),
+			expectedError:  errors.New("syntax error: unexpected '(', expecting USING"),
 		},
 		{
-			input:          "UPSERT INTO VALUES (2)",
+			input:          "DROP POLICY acme_rows",
 			expectedOutput: nil,
-			expectedError:  errors.New("syntax error: unexpected VALUES, expecting IDENTIFIER"),
+			expectedError:  errors.New("syntax error: unexpected $end, expecting ON"),
 		},
 	}
 
@@ -335,80 +444,137 @@ func TestInsertIntoStmt(t *testing.T) {
 	}
 }
 
-func TestStmtSeparator(t *testing.T) {
+func TestViewStmt(t *testing.T) {
 	testCases := []struct {
 		input          string
 		expectedOutput []SQLStmt
 		expectedError  error
 	}{
 		{
-			input: "CREATE TABLE table1 (id INTEGER, PRIMARY KEY id);",
+			input: "CREATE VIEW acme_orders AS SELECT id, amount FROM orders WHERE tenant = 'acme';",
 			expectedOutput: []SQLStmt{
-				&CreateTableStmt{
-					table: "table1",
-					colsSpec: []*ColSpec{
-						{colName: "id", colType: IntegerType},
+				&CreateViewStmt{
+					view: "acme_orders",
+					query: &SelectStmt{
+						selectors: []Selector{
+							&ColSelector{col: "id"},
+							&ColSelector{col: "amount"},
+						},
+						ds: &TableRef{table: "orders"},
+						where: &CmpBoolExp{
+							op:    EQ,
+							left:  &ColSelector{col: "tenant"},
+							right: &Varchar{val: "acme"},
+						},
 					},
-					pk: "id",
+					sql: "SELECT id, amount FROM orders WHERE tenant = 'acme'",
 				},
 			},
 			expectedError: nil,
 		},
 		{
-			input: "CREATE TABLE table1 (id INTEGER, PRIMARY KEY id)\n",
+			input: "CREATE VIEW IF NOT EXISTS big_orders AS\n\tSELECT id\n\tFROM orders /* only big ones */\n\tWHERE amount > 100",
 			expectedOutput: []SQLStmt{
-				&CreateTableStmt{
-					table: "table1",
-					colsSpec: []*ColSpec{
-						{colName: "id", colType: IntegerType},
+				&CreateViewStmt{
+					view:        "big_orders",
+					ifNotExists: true,
+					query: &SelectStmt{
+						selectors: []Selector{&ColSelector{col: "id"}},
+						ds:        &TableRef{table: "orders"},
+						where: &CmpBoolExp{
+							op:    GT,
+							left:  &ColSelector{col: "amount"},
+							right: &Number{val: 100},
+						},
 					},
-					pk: "id",
+					sql: "SELECT id\n\tFROM orders /* only big ones */\n\tWHERE amount > 100",
 				},
 			},
 			expectedError: nil,
 		},
 		{
-			input: "CREATE TABLE table1 (id INTEGER, PRIMARY KEY id)\r\n",
+			input: "BEGIN TRANSACTION CREATE VIEW v1 AS SELECT id FROM orders COMMIT",
 			expectedOutput: []SQLStmt{
-				&CreateTableStmt{
-					table: "table1",
-					colsSpec: []*ColSpec{
-						{colName: "id", colType: IntegerType},
+				&TxStmt{
+					stmts: []SQLStmt{
+						&CreateViewStmt{
+							view: "v1",
+							query: &SelectStmt{
+								selectors: []Selector{&ColSelector{col: "id"}},
+								ds:        &TableRef{table: "orders"},
+							},
+							sql: "SELECT id FROM orders",
+						},
 					},
-					pk: "id",
 				},
 			},
 			expectedError: nil,
 		},
 		{
-			input: "CREATE DATABASE db1; USE DATABASE db1;",
+			input:          "DROP VIEW acme_orders",
+			expectedOutput: []SQLStmt{&DropViewStmt{view: "acme_orders"}},
+			expectedError:  nil,
+		},
+		{
+			input:          "CREATE VIEW acme_orders SELECT id FROM orders",
+			expectedOutput: nil,
+			expectedError:  errors.New("syntax error: unexpected SELECT, expecting AS"),
+		},
+	}
+
+	for i, tc := range testCases {
+		res, err := ParseString(tc.input)
+		require.Equal(t, tc.expectedError, err, fmt.Sprintf("failed on iteration %d", i))
+
+		if tc.expectedError == nil {
+			require.Equal(t, tc.expectedOutput, res, fmt.Sprintf("failed on iteration %d", i))
+		}
+	}
+}
+
+func TestAlterTableStmt(t *testing.T) {
+	testCases := []struct {
+		input          string
+		expectedOutput []SQLStmt
+		expectedError  error
+	}{
+		{
+			input: "ALTER TABLE table1 ADD COLUMN title VARCHAR",
 			expectedOutput: []SQLStmt{
-				&CreateDatabaseStmt{DB: "db1"},
-				&UseDatabaseStmt{DB: "db1"},
-			},
+				&AddColumnStmt{
+					table:   "table1",
+					colSpec: &ColSpec{colName: "title", colType: VarcharType},
+				}},
 			expectedError: nil,
 		},
 		{
-			input: "CREATE DATABASE db1; /* some comment here */ USE /* another comment here */ DATABASE db1",
+			input: "ALTER TABLE table1 ADD COLUMN title BLOB",
 			expectedOutput: []SQLStmt{
-				&CreateDatabaseStmt{DB: "db1"},
-				&UseDatabaseStmt{DB: "db1"},
-			},
+				&AddColumnStmt{
+					table:   "table1",
+					colSpec: &ColSpec{colName: "title", colType: BLOBType},
+				}},
 			expectedError: nil,
 		},
 		{
-			input: "CREATE DATABASE db1; USE DATABASE db1; USE DATABASE db1",
+			input: "ALTER TABLE table1 RENAME COLUMN title TO name",
 			expectedOutput: []SQLStmt{
-				&CreateDatabaseStmt{DB: "db1"},
-				&UseDatabaseStmt{DB: "db1"},
-				&UseDatabaseStmt{DB: "db1"},
-			},
+				&RenameColumnStmt{
+					table:   "table1",
+					oldName: "title",
+					newName: "name",
+				}},
 			expectedError: nil,
 		},
 		{
-			input:          "CREATE DATABASE db1 USE DATABASE db1",
+			input:          "ALTER TABLE table1 COLUMN title VARCHAR",
 			expectedOutput: nil,
-			expectedError:  errors.New("syntax error: unexpected USE"),
+			expectedError:  errors.New("syntax error: unexpected COLUMN, expecting ADD or RENAME"),
+		},
+		{
+			input:          "ALTER TABLE table1 RENAME COLUMN title name",
+			expectedOutput: nil,
+			expectedError:  errors.New("syntax error: unexpected IDENTIFIER, expecting TO"),
 		},
 	}
 
@@ -422,30 +588,31 @@ func TestStmtSeparator(t *testing.T) {
 	}
 }
 
-func TestTxStmt(t *testing.T) {
+func TestInsertIntoStmt(t *testing.T) {
+	decodedBLOB, err := hex.DecodeString("AED0393F")
+	require.NoError(t, err)
+
 	testCases := []struct {
 		input          string
 		expectedOutput []SQLStmt
 		expectedError  error
 	}{
 		{
-			input: "BEGIN TRANSACTION UPSERT INTO table1 (id, label) VALUES (100, 'label1'); UPSERT INTO table2 (id) VALUES (10) COMMIT;",
+			input: "UPSERT INTO table1(id, time, title, active, compressed, payload, note) VALUES (2, now(), 'untitled row', TRUE, false, x'AED0393F', @param1)",
 			expectedOutput: []SQLStmt{
-				&TxStmt{
-					stmts: []SQLStmt{
-						&UpsertIntoStmt{
-							tableRef: &TableRef{table: "table1"},
-							cols:     []string{"id", "label"},
-							rows: []*RowSpec{
-								{Values: []ValueExp{&Number{val: 100}, &Varchar{val: "label1"}}},
-							},
+				&UpsertIntoStmt{
+					tableRef: &TableRef{table: "table1"},
+					cols:     []string{"id", "time", "title", "active", "compressed", "payload", "note"},
+					rows: []*RowSpec{
+						{Values: []ValueExp{
+							&Number{val: 2},
+							&SysFn{fn: "now"},
+							&Varchar{val: "untitled row"},
+							&Bool{val: true},
+							&Bool{val: false},
+							&Blob{val: decodedBLOB},
+							&Param{id: "param1"},
 						},
-						&UpsertIntoStmt{
-							tableRef: &TableRef{table: "table2"},
-							cols:     []string{"id"},
-							rows: []*RowSpec{
-								{Values: []ValueExp{&Number{val: 10}}},
-							},
 						},
 					},
 				},
@@ -453,16 +620,287 @@ func TestTxStmt(t *testing.T) {
 			expectedError: nil,
 		},
 		{
-			input: "CREATE TABLE table1 (id INTEGER, label VARCHAR, PRIMARY KEY id); BEGIN TRANSACTION UPSERT INTO table1 (id, label) VALUES (100, 'label1'); COMMIT;",
+			input: "UPSERT INTO table1(id, active) VALUES (1, false), (2, true), (3, true)",
 			expectedOutput: []SQLStmt{
-				&CreateTableStmt{
-					table: "table1",
-					colsSpec: []*ColSpec{
-						{colName: "id", colType: IntegerType},
-						{colName: "label", colType: VarcharType},
+				&UpsertIntoStmt{
+					tableRef: &TableRef{table: "table1"},
+					cols:     []string{"id", "active"},
+					rows: []*RowSpec{
+						{Values: []ValueExp{&Number{val: 1}, &Bool{val: false}}},
+						{Values: []ValueExp{&Number{val: 2}, &Bool{val: true}}},
+						{Values: []ValueExp{&Number{val: 3}, &Bool{val: true}}},
+					},
+				},
+			},
+			expectedError: nil,
+		},
+		{
+			input: "INSERT INTO table1(id, price, delta) VALUES (1, 10.25, -0.5)",
+			expectedOutput: []SQLStmt{
+				&UpsertIntoStmt{
+					isInsert: true,
+					tableRef: &TableRef{table: "table1"},
+					cols:     []string{"id", "price", "delta"},
+					rows: []*RowSpec{
+						{Values: []ValueExp{&Number{val: 1}, &Float{val: 10.25}, &Float{val: -0.5}}},
+					},
+				},
+			},
+			expectedError: nil,
+		},
+		{
+			input: "INSERT INTO table1(title) VALUES ('untitled') RETURNING id, title",
+			expectedOutput: []SQLStmt{
+				&UpsertIntoStmt{
+					isInsert:  true,
+					tableRef:  &TableRef{table: "table1"},
+					cols:      []string{"title"},
+					rows:      []*RowSpec{{Values: []ValueExp{&Varchar{val: "untitled"}}}},
+					returning: &ReturningSpec{cols: []string{"id", "title"}},
+				},
+			},
+			expectedError: nil,
+		},
+		{
+			input: "UPSERT INTO table1(id) VALUES (1) RETURNING *",
+			expectedOutput: []SQLStmt{
+				&UpsertIntoStmt{
+					tableRef:  &TableRef{table: "table1"},
+					cols:      []string{"id"},
+					rows:      []*RowSpec{{Values: []ValueExp{&Number{val: 1}}}},
+					returning: &ReturningSpec{},
+				},
+			},
+			expectedError: nil,
+		},
+		{
+			input:          "UPSERT INTO table1() VALUES (2, 'untitled')",
+			expectedOutput: nil,
+			expectedError:  errors.New("syntax error: unexpected ')', expecting IDENTIFIER"),
+		},
+		{
+			input:          "UPSERT INTO VALUES (2)",
+			expectedOutput: nil,
+			expectedError:  errors.New("syntax error: unexpected VALUES, expecting IDENTIFIER"),
+		},
+	}
+
+	for i, tc := range testCases {
+		res, err := ParseString(tc.input)
+		require.Equal(t, tc.expectedError, err, fmt.Sprintf("failed on iteration %d", i))
+
+		if tc.expectedError == nil {
+			require.Equal(t, tc.expectedOutput, res, fmt.Sprintf("failed on iteration %d", i))
+		}
+	}
+}
+
+func TestDeleteFromStmt(t *testing.T) {
+	testCases := []struct {
+		input          string
+		expectedOutput []SQLStmt
+		expectedError  error
+	}{
+		{
+			input: "DELETE FROM table1",
+			expectedOutput: []SQLStmt{
+				&DeleteFromStmt{
+					tableRef: &TableRef{table: "table1"},
+				},
+			},
+			expectedError: nil,
+		},
+		{
+			input: "DELETE FROM db1.table1 WHERE id >= @id AND active = false",
+			expectedOutput: []SQLStmt{
+				&DeleteFromStmt{
+					tableRef: &TableRef{db: "db1", table: "table1"},
+					where: &BinBoolExp{
+						op: AND,
+						left: &CmpBoolExp{
+							op:    GE,
+							left:  &ColSelector{col: "id"},
+							right: &Param{id: "id"},
+						},
+						right: &CmpBoolExp{
+							op:    EQ,
+							left:  &ColSelector{col: "active"},
+							right: &Bool{val: false},
+						},
+					},
+				},
+			},
+			expectedError: nil,
+		},
+		{
+			input:          "DELETE table1 WHERE id = 1",
+			expectedOutput: nil,
+			expectedError:  errors.New("syntax error: unexpected IDENTIFIER, expecting FROM"),
+		},
+	}
+
+	for i, tc := range testCases {
+		res, err := ParseString(tc.input)
+		require.Equal(t, tc.expectedError, err, fmt.Sprintf("failed on iteration %d", i))
+
+		if tc.expectedError == nil {
+			require.Equal(t, tc.expectedOutput, res, fmt.Sprintf("failed on iteration %d", i))
+		}
+	}
+}
+
+func TestUpdateStmt(t *testing.T) {
+	testCases := []struct {
+		input          string
+		expectedOutput []SQLStmt
+		expectedError  error
+	}{
+		{
+			input: "UPDATE table1 SET title = 'untitled', amount = amount + @delta WHERE id = 1",
+			expectedOutput: []SQLStmt{
+				&UpdateStmt{
+					tableRef: &TableRef{table: "table1"},
+					updates: []*colUpdate{
+						{col: "title", val: &Varchar{val: "untitled"}},
+						{col: "amount", val: &NumExp{op: ADDOP, left: &ColSelector{col: "amount"}, right: &Param{id: "delta"}}},
+					},
+					where: &CmpBoolExp{
+						op:    EQ,
+						left:  &ColSelector{col: "id"},
+						right: &Number{val: 1},
+					},
+				},
+			},
+			expectedError: nil,
+		},
+		{
+			input: "UPDATE db1.table1 SET active = NULL",
+			expectedOutput: []SQLStmt{
+				&UpdateStmt{
+					tableRef: &TableRef{db: "db1", table: "table1"},
+					updates:  []*colUpdate{{col: "active", val: &NullValue{}}},
+				},
+			},
+			expectedError: nil,
+		},
+		{
+			input:          "UPDATE table1 SET amount > 1",
+			expectedOutput: nil,
+			expectedError:  errors.New("syntax error: unexpected CMPOP, expecting ="),
+		},
+		{
+			input:          "UPDATE table1 WHERE id = 1",
+			expectedOutput: nil,
+			expectedError:  errors.New("syntax error: unexpected WHERE, expecting SET"),
+		},
+	}
+
+	for i, tc := range testCases {
+		res, err := ParseString(tc.input)
+		require.Equal(t, tc.expectedError, err, fmt.Sprintf("failed on iteration %d", i))
+
+		if tc.expectedError == nil {
+			require.Equal(t, tc.expectedOutput, res, fmt.Sprintf("failed on iteration %d", i))
+		}
+	}
+}
+
+func TestStmtSeparator(t *testing.T) {
+	testCases := []struct {
+		input          string
+		expectedOutput []SQLStmt
+		expectedError  error
+	}{
+		{
+			input: "CREATE TABLE table1 (id INTEGER, PRIMARY KEY id);",
+			expectedOutput: []SQLStmt{
+				&CreateTableStmt{
+					table: "table1",
+					colsSpec: []*ColSpec{
+						{colName: "id", colType: IntegerType},
+					},
+					pk: []string{"id"},
+				},
+			},
+			expectedError: nil,
+		},
+		{
+			input: "CREATE TABLE table1 (id INTEGER, PRIMARY KEY id)\n",
+			expectedOutput: []SQLStmt{
+				&CreateTableStmt{
+					table: "table1",
+					colsSpec: []*ColSpec{
+						{colName: "id", colType: IntegerType},
+					},
+					pk: []string{"id"},
+				},
+			},
+			expectedError: nil,
+		},
+		{
+			input: "CREATE TABLE table1 (id INTEGER, PRIMARY KEY id)\r\n",
+			expectedOutput: []SQLStmt{
+				&CreateTableStmt{
+					table: "table1",
+					colsSpec: []*ColSpec{
+						{colName: "id", colType: IntegerType},
 					},
-					pk: "id",
+					pk: []string{"id"},
 				},
+			},
+			expectedError: nil,
+		},
+		{
+			input: "CREATE DATABASE db1; USE DATABASE db1;",
+			expectedOutput: []SQLStmt{
+				&CreateDatabaseStmt{DB: "db1"},
+				&UseDatabaseStmt{DB: "db1"},
+			},
+			expectedError: nil,
+		},
+		{
+			input: "CREATE DATABASE db1; /* some comment here */ USE /* another comment here */ DATABASE db1",
+			expectedOutput: []SQLStmt{
+				&CreateDatabaseStmt{DB: "db1"},
+				&UseDatabaseStmt{DB: "db1"},
+			},
+			expectedError: nil,
+		},
+		{
+			input: "CREATE DATABASE db1; USE DATABASE db1; USE DATABASE db1",
+			expectedOutput: []SQLStmt{
+				&CreateDatabaseStmt{DB: "db1"},
+				&UseDatabaseStmt{DB: "db1"},
+				&UseDatabaseStmt{DB: "db1"},
+			},
+			expectedError: nil,
+		},
+		{
+			input:          "CREATE DATABASE db1 USE DATABASE db1",
+			expectedOutput: nil,
+			expectedError:  errors.New("syntax error: unexpected USE"),
+		},
+	}
+
+	for i, tc := range testCases {
+		res, err := ParseString(tc.input)
+		require.Equal(t, tc.expectedError, err, fmt.Sprintf("failed on iteration %d", i))
+
+		if tc.expectedError == nil {
+			require.Equal(t, tc.expectedOutput, res, fmt.Sprintf("failed on iteration %d", i))
+		}
+	}
+}
+
+func TestTxStmt(t *testing.T) {
+	testCases := []struct {
+		input          string
+		expectedOutput []SQLStmt
+		expectedError  error
+	}{
+		{
+			input: "BEGIN TRANSACTION UPSERT INTO table1 (id, label) VALUES (100, 'label1'); UPSERT INTO table2 (id) VALUES (10) COMMIT;",
+			expectedOutput: []SQLStmt{
 				&TxStmt{
 					stmts: []SQLStmt{
 						&UpsertIntoStmt{
@@ -472,68 +910,530 @@ func TestTxStmt(t *testing.T) {
 								{Values: []ValueExp{&Number{val: 100}, &Varchar{val: "label1"}}},
 							},
 						},
+						&UpsertIntoStmt{
+							tableRef: &TableRef{table: "table2"},
+							cols:     []string{"id"},
+							rows: []*RowSpec{
+								{Values: []ValueExp{&Number{val: 10}}},
+							},
+						},
+					},
+				},
+			},
+			expectedError: nil,
+		},
+		{
+			input: "CREATE TABLE table1 (id INTEGER, label VARCHAR, PRIMARY KEY id); BEGIN TRANSACTION UPSERT INTO table1 (id, label) VALUES (100, 'label1'); COMMIT;",
+			expectedOutput: []SQLStmt{
+				&CreateTableStmt{
+					table: "table1",
+					colsSpec: []*ColSpec{
+						{colName: "id", colType: IntegerType},
+						{colName: "label", colType: VarcharType},
+					},
+					pk: []string{"id"},
+				},
+				&TxStmt{
+					stmts: []SQLStmt{
+						&UpsertIntoStmt{
+							tableRef: &TableRef{table: "table1"},
+							cols:     []string{"id", "label"},
+							rows: []*RowSpec{
+								{Values: []ValueExp{&Number{val: 100}, &Varchar{val: "label1"}}},
+							},
+						},
+					},
+				},
+			},
+			expectedError: nil,
+		},
+		{
+			input: "BEGIN TRANSACTION CREATE TABLE table1 (id INTEGER, label VARCHAR NOT NULL, PRIMARY KEY id); UPSERT INTO table1 (id, label) VALUES (100, 'label1'); COMMIT;",
+			expectedOutput: []SQLStmt{
+				&TxStmt{
+					stmts: []SQLStmt{
+						&CreateTableStmt{
+							table: "table1",
+							colsSpec: []*ColSpec{
+								{colName: "id", colType: IntegerType},
+								{colName: "label", colType: VarcharType, notNull: true},
+							},
+							pk: []string{"id"},
+						},
+						&UpsertIntoStmt{
+							tableRef: &TableRef{table: "table1"},
+							cols:     []string{"id", "label"},
+							rows: []*RowSpec{
+								{Values: []ValueExp{&Number{val: 100}, &Varchar{val: "label1"}}},
+							},
+						},
+					},
+				},
+			},
+			expectedError: nil,
+		},
+		{
+			input:          "BEGIN TRANSACTION UPSERT INTO table1 (id, label) VALUES (100, 'label1');",
+			expectedOutput: nil,
+			expectedError:  errors.New("syntax error: unexpected $end, expecting COMMIT"),
+		},
+		{
+			input:          "BEGIN TRANSACTION UPSERT INTO table1 (id, label) VALUES (100, 'label1'); BEGIN TRANSACTION CREATE TABLE table1 (id INTEGER, label VARCHAR, PRIMARY KEY id) COMMIT; COMMIT",
+			expectedOutput: nil,
+			expectedError:  errors.New("syntax error: unexpected BEGIN, expecting COMMIT"),
+		},
+		{
+			input: "BEGIN; UPSERT INTO table1 (id) VALUES (1); COMMIT;",
+			expectedOutput: []SQLStmt{
+				&BeginTransactionStmt{},
+				&UpsertIntoStmt{
+					tableRef: &TableRef{table: "table1"},
+					cols:     []string{"id"},
+					rows: []*RowSpec{
+						{Values: []ValueExp{&Number{val: 1}}},
+					},
+				},
+				&CommitStmt{},
+			},
+			expectedError: nil,
+		},
+		{
+			input:          "BEGIN TRANSACTION; ROLLBACK",
+			expectedOutput: []SQLStmt{&BeginTransactionStmt{}, &RollbackStmt{}},
+			expectedError:  nil,
+		},
+		{
+			input:          "COMMIT TRANSACTION",
+			expectedOutput: []SQLStmt{&CommitStmt{}},
+			expectedError:  nil,
+		},
+		{
+			input:          "BEGIN READ WRITE",
+			expectedOutput: []SQLStmt{&BeginTransactionStmt{}},
+			expectedError:  nil,
+		},
+		{
+			input:          "BEGIN TRANSACTION READ ONLY",
+			expectedOutput: nil,
+			expectedError:  errors.New("syntax error: unexpected IDENTIFIER, expecting READ WRITE"),
+		},
+	}
+
+	for i, tc := range testCases {
+		res, err := ParseString(tc.input)
+		require.Equal(t, tc.expectedError, err, fmt.Sprintf("failed on iteration %d", i))
+
+		if tc.expectedError == nil {
+			require.Equal(t, tc.expectedOutput, res, fmt.Sprintf("failed on iteration %d", i))
+		}
+	}
+}
+
+func TestSelectStmt(t *testing.T) {
+	bs, _ := hex.DecodeString("AED0393F")
+
+	testCases := []struct {
+		input          string
+		expectedOutput []SQLStmt
+		expectedError  error
+	}{
+		{
+			input: "SELECT id, title FROM table1",
+			expectedOutput: []SQLStmt{
+				&SelectStmt{
+					distinct: false,
+					selectors: []Selector{
+						&ColSelector{col: "id"},
+						&ColSelector{col: "title"},
+					},
+					ds: &TableRef{table: "table1"},
+				}},
+			expectedError: nil,
+		},
+		{
+			input: "EXPLAIN SELECT id FROM table1",
+			expectedOutput: []SQLStmt{
+				&SelectStmt{
+					selectors: []Selector{
+						&ColSelector{col: "id"},
+					},
+					ds:      &TableRef{table: "table1"},
+					explain: true,
+				}},
+			expectedError: nil,
+		},
+		{
+			input: "EXPLAIN ANALYZE SELECT id FROM table1;",
+			expectedOutput: []SQLStmt{
+				&SelectStmt{
+					selectors: []Selector{
+						&ColSelector{col: "id"},
+					},
+					ds:      &TableRef{table: "table1"},
+					explain: true,
+					analyze: true,
+				}},
+			expectedError: nil,
+		},
+		{
+			input:          "EXPLAIN DELETE FROM table1",
+			expectedOutput: nil,
+			expectedError:  errors.New("syntax error: unexpected DELETE, expecting SELECT"),
+		},
+		{
+			input: "SELECT id, title FROM db1.table1 AS t1",
+			expectedOutput: []SQLStmt{
+				&SelectStmt{
+					distinct: false,
+					selectors: []Selector{
+						&ColSelector{col: "id"},
+						&ColSelector{col: "title"},
+					},
+					ds: &TableRef{db: "db1", table: "table1"},
+					as: "t1",
+				}},
+			expectedError: nil,
+		},
+		{
+			input: "SELECT t1.id, title FROM (db1.table1 AS t1)",
+			expectedOutput: []SQLStmt{
+				&SelectStmt{
+					distinct: false,
+					selectors: []Selector{
+						&ColSelector{table: "t1", col: "id"},
+						&ColSelector{col: "title"},
+					},
+					ds: &TableRef{db: "db1", table: "table1", as: "t1"},
+				}},
+			expectedError: nil,
+		},
+		{
+			input: "SELECT id, title FROM (table1 BEFORE TX 10 AS t1)",
+			expectedOutput: []SQLStmt{
+				&SelectStmt{
+					distinct: false,
+					selectors: []Selector{
+						&ColSelector{col: "id"},
+						&ColSelector{col: "title"},
+					},
+					ds: &TableRef{table: "table1", asBefore: 10, as: "t1"},
+				}},
+			expectedError: nil,
+		},
+		{
+			input: "SELECT id FROM table1 BEFORE TX 10 AS t1",
+			expectedOutput: []SQLStmt{
+				&SelectStmt{
+					selectors: []Selector{
+						&ColSelector{col: "id"},
+					},
+					ds: &TableRef{table: "table1", asBefore: 10},
+					as: "t1",
+				}},
+			expectedError: nil,
+		},
+		{
+			input: "SELECT id FROM table1 SINCE TX 5 WHERE id > 1",
+			expectedOutput: []SQLStmt{
+				&SelectStmt{
+					selectors: []Selector{
+						&ColSelector{col: "id"},
+					},
+					ds:    &TableRef{table: "table1", sinceTx: 5},
+					where: &CmpBoolExp{op: GT, left: &ColSelector{col: "id"}, right: &Number{val: 1}},
+				}},
+			expectedError: nil,
+		},
+		{
+			input: "SELECT id FROM (db1.table1 SINCE TX 5 BEFORE TX 10 AS t1)",
+			expectedOutput: []SQLStmt{
+				&SelectStmt{
+					selectors: []Selector{
+						&ColSelector{col: "id"},
+					},
+					ds: &TableRef{db: "db1", table: "table1", sinceTx: 5, asBefore: 10, as: "t1"},
+				}},
+			expectedError: nil,
+		},
+		{
+			input: "SELECT id, title FROM (table1 AS OF TIMESTAMP 1633046400)",
+			expectedOutput: []SQLStmt{
+				&SelectStmt{
+					distinct: false,
+					selectors: []Selector{
+						&ColSelector{col: "id"},
+						&ColSelector{col: "title"},
+					},
+					ds: &TableRef{table: "table1", asOf: &Number{val: 1633046400}},
+				}},
+			expectedError: nil,
+		},
+		{
+			input: "SELECT id, title FROM (db1.table1 AS OF TIMESTAMP 1633046400 AS t1)",
+			expectedOutput: []SQLStmt{
+				&SelectStmt{
+					distinct: false,
+					selectors: []Selector{
+						&ColSelector{col: "id"},
+						&ColSelector{col: "title"},
+					},
+					ds: &TableRef{db: "db1", table: "table1", asOf: &Number{val: 1633046400}, as: "t1"},
+				}},
+			expectedError: nil,
+		},
+		{
+			input: "SELECT id FROM table1 AS OF TIMESTAMP NOW() WHERE id > 0",
+			expectedOutput: []SQLStmt{
+				&SelectStmt{
+					selectors: []Selector{
+						&ColSelector{col: "id"},
+					},
+					ds: &TableRef{table: "table1", asOf: &SysFn{fn: "now"}},
+					where: &CmpBoolExp{
+						op:    GT,
+						left:  &ColSelector{col: "id"},
+						right: &Number{val: 0},
+					},
+				}},
+			expectedError: nil,
+		},
+		{
+			input: "SELECT id FROM table1 AS OF TIMESTAMP @ts AS t1",
+			expectedOutput: []SQLStmt{
+				&SelectStmt{
+					selectors: []Selector{
+						&ColSelector{col: "id"},
+					},
+					ds: &TableRef{table: "table1", asOf: &Param{id: "ts"}},
+					as: "t1",
+				}},
+			expectedError: nil,
+		},
+		{
+			input: "SELECT id FROM (table1 AS OF TIMESTAMP '2021-10-01 00:00:00' AS t1)",
+			expectedOutput: []SQLStmt{
+				&SelectStmt{
+					selectors: []Selector{
+						&ColSelector{col: "id"},
+					},
+					ds: &TableRef{table: "table1", asOf: &Varchar{val: "2021-10-01 00:00:00"}, as: "t1"},
+				}},
+			expectedError: nil,
+		},
+		{
+			input:          "SELECT id, title FROM table1 AS OF INTEGER 1633046400",
+			expectedOutput: nil,
+			expectedError:  errors.New("syntax error: unexpected TYPE, expecting TIMESTAMP"),
+		},
+		{
+			input:          "SELECT id, title FROM (table1 AS OF INTEGER 1633046400)",
+			expectedOutput: nil,
+			expectedError:  errors.New("syntax error: unexpected TYPE, expecting TIMESTAMP"),
+		},
+		{
+			input: "SELECT db1.table1.id, title FROM (db1.table1 AS t1) WHERE payload >= x'AED0393F'",
+			expectedOutput: []SQLStmt{
+				&SelectStmt{
+					distinct: false,
+					selectors: []Selector{
+						&ColSelector{db: "db1", table: "table1", col: "id"},
+						&ColSelector{col: "title"},
+					},
+					ds: &TableRef{db: "db1", table: "table1", as: "t1"},
+					where: &CmpBoolExp{
+						op: GE,
+						left: &ColSelector{
+							col: "payload",
+						},
+						right: &Blob{val: bs},
+					},
+				}},
+			expectedError: nil,
+		},
+		{
+			input: "SELECT DISTINCT id, time, name FROM table1 WHERE country = 'US' AND time <= NOW() AND name = @pname",
+			expectedOutput: []SQLStmt{
+				&SelectStmt{
+					distinct: true,
+					selectors: []Selector{
+						&ColSelector{col: "id"},
+						&ColSelector{col: "time"},
+						&ColSelector{col: "name"},
+					},
+					ds: &TableRef{table: "table1"},
+					where: &BinBoolExp{
+						op: AND,
+						left: &BinBoolExp{
+							op: AND,
+							left: &CmpBoolExp{
+								op: EQ,
+								left: &ColSelector{
+									col: "country",
+								},
+								right: &Varchar{val: "US"},
+							},
+							right: &CmpBoolExp{
+								op: LE,
+								left: &ColSelector{
+									col: "time",
+								},
+								right: &SysFn{fn: "now"},
+							},
+						},
+						right: &CmpBoolExp{
+							op: EQ,
+							left: &ColSelector{
+								col: "name",
+							},
+							right: &Param{id: "pname"},
+						},
+					},
+				}},
+			expectedError: nil,
+		},
+		{
+			input: "SELECT id, title, year FROM table1 ORDER BY title ASC, year DESC",
+			expectedOutput: []SQLStmt{
+				&SelectStmt{
+					distinct: false,
+					selectors: []Selector{
+						&ColSelector{col: "id"},
+						&ColSelector{col: "title"},
+						&ColSelector{col: "year"},
+					},
+					ds: &TableRef{table: "table1"},
+					orderBy: []*OrdCol{
+						{exp: &ColSelector{col: "title"}, cmp: GreaterOrEqualTo},
+						{exp: &ColSelector{col: "year"}, cmp: LowerOrEqualTo},
+					},
+				}},
+			expectedError: nil,
+		},
+		{
+			input: "SELECT id FROM table1 ORDER BY year - @y DESC, COUNT()",
+			expectedOutput: []SQLStmt{
+				&SelectStmt{
+					distinct: false,
+					selectors: []Selector{
+						&ColSelector{col: "id"},
+					},
+					ds: &TableRef{table: "table1"},
+					orderBy: []*OrdCol{
+						{exp: &NumExp{op: SUBSOP, left: &ColSelector{col: "year"}, right: &Param{id: "y"}}, cmp: LowerOrEqualTo},
+						{exp: &AggColSelector{aggFn: COUNT, col: "*"}, cmp: GreaterOrEqualTo},
+					},
+				}},
+			expectedError: nil,
+		},
+		{
+			input: "SELECT id, name, table2.status FROM table1 INNER JOIN table2 ON table1.id = table2.id WHERE name = 'John' ORDER BY name DESC",
+			expectedOutput: []SQLStmt{
+				&SelectStmt{
+					distinct: false,
+					selectors: []Selector{
+						&ColSelector{col: "id"},
+						&ColSelector{col: "name"},
+						&ColSelector{table: "table2", col: "status"},
+					},
+					ds: &TableRef{table: "table1"},
+					joins: []*JoinSpec{
+						{
+							joinType: InnerJoin,
+							ds:       &TableRef{table: "table2"},
+							cond: &CmpBoolExp{
+								op: EQ,
+								left: &ColSelector{
+									table: "table1",
+									col:   "id",
+								},
+								right: &ColSelector{
+									table: "table2",
+									col:   "id",
+								},
+							},
+						},
 					},
-				},
-			},
+					where: &CmpBoolExp{
+						op:    EQ,
+						left:  &ColSelector{col: "name"},
+						right: &Varchar{val: "John"},
+					},
+					orderBy: []*OrdCol{
+						{exp: &ColSelector{col: "name"}, cmp: LowerOrEqualTo},
+					},
+				}},
 			expectedError: nil,
 		},
 		{
-			input: "BEGIN TRANSACTION CREATE TABLE table1 (id INTEGER, label VARCHAR NOT NULL, PRIMARY KEY id); UPSERT INTO table1 (id, label) VALUES (100, 'label1'); COMMIT;",
+			input: "SELECT id, name, table2.status FROM table1 LEFT JOIN table2 ON table1.id = table2.id WHERE name = 'John' ORDER BY name DESC",
 			expectedOutput: []SQLStmt{
-				&TxStmt{
-					stmts: []SQLStmt{
-						&CreateTableStmt{
-							table: "table1",
-							colsSpec: []*ColSpec{
-								{colName: "id", colType: IntegerType},
-								{colName: "label", colType: VarcharType, notNull: true},
-							},
-							pk: "id",
-						},
-						&UpsertIntoStmt{
-							tableRef: &TableRef{table: "table1"},
-							cols:     []string{"id", "label"},
-							rows: []*RowSpec{
-								{Values: []ValueExp{&Number{val: 100}, &Varchar{val: "label1"}}},
+				&SelectStmt{
+					distinct: false,
+					selectors: []Selector{
+						&ColSelector{col: "id"},
+						&ColSelector{col: "name"},
+						&ColSelector{table: "table2", col: "status"},
+					},
+					ds: &TableRef{table: "table1"},
+					joins: []*JoinSpec{
+						{
+							joinType: LeftJoin,
+							ds:       &TableRef{table: "table2"},
+							cond: &CmpBoolExp{
+								op: EQ,
+								left: &ColSelector{
+									table: "table1",
+									col:   "id",
+								},
+								right: &ColSelector{
+									table: "table2",
+									col:   "id",
+								},
 							},
 						},
 					},
-				},
-			},
+					where: &CmpBoolExp{
+						op:    EQ,
+						left:  &ColSelector{col: "name"},
+						right: &Varchar{val: "John"},
+					},
+					orderBy: []*OrdCol{
+						{exp: &ColSelector{col: "name"}, cmp: LowerOrEqualTo},
+					},
+				}},
 			expectedError: nil,
 		},
 		{
-			input:          "BEGIN TRANSACTION UPSERT INTO table1 (id, label) VALUES (100, 'label1');",
-			expectedOutput: nil,
-			expectedError:  errors.New("syntax error: unexpected $end, expecting COMMIT"),
+			input: "SELECT id, table2.status FROM table1 RIGHT OUTER JOIN table2 ON table1.id = table2.id",
+			expectedOutput: []SQLStmt{
+				&SelectStmt{
+					distinct: false,
+					selectors: []Selector{
+						&ColSelector{col: "id"},
+						&ColSelector{table: "table2", col: "status"},
+					},
+					ds: &TableRef{table: "table1"},
+					joins: []*JoinSpec{
+						{
+							joinType: RightJoin,
+							ds:       &TableRef{table: "table2"},
+							cond: &CmpBoolExp{
+								op:    EQ,
+								left:  &ColSelector{table: "table1", col: "id"},
+								right: &ColSelector{table: "table2", col: "id"},
+							},
+						},
+					},
+				}},
+			expectedError: nil,
 		},
 		{
-			input:          "BEGIN TRANSACTION UPSERT INTO table1 (id, label) VALUES (100, 'label1'); BEGIN TRANSACTION CREATE TABLE table1 (id INTEGER, label VARCHAR, PRIMARY KEY id) COMMIT; COMMIT",
+			input:          "SELECT id FROM table1 INNER OUTER JOIN table2 ON table1.id = table2.id",
 			expectedOutput: nil,
-			expectedError:  errors.New("syntax error: unexpected BEGIN, expecting COMMIT"),
+			expectedError:  errors.New("syntax error: unexpected OUTER, expecting JOIN"),
 		},
-	}
-
-	for i, tc := range testCases {
-		res, err := ParseString(tc.input)
-		require.Equal(t, tc.expectedError, err, fmt.Sprintf("failed on iteration %d", i))
-
-		if tc.expectedError == nil {
-			require.Equal(t, tc.expectedOutput, res, fmt.Sprintf("failed on iteration %d", i))
-		}
-	}
-}
-
-func TestSelectStmt(t *testing.T) {
-	bs, _ := hex.DecodeString("AED0393F")
-
-	testCases := []struct {
-		input          string
-		expectedOutput []SQLStmt
-		expectedError  error
-	}{
 		{
-			input: "SELECT id, title FROM table1",
+			input: "SELECT id, title FROM (SELECT col1 AS id, col2 AS title FROM table2 LIMIT 100) LIMIT 10",
 			expectedOutput: []SQLStmt{
 				&SelectStmt{
 					distinct: false,
@@ -541,247 +1441,292 @@ func TestSelectStmt(t *testing.T) {
 						&ColSelector{col: "id"},
 						&ColSelector{col: "title"},
 					},
-					ds: &TableRef{table: "table1"},
+					ds: &SelectStmt{
+						distinct: false,
+						selectors: []Selector{
+							&ColSelector{col: "col1", as: "id"},
+							&ColSelector{col: "col2", as: "title"},
+						},
+						ds:    &TableRef{table: "table2"},
+						limit: uint64(100),
+					},
+					limit: uint64(10),
 				}},
 			expectedError: nil,
 		},
 		{
-			input: "SELECT id, title FROM db1.table1 AS t1",
+			input: "SELECT id, name, time FROM table1 WHERE time >= '20210101 00:00:00.000' AND time < '20210211 00:00:00.000'",
 			expectedOutput: []SQLStmt{
 				&SelectStmt{
 					distinct: false,
 					selectors: []Selector{
 						&ColSelector{col: "id"},
-						&ColSelector{col: "title"},
+						&ColSelector{col: "name"},
+						&ColSelector{col: "time"},
+					},
+					ds: &TableRef{table: "table1"},
+					where: &BinBoolExp{
+						op: AND,
+						left: &CmpBoolExp{
+							op: GE,
+							left: &ColSelector{
+								col: "time",
+							},
+							right: &Varchar{val: "20210101 00:00:00.000"},
+						},
+						right: &CmpBoolExp{
+							op: LT,
+							left: &ColSelector{
+								col: "time",
+							},
+							right: &Varchar{val: "20210211 00:00:00.000"},
+						},
 					},
-					ds: &TableRef{db: "db1", table: "table1"},
-					as: "t1",
 				}},
 			expectedError: nil,
 		},
+	}
+
+	for i, tc := range testCases {
+		res, err := ParseString(tc.input)
+		require.Equal(t, tc.expectedError, err, fmt.Sprintf("failed on iteration %d", i))
+
+		if tc.expectedError == nil {
+			require.Equal(t, tc.expectedOutput, res, fmt.Sprintf("failed on iteration %d", i))
+		}
+	}
+}
+
+func TestAggFnStmt(t *testing.T) {
+	testCases := []struct {
+		input          string
+		expectedOutput []SQLStmt
+		expectedError  error
+	}{
 		{
-			input: "SELECT t1.id, title FROM (db1.table1 AS t1)",
+			input: "SELECT COUNT() FROM table1",
 			expectedOutput: []SQLStmt{
 				&SelectStmt{
 					distinct: false,
 					selectors: []Selector{
-						&ColSelector{table: "t1", col: "id"},
-						&ColSelector{col: "title"},
+						&AggColSelector{aggFn: COUNT, col: "*"},
 					},
-					ds: &TableRef{db: "db1", table: "table1", as: "t1"},
+					ds: &TableRef{table: "table1"},
 				}},
 			expectedError: nil,
 		},
 		{
-			input: "SELECT db1.table1.id, title FROM (db1.table1 AS t1) WHERE payload >= x'AED0393F'",
+			input: "SELECT country, SUM(amount) FROM table1 GROUP BY country HAVING SUM(amount) > 0",
 			expectedOutput: []SQLStmt{
 				&SelectStmt{
 					distinct: false,
 					selectors: []Selector{
-						&ColSelector{db: "db1", table: "table1", col: "id"},
-						&ColSelector{col: "title"},
+						&ColSelector{col: "country"},
+						&AggColSelector{aggFn: SUM, col: "amount"},
 					},
-					ds: &TableRef{db: "db1", table: "table1", as: "t1"},
-					where: &CmpBoolExp{
-						op: GE,
-						left: &ColSelector{
-							col: "payload",
-						},
-						right: &Blob{val: bs},
+					ds: &TableRef{table: "table1"},
+					groupBy: []ValueExp{
+						&ColSelector{col: "country"},
+					},
+					having: &CmpBoolExp{
+						op:    GT,
+						left:  &AggColSelector{aggFn: SUM, col: "amount"},
+						right: &Number{val: 0},
 					},
 				}},
 			expectedError: nil,
 		},
 		{
-			input: "SELECT DISTINCT id, time, name FROM table1 WHERE country = 'US' AND time <= NOW() AND name = @pname",
+			input: "SELECT COUNT(), MAX(amount) FROM table1 GROUP BY amount / @d, country HAVING AVG(amount) > 10",
 			expectedOutput: []SQLStmt{
 				&SelectStmt{
-					distinct: true,
+					distinct: false,
 					selectors: []Selector{
-						&ColSelector{col: "id"},
-						&ColSelector{col: "time"},
-						&ColSelector{col: "name"},
+						&AggColSelector{aggFn: COUNT, col: "*"},
+						&AggColSelector{aggFn: MAX, col: "amount"},
 					},
 					ds: &TableRef{table: "table1"},
-					where: &BinBoolExp{
-						op: AND,
-						left: &BinBoolExp{
-							op: AND,
-							left: &CmpBoolExp{
-								op: EQ,
-								left: &ColSelector{
-									col: "country",
-								},
-								right: &Varchar{val: "US"},
-							},
-							right: &CmpBoolExp{
-								op: LE,
-								left: &ColSelector{
-									col: "time",
-								},
-								right: &SysFn{fn: "now"},
-							},
-						},
-						right: &CmpBoolExp{
-							op: EQ,
-							left: &ColSelector{
-								col: "name",
-							},
-							right: &Param{id: "pname"},
-						},
+					groupBy: []ValueExp{
+						&NumExp{op: DIVOP, left: &ColSelector{col: "amount"}, right: &Param{id: "d"}},
+						&ColSelector{col: "country"},
+					},
+					having: &CmpBoolExp{
+						op:    GT,
+						left:  &AggColSelector{aggFn: AVG, col: "amount"},
+						right: &Number{val: 10},
 					},
 				}},
 			expectedError: nil,
 		},
 		{
-			input: "SELECT id, title, year FROM table1 ORDER BY title ASC, year DESC",
+			input: "SELECT country, COUNT(DISTINCT t.customer) FROM (table1 AS t) GROUP BY country",
 			expectedOutput: []SQLStmt{
 				&SelectStmt{
 					distinct: false,
 					selectors: []Selector{
-						&ColSelector{col: "id"},
-						&ColSelector{col: "title"},
-						&ColSelector{col: "year"},
+						&ColSelector{col: "country"},
+						&AggColSelector{aggFn: COUNT, table: "t", col: "customer", distinct: true},
 					},
-					ds: &TableRef{table: "table1"},
-					orderBy: []*OrdCol{
-						{sel: &ColSelector{col: "title"}, cmp: GreaterOrEqualTo},
-						{sel: &ColSelector{col: "year"}, cmp: LowerOrEqualTo},
+					ds: &TableRef{table: "table1", as: "t"},
+					groupBy: []ValueExp{
+						&ColSelector{col: "country"},
 					},
 				}},
 			expectedError: nil,
 		},
 		{
-			input: "SELECT id, name, table2.status FROM table1 INNER JOIN table2 ON table1.id = table2.id WHERE name = 'John' ORDER BY name DESC",
+			input:          "SELECT SUM(DISTINCT amount) FROM table1",
+			expectedOutput: nil,
+			expectedError:  errors.New("DISTINCT is only supported by COUNT"),
+		},
+	}
+
+	for i, tc := range testCases {
+		res, err := ParseString(tc.input)
+		require.Equal(t, tc.expectedError, err, fmt.Sprintf("failed on iteration %d", i))
+
+		if tc.expectedError == nil {
+			require.Equal(t, tc.expectedOutput, res, fmt.Sprintf("failed on iteration %d", i))
+		}
+	}
+}
+
+func TestJSONSelectorStmt(t *testing.T) {
+	testCases := []struct {
+		input          string
+		expectedOutput []SQLStmt
+		expectedError  error
+	}{
+		{
+			input: "SELECT doc->'address'->>'city' AS city, doc->'tags'->0 FROM table1 WHERE doc->'age' > 30",
 			expectedOutput: []SQLStmt{
 				&SelectStmt{
 					distinct: false,
 					selectors: []Selector{
-						&ColSelector{col: "id"},
-						&ColSelector{col: "name"},
-						&ColSelector{table: "table2", col: "status"},
+						&JSONSelector{col: &ColSelector{col: "doc"}, path: []interface{}{"address", "city"}, asText: true, as: "city"},
+						&JSONSelector{col: &ColSelector{col: "doc"}, path: []interface{}{"tags", uint64(0)}},
 					},
 					ds: &TableRef{table: "table1"},
-					joins: []*JoinSpec{
-						{
-							joinType: InnerJoin,
-							ds:       &TableRef{table: "table2"},
-							cond: &CmpBoolExp{
-								op: EQ,
-								left: &ColSelector{
-									table: "table1",
-									col:   "id",
-								},
-								right: &ColSelector{
-									table: "table2",
-									col:   "id",
-								},
-							},
-						},
-					},
 					where: &CmpBoolExp{
-						op:    EQ,
-						left:  &ColSelector{col: "name"},
-						right: &Varchar{val: "John"},
-					},
-					orderBy: []*OrdCol{
-						{sel: &ColSelector{col: "name"}, cmp: LowerOrEqualTo},
+						op:    GT,
+						left:  &JSONSelector{col: &ColSelector{col: "doc"}, path: []interface{}{"age"}},
+						right: &Number{val: 30},
 					},
 				}},
 			expectedError: nil,
 		},
 		{
-			input: "SELECT id, name, table2.status FROM table1 LEFT JOIN table2 ON table1.id = table2.id WHERE name = 'John' ORDER BY name DESC",
+			input: `SELECT JSON_VALUE(t.doc, '$.address."zip code"[1]') FROM t`,
 			expectedOutput: []SQLStmt{
 				&SelectStmt{
 					distinct: false,
 					selectors: []Selector{
-						&ColSelector{col: "id"},
-						&ColSelector{col: "name"},
-						&ColSelector{table: "table2", col: "status"},
-					},
-					ds: &TableRef{table: "table1"},
-					joins: []*JoinSpec{
-						{
-							joinType: LeftJoin,
-							ds:       &TableRef{table: "table2"},
-							cond: &CmpBoolExp{
-								op: EQ,
-								left: &ColSelector{
-									table: "table1",
-									col:   "id",
-								},
-								right: &ColSelector{
-									table: "table2",
-									col:   "id",
-								},
-							},
+						&JSONSelector{
+							col:         &ColSelector{table: "t", col: "doc"},
+							path:        []interface{}{"address", "zip code", uint64(1)},
+							asText:      true,
+							scalarsOnly: true,
 						},
 					},
-					where: &CmpBoolExp{
-						op:    EQ,
-						left:  &ColSelector{col: "name"},
-						right: &Varchar{val: "John"},
-					},
-					orderBy: []*OrdCol{
-						{sel: &ColSelector{col: "name"}, cmp: LowerOrEqualTo},
-					},
+					ds: &TableRef{table: "t"},
 				}},
 			expectedError: nil,
 		},
 		{
-			input: "SELECT id, title FROM (SELECT col1 AS id, col2 AS title FROM table2 LIMIT 100) LIMIT 10",
+			input:          "SELECT doc->>'a'->'b' FROM table1",
+			expectedOutput: nil,
+			expectedError:  errors.New("invalid JSON path: values can not be extracted from text"),
+		},
+		{
+			input:          "SELECT COUNT()->'a' FROM table1",
+			expectedOutput: nil,
+			expectedError:  errors.New("illegal extraction. JSON column expected"),
+		},
+		{
+			input:          "SELECT JSON_QUERY(doc, '$.a') FROM table1",
+			expectedOutput: nil,
+			expectedError:  errors.New("function does not exist: json_query"),
+		},
+	}
+
+	for i, tc := range testCases {
+		res, err := ParseString(tc.input)
+		require.Equal(t, tc.expectedError, err, fmt.Sprintf("failed on iteration %d", i))
+
+		if tc.expectedError == nil {
+			require.Equal(t, tc.expectedOutput, res, fmt.Sprintf("failed on iteration %d", i))
+		}
+	}
+}
+
+func TestCaseWhenStmt(t *testing.T) {
+	testCases := []struct {
+		input          string
+		expectedOutput []SQLStmt
+		expectedError  error
+	}{
+		{
+			input: "SELECT id, CASE WHEN age < 18 THEN 'minor' WHEN age IS NULL THEN NULL ELSE 'adult' END AS age_group FROM clients",
 			expectedOutput: []SQLStmt{
 				&SelectStmt{
-					distinct: false,
 					selectors: []Selector{
 						&ColSelector{col: "id"},
-						&ColSelector{col: "title"},
-					},
-					ds: &SelectStmt{
-						distinct: false,
-						selectors: []Selector{
-							&ColSelector{col: "col1", as: "id"},
-							&ColSelector{col: "col2", as: "title"},
+						&CaseWhenExp{
+							whens: []*whenThen{
+								{
+									when: &CmpBoolExp{op: LT, left: &ColSelector{col: "age"}, right: &Number{val: 18}},
+									then: &Varchar{val: "minor"},
+								},
+								{
+									when: &IsNullBoolExp{exp: &ColSelector{col: "age"}},
+									then: &NullValue{},
+								},
+							},
+							elseExp: &Varchar{val: "adult"},
+							as:      "age_group",
 						},
-						ds:    &TableRef{table: "table2"},
-						limit: uint64(100),
 					},
-					limit: uint64(10),
+					ds: &TableRef{table: "clients"},
 				}},
 			expectedError: nil,
 		},
 		{
-			input: "SELECT id, name, time FROM table1 WHERE time >= '20210101 00:00:00.000' AND time < '20210211 00:00:00.000'",
+			input: "SELECT id FROM clients WHERE CASE country WHEN 'ES' THEN 1 WHEN @country THEN 2 END > 0",
 			expectedOutput: []SQLStmt{
 				&SelectStmt{
-					distinct: false,
 					selectors: []Selector{
 						&ColSelector{col: "id"},
-						&ColSelector{col: "name"},
-						&ColSelector{col: "time"},
 					},
-					ds: &TableRef{table: "table1"},
-					where: &BinBoolExp{
-						op: AND,
-						left: &CmpBoolExp{
-							op: GE,
-							left: &ColSelector{
-								col: "time",
-							},
-							right: &Varchar{val: "20210101 00:00:00.000"},
-						},
-						right: &CmpBoolExp{
-							op: LT,
-							left: &ColSelector{
-								col: "time",
+					ds: &TableRef{table: "clients"},
+					where: &CmpBoolExp{
+						op: GT,
+						left: &CaseWhenExp{
+							exp: &ColSelector{col: "country"},
+							whens: []*whenThen{
+								{when: &Varchar{val: "ES"}, then: &Number{val: 1}},
+								{when: &Param{id: "country"}, then: &Number{val: 2}},
 							},
-							right: &Varchar{val: "20210211 00:00:00.000"},
 						},
+						right: &Number{val: 0},
 					},
 				}},
 			expectedError: nil,
 		},
+		{
+			input:          "SELECT SUM(CASE WHEN paid THEN amount ELSE 0 END) FROM invoices",
+			expectedOutput: nil,
+			expectedError:  errors.New("syntax error: unexpected CASE, expecting DISTINCT or IDENTIFIER or ')'"),
+		},
+		{
+			input:          "SELECT CASE WHEN paid THEN 1 FROM invoices",
+			expectedOutput: nil,
+			expectedError:  errors.New("syntax error: unexpected FROM, expecting END"),
+		},
+		{
+			input:          "SELECT CASE ELSE 1 END FROM invoices",
+			expectedOutput: nil,
+			expectedError:  errors.New("syntax error: unexpected ELSE, expecting WHEN"),
+		},
 	}
 
 	for i, tc := range testCases {
@@ -794,45 +1739,61 @@ func TestSelectStmt(t *testing.T) {
 	}
 }
 
-func TestAggFnStmt(t *testing.T) {
+func TestFnCallStmt(t *testing.T) {
 	testCases := []struct {
 		input          string
 		expectedOutput []SQLStmt
 		expectedError  error
 	}{
 		{
-			input: "SELECT COUNT() FROM table1",
+			input: "SELECT UPPER(name) AS uname, SUBSTRING(name, 1, @len), CONCAT(name, ' ', surname) FROM clients WHERE LENGTH(name) > 3",
 			expectedOutput: []SQLStmt{
 				&SelectStmt{
-					distinct: false,
 					selectors: []Selector{
-						&AggColSelector{aggFn: COUNT, col: "*"},
+						&FnCall{fn: "upper", params: []ValueExp{&ColSelector{col: "name"}}, as: "uname"},
+						&FnCall{fn: "substring", params: []ValueExp{&ColSelector{col: "name"}, &Number{val: 1}, &Param{id: "len"}}},
+						&FnCall{fn: "concat", params: []ValueExp{&ColSelector{col: "name"}, &Varchar{val: " "}, &ColSelector{col: "surname"}}},
+					},
+					ds: &TableRef{table: "clients"},
+					where: &CmpBoolExp{
+						op:    GT,
+						left:  &FnCall{fn: "length", params: []ValueExp{&ColSelector{col: "name"}}},
+						right: &Number{val: 3},
 					},
-					ds: &TableRef{table: "table1"},
 				}},
 			expectedError: nil,
 		},
 		{
-			input: "SELECT country, SUM(amount) FROM table1 GROUP BY country HAVING SUM(amount) > 0",
+			input: "SELECT COALESCE(nickname, LOWER(name), 'unknown'), CAST(age AS VARCHAR) FROM clients",
 			expectedOutput: []SQLStmt{
 				&SelectStmt{
-					distinct: false,
 					selectors: []Selector{
-						&ColSelector{col: "country"},
-						&AggColSelector{aggFn: SUM, col: "amount"},
-					},
-					ds: &TableRef{table: "table1"},
-					groupBy: []*ColSelector{
-						{col: "country"},
-					},
-					having: &CmpBoolExp{
-						op:    GT,
-						left:  &AggColSelector{aggFn: SUM, col: "amount"},
-						right: &Number{val: 0},
+						&FnCall{fn: "coalesce", params: []ValueExp{
+							&ColSelector{col: "nickname"},
+							&FnCall{fn: "lower", params: []ValueExp{&ColSelector{col: "name"}}},
+							&Varchar{val: "unknown"},
+						}},
+						&FnCall{fn: "cast", params: []ValueExp{&ColSelector{col: "age"}, &Varchar{val: "VARCHAR"}}},
 					},
+					ds: &TableRef{table: "clients"},
 				}},
 			expectedError: nil,
 		},
+		{
+			input:          "SELECT REVERSE(name) FROM clients",
+			expectedOutput: nil,
+			expectedError:  errors.New("function does not exist: reverse"),
+		},
+		{
+			input:          "SELECT UPPER(name, surname) FROM clients",
+			expectedOutput: nil,
+			expectedError:  errors.New("invalid function parameters: wrong number of parameters for UPPER"),
+		},
+		{
+			input:          "SELECT CAST(age AS NUMBER) FROM clients",
+			expectedOutput: nil,
+			expectedError:  errors.New("syntax error: unexpected IDENTIFIER, expecting TYPE"),
+		},
 	}
 
 	for i, tc := range testCases {
@@ -993,11 +1954,11 @@ func TestExpressions(t *testing.T) {
 					},
 					ds: &TableRef{table: "table1"},
 					where: &LikeBoolExp{
-						sel: &ColSelector{
+						val: &ColSelector{
 							table: "table1",
 							col:   "title",
 						},
-						pattern: "J%O",
+						pattern: &Varchar{val: "J%O"},
 					},
 				}},
 			expectedError: nil,
@@ -1034,11 +1995,11 @@ func TestExpressions(t *testing.T) {
 							},
 						},
 						right: &LikeBoolExp{
-							sel: &ColSelector{
+							val: &ColSelector{
 								table: "table1",
 								col:   "title",
 							},
-							pattern: "J%O",
+							pattern: &Varchar{val: "J%O"},
 						},
 					},
 				}},
@@ -1074,6 +2035,161 @@ func TestExpressions(t *testing.T) {
 				}},
 			expectedError: nil,
 		},
+		{
+			input: "SELECT id FROM clients WHERE id IN (1, 2) AND country NOT IN ('es', @country)",
+			expectedOutput: []SQLStmt{
+				&SelectStmt{
+					selectors: []Selector{
+						&ColSelector{col: "id"},
+					},
+					ds: &TableRef{table: "clients"},
+					where: &BinBoolExp{
+						op: AND,
+						left: &InListExp{
+							val:    &ColSelector{col: "id"},
+							values: []ValueExp{&Number{val: 1}, &Number{val: 2}},
+						},
+						right: &InListExp{
+							val:    &ColSelector{col: "country"},
+							notIn:  true,
+							values: []ValueExp{&Varchar{val: "es"}, &Param{id: "country"}},
+						},
+					},
+				}},
+			expectedError: nil,
+		},
+		{
+			input: "SELECT id FROM clients WHERE id IN @ids OR country NOT IN @countries",
+			expectedOutput: []SQLStmt{
+				&SelectStmt{
+					selectors: []Selector{
+						&ColSelector{col: "id"},
+					},
+					ds: &TableRef{table: "clients"},
+					where: &BinBoolExp{
+						op: OR,
+						left: &InListExp{
+							val:    &ColSelector{col: "id"},
+							values: []ValueExp{&Param{id: "ids"}},
+						},
+						right: &InListExp{
+							val:    &ColSelector{col: "country"},
+							notIn:  true,
+							values: []ValueExp{&Param{id: "countries"}},
+						},
+					},
+				}},
+			expectedError: nil,
+		},
+		{
+			input: "SELECT id FROM clients WHERE id NOT IN (SELECT id_client FROM orders) OR age > (SELECT AVG(age) FROM clients)",
+			expectedOutput: []SQLStmt{
+				&SelectStmt{
+					selectors: []Selector{
+						&ColSelector{col: "id"},
+					},
+					ds: &TableRef{table: "clients"},
+					where: &BinBoolExp{
+						op: OR,
+						left: &InSubQueryExp{
+							val:   &ColSelector{col: "id"},
+							notIn: true,
+							q: &SelectStmt{
+								selectors: []Selector{
+									&ColSelector{col: "id_client"},
+								},
+								ds: &TableRef{table: "orders"},
+							},
+						},
+						right: &CmpBoolExp{
+							op:   GT,
+							left: &ColSelector{col: "age"},
+							right: &SubQueryExp{
+								q: &SelectStmt{
+									selectors: []Selector{
+										&AggColSelector{aggFn: AVG, col: "age"},
+									},
+									ds: &TableRef{table: "clients"},
+								},
+							},
+						},
+					},
+				}},
+			expectedError: nil,
+		},
+		{
+			input: "SELECT id FROM clients WHERE country IS NULL OR NOT (age + 1 IS NOT NULL)",
+			expectedOutput: []SQLStmt{
+				&SelectStmt{
+					selectors: []Selector{
+						&ColSelector{col: "id"},
+					},
+					ds: &TableRef{table: "clients"},
+					where: &BinBoolExp{
+						op: OR,
+						left: &IsNullBoolExp{
+							exp: &ColSelector{col: "country"},
+						},
+						right: &NotBoolExp{
+							exp: &IsNullBoolExp{
+								exp: &NumExp{
+									op:    ADDOP,
+									left:  &ColSelector{col: "age"},
+									right: &Number{val: 1},
+								},
+								isNot: true,
+							},
+						},
+					},
+				}},
+			expectedError: nil,
+		},
+		{
+			input: "SELECT id FROM clients WHERE name NOT ILIKE 'j%' AND country LIKE @country",
+			expectedOutput: []SQLStmt{
+				&SelectStmt{
+					selectors: []Selector{
+						&ColSelector{col: "id"},
+					},
+					ds: &TableRef{table: "clients"},
+					where: &BinBoolExp{
+						op: AND,
+						left: &LikeBoolExp{
+							val:             &ColSelector{col: "name"},
+							notLike:         true,
+							caseInsensitive: true,
+							pattern:         &Varchar{val: "j%"},
+						},
+						right: &LikeBoolExp{
+							val:     &ColSelector{col: "country"},
+							pattern: &Param{id: "country"},
+						},
+					},
+				}},
+			expectedError: nil,
+		},
+		{
+			input: "SELECT id FROM clients WHERE NOT name LIKE 'j%'",
+			expectedOutput: []SQLStmt{
+				&SelectStmt{
+					selectors: []Selector{
+						&ColSelector{col: "id"},
+					},
+					ds: &TableRef{table: "clients"},
+					where: &NotBoolExp{
+						exp: &LikeBoolExp{
+							val:     &ColSelector{col: "name"},
+							pattern: &Varchar{val: "j%"},
+						},
+					},
+				}},
+			expectedError: nil,
+		},
+		{
+			input:          "SELECT id FROM clients WHERE country IS 'es'",
+			expectedOutput: nil,
+			expectedError:  errors.New("syntax error: unexpected VARCHAR, expecting NOT or NULL"),
+		},
 	}
 
 	for i, tc := range testCases {
@@ -1124,7 +2240,7 @@ func TestMultiLineStmts(t *testing.T) {
 						{colName: "active", colType: BooleanType},
 						{colName: "content", colType: BLOBType},
 					},
-					pk: "id",
+					pk: []string{"id"},
 				},
 				&TxStmt{
 					stmts: []SQLStmt{
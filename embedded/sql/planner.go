@@ -0,0 +1,425 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sql
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/codenotary/immudb/embedded/store"
+)
+
+// lookupCostFactor is the cost of reading a row by its primary key, relative to the cost of reading the next entry of a scan.
+// Rows read through an index or joined by their primary key are looked up this way
+const lookupCostFactor = 4
+
+// scanPlan is a way of reading the rows of a table, along with the number of entries it's estimated to read and its cost
+type scanPlan struct {
+	// column the rows are read in the order of, the whole primary key is scanned when nil
+	ordCol *OrdCol
+	rows   float64
+	cost   float64
+}
+
+// resolveScan returns the reader of the rows of the data source of the query, joined with the rows of the joined data sources.
+// Unless rows are read in the order of an indexed column, the cheapest way of reading them is chosen according to the
+// estimated number of entries read for the conditions over the columns of the table, which are applied as soon as its rows
+// are read when there are joins. A single inner join between tables is read starting from the table estimated to be
+// the cheapest one to read, provided the rows of the other table can be looked up by their primary key
+func (stmt *SelectStmt) resolveScan(ctx context.Context, e *Engine, implicitDB *Database, snap *store.Snapshot, params map[string]interface{}, where ValueExp, orderByCol *OrdCol) (RowReader, error) {
+	tableRef, isTableRef := stmt.ds.(*TableRef)
+	if !isTableRef {
+		return stmt.resolveJoins(ctx, e, implicitDB, snap, params, stmt.ds, orderByCol, nil, stmt.joins)
+	}
+
+	var conds []ValueExp
+	if where != nil {
+		conds = conjunctions(where)
+	}
+
+	outerConds := conds
+	if len(stmt.joins) > 0 {
+		outerConds = pushableConds(e, implicitDB, conds, tableRef, stmt.joins, true)
+	}
+
+	if orderByCol != nil {
+		// rows are read from the bound set by the conditions, if any, and until the opposite one
+		startCmp, endCmp := GreaterOrEqualTo, LowerOrEqualTo
+		if orderByCol.cmp == LowerOrEqualTo {
+			startCmp, endCmp = LowerOrEqualTo, GreaterOrEqualTo
+		}
+
+		seekVal := scanBound(e, implicitDB, tableRef, outerConds, orderByCol.sel.col, startCmp, params)
+		if seekVal != nil {
+			orderByCol.initKeyVal = seekVal
+			orderByCol.useInitKeyVal = true
+		}
+
+		orderByCol.endKeyVal = scanBound(e, implicitDB, tableRef, outerConds, orderByCol.sel.col, endCmp, params)
+
+		// only the ranges of the index holding the values matched by a prefix pattern, an IN list or an equality are read
+		_, orderByCol.valPrefixes = restrictedScan(e, implicitDB, tableRef, outerConds, orderByCol, params)
+
+		return stmt.resolveJoins(ctx, e, implicitDB, snap, params, tableRef, orderByCol, outerConds, stmt.joins)
+	}
+
+	plan, err := cheapestScan(e, implicitDB, snap, tableRef, outerConds, params)
+	if err != nil {
+		return nil, err
+	}
+
+	joinedRef, swappedJoin := stmt.swappableJoin(e, implicitDB)
+	if swappedJoin != nil {
+		joinedConds := pushableConds(e, implicitDB, conds, joinedRef, nil, false)
+
+		joinedPlan, err := cheapestScan(e, implicitDB, snap, joinedRef, joinedConds, params)
+		if err != nil {
+			return nil, err
+		}
+
+		cost := math.Inf(1)
+
+		table, err := joinedRef.referencedTable(e, implicitDB)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = jointPKColumns(stmt.joins[0].cond, table, joinedRef.Alias())
+		if err == nil {
+			cost = plan.cost + plan.rows*lookupCostFactor
+		}
+
+		if joinedPlan.cost+joinedPlan.rows*lookupCostFactor < cost {
+			return stmt.resolveJoins(ctx, e, implicitDB, snap, params, joinedRef, joinedPlan.ordCol, joinedConds, []*JoinSpec{swappedJoin})
+		}
+	}
+
+	return stmt.resolveJoins(ctx, e, implicitDB, snap, params, tableRef, plan.ordCol, outerConds, stmt.joins)
+}
+
+// resolveJoins returns the reader of the rows of the data source, read as set by ordCol, joined with the rows of the joined data sources.
+// When there are joins, the conditions over the columns of the data source are applied to its rows before they're joined
+func (stmt *SelectStmt) resolveJoins(ctx context.Context, e *Engine, implicitDB *Database, snap *store.Snapshot, params map[string]interface{}, ds DataSource, ordCol *OrdCol, conds []ValueExp, joins []*JoinSpec) (RowReader, error) {
+	rowReader, err := ds.Resolve(ctx, e, implicitDB, snap, params, ordCol)
+	if err != nil {
+		return nil, err
+	}
+
+	rowReader = stmt.analyzed(rowReader)
+
+	if len(joins) == 0 {
+		return rowReader, nil
+	}
+
+	if len(conds) > 0 {
+		cond := conds[0]
+		for _, c := range conds[1:] {
+			cond = &BinBoolExp{op: AND, left: cond, right: c}
+		}
+
+		rowReader, err = e.newConditionalRowReader(rowReader, cond, params)
+		if err != nil {
+			return nil, err
+		}
+
+		rowReader = stmt.analyzed(rowReader)
+	}
+
+	if swapped, ok := ds.(*TableRef); ok && swapped != stmt.ds {
+		// unqualified columns within the condition of the join refer to the table given in the FROM clause
+		rowReader = &swappedRowReader{RowReader: rowReader, implicitTable: stmt.ds.Alias()}
+	}
+
+	jointRowReader, err := e.newJointRowReader(ctx, implicitDB, snap, params, rowReader, joins)
+	if err != nil {
+		rowReader.Close()
+		return nil, err
+	}
+
+	return stmt.analyzed(jointRowReader), nil
+}
+
+// swappableJoin returns the joined table and the join of the table given in the FROM clause to it, when the only join of
+// the query is an inner join between tables whose condition allows looking up the rows of the table given in the FROM clause
+// by their primary key, so the joined table may be read first
+func (stmt *SelectStmt) swappableJoin(e *Engine, implicitDB *Database) (*TableRef, *JoinSpec) {
+	if len(stmt.joins) != 1 || stmt.joins[0].joinType != InnerJoin {
+		return nil, nil
+	}
+
+	tableRef := stmt.ds.(*TableRef)
+
+	joinedRef, isTableRef := stmt.joins[0].ds.(*TableRef)
+	if !isTableRef || joinedRef.Alias() == tableRef.Alias() {
+		return nil, nil
+	}
+
+	table, err := tableRef.referencedTable(e, implicitDB)
+	if err != nil {
+		return nil, nil
+	}
+
+	joinedTable, err := joinedRef.referencedTable(e, implicitDB)
+	if err != nil {
+		return nil, nil
+	}
+
+	fkSels, err := jointPKColumns(stmt.joins[0].cond, table, tableRef.Alias())
+	if err != nil {
+		return nil, nil
+	}
+
+	// unqualified columns would refer to the table given in the FROM clause
+	for _, fkSel := range fkSels {
+		if fkSel.table != joinedRef.Alias() || (fkSel.db != "" && fkSel.db != joinedTable.db.name) {
+			return nil, nil
+		}
+	}
+
+	return joinedRef, &JoinSpec{joinType: InnerJoin, ds: tableRef, cond: stmt.joins[0].cond}
+}
+
+// pushableConds returns the conditions which only refer to columns of the table, so they can be applied to its rows
+// before they're joined. Unqualified columns refer to the table when it's the implicit one. Conditions are not applied
+// in advance when there are right joins, as rows without a match are returned padded with null values
+func pushableConds(e *Engine, implicitDB *Database, conds []ValueExp, tableRef *TableRef, joins []*JoinSpec, implicit bool) []ValueExp {
+	for _, jspec := range joins {
+		if jspec.joinType != InnerJoin && jspec.joinType != LeftJoin {
+			return nil
+		}
+	}
+
+	table, err := tableRef.referencedTable(e, implicitDB)
+	if err != nil {
+		return nil
+	}
+
+	var pushable []ValueExp
+
+	for _, cond := range conds {
+		sels, ok := selectorsIn(cond, nil)
+		if !ok {
+			continue
+		}
+
+		refersToTable := true
+
+		for _, sel := range sels {
+			if (sel.db != "" && sel.db != table.db.name) ||
+				(sel.table == "" && !implicit) ||
+				(sel.table != "" && sel.table != tableRef.Alias()) {
+				refersToTable = false
+				break
+			}
+
+			_, err := table.GetColumnByName(sel.col)
+			if err != nil {
+				refersToTable = false
+				break
+			}
+		}
+
+		if refersToTable {
+			pushable = append(pushable, cond)
+		}
+	}
+
+	return pushable
+}
+
+// selectorsIn appends to sels the columns used in the expression, false is returned when the expression
+// holds something other than columns, values and operations over them
+func selectorsIn(exp ValueExp, sels []*ColSelector) ([]*ColSelector, bool) {
+	switch e := exp.(type) {
+	case *NullValue, *Number, *Float, *Varchar, *Bool, *Blob, *Timestamp, *Param, *SysFn:
+		return sels, true
+	case *ColSelector:
+		return append(sels, e), true
+	case *NumExp:
+		return selectorsInAll(sels, e.left, e.right)
+	case *CmpBoolExp:
+		return selectorsInAll(sels, e.left, e.right)
+	case *BinBoolExp:
+		return selectorsInAll(sels, e.left, e.right)
+	case *NotBoolExp:
+		return selectorsIn(e.exp, sels)
+	case *IsNullBoolExp:
+		return selectorsIn(e.exp, sels)
+	case *LikeBoolExp:
+		return selectorsInAll(sels, e.val, e.pattern)
+	case *InListExp:
+		return selectorsInAll(sels, append([]ValueExp{e.val}, e.values...)...)
+	case *CaseWhenExp:
+		{
+			exps := []ValueExp{e.exp, e.elseExp}
+			for _, w := range e.whens {
+				exps = append(exps, w.when, w.then)
+			}
+
+			return selectorsInAll(sels, exps...)
+		}
+	case *FnCall:
+		return selectorsInAll(sels, e.params...)
+	}
+
+	return nil, false
+}
+
+func selectorsInAll(sels []*ColSelector, exps ...ValueExp) ([]*ColSelector, bool) {
+	for _, exp := range exps {
+		if exp == nil {
+			continue
+		}
+
+		var ok bool
+
+		sels, ok = selectorsIn(exp, sels)
+		if !ok {
+			return nil, false
+		}
+	}
+
+	return sels, true
+}
+
+// cheapestScan returns the cheapest way of reading the rows of the table satisfying the conditions, among reading the whole
+// primary key and reading the ranges of the primary key or of an index set by the conditions over their leading column
+func cheapestScan(e *Engine, implicitDB *Database, snap *store.Snapshot, tableRef *TableRef, conds []ValueExp, params map[string]interface{}) (*scanPlan, error) {
+	table, err := tableRef.referencedTable(e, implicitDB)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := e.estimatedScanKeys(snap, table, table.pk, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	cheapest := &scanPlan{rows: rows, cost: rows}
+
+	cols := []*Column{table.pk}
+
+	indexedColIDs := make([]uint64, 0, len(table.indexes))
+	for colID := range table.indexes {
+		if colID != table.pk.id {
+			indexedColIDs = append(indexedColIDs, colID)
+		}
+	}
+
+	sort.Slice(indexedColIDs, func(i, j int) bool { return indexedColIDs[i] < indexedColIDs[j] })
+
+	for _, colID := range indexedColIDs {
+		cols = append(cols, table.colsByID[colID])
+	}
+
+	for _, col := range cols {
+		costFactor := float64(1)
+		if col.id != table.pk.id {
+			// rows are looked up by the primary key held by each entry of the index
+			costFactor += lookupCostFactor
+		}
+
+		sel := &ColSelector{col: col.colName}
+
+		var candidates []*OrdCol
+
+		_, valPrefixes := restrictedScan(e, implicitDB, tableRef, conds, &OrdCol{sel: sel, cmp: GreaterOrEqualTo}, params)
+		if valPrefixes != nil {
+			candidates = append(candidates, &OrdCol{exp: sel, sel: sel, cmp: GreaterOrEqualTo, valPrefixes: valPrefixes})
+		}
+
+		startVal := scanBound(e, implicitDB, tableRef, conds, col.colName, GreaterOrEqualTo, params)
+		endVal := scanBound(e, implicitDB, tableRef, conds, col.colName, LowerOrEqualTo, params)
+
+		if startVal != nil || endVal != nil {
+			candidates = append(candidates, &OrdCol{
+				exp:           sel,
+				sel:           sel,
+				cmp:           GreaterOrEqualTo,
+				initKeyVal:    startVal,
+				useInitKeyVal: startVal != nil,
+				endKeyVal:     endVal,
+			})
+		}
+
+		for _, ordCol := range candidates {
+			rows, err := e.estimatedScanKeys(snap, table, col, ordCol)
+			if err != nil {
+				return nil, err
+			}
+
+			if rows*costFactor < cheapest.cost {
+				cheapest = &scanPlan{ordCol: ordCol, rows: rows, cost: rows * costFactor}
+			}
+		}
+	}
+
+	return cheapest, nil
+}
+
+// estimatedScanKeys returns the estimated number of entries read when scanning the table in the order of the column,
+// within the ranges set by ordCol or the whole primary key or index when it's nil
+func (e *Engine) estimatedScanKeys(snap *store.Snapshot, table *Table, col *Column, ordCol *OrdCol) (float64, error) {
+	prefix := e.scanPrefix(table, col)
+
+	if ordCol != nil && len(ordCol.valPrefixes) > 0 {
+		var keys uint64
+
+		for _, valPrefix := range ordCol.valPrefixes {
+			startKey := append(append([]byte{}, prefix...), valPrefix...)
+
+			n, err := snap.EstimatedKeys(startKey, prefixEnd(startKey))
+			if err != nil {
+				return 0, err
+			}
+
+			keys += n
+		}
+
+		return float64(keys), nil
+	}
+
+	startKey := prefix
+	endKey := prefixEnd(prefix)
+
+	if ordCol != nil && ordCol.useInitKeyVal {
+		startKey = append(append([]byte{}, prefix...), ordCol.initKeyVal...)
+	}
+
+	if ordCol != nil && ordCol.endKeyVal != nil {
+		endKey = prefixEnd(append(append([]byte{}, prefix...), ordCol.endKeyVal...))
+	}
+
+	keys, err := snap.EstimatedKeys(startKey, endKey)
+	if err != nil {
+		return 0, err
+	}
+
+	return float64(keys), nil
+}
+
+// swappedRowReader returns the rows of a joined table read before the table given in the FROM clause,
+// which remains the implicit table of the query
+type swappedRowReader struct {
+	RowReader
+	implicitTable string
+}
+
+func (r *swappedRowReader) ImplicitTable() string {
+	return r.implicitTable
+}
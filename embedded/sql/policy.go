@@ -0,0 +1,449 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sql
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type sessionUserKey struct{}
+
+type sessionUser struct {
+	name  string
+	roles []string
+}
+
+// WithUser returns a copy of the context binding the statements resolved with it to the user and its roles.
+// Rows of tables with policies are then restricted to the ones satisfying any policy applying to the user
+// or to one of its roles, no row is accessible if none of them applies. Policies can not be changed within
+// a user session.
+func WithUser(ctx context.Context, user string, roles ...string) context.Context {
+	return context.WithValue(ctx, sessionUserKey{}, &sessionUser{name: user, roles: roles})
+}
+
+func sessionUserFrom(ctx context.Context) *sessionUser {
+	u, _ := ctx.Value(sessionUserKey{}).(*sessionUser)
+	return u
+}
+
+// identifiers are lower-cased by the parser, thus user and role names are matched ignoring case
+func (p *Policy) appliesTo(u *sessionUser) bool {
+	if len(p.users) == 0 {
+		return true
+	}
+
+	for _, name := range p.users {
+		if strings.EqualFold(name, u.name) {
+			return true
+		}
+
+		for _, role := range u.roles {
+			if strings.EqualFold(name, role) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// rowFilter returns the condition rows of the table must satisfy within the user session,
+// or nil if rows are not restricted
+func (t *Table) rowFilter(u *sessionUser) ValueExp {
+	if u == nil || len(t.policies) == 0 {
+		return nil
+	}
+
+	filter := &anyBoolExp{}
+
+	for _, p := range t.policies {
+		if p.appliesTo(u) {
+			filter.exps = append(filter.exps, p.filter)
+		}
+	}
+
+	return filter
+}
+
+// checkRowFilter returns ErrPolicyViolation unless the row values satisfy the filter, missing values are read as null
+func (e *Engine) checkRowFilter(filter ValueExp, table *Table, values map[uint64]TypedValue) error {
+	r, err := filter.reduce(e.catalog, tableRow(table, values), table.db.name, table.name)
+	if err != nil {
+		return err
+	}
+
+	satisfies, isBool := r.(*Bool)
+	if !isBool || !satisfies.val {
+		return ErrPolicyViolation
+	}
+
+	return nil
+}
+
+// tableRow returns the row of the table holding the given values by column id, missing values are read as null
+func tableRow(table *Table, values map[uint64]TypedValue) *Row {
+	row := &Row{Values: make(map[string]TypedValue, len(table.colsByID))}
+
+	for _, col := range table.colsByID {
+		val, ok := values[col.id]
+		if !ok {
+			val = &NullValue{t: col.colType}
+		}
+
+		row.Values[EncodeSelector("", table.db.name, table.name, col.colName)] = val
+	}
+
+	return row
+}
+
+// IsRowAccessible returns whether the encoded row is accessible within the user session bound to the context
+func (e *Engine) IsRowAccessible(ctx context.Context, table *Table, encodedRow []byte) (bool, error) {
+	if ctx == nil || table == nil {
+		return false, ErrIllegalArguments
+	}
+
+	filter := table.rowFilter(sessionUserFrom(ctx))
+	if filter == nil {
+		return true, nil
+	}
+
+	values, err := decodeRow(encodedRow, table)
+	if err != nil {
+		return false, err
+	}
+
+	err = e.checkRowFilter(filter, table, values)
+	if err == ErrPolicyViolation {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// anyBoolExp is satisfied when any of its expressions reduces to true, null values are not satisfying
+type anyBoolExp struct {
+	exps []ValueExp
+}
+
+func (bexp *anyBoolExp) jointColumnTo(col *Column, tableAlias string) (*ColSelector, error) {
+	return nil, ErrJointColumnNotFound
+}
+
+func (bexp *anyBoolExp) substitute(params map[string]interface{}) (ValueExp, error) {
+	return bexp, nil
+}
+
+func (bexp *anyBoolExp) reduce(catalog *Catalog, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
+	for _, exp := range bexp.exps {
+		v, err := exp.reduce(catalog, row, implicitDB, implicitTable)
+		if err != nil {
+			return nil, err
+		}
+
+		satisfies, isBool := v.(*Bool)
+		if isBool && satisfies.val {
+			return satisfies, nil
+		}
+	}
+
+	return &Bool{val: false}, nil
+}
+
+var numOpSymbols = map[NumOperator]string{ADDOP: "+", SUBSOP: "-", DIVOP: "/", MULTOP: "*"}
+var cmpOpSymbols = map[CmpOperator]string{EQ: "=", NE: "!=", LT: "<", LE: "<=", GT: ">", GE: ">="}
+var logicOpNames = map[LogicOperator]string{AND: "AND", OR: "OR"}
+
+// formatPolicyFilter returns the filter as it's parsed within a CREATE POLICY statement, operations are
+// parenthesized so precedence is kept. Names of the referenced columns are added to cols when provided
+func formatPolicyFilter(exp ValueExp, cols map[string]struct{}) (string, error) {
+	return formatExp(exp, cols, false)
+}
+
+// formatExp formats the expression as formatPolicyFilter does. When lenient, expressions which can not be
+// part of a policy filter, e.g. qualified columns, parameters or aggregations, are formatted as well, as the
+// expression is only displayed
+func formatExp(exp ValueExp, cols map[string]struct{}, lenient bool) (string, error) {
+	switch v := exp.(type) {
+	case *NullValue:
+		{
+			return "NULL", nil
+		}
+	case *Number:
+		{
+			return fmt.Sprintf("%d", v.val), nil
+		}
+	case *Float:
+		{
+			s := strconv.FormatFloat(v.val, 'f', -1, 64)
+
+			// a decimal point is always included so the literal is parsed back as a float
+			if !strings.Contains(s, ".") {
+				s += ".0"
+			}
+
+			return s, nil
+		}
+	case *Varchar:
+		{
+			return fmt.Sprintf("'%s'", v.val), nil
+		}
+	case *Bool:
+		{
+			if v.val {
+				return "TRUE", nil
+			}
+			return "FALSE", nil
+		}
+	case *Blob:
+		{
+			return fmt.Sprintf("x'%s'", hex.EncodeToString(v.val)), nil
+		}
+	case *SysFn:
+		{
+			return fmt.Sprintf("%s()", strings.ToUpper(v.fn)), nil
+		}
+	case *ColSelector:
+		{
+			if lenient && v.table != "" {
+				return v.table + "." + v.col, nil
+			}
+
+			if v.db != "" || v.table != "" {
+				return "", fmt.Errorf("%w: %s", ErrInvalidPolicyFilter, v.col)
+			}
+
+			if cols != nil {
+				cols[v.col] = struct{}{}
+			}
+
+			return v.col, nil
+		}
+	case *NumExp:
+		{
+			return formatBinExp(v.left, numOpSymbols[v.op], v.right, cols, lenient)
+		}
+	case *CmpBoolExp:
+		{
+			return formatBinExp(v.left, cmpOpSymbols[v.op], v.right, cols, lenient)
+		}
+	case *BinBoolExp:
+		{
+			return formatBinExp(v.left, logicOpNames[v.op], v.right, cols, lenient)
+		}
+	case *NotBoolExp:
+		{
+			s, err := formatExp(v.exp, cols, lenient)
+			if err != nil {
+				return "", err
+			}
+
+			return fmt.Sprintf("(NOT %s)", s), nil
+		}
+	case *IsNullBoolExp:
+		{
+			s, err := formatExp(v.exp, cols, lenient)
+			if err != nil {
+				return "", err
+			}
+
+			if v.isNot {
+				return fmt.Sprintf("(%s IS NOT NULL)", s), nil
+			}
+
+			return fmt.Sprintf("(%s IS NULL)", s), nil
+		}
+	case *LikeBoolExp:
+		{
+			op := "LIKE"
+			if v.caseInsensitive {
+				op = "ILIKE"
+			}
+			if v.notLike {
+				op = "NOT " + op
+			}
+
+			return formatBinExp(v.val, op, v.pattern, cols, lenient)
+		}
+	case *CaseWhenExp:
+		{
+			var b strings.Builder
+
+			b.WriteString("(CASE")
+
+			if v.exp != nil {
+				s, err := formatExp(v.exp, cols, lenient)
+				if err != nil {
+					return "", err
+				}
+
+				b.WriteString(" " + s)
+			}
+
+			for _, w := range v.whens {
+				when, err := formatExp(w.when, cols, lenient)
+				if err != nil {
+					return "", err
+				}
+
+				then, err := formatExp(w.then, cols, lenient)
+				if err != nil {
+					return "", err
+				}
+
+				fmt.Fprintf(&b, " WHEN %s THEN %s", when, then)
+			}
+
+			if v.elseExp != nil {
+				s, err := formatExp(v.elseExp, cols, lenient)
+				if err != nil {
+					return "", err
+				}
+
+				b.WriteString(" ELSE " + s)
+			}
+
+			b.WriteString(" END)")
+
+			return b.String(), nil
+		}
+	case *FnCall:
+		{
+			args := make([]string, len(v.params))
+
+			for i, p := range v.params {
+				// the type given to CAST is kept as a type name
+				if v.fn == "cast" && i == 1 {
+					args[i] = p.(*Varchar).val
+					continue
+				}
+
+				s, err := formatExp(p, cols, lenient)
+				if err != nil {
+					return "", err
+				}
+
+				args[i] = s
+			}
+
+			if v.fn == "cast" {
+				return fmt.Sprintf("CAST(%s AS %s)", args[0], args[1]), nil
+			}
+
+			return fmt.Sprintf("%s(%s)", strings.ToUpper(v.fn), strings.Join(args, ", ")), nil
+		}
+	}
+
+	if lenient {
+		return formatDisplayedExp(exp, cols)
+	}
+
+	// parameters, aggregations and sub-queries are not supported
+	return "", ErrInvalidPolicyFilter
+}
+
+// formatDisplayedExp formats the expressions only accepted by formatExp when lenient
+func formatDisplayedExp(exp ValueExp, cols map[string]struct{}) (string, error) {
+	switch v := exp.(type) {
+	case *Param:
+		{
+			return "@" + v.id, nil
+		}
+	case *AggColSelector:
+		{
+			col := v.col
+			if v.table != "" && col != "*" {
+				col = v.table + "." + col
+			}
+
+			if col == "*" {
+				col = ""
+			}
+
+			if v.distinct {
+				col = "DISTINCT " + col
+			}
+
+			return fmt.Sprintf("%s(%s)", v.aggFn, col), nil
+		}
+	case *InListExp:
+		{
+			val, err := formatExp(v.val, cols, true)
+			if err != nil {
+				return "", err
+			}
+
+			values := make([]string, len(v.values))
+
+			for i, value := range v.values {
+				values[i], err = formatExp(value, cols, true)
+				if err != nil {
+					return "", err
+				}
+			}
+
+			op := "IN"
+			if v.notIn {
+				op = "NOT IN"
+			}
+
+			return fmt.Sprintf("(%s %s (%s))", val, op, strings.Join(values, ", ")), nil
+		}
+	case *anyBoolExp:
+		{
+			if len(v.exps) == 0 {
+				return "FALSE", nil
+			}
+
+			exps := make([]string, len(v.exps))
+
+			for i, e := range v.exps {
+				s, err := formatExp(e, cols, true)
+				if err != nil {
+					return "", err
+				}
+
+				exps[i] = s
+			}
+
+			return "(" + strings.Join(exps, " OR ") + ")", nil
+		}
+	case *SubQueryExp, *InSubQueryExp, *ExistsBoolExp:
+		{
+			return "(SUBQUERY)", nil
+		}
+	}
+
+	return "", ErrInvalidPolicyFilter
+}
+
+func formatBinExp(left ValueExp, op string, right ValueExp, cols map[string]struct{}, lenient bool) (string, error) {
+	l, err := formatExp(left, cols, lenient)
+	if err != nil {
+		return "", err
+	}
+
+	r, err := formatExp(right, cols, lenient)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("(%s %s %s)", l, op, r), nil
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package sql
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatPolicyFilter(t *testing.T) {
+	filters := []string{
+		"(tenant = 'acme')",
+		"((tenant = 'acme') OR ((NOT archived) AND (amount >= (10 * 2))))",
+		"((id - 1) != (0 - 5))",
+		"((ts < NOW()) AND (data = x'aabb'))",
+		"((name LIKE 'a%') AND (code = NULL))",
+		"((name NOT ILIKE '_b%') OR (code LIKE tenant))",
+		"(active = TRUE)",
+		"((code IS NULL) OR (tenant IS NOT NULL))",
+		"(CASE WHEN (amount > 10) THEN (tenant = 'acme') ELSE archived END)",
+		"((CASE tenant WHEN 'acme' THEN 1 WHEN 'globex' THEN 2 END) = 1)",
+		"((UPPER(tenant) = 'ACME') AND (COALESCE(code, SUBSTRING(name, 1, 3)) != ''))",
+		"(CAST(amount AS INTEGER) > LENGTH(CONCAT(tenant, '-', code)))",
+	}
+
+	for _, filter := range filters {
+		stmts, err := ParseString("CREATE POLICY p ON t USING " + filter)
+		require.NoError(t, err)
+
+		cols := make(map[string]struct{})
+
+		f, err := formatPolicyFilter(stmts[0].(*CreatePolicyStmt).filter, cols)
+		require.NoError(t, err)
+		require.Equal(t, filter, f)
+		require.NotEmpty(t, cols)
+	}
+
+	_, err := formatPolicyFilter(&Param{id: "tenant"}, nil)
+	require.Equal(t, ErrInvalidPolicyFilter, err)
+
+	_, err = formatPolicyFilter(&ColSelector{table: "t", col: "tenant"}, nil)
+	require.True(t, errors.Is(err, ErrInvalidPolicyFilter))
+
+	_, err = formatPolicyFilter(&AggColSelector{aggFn: COUNT, col: "*"}, nil)
+	require.Equal(t, ErrInvalidPolicyFilter, err)
+}
+
+func TestPolicyAppliesTo(t *testing.T) {
+	p := &Policy{users: []string{"alice", "read"}}
+
+	require.True(t, p.appliesTo(sessionUserFrom(WithUser(context.Background(), "Alice"))))
+	require.True(t, p.appliesTo(sessionUserFrom(WithUser(context.Background(), "bob", "read"))))
+	require.False(t, p.appliesTo(sessionUserFrom(WithUser(context.Background(), "bob", "readwrite"))))
+
+	p = &Policy{}
+	require.True(t, p.appliesTo(sessionUserFrom(WithUser(context.Background(), "bob"))))
+
+	require.Nil(t, sessionUserFrom(context.Background()))
+}
@@ -17,6 +17,14 @@ package sql
 
 import "fmt"
 
+// computedSelector is a selector whose values are computed from the ones of each row,
+// such as CASE expressions and function calls
+type computedSelector interface {
+	Selector
+	valType(cols map[string]*ColDescriptor, implicitDB, implicitTable string) (SQLValueType, error)
+	reduceAs(t SQLValueType, catalog *Catalog, row *Row, implicitDB, implicitTable string) (TypedValue, error)
+}
+
 type projectedRowReader struct {
 	e *Engine
 
@@ -26,19 +34,60 @@ type projectedRowReader struct {
 
 	selectors []Selector
 
+	// types of the values of the computed selectors, by position
+	computedTypes map[int]SQLValueType
+
 	limit uint64
 
 	read uint64
 }
 
-func (e *Engine) newProjectedRowReader(rowReader RowReader, tableAlias string, selectors []Selector, limit uint64) (*projectedRowReader, error) {
-	return &projectedRowReader{
-		e:          e,
-		rowReader:  rowReader,
-		tableAlias: tableAlias,
-		selectors:  selectors,
-		limit:      limit,
-	}, nil
+func (e *Engine) newProjectedRowReader(rowReader RowReader, tableAlias string, selectors []Selector, params map[string]interface{}, limit uint64) (*projectedRowReader, error) {
+	pr := &projectedRowReader{
+		e:             e,
+		rowReader:     rowReader,
+		tableAlias:    tableAlias,
+		selectors:     make([]Selector, len(selectors)),
+		computedTypes: make(map[int]SQLValueType),
+		limit:         limit,
+	}
+
+	var dsColDescriptors map[string]*ColDescriptor
+
+	for i, sel := range selectors {
+		computed, isComputed := sel.(computedSelector)
+		if !isComputed {
+			pr.selectors[i] = sel
+			continue
+		}
+
+		if dsColDescriptors == nil {
+			cols, err := rowReader.colsBySelector()
+			if err != nil {
+				return nil, err
+			}
+
+			dsColDescriptors = cols
+		}
+
+		// parameters are substituted once, as they don't change while reading rows
+		rexp, err := computed.substitute(params)
+		if err != nil {
+			return nil, err
+		}
+
+		computed = rexp.(computedSelector)
+
+		t, err := computed.valType(dsColDescriptors, rowReader.ImplicitDB(), rowReader.ImplicitTable())
+		if err != nil {
+			return nil, err
+		}
+
+		pr.selectors[i] = computed
+		pr.computedTypes[i] = t
+	}
+
+	return pr, nil
 }
 
 func (pr *projectedRowReader) ImplicitDB() string {
@@ -86,7 +135,10 @@ func (pr *projectedRowReader) Columns() ([]*ColDescriptor, error) {
 			col = sel.alias()
 		}
 
-		if aggFn != "" {
+		_, isJSON := sel.(*JSONSelector)
+		_, isComputed := pr.computedTypes[i]
+
+		if aggFn != "" || isJSON || isComputed {
 			aggFn = ""
 			col = sel.alias()
 			if col == "" {
@@ -117,11 +169,27 @@ func (pr *projectedRowReader) colsBySelector() (map[string]*ColDescriptor, error
 	for i, sel := range pr.selectors {
 		aggFn, db, table, col := sel.resolve(pr.rowReader.ImplicitDB(), pr.rowReader.ImplicitTable())
 
-		encSel := EncodeSelector(aggFn, db, table, col)
+		// values extracted from JSON columns and computed values are named as aggregations
+		jsonSel, isJSON := sel.(*JSONSelector)
+		colType, isComputed := pr.computedTypes[i]
+
+		if !isComputed {
+			encSel := EncodeSelector(aggFn, db, table, col)
+
+			colDesc, ok := dsColDescriptors[encSel]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", ErrColumnDoesNotExist, col)
+			}
+
+			colType = colDesc.Type
+		}
+
+		if isJSON {
+			if colType != JSONType {
+				return nil, fmt.Errorf("%w: %s", ErrExpectingJSONColumn, col)
+			}
 
-		colDesc, ok := dsColDescriptors[encSel]
-		if !ok {
-			return nil, ErrColumnDoesNotExist
+			colType = jsonSel.valType()
 		}
 
 		if pr.tableAlias != "" {
@@ -133,7 +201,7 @@ func (pr *projectedRowReader) colsBySelector() (map[string]*ColDescriptor, error
 			col = sel.alias()
 		}
 
-		if aggFn != "" {
+		if aggFn != "" || isJSON || isComputed {
 			aggFn = ""
 			col = sel.alias()
 			if col == "" {
@@ -141,8 +209,8 @@ func (pr *projectedRowReader) colsBySelector() (map[string]*ColDescriptor, error
 			}
 		}
 
-		encSel = EncodeSelector(aggFn, db, table, col)
-		colDescriptors[encSel] = &ColDescriptor{Selector: encSel, Type: colDesc.Type}
+		encSel := EncodeSelector(aggFn, db, table, col)
+		colDescriptors[encSel] = &ColDescriptor{Selector: encSel, Type: colType}
 	}
 
 	return colDescriptors, nil
@@ -182,11 +250,30 @@ func (pr *projectedRowReader) Read() (*Row, error) {
 	for i, sel := range pr.selectors {
 		aggFn, db, table, col := sel.resolve(pr.rowReader.ImplicitDB(), pr.rowReader.ImplicitTable())
 
-		encSel := EncodeSelector(aggFn, db, table, col)
+		var val TypedValue
+
+		jsonSel, isJSON := sel.(*JSONSelector)
+		computedType, isComputed := pr.computedTypes[i]
+
+		if isComputed {
+			val, err = sel.(computedSelector).reduceAs(computedType, pr.e.catalog, row, pr.rowReader.ImplicitDB(), pr.rowReader.ImplicitTable())
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			v, ok := row.Values[EncodeSelector(aggFn, db, table, col)]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", ErrColumnDoesNotExist, col)
+			}
 
-		val, ok := row.Values[encSel]
-		if !ok {
-			return nil, ErrColumnDoesNotExist
+			val = v
+		}
+
+		if isJSON {
+			val, err = jsonSel.extract(val)
+			if err != nil {
+				return nil, err
+			}
 		}
 
 		if pr.tableAlias != "" {
@@ -198,7 +285,7 @@ func (pr *projectedRowReader) Read() (*Row, error) {
 			col = sel.alias()
 		}
 
-		if aggFn != "" {
+		if aggFn != "" || isJSON || isComputed {
 			aggFn = ""
 			col = sel.alias()
 			if col == "" {
@@ -0,0 +1,152 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sql
+
+import (
+	"github.com/codenotary/immudb/embedded/store"
+)
+
+// ReturningSpec lists the columns of the rows written by a statement which are returned once they're committed,
+// all the columns of the table are returned when none is given
+type ReturningSpec struct {
+	cols []string
+}
+
+func (r *ReturningSpec) validate(table *Table) error {
+	for _, c := range r.cols {
+		_, err := table.GetColumnByName(c)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// returnedRows returns a reader of the rows written by the statement as they were committed, thus including
+// generated primary keys and default values. It's nil when the statement has no RETURNING clause
+func (stmt *UpsertIntoStmt) returnedRows(e *Engine, implicitDB *Database, entries []*store.KV) (RowReader, error) {
+	if stmt.returning == nil {
+		return nil, nil
+	}
+
+	table, err := stmt.tableRef.referencedTable(e, implicitDB)
+	if err != nil {
+		return nil, err
+	}
+
+	cols := make([]*Column, 0, len(table.colsByID))
+
+	if len(stmt.returning.cols) == 0 {
+		for id := uint64(1); id <= uint64(len(table.colsByID)); id++ {
+			cols = append(cols, table.colsByID[id])
+		}
+	}
+
+	for _, c := range stmt.returning.cols {
+		col, err := table.GetColumnByName(c)
+		if err != nil {
+			return nil, err
+		}
+
+		cols = append(cols, col)
+	}
+
+	r := &returnedRowReader{
+		implicitDB: table.db.name,
+		table:      table.name,
+		colsByPos:  make([]*ColDescriptor, len(cols)),
+		colsBySel:  make(map[string]*ColDescriptor, len(cols)),
+	}
+
+	for i, col := range cols {
+		colDescriptor := &ColDescriptor{Selector: EncodeSelector("", table.db.name, table.name, col.colName), Type: col.colType}
+		r.colsByPos[i] = colDescriptor
+		r.colsBySel[colDescriptor.Selector] = colDescriptor
+	}
+
+	for _, kv := range entries {
+		if !e.isRowEntry(kv) {
+			continue
+		}
+
+		values, err := decodeRow(kv.Value, table)
+		if err != nil {
+			return nil, err
+		}
+
+		row := &Row{Values: make(map[string]TypedValue, len(cols))}
+
+		for i, col := range cols {
+			val, ok := values[col.id]
+			if !ok {
+				val = &NullValue{t: col.colType}
+			}
+
+			row.Values[r.colsByPos[i].Selector] = val
+		}
+
+		r.rows = append(r.rows, row)
+	}
+
+	return r, nil
+}
+
+// returnedRowReader reads the rows returned by a statement with a RETURNING clause
+type returnedRowReader struct {
+	implicitDB string
+	table      string
+	colsByPos  []*ColDescriptor
+	colsBySel  map[string]*ColDescriptor
+
+	rows []*Row
+}
+
+func (r *returnedRowReader) ImplicitDB() string {
+	return r.implicitDB
+}
+
+func (r *returnedRowReader) ImplicitTable() string {
+	return r.table
+}
+
+func (r *returnedRowReader) Columns() ([]*ColDescriptor, error) {
+	return r.colsByPos, nil
+}
+
+func (r *returnedRowReader) colsBySelector() (map[string]*ColDescriptor, error) {
+	return r.colsBySel, nil
+}
+
+func (r *returnedRowReader) Read() (*Row, error) {
+	if len(r.rows) == 0 {
+		return nil, ErrNoMoreRows
+	}
+
+	row := r.rows[0]
+	r.rows = r.rows[1:]
+
+	return row, nil
+}
+
+func (r *returnedRowReader) Close() error {
+	return nil
+}
+
+func (r *returnedRowReader) explain() *planNode {
+	return &planNode{desc: "RETURNING"}
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package sql
 
 import (
+	"bytes"
+	"context"
 	"encoding/binary"
 
 	"github.com/codenotary/immudb/embedded/store"
@@ -29,6 +31,8 @@ type RowReader interface {
 	Close() error
 	Columns() ([]*ColDescriptor, error)
 	colsBySelector() (map[string]*ColDescriptor, error)
+	// explain describes the step of the plan of the query performed by the reader
+	explain() *planNode
 }
 
 type Row struct {
@@ -64,17 +68,28 @@ func (row *Row) Compatible(aRow *Row, selectors []*ColSelector, db, table string
 }
 
 type rawRowReader struct {
+	ctx        context.Context
 	e          *Engine
 	implicitDB string
 	snap       *store.Snapshot
 	table      *Table
 	asBefore   uint64
+	// only rows written in this tx or in a later one are read
+	sinceTx    uint64
 	tableAlias string
 	colsByPos  []*ColDescriptor
 	colsBySel  map[string]*ColDescriptor
 	col        string
 	desc       bool
 	reader     *store.KeyReader
+	// rows are read from the bound set by the condition of the query rather than from the first indexed value
+	bounded bool
+	// number of value prefixes the scan is restricted to, as set by a LIKE, IN or equality condition
+	prefixes int
+	// key at which the scan stops, the first key not read in ascending order and the last one read in descending order
+	endKey []byte
+	// key ranges read once the current reader gets exhausted
+	pendingSpecs []*store.KeyReaderSpec
 }
 
 type ColDescriptor struct {
@@ -82,8 +97,11 @@ type ColDescriptor struct {
 	Type     SQLValueType
 }
 
-func (e *Engine) newRawRowReader(db *Database, snap *store.Snapshot, table *Table, asBefore uint64, tableAlias string, colName string, cmp Comparison, encInitKeyVal []byte) (*rawRowReader, error) {
-	if snap == nil || table == nil {
+// newRawRowReader returns a reader of the rows of the table in the order of the given column, which must be the leading column
+// of the primary key or of an index. When encoded value prefixes are provided, only the rows whose value starts with one of them are read,
+// one prefix after the other as they are given, while the initial value is not taken into account
+func (e *Engine) newRawRowReader(ctx context.Context, db *Database, snap *store.Snapshot, table *Table, asBefore uint64, tableAlias string, colName string, cmp Comparison, encInitKeyVal []byte, encValPrefixes ...[]byte) (*rawRowReader, error) {
+	if ctx == nil || snap == nil || table == nil {
 		return nil, ErrIllegalArguments
 	}
 
@@ -92,7 +110,7 @@ func (e *Engine) newRawRowReader(db *Database, snap *store.Snapshot, table *Tabl
 		return nil, err
 	}
 
-	prefix := e.mapKey(RowPrefix, EncodeID(table.db.id), EncodeID(table.id), EncodeID(col.id))
+	prefix := e.scanPrefix(table, col)
 
 	if cmp == EqualTo {
 		prefix = append(prefix, encInitKeyVal...)
@@ -106,13 +124,12 @@ func (e *Engine) newRawRowReader(db *Database, snap *store.Snapshot, table *Tabl
 	}
 
 	if cmp == LowerThan || cmp == LowerOrEqualTo {
-		if table.pk.colName == colName {
-			skey = append(skey, encInitKeyVal...)
-		} else {
-			maxPKVal := maxKeyVal(table.pk.colType)
+		skey = append(skey, encInitKeyVal...)
 
-			skey = append(skey, encInitKeyVal...)
-			skey = append(skey, maxPKVal...)
+		// keys holding the given value are followed by the values of the remaining columns of the primary key or index,
+		// and by the primary key itself in the case of an index
+		if table.pk.colName != colName || len(table.pkCols) > 1 {
+			skey = append(skey, mKeyVal[:]...)
 		}
 	}
 
@@ -123,6 +140,26 @@ func (e *Engine) newRawRowReader(db *Database, snap *store.Snapshot, table *Tabl
 		DescOrder:     cmp == LowerThan || cmp == LowerOrEqualTo,
 	}
 
+	var pendingSpecs []*store.KeyReaderSpec
+
+	if len(encValPrefixes) > 0 {
+		specs := make([]*store.KeyReaderSpec, len(encValPrefixes))
+
+		for i, encValPrefix := range encValPrefixes {
+			valPrefix := make([]byte, len(prefix)+len(encValPrefix))
+			copy(valPrefix, prefix)
+			copy(valPrefix[len(prefix):], encValPrefix)
+
+			// rows are read from the first or the last key with the prefix, as the order requires
+			specs[i] = &store.KeyReaderSpec{
+				Prefix:    valPrefix,
+				DescOrder: rSpec.DescOrder,
+			}
+		}
+
+		rSpec, pendingSpecs = specs[0], specs[1:]
+	}
+
 	r, err := snap.NewKeyReader(rSpec)
 	if err != nil {
 		return nil, err
@@ -148,20 +185,44 @@ func (e *Engine) newRawRowReader(db *Database, snap *store.Snapshot, table *Tabl
 	}
 
 	return &rawRowReader{
-		e:          e,
-		implicitDB: implicitDB,
-		snap:       snap,
-		table:      table,
-		asBefore:   asBefore,
-		colsByPos:  colsByPos,
-		colsBySel:  colsBySel,
-		tableAlias: tableAlias,
-		col:        col.colName,
-		desc:       rSpec.DescOrder,
-		reader:     r,
+		ctx:          ctx,
+		e:            e,
+		implicitDB:   implicitDB,
+		snap:         snap,
+		table:        table,
+		asBefore:     asBefore,
+		colsByPos:    colsByPos,
+		colsBySel:    colsBySel,
+		tableAlias:   tableAlias,
+		col:          col.colName,
+		desc:         rSpec.DescOrder,
+		prefixes:     len(encValPrefixes),
+		reader:       r,
+		pendingSpecs: pendingSpecs,
 	}, nil
 }
 
+// scanPrefix returns the prefix of the keys of the entries holding the rows of the table in the order of the column,
+// which must be the leading column of the primary key or of an index
+func (e *Engine) scanPrefix(table *Table, col *Column) []byte {
+	return e.mapKey(RowPrefix, EncodeID(table.db.id), EncodeID(table.id), EncodeID(col.id))
+}
+
+// prefixEnd returns the first key following every key with the given prefix, nil if there is no such key
+func prefixEnd(prefix []byte) []byte {
+	end := make([]byte, len(prefix))
+	copy(end, prefix)
+
+	for i := len(end) - 1; i >= 0; i-- {
+		end[i]++
+		if end[i] != 0 {
+			return end[:i+1]
+		}
+	}
+
+	return nil
+}
+
 func (r *rawRowReader) ImplicitDB() string {
 	return r.implicitDB
 }
@@ -179,43 +240,34 @@ func (r *rawRowReader) colsBySelector() (map[string]*ColDescriptor, error) {
 }
 
 func (r *rawRowReader) Read() (row *Row, err error) {
-	var mkey []byte
-	var vref *store.ValueRef
-
-	if r.asBefore > 0 {
-		mkey, vref, _, err = r.reader.ReadAsBefore(r.asBefore)
-	} else {
-		mkey, vref, _, _, err = r.reader.Read()
+	// checked on every row as filtering readers may go through the whole table
+	err = r.ctx.Err()
+	if err != nil {
+		return nil, err
 	}
+
+	decodedValues, err := r.readRow()
 	if err != nil {
 		return nil, err
 	}
 
-	var v []byte
+	values := make(map[string]TypedValue, len(r.table.ColsByID()))
 
-	//decompose key, determine if it's pk, when it's pk, the value holds the actual row data
-	if r.table.pk.colName == r.col {
-		v, err = vref.Resolve()
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		_, _, _, _, encPKVal, err := r.e.unmapIndexedRow(mkey)
-		if err != nil {
-			return nil, err
+	for _, col := range r.table.colsByName {
+		val, ok := decodedValues[col.id]
+		if !ok {
+			val = &NullValue{t: col.colType}
 		}
 
-		v, _, _, err = r.snap.Get(r.e.mapKey(RowPrefix, EncodeID(r.table.db.id), EncodeID(r.table.id), EncodeID(r.table.pk.id), encPKVal))
-		if err != nil {
-			return nil, err
-		}
+		values[EncodeSelector("", r.table.db.name, r.tableAlias, col.colName)] = val
 	}
 
-	values := make(map[string]TypedValue, len(r.table.ColsByID()))
+	return &Row{Values: values}, nil
+}
 
-	for _, col := range r.table.colsByName {
-		values[EncodeSelector("", r.table.db.name, r.tableAlias, col.colName)] = &NullValue{t: col.colType}
-	}
+// decodeRow returns the values of an encoded row by column id, null values are not included
+func decodeRow(v []byte, table *Table) (map[uint64]TypedValue, error) {
+	values := make(map[uint64]TypedValue, len(table.colsByID))
 
 	voff := 0
 
@@ -230,7 +282,7 @@ func (r *rawRowReader) Read() (row *Row, err error) {
 		colID := binary.BigEndian.Uint64(v[voff:])
 		voff += EncIDLen
 
-		col, err := r.table.GetColumnByID(colID)
+		col, err := table.GetColumnByID(colID)
 		if err != nil {
 			return nil, ErrCorruptedData
 		}
@@ -241,10 +293,164 @@ func (r *rawRowReader) Read() (row *Row, err error) {
 		}
 
 		voff += n
-		values[EncodeSelector("", r.table.db.name, r.tableAlias, col.colName)] = val
+		values[colID] = val
 	}
 
-	return &Row{Values: values}, nil
+	return values, nil
+}
+
+// readRow returns the values of the next row by column id, deleted rows and stale index entries are skipped
+func (r *rawRowReader) readRow() (map[uint64]TypedValue, error) {
+	for {
+		var mkey []byte
+		var vref *store.ValueRef
+		var tx uint64
+		var err error
+
+		if r.asBefore > 0 {
+			mkey, vref, tx, err = r.reader.ReadAsBefore(r.asBefore)
+		} else {
+			mkey, vref, tx, _, err = r.reader.Read()
+		}
+		if err == store.ErrNoMoreEntries && len(r.pendingSpecs) > 0 {
+			err = r.nextKeyRange()
+			if err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if r.endKey != nil &&
+			((!r.desc && bytes.Compare(mkey, r.endKey) >= 0) || (r.desc && bytes.Compare(mkey, r.endKey) < 0)) {
+			return nil, store.ErrNoMoreEntries
+		}
+
+		// rows deleted before asBefore, only latest entries are skipped by the reader itself
+		if vref.KVMetadata().Deleted() {
+			continue
+		}
+
+		//decompose key, determine if it's pk, when it's pk, the value holds the actual row data
+		if r.table.pk.colName == r.col {
+			if tx < r.sinceTx {
+				continue
+			}
+
+			v, err := vref.Resolve()
+			if err != nil {
+				return nil, err
+			}
+
+			return decodeRow(v, r.table)
+		}
+
+		idx := r.table.indexes[r.table.colsByName[r.col].id]
+
+		encVal, encPKVal, err := r.e.unmapIndexedRow(idx, mkey)
+		if err == ErrCorruptedData {
+			// entries of a dropped index led by the same column but made of other columns
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		v, rowTx, err := r.readPKRow(encPKVal)
+		if err == store.ErrKeyNotFound {
+			// index entry of a deleted row
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if rowTx < r.sinceTx {
+			continue
+		}
+
+		values, err := decodeRow(v, r.table)
+		if err != nil {
+			return nil, err
+		}
+
+		// index entries written before the indexed values were changed while the index was dropped
+		currEncVal, err := idx.encodeValues(values)
+		if err == ErrIndexedColumnCanNotBeNull {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if !bytes.Equal(currEncVal, encVal) {
+			continue
+		}
+
+		return values, nil
+	}
+}
+
+// readPKRow returns the encoded row with the given primary key value along with the tx it was written in,
+// the row is read as it was before asBefore when the table is read as of a previous tx
+func (r *rawRowReader) readPKRow(encPKVal []byte) ([]byte, uint64, error) {
+	pkKey := r.e.rowKey(r.table, encPKVal)
+
+	if r.asBefore == 0 {
+		v, tx, _, err := r.snap.Get(pkKey)
+		return v, tx, err
+	}
+
+	reader, err := r.snap.NewKeyReader(&store.KeyReaderSpec{
+		SeekKey:       pkKey,
+		Prefix:        pkKey,
+		InclusiveSeek: true,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	defer reader.Close()
+
+	key, vref, tx, err := reader.ReadAsBefore(r.asBefore)
+	if err == store.ErrNoMoreEntries {
+		return nil, 0, store.ErrKeyNotFound
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if !bytes.Equal(key, pkKey) || vref.KVMetadata().Deleted() {
+		return nil, 0, store.ErrKeyNotFound
+	}
+
+	v, err := vref.Resolve()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return v, tx, nil
+}
+
+// nextKeyRange replaces the exhausted reader with a reader of the next pending key range
+func (r *rawRowReader) nextKeyRange() error {
+	reader, err := r.snap.NewKeyReader(r.pendingSpecs[0])
+	if err != nil {
+		return err
+	}
+
+	err = r.reader.Close()
+	if err != nil {
+		reader.Close()
+		return err
+	}
+
+	r.reader = reader
+	r.pendingSpecs = r.pendingSpecs[1:]
+
+	return nil
 }
 
 func (r *rawRowReader) Close() error {
@@ -0,0 +1,421 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sql
+
+import (
+	"bufio"
+	"bytes"
+	"container/heap"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+
+	"github.com/codenotary/immudb/embedded/fileutil"
+	"github.com/codenotary/immudb/embedded/store"
+)
+
+// sortedRowReader returns the rows of the underlying reader sorted by the values of the ordering expressions.
+// Rows are buffered in memory until the size of the buffer exceeds the limit set in the engine,
+// then the buffer is sorted and spilled as a run into a temporary file. Runs are merged once all
+// the rows have been read. The sort is stable, rows with same ordering values keep their relative order.
+type sortedRowReader struct {
+	e *Engine
+
+	rowReader RowReader
+
+	ordCols []*OrdCol
+
+	params map[string]interface{}
+
+	bufferSize int
+
+	buffer       []*sortedRow
+	bufferedSize int
+
+	runs    []*sortRun
+	pending sortRunHeap
+
+	sorted bool
+}
+
+type sortedRow struct {
+	keys []TypedValue
+	row  *Row
+}
+
+type sortRun struct {
+	idx  int
+	f    *os.File
+	r    *bufio.Reader
+	head *sortedRow
+}
+
+func (e *Engine) newSortedRowReader(rowReader RowReader, ordCols []*OrdCol, params map[string]interface{}) (*sortedRowReader, error) {
+	if rowReader == nil || len(ordCols) == 0 {
+		return nil, ErrIllegalArguments
+	}
+
+	return &sortedRowReader{
+		e:          e,
+		rowReader:  rowReader,
+		ordCols:    ordCols,
+		params:     params,
+		bufferSize: e.sortBufferSize,
+	}, nil
+}
+
+func (sr *sortedRowReader) ImplicitDB() string {
+	return sr.rowReader.ImplicitDB()
+}
+
+func (sr *sortedRowReader) ImplicitTable() string {
+	return sr.rowReader.ImplicitTable()
+}
+
+func (sr *sortedRowReader) Columns() ([]*ColDescriptor, error) {
+	return sr.rowReader.Columns()
+}
+
+func (sr *sortedRowReader) colsBySelector() (map[string]*ColDescriptor, error) {
+	return sr.rowReader.colsBySelector()
+}
+
+func (sr *sortedRowReader) Read() (*Row, error) {
+	if !sr.sorted {
+		err := sr.sort()
+		if err != nil {
+			return nil, err
+		}
+
+		sr.sorted = true
+	}
+
+	if sr.runs == nil {
+		if len(sr.buffer) == 0 {
+			return nil, store.ErrNoMoreEntries
+		}
+
+		r := sr.buffer[0]
+		sr.buffer = sr.buffer[1:]
+
+		return r.row, nil
+	}
+
+	if sr.pending.Len() == 0 {
+		return nil, store.ErrNoMoreEntries
+	}
+
+	run := sr.pending.runs[0]
+	r := run.head
+
+	var err error
+
+	run.head, err = readSortedRow(run.r)
+	if err != nil {
+		return nil, err
+	}
+
+	if run.head == nil {
+		heap.Pop(&sr.pending)
+	} else {
+		heap.Fix(&sr.pending, 0)
+	}
+
+	if sr.pending.err != nil {
+		return nil, sr.pending.err
+	}
+
+	return r.row, nil
+}
+
+func (sr *sortedRowReader) sort() error {
+	for {
+		row, err := sr.rowReader.Read()
+		if err == store.ErrNoMoreEntries {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		keys := make([]TypedValue, len(sr.ordCols))
+
+		for i, ordCol := range sr.ordCols {
+			exp, err := ordCol.exp.substitute(sr.params)
+			if err != nil {
+				return err
+			}
+
+			keys[i], err = exp.reduce(sr.e.catalog, row, sr.rowReader.ImplicitDB(), sr.rowReader.ImplicitTable())
+			if err != nil {
+				return err
+			}
+		}
+
+		sr.buffer = append(sr.buffer, &sortedRow{keys: keys, row: row})
+		sr.bufferedSize += estimatedRowSize(row)
+
+		if sr.bufferedSize > sr.bufferSize {
+			err = sr.spillRun()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if sr.runs == nil {
+		return sr.sortBuffer()
+	}
+
+	if len(sr.buffer) > 0 {
+		err := sr.spillRun()
+		if err != nil {
+			return err
+		}
+	}
+
+	sr.pending = sortRunHeap{ordCols: sr.ordCols}
+
+	for _, run := range sr.runs {
+		_, err := run.f.Seek(0, io.SeekStart)
+		if err != nil {
+			return err
+		}
+
+		run.r = bufio.NewReader(run.f)
+
+		run.head, err = readSortedRow(run.r)
+		if err != nil {
+			return err
+		}
+
+		if run.head != nil {
+			sr.pending.runs = append(sr.pending.runs, run)
+		}
+	}
+
+	heap.Init(&sr.pending)
+
+	return sr.pending.err
+}
+
+func (sr *sortedRowReader) sortBuffer() error {
+	var err error
+
+	sort.SliceStable(sr.buffer, func(i, j int) bool {
+		cmp, cmpErr := compareSortKeys(sr.ordCols, sr.buffer[i].keys, sr.buffer[j].keys)
+		if cmpErr != nil && err == nil {
+			err = cmpErr
+		}
+		return cmp < 0
+	})
+
+	return err
+}
+
+// spillRun writes the sorted buffer into a new temporary file
+func (sr *sortedRowReader) spillRun() error {
+	err := sr.sortBuffer()
+	if err != nil {
+		return err
+	}
+
+	f, err := ioutil.TempFile("", "immudb_sql_sort_")
+	if err != nil {
+		return err
+	}
+
+	sr.runs = append(sr.runs, &sortRun{idx: len(sr.runs), f: f})
+
+	w := bufio.NewWriter(f)
+
+	for _, r := range sr.buffer {
+		err = writeSortedRow(w, r)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = w.Flush()
+	if err != nil {
+		return err
+	}
+
+	sr.buffer = nil
+	sr.bufferedSize = 0
+
+	return nil
+}
+
+func (sr *sortedRowReader) Close() error {
+	for _, run := range sr.runs {
+		run.f.Close()
+		fileutil.Remove(run.f.Name())
+	}
+
+	return sr.rowReader.Close()
+}
+
+// compareSortKeys compares the ordering values of two rows, null values go first in ascending order
+func compareSortKeys(ordCols []*OrdCol, keys1, keys2 []TypedValue) (int, error) {
+	for i, ordCol := range ordCols {
+		var cmp int
+
+		null1, null2 := isNull(keys1[i]), isNull(keys2[i])
+
+		switch {
+		case null1 && null2:
+			cmp = 0
+		case null1:
+			cmp = -1
+		case null2:
+			cmp = 1
+		default:
+			{
+				var err error
+
+				cmp, err = keys1[i].Compare(keys2[i])
+				if err != nil {
+					return 0, err
+				}
+			}
+		}
+
+		if ordCol.cmp == LowerOrEqualTo {
+			cmp = -cmp
+		}
+
+		if cmp != 0 {
+			return cmp, nil
+		}
+	}
+
+	return 0, nil
+}
+
+// estimatedRowSize approximates the memory used by the values of a row
+func estimatedRowSize(row *Row) int {
+	size := 0
+
+	for sel, v := range row.Values {
+		size += len(sel)
+
+		switch tv := v.(type) {
+		case *Varchar:
+			size += len(tv.val)
+		case *JSON:
+			size += len(tv.val)
+		case *Blob:
+			size += len(tv.val)
+		default:
+			size += 8
+		}
+	}
+
+	return size
+}
+
+// sortRunHeap keeps runs ordered by their first row, ties are resolved by the order of the runs
+type sortRunHeap struct {
+	ordCols []*OrdCol
+	runs    []*sortRun
+	err     error
+}
+
+func (h *sortRunHeap) Len() int {
+	return len(h.runs)
+}
+
+func (h *sortRunHeap) Less(i, j int) bool {
+	cmp, err := compareSortKeys(h.ordCols, h.runs[i].head.keys, h.runs[j].head.keys)
+	if err != nil && h.err == nil {
+		h.err = err
+	}
+
+	if cmp == 0 {
+		return h.runs[i].idx < h.runs[j].idx
+	}
+
+	return cmp < 0
+}
+
+func (h *sortRunHeap) Swap(i, j int) {
+	h.runs[i], h.runs[j] = h.runs[j], h.runs[i]
+}
+
+func (h *sortRunHeap) Push(x interface{}) {
+	h.runs = append(h.runs, x.(*sortRun))
+}
+
+func (h *sortRunHeap) Pop() interface{} {
+	run := h.runs[len(h.runs)-1]
+	h.runs = h.runs[:len(h.runs)-1]
+	return run
+}
+
+// writeSortedRow writes #keys + key* + row
+func writeSortedRow(w io.Writer, r *sortedRow) error {
+	var buf bytes.Buffer
+
+	writeSpilledUint64(&buf, uint64(len(r.keys)))
+
+	for _, k := range r.keys {
+		err := writeSpilledValue(&buf, k)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := writeSpilledRow(&buf, r.row)
+	if err != nil {
+		return err
+	}
+
+	return writeSpilledRecord(w, buf.Bytes())
+}
+
+// readSortedRow returns nil when there are no more rows to read
+func readSortedRow(r io.Reader) (*sortedRow, error) {
+	b, err := readSpilledRecord(r)
+	if err != nil || b == nil {
+		return nil, err
+	}
+
+	d := &spillDecoder{b: b}
+
+	keysLen, err := d.readUint64()
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]TypedValue, keysLen)
+
+	for i := range keys {
+		keys[i], err = d.readValue()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	row, err := d.readRow()
+	if err != nil {
+		return nil, err
+	}
+
+	return &sortedRow{keys: keys, row: row}, nil
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sql
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortedRowReader(t *testing.T) {
+	catalogStore, err := store.Open("catalog_sorted_reader", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_sorted_reader")
+
+	dataStore, err := store.Open("sqldata_sorted_reader", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_sorted_reader")
+
+	engine, err := NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
+
+	_, err = engine.newSortedRowReader(nil, nil, nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	db, err := engine.catalog.newDatabase("db1")
+	require.NoError(t, err)
+
+	table, err := db.newTable("table1", []*ColSpec{{colName: "id", colType: IntegerType}}, nil, []string{"id"})
+	require.NoError(t, err)
+
+	snap, err := engine.Snapshot()
+	require.NoError(t, err)
+
+	r, err := engine.newRawRowReader(context.Background(), db, snap, table, 0, "", "id", EqualTo, nil)
+	require.NoError(t, err)
+
+	_, err = engine.newSortedRowReader(r, nil, nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	sr, err := engine.newSortedRowReader(r, []*OrdCol{{exp: &ColSelector{col: "id"}, cmp: LowerOrEqualTo}}, nil)
+	require.NoError(t, err)
+
+	cols, err := sr.Columns()
+	require.NoError(t, err)
+	require.Len(t, cols, 1)
+
+	_, err = sr.Read()
+	require.Equal(t, store.ErrNoMoreEntries, err)
+}
+
+func TestCompareSortKeys(t *testing.T) {
+	asc := []*OrdCol{{cmp: GreaterOrEqualTo}, {cmp: LowerOrEqualTo}}
+
+	cmp, err := compareSortKeys(asc, []TypedValue{&Number{val: 1}, &Varchar{val: "a"}}, []TypedValue{&Number{val: 2}, &Varchar{val: "a"}})
+	require.NoError(t, err)
+	require.Equal(t, -1, cmp)
+
+	cmp, err = compareSortKeys(asc, []TypedValue{&Number{val: 1}, &Varchar{val: "a"}}, []TypedValue{&Number{val: 1}, &Varchar{val: "b"}})
+	require.NoError(t, err)
+	require.Equal(t, 1, cmp)
+
+	cmp, err = compareSortKeys(asc, []TypedValue{&Number{val: 1}, &Varchar{val: "a"}}, []TypedValue{&Number{val: 1}, &Varchar{val: "a"}})
+	require.NoError(t, err)
+	require.Equal(t, 0, cmp)
+
+	cmp, err = compareSortKeys(asc, []TypedValue{&NullValue{t: IntegerType}, nil}, []TypedValue{&Number{val: 0}, nil})
+	require.NoError(t, err)
+	require.Equal(t, -1, cmp)
+
+	cmp, err = compareSortKeys(asc, []TypedValue{&Number{val: 0}, nil}, []TypedValue{&NullValue{t: IntegerType}, nil})
+	require.NoError(t, err)
+	require.Equal(t, 1, cmp)
+
+	cmp, err = compareSortKeys(asc, []TypedValue{&Number{val: 0}, &NullValue{t: VarcharType}}, []TypedValue{&Number{val: 0}, &NullValue{t: VarcharType}})
+	require.NoError(t, err)
+	require.Equal(t, 0, cmp)
+
+	_, err = compareSortKeys(asc, []TypedValue{&Number{val: 1}}, []TypedValue{&Varchar{val: "a"}})
+	require.Equal(t, ErrNotComparableValues, err)
+}
+
+func TestSortedRowEncoding(t *testing.T) {
+	r := &sortedRow{
+		keys: []TypedValue{&Number{val: 1}, &NullValue{t: VarcharType}},
+		row: &Row{Values: map[string]TypedValue{
+			"(db1.table1.id)":    &Number{val: 1},
+			"(db1.table1.title)": &NullValue{t: VarcharType},
+		}},
+	}
+
+	var buf bytes.Buffer
+
+	err := writeSortedRow(&buf, r)
+	require.NoError(t, err)
+
+	rr, err := readSortedRow(&buf)
+	require.NoError(t, err)
+	require.Equal(t, r, rr)
+
+	rr, err = readSortedRow(&buf)
+	require.NoError(t, err)
+	require.Nil(t, rr)
+
+	err = writeSortedRow(&buf, &sortedRow{keys: []TypedValue{nil}})
+	require.Equal(t, ErrInvalidValue, err)
+}
@@ -20,6 +20,10 @@ package sql
 func setResult(l yyLexer, stmts []SQLStmt) {
     l.(*lexer).result = stmts
 }
+
+func textFrom(l yyLexer, pos int) string {
+    return l.(*lexer).textFrom(pos)
+}
 %}
 
 %union{
@@ -27,13 +31,16 @@ func setResult(l yyLexer, stmts []SQLStmt) {
     stmt SQLStmt
     colsSpec []*ColSpec
     colSpec *ColSpec
-    cols []*ColSelector
+    checks []*CheckSpec
+    check *CheckSpec
+    returning *ReturningSpec
     rows []*RowSpec
     row *RowSpec
     values []ValueExp
     value ValueExp
     id string
     number uint64
+    float float64
     str string
     boolean bool
     blob []byte
@@ -54,22 +61,34 @@ func setResult(l yyLexer, stmts []SQLStmt) {
     err error
     ordcols []*OrdCol
     opt_ord Comparison
+    idxCol *IndexColSpec
+    idxCols []*IndexColSpec
     logicOp LogicOperator
     cmpOp CmpOperator
+    update *colUpdate
+    updates []*colUpdate
+    whens []*whenThen
+    pos int
 }
 
-%token CREATE USE DATABASE SNAPSHOT SINCE UP TO TABLE INDEX ON ALTER ADD COLUMN PRIMARY KEY
-%token BEGIN TRANSACTION COMMIT
-%token INSERT UPSERT INTO VALUES
-%token SELECT DISTINCT FROM BEFORE TX JOIN HAVING WHERE GROUP BY LIMIT ORDER ASC DESC AS
-%token NOT LIKE IF EXISTS
+%token CREATE USE DATABASE SNAPSHOT SINCE UP TO TABLE INDEX ON ALTER ADD RENAME COLUMN PRIMARY KEY DROP AUTO_INCREMENT UNIQUE DEFAULT CHECK CONSTRAINT
+%token POLICY USING TRUNCATE VIEW
+%token BEGIN TRANSACTION COMMIT ROLLBACK
+%token INSERT UPSERT INTO VALUES DELETE UPDATE SET RETURNING
+%token EXPLAIN ANALYZE
+%token SELECT DISTINCT FROM BEFORE TX OF JOIN OUTER HAVING WHERE GROUP BY LIMIT ORDER ASC DESC AS AS_OF
+%token NOT LIKE ILIKE IF EXISTS IN IS
+%token CASE WHEN THEN ELSE END
+%token CAST
 %token NULL
 %token <joinType> JOINTYPE
 %token <logicOp> LOP
 %token <cmpOp> CMPOP
+%token <boolean> JSONOP
 %token <id> IDENTIFIER
 %token <sqlType> TYPE
 %token <number> NUMBER
+%token <float> FLOAT
 %token <str> VARCHAR
 %token <boolean> BOOLEAN
 %token <blob> BLOB
@@ -79,9 +98,8 @@ func setResult(l yyLexer, stmts []SQLStmt) {
 %left  ','
 %right AS
 %left  LOP
-%right LIKE
 %right NOT
-%left  CMPOP
+%left  CMPOP IN IS LIKE ILIKE
 %left '+' '-'
 %left '*' '/'
 %left  '.'
@@ -92,12 +110,16 @@ func setResult(l yyLexer, stmts []SQLStmt) {
 %type <stmt> sqlstmt dstmt ddlstmt dmlstmt dqlstmt
 %type <colsSpec> colsSpec
 %type <colSpec> colSpec
-%type <ids> ids
-%type <cols> cols
+%type <checks> opt_checks
+%type <check> check
+%type <returning> opt_returning
+%type <ids> ids opt_to pkCols
+%type <idxCols> idxCols
+%type <idxCol> idxCol
 %type <rows> rows
 %type <row> row
-%type <values> values
-%type <value> val
+%type <values> values exps opt_groupby
+%type <value> val row_val opt_default
 %type <sel> selector
 %type <sels> opt_selectors selectors
 %type <col> col
@@ -107,14 +129,16 @@ func setResult(l yyLexer, stmts []SQLStmt) {
 %type <number> opt_since opt_as_before
 %type <joins> opt_joins joins
 %type <join> join
-%type <boolExp> boolExp opt_where opt_having
+%type <boolExp> boolExp opt_where opt_having opt_case_exp opt_else
+%type <whens> whens
 %type <binExp> binExp
-%type <cols> opt_groupby
 %type <number> opt_limit
-%type <id> opt_as
+%type <id> opt_as opt_constraint
 %type <ordcols> ordcols opt_orderby
 %type <opt_ord> opt_ord
-%type <boolean> opt_if_not_exists opt_not_null
+%type <boolean> opt_if_not_exists opt_auto_increment opt_not_null opt_unique opt_analyze
+%type <update> update
+%type <updates> updates
 
 %start sql
     
@@ -136,6 +160,15 @@ sqlstmts:
     {
         $$ = []SQLStmt{$1}
     }
+|
+    EXPLAIN opt_analyze dqlstmt opt_separator
+    {
+        stmt := $3.(*SelectStmt)
+        stmt.explain = true
+        stmt.analyze = $2
+
+        $$ = []SQLStmt{stmt}
+    }
 |
     sqlstmt STMT_SEPARATOR sqlstmts
     {
@@ -144,6 +177,16 @@ sqlstmts:
 
 opt_separator: {} | STMT_SEPARATOR
 
+opt_analyze:
+    {
+        $$ = false
+    }
+|
+    ANALYZE
+    {
+        $$ = true
+    }
+
 sqlstmt:
     dstmt
     {
@@ -154,6 +197,34 @@ sqlstmt:
     {
         $$ = &TxStmt{stmts: $3}
     }
+|
+    BEGIN opt_transaction opt_tx_mode
+    {
+        $$ = &BeginTransactionStmt{}
+    }
+|
+    COMMIT opt_transaction
+    {
+        $$ = &CommitStmt{}
+    }
+|
+    ROLLBACK opt_transaction
+    {
+        $$ = &RollbackStmt{}
+    }
+
+opt_transaction: {} | TRANSACTION
+
+opt_tx_mode:
+    {}
+|
+    IDENTIFIER IDENTIFIER
+    {
+        if $1 != "read" || $2 != "write" {
+            yylex.Error("syntax error: unexpected IDENTIFIER, expecting READ WRITE")
+            return 1
+        }
+    }
 
 dstmt: ddlstmt | dmlstmt
 
@@ -184,20 +255,70 @@ ddlstmt:
         $$ = &UseSnapshotStmt{sinceTx: $3, asBefore: $4}
     }
 |
-    CREATE TABLE opt_if_not_exists IDENTIFIER '(' colsSpec ',' PRIMARY KEY IDENTIFIER ')'
+    CREATE TABLE opt_if_not_exists IDENTIFIER '(' colsSpec ',' opt_checks PRIMARY KEY pkCols ')'
     {
-        $$ = &CreateTableStmt{ifNotExists: $3, table: $4, colsSpec: $6, pk: $10}
+        $$ = &CreateTableStmt{ifNotExists: $3, table: $4, colsSpec: $6, checks: $8, pk: $11}
     }
 |
-    CREATE INDEX ON IDENTIFIER '(' IDENTIFIER ')'
+    CREATE INDEX ON IDENTIFIER '(' idxCols ')'
     {
-        $$ = &CreateIndexStmt{table: $4, col: $6}
+        $$ = &CreateIndexStmt{table: $4, cols: $6}
     }
 |
     ALTER TABLE IDENTIFIER ADD COLUMN colSpec
     {
         $$ = &AddColumnStmt{table: $3, colSpec: $6}
     }
+|
+    ALTER TABLE IDENTIFIER RENAME COLUMN IDENTIFIER TO IDENTIFIER
+    {
+        $$ = &RenameColumnStmt{table: $3, oldName: $6, newName: $8}
+    }
+|
+    DROP TABLE IDENTIFIER
+    {
+        $$ = &DropTableStmt{table: $3}
+    }
+|
+    DROP INDEX ON IDENTIFIER '(' ids ')'
+    {
+        $$ = &DropIndexStmt{table: $4, cols: $6}
+    }
+|
+    TRUNCATE TABLE IDENTIFIER
+    {
+        $$ = &TruncateTableStmt{table: $3}
+    }
+|
+    CREATE POLICY IDENTIFIER ON IDENTIFIER opt_to USING '(' boolExp ')'
+    {
+        $$ = &CreatePolicyStmt{name: $3, table: $5, users: $6, filter: $9}
+    }
+|
+    DROP POLICY IDENTIFIER ON IDENTIFIER
+    {
+        $$ = &DropPolicyStmt{name: $3, table: $5}
+    }
+|
+    CREATE VIEW opt_if_not_exists IDENTIFIER AS dqlstmt
+    {
+        $$ = &CreateViewStmt{ifNotExists: $3, view: $4, query: $6.(*SelectStmt), sql: textFrom(yylex, $<pos>6)}
+    }
+|
+    DROP VIEW IDENTIFIER
+    {
+        $$ = &DropViewStmt{view: $3}
+    }
+
+opt_to:
+    {
+        $$ = nil
+    }
+|
+    TO ids
+    {
+        $$ = $2
+    }
 
 opt_since:
     {
@@ -220,14 +341,46 @@ opt_if_not_exists:
     }
 
 dmlstmt:
-    INSERT INTO tableRef '(' ids ')' VALUES rows
+    INSERT INTO tableRef '(' ids ')' VALUES rows opt_returning
+    {
+        $$ = &UpsertIntoStmt{isInsert: true, tableRef: $3, cols: $5, rows: $8, returning: $9}
+    }
+|
+    UPSERT INTO tableRef '(' ids ')' VALUES rows opt_returning
     {
-        $$ = &UpsertIntoStmt{isInsert: true, tableRef: $3, cols: $5, rows: $8}
+        $$ = &UpsertIntoStmt{tableRef: $3, cols: $5, rows: $8, returning: $9}
     }
 |
-    UPSERT INTO tableRef '(' ids ')' VALUES rows
+    DELETE FROM tableRef opt_where
     {
-        $$ = &UpsertIntoStmt{tableRef: $3, cols: $5, rows: $8}
+        $$ = &DeleteFromStmt{tableRef: $3, where: $4}
+    }
+|
+    UPDATE tableRef SET updates opt_where
+    {
+        $$ = &UpdateStmt{tableRef: $2, updates: $4, where: $5}
+    }
+
+updates:
+    update
+    {
+        $$ = []*colUpdate{$1}
+    }
+|
+    updates ',' update
+    {
+        $$ = append($1, $3)
+    }
+
+update:
+    IDENTIFIER CMPOP boolExp
+    {
+        if $2 != EQ {
+            yylex.Error("syntax error: unexpected CMPOP, expecting =")
+            return 1
+        }
+
+        $$ = &colUpdate{col: $1, val: $3}
     }
 
 rows:
@@ -247,6 +400,21 @@ row:
         $$ = &RowSpec{Values: $2}
     }
 
+opt_returning:
+    {
+        $$ = nil
+    }
+|
+    RETURNING '*'
+    {
+        $$ = &ReturningSpec{}
+    }
+|
+    RETURNING ids
+    {
+        $$ = &ReturningSpec{cols: $2}
+    }
+
 ids:
     IDENTIFIER
     {
@@ -258,24 +426,63 @@ ids:
         $$ = append($1, $3)
     }
 
-cols:
-    col
+pkCols:
+    IDENTIFIER
     {
-        $$ = []*ColSelector{$1}
+        $$ = []string{$1}
     }
 |
-    cols ',' col
+    '(' ids ')'
+    {
+        $$ = $2
+    }
+
+idxCols:
+    idxCol
+    {
+        $$ = []*IndexColSpec{$1}
+    }
+|
+    idxCols ',' idxCol
     {
         $$ = append($1, $3)
     }
 
+idxCol:
+    IDENTIFIER opt_ord
+    {
+        $$ = &IndexColSpec{colName: $1, desc: $2 == LowerOrEqualTo}
+    }
+
 values:
+    row_val
+    {
+        $$ = []ValueExp{$1}
+    }
+|
+    values ',' row_val
+    {
+        $$ = append($1, $3)
+    }
+
+row_val:
     val
+    {
+        $$ = $1
+    }
+|
+    '-' FLOAT
+    {
+        $$ = &Float{val: -$2}
+    }
+
+exps:
+    boolExp
     {
         $$ = []ValueExp{$1}
     }
 |
-    values ',' val
+    exps ',' boolExp
     {
         $$ = append($1, $3)
     }
@@ -285,6 +492,11 @@ val:
     {
         $$ = &Number{val: $1}
     }
+|
+    FLOAT
+    {
+        $$ = &Float{val: $1}
+    }
 |
     VARCHAR
     {
@@ -310,6 +522,16 @@ val:
     {
         $$ = &Param{id: $2}
     }
+|
+    '$' NUMBER
+    {
+        if $2 == 0 {
+            yylex.Error("positional parameters start at $1")
+            return 1
+        }
+
+        $$ = &Param{id: PositionalParam(int($2))}
+    }
 |
     NULL
     {
@@ -328,9 +550,65 @@ colsSpec:
     }
 
 colSpec:
-    IDENTIFIER TYPE opt_not_null
+    IDENTIFIER TYPE opt_auto_increment opt_not_null opt_default opt_unique
+    {
+        $$ = &ColSpec{colName: $1, colType: $2, autoIncrement: $3, notNull: $4, defaultValue: $5, unique: $6}
+    }
+
+opt_auto_increment:
+    {
+        $$ = false
+    }
+|
+    AUTO_INCREMENT
+    {
+        $$ = true
+    }
+
+opt_default:
+    {
+        $$ = nil
+    }
+|
+    DEFAULT row_val
+    {
+        $$ = $2
+    }
+
+opt_checks:
     {
-        $$ = &ColSpec{colName: $1, colType: $2, notNull: $3}
+        $$ = nil
+    }
+|
+    opt_checks check ','
+    {
+        $$ = append($1, $2)
+    }
+
+check:
+    opt_constraint CHECK '(' boolExp ')'
+    {
+        $$ = &CheckSpec{name: $1, exp: $4}
+    }
+
+opt_constraint:
+    {
+        $$ = ""
+    }
+|
+    CONSTRAINT IDENTIFIER
+    {
+        $$ = $2
+    }
+
+opt_unique:
+    {
+        $$ = false
+    }
+|
+    UNIQUE
+    {
+        $$ = true
     }
 
 opt_not_null:
@@ -404,6 +682,11 @@ selector:
     {
         $$ = $1
     }
+|
+    CASE opt_case_exp whens opt_else END
+    {
+        $$ = &CaseWhenExp{exp: $2, whens: $3, elseExp: $4}
+    }
 |
     AGGREGATE_FUNC '(' ')'
     {
@@ -414,6 +697,64 @@ selector:
     {
         $$ = &AggColSelector{aggFn: $1, db: $3.db, table: $3.table, col: $3.col}
     }
+|
+    AGGREGATE_FUNC '(' DISTINCT col ')'
+    {
+        if $1 != COUNT {
+            yylex.Error("DISTINCT is only supported by COUNT")
+            return 1
+        }
+
+        $$ = &AggColSelector{aggFn: $1, db: $4.db, table: $4.table, col: $4.col, distinct: true}
+    }
+|
+    selector JSONOP VARCHAR
+    {
+        sel, err := jsonSelectorFrom($1, $3, $2)
+        if err != nil {
+            yylex.Error(err.Error())
+            return 1
+        }
+
+        $$ = sel
+    }
+|
+    selector JSONOP NUMBER
+    {
+        sel, err := jsonSelectorFrom($1, $3, $2)
+        if err != nil {
+            yylex.Error(err.Error())
+            return 1
+        }
+
+        $$ = sel
+    }
+|
+    IDENTIFIER '(' exps ')'
+    {
+        if $1 == "json_value" {
+            sel, err := jsonValueSelectorFrom($3)
+            if err != nil {
+                yylex.Error(err.Error())
+                return 1
+            }
+
+            $$ = sel
+        } else {
+            fn, err := newFnCall($1, $3)
+            if err != nil {
+                yylex.Error(err.Error())
+                return 1
+            }
+
+            $$ = fn
+        }
+    }
+|
+    CAST '(' boolExp AS TYPE ')'
+    {
+        $$ = &FnCall{fn: "cast", params: []ValueExp{$3, &Varchar{val: string($5)}}}
+    }
 
 col:
     IDENTIFIER
@@ -437,10 +778,60 @@ ds:
         $$ = $1
     }
 |
-    '(' tableRef opt_as_before opt_as ')'
+    tableRef BEFORE TX NUMBER
     {
-        $2.asBefore = $3
-        $2.as = $4
+        $1.asBefore = $4
+        $$ = $1
+    }
+|
+    tableRef SINCE TX NUMBER opt_as_before
+    {
+        $1.sinceTx = $4
+        $1.asBefore = $5
+        $$ = $1
+    }
+|
+    '(' tableRef opt_as ')'
+    {
+        $2.as = $3
+        $$ = $2
+    }
+|
+    '(' tableRef BEFORE TX NUMBER opt_as ')'
+    {
+        $2.asBefore = $5
+        $2.as = $6
+        $$ = $2
+    }
+|
+    '(' tableRef SINCE TX NUMBER opt_as_before opt_as ')'
+    {
+        $2.sinceTx = $5
+        $2.asBefore = $6
+        $2.as = $7
+        $$ = $2
+    }
+|
+    tableRef AS_OF TYPE val
+    {
+        if $3 != TimestampType {
+            yylex.Error("syntax error: unexpected TYPE, expecting TIMESTAMP")
+            return 1
+        }
+
+        $1.asOf = $4
+        $$ = $1
+    }
+|
+    '(' tableRef AS_OF TYPE val opt_as ')'
+    {
+        if $4 != TimestampType {
+            yylex.Error("syntax error: unexpected TYPE, expecting TIMESTAMP")
+            return 1
+        }
+
+        $2.asOf = $5
+        $2.as = $6
         $$ = $2
     }
 |
@@ -496,6 +887,16 @@ join:
     {
         $$ = &JoinSpec{joinType: $1, ds: $3, cond: $5}
     }
+|
+    JOINTYPE OUTER JOIN ds ON boolExp
+    {
+        if $1 == InnerJoin {
+            yylex.Error("syntax error: unexpected OUTER, expecting JOIN")
+            return 1
+        }
+
+        $$ = &JoinSpec{joinType: $1, ds: $4, cond: $6}
+    }
 
 opt_where:
     {
@@ -512,7 +913,7 @@ opt_groupby:
         $$ = nil
     }
 |
-    GROUP BY cols
+    GROUP BY exps
     {
         $$ = $3
     }
@@ -548,14 +949,14 @@ opt_orderby:
     }
 
 ordcols:
-    col opt_ord
+    boolExp opt_ord
     {
-        $$ = []*OrdCol{{sel: $1, cmp: $2}}
+        $$ = []*OrdCol{{exp: $1, cmp: $2}}
     }
 |
-    ordcols ',' col opt_ord
+    ordcols ',' boolExp opt_ord
     {
-        $$ = append($1, &OrdCol{sel: $3, cmp: $4})
+        $$ = append($1, &OrdCol{exp: $3, cmp: $4})
     }
 
 opt_ord:
@@ -573,6 +974,37 @@ opt_ord:
         $$ = LowerOrEqualTo
     }
 
+opt_case_exp:
+    {
+        $$ = nil
+    }
+|
+    boolExp
+    {
+        $$ = $1
+    }
+
+whens:
+    WHEN boolExp THEN boolExp
+    {
+        $$ = []*whenThen{{when: $2, then: $4}}
+    }
+|
+    whens WHEN boolExp THEN boolExp
+    {
+        $$ = append($1, &whenThen{when: $3, then: $5})
+    }
+
+opt_else:
+    {
+        $$ = nil
+    }
+|
+    ELSE boolExp
+    {
+        $$ = $2
+    }
+
 opt_as:
     {
         $$ = ""
@@ -614,14 +1046,74 @@ boolExp:
         $$ = $2
     }
 |
-    selector LIKE VARCHAR
+    EXISTS '(' dqlstmt ')'
     {
-        $$ = &LikeBoolExp{sel: $1, pattern: $3}
+        $$ = &ExistsBoolExp{q: ($3).(*SelectStmt)}
     }
 |
-    EXISTS '(' dqlstmt ')'
+    '(' dqlstmt ')'
     {
-        $$ = &ExistsBoolExp{q: ($3).(*SelectStmt)}
+        $$ = &SubQueryExp{q: ($2).(*SelectStmt)}
+    }
+|
+    boolExp IS NULL
+    {
+        $$ = &IsNullBoolExp{exp: $1}
+    }
+|
+    boolExp IS NOT NULL
+    {
+        $$ = &IsNullBoolExp{exp: $1, isNot: true}
+    }
+|
+    boolExp LIKE boolExp
+    {
+        $$ = &LikeBoolExp{val: $1, pattern: $3}
+    }
+|
+    boolExp NOT LIKE boolExp
+    {
+        $$ = &LikeBoolExp{val: $1, notLike: true, pattern: $4}
+    }
+|
+    boolExp ILIKE boolExp
+    {
+        $$ = &LikeBoolExp{val: $1, caseInsensitive: true, pattern: $3}
+    }
+|
+    boolExp NOT ILIKE boolExp
+    {
+        $$ = &LikeBoolExp{val: $1, notLike: true, caseInsensitive: true, pattern: $4}
+    }
+|
+    boolExp IN '(' exps ')'
+    {
+        $$ = &InListExp{val: $1, values: $4}
+    }
+|
+    boolExp NOT IN '(' exps ')'
+    {
+        $$ = &InListExp{val: $1, notIn: true, values: $5}
+    }
+|
+    boolExp IN '@' IDENTIFIER
+    {
+        $$ = &InListExp{val: $1, values: []ValueExp{&Param{id: $4}}}
+    }
+|
+    boolExp NOT IN '@' IDENTIFIER
+    {
+        $$ = &InListExp{val: $1, notIn: true, values: []ValueExp{&Param{id: $5}}}
+    }
+|
+    boolExp IN '(' dqlstmt ')'
+    {
+        $$ = &InSubQueryExp{val: $1, q: ($4).(*SelectStmt)}
+    }
+|
+    boolExp NOT IN '(' dqlstmt ')'
+    {
+        $$ = &InSubQueryExp{val: $1, notIn: true, q: ($5).(*SelectStmt)}
     }
 
 binExp:
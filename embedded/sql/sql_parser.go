@@ -7,41 +7,54 @@ func setResult(l yyLexer, stmts []SQLStmt) {
 	l.(*lexer).result = stmts
 }
 
+func textFrom(l yyLexer, pos int) string {
+	return l.(*lexer).textFrom(pos)
+}
+
 type yySymType struct {
-	yys      int
-	stmts    []SQLStmt
-	stmt     SQLStmt
-	colsSpec []*ColSpec
-	colSpec  *ColSpec
-	cols     []*ColSelector
-	rows     []*RowSpec
-	row      *RowSpec
-	values   []ValueExp
-	value    ValueExp
-	id       string
-	number   uint64
-	str      string
-	boolean  bool
-	blob     []byte
-	sqlType  SQLValueType
-	aggFn    AggregateFn
-	ids      []string
-	col      *ColSelector
-	sel      Selector
-	sels     []Selector
-	distinct bool
-	ds       DataSource
-	tableRef *TableRef
-	joins    []*JoinSpec
-	join     *JoinSpec
-	joinType JoinType
-	boolExp  ValueExp
-	binExp   ValueExp
-	err      error
-	ordcols  []*OrdCol
-	opt_ord  Comparison
-	logicOp  LogicOperator
-	cmpOp    CmpOperator
+	yys       int
+	stmts     []SQLStmt
+	stmt      SQLStmt
+	colsSpec  []*ColSpec
+	colSpec   *ColSpec
+	checks    []*CheckSpec
+	check     *CheckSpec
+	returning *ReturningSpec
+	rows      []*RowSpec
+	row       *RowSpec
+	values    []ValueExp
+	value     ValueExp
+	id        string
+	number    uint64
+	float     float64
+	str       string
+	boolean   bool
+	blob      []byte
+	sqlType   SQLValueType
+	aggFn     AggregateFn
+	ids       []string
+	col       *ColSelector
+	sel       Selector
+	sels      []Selector
+	distinct  bool
+	ds        DataSource
+	tableRef  *TableRef
+	joins     []*JoinSpec
+	join      *JoinSpec
+	joinType  JoinType
+	boolExp   ValueExp
+	binExp    ValueExp
+	err       error
+	ordcols   []*OrdCol
+	opt_ord   Comparison
+	idxCol    *IndexColSpec
+	idxCols   []*IndexColSpec
+	logicOp   LogicOperator
+	cmpOp     CmpOperator
+	update    *colUpdate
+	updates   []*colUpdate
+	whens     []*whenThen
+	pos       int
 }
 
 const CREATE = 57346
@@ -56,48 +69,80 @@ const INDEX = 57354
 const ON = 57355
 const ALTER = 57356
 const ADD = 57357
-const COLUMN = 57358
-const PRIMARY = 57359
-const KEY = 57360
-const BEGIN = 57361
-const TRANSACTION = 57362
-const COMMIT = 57363
-const INSERT = 57364
-const UPSERT = 57365
-const INTO = 57366
-const VALUES = 57367
-const SELECT = 57368
-const DISTINCT = 57369
-const FROM = 57370
-const BEFORE = 57371
-const TX = 57372
-const JOIN = 57373
-const HAVING = 57374
-const WHERE = 57375
-const GROUP = 57376
-const BY = 57377
-const LIMIT = 57378
-const ORDER = 57379
-const ASC = 57380
-const DESC = 57381
-const AS = 57382
-const NOT = 57383
-const LIKE = 57384
-const IF = 57385
-const EXISTS = 57386
-const NULL = 57387
-const JOINTYPE = 57388
-const LOP = 57389
-const CMPOP = 57390
-const IDENTIFIER = 57391
-const TYPE = 57392
-const NUMBER = 57393
-const VARCHAR = 57394
-const BOOLEAN = 57395
-const BLOB = 57396
-const AGGREGATE_FUNC = 57397
-const ERROR = 57398
-const STMT_SEPARATOR = 57399
+const RENAME = 57358
+const COLUMN = 57359
+const PRIMARY = 57360
+const KEY = 57361
+const DROP = 57362
+const AUTO_INCREMENT = 57363
+const UNIQUE = 57364
+const DEFAULT = 57365
+const CHECK = 57366
+const CONSTRAINT = 57367
+const POLICY = 57368
+const USING = 57369
+const TRUNCATE = 57370
+const VIEW = 57371
+const BEGIN = 57372
+const TRANSACTION = 57373
+const COMMIT = 57374
+const ROLLBACK = 57375
+const INSERT = 57376
+const UPSERT = 57377
+const INTO = 57378
+const VALUES = 57379
+const DELETE = 57380
+const UPDATE = 57381
+const SET = 57382
+const RETURNING = 57383
+const EXPLAIN = 57384
+const ANALYZE = 57385
+const SELECT = 57386
+const DISTINCT = 57387
+const FROM = 57388
+const BEFORE = 57389
+const TX = 57390
+const OF = 57391
+const JOIN = 57392
+const OUTER = 57393
+const HAVING = 57394
+const WHERE = 57395
+const GROUP = 57396
+const BY = 57397
+const LIMIT = 57398
+const ORDER = 57399
+const ASC = 57400
+const DESC = 57401
+const AS = 57402
+const AS_OF = 57403
+const NOT = 57404
+const LIKE = 57405
+const ILIKE = 57406
+const IF = 57407
+const EXISTS = 57408
+const IN = 57409
+const IS = 57410
+const CASE = 57411
+const WHEN = 57412
+const THEN = 57413
+const ELSE = 57414
+const END = 57415
+const CAST = 57416
+const NULL = 57417
+const JOINTYPE = 57418
+const LOP = 57419
+const CMPOP = 57420
+const JSONOP = 57421
+const IDENTIFIER = 57422
+const TYPE = 57423
+const NUMBER = 57424
+const FLOAT = 57425
+const VARCHAR = 57426
+const BOOLEAN = 57427
+const BLOB = 57428
+const AGGREGATE_FUNC = 57429
+const ERROR = 57430
+const STMT_SEPARATOR = 57431
 
 var yyToknames = [...]string{
 	"$end",
@@ -115,22 +160,42 @@ var yyToknames = [...]string{
 	"ON",
 	"ALTER",
 	"ADD",
+	"RENAME",
 	"COLUMN",
 	"PRIMARY",
 	"KEY",
+	"DROP",
+	"AUTO_INCREMENT",
+	"UNIQUE",
+	"DEFAULT",
+	"CHECK",
+	"CONSTRAINT",
+	"POLICY",
+	"USING",
+	"TRUNCATE",
+	"VIEW",
 	"BEGIN",
 	"TRANSACTION",
 	"COMMIT",
+	"ROLLBACK",
 	"INSERT",
 	"UPSERT",
 	"INTO",
 	"VALUES",
+	"DELETE",
+	"UPDATE",
+	"SET",
+	"RETURNING",
+	"EXPLAIN",
+	"ANALYZE",
 	"SELECT",
 	"DISTINCT",
 	"FROM",
 	"BEFORE",
 	"TX",
+	"OF",
 	"JOIN",
+	"OUTER",
 	"HAVING",
 	"WHERE",
 	"GROUP",
@@ -140,17 +205,29 @@ var yyToknames = [...]string{
 	"ASC",
 	"DESC",
 	"AS",
+	"AS_OF",
 	"NOT",
 	"LIKE",
+	"ILIKE",
 	"IF",
 	"EXISTS",
+	"IN",
+	"IS",
+	"CASE",
+	"WHEN",
+	"THEN",
+	"ELSE",
+	"END",
+	"CAST",
 	"NULL",
 	"JOINTYPE",
 	"LOP",
 	"CMPOP",
+	"JSONOP",
 	"IDENTIFIER",
 	"TYPE",
 	"NUMBER",
+	"FLOAT",
 	"VARCHAR",
 	"BOOLEAN",
 	"BLOB",
@@ -166,7 +243,9 @@ var yyToknames = [...]string{
 	"'('",
 	"')'",
 	"'@'",
+	"'$'",
 }
+
 var yyStatenames = [...]string{}
 
 const yyEofCode = 1
@@ -181,164 +260,278 @@ var yyExca = [...]int{
 
 const yyPrivate = 57344
 
-const yyLast = 251
+const yyLast = 590
 
 var yyAct = [...]int{
-
-	205, 37, 56, 145, 122, 4, 124, 144, 99, 71,
-	63, 90, 126, 72, 85, 129, 136, 197, 196, 191,
-	134, 105, 130, 131, 132, 133, 38, 190, 186, 106,
-	127, 165, 136, 155, 156, 128, 171, 135, 130, 131,
-	132, 133, 48, 50, 151, 152, 154, 153, 105, 49,
-	59, 184, 162, 135, 155, 156, 104, 39, 116, 156,
-	77, 112, 96, 162, 73, 151, 152, 154, 153, 151,
-	152, 154, 153, 76, 146, 97, 161, 95, 81, 94,
-	151, 152, 154, 153, 88, 79, 53, 93, 69, 67,
-	58, 18, 16, 103, 154, 153, 39, 68, 59, 204,
-	195, 168, 38, 109, 111, 114, 55, 34, 123, 39,
-	183, 31, 200, 102, 138, 38, 5, 83, 139, 115,
-	137, 7, 39, 140, 188, 163, 118, 147, 113, 36,
-	100, 158, 159, 160, 101, 86, 32, 87, 78, 75,
-	62, 60, 49, 47, 49, 44, 40, 143, 92, 167,
-	100, 142, 176, 170, 174, 80, 177, 178, 179, 180,
-	181, 182, 42, 157, 74, 70, 61, 185, 57, 187,
-	32, 173, 189, 206, 207, 193, 194, 150, 121, 15,
-	108, 149, 110, 82, 17, 65, 64, 54, 21, 10,
-	11, 7, 119, 117, 29, 199, 202, 203, 198, 12,
-	10, 11, 28, 51, 6, 166, 208, 13, 14, 209,
-	12, 7, 52, 19, 2, 84, 66, 22, 13, 14,
-	164, 43, 23, 24, 27, 46, 25, 26, 141, 41,
-	172, 30, 201, 192, 120, 125, 148, 107, 91, 89,
-	45, 20, 35, 33, 169, 175, 98, 9, 8, 3,
-	1,
+	174, 285, 101, 188, 125, 351, 95, 323, 173, 347,
+	238, 141, 322, 196, 235, 63, 134, 398, 4, 142,
+	271, 137, 272, 216, 248, 217, 103, 376, 397, 230,
+	106, 384, 403, 64, 230, 375, 10, 337, 67, 115,
+	386, 51, 312, 382, 107, 54, 108, 109, 110, 111,
+	112, 65, 227, 338, 103, 104, 387, 52, 106, 314,
+	105, 64, 113, 114, 171, 99, 67, 115, 83, 84,
+	85, 313, 107, 143, 108, 109, 110, 111, 112, 65,
+	152, 151, 153, 104, 248, 154, 150, 303, 105, 284,
+	113, 114, 291, 248, 248, 159, 160, 283, 277, 172,
+	276, 249, 247, 263, 161, 162, 163, 228, 155, 156,
+	158, 157, 225, 230, 232, 404, 169, 118, 115, 166,
+	176, 231, 388, 302, 164, 108, 109, 110, 111, 112,
+	324, 118, 170, 117, 353, 332, 191, 190, 320, 118,
+	186, 113, 114, 155, 156, 158, 157, 179, 177, 100,
+	208, 204, 211, 192, 215, 165, 218, 219, 220, 221,
+	222, 223, 203, 202, 133, 115, 132, 119, 116, 91,
+	302, 25, 108, 109, 110, 111, 112, 23, 158, 157,
+	64, 87, 189, 62, 226, 67, 55, 229, 113, 114,
+	246, 66, 6, 349, 373, 251, 135, 401, 65, 244,
+	242, 248, 230, 60, 365, 281, 94, 265, 266, 259,
+	377, 253, 252, 269, 270, 250, 394, 334, 152, 151,
+	153, 56, 333, 154, 150, 273, 146, 300, 145, 299,
+	243, 278, 193, 159, 160, 274, 183, 168, 306, 282,
+	280, 348, 258, 10, 144, 289, 155, 156, 158, 157,
+	189, 367, 103, 371, 321, 239, 106, 236, 311, 64,
+	293, 301, 279, 275, 67, 115, 297, 138, 308, 245,
+	107, 172, 108, 109, 110, 111, 112, 65, 140, 52,
+	309, 104, 97, 187, 56, 180, 105, 227, 113, 114,
+	310, 175, 167, 64, 147, 319, 316, 139, 67, 130,
+	124, 96, 370, 122, 66, 331, 120, 325, 336, 335,
+	330, 65, 92, 52, 82, 81, 80, 152, 151, 153,
+	78, 346, 154, 150, 77, 74, 72, 352, 357, 68,
+	359, 58, 159, 160, 194, 354, 96, 358, 345, 362,
+	361, 198, 363, 268, 210, 155, 156, 158, 157, 149,
+	264, 344, 224, 374, 121, 178, 372, 209, 206, 70,
+	207, 381, 212, 213, 97, 181, 214, 261, 356, 383,
+	200, 380, 352, 286, 287, 391, 379, 328, 296, 352,
+	135, 396, 392, 327, 298, 393, 254, 255, 305, 400,
+	304, 399, 257, 256, 182, 286, 287, 127, 402, 152,
+	151, 153, 405, 126, 154, 150, 260, 406, 34, 199,
+	93, 50, 10, 27, 159, 160, 152, 151, 153, 97,
+	262, 154, 150, 201, 86, 307, 69, 155, 156, 158,
+	157, 159, 160, 152, 151, 153, 294, 292, 154, 150,
+	49, 48, 267, 89, 155, 156, 158, 157, 159, 160,
+	233, 22, 152, 151, 153, 366, 24, 154, 150, 31,
+	28, 155, 156, 158, 157, 288, 73, 159, 160, 152,
+	151, 153, 369, 35, 154, 150, 390, 2, 36, 37,
+	155, 156, 158, 157, 159, 160, 152, 151, 153, 318,
+	364, 154, 150, 38, 13, 14, 39, 155, 156, 158,
+	157, 53, 160, 185, 15, 184, 88, 360, 90, 329,
+	16, 13, 14, 131, 155, 156, 158, 157, 17, 339,
+	7, 15, 8, 9, 18, 19, 342, 16, 20, 21,
+	128, 129, 5, 123, 10, 17, 43, 44, 29, 79,
+	71, 18, 19, 47, 42, 20, 21, 30, 32, 290,
+	241, 45, 76, 57, 46, 40, 41, 136, 26, 389,
+	343, 317, 355, 395, 341, 378, 102, 148, 205, 98,
+	326, 197, 195, 75, 33, 61, 59, 368, 295, 350,
+	237, 385, 240, 340, 315, 234, 12, 11, 3, 1,
 }
-var yyPact = [...]int{
 
-	185, -1000, -1000, 29, 28, -1000, 193, 161, -1000, -1000,
-	211, 220, 213, 178, 170, -1000, 185, -1000, -1000, 196,
-	47, -1000, 97, 119, 208, 96, 217, 94, 93, 93,
-	-1000, 182, 23, 159, -1000, 49, 128, -1000, 26, 36,
-	-1000, 92, 125, 91, -1000, 157, 155, 201, 25, 35,
-	24, -1000, -1000, 196, 0, 60, -1000, 90, 8, 89,
-	21, 111, 14, -1000, 153, 66, 199, 86, 88, 86,
-	-1000, 102, -1000, 95, 128, -1000, -1000, -3, 13, 81,
-	-1000, 85, 62, -1000, 81, -9, -1000, -1000, -36, 147,
-	-1000, 102, 151, 157, -4, -1000, -1000, 79, 48, -1000,
-	69, -7, -1000, -1000, 168, 77, 167, 144, -29, -1000,
-	0, 128, -1000, -1000, 101, 106, -1000, 10, -1000, 10,
-	149, 142, 7, 121, -1000, -1000, -29, -29, -29, 12,
-	-1000, -1000, -1000, -1000, -12, 76, -1000, 207, -34, 187,
-	-1000, -1000, -1000, 104, 44, -1000, -13, 44, 134, -29,
-	73, -29, -29, -29, -29, -29, -29, 58, 11, 34,
-	-14, 165, -37, -1000, -29, -1000, 75, -1000, 10, -38,
-	-1000, -1, 139, 141, 7, 43, -1000, 34, 34, -1000,
-	-1000, 11, 22, -1000, -1000, -47, -1000, 7, -48, -1000,
-	-1000, -13, 128, 61, 73, 73, -1000, -1000, -1000, -1000,
-	-1000, 42, 135, -1000, 73, -1000, -1000, -1000, 135, -1000,
+var yyPact = [...]int{
+	490, -1000, -1000, 82, 76, 370, -1000, 429, 428, 428,
+	363, -1000, -1000, 467, 549, 533, 525, 532, 405, 404,
+	365, 233, -1000, 490, -1000, -1000, 368, -1000, 507, 251,
+	-1000, -1000, -1000, 111, -1000, 249, 294, 527, 246, 294,
+	245, 544, 244, 240, 526, 236, 235, 234, 233, 233,
+	233, 384, 87, -1000, 76, 411, 74, -1000, 232, 364,
+	-1000, 117, 222, -1000, -36, 72, 37, 71, -1000, 226,
+	292, 223, 520, 220, -1000, 356, 349, 515, -1000, 219,
+	500, -1000, -1000, 70, 68, 327, 187, 217, -1000, -1000,
+	-1000, 507, -1000, -23, 224, -1000, 144, 214, 279, 407,
+	257, -1000, -1000, -36, -36, -8, 59, 23, -1000, -1000,
+	-1000, -1000, -1000, 212, 155, -1000, 19, -36, 211, -36,
+	52, 289, 51, 205, 305, -1000, 346, 154, 488, 486,
+	44, 203, 170, 170, -1000, -36, 143, -1000, 256, -1000,
+	-1000, 265, 362, 199, 222, -1000, -1000, -1000, 288, -36,
+	282, -36, 299, -36, -73, -36, -36, -36, -36, -36,
+	-36, 424, 86, 255, 15, 368, 190, -1000, -1000, -1000,
+	10, 191, 45, 24, 407, 20, 390, 177, -1000, 175,
+	540, 368, 148, -1000, 177, 189, 170, -1000, 5, -1000,
+	4, 407, -1000, 187, -36, 327, -1000, 265, 336, 345,
+	344, 161, 359, 6, -1000, 277, -36, -36, 371, -1000,
+	268, 53, -36, -36, -76, 53, -8, 183, 86, 86,
+	-1000, -1000, 424, 53, -1000, -1000, 3, -1000, -1000, 1,
+	-36, -1000, 182, 159, 116, -1000, 158, 0, -1000, 315,
+	438, 170, -1000, -1000, -1000, 539, -5, 400, 180, 399,
+	-1000, 407, 324, -1000, -23, 334, 147, 145, 90, -10,
+	342, 340, 157, -1000, -1000, 354, 407, -36, -1000, 53,
+	53, -8, 178, -55, -26, -1000, -1000, -1000, 407, -1000,
+	-38, 177, 468, -1000, 175, -1000, -1000, -1000, 42, 112,
+	174, -1000, 34, -1000, 34, 331, 322, 496, -23, -1000,
+	356, -1000, 39, -1000, 140, 135, 90, -36, 407, -60,
+	-44, -1000, -1000, -1000, -1000, 501, -1000, 276, -1000, -1000,
+	-36, -1000, 152, -1000, 43, 152, 311, -36, -36, -36,
+	494, -1000, -45, 304, 356, 304, 407, -1000, -1000, 471,
+	115, 431, 171, 449, -1000, 227, 156, -1000, 34, 102,
+	-62, -1000, -1000, 127, -1000, 320, 316, 407, 113, 407,
+	-36, -54, 304, -66, -40, -1000, 26, -1000, 454, 43,
+	-1000, -1000, -1000, -1000, 112, -1000, 43, -1000, 304, 134,
+	-36, 407, -1000, -69, -1000, -80, -1000, 170, -36, -1000,
+	-1000, -1000, -1000, -1000, -1000, 108, 337, -1000, -1000, -65,
+	18, -36, -1000, -1000, -1000, 337, -1000,
 }
-var yyPgo = [...]int{
 
-	0, 250, 214, 111, 249, 116, 248, 247, 5, 246,
-	8, 14, 245, 7, 3, 244, 6, 108, 243, 242,
-	1, 241, 9, 13, 240, 10, 239, 11, 238, 4,
-	237, 236, 235, 234, 233, 2, 232, 230, 0, 229,
-	228, 179,
+var yyPgo = [...]int{
+	0, 589, 477, 186, 588, 192, 587, 586, 18, 585,
+	14, 584, 583, 9, 3, 582, 581, 580, 10, 12,
+	7, 579, 8, 578, 2, 5, 577, 149, 576, 575,
+	15, 574, 11, 19, 573, 4, 572, 13, 571, 0,
+	16, 570, 569, 568, 567, 566, 565, 6, 564, 563,
+	562, 1, 426, 561, 560, 559, 558, 21, 557, 451,
+	538, 553,
 }
-var yyR1 = [...]int{
 
-	0, 1, 2, 2, 2, 41, 41, 4, 4, 5,
-	5, 3, 3, 6, 6, 6, 6, 6, 6, 24,
-	24, 39, 39, 7, 7, 13, 13, 14, 11, 11,
-	12, 12, 15, 15, 16, 16, 16, 16, 16, 16,
-	16, 9, 9, 10, 40, 40, 40, 8, 21, 21,
-	18, 18, 19, 19, 17, 17, 17, 20, 20, 20,
-	22, 22, 22, 23, 23, 25, 25, 26, 26, 27,
-	27, 28, 30, 30, 33, 33, 31, 31, 34, 34,
-	37, 37, 36, 36, 38, 38, 38, 35, 35, 29,
-	29, 29, 29, 29, 29, 29, 29, 32, 32, 32,
-	32, 32, 32,
+var yyR1 = [...]int{
+	0, 1, 2, 2, 2, 2, 59, 59, 56, 56,
+	4, 4, 4, 4, 4, 60, 60, 61, 61, 5,
+	5, 3, 3, 6, 6, 6, 6, 6, 6, 6,
+	6, 6, 6, 6, 6, 6, 6, 15, 15, 34,
+	34, 52, 52, 7, 7, 7, 7, 58, 58, 57,
+	19, 19, 20, 13, 13, 13, 14, 14, 16, 16,
+	17, 17, 18, 21, 21, 25, 25, 22, 22, 24,
+	24, 24, 24, 24, 24, 24, 24, 24, 9, 9,
+	10, 53, 53, 26, 26, 11, 11, 12, 48, 48,
+	55, 55, 54, 54, 54, 8, 31, 31, 28, 28,
+	29, 29, 27, 27, 27, 27, 27, 27, 27, 27,
+	27, 30, 30, 30, 32, 32, 32, 32, 32, 32,
+	32, 32, 32, 33, 33, 35, 35, 36, 36, 37,
+	37, 38, 38, 40, 40, 23, 23, 41, 41, 46,
+	46, 50, 50, 49, 49, 51, 51, 51, 42, 42,
+	44, 44, 43, 43, 47, 47, 39, 39, 39, 39,
+	39, 39, 39, 39, 39, 39, 39, 39, 39, 39,
+	39, 39, 39, 39, 39, 39, 45, 45, 45, 45,
+	45, 45,
 }
-var yyR2 = [...]int{
 
-	0, 1, 2, 2, 3, 0, 1, 1, 4, 1,
-	1, 2, 3, 3, 3, 4, 11, 7, 6, 0,
-	3, 0, 3, 8, 8, 1, 3, 3, 1, 3,
-	1, 3, 1, 3, 1, 1, 1, 1, 3, 2,
-	1, 1, 3, 3, 0, 1, 2, 12, 0, 1,
-	1, 1, 2, 4, 1, 3, 4, 1, 3, 5,
-	1, 5, 3, 1, 3, 0, 3, 0, 1, 1,
-	2, 5, 0, 2, 0, 3, 0, 2, 0, 2,
-	0, 3, 2, 4, 0, 1, 1, 0, 2, 1,
-	1, 1, 2, 2, 3, 3, 4, 3, 3, 3,
-	3, 3, 3,
+var yyR2 = [...]int{
+	0, 1, 2, 2, 4, 3, 0, 1, 0, 1,
+	1, 4, 3, 2, 2, 0, 1, 0, 2, 1,
+	1, 2, 3, 3, 3, 4, 12, 7, 6, 8,
+	3, 7, 3, 10, 5, 6, 3, 0, 2, 0,
+	3, 0, 3, 9, 9, 4, 5, 1, 3, 3,
+	1, 3, 3, 0, 2, 2, 1, 3, 1, 3,
+	1, 3, 2, 1, 3, 1, 2, 1, 3, 1,
+	1, 1, 1, 1, 3, 2, 2, 1, 1, 3,
+	6, 0, 1, 0, 2, 0, 3, 5, 0, 2,
+	0, 1, 0, 1, 2, 12, 0, 1, 1, 1,
+	2, 4, 1, 5, 3, 4, 5, 3, 3, 4,
+	6, 1, 3, 5, 1, 4, 5, 4, 7, 8,
+	4, 7, 3, 1, 3, 0, 3, 0, 1, 1,
+	2, 5, 6, 0, 2, 0, 3, 0, 2, 0,
+	2, 0, 3, 2, 4, 0, 1, 1, 0, 1,
+	4, 5, 0, 2, 0, 2, 1, 1, 1, 2,
+	2, 3, 4, 3, 3, 4, 3, 4, 3, 4,
+	5, 6, 4, 5, 5, 6, 3, 3, 3, 3,
+	3, 3,
 }
-var yyChk = [...]int{
 
-	-1000, -1, -2, -4, -8, -5, 19, 26, -6, -7,
-	4, 5, 14, 22, 23, -41, 63, -41, 63, 20,
-	-21, 27, 6, 11, 12, 6, 7, 11, 24, 24,
-	-2, -3, -5, -18, 60, -19, -17, -20, 55, 49,
-	49, -39, 43, 13, 49, -24, 8, 49, -23, 49,
-	-23, 21, -41, 63, 28, 57, -35, 40, 64, 62,
-	49, 41, 49, -25, 29, 30, 15, 64, 62, 64,
-	-3, -22, -23, 64, -17, 49, 65, -20, 49, 64,
-	44, 64, 30, 51, 16, -11, 49, 49, -11, -26,
-	-27, -28, 46, -23, -8, -35, 65, 62, -9, -10,
-	49, 49, 51, -10, 65, 57, 65, -30, 33, -27,
-	31, -25, 65, 49, 57, 50, 65, 25, 49, 25,
-	-33, 34, -29, -17, -16, -32, 41, 59, 64, 44,
-	51, 52, 53, 54, 49, 66, 45, -22, -35, 17,
-	-10, -40, 45, 41, -13, -14, 64, -13, -31, 32,
-	35, 58, 59, 61, 60, 47, 48, 42, -29, -29,
-	-29, 64, 64, 49, 13, 65, 18, 45, 57, -15,
-	-16, 49, -37, 37, -29, -12, -20, -29, -29, -29,
-	-29, -29, -29, 52, 65, -8, 65, -29, 49, -14,
-	65, 57, -34, 36, 35, 57, 65, 65, -16, -35,
-	51, -36, -20, -20, 57, -38, 38, 39, -20, -38,
+var yyChk = [...]int{
+	-1000, -1, -2, -4, -8, 42, -5, 30, 32, 33,
+	44, -6, -7, 4, 5, 14, 20, 28, 34, 35,
+	38, 39, -59, 95, -59, 95, -56, 43, 31, -60,
+	-60, 31, -60, -31, 45, 6, 11, 12, 26, 29,
+	6, 7, 11, 11, 12, 26, 29, 11, 36, 36,
+	46, -33, 80, -2, -8, -3, -5, -61, 80, -28,
+	92, -29, -27, -30, 69, 87, 80, 74, 80, -52,
+	65, 13, 80, -52, 80, -34, 8, 80, 80, 13,
+	80, 80, 80, -33, -33, -33, 40, 94, -59, 32,
+	-59, 95, 80, 46, 89, -47, 79, 60, -42, -39,
+	-27, -24, -45, 62, 91, 96, 66, 80, 82, 83,
+	84, 85, 86, 98, 99, 75, 96, 96, 94, 96,
+	80, 62, 80, 13, 80, -35, 47, 48, 15, 16,
+	80, 13, 96, 96, -40, 53, -58, -57, 80, 80,
+	-3, -32, -33, 96, -27, 84, 82, 80, -44, 70,
+	68, 63, 62, 64, 67, 90, 91, 93, 92, 77,
+	78, -39, -39, -39, -8, 96, 96, 80, 82, 97,
+	-30, 45, 80, -22, -39, 80, -39, 96, 66, 96,
+	80, 60, 48, 82, 17, 17, 96, 80, -14, 80,
+	-14, -39, -40, 89, 78, -36, -37, -38, 76, 47,
+	8, 61, -33, -8, -47, -43, 70, 72, -39, 75,
+	62, -39, 63, 64, 67, -39, 96, 98, -39, -39,
+	-39, -39, -39, -39, 97, 97, -8, 97, 97, -30,
+	89, 97, 94, 60, -9, -10, 80, -17, -18, 80,
+	-15, 10, -8, 82, -10, 80, -14, 97, 89, 97,
+	-57, -39, -40, -37, 50, 51, 48, 48, 81, -47,
+	47, 8, 61, 97, 73, -39, -39, 71, 75, -39,
+	-39, 96, 98, -22, -8, 80, 97, 97, -39, 80,
+	81, 89, 81, 97, 89, -51, 58, 59, 27, -14,
+	10, 97, 37, 80, 37, -23, 54, -32, 50, 82,
+	82, -24, 80, 97, 48, 48, 81, 71, -39, -22,
+	-8, 80, 97, 97, 97, -11, -10, -53, 21, -18,
+	96, 80, -19, -20, 96, -19, -41, 52, 55, 13,
+	-32, -35, 96, 82, 82, -24, -39, 97, 97, 18,
+	-12, -48, 25, -54, 75, 62, -39, -13, 89, 41,
+	-21, -25, -24, 91, -13, -50, 57, -39, -22, -39,
+	13, -47, -35, -47, 19, 89, 24, 80, -26, 23,
+	75, 97, -20, 92, -14, 97, 89, 83, -46, 56,
+	55, -39, 97, -47, 97, -16, 80, 96, 96, -55,
+	22, -25, -25, -47, 82, -49, -39, 97, 97, -14,
+	-39, 89, -51, 97, 97, -39, -51,
 }
-var yyDef = [...]int{
 
-	0, -2, 1, 5, 5, 7, 0, 48, 9, 10,
-	0, 0, 0, 0, 0, 2, 6, 3, 6, 0,
-	0, 49, 0, 21, 0, 0, 19, 0, 0, 0,
-	4, 0, 5, 0, 50, 51, 87, 54, 0, 57,
-	13, 0, 0, 0, 14, 65, 0, 0, 0, 63,
-	0, 8, 11, 6, 0, 0, 52, 0, 0, 0,
-	0, 0, 0, 15, 0, 0, 0, 0, 0, 0,
-	12, 67, 60, 0, 87, 88, 55, 0, 58, 0,
-	22, 0, 0, 20, 0, 0, 28, 64, 0, 72,
-	68, 69, 0, 65, 0, 53, 56, 0, 0, 41,
-	0, 0, 66, 18, 0, 0, 0, 74, 0, 70,
-	0, 87, 62, 59, 0, 44, 17, 0, 29, 0,
-	76, 0, 73, 89, 90, 91, 0, 0, 0, 0,
-	34, 35, 36, 37, 57, 0, 40, 0, 0, 0,
-	42, 43, 45, 0, 23, 25, 0, 24, 80, 0,
-	0, 0, 0, 0, 0, 0, 0, 0, 92, 93,
-	0, 0, 0, 39, 0, 61, 0, 46, 0, 0,
-	32, 0, 78, 0, 77, 75, 30, 97, 98, 99,
-	100, 101, 102, 95, 94, 0, 38, 71, 0, 26,
-	27, 0, 87, 0, 0, 0, 96, 16, 33, 47,
-	79, 81, 84, 31, 0, 82, 85, 86, 84, 83,
+var yyDef = [...]int{
+	0, -2, 1, 6, 6, 8, 10, 15, 15, 15,
+	96, 19, 20, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 2, 7, 3, 7, 0, 9, 16, 17,
+	13, 16, 14, 0, 97, 0, 41, 0, 0, 41,
+	0, 39, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 123, 5, 6, 0, 6, 12, 0, 0,
+	98, 99, 154, 102, 148, 0, 111, 0, 23, 0,
+	0, 0, 0, 0, 24, 125, 0, 0, 30, 0,
+	0, 36, 32, 0, 0, 133, 0, 0, 4, 11,
+	21, 7, 18, 0, 0, 100, 0, 0, 0, 149,
+	156, 157, 158, 0, 0, 0, 0, 111, 69, 70,
+	71, 72, 73, 0, 0, 77, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 25, 0, 0, 0, 0,
+	0, 0, 0, 0, 45, 0, 133, 47, 0, 124,
+	22, 127, 114, 0, 154, 107, 108, 155, 152, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	0, 159, 160, 0, 0, 0, 0, 75, 76, 104,
+	0, 0, 111, 0, 67, 112, 0, 0, 42, 0,
+	37, 0, 0, 40, 0, 0, 0, 34, 0, 56,
+	0, 134, 46, 0, 0, 133, 128, 129, 0, 0,
+	0, 0, 154, 0, 101, 0, 0, 0, 0, 164,
+	0, 166, 0, 0, 0, 168, 0, 0, 176, 177,
+	178, 179, 180, 181, 161, 163, 0, 74, 105, 0,
+	0, 109, 0, 0, 0, 78, 0, 0, 60, 145,
+	0, 0, 35, 126, 28, 0, 0, 0, 0, 0,
+	48, 49, 135, 130, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 122, 103, 0, 153, 0, 165, 167,
+	169, 0, 0, 0, 0, 172, 162, 106, 68, 113,
+	0, 85, 81, 27, 0, 62, 146, 147, 0, 38,
+	0, 31, 0, 57, 0, 137, 0, 0, 0, 115,
+	125, 120, 0, 117, 0, 0, 0, 0, 150, 0,
+	0, 173, 170, 174, 110, 88, 79, 92, 82, 61,
+	0, 29, 53, 50, 0, 53, 141, 0, 0, 0,
+	0, 116, 0, 154, 125, 154, 151, 171, 175, 0,
+	0, 0, 0, 83, 93, 0, 0, 43, 0, 0,
+	0, 63, 65, 0, 44, 139, 0, 138, 136, 131,
+	0, 0, 154, 0, 0, 86, 0, 89, 90, 0,
+	94, 33, 51, 54, 55, 52, 0, 66, 154, 0,
+	0, 132, 118, 0, 121, 0, 58, 0, 0, 80,
+	91, 84, 64, 95, 140, 142, 145, 119, 26, 0,
+	0, 0, 143, 59, 87, 145, 144,
 }
-var yyTok1 = [...]int{
 
+var yyTok1 = [...]int{
 	1, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
+	3, 3, 3, 3, 3, 3, 99, 3, 3, 3,
+	96, 97, 92, 90, 89, 91, 94, 93, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	64, 65, 60, 58, 57, 59, 62, 61, 3, 3,
-	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	3, 3, 3, 3, 66,
+	3, 3, 3, 3, 98,
 }
-var yyTok2 = [...]int{
 
+var yyTok2 = [...]int{
 	2, 3, 4, 5, 6, 7, 8, 9, 10, 11,
 	12, 13, 14, 15, 16, 17, 18, 19, 20, 21,
 	22, 23, 24, 25, 26, 27, 28, 29, 30, 31,
 	32, 33, 34, 35, 36, 37, 38, 39, 40, 41,
 	42, 43, 44, 45, 46, 47, 48, 49, 50, 51,
-	52, 53, 54, 55, 56, 63,
+	52, 53, 54, 55, 56, 57, 58, 59, 60, 61,
+	62, 63, 64, 65, 66, 67, 68, 69, 70, 71,
+	72, 73, 74, 75, 76, 77, 78, 79, 80, 81,
+	82, 83, 84, 85, 86, 87, 88, 95,
 }
+
 var yyTok3 = [...]int{
 	0,
 }
@@ -695,205 +888,455 @@ yydefault:
 			yyVAL.stmts = []SQLStmt{yyDollar[1].stmt}
 		}
 	case 4:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		{
+			stmt := yyDollar[3].stmt.(*SelectStmt)
+			stmt.explain = true
+			stmt.analyze = yyDollar[2].boolean
+
+			yyVAL.stmts = []SQLStmt{stmt}
+		}
+	case 5:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.stmts = append([]SQLStmt{yyDollar[1].stmt}, yyDollar[3].stmts...)
 		}
-	case 5:
+	case 6:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 		}
-	case 7:
+	case 8:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		{
+			yyVAL.boolean = false
+		}
+	case 9:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.boolean = true
+		}
+	case 10:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.stmt = yyDollar[1].stmt
 		}
-	case 8:
+	case 11:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			yyVAL.stmt = &TxStmt{stmts: yyDollar[3].stmts}
 		}
-	case 11:
+	case 12:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{
+			yyVAL.stmt = &BeginTransactionStmt{}
+		}
+	case 13:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		{
+			yyVAL.stmt = &CommitStmt{}
+		}
+	case 14:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		{
+			yyVAL.stmt = &RollbackStmt{}
+		}
+	case 15:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		{
+		}
+	case 17:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		{
+		}
+	case 18:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		{
+			if yyDollar[1].id != "read" || yyDollar[2].id != "write" {
+				yylex.Error("syntax error: unexpected IDENTIFIER, expecting READ WRITE")
+				return 1
+			}
+		}
+	case 21:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.stmts = []SQLStmt{yyDollar[1].stmt}
 		}
-	case 12:
+	case 22:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.stmts = append([]SQLStmt{yyDollar[1].stmt}, yyDollar[3].stmts...)
 		}
-	case 13:
+	case 23:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.stmt = &CreateDatabaseStmt{DB: yyDollar[3].id}
 		}
-	case 14:
+	case 24:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.stmt = &UseDatabaseStmt{DB: yyDollar[3].id}
 		}
-	case 15:
+	case 25:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			yyVAL.stmt = &UseSnapshotStmt{sinceTx: yyDollar[3].number, asBefore: yyDollar[4].number}
 		}
-	case 16:
-		yyDollar = yyS[yypt-11 : yypt+1]
+	case 26:
+		yyDollar = yyS[yypt-12 : yypt+1]
 		{
-			yyVAL.stmt = &CreateTableStmt{ifNotExists: yyDollar[3].boolean, table: yyDollar[4].id, colsSpec: yyDollar[6].colsSpec, pk: yyDollar[10].id}
+			yyVAL.stmt = &CreateTableStmt{ifNotExists: yyDollar[3].boolean, table: yyDollar[4].id, colsSpec: yyDollar[6].colsSpec, checks: yyDollar[8].checks, pk: yyDollar[11].ids}
 		}
-	case 17:
+	case 27:
 		yyDollar = yyS[yypt-7 : yypt+1]
 		{
-			yyVAL.stmt = &CreateIndexStmt{table: yyDollar[4].id, col: yyDollar[6].id}
+			yyVAL.stmt = &CreateIndexStmt{table: yyDollar[4].id, cols: yyDollar[6].idxCols}
 		}
-	case 18:
+	case 28:
 		yyDollar = yyS[yypt-6 : yypt+1]
 		{
 			yyVAL.stmt = &AddColumnStmt{table: yyDollar[3].id, colSpec: yyDollar[6].colSpec}
 		}
-	case 19:
+	case 29:
+		yyDollar = yyS[yypt-8 : yypt+1]
+		{
+			yyVAL.stmt = &RenameColumnStmt{table: yyDollar[3].id, oldName: yyDollar[6].id, newName: yyDollar[8].id}
+		}
+	case 30:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{
+			yyVAL.stmt = &DropTableStmt{table: yyDollar[3].id}
+		}
+	case 31:
+		yyDollar = yyS[yypt-7 : yypt+1]
+		{
+			yyVAL.stmt = &DropIndexStmt{table: yyDollar[4].id, cols: yyDollar[6].ids}
+		}
+	case 32:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{
+			yyVAL.stmt = &TruncateTableStmt{table: yyDollar[3].id}
+		}
+	case 33:
+		yyDollar = yyS[yypt-10 : yypt+1]
+		{
+			yyVAL.stmt = &CreatePolicyStmt{name: yyDollar[3].id, table: yyDollar[5].id, users: yyDollar[6].ids, filter: yyDollar[9].boolExp}
+		}
+	case 34:
+		yyDollar = yyS[yypt-5 : yypt+1]
+		{
+			yyVAL.stmt = &DropPolicyStmt{name: yyDollar[3].id, table: yyDollar[5].id}
+		}
+	case 35:
+		yyDollar = yyS[yypt-6 : yypt+1]
+		{
+			yyVAL.stmt = &CreateViewStmt{ifNotExists: yyDollar[3].boolean, view: yyDollar[4].id, query: yyDollar[6].stmt.(*SelectStmt), sql: textFrom(yylex, yyDollar[6].pos)}
+		}
+	case 36:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{
+			yyVAL.stmt = &DropViewStmt{view: yyDollar[3].id}
+		}
+	case 37:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		{
+			yyVAL.ids = nil
+		}
+	case 38:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		{
+			yyVAL.ids = yyDollar[2].ids
+		}
+	case 39:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.number = 0
 		}
-	case 20:
+	case 40:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.number = yyDollar[3].number
 		}
-	case 21:
+	case 41:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.boolean = false
 		}
-	case 22:
+	case 42:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.boolean = true
 		}
-	case 23:
-		yyDollar = yyS[yypt-8 : yypt+1]
+	case 43:
+		yyDollar = yyS[yypt-9 : yypt+1]
 		{
-			yyVAL.stmt = &UpsertIntoStmt{isInsert: true, tableRef: yyDollar[3].tableRef, cols: yyDollar[5].ids, rows: yyDollar[8].rows}
+			yyVAL.stmt = &UpsertIntoStmt{isInsert: true, tableRef: yyDollar[3].tableRef, cols: yyDollar[5].ids, rows: yyDollar[8].rows, returning: yyDollar[9].returning}
 		}
-	case 24:
-		yyDollar = yyS[yypt-8 : yypt+1]
+	case 44:
+		yyDollar = yyS[yypt-9 : yypt+1]
 		{
-			yyVAL.stmt = &UpsertIntoStmt{tableRef: yyDollar[3].tableRef, cols: yyDollar[5].ids, rows: yyDollar[8].rows}
+			yyVAL.stmt = &UpsertIntoStmt{tableRef: yyDollar[3].tableRef, cols: yyDollar[5].ids, rows: yyDollar[8].rows, returning: yyDollar[9].returning}
 		}
-	case 25:
+	case 45:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		{
+			yyVAL.stmt = &DeleteFromStmt{tableRef: yyDollar[3].tableRef, where: yyDollar[4].boolExp}
+		}
+	case 46:
+		yyDollar = yyS[yypt-5 : yypt+1]
+		{
+			yyVAL.stmt = &UpdateStmt{tableRef: yyDollar[2].tableRef, updates: yyDollar[4].updates, where: yyDollar[5].boolExp}
+		}
+	case 47:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.updates = []*colUpdate{yyDollar[1].update}
+		}
+	case 48:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{
+			yyVAL.updates = append(yyDollar[1].updates, yyDollar[3].update)
+		}
+	case 49:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{
+			if yyDollar[2].cmpOp != EQ {
+				yylex.Error("syntax error: unexpected CMPOP, expecting =")
+				return 1
+			}
+
+			yyVAL.update = &colUpdate{col: yyDollar[1].id, val: yyDollar[3].boolExp}
+		}
+	case 50:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.rows = []*RowSpec{yyDollar[1].row}
 		}
-	case 26:
+	case 51:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.rows = append(yyDollar[1].rows, yyDollar[3].row)
 		}
-	case 27:
+	case 52:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.row = &RowSpec{Values: yyDollar[2].values}
 		}
-	case 28:
+	case 53:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		{
+			yyVAL.returning = nil
+		}
+	case 54:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		{
+			yyVAL.returning = &ReturningSpec{}
+		}
+	case 55:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		{
+			yyVAL.returning = &ReturningSpec{cols: yyDollar[2].ids}
+		}
+	case 56:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.ids = []string{yyDollar[1].id}
 		}
-	case 29:
+	case 57:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.ids = append(yyDollar[1].ids, yyDollar[3].id)
 		}
-	case 30:
+	case 58:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
-			yyVAL.cols = []*ColSelector{yyDollar[1].col}
+			yyVAL.ids = []string{yyDollar[1].id}
 		}
-	case 31:
+	case 59:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
-			yyVAL.cols = append(yyDollar[1].cols, yyDollar[3].col)
+			yyVAL.ids = yyDollar[2].ids
 		}
-	case 32:
+	case 60:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.idxCols = []*IndexColSpec{yyDollar[1].idxCol}
+		}
+	case 61:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{
+			yyVAL.idxCols = append(yyDollar[1].idxCols, yyDollar[3].idxCol)
+		}
+	case 62:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		{
+			yyVAL.idxCol = &IndexColSpec{colName: yyDollar[1].id, desc: yyDollar[2].opt_ord == LowerOrEqualTo}
+		}
+	case 63:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.values = []ValueExp{yyDollar[1].value}
 		}
-	case 33:
+	case 64:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.values = append(yyDollar[1].values, yyDollar[3].value)
 		}
-	case 34:
+	case 65:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.value = yyDollar[1].value
+		}
+	case 66:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		{
+			yyVAL.value = &Float{val: -yyDollar[2].float}
+		}
+	case 67:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.values = []ValueExp{yyDollar[1].boolExp}
+		}
+	case 68:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{
+			yyVAL.values = append(yyDollar[1].values, yyDollar[3].boolExp)
+		}
+	case 69:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.value = &Number{val: yyDollar[1].number}
 		}
-	case 35:
+	case 70:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.value = &Float{val: yyDollar[1].float}
+		}
+	case 71:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.value = &Varchar{val: yyDollar[1].str}
 		}
-	case 36:
+	case 72:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.value = &Bool{val: yyDollar[1].boolean}
 		}
-	case 37:
+	case 73:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.value = &Blob{val: yyDollar[1].blob}
 		}
-	case 38:
+	case 74:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.value = &SysFn{fn: yyDollar[1].id}
 		}
-	case 39:
+	case 75:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.value = &Param{id: yyDollar[2].id}
 		}
-	case 40:
+	case 76:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		{
+			if yyDollar[2].number == 0 {
+				yylex.Error("positional parameters start at $1")
+				return 1
+			}
+
+			yyVAL.value = &Param{id: PositionalParam(int(yyDollar[2].number))}
+		}
+	case 77:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.value = &NullValue{}
 		}
-	case 41:
+	case 78:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.colsSpec = []*ColSpec{yyDollar[1].colSpec}
 		}
-	case 42:
+	case 79:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.colsSpec = append(yyDollar[1].colsSpec, yyDollar[3].colSpec)
 		}
-	case 43:
+	case 80:
+		yyDollar = yyS[yypt-6 : yypt+1]
+		{
+			yyVAL.colSpec = &ColSpec{colName: yyDollar[1].id, colType: yyDollar[2].sqlType, autoIncrement: yyDollar[3].boolean, notNull: yyDollar[4].boolean, defaultValue: yyDollar[5].value, unique: yyDollar[6].boolean}
+		}
+	case 81:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		{
+			yyVAL.boolean = false
+		}
+	case 82:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.boolean = true
+		}
+	case 83:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		{
+			yyVAL.value = nil
+		}
+	case 84:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		{
+			yyVAL.value = yyDollar[2].value
+		}
+	case 85:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		{
+			yyVAL.checks = nil
+		}
+	case 86:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
-			yyVAL.colSpec = &ColSpec{colName: yyDollar[1].id, colType: yyDollar[2].sqlType, notNull: yyDollar[3].boolean}
+			yyVAL.checks = append(yyDollar[1].checks, yyDollar[2].check)
 		}
-	case 44:
+	case 87:
+		yyDollar = yyS[yypt-5 : yypt+1]
+		{
+			yyVAL.check = &CheckSpec{name: yyDollar[1].id, exp: yyDollar[4].boolExp}
+		}
+	case 88:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		{
+			yyVAL.id = ""
+		}
+	case 89:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		{
+			yyVAL.id = yyDollar[2].id
+		}
+	case 90:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.boolean = false
 		}
-	case 45:
+	case 91:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.boolean = true
+		}
+	case 92:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		{
+			yyVAL.boolean = false
+		}
+	case 93:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.boolean = false
 		}
-	case 46:
+	case 94:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.boolean = true
 		}
-	case 47:
+	case 95:
 		yyDollar = yyS[yypt-12 : yypt+1]
 		{
 			yyVAL.stmt = &SelectStmt{
@@ -902,288 +1345,501 @@ yydefault:
 				ds:        yyDollar[5].ds,
 				joins:     yyDollar[6].joins,
 				where:     yyDollar[7].boolExp,
-				groupBy:   yyDollar[8].cols,
+				groupBy:   yyDollar[8].values,
 				having:    yyDollar[9].boolExp,
 				orderBy:   yyDollar[10].ordcols,
 				limit:     yyDollar[11].number,
 				as:        yyDollar[12].id,
 			}
 		}
-	case 48:
+	case 96:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.distinct = false
 		}
-	case 49:
+	case 97:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.distinct = true
 		}
-	case 50:
+	case 98:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.sels = nil
 		}
-	case 51:
+	case 99:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.sels = yyDollar[1].sels
 		}
-	case 52:
+	case 100:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyDollar[1].sel.setAlias(yyDollar[2].id)
 			yyVAL.sels = []Selector{yyDollar[1].sel}
 		}
-	case 53:
+	case 101:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			yyDollar[3].sel.setAlias(yyDollar[4].id)
 			yyVAL.sels = append(yyDollar[1].sels, yyDollar[3].sel)
 		}
-	case 54:
+	case 102:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.sel = yyDollar[1].col
 		}
-	case 55:
+	case 103:
+		yyDollar = yyS[yypt-5 : yypt+1]
+		{
+			yyVAL.sel = &CaseWhenExp{exp: yyDollar[2].boolExp, whens: yyDollar[3].whens, elseExp: yyDollar[4].boolExp}
+		}
+	case 104:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.sel = &AggColSelector{aggFn: yyDollar[1].aggFn, col: "*"}
 		}
-	case 56:
+	case 105:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			yyVAL.sel = &AggColSelector{aggFn: yyDollar[1].aggFn, db: yyDollar[3].col.db, table: yyDollar[3].col.table, col: yyDollar[3].col.col}
 		}
-	case 57:
+	case 106:
+		yyDollar = yyS[yypt-5 : yypt+1]
+		{
+			if yyDollar[1].aggFn != COUNT {
+				yylex.Error("DISTINCT is only supported by COUNT")
+				return 1
+			}
+
+			yyVAL.sel = &AggColSelector{aggFn: yyDollar[1].aggFn, db: yyDollar[4].col.db, table: yyDollar[4].col.table, col: yyDollar[4].col.col, distinct: true}
+		}
+	case 107:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{
+			sel, err := jsonSelectorFrom(yyDollar[1].sel, yyDollar[3].str, yyDollar[2].boolean)
+			if err != nil {
+				yylex.Error(err.Error())
+				return 1
+			}
+
+			yyVAL.sel = sel
+		}
+	case 108:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{
+			sel, err := jsonSelectorFrom(yyDollar[1].sel, yyDollar[3].number, yyDollar[2].boolean)
+			if err != nil {
+				yylex.Error(err.Error())
+				return 1
+			}
+
+			yyVAL.sel = sel
+		}
+	case 109:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		{
+			if yyDollar[1].id == "json_value" {
+				sel, err := jsonValueSelectorFrom(yyDollar[3].values)
+				if err != nil {
+					yylex.Error(err.Error())
+					return 1
+				}
+
+				yyVAL.sel = sel
+			} else {
+				fn, err := newFnCall(yyDollar[1].id, yyDollar[3].values)
+				if err != nil {
+					yylex.Error(err.Error())
+					return 1
+				}
+
+				yyVAL.sel = fn
+			}
+		}
+	case 110:
+		yyDollar = yyS[yypt-6 : yypt+1]
+		{
+			yyVAL.sel = &FnCall{fn: "cast", params: []ValueExp{yyDollar[3].boolExp, &Varchar{val: string(yyDollar[5].sqlType)}}}
+		}
+	case 111:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.col = &ColSelector{col: yyDollar[1].id}
 		}
-	case 58:
+	case 112:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.col = &ColSelector{table: yyDollar[1].id, col: yyDollar[3].id}
 		}
-	case 59:
+	case 113:
 		yyDollar = yyS[yypt-5 : yypt+1]
 		{
 			yyVAL.col = &ColSelector{db: yyDollar[1].id, table: yyDollar[3].id, col: yyDollar[5].id}
 		}
-	case 60:
+	case 114:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.ds = yyDollar[1].tableRef
 		}
-	case 61:
+	case 115:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		{
+			yyDollar[1].tableRef.asBefore = yyDollar[4].number
+			yyVAL.ds = yyDollar[1].tableRef
+		}
+	case 116:
 		yyDollar = yyS[yypt-5 : yypt+1]
 		{
-			yyDollar[2].tableRef.asBefore = yyDollar[3].number
-			yyDollar[2].tableRef.as = yyDollar[4].id
+			yyDollar[1].tableRef.sinceTx = yyDollar[4].number
+			yyDollar[1].tableRef.asBefore = yyDollar[5].number
+			yyVAL.ds = yyDollar[1].tableRef
+		}
+	case 117:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		{
+			yyDollar[2].tableRef.as = yyDollar[3].id
 			yyVAL.ds = yyDollar[2].tableRef
 		}
-	case 62:
+	case 118:
+		yyDollar = yyS[yypt-7 : yypt+1]
+		{
+			yyDollar[2].tableRef.asBefore = yyDollar[5].number
+			yyDollar[2].tableRef.as = yyDollar[6].id
+			yyVAL.ds = yyDollar[2].tableRef
+		}
+	case 119:
+		yyDollar = yyS[yypt-8 : yypt+1]
+		{
+			yyDollar[2].tableRef.sinceTx = yyDollar[5].number
+			yyDollar[2].tableRef.asBefore = yyDollar[6].number
+			yyDollar[2].tableRef.as = yyDollar[7].id
+			yyVAL.ds = yyDollar[2].tableRef
+		}
+	case 120:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		{
+			if yyDollar[3].sqlType != TimestampType {
+				yylex.Error("syntax error: unexpected TYPE, expecting TIMESTAMP")
+				return 1
+			}
+
+			yyDollar[1].tableRef.asOf = yyDollar[4].value
+			yyVAL.ds = yyDollar[1].tableRef
+		}
+	case 121:
+		yyDollar = yyS[yypt-7 : yypt+1]
+		{
+			if yyDollar[4].sqlType != TimestampType {
+				yylex.Error("syntax error: unexpected TYPE, expecting TIMESTAMP")
+				return 1
+			}
+
+			yyDollar[2].tableRef.asOf = yyDollar[5].value
+			yyDollar[2].tableRef.as = yyDollar[6].id
+			yyVAL.ds = yyDollar[2].tableRef
+		}
+	case 122:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.ds = yyDollar[2].stmt.(*SelectStmt)
 		}
-	case 63:
+	case 123:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.tableRef = &TableRef{table: yyDollar[1].id}
 		}
-	case 64:
+	case 124:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.tableRef = &TableRef{db: yyDollar[1].id, table: yyDollar[3].id}
 		}
-	case 65:
+	case 125:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.number = 0
 		}
-	case 66:
+	case 126:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.number = yyDollar[3].number
 		}
-	case 67:
+	case 127:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.joins = nil
 		}
-	case 68:
+	case 128:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.joins = yyDollar[1].joins
 		}
-	case 69:
+	case 129:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.joins = []*JoinSpec{yyDollar[1].join}
 		}
-	case 70:
+	case 130:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.joins = append([]*JoinSpec{yyDollar[1].join}, yyDollar[2].joins...)
 		}
-	case 71:
+	case 131:
 		yyDollar = yyS[yypt-5 : yypt+1]
 		{
 			yyVAL.join = &JoinSpec{joinType: yyDollar[1].joinType, ds: yyDollar[3].ds, cond: yyDollar[5].boolExp}
 		}
-	case 72:
+	case 132:
+		yyDollar = yyS[yypt-6 : yypt+1]
+		{
+			if yyDollar[1].joinType == InnerJoin {
+				yylex.Error("syntax error: unexpected OUTER, expecting JOIN")
+				return 1
+			}
+
+			yyVAL.join = &JoinSpec{joinType: yyDollar[1].joinType, ds: yyDollar[4].ds, cond: yyDollar[6].boolExp}
+		}
+	case 133:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.boolExp = nil
 		}
-	case 73:
+	case 134:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.boolExp = yyDollar[2].boolExp
 		}
-	case 74:
+	case 135:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
-			yyVAL.cols = nil
+			yyVAL.values = nil
 		}
-	case 75:
+	case 136:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
-			yyVAL.cols = yyDollar[3].cols
+			yyVAL.values = yyDollar[3].values
 		}
-	case 76:
+	case 137:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.boolExp = nil
 		}
-	case 77:
+	case 138:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.boolExp = yyDollar[2].boolExp
 		}
-	case 78:
+	case 139:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.number = 0
 		}
-	case 79:
+	case 140:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.number = yyDollar[2].number
 		}
-	case 80:
+	case 141:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.ordcols = nil
 		}
-	case 81:
+	case 142:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.ordcols = yyDollar[3].ordcols
 		}
-	case 82:
+	case 143:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
-			yyVAL.ordcols = []*OrdCol{{sel: yyDollar[1].col, cmp: yyDollar[2].opt_ord}}
+			yyVAL.ordcols = []*OrdCol{{exp: yyDollar[1].boolExp, cmp: yyDollar[2].opt_ord}}
 		}
-	case 83:
+	case 144:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
-			yyVAL.ordcols = append(yyDollar[1].ordcols, &OrdCol{sel: yyDollar[3].col, cmp: yyDollar[4].opt_ord})
+			yyVAL.ordcols = append(yyDollar[1].ordcols, &OrdCol{exp: yyDollar[3].boolExp, cmp: yyDollar[4].opt_ord})
 		}
-	case 84:
+	case 145:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.opt_ord = GreaterOrEqualTo
 		}
-	case 85:
+	case 146:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.opt_ord = GreaterOrEqualTo
 		}
-	case 86:
+	case 147:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.opt_ord = LowerOrEqualTo
 		}
-	case 87:
+	case 148:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		{
+			yyVAL.boolExp = nil
+		}
+	case 149:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.boolExp = yyDollar[1].boolExp
+		}
+	case 150:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		{
+			yyVAL.whens = []*whenThen{{when: yyDollar[2].boolExp, then: yyDollar[4].boolExp}}
+		}
+	case 151:
+		yyDollar = yyS[yypt-5 : yypt+1]
+		{
+			yyVAL.whens = append(yyDollar[1].whens, &whenThen{when: yyDollar[3].boolExp, then: yyDollar[5].boolExp})
+		}
+	case 152:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		{
+			yyVAL.boolExp = nil
+		}
+	case 153:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		{
+			yyVAL.boolExp = yyDollar[2].boolExp
+		}
+	case 154:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.id = ""
 		}
-	case 88:
+	case 155:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.id = yyDollar[2].id
 		}
-	case 89:
+	case 156:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.boolExp = yyDollar[1].sel
 		}
-	case 90:
+	case 157:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.boolExp = yyDollar[1].value
 		}
-	case 91:
+	case 158:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			yyVAL.boolExp = yyDollar[1].binExp
 		}
-	case 92:
+	case 159:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.boolExp = &NotBoolExp{exp: yyDollar[2].boolExp}
 		}
-	case 93:
+	case 160:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.boolExp = &NumExp{left: &Number{val: uint64(0)}, op: SUBSOP, right: yyDollar[2].boolExp}
 		}
-	case 94:
+	case 161:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.boolExp = yyDollar[2].boolExp
 		}
-	case 95:
+	case 162:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		{
+			yyVAL.boolExp = &ExistsBoolExp{q: (yyDollar[3].stmt).(*SelectStmt)}
+		}
+	case 163:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
-			yyVAL.boolExp = &LikeBoolExp{sel: yyDollar[1].sel, pattern: yyDollar[3].str}
+			yyVAL.boolExp = &SubQueryExp{q: (yyDollar[2].stmt).(*SelectStmt)}
 		}
-	case 96:
+	case 164:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{
+			yyVAL.boolExp = &IsNullBoolExp{exp: yyDollar[1].boolExp}
+		}
+	case 165:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
-			yyVAL.boolExp = &ExistsBoolExp{q: (yyDollar[3].stmt).(*SelectStmt)}
+			yyVAL.boolExp = &IsNullBoolExp{exp: yyDollar[1].boolExp, isNot: true}
 		}
-	case 97:
+	case 166:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{
+			yyVAL.boolExp = &LikeBoolExp{val: yyDollar[1].boolExp, pattern: yyDollar[3].boolExp}
+		}
+	case 167:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		{
+			yyVAL.boolExp = &LikeBoolExp{val: yyDollar[1].boolExp, notLike: true, pattern: yyDollar[4].boolExp}
+		}
+	case 168:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{
+			yyVAL.boolExp = &LikeBoolExp{val: yyDollar[1].boolExp, caseInsensitive: true, pattern: yyDollar[3].boolExp}
+		}
+	case 169:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		{
+			yyVAL.boolExp = &LikeBoolExp{val: yyDollar[1].boolExp, notLike: true, caseInsensitive: true, pattern: yyDollar[4].boolExp}
+		}
+	case 170:
+		yyDollar = yyS[yypt-5 : yypt+1]
+		{
+			yyVAL.boolExp = &InListExp{val: yyDollar[1].boolExp, values: yyDollar[4].values}
+		}
+	case 171:
+		yyDollar = yyS[yypt-6 : yypt+1]
+		{
+			yyVAL.boolExp = &InListExp{val: yyDollar[1].boolExp, notIn: true, values: yyDollar[5].values}
+		}
+	case 172:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		{
+			yyVAL.boolExp = &InListExp{val: yyDollar[1].boolExp, values: []ValueExp{&Param{id: yyDollar[4].id}}}
+		}
+	case 173:
+		yyDollar = yyS[yypt-5 : yypt+1]
+		{
+			yyVAL.boolExp = &InListExp{val: yyDollar[1].boolExp, notIn: true, values: []ValueExp{&Param{id: yyDollar[5].id}}}
+		}
+	case 174:
+		yyDollar = yyS[yypt-5 : yypt+1]
+		{
+			yyVAL.boolExp = &InSubQueryExp{val: yyDollar[1].boolExp, q: (yyDollar[4].stmt).(*SelectStmt)}
+		}
+	case 175:
+		yyDollar = yyS[yypt-6 : yypt+1]
+		{
+			yyVAL.boolExp = &InSubQueryExp{val: yyDollar[1].boolExp, notIn: true, q: (yyDollar[5].stmt).(*SelectStmt)}
+		}
+	case 176:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.binExp = &NumExp{left: yyDollar[1].boolExp, op: ADDOP, right: yyDollar[3].boolExp}
 		}
-	case 98:
+	case 177:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.binExp = &NumExp{left: yyDollar[1].boolExp, op: SUBSOP, right: yyDollar[3].boolExp}
 		}
-	case 99:
+	case 178:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.binExp = &NumExp{left: yyDollar[1].boolExp, op: DIVOP, right: yyDollar[3].boolExp}
 		}
-	case 100:
+	case 179:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.binExp = &NumExp{left: yyDollar[1].boolExp, op: MULTOP, right: yyDollar[3].boolExp}
 		}
-	case 101:
+	case 180:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.binExp = &BinBoolExp{left: yyDollar[1].boolExp, op: yyDollar[2].logicOp, right: yyDollar[3].boolExp}
 		}
-	case 102:
+	case 181:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.binExp = &CmpBoolExp{left: yyDollar[1].boolExp, op: yyDollar[2].cmpOp, right: yyDollar[3].boolExp}
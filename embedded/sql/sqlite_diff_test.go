@@ -0,0 +1,471 @@
+// +build sqlite
+
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package sql
+
+import (
+	dbsql "database/sql"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codenotary/immudb/embedded/store"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+// Differential testing of the sql engine against SQLite: random statements within the supported
+// grammar are executed by both engines and the rows they return are compared.
+// Run with: go test -tags sqlite -run TestSQLiteDifferential ./embedded/sql
+
+var sqliteSeed = flag.Int64("sqlite.seed", 0, "seed used to generate the statements compared against SQLite, time-based when zero")
+var sqliteRounds = flag.Int("sqlite.rounds", 500, "number of random statements compared against SQLite")
+
+type diffColumn struct {
+	name     string
+	colType  SQLValueType
+	nullable bool
+}
+
+// id is the primary key and n is indexed, thus both of them can be used to sort rows
+var diffColumns = []diffColumn{
+	{name: "id", colType: IntegerType},
+	{name: "n", colType: IntegerType},
+	{name: "a", colType: IntegerType, nullable: true},
+	{name: "s", colType: VarcharType, nullable: true},
+	{name: "b", colType: BooleanType, nullable: true},
+}
+
+var diffVarchars = []string{"a", "b", "ab", "ba", "abc", "c"}
+
+// values are lowercase, so the case-insensitive LIKE of SQLite matches as the one of immudb
+var diffLikePatterns = []string{"a%", "%b", "_b%", "a_c", "%", "ab", "%a%"}
+
+// diffStmt holds the same statement written for each engine
+type diffStmt struct {
+	immudb string
+	sqlite string
+}
+
+type diffGen struct {
+	rnd    *rand.Rand
+	nextID int
+}
+
+func (g *diffGen) column(filter func(c diffColumn) bool) diffColumn {
+	for {
+		c := diffColumns[g.rnd.Intn(len(diffColumns))]
+		if filter == nil || filter(c) {
+			return c
+		}
+	}
+}
+
+func (g *diffGen) value(colType SQLValueType) diffStmt {
+	switch colType {
+	case IntegerType:
+		{
+			v := fmt.Sprintf("%d", g.rnd.Intn(10))
+			return diffStmt{v, v}
+		}
+	case VarcharType:
+		{
+			v := fmt.Sprintf("'%s'", diffVarchars[g.rnd.Intn(len(diffVarchars))])
+			return diffStmt{v, v}
+		}
+	}
+
+	if g.rnd.Intn(2) == 0 {
+		return diffStmt{"false", "0"}
+	}
+	return diffStmt{"true", "1"}
+}
+
+// assigned returns a value to be stored into the column
+func (g *diffGen) assigned(c diffColumn) diffStmt {
+	if c.nullable && g.rnd.Intn(4) == 0 {
+		return diffStmt{"NULL", "NULL"}
+	}
+	return g.value(c.colType)
+}
+
+// operand returns a value to be compared with values of the given column
+func (g *diffGen) operand(c diffColumn) diffStmt {
+	switch g.rnd.Intn(6) {
+	case 0:
+		{
+			return diffStmt{"NULL", "NULL"}
+		}
+	case 1:
+		{
+			return g.colRef(g.column(func(o diffColumn) bool { return o.colType == c.colType }))
+		}
+	}
+	return g.value(c.colType)
+}
+
+func (g *diffGen) colRef(c diffColumn) diffStmt {
+	return diffStmt{c.name, c.name}
+}
+
+func (g *diffGen) cond(depth int) diffStmt {
+	if depth == 0 || g.rnd.Intn(3) == 0 {
+		c := g.column(nil)
+		l := g.colRef(c)
+
+		if g.rnd.Intn(5) == 0 {
+			pred := []string{"IS NULL", "IS NOT NULL"}[g.rnd.Intn(2)]
+			return diffStmt{fmt.Sprintf("(%s %s)", l.immudb, pred), fmt.Sprintf("(%s %s)", l.sqlite, pred)}
+		}
+
+		if c.colType == VarcharType && g.rnd.Intn(2) == 0 {
+			op := []string{"LIKE", "NOT LIKE"}[g.rnd.Intn(2)]
+			pattern := diffLikePatterns[g.rnd.Intn(len(diffLikePatterns))]
+			return diffStmt{fmt.Sprintf("(%s %s '%s')", l.immudb, op, pattern), fmt.Sprintf("(%s %s '%s')", l.sqlite, op, pattern)}
+		}
+
+		if g.rnd.Intn(4) == 0 {
+			// long enough lists are looked up in a set
+			op := []string{"IN", "NOT IN"}[g.rnd.Intn(2)]
+			immudbVals := make([]string, 1+g.rnd.Intn(10))
+			sqliteVals := make([]string, len(immudbVals))
+
+			for i := range immudbVals {
+				v := g.operand(c)
+				immudbVals[i], sqliteVals[i] = v.immudb, v.sqlite
+			}
+
+			return diffStmt{
+				fmt.Sprintf("(%s %s (%s))", l.immudb, op, strings.Join(immudbVals, ", ")),
+				fmt.Sprintf("(%s %s (%s))", l.sqlite, op, strings.Join(sqliteVals, ", ")),
+			}
+		}
+
+		r := g.operand(c)
+		op := []string{"=", "!=", "<", "<=", ">", ">="}[g.rnd.Intn(6)]
+
+		return diffStmt{
+			fmt.Sprintf("(%s %s %s)", l.immudb, op, r.immudb),
+			fmt.Sprintf("(%s %s %s)", l.sqlite, op, r.sqlite),
+		}
+	}
+
+	switch g.rnd.Intn(4) {
+	case 0:
+		{
+			e := g.cond(depth - 1)
+			return diffStmt{fmt.Sprintf("(NOT %s)", e.immudb), fmt.Sprintf("(NOT %s)", e.sqlite)}
+		}
+	case 1:
+		{
+			l, r := g.cond(depth-1), g.cond(depth-1)
+			return diffStmt{fmt.Sprintf("(%s AND %s)", l.immudb, r.immudb), fmt.Sprintf("(%s AND %s)", l.sqlite, r.sqlite)}
+		}
+	case 2:
+		{
+			when, then, els := g.cond(depth-1), g.cond(depth-1), g.cond(depth-1)
+			return diffStmt{
+				fmt.Sprintf("(CASE WHEN %s THEN %s ELSE %s END)", when.immudb, then.immudb, els.immudb),
+				fmt.Sprintf("(CASE WHEN %s THEN %s ELSE %s END)", when.sqlite, then.sqlite, els.sqlite),
+			}
+		}
+	}
+
+	l, r := g.cond(depth-1), g.cond(depth-1)
+	return diffStmt{fmt.Sprintf("(%s OR %s)", l.immudb, r.immudb), fmt.Sprintf("(%s OR %s)", l.sqlite, r.sqlite)}
+}
+
+// caseExp returns either a simple or a searched CASE expression. Results are not booleans,
+// as SQLite returns them as integers when they are not read from a column
+func (g *diffGen) caseExp() diffStmt {
+	rc := g.column(func(c diffColumn) bool { return c.colType != BooleanType })
+
+	var immudbExp, sqliteExp strings.Builder
+
+	immudbExp.WriteString("CASE")
+	sqliteExp.WriteString("CASE")
+
+	var sc *diffColumn
+	if g.rnd.Intn(2) == 0 {
+		c := g.column(nil)
+		sc = &c
+
+		fmt.Fprintf(&immudbExp, " %s", c.name)
+		fmt.Fprintf(&sqliteExp, " %s", c.name)
+	}
+
+	for i := 0; i < 1+g.rnd.Intn(3); i++ {
+		var when diffStmt
+		if sc != nil {
+			when = g.operand(*sc)
+		} else {
+			when = g.cond(1)
+		}
+
+		then := g.operand(rc)
+
+		fmt.Fprintf(&immudbExp, " WHEN %s THEN %s", when.immudb, then.immudb)
+		fmt.Fprintf(&sqliteExp, " WHEN %s THEN %s", when.sqlite, then.sqlite)
+	}
+
+	if g.rnd.Intn(2) == 0 {
+		els := g.operand(rc)
+
+		fmt.Fprintf(&immudbExp, " ELSE %s", els.immudb)
+		fmt.Fprintf(&sqliteExp, " ELSE %s", els.sqlite)
+	}
+
+	immudbExp.WriteString(" END")
+	sqliteExp.WriteString(" END")
+
+	return diffStmt{immudbExp.String(), sqliteExp.String()}
+}
+
+func (g *diffGen) upsert() diffStmt {
+	id := g.nextID
+
+	if g.nextID > 0 && g.rnd.Intn(4) == 0 {
+		id = g.rnd.Intn(g.nextID)
+	} else {
+		g.nextID++
+	}
+
+	vals := make([]string, len(diffColumns))
+	svals := make([]string, len(diffColumns))
+
+	vals[0] = fmt.Sprintf("%d", id)
+	svals[0] = vals[0]
+
+	for i, c := range diffColumns[1:] {
+		v := g.assigned(c)
+		vals[i+1] = v.immudb
+		svals[i+1] = v.sqlite
+	}
+
+	return diffStmt{
+		fmt.Sprintf("UPSERT INTO table1 (id, n, a, s, b) VALUES (%s)", strings.Join(vals, ", ")),
+		fmt.Sprintf("INSERT OR REPLACE INTO table1 (id, n, a, s, b) VALUES (%s)", strings.Join(svals, ", ")),
+	}
+}
+
+func (g *diffGen) update() diffStmt {
+	c := g.column(func(c diffColumn) bool { return c.name != "id" })
+	v := g.assigned(c)
+	cond := g.cond(2)
+
+	return diffStmt{
+		fmt.Sprintf("UPDATE table1 SET %s = %s WHERE %s", c.name, v.immudb, cond.immudb),
+		fmt.Sprintf("UPDATE table1 SET %s = %s WHERE %s", c.name, v.sqlite, cond.sqlite),
+	}
+}
+
+func (g *diffGen) delete() diffStmt {
+	cond := g.cond(2)
+
+	return diffStmt{
+		fmt.Sprintf("DELETE FROM table1 WHERE %s", cond.immudb),
+		fmt.Sprintf("DELETE FROM table1 WHERE %s", cond.sqlite),
+	}
+}
+
+func (g *diffGen) query() diffStmt {
+	cond := g.cond(3)
+
+	// aggregations over no rows are zero instead of null
+	if g.rnd.Intn(4) == 0 {
+		return diffStmt{
+			fmt.Sprintf("SELECT COUNT(), SUM(id), MIN(n), MAX(n) FROM table1 WHERE %s", cond.immudb),
+			fmt.Sprintf("SELECT COUNT(*), COALESCE(SUM(id), 0), COALESCE(MIN(n), 0), COALESCE(MAX(n), 0) FROM table1 WHERE %s", cond.sqlite),
+		}
+	}
+
+	ordCol := "id"
+	if g.rnd.Intn(2) == 0 {
+		ordCol = "n"
+	}
+
+	ord := "ASC"
+	if g.rnd.Intn(2) == 0 {
+		ord = "DESC"
+	}
+
+	limit := ""
+	if g.rnd.Intn(3) == 0 {
+		limit = fmt.Sprintf(" LIMIT %d", 1+g.rnd.Intn(5))
+	}
+
+	// rows sharing the same indexed value are sorted by primary key
+	sordCols := fmt.Sprintf("id %s", ord)
+	if ordCol == "n" {
+		sordCols = fmt.Sprintf("n %s, id %s", ord, ord)
+	}
+
+	caseExp := g.caseExp()
+
+	return diffStmt{
+		fmt.Sprintf("SELECT id, n, a, s, b, %s FROM table1 WHERE %s ORDER BY %s %s%s", caseExp.immudb, cond.immudb, ordCol, ord, limit),
+		fmt.Sprintf("SELECT id, n, a, s, b, %s FROM table1 WHERE %s ORDER BY %s%s", caseExp.sqlite, cond.sqlite, sordCols, limit),
+	}
+}
+
+func diffValue(v interface{}) interface{} {
+	switch rv := v.(type) {
+	case uint64:
+		return int64(rv)
+	case []byte:
+		return string(rv)
+	}
+	return v
+}
+
+func immudbRows(t *testing.T, engine *Engine, query string) [][]interface{} {
+	r, err := engine.QueryStmt(query, nil, true)
+	require.NoError(t, err, query)
+	defer r.Close()
+
+	cols, err := r.Columns()
+	require.NoError(t, err, query)
+
+	var rows [][]interface{}
+
+	for {
+		row, err := r.Read()
+		if err == ErrNoMoreRows {
+			break
+		}
+		require.NoError(t, err, query)
+
+		vals := make([]interface{}, len(cols))
+		for i, c := range cols {
+			vals[i] = diffValue(row.Values[c.Selector].Value())
+		}
+
+		rows = append(rows, vals)
+	}
+
+	return rows
+}
+
+func sqliteRows(t *testing.T, db *dbsql.DB, query string) [][]interface{} {
+	r, err := db.Query(query)
+	require.NoError(t, err, query)
+	defer r.Close()
+
+	cols, err := r.Columns()
+	require.NoError(t, err, query)
+
+	var rows [][]interface{}
+
+	for r.Next() {
+		vals := make([]interface{}, len(cols))
+		ptrs := make([]interface{}, len(cols))
+		for i := range vals {
+			ptrs[i] = &vals[i]
+		}
+
+		err = r.Scan(ptrs...)
+		require.NoError(t, err, query)
+
+		for i := range vals {
+			vals[i] = diffValue(vals[i])
+		}
+
+		rows = append(rows, vals)
+	}
+	require.NoError(t, r.Err(), query)
+
+	return rows
+}
+
+func TestSQLiteDifferential(t *testing.T) {
+	seed := *sqliteSeed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	t.Logf("seed: %d", seed)
+
+	catalogStore, err := store.Open("catalog_sqlite_diff", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("catalog_sqlite_diff")
+
+	dataStore, err := store.Open("sqldata_sqlite_diff", store.DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("sqldata_sqlite_diff")
+
+	engine, err := NewEngine(catalogStore, dataStore, prefix)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE DATABASE db1", nil, true)
+	require.NoError(t, err)
+
+	err = engine.UseDatabase("db1")
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE TABLE table1 (id INTEGER, n INTEGER NOT NULL, a INTEGER, s VARCHAR, b BOOLEAN, PRIMARY KEY id)", nil, true)
+	require.NoError(t, err)
+
+	_, _, err = engine.ExecStmt("CREATE INDEX ON table1(n)", nil, true)
+	require.NoError(t, err)
+
+	db, err := dbsql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	// a single connection is used as each connection to an in-memory database gets a new one
+	db.SetMaxOpenConns(1)
+
+	_, err = db.Exec("CREATE TABLE table1 (id INTEGER PRIMARY KEY, n INTEGER NOT NULL, a INTEGER, s VARCHAR, b BOOLEAN)")
+	require.NoError(t, err)
+
+	g := &diffGen{rnd: rand.New(rand.NewSource(seed))}
+
+	for i := 0; i < *sqliteRounds; i++ {
+		var stmt diffStmt
+
+		switch n := g.rnd.Intn(10); {
+		case n < 4:
+			stmt = g.upsert()
+		case n < 5:
+			stmt = g.update()
+		case n < 6:
+			stmt = g.delete()
+		default:
+			stmt = g.query()
+		}
+
+		if strings.HasPrefix(stmt.immudb, "SELECT") {
+			require.Equal(t, sqliteRows(t, db, stmt.sqlite), immudbRows(t, engine, stmt.immudb), "seed: %d, query: %s", seed, stmt.immudb)
+			continue
+		}
+
+		_, _, err = engine.ExecStmt(stmt.immudb, nil, true)
+		require.NoError(t, err, "seed: %d, stmt: %s", seed, stmt.immudb)
+
+		_, err = db.Exec(stmt.sqlite)
+		require.NoError(t, err, stmt.sqlite)
+
+		require.Equal(t,
+			sqliteRows(t, db, "SELECT id, n, a, s, b FROM table1 ORDER BY id"),
+			immudbRows(t, engine, "SELECT id, n, a, s, b FROM table1"),
+			"seed: %d, stmt: %s", seed, stmt.immudb,
+		)
+	}
+}
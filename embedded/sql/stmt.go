@@ -18,9 +18,12 @@ package sql
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
 	"errors"
-	"regexp"
+	"fmt"
+	"math"
+	"sort"
 	"strings"
 	"time"
 
@@ -29,20 +32,40 @@ import (
 
 const (
 	catalogDatabasePrefix = "CATALOG.DATABASE." // (key=CATALOG.DATABASE.{dbID}, value={dbNAME})
-	catalogTablePrefix    = "CATALOG.TABLE."    // (key=CATALOG.TABLE.{dbID}{tableID}{pkID}, value={tableNAME})
-	catalogColumnPrefix   = "CATALOG.COLUMN."   // (key=CATALOG.COLUMN.{dbID}{tableID}{colID}{colTYPE}, value={nullable}{colNAME})
-	catalogIndexPrefix    = "CATALOG.INDEX."    // (key=CATALOG.INDEX.{dbID}{tableID}{colID}, value={})
-	RowPrefix             = "ROW."              // (key=ROW.{dbID}{tableID}{colID}({valLen}{val})?{pkValLen}{pkVal}, value={})
+	catalogTablePrefix    = "CATALOG.TABLE."    // (key=CATALOG.TABLE.{dbID}{tableID}{pkID}({pkID})*, value={tableNAME})
+	catalogColumnPrefix   = "CATALOG.COLUMN."   // (key=CATALOG.COLUMN.{dbID}{tableID}{colID}{colTYPE}, value={flags}({colNameLen})?{colNAME}{defaultVALUE}?)
+	catalogIndexPrefix    = "CATALOG.INDEX."    // (key=CATALOG.INDEX.{dbID}{tableID}{colID}{flags}?({colID}{flags})*, value={tableNAME})
+	catalogPolicyPrefix   = "CATALOG.POLICY."   // (key=CATALOG.POLICY.{dbID}{tableID}{policyNAME}, value={CREATE POLICY statement})
+	catalogCheckPrefix    = "CATALOG.CHECK."    // (key=CATALOG.CHECK.{dbID}{tableID}{checkNAME}, value={condition})
+	catalogViewPrefix     = "CATALOG.VIEW."     // (key=CATALOG.VIEW.{dbID}{viewNAME}, value={CREATE VIEW statement})
+	RowPrefix             = "ROW."              // (key=ROW.{dbID}{tableID}{colID}({valLen}{val})*({pkValLen}{pkVal})+, value={})
+	SeqPrefix             = "SEQ."              // (key=SEQ.{dbID}{tableID}, value={last auto incremental pk})
+	UniquePrefix          = "UNIQUE."           // (key=UNIQUE.{dbID}{tableID}{colID}{valLen}{val}, value={pkValLen}{pkVal})
+)
+
+// flags of columns as persisted in the catalog
+const (
+	notNullFlag byte = 1 << iota
+	autoIncrementFlag
+	defaultValueFlag
+)
+
+// flags of indexes as persisted in the catalog, indexes created before flags were introduced have none
+const (
+	uniqueIndexFlag byte = 1 << iota
+	descIndexFlag
 )
 
 type SQLValueType = string
 
 const (
 	IntegerType   SQLValueType = "INTEGER"
+	FloatType                  = "FLOAT"
 	BooleanType                = "BOOLEAN"
 	VarcharType                = "VARCHAR"
 	BLOBType                   = "BLOB"
 	TimestampType              = "TIMESTAMP"
+	JSONType                   = "JSON"
 )
 
 type AggregateFn = string
@@ -92,7 +115,7 @@ const (
 
 type SQLStmt interface {
 	isDDL() bool
-	CompileUsing(e *Engine, implicitDB *Database, params map[string]interface{}) (ces, des []*store.KV, db *Database, err error)
+	CompileUsing(ctx context.Context, e *Engine, implicitDB *Database, params map[string]interface{}) (ces, des []*store.KV, db *Database, err error)
 }
 
 type TxStmt struct {
@@ -108,9 +131,14 @@ func (stmt *TxStmt) isDDL() bool {
 	return false
 }
 
-func (stmt *TxStmt) CompileUsing(e *Engine, implicitDB *Database, params map[string]interface{}) (ces, des []*store.KV, db *Database, err error) {
+func (stmt *TxStmt) CompileUsing(ctx context.Context, e *Engine, implicitDB *Database, params map[string]interface{}) (ces, des []*store.KV, db *Database, err error) {
 	for _, stmt := range stmt.stmts {
-		cs, ds, db, err := stmt.CompileUsing(e, implicitDB, params)
+		err := ctx.Err()
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		cs, ds, db, err := stmt.CompileUsing(ctx, e, implicitDB, params)
 		if err != nil {
 			return nil, nil, nil, err
 		}
@@ -124,6 +152,41 @@ func (stmt *TxStmt) CompileUsing(e *Engine, implicitDB *Database, params map[str
 	return ces, des, implicitDB, nil
 }
 
+// BeginTransactionStmt starts a transaction within the session executing the statements, the statements following it
+// are deferred until the transaction is committed, thus they are committed atomically. Sessions are not handled by the
+// engine, which fails to execute BEGIN, COMMIT and ROLLBACK statements
+type BeginTransactionStmt struct{}
+
+func (stmt *BeginTransactionStmt) isDDL() bool {
+	return false
+}
+
+func (stmt *BeginTransactionStmt) CompileUsing(ctx context.Context, e *Engine, implicitDB *Database, params map[string]interface{}) (ces, des []*store.KV, db *Database, err error) {
+	return nil, nil, nil, ErrSessionTxStmt
+}
+
+// CommitStmt executes the statements deferred since the transaction of the session was started
+type CommitStmt struct{}
+
+func (stmt *CommitStmt) isDDL() bool {
+	return false
+}
+
+func (stmt *CommitStmt) CompileUsing(ctx context.Context, e *Engine, implicitDB *Database, params map[string]interface{}) (ces, des []*store.KV, db *Database, err error) {
+	return nil, nil, nil, ErrSessionTxStmt
+}
+
+// RollbackStmt discards the statements deferred since the transaction of the session was started
+type RollbackStmt struct{}
+
+func (stmt *RollbackStmt) isDDL() bool {
+	return false
+}
+
+func (stmt *RollbackStmt) CompileUsing(ctx context.Context, e *Engine, implicitDB *Database, params map[string]interface{}) (ces, des []*store.KV, db *Database, err error) {
+	return nil, nil, nil, ErrSessionTxStmt
+}
+
 type CreateDatabaseStmt struct {
 	DB string
 }
@@ -136,7 +199,7 @@ func (stmt *CreateDatabaseStmt) isDDL() bool {
 	return true
 }
 
-func (stmt *CreateDatabaseStmt) CompileUsing(e *Engine, implicitDB *Database, params map[string]interface{}) (ces, des []*store.KV, db *Database, err error) {
+func (stmt *CreateDatabaseStmt) CompileUsing(ctx context.Context, e *Engine, implicitDB *Database, params map[string]interface{}) (ces, des []*store.KV, db *Database, err error) {
 	db, err = e.catalog.newDatabase(stmt.DB)
 	if err != nil {
 		return nil, nil, nil, err
@@ -160,7 +223,7 @@ func (stmt *UseDatabaseStmt) isDDL() bool {
 	return false
 }
 
-func (stmt *UseDatabaseStmt) CompileUsing(e *Engine, implicitDB *Database, params map[string]interface{}) (ces, des []*store.KV, db *Database, err error) {
+func (stmt *UseDatabaseStmt) CompileUsing(ctx context.Context, e *Engine, implicitDB *Database, params map[string]interface{}) (ces, des []*store.KV, db *Database, err error) {
 	db, err = e.catalog.GetDatabaseByName(stmt.DB)
 	if err != nil {
 		return nil, nil, nil, err
@@ -178,7 +241,7 @@ func (stmt *UseSnapshotStmt) isDDL() bool {
 	return false
 }
 
-func (stmt *UseSnapshotStmt) CompileUsing(e *Engine, implicitDB *Database, params map[string]interface{}) (ces, des []*store.KV, db *Database, err error) {
+func (stmt *UseSnapshotStmt) CompileUsing(ctx context.Context, e *Engine, implicitDB *Database, params map[string]interface{}) (ces, des []*store.KV, db *Database, err error) {
 	return nil, nil, nil, ErrNoSupported
 }
 
@@ -186,14 +249,15 @@ type CreateTableStmt struct {
 	table       string
 	ifNotExists bool
 	colsSpec    []*ColSpec
-	pk          string
+	checks      []*CheckSpec
+	pk          []string
 }
 
 func (stmt *CreateTableStmt) isDDL() bool {
 	return true
 }
 
-func (stmt *CreateTableStmt) CompileUsing(e *Engine, implicitDB *Database, params map[string]interface{}) (ces, des []*store.KV, db *Database, err error) {
+func (stmt *CreateTableStmt) CompileUsing(ctx context.Context, e *Engine, implicitDB *Database, params map[string]interface{}) (ces, des []*store.KV, db *Database, err error) {
 	if implicitDB == nil {
 		return nil, nil, nil, ErrNoDatabaseSelected
 	}
@@ -202,27 +266,25 @@ func (stmt *CreateTableStmt) CompileUsing(e *Engine, implicitDB *Database, param
 		return nil, nil, implicitDB, nil
 	}
 
-	table, err := implicitDB.newTable(stmt.table, stmt.colsSpec, stmt.pk)
+	table, err := implicitDB.newTable(stmt.table, stmt.colsSpec, stmt.checks, stmt.pk)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
-	for colID, col := range table.ColsByID() {
-		v := make([]byte, 1+len(col.colName))
-		if col.notNull {
-			v[0] = 1
-		}
-		copy(v[1:], []byte(col.Name()))
+	for _, c := range table.checks {
+		ces = append(ces, e.checkEntry(c, false))
+	}
 
-		ce := &store.KV{
-			Key:   e.mapKey(catalogColumnPrefix, EncodeID(implicitDB.id), EncodeID(table.id), EncodeID(colID), []byte(col.colType)),
-			Value: v,
-		}
-		ces = append(ces, ce)
+	for _, col := range table.ColsByID() {
+		ces = append(ces, e.columnEntry(col))
+	}
+
+	for _, idx := range table.indexes {
+		ces = append(ces, e.indexEntry(idx))
 	}
 
 	te := &store.KV{
-		Key:   e.mapKey(catalogTablePrefix, EncodeID(implicitDB.id), EncodeID(table.id), EncodeID(table.pk.id)),
+		Key:   e.tableKey(table),
 		Value: []byte(table.name),
 	}
 	ces = append(ces, te)
@@ -230,22 +292,134 @@ func (stmt *CreateTableStmt) CompileUsing(e *Engine, implicitDB *Database, param
 	return ces, des, implicitDB, nil
 }
 
+// columnEntry returns the catalog entry of the column, column type is part of the key thus only its name and nullability can be changed.
+// Default values are kept as they're given within the values of an insertion, following the length-prefixed name of the column
+func (e *Engine) columnEntry(col *Column) *store.KV {
+	var flags byte
+	if col.notNull {
+		flags |= notNullFlag
+	}
+	if col.autoIncrement {
+		flags |= autoIncrementFlag
+	}
+
+	v := []byte{flags}
+
+	if col.defaultValue == nil {
+		v = append(v, []byte(col.colName)...)
+	} else {
+		v[0] |= defaultValueFlag
+
+		var nameLen [EncLenLen]byte
+		binary.BigEndian.PutUint32(nameLen[:], uint32(len(col.colName)))
+
+		def, _ := formatExp(col.defaultValue, nil, false)
+
+		v = append(v, nameLen[:]...)
+		v = append(v, []byte(col.colName)...)
+		v = append(v, []byte(def)...)
+	}
+
+	return &store.KV{
+		Key:   e.mapKey(catalogColumnPrefix, EncodeID(col.table.db.id), EncodeID(col.table.id), EncodeID(col.id), []byte(col.colType)),
+		Value: v,
+	}
+}
+
+// tableKey returns the key of the catalog entry of the table, the columns of the primary key are part of it
+func (e *Engine) tableKey(table *Table) []byte {
+	key := e.mapKey(catalogTablePrefix, EncodeID(table.db.id), EncodeID(table.id))
+
+	for _, col := range table.pkCols {
+		key = append(key, EncodeID(col.id)...)
+	}
+
+	return key
+}
+
+// indexEntry returns the catalog entry of the index, unique indexes are flagged after the leading column
+// while each one of the following columns of multi-column indexes is flagged after its id
+func (e *Engine) indexEntry(idx *Index) *store.KV {
+	table := idx.table
+
+	key := e.mapKey(catalogIndexPrefix, EncodeID(table.db.id), EncodeID(table.id), EncodeID(idx.id()))
+
+	var flags byte
+	if idx.IsUnique() {
+		flags |= uniqueIndexFlag
+	}
+
+	if flags != 0 || len(idx.cols) > 1 {
+		key = append(key, flags)
+	}
+
+	for i, col := range idx.cols[1:] {
+		flags = 0
+		if idx.desc[i+1] {
+			flags |= descIndexFlag
+		}
+
+		key = append(key, EncodeID(col.id)...)
+		key = append(key, flags)
+	}
+
+	return &store.KV{
+		Key:   key,
+		Value: []byte(table.name),
+	}
+}
+
+// uniqueEntry returns the entry claiming the value of a unique column for the row. The entry is rejected at commit time
+// if the value is held by another row. Entries of values no longer held are marked as deleted, so they can be claimed again
+func (e *Engine) uniqueEntry(col *Column, encVal, pkEncVal []byte, deleted bool) *store.KV {
+	kv := &store.KV{
+		Key: e.mapKey(UniquePrefix, EncodeID(col.table.db.id), EncodeID(col.table.id), EncodeID(col.id), encVal),
+	}
+
+	if deleted {
+		kv.Metadata = store.NewKVMetadata().AsDeleted(true)
+	} else {
+		kv.Value = pkEncVal
+		kv.Unique = true
+	}
+
+	return kv
+}
+
+// claimUnique returns the entry claiming the value of a unique column for the row,
+// rows written by the same statement can not share values either
+func (e *Engine) claimUnique(claims map[string]struct{}, col *Column, encVal, pkEncVal []byte) (*store.KV, error) {
+	kv := e.uniqueEntry(col, encVal, pkEncVal, false)
+
+	_, claimed := claims[string(kv.Key)]
+	if claimed {
+		return nil, fmt.Errorf("%w: %s(%s)", ErrUniqueConstraintViolation, col.table.name, col.colName)
+	}
+
+	claims[string(kv.Key)] = struct{}{}
+
+	return kv, nil
+}
+
 type ColSpec struct {
-	colName string
-	colType SQLValueType
-	notNull bool
+	colName       string
+	colType       SQLValueType
+	autoIncrement bool
+	notNull       bool
+	defaultValue  ValueExp
+	unique        bool
 }
 
 type CreateIndexStmt struct {
 	table string
-	col   string
+	cols  []*IndexColSpec
 }
 
 func (stmt *CreateIndexStmt) isDDL() bool {
 	return true
 }
 
-func (stmt *CreateIndexStmt) CompileUsing(e *Engine, implicitDB *Database, params map[string]interface{}) (ces, des []*store.KV, db *Database, err error) {
+func (stmt *CreateIndexStmt) CompileUsing(ctx context.Context, e *Engine, implicitDB *Database, params map[string]interface{}) (ces, des []*store.KV, db *Database, err error) {
 	if implicitDB == nil {
 		return nil, nil, nil, ErrNoDatabaseSelected
 	}
@@ -255,23 +429,14 @@ func (stmt *CreateIndexStmt) CompileUsing(e *Engine, implicitDB *Database, param
 		return nil, nil, nil, err
 	}
 
-	if table.pk.colName == stmt.col {
-		return nil, nil, nil, ErrIndexAlreadyExists
-	}
-
-	col, err := table.GetColumnByName(stmt.col)
+	idx, err := table.newIndex(stmt.cols)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
-	_, exists := table.indexes[col.id]
-	if exists {
-		return nil, nil, nil, ErrIndexAlreadyExists
-	}
-
 	// check table is empty
 	lastTxID, _ := e.dataStore.Alh()
-	err = e.dataStore.WaitForIndexingUpto(lastTxID, nil)
+	err = e.dataStore.WaitForIndexingUpto(context.Background(), lastTxID)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -285,13 +450,7 @@ func (stmt *CreateIndexStmt) CompileUsing(e *Engine, implicitDB *Database, param
 		return nil, nil, nil, ErrLimitedIndex
 	}
 
-	table.indexes[col.id] = struct{}{}
-
-	te := &store.KV{
-		Key:   e.mapKey(catalogIndexPrefix, EncodeID(table.db.id), EncodeID(table.id), EncodeID(col.id)),
-		Value: []byte(table.name),
-	}
-	ces = append(ces, te)
+	ces = append(ces, e.indexEntry(idx))
 
 	return ces, des, implicitDB, nil
 }
@@ -305,186 +464,480 @@ func (stmt *AddColumnStmt) isDDL() bool {
 	return true
 }
 
-func (stmt *AddColumnStmt) CompileUsing(e *Engine, implicitDB *Database, params map[string]interface{}) (ces, des []*store.KV, db *Database, err error) {
-	return nil, nil, nil, ErrNoSupported
+// CompileUsing adds a nullable column to the table, the column is read as null from existing rows
+func (stmt *AddColumnStmt) CompileUsing(ctx context.Context, e *Engine, implicitDB *Database, params map[string]interface{}) (ces, des []*store.KV, db *Database, err error) {
+	if implicitDB == nil {
+		return nil, nil, nil, ErrNoDatabaseSelected
+	}
+
+	table, err := implicitDB.GetTableByName(stmt.table)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	col, err := table.newColumn(stmt.colSpec)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	ces = append(ces, e.columnEntry(col))
+
+	return ces, des, implicitDB, nil
 }
 
-type UpsertIntoStmt struct {
-	isInsert bool
-	tableRef *TableRef
-	cols     []string
-	rows     []*RowSpec
+type RenameColumnStmt struct {
+	table   string
+	oldName string
+	newName string
 }
 
-type RowSpec struct {
-	Values []ValueExp
+func (stmt *RenameColumnStmt) isDDL() bool {
+	return true
 }
 
-func (r *RowSpec) bytes(catalog *Catalog, t *Table, cols []string, params map[string]interface{}) ([]byte, error) {
-	valbuf := bytes.Buffer{}
+// CompileUsing renames the column, row values are bound to the column id thus existing rows are not rewritten
+func (stmt *RenameColumnStmt) CompileUsing(ctx context.Context, e *Engine, implicitDB *Database, params map[string]interface{}) (ces, des []*store.KV, db *Database, err error) {
+	if implicitDB == nil {
+		return nil, nil, nil, ErrNoDatabaseSelected
+	}
 
-	colCount := 0
+	table, err := implicitDB.GetTableByName(stmt.table)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 
-	notNullCols := make(map[uint64]struct{}, len(t.colsByID))
+	col, err := table.renameColumn(stmt.oldName, stmt.newName)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 
-	for i, val := range r.Values {
-		col, err := t.GetColumnByName(cols[i])
-		if err != nil {
-			return nil, err
-		}
+	ces = append(ces, e.columnEntry(col))
 
-		sval, err := val.substitute(params)
-		if err != nil {
-			return nil, err
-		}
+	return ces, des, implicitDB, nil
+}
 
-		rval, err := sval.reduce(catalog, nil, t.db.name, t.name)
-		if err != nil {
-			return nil, err
-		}
+type DropTableStmt struct {
+	table string
+}
 
-		_, isNull := rval.(*NullValue)
-		if isNull {
-			continue
-		}
+func (stmt *DropTableStmt) isDDL() bool {
+	return true
+}
 
-		b := make([]byte, EncIDLen)
-		binary.BigEndian.PutUint64(b, uint64(col.id))
+// CompileUsing marks the catalog entries of the table as deleted, rows of the table are kept in the store
+// but they are no longer reachable as the id of a dropped table is never reused
+func (stmt *DropTableStmt) CompileUsing(ctx context.Context, e *Engine, implicitDB *Database, params map[string]interface{}) (ces, des []*store.KV, db *Database, err error) {
+	if implicitDB == nil {
+		return nil, nil, nil, ErrNoDatabaseSelected
+	}
 
-		_, err = valbuf.Write(b)
-		if err != nil {
-			return nil, err
-		}
+	table, err := implicitDB.dropTable(stmt.table)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 
-		valb, err := EncodeValue(rval, col.colType, !asKey)
-		if err != nil {
-			return nil, err
-		}
+	return e.tableEntries(table, true), des, implicitDB, nil
+}
 
-		_, err = valbuf.Write(valb)
-		if err != nil {
-			return nil, err
-		}
+// tableEntries returns the catalog entries of the table, its columns, indexes, policies and check constraints
+func (e *Engine) tableEntries(table *Table, deleted bool) []*store.KV {
+	var entries []*store.KV
 
-		notNullCols[col.id] = struct{}{}
+	for _, col := range table.colsByID {
+		entries = append(entries, e.columnEntry(col))
+	}
 
-		colCount++
+	for _, idx := range table.indexes {
+		entries = append(entries, e.indexEntry(idx))
 	}
 
-	for _, c := range t.colsByID {
-		if c.IsNullable() {
-			continue
-		}
+	for _, p := range table.policies {
+		entries = append(entries, e.policyEntry(p, false))
+	}
 
-		_, notNull := notNullCols[c.id]
-		if !notNull {
-			return nil, ErrNotNullableColumnCannotBeNull
-		}
+	for _, c := range table.checks {
+		entries = append(entries, e.checkEntry(c, false))
 	}
 
-	b := make([]byte, EncLenLen+len(valbuf.Bytes()))
-	binary.BigEndian.PutUint32(b, uint32(colCount))
-	copy(b[EncLenLen:], valbuf.Bytes())
+	entries = append(entries, &store.KV{
+		Key:   e.tableKey(table),
+		Value: []byte(table.name),
+	})
 
-	return b, nil
-}
+	if deleted {
+		for _, kv := range entries {
+			kv.Metadata = store.NewKVMetadata().AsDeleted(true)
+		}
+	}
 
-func (stmt *UpsertIntoStmt) isDDL() bool {
-	return false
+	return entries
 }
 
-func (stmt *UpsertIntoStmt) Validate(table *Table) (map[uint64]int, error) {
-	pkIncluded := false
-	selByColID := make(map[uint64]int, len(stmt.cols))
+type TruncateTableStmt struct {
+	table string
+}
 
-	for i, c := range stmt.cols {
-		col, err := table.GetColumnByName(c)
-		if err != nil {
-			return nil, err
-		}
+func (stmt *TruncateTableStmt) isDDL() bool {
+	return true
+}
 
-		if table.pk.colName == c {
-			pkIncluded = true
-		}
+// CompileUsing replaces the table with a new empty version of it within a single catalog transaction.
+// Catalog entries of the truncated version are marked as deleted, as done when dropping a table,
+// and its rows are kept in the store, where they can still be proven, but they are no longer reachable
+// as the new version of the table gets a new id
+func (stmt *TruncateTableStmt) CompileUsing(ctx context.Context, e *Engine, implicitDB *Database, params map[string]interface{}) (ces, des []*store.KV, db *Database, err error) {
+	if implicitDB == nil {
+		return nil, nil, nil, ErrNoDatabaseSelected
+	}
 
-		_, duplicated := selByColID[col.id]
-		if duplicated {
-			return nil, ErrDuplicatedColumn
-		}
+	table, err := implicitDB.GetTableByName(stmt.table)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 
-		selByColID[col.id] = i
+	// rows not accessible within the session can not be deleted
+	if len(table.policies) > 0 && sessionUserFrom(ctx) != nil {
+		return nil, nil, nil, ErrPolicyViolation
 	}
 
-	if !pkIncluded {
-		return nil, ErrPKCanNotBeNull
+	truncated, table, err := implicitDB.truncateTable(stmt.table)
+	if err != nil {
+		return nil, nil, nil, err
 	}
 
-	return selByColID, nil
+	ces = append(e.tableEntries(truncated, true), e.tableEntries(table, false)...)
+
+	return ces, des, implicitDB, nil
 }
 
-func (stmt *UpsertIntoStmt) CompileUsing(e *Engine, implicitDB *Database, params map[string]interface{}) (ces, des []*store.KV, db *Database, err error) {
-	table, err := stmt.tableRef.referencedTable(e, implicitDB)
+type DropIndexStmt struct {
+	table string
+	cols  []string
+}
+
+func (stmt *DropIndexStmt) isDDL() bool {
+	return true
+}
+
+// CompileUsing marks the catalog entry of the index as deleted, index entries are kept in the store
+// and the ones no longer matching the row they refer to are skipped if the index gets created again
+func (stmt *DropIndexStmt) CompileUsing(ctx context.Context, e *Engine, implicitDB *Database, params map[string]interface{}) (ces, des []*store.KV, db *Database, err error) {
+	if implicitDB == nil {
+		return nil, nil, nil, ErrNoDatabaseSelected
+	}
+
+	table, err := implicitDB.GetTableByName(stmt.table)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
-	cs, err := stmt.Validate(table)
+	// the entry is built before the index gets dropped, as its key depends on whether the index is unique
+	idx, err := table.indexOn(stmt.cols)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
-	for _, row := range stmt.rows {
-		if len(row.Values) != len(stmt.cols) {
-			return nil, nil, nil, ErrInvalidNumberOfValues
-		}
+	ie := e.indexEntry(idx)
+	ie.Metadata = store.NewKVMetadata().AsDeleted(true)
 
-		pkVal := row.Values[cs[table.pk.id]]
+	_, err = table.dropIndex(stmt.cols)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 
-		val, err := pkVal.substitute(params)
-		if err != nil {
-			return nil, nil, nil, err
-		}
+	ces = append(ces, ie)
 
-		rval, err := val.reduce(e.catalog, nil, implicitDB.name, table.name)
-		if err != nil {
-			return nil, nil, nil, err
-		}
+	return ces, des, implicitDB, nil
+}
 
-		_, isNull := rval.(*NullValue)
-		if isNull {
-			return nil, nil, nil, ErrPKCanNotBeNull
-		}
+type CreatePolicyStmt struct {
+	name   string
+	table  string
+	users  []string
+	filter ValueExp
+}
 
-		pkEncVal, err := EncodeValue(rval, table.pk.colType, asKey)
-		if err != nil {
-			return nil, nil, nil, err
-		}
+func (stmt *CreatePolicyStmt) isDDL() bool {
+	return true
+}
 
-		bs, err := row.bytes(e.catalog, table, stmt.cols, params)
-		if err != nil {
+// CompileUsing persists the policy as the statement creating it, so its filter is parsed again when the catalog is loaded
+func (stmt *CreatePolicyStmt) CompileUsing(ctx context.Context, e *Engine, implicitDB *Database, params map[string]interface{}) (ces, des []*store.KV, db *Database, err error) {
+	if implicitDB == nil {
+		return nil, nil, nil, ErrNoDatabaseSelected
+	}
+
+	if sessionUserFrom(ctx) != nil {
+		return nil, nil, nil, ErrPolicyChangeNotAllowed
+	}
+
+	table, err := implicitDB.GetTableByName(stmt.table)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	p, err := table.newPolicy(stmt.name, stmt.users, stmt.filter)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	ces = append(ces, e.policyEntry(p, false))
+
+	return ces, des, implicitDB, nil
+}
+
+func (e *Engine) policyEntry(p *Policy, deleted bool) *store.KV {
+	kv := &store.KV{
+		Key:   e.mapKey(catalogPolicyPrefix, EncodeID(p.table.db.id), EncodeID(p.table.id), []byte(p.name)),
+		Value: []byte(p.String()),
+	}
+
+	if deleted {
+		kv.Metadata = store.NewKVMetadata().AsDeleted(true)
+	}
+
+	return kv
+}
+
+type DropPolicyStmt struct {
+	name  string
+	table string
+}
+
+func (stmt *DropPolicyStmt) isDDL() bool {
+	return true
+}
+
+func (stmt *DropPolicyStmt) CompileUsing(ctx context.Context, e *Engine, implicitDB *Database, params map[string]interface{}) (ces, des []*store.KV, db *Database, err error) {
+	if implicitDB == nil {
+		return nil, nil, nil, ErrNoDatabaseSelected
+	}
+
+	if sessionUserFrom(ctx) != nil {
+		return nil, nil, nil, ErrPolicyChangeNotAllowed
+	}
+
+	table, err := implicitDB.GetTableByName(stmt.table)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	p, err := table.dropPolicy(stmt.name)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	ces = append(ces, e.policyEntry(p, true))
+
+	return ces, des, implicitDB, nil
+}
+
+type UpsertIntoStmt struct {
+	isInsert  bool
+	tableRef  *TableRef
+	cols      []string
+	rows      []*RowSpec
+	returning *ReturningSpec
+}
+
+type RowSpec struct {
+	Values []ValueExp
+}
+
+func (r *RowSpec) bytes(catalog *Catalog, t *Table, cols []string, params map[string]interface{}) ([]byte, error) {
+	valbuf := bytes.Buffer{}
+
+	colCount := 0
+
+	notNullCols := make(map[uint64]struct{}, len(t.colsByID))
+
+	for i, val := range r.Values {
+		col, err := t.GetColumnByName(cols[i])
+		if err != nil {
+			return nil, err
+		}
+
+		sval, err := val.substitute(params)
+		if err != nil {
+			return nil, err
+		}
+
+		rval, err := sval.reduce(catalog, nil, t.db.name, t.name)
+		if err != nil {
+			return nil, err
+		}
+
+		_, isNull := rval.(*NullValue)
+		if isNull {
+			continue
+		}
+
+		b := make([]byte, EncIDLen)
+		binary.BigEndian.PutUint64(b, uint64(col.id))
+
+		_, err = valbuf.Write(b)
+		if err != nil {
+			return nil, err
+		}
+
+		valb, err := EncodeValue(rval, col.colType, !asKey)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = valbuf.Write(valb)
+		if err != nil {
+			return nil, err
+		}
+
+		notNullCols[col.id] = struct{}{}
+
+		colCount++
+	}
+
+	for _, c := range t.colsByID {
+		if c.IsNullable() {
+			continue
+		}
+
+		_, notNull := notNullCols[c.id]
+		if !notNull {
+			return nil, ErrNotNullableColumnCannotBeNull
+		}
+	}
+
+	b := make([]byte, EncLenLen+len(valbuf.Bytes()))
+	binary.BigEndian.PutUint32(b, uint32(colCount))
+	copy(b[EncLenLen:], valbuf.Bytes())
+
+	return b, nil
+}
+
+func (stmt *UpsertIntoStmt) isDDL() bool {
+	return false
+}
+
+func (stmt *UpsertIntoStmt) Validate(table *Table) (map[uint64]int, error) {
+	selByColID := make(map[uint64]int, len(stmt.cols))
+
+	for i, c := range stmt.cols {
+		col, err := table.GetColumnByName(c)
+		if err != nil {
+			return nil, err
+		}
+
+		_, duplicated := selByColID[col.id]
+		if duplicated {
+			return nil, ErrDuplicatedColumn
+		}
+
+		selByColID[col.id] = i
+	}
+
+	if stmt.returning != nil {
+		err := stmt.returning.validate(table)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, col := range table.pkCols {
+		_, pkIncluded := selByColID[col.id]
+
+		// values of auto incremental primary keys are generated when not provided, as default values are
+		if !pkIncluded && !col.autoIncrement && col.defaultValue == nil {
+			return nil, ErrPKCanNotBeNull
+		}
+	}
+
+	return selByColID, nil
+}
+
+func (stmt *UpsertIntoStmt) CompileUsing(ctx context.Context, e *Engine, implicitDB *Database, params map[string]interface{}) (ces, des []*store.KV, db *Database, err error) {
+	table, err := stmt.tableRef.referencedTable(e, implicitDB)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	cs, err := stmt.Validate(table)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// index entries of replaced rows are removed when indexed values change, thus current rows are read
+	replacing := !stmt.isInsert && len(table.indexes) > 0
+
+	// within a user session both the current and the new rows must satisfy the policies of the table
+	filter := table.rowFilter(sessionUserFrom(ctx))
+
+	if replacing || filter != nil {
+		txID, _ := e.dataStore.Alh()
+
+		err = e.dataStore.WaitForIndexingUpto(ctx, txID)
+		if err != nil {
 			return nil, nil, nil, err
 		}
+	}
 
-		// create entry for the column which is the pk
-		mkey := e.mapKey(RowPrefix, EncodeID(table.db.id), EncodeID(table.id), EncodeID(table.pk.id), pkEncVal)
+	cols := append([]string{}, stmt.cols...)
 
-		pke := &store.KV{
-			Key:    mkey,
-			Value:  bs,
-			Unique: stmt.isInsert,
+	_, pkIncluded := cs[table.pk.id]
+	generatedPK := table.pk.autoIncrement && !pkIncluded
+
+	if table.pk.autoIncrement {
+		e.seqMutex.Lock()
+		defer e.seqMutex.Unlock()
+
+		err = e.loadSeq(ctx, table)
+		if err != nil {
+			return nil, nil, nil, err
 		}
-		des = append(des, pke)
 
-		// create entries for each indexed column, with value as value for pk column
-		for colID := range table.indexes {
-			colPos, defined := cs[colID]
-			if !defined {
-				return nil, nil, nil, ErrIndexedColumnCanNotBeNull
-			}
+		if generatedPK {
+			cols = append(cols, table.pk.colName)
+			cs[table.pk.id] = len(cols) - 1
+		}
+	}
+
+	// columns not included in the statement get their default values, which are evaluated for each row
+	var defaults []ValueExp
+
+	for id := uint64(1); id <= uint64(len(table.colsByID)); id++ {
+		col := table.colsByID[id]
+
+		_, included := cs[col.id]
+		if included || col.defaultValue == nil {
+			continue
+		}
 
-			cVal := row.Values[colPos]
+		cols = append(cols, col.colName)
+		cs[col.id] = len(cols) - 1
+		defaults = append(defaults, col.defaultValue)
+	}
+
+	seq := table.seq
+
+	claims := make(map[string]struct{})
+
+	for _, row := range stmt.rows {
+		if len(row.Values) != len(stmt.cols) {
+			return nil, nil, nil, ErrInvalidNumberOfValues
+		}
+
+		rowValues := append([]ValueExp{}, row.Values...)
+
+		if generatedPK {
+			seq++
+
+			rowValues = append(rowValues, &Number{val: seq})
+		}
+
+		row = &RowSpec{Values: append(rowValues, defaults...)}
+
+		for _, col := range table.pkCols {
+			pkVal := row.Values[cs[col.id]]
 
-			val, err := cVal.substitute(params)
+			val, err := pkVal.substitute(params)
 			if err != nil {
 				return nil, nil, nil, err
 			}
@@ -496,30 +949,490 @@ func (stmt *UpsertIntoStmt) CompileUsing(e *Engine, implicitDB *Database, params
 
 			_, isNull := rval.(*NullValue)
 			if isNull {
-				return nil, nil, nil, ErrIndexedColumnCanNotBeNull
+				return nil, nil, nil, ErrPKCanNotBeNull
 			}
+		}
+
+		bs, err := row.bytes(e.catalog, table, cols, params)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		// keys are made of the values as they were stored, so values such as NOW() are evaluated only once
+		values, err := decodeRow(bs, table)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		// default values are already included thus they're checked as well
+		err = e.checkConstraints(table, values)
+		if err != nil {
+			return nil, nil, nil, err
+		}
 
-			col, err := table.GetColumnByID(colID)
+		// provided values greater than the last generated one move the sequence forward
+		if table.pk.autoIncrement && pkIncluded {
+			pk := values[table.pk.id].Value().(uint64)
+			if pk > seq {
+				seq = pk
+			}
+		}
+
+		pkEncVal, err := table.encodePK(values)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		// create entry for the columns of the pk
+		mkey := e.rowKey(table, pkEncVal)
+
+		// rows with generated primary keys are never expected to replace existing ones
+		pke := &store.KV{
+			Key:    mkey,
+			Value:  bs,
+			Unique: stmt.isInsert || generatedPK,
+		}
+		des = append(des, pke)
+
+		var prevValues map[uint64]TypedValue
+
+		if replacing || filter != nil {
+			prevValues, err = e.currentRowValues(table, mkey)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+		}
+
+		if filter != nil {
+			if prevValues != nil {
+				err = e.checkRowFilter(filter, table, prevValues)
+				if err != nil {
+					return nil, nil, nil, err
+				}
+			}
+
+			err = e.checkRowFilter(filter, table, values)
 			if err != nil {
 				return nil, nil, nil, err
 			}
+		}
 
-			encVal, err := EncodeValue(rval, col.colType, asKey)
+		// create entries for each index, with the values of the indexed columns followed by the values of the pk
+		for _, idx := range table.indexes {
+			encVal, err := idx.encodeValues(values)
 			if err != nil {
 				return nil, nil, nil, err
 			}
 
 			ie := &store.KV{
-				Key:   e.mapKey(RowPrefix, EncodeID(table.db.id), EncodeID(table.id), EncodeID(colID), encVal, pkEncVal),
+				Key:   e.indexKey(idx, encVal, pkEncVal),
 				Value: nil,
 			}
 			des = append(des, ie)
+
+			// unique indexes are made of a single column
+			col := idx.cols[0]
+			unique := idx.IsUnique()
+
+			if prevValues == nil {
+				if unique {
+					ue, err := e.claimUnique(claims, col, encVal, pkEncVal)
+					if err != nil {
+						return nil, nil, nil, err
+					}
+
+					des = append(des, ue)
+				}
+
+				continue
+			}
+
+			prevEncVal, err := idx.encodeValues(prevValues)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+
+			if !bytes.Equal(prevEncVal, encVal) {
+				des = append(des, &store.KV{
+					Key:      e.indexKey(idx, prevEncVal, pkEncVal),
+					Metadata: store.NewKVMetadata().AsDeleted(true),
+				})
+
+				if unique {
+					ue, err := e.claimUnique(claims, col, encVal, pkEncVal)
+					if err != nil {
+						return nil, nil, nil, err
+					}
+
+					des = append(des, e.uniqueEntry(col, prevEncVal, pkEncVal, true), ue)
+				}
+			}
 		}
 	}
 
+	if seq > table.seq {
+		// the sequence is moved forward even if the tx is not committed, thus generated values may not be contiguous
+		table.seq = seq
+
+		des = append(des, e.seqEntry(table))
+	}
+
 	return ces, des, implicitDB, nil
 }
 
+// seqEntry returns the entry holding the last value assigned to the auto incremental primary key of the table
+func (e *Engine) seqEntry(table *Table) *store.KV {
+	v := make([]byte, EncIDLen)
+	binary.BigEndian.PutUint64(v, table.seq)
+
+	return &store.KV{
+		Key:   e.mapKey(SeqPrefix, EncodeID(table.db.id), EncodeID(table.id)),
+		Value: v,
+	}
+}
+
+// loadSeq reads the last value assigned to the auto incremental primary key of the table, unless it was already read
+func (e *Engine) loadSeq(ctx context.Context, table *Table) error {
+	if table.seqLoaded {
+		return nil
+	}
+
+	txID, _ := e.dataStore.Alh()
+
+	err := e.dataStore.WaitForIndexingUpto(ctx, txID)
+	if err != nil {
+		return err
+	}
+
+	v, _, _, err := e.dataStore.Get(e.mapKey(SeqPrefix, EncodeID(table.db.id), EncodeID(table.id)))
+	if err != nil && err != store.ErrKeyNotFound {
+		return err
+	}
+	if err == nil {
+		if len(v) != EncIDLen {
+			return ErrCorruptedData
+		}
+
+		table.seq = binary.BigEndian.Uint64(v)
+	}
+
+	table.seqLoaded = true
+
+	return nil
+}
+
+// currentRowValues returns the values of the row currently stored under the pk key, or nil if there is no such row
+func (e *Engine) currentRowValues(table *Table, pkKey []byte) (map[uint64]TypedValue, error) {
+	v, _, _, err := e.dataStore.Get(pkKey)
+	if err == store.ErrKeyNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeRow(v, table)
+}
+
+type DeleteFromStmt struct {
+	tableRef *TableRef
+	where    ValueExp
+}
+
+func (stmt *DeleteFromStmt) isDDL() bool {
+	return false
+}
+
+// CompileUsing marks as deleted every row currently satisfying the where clause together with its index entries,
+// previous values of deleted rows remain part of their history and can still be read as before the deletion
+func (stmt *DeleteFromStmt) CompileUsing(ctx context.Context, e *Engine, implicitDB *Database, params map[string]interface{}) (ces, des []*store.KV, db *Database, err error) {
+	table, err := stmt.tableRef.referencedTable(e, implicitDB)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	err = e.forEachCurrentRow(ctx, table, stmt.where, params, func(row *Row) error {
+		values := table.rowValues(row)
+
+		pkEncVal, err := table.encodePK(values)
+		if err != nil {
+			return err
+		}
+
+		des = append(des, &store.KV{
+			Key:      e.rowKey(table, pkEncVal),
+			Metadata: store.NewKVMetadata().AsDeleted(true),
+		})
+
+		for _, idx := range table.indexes {
+			encVal, err := idx.encodeValues(values)
+			if err != nil {
+				return err
+			}
+
+			des = append(des, &store.KV{
+				Key:      e.indexKey(idx, encVal, pkEncVal),
+				Metadata: store.NewKVMetadata().AsDeleted(true),
+			})
+
+			if idx.IsUnique() {
+				des = append(des, e.uniqueEntry(idx.cols[0], encVal, pkEncVal, true))
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return ces, des, implicitDB, nil
+}
+
+type UpdateStmt struct {
+	tableRef *TableRef
+	updates  []*colUpdate
+	where    ValueExp
+}
+
+type colUpdate struct {
+	col string
+	val ValueExp
+}
+
+func (stmt *UpdateStmt) isDDL() bool {
+	return false
+}
+
+func (stmt *UpdateStmt) Validate(table *Table) error {
+	colIDs := make(map[uint64]struct{}, len(stmt.updates))
+
+	for _, update := range stmt.updates {
+		col, err := table.GetColumnByName(update.col)
+		if err != nil {
+			return err
+		}
+
+		if table.IsPrimaryKey(col.colName) {
+			return ErrPKCanNotBeUpdated
+		}
+
+		_, duplicated := colIDs[col.id]
+		if duplicated {
+			return ErrDuplicatedColumn
+		}
+
+		colIDs[col.id] = struct{}{}
+	}
+
+	return nil
+}
+
+// CompileUsing sets the new values of every row currently satisfying the where clause, values are evaluated
+// over the current row. Index entries of replaced values are marked as deleted
+func (stmt *UpdateStmt) CompileUsing(ctx context.Context, e *Engine, implicitDB *Database, params map[string]interface{}) (ces, des []*store.KV, db *Database, err error) {
+	table, err := stmt.tableRef.referencedTable(e, implicitDB)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	err = stmt.Validate(table)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// updated rows must keep satisfying the policies of the table within a user session
+	filter := table.rowFilter(sessionUserFrom(ctx))
+
+	claims := make(map[string]struct{})
+
+	err = e.forEachCurrentRow(ctx, table, stmt.where, params, func(row *Row) error {
+		prevValues := table.rowValues(row)
+		values := table.rowValues(row)
+
+		for _, update := range stmt.updates {
+			col, err := table.GetColumnByName(update.col)
+			if err != nil {
+				return err
+			}
+
+			val, err := update.val.substitute(params)
+			if err != nil {
+				return err
+			}
+
+			rval, err := val.reduce(e.catalog, row, table.db.name, table.name)
+			if err != nil {
+				return err
+			}
+
+			values[col.id] = rval
+		}
+
+		if filter != nil {
+			err = e.checkRowFilter(filter, table, values)
+			if err != nil {
+				return err
+			}
+		}
+
+		// values are encoded in column order so the encoded row is deterministic
+		colIDs := make([]uint64, 0, len(values))
+		for colID := range values {
+			colIDs = append(colIDs, colID)
+		}
+		sort.Slice(colIDs, func(i, j int) bool { return colIDs[i] < colIDs[j] })
+
+		cols := make([]string, len(colIDs))
+		rowSpec := &RowSpec{Values: make([]ValueExp, len(colIDs))}
+
+		for i, colID := range colIDs {
+			exp, ok := values[colID].(ValueExp)
+			if !ok {
+				return ErrInvalidValue
+			}
+
+			cols[i] = table.colsByID[colID].colName
+			rowSpec.Values[i] = exp
+		}
+
+		bs, err := rowSpec.bytes(e.catalog, table, cols, params)
+		if err != nil {
+			return err
+		}
+
+		err = e.checkConstraints(table, values)
+		if err != nil {
+			return err
+		}
+
+		pkEncVal, err := table.encodePK(values)
+		if err != nil {
+			return err
+		}
+
+		des = append(des, &store.KV{
+			Key:   e.rowKey(table, pkEncVal),
+			Value: bs,
+		})
+
+		for _, idx := range table.indexes {
+			encVal, err := idx.encodeValues(values)
+			if err != nil {
+				return err
+			}
+
+			des = append(des, &store.KV{
+				Key: e.indexKey(idx, encVal, pkEncVal),
+			})
+
+			prevEncVal, err := idx.encodeValues(prevValues)
+			if err != nil {
+				return err
+			}
+
+			if !bytes.Equal(prevEncVal, encVal) {
+				des = append(des, &store.KV{
+					Key:      e.indexKey(idx, prevEncVal, pkEncVal),
+					Metadata: store.NewKVMetadata().AsDeleted(true),
+				})
+
+				// unique indexes are made of a single column
+				if idx.IsUnique() {
+					col := idx.cols[0]
+
+					ue, err := e.claimUnique(claims, col, encVal, pkEncVal)
+					if err != nil {
+						return err
+					}
+
+					des = append(des, e.uniqueEntry(col, prevEncVal, pkEncVal, true), ue)
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return ces, des, implicitDB, nil
+}
+
+// rowValues returns the values of a row read from the table by column id
+func (t *Table) rowValues(row *Row) map[uint64]TypedValue {
+	values := make(map[uint64]TypedValue, len(t.colsByID))
+
+	for _, col := range t.colsByID {
+		values[col.id] = row.Values[EncodeSelector("", t.db.name, t.name, col.colName)]
+	}
+
+	return values
+}
+
+// forEachCurrentRow calls fn with every row of the table currently satisfying the condition,
+// rows are resolved from the latest committed data regardless of the snapshot in use by queries
+// and they are not read once the context gets done. Within a user session only rows satisfying
+// the policies of the table are considered
+func (e *Engine) forEachCurrentRow(ctx context.Context, table *Table, cond ValueExp, params map[string]interface{}, fn func(row *Row) error) error {
+	txID, _ := e.dataStore.Alh()
+
+	err := e.dataStore.WaitForIndexingUpto(ctx, txID)
+	if err != nil {
+		return err
+	}
+
+	snap, err := e.dataStore.SnapshotSince(txID)
+	if err != nil {
+		return err
+	}
+	defer snap.Close()
+
+	var rowReader RowReader
+
+	rowReader, err = e.newRawRowReader(ctx, table.db, snap, table, 0, "", table.pk.colName, GreaterOrEqualTo, nil)
+	if err != nil {
+		return err
+	}
+	defer rowReader.Close()
+
+	filter := table.rowFilter(sessionUserFrom(ctx))
+	if filter != nil {
+		rowReader, err = e.newConditionalRowReader(rowReader, filter, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	if cond != nil {
+		cond, err = e.materializeSubqueries(ctx, table.db, snap, params, cond)
+		if err != nil {
+			return err
+		}
+
+		rowReader, err = e.newConditionalRowReader(rowReader, cond, params)
+		if err != nil {
+			return err
+		}
+	}
+
+	for {
+		row, err := rowReader.Read()
+		if err == store.ErrNoMoreEntries {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		err = fn(row)
+		if err != nil {
+			return err
+		}
+	}
+}
+
 type ValueExp interface {
 	jointColumnTo(col *Column, tableAlias string) (*ColSelector, error)
 	substitute(params map[string]interface{}) (ValueExp, error)
@@ -599,6 +1512,10 @@ func (v *Number) Compare(val TypedValue) (int, error) {
 		return 1, nil
 	}
 
+	if val.Type() == FloatType {
+		return cmpFloats(float64(v.val), val.Value().(float64)), nil
+	}
+
 	if val.Type() != IntegerType {
 		return 0, ErrNotComparableValues
 	}
@@ -616,6 +1533,144 @@ func (v *Number) Compare(val TypedValue) (int, error) {
 	return -1, nil
 }
 
+// Float is a double precision floating point value, it's comparable with integer values as well
+type Float struct {
+	val float64
+}
+
+func (v *Float) Type() SQLValueType {
+	return FloatType
+}
+
+func (v *Float) jointColumnTo(col *Column, tableAlias string) (*ColSelector, error) {
+	return nil, ErrJointColumnNotFound
+}
+
+func (v *Float) substitute(params map[string]interface{}) (ValueExp, error) {
+	return v, nil
+}
+
+func (v *Float) reduce(catalog *Catalog, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
+	return v, nil
+}
+
+func (v *Float) Value() interface{} {
+	return v.val
+}
+
+func (v *Float) Compare(val TypedValue) (int, error) {
+	_, isNull := val.(*NullValue)
+	if isNull {
+		return 1, nil
+	}
+
+	switch val.Type() {
+	case FloatType:
+		return cmpFloats(v.val, val.Value().(float64)), nil
+	case IntegerType:
+		return cmpFloats(v.val, float64(val.Value().(uint64))), nil
+	}
+
+	return 0, ErrNotComparableValues
+}
+
+func cmpFloats(l, r float64) int {
+	if l == r {
+		return 0
+	}
+
+	if l > r {
+		return 1
+	}
+
+	return -1
+}
+
+type Timestamp struct {
+	val time.Time
+}
+
+// newTimestamp returns the timestamp as it's stored, in UTC and with microsecond precision
+func newTimestamp(t time.Time) *Timestamp {
+	return &Timestamp{val: t.UTC().Truncate(time.Microsecond)}
+}
+
+// timestampLayouts are the accepted formats of timestamps given as strings, timestamps without a
+// time zone are taken as UTC ones
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z07",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+func parseTimestamp(s string) (*Timestamp, error) {
+	for _, layout := range timestampLayouts {
+		t, err := time.Parse(layout, s)
+		if err == nil {
+			return newTimestamp(t), nil
+		}
+	}
+
+	return nil, ErrInvalidValue
+}
+
+func (v *Timestamp) Type() SQLValueType {
+	return TimestampType
+}
+
+func (v *Timestamp) jointColumnTo(col *Column, tableAlias string) (*ColSelector, error) {
+	return nil, ErrJointColumnNotFound
+}
+
+func (v *Timestamp) substitute(params map[string]interface{}) (ValueExp, error) {
+	return v, nil
+}
+
+func (v *Timestamp) reduce(catalog *Catalog, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
+	return v, nil
+}
+
+func (v *Timestamp) Value() interface{} {
+	return v.val
+}
+
+func (v *Timestamp) Compare(val TypedValue) (int, error) {
+	_, isNull := val.(*NullValue)
+	if isNull {
+		return 1, nil
+	}
+
+	var rval time.Time
+
+	switch val.Type() {
+	case TimestampType:
+		rval = val.Value().(time.Time)
+	case VarcharType:
+		{
+			// string literals are compared as timestamps
+			ts, err := parseTimestamp(val.Value().(string))
+			if err != nil {
+				return 0, ErrNotComparableValues
+			}
+			rval = ts.val
+		}
+	default:
+		return 0, ErrNotComparableValues
+	}
+
+	if v.val.Equal(rval) {
+		return 0, nil
+	}
+
+	if v.val.After(rval) {
+		return 1, nil
+	}
+
+	return -1, nil
+}
+
 type Varchar struct {
 	val string
 }
@@ -646,6 +1701,16 @@ func (v *Varchar) Compare(val TypedValue) (int, error) {
 		return 1, nil
 	}
 
+	if val.Type() == TimestampType {
+		ts, err := parseTimestamp(v.val)
+		if err != nil {
+			return 0, ErrNotComparableValues
+		}
+
+		cmp, err := val.Compare(ts)
+		return -cmp, err
+	}
+
 	if val.Type() != VarcharType {
 		return 0, ErrNotComparableValues
 	}
@@ -755,7 +1820,7 @@ func (v *SysFn) substitute(params map[string]interface{}) (ValueExp, error) {
 
 func (v *SysFn) reduce(catalog *Catalog, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
 	if strings.ToUpper(v.fn) == "NOW" {
-		return &Number{val: uint64(time.Now().UnixNano())}, nil
+		return newTimestamp(time.Now()), nil
 	}
 
 	return nil, errors.New("not yet supported")
@@ -765,6 +1830,11 @@ type Param struct {
 	id string
 }
 
+// PositionalParam returns the name of the parameter referenced as $pos, so its value can be given as any named parameter
+func PositionalParam(pos int) string {
+	return fmt.Sprintf("param%d", pos)
+}
+
 func (p *Param) jointColumnTo(col *Column, tableAlias string) (*ColSelector, error) {
 	return nil, ErrJointColumnNotFound
 }
@@ -775,6 +1845,11 @@ func (p *Param) substitute(params map[string]interface{}) (ValueExp, error) {
 		return nil, ErrMissingParameter
 	}
 
+	return paramValue(val)
+}
+
+// paramValue returns the value corresponding to the value of a parameter
+func paramValue(val interface{}) (ValueExp, error) {
 	if val == nil {
 		return &NullValue{}, nil
 	}
@@ -796,10 +1871,22 @@ func (p *Param) substitute(params map[string]interface{}) (ValueExp, error) {
 		{
 			return &Number{val: v}, nil
 		}
+	case float64:
+		{
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				return nil, ErrUnsupportedParameter
+			}
+
+			return &Float{val: v}, nil
+		}
 	case []byte:
 		{
 			return &Blob{val: v}, nil
 		}
+	case time.Time:
+		{
+			return newTimestamp(v), nil
+		}
 	}
 
 	return nil, ErrUnsupportedParameter
@@ -820,7 +1907,7 @@ const (
 )
 
 type DataSource interface {
-	Resolve(e *Engine, implicitDB *Database, snap *store.Snapshot, params map[string]interface{}, ordCol *OrdCol) (RowReader, error)
+	Resolve(ctx context.Context, e *Engine, implicitDB *Database, snap *store.Snapshot, params map[string]interface{}, ordCol *OrdCol) (RowReader, error)
 	Alias() string
 }
 
@@ -830,117 +1917,503 @@ type SelectStmt struct {
 	ds        DataSource
 	joins     []*JoinSpec
 	where     ValueExp
-	groupBy   []*ColSelector
+	groupBy   []ValueExp
 	having    ValueExp
 	limit     uint64
 	orderBy   []*OrdCol
 	as        string
+	// the plan of the query is returned instead of its rows. When analyzed, rows are read so the plan
+	// includes the rows returned by each step and the time spent
+	explain bool
+	analyze bool
+}
+
+func (stmt *SelectStmt) isDDL() bool {
+	return false
+}
+
+func (stmt *SelectStmt) Limit() uint64 {
+	return stmt.limit
+}
+
+func (stmt *SelectStmt) CompileUsing(ctx context.Context, e *Engine, implicitDB *Database, params map[string]interface{}) (ces, des []*store.KV, db *Database, err error) {
+	if stmt.groupBy == nil && stmt.having != nil {
+		return nil, nil, nil, ErrHavingClauseRequiresGroupClause
+	}
+
+	tableRef, isTableRef := stmt.ds.(*TableRef)
+
+	if len(stmt.orderBy) > 0 && isTableRef && tableRef.referencedView(e, implicitDB) == nil {
+		table, err := tableRef.referencedTable(e, implicitDB)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		for _, ordCol := range stmt.orderBy {
+			sel, isCol := ordCol.exp.(*ColSelector)
+			if !isCol || stmt.joins != nil || sel.table != "" {
+				continue
+			}
+
+			_, err = table.GetColumnByName(sel.col)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+		}
+	}
+
+	return nil, nil, implicitDB, nil
+}
+
+// indexedOrdCol returns the column to be used to read rows in the requested order from an index,
+// nil is returned when rows need to be sorted
+func (stmt *SelectStmt) indexedOrdCol(e *Engine, implicitDB *Database) *OrdCol {
+	if len(stmt.orderBy) != 1 {
+		return nil
+	}
+
+	tableRef, isTableRef := stmt.ds.(*TableRef)
+	if !isTableRef {
+		return nil
+	}
+
+	sel, isCol := stmt.orderBy[0].exp.(*ColSelector)
+	if !isCol {
+		return nil
+	}
+
+	table, err := tableRef.referencedTable(e, implicitDB)
+	if err != nil {
+		return nil
+	}
+
+	if (sel.db != "" && sel.db != table.db.name) || (sel.table != "" && sel.table != table.name) {
+		return nil
+	}
+
+	col, err := table.GetColumnByName(sel.col)
+	if err != nil {
+		return nil
+	}
+
+	_, indexed := table.indexes[col.id]
+	if table.pk.id != col.id && !indexed {
+		return nil
+	}
+
+	return &OrdCol{exp: sel, sel: sel, cmp: stmt.orderBy[0].cmp}
+}
+
+func (stmt *SelectStmt) Resolve(ctx context.Context, e *Engine, implicitDB *Database, snap *store.Snapshot, params map[string]interface{}, ordCol *OrdCol) (RowReader, error) {
+	if stmt.explain {
+		return stmt.resolvePlan(ctx, e, implicitDB, snap, params)
+	}
+
+	// references to views are expanded into their queries
+	stmt, err := stmt.expandViews(e, implicitDB)
+	if err != nil {
+		return nil, err
+	}
+
+	orderByCol := stmt.indexedOrdCol(e, implicitDB)
+
+	// sub-queries are evaluated before reading any row, as they are not correlated with the rows being read
+	var where, having ValueExp
+
+	if stmt.where != nil {
+		where, err = e.materializeSubqueries(ctx, implicitDB, snap, params, stmt.where)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if stmt.having != nil {
+		having, err = e.materializeSubqueries(ctx, implicitDB, snap, params, stmt.having)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rowReader, err := stmt.resolveScan(ctx, e, implicitDB, snap, params, where, orderByCol)
+	if err != nil {
+		return nil, err
+	}
+
+	if where != nil {
+		rowReader, err = e.newConditionalRowReader(rowReader, where, params)
+		if err != nil {
+			return nil, err
+		}
+
+		rowReader = stmt.analyzed(rowReader)
+	}
+
+	containsAggregations := false
+	for _, sel := range stmt.selectors {
+		containsAggregations = len(aggregationsIn(sel, nil)) > 0
+		if containsAggregations {
+			break
+		}
+	}
+
+	if containsAggregations || stmt.groupBy != nil {
+		// aggregations may also be used for filtering and sorting
+		aggExps := []ValueExp{having}
+		for _, ordCol := range stmt.orderBy {
+			aggExps = append(aggExps, ordCol.exp)
+		}
+
+		rowReader, err = e.newGroupedRowReader(rowReader, stmt.selectors, stmt.groupBy, aggExps, params)
+		if err != nil {
+			return nil, err
+		}
+
+		rowReader = stmt.analyzed(rowReader)
+
+		if having != nil {
+			rowReader, err = e.newConditionalRowReader(rowReader, having, params)
+			if err != nil {
+				return nil, err
+			}
+
+			rowReader = stmt.analyzed(rowReader)
+		}
+	}
+
+	if len(stmt.orderBy) > 0 && orderByCol == nil {
+		rowReader, err = e.newSortedRowReader(rowReader, stmt.orderBy, params)
+		if err != nil {
+			return nil, err
+		}
+
+		rowReader = stmt.analyzed(rowReader)
+	}
+
+	// the limit is applied once duplicated rows are discarded
+	limit := stmt.limit
+	if stmt.distinct {
+		limit = 0
+	}
+
+	projectedRowReader, err := e.newProjectedRowReader(rowReader, stmt.as, stmt.selectors, params, limit)
+	if err != nil {
+		rowReader.Close()
+		return nil, err
+	}
+
+	if !stmt.distinct {
+		return stmt.analyzed(projectedRowReader), nil
+	}
+
+	distinctRowReader, err := e.newDistinctRowReader(stmt.analyzed(projectedRowReader), stmt.limit)
+	if err != nil {
+		projectedRowReader.Close()
+		return nil, err
+	}
+
+	return stmt.analyzed(distinctRowReader), nil
+}
+
+// seekVal returns the encoded value from which the rows can be read in the order of the indexed column,
+// as bounded by range comparisons over the column within the top-level conjunctions of the condition.
+// Only types whose encoding is ordered as their values are bounded, nil is returned when there is no bound
+func (stmt *SelectStmt) seekVal(e *Engine, implicitDB *Database, where ValueExp, ordCol *OrdCol, params map[string]interface{}) []byte {
+	tableRef, ok := stmt.ds.(*TableRef)
+	if !ok {
+		return nil
+	}
+
+	return scanBound(e, implicitDB, tableRef, conjunctions(where), ordCol.sel.col, ordCol.cmp, params)
 }
 
-func (stmt *SelectStmt) isDDL() bool {
-	return false
-}
+// scanBound returns the tightest encoded bound of the values of the column set by range comparisons within the conditions,
+// the lower bound when the comparison is GreaterOrEqualTo and the upper one when it's LowerOrEqualTo.
+// Only types whose encoding is ordered as their values are bounded, nil is returned when there is no bound
+func scanBound(e *Engine, implicitDB *Database, tableRef *TableRef, conds []ValueExp, colName string, cmp Comparison, params map[string]interface{}) []byte {
+	table, err := tableRef.referencedTable(e, implicitDB)
+	if err != nil {
+		return nil
+	}
+
+	col, err := table.GetColumnByName(colName)
+	if err != nil {
+		return nil
+	}
+
+	if col.colType != IntegerType && col.colType != FloatType && col.colType != TimestampType {
+		return nil
+	}
+
+	var seekVal []byte
+
+	for _, exp := range conds {
+		cmpExp, ok := exp.(*CmpBoolExp)
+		if !ok {
+			continue
+		}
+
+		sel, val, op := cmpExp.left, cmpExp.right, cmpExp.op
+
+		_, isSel := sel.(*ColSelector)
+		if !isSel {
+			sel, val, op = val, sel, flippedCmpOps[op]
+		}
+
+		colSel, isSel := sel.(*ColSelector)
+		if !isSel || colSel.col != col.colName ||
+			(colSel.db != "" && colSel.db != table.db.name) ||
+			(colSel.table != "" && colSel.table != tableRef.Alias()) {
+			continue
+		}
+
+		if cmp == GreaterOrEqualTo && op != EQ && op != GT && op != GE {
+			continue
+		}
+
+		if cmp == LowerOrEqualTo && op != EQ && op != LT && op != LE {
+			continue
+		}
+
+		switch val.(type) {
+		case *Number, *Float, *Varchar, *Timestamp, *Param, *SysFn:
+		default:
+			// only values which do not depend on the row being read
+			continue
+		}
+
+		sval, err := val.substitute(params)
+		if err != nil {
+			continue
+		}
+
+		rval, err := sval.reduce(e.catalog, nil, table.db.name, table.name)
+		if err != nil {
+			continue
+		}
+
+		encVal, err := EncodeValue(rval, col.colType, asKey)
+		if err != nil {
+			continue
+		}
+
+		// the tightest bound is used
+		if seekVal == nil ||
+			(cmp == GreaterOrEqualTo && bytes.Compare(encVal, seekVal) > 0) ||
+			(cmp == LowerOrEqualTo && bytes.Compare(encVal, seekVal) < 0) {
+			seekVal = encVal
+		}
+	}
 
-func (stmt *SelectStmt) Limit() uint64 {
-	return stmt.limit
+	return seekVal
 }
 
-func (stmt *SelectStmt) CompileUsing(e *Engine, implicitDB *Database, params map[string]interface{}) (ces, des []*store.KV, db *Database, err error) {
-	if stmt.distinct {
-		return nil, nil, nil, ErrNoSupported
+// restrictedScan returns the primary key or indexed column, and the encoded prefixes of its values, to which the rows can be
+// restricted as required by one of the given conditions over the column i.e. a case-sensitive LIKE condition
+// e.g. title LIKE 'abc%', an IN condition over constant values e.g. id IN (1, 5, 7) or an equality e.g. id = 5.
+// When rows are read in the order of an indexed column, only conditions over that column are used.
+// Prefixes are returned in the order rows are read
+func restrictedScan(e *Engine, implicitDB *Database, tableRef *TableRef, conds []ValueExp, ordCol *OrdCol, params map[string]interface{}) (*ColSelector, [][]byte) {
+	table, err := tableRef.referencedTable(e, implicitDB)
+	if err != nil {
+		return nil, nil
 	}
 
-	if stmt.groupBy == nil && stmt.having != nil {
-		return nil, nil, nil, ErrHavingClauseRequiresGroupClause
-	}
+	for _, exp := range conds {
+		var colExp ValueExp
+		var valPrefixes func(col *Column) [][]byte
 
-	if len(stmt.orderBy) > 1 {
-		return nil, nil, nil, ErrLimitedOrderBy
-	}
+		switch bexp := exp.(type) {
+		case *LikeBoolExp:
+			if bexp.notLike || bexp.caseInsensitive {
+				continue
+			}
 
-	if len(stmt.orderBy) > 0 {
-		tableRef, ok := stmt.ds.(*TableRef)
-		if !ok {
-			return nil, nil, nil, ErrLimitedOrderBy
+			colExp = bexp.val
+			valPrefixes = func(col *Column) [][]byte {
+				return likeValPrefixes(e, table, col, bexp.pattern, params)
+			}
+		case *InListExp:
+			if bexp.notIn {
+				continue
+			}
+
+			colExp = bexp.val
+			valPrefixes = func(col *Column) [][]byte {
+				return inListValPrefixes(bexp, col, params)
+			}
+		case *CmpBoolExp:
+			if bexp.op != EQ {
+				continue
+			}
+
+			var val ValueExp
+
+			colExp, val = bexp.left, bexp.right
+			if _, isSel := colExp.(*ColSelector); !isSel {
+				colExp, val = val, colExp
+			}
+
+			sval, err := val.substitute(params)
+			if err != nil {
+				continue
+			}
+
+			if _, isConst := constantValue(sval); !isConst {
+				continue
+			}
+
+			// an equality is restricted as an IN list of a single value
+			inList := &InListExp{val: colExp, values: []ValueExp{sval}}
+			valPrefixes = func(col *Column) [][]byte {
+				return inListValPrefixes(inList, col, params)
+			}
+		default:
+			continue
 		}
 
-		table, err := tableRef.referencedTable(e, implicitDB)
-		if err != nil {
-			return nil, nil, nil, err
+		colSel, isSel := colExp.(*ColSelector)
+		if !isSel ||
+			(colSel.db != "" && colSel.db != table.db.name) ||
+			(colSel.table != "" && colSel.table != tableRef.Alias()) {
+			continue
 		}
 
-		col, err := table.GetColumnByName(stmt.orderBy[0].sel.col)
-		if err != nil {
-			return nil, nil, nil, err
+		if ordCol != nil && ordCol.sel.col != colSel.col {
+			continue
 		}
 
-		if table.pk.id == col.id {
-			return nil, nil, nil, nil
+		col, err := table.GetColumnByName(colSel.col)
+		if err != nil {
+			continue
 		}
 
 		_, indexed := table.indexes[col.id]
-		if !indexed {
-			return nil, nil, nil, ErrLimitedOrderBy
+		if table.pk.id != col.id && !indexed {
+			continue
+		}
+
+		prefixes := valPrefixes(col)
+		if len(prefixes) == 0 {
+			continue
+		}
+
+		if ordCol != nil && ordCol.cmp == LowerOrEqualTo {
+			for i, j := 0, len(prefixes)-1; i < j; i, j = i+1, j-1 {
+				prefixes[i], prefixes[j] = prefixes[j], prefixes[i]
+			}
 		}
+
+		return colSel, prefixes
 	}
 
-	return nil, nil, implicitDB, nil
+	return nil, nil
 }
 
-func (stmt *SelectStmt) Resolve(e *Engine, implicitDB *Database, snap *store.Snapshot, params map[string]interface{}, ordCol *OrdCol) (RowReader, error) {
-	var orderByCol *OrdCol
+// likeValPrefixes returns the encoded prefixes, in ascending order, of the values matched by a LIKE pattern with a fixed prefix.
+// As values are encoded with their length first, there is a prefix for each possible length
+func likeValPrefixes(e *Engine, table *Table, col *Column, patternExp ValueExp, params map[string]interface{}) [][]byte {
+	if col.colType != VarcharType {
+		return nil
+	}
 
-	if len(stmt.orderBy) > 0 {
-		orderByCol = stmt.orderBy[0]
+	switch patternExp.(type) {
+	case *Varchar, *Param:
+	default:
+		// only patterns which do not depend on the row being read
+		return nil
 	}
 
-	rowReader, err := stmt.ds.Resolve(e, implicitDB, snap, params, orderByCol)
+	spattern, err := patternExp.substitute(params)
 	if err != nil {
-		return nil, err
+		return nil
 	}
 
-	if stmt.joins != nil {
-		rowReader, err = e.newJointRowReader(implicitDB, snap, params, rowReader, stmt.joins)
-		if err != nil {
-			return nil, err
-		}
+	pattern, err := spattern.reduce(e.catalog, nil, table.db.name, table.name)
+	if err != nil || pattern.Type() != VarcharType || isNull(pattern) {
+		return nil
 	}
 
-	if stmt.where != nil {
-		rowReader, err = e.newConditionalRowReader(rowReader, stmt.where, params)
-		if err != nil {
-			return nil, err
-		}
+	prefix, exact, err := likePrefix(pattern.Value().(string))
+	if err != nil || prefix == "" || len(prefix) > len(maxKeyVal(VarcharType)) {
+		return nil
 	}
 
-	containsAggregations := false
-	for _, sel := range stmt.selectors {
-		_, containsAggregations = sel.(*AggColSelector)
-		if containsAggregations {
-			break
-		}
+	maxLen := len(maxKeyVal(VarcharType))
+	if exact {
+		maxLen = len(prefix)
+	}
+
+	valPrefixes := make([][]byte, 0, maxLen-len(prefix)+1)
+
+	for l := len(prefix); l <= maxLen; l++ {
+		valPrefix := make([]byte, EncLenLen+len(prefix))
+		binary.BigEndian.PutUint32(valPrefix, uint32(l))
+		copy(valPrefix[EncLenLen:], prefix)
+
+		valPrefixes = append(valPrefixes, valPrefix)
+	}
+
+	return valPrefixes
+}
+
+// inListValPrefixes returns the encoded values of an IN list, in ascending order and without duplicates,
+// so each of them is probed in the index. Lists with values which do not depend on the row being read are required
+func inListValPrefixes(bexp *InListExp, col *Column, params map[string]interface{}) [][]byte {
+	if col.colType == FloatType {
+		// distinct encodings may correspond to equal values e.g. 0.0 and -0.0
+		return nil
+	}
+
+	sexp, err := bexp.substitute(params)
+	if err != nil {
+		return nil
 	}
 
-	if containsAggregations {
-		var groupBy []*ColSelector
-		if stmt.groupBy != nil {
-			groupBy = stmt.groupBy
+	valSet := make(map[string]struct{})
+	valPrefixes := make([][]byte, 0, len(sexp.(*InListExp).values))
+
+	for _, v := range sexp.(*InListExp).values {
+		tv, isConst := constantValue(v)
+		if !isConst {
+			return nil
+		}
+
+		if isNull(tv) {
+			// null values match no row
+			continue
 		}
 
-		rowReader, err = e.newGroupedRowReader(rowReader, stmt.selectors, groupBy)
+		encVal, err := EncodeValue(tv, col.colType, true)
 		if err != nil {
-			return nil, err
+			// values which can not be encoded as the column may still be equal to some of its values
+			return nil
 		}
 
-		if stmt.having != nil {
-			rowReader, err = e.newConditionalRowReader(rowReader, stmt.having, params)
-			if err != nil {
-				return nil, err
-			}
+		_, dup := valSet[string(encVal)]
+		if dup {
+			continue
 		}
+
+		valSet[string(encVal)] = struct{}{}
+		valPrefixes = append(valPrefixes, encVal)
+	}
+
+	sort.Slice(valPrefixes, func(i, j int) bool {
+		return bytes.Compare(valPrefixes[i], valPrefixes[j]) < 0
+	})
+
+	return valPrefixes
+}
+
+var flippedCmpOps = map[CmpOperator]CmpOperator{EQ: EQ, NE: NE, LT: GT, LE: GE, GT: LT, GE: LE}
+
+func conjunctions(exp ValueExp) []ValueExp {
+	bexp, ok := exp.(*BinBoolExp)
+	if !ok || bexp.op != AND {
+		return []ValueExp{exp}
 	}
 
-	return e.newProjectedRowReader(rowReader, stmt.as, stmt.selectors, stmt.limit)
+	return append(conjunctions(bexp.left), conjunctions(bexp.right)...)
 }
 
 func (stmt *SelectStmt) Alias() string {
@@ -955,7 +2428,11 @@ type TableRef struct {
 	db       string
 	table    string
 	asBefore uint64
-	as       string
+	// only rows written in this tx or in a later one are read
+	sinceTx uint64
+	// rows are read as they were at this timestamp
+	asOf ValueExp
+	as   string
 }
 
 func (stmt *TableRef) referencedTable(e *Engine, implicitDB *Database) (*Table, error) {
@@ -986,7 +2463,37 @@ func (stmt *TableRef) referencedTable(e *Engine, implicitDB *Database) (*Table,
 	return table, nil
 }
 
-func (stmt *TableRef) Resolve(e *Engine, implicitDB *Database, snap *store.Snapshot, params map[string]interface{}, ordCol *OrdCol) (RowReader, error) {
+// asOfTimestamp evaluates the timestamp the rows are read as of. Besides timestamps, it may be given
+// as a string holding one or as the number of seconds since the unix epoch
+func (stmt *TableRef) asOfTimestamp(e *Engine, params map[string]interface{}) (time.Time, error) {
+	exp, err := stmt.asOf.substitute(params)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	v, err := exp.reduce(e.catalog, nil, "", "")
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	switch v.Type() {
+	case TimestampType:
+		return v.Value().(time.Time), nil
+	case IntegerType:
+		return time.Unix(int64(v.Value().(uint64)), 0), nil
+	case VarcharType:
+		ts, err := parseTimestamp(strings.TrimSpace(v.Value().(string)))
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%w: '%s' is not a timestamp", ErrInvalidValue, v.Value())
+		}
+
+		return ts.val, nil
+	}
+
+	return time.Time{}, fmt.Errorf("%w: rows can only be read as of a timestamp", ErrInvalidValue)
+}
+
+func (stmt *TableRef) Resolve(ctx context.Context, e *Engine, implicitDB *Database, snap *store.Snapshot, params map[string]interface{}, ordCol *OrdCol) (RowReader, error) {
 	if e == nil || snap == nil || (ordCol != nil && ordCol.sel == nil) {
 		return nil, ErrIllegalArguments
 	}
@@ -1018,7 +2525,7 @@ func (stmt *TableRef) Resolve(e *Engine, implicitDB *Database, snap *store.Snaps
 		if table.pk.colName != ordCol.sel.col {
 			_, indexed := table.indexes[col.id]
 			if !indexed {
-				return nil, ErrColumnNotIndexed
+				return nil, fmt.Errorf("%w: %s", ErrColumnNotIndexed, ordCol.sel.col)
 			}
 		}
 
@@ -1026,7 +2533,17 @@ func (stmt *TableRef) Resolve(e *Engine, implicitDB *Database, snap *store.Snaps
 		cmp = ordCol.cmp
 
 		if ordCol.useInitKeyVal {
-			if len(ordCol.initKeyVal) > EncLenLen+len(maxKeyVal(col.colType)) {
+			maxLen := EncLenLen + len(maxKeyVal(col.colType))
+
+			// rows of joined tables are looked up by the values of every column of the primary key
+			if col.id == table.pk.id {
+				maxLen = 0
+				for _, c := range table.pkCols {
+					maxLen += EncLenLen + len(maxKeyVal(c.colType))
+				}
+			}
+
+			if len(ordCol.initKeyVal) > maxLen {
 				return nil, ErrMaxKeyLengthExceeded
 			}
 			initKeyVal = ordCol.initKeyVal
@@ -1038,11 +2555,63 @@ func (stmt *TableRef) Resolve(e *Engine, implicitDB *Database, snap *store.Snaps
 	}
 
 	asBefore := stmt.asBefore
+
+	if stmt.asOf != nil {
+		asOf, err := stmt.asOfTimestamp(e, params)
+		if err != nil {
+			return nil, err
+		}
+
+		txID, err := e.dataStore.LastTxUntil(asOf)
+		if err != nil && err != store.ErrTxNotFound {
+			return nil, err
+		}
+
+		// rows as they were right after txID was committed, none if there was no tx yet
+		asBefore = txID + 1
+	}
+
 	if asBefore == 0 {
 		asBefore = e.snapAsBeforeTx
 	}
 
-	return e.newRawRowReader(implicitDB, snap, table, asBefore, stmt.as, colName, cmp, initKeyVal)
+	if stmt.sinceTx > 0 && asBefore > 0 && stmt.sinceTx >= asBefore {
+		return nil, fmt.Errorf("%w: rows can not be read since tx %d as before tx %d", ErrIllegalArguments, stmt.sinceTx, asBefore)
+	}
+
+	var valPrefixes [][]byte
+	if ordCol != nil {
+		valPrefixes = ordCol.valPrefixes
+	}
+
+	rowReader, err := e.newRawRowReader(ctx, implicitDB, snap, table, asBefore, stmt.as, colName, cmp, initKeyVal, valPrefixes...)
+	if err != nil {
+		return nil, err
+	}
+
+	rowReader.bounded = ordCol != nil && ordCol.useInitKeyVal
+	rowReader.sinceTx = stmt.sinceTx
+
+	if ordCol != nil && ordCol.endKeyVal != nil && len(valPrefixes) == 0 {
+		col, err := table.GetColumnByName(colName)
+		if err != nil {
+			return nil, err
+		}
+
+		rowReader.endKey = append(e.scanPrefix(table, col), ordCol.endKeyVal...)
+
+		// rows holding the value are read before the scan stops, they come last in ascending order
+		if !rowReader.desc {
+			rowReader.endKey = prefixEnd(rowReader.endKey)
+		}
+	}
+
+	filter := table.rowFilter(sessionUserFrom(ctx))
+	if filter == nil {
+		return rowReader, nil
+	}
+
+	return e.newConditionalRowReader(rowReader, filter, nil)
 }
 
 func (stmt *TableRef) Alias() string {
@@ -1063,10 +2632,15 @@ type GroupBySpec struct {
 }
 
 type OrdCol struct {
-	sel           *ColSelector
+	exp           ValueExp     // expression rows get sorted by
+	sel           *ColSelector // indexed column used for scanning in order
 	cmp           Comparison
 	initKeyVal    []byte
 	useInitKeyVal bool
+	// encoded value prefixes the scan is restricted to, as set by a LIKE, IN or equality condition over the column
+	valPrefixes [][]byte
+	// encoded value at which the scan stops, once past the rows holding it
+	endKeyVal []byte
 }
 
 type Selector interface {
@@ -1130,7 +2704,7 @@ func (sel *ColSelector) reduce(catalog *Catalog, row *Row, implicitDB, implicitT
 
 	v, ok := row.Values[EncodeSelector(aggFn, db, table, col)]
 	if !ok {
-		return nil, ErrColumnDoesNotExist
+		return nil, fmt.Errorf("%w: %s", ErrColumnDoesNotExist, col)
 	}
 
 	return v, nil
@@ -1142,6 +2716,8 @@ type AggColSelector struct {
 	table string
 	col   string
 	as    string
+	// only distinct values are aggregated i.e. COUNT(DISTINCT col)
+	distinct bool
 }
 
 func EncodeSelector(aggFn, db, table, col string) string {
@@ -1181,7 +2757,7 @@ func (sel *AggColSelector) substitute(params map[string]interface{}) (ValueExp,
 func (sel *AggColSelector) reduce(catalog *Catalog, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
 	v, ok := row.Values[EncodeSelector(sel.resolve(implicitDB, implicitTable))]
 	if !ok {
-		return nil, ErrColumnDoesNotExist
+		return nil, fmt.Errorf("%w: %s", ErrColumnDoesNotExist, sel.col)
 	}
 	return v, nil
 }
@@ -1223,6 +2799,21 @@ func (bexp *NumExp) reduce(catalog *Catalog, row *Row, implicitDB, implicitTable
 		return nil, err
 	}
 
+	_, isNullLeft := vl.(*NullValue)
+	_, isNullRight := vr.(*NullValue)
+
+	if isNullLeft || isNullRight {
+		if vl.Type() == FloatType || vr.Type() == FloatType {
+			return &NullValue{t: FloatType}, nil
+		}
+
+		return &NullValue{t: IntegerType}, nil
+	}
+
+	if vl.Type() == FloatType || vr.Type() == FloatType {
+		return bexp.reduceFloats(vl, vr)
+	}
+
 	nl, isNumber := vl.Value().(uint64)
 	if !isNumber {
 		return nil, ErrInvalidCondition
@@ -1259,6 +2850,55 @@ func (bexp *NumExp) reduce(catalog *Catalog, row *Row, implicitDB, implicitTable
 	return nil, ErrUnexpected
 }
 
+// reduceFloats operates with floating point arithmetic when any of the operands is a float
+func (bexp *NumExp) reduceFloats(vl, vr TypedValue) (TypedValue, error) {
+	fl, isNumber := asFloat(vl)
+	if !isNumber {
+		return nil, ErrInvalidCondition
+	}
+
+	fr, isNumber := asFloat(vr)
+	if !isNumber {
+		return nil, ErrInvalidCondition
+	}
+
+	switch bexp.op {
+	case ADDOP:
+		{
+			return &Float{val: fl + fr}, nil
+		}
+	case SUBSOP:
+		{
+			return &Float{val: fl - fr}, nil
+		}
+	case DIVOP:
+		{
+			if fr == 0 {
+				return nil, ErrDivisionByZero
+			}
+
+			return &Float{val: fl / fr}, nil
+		}
+	case MULTOP:
+		{
+			return &Float{val: fl * fr}, nil
+		}
+	}
+
+	return nil, ErrUnexpected
+}
+
+func asFloat(v TypedValue) (float64, bool) {
+	switch tv := v.Value().(type) {
+	case float64:
+		return tv, true
+	case uint64:
+		return float64(tv), true
+	}
+
+	return 0, false
+}
+
 type NotBoolExp struct {
 	exp ValueExp
 }
@@ -1284,6 +2924,10 @@ func (bexp *NotBoolExp) reduce(catalog *Catalog, row *Row, implicitDB, implicitT
 		return nil, err
 	}
 
+	if isUnknown(v) {
+		return &NullValue{t: BooleanType}, nil
+	}
+
 	r, isBool := v.Value().(bool)
 	if !isBool {
 		return nil, ErrInvalidCondition
@@ -1292,9 +2936,13 @@ func (bexp *NotBoolExp) reduce(catalog *Catalog, row *Row, implicitDB, implicitT
 	return &Bool{val: !r}, nil
 }
 
+// LikeBoolExp matches values against a pattern where % stands for any sequence of characters and _ for a single one,
+// wildcards are matched literally when escaped with a backslash. Letter case is ignored by ILIKE
 type LikeBoolExp struct {
-	sel     Selector
-	pattern string
+	val             ValueExp
+	notLike         bool
+	caseInsensitive bool
+	pattern         ValueExp
 }
 
 func (bexp *LikeBoolExp) jointColumnTo(col *Column, tableAlias string) (*ColSelector, error) {
@@ -1302,25 +2950,49 @@ func (bexp *LikeBoolExp) jointColumnTo(col *Column, tableAlias string) (*ColSele
 }
 
 func (bexp *LikeBoolExp) substitute(params map[string]interface{}) (ValueExp, error) {
-	return bexp, nil
+	rval, err := bexp.val.substitute(params)
+	if err != nil {
+		return nil, err
+	}
+
+	rpattern, err := bexp.pattern.substitute(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LikeBoolExp{val: rval, notLike: bexp.notLike, caseInsensitive: bexp.caseInsensitive, pattern: rpattern}, nil
 }
 
 func (bexp *LikeBoolExp) reduce(catalog *Catalog, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
-	v, ok := row.Values[EncodeSelector(bexp.sel.resolve(implicitDB, implicitTable))]
-	if !ok {
-		return nil, ErrColumnDoesNotExist
+	v, err := bexp.val.reduce(catalog, row, implicitDB, implicitTable)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := bexp.pattern.reduce(catalog, row, implicitDB, implicitTable)
+	if err != nil {
+		return nil, err
+	}
+
+	// untyped nulls are accepted as well
+	if v.Type() != VarcharType && v.Type() != "" {
+		return nil, fmt.Errorf("%w: LIKE is only supported over VARCHAR values", ErrInvalidValue)
+	}
+
+	if p.Type() != VarcharType && p.Type() != "" {
+		return nil, fmt.Errorf("%w: LIKE patterns must be VARCHAR values", ErrInvalidValue)
 	}
 
-	if v.Type() != VarcharType {
-		return nil, ErrInvalidColumn
+	if isNull(v) || isNull(p) {
+		return &NullValue{t: BooleanType}, nil
 	}
 
-	matched, err := regexp.MatchString(bexp.pattern, v.Value().(string))
+	matched, err := likeMatch(p.Value().(string), v.Value().(string), bexp.caseInsensitive)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Bool{val: matched}, nil
+	return &Bool{val: matched != bexp.notLike}, nil
 }
 
 type CmpBoolExp struct {
@@ -1390,6 +3062,31 @@ func (bexp *CmpBoolExp) reduce(catalog *Catalog, row *Row, implicitDB, implicitT
 		return nil, err
 	}
 
+	// comparisons involving nulls are unknown, IS [NOT] NULL is used to check for nulls
+	_, isNullLeft := vl.(*NullValue)
+	_, isNullRight := vr.(*NullValue)
+
+	if isNullLeft || isNullRight {
+		if !comparableTypes(vl.Type(), vr.Type()) {
+			return nil, ErrNotComparableValues
+		}
+
+		return &NullValue{t: BooleanType}, nil
+	}
+
+	_, isLeftJSON := vl.(*JSON)
+	_, isRightJSON := vr.(*JSON)
+
+	if isRightJSON && !isLeftJSON {
+		// JSON values are compared with values of other types as scalar values
+		r, err := vr.Compare(vl)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Bool{val: cmpSatisfiesOp(-r, bexp.op)}, nil
+	}
+
 	r, err := vl.Compare(vr)
 	if err != nil {
 		return nil, err
@@ -1398,6 +3095,15 @@ func (bexp *CmpBoolExp) reduce(catalog *Catalog, row *Row, implicitDB, implicitT
 	return &Bool{val: cmpSatisfiesOp(r, bexp.op)}, nil
 }
 
+// comparableTypes returns whether values of the given types can be compared, untyped nulls are comparable with any value
+func comparableTypes(t1, t2 SQLValueType) bool {
+	if t1 == "" || t2 == "" || t1 == t2 || t1 == JSONType || t2 == JSONType {
+		return true
+	}
+
+	return (t1 == IntegerType && t2 == FloatType) || (t1 == FloatType && t2 == IntegerType)
+}
+
 func cmpSatisfiesOp(cmp int, op CmpOperator) bool {
 	switch cmp {
 	case 0:
@@ -1421,8 +3127,18 @@ type BinBoolExp struct {
 	left, right ValueExp
 }
 
+// jointColumnTo looks for the column within the equalities of a conjunction, as required to join tables with composite primary keys
 func (bexp *BinBoolExp) jointColumnTo(col *Column, tableAlias string) (*ColSelector, error) {
-	return nil, ErrJointColumnNotFound
+	if bexp.op != AND {
+		return nil, ErrJointColumnNotFound
+	}
+
+	sel, err := bexp.left.jointColumnTo(col, tableAlias)
+	if err != ErrJointColumnNotFound {
+		return sel, err
+	}
+
+	return bexp.right.jointColumnTo(col, tableAlias)
 }
 
 func (bexp *BinBoolExp) substitute(params map[string]interface{}) (ValueExp, error) {
@@ -1453,30 +3169,85 @@ func (bexp *BinBoolExp) reduce(catalog *Catalog, row *Row, implicitDB, implicitT
 		return nil, err
 	}
 
+	unknownLeft := isUnknown(vl)
+	unknownRight := isUnknown(vr)
+
 	bl, isBool := vl.(*Bool)
-	if !isBool {
+	if !isBool && !unknownLeft {
 		return nil, ErrInvalidValue
 	}
 
 	br, isBool := vr.(*Bool)
-	if !isBool {
+	if !isBool && !unknownRight {
 		return nil, ErrInvalidValue
 	}
 
+	// three-valued logic: a known operand may settle the result even when the other one is unknown
 	switch bexp.op {
 	case AND:
 		{
-			return &Bool{val: bl.val && br.val}, nil
+			if (!unknownLeft && !bl.val) || (!unknownRight && !br.val) {
+				return &Bool{val: false}, nil
+			}
+
+			if unknownLeft || unknownRight {
+				return &NullValue{t: BooleanType}, nil
+			}
+
+			return &Bool{val: true}, nil
 		}
 	case OR:
 		{
-			return &Bool{val: bl.val || br.val}, nil
+			if (!unknownLeft && bl.val) || (!unknownRight && br.val) {
+				return &Bool{val: true}, nil
+			}
+
+			if unknownLeft || unknownRight {
+				return &NullValue{t: BooleanType}, nil
+			}
+
+			return &Bool{val: false}, nil
 		}
 	}
 
 	return nil, ErrUnexpected
 }
 
+// isUnknown returns whether the value is the unknown boolean value i.e. a boolean (or untyped) null
+func isUnknown(v TypedValue) bool {
+	n, isNull := v.(*NullValue)
+	return isNull && (n.t == BooleanType || n.t == "")
+}
+
+// IsNullBoolExp checks whether the value of the expression is null e.g. title IS NULL or title IS NOT NULL,
+// as opposed to comparisons, its result is never unknown
+type IsNullBoolExp struct {
+	exp   ValueExp
+	isNot bool
+}
+
+func (bexp *IsNullBoolExp) jointColumnTo(col *Column, tableAlias string) (*ColSelector, error) {
+	return nil, ErrJointColumnNotFound
+}
+
+func (bexp *IsNullBoolExp) substitute(params map[string]interface{}) (ValueExp, error) {
+	rexp, err := bexp.exp.substitute(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IsNullBoolExp{exp: rexp, isNot: bexp.isNot}, nil
+}
+
+func (bexp *IsNullBoolExp) reduce(catalog *Catalog, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
+	v, err := bexp.exp.reduce(catalog, row, implicitDB, implicitTable)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Bool{val: (v.Value() == nil) != bexp.isNot}, nil
+}
+
 type ExistsBoolExp struct {
 	q *SelectStmt
 }
@@ -1490,5 +3261,6 @@ func (bexp *ExistsBoolExp) substitute(params map[string]interface{}) (ValueExp,
 }
 
 func (bexp *ExistsBoolExp) reduce(catalog *Catalog, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
-	return nil, errors.New("not yet supported")
+	// sub-queries are replaced by their results before conditions get evaluated
+	return nil, ErrLimitedSubqueries
 }
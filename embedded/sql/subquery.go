@@ -0,0 +1,527 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sql
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/codenotary/immudb/embedded/store"
+)
+
+// SubQueryExp is a sub-query used as a scalar expression, it must return a single column and at most one row
+type SubQueryExp struct {
+	q *SelectStmt
+}
+
+func (bexp *SubQueryExp) jointColumnTo(col *Column, tableAlias string) (*ColSelector, error) {
+	return nil, ErrJointColumnNotFound
+}
+
+func (bexp *SubQueryExp) substitute(params map[string]interface{}) (ValueExp, error) {
+	return bexp, nil
+}
+
+func (bexp *SubQueryExp) reduce(catalog *Catalog, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
+	// sub-queries are replaced by their results before conditions get evaluated
+	return nil, ErrLimitedSubqueries
+}
+
+// InSubQueryExp checks whether a value is (or is not) included in the single column returned by a sub-query
+type InSubQueryExp struct {
+	val   ValueExp
+	notIn bool
+	q     *SelectStmt
+}
+
+func (bexp *InSubQueryExp) jointColumnTo(col *Column, tableAlias string) (*ColSelector, error) {
+	return nil, ErrJointColumnNotFound
+}
+
+func (bexp *InSubQueryExp) substitute(params map[string]interface{}) (ValueExp, error) {
+	rval, err := bexp.val.substitute(params)
+	if err != nil {
+		return nil, err
+	}
+
+	bexp.val = rval
+
+	return bexp, nil
+}
+
+func (bexp *InSubQueryExp) reduce(catalog *Catalog, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
+	// sub-queries are replaced by their results before conditions get evaluated
+	return nil, ErrLimitedSubqueries
+}
+
+// inListSetThreshold is the number of values from which the values of a list are looked up in a set instead of being compared one by one
+const inListSetThreshold = 8
+
+// InListExp checks whether a value is (or is not) equal to any of the values of the list,
+// values are compared as in any other comparison, thus a null value is only equal to null values.
+// Parameters bound to lists of values e.g. id IN @ids, are expanded into the values they hold
+type InListExp struct {
+	val    ValueExp
+	notIn  bool
+	values []ValueExp
+
+	// the values of the list by their encoding, set when the list holds enough constant values of the same type
+	valSet  map[string]struct{}
+	setType SQLValueType
+	hasNull bool
+}
+
+func newInListExp(val ValueExp, notIn bool, values []ValueExp) *InListExp {
+	bexp := &InListExp{val: val, notIn: notIn, values: values}
+
+	if len(values) < inListSetThreshold {
+		return bexp
+	}
+
+	valSet := make(map[string]struct{}, len(values))
+	setType := ""
+
+	for _, v := range values {
+		tv, isConst := constantValue(v)
+		if !isConst {
+			return bexp
+		}
+
+		if isNull(tv) {
+			bexp.hasNull = true
+			continue
+		}
+
+		switch tv.Type() {
+		case IntegerType, VarcharType, BooleanType, BLOBType, TimestampType:
+		default:
+			// values of other types may be equal to values with a different encoding
+			return bexp
+		}
+
+		if setType != "" && tv.Type() != setType {
+			return bexp
+		}
+
+		setType = tv.Type()
+
+		encVal, err := EncodeValue(tv, setType, false)
+		if err != nil {
+			return bexp
+		}
+
+		valSet[string(encVal)] = struct{}{}
+	}
+
+	bexp.valSet = valSet
+	bexp.setType = setType
+
+	return bexp
+}
+
+// constantValue returns the value of literal expressions, as they do not depend on the row being read
+func constantValue(exp ValueExp) (TypedValue, bool) {
+	switch v := exp.(type) {
+	case *Number, *Float, *Varchar, *Bool, *Blob, *Timestamp, *NullValue:
+		return v.(TypedValue), true
+	}
+
+	return nil, false
+}
+
+// paramList returns the values of a parameter bound to a list, blobs are not taken as lists
+func paramList(val interface{}) ([]interface{}, bool) {
+	if val == nil {
+		return nil, false
+	}
+
+	rv := reflect.ValueOf(val)
+	if rv.Kind() != reflect.Slice || rv.Type().Elem().Kind() == reflect.Uint8 {
+		return nil, false
+	}
+
+	list := make([]interface{}, rv.Len())
+	for i := range list {
+		list[i] = rv.Index(i).Interface()
+	}
+
+	return list, true
+}
+
+func (bexp *InListExp) jointColumnTo(col *Column, tableAlias string) (*ColSelector, error) {
+	return nil, ErrJointColumnNotFound
+}
+
+func (bexp *InListExp) substitute(params map[string]interface{}) (ValueExp, error) {
+	rval, err := bexp.val.substitute(params)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]ValueExp, 0, len(bexp.values))
+
+	for _, v := range bexp.values {
+		p, isParam := v.(*Param)
+		if isParam {
+			list, isList := paramList(params[p.id])
+			if isList {
+				for _, e := range list {
+					pv, err := paramValue(e)
+					if err != nil {
+						return nil, err
+					}
+
+					values = append(values, pv)
+				}
+
+				continue
+			}
+		}
+
+		sv, err := v.substitute(params)
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, sv)
+	}
+
+	return newInListExp(rval, bexp.notIn, values), nil
+}
+
+func (bexp *InListExp) reduce(catalog *Catalog, row *Row, implicitDB, implicitTable string) (TypedValue, error) {
+	rval, err := bexp.val.reduce(catalog, row, implicitDB, implicitTable)
+	if err != nil {
+		return nil, err
+	}
+
+	_, isNull := rval.(*NullValue)
+	if isNull {
+		return &NullValue{t: BooleanType}, nil
+	}
+
+	if bexp.valSet != nil && rval.Type() == bexp.setType {
+		encVal, err := EncodeValue(rval, bexp.setType, false)
+		if err != nil {
+			return nil, err
+		}
+
+		_, found := bexp.valSet[string(encVal)]
+		if found {
+			return &Bool{val: !bexp.notIn}, nil
+		}
+
+		if bexp.hasNull {
+			return &NullValue{t: BooleanType}, nil
+		}
+
+		return &Bool{val: bexp.notIn}, nil
+	}
+
+	// when there is no match, nulls within the list make the result unknown
+	unknown := false
+
+	for _, v := range bexp.values {
+		rv, err := v.reduce(catalog, row, implicitDB, implicitTable)
+		if err != nil {
+			return nil, err
+		}
+
+		_, isNull := rv.(*NullValue)
+		if isNull {
+			unknown = true
+			continue
+		}
+
+		r, err := rval.Compare(rv)
+		if err != nil {
+			return nil, err
+		}
+
+		if r == 0 {
+			return &Bool{val: !bexp.notIn}, nil
+		}
+	}
+
+	if unknown {
+		return &NullValue{t: BooleanType}, nil
+	}
+
+	return &Bool{val: bexp.notIn}, nil
+}
+
+// materializeSubqueries returns a copy of the expression where (uncorrelated) sub-queries are replaced by their results,
+// i.e. scalar sub-queries by the returned value, IN sub-queries by the list of returned values and EXISTS by a boolean
+func (e *Engine) materializeSubqueries(ctx context.Context, implicitDB *Database, snap *store.Snapshot, params map[string]interface{}, exp ValueExp) (ValueExp, error) {
+	switch v := exp.(type) {
+	case *NumExp:
+		{
+			left, right, err := e.materializeBinExp(ctx, implicitDB, snap, params, v.left, v.right)
+			if err != nil {
+				return nil, err
+			}
+
+			return &NumExp{op: v.op, left: left, right: right}, nil
+		}
+	case *CmpBoolExp:
+		{
+			left, right, err := e.materializeBinExp(ctx, implicitDB, snap, params, v.left, v.right)
+			if err != nil {
+				return nil, err
+			}
+
+			return &CmpBoolExp{op: v.op, left: left, right: right}, nil
+		}
+	case *BinBoolExp:
+		{
+			left, right, err := e.materializeBinExp(ctx, implicitDB, snap, params, v.left, v.right)
+			if err != nil {
+				return nil, err
+			}
+
+			return &BinBoolExp{op: v.op, left: left, right: right}, nil
+		}
+	case *NotBoolExp:
+		{
+			rexp, err := e.materializeSubqueries(ctx, implicitDB, snap, params, v.exp)
+			if err != nil {
+				return nil, err
+			}
+
+			return &NotBoolExp{exp: rexp}, nil
+		}
+	case *IsNullBoolExp:
+		{
+			rexp, err := e.materializeSubqueries(ctx, implicitDB, snap, params, v.exp)
+			if err != nil {
+				return nil, err
+			}
+
+			return &IsNullBoolExp{exp: rexp, isNot: v.isNot}, nil
+		}
+	case *LikeBoolExp:
+		{
+			rval, err := e.materializeSubqueries(ctx, implicitDB, snap, params, v.val)
+			if err != nil {
+				return nil, err
+			}
+
+			rpattern, err := e.materializeSubqueries(ctx, implicitDB, snap, params, v.pattern)
+			if err != nil {
+				return nil, err
+			}
+
+			return &LikeBoolExp{val: rval, notLike: v.notLike, caseInsensitive: v.caseInsensitive, pattern: rpattern}, nil
+		}
+	case *InListExp:
+		{
+			rval, err := e.materializeSubqueries(ctx, implicitDB, snap, params, v.val)
+			if err != nil {
+				return nil, err
+			}
+
+			values := make([]ValueExp, len(v.values))
+
+			for i, val := range v.values {
+				values[i], err = e.materializeSubqueries(ctx, implicitDB, snap, params, val)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			return newInListExp(rval, v.notIn, values), nil
+		}
+	case *CaseWhenExp:
+		{
+			rc := &CaseWhenExp{whens: make([]*whenThen, len(v.whens)), as: v.as}
+
+			var err error
+
+			if v.exp != nil {
+				rc.exp, err = e.materializeSubqueries(ctx, implicitDB, snap, params, v.exp)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			for i, w := range v.whens {
+				when, then, err := e.materializeBinExp(ctx, implicitDB, snap, params, w.when, w.then)
+				if err != nil {
+					return nil, err
+				}
+
+				rc.whens[i] = &whenThen{when: when, then: then}
+			}
+
+			if v.elseExp != nil {
+				rc.elseExp, err = e.materializeSubqueries(ctx, implicitDB, snap, params, v.elseExp)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			return rc, nil
+		}
+	case *FnCall:
+		{
+			rc := &FnCall{fn: v.fn, params: make([]ValueExp, len(v.params)), as: v.as}
+
+			for i, p := range v.params {
+				rp, err := e.materializeSubqueries(ctx, implicitDB, snap, params, p)
+				if err != nil {
+					return nil, err
+				}
+
+				rc.params[i] = rp
+			}
+
+			return rc, nil
+		}
+	case *InSubQueryExp:
+		{
+			rval, err := e.materializeSubqueries(ctx, implicitDB, snap, params, v.val)
+			if err != nil {
+				return nil, err
+			}
+
+			values, _, err := e.subqueryValues(ctx, implicitDB, snap, params, v.q, 0)
+			if err != nil {
+				return nil, err
+			}
+
+			return newInListExp(rval, v.notIn, values), nil
+		}
+	case *SubQueryExp:
+		{
+			values, colType, err := e.subqueryValues(ctx, implicitDB, snap, params, v.q, 2)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(values) > 1 {
+				return nil, ErrSubqueryRows
+			}
+
+			if len(values) == 0 {
+				return &NullValue{t: colType}, nil
+			}
+
+			return values[0], nil
+		}
+	case *ExistsBoolExp:
+		{
+			r, err := v.q.Resolve(ctx, e, implicitDB, snap, params, nil)
+			if err != nil {
+				return nil, err
+			}
+			defer r.Close()
+
+			_, err = r.Read()
+			if err == store.ErrNoMoreEntries {
+				return &Bool{val: false}, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			return &Bool{val: true}, nil
+		}
+	}
+
+	return exp, nil
+}
+
+func (e *Engine) materializeBinExp(ctx context.Context, implicitDB *Database, snap *store.Snapshot, params map[string]interface{}, left, right ValueExp) (ValueExp, ValueExp, error) {
+	rleft, err := e.materializeSubqueries(ctx, implicitDB, snap, params, left)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rright, err := e.materializeSubqueries(ctx, implicitDB, snap, params, right)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return rleft, rright, nil
+}
+
+// subqueryValues returns the values of the single column returned by the sub-query and its type,
+// when limit is greater than zero no more than limit values are read
+func (e *Engine) subqueryValues(ctx context.Context, implicitDB *Database, snap *store.Snapshot, params map[string]interface{}, q *SelectStmt, limit int) ([]ValueExp, SQLValueType, error) {
+	r, err := q.Resolve(ctx, e, implicitDB, snap, params, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	defer r.Close()
+
+	cols, err := r.Columns()
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(cols) != 1 {
+		return nil, "", ErrSubqueryColumns
+	}
+
+	var values []ValueExp
+
+	for limit == 0 || len(values) < limit {
+		row, err := r.Read()
+		if err == store.ErrNoMoreEntries {
+			break
+		}
+		if err != nil {
+			return nil, "", err
+		}
+
+		v, err := valueExpFrom(row.Values[cols[0].Selector], cols[0].Type)
+		if err != nil {
+			return nil, "", err
+		}
+
+		values = append(values, v)
+	}
+
+	return values, cols[0].Type, nil
+}
+
+// valueExpFrom returns the value as a literal, aggregated values are not valid expressions by themselves
+func valueExpFrom(v TypedValue, colType SQLValueType) (ValueExp, error) {
+	if v == nil || v.Value() == nil {
+		return &NullValue{t: colType}, nil
+	}
+
+	switch v.Type() {
+	case IntegerType:
+		return &Number{val: v.Value().(uint64)}, nil
+	case FloatType:
+		return &Float{val: v.Value().(float64)}, nil
+	case BooleanType:
+		return &Bool{val: v.Value().(bool)}, nil
+	case VarcharType:
+		return &Varchar{val: v.Value().(string)}, nil
+	case BLOBType:
+		return &Blob{val: v.Value().([]byte)}, nil
+	case TimestampType:
+		return &Timestamp{val: v.Value().(time.Time)}, nil
+	case JSONType:
+		return &JSON{val: v.Value().(string)}, nil
+	}
+
+	return nil, ErrInvalidValue
+}
@@ -0,0 +1,396 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/codenotary/immudb/embedded/store"
+)
+
+// View is a named query. References to views are expanded into their queries when statements are resolved,
+// thus views don't hold any row and the policies of the tables read by them still apply
+type View struct {
+	db    *Database
+	name  string
+	query *SelectStmt
+	sql   string // the query as it was written when the view was created
+}
+
+func (db *Database) ExistView(name string) bool {
+	_, exists := db.viewsByName[name]
+	return exists
+}
+
+func (db *Database) GetViews() []*View {
+	vs := make([]*View, 0, len(db.viewsByName))
+
+	for _, v := range db.viewsByName {
+		vs = append(vs, v)
+	}
+
+	sort.Slice(vs, func(i, j int) bool { return vs[i].name < vs[j].name })
+
+	return vs
+}
+
+func (db *Database) GetViewByName(name string) (*View, error) {
+	v, exists := db.viewsByName[name]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrViewDoesNotExist, name)
+	}
+	return v, nil
+}
+
+func (db *Database) newView(name string, query *SelectStmt, sql string) (*View, error) {
+	if len(name) == 0 || query == nil {
+		return nil, ErrIllegalArguments
+	}
+
+	if db.ExistView(name) {
+		return nil, fmt.Errorf("%w: %s", ErrViewAlreadyExists, name)
+	}
+
+	if db.ExistTable(name) {
+		return nil, fmt.Errorf("%w: %s", ErrTableAlreadyExists, name)
+	}
+
+	v := &View{
+		db:    db,
+		name:  name,
+		query: query,
+		sql:   sql,
+	}
+
+	db.viewsByName[name] = v
+
+	return v, nil
+}
+
+func (db *Database) dropView(name string) (*View, error) {
+	v, err := db.GetViewByName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	delete(db.viewsByName, name)
+
+	return v, nil
+}
+
+func (v *View) Name() string {
+	return v.name
+}
+
+func (v *View) Database() *Database {
+	return v.db
+}
+
+// String returns the statement creating the view
+func (v *View) String() string {
+	return fmt.Sprintf("CREATE VIEW %s AS %s", v.name, v.sql)
+}
+
+// dataSourcesOf returns the data sources read by the query, either directly or as joined ones
+func dataSourcesOf(query *SelectStmt) []DataSource {
+	dss := []DataSource{query.ds}
+
+	for _, j := range query.joins {
+		dss = append(dss, j.ds)
+	}
+
+	return dss
+}
+
+// viewsReferencedBy returns the names of the views of the database read by the query
+func (db *Database) viewsReferencedBy(query *SelectStmt) []string {
+	var names []string
+
+	for _, ds := range dataSourcesOf(query) {
+		switch r := ds.(type) {
+		case *TableRef:
+			{
+				if (r.db == "" || r.db == db.name) && db.ExistView(r.table) {
+					names = append(names, r.table)
+				}
+			}
+		case *SelectStmt:
+			{
+				names = append(names, db.viewsReferencedBy(r)...)
+			}
+		}
+	}
+
+	return names
+}
+
+// writeViewsDDL writes the statements creating the views of the database, views are created after the ones they read
+func (db *Database) writeViewsDDL(b *strings.Builder) {
+	written := make(map[string]struct{}, len(db.viewsByName))
+
+	var write func(v *View)
+
+	write = func(v *View) {
+		if _, ok := written[v.name]; ok {
+			return
+		}
+		written[v.name] = struct{}{}
+
+		for _, name := range db.viewsReferencedBy(v.query) {
+			write(db.viewsByName[name])
+		}
+
+		fmt.Fprintf(b, "%s;\n", v)
+	}
+
+	for _, v := range db.GetViews() {
+		write(v)
+	}
+}
+
+type CreateViewStmt struct {
+	view        string
+	ifNotExists bool
+	query       *SelectStmt
+	sql         string
+}
+
+func (stmt *CreateViewStmt) isDDL() bool {
+	return true
+}
+
+// CompileUsing persists the view as the statement creating it, so its query is parsed again when the catalog is loaded.
+// Views and tables read by the query must exist, and the view can not be read by them
+func (stmt *CreateViewStmt) CompileUsing(ctx context.Context, e *Engine, implicitDB *Database, params map[string]interface{}) (ces, des []*store.KV, db *Database, err error) {
+	if implicitDB == nil {
+		return nil, nil, nil, ErrNoDatabaseSelected
+	}
+
+	if stmt.ifNotExists && implicitDB.ExistView(stmt.view) {
+		return nil, nil, implicitDB, nil
+	}
+
+	err = implicitDB.checkViewQuery(e, stmt.query, implicitDB, stmt.view)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	_, _, _, err = stmt.query.CompileUsing(ctx, e, implicitDB, params)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	v, err := implicitDB.newView(stmt.view, stmt.query, stmt.sql)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	ces = append(ces, e.viewEntry(v, false))
+
+	return ces, des, implicitDB, nil
+}
+
+// checkViewQuery checks the data sources of a query of the database exist, and that none of them reads the view
+// being created, either directly or through other views
+func (db *Database) checkViewQuery(e *Engine, query *SelectStmt, viewDB *Database, view string) error {
+	for _, ds := range dataSourcesOf(query) {
+		switch r := ds.(type) {
+		case *TableRef:
+			{
+				if (r.db == viewDB.name || (r.db == "" && db == viewDB)) && r.table == view {
+					return fmt.Errorf("%w: %s", ErrRecursiveView, view)
+				}
+
+				v := r.referencedView(e, db)
+				if v != nil {
+					err := v.db.checkViewQuery(e, v.query, viewDB, view)
+					if err != nil {
+						return err
+					}
+
+					continue
+				}
+
+				_, err := r.referencedTable(e, db)
+				if err != nil {
+					return err
+				}
+			}
+		case *SelectStmt:
+			{
+				err := db.checkViewQuery(e, r, viewDB, view)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (e *Engine) viewEntry(v *View, deleted bool) *store.KV {
+	kv := &store.KV{
+		Key:   e.mapKey(catalogViewPrefix, EncodeID(v.db.id), []byte(v.name)),
+		Value: []byte(v.String()),
+	}
+
+	if deleted {
+		kv.Metadata = store.NewKVMetadata().AsDeleted(true)
+	}
+
+	return kv
+}
+
+type DropViewStmt struct {
+	view string
+}
+
+func (stmt *DropViewStmt) isDDL() bool {
+	return true
+}
+
+func (stmt *DropViewStmt) CompileUsing(ctx context.Context, e *Engine, implicitDB *Database, params map[string]interface{}) (ces, des []*store.KV, db *Database, err error) {
+	if implicitDB == nil {
+		return nil, nil, nil, ErrNoDatabaseSelected
+	}
+
+	v, err := implicitDB.dropView(stmt.view)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	ces = append(ces, e.viewEntry(v, true))
+
+	return ces, des, implicitDB, nil
+}
+
+// referencedView returns the view referenced by the table reference, nil is returned when there is no such view
+func (stmt *TableRef) referencedView(e *Engine, implicitDB *Database) *View {
+	db := implicitDB
+
+	if stmt.db != "" {
+		rdb, err := e.catalog.GetDatabaseByName(stmt.db)
+		if err != nil {
+			return nil
+		}
+
+		db = rdb
+	}
+
+	if db == nil {
+		return nil
+	}
+
+	return db.viewsByName[stmt.table]
+}
+
+type expandedViewsKey struct{}
+
+// viewRef is the data source a reference to a view is expanded into, rows are read by the query of the view
+// and named after the reference
+type viewRef struct {
+	view *View
+	as   string
+}
+
+// expandViews returns a copy of the statement where the data sources referencing views are replaced by their queries.
+// Views can't be read as of a previous tx, as the query may read several tables
+func (stmt *SelectStmt) expandViews(e *Engine, implicitDB *Database) (*SelectStmt, error) {
+	expand := func(ds DataSource) (DataSource, bool, error) {
+		r, isTableRef := ds.(*TableRef)
+		if !isTableRef {
+			return ds, false, nil
+		}
+
+		v := r.referencedView(e, implicitDB)
+		if v == nil {
+			return ds, false, nil
+		}
+
+		if r.asBefore > 0 || r.sinceTx > 0 || r.asOf != nil {
+			return nil, false, fmt.Errorf("%w: views can not be read as of a previous tx", ErrNoSupported)
+		}
+
+		return &viewRef{view: v, as: r.Alias()}, true, nil
+	}
+
+	ds, expanded, err := expand(stmt.ds)
+	if err != nil {
+		return nil, err
+	}
+
+	var joins []*JoinSpec
+
+	for i, j := range stmt.joins {
+		jds, jexpanded, err := expand(j.ds)
+		if err != nil {
+			return nil, err
+		}
+
+		if !jexpanded {
+			continue
+		}
+
+		if joins == nil {
+			joins = append([]*JoinSpec{}, stmt.joins...)
+		}
+
+		joins[i] = &JoinSpec{joinType: j.joinType, ds: jds, cond: j.cond}
+	}
+
+	if !expanded && joins == nil {
+		return stmt, nil
+	}
+
+	rstmt := *stmt
+	rstmt.ds = ds
+
+	if joins != nil {
+		rstmt.joins = joins
+	}
+
+	return &rstmt, nil
+}
+
+// Resolve reads the rows of the view within the database of the view. Views being expanded are tracked by the context,
+// so a view reading itself, e.g. through a sub-query, fails instead of being expanded indefinitely
+func (r *viewRef) Resolve(ctx context.Context, e *Engine, implicitDB *Database, snap *store.Snapshot, params map[string]interface{}, ordCol *OrdCol) (RowReader, error) {
+	expanded, _ := ctx.Value(expandedViewsKey{}).(map[*View]struct{})
+
+	if _, ok := expanded[r.view]; ok {
+		return nil, fmt.Errorf("%w: %s", ErrRecursiveView, r.view.name)
+	}
+
+	rexpanded := make(map[*View]struct{}, len(expanded)+1)
+	for v := range expanded {
+		rexpanded[v] = struct{}{}
+	}
+	rexpanded[r.view] = struct{}{}
+
+	q := *r.view.query
+	q.as = r.as
+
+	return q.Resolve(context.WithValue(ctx, expandedViewsKey{}, rexpanded), e, r.view.db, snap, params, nil)
+}
+
+func (r *viewRef) Alias() string {
+	return r.as
+}
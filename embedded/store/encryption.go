@@ -0,0 +1,87 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package store
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+)
+
+const encryptionKeyCheckPrefix = "immudb-encryption-key-check"
+
+func validEncryptionKey(key []byte) bool {
+	return key == nil || len(key) == 16 || len(key) == 24 || len(key) == 32
+}
+
+// encryptionKeyCheck returns the value stored as metadata to detect a wrong key is used when reopening the store
+func encryptionKeyCheck(key []byte) []byte {
+	h := sha256.Sum256(append([]byte(encryptionKeyCheckPrefix), key...))
+	return h[:]
+}
+
+func newValueCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// encryptedLen returns the size a value takes in the value log
+func (s *ImmuStore) encryptedLen(vLen int) int {
+	if s.cipher == nil {
+		return vLen
+	}
+
+	return s.cipher.NonceSize() + vLen + s.cipher.Overhead()
+}
+
+// encryptValue returns the value as stored in the value log i.e. nonce + sealed value
+func (s *ImmuStore) encryptValue(value []byte) ([]byte, error) {
+	if s.cipher == nil {
+		return value, nil
+	}
+
+	nonce := make([]byte, s.cipher.NonceSize(), s.encryptedLen(len(value)))
+
+	_, err := rand.Read(nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.cipher.Seal(nonce, nonce, value, nil), nil
+}
+
+// decryptValue opens the value as stored in the value log into b
+func (s *ImmuStore) decryptValue(b []byte, encValue []byte) error {
+	nonceSize := s.cipher.NonceSize()
+
+	if len(encValue) < nonceSize {
+		return ErrCorruptedData
+	}
+
+	value, err := s.cipher.Open(nil, encValue[:nonceSize], encValue[nonceSize:], nil)
+	if err != nil || len(value) != len(b) {
+		return ErrCorruptedData
+	}
+
+	copy(b, value)
+
+	return nil
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package store
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestImmudbStoreEncryption(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+
+	_, err := Open("data_encryption", DefaultOptions().WithEncryptionKey([]byte("short")))
+	require.Equal(t, ErrIllegalArguments, err)
+
+	opts := DefaultOptions().WithSynced(false).WithMaxConcurrency(1).WithEncryptionKey(key)
+
+	immuStore, err := Open("data_encryption", opts)
+	require.NoError(t, err)
+	defer os.RemoveAll("data_encryption")
+
+	value := []byte("a value which must not be stored in plain")
+
+	md, err := immuStore.Commit([]*KV{{Key: []byte("key1"), Value: value}}, true)
+	require.NoError(t, err)
+
+	val, _, _, err := immuStore.Get([]byte("key1"))
+	require.NoError(t, err)
+	require.Equal(t, value, val)
+
+	tx := immuStore.NewTx()
+
+	err = immuStore.ReadTx(md.ID, tx)
+	require.NoError(t, err)
+
+	proof, err := tx.Proof([]byte("key1"))
+	require.NoError(t, err)
+	require.True(t, VerifyInclusion(proof, &KV{Key: []byte("key1"), Value: value}, tx.Eh()))
+
+	err = immuStore.Close()
+	require.NoError(t, err)
+
+	files, err := filepath.Glob(filepath.Join("data_encryption", "val_0", "*"))
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	for _, f := range files {
+		content, err := ioutil.ReadFile(f)
+		require.NoError(t, err)
+		require.False(t, bytes.Contains(content, value))
+	}
+
+	_, err = Open("data_encryption", DefaultOptions())
+	require.Equal(t, ErrMissingEncryptionKey, err)
+
+	_, err = Open("data_encryption", DefaultOptions().WithEncryptionKey([]byte("fedcba9876543210fedcba9876543210")))
+	require.Equal(t, ErrInvalidEncryptionKey, err)
+
+	immuStore, err = Open("data_encryption", opts)
+	require.NoError(t, err)
+
+	val, _, _, err = immuStore.Get([]byte("key1"))
+	require.NoError(t, err)
+	require.Equal(t, value, val)
+
+	err = immuStore.Close()
+	require.NoError(t, err)
+}
+
+func TestImmudbStoreUnencrypted(t *testing.T) {
+	immuStore, err := Open("data_unencrypted", DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("data_unencrypted")
+
+	err = immuStore.Close()
+	require.NoError(t, err)
+
+	_, err = Open("data_unencrypted", DefaultOptions().WithEncryptionKey([]byte("0123456789abcdef")))
+	require.Equal(t, ErrUnencryptedStore, err)
+}
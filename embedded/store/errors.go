@@ -0,0 +1,65 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package store
+
+import "fmt"
+
+// Errors returned by the store may wrap one of the package sentinel errors, together with
+// the context in which it occurred. errors.Is must be used to check for the sentinel error,
+// while errors.As provides access to the context.
+
+// LogError annotates an error with the log, and the offset within it, where it occurred
+type LogError struct {
+	Path   string
+	Offset int64
+	Err    error
+}
+
+func (e *LogError) Error() string {
+	return fmt.Sprintf("%v (log '%s', offset %d)", e.Err, e.Path, e.Offset)
+}
+
+func (e *LogError) Unwrap() error {
+	return e.Err
+}
+
+// TxError annotates an error with the transaction it relates to
+type TxError struct {
+	TxID uint64
+	Err  error
+}
+
+func (e *TxError) Error() string {
+	return fmt.Sprintf("%v (tx %d)", e.Err, e.TxID)
+}
+
+func (e *TxError) Unwrap() error {
+	return e.Err
+}
+
+// KeyError annotates an error with the key it relates to
+type KeyError struct {
+	Key []byte
+	Err error
+}
+
+func (e *KeyError) Error() string {
+	return fmt.Sprintf("%v (key %q)", e.Err, e.Key)
+}
+
+func (e *KeyError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package store
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrappedErrors(t *testing.T) {
+	err := error(&LogError{
+		Path:   "data/tx",
+		Offset: 10,
+		Err:    &TxError{TxID: 2, Err: ErrorCorruptedTxData},
+	})
+
+	require.True(t, errors.Is(err, ErrorCorruptedTxData))
+	require.False(t, errors.Is(err, ErrCorruptedData))
+	require.Equal(t, "tx data is corrupted (tx 2) (log 'data/tx', offset 10)", err.Error())
+
+	var logErr *LogError
+	require.True(t, errors.As(err, &logErr))
+	require.Equal(t, "data/tx", logErr.Path)
+	require.Equal(t, int64(10), logErr.Offset)
+
+	var txErr *TxError
+	require.True(t, errors.As(err, &txErr))
+	require.Equal(t, uint64(2), txErr.TxID)
+
+	err = &KeyError{Key: []byte("key1"), Err: ErrDuplicatedKey}
+	require.True(t, errors.Is(err, ErrDuplicatedKey))
+	require.Equal(t, `duplicated key (key "key1")`, err.Error())
+}
+
+func TestCorruptedCommitLogError(t *testing.T) {
+	dir := "data_corrupted_clog"
+	defer os.RemoveAll(dir)
+
+	immuStore, err := Open(dir, DefaultOptions().WithSynced(false))
+	require.NoError(t, err)
+
+	_, err = immuStore.Commit([]*KV{{Key: []byte("key1"), Value: []byte("value1")}}, false)
+	require.NoError(t, err)
+
+	err = immuStore.Close()
+	require.NoError(t, err)
+
+	cLogFiles, err := filepath.Glob(filepath.Join(dir, "commit", "*.txi"))
+	require.NoError(t, err)
+	require.Len(t, cLogFiles, 1)
+
+	finfo, err := os.Stat(cLogFiles[0])
+	require.NoError(t, err)
+
+	// the entry of the single committed tx gets partially removed
+	err = os.Truncate(cLogFiles[0], finfo.Size()-1)
+	require.NoError(t, err)
+
+	_, err = Open(dir, DefaultOptions())
+	require.True(t, errors.Is(err, ErrCorruptedCLog))
+
+	var logErr *LogError
+	require.True(t, errors.As(err, &logErr))
+	require.Equal(t, filepath.Join(dir, "commit"), logErr.Path)
+	require.Equal(t, int64(0), logErr.Offset)
+}
+
+func TestCorruptionHandler(t *testing.T) {
+	dir := "data_corruption_handler"
+	defer os.RemoveAll(dir)
+
+	type corruption struct {
+		op  string
+		err error
+	}
+
+	var corruptions []corruption
+
+	opts := DefaultOptions().
+		WithSynced(false).
+		WithOnCorruption(func(op string, err error) {
+			corruptions = append(corruptions, corruption{op: op, err: err})
+		})
+
+	immuStore, err := Open(dir, opts)
+	require.NoError(t, err)
+
+	var txs []*TxMetadata
+
+	for _, kv := range []string{"key1", "key2", "key3"} {
+		txmd, err := immuStore.Commit([]*KV{{Key: []byte(kv), Value: []byte("value of " + kv)}}, true)
+		require.NoError(t, err)
+
+		txs = append(txs, txmd)
+	}
+
+	_, err = immuStore.LinearProof(1, 3)
+	require.NoError(t, err)
+
+	// binary linking is completed so transactions are not read while opening the store
+	require.Eventually(t, func() bool {
+		blTxID, _ := immuStore.BlInfo()
+		return blTxID == 3
+	}, time.Second, 10*time.Millisecond)
+
+	err = immuStore.Close()
+	require.NoError(t, err)
+
+	require.Empty(t, corruptions)
+
+	tamper := func(pattern string, search []byte, index func([]byte, []byte) int) {
+		files, err := filepath.Glob(filepath.Join(dir, pattern))
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+
+		content, err := ioutil.ReadFile(files[0])
+		require.NoError(t, err)
+
+		i := index(content, search)
+		require.True(t, i >= 0)
+
+		content[i]++
+
+		err = ioutil.WriteFile(files[0], content, 0644)
+		require.NoError(t, err)
+	}
+
+	// the reference to the first tx is altered into the second one, thus its stored alh doesn't match anymore.
+	// The last occurrence is searched as the alh of the first tx is also stored along with it
+	tamper(filepath.Join("tx", "*.tx"), txs[1].PrevAlh[:], bytes.LastIndex)
+	tamper(filepath.Join("val_0", "*.val"), []byte("value of key3"), bytes.Index)
+
+	immuStore, err = Open(dir, opts)
+	require.NoError(t, err)
+	defer immuStore.Close()
+
+	_, err = immuStore.LinearProof(1, 3)
+	require.True(t, errors.Is(err, ErrorCorruptedTxData))
+
+	require.Len(t, corruptions, 1)
+	require.Equal(t, "reading tx", corruptions[0].op)
+	require.True(t, errors.Is(corruptions[0].err, ErrorCorruptedTxData))
+
+	var logErr *LogError
+	require.True(t, errors.As(corruptions[0].err, &logErr))
+	require.Equal(t, filepath.Join(dir, "tx"), logErr.Path)
+
+	var txErr *TxError
+	require.True(t, errors.As(corruptions[0].err, &txErr))
+	require.Equal(t, uint64(2), txErr.TxID)
+
+	_, _, _, err = immuStore.Get([]byte("key1"))
+	require.NoError(t, err)
+	require.Len(t, corruptions, 1)
+
+	_, _, _, err = immuStore.Get([]byte("key3"))
+	require.True(t, errors.Is(err, ErrCorruptedData))
+
+	require.Len(t, corruptions, 2)
+	require.Equal(t, "reading value", corruptions[1].op)
+	require.True(t, errors.Is(corruptions[1].err, ErrCorruptedData))
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// EventType identifies what happened within the store
+type EventType int
+
+const (
+	// EventCommit is published once a transaction gets committed
+	EventCommit EventType = iota
+	// EventCompaction is published once the index gets compacted
+	EventCompaction
+	// EventTruncation is published once values get discarded
+	EventTruncation
+)
+
+func (t EventType) String() string {
+	switch t {
+	case EventCommit:
+		return "commit"
+	case EventCompaction:
+		return "compaction"
+	case EventTruncation:
+		return "truncation"
+	}
+
+	return "unknown"
+}
+
+// Event is published by the store to its listeners
+type Event struct {
+	Type EventType
+	// the committed tx for commit events, the latest indexed tx for compaction events
+	// and the latest tx which values were discarded for truncation events
+	TxID uint64
+	Time time.Time
+}
+
+// EventListener receives the events published by the store through a bounded queue.
+// Events are dropped when the queue is full, so slow listeners never hold the store back.
+type EventListener struct {
+	// accessed atomically (kept first for 64-bit alignment)
+	dropped uint64
+
+	bus    *eventBus
+	types  map[EventType]struct{} // every type is accepted when empty
+	events chan Event
+}
+
+// Events returns the queue of the listener, it gets closed once the listener or the store are closed
+func (l *EventListener) Events() <-chan Event {
+	return l.events
+}
+
+// Dropped returns the number of events which were not delivered because the queue was full
+func (l *EventListener) Dropped() uint64 {
+	return atomic.LoadUint64(&l.dropped)
+}
+
+// Close stops the delivery of events to the listener
+func (l *EventListener) Close() {
+	l.bus.remove(l)
+}
+
+func (l *EventListener) accepts(t EventType) bool {
+	if len(l.types) == 0 {
+		return true
+	}
+
+	_, accepted := l.types[t]
+	return accepted
+}
+
+type eventBus struct {
+	mutex     sync.RWMutex
+	listeners map[*EventListener]struct{}
+	closed    bool
+}
+
+func newEventBus() *eventBus {
+	return &eventBus{
+		listeners: make(map[*EventListener]struct{}),
+	}
+}
+
+func (b *eventBus) add(l *EventListener) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.closed {
+		return ErrAlreadyClosed
+	}
+
+	b.listeners[l] = struct{}{}
+
+	return nil
+}
+
+func (b *eventBus) remove(l *EventListener) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	_, exists := b.listeners[l]
+	if !exists {
+		return
+	}
+
+	delete(b.listeners, l)
+	close(l.events)
+}
+
+// publish delivers the event to the listeners accepting its type, the clock is read only if there is any
+// so the timestamps the store assigns to transactions are not affected when nobody listens
+func (b *eventBus) publish(t EventType, txID uint64, now func() time.Time) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	var e *Event
+
+	for l := range b.listeners {
+		if !l.accepts(t) {
+			continue
+		}
+
+		if e == nil {
+			e = &Event{Type: t, TxID: txID, Time: now()}
+		}
+
+		select {
+		case l.events <- *e:
+		default:
+			atomic.AddUint64(&l.dropped, 1)
+		}
+	}
+}
+
+func (b *eventBus) close() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for l := range b.listeners {
+		close(l.events)
+	}
+
+	b.listeners = nil
+	b.closed = true
+}
+
+// Listen returns a listener of the events of the given types, or of every type when none is given.
+// Up to queueSize events are kept until they are received, later ones are dropped.
+func (s *ImmuStore) Listen(queueSize int, types ...EventType) (*EventListener, error) {
+	if queueSize <= 0 {
+		return nil, ErrIllegalArguments
+	}
+
+	l := &EventListener{
+		bus:    s.events,
+		types:  make(map[EventType]struct{}, len(types)),
+		events: make(chan Event, queueSize),
+	}
+
+	for _, t := range types {
+		l.types[t] = struct{}{}
+	}
+
+	err := s.events.add(l)
+	if err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+func (s *ImmuStore) publish(t EventType, txID uint64) {
+	s.events.publish(t, txID, s.timeFunc)
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventListeners(t *testing.T) {
+	opts := DefaultOptions().WithSynced(false).WithMaxConcurrency(1)
+	opts.WithIndexOptions(opts.IndexOpts.WithFlushThld(10))
+
+	immuStore, err := Open("data_events", opts)
+	require.NoError(t, err)
+	defer os.RemoveAll("data_events")
+
+	_, err = immuStore.Listen(0)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	all, err := immuStore.Listen(100)
+	require.NoError(t, err)
+
+	commits, err := immuStore.Listen(2, EventCommit)
+	require.NoError(t, err)
+
+	maintenance, err := immuStore.Listen(10, EventCompaction, EventTruncation)
+	require.NoError(t, err)
+
+	txCount := 10
+	eCount := 10
+
+	for i := 1; i <= txCount; i++ {
+		kvs := make([]*KV, eCount)
+
+		for j := 0; j < eCount; j++ {
+			kvs[j] = &KV{Key: []byte(fmt.Sprintf("key%d", j)), Value: []byte(fmt.Sprintf("value%d", i))}
+		}
+
+		_, err = immuStore.Commit(kvs, false)
+		require.NoError(t, err)
+	}
+
+	for i := 1; i <= txCount; i++ {
+		e := <-all.Events()
+		require.Equal(t, EventCommit, e.Type)
+		require.Equal(t, uint64(i), e.TxID)
+	}
+
+	// events are dropped once the queue is full
+	require.Len(t, commits.Events(), 2)
+	require.Equal(t, uint64(txCount-2), commits.Dropped())
+	require.Equal(t, uint64(1), (<-commits.Events()).TxID)
+
+	err = immuStore.WaitForIndexingUpto(context.Background(), uint64(txCount))
+	require.NoError(t, err)
+
+	err = immuStore.TruncateUpTo(2)
+	require.NoError(t, err)
+
+	e := <-maintenance.Events()
+	require.Equal(t, EventTruncation, e.Type)
+	require.Equal(t, uint64(2), e.TxID)
+
+	time.Sleep(100 * time.Millisecond)
+
+	err = immuStore.CompactIndex()
+	require.NoError(t, err)
+
+	e = <-maintenance.Events()
+	require.Equal(t, EventCompaction, e.Type)
+	require.Equal(t, uint64(txCount), e.TxID)
+
+	require.Equal(t, EventTruncation, (<-all.Events()).Type)
+	require.Equal(t, EventCompaction, (<-all.Events()).Type)
+	require.Equal(t, uint64(0), all.Dropped())
+
+	// closed listeners don't receive events anymore
+	all.Close()
+	all.Close()
+
+	_, open := <-all.Events()
+	require.False(t, open)
+
+	err = immuStore.Close()
+	require.NoError(t, err)
+
+	_, open = <-maintenance.Events()
+	require.False(t, open)
+
+	_, err = immuStore.Listen(1)
+	require.Equal(t, ErrAlreadyClosed, err)
+}
@@ -0,0 +1,398 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package store
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+
+	"github.com/codenotary/immudb/embedded/tbtree"
+)
+
+var ErrExpressionIndexNotFound = errors.New("expression index not found")
+var ErrSecondaryIndexesDisabled = errors.New("secondary indexes are disabled")
+
+// KeyExtractor returns the key under which an entry is sorted in an expression index
+// e.g. a timestamp or a tenant id embedded in the key of the entry.
+// Entries for which ok is false are not included in the index.
+type KeyExtractor func(key []byte) (indexKey []byte, ok bool)
+
+// expression indexes hold a marker entry which is updated by every transaction,
+// thus the timestamp of the index matches the id of the latest indexed transaction
+// even when there are no entries to be included
+var exprIndexMarkerKey = []byte{}
+
+// secondaryIndexName names the expression index holding the secondary index entries declared in entries metadata
+// (see KVMetadata.IndexedBy), it's thus reserved. Entries are indexed under nameLen + name + keyLen + key,
+// so entries of each index are grouped together and sorted by the length of their index key first.
+const secondaryIndexName = "secondary"
+
+type expressionIndex struct {
+	name      string
+	extractor KeyExtractor
+	secondary bool
+	valueOff  int // leading bytes of values skipped by value prefix indexes
+	index     *tbtree.TBtree
+}
+
+func validExpressionIndexes(exprIndexes map[string]KeyExtractor) bool {
+	for name, extractor := range exprIndexes {
+		if !validIdentifier(name) || name == secondaryIndexName || extractor == nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// identifiers such as index or snapshot names are used as part of file names
+func validIdentifier(name string) bool {
+	if len(name) == 0 {
+		return false
+	}
+
+	for _, c := range name {
+		if !(c >= 'a' && c <= 'z') && !(c >= 'A' && c <= 'Z') && !(c >= '0' && c <= '9') && c != '_' && c != '-' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// kvsFor returns the entries of the transaction txID to be inserted into the expression index, the marker entry included.
+// Entries are indexed under indexKey + key with value keyLen + indexedValue
+func (ei *expressionIndex) kvsFor(st *ImmuStore, txID uint64, entries []*TxEntry, kvs []*tbtree.KV) ([]*tbtree.KV, error) {
+	exprKVs := []*tbtree.KV{{K: exprIndexMarkerKey, V: []byte{}, T: txID}}
+
+	for i, kv := range kvs {
+		indexKeys, err := ei.indexKeysFor(st, entries[i])
+		if err != nil {
+			return nil, err
+		}
+
+		for _, indexKey := range indexKeys {
+			k := make([]byte, len(indexKey)+len(kv.K))
+			copy(k, indexKey)
+			copy(k[len(indexKey):], kv.K)
+
+			v := make([]byte, szSize+len(kv.V))
+			binary.BigEndian.PutUint32(v, uint32(len(kv.K)))
+			copy(v[szSize:], kv.V)
+
+			exprKVs = append(exprKVs, &tbtree.KV{K: k, V: v, T: txID})
+		}
+	}
+
+	return exprKVs, nil
+}
+
+func (ei *expressionIndex) indexKeysFor(st *ImmuStore, e *TxEntry) ([][]byte, error) {
+	if ei.secondary {
+		return secondaryIndexKeysFor(st, e, ei.valueOff)
+	}
+
+	indexKey, ok := ei.extractor(e.key())
+	if !ok {
+		return nil, nil
+	}
+
+	return [][]byte{indexKey}, nil
+}
+
+// secondaryIndexKeysFor returns the keys of the secondary index entries declared by the entry,
+// the value is only read when indexed by its prefix
+func secondaryIndexKeysFor(st *ImmuStore, e *TxEntry, valueOff int) ([][]byte, error) {
+	if e.md == nil || len(e.md.indexes) == 0 {
+		return nil, nil
+	}
+
+	var indexKeys [][]byte
+
+	var value []byte
+	valueRead := false
+
+	for _, index := range e.md.indexes {
+		key := index.Tag
+
+		if index.ValuePrefixLen > 0 {
+			if !valueRead {
+				value = make([]byte, e.vLen)
+
+				_, err := st.readEntryValue(value, e)
+				if err == ErrValueTruncated {
+					// values discarded by retention can't be indexed by their prefix
+					value = nil
+				} else if err != nil {
+					return nil, err
+				}
+
+				if len(value) >= valueOff {
+					value = value[valueOff:]
+				} else {
+					value = nil
+				}
+
+				valueRead = true
+			}
+
+			if len(value) < index.ValuePrefixLen {
+				continue
+			}
+
+			key = value[:index.ValuePrefixLen]
+		}
+
+		indexKeys = append(indexKeys, append(secondaryIndexPrefix(index.Name), secondaryIndexKey(key)...))
+	}
+
+	return indexKeys, nil
+}
+
+func secondaryIndexPrefix(name string) []byte {
+	return append([]byte{byte(len(name))}, name...)
+}
+
+// secondaryIndexKey encodes key preserving its lexicographical order while being prefix-free,
+// so entries of different index keys are not interleaved when followed by the entry key.
+// Zero bytes are escaped as 0x00 0xFF and the encoded key is terminated by 0x00 0x00
+func secondaryIndexKey(key []byte) []byte {
+	return append(secondaryIndexBound(key), 0x00, 0x00)
+}
+
+// secondaryIndexBound encodes key as a range bound, any encoded index key equal or greater than key is not less than it
+func secondaryIndexBound(key []byte) []byte {
+	b := make([]byte, 0, len(key)+2)
+
+	for _, c := range key {
+		b = append(b, c)
+
+		if c == 0x00 {
+			b = append(b, 0xFF)
+		}
+	}
+
+	return b
+}
+
+func decodeSecondaryIndexKey(b []byte) ([]byte, error) {
+	key := make([]byte, 0, len(b))
+
+	for i := 0; i < len(b); i++ {
+		if b[i] != 0x00 {
+			key = append(key, b[i])
+			continue
+		}
+
+		if i+1 == len(b) {
+			return nil, ErrCorruptedData
+		}
+
+		if b[i+1] == 0x00 {
+			if i+2 != len(b) {
+				return nil, ErrCorruptedData
+			}
+
+			return key, nil
+		}
+
+		if b[i+1] != 0xFF {
+			return nil, ErrCorruptedData
+		}
+
+		key = append(key, 0x00)
+		i++
+	}
+
+	return nil, ErrCorruptedData
+}
+
+// ScanBySpec specifies the range of index keys to be read from an expression index.
+// Index keys are sorted together with the key they were extracted from, ranges are thus
+// exact as long as no index key is a prefix of another one e.g. fixed-length index keys.
+type ScanBySpec struct {
+	// inclusive lower bound, no lower bound when empty
+	StartKey []byte
+	// exclusive upper bound, no upper bound when empty
+	EndKey []byte
+
+	DescOrder bool
+
+	// the index must include at least up to this transaction
+	SinceTx uint64
+}
+
+// ExpressionIndexReader reads the entries of an expression index sorted by their index key
+type ExpressionIndexReader struct {
+	store  *ImmuStore
+	snap   *tbtree.Snapshot
+	reader *tbtree.Reader
+
+	// prefix of all the entries read, for secondary indexes index keys are encoded with their length
+	prefix    []byte
+	secondary bool
+	startKey  []byte
+	endKey    []byte
+	descOrder bool
+}
+
+// ScanBy returns a reader of the entries in the expression index indexName whose index key is within the specified range.
+// Indexing may be lagging behind, WaitForIndexingUpto can be used to ensure the index is up to date.
+func (s *ImmuStore) ScanBy(indexName string, spec *ScanBySpec) (*ExpressionIndexReader, error) {
+	if spec == nil {
+		return nil, ErrIllegalArguments
+	}
+
+	snap, err := s.indexer.ExpressionIndexSnapshotSince(indexName, spec.SinceTx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.newExpressionIndexReader(snap, nil, false, spec)
+}
+
+// IndexScan returns a reader of the entries in the secondary index indexName, as declared in the metadata of entries,
+// whose index key is within the specified range. Index keys are sorted lexicographically, a tag or value prefix
+// is exactly matched by the range [key, key + 0x00).
+// Indexing may be lagging behind, WaitForIndexingUpto can be used to ensure the index is up to date.
+func (s *ImmuStore) IndexScan(indexName string, spec *ScanBySpec) (*ExpressionIndexReader, error) {
+	if spec == nil || !validIdentifier(indexName) || len(indexName) > MaxKVIndexNameLen {
+		return nil, ErrIllegalArguments
+	}
+
+	snap, err := s.indexer.SecondaryIndexSnapshotSince(spec.SinceTx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.newExpressionIndexReader(snap, secondaryIndexPrefix(indexName), true, spec)
+}
+
+func (s *ImmuStore) newExpressionIndexReader(snap *tbtree.Snapshot, prefix []byte, secondary bool, spec *ScanBySpec) (*ExpressionIndexReader, error) {
+	r := &ExpressionIndexReader{
+		store:     s,
+		snap:      snap,
+		prefix:    prefix,
+		secondary: secondary,
+		startKey:  spec.StartKey,
+		endKey:    spec.EndKey,
+		descOrder: spec.DescOrder,
+	}
+
+	if secondary {
+		if len(spec.StartKey) > 0 {
+			r.startKey = secondaryIndexBound(spec.StartKey)
+		}
+		if len(spec.EndKey) > 0 {
+			r.endKey = secondaryIndexBound(spec.EndKey)
+		}
+	}
+
+	readerSpec := &tbtree.ReaderSpec{
+		SeekKey:       append(append([]byte{}, prefix...), r.startKey...),
+		Prefix:        prefix,
+		InclusiveSeek: true,
+		DescOrder:     spec.DescOrder,
+	}
+
+	if spec.DescOrder {
+		readerSpec.SeekKey = append(append([]byte{}, prefix...), r.endKey...)
+
+		if len(prefix) > 0 && len(r.endKey) == 0 {
+			// no entry of the index is greater than the prefix following it
+			readerSpec.SeekKey[len(prefix)-1]++
+		}
+	}
+
+	reader, err := snap.NewReader(readerSpec)
+	if err != nil {
+		snap.Close()
+		return nil, err
+	}
+
+	r.reader = reader
+
+	return r, nil
+}
+
+// Read returns the next entry within the range, deleted and expired entries are skipped
+func (r *ExpressionIndexReader) Read() (indexKey []byte, key []byte, val *ValueRef, tx uint64, err error) {
+	for {
+		k, v, tx, _, err := r.reader.Read()
+		if err != nil {
+			return nil, nil, nil, 0, err
+		}
+
+		if len(k) == 0 {
+			// marker entry
+			continue
+		}
+
+		if len(v) < szSize {
+			return nil, nil, nil, 0, ErrCorruptedData
+		}
+
+		kLen := int(binary.BigEndian.Uint32(v))
+		if len(r.prefix)+kLen > len(k) {
+			return nil, nil, nil, 0, ErrCorruptedData
+		}
+
+		indexKey = k[len(r.prefix) : len(k)-kLen]
+		key = k[len(k)-kLen:]
+
+		if len(r.startKey) > 0 && bytes.Compare(indexKey, r.startKey) < 0 {
+			if r.descOrder {
+				return nil, nil, nil, 0, ErrNoMoreEntries
+			}
+			continue
+		}
+
+		if len(r.endKey) > 0 && bytes.Compare(indexKey, r.endKey) >= 0 {
+			if !r.descOrder {
+				return nil, nil, nil, 0, ErrNoMoreEntries
+			}
+			continue
+		}
+
+		if r.secondary {
+			indexKey, err = decodeSecondaryIndexKey(indexKey)
+			if err != nil {
+				return nil, nil, nil, 0, err
+			}
+		}
+
+		val, err = r.store.valueRefFrom(v[szSize:])
+		if err != nil {
+			return nil, nil, nil, 0, err
+		}
+
+		if val.md.hiddenAt(r.store.timeFunc()) {
+			continue
+		}
+
+		return indexKey, key, val, tx, nil
+	}
+}
+
+func (r *ExpressionIndexReader) Close() error {
+	err := r.reader.Close()
+	if err != nil {
+		return err
+	}
+
+	return r.snap.Close()
+}
@@ -0,0 +1,303 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package store
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// keys are formatted as tenant/id followed by an 8 bytes timestamp
+func eventKey(tenant string, id int, ts uint64) []byte {
+	k := []byte(fmt.Sprintf("%s/%d", tenant, id))
+
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], ts)
+
+	return append(k, b[:]...)
+}
+
+func tsExtractor(key []byte) ([]byte, bool) {
+	if len(key) < 8 || !bytes.Contains(key, []byte("/")) {
+		return nil, false
+	}
+	return key[len(key)-8:], true
+}
+
+func tenantExtractor(key []byte) ([]byte, bool) {
+	i := bytes.IndexByte(key, '/')
+	if i < 0 {
+		return nil, false
+	}
+	return key[:i+1], true
+}
+
+func tsKey(ts uint64) []byte {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], ts)
+	return b[:]
+}
+
+func readAllBy(t *testing.T, st *ImmuStore, indexName string, spec *ScanBySpec) (keys [][]byte, values [][]byte) {
+	r, err := st.ScanBy(indexName, spec)
+	require.NoError(t, err)
+
+	return readAll(t, r)
+}
+
+func readAllIndexed(t *testing.T, st *ImmuStore, indexName string, spec *ScanBySpec) (keys [][]byte, values [][]byte) {
+	r, err := st.IndexScan(indexName, spec)
+	require.NoError(t, err)
+
+	return readAll(t, r)
+}
+
+func readAll(t *testing.T, r *ExpressionIndexReader) (keys [][]byte, values [][]byte) {
+	defer r.Close()
+
+	for {
+		_, key, valRef, _, err := r.Read()
+		if err == ErrNoMoreEntries {
+			return keys, values
+		}
+		require.NoError(t, err)
+
+		val, err := valRef.Resolve()
+		require.NoError(t, err)
+
+		keys = append(keys, key)
+		values = append(values, val)
+	}
+}
+
+func TestExpressionIndexes(t *testing.T) {
+	defer os.RemoveAll("data_expr_index")
+
+	opts := DefaultOptions().WithSynced(false)
+	opts.WithIndexOptions(opts.IndexOpts.WithExpressionIndex("ts", tsExtractor))
+
+	st, err := Open("data_expr_index", opts)
+	require.NoError(t, err)
+
+	timestamps := []uint64{40, 10, 30, 50, 20}
+
+	for i, ts := range timestamps {
+		_, err = st.Commit([]*KV{
+			{Key: eventKey("tenant1", i, ts), Value: []byte(fmt.Sprintf("value%d", ts))},
+			{Key: eventKey("tenant2", i, ts+1), Value: []byte(fmt.Sprintf("value%d", ts+1))},
+		}, false)
+		require.NoError(t, err)
+	}
+
+	// entries not included by the extractor still update the index
+	txmd, err := st.Commit([]*KV{{Key: []byte("other"), Value: []byte("value")}}, false)
+	require.NoError(t, err)
+
+	err = st.WaitForIndexingUpto(context.Background(), txmd.ID)
+	require.NoError(t, err)
+
+	_, err = st.ScanBy("ts", nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = st.ScanBy("unknown", &ScanBySpec{})
+	require.Equal(t, ErrExpressionIndexNotFound, err)
+
+	keys, values := readAllBy(t, st, "ts", &ScanBySpec{SinceTx: txmd.ID})
+	require.Len(t, keys, 2*len(timestamps))
+	require.Equal(t, []byte("value10"), values[0])
+	require.Equal(t, []byte("value51"), values[len(values)-1])
+
+	_, values = readAllBy(t, st, "ts", &ScanBySpec{StartKey: tsKey(20), EndKey: tsKey(40)})
+	require.Equal(t, [][]byte{[]byte("value20"), []byte("value21"), []byte("value30"), []byte("value31")}, values)
+
+	keys, values = readAllBy(t, st, "ts", &ScanBySpec{StartKey: tsKey(20), EndKey: tsKey(40), DescOrder: true})
+	require.Equal(t, [][]byte{[]byte("value31"), []byte("value30"), []byte("value21"), []byte("value20")}, values)
+	require.Equal(t, eventKey("tenant2", 2, 31), keys[0])
+
+	err = st.Close()
+	require.NoError(t, err)
+
+	// indexes registered on an existing store are built from the first transaction
+	opts.WithIndexOptions(opts.IndexOpts.WithExpressionIndex("tenant", tenantExtractor))
+
+	st, err = Open("data_expr_index", opts)
+	require.NoError(t, err)
+
+	err = st.WaitForIndexingUpto(context.Background(), txmd.ID)
+	require.NoError(t, err)
+
+	keys, _ = readAllBy(t, st, "tenant", &ScanBySpec{StartKey: []byte("tenant2/"), SinceTx: txmd.ID})
+	require.Len(t, keys, len(timestamps))
+
+	for _, k := range keys {
+		require.True(t, bytes.HasPrefix(k, []byte("tenant2/")))
+	}
+
+	_, values = readAllBy(t, st, "ts", &ScanBySpec{StartKey: tsKey(50)})
+	require.Equal(t, [][]byte{[]byte("value50"), []byte("value51")}, values)
+
+	err = st.Close()
+	require.NoError(t, err)
+}
+
+func TestExpressionIndexesInvalidOptions(t *testing.T) {
+	opts := DefaultOptions()
+	opts.WithIndexOptions(opts.IndexOpts.WithExpressionIndex("invalid/name", tsExtractor))
+
+	_, err := Open("data_expr_index_invalid", opts)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	opts = DefaultOptions()
+	opts.WithIndexOptions(opts.IndexOpts.WithExpressionIndex("ts", nil))
+
+	_, err = Open("data_expr_index_invalid", opts)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	opts = DefaultOptions()
+	opts.WithIndexOptions(opts.IndexOpts.WithExpressionIndex(secondaryIndexName, tsExtractor))
+
+	_, err = Open("data_expr_index_invalid", opts)
+	require.Equal(t, ErrIllegalArguments, err)
+}
+
+func TestSecondaryIndexes(t *testing.T) {
+	defer os.RemoveAll("data_secondary_index")
+
+	st, err := Open("data_secondary_index", DefaultOptions().WithSynced(false))
+	require.NoError(t, err)
+
+	kvWith := func(key, value string, indexedBy func(md *KVMetadata) (*KVMetadata, error)) *KV {
+		md, err := indexedBy(NewKVMetadata())
+		require.NoError(t, err)
+
+		return &KV{Key: []byte(key), Value: []byte(value), Metadata: md}
+	}
+
+	taggedBy := func(tags ...string) func(md *KVMetadata) (*KVMetadata, error) {
+		return func(md *KVMetadata) (*KVMetadata, error) {
+			for _, tag := range tags {
+				_, err := md.IndexedBy("tags", []byte(tag))
+				if err != nil {
+					return nil, err
+				}
+			}
+			return md, nil
+		}
+	}
+
+	byCountry := func(md *KVMetadata) (*KVMetadata, error) {
+		return md.IndexedByValuePrefix("country", 2)
+	}
+
+	_, err = st.Commit([]*KV{
+		kvWith("user1", "ES:Madrid", byCountry),
+		kvWith("user2", "IT:Rome", byCountry),
+		kvWith("doc1", "value1", taggedBy("a", "ab")),
+	}, false)
+	require.NoError(t, err)
+
+	txmd, err := st.Commit([]*KV{
+		kvWith("user3", "ES:Barcelona", byCountry),
+		kvWith("user4", "E", byCountry),
+		kvWith("doc2", "value2", taggedBy("a")),
+		kvWith("doc0", "value0", taggedBy("ab")),
+	}, false)
+	require.NoError(t, err)
+
+	_, err = st.IndexScan("tags", &ScanBySpec{})
+	require.Equal(t, ErrSecondaryIndexesDisabled, err)
+
+	err = st.Close()
+	require.NoError(t, err)
+
+	// secondary indexes enabled on an existing store are built from the first transaction
+	opts := DefaultOptions().WithSynced(false)
+	opts.WithIndexOptions(opts.IndexOpts.WithSecondaryIndexes(true))
+
+	st, err = Open("data_secondary_index", opts)
+	require.NoError(t, err)
+
+	defer st.Close()
+
+	err = st.WaitForIndexingUpto(context.Background(), txmd.ID)
+	require.NoError(t, err)
+
+	_, err = st.IndexScan("tags", nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = st.IndexScan("invalid/name", &ScanBySpec{})
+	require.Equal(t, ErrIllegalArguments, err)
+
+	keys, _ := readAllIndexed(t, st, "tags", &ScanBySpec{StartKey: []byte("a"), EndKey: []byte("a\x00"), SinceTx: txmd.ID})
+	require.Equal(t, [][]byte{[]byte("doc1"), []byte("doc2")}, keys)
+
+	keys, _ = readAllIndexed(t, st, "tags", &ScanBySpec{StartKey: []byte("ab"), EndKey: []byte("ab\x00"), DescOrder: true})
+	require.Equal(t, [][]byte{[]byte("doc1"), []byte("doc0")}, keys)
+
+	_, values := readAllIndexed(t, st, "country", &ScanBySpec{StartKey: []byte("ES"), EndKey: []byte("ET")})
+	require.Equal(t, [][]byte{[]byte("ES:Madrid"), []byte("ES:Barcelona")}, values)
+
+	keys, _ = readAllIndexed(t, st, "country", &ScanBySpec{DescOrder: true})
+	require.Equal(t, [][]byte{[]byte("user2"), []byte("user3"), []byte("user1")}, keys)
+
+	keys, _ = readAllIndexed(t, st, "unknown", &ScanBySpec{})
+	require.Empty(t, keys)
+
+	r, err := st.IndexScan("country", &ScanBySpec{StartKey: []byte("IT")})
+	require.NoError(t, err)
+
+	indexKey, key, _, tx, err := r.Read()
+	require.NoError(t, err)
+	require.Equal(t, []byte("IT"), indexKey)
+	require.Equal(t, []byte("user2"), key)
+	require.Equal(t, uint64(1), tx)
+
+	_, _, _, _, err = r.Read()
+	require.Equal(t, ErrNoMoreEntries, err)
+
+	err = r.Close()
+	require.NoError(t, err)
+}
+
+func TestSecondaryIndexKeyEncoding(t *testing.T) {
+	keys := [][]byte{{}, {0x00}, {0x00, 0x00}, {0x00, 0xFF}, {0x01}, []byte("a"), []byte("a\x00"), []byte("a\x00b"), []byte("ab")}
+
+	for i, key := range keys {
+		ek := secondaryIndexKey(key)
+
+		dk, err := decodeSecondaryIndexKey(ek)
+		require.NoError(t, err)
+		require.Equal(t, key, dk)
+
+		require.True(t, bytes.Compare(ek, secondaryIndexBound(key)) >= 0)
+
+		if i > 0 {
+			require.True(t, bytes.Compare(secondaryIndexKey(keys[i-1]), ek) < 0)
+			require.True(t, bytes.Compare(ek, secondaryIndexBound(append(key, 0x00))) < 0)
+		}
+	}
+
+	for _, ek := range [][]byte{{}, {0x00}, {'a'}, {0x00, 0x01}, {0x00, 0x00, 'a'}} {
+		_, err := decodeSecondaryIndexKey(ek)
+		require.Equal(t, ErrCorruptedData, err)
+	}
+}
@@ -18,15 +18,19 @@ package store
 import (
 	"bytes"
 	"container/list"
+	"context"
+	"crypto/cipher"
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"os"
 	"path/filepath"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/codenotary/immudb/embedded/ahtree"
@@ -34,6 +38,7 @@ import (
 	"github.com/codenotary/immudb/embedded/appendable/multiapp"
 	"github.com/codenotary/immudb/embedded/appendable/singleapp"
 	"github.com/codenotary/immudb/embedded/cache"
+	"github.com/codenotary/immudb/embedded/fileutil"
 	"github.com/codenotary/immudb/embedded/multierr"
 	"github.com/codenotary/immudb/embedded/tbtree"
 	"github.com/codenotary/immudb/embedded/watchers"
@@ -64,14 +69,29 @@ var ErrNoMoreEntries = tbtree.ErrNoMoreEntries
 var ErrIllegalState = tbtree.ErrIllegalState
 var ErrOffsetOutOfRange = tbtree.ErrOffsetOutOfRange
 var ErrUnexpectedError = errors.New("unexpected error")
+var ErrCancellationRequested = watchers.ErrCancellationRequested
+var ErrCompactionCancelled = tbtree.ErrCompactionCancelled
 
 var ErrSourceTxNewerThanTargetTx = errors.New("source tx is newer than target tx")
 var ErrLinearProofMaxLenExceeded = errors.New("max linear proof length limit exceeded")
+var ErrUnsupportedTxVersion = errors.New("unsupported tx version")
+var ErrValueTruncated = errors.New("value has been truncated")
+var ErrStoreShrunk = errors.New("store has been shrunk, its compacted copy must be used instead")
+var ErrReadOnly = errors.New("store opened in read-only mode")
+var ErrMissingEncryptionKey = errors.New("store is encrypted but no encryption key was provided")
+var ErrInvalidEncryptionKey = errors.New("invalid encryption key")
+var ErrUnencryptedStore = errors.New("encryption key provided but store is not encrypted")
+var ErrLocked = fileutil.ErrLocked
 
 const MaxKeyLen = 1024 // assumed to be not lower than hash size
 
+const MaxTxEntries = math.MaxUint16 // nentries is encoded using two bytes
+
 const MaxParallelIO = 127
 
+// file locked while the store is open for writing
+const lockFilename = "LOCK"
+
 const cLogEntrySize = offsetSize + szSize // tx offset & size
 
 const txIDSize = 8
@@ -89,13 +109,30 @@ const (
 	metaMaxKeyLen    = "MAX_KEY_LEN"
 	metaMaxValueLen  = "MAX_VALUE_LEN"
 	metaFileSize     = "FILE_SIZE"
+
+	metaInlineValueThld = "INLINE_VALUE_THLD"
+
+	metaEncryptionKeyCheck = "ENCRYPTION_KEY_CHECK"
 )
 
 const indexDirname = "index"
 const ahtDirname = "aht"
 
 type ImmuStore struct {
-	path string
+	// values of uncommitted txs, accessed atomically (kept first for 64-bit alignment)
+	orphanedValuesSize  int64
+	reclaimedValuesSize int64
+
+	// reads of the tx log, accessed atomically
+	txCacheHits   uint64
+	txCacheMisses uint64
+
+	path     string
+	txLogDir string
+	vLogDir  string
+
+	// exclusive lock over the store directory, nil in read-only mode
+	lock *fileutil.Flock
 
 	log              logger.Logger
 	lastNotification time.Time
@@ -105,11 +142,17 @@ type ImmuStore struct {
 	vLogUnlockedList *list.List
 	vLogsCond        *sync.Cond
 
+	cipher cipher.AEAD // values are encrypted when set
+
+	compressedValues bool // each value is compressed as a whole thus it can't be read nor written in chunks
+
 	txLog appendable.Appendable
 	cLog  appendable.Appendable
 
 	txLogCache *cache.LRUCache
 
+	commitLatency *latencyHistogram
+
 	committedTxID      uint64
 	committedAlh       [sha256.Size]byte
 	committedTxLogSize int64
@@ -117,12 +160,19 @@ type ImmuStore struct {
 
 	readOnly          bool
 	synced            bool
+	fileMode          os.FileMode
 	maxConcurrency    int
 	maxIOConcurrency  int
 	maxTxEntries      int
 	maxKeyLen         int
 	maxValueLen       int
+	inlineValueThld   int
 	maxLinearProofLen int
+	retentionPeriod   time.Duration
+	vacuumInterval    time.Duration
+	vacuumBatchSize   int
+	timeFunc          TimeFunc
+	onCorruption      CorruptionHandler
 
 	maxTxSize int
 
@@ -131,20 +181,37 @@ type ImmuStore struct {
 
 	_txbs []byte // pre-allocated buffer to support tx serialization
 
-	_kvs []*tbtree.KV //pre-allocated for indexing
-
 	aht      *ahtree.AHtree
 	blBuffer chan ([sha256.Size]byte)
 	blErr    error
+	blActive bool // binary linking goroutine started, it's not yet when opening fails
+
+	timeIndex   appendable.Appendable
+	committedTs int64 // indexed timestamp of the latest committed tx
 
 	wHub *watchers.WatchersHub
 
+	events *eventBus
+
 	indexer *indexer
 
+	namedSnapshots      map[string]uint64 // txID of each named snapshot
+	namedSnapshotsMutex sync.Mutex
+
 	closed bool
+	shrunk bool // commits are not accepted once the store was shrunk
 	done   chan (struct{})
 
 	mutex sync.Mutex
+
+	// commits hold it for reading so values can not be discarded while being committed
+	truncationRWMutex sync.RWMutex
+
+	truncatedTxID   uint64     // values up to this tx were discarded, live ones were relocated
+	truncationMutex sync.Mutex // truncations are made one at a time
+
+	vacuumCancel context.CancelFunc // stops periodic vacuuming, if started
+	vacuumDone   chan struct{}
 }
 
 type refVLog struct {
@@ -153,20 +220,14 @@ type refVLog struct {
 }
 
 type KV struct {
-	Key    []byte
-	Value  []byte
-	Unique bool
+	Key      []byte
+	Metadata *KVMetadata
+	Value    []byte
+	Unique   bool
 }
 
 func (kv *KV) Digest() [sha256.Size]byte {
-	b := make([]byte, len(kv.Key)+sha256.Size)
-
-	copy(b[:], kv.Key)
-
-	hvalue := sha256.Sum256(kv.Value)
-	copy(b[len(kv.Key):], hvalue[:])
-
-	return sha256.Sum256(b)
+	return entryDigest(kv.Key, kv.Metadata, sha256.Sum256(kv.Value))
 }
 
 func Open(path string, opts *Options) (*ImmuStore, error) {
@@ -174,9 +235,16 @@ func Open(path string, opts *Options) (*ImmuStore, error) {
 		return nil, ErrIllegalArguments
 	}
 
+	// paths exceeding the length limit of the platform, if any, are supported
+	path, err := fileutil.LongPath(path)
+	if err != nil {
+		return nil, err
+	}
+
 	finfo, err := os.Stat(path)
 	if err != nil {
-		if os.IsNotExist(err) {
+		// nothing is created in read-only mode, e.g. when opened from a filesystem snapshot
+		if os.IsNotExist(err) && !opts.ReadOnly {
 			err = os.Mkdir(path, opts.FileMode)
 			if err != nil {
 				return nil, err
@@ -188,12 +256,41 @@ func Open(path string, opts *Options) (*ImmuStore, error) {
 		return nil, ErrorPathIsNotADirectory
 	}
 
+	var lock *fileutil.Flock
+
+	// the store can't be written by more than one instance at a time, either from the same process or not
+	if !opts.ReadOnly {
+		lock, err = fileutil.Lock(filepath.Join(path, lockFilename), opts.FileMode)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	st, err := open(path, opts)
+	if err != nil {
+		if lock != nil {
+			lock.Unlock()
+		}
+		return nil, err
+	}
+
+	st.lock = lock
+
+	return st, nil
+}
+
+func open(path string, opts *Options) (*ImmuStore, error) {
 	metadata := appendable.NewMetadata(nil)
 	metadata.PutInt(metaVersion, Version)
 	metadata.PutInt(metaMaxTxEntries, opts.MaxTxEntries)
 	metadata.PutInt(metaMaxKeyLen, opts.MaxKeyLen)
 	metadata.PutInt(metaMaxValueLen, opts.MaxValueLen)
 	metadata.PutInt(metaFileSize, opts.FileSize)
+	metadata.PutInt(metaInlineValueThld, opts.InlineValueThld)
+
+	if opts.EncryptionKey != nil {
+		metadata.Put(metaEncryptionKeyCheck, encryptionKeyCheck(opts.EncryptionKey))
+	}
 
 	appendableOpts := multiapp.DefaultOptions().
 		WithReadOnly(opts.ReadOnly).
@@ -202,10 +299,22 @@ func Open(path string, opts *Options) (*ImmuStore, error) {
 		WithFileMode(opts.FileMode).
 		WithMetadata(metadata.Bytes())
 
+	txLogDir := dirFor(opts.TxLogDir, path)
+	vLogDir := dirFor(opts.ValueLogDir, path)
+
+	if !opts.ReadOnly {
+		for _, dir := range []string{txLogDir, vLogDir} {
+			err := os.MkdirAll(dir, opts.FileMode)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	appendableOpts.WithFileExt("tx")
 	appendableOpts.WithCompressionFormat(appendable.NoCompression)
 	appendableOpts.WithMaxOpenedFiles(opts.TxLogMaxOpenedFiles)
-	txLogPath := filepath.Join(path, "tx")
+	txLogPath := filepath.Join(txLogDir, "tx")
 	txLog, err := multiapp.Open(txLogPath, appendableOpts)
 	if err != nil {
 		return nil, err
@@ -214,7 +323,7 @@ func Open(path string, opts *Options) (*ImmuStore, error) {
 	appendableOpts.WithFileExt("txi")
 	appendableOpts.WithCompressionFormat(appendable.NoCompression)
 	appendableOpts.WithMaxOpenedFiles(opts.CommitLogMaxOpenedFiles)
-	cLogPath := filepath.Join(path, "commit")
+	cLogPath := filepath.Join(txLogDir, "commit")
 	cLog, err := multiapp.Open(cLogPath, appendableOpts)
 	if err != nil {
 		return nil, err
@@ -227,7 +336,7 @@ func Open(path string, opts *Options) (*ImmuStore, error) {
 		appendableOpts.WithCompressionFormat(opts.CompressionFormat)
 		appendableOpts.WithCompresionLevel(opts.CompressionLevel)
 		appendableOpts.WithMaxOpenedFiles(opts.VLogMaxOpenedFiles)
-		vLogPath := filepath.Join(path, fmt.Sprintf("val_%d", i))
+		vLogPath := filepath.Join(vLogDir, fmt.Sprintf("val_%d", i))
 		vLog, err := multiapp.Open(vLogPath, appendableOpts)
 		if err != nil {
 			return nil, err
@@ -265,13 +374,45 @@ func OpenWith(path string, vLogs []appendable.Appendable, txLog, cLog appendable
 		return nil, ErrCorruptedCLog
 	}
 
+	// stores created before values could be inlined never inline them
+	inlineValueThld, _ := metadata.GetInt(metaInlineValueThld)
+
+	var valueCipher cipher.AEAD
+
+	keyCheck, encrypted := metadata.Get(metaEncryptionKeyCheck)
+
+	if encrypted && opts.EncryptionKey == nil {
+		return nil, ErrMissingEncryptionKey
+	}
+
+	if !encrypted && opts.EncryptionKey != nil {
+		return nil, ErrUnencryptedStore
+	}
+
+	if encrypted {
+		if !bytes.Equal(keyCheck, encryptionKeyCheck(opts.EncryptionKey)) {
+			return nil, ErrInvalidEncryptionKey
+		}
+
+		c, err := newValueCipher(opts.EncryptionKey)
+		if err != nil {
+			return nil, err
+		}
+
+		valueCipher = c
+	}
+
 	cLogSize, err := cLog.Size()
 	if err != nil {
 		return nil, err
 	}
 
 	if cLogSize%cLogEntrySize > 0 {
-		return nil, ErrCorruptedCLog
+		return nil, &LogError{
+			Path:   filepath.Join(dirFor(opts.TxLogDir, path), "commit"),
+			Offset: cLogSize - cLogSize%cLogEntrySize,
+			Err:    ErrCorruptedCLog,
+		}
 	}
 
 	var committedTxLogSize int64
@@ -298,14 +439,23 @@ func OpenWith(path string, vLogs []appendable.Appendable, txLog, cLog appendable
 	}
 
 	if txLogFileSize < committedTxLogSize {
-		return nil, ErrorCorruptedTxData
+		return nil, &LogError{
+			Path:   filepath.Join(dirFor(opts.TxLogDir, path), "tx"),
+			Offset: txLogFileSize,
+			Err:    &TxError{TxID: committedTxID, Err: ErrorCorruptedTxData},
+		}
+	}
+
+	maxInlineValueLen := inlineValueThld
+	if inlineValueThld > 0 && valueCipher != nil {
+		maxInlineValueLen += valueCipher.NonceSize() + valueCipher.Overhead()
 	}
 
-	maxTxSize := maxTxSize(maxTxEntries, maxKeyLen)
+	maxTxSize := maxTxSize(maxTxEntries, maxKeyLen, maxInlineValueLen)
 
 	txs := list.New()
 
-	// one extra tx pre-allocation for indexing thread
+	// one extra tx pre-allocation for internal operations
 	for i := 0; i < opts.MaxConcurrency+1; i++ {
 		txs.PushBack(NewTx(maxTxEntries, maxKeyLen))
 	}
@@ -329,9 +479,15 @@ func OpenWith(path string, vLogs []appendable.Appendable, txLog, cLog appendable
 	vLogsMap := make(map[byte]*refVLog, len(vLogs))
 	vLogUnlockedList := list.New()
 
+	compressedValues := false
+
 	for i, vLog := range vLogs {
 		e := vLogUnlockedList.PushBack(byte(i))
 		vLogsMap[byte(i)] = &refVLog{vLog: vLog, unlockedRef: e}
+
+		if c, ok := vLog.(interface{ CompressionFormat() int }); ok && c.CompressionFormat() != appendable.NoCompression {
+			compressedValues = true
+		}
 	}
 
 	ahtPath := filepath.Join(path, ahtDirname)
@@ -347,9 +503,9 @@ func OpenWith(path string, vLogs []appendable.Appendable, txLog, cLog appendable
 		return nil, err
 	}
 
-	kvs := make([]*tbtree.KV, maxTxEntries)
-	for i := range kvs {
-		kvs[i] = &tbtree.KV{K: make([]byte, maxKeyLen), V: make([]byte, sha256.Size+szSize+offsetSize)}
+	timeIndex, err := openTimeIndex(path, fileSize, opts)
+	if err != nil {
+		return nil, err
 	}
 
 	var blBuffer chan ([sha256.Size]byte)
@@ -362,14 +518,34 @@ func OpenWith(path string, vLogs []appendable.Appendable, txLog, cLog appendable
 		return nil, err
 	}
 
+	namedSnapshots, err := readNamedSnapshots(path)
+	if err != nil {
+		return nil, err
+	}
+
+	truncatedTxID, err := readTruncatedTxID(path)
+	if err != nil {
+		return nil, err
+	}
+
+	timeFunc := opts.TimeFunc
+	if timeFunc == nil {
+		timeFunc = time.Now
+	}
+
 	store := &ImmuStore{
 		path:               path,
+		txLogDir:           dirFor(opts.TxLogDir, path),
+		vLogDir:            dirFor(opts.ValueLogDir, path),
 		log:                opts.log,
 		txLog:              txLog,
 		txLogCache:         txLogCache,
+		commitLatency:      newLatencyHistogram(),
 		vLogs:              vLogsMap,
 		vLogUnlockedList:   vLogUnlockedList,
 		vLogsCond:          sync.NewCond(&sync.Mutex{}),
+		cipher:             valueCipher,
+		compressedValues:   compressedValues,
 		cLog:               cLog,
 		committedTxLogSize: committedTxLogSize,
 		committedTxID:      committedTxID,
@@ -377,21 +553,35 @@ func OpenWith(path string, vLogs []appendable.Appendable, txLog, cLog appendable
 
 		readOnly:          opts.ReadOnly,
 		synced:            opts.Synced,
+		fileMode:          opts.FileMode,
 		maxConcurrency:    opts.MaxConcurrency,
 		maxIOConcurrency:  opts.MaxIOConcurrency,
 		maxTxEntries:      maxTxEntries,
 		maxKeyLen:         maxKeyLen,
 		maxValueLen:       maxInt(maxValueLen, opts.MaxValueLen),
+		inlineValueThld:   inlineValueThld,
 		maxLinearProofLen: opts.MaxLinearProofLen,
+		retentionPeriod:   opts.RetentionPeriod,
+		vacuumInterval:    opts.VacuumInterval,
+		vacuumBatchSize:   opts.VacuumBatchSize,
+		timeFunc:          timeFunc,
+		onCorruption:      opts.OnCorruption,
 
 		maxTxSize: maxTxSize,
 
 		aht:      aht,
 		blBuffer: blBuffer,
 
+		timeIndex: timeIndex,
+
+		namedSnapshots: namedSnapshots,
+
+		truncatedTxID: truncatedTxID,
+
 		wHub: watchers.New(0, 1+opts.MaxWaitees),
 
-		_kvs:  kvs,
+		events: newEventBus(),
+
 		_txs:  txs,
 		_txbs: txbs,
 
@@ -415,11 +605,22 @@ func OpenWith(path string, vLogs []appendable.Appendable, txLog, cLog appendable
 		WithMaxNodeSize(opts.IndexOpts.MaxNodeSize).
 		WithRenewSnapRootAfter(opts.IndexOpts.RenewSnapRootAfter).
 		WithCompactionThld(opts.IndexOpts.CompactionThld).
-		WithDelayDuringCompaction(opts.IndexOpts.DelayDuringCompaction)
+		WithDelayDuringCompaction(opts.IndexOpts.DelayDuringCompaction).
+		WithBloomFilterBitsPerKey(opts.IndexOpts.BloomFilterBitsPerKey)
 
-	indexPath := filepath.Join(store.path, indexDirname)
+	indexDir := dirFor(opts.IndexDir, path)
 
-	store.indexer, err = newIndexer(indexPath, store, indexOpts, opts.MaxWaitees)
+	if !opts.ReadOnly {
+		err = os.MkdirAll(indexDir, opts.FileMode)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	indexPath := filepath.Join(indexDir, indexDirname)
+
+	store.indexer, err = newIndexer(indexPath, store, indexOpts, opts.IndexOpts.MaxBulkSize, opts.IndexOpts.Concurrency, opts.MaxWaitees,
+		opts.IndexOpts.ExpressionIndexes, opts.IndexOpts.SecondaryIndexes, opts.IndexOpts.ValuePrefixOffset)
 	if err != nil {
 		return nil, err
 	}
@@ -435,10 +636,25 @@ func OpenWith(path string, vLogs []appendable.Appendable, txLog, cLog appendable
 		return nil, err
 	}
 
+	err = store.syncTimeIndex()
+	if err != nil {
+		store.Close()
+		return nil, err
+	}
+
 	if store.blBuffer != nil {
+		store.blActive = true
 		go store.binaryLinking()
 	}
 
+	if !store.readOnly && store.retentionPeriod > 0 && store.vacuumInterval > 0 {
+		var ctx context.Context
+		ctx, store.vacuumCancel = context.WithCancel(context.Background())
+		store.vacuumDone = make(chan struct{})
+
+		go store.vacuumPeriodically(ctx)
+	}
+
 	return store, nil
 }
 
@@ -474,37 +690,85 @@ func (s *ImmuStore) notify(nType NotificationType, mandatory bool, formattedMess
 	}
 }
 
+// Now returns the current time as provided by the time source of the store, i.e. Options.TimeFunc
+func (s *ImmuStore) Now() time.Time {
+	return s.timeFunc()
+}
+
 func (s *ImmuStore) IndexInfo() uint64 {
 	return s.indexer.Ts()
 }
 
+// IndexingProgress returns the latest indexed and committed transactions together with indexing counters
+func (s *ImmuStore) IndexingProgress() IndexingProgress {
+	progress := s.indexer.Progress()
+	progress.CommittedTxID, _, _ = s.commitState()
+
+	return progress
+}
+
 func (s *ImmuStore) ExistKeyWith(prefix []byte, neq []byte, smaller bool) (bool, error) {
 	return s.indexer.ExistKeyWith(prefix, neq, smaller)
 }
 
+// Get returns the latest value of the key, deleted and expired entries are not visible
 func (s *ImmuStore) Get(key []byte) (value []byte, tx uint64, hc uint64, err error) {
-	indexedVal, tx, hc, err := s.indexer.Get(key)
+	valRef, tx, hc, err := s.GetRef(key)
 	if err != nil {
 		return nil, 0, 0, err
 	}
 
-	valRef, err := s.valueRefFrom(indexedVal)
+	val, err := valRef.Resolve()
 	if err != nil {
 		return nil, 0, 0, err
 	}
 
-	val, err := valRef.Resolve()
+	return val, tx, hc, err
+}
+
+// GetRef returns a reference to the current value of the key, the value is not read
+func (s *ImmuStore) GetRef(key []byte) (valRef *ValueRef, tx uint64, hc uint64, err error) {
+	indexedVal, tx, hc, err := s.indexer.Get(key)
 	if err != nil {
 		return nil, 0, 0, err
 	}
 
-	return val, tx, hc, err
+	valRef, err = s.valueRefFrom(indexedVal)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	if valRef.md.hiddenAt(s.timeFunc()) {
+		return nil, 0, 0, ErrKeyNotFound
+	}
+
+	return valRef, tx, hc, nil
 }
 
 func (s *ImmuStore) History(key []byte, offset uint64, descOrder bool, limit int) (txs []uint64, err error) {
 	return s.indexer.History(key, offset, descOrder, limit)
 }
 
+// HistoryAt returns revisions of the key as Snapshot.HistoryAt does but reading the latest indexed state
+func (s *ImmuStore) HistoryAt(key []byte, atRevision uint64, offset uint64, descOrder bool, limit int) ([]KeyRevision, error) {
+	for {
+		revs, hc, err := historyAt(s.indexer, key, atRevision, offset, descOrder, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		// history is append-only, revisions indexed while reading may only have shifted the offset
+		_, _, currHC, err := s.indexer.Get(key)
+		if err != nil {
+			return nil, err
+		}
+
+		if currHC == hc {
+			return revs, nil
+		}
+	}
+}
+
 func (s *ImmuStore) NewTx() *Tx {
 	return NewTx(s.maxTxEntries, s.maxKeyLen)
 }
@@ -546,6 +810,31 @@ func (s *ImmuStore) binaryLinking() {
 				}
 			}
 		case <-s.done:
+			{
+				s.linkBufferedTxs()
+
+				// closing waits until buffered txs get linked
+				s.done <- struct{}{}
+				return
+			}
+		}
+	}
+}
+
+// linkBufferedTxs links the txs committed but not yet linked when the store gets closed,
+// thus a closed store is up to date e.g. when opened in read-only mode
+func (s *ImmuStore) linkBufferedTxs() {
+	for {
+		select {
+		case alh := <-s.blBuffer:
+			{
+				_, _, err := s.aht.Append(alh[:])
+				if err != nil {
+					s.log.Errorf("Binary linking at '%s' stopped due to error: %v", s.path, err)
+					return
+				}
+			}
+		default:
 			{
 				return
 			}
@@ -578,6 +867,7 @@ func (s *ImmuStore) syncBinaryLinking() error {
 		return nil
 	}
 
+	// in read-only mode txs are only linked in memory
 	s.log.Infof("Syncing Binary Linking at '%s'...", s.path)
 
 	tx, err := s.fetchAllocTx()
@@ -601,7 +891,11 @@ func (s *ImmuStore) syncBinaryLinking() error {
 		}
 
 		alh := tx.Alh
-		s.aht.Append(alh[:])
+
+		_, _, err = s.aht.Append(alh[:])
+		if err != nil {
+			return err
+		}
 	}
 
 	s.log.Infof("Binary Linking up to date at '%s'", s.path)
@@ -609,22 +903,55 @@ func (s *ImmuStore) syncBinaryLinking() error {
 	return nil
 }
 
-func (s *ImmuStore) WaitForIndexingUpto(txID uint64, cancellation <-chan struct{}) error {
-	return s.indexer.WaitForIndexingUpto(txID, cancellation)
+// WaitForIndexingUpto waits until the transaction txID gets indexed,
+// the error of the context is returned if it gets done in the meantime
+func (s *ImmuStore) WaitForIndexingUpto(ctx context.Context, txID uint64) error {
+	err := s.indexer.WaitForIndexingUpto(txID, ctx.Done())
+	if err == watchers.ErrCancellationRequested {
+		return ctx.Err()
+	}
+
+	return err
 }
 
 func (s *ImmuStore) CompactIndex() error {
-	return s.indexer.CompactIndex()
+	return s.CompactIndexWithCancellation(nil)
 }
 
-func maxTxSize(maxTxEntries, maxKeyLen int) int {
+// CompactIndexWithCancellation compacts the index unless cancellation gets closed before the compaction completes.
+// Closing the store also cancels an ongoing compaction. Files of incomplete compactions are removed, while a
+// completed compaction interrupted before replacing the index is applied the next time the store is opened.
+func (s *ImmuStore) CompactIndexWithCancellation(cancellation <-chan struct{}) error {
+	if s.readOnly {
+		return ErrReadOnly
+	}
+
+	err := s.indexer.CompactIndex(cancellation)
+	if err != nil {
+		return err
+	}
+
+	s.publish(EventCompaction, s.indexer.Ts())
+
+	return nil
+}
+
+// IndexCompactionProgress returns the state of the ongoing compaction of the index, if any
+func (s *ImmuStore) IndexCompactionProgress() tbtree.CompactionProgress {
+	return s.indexer.CompactionProgress()
+}
+
+func maxTxSize(maxTxEntries, maxKeyLen, maxInlineValueLen int) int {
 	return txIDSize /*txID*/ +
 		tsSize /*ts*/ +
 		txIDSize /*blTxID*/ +
 		sha256.Size /*blRoot*/ +
 		sha256.Size /*prevAlh*/ +
-		szSize /*|entries|*/ +
-		maxTxEntries*(szSize /*kLen*/ +maxKeyLen /*key*/ +szSize /*vLen*/ +offsetSize /*vOff*/ +sha256.Size /*hValue*/) +
+		txVersionSize /*version*/ +
+		2 /*|entries|*/ +
+		txAttrsLenSize /*attrsLen*/ +
+		MaxTxAttributesLen /*attrs*/ +
+		maxTxEntries*(mdLenSize /*mdLen*/ +MaxKVMetadataLen /*md*/ +szSize /*kLen*/ +maxKeyLen /*key*/ +szSize /*vLen*/ +offsetSize /*vOff*/ +sha256.Size /*hValue*/ +inlineValueLenSize /*inlineValueLen*/ +maxInlineValueLen /*inlineValue*/) +
 		sha256.Size /*eH*/ +
 		sha256.Size /*txH*/
 }
@@ -657,6 +984,11 @@ func (s *ImmuStore) MaxValueLen() int {
 	return s.maxValueLen
 }
 
+// InlineValueThld returns the length up to which values are stored inline within the tx log, set when the store was created
+func (s *ImmuStore) InlineValueThld() int {
+	return s.inlineValueThld
+}
+
 func (s *ImmuStore) MaxLinearProofLen() int {
 	return s.maxLinearProofLen
 }
@@ -684,12 +1016,15 @@ func (s *ImmuStore) releaseAllocTx(tx *Tx) {
 	s._txs.PushBack(tx)
 }
 
+// inlineVLogID is the value log id of values stored inline, their offsets refer to the tx log
+const inlineVLogID byte = 0xff
+
 func encodeOffset(offset int64, vLogID byte) int64 {
 	return int64(vLogID)<<56 | offset
 }
 
 func decodeOffset(offset int64) (byte, int64) {
-	return byte(offset >> 56), offset & ^(0xff << 55)
+	return byte(uint64(offset) >> 56), offset & (1<<56 - 1)
 }
 
 func (s *ImmuStore) fetchAnyVLog() (vLodID byte, vLog appendable.Appendable) {
@@ -742,22 +1077,81 @@ func (s *ImmuStore) releaseVLog(vLogID byte) {
 type appendableResult struct {
 	offsets []int64
 	err     error
+
+	// values stored inline within the tx, as written into the tx log
+	inlineValues [][]byte
+
+	// value log regions holding the appended values
+	regions []valueRegion
+}
+
+type valueRegion struct {
+	vLogID   byte
+	startOff int64
+	endOff   int64
 }
 
 func (s *ImmuStore) appendData(entries []*KV, donec chan<- appendableResult) {
 	offsets := make([]int64, len(entries))
 
+	var inlineValues [][]byte
+	inlinedAll := true
+
+	for i, e := range entries {
+		if len(e.Value) == 0 {
+			continue
+		}
+
+		if len(e.Value) > s.inlineValueThld {
+			inlinedAll = false
+			continue
+		}
+
+		val, err := s.encryptValue(e.Value)
+		if err != nil {
+			donec <- appendableResult{err: err}
+			return
+		}
+
+		if inlineValues == nil {
+			inlineValues = make([][]byte, len(entries))
+		}
+
+		inlineValues[i] = val
+		offsets[i] = encodeOffset(0, inlineVLogID)
+	}
+
+	if inlinedAll {
+		// the value log is neither written nor synced
+		donec <- appendableResult{offsets: offsets, inlineValues: inlineValues}
+		return
+	}
+
 	vLogID, vLog := s.fetchAnyVLog()
 	defer s.releaseVLog(vLogID)
 
+	startOff := vLog.Offset()
+
+	fail := func(err error) {
+		// values partially written are not referenced by any tx, the space is reused
+		vLog.SetOffset(startOff)
+		donec <- appendableResult{err: err}
+	}
+
 	for i := 0; i < len(offsets); i++ {
-		if len(entries[i].Value) == 0 {
+		if len(entries[i].Value) == 0 || len(entries[i].Value) <= s.inlineValueThld {
 			continue
 		}
 
-		voff, _, err := vLog.Append(entries[i].Value)
+		val, err := s.encryptValue(entries[i].Value)
+		if err != nil {
+			fail(err)
+			return
+		}
+
+		voff, _, err := vLog.Append(val)
 		if err != nil {
-			donec <- appendableResult{nil, err}
+			fail(err)
 			return
 		}
 		offsets[i] = encodeOffset(voff, vLogID)
@@ -765,22 +1159,99 @@ func (s *ImmuStore) appendData(entries []*KV, donec chan<- appendableResult) {
 
 	err := vLog.Flush()
 	if err != nil {
-		donec <- appendableResult{nil, err}
+		fail(err)
 		return
 	}
 
 	if s.synced {
 		err = vLog.Sync()
 		if err != nil {
-			donec <- appendableResult{nil, err}
+			fail(err)
 			return
 		}
 	}
 
-	donec <- appendableResult{offsets, nil}
+	donec <- appendableResult{
+		offsets:      offsets,
+		inlineValues: inlineValues,
+		regions:      []valueRegion{{vLogID: vLogID, startOff: startOff, endOff: vLog.Offset()}},
+	}
+}
+
+// reclaimValues makes the space taken by the values appended for a tx which couldn't be committed
+// available for new values. It's only possible while no other values were appended after them,
+// otherwise the orphaned values remain in the value log until it gets truncated.
+// checkClosed must be false if the store mutex is being held by the caller.
+func (s *ImmuStore) reclaimValues(r appendableResult, checkClosed bool) {
+	if r.err != nil {
+		return
+	}
+
+	// latest regions first, so earlier ones may become the last values in the value log
+	for i := len(r.regions) - 1; i >= 0; i-- {
+		s.reclaimRegion(r.regions[i], checkClosed)
+	}
+}
+
+func (s *ImmuStore) reclaimRegion(r valueRegion, checkClosed bool) {
+	if r.startOff == r.endOff {
+		return
+	}
+
+	vLog, err := s.fetchVLog(r.vLogID, checkClosed)
+	if err != nil {
+		return
+	}
+	defer s.releaseVLog(r.vLogID)
+
+	if vLog.Offset() != r.endOff {
+		atomic.AddInt64(&s.orphanedValuesSize, r.endOff-r.startOff)
+		return
+	}
+
+	err = vLog.SetOffset(r.startOff)
+	if err != nil {
+		atomic.AddInt64(&s.orphanedValuesSize, r.endOff-r.startOff)
+		s.log.Warningf("Got '%v' while reclaiming orphaned values at '%s'", err, s.path)
+		return
+	}
+
+	atomic.AddInt64(&s.reclaimedValuesSize, r.endOff-r.startOff)
+}
+
+// OrphanedValuesSize returns the number of bytes taken by values of uncommitted transactions which
+// couldn't be reclaimed since the store was opened, and the number of bytes which were reclaimed
+func (s *ImmuStore) OrphanedValuesSize() (orphaned int64, reclaimed int64) {
+	return atomic.LoadInt64(&s.orphanedValuesSize), atomic.LoadInt64(&s.reclaimedValuesSize)
 }
 
 func (s *ImmuStore) Commit(entries []*KV, waitForIndexing bool) (*TxMetadata, error) {
+	return s.CommitWithPreconditions(entries, nil, waitForIndexing)
+}
+
+// CommitWithPreconditions commits the entries only if all the preconditions are satisfied,
+// otherwise ErrPreconditionFailed is returned and no tx is committed
+func (s *ImmuStore) CommitWithPreconditions(entries []*KV, preconditions []Precondition, waitForIndexing bool) (*TxMetadata, error) {
+	return s.CommitWithAttributes(entries, nil, preconditions, waitForIndexing)
+}
+
+// CommitWithAttributes commits the entries in a tx holding the given attributes,
+// if all the preconditions are satisfied
+func (s *ImmuStore) CommitWithAttributes(entries []*KV, attrs *TxAttributes, preconditions []Precondition, waitForIndexing bool) (*TxMetadata, error) {
+	return s.commitEntries(entries, attrs, preconditions, nil, waitForIndexing)
+}
+
+// commitEntries commits the entries in a new tx, when replicatedTx is provided the new tx must be identical to it
+func (s *ImmuStore) commitEntries(entries []*KV, attrs *TxAttributes, preconditions []Precondition, replicatedTx *Tx, waitForIndexing bool) (*TxMetadata, error) {
+	if s.readOnly {
+		return nil, ErrReadOnly
+	}
+
+	start := time.Now()
+
+	s.truncationRWMutex.RLock()
+	defer s.truncationRWMutex.RUnlock()
+
 	s.mutex.Lock()
 	if s.closed {
 		s.mutex.Unlock()
@@ -793,12 +1264,17 @@ func (s *ImmuStore) Commit(entries []*KV, waitForIndexing bool) (*TxMetadata, er
 		return nil, err
 	}
 
+	err = s.validatePreconditions(preconditions)
+	if err != nil {
+		return nil, err
+	}
+
 	appendableCh := make(chan appendableResult)
 	go s.appendData(entries, appendableCh)
 
 	tx, err := s.fetchAllocTx()
 	if err != nil {
-		<-appendableCh // wait for data to be written
+		s.reclaimValues(<-appendableCh, true) // wait for data to be written
 		return nil, err
 	}
 	defer s.releaseAllocTx(tx)
@@ -808,11 +1284,20 @@ func (s *ImmuStore) Commit(entries []*KV, waitForIndexing bool) (*TxMetadata, er
 	for i, e := range entries {
 		txe := tx.entries[i]
 		txe.setKey(e.Key)
+		txe.md = e.Metadata
 		txe.vLen = len(e.Value)
 		txe.hVal = sha256.Sum256(e.Value)
 		txe.unique = e.Unique
 	}
 
+	tx.Attributes = attrs
+	tx.Version = versionFor(tx.Entries(), attrs)
+
+	if replicatedTx != nil {
+		// txs written with older formats are replicated as they are
+		tx.Version = replicatedTx.Version
+	}
+
 	tx.BuildHashTree()
 
 	r := <-appendableCh // wait for data to be written
@@ -828,7 +1313,7 @@ func (s *ImmuStore) Commit(entries []*KV, waitForIndexing bool) (*TxMetadata, er
 		return nil, ErrAlreadyClosed
 	}
 
-	err = s.commit(tx, r.offsets)
+	err = s.commit(tx, r, preconditions, replicatedTx)
 	if err != nil {
 		s.mutex.Unlock()
 		return nil, err
@@ -836,8 +1321,10 @@ func (s *ImmuStore) Commit(entries []*KV, waitForIndexing bool) (*TxMetadata, er
 
 	s.mutex.Unlock()
 
+	s.commitLatency.observe(time.Since(start))
+
 	if waitForIndexing {
-		err = s.WaitForIndexingUpto(tx.ID, nil)
+		err = s.WaitForIndexingUpto(context.Background(), tx.ID)
 		if err != nil {
 			return tx.Metadata(), err
 		}
@@ -846,18 +1333,113 @@ func (s *ImmuStore) Commit(entries []*KV, waitForIndexing bool) (*TxMetadata, er
 	return tx.Metadata(), nil
 }
 
-func (s *ImmuStore) commit(tx *Tx, offsets []int64) error {
+// SetWithTTL commits a single entry which won't be visible through the index once the ttl has elapsed,
+// the entry is still part of the immutable history
+func (s *ImmuStore) SetWithTTL(key, value []byte, ttl time.Duration, waitForIndexing bool) (*TxMetadata, error) {
+	if ttl <= 0 {
+		return nil, ErrIllegalArguments
+	}
+
+	md := NewKVMetadata().ExpiresAt(s.timeFunc().Add(ttl))
+
+	return s.Commit([]*KV{{Key: key, Metadata: md, Value: value}}, waitForIndexing)
+}
+
+func (s *ImmuStore) commit(tx *Tx, r appendableResult, preconditions []Precondition, replicatedTx *Tx) error {
+	err := s.prepareCommit(tx, r, preconditions, replicatedTx)
+	if err != nil {
+		// the tx was rejected before being written thus its values are not referenced
+		s.reclaimValues(r, false)
+		return err
+	}
+
+	return s.writeTx(tx)
+}
+
+// writeTx appends a prepared tx into the logs and makes it the latest committed one
+func (s *ImmuStore) writeTx(tx *Tx) error {
+	// tx serialization using pre-allocated buffer, prepareCommit already moved the tx log to the end of the latest tx
+	txSize := tx.serializeForLogAt(s._txbs, s.txLog.Offset())
+
+	txbs := make([]byte, txSize)
+	copy(txbs, s._txbs[:txSize])
+
+	txOff, _, err := s.txLog.Append(txbs)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = s.txLogCache.Put(tx.ID, txbs)
+	if err != nil {
+		return err
+	}
+
+	err = s.txLog.Flush()
+	if err != nil {
+		return err
+	}
+
+	err = s.appendTs(tx.Ts)
+	if err != nil {
+		return err
+	}
+
+	err = s.timeIndex.Flush()
+	if err != nil {
+		return err
+	}
+
+	var cb [cLogEntrySize]byte
+	binary.BigEndian.PutUint64(cb[:], uint64(txOff))
+	binary.BigEndian.PutUint32(cb[offsetSize:], uint32(txSize))
+	_, _, err = s.cLog.Append(cb[:])
+	if err != nil {
+		return err
+	}
+
+	err = s.cLog.Flush()
+	if err != nil {
+		return err
+	}
+
+	// binary linking only includes txs written into the commit log, a failed commit can't leave it ahead
+	if s.blBuffer == nil {
+		_, _, err := s.aht.Append(tx.Alh[:])
+		if err != nil {
+			s.blErr = err
+			return err
+		}
+	} else {
+		s.blBuffer <- tx.Alh
+	}
+
+	committedTxID := s.advanceCommitState(tx.Alh, int64(txSize))
+	s.wHub.DoneUpto(committedTxID)
+
+	s.publish(EventCommit, committedTxID)
+
+	return nil
+}
+
+// prepareCommit validates and links the tx to the latest committed one, nothing is written.
+// Replicated txs keep the timestamp and binary linking of the original tx, which is then compared to the new one.
+func (s *ImmuStore) prepareCommit(tx *Tx, r appendableResult, preconditions []Precondition, replicatedTx *Tx) error {
 	if s.blErr != nil {
 		return s.blErr
 	}
 
+	if s.shrunk {
+		return ErrStoreShrunk
+	}
+
 	// will overwrite partially written and uncommitted data
 	committedTxID, committedAlh, committedTxLogSize := s.commitState()
 
 	s.txLog.SetOffset(committedTxLogSize)
+	s.timeIndex.SetOffset(int64(committedTxID) * tsSize)
 
 	tx.ID = committedTxID + 1
-	tx.Ts = time.Now().Unix()
+	tx.Ts = s.timeFunc().Unix()
 
 	blTxID, blRoot, err := s.aht.Root()
 	if err != nil && err != ahtree.ErrEmptyTree {
@@ -873,34 +1455,36 @@ func (s *ImmuStore) commit(tx *Tx, offsets []int64) error {
 
 	tx.PrevAlh = committedAlh
 
-	txSize := 0
-
-	// tx serialization into pre-allocated buffer
-	binary.BigEndian.PutUint64(s._txbs[txSize:], uint64(tx.ID))
-	txSize += txIDSize
-	binary.BigEndian.PutUint64(s._txbs[txSize:], uint64(tx.Ts))
-	txSize += tsSize
-	binary.BigEndian.PutUint64(s._txbs[txSize:], uint64(tx.BlTxID))
-	txSize += txIDSize
-	copy(s._txbs[txSize:], tx.BlRoot[:])
-	txSize += sha256.Size
-	copy(s._txbs[txSize:], tx.PrevAlh[:])
-	txSize += sha256.Size
-	binary.BigEndian.PutUint32(s._txbs[txSize:], uint32(tx.nentries))
-	txSize += szSize
+	if replicatedTx != nil {
+		err = s.linkReplicatedTx(tx, replicatedTx)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = s.checkPreconditions(tx.ID, preconditions)
+	if err != nil {
+		return err
+	}
 
 	for i := 0; i < tx.nentries; i++ {
 		txe := tx.entries[i]
-		txe.vOff = offsets[i]
+		txe.vOff = r.offsets[i]
+		txe.val = nil
+
+		if r.inlineValues != nil {
+			txe.val = r.inlineValues[i]
+		}
 
 		if txe.unique {
 			if tx.ID > 1 {
-				err = s.WaitForIndexingUpto(tx.ID-1, nil)
+				err = s.WaitForIndexingUpto(context.Background(), tx.ID-1)
 				if err != nil {
 					return err
 				}
 
-				_, _, _, err = s.indexer.Get(txe.Key())
+				// deleted and expired keys may be set again
+				_, _, _, err = s.GetRef(txe.Key())
 				if err == nil {
 					return ErrKeyAlreadyExists
 				}
@@ -909,69 +1493,14 @@ func (s *ImmuStore) commit(tx *Tx, offsets []int64) error {
 				}
 			}
 		}
-
-		// tx serialization using pre-allocated buffer
-		binary.BigEndian.PutUint32(s._txbs[txSize:], uint32(txe.kLen))
-		txSize += szSize
-		copy(s._txbs[txSize:], txe.k[:txe.kLen])
-		txSize += txe.kLen
-		binary.BigEndian.PutUint32(s._txbs[txSize:], uint32(txe.vLen))
-		txSize += szSize
-		binary.BigEndian.PutUint64(s._txbs[txSize:], uint64(txe.vOff))
-		txSize += offsetSize
-		copy(s._txbs[txSize:], txe.hVal[:])
-		txSize += sha256.Size
 	}
 
 	tx.CalcAlh()
 
-	// tx serialization using pre-allocated buffer
-	copy(s._txbs[txSize:], tx.Alh[:])
-	txSize += sha256.Size
-
-	txbs := make([]byte, txSize)
-	copy(txbs, s._txbs[:txSize])
-
-	txOff, _, err := s.txLog.Append(txbs)
-	if err != nil {
-		return err
+	if replicatedTx != nil && tx.Alh != replicatedTx.Alh {
+		return fmt.Errorf("%w: tx %d differs from the replicated one", ErrReplicatedTxMismatch, tx.ID)
 	}
 
-	_, _, err = s.txLogCache.Put(tx.ID, txbs)
-	if err != nil {
-		return err
-	}
-
-	err = s.txLog.Flush()
-	if err != nil {
-		return err
-	}
-
-	if s.blBuffer == nil {
-		_, _, err := s.aht.Append(tx.Alh[:])
-		if err != nil {
-			return err
-		}
-	} else {
-		s.blBuffer <- tx.Alh
-	}
-
-	var cb [cLogEntrySize]byte
-	binary.BigEndian.PutUint64(cb[:], uint64(txOff))
-	binary.BigEndian.PutUint32(cb[offsetSize:], uint32(txSize))
-	_, _, err = s.cLog.Append(cb[:])
-	if err != nil {
-		return err
-	}
-
-	err = s.cLog.Flush()
-	if err != nil {
-		return err
-	}
-
-	committedTxID = s.advanceCommitState(tx.Alh, int64(txSize))
-	s.wHub.DoneUpto(committedTxID)
-
 	return nil
 }
 
@@ -994,13 +1523,18 @@ func (s *ImmuStore) commitState() (txID uint64, txAlh [sha256.Size]byte, clogSiz
 }
 
 func (s *ImmuStore) CommitWith(callback func(txID uint64, index KeyIndex) ([]*KV, error), waitForIndexing bool) (*TxMetadata, error) {
-	md, err := s.commitWith(callback)
+	return s.CommitWithCallback(callback, nil, waitForIndexing)
+}
+
+// CommitWithCallback commits the entries returned by the callback in a tx holding the given attributes
+func (s *ImmuStore) CommitWithCallback(callback func(txID uint64, index KeyIndex) ([]*KV, error), attrs *TxAttributes, waitForIndexing bool) (*TxMetadata, error) {
+	md, err := s.commitWith(callback, attrs)
 	if err != nil {
 		return nil, err
 	}
 
 	if waitForIndexing {
-		err = s.WaitForIndexingUpto(md.ID, nil)
+		err = s.WaitForIndexingUpto(context.Background(), md.ID)
 		if err != nil {
 			return md, err
 		}
@@ -1021,21 +1555,55 @@ func (index *unsafeIndex) Get(key []byte) (value []byte, tx uint64, hc uint64, e
 	return index.st.Get(key)
 }
 
-func (s *ImmuStore) commitWith(callback func(txID uint64, index KeyIndex) ([]*KV, error)) (*TxMetadata, error) {
+func (s *ImmuStore) commitWith(callback func(txID uint64, index KeyIndex) ([]*KV, error), attrs *TxAttributes) (*TxMetadata, error) {
+	ptx, err := s.PrepareCommitWith(callback, attrs)
+	if err != nil {
+		return nil, err
+	}
+
+	return ptx.Commit()
+}
+
+// PreparedTx is a tx validated and linked to the latest committed one, whose values were already written.
+// The store remains locked until the tx is either committed or aborted, so that other stores can take part
+// in a two-phase commit.
+type PreparedTx struct {
+	st    *ImmuStore
+	tx    *Tx
+	r     appendableResult
+	start time.Time
+	done  bool
+}
+
+// PrepareCommitWith prepares a tx holding the entries returned by the callback and the given attributes,
+// the store can't commit any other tx until Commit or Abort are called on the prepared one
+func (s *ImmuStore) PrepareCommitWith(callback func(txID uint64, index KeyIndex) ([]*KV, error), attrs *TxAttributes) (ptx *PreparedTx, err error) {
 	if callback == nil {
 		return nil, ErrIllegalArguments
 	}
 
+	if s.readOnly {
+		return nil, ErrReadOnly
+	}
+
+	start := time.Now()
+
+	s.truncationRWMutex.RLock()
 	s.mutex.Lock()
-	defer s.mutex.Unlock()
+	s.indexer.Pause()
+
+	defer func() {
+		if err != nil {
+			s.indexer.Resume()
+			s.mutex.Unlock()
+			s.truncationRWMutex.RUnlock()
+		}
+	}()
 
 	if s.closed {
 		return nil, ErrAlreadyClosed
 	}
 
-	s.indexer.Pause()
-	defer s.indexer.Resume()
-
 	committedTxID, _, _ := s.commitState()
 	txID := committedTxID + 1
 
@@ -1054,35 +1622,96 @@ func (s *ImmuStore) commitWith(callback func(txID uint64, index KeyIndex) ([]*KV
 
 	tx, err := s.fetchAllocTx()
 	if err != nil {
-		<-appendableCh // wait for data to be writen
+		s.reclaimValues(<-appendableCh, false) // wait for data to be writen
 		return nil, err
 	}
-	defer s.releaseAllocTx(tx)
 
 	tx.nentries = len(entries)
 
 	for i, e := range entries {
 		txe := tx.entries[i]
 		txe.setKey(e.Key)
+		txe.md = e.Metadata
 		txe.vLen = len(e.Value)
 		txe.hVal = sha256.Sum256(e.Value)
 		txe.unique = e.Unique
 	}
 
+	tx.Attributes = attrs
+	tx.Version = versionFor(tx.Entries(), attrs)
+
 	tx.BuildHashTree()
 
 	r := <-appendableCh // wait for data to be writen
 	err = r.err
 	if err != nil {
+		s.releaseAllocTx(tx)
 		return nil, err
 	}
 
-	err = s.commit(tx, r.offsets)
+	err = s.prepareCommit(tx, r, nil, nil)
 	if err != nil {
+		// the tx was rejected before being written thus its values are not referenced
+		s.reclaimValues(r, false)
+		s.releaseAllocTx(tx)
 		return nil, err
 	}
 
-	return tx.Metadata(), nil
+	return &PreparedTx{st: s, tx: tx, r: r, start: start}, nil
+}
+
+// TxID returns the id the prepared tx is committed with
+func (ptx *PreparedTx) TxID() uint64 {
+	return ptx.tx.ID
+}
+
+// Eh returns the hash of the entries of the prepared tx, it doesn't depend on when the tx gets committed
+func (ptx *PreparedTx) Eh() [sha256.Size]byte {
+	return ptx.tx.Eh()
+}
+
+// Commit writes the prepared tx and unlocks the store
+func (ptx *PreparedTx) Commit() (*TxMetadata, error) {
+	if ptx.done {
+		return nil, ErrIllegalState
+	}
+
+	defer ptx.release()
+
+	s := ptx.st
+
+	err := s.writeTx(ptx.tx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.commitLatency.observe(time.Since(ptx.start))
+
+	return ptx.tx.Metadata(), nil
+}
+
+// Abort discards the prepared tx and unlocks the store
+func (ptx *PreparedTx) Abort() error {
+	if ptx.done {
+		return ErrIllegalState
+	}
+
+	defer ptx.release()
+
+	ptx.st.reclaimValues(ptx.r, false)
+
+	return nil
+}
+
+func (ptx *PreparedTx) release() {
+	ptx.done = true
+
+	s := ptx.st
+
+	s.releaseAllocTx(ptx.tx)
+	s.indexer.Resume()
+	s.mutex.Unlock()
+	s.truncationRWMutex.RUnlock()
 }
 
 type DualProof struct {
@@ -1123,7 +1752,7 @@ func (s *ImmuStore) DualProof(sourceTx, targetTx *Tx) (proof *DualProof, err err
 	}
 
 	if sourceTx.BlTxID > targetTx.BlTxID {
-		return nil, ErrorCorruptedTxData
+		return nil, s.notifyCorruption("building dual proof", &TxError{TxID: sourceTx.ID, Err: ErrorCorruptedTxData})
 	}
 
 	if sourceTx.BlTxID > 0 {
@@ -1236,7 +1865,7 @@ func (s *ImmuStore) txOffsetAndSize(txID uint64) (int64, int, error) {
 		return 0, 0, ErrTxNotFound
 	}
 	if err == io.EOF && n > 0 {
-		return 0, n, ErrCorruptedCLog
+		return 0, n, s.notifyCorruption("reading commit log", &LogError{Path: filepath.Join(s.txLogDir, "commit"), Offset: int64(off), Err: ErrCorruptedCLog})
 	}
 	if err != nil {
 		return 0, 0, err
@@ -1279,6 +1908,9 @@ func (s *ImmuStore) ReadTx(txID uint64, tx *Tx) error {
 	}
 	if err == cache.ErrKeyNotFound {
 		cacheMiss = true
+		atomic.AddUint64(&s.txCacheMisses, 1)
+	} else {
+		atomic.AddUint64(&s.txCacheHits, 1)
 	}
 
 	txOff, txSize, err := s.txOffsetAndSize(txID)
@@ -1298,7 +1930,14 @@ func (s *ImmuStore) ReadTx(txID uint64, tx *Tx) error {
 
 	err = tx.readFrom(r)
 	if err == io.EOF {
-		return ErrorCorruptedTxData
+		err = ErrorCorruptedTxData
+	}
+	if err == ErrorCorruptedTxData {
+		return s.notifyCorruption("reading tx", &LogError{
+			Path:   filepath.Join(s.txLogDir, "tx"),
+			Offset: txOff,
+			Err:    &TxError{TxID: txID, Err: err},
+		})
 	}
 
 	return err
@@ -1308,7 +1947,7 @@ func (s *ImmuStore) ReadValue(tx *Tx, key []byte) ([]byte, error) {
 	for _, e := range tx.Entries() {
 		if bytes.Equal(e.key(), key) {
 			v := make([]byte, e.vLen)
-			_, err := s.ReadValueAt(v, e.vOff, e.hVal)
+			_, err := s.readEntryValue(v, e)
 			if err != nil {
 				return nil, err
 			}
@@ -1322,23 +1961,74 @@ func (s *ImmuStore) ReadValueAt(b []byte, off int64, hvalue [sha256.Size]byte) (
 	vLogID, offset := decodeOffset(off)
 
 	if vLogID > 0 {
-		vLog, err := s.fetchVLog(vLogID, true)
-		if err != nil {
-			return 0, err
+		vb := b
+		if s.cipher != nil {
+			vb = make([]byte, s.encryptedLen(len(b)))
 		}
-		defer s.releaseVLog(vLogID)
 
-		n, err := vLog.ReadAt(b, offset)
+		n, err := s.readStoredValue(vb, vLogID, offset)
 		if err == multiapp.ErrAlreadyClosed || err == singleapp.ErrAlreadyClosed {
 			return n, ErrAlreadyClosed
 		}
+		if err == multiapp.ErrDiscarded {
+			return n, ErrValueTruncated
+		}
 		if err != nil {
 			return n, err
 		}
+
+		if s.cipher != nil {
+			err = s.decryptValue(b, vb)
+			if err != nil {
+				return len(b), s.notifyCorruption("reading value", s.valueLogError(vLogID, offset, err))
+			}
+		}
 	}
 
 	if hvalue != sha256.Sum256(b) {
-		return len(b), ErrCorruptedData
+		return len(b), s.notifyCorruption("reading value", s.valueLogError(vLogID, offset, ErrCorruptedData))
+	}
+
+	return len(b), nil
+}
+
+// readStoredValue reads the value as stored, either in a value log or inline within the tx log
+func (s *ImmuStore) readStoredValue(vb []byte, vLogID byte, offset int64) (int, error) {
+	if vLogID == inlineVLogID {
+		return s.txLog.ReadAt(vb, offset)
+	}
+
+	vLog, err := s.fetchVLog(vLogID, true)
+	if err != nil {
+		return 0, err
+	}
+	defer s.releaseVLog(vLogID)
+
+	return vLog.ReadAt(vb, offset)
+}
+
+// readEntryValue reads the value of an entry of a tx read from the tx log,
+// values stored inline were already read together with the tx
+func (s *ImmuStore) readEntryValue(b []byte, e *TxEntry) (int, error) {
+	if !e.inlined() || e.val == nil {
+		return s.ReadValueAt(b, e.vOff, e.hVal)
+	}
+
+	_, offset := decodeOffset(e.vOff)
+
+	if s.cipher != nil {
+		err := s.decryptValue(b, e.val)
+		if err != nil {
+			return len(b), s.notifyCorruption("reading value", s.valueLogError(inlineVLogID, offset, err))
+		}
+	} else if len(e.val) != len(b) {
+		return len(b), s.notifyCorruption("reading value", s.valueLogError(inlineVLogID, offset, ErrCorruptedData))
+	} else {
+		copy(b, e.val)
+	}
+
+	if e.hVal != sha256.Sum256(b) {
+		return len(b), s.notifyCorruption("reading value", s.valueLogError(inlineVLogID, offset, ErrCorruptedData))
 	}
 
 	return len(b), nil
@@ -1360,15 +2050,16 @@ func (s *ImmuStore) validateEntries(entries []*KV) error {
 		}
 
 		if len(kv.Key) > s.maxKeyLen {
-			return ErrorMaxKeyLenExceeded
+			return &KeyError{Key: kv.Key, Err: ErrorMaxKeyLenExceeded}
 		}
+
 		if len(kv.Value) > s.maxValueLen {
-			return ErrorMaxValueLenExceeded
+			return &KeyError{Key: kv.Key, Err: ErrorMaxValueLenExceeded}
 		}
 
 		b64k := base64.StdEncoding.EncodeToString(kv.Key)
 		if _, ok := m[b64k]; ok {
-			return ErrDuplicatedKey
+			return &KeyError{Key: kv.Key, Err: ErrDuplicatedKey}
 		}
 		m[b64k] = struct{}{}
 	}
@@ -1403,10 +2094,20 @@ func (s *ImmuStore) Sync() error {
 		return err
 	}
 
+	if s.timeIndex != nil {
+		err = s.timeIndex.Sync()
+		if err != nil {
+			return err
+		}
+	}
+
 	return s.indexer.Sync()
 }
 
 func (s *ImmuStore) Close() error {
+	// an ongoing vacuum may be committing relocated values, it's stopped before the store gets closed
+	s.stopVacuum()
+
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
@@ -1428,14 +2129,16 @@ func (s *ImmuStore) Close() error {
 	}
 	s.vLogsCond.Broadcast()
 
-	if s.blBuffer != nil && s.blErr == nil {
+	if s.blBuffer != nil && s.blActive && s.blErr == nil {
 		s.log.Infof("Stopping Binary Linking at '%s'...", s.path)
 		s.done <- struct{}{}
+		<-s.done
 		s.log.Infof("Binary linking gracefully stopped at '%s'", s.path)
 		close(s.blBuffer)
 	}
 
 	s.wHub.Close()
+	s.events.close()
 
 	iErr := s.indexer.Close()
 	if iErr != nil {
@@ -1457,6 +2160,20 @@ func (s *ImmuStore) Close() error {
 		errors = append(errors, tErr)
 	}
 
+	if s.timeIndex != nil {
+		tiErr := s.timeIndex.Close()
+		if tiErr != nil {
+			errors = append(errors, tiErr)
+		}
+	}
+
+	if s.lock != nil {
+		lErr := s.lock.Unlock()
+		if lErr != nil {
+			errors = append(errors, lErr)
+		}
+	}
+
 	if len(errors) > 0 {
 		return &multierr.MultiErr{Errors: errors}
 	}
@@ -1464,6 +2181,32 @@ func (s *ImmuStore) Close() error {
 	return nil
 }
 
+// valueLogError annotates err with the value log and the offset of the value being read
+func (s *ImmuStore) valueLogError(vLogID byte, offset int64, err error) error {
+	if vLogID == inlineVLogID {
+		return &LogError{
+			Path:   filepath.Join(s.txLogDir, "tx"),
+			Offset: offset,
+			Err:    err,
+		}
+	}
+
+	return &LogError{
+		Path:   filepath.Join(s.vLogDir, fmt.Sprintf("val_%d", vLogID-1)),
+		Offset: offset,
+		Err:    err,
+	}
+}
+
+// notifyCorruption reports the inconsistency to the corruption handler, if any, and returns the error as is
+func (s *ImmuStore) notifyCorruption(op string, err error) error {
+	if s.onCorruption != nil {
+		s.onCorruption(op, err)
+	}
+
+	return err
+}
+
 func (s *ImmuStore) wrapAppendableErr(err error, action string) error {
 	if err == singleapp.ErrAlreadyClosed || err == multiapp.ErrAlreadyClosed {
 		s.log.Warningf("Got '%v' while '%s'", err, action)
@@ -17,9 +17,11 @@ package store
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"math/rand"
 	"os"
 	"path/filepath"
@@ -190,6 +192,30 @@ func TestImmudbStoreOpenWithInvalidPath(t *testing.T) {
 	require.Equal(t, ErrorPathIsNotADirectory, err)
 }
 
+func TestImmudbStoreLocked(t *testing.T) {
+	immuStore, err := Open("locked_store", DefaultOptions())
+	require.NoError(t, err)
+	defer os.RemoveAll("locked_store")
+
+	_, err = Open("locked_store", DefaultOptions())
+	require.True(t, errors.Is(err, ErrLocked))
+
+	roStore, err := Open("locked_store", DefaultOptions().WithReadOnly(true))
+	require.NoError(t, err)
+
+	err = roStore.Close()
+	require.NoError(t, err)
+
+	err = immuStore.Close()
+	require.NoError(t, err)
+
+	immuStore, err = Open("locked_store", DefaultOptions())
+	require.NoError(t, err)
+
+	err = immuStore.Close()
+	require.NoError(t, err)
+}
+
 func TestImmudbStoreOnClosedStore(t *testing.T) {
 	immuStore, err := Open("closed_store", DefaultOptions().WithMaxConcurrency(1))
 	require.NoError(t, err)
@@ -405,11 +431,15 @@ func TestImmudbStoreEdgeCases(t *testing.T) {
 
 	entry = &KV{Key: make([]byte, immuStore.maxKeyLen+1), Value: make([]byte, 1)}
 	err = immuStore.validateEntries([]*KV{entry})
-	require.Equal(t, ErrorMaxKeyLenExceeded, err)
+	require.True(t, errors.Is(err, ErrorMaxKeyLenExceeded))
 
 	entry = &KV{Key: make([]byte, 1), Value: make([]byte, immuStore.maxValueLen+1)}
 	err = immuStore.validateEntries([]*KV{entry})
-	require.Equal(t, ErrorMaxValueLenExceeded, err)
+	require.True(t, errors.Is(err, ErrorMaxValueLenExceeded))
+
+	var keyErr *KeyError
+	require.True(t, errors.As(err, &keyErr))
+	require.Equal(t, entry.Key, keyErr.Key)
 }
 
 func TestImmudbSetBlErr(t *testing.T) {
@@ -452,7 +482,7 @@ func TestImmudbStoreIndexing(t *testing.T) {
 		{Key: []byte("key"), Value: []byte("value")},
 		{Key: []byte("key"), Value: []byte("value")},
 	}, false)
-	require.Equal(t, ErrDuplicatedKey, err)
+	require.True(t, errors.Is(err, ErrDuplicatedKey))
 
 	txCount := 1000
 	eCount := 10
@@ -579,7 +609,223 @@ func TestImmudbStoreUniqueCommit(t *testing.T) {
 	require.NoError(t, err)
 
 	_, err = immuStore.Commit([]*KV{{Key: []byte{1, 0, 0}, Value: []byte{0, 0, 1}, Unique: true}, {Key: []byte{1, 0, 0}, Value: []byte{0, 1, 1}, Unique: true}}, false)
-	require.Equal(t, ErrDuplicatedKey, err)
+	require.True(t, errors.Is(err, ErrDuplicatedKey))
+}
+
+func TestImmudbStoreTimeFunc(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+
+	opts := DefaultOptions().WithSynced(false).WithTimeFunc(func() time.Time { return now })
+	immuStore, err := Open("data_time_func", opts)
+	require.NoError(t, err)
+	defer os.RemoveAll("data_time_func")
+	defer immuStore.Close()
+
+	require.Equal(t, now, immuStore.Now())
+
+	md, err := immuStore.SetWithTTL([]byte("key1"), []byte("value1"), time.Hour, true)
+	require.NoError(t, err)
+	require.Equal(t, now.Unix(), md.Ts)
+
+	_, _, _, err = immuStore.Get([]byte("key1"))
+	require.NoError(t, err)
+
+	// expiration is evaluated with the time source of the store
+	now = now.Add(2 * time.Hour)
+
+	_, _, _, err = immuStore.Get([]byte("key1"))
+	require.Equal(t, ErrKeyNotFound, err)
+
+	md, err = immuStore.Commit([]*KV{{Key: []byte("key2"), Value: []byte("value2")}}, true)
+	require.NoError(t, err)
+	require.Equal(t, now.Unix(), md.Ts)
+
+	// a clock going backwards doesn't make the time index go backwards
+	now = now.Add(-24 * time.Hour)
+
+	md, err = immuStore.Commit([]*KV{{Key: []byte("key3"), Value: []byte("value3")}}, true)
+	require.NoError(t, err)
+	require.Equal(t, now.Unix(), md.Ts)
+
+	_, err = immuStore.LastTxUntil(now)
+	require.Equal(t, ErrTxNotFound, err)
+}
+
+func TestImmudbStoreExpiration(t *testing.T) {
+	opts := DefaultOptions().WithSynced(false).WithMaxConcurrency(1)
+	immuStore, err := Open("data_expiration", opts)
+	require.NoError(t, err)
+	defer os.RemoveAll("data_expiration")
+
+	_, err = immuStore.SetWithTTL([]byte("key1"), []byte("value1"), 0, true)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	md, err := immuStore.SetWithTTL([]byte("key1"), []byte("value1"), time.Hour, true)
+	require.NoError(t, err)
+	require.Equal(t, TxVersion1, md.Version)
+
+	val, _, _, err := immuStore.Get([]byte("key1"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1"), val)
+
+	expiredMd := NewKVMetadata().ExpiresAt(time.Now().Add(-1 * time.Hour))
+
+	md, err = immuStore.Commit([]*KV{
+		{Key: []byte("key1"), Metadata: expiredMd, Value: []byte("value2")},
+		{Key: []byte("key2"), Value: []byte("value2")},
+	}, true)
+	require.NoError(t, err)
+
+	_, _, _, err = immuStore.Get([]byte("key1"))
+	require.Equal(t, ErrKeyNotFound, err)
+
+	snap, err := immuStore.Snapshot()
+	require.NoError(t, err)
+
+	_, _, _, err = snap.Get([]byte("key1"))
+	require.Equal(t, ErrKeyNotFound, err)
+
+	reader, err := snap.NewKeyReader(&KeyReaderSpec{Prefix: []byte("key")})
+	require.NoError(t, err)
+
+	k, _, _, _, err := reader.Read()
+	require.NoError(t, err)
+	require.Equal(t, []byte("key2"), k)
+
+	_, _, _, _, err = reader.Read()
+	require.Equal(t, ErrNoMoreEntries, err)
+
+	require.NoError(t, reader.Close())
+	require.NoError(t, snap.Close())
+
+	// expired entries are still part of the immutable history
+	txs, err := immuStore.History([]byte("key1"), 0, false, 10)
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+
+	tx := immuStore.NewTx()
+
+	err = immuStore.ReadTx(md.ID, tx)
+	require.NoError(t, err)
+	require.Equal(t, md.Alh(), tx.Alh)
+	require.True(t, tx.Entries()[0].Metadata().Equal(expiredMd))
+	require.Nil(t, tx.Entries()[1].Metadata())
+
+	val, err = immuStore.ReadValue(tx, []byte("key1"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value2"), val)
+
+	proof, err := tx.Proof([]byte("key1"))
+	require.NoError(t, err)
+	require.True(t, VerifyInclusion(proof, &KV{Key: []byte("key1"), Metadata: expiredMd, Value: []byte("value2")}, tx.Eh()))
+	require.False(t, VerifyInclusion(proof, &KV{Key: []byte("key1"), Value: []byte("value2")}, tx.Eh()))
+
+	err = immuStore.Close()
+	require.NoError(t, err)
+
+	immuStore, err = Open("data_expiration", opts)
+	require.NoError(t, err)
+
+	_, _, _, err = immuStore.Get([]byte("key1"))
+	require.Equal(t, ErrKeyNotFound, err)
+
+	err = immuStore.Close()
+	require.NoError(t, err)
+}
+
+func TestImmudbStoreDeletion(t *testing.T) {
+	opts := DefaultOptions().WithSynced(false).WithMaxConcurrency(1)
+	immuStore, err := Open("data_deletion", opts)
+	require.NoError(t, err)
+	defer os.RemoveAll("data_deletion")
+
+	defer immuStore.Close()
+
+	_, err = immuStore.Commit([]*KV{
+		{Key: []byte("key1"), Value: []byte("value1")},
+		{Key: []byte("key2"), Value: []byte("value2")},
+	}, true)
+	require.NoError(t, err)
+
+	deletedMd := NewKVMetadata().AsDeleted(true)
+
+	md, err := immuStore.Commit([]*KV{{Key: []byte("key1"), Metadata: deletedMd}}, true)
+	require.NoError(t, err)
+
+	_, _, _, err = immuStore.Get([]byte("key1"))
+	require.Equal(t, ErrKeyNotFound, err)
+
+	snap, err := immuStore.Snapshot()
+	require.NoError(t, err)
+
+	_, _, _, err = snap.Get([]byte("key1"))
+	require.Equal(t, ErrKeyNotFound, err)
+
+	reader, err := snap.NewKeyReader(&KeyReaderSpec{Prefix: []byte("key")})
+	require.NoError(t, err)
+
+	k, _, _, _, err := reader.Read()
+	require.NoError(t, err)
+	require.Equal(t, []byte("key2"), k)
+
+	_, _, _, _, err = reader.Read()
+	require.Equal(t, ErrNoMoreEntries, err)
+
+	require.NoError(t, reader.Close())
+
+	reader, err = snap.NewKeyReader(&KeyReaderSpec{Prefix: []byte("key"), IncludeDeleted: true})
+	require.NoError(t, err)
+
+	k, valRef, _, _, err := reader.Read()
+	require.NoError(t, err)
+	require.Equal(t, []byte("key1"), k)
+	require.True(t, valRef.KVMetadata().Deleted())
+
+	k, valRef, _, _, err = reader.Read()
+	require.NoError(t, err)
+	require.Equal(t, []byte("key2"), k)
+	require.False(t, valRef.KVMetadata().Deleted())
+
+	_, _, _, _, err = reader.Read()
+	require.Equal(t, ErrNoMoreEntries, err)
+
+	require.NoError(t, reader.Close())
+
+	// previous values are read as before the deletion
+	reader, err = snap.NewKeyReader(&KeyReaderSpec{Prefix: []byte("key1")})
+	require.NoError(t, err)
+
+	_, valRef, _, err = reader.ReadAsBefore(md.ID)
+	require.NoError(t, err)
+	require.False(t, valRef.KVMetadata().Deleted())
+
+	val, err := valRef.Resolve()
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1"), val)
+
+	require.NoError(t, reader.Close())
+	require.NoError(t, snap.Close())
+
+	txs, err := immuStore.History([]byte("key1"), 0, false, 10)
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+
+	tx := immuStore.NewTx()
+
+	err = immuStore.ReadTx(md.ID, tx)
+	require.NoError(t, err)
+	require.True(t, tx.Entries()[0].Metadata().Deleted())
+
+	// deleted keys can be set again even when they must be unique
+	_, err = immuStore.Commit([]*KV{{Key: []byte("key2"), Value: []byte("value3"), Unique: true}}, true)
+	require.Equal(t, ErrKeyAlreadyExists, err)
+
+	_, err = immuStore.Commit([]*KV{{Key: []byte("key1"), Value: []byte("value3"), Unique: true}}, true)
+	require.NoError(t, err)
+
+	val, _, _, err = immuStore.Get([]byte("key1"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value3"), val)
 }
 
 func TestImmudbStoreCommitWith(t *testing.T) {
@@ -643,7 +889,7 @@ func TestImmudbStoreHistoricalValues(t *testing.T) {
 		{Key: []byte("key"), Value: []byte("value")},
 		{Key: []byte("key"), Value: []byte("value")},
 	}, false)
-	require.Equal(t, ErrDuplicatedKey, err)
+	require.True(t, errors.Is(err, ErrDuplicatedKey))
 
 	for i := 0; i < txCount; i++ {
 		kvs := make([]*KV, eCount)
@@ -863,7 +1109,7 @@ func TestLeavesMatchesAHTSync(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, uint64(i+1), txMetadata.ID)
 
-		immuStore.WaitForIndexingUpto(txMetadata.ID, nil)
+		immuStore.WaitForIndexingUpto(context.Background(), txMetadata.ID)
 
 		exists, err := immuStore.ExistKeyWith(kvs[0].Key, nil, false)
 		require.NoError(t, err)
@@ -1211,6 +1457,178 @@ func TestReOpenningImmudbStore(t *testing.T) {
 	}
 }
 
+// dirState returns the size and modification time of every file and directory under the given path
+func dirState(t *testing.T, path string) map[string]string {
+	state := make(map[string]string)
+
+	err := filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		state[p] = fmt.Sprintf("%v %d %d", info.Mode(), info.Size(), info.ModTime().UnixNano())
+		return nil
+	})
+	require.NoError(t, err)
+
+	return state
+}
+
+// copyTree copies the files under src as a filesystem snapshot would do
+func copyTree(t *testing.T, src, dst string) {
+	err := filepath.Walk(src, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			return os.MkdirAll(filepath.Join(dst, rel), info.Mode())
+		}
+
+		b, err := ioutil.ReadFile(p)
+		if err != nil {
+			return err
+		}
+
+		return ioutil.WriteFile(filepath.Join(dst, rel), b, info.Mode())
+	})
+	require.NoError(t, err)
+}
+
+func TestImmudbStoreReadOnly(t *testing.T) {
+	dir := "data_read_only"
+	defer os.RemoveAll(dir)
+
+	_, err := Open(dir, DefaultOptions().WithReadOnly(true))
+	require.True(t, os.IsNotExist(err))
+	require.NoDirExists(t, dir)
+
+	txCount := 50
+
+	immuStore, err := Open(dir, DefaultOptions().WithSynced(false))
+	require.NoError(t, err)
+
+	for i := 0; i < txCount; i++ {
+		_, err = immuStore.Commit([]*KV{{Key: []byte(fmt.Sprintf("key%d", i)), Value: []byte(fmt.Sprintf("value%d", i))}}, false)
+		require.NoError(t, err)
+	}
+
+	err = immuStore.Close()
+	require.NoError(t, err)
+
+	t.Run("nothing should be written when opened in read-only mode", func(t *testing.T) {
+		state := dirState(t, dir)
+
+		immuStore, err := Open(dir, DefaultOptions().WithReadOnly(true))
+		require.NoError(t, err)
+
+		// txs buffered for binary linking get linked when the store gets closed
+		blTxID, err := immuStore.BlInfo()
+		require.NoError(t, err)
+		require.Equal(t, uint64(txCount), blTxID)
+
+		err = immuStore.WaitForIndexingUpto(context.Background(), uint64(txCount))
+		require.NoError(t, err)
+
+		val, _, _, err := immuStore.Get([]byte("key7"))
+		require.NoError(t, err)
+		require.Equal(t, []byte("value7"), val)
+
+		_, err = immuStore.Commit([]*KV{{Key: []byte("key"), Value: []byte("value")}}, false)
+		require.Equal(t, ErrReadOnly, err)
+
+		_, err = immuStore.CommitWith(func(txID uint64, index KeyIndex) ([]*KV, error) {
+			return []*KV{{Key: []byte("key"), Value: []byte("value")}}, nil
+		}, false)
+		require.Equal(t, ErrReadOnly, err)
+
+		err = immuStore.CompactIndex()
+		require.Equal(t, ErrReadOnly, err)
+
+		err = immuStore.Close()
+		require.NoError(t, err)
+
+		require.Equal(t, state, dirState(t, dir))
+	})
+
+	t.Run("txs not yet indexed nor linked should be processed in memory when opened in read-only mode", func(t *testing.T) {
+		liveDir := "data_read_only_live"
+		defer os.RemoveAll(liveDir)
+
+		snapDir := "data_read_only_snap"
+		defer os.RemoveAll(snapDir)
+
+		opts := DefaultOptions().WithSynced(false).WithIndexOptions(DefaultIndexOptions().WithFlushThld(10 * txCount))
+
+		immuStore, err := Open(liveDir, opts)
+		require.NoError(t, err)
+
+		for i := 0; i < txCount; i++ {
+			_, err = immuStore.Commit([]*KV{{Key: []byte(fmt.Sprintf("key%d", i)), Value: []byte(fmt.Sprintf("value%d", i))}}, true)
+			require.NoError(t, err)
+		}
+
+		err = immuStore.Sync()
+		require.NoError(t, err)
+
+		// the index and the binary linking are copied without the latest txs, as in a filesystem snapshot
+		copyTree(t, liveDir, snapDir)
+
+		err = immuStore.Close()
+		require.NoError(t, err)
+
+		state := dirState(t, snapDir)
+
+		immuStore, err = Open(snapDir, opts.WithReadOnly(true))
+		require.NoError(t, err)
+
+		blTxID, err := immuStore.BlInfo()
+		require.NoError(t, err)
+		require.Equal(t, uint64(txCount), blTxID)
+
+		sourceTx := immuStore.NewTx()
+		targetTx := immuStore.NewTx()
+
+		err = immuStore.ReadTx(1, sourceTx)
+		require.NoError(t, err)
+
+		err = immuStore.ReadTx(uint64(txCount), targetTx)
+		require.NoError(t, err)
+
+		dproof, err := immuStore.DualProof(sourceTx, targetTx)
+		require.NoError(t, err)
+		require.True(t, VerifyDualProof(dproof, 1, uint64(txCount), sourceTx.Alh, targetTx.Alh))
+
+		err = immuStore.WaitForIndexingUpto(context.Background(), uint64(txCount))
+		require.NoError(t, err)
+
+		for _, i := range []int{0, txCount - 1} {
+			val, _, _, err := immuStore.Get([]byte(fmt.Sprintf("key%d", i)))
+			require.NoError(t, err)
+			require.Equal(t, []byte(fmt.Sprintf("value%d", i)), val)
+		}
+
+		snap, err := immuStore.Snapshot()
+		require.NoError(t, err)
+
+		_, _, _, err = snap.Get([]byte(fmt.Sprintf("key%d", txCount-1)))
+		require.NoError(t, err)
+
+		err = snap.Close()
+		require.NoError(t, err)
+
+		err = immuStore.Close()
+		require.NoError(t, err)
+
+		require.Equal(t, state, dirState(t, snapDir))
+	})
+}
+
 func TestReOpenningWithCompressionEnabledImmudbStore(t *testing.T) {
 	defer os.RemoveAll("data_compression")
 
@@ -1441,3 +1859,387 @@ func BenchmarkAppend(b *testing.B) {
 		}
 	}
 }
+
+func TestImmudbStoreWithBloomFilter(t *testing.T) {
+	opts := DefaultOptions().WithSynced(false).WithMaxConcurrency(1)
+	opts.WithIndexOptions(opts.IndexOpts.WithFlushThld(10).WithCompactionThld(1).WithBloomFilterBitsPerKey(10))
+
+	immuStore, err := Open("data_bloom", opts)
+	require.NoError(t, err)
+	defer os.RemoveAll("data_bloom")
+
+	txCount := 10
+
+	for i := 0; i < txCount; i++ {
+		_, err := immuStore.Commit([]*KV{{Key: []byte(fmt.Sprintf("key%d", i)), Value: []byte("value")}}, true)
+		require.NoError(t, err)
+	}
+
+	checkKeys := func() {
+		for i := 0; i < txCount; i++ {
+			_, tx, _, err := immuStore.Get([]byte(fmt.Sprintf("key%d", i)))
+			require.NoError(t, err)
+			require.Equal(t, uint64(i+1), tx)
+		}
+
+		_, _, _, err = immuStore.Get([]byte("missing-key"))
+		require.Equal(t, ErrKeyNotFound, err)
+	}
+
+	checkKeys()
+
+	err = immuStore.CompactIndex()
+	require.NoError(t, err)
+
+	checkKeys()
+
+	err = immuStore.Close()
+	require.NoError(t, err)
+
+	immuStore, err = Open("data_bloom", opts)
+	require.NoError(t, err)
+
+	checkKeys()
+
+	err = immuStore.Close()
+	require.NoError(t, err)
+}
+
+func TestImmudbStoreReclaimOrphanedValues(t *testing.T) {
+	immuStore, err := Open("data_reclaim_values", DefaultOptions().WithSynced(false).WithMaxIOConcurrency(1))
+	require.NoError(t, err)
+	defer os.RemoveAll("data_reclaim_values")
+
+	_, err = immuStore.Commit([]*KV{{Key: []byte("key1"), Value: []byte("value1")}}, false)
+	require.NoError(t, err)
+
+	largeValue := make([]byte, 1024)
+	rand.Read(largeValue)
+
+	_, err = immuStore.CommitWithPreconditions(
+		[]*KV{{Key: []byte("key2"), Value: largeValue}},
+		[]Precondition{&PreconditionKeyMustHaveRevision{Key: []byte("key2"), Revision: 1}},
+		false,
+	)
+	require.True(t, errors.Is(err, ErrPreconditionFailed))
+
+	orphaned, reclaimed := immuStore.OrphanedValuesSize()
+	require.Zero(t, orphaned)
+	require.Equal(t, int64(len(largeValue)), reclaimed)
+
+	_, err = immuStore.Commit([]*KV{{Key: []byte("key2"), Value: largeValue, Unique: true}}, false)
+	require.NoError(t, err)
+
+	_, err = immuStore.Commit([]*KV{{Key: []byte("key2"), Value: largeValue, Unique: true}}, false)
+	require.Equal(t, ErrKeyAlreadyExists, err)
+
+	orphaned, reclaimed = immuStore.OrphanedValuesSize()
+	require.Zero(t, orphaned)
+	require.Equal(t, int64(2*len(largeValue)), reclaimed)
+
+	_, err = immuStore.Commit([]*KV{{Key: []byte("key3"), Value: []byte("value3")}}, false)
+	require.NoError(t, err)
+
+	vLog, err := immuStore.fetchVLog(1, true)
+	require.NoError(t, err)
+	require.Equal(t, int64(len("value1")+len(largeValue)+len("value3")), vLog.Offset())
+	immuStore.releaseVLog(1)
+
+	err = immuStore.WaitForIndexingUpto(context.Background(), 3)
+	require.NoError(t, err)
+
+	for _, kv := range []*KV{
+		{Key: []byte("key1"), Value: []byte("value1")},
+		{Key: []byte("key2"), Value: largeValue},
+		{Key: []byte("key3"), Value: []byte("value3")},
+	} {
+		_, tx, _, err := immuStore.Get(kv.Key)
+		require.NoError(t, err)
+
+		txHolder := immuStore.NewTx()
+		err = immuStore.ReadTx(tx, txHolder)
+		require.NoError(t, err)
+
+		val, err := immuStore.ReadValue(txHolder, kv.Key)
+		require.NoError(t, err)
+		require.Equal(t, kv.Value, val)
+	}
+
+	err = immuStore.Close()
+	require.NoError(t, err)
+}
+
+func TestImmudbStoreCompactIndexCancellation(t *testing.T) {
+	opts := DefaultOptions().WithSynced(false).WithMaxConcurrency(1)
+	opts.WithIndexOptions(opts.IndexOpts.WithFlushThld(1).WithCompactionThld(1))
+
+	immuStore, err := Open("data_compaction_cancel", opts)
+	require.NoError(t, err)
+	defer os.RemoveAll("data_compaction_cancel")
+
+	txCount := 10
+
+	for i := 0; i < txCount; i++ {
+		_, err := immuStore.Commit([]*KV{{Key: []byte(fmt.Sprintf("key%d", i)), Value: []byte("value")}}, true)
+		require.NoError(t, err)
+	}
+
+	cancellation := make(chan struct{})
+	close(cancellation)
+
+	err = immuStore.CompactIndexWithCancellation(cancellation)
+	require.Equal(t, ErrCompactionCancelled, err)
+	require.False(t, immuStore.Metrics().IndexCompactionInProgress)
+
+	leftovers, err := filepath.Glob(filepath.Join("data_compaction_cancel", indexDirname, "*_*"))
+	require.NoError(t, err)
+	require.Empty(t, leftovers)
+
+	err = immuStore.CompactIndex()
+	require.NoError(t, err)
+
+	for i := 0; i < txCount; i++ {
+		_, tx, _, err := immuStore.Get([]byte(fmt.Sprintf("key%d", i)))
+		require.NoError(t, err)
+		require.Equal(t, uint64(i+1), tx)
+	}
+
+	err = immuStore.Close()
+	require.NoError(t, err)
+}
+
+func TestImmudbStorePreparedTx(t *testing.T) {
+	immuStore, err := Open("data_prepared_tx", DefaultOptions().WithSynced(false))
+	require.NoError(t, err)
+	defer os.RemoveAll("data_prepared_tx")
+
+	_, err = immuStore.PrepareCommitWith(nil, nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = immuStore.PrepareCommitWith(func(txID uint64, index KeyIndex) ([]*KV, error) {
+		return nil, nil
+	}, nil)
+	require.Equal(t, ErrorNoEntriesProvided, err)
+
+	ptx, err := immuStore.PrepareCommitWith(func(txID uint64, index KeyIndex) ([]*KV, error) {
+		return []*KV{{Key: []byte("key1"), Value: []byte("aborted")}}, nil
+	}, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), ptx.TxID())
+
+	err = ptx.Abort()
+	require.NoError(t, err)
+
+	err = ptx.Abort()
+	require.Equal(t, ErrIllegalState, err)
+
+	_, err = ptx.Commit()
+	require.Equal(t, ErrIllegalState, err)
+
+	require.Equal(t, uint64(0), immuStore.TxCount())
+
+	ptx, err = immuStore.PrepareCommitWith(func(txID uint64, index KeyIndex) ([]*KV, error) {
+		return []*KV{{Key: []byte("key1"), Value: []byte("committed")}}, nil
+	}, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), ptx.TxID())
+
+	committed := make(chan struct{})
+
+	go func() {
+		// the store remains locked until the prepared tx is committed
+		_, err := immuStore.Commit([]*KV{{Key: []byte("key2"), Value: []byte("value2")}}, false)
+		require.NoError(t, err)
+		close(committed)
+	}()
+
+	eh := ptx.Eh()
+
+	txmd, err := ptx.Commit()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), txmd.ID)
+	require.Equal(t, eh, txmd.Eh)
+
+	<-committed
+
+	err = immuStore.WaitForIndexingUpto(context.Background(), 2)
+	require.NoError(t, err)
+
+	value, tx, _, err := immuStore.Get([]byte("key1"))
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), tx)
+	require.Equal(t, []byte("committed"), value)
+
+	err = immuStore.Close()
+	require.NoError(t, err)
+}
+
+func TestImmudbStoreSeparateDirs(t *testing.T) {
+	defer os.RemoveAll("data_separate_dirs")
+
+	opts := DefaultOptions().
+		WithSynced(false).
+		WithTxLogDir(filepath.Join("data_separate_dirs", "fast")).
+		WithValueLogDir(filepath.Join("data_separate_dirs", "bulk")).
+		WithIndexDir(filepath.Join("data_separate_dirs", "idx"))
+
+	err := os.Mkdir("data_separate_dirs", 0700)
+	require.NoError(t, err)
+
+	path := filepath.Join("data_separate_dirs", "store")
+
+	immuStore, err := Open(path, opts)
+	require.NoError(t, err)
+
+	_, err = immuStore.Commit([]*KV{{Key: []byte("key1"), Value: []byte("value1")}}, true)
+	require.NoError(t, err)
+
+	err = immuStore.Close()
+	require.NoError(t, err)
+
+	for _, p := range []string{
+		filepath.Join(opts.TxLogDir, "tx"),
+		filepath.Join(opts.TxLogDir, "commit"),
+		filepath.Join(opts.ValueLogDir, "val_0"),
+		filepath.Join(opts.IndexDir, indexDirname),
+		filepath.Join(path, ahtDirname),
+	} {
+		require.DirExists(t, p)
+	}
+
+	for _, p := range []string{"tx", "commit", "val_0", indexDirname} {
+		require.NoDirExists(t, filepath.Join(path, p))
+	}
+
+	immuStore, err = Open(path, opts)
+	require.NoError(t, err)
+
+	value, tx, _, err := immuStore.Get([]byte("key1"))
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), tx)
+	require.Equal(t, []byte("value1"), value)
+
+	err = immuStore.Close()
+	require.NoError(t, err)
+}
+
+func TestImmudbStoreInlineValues(t *testing.T) {
+	for _, encrypted := range []bool{false, true} {
+		t.Run(fmt.Sprintf("encrypted=%v", encrypted), func(t *testing.T) {
+			defer os.RemoveAll("data_inline_values")
+			defer os.RemoveAll("data_inline_values_replica")
+
+			opts := DefaultOptions().WithSynced(false).WithInlineValueThld(8)
+			if encrypted {
+				opts.WithEncryptionKey([]byte("0123456789abcdef"))
+			}
+
+			immuStore, err := Open("data_inline_values", opts)
+			require.NoError(t, err)
+			require.Equal(t, 8, immuStore.InlineValueThld())
+
+			vLogSize := func() int64 {
+				sz, err := immuStore.vLogs[0].vLog.Size()
+				require.NoError(t, err)
+				return sz
+			}
+
+			_, err = immuStore.Commit([]*KV{
+				{Key: []byte("key1"), Value: []byte("small1")},
+				{Key: []byte("key2"), Value: []byte("")},
+			}, true)
+			require.NoError(t, err)
+			require.Zero(t, vLogSize())
+
+			_, err = immuStore.Commit([]*KV{
+				{Key: []byte("key3"), Value: []byte("small3")},
+				{Key: []byte("key4"), Value: []byte("a value larger than the threshold")},
+			}, true)
+			require.NoError(t, err)
+			require.NotZero(t, vLogSize())
+
+			check := func(st *ImmuStore) {
+				for key, expected := range map[string]string{
+					"key1": "small1",
+					"key2": "",
+					"key3": "small3",
+					"key4": "a value larger than the threshold",
+				} {
+					value, _, _, err := st.Get([]byte(key))
+					require.NoError(t, err)
+					require.Equal(t, []byte(expected), value)
+				}
+
+				tx := st.NewTx()
+
+				err = st.ReadTx(2, tx)
+				require.NoError(t, err)
+				require.True(t, tx.Entries()[0].inlined())
+				require.False(t, tx.Entries()[1].inlined())
+
+				value, err := st.ReadValue(tx, []byte("key3"))
+				require.NoError(t, err)
+				require.Equal(t, []byte("small3"), value)
+
+				valRef, _, _, err := st.GetRef([]byte("key3"))
+				require.NoError(t, err)
+
+				r, err := valRef.Reader()
+				require.NoError(t, err)
+
+				value, err = ioutil.ReadAll(r)
+				require.NoError(t, err)
+				require.Equal(t, []byte("small3"), value)
+			}
+
+			check(immuStore)
+
+			// the threshold is set when the store is created
+			err = immuStore.Close()
+			require.NoError(t, err)
+
+			immuStore, err = Open("data_inline_values", opts.WithInlineValueThld(0))
+			require.NoError(t, err)
+			defer immuStore.Close()
+
+			require.Equal(t, 8, immuStore.InlineValueThld())
+			check(immuStore)
+
+			// replicas inline values according to their own threshold
+			replica, err := Open("data_inline_values_replica", DefaultOptions().WithSynced(false))
+			require.NoError(t, err)
+			defer replica.Close()
+
+			for txID := uint64(1); txID <= immuStore.TxCount(); txID++ {
+				etx, err := immuStore.ExportTx(txID, immuStore.NewTx())
+				require.NoError(t, err)
+
+				md, err := replica.ReplicateTx(etx, true)
+				require.NoError(t, err)
+
+				tx := immuStore.NewTx()
+				err = immuStore.ReadTx(txID, tx)
+				require.NoError(t, err)
+				require.Equal(t, tx.Alh, md.Alh())
+			}
+
+			value, _, _, err := replica.Get([]byte("key3"))
+			require.NoError(t, err)
+			require.Equal(t, []byte("small3"), value)
+
+			// inline values are copied together with their txs
+			err = immuStore.ShrinkTo("data_inline_values_shrunk")
+			require.NoError(t, err)
+			defer os.RemoveAll("data_inline_values_shrunk")
+
+			shrunk, err := Open("data_inline_values_shrunk", opts)
+			require.NoError(t, err)
+			defer shrunk.Close()
+
+			err = shrunk.WaitForIndexingUpto(context.Background(), shrunk.TxCount())
+			require.NoError(t, err)
+
+			check(shrunk)
+		})
+	}
+}
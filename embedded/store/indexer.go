@@ -18,10 +18,9 @@ package store
 import (
 	"crypto/sha256"
 	"encoding/binary"
-	"fmt"
-	"os"
-	"path/filepath"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/codenotary/immudb/embedded/tbtree"
@@ -35,16 +34,29 @@ type indexer struct {
 
 	index *tbtree.TBtree
 
+	exprIndexes []*expressionIndex // sorted by name
+
 	cancellation chan struct{}
 	wHub         *watchers.WatchersHub
 
 	state     int
 	stateCond *sync.Cond
 
+	maxBulkSize int
+
+	workers      []*indexingWorker // pre-allocated
+	workersMutex sync.Mutex
+
+	indexedTxs     uint64
+	indexedEntries uint64
+	indexingTime   time.Duration
+	progressMutex  sync.Mutex
+
 	closed bool
 
-	compactionMutex sync.Mutex
-	mutex           sync.Mutex
+	compactionCancellation chan struct{}
+	compactionMutex        sync.Mutex
+	mutex                  sync.Mutex
 }
 
 type runningState = int
@@ -55,24 +67,100 @@ const (
 	paused
 )
 
-func newIndexer(path string, store *ImmuStore, indexOpts *tbtree.Options, maxWaitees int) (*indexer, error) {
+// IndexingProgress reports how far indexing is from the latest committed transaction.
+// Counters are accumulated since the store was opened.
+type IndexingProgress struct {
+	IndexedTxID    uint64
+	CommittedTxID  uint64
+	IndexedTxs     uint64
+	IndexedEntries uint64
+	IndexingTime   time.Duration
+}
+
+// indexingWorker reads and prepares transactions to be indexed. Prepared entries are placed into buffers
+// reused by the following indexing rounds, as they are copied when inserted into the index
+type indexingWorker struct {
+	tx *Tx
+
+	kvs    []tbtree.KV
+	kvRefs []*tbtree.KV
+	buf    []byte // keys and indexed values
+}
+
+func (w *indexingWorker) reset() {
+	w.kvs = w.kvs[:0]
+	w.kvRefs = w.kvRefs[:0]
+	w.buf = w.buf[:0]
+}
+
+// preparedTx holds the entries of a transaction, read by a worker, ready to be inserted into the index
+type preparedTx struct {
+	kvs     []*tbtree.KV
+	exprKVs [][]*tbtree.KV // one slice per expression index
+	prevAlh [sha256.Size]byte
+	alh     [sha256.Size]byte
+	err     error
+	done    chan struct{}
+}
+
+func newIndexer(path string, store *ImmuStore, indexOpts *tbtree.Options, maxBulkSize, concurrency, maxWaitees int,
+	extractors map[string]KeyExtractor, secondaryIndexes bool, valuePrefixOffset int) (*indexer, error) {
+
 	index, err := tbtree.Open(path, indexOpts)
 	if err != nil {
 		return nil, err
 	}
 
+	names := make([]string, 0, len(extractors)+1)
+	for name := range extractors {
+		names = append(names, name)
+	}
+	if secondaryIndexes {
+		names = append(names, secondaryIndexName)
+	}
+	sort.Strings(names)
+
+	exprIndexes := make([]*expressionIndex, len(names))
+
+	for i, name := range names {
+		exprIndex, err := tbtree.Open(path+"_"+name, indexOpts)
+		if err != nil {
+			index.Close()
+			for _, ei := range exprIndexes[:i] {
+				ei.index.Close()
+			}
+			return nil, err
+		}
+
+		exprIndexes[i] = &expressionIndex{
+			name:      name,
+			extractor: extractors[name],
+			secondary: name == secondaryIndexName,
+			valueOff:  valuePrefixOffset,
+			index:     exprIndex,
+		}
+	}
+
 	var wHub *watchers.WatchersHub
 	if maxWaitees > 0 {
 		wHub = watchers.New(0, maxWaitees)
 	}
 
+	workers := make([]*indexingWorker, concurrency)
+	for i := range workers {
+		workers[i] = &indexingWorker{tx: store.NewTx()}
+	}
+
 	indexer := &indexer{
-		store:     store,
-		path:      path,
-		index:     index,
-		wHub:      wHub,
-		state:     stopped,
-		stateCond: sync.NewCond(&sync.Mutex{}),
+		store:       store,
+		path:        path,
+		index:       index,
+		exprIndexes: exprIndexes,
+		wHub:        wHub,
+		state:       stopped,
+		stateCond:   sync.NewCond(&sync.Mutex{}),
+		maxBulkSize: maxBulkSize,
+		workers:     workers,
 	}
 
 	indexer.resume()
@@ -80,11 +168,48 @@ func newIndexer(path string, store *ImmuStore, indexOpts *tbtree.Options, maxWai
 	return indexer, nil
 }
 
+// Ts returns the id of the latest transaction included in all the indexes
 func (idx *indexer) Ts() uint64 {
 	idx.mutex.Lock()
 	defer idx.mutex.Unlock()
 
-	return idx.index.Ts()
+	return idx.lastIndexedTx()
+}
+
+func (idx *indexer) lastIndexedTx() uint64 {
+	ts := idx.index.Ts()
+
+	for _, ei := range idx.exprIndexes {
+		eiTs := ei.index.Ts()
+		if eiTs < ts {
+			ts = eiTs
+		}
+	}
+
+	return ts
+}
+
+func (idx *indexer) Progress() IndexingProgress {
+	idx.progressMutex.Lock()
+	defer idx.progressMutex.Unlock()
+
+	return IndexingProgress{
+		IndexedTxID:    idx.Ts(),
+		IndexedTxs:     idx.indexedTxs,
+		IndexedEntries: idx.indexedEntries,
+		IndexingTime:   idx.indexingTime,
+	}
+}
+
+// ActiveSnapshots returns the number of snapshots not yet closed, those of expression indexes included
+func (idx *indexer) ActiveSnapshots() int {
+	n := idx.index.ActiveSnapshots()
+
+	for _, ei := range idx.exprIndexes {
+		n += ei.index.ActiveSnapshots()
+	}
+
+	return n
 }
 
 func (idx *indexer) Get(key []byte) (value []byte, tx uint64, hc uint64, err error) {
@@ -131,6 +256,40 @@ func (idx *indexer) SnapshotSince(tx uint64) (*tbtree.Snapshot, error) {
 	return idx.index.SnapshotSince(tx)
 }
 
+func (idx *indexer) ExpressionIndexSnapshotSince(name string, tx uint64) (*tbtree.Snapshot, error) {
+	idx.mutex.Lock()
+	defer idx.mutex.Unlock()
+
+	if idx.closed {
+		return nil, ErrAlreadyClosed
+	}
+
+	for _, ei := range idx.exprIndexes {
+		if ei.name == name && !ei.secondary {
+			return ei.index.SnapshotSince(tx)
+		}
+	}
+
+	return nil, ErrExpressionIndexNotFound
+}
+
+func (idx *indexer) SecondaryIndexSnapshotSince(tx uint64) (*tbtree.Snapshot, error) {
+	idx.mutex.Lock()
+	defer idx.mutex.Unlock()
+
+	if idx.closed {
+		return nil, ErrAlreadyClosed
+	}
+
+	for _, ei := range idx.exprIndexes {
+		if ei.secondary {
+			return ei.index.SnapshotSince(tx)
+		}
+	}
+
+	return nil, ErrSecondaryIndexesDisabled
+}
+
 func (idx *indexer) ExistKeyWith(prefix []byte, neq []byte, smaller bool) (bool, error) {
 	idx.mutex.Lock()
 	defer idx.mutex.Unlock()
@@ -150,10 +309,19 @@ func (idx *indexer) Sync() error {
 		return ErrAlreadyClosed
 	}
 
+	for _, ei := range idx.exprIndexes {
+		err := ei.index.Sync()
+		if err != nil {
+			return err
+		}
+	}
+
 	return idx.index.Sync()
 }
 
 func (idx *indexer) Close() error {
+	idx.cancelCompaction()
+
 	idx.compactionMutex.Lock()
 	defer idx.compactionMutex.Unlock()
 
@@ -169,6 +337,14 @@ func (idx *indexer) Close() error {
 
 	idx.closed = true
 
+	for _, ei := range idx.exprIndexes {
+		err := ei.index.Close()
+		if err != nil {
+			idx.index.Close()
+			return err
+		}
+	}
+
 	return idx.index.Close()
 }
 
@@ -180,10 +356,37 @@ func (idx *indexer) WaitForIndexingUpto(txID uint64, cancellation <-chan struct{
 	return watchers.ErrMaxWaitessLimitExceeded
 }
 
-func (idx *indexer) CompactIndex() (err error) {
+func (idx *indexer) CompactIndex(cancellation <-chan struct{}) (err error) {
 	idx.compactionMutex.Lock()
 	defer idx.compactionMutex.Unlock()
 
+	select {
+	case <-cancellation:
+		return ErrCompactionCancelled
+	default:
+	}
+
+	idx.mutex.Lock()
+	if idx.closed {
+		idx.mutex.Unlock()
+		return ErrAlreadyClosed
+	}
+	// closing the indexer cancels an ongoing compaction
+	compactionCancellation := make(chan struct{})
+	idx.compactionCancellation = compactionCancellation
+	idx.mutex.Unlock()
+
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		select {
+		case <-cancellation:
+			idx.cancelCompaction()
+		case <-done:
+		}
+	}()
+
 	idx.store.notify(Info, true, "Compacting index '%s'...", idx.store.path)
 
 	defer func() {
@@ -194,7 +397,7 @@ func (idx *indexer) CompactIndex() (err error) {
 		}
 	}()
 
-	compactedIndexID, err := idx.index.CompactIndex()
+	_, err = idx.index.CompactIndexWithCancellation(compactionCancellation)
 	if err != nil {
 		return err
 	}
@@ -209,7 +412,25 @@ func (idx *indexer) CompactIndex() (err error) {
 	idx.stop()
 	defer idx.resume()
 
-	return idx.replaceIndex(compactedIndexID)
+	return idx.replaceIndex()
+}
+
+func (idx *indexer) cancelCompaction() {
+	idx.mutex.Lock()
+	defer idx.mutex.Unlock()
+
+	if idx.compactionCancellation != nil {
+		close(idx.compactionCancellation)
+		idx.compactionCancellation = nil
+	}
+}
+
+// CompactionProgress returns the state of the ongoing compaction of the index, if any
+func (idx *indexer) CompactionProgress() tbtree.CompactionProgress {
+	idx.mutex.Lock()
+	defer idx.mutex.Unlock()
+
+	return idx.index.CompactionProgress()
 }
 
 func (idx *indexer) stop() {
@@ -232,7 +453,8 @@ func (idx *indexer) resume() {
 	idx.store.notify(Info, true, "Indexing in progress at '%s'", idx.store.path)
 }
 
-func (idx *indexer) replaceIndex(compactedIndexID uint64) error {
+// replaceIndex reopens the index, the completed compaction is applied by the tree when it gets opened
+func (idx *indexer) replaceIndex() error {
 	opts := idx.index.GetOptions()
 
 	err := idx.index.Close()
@@ -240,31 +462,6 @@ func (idx *indexer) replaceIndex(compactedIndexID uint64) error {
 		return err
 	}
 
-	nLogPath := filepath.Join(idx.path, "nodes")
-	err = os.RemoveAll(nLogPath)
-	if err != nil {
-		return err
-	}
-
-	cLogPath := filepath.Join(idx.path, "commit")
-	err = os.RemoveAll(cLogPath)
-	if err != nil {
-		return err
-	}
-
-	cnLogPath := filepath.Join(idx.path, fmt.Sprintf("nodes_%d", compactedIndexID))
-	ccLogPath := filepath.Join(idx.path, fmt.Sprintf("commit_%d", compactedIndexID))
-
-	err = os.Rename(cnLogPath, nLogPath)
-	if err != nil {
-		return err
-	}
-
-	err = os.Rename(ccLogPath, cLogPath)
-	if err != nil {
-		return err
-	}
-
 	index, err := tbtree.Open(idx.path, opts)
 	if err != nil {
 		return err
@@ -289,7 +486,7 @@ func (idx *indexer) Pause() {
 
 func (idx *indexer) doIndexing(cancellation <-chan struct{}) {
 	for {
-		lastIndexedTx := idx.index.Ts()
+		lastIndexedTx := idx.lastIndexedTx()
 
 		if idx.wHub != nil {
 			idx.wHub.DoneUpto(lastIndexedTx)
@@ -321,7 +518,7 @@ func (idx *indexer) doIndexing(cancellation <-chan struct{}) {
 		}
 		idx.stateCond.L.Unlock()
 
-		err = idx.indexSince(lastIndexedTx+1, 10)
+		err = idx.indexSince(lastIndexedTx+1, idx.maxBulkSize)
 		if err == ErrAlreadyClosed || err == tbtree.ErrAlreadyClosed {
 			return
 		}
@@ -332,44 +529,212 @@ func (idx *indexer) doIndexing(cancellation <-chan struct{}) {
 	}
 }
 
+// indexSince indexes up to limit transactions starting from txID.
+// Transactions are read and prepared by concurrent workers, while their entries are inserted
+// into the index following the order of transactions. Consecutive transactions already prepared
+// are inserted together in a single bulk, each entry at the timestamp of its transaction.
 func (idx *indexer) indexSince(txID uint64, limit int) error {
-	tx, err := idx.store.fetchAllocTx()
-	if err != nil {
-		return err
+	idx.workersMutex.Lock()
+	defer idx.workersMutex.Unlock()
+
+	committedTxID, _, _ := idx.store.commitState()
+	if committedTxID < txID {
+		return nil
 	}
-	defer idx.store.releaseAllocTx(tx)
 
-	txReader, err := idx.store.newTxReader(txID, false, tx)
-	if err != nil {
-		return err
+	n := limit
+	if committedTxID-txID+1 < uint64(limit) {
+		n = int(committedTxID - txID + 1)
+	}
+
+	start := time.Now()
+
+	prepared := make([]*preparedTx, n)
+	for i := range prepared {
+		prepared[i] = &preparedTx{done: make(chan struct{})}
+	}
+
+	workers := len(idx.workers)
+	if workers > n {
+		workers = n
 	}
 
-	for i := 0; i < limit; i++ {
-		tx, err := txReader.Read()
-		if err == ErrNoMoreEntries {
-			break
+	var stopped int32
+	var wg sync.WaitGroup
+
+	wg.Add(workers)
+
+	for w := 0; w < workers; w++ {
+		go func(worker *indexingWorker, w int) {
+			defer wg.Done()
+
+			worker.reset()
+
+			for i := w; i < n; i += workers {
+				if atomic.LoadInt32(&stopped) == 1 {
+					return
+				}
+
+				idx.prepareTx(txID+uint64(i), worker, prepared[i])
+				close(prepared[i].done)
+			}
+		}(idx.workers[w], w)
+	}
+
+	defer func() {
+		atomic.StoreInt32(&stopped, 1)
+		wg.Wait()
+	}()
+
+	var kvs []*tbtree.KV
+	exprKVs := make([][]*tbtree.KV, len(idx.exprIndexes))
+
+	// expression indexes may be behind the main one e.g. when registered on an existing store
+	indexTs := idx.index.Ts()
+
+	exprIndexTs := make([]uint64, len(idx.exprIndexes))
+	for j, ei := range idx.exprIndexes {
+		exprIndexTs[j] = ei.index.Ts()
+	}
+
+	var batchedTxs, batchedEntries int
+
+	for i, p := range prepared {
+		<-p.done
+
+		if p.err != nil {
+			return p.err
 		}
-		if err != nil {
-			return err
+
+		if i > 0 && p.prevAlh != prepared[i-1].alh {
+			return idx.store.notifyCorruption("indexing", &TxError{TxID: txID + uint64(i), Err: ErrorCorruptedTxData})
+		}
+
+		if txID+uint64(i) > indexTs {
+			kvs = append(kvs, p.kvs...)
+		}
+
+		for j := range idx.exprIndexes {
+			if txID+uint64(i) > exprIndexTs[j] {
+				exprKVs[j] = append(exprKVs[j], p.exprKVs[j]...)
+			}
 		}
 
-		txEntries := tx.Entries()
+		batchedTxs++
+		batchedEntries += len(p.kvs)
 
-		for i, e := range txEntries {
-			var b [szSize + offsetSize + sha256.Size]byte
-			binary.BigEndian.PutUint32(b[:], uint32(e.vLen))
-			binary.BigEndian.PutUint64(b[szSize:], uint64(e.vOff))
-			copy(b[szSize+offsetSize:], e.hVal[:])
+		// entries are not needed anymore
+		p.kvs = nil
+		p.exprKVs = nil
 
-			idx.store._kvs[i].K = e.key()
-			idx.store._kvs[i].V = b[:]
+		// the batch is inserted once the next transaction is not yet prepared
+		if i+1 < n {
+			select {
+			case <-prepared[i+1].done:
+				continue
+			default:
+			}
 		}
 
-		err = idx.index.BulkInsert(idx.store._kvs[:len(txEntries)])
-		if err != nil {
-			return err
+		if len(kvs) > 0 {
+			err := idx.index.BulkInsert(kvs)
+			if err != nil {
+				return err
+			}
 		}
+
+		for j, ei := range idx.exprIndexes {
+			if len(exprKVs[j]) > 0 {
+				err := ei.index.BulkInsert(exprKVs[j])
+				if err != nil {
+					return err
+				}
+			}
+
+			exprKVs[j] = exprKVs[j][:0]
+		}
+
+		kvs = kvs[:0]
+
+		idx.progressMutex.Lock()
+		idx.indexedTxs += uint64(batchedTxs)
+		idx.indexedEntries += uint64(batchedEntries)
+		idx.indexingTime += time.Since(start)
+		idx.progressMutex.Unlock()
+
+		batchedTxs, batchedEntries = 0, 0
+
+		start = time.Now()
 	}
 
 	return nil
 }
+
+func (idx *indexer) prepareTx(txID uint64, w *indexingWorker, p *preparedTx) {
+	p.err = idx.store.ReadTx(txID, w.tx)
+	if p.err != nil {
+		return
+	}
+
+	txEntries := w.tx.Entries()
+
+	kvsOff := len(w.kvs)
+
+	for _, e := range txEntries {
+		kOff := len(w.buf)
+		w.buf = append(w.buf, e.key()...)
+
+		vOff := len(w.buf)
+		w.buf = appendIndexedValue(w.buf, e)
+
+		w.kvs = append(w.kvs, tbtree.KV{
+			K: w.buf[kOff:vOff:vOff],
+			V: w.buf[vOff:len(w.buf):len(w.buf)],
+			T: txID,
+		})
+	}
+
+	// buffers may have been reallocated, entries prepared before keep referencing the previous ones
+	kvRefsOff := len(w.kvRefs)
+
+	for i := kvsOff; i < len(w.kvs); i++ {
+		w.kvRefs = append(w.kvRefs, &w.kvs[i])
+	}
+
+	p.kvs = w.kvRefs[kvRefsOff:len(w.kvRefs):len(w.kvRefs)]
+
+	p.exprKVs = make([][]*tbtree.KV, len(idx.exprIndexes))
+
+	for i, ei := range idx.exprIndexes {
+		p.exprKVs[i], p.err = ei.kvsFor(idx.store, txID, txEntries, p.kvs)
+		if p.err != nil {
+			return
+		}
+	}
+
+	p.prevAlh = w.tx.PrevAlh
+	p.alh = w.tx.Alh
+}
+
+// appendIndexedValue appends to b the indexed value of the entry, encoded as vLen + vOff + hValue.
+// Entry metadata is appended as mdLen + md only when present
+func appendIndexedValue(b []byte, e *TxEntry) []byte {
+	mdbs := e.md.Bytes()
+
+	var hdr [szSize + offsetSize]byte
+	binary.BigEndian.PutUint32(hdr[:], uint32(e.vLen))
+	binary.BigEndian.PutUint64(hdr[szSize:], uint64(e.vOff))
+
+	b = append(b, hdr[:]...)
+	b = append(b, e.hVal[:]...)
+
+	if len(mdbs) > 0 {
+		var mdLen [mdLenSize]byte
+		binary.BigEndian.PutUint16(mdLen[:], uint16(len(mdbs)))
+
+		b = append(b, mdLen[:]...)
+		b = append(b, mdbs...)
+	}
+
+	return b
+}
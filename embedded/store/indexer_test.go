@@ -0,0 +1,220 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package store
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestImmudbStoreConcurrentIndexing(t *testing.T) {
+	for _, concurrency := range []int{1, 3, 8} {
+		t.Run(fmt.Sprintf("concurrency %d", concurrency), func(t *testing.T) {
+			dir := fmt.Sprintf("data_concurrent_indexing_%d", concurrency)
+			defer os.RemoveAll(dir)
+
+			indexOpts := DefaultIndexOptions().
+				WithMaxBulkSize(5).
+				WithConcurrency(concurrency)
+
+			opts := DefaultOptions().
+				WithSynced(false).
+				WithIndexOptions(indexOpts)
+
+			immuStore, err := Open(dir, opts)
+			require.NoError(t, err)
+			require.Len(t, immuStore.indexer.workers, concurrency)
+
+			txCount := 50
+			keyCount := 7
+
+			for i := 0; i < txCount; i++ {
+				kvs := make([]*KV, keyCount)
+
+				for j := 0; j < keyCount; j++ {
+					kvs[j] = &KV{
+						Key:   []byte(fmt.Sprintf("key%d", j)),
+						Value: []byte(fmt.Sprintf("value%d_%d", i, j)),
+					}
+				}
+
+				_, err = immuStore.Commit(kvs, false)
+				require.NoError(t, err)
+			}
+
+			err = immuStore.WaitForIndexingUpto(context.Background(), uint64(txCount))
+			require.NoError(t, err)
+
+			for j := 0; j < keyCount; j++ {
+				key := []byte(fmt.Sprintf("key%d", j))
+
+				v, tx, hc, err := immuStore.Get(key)
+				require.NoError(t, err)
+				require.Equal(t, []byte(fmt.Sprintf("value%d_%d", txCount-1, j)), v)
+				require.Equal(t, uint64(txCount), tx)
+				require.Equal(t, uint64(txCount), hc)
+
+				txs, err := immuStore.History(key, 0, false, txCount)
+				require.NoError(t, err)
+				require.Len(t, txs, txCount)
+
+				for i, txID := range txs {
+					require.Equal(t, uint64(i+1), txID)
+				}
+			}
+
+			progress := immuStore.IndexingProgress()
+			require.Equal(t, uint64(txCount), progress.IndexedTxID)
+			require.Equal(t, uint64(txCount), progress.CommittedTxID)
+			require.Equal(t, uint64(txCount), progress.IndexedTxs)
+			require.Equal(t, uint64(txCount*keyCount), progress.IndexedEntries)
+			require.Greater(t, int64(progress.IndexingTime), int64(0))
+
+			err = immuStore.Close()
+			require.NoError(t, err)
+
+			immuStore, err = Open(dir, opts)
+			require.NoError(t, err)
+
+			// counters are accumulated since the store was opened
+			progress = immuStore.IndexingProgress()
+			require.Equal(t, uint64(txCount), progress.IndexedTxID)
+			require.Zero(t, progress.IndexedTxs)
+
+			err = immuStore.Close()
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestIndexerIndexSinceNothingCommitted(t *testing.T) {
+	immuStore, err := Open("data_index_since", DefaultOptions().WithSynced(false))
+	require.NoError(t, err)
+	defer os.RemoveAll("data_index_since")
+
+	err = immuStore.indexer.indexSince(1, 10)
+	require.NoError(t, err)
+
+	require.Zero(t, immuStore.IndexingProgress().IndexedTxs)
+
+	err = immuStore.Close()
+	require.NoError(t, err)
+}
+
+func TestIndexerIndexSinceInBatches(t *testing.T) {
+	defer os.RemoveAll("data_index_batches")
+
+	opts := DefaultOptions().WithSynced(false)
+	opts.WithIndexOptions(opts.IndexOpts.
+		WithMaxBulkSize(10).
+		WithConcurrency(10).
+		WithExpressionIndex("ts", tsExtractor))
+
+	immuStore, err := Open("data_index_batches", opts)
+	require.NoError(t, err)
+
+	immuStore.indexer.Pause()
+
+	commitTxs := func(from, to int) {
+		for i := from; i <= to; i++ {
+			_, err = immuStore.Commit([]*KV{
+				{Key: []byte("key"), Value: []byte(fmt.Sprintf("value%d", i))},
+				{Key: eventKey("tenant1", i, uint64(i)), Value: []byte(fmt.Sprintf("event%d", i))},
+			}, false)
+			require.NoError(t, err)
+		}
+	}
+
+	commitTxs(1, 10)
+
+	err = immuStore.indexer.indexSince(1, 10)
+	require.NoError(t, err)
+
+	worker := immuStore.indexer.workers[0]
+	require.Len(t, worker.kvs, 2)
+
+	preparedKV := &worker.kvs[0]
+
+	commitTxs(11, 20)
+
+	err = immuStore.indexer.indexSince(11, 10)
+	require.NoError(t, err)
+
+	// buffers of the workers are reused across indexing rounds
+	require.Same(t, preparedKV, &worker.kvs[0])
+
+	// entries of transactions inserted together keep the timestamp of their transaction
+	require.Equal(t, uint64(20), immuStore.indexer.index.Ts())
+	require.Equal(t, uint64(20), immuStore.indexer.exprIndexes[0].index.Ts())
+
+	txs, err := immuStore.History([]byte("key"), 0, false, 20)
+	require.NoError(t, err)
+	require.Len(t, txs, 20)
+
+	for i, txID := range txs {
+		require.Equal(t, uint64(i+1), txID)
+	}
+
+	v, tx, _, err := immuStore.Get([]byte("key"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value20"), v)
+	require.Equal(t, uint64(20), tx)
+
+	_, values := readAllBy(t, immuStore, "ts", &ScanBySpec{StartKey: tsKey(19)})
+	require.Equal(t, [][]byte{[]byte("event19"), []byte("event20")}, values)
+
+	progress := immuStore.IndexingProgress()
+	require.Equal(t, uint64(20), progress.IndexedTxs)
+	require.Equal(t, uint64(40), progress.IndexedEntries)
+
+	immuStore.indexer.Resume()
+
+	err = immuStore.Close()
+	require.NoError(t, err)
+}
+
+func TestImmudbStoreWaitForIndexingWithContext(t *testing.T) {
+	immuStore, err := Open("data_indexing_ctx", DefaultOptions().WithSynced(false))
+	require.NoError(t, err)
+	defer os.RemoveAll("data_indexing_ctx")
+
+	_, err = immuStore.Commit([]*KV{{Key: []byte("key1"), Value: []byte("value1")}}, false)
+	require.NoError(t, err)
+
+	err = immuStore.WaitForIndexingUpto(context.Background(), 1)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// tx 2 is never committed
+	err = immuStore.WaitForIndexingUpto(ctx, 2)
+	require.Equal(t, context.DeadlineExceeded, err)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+
+	err = immuStore.WaitForIndexingUpto(ctx, 2)
+	require.Equal(t, context.Canceled, err)
+
+	err = immuStore.Close()
+	require.NoError(t, err)
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package store
+
+import (
+	"sort"
+
+	"github.com/codenotary/immudb/embedded/tbtree"
+)
+
+// iterationBatchSize is the number of entries whose values are resolved at once while iterating
+const iterationBatchSize = 64
+
+// IterateFn is called for each entry being iterated, iteration stops as soon as it returns false or an error.
+// The value may be kept by the callee as it's not reused by later calls
+type IterateFn func(key []byte, val []byte, tx uint64) (more bool, err error)
+
+type iteratedEntry struct {
+	key []byte
+	ref *ValueRef
+	tx  uint64
+	val []byte
+}
+
+// Iterate calls fn with every visible entry whose key has the given prefix, in ascending key order,
+// as of the entries indexed so far. Expired and deleted entries are skipped.
+func (s *ImmuStore) Iterate(prefix []byte, fn IterateFn) error {
+	snap, err := s.Snapshot()
+	if err != nil {
+		return err
+	}
+	defer snap.Close()
+
+	return snap.Iterate(prefix, fn)
+}
+
+// Iterate calls fn with every visible entry of the snapshot whose key has the given prefix, in ascending key order.
+// Values are resolved in batches, sorted by their location and read into a single buffer per batch
+func (s *Snapshot) Iterate(prefix []byte, fn IterateFn) error {
+	if fn == nil {
+		return ErrIllegalArguments
+	}
+
+	r, err := s.snap.NewReader(&tbtree.ReaderSpec{
+		SeekKey:       prefix,
+		Prefix:        prefix,
+		InclusiveSeek: true,
+	})
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	batch := make([]*iteratedEntry, 0, iterationBatchSize)
+	byOffset := make([]*iteratedEntry, 0, iterationBatchSize)
+
+	for {
+		batch = batch[:0]
+		exhausted := false
+
+		for len(batch) < iterationBatchSize {
+			key, indexedVal, tx, _, err := r.Read()
+			if err == tbtree.ErrNoMoreEntries {
+				exhausted = true
+				break
+			}
+			if err != nil {
+				return err
+			}
+
+			ref, err := s.st.valueRefFrom(indexedVal)
+			if err != nil {
+				return err
+			}
+
+			if ref.md.ExpiredAt(s.st.timeFunc()) || ref.md.Deleted() {
+				continue
+			}
+
+			batch = append(batch, &iteratedEntry{key: key, ref: ref, tx: tx})
+		}
+
+		err = s.resolveValues(batch, byOffset[:0])
+		if err != nil {
+			return err
+		}
+
+		for _, e := range batch {
+			more, err := fn(e.key, e.val, e.tx)
+			if err != nil || !more {
+				return err
+			}
+		}
+
+		if exhausted {
+			return nil
+		}
+	}
+}
+
+// resolveValues reads the values of the entries in the order they are stored, so reads are mostly sequential
+func (s *Snapshot) resolveValues(entries []*iteratedEntry, byOffset []*iteratedEntry) error {
+	size := 0
+
+	for _, e := range entries {
+		size += int(e.ref.valLen)
+	}
+
+	buf := make([]byte, size)
+
+	for _, e := range entries {
+		e.val = buf[:e.ref.valLen:e.ref.valLen]
+		buf = buf[e.ref.valLen:]
+
+		byOffset = append(byOffset, e)
+	}
+
+	sort.Slice(byOffset, func(i, j int) bool {
+		return byOffset[i].ref.vOff < byOffset[j].ref.vOff
+	})
+
+	for _, e := range byOffset {
+		_, err := s.st.ReadValueAt(e.val, e.ref.vOff, e.ref.hVal)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
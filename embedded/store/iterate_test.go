@@ -0,0 +1,123 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package store
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestImmudbStoreIterate(t *testing.T) {
+	immuStore, err := Open("data_store_iterate", DefaultOptions().WithSynced(false))
+	require.NoError(t, err)
+	defer os.RemoveAll("data_store_iterate")
+
+	defer immuStore.Close()
+
+	keyCount := 2*iterationBatchSize + 10
+
+	// entries are committed in reverse key order so values are not stored in key order
+	for i := keyCount - 1; i >= 0; i-- {
+		_, err = immuStore.Commit([]*KV{
+			{Key: []byte(fmt.Sprintf("key%03d", i)), Value: []byte(fmt.Sprintf("value%d", i))},
+			{Key: []byte(fmt.Sprintf("other%03d", i)), Value: []byte(fmt.Sprintf("other%d", i))},
+		}, true)
+		require.NoError(t, err)
+	}
+
+	_, err = immuStore.Commit([]*KV{
+		{Key: []byte("key000"), Metadata: NewKVMetadata().AsDeleted(true)},
+		{Key: []byte("key001"), Metadata: NewKVMetadata().ExpiresAt(time.Now().Add(-1 * time.Hour)), Value: []byte("expired")},
+		{Key: []byte("key002"), Value: []byte{}},
+	}, true)
+	require.NoError(t, err)
+
+	t.Run("every visible entry with the prefix should be iterated in key order", func(t *testing.T) {
+		var keys []string
+		var values [][]byte
+
+		err := immuStore.Iterate([]byte("key"), func(key, val []byte, tx uint64) (bool, error) {
+			require.NotZero(t, tx)
+
+			keys = append(keys, string(key))
+			values = append(values, val)
+
+			return true, nil
+		})
+		require.NoError(t, err)
+
+		require.Len(t, keys, keyCount-2)
+		require.Equal(t, "key002", keys[0])
+		require.Empty(t, values[0])
+
+		for i := 3; i < keyCount; i++ {
+			require.Equal(t, fmt.Sprintf("key%03d", i), keys[i-2])
+			require.Equal(t, []byte(fmt.Sprintf("value%d", i)), values[i-2])
+		}
+	})
+
+	t.Run("iteration should stop when requested", func(t *testing.T) {
+		n := 0
+
+		err := immuStore.Iterate([]byte("other"), func(key, val []byte, tx uint64) (bool, error) {
+			n++
+			return n < iterationBatchSize+1, nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, iterationBatchSize+1, n)
+
+		errStop := errors.New("stop")
+
+		err = immuStore.Iterate(nil, func(key, val []byte, tx uint64) (bool, error) {
+			return true, errStop
+		})
+		require.Equal(t, errStop, err)
+	})
+
+	t.Run("iteration over a snapshot should not include later entries", func(t *testing.T) {
+		snap, err := immuStore.Snapshot()
+		require.NoError(t, err)
+		defer snap.Close()
+
+		_, err = immuStore.Commit([]*KV{{Key: []byte("key999"), Value: []byte("value999")}}, true)
+		require.NoError(t, err)
+
+		var last []byte
+
+		err = snap.Iterate([]byte("key"), func(key, val []byte, tx uint64) (bool, error) {
+			last = key
+			return true, nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []byte(fmt.Sprintf("key%03d", keyCount-1)), last)
+
+		err = snap.Iterate([]byte("key"), nil)
+		require.Equal(t, ErrIllegalArguments, err)
+	})
+
+	t.Run("iteration without matching entries should not call the callback", func(t *testing.T) {
+		err := immuStore.Iterate([]byte("missing"), func(key, val []byte, tx uint64) (bool, error) {
+			require.Fail(t, "unexpected entry")
+			return false, nil
+		})
+		require.NoError(t, err)
+	})
+}
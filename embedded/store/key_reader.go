@@ -29,9 +29,10 @@ type Snapshot struct {
 }
 
 type KeyReader struct {
-	store  *ImmuStore
-	reader *tbtree.Reader
-	_tx    *Tx
+	store          *ImmuStore
+	reader         *tbtree.Reader
+	includeDeleted bool
+	_tx            *Tx
 }
 
 type KeyReaderSpec struct {
@@ -39,31 +40,126 @@ type KeyReaderSpec struct {
 	Prefix        []byte
 	InclusiveSeek bool
 	DescOrder     bool
+	// IncludeDeleted makes deleted entries to be read as well, expired entries are always skipped
+	IncludeDeleted bool
 }
 
 func (s *Snapshot) Get(key []byte) (val []byte, tx uint64, hc uint64, err error) {
-	indexedVal, tx, hc, err := s.snap.Get(key)
+	valRef, tx, hc, err := s.getRef(key)
 	if err != nil {
 		return nil, 0, 0, err
 	}
 
-	valRef, err := s.st.valueRefFrom(indexedVal)
+	val, err = valRef.Resolve()
 	if err != nil {
 		return nil, 0, 0, err
 	}
 
-	val, err = valRef.Resolve()
+	return val, tx, hc, nil
+}
+
+// getRef returns a reference to the value of the key as of the snapshot, the value is not read
+func (s *Snapshot) getRef(key []byte) (valRef *ValueRef, tx uint64, hc uint64, err error) {
+	indexedVal, tx, hc, err := s.snap.Get(key)
 	if err != nil {
 		return nil, 0, 0, err
 	}
 
-	return val, tx, hc, nil
+	valRef, err = s.st.valueRefFrom(indexedVal)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	if valRef.md.hiddenAt(s.st.timeFunc()) {
+		return nil, 0, 0, ErrKeyNotFound
+	}
+
+	return valRef, tx, hc, nil
 }
 
 func (s *Snapshot) History(key []byte, offset uint64, descOrder bool, limit int) (tss []uint64, err error) {
 	return s.snap.History(key, offset, descOrder, limit)
 }
 
+// KeyRevision is a revision of a key, revisions are numbered from 1 in the order they were committed
+type KeyRevision struct {
+	Tx       uint64
+	Revision uint64
+}
+
+// HistoryAt returns up to limit revisions of the key as of its revision atRevision, the latest one when zero.
+// Offset is counted from the newest revision in descending order and from the first one otherwise,
+// so pages read with the same atRevision are not shifted by revisions committed meanwhile
+func (s *Snapshot) HistoryAt(key []byte, atRevision uint64, offset uint64, descOrder bool, limit int) ([]KeyRevision, error) {
+	revs, _, err := historyAt(s.snap, key, atRevision, offset, descOrder, limit)
+	return revs, err
+}
+
+type historyReader interface {
+	Get(key []byte) (value []byte, ts uint64, hc uint64, err error)
+	History(key []byte, offset uint64, descOrder bool, limit int) (tss []uint64, err error)
+}
+
+// historyAt returns the revisions of the key along with the number of revisions read before them
+func historyAt(r historyReader, key []byte, atRevision uint64, offset uint64, descOrder bool, limit int) ([]KeyRevision, uint64, error) {
+	if limit < 1 {
+		return nil, 0, ErrIllegalArguments
+	}
+
+	_, _, hc, err := r.Get(key)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if atRevision == 0 {
+		atRevision = hc
+	}
+
+	if atRevision > hc {
+		return nil, 0, ErrIllegalArguments
+	}
+
+	if offset >= atRevision {
+		return nil, 0, ErrOffsetOutOfRange
+	}
+
+	if uint64(limit) > atRevision-offset {
+		limit = int(atRevision - offset)
+	}
+
+	hOffset := offset
+
+	if descOrder {
+		// revisions newer than atRevision are skipped
+		hOffset += hc - atRevision
+	}
+
+	txs, err := r.History(key, hOffset, descOrder, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	revs := make([]KeyRevision, len(txs))
+
+	for i, tx := range txs {
+		revs[i].Tx = tx
+
+		if descOrder {
+			revs[i].Revision = atRevision - offset - uint64(i)
+		} else {
+			revs[i].Revision = offset + uint64(i) + 1
+		}
+	}
+
+	return revs, hc, nil
+}
+
+// EstimatedKeys returns an approximation of the number of indexed keys k such that
+// startKey <= k < endKey, an empty endKey meaning the range is unbounded
+func (s *Snapshot) EstimatedKeys(startKey, endKey []byte) (uint64, error) {
+	return s.snap.EstimatedKeys(startKey, endKey)
+}
+
 func (s *Snapshot) Ts() uint64 {
 	return s.snap.Ts()
 }
@@ -88,9 +184,10 @@ func (s *Snapshot) NewKeyReader(spec *KeyReaderSpec) (*KeyReader, error) {
 	}
 
 	return &KeyReader{
-		store:  s.st,
-		reader: r,
-		_tx:    s.st.NewTx(),
+		store:          s.st,
+		reader:         r,
+		includeDeleted: spec.IncludeDeleted,
+		_tx:            s.st.NewTx(),
 	}, nil
 }
 
@@ -98,28 +195,56 @@ type ValueRef struct {
 	hVal   [32]byte
 	vOff   int64
 	valLen uint32
+	md     *KVMetadata
 	st     *ImmuStore
 }
 
 func (st *ImmuStore) valueRefFrom(indexedVal []byte) (*ValueRef, error) {
-	if len(indexedVal) != 4+8+32 {
+	const fixedLen = szSize + offsetSize + sha256.Size
+
+	if len(indexedVal) < fixedLen {
 		return nil, ErrCorruptedData
 	}
 
 	valLen := binary.BigEndian.Uint32(indexedVal)
-	vOff := binary.BigEndian.Uint64(indexedVal[4:])
+	vOff := binary.BigEndian.Uint64(indexedVal[szSize:])
 
 	var hVal [sha256.Size]byte
-	copy(hVal[:], indexedVal[4+8:])
+	copy(hVal[:], indexedVal[szSize+offsetSize:])
+
+	var md *KVMetadata
+
+	if len(indexedVal) > fixedLen {
+		if len(indexedVal) < fixedLen+mdLenSize {
+			return nil, ErrCorruptedData
+		}
+
+		mdLen := int(binary.BigEndian.Uint16(indexedVal[fixedLen:]))
+		if len(indexedVal) != fixedLen+mdLenSize+mdLen {
+			return nil, ErrCorruptedData
+		}
+
+		var err error
+
+		md, err = kvMetadataFrom(indexedVal[fixedLen+mdLenSize:])
+		if err != nil {
+			return nil, ErrCorruptedData
+		}
+	}
 
 	return &ValueRef{
 		hVal:   hVal,
 		vOff:   int64(vOff),
 		valLen: valLen,
+		md:     md,
 		st:     st,
 	}, nil
 }
 
+func (v *ValueRef) KVMetadata() *KVMetadata {
+	return v.md
+}
+
 // Resolve ...
 func (v *ValueRef) Resolve() ([]byte, error) {
 	refVal := make([]byte, v.valLen)
@@ -144,6 +269,7 @@ func (r *KeyReader) ReadAsBefore(txID uint64) (key []byte, val *ValueRef, tx uin
 				hVal:   e.hVal,
 				vOff:   int64(e.vOff),
 				valLen: uint32(e.vLen),
+				md:     e.md,
 				st:     r.store,
 			}
 
@@ -154,18 +280,25 @@ func (r *KeyReader) ReadAsBefore(txID uint64) (key []byte, val *ValueRef, tx uin
 	return nil, nil, 0, ErrUnexpectedError
 }
 
+// Read returns the next visible entry, expired entries are skipped as well as deleted ones unless they were requested
 func (r *KeyReader) Read() (key []byte, val *ValueRef, tx uint64, hc uint64, err error) {
-	key, indexedVal, tx, hc, err := r.reader.Read()
-	if err != nil {
-		return nil, nil, 0, 0, err
-	}
+	for {
+		key, indexedVal, tx, hc, err := r.reader.Read()
+		if err != nil {
+			return nil, nil, 0, 0, err
+		}
 
-	val, err = r.store.valueRefFrom(indexedVal)
-	if err != nil {
-		return nil, nil, 0, 0, err
-	}
+		val, err = r.store.valueRefFrom(indexedVal)
+		if err != nil {
+			return nil, nil, 0, 0, err
+		}
+
+		if val.md.ExpiredAt(r.store.timeFunc()) || (val.md.Deleted() && !r.includeDeleted) {
+			continue
+		}
 
-	return key, val, tx, hc, nil
+		return key, val, tx, hc, nil
+	}
 }
 
 func (r *KeyReader) Reset() error {
@@ -16,6 +16,7 @@ limitations under the License.
 package store
 
 import (
+	"context"
 	"encoding/binary"
 	"os"
 	"testing"
@@ -138,3 +139,66 @@ func TestImmudbStoreReaderAsBefore(t *testing.T) {
 		require.NoError(t, err)
 	}
 }
+
+func TestImmudbStoreHistoryAt(t *testing.T) {
+	immuStore, err := Open("data_store_history_at", DefaultOptions().WithSynced(false))
+	require.NoError(t, err)
+	defer os.RemoveAll("data_store_history_at")
+
+	defer immuStore.Close()
+
+	key := []byte("key1")
+
+	for i := 0; i < 10; i++ {
+		_, err := immuStore.Commit([]*KV{{Key: key, Value: []byte{byte(i)}}}, true)
+		require.NoError(t, err)
+	}
+
+	err = immuStore.WaitForIndexingUpto(context.Background(), 10)
+	require.NoError(t, err)
+
+	_, err = immuStore.HistoryAt(key, 0, 0, false, 0)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = immuStore.HistoryAt([]byte("key2"), 0, 0, false, 10)
+	require.Equal(t, ErrKeyNotFound, err)
+
+	_, err = immuStore.HistoryAt(key, 11, 0, false, 10)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = immuStore.HistoryAt(key, 0, 10, false, 10)
+	require.Equal(t, ErrOffsetOutOfRange, err)
+
+	revs, err := immuStore.HistoryAt(key, 0, 2, false, 3)
+	require.NoError(t, err)
+	require.Equal(t, []KeyRevision{{Tx: 3, Revision: 3}, {Tx: 4, Revision: 4}, {Tx: 5, Revision: 5}}, revs)
+
+	revs, err = immuStore.HistoryAt(key, 0, 0, true, 3)
+	require.NoError(t, err)
+	require.Equal(t, []KeyRevision{{Tx: 10, Revision: 10}, {Tx: 9, Revision: 9}, {Tx: 8, Revision: 8}}, revs)
+
+	// pages read at the revision of the first one are not shifted by newer revisions
+	for i := 10; i < 15; i++ {
+		_, err := immuStore.Commit([]*KV{{Key: key, Value: []byte{byte(i)}}}, true)
+		require.NoError(t, err)
+	}
+
+	err = immuStore.WaitForIndexingUpto(context.Background(), 15)
+	require.NoError(t, err)
+
+	revs, err = immuStore.HistoryAt(key, 10, 3, true, 3)
+	require.NoError(t, err)
+	require.Equal(t, []KeyRevision{{Tx: 7, Revision: 7}, {Tx: 6, Revision: 6}, {Tx: 5, Revision: 5}}, revs)
+
+	revs, err = immuStore.HistoryAt(key, 10, 6, true, 10)
+	require.NoError(t, err)
+	require.Equal(t, []KeyRevision{{Tx: 4, Revision: 4}, {Tx: 3, Revision: 3}, {Tx: 2, Revision: 2}, {Tx: 1, Revision: 1}}, revs)
+
+	revs, err = immuStore.HistoryAt(key, 10, 8, false, 10)
+	require.NoError(t, err)
+	require.Equal(t, []KeyRevision{{Tx: 9, Revision: 9}, {Tx: 10, Revision: 10}}, revs)
+
+	revs, err = immuStore.HistoryAt(key, 0, 0, true, 1)
+	require.NoError(t, err)
+	require.Equal(t, []KeyRevision{{Tx: 15, Revision: 15}}, revs)
+}
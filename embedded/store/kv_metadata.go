@@ -0,0 +1,362 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package store
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"time"
+)
+
+var ErrNonExpirable = errors.New("non expirable")
+var ErrCorruptedKVMetadata = errors.New("corrupted kv metadata")
+
+// attribute codes are part of the serialized metadata, they must never be reused
+const (
+	expiresAtAttrCode byte = 0
+	indexesAttrCode   byte = 1
+	deletedAttrCode   byte = 2
+)
+
+// index kinds are part of the serialized metadata, they must never be reused
+const (
+	tagIndexKind         byte = 0
+	valuePrefixIndexKind byte = 1
+)
+
+const (
+	// MaxKVIndexes is the max number of secondary index entries of a single entry
+	MaxKVIndexes = 4
+	// MaxKVIndexNameLen is the max length of the name of a secondary index
+	MaxKVIndexNameLen = 32
+	// MaxKVIndexKeyLen is the max length of a tag or value prefix an entry is indexed by
+	MaxKVIndexKeyLen = 64
+)
+
+const expiresAtAttrSize = 1 + tsSize
+const maxIndexesAttrSize = 1 + 1 + MaxKVIndexes*(1+MaxKVIndexNameLen+1+1+MaxKVIndexKeyLen)
+const deletedAttrSize = 1
+
+// MaxKVMetadataLen is the max length of a serialized KVMetadata, it must be updated when new attributes are added
+const MaxKVMetadataLen = expiresAtAttrSize + maxIndexesAttrSize + deletedAttrSize
+
+const mdLenSize = 2
+
+// KVMetadata holds optional attributes of an entry. Metadata is included in the entry digest,
+// thus it's as immutable as the key and value it's associated with.
+type KVMetadata struct {
+	expirable bool
+	expiresAt time.Time
+	indexes   []KVIndex
+	deleted   bool
+}
+
+// KVIndex is a secondary index entry declared by an entry, the entry is indexed under Name
+// by the user-supplied Tag or, when ValuePrefixLen is set, by that many leading bytes of its value.
+// Entries whose value is shorter than ValuePrefixLen are not indexed.
+type KVIndex struct {
+	Name           string
+	Tag            []byte
+	ValuePrefixLen int
+}
+
+func (i KVIndex) equal(other KVIndex) bool {
+	return i.Name == other.Name && bytes.Equal(i.Tag, other.Tag) && i.ValuePrefixLen == other.ValuePrefixLen
+}
+
+func (i KVIndex) valid() bool {
+	if !validIdentifier(i.Name) || len(i.Name) > MaxKVIndexNameLen {
+		return false
+	}
+
+	if i.ValuePrefixLen > 0 {
+		return len(i.Tag) == 0 && i.ValuePrefixLen <= MaxKVIndexKeyLen
+	}
+
+	return len(i.Tag) > 0 && len(i.Tag) <= MaxKVIndexKeyLen && i.ValuePrefixLen == 0
+}
+
+func NewKVMetadata() *KVMetadata {
+	return &KVMetadata{}
+}
+
+func (md *KVMetadata) ExpiresAt(expiresAt time.Time) *KVMetadata {
+	md.expirable = true
+	md.expiresAt = time.Unix(expiresAt.Unix(), 0)
+	return md
+}
+
+func (md *KVMetadata) NonExpirable() *KVMetadata {
+	md.expirable = false
+	md.expiresAt = time.Time{}
+	return md
+}
+
+func (md *KVMetadata) IsExpirable() bool {
+	return md != nil && md.expirable
+}
+
+func (md *KVMetadata) ExpirationTime() (time.Time, error) {
+	if !md.IsExpirable() {
+		return time.Time{}, ErrNonExpirable
+	}
+
+	return md.expiresAt, nil
+}
+
+// ExpiredAt returns true if the entry is no longer visible at the given time
+func (md *KVMetadata) ExpiredAt(t time.Time) bool {
+	return md.IsExpirable() && !md.expiresAt.After(t)
+}
+
+// AsDeleted marks the entry as a logical deletion of its key, previous values are kept in the key history
+func (md *KVMetadata) AsDeleted(deleted bool) *KVMetadata {
+	md.deleted = deleted
+	return md
+}
+
+func (md *KVMetadata) Deleted() bool {
+	return md != nil && md.deleted
+}
+
+// hiddenAt returns true if the entry is not visible at the given time, either because it was deleted or it already expired
+func (md *KVMetadata) hiddenAt(t time.Time) bool {
+	return md.Deleted() || md.ExpiredAt(t)
+}
+
+// IndexedBy adds the entry to the secondary index name under the given tag
+func (md *KVMetadata) IndexedBy(name string, tag []byte) (*KVMetadata, error) {
+	return md.addIndex(KVIndex{Name: name, Tag: append([]byte{}, tag...)})
+}
+
+// IndexedByValuePrefix adds the entry to the secondary index name under the first prefixLen bytes of its value
+func (md *KVMetadata) IndexedByValuePrefix(name string, prefixLen int) (*KVMetadata, error) {
+	return md.addIndex(KVIndex{Name: name, ValuePrefixLen: prefixLen})
+}
+
+func (md *KVMetadata) addIndex(index KVIndex) (*KVMetadata, error) {
+	if !index.valid() || len(md.indexes) == MaxKVIndexes {
+		return nil, ErrIllegalArguments
+	}
+
+	for _, i := range md.indexes {
+		if i.equal(index) {
+			return nil, ErrIllegalArguments
+		}
+	}
+
+	md.indexes = append(md.indexes, index)
+
+	return md, nil
+}
+
+// Indexes returns the secondary index entries declared by the entry
+func (md *KVMetadata) Indexes() []KVIndex {
+	if md == nil {
+		return nil
+	}
+
+	indexes := make([]KVIndex, len(md.indexes))
+
+	for i, index := range md.indexes {
+		indexes[i] = index
+
+		if index.Tag != nil {
+			indexes[i].Tag = append([]byte{}, index.Tag...)
+		}
+	}
+
+	return indexes
+}
+
+func (md *KVMetadata) Equal(other *KVMetadata) bool {
+	if md == nil || other == nil {
+		return md == other
+	}
+
+	if len(md.indexes) != len(other.indexes) {
+		return false
+	}
+
+	for i := range md.indexes {
+		if !md.indexes[i].equal(other.indexes[i]) {
+			return false
+		}
+	}
+
+	return md.expirable == other.expirable && md.expiresAt.Equal(other.expiresAt) && md.deleted == other.deleted
+}
+
+// Bytes returns the serialized metadata, only set attributes are included
+func (md *KVMetadata) Bytes() []byte {
+	if md == nil {
+		return nil
+	}
+
+	var b []byte
+
+	if md.expirable {
+		var ab [expiresAtAttrSize]byte
+		ab[0] = expiresAtAttrCode
+		binary.BigEndian.PutUint64(ab[1:], uint64(md.expiresAt.Unix()))
+		b = append(b, ab[:]...)
+	}
+
+	if len(md.indexes) > 0 {
+		b = append(b, indexesAttrCode, byte(len(md.indexes)))
+
+		for _, index := range md.indexes {
+			b = append(b, byte(len(index.Name)))
+			b = append(b, index.Name...)
+
+			if index.ValuePrefixLen > 0 {
+				b = append(b, valuePrefixIndexKind, byte(index.ValuePrefixLen))
+			} else {
+				b = append(b, tagIndexKind, byte(len(index.Tag)))
+				b = append(b, index.Tag...)
+			}
+		}
+	}
+
+	if md.deleted {
+		b = append(b, deletedAttrCode)
+	}
+
+	return b
+}
+
+func (md *KVMetadata) ReadFrom(b []byte) error {
+	if len(b) > MaxKVMetadataLen {
+		return ErrCorruptedKVMetadata
+	}
+
+	md.NonExpirable()
+	md.indexes = nil
+	md.deleted = false
+
+	i := 0
+
+	for i < len(b) {
+		switch b[i] {
+		case expiresAtAttrCode:
+			{
+				if len(b) < i+expiresAtAttrSize {
+					return ErrCorruptedKVMetadata
+				}
+
+				md.ExpiresAt(time.Unix(int64(binary.BigEndian.Uint64(b[i+1:])), 0))
+				i += expiresAtAttrSize
+			}
+		case indexesAttrCode:
+			{
+				n, err := md.readIndexesFrom(b[i+1:])
+				if err != nil {
+					return err
+				}
+
+				i += 1 + n
+			}
+		case deletedAttrCode:
+			{
+				if md.deleted {
+					return ErrCorruptedKVMetadata
+				}
+
+				md.deleted = true
+				i += deletedAttrSize
+			}
+		default:
+			return ErrCorruptedKVMetadata
+		}
+	}
+
+	return nil
+}
+
+// readIndexesFrom reads the indexes attribute, returning the number of bytes read
+func (md *KVMetadata) readIndexesFrom(b []byte) (int, error) {
+	if len(b) < 1 || md.indexes != nil {
+		return 0, ErrCorruptedKVMetadata
+	}
+
+	count := int(b[0])
+	if count == 0 || count > MaxKVIndexes {
+		return 0, ErrCorruptedKVMetadata
+	}
+
+	i := 1
+
+	for j := 0; j < count; j++ {
+		if len(b) < i+1 {
+			return 0, ErrCorruptedKVMetadata
+		}
+
+		nameLen := int(b[i])
+		i++
+
+		if len(b) < i+nameLen+2 {
+			return 0, ErrCorruptedKVMetadata
+		}
+
+		index := KVIndex{Name: string(b[i : i+nameLen])}
+		i += nameLen
+
+		kind := b[i]
+		l := int(b[i+1])
+		i += 2
+
+		switch kind {
+		case tagIndexKind:
+			{
+				if len(b) < i+l {
+					return 0, ErrCorruptedKVMetadata
+				}
+
+				index.Tag = append([]byte{}, b[i:i+l]...)
+				i += l
+			}
+		case valuePrefixIndexKind:
+			{
+				index.ValuePrefixLen = l
+			}
+		default:
+			return 0, ErrCorruptedKVMetadata
+		}
+
+		_, err := md.addIndex(index)
+		if err != nil {
+			return 0, ErrCorruptedKVMetadata
+		}
+	}
+
+	return i, nil
+}
+
+func kvMetadataFrom(b []byte) (*KVMetadata, error) {
+	if len(b) == 0 {
+		return nil, nil
+	}
+
+	md := NewKVMetadata()
+
+	err := md.ReadFrom(b)
+	if err != nil {
+		return nil, err
+	}
+
+	return md, nil
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package store
+
+import (
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKVMetadata(t *testing.T) {
+	now := time.Now()
+
+	var nilMd *KVMetadata
+	require.False(t, nilMd.IsExpirable())
+	require.False(t, nilMd.ExpiredAt(now))
+	require.Nil(t, nilMd.Bytes())
+
+	md := NewKVMetadata()
+	require.False(t, md.IsExpirable())
+	require.Empty(t, md.Bytes())
+
+	_, err := md.ExpirationTime()
+	require.Equal(t, ErrNonExpirable, err)
+
+	md.ExpiresAt(now)
+	require.True(t, md.IsExpirable())
+	require.True(t, md.ExpiredAt(now))
+	require.False(t, md.ExpiredAt(now.Add(-1*time.Second)))
+
+	expTime, err := md.ExpirationTime()
+	require.NoError(t, err)
+	require.Equal(t, now.Unix(), expTime.Unix())
+
+	bs := md.Bytes()
+	require.Len(t, bs, expiresAtAttrSize)
+
+	decodedMd := NewKVMetadata()
+	err = decodedMd.ReadFrom(bs)
+	require.NoError(t, err)
+	require.True(t, md.Equal(decodedMd))
+
+	err = decodedMd.ReadFrom(bs[:len(bs)-1])
+	require.Equal(t, ErrCorruptedKVMetadata, err)
+
+	err = decodedMd.ReadFrom([]byte{0xff})
+	require.Equal(t, ErrCorruptedKVMetadata, err)
+
+	err = decodedMd.ReadFrom(make([]byte, MaxKVMetadataLen+1))
+	require.Equal(t, ErrCorruptedKVMetadata, err)
+
+	md.NonExpirable()
+	require.False(t, md.IsExpirable())
+	require.False(t, md.Equal(nil))
+	require.True(t, nilMd.Equal(nil))
+}
+
+func TestKVMetadataIndexes(t *testing.T) {
+	var nilMd *KVMetadata
+	require.Nil(t, nilMd.Indexes())
+
+	md := NewKVMetadata()
+
+	_, err := md.IndexedBy("tags", nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = md.IndexedBy("invalid/name", []byte("tag1"))
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = md.IndexedBy("tags", make([]byte, MaxKVIndexKeyLen+1))
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = md.IndexedByValuePrefix("prefix", 0)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = md.IndexedByValuePrefix("prefix", MaxKVIndexKeyLen+1)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	md.ExpiresAt(time.Now())
+
+	_, err = md.IndexedBy("tags", []byte("tag1"))
+	require.NoError(t, err)
+
+	_, err = md.IndexedBy("tags", []byte("tag1"))
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = md.IndexedBy("tags", []byte("tag2"))
+	require.NoError(t, err)
+
+	_, err = md.IndexedByValuePrefix("prefix", 4)
+	require.NoError(t, err)
+
+	_, err = md.IndexedBy("tags", []byte("tag3"))
+	require.NoError(t, err)
+
+	_, err = md.IndexedBy("tags", []byte("tag4"))
+	require.Equal(t, ErrIllegalArguments, err)
+
+	indexes := md.Indexes()
+	require.Len(t, indexes, MaxKVIndexes)
+	require.Equal(t, KVIndex{Name: "tags", Tag: []byte("tag1")}, indexes[0])
+	require.Equal(t, KVIndex{Name: "prefix", ValuePrefixLen: 4}, indexes[2])
+
+	bs := md.Bytes()
+
+	decodedMd := NewKVMetadata()
+	err = decodedMd.ReadFrom(bs)
+	require.NoError(t, err)
+	require.True(t, md.Equal(decodedMd))
+	require.True(t, decodedMd.IsExpirable())
+
+	require.False(t, md.Equal(NewKVMetadata().ExpiresAt(time.Now())))
+
+	for i := expiresAtAttrSize + 1; i < len(bs); i++ {
+		err = decodedMd.ReadFrom(bs[:i])
+		require.Equal(t, ErrCorruptedKVMetadata, err)
+	}
+
+	// indexes attribute can't be repeated
+	err = decodedMd.ReadFrom(append(bs, bs[expiresAtAttrSize:]...))
+	require.Equal(t, ErrCorruptedKVMetadata, err)
+
+	largestMd := NewKVMetadata().ExpiresAt(time.Now()).AsDeleted(true)
+	for i := 0; i < MaxKVIndexes; i++ {
+		name := make([]byte, MaxKVIndexNameLen)
+		for j := range name {
+			name[j] = 'a' + byte(i)
+		}
+
+		_, err = largestMd.IndexedBy(string(name), make([]byte, MaxKVIndexKeyLen))
+		require.NoError(t, err)
+	}
+	require.Len(t, largestMd.Bytes(), MaxKVMetadataLen)
+}
+
+func TestKVMetadataDeleted(t *testing.T) {
+	var nilMd *KVMetadata
+	require.False(t, nilMd.Deleted())
+
+	md := NewKVMetadata().AsDeleted(true)
+	require.True(t, md.Deleted())
+	require.True(t, md.hiddenAt(time.Now()))
+	require.False(t, md.Equal(NewKVMetadata()))
+
+	bs := md.Bytes()
+	require.Len(t, bs, deletedAttrSize)
+
+	decodedMd := NewKVMetadata()
+	err := decodedMd.ReadFrom(bs)
+	require.NoError(t, err)
+	require.True(t, decodedMd.Deleted())
+	require.True(t, md.Equal(decodedMd))
+
+	// deleted attribute can't be repeated
+	err = decodedMd.ReadFrom(append(bs, bs...))
+	require.Equal(t, ErrCorruptedKVMetadata, err)
+
+	md.AsDeleted(false)
+	require.False(t, md.Deleted())
+	require.Empty(t, md.Bytes())
+}
+
+func TestEntryDigestWithMetadata(t *testing.T) {
+	kv := &KV{Key: []byte("key"), Value: []byte("value")}
+	legacyDigest := kv.Digest()
+
+	kv.Metadata = NewKVMetadata()
+	require.Equal(t, legacyDigest, kv.Digest())
+
+	kv.Metadata.ExpiresAt(time.Now())
+	require.NotEqual(t, legacyDigest, kv.Digest())
+
+	e := NewTxEntry(kv.Key, kv.Metadata, len(kv.Value), sha256.Sum256(kv.Value), 0)
+	require.Equal(t, kv.Digest(), e.Digest())
+}
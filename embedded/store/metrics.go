@@ -0,0 +1,129 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package store
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// CommitLatencyBuckets are the upper bounds of the buckets of the commit latency histogram
+var CommitLatencyBuckets = []time.Duration{
+	time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	5 * time.Second,
+}
+
+// Metrics holds the internal gauges of the store, counters are accumulated since the store was opened
+type Metrics struct {
+	// transaction ids are sequential thus it's also the number of committed transactions
+	CommittedTxID uint64
+	IndexedTxID   uint64
+
+	// reads of transactions served by the tx cache, used to calculate accumulated linear hashes and proofs
+	TxCacheHits   uint64
+	TxCacheMisses uint64
+
+	CommitLatency LatencyHistogram
+
+	ActiveSnapshots int
+
+	OrphanedValuesSize  int64
+	ReclaimedValuesSize int64
+
+	// state of the ongoing compaction of the index, if any
+	IndexCompactionInProgress   bool
+	IndexCompactionWrittenBytes int64
+}
+
+// IndexingLag returns the number of committed transactions not yet indexed
+func (m *Metrics) IndexingLag() uint64 {
+	if m.IndexedTxID >= m.CommittedTxID {
+		return 0
+	}
+	return m.CommittedTxID - m.IndexedTxID
+}
+
+// LatencyHistogram holds the number of observations within each of the CommitLatencyBuckets
+type LatencyHistogram struct {
+	// cumulative counts, BucketCounts[i] is the number of observations lower or equal to CommitLatencyBuckets[i]
+	BucketCounts []uint64
+	Count        uint64
+	Sum          time.Duration
+}
+
+type latencyHistogram struct {
+	counts []uint64 // one per bucket plus one for observations exceeding all of them
+	count  uint64
+	sum    int64
+}
+
+func newLatencyHistogram() *latencyHistogram {
+	return &latencyHistogram{counts: make([]uint64, len(CommitLatencyBuckets)+1)}
+}
+
+func (h *latencyHistogram) observe(d time.Duration) {
+	i := 0
+	for i < len(CommitLatencyBuckets) && d > CommitLatencyBuckets[i] {
+		i++
+	}
+
+	atomic.AddUint64(&h.counts[i], 1)
+	atomic.AddInt64(&h.sum, int64(d))
+	atomic.AddUint64(&h.count, 1)
+}
+
+func (h *latencyHistogram) snapshot() LatencyHistogram {
+	bucketCounts := make([]uint64, len(CommitLatencyBuckets))
+
+	var acc uint64
+	for i := range bucketCounts {
+		acc += atomic.LoadUint64(&h.counts[i])
+		bucketCounts[i] = acc
+	}
+
+	return LatencyHistogram{
+		BucketCounts: bucketCounts,
+		Count:        atomic.LoadUint64(&h.count),
+		Sum:          time.Duration(atomic.LoadInt64(&h.sum)),
+	}
+}
+
+// Metrics returns the current values of the internal gauges of the store
+func (s *ImmuStore) Metrics() *Metrics {
+	committedTxID, _, _ := s.commitState()
+	orphaned, reclaimed := s.OrphanedValuesSize()
+	compaction := s.IndexCompactionProgress()
+
+	return &Metrics{
+		CommittedTxID:       committedTxID,
+		IndexedTxID:         s.indexer.Ts(),
+		TxCacheHits:         atomic.LoadUint64(&s.txCacheHits),
+		TxCacheMisses:       atomic.LoadUint64(&s.txCacheMisses),
+		CommitLatency:       s.commitLatency.snapshot(),
+		ActiveSnapshots:     s.indexer.ActiveSnapshots(),
+		OrphanedValuesSize:  orphaned,
+		ReclaimedValuesSize: reclaimed,
+
+		IndexCompactionInProgress:   compaction.InProgress,
+		IndexCompactionWrittenBytes: compaction.WrittenBytes,
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package store
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreMetrics(t *testing.T) {
+	defer os.RemoveAll("data_metrics")
+
+	immuStore, err := Open("data_metrics", DefaultOptions().WithSynced(false).WithTxLogCacheSize(1))
+	require.NoError(t, err)
+	defer immuStore.Close()
+
+	m := immuStore.Metrics()
+	require.Zero(t, m.CommittedTxID)
+	require.Zero(t, m.IndexingLag())
+	require.Zero(t, m.CommitLatency.Count)
+	require.Len(t, m.CommitLatency.BucketCounts, len(CommitLatencyBuckets))
+
+	for i := 0; i < 3; i++ {
+		_, err = immuStore.Commit([]*KV{{Key: []byte("key"), Value: []byte("value")}}, false)
+		require.NoError(t, err)
+	}
+
+	err = immuStore.WaitForIndexingUpto(context.Background(), 3)
+	require.NoError(t, err)
+
+	tx := immuStore.NewTx()
+
+	// only the latest tx is kept in the cache
+	err = immuStore.ReadTx(3, tx)
+	require.NoError(t, err)
+
+	err = immuStore.ReadTx(1, tx)
+	require.NoError(t, err)
+
+	snap, err := immuStore.Snapshot()
+	require.NoError(t, err)
+
+	m = immuStore.Metrics()
+	require.Equal(t, uint64(3), m.CommittedTxID)
+	require.Equal(t, uint64(3), m.IndexedTxID)
+	require.Zero(t, m.IndexingLag())
+	require.Equal(t, 1, m.ActiveSnapshots)
+	require.Equal(t, uint64(3), m.CommitLatency.Count)
+	require.Equal(t, uint64(3), m.CommitLatency.BucketCounts[len(CommitLatencyBuckets)-1])
+	require.NotZero(t, m.CommitLatency.Sum)
+
+	// reads done while indexing are accounted as well
+	require.GreaterOrEqual(t, m.TxCacheHits, uint64(1))
+	require.GreaterOrEqual(t, m.TxCacheMisses, uint64(1))
+
+	err = snap.Close()
+	require.NoError(t, err)
+
+	require.Zero(t, immuStore.Metrics().ActiveSnapshots)
+}
+
+func TestLatencyHistogram(t *testing.T) {
+	h := newLatencyHistogram()
+
+	h.observe(0)
+	h.observe(CommitLatencyBuckets[1])
+	h.observe(CommitLatencyBuckets[1] + 1)
+	h.observe(time.Hour)
+
+	s := h.snapshot()
+	require.Equal(t, uint64(4), s.Count)
+	require.Equal(t, uint64(1), s.BucketCounts[0])
+	require.Equal(t, uint64(2), s.BucketCounts[1])
+	require.Equal(t, uint64(3), s.BucketCounts[2])
+	require.Equal(t, uint64(3), s.BucketCounts[len(CommitLatencyBuckets)-1])
+	require.Equal(t, 2*CommitLatencyBuckets[1]+1+time.Hour, s.Sum)
+}
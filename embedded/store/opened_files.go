@@ -0,0 +1,102 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package store
+
+import "github.com/codenotary/immudb/embedded/appendable"
+
+// filesCache is implemented by appendables keeping their files open, e.g. multi-file appendables
+type filesCache interface {
+	Path() string
+	MaxOpenedFiles() int
+	OpenedFiles() ([]string, error)
+	CloseCachedFiles() (int, error)
+}
+
+// LogFiles reports the files a log of the store holds open
+type LogFiles struct {
+	Path        string
+	OpenedFiles []string
+	// max number of files kept open for reading besides the one being appended
+	MaxOpenedFiles int
+}
+
+func (s *ImmuStore) filesCaches() []filesCache {
+	apps := []appendable.Appendable{s.txLog, s.cLog}
+
+	for i := 0; i < len(s.vLogs); i++ {
+		apps = append(apps, s.vLogs[byte(i)].vLog)
+	}
+
+	var caches []filesCache
+
+	for _, app := range apps {
+		if c, ok := app.(filesCache); ok {
+			caches = append(caches, c)
+		}
+	}
+
+	return caches
+}
+
+// OpenedFiles returns the files currently open by the tx, commit and value logs
+func (s *ImmuStore) OpenedFiles() ([]*LogFiles, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.closed {
+		return nil, ErrAlreadyClosed
+	}
+
+	var logs []*LogFiles
+
+	for _, c := range s.filesCaches() {
+		files, err := c.OpenedFiles()
+		if err != nil {
+			return nil, err
+		}
+
+		logs = append(logs, &LogFiles{
+			Path:           c.Path(),
+			OpenedFiles:    files,
+			MaxOpenedFiles: c.MaxOpenedFiles(),
+		})
+	}
+
+	return logs, nil
+}
+
+// CloseCachedFiles closes the files the logs keep open for reading and returns how many of them were closed.
+// Files being appended remain open while the others get reopened when read again
+func (s *ImmuStore) CloseCachedFiles() (int, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.closed {
+		return 0, ErrAlreadyClosed
+	}
+
+	closed := 0
+
+	for _, c := range s.filesCaches() {
+		n, err := c.CloseCachedFiles()
+		closed += n
+		if err != nil {
+			return closed, err
+		}
+	}
+
+	return closed, nil
+}
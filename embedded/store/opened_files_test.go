@@ -0,0 +1,94 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package store
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestImmudbStoreOpenedFiles(t *testing.T) {
+	opts := DefaultOptions().
+		WithSynced(false).
+		WithMaxConcurrency(1).
+		WithMaxIOConcurrency(1).
+		WithFileSize(64)
+
+	immuStore, err := Open("data_opened_files", opts)
+	require.NoError(t, err)
+	defer os.RemoveAll("data_opened_files")
+
+	txCount := 20
+
+	for i := 1; i <= txCount; i++ {
+		_, err = immuStore.Commit([]*KV{
+			{Key: []byte(fmt.Sprintf("key%d", i)), Value: []byte(fmt.Sprintf("value%11d", i))},
+		}, true)
+		require.NoError(t, err)
+	}
+
+	logs, err := immuStore.OpenedFiles()
+	require.NoError(t, err)
+	require.Len(t, logs, 3)
+
+	require.Equal(t, filepath.Join("data_opened_files", "tx"), logs[0].Path)
+	require.Equal(t, opts.TxLogMaxOpenedFiles, logs[0].MaxOpenedFiles)
+	require.Equal(t, filepath.Join("data_opened_files", "commit"), logs[1].Path)
+	require.Equal(t, opts.CommitLogMaxOpenedFiles, logs[1].MaxOpenedFiles)
+	require.Equal(t, filepath.Join("data_opened_files", "val_0"), logs[2].Path)
+	require.Equal(t, opts.VLogMaxOpenedFiles, logs[2].MaxOpenedFiles)
+
+	for _, l := range logs {
+		require.NotEmpty(t, l.OpenedFiles)
+		require.LessOrEqual(t, len(l.OpenedFiles), l.MaxOpenedFiles+1)
+	}
+
+	closed, err := immuStore.CloseCachedFiles()
+	require.NoError(t, err)
+	require.Greater(t, closed, 0)
+
+	logs, err = immuStore.OpenedFiles()
+	require.NoError(t, err)
+
+	for _, l := range logs {
+		require.Len(t, l.OpenedFiles, 1)
+	}
+
+	// closed files are reopened when read again
+	tx := immuStore.NewTx()
+
+	for i := 1; i <= txCount; i++ {
+		err = immuStore.ReadTx(uint64(i), tx)
+		require.NoError(t, err)
+
+		val, err := immuStore.ReadValue(tx, []byte(fmt.Sprintf("key%d", i)))
+		require.NoError(t, err)
+		require.Equal(t, []byte(fmt.Sprintf("value%11d", i)), val)
+	}
+
+	err = immuStore.Close()
+	require.NoError(t, err)
+
+	_, err = immuStore.OpenedFiles()
+	require.Equal(t, ErrAlreadyClosed, err)
+
+	_, err = immuStore.CloseCachedFiles()
+	require.Equal(t, ErrAlreadyClosed, err)
+}
@@ -37,6 +37,13 @@ const DefaultCompressionFormat = appendable.DefaultCompressionFormat
 const DefaultCompressionLevel = appendable.DefaultCompressionLevel
 const DefaultTxLogCacheSize = 1000
 const DefaultMaxWaitees = 1000
+const DefaultIndexingMaxBulkSize = 10
+const DefaultVacuumInterval = time.Minute
+const DefaultVacuumBatchSize = 1000
+const DefaultIndexingConcurrency = 4
+const DefaultInlineValueThld = 0
+
+const MaxInlineValueThld = 1024
 
 const MaxFileSize = (1 << 31) - 1 // 2Gb
 
@@ -58,6 +65,35 @@ type Options struct {
 
 	MaxWaitees int
 
+	// values are encrypted using AES-GCM when a 16, 24 or 32 bytes key is provided,
+	// the key is not stored, it must be provided every time the store is opened
+	EncryptionKey []byte
+
+	// values of transactions older than the retention period are discarded when vacuuming, current values of keys are
+	// committed again so they are kept. Zero means no retention limit
+	RetentionPeriod time.Duration
+
+	// values out of the retention period are discarded in background every vacuum interval,
+	// zero disables periodic vacuuming
+	VacuumInterval time.Duration
+
+	// maximum number of transactions whose values are discarded at once while vacuuming,
+	// bounding the number of live values relocated in a row
+	VacuumBatchSize int
+
+	// TimeFunc provides the timestamp of new transactions, time.Now is used when not set
+	TimeFunc TimeFunc
+
+	// OnCorruption is invoked as soon as an inconsistency is detected e.g. while building proofs or indexing,
+	// before the error is returned to the caller
+	OnCorruption CorruptionHandler
+
+	// directories where the tx and commit logs, the value logs and the index are placed instead of the directory
+	// of the store e.g. to keep them on different devices. They must be provided every time the store is opened
+	TxLogDir    string
+	ValueLogDir string
+	IndexDir    string
+
 	// options below are only set during initialization and stored as metadata
 	MaxTxEntries      int
 	MaxKeyLen         int
@@ -66,10 +102,21 @@ type Options struct {
 	CompressionFormat int
 	CompressionLevel  int
 
+	// values up to this length are stored within their tx in the tx log instead of the value log,
+	// saving a write and a read for small values. Zero disables it, txs holding inline values can't
+	// be read by versions not supporting them
+	InlineValueThld int
+
 	// options below affect indexing
 	IndexOpts *IndexOptions
 }
 
+type TimeFunc func() time.Time
+
+// CorruptionHandler receives the operation during which an inconsistency was detected and the error describing it,
+// errors.Is and errors.As can be used to get the corrupted tx or log position. It must not block.
+type CorruptionHandler func(op string, err error)
+
 type IndexOptions struct {
 	CacheSize             int
 	FlushThld             int
@@ -78,6 +125,24 @@ type IndexOptions struct {
 	RenewSnapRootAfter    time.Duration
 	CompactionThld        int
 	DelayDuringCompaction time.Duration
+	BloomFilterBitsPerKey int
+
+	// maximum number of transactions indexed in a row, indexing can be paused or stopped in between
+	MaxBulkSize int
+
+	// number of goroutines reading and preparing transactions to be indexed,
+	// entries are inserted into the index following the order of transactions
+	Concurrency int
+
+	// auxiliary indexes sorting entries by the key returned by the extractor, by index name.
+	// Extractors are not stored, they must be provided every time the store is opened
+	ExpressionIndexes map[string]KeyExtractor
+
+	// maintain the secondary indexes declared in the metadata of entries, see KVMetadata.IndexedBy
+	SecondaryIndexes bool
+
+	// number of leading bytes of values skipped by value prefix indexes e.g. a marker prepended to every value
+	ValuePrefixOffset int
 }
 
 func DefaultOptions() *Options {
@@ -99,6 +164,9 @@ func DefaultOptions() *Options {
 
 		MaxWaitees: DefaultMaxWaitees,
 
+		VacuumInterval:  DefaultVacuumInterval,
+		VacuumBatchSize: DefaultVacuumBatchSize,
+
 		// options below are only set during initialization and stored as metadata
 		MaxTxEntries:      DefaultMaxTxEntries,
 		MaxKeyLen:         DefaultMaxKeyLen,
@@ -106,6 +174,7 @@ func DefaultOptions() *Options {
 		FileSize:          DefaultFileSize,
 		CompressionFormat: DefaultCompressionFormat,
 		CompressionLevel:  DefaultCompressionLevel,
+		InlineValueThld:   DefaultInlineValueThld,
 
 		IndexOpts: DefaultIndexOptions(),
 	}
@@ -120,6 +189,9 @@ func DefaultIndexOptions() *IndexOptions {
 		RenewSnapRootAfter:    time.Duration(1000) * time.Millisecond,
 		CompactionThld:        tbtree.DefaultCompactionThld,
 		DelayDuringCompaction: 0,
+		BloomFilterBitsPerKey: 0,
+		MaxBulkSize:           DefaultIndexingMaxBulkSize,
+		Concurrency:           DefaultIndexingConcurrency,
 	}
 }
 
@@ -138,13 +210,22 @@ func validOptions(opts *Options) bool {
 
 		opts.MaxWaitees >= 0 &&
 
+		opts.RetentionPeriod >= 0 &&
+		opts.VacuumInterval >= 0 &&
+		opts.VacuumBatchSize > 0 &&
+
+		validEncryptionKey(opts.EncryptionKey) &&
+
 		// options below are only set during initialization and stored as metadata
 		opts.MaxTxEntries > 0 &&
+		opts.MaxTxEntries <= MaxTxEntries &&
 		opts.MaxKeyLen > 0 &&
 		opts.MaxKeyLen <= MaxKeyLen &&
 		opts.MaxValueLen > 0 &&
 		opts.FileSize > 0 &&
 		opts.FileSize < MaxFileSize &&
+		opts.InlineValueThld >= 0 &&
+		opts.InlineValueThld <= MaxInlineValueThld &&
 		opts.log != nil &&
 		validIndexOptions(opts.IndexOpts)
 }
@@ -155,7 +236,12 @@ func validIndexOptions(opts *IndexOptions) bool {
 		opts.FlushThld > 0 &&
 		opts.MaxActiveSnapshots > 0 &&
 		opts.MaxNodeSize > 0 &&
-		opts.RenewSnapRootAfter >= 0
+		opts.RenewSnapRootAfter >= 0 &&
+		opts.BloomFilterBitsPerKey >= 0 &&
+		opts.MaxBulkSize > 0 &&
+		opts.Concurrency > 0 &&
+		opts.ValuePrefixOffset >= 0 &&
+		validExpressionIndexes(opts.ExpressionIndexes)
 }
 
 func (opts *Options) WithReadOnly(readOnly bool) *Options {
@@ -203,6 +289,11 @@ func (opts *Options) WithMaxValueLen(maxValueLen int) *Options {
 	return opts
 }
 
+func (opts *Options) WithInlineValueThld(inlineValueThld int) *Options {
+	opts.InlineValueThld = inlineValueThld
+	return opts
+}
+
 func (opts *Options) WithMaxLinearProofLen(maxLinearProofLen int) *Options {
 	opts.MaxLinearProofLen = maxLinearProofLen
 	return opts
@@ -238,6 +329,59 @@ func (opts *Options) WithMaxWaitees(maxWaitees int) *Options {
 	return opts
 }
 
+func (opts *Options) WithEncryptionKey(encryptionKey []byte) *Options {
+	opts.EncryptionKey = encryptionKey
+	return opts
+}
+
+func (opts *Options) WithRetentionPeriod(retentionPeriod time.Duration) *Options {
+	opts.RetentionPeriod = retentionPeriod
+	return opts
+}
+
+func (opts *Options) WithVacuumInterval(vacuumInterval time.Duration) *Options {
+	opts.VacuumInterval = vacuumInterval
+	return opts
+}
+
+func (opts *Options) WithVacuumBatchSize(vacuumBatchSize int) *Options {
+	opts.VacuumBatchSize = vacuumBatchSize
+	return opts
+}
+
+func (opts *Options) WithTimeFunc(timeFunc TimeFunc) *Options {
+	opts.TimeFunc = timeFunc
+	return opts
+}
+
+func (opts *Options) WithOnCorruption(handler CorruptionHandler) *Options {
+	opts.OnCorruption = handler
+	return opts
+}
+
+func (opts *Options) WithTxLogDir(dir string) *Options {
+	opts.TxLogDir = dir
+	return opts
+}
+
+func (opts *Options) WithValueLogDir(dir string) *Options {
+	opts.ValueLogDir = dir
+	return opts
+}
+
+func (opts *Options) WithIndexDir(dir string) *Options {
+	opts.IndexDir = dir
+	return opts
+}
+
+// dirFor returns the directory where the files placed in dir are located, the store path if dir is not set
+func dirFor(dir, path string) string {
+	if dir == "" {
+		return path
+	}
+	return dir
+}
+
 func (opts *Options) WithCompressionFormat(compressionFormat int) *Options {
 	opts.CompressionFormat = compressionFormat
 	return opts
@@ -289,3 +433,40 @@ func (opts *IndexOptions) WithDelayDuringCompaction(delayDuringCompaction time.D
 	opts.DelayDuringCompaction = delayDuringCompaction
 	return opts
 }
+
+func (opts *IndexOptions) WithBloomFilterBitsPerKey(bitsPerKey int) *IndexOptions {
+	opts.BloomFilterBitsPerKey = bitsPerKey
+	return opts
+}
+
+func (opts *IndexOptions) WithMaxBulkSize(maxBulkSize int) *IndexOptions {
+	opts.MaxBulkSize = maxBulkSize
+	return opts
+}
+
+func (opts *IndexOptions) WithConcurrency(concurrency int) *IndexOptions {
+	opts.Concurrency = concurrency
+	return opts
+}
+
+// WithExpressionIndex registers an auxiliary index named name, sorting entries by the key returned by extractor
+func (opts *IndexOptions) WithExpressionIndex(name string, extractor KeyExtractor) *IndexOptions {
+	if opts.ExpressionIndexes == nil {
+		opts.ExpressionIndexes = make(map[string]KeyExtractor)
+	}
+	opts.ExpressionIndexes[name] = extractor
+	return opts
+}
+
+// WithSecondaryIndexes sets if the secondary indexes declared in the metadata of entries are maintained.
+// When enabled on an existing store, previous transactions are indexed as well
+func (opts *IndexOptions) WithSecondaryIndexes(secondaryIndexes bool) *IndexOptions {
+	opts.SecondaryIndexes = secondaryIndexes
+	return opts
+}
+
+// WithValuePrefixOffset sets the number of leading bytes of values skipped by value prefix indexes
+func (opts *IndexOptions) WithValuePrefixOffset(valuePrefixOffset int) *IndexOptions {
+	opts.ValuePrefixOffset = valuePrefixOffset
+	return opts
+}
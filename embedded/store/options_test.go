@@ -45,10 +45,16 @@ func TestValidOptions(t *testing.T) {
 	require.Equal(t, DefaultMaxLinearProofLen, opts.WithMaxLinearProofLen(DefaultMaxLinearProofLen).MaxLinearProofLen)
 	require.Equal(t, DefaultMaxTxEntries, opts.WithMaxTxEntries(DefaultMaxTxEntries).MaxTxEntries)
 	require.Equal(t, DefaultMaxValueLen, opts.WithMaxValueLen(DefaultMaxValueLen).MaxValueLen)
+	require.Equal(t, 64, opts.WithInlineValueThld(64).InlineValueThld)
 	require.Equal(t, DefaultTxLogCacheSize, opts.WithTxLogCacheSize(DefaultOptions().TxLogCacheSize).TxLogCacheSize)
 	require.Equal(t, 2, opts.WithTxLogMaxOpenedFiles(2).TxLogMaxOpenedFiles)
 	require.Equal(t, 3, opts.WithVLogMaxOpenedFiles(3).VLogMaxOpenedFiles)
 	require.Equal(t, DefaultMaxWaitees, opts.WithMaxWaitees(DefaultMaxWaitees).MaxWaitees)
+	require.Equal(t, time.Hour, opts.WithRetentionPeriod(time.Hour).RetentionPeriod)
+	require.Equal(t, time.Second, opts.WithVacuumInterval(time.Second).VacuumInterval)
+	require.Equal(t, 10, opts.WithVacuumBatchSize(10).VacuumBatchSize)
+	require.NotNil(t, opts.WithTimeFunc(time.Now).TimeFunc)
+	require.Equal(t, []byte("0123456789abcdef"), opts.WithEncryptionKey([]byte("0123456789abcdef")).EncryptionKey)
 
 	require.True(t, opts.WithSynced(true).Synced)
 
@@ -63,6 +69,9 @@ func TestValidOptions(t *testing.T) {
 	require.True(t, opts.WithReadOnly(true).ReadOnly)
 	require.True(t, validOptions(opts))
 
+	require.False(t, validOptions(opts.WithInlineValueThld(MaxInlineValueThld+1)))
+	opts.WithInlineValueThld(MaxInlineValueThld)
+
 	require.Nil(t, opts.WithIndexOptions(nil).IndexOpts)
 	require.False(t, validOptions(opts))
 
@@ -76,7 +85,11 @@ func TestValidOptions(t *testing.T) {
 	require.Equal(t, 4096, indexOpts.WithMaxNodeSize(4096).MaxNodeSize)
 	require.Equal(t, time.Duration(1000)*time.Millisecond,
 		indexOpts.WithRenewSnapRootAfter(time.Duration(1000)*time.Millisecond).RenewSnapRootAfter)
+	require.False(t, validOptions(opts))
+	require.Equal(t, DefaultIndexingMaxBulkSize, indexOpts.WithMaxBulkSize(DefaultIndexingMaxBulkSize).MaxBulkSize)
+	require.Equal(t, 2, indexOpts.WithConcurrency(2).Concurrency)
 	require.True(t, validOptions(opts))
 	require.Equal(t, 3, indexOpts.WithCompactionThld(3).CompactionThld)
 	require.Equal(t, 1*time.Millisecond, indexOpts.WithDelayDuringCompaction(1*time.Millisecond).DelayDuringCompaction)
+	require.Equal(t, 10, indexOpts.WithBloomFilterBitsPerKey(10).BloomFilterBitsPerKey)
 }
@@ -0,0 +1,204 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var ErrPreconditionFailed = errors.New("precondition failed")
+var ErrInvalidPrecondition = errors.New("invalid precondition")
+
+// Precondition is a condition over the current state of the index which must be satisfied
+// for a transaction to be committed. Preconditions are validated once all the previously
+// committed transactions have been indexed, thus they provide compare-and-set semantics.
+type Precondition interface {
+	String() string
+
+	// Validate checks the precondition is well-formed
+	Validate(st *ImmuStore) error
+
+	// Check returns ErrPreconditionFailed if the precondition is not satisfied by the current index
+	Check(idx KeyIndex) error
+}
+
+// PreconditionKeyMustNotExist is satisfied when the key does not exist or it has already expired
+type PreconditionKeyMustNotExist struct {
+	Key []byte
+}
+
+// PreconditionKeyMustHaveRevision is satisfied when the key has been set exactly Revision times
+type PreconditionKeyMustHaveRevision struct {
+	Key      []byte
+	Revision uint64
+}
+
+// PreconditionKeyNotModifiedAfterTx is satisfied when the key was not set after the transaction TxID,
+// including the case in which the key does not exist or it has already expired
+type PreconditionKeyNotModifiedAfterTx struct {
+	Key  []byte
+	TxID uint64
+}
+
+func (p *PreconditionKeyMustNotExist) String() string {
+	return fmt.Sprintf("key %q must not exist", p.Key)
+}
+
+func (p *PreconditionKeyMustNotExist) Validate(st *ImmuStore) error {
+	return validatePreconditionKey(st, p.Key)
+}
+
+func (p *PreconditionKeyMustNotExist) Check(idx KeyIndex) error {
+	_, _, _, err := idx.Get(p.Key)
+	if err == nil {
+		return fmt.Errorf("%w: %s", ErrPreconditionFailed, p)
+	}
+	if err == ErrKeyNotFound {
+		return nil
+	}
+	return err
+}
+
+func (p *PreconditionKeyMustHaveRevision) String() string {
+	return fmt.Sprintf("key %q must have revision %d", p.Key, p.Revision)
+}
+
+func (p *PreconditionKeyMustHaveRevision) Validate(st *ImmuStore) error {
+	if p.Revision == 0 {
+		return ErrInvalidPrecondition
+	}
+	return validatePreconditionKey(st, p.Key)
+}
+
+func (p *PreconditionKeyMustHaveRevision) Check(idx KeyIndex) error {
+	_, _, hc, err := idx.Get(p.Key)
+	if err == ErrKeyNotFound {
+		return fmt.Errorf("%w: %s", ErrPreconditionFailed, p)
+	}
+	if err != nil {
+		return err
+	}
+	if hc != p.Revision {
+		return fmt.Errorf("%w: %s", ErrPreconditionFailed, p)
+	}
+	return nil
+}
+
+func (p *PreconditionKeyNotModifiedAfterTx) String() string {
+	return fmt.Sprintf("key %q must not be modified after tx %d", p.Key, p.TxID)
+}
+
+func (p *PreconditionKeyNotModifiedAfterTx) Validate(st *ImmuStore) error {
+	if p.TxID == 0 {
+		return ErrInvalidPrecondition
+	}
+	return validatePreconditionKey(st, p.Key)
+}
+
+func (p *PreconditionKeyNotModifiedAfterTx) Check(idx KeyIndex) error {
+	_, tx, _, err := idx.Get(p.Key)
+	if err == ErrKeyNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if tx > p.TxID {
+		return fmt.Errorf("%w: %s", ErrPreconditionFailed, p)
+	}
+	return nil
+}
+
+func validatePreconditionKey(st *ImmuStore, key []byte) error {
+	if len(key) == 0 {
+		return ErrNullKey
+	}
+	if len(key) > st.maxKeyLen {
+		return &KeyError{Key: key, Err: ErrorMaxKeyLenExceeded}
+	}
+	return nil
+}
+
+func (s *ImmuStore) validatePreconditions(preconditions []Precondition) error {
+	if len(preconditions) > s.maxTxEntries {
+		return ErrorMaxTxEntriesLimitExceeded
+	}
+
+	for _, p := range preconditions {
+		if p == nil {
+			return ErrInvalidPrecondition
+		}
+
+		err := p.Validate(s)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// preconditionsIndex exposes the entries currently indexed, without resolving values and
+// hiding the ones already expired. Deleted entries are kept visible as a deletion is a modification of the key
+type preconditionsIndex struct {
+	st *ImmuStore
+}
+
+func (index *preconditionsIndex) Get(key []byte) (value []byte, tx uint64, hc uint64, err error) {
+	indexedVal, tx, hc, err := index.st.indexer.Get(key)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	valRef, err := index.st.valueRefFrom(indexedVal)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	if valRef.md.ExpiredAt(index.st.timeFunc()) {
+		return nil, 0, 0, ErrKeyNotFound
+	}
+
+	return nil, tx, hc, nil
+}
+
+// checkPreconditions must be called while holding the store mutex, so no other tx gets committed
+// between the moment preconditions are checked and the one the tx is appended
+func (s *ImmuStore) checkPreconditions(txID uint64, preconditions []Precondition) error {
+	if len(preconditions) == 0 {
+		return nil
+	}
+
+	if txID > 1 {
+		err := s.WaitForIndexingUpto(context.Background(), txID-1)
+		if err != nil {
+			return err
+		}
+	}
+
+	idx := &preconditionsIndex{st: s}
+
+	for _, p := range preconditions {
+		err := p.Check(idx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
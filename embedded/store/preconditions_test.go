@@ -0,0 +1,136 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package store
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestImmudbStorePreconditions(t *testing.T) {
+	immuStore, err := Open("data_preconditions", DefaultOptions().WithSynced(false))
+	require.NoError(t, err)
+	defer os.RemoveAll("data_preconditions")
+
+	defer immuStore.Close()
+
+	key := []byte("key")
+	kvs := []*KV{{Key: key, Value: []byte("value")}}
+
+	_, err = immuStore.CommitWithPreconditions(kvs, []Precondition{nil}, false)
+	require.Equal(t, ErrInvalidPrecondition, err)
+
+	_, err = immuStore.CommitWithPreconditions(kvs, []Precondition{&PreconditionKeyMustNotExist{}}, false)
+	require.Equal(t, ErrNullKey, err)
+
+	_, err = immuStore.CommitWithPreconditions(kvs, []Precondition{&PreconditionKeyMustHaveRevision{Key: key}}, false)
+	require.Equal(t, ErrInvalidPrecondition, err)
+
+	_, err = immuStore.CommitWithPreconditions(kvs, []Precondition{&PreconditionKeyNotModifiedAfterTx{Key: key}}, false)
+	require.Equal(t, ErrInvalidPrecondition, err)
+
+	_, err = immuStore.CommitWithPreconditions(kvs, []Precondition{&PreconditionKeyMustHaveRevision{Key: key, Revision: 1}}, false)
+	require.True(t, errors.Is(err, ErrPreconditionFailed))
+
+	md, err := immuStore.CommitWithPreconditions(kvs, []Precondition{
+		&PreconditionKeyMustNotExist{Key: key},
+		&PreconditionKeyNotModifiedAfterTx{Key: key, TxID: 1},
+	}, false)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), md.ID)
+
+	_, err = immuStore.CommitWithPreconditions(kvs, []Precondition{&PreconditionKeyMustNotExist{Key: key}}, false)
+	require.True(t, errors.Is(err, ErrPreconditionFailed))
+
+	md, err = immuStore.CommitWithPreconditions(kvs, []Precondition{&PreconditionKeyMustHaveRevision{Key: key, Revision: 1}}, false)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), md.ID)
+
+	_, err = immuStore.CommitWithPreconditions(kvs, []Precondition{&PreconditionKeyMustHaveRevision{Key: key, Revision: 1}}, false)
+	require.True(t, errors.Is(err, ErrPreconditionFailed))
+
+	_, err = immuStore.CommitWithPreconditions(kvs, []Precondition{&PreconditionKeyNotModifiedAfterTx{Key: key, TxID: 1}}, false)
+	require.True(t, errors.Is(err, ErrPreconditionFailed))
+
+	md, err = immuStore.CommitWithPreconditions(kvs, []Precondition{&PreconditionKeyNotModifiedAfterTx{Key: key, TxID: 2}}, false)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), md.ID)
+
+	// failed preconditions do not leave gaps in the tx log
+	require.Equal(t, uint64(3), immuStore.TxCount())
+
+	// expired entries are not visible to preconditions
+	expiringKey := []byte("expiringKey")
+
+	_, err = immuStore.Commit([]*KV{{
+		Key:      expiringKey,
+		Metadata: NewKVMetadata().ExpiresAt(time.Now()),
+		Value:    []byte("value"),
+	}}, false)
+	require.NoError(t, err)
+
+	_, err = immuStore.CommitWithPreconditions(
+		[]*KV{{Key: expiringKey, Value: []byte("value")}},
+		[]Precondition{&PreconditionKeyMustNotExist{Key: expiringKey}},
+		false,
+	)
+	require.NoError(t, err)
+}
+
+func TestImmudbStoreConcurrentPreconditions(t *testing.T) {
+	immuStore, err := Open("data_concurrent_preconditions", DefaultOptions().WithSynced(false))
+	require.NoError(t, err)
+	defer os.RemoveAll("data_concurrent_preconditions")
+
+	defer immuStore.Close()
+
+	key := []byte("key")
+
+	workers := 10
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+
+	committed := make(chan struct{}, workers)
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+
+			_, err := immuStore.CommitWithPreconditions(
+				[]*KV{{Key: key, Value: []byte("value")}},
+				[]Precondition{&PreconditionKeyMustNotExist{Key: key}},
+				false,
+			)
+			if err == nil {
+				committed <- struct{}{}
+				return
+			}
+
+			require.True(t, errors.Is(err, ErrPreconditionFailed))
+		}()
+	}
+
+	wg.Wait()
+
+	// only one of the concurrent writers is able to set the key
+	require.Len(t, committed, 1)
+}
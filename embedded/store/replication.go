@@ -0,0 +1,161 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package store
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/codenotary/immudb/embedded/appendable"
+)
+
+var ErrReplicatedTxMismatch = errors.New("replicated tx does not match the local state")
+
+// ExportTx returns the tx txID together with its values, serialized so it can be replicated into another store.
+// Values are exported in plain form, thus stores using different encryption keys can replicate each other.
+//
+// Exported tx layout: [txLen uint32][tx as stored in the tx log][value of each entry]
+func (s *ImmuStore) ExportTx(txID uint64, tx *Tx) ([]byte, error) {
+	err := s.ReadTx(txID, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	txbs := make([]byte, s.maxTxSize)
+	txSize := tx.serializeTo(txbs)
+
+	var b bytes.Buffer
+
+	var lb [szSize]byte
+	binary.BigEndian.PutUint32(lb[:], uint32(txSize))
+	b.Write(lb[:])
+	b.Write(txbs[:txSize])
+
+	for _, e := range tx.Entries() {
+		v := make([]byte, e.vLen)
+
+		_, err = s.readEntryValue(v, e)
+		if err != nil {
+			return nil, err
+		}
+
+		b.Write(v)
+	}
+
+	return b.Bytes(), nil
+}
+
+// ReplicateTx commits a tx exported from another store. The tx must be the next one to be committed,
+// it's linked to the latest committed tx and committed only if it's identical to the exported one
+// i.e. both stores hold the same history.
+func (s *ImmuStore) ReplicateTx(exportedTx []byte, waitForIndexing bool) (*TxMetadata, error) {
+	tx, values, err := s.readExportedTx(exportedTx)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*KV, tx.nentries)
+
+	for i, e := range tx.Entries() {
+		if len(values) < e.vLen {
+			return nil, ErrIllegalArguments
+		}
+
+		v := values[:e.vLen]
+		values = values[e.vLen:]
+
+		if sha256.Sum256(v) != e.hVal {
+			return nil, ErrCorruptedData
+		}
+
+		entries[i] = &KV{Key: e.Key(), Metadata: e.md, Value: v}
+	}
+
+	if len(values) > 0 {
+		return nil, ErrIllegalArguments
+	}
+
+	return s.commitEntries(entries, tx.Attributes, nil, tx, waitForIndexing)
+}
+
+// ExportedTxMetadata returns the metadata of a tx exported from another store, so the tx can be
+// verified to belong to a given history before it gets replicated
+func (s *ImmuStore) ExportedTxMetadata(exportedTx []byte) (*TxMetadata, error) {
+	tx, _, err := s.readExportedTx(exportedTx)
+	if err != nil {
+		return nil, err
+	}
+
+	return tx.Metadata(), nil
+}
+
+// readExportedTx returns the tx held by an exported tx together with the values of its entries
+func (s *ImmuStore) readExportedTx(exportedTx []byte) (*Tx, []byte, error) {
+	if len(exportedTx) < szSize {
+		return nil, nil, ErrIllegalArguments
+	}
+
+	txSize := int(binary.BigEndian.Uint32(exportedTx))
+	if txSize > s.maxTxSize || len(exportedTx) < szSize+txSize {
+		return nil, nil, ErrIllegalArguments
+	}
+
+	tx := s.NewTx()
+
+	err := tx.readFrom(appendable.NewReaderFrom(bytes.NewReader(exportedTx[szSize:]), 0, txSize))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return tx, exportedTx[szSize+txSize:], nil
+}
+
+// linkReplicatedTx sets the timestamp and binary linking of the replicated tx into the tx being committed,
+// which must have the same id and previous linear hash
+func (s *ImmuStore) linkReplicatedTx(tx, replicatedTx *Tx) error {
+	if tx.ID != replicatedTx.ID {
+		return fmt.Errorf("%w: tx %d was expected but tx %d was received", ErrReplicatedTxMismatch, tx.ID, replicatedTx.ID)
+	}
+
+	if tx.PrevAlh != replicatedTx.PrevAlh {
+		return fmt.Errorf("%w: tx %d is linked to a different history", ErrReplicatedTxMismatch, tx.ID)
+	}
+
+	if replicatedTx.BlTxID >= tx.ID {
+		return ErrUnexpectedLinkingError
+	}
+
+	// binary linking may lag behind, the root is checked if already calculated
+	if replicatedTx.BlTxID > 0 && replicatedTx.BlTxID <= s.aht.Size() {
+		blRoot, err := s.aht.RootAt(replicatedTx.BlTxID)
+		if err != nil {
+			return err
+		}
+
+		if blRoot != replicatedTx.BlRoot {
+			return fmt.Errorf("%w: tx %d is linked to a different binary linking root", ErrReplicatedTxMismatch, tx.ID)
+		}
+	}
+
+	tx.Ts = replicatedTx.Ts
+	tx.BlTxID = replicatedTx.BlTxID
+	tx.BlRoot = replicatedTx.BlRoot
+
+	return nil
+}
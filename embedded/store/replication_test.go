@@ -0,0 +1,153 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package store
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestImmudbStoreReplication(t *testing.T) {
+	primaryStore, err := Open("data_replication_primary", DefaultOptions().WithSynced(false))
+	require.NoError(t, err)
+	defer os.RemoveAll("data_replication_primary")
+
+	// values are exported in plain form so the replica may use its own encryption key
+	replicaStore, err := Open("data_replication_replica", DefaultOptions().WithSynced(false).WithEncryptionKey(make([]byte, 32)))
+	require.NoError(t, err)
+	defer os.RemoveAll("data_replication_replica")
+
+	attrs, err := NewTxAttributes().WithTraceID(make([]byte, 16))
+	require.NoError(t, err)
+
+	txCount := 10
+
+	for i := 1; i <= txCount; i++ {
+		kvs := []*KV{
+			{Key: []byte(fmt.Sprintf("key%d", i)), Value: []byte(fmt.Sprintf("value%d", i))},
+			{Key: []byte(fmt.Sprintf("mdkey%d", i)), Metadata: NewKVMetadata().ExpiresAt(time.Now().Add(time.Hour))},
+		}
+
+		var txAttrs *TxAttributes
+		if i%2 == 0 {
+			txAttrs = attrs
+		}
+
+		_, err = primaryStore.CommitWithAttributes(kvs, txAttrs, nil, false)
+		require.NoError(t, err)
+	}
+
+	tx := primaryStore.NewTx()
+
+	_, err = primaryStore.ExportTx(uint64(txCount+1), tx)
+	require.Equal(t, ErrTxNotFound, err)
+
+	exportedTx2, err := primaryStore.ExportTx(2, tx)
+	require.NoError(t, err)
+
+	_, err = replicaStore.ReplicateTx(exportedTx2, false)
+	require.True(t, errors.Is(err, ErrReplicatedTxMismatch))
+
+	for i := 1; i <= txCount; i++ {
+		exportedTx, err := primaryStore.ExportTx(uint64(i), tx)
+		require.NoError(t, err)
+
+		// exported txs can be verified before being replicated
+		exportedMd, err := replicaStore.ExportedTxMetadata(exportedTx)
+		require.NoError(t, err)
+		require.Equal(t, uint64(i), exportedMd.ID)
+		require.Equal(t, tx.PrevAlh, exportedMd.PrevAlh)
+		require.Equal(t, tx.Alh, exportedMd.Alh())
+
+		md, err := replicaStore.ReplicateTx(exportedTx, false)
+		require.NoError(t, err)
+		require.Equal(t, uint64(i), md.ID)
+		require.Equal(t, tx.Alh, md.Alh())
+	}
+
+	primaryTxID, primaryAlh := primaryStore.Alh()
+	replicaTxID, replicaAlh := replicaStore.Alh()
+	require.Equal(t, primaryTxID, replicaTxID)
+	require.Equal(t, primaryAlh, replicaAlh)
+
+	replicaTx := replicaStore.NewTx()
+
+	err = replicaStore.ReadTx(uint64(txCount), replicaTx)
+	require.NoError(t, err)
+
+	v, err := replicaStore.ReadValue(replicaTx, []byte(fmt.Sprintf("key%d", txCount)))
+	require.NoError(t, err)
+	require.Equal(t, []byte(fmt.Sprintf("value%d", txCount)), v)
+
+	// txs already replicated are rejected
+	_, err = replicaStore.ReplicateTx(exportedTx2, false)
+	require.True(t, errors.Is(err, ErrReplicatedTxMismatch))
+
+	// once histories diverge, txs are not replicated anymore
+	_, err = replicaStore.Commit([]*KV{{Key: []byte("key"), Value: []byte("replica")}}, false)
+	require.NoError(t, err)
+
+	_, err = primaryStore.Commit([]*KV{{Key: []byte("key"), Value: []byte("primary")}}, false)
+	require.NoError(t, err)
+
+	_, err = primaryStore.Commit([]*KV{{Key: []byte("key"), Value: []byte("primary")}}, false)
+	require.NoError(t, err)
+
+	exportedTx, err := primaryStore.ExportTx(uint64(txCount+2), tx)
+	require.NoError(t, err)
+
+	_, err = replicaStore.ReplicateTx(exportedTx, false)
+	require.True(t, errors.Is(err, ErrReplicatedTxMismatch))
+
+	t.Run("tampered exports", func(t *testing.T) {
+		_, err := replicaStore.ReplicateTx(nil, false)
+		require.Equal(t, ErrIllegalArguments, err)
+
+		_, err = replicaStore.ExportedTxMetadata(nil)
+		require.Equal(t, ErrIllegalArguments, err)
+
+		_, err = replicaStore.ReplicateTx(exportedTx[:len(exportedTx)-1], false)
+		require.Equal(t, ErrIllegalArguments, err)
+
+		_, err = replicaStore.ReplicateTx(append(exportedTx, 0), false)
+		require.Equal(t, ErrIllegalArguments, err)
+
+		tampered := make([]byte, len(exportedTx))
+		copy(tampered, exportedTx)
+		tampered[len(tampered)-1]++
+
+		_, err = replicaStore.ReplicateTx(tampered, false)
+		require.Equal(t, ErrCorruptedData, err)
+
+		copy(tampered, exportedTx)
+		// timestamp of the tx
+		tampered[szSize+txIDSize]++
+
+		_, err = replicaStore.ReplicateTx(tampered, false)
+		require.Equal(t, ErrorCorruptedTxData, err)
+	})
+
+	err = replicaStore.Close()
+	require.NoError(t, err)
+
+	err = primaryStore.Close()
+	require.NoError(t, err)
+}
@@ -0,0 +1,380 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package store
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+
+	"github.com/codenotary/immudb/embedded/appendable"
+	"github.com/codenotary/immudb/embedded/appendable/multiapp"
+	"github.com/codenotary/immudb/embedded/fileutil"
+)
+
+// shrinkWriter writes the compacted copy of a store.
+// All the values are written into the first value log, starting from its second file.
+// Discarded values point to the first file, which is not kept, thus they are still reported as truncated.
+type shrinkWriter struct {
+	txLog appendable.Appendable
+	cLog  appendable.Appendable
+	vLog  appendable.Appendable
+
+	tx   *Tx
+	txbs []byte
+
+	// id of the last transaction in the copy
+	copiedTxID uint64
+}
+
+type compressedAppendable interface {
+	CompressionFormat() int
+	CompressionLevel() int
+}
+
+// PrepareShrinkTo copies into dstPath, while the store keeps accepting commits, the transactions committed so far
+// and the values which were not discarded by a previous truncation. The copy may be opened in the meantime,
+// so its derived data gets built in advance, and it's completed by ShrinkTo, which then only has to copy
+// the transactions committed after it was prepared.
+func (s *ImmuStore) PrepareShrinkTo(dstPath string) error {
+	err := s.checkShrinkable()
+	if err != nil {
+		return err
+	}
+
+	err = s.mkdirShrunkCopy(dstPath)
+	if err != nil {
+		return err
+	}
+
+	err = s.prepareShrinkTo(dstPath)
+	if err != nil {
+		fileutil.RemoveAll(dstPath)
+		return err
+	}
+
+	return nil
+}
+
+func (s *ImmuStore) prepareShrinkTo(dstPath string) error {
+	w, err := s.newShrinkWriter(dstPath)
+	if err != nil {
+		return err
+	}
+
+	err = s.copyCommittedTxs(w)
+	if err != nil {
+		w.close()
+		return err
+	}
+
+	return w.close()
+}
+
+// ShrinkTo writes into dstPath, which must either not exist or hold a copy written by PrepareShrinkTo, a compacted
+// copy of the store holding the same transactions but only the values which were not discarded by a previous truncation.
+// Values are copied while the store keeps accepting commits, then commits are blocked until the latest
+// transactions are copied. Once shrunk, the store does not accept commits anymore so the copy can take its place.
+// Derived data i.e. the index, the binary linking tree and the time index are built when the copy gets opened,
+// only for the transactions copied since the copy was last opened.
+func (s *ImmuStore) ShrinkTo(dstPath string) error {
+	err := s.checkShrinkable()
+	if err != nil {
+		return err
+	}
+
+	err = s.mkdirShrunkCopy(dstPath)
+	if err != nil {
+		return err
+	}
+
+	err = s.shrinkTo(dstPath)
+	if err != nil {
+		fileutil.RemoveAll(dstPath)
+		return err
+	}
+
+	s.log.Infof("Store '%s' successfully shrunk into '%s'", s.path, dstPath)
+
+	return nil
+}
+
+func (s *ImmuStore) checkShrinkable() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.closed {
+		return ErrAlreadyClosed
+	}
+
+	if s.shrunk {
+		return ErrStoreShrunk
+	}
+
+	if s.readOnly {
+		return ErrReadOnly
+	}
+
+	return nil
+}
+
+// mkdirShrunkCopy creates the folder of the copy, unless it already holds a copy written by PrepareShrinkTo
+func (s *ImmuStore) mkdirShrunkCopy(dstPath string) error {
+	err := os.Mkdir(dstPath, s.fileMode)
+	if os.IsExist(err) {
+		if _, cErr := os.Stat(filepath.Join(dstPath, "commit")); cErr == nil {
+			return nil
+		}
+	}
+
+	return err
+}
+
+func (s *ImmuStore) shrinkTo(dstPath string) error {
+	w, err := s.newShrinkWriter(dstPath)
+	if err != nil {
+		return err
+	}
+
+	err = s.copyCommittedTxs(w)
+	if err != nil {
+		w.close()
+		return err
+	}
+
+	// commits are blocked while the ones made in the meantime are copied
+	s.truncationRWMutex.Lock()
+	defer s.truncationRWMutex.Unlock()
+
+	committedTxID, _, _ := s.commitState()
+
+	err = s.copyTxs(w, w.copiedTxID+1, committedTxID)
+	if err != nil {
+		w.close()
+		return err
+	}
+
+	err = w.close()
+	if err != nil {
+		return err
+	}
+
+	// named snapshots are kept by the copy
+	s.namedSnapshotsMutex.Lock()
+	err = writeNamedSnapshots(dstPath, s.namedSnapshots, s.fileMode)
+	s.namedSnapshotsMutex.Unlock()
+	if err != nil {
+		return err
+	}
+
+	// so does the truncation point, live values up to it were already relocated
+	if s.truncatedTxID > 0 {
+		err = writeTruncatedTxID(dstPath, s.truncatedTxID, s.fileMode)
+		if err != nil {
+			return err
+		}
+	}
+
+	s.mutex.Lock()
+	s.shrunk = true
+	s.mutex.Unlock()
+
+	return nil
+}
+
+// copyCommittedTxs copies the transactions committed so far, which are not yet in the copy, without blocking commits
+func (s *ImmuStore) copyCommittedTxs(w *shrinkWriter) error {
+	s.truncationRWMutex.RLock()
+	defer s.truncationRWMutex.RUnlock()
+
+	committedTxID, _, _ := s.commitState()
+
+	if w.copiedTxID > committedTxID {
+		return ErrCorruptedCLog
+	}
+
+	return s.copyTxs(w, w.copiedTxID+1, committedTxID)
+}
+
+func (s *ImmuStore) newShrinkWriter(dstPath string) (*shrinkWriter, error) {
+	metadata := s.cLog.Metadata()
+
+	fileSize, ok := appendable.NewMetadata(metadata).GetInt(metaFileSize)
+	if !ok {
+		return nil, ErrCorruptedCLog
+	}
+
+	appendableOpts := multiapp.DefaultOptions().
+		WithFileSize(fileSize).
+		WithFileMode(s.fileMode).
+		WithMetadata(metadata)
+
+	appendableOpts.WithFileExt("tx")
+	appendableOpts.WithCompressionFormat(appendable.NoCompression)
+	txLog, err := multiapp.Open(filepath.Join(dstPath, "tx"), appendableOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	appendableOpts.WithFileExt("txi")
+	cLog, err := multiapp.Open(filepath.Join(dstPath, "commit"), appendableOpts)
+	if err != nil {
+		txLog.Close()
+		return nil, err
+	}
+
+	appendableOpts.WithFileExt("val")
+	if vLog, ok := s.vLogs[0].vLog.(compressedAppendable); ok {
+		appendableOpts.WithCompressionFormat(vLog.CompressionFormat())
+		appendableOpts.WithCompresionLevel(vLog.CompressionLevel())
+	}
+	vLog, err := multiapp.Open(filepath.Join(dstPath, "val_0"), appendableOpts)
+	if err != nil {
+		txLog.Close()
+		cLog.Close()
+		return nil, err
+	}
+
+	w := &shrinkWriter{
+		txLog: txLog,
+		cLog:  cLog,
+		vLog:  vLog,
+		tx:    s.NewTx(),
+		txbs:  make([]byte, s.maxTxSize),
+	}
+
+	cLogSize, err := cLog.Size()
+	if err == nil && cLogSize%cLogEntrySize > 0 {
+		err = ErrCorruptedCLog
+	}
+	if err != nil {
+		w.close()
+		return nil, err
+	}
+
+	// a copy written by PrepareShrinkTo is completed, otherwise the first file of the value log is skipped
+	if cLogSize > 0 {
+		w.copiedTxID = uint64(cLogSize / cLogEntrySize)
+		return w, nil
+	}
+
+	err = vLog.SetOffset(int64(fileSize))
+	if err == nil {
+		err = vLog.DiscardUpTo(int64(fileSize))
+	}
+	if err != nil {
+		w.close()
+		return nil, err
+	}
+
+	return w, nil
+}
+
+func (s *ImmuStore) copyTxs(w *shrinkWriter, fromTxID, toTxID uint64) error {
+	for txID := fromTxID; txID <= toTxID; txID++ {
+		err := s.ReadTx(txID, w.tx)
+		if err != nil {
+			return err
+		}
+
+		for _, e := range w.tx.Entries() {
+			// values stored inline are copied together with their tx
+			if e.vLen == 0 || e.inlined() {
+				continue
+			}
+
+			e.vOff, err = s.copyValue(w, e)
+			if err != nil {
+				return err
+			}
+		}
+
+		txSize := w.tx.serializeForLogAt(w.txbs, w.txLog.Offset())
+
+		txOff, _, err := w.txLog.Append(w.txbs[:txSize])
+		if err != nil {
+			return err
+		}
+
+		var cb [cLogEntrySize]byte
+		binary.BigEndian.PutUint64(cb[:], uint64(txOff))
+		binary.BigEndian.PutUint32(cb[offsetSize:], uint32(txSize))
+
+		_, _, err = w.cLog.Append(cb[:])
+		if err != nil {
+			return err
+		}
+
+		w.copiedTxID = txID
+	}
+
+	return nil
+}
+
+// copyValue copies the value, as stored, into the value log of the copy and returns its new offset
+func (s *ImmuStore) copyValue(w *shrinkWriter, e *TxEntry) (int64, error) {
+	vLogID, off := decodeOffset(e.vOff)
+
+	vLog, err := s.fetchVLog(vLogID, true)
+	if err != nil {
+		return 0, err
+	}
+	defer s.releaseVLog(vLogID)
+
+	vLen := e.vLen
+	if s.cipher != nil {
+		vLen = s.encryptedLen(vLen)
+	}
+
+	vb := make([]byte, vLen)
+
+	_, err = vLog.ReadAt(vb, off)
+	if err == multiapp.ErrDiscarded {
+		return encodeOffset(0, 1), nil
+	}
+	if err != nil {
+		return 0, s.wrapAppendableErr(err, "reading value")
+	}
+
+	newOff, _, err := w.vLog.Append(vb)
+	if err != nil {
+		return 0, err
+	}
+
+	return encodeOffset(newOff, 1), nil
+}
+
+func (w *shrinkWriter) close() error {
+	var err error
+
+	for _, app := range []appendable.Appendable{w.vLog, w.txLog, w.cLog} {
+		fErr := app.Flush()
+		if fErr == nil {
+			fErr = app.Sync()
+		}
+
+		cErr := app.Close()
+
+		if err == nil {
+			err = fErr
+		}
+		if err == nil {
+			err = cErr
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,321 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package store
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestImmudbStoreShrink(t *testing.T) {
+	opts := DefaultOptions().
+		WithSynced(false).
+		WithMaxConcurrency(1).
+		WithFileSize(64)
+
+	immuStore, err := Open("data_shrink", opts)
+	require.NoError(t, err)
+	defer os.RemoveAll("data_shrink")
+	defer os.RemoveAll("data_shrunk")
+
+	txCount := 20
+
+	for i := 1; i <= txCount; i++ {
+		// each value takes 16 bytes so each value log file holds exactly four values
+		_, err = immuStore.Commit([]*KV{
+			{Key: []byte(fmt.Sprintf("key%d", i)), Value: []byte(fmt.Sprintf("value%11d", i))},
+			{Key: []byte(fmt.Sprintf("nokey%d", i))},
+		}, true)
+		require.NoError(t, err)
+	}
+
+	// values of tx 9 and 10 share the value log file with values of retained transactions
+	err = immuStore.TruncateUpTo(10)
+	require.NoError(t, err)
+
+	// current values of the truncated transactions were relocated into a new one
+	committedTxCount := txCount + 1
+	require.Equal(t, uint64(committedTxCount), immuStore.TxCount())
+
+	alhs := make([][32]byte, committedTxCount)
+
+	tx := immuStore.NewTx()
+
+	for i := 1; i <= committedTxCount; i++ {
+		err = immuStore.ReadTx(uint64(i), tx)
+		require.NoError(t, err)
+
+		alhs[i-1] = tx.Alh
+	}
+
+	err = os.Mkdir("data_shrunk", 0700)
+	require.NoError(t, err)
+
+	err = immuStore.ShrinkTo("data_shrunk")
+	require.True(t, os.IsExist(err))
+
+	err = os.Remove("data_shrunk")
+	require.NoError(t, err)
+
+	err = immuStore.ShrinkTo("data_shrunk")
+	require.NoError(t, err)
+
+	_, err = immuStore.Commit([]*KV{{Key: []byte("key"), Value: []byte("value")}}, false)
+	require.Equal(t, ErrStoreShrunk, err)
+
+	err = immuStore.ShrinkTo("data_shrunk2")
+	require.Equal(t, ErrStoreShrunk, err)
+
+	err = immuStore.Close()
+	require.NoError(t, err)
+
+	err = immuStore.ShrinkTo("data_shrunk2")
+	require.Equal(t, ErrAlreadyClosed, err)
+
+	shrunkStore, err := Open("data_shrunk", opts)
+	require.NoError(t, err)
+
+	require.Equal(t, uint64(committedTxCount), shrunkStore.TxCount())
+
+	err = shrunkStore.WaitForIndexingUpto(context.Background(), uint64(committedTxCount))
+	require.NoError(t, err)
+
+	err = shrunkStore.ReadTx(uint64(committedTxCount), tx)
+	require.NoError(t, err)
+	require.Equal(t, alhs[committedTxCount-1], tx.Alh)
+
+	for i := 1; i <= txCount; i++ {
+		err = shrunkStore.ReadTx(uint64(i), tx)
+		require.NoError(t, err)
+		require.Equal(t, alhs[i-1], tx.Alh)
+
+		key := []byte(fmt.Sprintf("key%d", i))
+
+		v, err := shrunkStore.ReadValue(tx, key)
+
+		if i <= 8 {
+			require.Equal(t, ErrValueTruncated, err)
+		} else {
+			require.NoError(t, err)
+			require.Equal(t, []byte(fmt.Sprintf("value%11d", i)), v)
+		}
+
+		v, _, _, err = shrunkStore.Get(key)
+		require.NoError(t, err)
+		require.Equal(t, []byte(fmt.Sprintf("value%11d", i)), v)
+
+		v, err = shrunkStore.ReadValue(tx, []byte(fmt.Sprintf("nokey%d", i)))
+		require.NoError(t, err)
+		require.Empty(t, v)
+	}
+
+	lproof, err := shrunkStore.LinearProof(1, uint64(committedTxCount))
+	require.NoError(t, err)
+	require.True(t, VerifyLinearProof(lproof, 1, uint64(committedTxCount), alhs[0], alhs[committedTxCount-1]))
+
+	md, err := shrunkStore.Commit([]*KV{{Key: []byte("key"), Value: []byte("value")}}, true)
+	require.NoError(t, err)
+	require.Equal(t, uint64(committedTxCount+1), md.ID)
+
+	v, _, _, err := shrunkStore.Get([]byte("key"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), v)
+
+	err = shrunkStore.Close()
+	require.NoError(t, err)
+}
+
+func TestImmudbStoreShrinkWhileCommitting(t *testing.T) {
+	opts := DefaultOptions().WithSynced(false)
+
+	immuStore, err := Open("data_shrink_online", opts)
+	require.NoError(t, err)
+	defer os.RemoveAll("data_shrink_online")
+	defer os.RemoveAll("data_shrunk_online")
+
+	for i := 0; i < 100; i++ {
+		_, err = immuStore.Commit([]*KV{{Key: []byte(fmt.Sprintf("key%d", i)), Value: []byte(fmt.Sprintf("value%d", i))}}, false)
+		require.NoError(t, err)
+	}
+
+	committingCh := make(chan struct{})
+	lastTxIDCh := make(chan uint64)
+
+	go func() {
+		var lastTxID uint64
+
+		for i := 0; ; i++ {
+			md, err := immuStore.Commit([]*KV{{Key: []byte(fmt.Sprintf("key%d", i)), Value: []byte(fmt.Sprintf("value%d", i))}}, false)
+			if err == ErrStoreShrunk {
+				break
+			}
+			require.NoError(t, err)
+
+			if lastTxID == 0 {
+				close(committingCh)
+			}
+
+			lastTxID = md.ID
+		}
+
+		lastTxIDCh <- lastTxID
+	}()
+
+	<-committingCh
+
+	err = immuStore.ShrinkTo("data_shrunk_online")
+	require.NoError(t, err)
+
+	lastTxID := <-lastTxIDCh
+
+	err = immuStore.Close()
+	require.NoError(t, err)
+
+	shrunkStore, err := Open("data_shrunk_online", opts)
+	require.NoError(t, err)
+
+	require.Greater(t, lastTxID, uint64(100))
+	require.Equal(t, lastTxID, shrunkStore.TxCount())
+
+	err = shrunkStore.Close()
+	require.NoError(t, err)
+}
+
+func TestImmudbStoreShrinkEncrypted(t *testing.T) {
+	opts := DefaultOptions().
+		WithSynced(false).
+		WithEncryptionKey([]byte("0123456789abcdef0123456789abcdef"))
+
+	immuStore, err := Open("data_shrink_encrypted", opts)
+	require.NoError(t, err)
+	defer os.RemoveAll("data_shrink_encrypted")
+	defer os.RemoveAll("data_shrunk_encrypted")
+
+	_, err = immuStore.Commit([]*KV{{Key: []byte("key"), Value: []byte("value")}}, false)
+	require.NoError(t, err)
+
+	err = immuStore.ShrinkTo("data_shrunk_encrypted")
+	require.NoError(t, err)
+
+	err = immuStore.Close()
+	require.NoError(t, err)
+
+	_, err = Open("data_shrunk_encrypted", DefaultOptions())
+	require.Equal(t, ErrMissingEncryptionKey, err)
+
+	shrunkStore, err := Open("data_shrunk_encrypted", opts)
+	require.NoError(t, err)
+
+	err = shrunkStore.WaitForIndexingUpto(context.Background(), 1)
+	require.NoError(t, err)
+
+	v, _, _, err := shrunkStore.Get([]byte("key"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), v)
+
+	err = shrunkStore.Close()
+	require.NoError(t, err)
+}
+
+func TestImmudbStoreShrinkPrepared(t *testing.T) {
+	opts := DefaultOptions().WithSynced(false)
+
+	immuStore, err := Open("data_shrink_prepared", opts)
+	require.NoError(t, err)
+	defer os.RemoveAll("data_shrink_prepared")
+	defer os.RemoveAll("data_shrunk_prepared")
+
+	for i := 1; i <= 10; i++ {
+		_, err = immuStore.Commit([]*KV{{Key: []byte(fmt.Sprintf("key%d", i)), Value: []byte(fmt.Sprintf("value%d", i))}}, false)
+		require.NoError(t, err)
+	}
+
+	err = immuStore.PrepareShrinkTo("data_shrunk_prepared")
+	require.NoError(t, err)
+
+	// the store keeps accepting commits once the copy is prepared
+	for i := 11; i <= 15; i++ {
+		_, err = immuStore.Commit([]*KV{{Key: []byte(fmt.Sprintf("key%d", i)), Value: []byte(fmt.Sprintf("value%d", i))}}, false)
+		require.NoError(t, err)
+	}
+
+	// the index of the prepared copy is built in advance
+	preparedStore, err := Open("data_shrunk_prepared", opts)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), preparedStore.TxCount())
+
+	err = preparedStore.WaitForIndexingUpto(context.Background(), 10)
+	require.NoError(t, err)
+
+	err = preparedStore.Close()
+	require.NoError(t, err)
+
+	err = immuStore.PrepareShrinkTo("data_shrunk_prepared")
+	require.NoError(t, err)
+
+	for i := 16; i <= 20; i++ {
+		_, err = immuStore.Commit([]*KV{{Key: []byte(fmt.Sprintf("key%d", i)), Value: []byte(fmt.Sprintf("value%d", i))}}, false)
+		require.NoError(t, err)
+	}
+
+	err = immuStore.ShrinkTo("data_shrunk_prepared")
+	require.NoError(t, err)
+
+	_, err = immuStore.Commit([]*KV{{Key: []byte("key"), Value: []byte("value")}}, false)
+	require.Equal(t, ErrStoreShrunk, err)
+
+	err = immuStore.PrepareShrinkTo("data_shrunk_prepared")
+	require.Equal(t, ErrStoreShrunk, err)
+
+	_, alh := immuStore.Alh()
+
+	err = immuStore.Close()
+	require.NoError(t, err)
+
+	shrunkStore, err := Open("data_shrunk_prepared", opts)
+	require.NoError(t, err)
+	require.Equal(t, uint64(20), shrunkStore.TxCount())
+
+	_, shrunkAlh := shrunkStore.Alh()
+	require.Equal(t, alh, shrunkAlh)
+
+	err = shrunkStore.WaitForIndexingUpto(context.Background(), 20)
+	require.NoError(t, err)
+
+	tx := shrunkStore.NewTx()
+
+	for i := 1; i <= 20; i++ {
+		key := []byte(fmt.Sprintf("key%d", i))
+
+		err = shrunkStore.ReadTx(uint64(i), tx)
+		require.NoError(t, err)
+
+		v, err := shrunkStore.ReadValue(tx, key)
+		require.NoError(t, err)
+		require.Equal(t, []byte(fmt.Sprintf("value%d", i)), v)
+
+		v, _, _, err = shrunkStore.Get(key)
+		require.NoError(t, err)
+		require.Equal(t, []byte(fmt.Sprintf("value%d", i)), v)
+	}
+
+	err = shrunkStore.Close()
+	require.NoError(t, err)
+}
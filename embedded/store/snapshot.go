@@ -0,0 +1,492 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package store
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/codenotary/immudb/embedded/fileutil"
+)
+
+var ErrSnapshotNotFound = errors.New("named snapshot not found")
+var ErrSnapshotAlreadyExists = errors.New("named snapshot already exists")
+var ErrSnapshotPinned = errors.New("store can not be truncated while named snapshots exist")
+var ErrInvalidSnapshotExport = errors.New("invalid snapshot export")
+
+// file holding the named snapshots of the store
+const namedSnapshotsFilename = "SNAPSHOTS"
+
+// file describing the snapshot held by an export
+const snapshotManifestFilename = "SNAPSHOT"
+
+const snapshotManifestVersion = 1
+
+// NamedSnapshot pins the state of the store at a given transaction so it can be exported
+type NamedSnapshot struct {
+	Name string
+	TxID uint64
+}
+
+// SnapshotManifest describes the snapshot held by an export, it's used to verify the store once imported
+type SnapshotManifest struct {
+	Name string
+	TxID uint64
+	Ts   int64
+	Alh  [sha256.Size]byte
+}
+
+// CreateNamedSnapshot pins the state of the store at txID under the given name.
+// The store can not be truncated while named snapshots exist, as exports include every transaction
+// up to the snapshot together with their values. Values discarded before are exported as truncated.
+func (s *ImmuStore) CreateNamedSnapshot(name string, txID uint64) error {
+	if !validIdentifier(name) {
+		return ErrIllegalArguments
+	}
+
+	if s.readOnly {
+		return ErrReadOnly
+	}
+
+	// truncation can not happen in the meantime
+	s.truncationRWMutex.RLock()
+	defer s.truncationRWMutex.RUnlock()
+
+	s.namedSnapshotsMutex.Lock()
+	defer s.namedSnapshotsMutex.Unlock()
+
+	s.mutex.Lock()
+	closed := s.closed
+	s.mutex.Unlock()
+
+	if closed {
+		return ErrAlreadyClosed
+	}
+
+	committedTxID, _, _ := s.commitState()
+
+	if txID == 0 || txID > committedTxID {
+		return ErrIllegalArguments
+	}
+
+	if _, ok := s.namedSnapshots[name]; ok {
+		return ErrSnapshotAlreadyExists
+	}
+
+	snapshots := make(map[string]uint64, len(s.namedSnapshots)+1)
+	for n, id := range s.namedSnapshots {
+		snapshots[n] = id
+	}
+	snapshots[name] = txID
+
+	err := writeNamedSnapshots(s.path, snapshots, s.fileMode)
+	if err != nil {
+		return err
+	}
+
+	s.namedSnapshots = snapshots
+
+	return nil
+}
+
+// DeleteNamedSnapshot unpins the snapshot, the store can be truncated once no named snapshots exist
+func (s *ImmuStore) DeleteNamedSnapshot(name string) error {
+	if s.readOnly {
+		return ErrReadOnly
+	}
+
+	s.namedSnapshotsMutex.Lock()
+	defer s.namedSnapshotsMutex.Unlock()
+
+	if _, ok := s.namedSnapshots[name]; !ok {
+		return ErrSnapshotNotFound
+	}
+
+	snapshots := make(map[string]uint64, len(s.namedSnapshots))
+	for n, id := range s.namedSnapshots {
+		if n != name {
+			snapshots[n] = id
+		}
+	}
+
+	err := writeNamedSnapshots(s.path, snapshots, s.fileMode)
+	if err != nil {
+		return err
+	}
+
+	s.namedSnapshots = snapshots
+
+	return nil
+}
+
+// NamedSnapshots returns the named snapshots of the store sorted by name
+func (s *ImmuStore) NamedSnapshots() []NamedSnapshot {
+	s.namedSnapshotsMutex.Lock()
+	defer s.namedSnapshotsMutex.Unlock()
+
+	snapshots := make([]NamedSnapshot, 0, len(s.namedSnapshots))
+	for name, txID := range s.namedSnapshots {
+		snapshots = append(snapshots, NamedSnapshot{Name: name, TxID: txID})
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].Name < snapshots[j].Name })
+
+	return snapshots
+}
+
+func (s *ImmuStore) hasNamedSnapshots() bool {
+	s.namedSnapshotsMutex.Lock()
+	defer s.namedSnapshotsMutex.Unlock()
+
+	return len(s.namedSnapshots) > 0
+}
+
+// ExportNamedSnapshot writes into dstPath, which must not exist, a copy of the store holding the transactions
+// up to the snapshot together with a manifest describing it. Values are copied as stored, thus the same
+// encryption key is required to open the copy. Commits are accepted while the snapshot is exported.
+func (s *ImmuStore) ExportNamedSnapshot(name, dstPath string) error {
+	s.namedSnapshotsMutex.Lock()
+	txID, ok := s.namedSnapshots[name]
+	s.namedSnapshotsMutex.Unlock()
+
+	if !ok {
+		return ErrSnapshotNotFound
+	}
+
+	s.mutex.Lock()
+	closed := s.closed
+	s.mutex.Unlock()
+
+	if closed {
+		return ErrAlreadyClosed
+	}
+
+	err := os.Mkdir(dstPath, s.fileMode)
+	if err != nil {
+		return err
+	}
+
+	err = s.exportUpTo(name, txID, dstPath)
+	if err != nil {
+		fileutil.RemoveAll(dstPath)
+		return err
+	}
+
+	s.log.Infof("Snapshot '%s' of store '%s' successfully exported into '%s'", name, s.path, dstPath)
+
+	return nil
+}
+
+func (s *ImmuStore) exportUpTo(name string, txID uint64, dstPath string) error {
+	w, err := s.newShrinkWriter(dstPath)
+	if err != nil {
+		return err
+	}
+
+	s.truncationRWMutex.RLock()
+	err = s.copyTxs(w, 1, txID)
+	s.truncationRWMutex.RUnlock()
+
+	if err != nil {
+		w.close()
+		return err
+	}
+
+	err = w.close()
+	if err != nil {
+		return err
+	}
+
+	tx := s.NewTx()
+
+	err = s.ReadTx(txID, tx)
+	if err != nil {
+		return err
+	}
+
+	return writeSnapshotManifest(dstPath, &SnapshotManifest{
+		Name: name,
+		TxID: txID,
+		Ts:   tx.Ts,
+		Alh:  tx.Alh,
+	}, s.fileMode)
+}
+
+// ReadSnapshotManifest returns the manifest of the snapshot exported into exportPath
+func ReadSnapshotManifest(exportPath string) (*SnapshotManifest, error) {
+	b, err := ioutil.ReadFile(filepath.Join(exportPath, snapshotManifestFilename))
+	if err != nil {
+		return nil, err
+	}
+
+	r := bytes.NewReader(b)
+
+	var version byte
+	var nameLen uint16
+
+	m := &SnapshotManifest{}
+
+	err = binary.Read(r, binary.BigEndian, &version)
+	if err == nil && version != snapshotManifestVersion {
+		return nil, fmt.Errorf("%w: unsupported manifest version %d", ErrInvalidSnapshotExport, version)
+	}
+	if err == nil {
+		err = binary.Read(r, binary.BigEndian, &m.TxID)
+	}
+	if err == nil {
+		err = binary.Read(r, binary.BigEndian, &m.Ts)
+	}
+	if err == nil {
+		_, err = io.ReadFull(r, m.Alh[:])
+	}
+	if err == nil {
+		err = binary.Read(r, binary.BigEndian, &nameLen)
+	}
+	if err == nil {
+		name := make([]byte, nameLen)
+		_, err = io.ReadFull(r, name)
+		m.Name = string(name)
+	}
+	if err != nil || r.Len() > 0 {
+		return nil, fmt.Errorf("%w: corrupted manifest", ErrInvalidSnapshotExport)
+	}
+
+	return m, nil
+}
+
+func writeSnapshotManifest(exportPath string, m *SnapshotManifest, fileMode os.FileMode) error {
+	var b bytes.Buffer
+
+	b.WriteByte(snapshotManifestVersion)
+	binary.Write(&b, binary.BigEndian, m.TxID)
+	binary.Write(&b, binary.BigEndian, m.Ts)
+	b.Write(m.Alh[:])
+	binary.Write(&b, binary.BigEndian, uint16(len(m.Name)))
+	b.WriteString(m.Name)
+
+	return ioutil.WriteFile(filepath.Join(exportPath, snapshotManifestFilename), b.Bytes(), fileMode)
+}
+
+// ImportSnapshot restores into path, which must not exist, the snapshot exported into exportPath.
+// Logs are restored into the directories set by the options, if any.
+// The restored store is opened with the given options and verified against the manifest of the export.
+func ImportSnapshot(exportPath, path string, opts *Options) (*ImmuStore, error) {
+	if !validOptions(opts) {
+		return nil, ErrIllegalArguments
+	}
+
+	m, err := ReadSnapshotManifest(exportPath)
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.Mkdir(path, opts.FileMode)
+	if err != nil {
+		return nil, err
+	}
+
+	logPaths := importedLogPaths(path, opts)
+
+	// logs may be placed outside of path, neither of them can exist
+	for _, logPath := range logPaths {
+		_, err = os.Stat(logPath)
+		if err == nil {
+			fileutil.RemoveAll(path)
+			return nil, fmt.Errorf("%w: '%s' already exists", ErrIllegalArguments, logPath)
+		}
+	}
+
+	st, err := importSnapshot(exportPath, path, m, opts)
+	if err != nil {
+		fileutil.RemoveAll(path)
+		for _, logPath := range logPaths {
+			fileutil.RemoveAll(logPath)
+		}
+		return nil, err
+	}
+
+	opts.log.Infof("Snapshot '%s' successfully imported into '%s'", m.Name, path)
+
+	return st, nil
+}
+
+func importSnapshot(exportPath, path string, m *SnapshotManifest, opts *Options) (*ImmuStore, error) {
+	for dir, dstPath := range importedLogPaths(path, opts) {
+		err := os.MkdirAll(filepath.Dir(dstPath), opts.FileMode)
+		if err != nil {
+			return nil, err
+		}
+
+		err = copyDir(filepath.Join(exportPath, dir), dstPath, opts.FileMode)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	st, err := Open(path, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	txID, alh := st.Alh()
+	if txID != m.TxID || alh != m.Alh {
+		st.Close()
+		return nil, fmt.Errorf("%w: restored store does not match the manifest", ErrInvalidSnapshotExport)
+	}
+
+	return st, nil
+}
+
+// importedLogPaths returns where each of the exported logs is restored to, by the name of its folder in the export
+func importedLogPaths(path string, opts *Options) map[string]string {
+	txLogDir := dirFor(opts.TxLogDir, path)
+	vLogDir := dirFor(opts.ValueLogDir, path)
+
+	return map[string]string{
+		"tx":     filepath.Join(txLogDir, "tx"),
+		"commit": filepath.Join(txLogDir, "commit"),
+		"val_0":  filepath.Join(vLogDir, "val_0"),
+	}
+}
+
+func copyDir(srcPath, dstPath string, fileMode os.FileMode) error {
+	fis, err := ioutil.ReadDir(srcPath)
+	if err != nil {
+		return err
+	}
+
+	err = os.Mkdir(dstPath, fileMode)
+	if err != nil {
+		return err
+	}
+
+	for _, fi := range fis {
+		if fi.IsDir() {
+			continue
+		}
+
+		err = copyFile(filepath.Join(srcPath, fi.Name()), filepath.Join(dstPath, fi.Name()), fileMode)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func copyFile(srcPath, dstPath string, fileMode os.FileMode) error {
+	src, err := fileutil.Open(srcPath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := fileutil.OpenFile(dstPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, fileMode)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(dst, src)
+	if err == nil {
+		err = dst.Sync()
+	}
+
+	cErr := dst.Close()
+	if err == nil {
+		err = cErr
+	}
+
+	return err
+}
+
+// readNamedSnapshots reads the named snapshots persisted into the store path, if any
+func readNamedSnapshots(path string) (map[string]uint64, error) {
+	snapshots := make(map[string]uint64)
+
+	b, err := ioutil.ReadFile(filepath.Join(path, namedSnapshotsFilename))
+	if os.IsNotExist(err) {
+		return snapshots, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for len(b) > 0 {
+		if len(b) < 2 {
+			return nil, ErrCorruptedData
+		}
+
+		nameLen := int(binary.BigEndian.Uint16(b))
+		b = b[2:]
+
+		if len(b) < nameLen+txIDSize {
+			return nil, ErrCorruptedData
+		}
+
+		snapshots[string(b[:nameLen])] = binary.BigEndian.Uint64(b[nameLen:])
+		b = b[nameLen+txIDSize:]
+	}
+
+	return snapshots, nil
+}
+
+// writeNamedSnapshots atomically replaces the named snapshots persisted into the store path
+func writeNamedSnapshots(path string, snapshots map[string]uint64, fileMode os.FileMode) error {
+	names := make([]string, 0, len(snapshots))
+	for name := range snapshots {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b bytes.Buffer
+
+	for _, name := range names {
+		binary.Write(&b, binary.BigEndian, uint16(len(name)))
+		b.WriteString(name)
+		binary.Write(&b, binary.BigEndian, snapshots[name])
+	}
+
+	tmpPath := filepath.Join(path, namedSnapshotsFilename+".tmp")
+
+	f, err := fileutil.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, fileMode)
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Write(b.Bytes())
+	if err == nil {
+		err = f.Sync()
+	}
+
+	cErr := f.Close()
+	if err == nil {
+		err = cErr
+	}
+	if err != nil {
+		fileutil.Remove(tmpPath)
+		return err
+	}
+
+	return fileutil.Rename(tmpPath, filepath.Join(path, namedSnapshotsFilename))
+}
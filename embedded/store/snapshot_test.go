@@ -0,0 +1,223 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestImmudbStoreNamedSnapshots(t *testing.T) {
+	opts := DefaultOptions().
+		WithSynced(false).
+		WithMaxConcurrency(1).
+		WithFileSize(64)
+
+	immuStore, err := Open("data_named_snapshots", opts)
+	require.NoError(t, err)
+	defer os.RemoveAll("data_named_snapshots")
+
+	for i := 1; i <= 10; i++ {
+		_, err = immuStore.Commit([]*KV{{Key: []byte(fmt.Sprintf("key%d", i)), Value: []byte(fmt.Sprintf("value%d", i))}}, false)
+		require.NoError(t, err)
+	}
+
+	err = immuStore.CreateNamedSnapshot("", 1)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	err = immuStore.CreateNamedSnapshot("../snap", 1)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	err = immuStore.CreateNamedSnapshot("snap", 0)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	err = immuStore.CreateNamedSnapshot("snap", 11)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	err = immuStore.CreateNamedSnapshot("snap_b", 5)
+	require.NoError(t, err)
+
+	err = immuStore.CreateNamedSnapshot("snap_a", 10)
+	require.NoError(t, err)
+
+	err = immuStore.CreateNamedSnapshot("snap_a", 3)
+	require.Equal(t, ErrSnapshotAlreadyExists, err)
+
+	require.Equal(t, []NamedSnapshot{{Name: "snap_a", TxID: 10}, {Name: "snap_b", TxID: 5}}, immuStore.NamedSnapshots())
+
+	err = immuStore.TruncateUpTo(5)
+	require.Equal(t, ErrSnapshotPinned, err)
+
+	err = immuStore.DeleteNamedSnapshot("snap_c")
+	require.Equal(t, ErrSnapshotNotFound, err)
+
+	err = immuStore.DeleteNamedSnapshot("snap_a")
+	require.NoError(t, err)
+
+	err = immuStore.Close()
+	require.NoError(t, err)
+
+	err = immuStore.CreateNamedSnapshot("snap_c", 1)
+	require.Equal(t, ErrAlreadyClosed, err)
+
+	immuStore, err = Open("data_named_snapshots", opts)
+	require.NoError(t, err)
+
+	require.Equal(t, []NamedSnapshot{{Name: "snap_b", TxID: 5}}, immuStore.NamedSnapshots())
+
+	err = immuStore.DeleteNamedSnapshot("snap_b")
+	require.NoError(t, err)
+
+	require.Empty(t, immuStore.NamedSnapshots())
+
+	err = immuStore.TruncateUpTo(5)
+	require.NoError(t, err)
+
+	err = immuStore.Close()
+	require.NoError(t, err)
+
+	err = ioutil.WriteFile(filepath.Join("data_named_snapshots", namedSnapshotsFilename), []byte{0, 5, 's'}, 0600)
+	require.NoError(t, err)
+
+	_, err = Open("data_named_snapshots", opts)
+	require.Equal(t, ErrCorruptedData, err)
+}
+
+func TestImmudbStoreExportImportSnapshot(t *testing.T) {
+	opts := DefaultOptions().
+		WithSynced(false).
+		WithMaxConcurrency(1).
+		WithFileSize(64)
+
+	immuStore, err := Open("data_export_snapshot", opts)
+	require.NoError(t, err)
+	defer os.RemoveAll("data_export_snapshot")
+	defer os.RemoveAll("data_snapshot_export")
+	defer os.RemoveAll("data_snapshot_import")
+
+	for i := 1; i <= 10; i++ {
+		_, err = immuStore.Commit([]*KV{{Key: []byte(fmt.Sprintf("key%d", i)), Value: []byte(fmt.Sprintf("value%d", i))}}, false)
+		require.NoError(t, err)
+	}
+
+	err = immuStore.ExportNamedSnapshot("snap", "data_snapshot_export")
+	require.Equal(t, ErrSnapshotNotFound, err)
+
+	err = immuStore.CreateNamedSnapshot("snap", 6)
+	require.NoError(t, err)
+
+	snapTx := immuStore.NewTx()
+	err = immuStore.ReadTx(6, snapTx)
+	require.NoError(t, err)
+
+	// commits made after the snapshot are not exported
+	for i := 11; i <= 15; i++ {
+		_, err = immuStore.Commit([]*KV{{Key: []byte(fmt.Sprintf("key%d", i)), Value: []byte(fmt.Sprintf("value%d", i))}}, false)
+		require.NoError(t, err)
+	}
+
+	err = os.Mkdir("data_snapshot_export", 0700)
+	require.NoError(t, err)
+
+	err = immuStore.ExportNamedSnapshot("snap", "data_snapshot_export")
+	require.True(t, os.IsExist(err))
+
+	err = os.Remove("data_snapshot_export")
+	require.NoError(t, err)
+
+	err = immuStore.ExportNamedSnapshot("snap", "data_snapshot_export")
+	require.NoError(t, err)
+
+	err = immuStore.Close()
+	require.NoError(t, err)
+
+	m, err := ReadSnapshotManifest("data_snapshot_export")
+	require.NoError(t, err)
+	require.Equal(t, "snap", m.Name)
+	require.Equal(t, uint64(6), m.TxID)
+	require.Equal(t, snapTx.Ts, m.Ts)
+	require.Equal(t, snapTx.Alh, m.Alh)
+
+	_, err = ImportSnapshot("data_snapshot_export", "data_snapshot_import", nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = ImportSnapshot("data_snapshot_missing", "data_snapshot_import", opts)
+	require.True(t, os.IsNotExist(err))
+
+	importedStore, err := ImportSnapshot("data_snapshot_export", "data_snapshot_import", opts)
+	require.NoError(t, err)
+
+	txID, alh := importedStore.Alh()
+	require.Equal(t, uint64(6), txID)
+	require.Equal(t, snapTx.Alh, alh)
+
+	require.Empty(t, importedStore.NamedSnapshots())
+
+	err = importedStore.WaitForIndexingUpto(context.Background(), 6)
+	require.NoError(t, err)
+
+	for i := 1; i <= 6; i++ {
+		v, _, _, err := importedStore.Get([]byte(fmt.Sprintf("key%d", i)))
+		require.NoError(t, err)
+		require.Equal(t, []byte(fmt.Sprintf("value%d", i)), v)
+	}
+
+	_, _, _, err = importedStore.Get([]byte("key7"))
+	require.Equal(t, ErrKeyNotFound, err)
+
+	// the restored store accepts new commits
+	_, err = importedStore.Commit([]*KV{{Key: []byte("key7"), Value: []byte("restored")}}, false)
+	require.NoError(t, err)
+
+	err = importedStore.Close()
+	require.NoError(t, err)
+
+	_, err = ImportSnapshot("data_snapshot_export", "data_snapshot_import", opts)
+	require.True(t, os.IsExist(err))
+
+	err = os.RemoveAll("data_snapshot_import")
+	require.NoError(t, err)
+
+	// an export whose manifest does not match its transactions is rejected
+	m.Alh[0]++
+	err = writeSnapshotManifest("data_snapshot_export", m, 0600)
+	require.NoError(t, err)
+
+	_, err = ImportSnapshot("data_snapshot_export", "data_snapshot_import", opts)
+	require.True(t, errors.Is(err, ErrInvalidSnapshotExport))
+
+	_, err = os.Stat("data_snapshot_import")
+	require.True(t, os.IsNotExist(err))
+
+	err = ioutil.WriteFile(filepath.Join("data_snapshot_export", snapshotManifestFilename), []byte{snapshotManifestVersion, 0}, 0600)
+	require.NoError(t, err)
+
+	_, err = ReadSnapshotManifest("data_snapshot_export")
+	require.True(t, errors.Is(err, ErrInvalidSnapshotExport))
+
+	err = ioutil.WriteFile(filepath.Join("data_snapshot_export", snapshotManifestFilename), []byte{snapshotManifestVersion + 1}, 0600)
+	require.NoError(t, err)
+
+	_, err = ReadSnapshotManifest("data_snapshot_export")
+	require.True(t, errors.Is(err, ErrInvalidSnapshotExport))
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package store
+
+// Subscription delivers committed transactions in order, starting from a given one.
+// Transactions are read on demand, thus consumers set the pace and nothing gets buffered.
+type Subscription struct {
+	st           *ImmuStore
+	txReader     *TxReader
+	cancellation <-chan struct{}
+}
+
+// Subscribe returns a subscription to transactions committed from initialTxID onwards,
+// closing the cancellation channel unblocks a subscriber waiting for the next transaction
+func (s *ImmuStore) Subscribe(initialTxID uint64, tx *Tx, cancellation <-chan struct{}) (*Subscription, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.closed {
+		return nil, ErrAlreadyClosed
+	}
+
+	txReader, err := s.newTxReader(initialTxID, false, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Subscription{
+		st:           s,
+		txReader:     txReader,
+		cancellation: cancellation,
+	}, nil
+}
+
+// Next returns the next committed transaction, waiting for it to be committed if needed
+func (sub *Subscription) Next() (*Tx, error) {
+	err := sub.st.wHub.WaitFor(sub.txReader.CurrTxID, sub.cancellation)
+	if err != nil {
+		return nil, err
+	}
+
+	return sub.txReader.Read()
+}
+
+// CurrTxID returns the id of the transaction to be returned by the next call to Next
+func (sub *Subscription) CurrTxID() uint64 {
+	return sub.txReader.CurrTxID
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package store
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscription(t *testing.T) {
+	opts := DefaultOptions().WithSynced(false).WithMaxConcurrency(1)
+	immuStore, err := Open("data_subscription", opts)
+	require.NoError(t, err)
+	defer os.RemoveAll("data_subscription")
+
+	_, err = immuStore.Subscribe(0, immuStore.NewTx(), nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = immuStore.Subscribe(1, nil, nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	txCount := 10
+
+	commit := func(i int) {
+		_, err := immuStore.Commit([]*KV{{Key: []byte(fmt.Sprintf("key%d", i)), Value: []byte("value")}}, false)
+		require.NoError(t, err)
+	}
+
+	commit(1)
+
+	sub, err := immuStore.Subscribe(1, immuStore.NewTx(), nil)
+	require.NoError(t, err)
+
+	// transactions are delivered as soon as they get committed
+	go func() {
+		for i := 2; i <= txCount; i++ {
+			commit(i)
+		}
+	}()
+
+	for i := 1; i <= txCount; i++ {
+		require.Equal(t, uint64(i), sub.CurrTxID())
+
+		tx, err := sub.Next()
+		require.NoError(t, err)
+		require.Equal(t, uint64(i), tx.ID)
+		require.Equal(t, []byte(fmt.Sprintf("key%d", i)), tx.Entries()[0].key())
+	}
+
+	cancellation := make(chan struct{})
+
+	sub, err = immuStore.Subscribe(uint64(txCount+1), immuStore.NewTx(), cancellation)
+	require.NoError(t, err)
+
+	close(cancellation)
+
+	_, err = sub.Next()
+	require.Equal(t, ErrCancellationRequested, err)
+
+	sub, err = immuStore.Subscribe(uint64(txCount+1), immuStore.NewTx(), nil)
+	require.NoError(t, err)
+
+	errCh := make(chan error)
+	go func() {
+		_, err := sub.Next()
+		errCh <- err
+	}()
+
+	err = immuStore.Close()
+	require.NoError(t, err)
+
+	require.Error(t, <-errCh)
+
+	_, err = immuStore.Subscribe(1, immuStore.NewTx(), nil)
+	require.Equal(t, ErrAlreadyClosed, err)
+}
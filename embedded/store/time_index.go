@@ -0,0 +1,230 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package store
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/codenotary/immudb/embedded/appendable"
+	"github.com/codenotary/immudb/embedded/appendable/multiapp"
+)
+
+const timeIndexDirname = "time"
+
+// openTimeIndex opens the tx-time index, holding the timestamp of every committed tx.
+// Timestamps are stored as non-decreasing values, thus a tx whose clock was behind the one
+// of a previous tx is indexed with the timestamp of the previous tx.
+//
+// A nil index is returned when a store created without it is opened in read-only mode,
+// in such case lookups are resolved using the tx log.
+func openTimeIndex(path string, fileSize int, opts *Options) (appendable.Appendable, error) {
+	timeIndexPath := filepath.Join(path, timeIndexDirname)
+
+	if opts.ReadOnly {
+		_, err := os.Stat(timeIndexPath)
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+	}
+
+	appendableOpts := multiapp.DefaultOptions().
+		WithReadOnly(opts.ReadOnly).
+		WithSynced(opts.Synced).
+		WithFileSize(fileSize).
+		WithFileMode(opts.FileMode).
+		WithFileExt("ts")
+
+	return multiapp.Open(timeIndexPath, appendableOpts)
+}
+
+// syncTimeIndex discards entries of uncommitted txs and indexes the txs committed
+// but not yet indexed, which may happen when the store was not properly closed
+func (s *ImmuStore) syncTimeIndex() error {
+	indexedTxs, err := s.timeIndexedTxs()
+	if err != nil {
+		return err
+	}
+
+	if indexedTxs > s.committedTxID {
+		indexedTxs = s.committedTxID
+	}
+
+	if indexedTxs > 0 {
+		s.committedTs, err = s.indexedTs(indexedTxs)
+		if err != nil {
+			return err
+		}
+	}
+
+	if s.readOnly {
+		// lookups of txs not yet indexed are resolved using the tx log
+		return nil
+	}
+
+	err = s.timeIndex.SetOffset(int64(indexedTxs) * tsSize)
+	if err != nil {
+		return err
+	}
+
+	if indexedTxs == s.committedTxID {
+		return nil
+	}
+
+	s.log.Infof("Syncing tx-time index at '%s'...", s.path)
+
+	tx, err := s.fetchAllocTx()
+	if err != nil {
+		return err
+	}
+	defer s.releaseAllocTx(tx)
+
+	txReader, err := s.NewTxReader(indexedTxs+1, false, tx)
+	if err != nil {
+		return err
+	}
+
+	for {
+		tx, err := txReader.Read()
+		if err == ErrNoMoreEntries {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		err = s.appendTs(tx.Ts)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = s.timeIndex.Flush()
+	if err != nil {
+		return err
+	}
+
+	s.log.Infof("Tx-time index up to date at '%s'", s.path)
+
+	return nil
+}
+
+// appendTs indexes the timestamp of the tx next to the last indexed one
+func (s *ImmuStore) appendTs(ts int64) error {
+	if ts < s.committedTs {
+		ts = s.committedTs
+	}
+
+	var b [tsSize]byte
+	binary.BigEndian.PutUint64(b[:], uint64(ts))
+
+	_, _, err := s.timeIndex.Append(b[:])
+	if err != nil {
+		return err
+	}
+
+	s.committedTs = ts
+
+	return nil
+}
+
+func (s *ImmuStore) timeIndexedTxs() (uint64, error) {
+	if s.timeIndex == nil {
+		return 0, nil
+	}
+
+	size, err := s.timeIndex.Size()
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(size / tsSize), nil
+}
+
+func (s *ImmuStore) indexedTs(txID uint64) (int64, error) {
+	var b [tsSize]byte
+
+	_, err := s.timeIndex.ReadAt(b[:], int64(txID-1)*tsSize)
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(binary.BigEndian.Uint64(b[:])), nil
+}
+
+// LastTxUntil returns the id of the latest tx committed at or before the given time,
+// ErrTxNotFound is returned if there is none
+func (s *ImmuStore) LastTxUntil(t time.Time) (uint64, error) {
+	committedTxID, _, _ := s.commitState()
+
+	indexedTxs, err := s.timeIndexedTxs()
+	if err != nil {
+		return 0, err
+	}
+
+	if indexedTxs > committedTxID {
+		indexedTxs = committedTxID
+	}
+
+	ts := t.Unix()
+
+	var txID uint64
+
+	for lo, hi := uint64(1), indexedTxs+1; lo < hi; {
+		mid := lo + (hi-lo)/2
+
+		midTs, err := s.indexedTs(mid)
+		if err != nil {
+			return 0, err
+		}
+
+		if midTs <= ts {
+			txID = mid
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+
+	if txID == indexedTxs && indexedTxs < committedTxID {
+		// txs not yet indexed (only when opened in read-only mode) are looked up in the tx log
+		tx := s.NewTx()
+
+		for lo, hi := indexedTxs+1, committedTxID+1; lo < hi; {
+			mid := lo + (hi-lo)/2
+
+			err := s.ReadTx(mid, tx)
+			if err != nil {
+				return 0, err
+			}
+
+			if tx.Ts <= ts {
+				txID = mid
+				lo = mid + 1
+			} else {
+				hi = mid
+			}
+		}
+	}
+
+	if txID == 0 {
+		return 0, ErrTxNotFound
+	}
+
+	return txID, nil
+}
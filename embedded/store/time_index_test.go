@@ -0,0 +1,134 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package store
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLastTxUntil(t *testing.T) {
+	dir := "data_time_index"
+	defer os.RemoveAll(dir)
+
+	opts := DefaultOptions().WithSynced(false)
+
+	immuStore, err := Open(dir, opts)
+	require.NoError(t, err)
+
+	_, err = immuStore.LastTxUntil(time.Now())
+	require.Equal(t, ErrTxNotFound, err)
+
+	txCount := 10
+
+	for i := 0; i < txCount; i++ {
+		_, err := immuStore.Commit([]*KV{{Key: []byte(fmt.Sprintf("key%d", i)), Value: []byte("value")}}, false)
+		require.NoError(t, err)
+	}
+
+	txID, err := immuStore.LastTxUntil(time.Now())
+	require.NoError(t, err)
+	require.Equal(t, uint64(txCount), txID)
+
+	_, err = immuStore.LastTxUntil(time.Now().Add(-time.Hour))
+	require.Equal(t, ErrTxNotFound, err)
+
+	tx := immuStore.NewTx()
+	err = immuStore.ReadTx(1, tx)
+	require.NoError(t, err)
+
+	txID, err = immuStore.LastTxUntil(time.Unix(tx.Ts, 0))
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, txID, uint64(1))
+
+	err = immuStore.WaitForIndexingUpto(context.Background(), uint64(txCount))
+	require.NoError(t, err)
+
+	err = immuStore.Close()
+	require.NoError(t, err)
+
+	t.Run("time index should be rebuilt from the tx log", func(t *testing.T) {
+		err := os.RemoveAll(filepath.Join(dir, timeIndexDirname))
+		require.NoError(t, err)
+
+		immuStore, err := Open(dir, opts)
+		require.NoError(t, err)
+
+		indexedTxs, err := immuStore.timeIndexedTxs()
+		require.NoError(t, err)
+		require.Equal(t, uint64(txCount), indexedTxs)
+
+		txID, err := immuStore.LastTxUntil(time.Now())
+		require.NoError(t, err)
+		require.Equal(t, uint64(txCount), txID)
+
+		// the index can not be updated once opened in read-only mode
+		err = immuStore.WaitForIndexingUpto(context.Background(), uint64(txCount))
+		require.NoError(t, err)
+
+		err = immuStore.Close()
+		require.NoError(t, err)
+	})
+
+	t.Run("txs should be looked up in the tx log when opened in read-only mode without time index", func(t *testing.T) {
+		err := os.RemoveAll(filepath.Join(dir, timeIndexDirname))
+		require.NoError(t, err)
+
+		immuStore, err := Open(dir, opts.WithReadOnly(true))
+		require.NoError(t, err)
+
+		txID, err := immuStore.LastTxUntil(time.Now())
+		require.NoError(t, err)
+		require.Equal(t, uint64(txCount), txID)
+
+		_, err = immuStore.LastTxUntil(time.Now().Add(-time.Hour))
+		require.Equal(t, ErrTxNotFound, err)
+
+		err = immuStore.Close()
+		require.NoError(t, err)
+	})
+}
+
+func TestTimeIndexNonDecreasing(t *testing.T) {
+	dir := "data_time_index_non_decreasing"
+	defer os.RemoveAll(dir)
+
+	immuStore, err := Open(dir, DefaultOptions().WithSynced(false))
+	require.NoError(t, err)
+	defer immuStore.Close()
+
+	now := time.Now().Unix()
+
+	err = immuStore.appendTs(now)
+	require.NoError(t, err)
+
+	// a tx committed with a clock behind is indexed with the timestamp of the previous tx
+	err = immuStore.appendTs(now - 10)
+	require.NoError(t, err)
+
+	err = immuStore.timeIndex.Flush()
+	require.NoError(t, err)
+
+	ts, err := immuStore.indexedTs(2)
+	require.NoError(t, err)
+	require.Equal(t, now, ts)
+}
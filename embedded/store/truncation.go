@@ -0,0 +1,435 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package store
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/codenotary/immudb/embedded/fileutil"
+)
+
+// truncatedTxIDFilename is the file, inside the store path, holding the id of the latest transaction
+// values were discarded up to, so relocation of live values resumes from there
+const truncatedTxIDFilename = "TRUNCATED"
+
+// TruncateUpTo discards the values of the transactions up to txID (included).
+// Transaction and commit logs are kept so proofs can still be generated and verified,
+// reading a discarded value returns ErrValueTruncated.
+// Values which are still current in the index, like those of the SQL catalog, are committed again
+// in new transactions before being discarded, so keys remain readable and only their history is lost.
+// Only whole value log files are removed, thus values belonging to discarded transactions
+// which share files with values of newer transactions may still be readable.
+// Truncation is not allowed while named snapshots exist.
+func (s *ImmuStore) TruncateUpTo(txID uint64) error {
+	return s.truncateUpTo(context.Background(), txID)
+}
+
+func (s *ImmuStore) truncateUpTo(ctx context.Context, txID uint64) error {
+	s.truncationMutex.Lock()
+	defer s.truncationMutex.Unlock()
+
+	err := s.checkTruncation(txID)
+	if err != nil {
+		return err
+	}
+
+	err = s.relocateLiveValues(ctx, txID)
+	if err != nil {
+		return err
+	}
+
+	s.truncationRWMutex.Lock()
+	defer s.truncationRWMutex.Unlock()
+
+	err = s.checkTruncation(txID)
+	if err != nil {
+		return err
+	}
+
+	discardOffs, err := s.discardOffsets(txID)
+	if err != nil {
+		return err
+	}
+
+	for vLogID, off := range discardOffs {
+		vLog, err := s.fetchVLog(vLogID, true)
+		if err != nil {
+			return err
+		}
+
+		err = vLog.DiscardUpTo(off)
+		s.releaseVLog(vLogID)
+		if err != nil {
+			return s.wrapAppendableErr(err, "discarding values")
+		}
+	}
+
+	if txID > s.truncatedTxID {
+		err = writeTruncatedTxID(s.path, txID, s.fileMode)
+		if err != nil {
+			return err
+		}
+
+		s.truncatedTxID = txID
+	}
+
+	s.log.Infof("Values up to tx %d discarded at '%s'", txID, s.path)
+
+	s.publish(EventTruncation, txID)
+
+	return nil
+}
+
+func (s *ImmuStore) checkTruncation(txID uint64) error {
+	s.mutex.Lock()
+	closed := s.closed
+	s.mutex.Unlock()
+
+	if closed {
+		return ErrAlreadyClosed
+	}
+
+	if s.readOnly {
+		return ErrReadOnly
+	}
+
+	if s.hasNamedSnapshots() {
+		return ErrSnapshotPinned
+	}
+
+	committedTxID, _, _ := s.commitState()
+
+	if txID == 0 || txID > committedTxID {
+		return ErrIllegalArguments
+	}
+
+	return nil
+}
+
+// discardOffsets returns, for each value log, the offset values can be discarded up to when truncating
+// up to txID. Values of retained transactions are never appended before them
+func (s *ImmuStore) discardOffsets(txID uint64) (map[byte]int64, error) {
+	discardOffs := make(map[byte]int64, len(s.vLogs))
+
+	for i := range s.vLogs {
+		vLogID := byte(i + 1)
+
+		vLog, err := s.fetchVLog(vLogID, true)
+		if err != nil {
+			return nil, err
+		}
+
+		size, err := vLog.Size()
+		s.releaseVLog(vLogID)
+		if err != nil {
+			return nil, err
+		}
+
+		discardOffs[vLogID] = size
+	}
+
+	committedTxID, _, _ := s.commitState()
+
+	if txID == committedTxID {
+		return discardOffs, nil
+	}
+
+	txReader, err := s.newTxReader(txID+1, false, s.NewTx())
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		tx, err := txReader.Read()
+		if err == ErrNoMoreEntries {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, e := range tx.Entries() {
+			// values stored inline are kept together with their tx
+			if e.vLen == 0 || e.inlined() {
+				continue
+			}
+
+			vLogID, off := decodeOffset(e.vOff)
+
+			if off < discardOffs[vLogID] {
+				discardOffs[vLogID] = off
+			}
+		}
+
+		if tx.ID == committedTxID {
+			break
+		}
+	}
+
+	return discardOffs, nil
+}
+
+// relocateLiveValues commits again the current values of the keys which would be discarded by truncating
+// up to txID. Only the transactions committed since the previous truncation are read, as live values of
+// older ones were already relocated. Keys modified in the meantime are not overwritten, the live values are
+// then looked up again
+func (s *ImmuStore) relocateLiveValues(ctx context.Context, txID uint64) error {
+	if txID <= s.truncatedTxID {
+		return nil
+	}
+
+	for {
+		committedTxID, _, _ := s.commitState()
+
+		err := s.WaitForIndexingUpto(ctx, committedTxID)
+		if err != nil {
+			return err
+		}
+
+		entries, preconditions, err := s.liveValuesOf(committedTxID, s.truncatedTxID+1, txID)
+		if err != nil {
+			return err
+		}
+
+		if len(entries) == 0 {
+			return nil
+		}
+
+		for len(entries) > 0 {
+			n := len(entries)
+			if n > s.maxTxEntries {
+				n = s.maxTxEntries
+			}
+
+			// relocated values must be indexed before the previous ones get discarded
+			_, err = s.CommitWithPreconditions(entries[:n], preconditions[:n], true)
+			if errors.Is(err, ErrPreconditionFailed) {
+				break
+			}
+			if err != nil {
+				return err
+			}
+
+			entries, preconditions = entries[n:], preconditions[n:]
+		}
+
+		if len(entries) == 0 {
+			s.log.Infof("Live values up to tx %d relocated at '%s'", txID, s.path)
+			return nil
+		}
+	}
+}
+
+// liveValuesOf returns the entries of the transactions from fromTxID to toTxID which are still current as of
+// snapTxID, and whose values are not stored inline, together with preconditions preventing them
+// from overwriting newer values
+func (s *ImmuStore) liveValuesOf(snapTxID, fromTxID, toTxID uint64) ([]*KV, []Precondition, error) {
+	snap, err := s.SnapshotSince(snapTxID)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer snap.Close()
+
+	txReader, err := s.newTxReader(fromTxID, false, s.NewTx())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var entries []*KV
+	var preconditions []Precondition
+
+	for {
+		tx, err := txReader.Read()
+		if err == ErrNoMoreEntries {
+			return entries, preconditions, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, e := range tx.Entries() {
+			// values stored inline are kept together with their tx
+			if e.vLen == 0 || e.inlined() {
+				continue
+			}
+
+			valRef, valTx, _, err := snap.getRef(e.key())
+			if err == ErrKeyNotFound {
+				continue
+			}
+			if err != nil {
+				return nil, nil, err
+			}
+
+			// the key was updated afterwards, the new value is relocated when its tx gets truncated
+			if valTx != tx.ID {
+				continue
+			}
+
+			val, err := valRef.Resolve()
+			if err == ErrValueTruncated {
+				// already discarded by a previous truncation
+				continue
+			}
+			if err != nil {
+				return nil, nil, err
+			}
+
+			k := make([]byte, e.kLen)
+			copy(k, e.key())
+
+			entries = append(entries, &KV{Key: k, Metadata: valRef.md, Value: val})
+			preconditions = append(preconditions, &PreconditionKeyNotModifiedAfterTx{Key: k, TxID: valTx})
+		}
+
+		if tx.ID == toTxID {
+			return entries, preconditions, nil
+		}
+	}
+}
+
+// Vacuum discards the values of the transactions committed before the retention period, truncating
+// at most VacuumBatchSize transactions at a time. It's a no-op if no retention period was set
+func (s *ImmuStore) Vacuum() error {
+	return s.vacuum(context.Background())
+}
+
+func (s *ImmuStore) vacuum(ctx context.Context) error {
+	if s.retentionPeriod == 0 {
+		return nil
+	}
+
+	txID, err := s.lastTxBefore(s.timeFunc().Add(-s.retentionPeriod))
+	if err != nil {
+		return err
+	}
+
+	for {
+		s.truncationMutex.Lock()
+		truncatedTxID := s.truncatedTxID
+		s.truncationMutex.Unlock()
+
+		if txID <= truncatedTxID {
+			return nil
+		}
+
+		batchTxID := txID
+		if batchTxID-truncatedTxID > uint64(s.vacuumBatchSize) {
+			batchTxID = truncatedTxID + uint64(s.vacuumBatchSize)
+		}
+
+		err = s.truncateUpTo(ctx, batchTxID)
+		if err != nil {
+			return err
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+// vacuumPeriodically discards the values out of the retention period every vacuum interval,
+// until the context gets cancelled
+func (s *ImmuStore) vacuumPeriodically(ctx context.Context) {
+	defer close(s.vacuumDone)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(s.vacuumInterval):
+		}
+
+		err := s.vacuum(ctx)
+		// values are kept while named snapshots exist or once the store was shrunk
+		if err != nil && ctx.Err() == nil && !errors.Is(err, ErrSnapshotPinned) && !errors.Is(err, ErrStoreShrunk) {
+			s.log.Warningf("Unable to discard values out of the retention period at '%s': %v", s.path, err)
+		}
+	}
+}
+
+func (s *ImmuStore) stopVacuum() {
+	if s.vacuumCancel == nil {
+		return
+	}
+
+	s.vacuumCancel()
+	<-s.vacuumDone
+}
+
+// lastTxBefore returns the id of the latest transaction committed before the given time,
+// or zero if there is none
+func (s *ImmuStore) lastTxBefore(t time.Time) (uint64, error) {
+	txID, err := s.LastTxUntil(time.Unix(t.Unix()-1, 0))
+	if err == ErrTxNotFound {
+		return 0, nil
+	}
+
+	return txID, err
+}
+
+// readTruncatedTxID reads the id of the latest transaction values were discarded up to, zero if none
+func readTruncatedTxID(path string) (uint64, error) {
+	b, err := ioutil.ReadFile(filepath.Join(path, truncatedTxIDFilename))
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if len(b) != txIDSize {
+		return 0, ErrCorruptedData
+	}
+
+	return binary.BigEndian.Uint64(b), nil
+}
+
+// writeTruncatedTxID atomically replaces the id of the latest transaction values were discarded up to
+func writeTruncatedTxID(path string, txID uint64, fileMode os.FileMode) error {
+	var b [txIDSize]byte
+	binary.BigEndian.PutUint64(b[:], txID)
+
+	tmpPath := filepath.Join(path, truncatedTxIDFilename+".tmp")
+
+	f, err := fileutil.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, fileMode)
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Write(b[:])
+	if err == nil {
+		err = f.Sync()
+	}
+
+	cErr := f.Close()
+	if err == nil {
+		err = cErr
+	}
+	if err != nil {
+		fileutil.Remove(tmpPath)
+		return err
+	}
+
+	return fileutil.Rename(tmpPath, filepath.Join(path, truncatedTxIDFilename))
+}
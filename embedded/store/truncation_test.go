@@ -0,0 +1,246 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package store
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestImmudbStoreTruncation(t *testing.T) {
+	opts := DefaultOptions().
+		WithSynced(false).
+		WithMaxConcurrency(1).
+		WithFileSize(64)
+
+	immuStore, err := Open("data_truncation", opts)
+	require.NoError(t, err)
+	defer os.RemoveAll("data_truncation")
+
+	txCount := 20
+
+	for i := 1; i <= txCount; i++ {
+		// each value takes 16 bytes so each value log file holds exactly four values
+		_, err = immuStore.Commit([]*KV{
+			{Key: []byte(fmt.Sprintf("key%d", i)), Value: []byte(fmt.Sprintf("value%11d", i))},
+		}, true)
+		require.NoError(t, err)
+	}
+
+	err = immuStore.TruncateUpTo(0)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	err = immuStore.TruncateUpTo(uint64(txCount + 1))
+	require.Equal(t, ErrIllegalArguments, err)
+
+	// values of tx 9 and 10 share the value log file with values of retained transactions
+	err = immuStore.TruncateUpTo(10)
+	require.NoError(t, err)
+	require.Equal(t, uint64(txCount+1), immuStore.TxCount())
+
+	checkTruncation := func(st *ImmuStore) {
+		tx := st.NewTx()
+
+		for i := 1; i <= txCount; i++ {
+			err = st.ReadTx(uint64(i), tx)
+			require.NoError(t, err)
+
+			key := []byte(fmt.Sprintf("key%d", i))
+
+			_, err = st.ReadValue(tx, key)
+
+			if i <= 8 {
+				require.Equal(t, ErrValueTruncated, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			// current values were relocated before being discarded
+			val, valTx, _, err := st.Get(key)
+			require.NoError(t, err)
+			require.Equal(t, []byte(fmt.Sprintf("value%11d", i)), val)
+
+			// values of truncated transactions are relocated, even those which were not discarded
+			if i <= 10 {
+				require.Equal(t, uint64(txCount+1), valTx)
+			} else {
+				require.Equal(t, uint64(i), valTx)
+			}
+		}
+
+		// proofs are not affected by truncation
+		sourceTx := st.NewTx()
+		targetTx := st.NewTx()
+
+		err = st.ReadTx(1, sourceTx)
+		require.NoError(t, err)
+
+		err = st.ReadTx(uint64(txCount), targetTx)
+		require.NoError(t, err)
+
+		dproof, err := st.DualProof(sourceTx, targetTx)
+		require.NoError(t, err)
+		require.True(t, VerifyDualProof(dproof, 1, uint64(txCount), sourceTx.Alh, targetTx.Alh))
+
+		lproof, err := st.LinearProof(1, uint64(txCount))
+		require.NoError(t, err)
+		require.True(t, VerifyLinearProof(lproof, 1, uint64(txCount), sourceTx.Alh, targetTx.Alh))
+	}
+
+	checkTruncation(immuStore)
+
+	err = immuStore.Close()
+	require.NoError(t, err)
+
+	err = immuStore.TruncateUpTo(10)
+	require.Equal(t, ErrAlreadyClosed, err)
+
+	immuStore, err = Open("data_truncation", opts)
+	require.NoError(t, err)
+
+	err = immuStore.WaitForIndexingUpto(context.Background(), immuStore.TxCount())
+	require.NoError(t, err)
+
+	checkTruncation(immuStore)
+
+	// the truncation point is kept, so only values of the transactions committed since then are relocated
+	require.Equal(t, uint64(10), immuStore.truncatedTxID)
+
+	err = immuStore.TruncateUpTo(12)
+	require.NoError(t, err)
+	require.Equal(t, uint64(12), immuStore.truncatedTxID)
+	require.Equal(t, uint64(txCount+2), immuStore.TxCount())
+
+	relocatedTx := immuStore.NewTx()
+
+	err = immuStore.ReadTx(uint64(txCount+2), relocatedTx)
+	require.NoError(t, err)
+	require.Len(t, relocatedTx.Entries(), 2)
+
+	// new values can still be committed once truncated up to the last tx
+	err = immuStore.TruncateUpTo(uint64(txCount))
+	require.NoError(t, err)
+
+	md, err := immuStore.Commit([]*KV{{Key: []byte("key"), Value: []byte("value")}}, true)
+	require.NoError(t, err)
+
+	tx := immuStore.NewTx()
+
+	err = immuStore.ReadTx(md.ID, tx)
+	require.NoError(t, err)
+
+	val, err := immuStore.ReadValue(tx, []byte("key"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), val)
+
+	err = immuStore.Close()
+	require.NoError(t, err)
+}
+
+func TestImmudbStoreVacuum(t *testing.T) {
+	opts := DefaultOptions().WithSynced(false).WithMaxConcurrency(1)
+
+	immuStore, err := Open("data_vacuum", opts)
+	require.NoError(t, err)
+	defer os.RemoveAll("data_vacuum")
+
+	defer immuStore.Close()
+
+	txID, err := immuStore.lastTxBefore(time.Now())
+	require.NoError(t, err)
+	require.Zero(t, txID)
+
+	for i := 0; i < 5; i++ {
+		_, err = immuStore.Commit([]*KV{{Key: []byte("key"), Value: []byte("value")}}, true)
+		require.NoError(t, err)
+	}
+
+	txID, err = immuStore.lastTxBefore(time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Zero(t, txID)
+
+	txID, err = immuStore.lastTxBefore(time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), txID)
+
+	// no retention period
+	err = immuStore.Vacuum()
+	require.NoError(t, err)
+
+	immuStore.retentionPeriod = time.Hour
+
+	err = immuStore.Vacuum()
+	require.NoError(t, err)
+
+	val, _, _, err := immuStore.Get([]byte("key"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), val)
+}
+
+func TestImmudbStorePeriodicVacuum(t *testing.T) {
+	now := time.Now().Unix()
+
+	opts := DefaultOptions().
+		WithSynced(false).
+		WithMaxConcurrency(1).
+		WithRetentionPeriod(time.Hour).
+		WithVacuumInterval(10 * time.Millisecond).
+		WithVacuumBatchSize(2).
+		WithTimeFunc(func() time.Time { return time.Unix(atomic.LoadInt64(&now), 0) })
+
+	immuStore, err := Open("data_periodic_vacuum", opts)
+	require.NoError(t, err)
+	defer os.RemoveAll("data_periodic_vacuum")
+
+	for i := 1; i <= 5; i++ {
+		_, err = immuStore.Commit([]*KV{{Key: []byte(fmt.Sprintf("key%d", i)), Value: []byte(fmt.Sprintf("value%d", i))}}, true)
+		require.NoError(t, err)
+	}
+
+	// values are not discarded while within the retention period
+	time.Sleep(50 * time.Millisecond)
+
+	immuStore.truncationMutex.Lock()
+	require.Zero(t, immuStore.truncatedTxID)
+	immuStore.truncationMutex.Unlock()
+
+	atomic.AddInt64(&now, int64(2*time.Hour/time.Second))
+
+	require.Eventually(t, func() bool {
+		immuStore.truncationMutex.Lock()
+		defer immuStore.truncationMutex.Unlock()
+
+		return immuStore.truncatedTxID == 5
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// values were relocated in batches of at most two transactions
+	require.Equal(t, uint64(8), immuStore.TxCount())
+
+	for i := 1; i <= 5; i++ {
+		val, _, _, err := immuStore.Get([]byte(fmt.Sprintf("key%d", i)))
+		require.NoError(t, err)
+		require.Equal(t, []byte(fmt.Sprintf("value%d", i)), val)
+	}
+
+	err = immuStore.Close()
+	require.NoError(t, err)
+}
@@ -31,6 +31,11 @@ type Tx struct {
 	BlRoot  [sha256.Size]byte
 	PrevAlh [sha256.Size]byte
 
+	// Version of the tx format, it's derived from the entries and attributes being committed
+	Version int
+
+	Attributes *TxAttributes
+
 	nentries int
 	entries  []*TxEntry
 
@@ -44,12 +49,34 @@ type TxMetadata struct {
 	ID       uint64
 	PrevAlh  [sha256.Size]byte
 	Ts       int64
+	Version  int
 	NEntries int
 	Eh       [sha256.Size]byte
 	BlTxID   uint64
 	BlRoot   [sha256.Size]byte
+
+	Attributes *TxAttributes
 }
 
+const (
+	// txs written before entry metadata was introduced
+	TxVersion0 = 0
+	// entries may hold metadata
+	TxVersion1 = 1
+	// txs may hold attributes
+	TxVersion2 = 2
+)
+
+const LastTxVersion = TxVersion2
+
+const txVersionSize = 2
+
+// inlineValuesFlag is set on the version of txs stored in the tx log together with some of their values,
+// it's not part of the version the tx hash is calculated with
+const inlineValuesFlag = 0x8000
+
+const inlineValueLenSize = 2
+
 func NewTx(nentries int, maxKeyLen int) *Tx {
 	entries := make([]*TxEntry, nentries)
 	for i := 0; i < nentries; i++ {
@@ -80,10 +107,13 @@ func (tx *Tx) Metadata() *TxMetadata {
 		ID:       tx.ID,
 		PrevAlh:  prevAlh,
 		Ts:       tx.Ts,
+		Version:  tx.Version,
 		NEntries: tx.nentries,
 		Eh:       tx.Eh(),
 		BlTxID:   tx.BlTxID,
 		BlRoot:   blRoot,
+
+		Attributes: tx.Attributes,
 	}
 }
 
@@ -93,13 +123,7 @@ func (txMetadata *TxMetadata) Alh() [sha256.Size]byte {
 	binary.BigEndian.PutUint64(bi[:], txMetadata.ID)
 	copy(bi[txIDSize:], txMetadata.PrevAlh[:])
 
-	var bj [tsSize + 4 + sha256.Size + txIDSize + sha256.Size]byte
-	binary.BigEndian.PutUint64(bj[:], uint64(txMetadata.Ts))
-	binary.BigEndian.PutUint32(bj[tsSize:], uint32(txMetadata.NEntries))
-	copy(bj[tsSize+4:], txMetadata.Eh[:])
-	binary.BigEndian.PutUint64(bj[tsSize+4+sha256.Size:], txMetadata.BlTxID)
-	copy(bj[tsSize+4+sha256.Size+txIDSize:], txMetadata.BlRoot[:])
-	innerHash := sha256.Sum256(bj[:]) // hash(ts + nentries + eH + blTxID + blRoot)
+	innerHash := innerHashFor(txMetadata.Ts, txMetadata.Version, txMetadata.NEntries, txMetadata.Eh, txMetadata.BlTxID, txMetadata.BlRoot, txMetadata.Attributes)
 
 	copy(bi[txIDSize+sha256.Size:], innerHash[:]) // hash(txID + prevAlh + innerHash)
 
@@ -135,15 +159,55 @@ func (tx *Tx) CalcAlh() {
 }
 
 func (tx *Tx) calcInnerHash() {
-	var bj [tsSize + 4 + sha256.Size + txIDSize + sha256.Size]byte
-	binary.BigEndian.PutUint64(bj[:], uint64(tx.Ts))
-	binary.BigEndian.PutUint32(bj[tsSize:], uint32(tx.nentries))
-	eh := tx.Eh()
-	copy(bj[tsSize+4:], eh[:])
-	binary.BigEndian.PutUint64(bj[tsSize+4+sha256.Size:], tx.BlTxID)
-	copy(bj[tsSize+4+sha256.Size+txIDSize:], tx.BlRoot[:])
+	tx.InnerHash = innerHashFor(tx.Ts, tx.Version, tx.nentries, tx.Eh(), tx.BlTxID, tx.BlRoot, tx.Attributes)
+}
 
-	tx.InnerHash = sha256.Sum256(bj[:]) // hash(ts + nentries + eH + blTxID + blRoot)
+// innerHashFor calculates hash(ts + version + nentries + eH + blTxID + blRoot)
+// version and nentries are encoded using two bytes each, thus the inner hash of
+// txs written with TxVersion0 (where nentries was encoded using four bytes) is preserved.
+// Starting from TxVersion2, attrsLen + attrs are appended after blRoot
+func innerHashFor(ts int64, version int, nentries int, eh [sha256.Size]byte, blTxID uint64, blRoot [sha256.Size]byte, attrs *TxAttributes) [sha256.Size]byte {
+	var attrsbs []byte
+	if version >= TxVersion2 {
+		attrsbs = attrs.Bytes()
+	}
+
+	bj := make([]byte, tsSize+txVersionSize+2+sha256.Size+txIDSize+sha256.Size, tsSize+txVersionSize+2+sha256.Size+txIDSize+sha256.Size+txAttrsLenSize+len(attrsbs))
+	i := 0
+	binary.BigEndian.PutUint64(bj[i:], uint64(ts))
+	i += tsSize
+	binary.BigEndian.PutUint16(bj[i:], uint16(version))
+	i += txVersionSize
+	binary.BigEndian.PutUint16(bj[i:], uint16(nentries))
+	i += 2
+	copy(bj[i:], eh[:])
+	i += sha256.Size
+	binary.BigEndian.PutUint64(bj[i:], blTxID)
+	i += txIDSize
+	copy(bj[i:], blRoot[:])
+
+	if version >= TxVersion2 {
+		var lb [txAttrsLenSize]byte
+		binary.BigEndian.PutUint16(lb[:], uint16(len(attrsbs)))
+		bj = append(bj, lb[:]...)
+		bj = append(bj, attrsbs...)
+	}
+
+	return sha256.Sum256(bj)
+}
+
+// versionFor returns the lowest tx format version able to hold the given entries and attributes
+func versionFor(entries []*TxEntry, attrs *TxAttributes) int {
+	if len(attrs.Bytes()) > 0 {
+		return TxVersion2
+	}
+
+	for _, e := range entries {
+		if len(e.md.Bytes()) > 0 {
+			return TxVersion1
+		}
+	}
+	return TxVersion0
 }
 
 func (tx *Tx) Eh() [sha256.Size]byte {
@@ -169,6 +233,104 @@ func (tx *Tx) Proof(key []byte) (*htree.InclusionProof, error) {
 	return tx.htree.InclusionProof(kindex)
 }
 
+// serializeTo writes the tx, without the values stored inline, into bs, which must be large enough to hold it,
+// and returns the number of bytes written
+func (tx *Tx) serializeTo(bs []byte) int {
+	return tx.serialize(bs, 0, false)
+}
+
+// serializeForLogAt writes the tx as stored in the tx log at the offset txOff. Values stored inline are written
+// after their entries, whose value offsets are set to the position of the values within the tx log.
+func (tx *Tx) serializeForLogAt(bs []byte, txOff int64) int {
+	return tx.serialize(bs, txOff, true)
+}
+
+func (tx *Tx) hasInlineValues() bool {
+	for i := 0; i < tx.nentries; i++ {
+		if tx.entries[i].inlined() {
+			return true
+		}
+	}
+	return false
+}
+
+func (tx *Tx) serialize(bs []byte, txOff int64, withInlineValues bool) int {
+	withInlineValues = withInlineValues && tx.hasInlineValues()
+
+	version := tx.Version
+	if withInlineValues {
+		version |= inlineValuesFlag
+	}
+
+	txSize := 0
+
+	binary.BigEndian.PutUint64(bs[txSize:], uint64(tx.ID))
+	txSize += txIDSize
+	binary.BigEndian.PutUint64(bs[txSize:], uint64(tx.Ts))
+	txSize += tsSize
+	binary.BigEndian.PutUint64(bs[txSize:], uint64(tx.BlTxID))
+	txSize += txIDSize
+	copy(bs[txSize:], tx.BlRoot[:])
+	txSize += sha256.Size
+	copy(bs[txSize:], tx.PrevAlh[:])
+	txSize += sha256.Size
+	binary.BigEndian.PutUint16(bs[txSize:], uint16(version))
+	txSize += txVersionSize
+	binary.BigEndian.PutUint16(bs[txSize:], uint16(tx.nentries))
+	txSize += 2
+
+	if tx.Version >= TxVersion2 {
+		attrsbs := tx.Attributes.Bytes()
+		binary.BigEndian.PutUint16(bs[txSize:], uint16(len(attrsbs)))
+		txSize += txAttrsLenSize
+		copy(bs[txSize:], attrsbs)
+		txSize += len(attrsbs)
+	}
+
+	for i := 0; i < tx.nentries; i++ {
+		txe := tx.entries[i]
+
+		if tx.Version >= TxVersion1 {
+			mdbs := txe.md.Bytes()
+			binary.BigEndian.PutUint16(bs[txSize:], uint16(len(mdbs)))
+			txSize += mdLenSize
+			copy(bs[txSize:], mdbs)
+			txSize += len(mdbs)
+		}
+
+		binary.BigEndian.PutUint32(bs[txSize:], uint32(txe.kLen))
+		txSize += szSize
+		copy(bs[txSize:], txe.k[:txe.kLen])
+		txSize += txe.kLen
+		binary.BigEndian.PutUint32(bs[txSize:], uint32(txe.vLen))
+		txSize += szSize
+
+		// the offset is set once the position of the inline value is known
+		vOffPos := txSize
+		txSize += offsetSize
+
+		copy(bs[txSize:], txe.hVal[:])
+		txSize += sha256.Size
+
+		if withInlineValues && txe.inlined() {
+			binary.BigEndian.PutUint16(bs[txSize:], uint16(len(txe.val)))
+			txSize += inlineValueLenSize
+
+			txe.vOff = encodeOffset(txOff+int64(txSize), inlineVLogID)
+
+			copy(bs[txSize:], txe.val)
+			txSize += len(txe.val)
+		}
+
+		binary.BigEndian.PutUint64(bs[vOffPos:], uint64(txe.vOff))
+	}
+
+	copy(bs[txSize:], tx.Alh[:])
+	txSize += sha256.Size
+
+	return txSize
+}
+
 func (tx *Tx) readFrom(r *appendable.Reader) error {
 	id, err := r.ReadUint64()
 	if err != nil {
@@ -198,17 +360,92 @@ func (tx *Tx) readFrom(r *appendable.Reader) error {
 		return err
 	}
 
-	nentries, err := r.ReadUint32()
+	version, err := r.ReadUint16()
+	if err != nil {
+		return err
+	}
+
+	withInlineValues := version&inlineValuesFlag != 0
+	tx.Version = int(version &^ inlineValuesFlag)
+
+	if tx.Version > LastTxVersion {
+		return ErrUnsupportedTxVersion
+	}
+
+	nentries, err := r.ReadUint16()
 	if err != nil {
 		return err
 	}
+
+	if int(nentries) > len(tx.entries) {
+		return ErrorCorruptedTxData
+	}
 	tx.nentries = int(nentries)
 
+	tx.Attributes = nil
+
+	if tx.Version >= TxVersion2 {
+		attrsLen, err := r.ReadUint16()
+		if err != nil {
+			return err
+		}
+
+		if attrsLen > MaxTxAttributesLen {
+			return ErrorCorruptedTxData
+		}
+
+		if attrsLen > 0 {
+			attrsbs := make([]byte, attrsLen)
+
+			_, err = r.Read(attrsbs)
+			if err != nil {
+				return err
+			}
+
+			tx.Attributes, err = txAttributesFrom(attrsbs)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	for i := 0; i < int(nentries); i++ {
+		tx.entries[i].md = nil
+		tx.entries[i].val = nil
+
+		if tx.Version >= TxVersion1 {
+			mdLen, err := r.ReadUint16()
+			if err != nil {
+				return err
+			}
+
+			if mdLen > MaxKVMetadataLen {
+				return ErrorCorruptedTxData
+			}
+
+			if mdLen > 0 {
+				mdbs := make([]byte, mdLen)
+
+				_, err = r.Read(mdbs)
+				if err != nil {
+					return err
+				}
+
+				tx.entries[i].md, err = kvMetadataFrom(mdbs)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
 		kLen, err := r.ReadUint32()
 		if err != nil {
 			return err
 		}
+
+		if int(kLen) > len(tx.entries[i].k) {
+			return ErrorCorruptedTxData
+		}
 		tx.entries[i].kLen = int(kLen)
 
 		_, err = r.Read(tx.entries[i].k[:kLen])
@@ -232,6 +469,21 @@ func (tx *Tx) readFrom(r *appendable.Reader) error {
 		if err != nil {
 			return err
 		}
+
+		if withInlineValues && tx.entries[i].inlined() {
+			valLen, err := r.ReadUint16()
+			if err != nil {
+				return err
+			}
+
+			// values are not reused as they may be referenced after the tx is read again
+			tx.entries[i].val = make([]byte, valLen)
+
+			_, err = r.Read(tx.entries[i].val)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	var alh [sha256.Size]byte
@@ -254,16 +506,21 @@ func (tx *Tx) readFrom(r *appendable.Reader) error {
 type TxEntry struct {
 	k      []byte
 	kLen   int
+	md     *KVMetadata
 	vLen   int
 	hVal   [sha256.Size]byte
 	vOff   int64
 	unique bool
+
+	// value as stored inline within the tx log, if so
+	val []byte
 }
 
-func NewTxEntry(key []byte, vLen int, hVal [sha256.Size]byte, vOff int64) *TxEntry {
+func NewTxEntry(key []byte, md *KVMetadata, vLen int, hVal [sha256.Size]byte, vOff int64) *TxEntry {
 	e := &TxEntry{
 		k:    make([]byte, len(key)),
 		kLen: len(key),
+		md:   md,
 		vLen: vLen,
 		hVal: hVal,
 		vOff: vOff,
@@ -289,6 +546,10 @@ func (e *TxEntry) Key() []byte {
 	return k
 }
 
+func (e *TxEntry) Metadata() *KVMetadata {
+	return e.md
+}
+
 func (e *TxEntry) HVal() [sha256.Size]byte {
 	return e.hVal
 }
@@ -301,11 +562,42 @@ func (e *TxEntry) VLen() int {
 	return e.vLen
 }
 
+// inlined returns true if the value is stored within the tx log instead of the value log
+func (e *TxEntry) inlined() bool {
+	vLogID, _ := decodeOffset(e.vOff)
+	return vLogID == inlineVLogID
+}
+
 func (e *TxEntry) Digest() [sha256.Size]byte {
-	b := make([]byte, e.kLen+sha256.Size)
+	return entryDigest(e.k[:e.kLen], e.md, e.hVal)
+}
+
+// entryDigest calculates hash(key + hValue) for entries without metadata, entries with metadata are
+// digested as hash(mdLen + md + kLen + key + hValue)
+func entryDigest(key []byte, md *KVMetadata, hVal [sha256.Size]byte) [sha256.Size]byte {
+	mdbs := md.Bytes()
+
+	if len(mdbs) == 0 {
+		b := make([]byte, len(key)+sha256.Size)
+
+		copy(b[:], key)
+		copy(b[len(key):], hVal[:])
+
+		return sha256.Sum256(b)
+	}
 
-	copy(b[:], e.k[:e.kLen])
-	copy(b[e.kLen:], e.hVal[:])
+	b := make([]byte, mdLenSize+len(mdbs)+2+len(key)+sha256.Size)
+	i := 0
+
+	binary.BigEndian.PutUint16(b[i:], uint16(len(mdbs)))
+	i += mdLenSize
+	copy(b[i:], mdbs)
+	i += len(mdbs)
+	binary.BigEndian.PutUint16(b[i:], uint16(len(key)))
+	i += 2
+	copy(b[i:], key)
+	i += len(key)
+	copy(b[i:], hVal[:])
 
 	return sha256.Sum256(b)
 }
@@ -0,0 +1,228 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package store
+
+import (
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var ErrInvalidTxArchive = errors.New("invalid tx archive")
+var ErrTxArchiveMismatch = errors.New("tx archive does not follow the latest committed tx")
+
+const txArchiveVersion = 1
+
+// TxArchiveHeader describes the range of txs held by a tx archive
+type TxArchiveHeader struct {
+	// SinceTx is the latest tx the archive can be applied onto, the archive holds the txs after it
+	SinceTx  uint64
+	SinceAlh [sha256.Size]byte
+	// UpToTx is the latest tx held by the archive
+	UpToTx  uint64
+	UpToAlh [sha256.Size]byte
+}
+
+// ExportTxRange writes into w a compressed archive holding the txs committed after sinceTx up to upToTx,
+// all the committed txs after sinceTx are exported when upToTx is zero.
+// The archive can be applied onto a copy of the store holding sinceTx as its latest tx, thus backups
+// can be incrementally updated.
+//
+// Archive layout (gzip compressed): [version byte][sinceTx uint64][sinceAlh][upToTx uint64][upToAlh]
+// followed by each tx as [exportedTxLen uint32][exported tx]
+func (s *ImmuStore) ExportTxRange(sinceTx, upToTx uint64, w io.Writer) (*TxArchiveHeader, error) {
+	if w == nil {
+		return nil, ErrIllegalArguments
+	}
+
+	committedTxID, _, _ := s.commitState()
+
+	if upToTx == 0 {
+		upToTx = committedTxID
+	}
+
+	if sinceTx >= upToTx || upToTx > committedTxID {
+		return nil, ErrIllegalArguments
+	}
+
+	tx := s.NewTx()
+
+	hdr := &TxArchiveHeader{
+		SinceTx:  sinceTx,
+		SinceAlh: sha256.Sum256(nil),
+		UpToTx:   upToTx,
+	}
+
+	if sinceTx > 0 {
+		err := s.ReadTx(sinceTx, tx)
+		if err != nil {
+			return nil, err
+		}
+		hdr.SinceAlh = tx.Alh
+	}
+
+	err := s.ReadTx(upToTx, tx)
+	if err != nil {
+		return nil, err
+	}
+	hdr.UpToAlh = tx.Alh
+
+	zw := gzip.NewWriter(w)
+
+	var b [1 + 2*(txIDSize+sha256.Size)]byte
+	b[0] = txArchiveVersion
+	binary.BigEndian.PutUint64(b[1:], hdr.SinceTx)
+	copy(b[1+txIDSize:], hdr.SinceAlh[:])
+	binary.BigEndian.PutUint64(b[1+txIDSize+sha256.Size:], hdr.UpToTx)
+	copy(b[1+2*txIDSize+sha256.Size:], hdr.UpToAlh[:])
+
+	_, err = zw.Write(b[:])
+	if err != nil {
+		return nil, err
+	}
+
+	for txID := sinceTx + 1; txID <= upToTx; txID++ {
+		exportedTx, err := s.ExportTx(txID, tx)
+		if err != nil {
+			return nil, err
+		}
+
+		var lb [szSize]byte
+		binary.BigEndian.PutUint32(lb[:], uint32(len(exportedTx)))
+
+		_, err = zw.Write(lb[:])
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = zw.Write(exportedTx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = zw.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return hdr, nil
+}
+
+// ReadTxArchiveHeader returns the header of the tx archive read from r
+func ReadTxArchiveHeader(r io.Reader) (*TxArchiveHeader, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTxArchive, err)
+	}
+	defer zr.Close()
+
+	return readTxArchiveHeader(zr)
+}
+
+func readTxArchiveHeader(r io.Reader) (*TxArchiveHeader, error) {
+	var b [1 + 2*(txIDSize+sha256.Size)]byte
+
+	_, err := io.ReadFull(r, b[:])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTxArchive, err)
+	}
+
+	if b[0] != txArchiveVersion {
+		return nil, fmt.Errorf("%w: unsupported archive version %d", ErrInvalidTxArchive, b[0])
+	}
+
+	hdr := &TxArchiveHeader{
+		SinceTx: binary.BigEndian.Uint64(b[1:]),
+		UpToTx:  binary.BigEndian.Uint64(b[1+txIDSize+sha256.Size:]),
+	}
+	copy(hdr.SinceAlh[:], b[1+txIDSize:])
+	copy(hdr.UpToAlh[:], b[1+2*txIDSize+sha256.Size:])
+
+	if hdr.SinceTx >= hdr.UpToTx {
+		return nil, fmt.Errorf("%w: empty tx range", ErrInvalidTxArchive)
+	}
+
+	return hdr, nil
+}
+
+// ApplyTxRange commits the txs held by the archive read from r. The latest committed tx must be the one
+// the archive was exported since, each tx is committed only if it's identical to the exported one.
+// Txs committed before an error is found are kept, thus applying an archive can be resumed by exporting
+// the remaining txs.
+func (s *ImmuStore) ApplyTxRange(r io.Reader, waitForIndexing bool) (*TxArchiveHeader, error) {
+	if r == nil {
+		return nil, ErrIllegalArguments
+	}
+
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTxArchive, err)
+	}
+	defer zr.Close()
+
+	hdr, err := readTxArchiveHeader(zr)
+	if err != nil {
+		return nil, err
+	}
+
+	committedTxID, committedAlh := s.Alh()
+
+	if committedTxID != hdr.SinceTx || committedAlh != hdr.SinceAlh {
+		return nil, fmt.Errorf("%w: the archive holds the txs after tx %d but the latest committed tx is %d", ErrTxArchiveMismatch, hdr.SinceTx, committedTxID)
+	}
+
+	for txID := hdr.SinceTx + 1; txID <= hdr.UpToTx; txID++ {
+		var lb [szSize]byte
+
+		_, err = io.ReadFull(zr, lb[:])
+		if err != nil {
+			return nil, fmt.Errorf("%w: tx %d is missing", ErrInvalidTxArchive, txID)
+		}
+
+		exportedTxLen := int(binary.BigEndian.Uint32(lb[:]))
+		if exportedTxLen > szSize+s.maxTxSize+s.maxTxEntries*s.maxValueLen {
+			return nil, fmt.Errorf("%w: tx %d exceeds the maximum size", ErrInvalidTxArchive, txID)
+		}
+
+		exportedTx := make([]byte, exportedTxLen)
+
+		_, err = io.ReadFull(zr, exportedTx)
+		if err != nil {
+			return nil, fmt.Errorf("%w: tx %d is truncated", ErrInvalidTxArchive, txID)
+		}
+
+		_, err = s.ReplicateTx(exportedTx, waitForIndexing)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = io.ReadFull(zr, make([]byte, 1))
+	if err != io.EOF {
+		return nil, fmt.Errorf("%w: unexpected data after tx %d", ErrInvalidTxArchive, hdr.UpToTx)
+	}
+
+	_, alh := s.Alh()
+	if alh != hdr.UpToAlh {
+		return nil, fmt.Errorf("%w: applied txs do not match the archive", ErrCorruptedData)
+	}
+
+	return hdr, nil
+}
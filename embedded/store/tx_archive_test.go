@@ -0,0 +1,136 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package store
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestImmudbStoreTxArchive(t *testing.T) {
+	immuStore, err := Open("data_tx_archive", DefaultOptions().WithSynced(false))
+	require.NoError(t, err)
+	defer os.RemoveAll("data_tx_archive")
+
+	backupStore, err := Open("data_tx_archive_backup", DefaultOptions().WithSynced(false))
+	require.NoError(t, err)
+	defer os.RemoveAll("data_tx_archive_backup")
+
+	commit := func(n int) {
+		for i := 0; i < n; i++ {
+			_, err := immuStore.Commit([]*KV{{Key: []byte(fmt.Sprintf("key%d", i)), Value: []byte(fmt.Sprintf("value%d", i))}}, false)
+			require.NoError(t, err)
+		}
+	}
+
+	commit(10)
+
+	var b bytes.Buffer
+
+	_, err = immuStore.ExportTxRange(0, 0, nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = immuStore.ExportTxRange(5, 5, &b)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = immuStore.ExportTxRange(0, 11, &b)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	// full backup
+	hdr, err := immuStore.ExportTxRange(0, 5, &b)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), hdr.SinceTx)
+	require.Equal(t, uint64(5), hdr.UpToTx)
+
+	archive := b.Bytes()
+
+	readHdr, err := ReadTxArchiveHeader(bytes.NewReader(archive))
+	require.NoError(t, err)
+	require.Equal(t, hdr, readHdr)
+
+	appliedHdr, err := backupStore.ApplyTxRange(bytes.NewReader(archive), false)
+	require.NoError(t, err)
+	require.Equal(t, hdr, appliedHdr)
+
+	txID, alh := backupStore.Alh()
+	require.Equal(t, uint64(5), txID)
+	require.Equal(t, hdr.UpToAlh, alh)
+
+	// archives can only be applied onto the tx they were exported since
+	_, err = backupStore.ApplyTxRange(bytes.NewReader(archive), false)
+	require.True(t, errors.Is(err, ErrTxArchiveMismatch))
+
+	// incremental backups
+	commit(5)
+
+	var incB bytes.Buffer
+
+	hdr, err = immuStore.ExportTxRange(5, 0, &incB)
+	require.NoError(t, err)
+	require.Equal(t, uint64(15), hdr.UpToTx)
+
+	t.Run("invalid archives", func(t *testing.T) {
+		_, err := backupStore.ApplyTxRange(nil, false)
+		require.Equal(t, ErrIllegalArguments, err)
+
+		_, err = backupStore.ApplyTxRange(bytes.NewReader([]byte("invalid")), false)
+		require.True(t, errors.Is(err, ErrInvalidTxArchive))
+
+		_, err = ReadTxArchiveHeader(bytes.NewReader(nil))
+		require.True(t, errors.Is(err, ErrInvalidTxArchive))
+
+		// txs applied before a truncated archive ends are kept
+		_, err = backupStore.ApplyTxRange(bytes.NewReader(incB.Bytes()[:incB.Len()-20]), false)
+		require.Error(t, err)
+	})
+
+	// applying is resumed from the latest applied tx
+	txID, _ = backupStore.Alh()
+
+	if txID < hdr.UpToTx {
+		var resumeB bytes.Buffer
+
+		_, err = immuStore.ExportTxRange(txID, 0, &resumeB)
+		require.NoError(t, err)
+
+		_, err = backupStore.ApplyTxRange(&resumeB, false)
+		require.NoError(t, err)
+	}
+
+	txID, alh = backupStore.Alh()
+	require.Equal(t, hdr.UpToTx, txID)
+	require.Equal(t, hdr.UpToAlh, alh)
+
+	backupTx := backupStore.NewTx()
+
+	err = backupStore.ReadTx(txID, backupTx)
+	require.NoError(t, err)
+
+	v, err := backupStore.ReadValue(backupTx, []byte("key4"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value4"), v)
+
+	err = backupStore.Close()
+	require.NoError(t, err)
+
+	err = immuStore.Close()
+	require.NoError(t, err)
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package store
+
+import (
+	"errors"
+)
+
+var ErrCorruptedTxAttributes = errors.New("corrupted tx attributes")
+
+// attribute codes are part of the serialized attributes, they must never be reused
+const (
+	traceIDAttrCode byte = 0
+)
+
+// TraceIDSize is the length of a W3C trace-id
+const TraceIDSize = 16
+
+const traceIDAttrSize = 1 + TraceIDSize
+
+// MaxTxAttributesLen is the max length of serialized TxAttributes, it must be updated when new attributes are added
+const MaxTxAttributesLen = traceIDAttrSize
+
+const txAttrsLenSize = 2
+
+// TxAttributes holds optional attributes of a transaction. Attributes are included in the inner hash of the tx,
+// thus they're as immutable as its entries.
+type TxAttributes struct {
+	traced  bool
+	traceID [TraceIDSize]byte
+}
+
+func NewTxAttributes() *TxAttributes {
+	return &TxAttributes{}
+}
+
+// WithTraceID sets the W3C trace-id of the request the tx was committed by
+func (attrs *TxAttributes) WithTraceID(traceID []byte) (*TxAttributes, error) {
+	if len(traceID) != TraceIDSize {
+		return nil, ErrIllegalArguments
+	}
+
+	attrs.traced = true
+	copy(attrs.traceID[:], traceID)
+
+	return attrs, nil
+}
+
+// TraceID returns the trace-id recorded within the tx or nil if none was recorded
+func (attrs *TxAttributes) TraceID() []byte {
+	if attrs == nil || !attrs.traced {
+		return nil
+	}
+
+	traceID := make([]byte, TraceIDSize)
+	copy(traceID, attrs.traceID[:])
+
+	return traceID
+}
+
+func (attrs *TxAttributes) Equal(other *TxAttributes) bool {
+	if attrs == nil || other == nil {
+		return attrs == other
+	}
+
+	return attrs.traced == other.traced && attrs.traceID == other.traceID
+}
+
+// Bytes returns the serialized attributes, only set attributes are included
+func (attrs *TxAttributes) Bytes() []byte {
+	if attrs == nil {
+		return nil
+	}
+
+	var b []byte
+
+	if attrs.traced {
+		var ab [traceIDAttrSize]byte
+		ab[0] = traceIDAttrCode
+		copy(ab[1:], attrs.traceID[:])
+		b = append(b, ab[:]...)
+	}
+
+	return b
+}
+
+func (attrs *TxAttributes) ReadFrom(b []byte) error {
+	if len(b) > MaxTxAttributesLen {
+		return ErrCorruptedTxAttributes
+	}
+
+	attrs.traced = false
+	attrs.traceID = [TraceIDSize]byte{}
+
+	i := 0
+
+	for i < len(b) {
+		switch b[i] {
+		case traceIDAttrCode:
+			{
+				if len(b) < i+traceIDAttrSize {
+					return ErrCorruptedTxAttributes
+				}
+
+				attrs.traced = true
+				copy(attrs.traceID[:], b[i+1:i+traceIDAttrSize])
+				i += traceIDAttrSize
+			}
+		default:
+			return ErrCorruptedTxAttributes
+		}
+	}
+
+	return nil
+}
+
+func txAttributesFrom(b []byte) (*TxAttributes, error) {
+	if len(b) == 0 {
+		return nil, nil
+	}
+
+	attrs := NewTxAttributes()
+
+	err := attrs.ReadFrom(b)
+	if err != nil {
+		return nil, err
+	}
+
+	return attrs, nil
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package store
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxAttributes(t *testing.T) {
+	var nilAttrs *TxAttributes
+	require.Nil(t, nilAttrs.TraceID())
+	require.Nil(t, nilAttrs.Bytes())
+	require.True(t, nilAttrs.Equal(nil))
+
+	attrs := NewTxAttributes()
+	require.Nil(t, attrs.TraceID())
+	require.Empty(t, attrs.Bytes())
+	require.False(t, attrs.Equal(nil))
+
+	_, err := attrs.WithTraceID([]byte{1, 2, 3})
+	require.Equal(t, ErrIllegalArguments, err)
+
+	traceID := []byte("0123456789abcdef")
+
+	_, err = attrs.WithTraceID(traceID)
+	require.NoError(t, err)
+	require.Equal(t, traceID, attrs.TraceID())
+
+	bs := attrs.Bytes()
+	require.Len(t, bs, MaxTxAttributesLen)
+
+	decodedAttrs := NewTxAttributes()
+	err = decodedAttrs.ReadFrom(bs)
+	require.NoError(t, err)
+	require.True(t, attrs.Equal(decodedAttrs))
+
+	err = decodedAttrs.ReadFrom(bs[:len(bs)-1])
+	require.Equal(t, ErrCorruptedTxAttributes, err)
+
+	err = decodedAttrs.ReadFrom([]byte{0xff})
+	require.Equal(t, ErrCorruptedTxAttributes, err)
+
+	err = decodedAttrs.ReadFrom(make([]byte, MaxTxAttributesLen+1))
+	require.Equal(t, ErrCorruptedTxAttributes, err)
+}
+
+func TestImmudbStoreTxAttributes(t *testing.T) {
+	immuStore, err := Open("data_tx_attributes", DefaultOptions().WithSynced(false))
+	require.NoError(t, err)
+	defer os.RemoveAll("data_tx_attributes")
+
+	kvs := []*KV{{Key: []byte("key"), Value: []byte("value")}}
+
+	md0, err := immuStore.Commit(kvs, false)
+	require.NoError(t, err)
+	require.Equal(t, TxVersion0, md0.Version)
+	require.Nil(t, md0.Attributes)
+
+	traceID := []byte("0123456789abcdef")
+
+	attrs, err := NewTxAttributes().WithTraceID(traceID)
+	require.NoError(t, err)
+
+	md1, err := immuStore.CommitWithAttributes(kvs, attrs, nil, false)
+	require.NoError(t, err)
+	require.Equal(t, TxVersion2, md1.Version)
+	require.Equal(t, traceID, md1.Attributes.TraceID())
+
+	md2, err := immuStore.CommitWithCallback(func(txID uint64, index KeyIndex) ([]*KV, error) {
+		return kvs, nil
+	}, attrs, false)
+	require.NoError(t, err)
+	require.Equal(t, TxVersion2, md2.Version)
+
+	err = immuStore.Close()
+	require.NoError(t, err)
+
+	immuStore, err = Open("data_tx_attributes", DefaultOptions().WithSynced(false))
+	require.NoError(t, err)
+	defer immuStore.Close()
+
+	tx := immuStore.NewTx()
+
+	err = immuStore.ReadTx(md0.ID, tx)
+	require.NoError(t, err)
+	require.Nil(t, tx.Attributes)
+	require.Equal(t, md0.Alh(), tx.Alh)
+
+	for _, md := range []*TxMetadata{md1, md2} {
+		err = immuStore.ReadTx(md.ID, tx)
+		require.NoError(t, err)
+		require.Equal(t, traceID, tx.Attributes.TraceID())
+		require.Equal(t, md.Alh(), tx.Alh)
+	}
+
+	sourceTx := immuStore.NewTx()
+
+	err = immuStore.ReadTx(md0.ID, sourceTx)
+	require.NoError(t, err)
+
+	proof, err := immuStore.DualProof(sourceTx, tx)
+	require.NoError(t, err)
+	require.True(t, VerifyDualProof(proof, md0.ID, md2.ID, md0.Alh(), md2.Alh()))
+
+	// attributes are part of the inner hash
+	md2.Attributes = nil
+	require.NotEqual(t, tx.Alh, md2.Alh())
+}
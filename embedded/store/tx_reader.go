@@ -73,12 +73,13 @@ func (txr *TxReader) Read() (*Tx, error) {
 	}
 
 	if txr.InitialTxID != txr.CurrTxID {
+		// a broken chain of transactions invalidates linear proofs built on it
 		if txr.Desc && txr.CurrAlh != txr._tx.Alh {
-			return nil, ErrorCorruptedTxData
+			return nil, txr.st.notifyCorruption("reading tx chain", &TxError{TxID: txr.CurrTxID, Err: ErrorCorruptedTxData})
 		}
 
 		if !txr.Desc && txr.CurrAlh != txr._tx.PrevAlh {
-			return nil, ErrorCorruptedTxData
+			return nil, txr.st.notifyCorruption("reading tx chain", &TxError{TxID: txr.CurrTxID, Err: ErrorCorruptedTxData})
 		}
 	}
 
@@ -0,0 +1,459 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package store
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"hash"
+	"io"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/codenotary/immudb/embedded/appendable/multiapp"
+	"github.com/codenotary/immudb/embedded/appendable/singleapp"
+	"github.com/codenotary/immudb/embedded/fileutil"
+)
+
+var ErrTxAlreadyFinished = errors.New("tx already committed or cancelled")
+
+// chunk size used when values are spooled, written into or read from the value log in chunks
+const valueChunkSize = 64 * 1024
+
+// StreamingTx is a transaction whose values are provided as readers, thus large values are never fully held in memory.
+// Values are spooled into temporary files while being read and written into the value log when the tx is committed,
+// so slow readers don't prevent other transactions from being committed. Either Commit or Cancel must be called.
+type StreamingTx struct {
+	st *ImmuStore
+
+	entries []*streamedEntry
+	keys    map[string]struct{}
+
+	finished bool
+}
+
+type streamedEntry struct {
+	key  []byte
+	md   *KVMetadata
+	vLen int
+	hVal [sha256.Size]byte
+	f    *os.File // spooled value, nil if empty
+}
+
+// NewStreamingTx starts a transaction whose values are provided as readers
+func (s *ImmuStore) NewStreamingTx() (*StreamingTx, error) {
+	if s.readOnly {
+		return nil, ErrReadOnly
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.closed {
+		return nil, ErrAlreadyClosed
+	}
+
+	return &StreamingTx{
+		st:   s,
+		keys: make(map[string]struct{}),
+	}, nil
+}
+
+// Add reads the value from r, the entry becomes visible once the tx is committed.
+// The transaction is not cancelled when an error is returned.
+func (tx *StreamingTx) Add(key []byte, md *KVMetadata, r io.Reader) (vLen int, err error) {
+	if tx.finished {
+		return 0, ErrTxAlreadyFinished
+	}
+
+	if key == nil || r == nil {
+		return 0, ErrIllegalArguments
+	}
+
+	if len(tx.entries) == tx.st.maxTxEntries {
+		return 0, ErrorMaxTxEntriesLimitExceeded
+	}
+
+	if len(key) > tx.st.maxKeyLen {
+		return 0, &KeyError{Key: key, Err: ErrorMaxKeyLenExceeded}
+	}
+
+	b64k := base64.StdEncoding.EncodeToString(key)
+	if _, ok := tx.keys[b64k]; ok {
+		return 0, &KeyError{Key: key, Err: ErrDuplicatedKey}
+	}
+
+	e := &streamedEntry{
+		key: make([]byte, len(key)),
+		md:  md,
+	}
+	copy(e.key, key)
+
+	err = tx.spool(e, r)
+	if err != nil {
+		return 0, err
+	}
+
+	tx.keys[b64k] = struct{}{}
+	tx.entries = append(tx.entries, e)
+
+	return e.vLen, nil
+}
+
+func (tx *StreamingTx) spool(e *streamedEntry, r io.Reader) error {
+	hasher := sha256.New()
+	chunk := make([]byte, valueChunkSize)
+
+	for {
+		n, err := r.Read(chunk)
+
+		if n > 0 {
+			if e.vLen+n > tx.st.maxValueLen {
+				e.discard()
+				return &KeyError{Key: e.key, Err: ErrorMaxValueLenExceeded}
+			}
+
+			if e.f == nil {
+				e.f, err = ioutil.TempFile("", "immudb_value_")
+				if err != nil {
+					return err
+				}
+			}
+
+			_, wErr := e.f.Write(chunk[:n])
+			if wErr != nil {
+				e.discard()
+				return wErr
+			}
+
+			hasher.Write(chunk[:n])
+			e.vLen += n
+		}
+
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			e.discard()
+			return err
+		}
+	}
+
+	copy(e.hVal[:], hasher.Sum(nil))
+
+	return nil
+}
+
+func (e *streamedEntry) discard() {
+	if e.f == nil {
+		return
+	}
+
+	e.f.Close()
+	fileutil.Remove(e.f.Name())
+	e.f = nil
+}
+
+// Commit writes the values into the value log and commits the entries added so far.
+// Spooled values are discarded regardless of the outcome.
+func (tx *StreamingTx) Commit(ctx context.Context, waitForIndexing bool) (*TxMetadata, error) {
+	if tx.finished {
+		return nil, ErrTxAlreadyFinished
+	}
+
+	md, err := tx.commit()
+
+	tx.Cancel()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if waitForIndexing {
+		err = tx.st.WaitForIndexingUpto(ctx, md.ID)
+		if err != nil {
+			return md, err
+		}
+	}
+
+	return md, nil
+}
+
+func (tx *StreamingTx) commit() (*TxMetadata, error) {
+	if len(tx.entries) == 0 {
+		return nil, ErrorNoEntriesProvided
+	}
+
+	start := time.Now()
+
+	tx.st.truncationRWMutex.RLock()
+	defer tx.st.truncationRWMutex.RUnlock()
+
+	tx.st.mutex.Lock()
+	closed := tx.st.closed
+	tx.st.mutex.Unlock()
+
+	if closed {
+		return nil, ErrAlreadyClosed
+	}
+
+	r := tx.appendValues()
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	stx, err := tx.st.fetchAllocTx()
+	if err != nil {
+		tx.st.reclaimValues(r, true)
+		return nil, err
+	}
+	defer tx.st.releaseAllocTx(stx)
+
+	stx.nentries = len(tx.entries)
+
+	for i, e := range tx.entries {
+		txe := stx.entries[i]
+		txe.setKey(e.key)
+		txe.md = e.md
+		txe.vLen = e.vLen
+		txe.hVal = e.hVal
+		txe.unique = false
+	}
+
+	stx.Attributes = nil
+	stx.Version = versionFor(stx.Entries(), nil)
+
+	stx.BuildHashTree()
+
+	tx.st.mutex.Lock()
+	defer tx.st.mutex.Unlock()
+
+	if tx.st.closed {
+		return nil, ErrAlreadyClosed
+	}
+
+	err = tx.st.commit(stx, r, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	tx.st.commitLatency.observe(time.Since(start))
+
+	return stx.Metadata(), nil
+}
+
+// appendValues copies the spooled values into the value log in chunks.
+// Encrypted or compressed values are processed as a whole, they are thus read into memory.
+func (tx *StreamingTx) appendValues() appendableResult {
+	offsets := make([]int64, len(tx.entries))
+
+	vLogID, vLog := tx.st.fetchAnyVLog()
+	defer tx.st.releaseVLog(vLogID)
+
+	startOff := vLog.Offset()
+
+	fail := func(err error) appendableResult {
+		// values partially written are not referenced by any tx, the space is reused
+		vLog.SetOffset(startOff)
+		return appendableResult{err: err}
+	}
+
+	wholeValues := tx.st.cipher != nil || tx.st.compressedValues
+
+	chunk := make([]byte, valueChunkSize)
+
+	for i, e := range tx.entries {
+		if e.f == nil {
+			continue
+		}
+
+		_, err := e.f.Seek(0, io.SeekStart)
+		if err != nil {
+			return fail(err)
+		}
+
+		if wholeValues {
+			val, err := ioutil.ReadAll(e.f)
+			if err != nil {
+				return fail(err)
+			}
+
+			if sha256.Sum256(val) != e.hVal {
+				return fail(ErrCorruptedData)
+			}
+
+			val, err = tx.st.encryptValue(val)
+			if err != nil {
+				return fail(err)
+			}
+
+			voff, _, err := vLog.Append(val)
+			if err != nil {
+				return fail(tx.st.wrapAppendableErr(err, "writing value"))
+			}
+
+			offsets[i] = encodeOffset(voff, vLogID)
+
+			continue
+		}
+
+		// the spooled value is verified as it may have been modified in the meantime
+		hasher := sha256.New()
+
+		written := 0
+
+		for {
+			n, err := e.f.Read(chunk)
+
+			if n > 0 {
+				voff, _, aErr := vLog.Append(chunk[:n])
+				if aErr != nil {
+					return fail(tx.st.wrapAppendableErr(aErr, "writing value"))
+				}
+
+				if written == 0 {
+					offsets[i] = encodeOffset(voff, vLogID)
+				}
+
+				hasher.Write(chunk[:n])
+				written += n
+			}
+
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return fail(err)
+			}
+		}
+
+		if written != e.vLen || !bytes.Equal(hasher.Sum(nil), e.hVal[:]) {
+			return fail(ErrCorruptedData)
+		}
+	}
+
+	err := vLog.Flush()
+	if err != nil {
+		return fail(err)
+	}
+
+	if tx.st.synced {
+		err = vLog.Sync()
+		if err != nil {
+			return fail(err)
+		}
+	}
+
+	return appendableResult{
+		offsets: offsets,
+		regions: []valueRegion{{vLogID: vLogID, startOff: startOff, endOff: vLog.Offset()}},
+	}
+}
+
+// Cancel discards the entries added so far
+func (tx *StreamingTx) Cancel() {
+	for _, e := range tx.entries {
+		e.discard()
+	}
+
+	tx.entries = nil
+	tx.finished = true
+}
+
+// Len returns the length of the value
+func (v *ValueRef) Len() int {
+	return int(v.valLen)
+}
+
+// Reader returns a reader of the value. Values are read from the value log in chunks,
+// their integrity is validated once fully read thus ErrCorruptedData may be returned at the end.
+// Encrypted, compressed or inline values are read as a whole.
+func (v *ValueRef) Reader() (io.Reader, error) {
+	vLogID, off := decodeOffset(v.vOff)
+
+	if v.valLen == 0 || v.st.cipher != nil || v.st.compressedValues || vLogID == inlineVLogID {
+		val, err := v.Resolve()
+		if err != nil {
+			return nil, err
+		}
+		return bytes.NewReader(val), nil
+	}
+
+	return &valueReader{
+		st:     v.st,
+		vLogID: vLogID,
+		off:    off,
+		vOff:   off,
+		left:   int64(v.valLen),
+		hVal:   v.hVal,
+		hasher: sha256.New(),
+	}, nil
+}
+
+type valueReader struct {
+	st     *ImmuStore
+	vLogID byte
+	vOff   int64 // offset of the value
+	off    int64 // offset of the next chunk
+	left   int64
+	hVal   [sha256.Size]byte
+	hasher hash.Hash
+}
+
+func (r *valueReader) Read(b []byte) (int, error) {
+	if r.left == 0 {
+		return 0, io.EOF
+	}
+
+	if int64(len(b)) > r.left {
+		b = b[:r.left]
+	}
+
+	vLog, err := r.st.fetchVLog(r.vLogID, true)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := vLog.ReadAt(b, r.off)
+	r.st.releaseVLog(r.vLogID)
+
+	if err == multiapp.ErrAlreadyClosed || err == singleapp.ErrAlreadyClosed {
+		return 0, ErrAlreadyClosed
+	}
+	if err == multiapp.ErrDiscarded {
+		return 0, ErrValueTruncated
+	}
+	if err != nil && err != io.EOF {
+		return 0, err
+	}
+	if n < len(b) {
+		return 0, r.st.notifyCorruption("reading value", r.st.valueLogError(r.vLogID, r.vOff, ErrCorruptedData))
+	}
+
+	r.hasher.Write(b[:n])
+	r.off += int64(n)
+	r.left -= int64(n)
+
+	if r.left == 0 && !bytes.Equal(r.hasher.Sum(nil), r.hVal[:]) {
+		return 0, r.st.notifyCorruption("reading value", r.st.valueLogError(r.vLogID, r.vOff, ErrCorruptedData))
+	}
+
+	return n, nil
+}
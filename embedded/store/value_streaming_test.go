@@ -0,0 +1,163 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package store
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"testing"
+
+	"github.com/codenotary/immudb/embedded/appendable"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamingTx(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		opts *Options
+	}{
+		{"plain", DefaultOptions()},
+		{"encrypted", DefaultOptions().WithEncryptionKey([]byte("0123456789abcdef"))},
+		{"compressed", DefaultOptions().WithCompressionFormat(appendable.GZipCompression)},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			path := "data_streaming_tx_" + c.name
+			defer os.RemoveAll(path)
+
+			immuStore, err := Open(path, c.opts.WithSynced(false).WithMaxValueLen(1<<20))
+			require.NoError(t, err)
+			defer immuStore.Close()
+
+			largeValue := make([]byte, 1<<20)
+			rand.Read(largeValue)
+
+			tx, err := immuStore.NewStreamingTx()
+			require.NoError(t, err)
+
+			_, err = tx.Add(nil, nil, bytes.NewReader(largeValue))
+			require.Equal(t, ErrIllegalArguments, err)
+
+			n, err := tx.Add([]byte("key1"), nil, bytes.NewReader(largeValue))
+			require.NoError(t, err)
+			require.Equal(t, len(largeValue), n)
+
+			_, err = tx.Add([]byte("key1"), nil, bytes.NewReader(largeValue))
+			require.True(t, errors.Is(err, ErrDuplicatedKey))
+
+			_, err = tx.Add([]byte("key2"), nil, bytes.NewReader(append(largeValue, 0)))
+			require.True(t, errors.Is(err, ErrorMaxValueLenExceeded))
+
+			_, err = tx.Add([]byte("key3"), nil, bytes.NewReader(nil))
+			require.NoError(t, err)
+
+			txmd, err := tx.Commit(context.Background(), true)
+			require.NoError(t, err)
+			require.Equal(t, uint64(1), txmd.ID)
+
+			_, err = tx.Commit(context.Background(), true)
+			require.Equal(t, ErrTxAlreadyFinished, err)
+
+			valRef, txID, _, err := immuStore.GetRef([]byte("key1"))
+			require.NoError(t, err)
+			require.Equal(t, txmd.ID, txID)
+			require.Equal(t, len(largeValue), valRef.Len())
+
+			r, err := valRef.Reader()
+			require.NoError(t, err)
+
+			val, err := ioutil.ReadAll(r)
+			require.NoError(t, err)
+			require.Equal(t, largeValue, val)
+
+			val, _, _, err = immuStore.Get([]byte("key3"))
+			require.NoError(t, err)
+			require.Empty(t, val)
+
+			_, _, _, err = immuStore.Get([]byte("key2"))
+			require.Equal(t, ErrKeyNotFound, err)
+
+			// cancelled transactions don't leave their values behind
+			tx, err = immuStore.NewStreamingTx()
+			require.NoError(t, err)
+
+			_, err = tx.Add([]byte("key4"), nil, bytes.NewReader(largeValue))
+			require.NoError(t, err)
+
+			spooled := tx.entries[0].f.Name()
+
+			tx.Cancel()
+
+			_, err = tx.Add([]byte("key5"), nil, bytes.NewReader(largeValue))
+			require.Equal(t, ErrTxAlreadyFinished, err)
+
+			_, err = os.Stat(spooled)
+			require.True(t, os.IsNotExist(err))
+
+			tx, err = immuStore.NewStreamingTx()
+			require.NoError(t, err)
+
+			_, err = tx.Commit(context.Background(), false)
+			require.Equal(t, ErrorNoEntriesProvided, err)
+
+			// pending streaming transactions don't prevent other transactions from being committed
+			tx, err = immuStore.NewStreamingTx()
+			require.NoError(t, err)
+
+			_, err = tx.Add([]byte("key6"), nil, bytes.NewReader(largeValue))
+			require.NoError(t, err)
+
+			_, err = immuStore.Commit([]*KV{{Key: []byte("key7"), Value: []byte("value7")}}, false)
+			require.NoError(t, err)
+
+			tx.Cancel()
+		})
+	}
+}
+
+func TestStreamingTxCorruptedValue(t *testing.T) {
+	defer os.RemoveAll("data_streaming_tx_corrupted")
+
+	immuStore, err := Open("data_streaming_tx_corrupted", DefaultOptions().WithSynced(false).WithMaxValueLen(1<<20))
+	require.NoError(t, err)
+	defer immuStore.Close()
+
+	value := make([]byte, 3*valueChunkSize)
+	rand.Read(value)
+
+	tx, err := immuStore.NewStreamingTx()
+	require.NoError(t, err)
+
+	_, err = tx.Add([]byte("key1"), nil, bytes.NewReader(value))
+	require.NoError(t, err)
+
+	_, err = tx.Commit(context.Background(), true)
+	require.NoError(t, err)
+
+	valRef, _, _, err := immuStore.GetRef([]byte("key1"))
+	require.NoError(t, err)
+
+	valRef.hVal[0]++
+
+	r, err := valRef.Reader()
+	require.NoError(t, err)
+
+	_, err = ioutil.ReadAll(r)
+	require.True(t, errors.Is(err, ErrCorruptedData))
+}
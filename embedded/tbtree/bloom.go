@@ -0,0 +1,349 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package tbtree
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/codenotary/immudb/embedded/fileutil"
+)
+
+const bloomFilterFilename = "bloom"
+const bloomFilterVersion = 1
+
+// initial number of keys a bloom filter is sized for, it doubles every time capacity is reached
+const bloomFilterInitialCapacity = 1 << 16
+
+// bloomFilter is a scalable bloom filter holding every key ever inserted into the tree.
+// Keys are never removed from a tree, thus it can be used to answer negative lookups
+// of the tree and of any of its snapshots without traversing nodes.
+type bloomFilter struct {
+	bitsPerKey int
+	hashCount  int
+
+	// tree ts up to which keys were added
+	ts uint64
+
+	filters []*bloomBitset
+
+	mutex sync.RWMutex
+}
+
+type bloomBitset struct {
+	capacity uint64
+	count    uint64
+	bits     []byte
+}
+
+func newBloomFilter(bitsPerKey int) *bloomFilter {
+	// optimal number of hash functions for the given number of bits per key
+	hashCount := int(math.Round(float64(bitsPerKey) * math.Ln2))
+	if hashCount < 1 {
+		hashCount = 1
+	}
+
+	return &bloomFilter{
+		bitsPerKey: bitsPerKey,
+		hashCount:  hashCount,
+	}
+}
+
+func newBloomBitset(capacity uint64, bitsPerKey int) *bloomBitset {
+	return &bloomBitset{
+		capacity: capacity,
+		bits:     make([]byte, (capacity*uint64(bitsPerKey)+7)/8),
+	}
+}
+
+func bloomHashes(key []byte) (h1, h2 uint64) {
+	h := sha256.Sum256(key)
+	return binary.BigEndian.Uint64(h[:]), binary.BigEndian.Uint64(h[8:]) | 1
+}
+
+func (b *bloomBitset) mayContain(h1, h2 uint64, hashCount int) bool {
+	nbits := uint64(len(b.bits)) * 8
+
+	for i := 0; i < hashCount; i++ {
+		pos := (h1 + uint64(i)*h2) % nbits
+		if b.bits[pos/8]&(1<<(pos%8)) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (b *bloomBitset) add(h1, h2 uint64, hashCount int) {
+	nbits := uint64(len(b.bits)) * 8
+
+	for i := 0; i < hashCount; i++ {
+		pos := (h1 + uint64(i)*h2) % nbits
+		b.bits[pos/8] |= 1 << (pos % 8)
+	}
+
+	b.count++
+}
+
+// mayContain returns false only when the key was never added to the filter
+func (f *bloomFilter) mayContain(key []byte) bool {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	h1, h2 := bloomHashes(key)
+
+	return f.mayContainHashes(h1, h2)
+}
+
+func (f *bloomFilter) mayContainHashes(h1, h2 uint64) bool {
+	for _, b := range f.filters {
+		if b.mayContain(h1, h2, f.hashCount) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (f *bloomFilter) add(key []byte, ts uint64) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.ts < ts {
+		f.ts = ts
+	}
+
+	h1, h2 := bloomHashes(key)
+
+	// new versions of existing keys do not consume capacity
+	if f.mayContainHashes(h1, h2) {
+		return
+	}
+
+	if len(f.filters) == 0 || f.filters[len(f.filters)-1].count == f.filters[len(f.filters)-1].capacity {
+		capacity := uint64(bloomFilterInitialCapacity)
+		if len(f.filters) > 0 {
+			capacity = f.filters[len(f.filters)-1].capacity * 2
+		}
+
+		f.filters = append(f.filters, newBloomBitset(capacity, f.bitsPerKey))
+	}
+
+	f.filters[len(f.filters)-1].add(h1, h2, f.hashCount)
+}
+
+// writeTo stores the filter into the file, replacing it only once fully written
+func (f *bloomFilter) writeTo(path string, fileMode os.FileMode, synced bool) error {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	tmpPath := path + ".tmp"
+
+	file, err := fileutil.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, fileMode)
+	if err != nil {
+		return err
+	}
+	defer fileutil.Remove(tmpPath)
+
+	w := bufio.NewWriter(file)
+
+	var b [8]byte
+
+	write := func(bs []byte) {
+		if err == nil {
+			_, err = w.Write(bs)
+		}
+	}
+
+	writeUint32 := func(v uint32) {
+		binary.BigEndian.PutUint32(b[:], v)
+		write(b[:4])
+	}
+
+	writeUint64 := func(v uint64) {
+		binary.BigEndian.PutUint64(b[:], v)
+		write(b[:])
+	}
+
+	write([]byte{bloomFilterVersion})
+	writeUint64(f.ts)
+	writeUint32(uint32(f.bitsPerKey))
+	writeUint32(uint32(len(f.filters)))
+
+	for _, bs := range f.filters {
+		writeUint64(bs.capacity)
+		writeUint64(bs.count)
+		write(bs.bits)
+	}
+
+	if err == nil {
+		err = w.Flush()
+	}
+
+	if err == nil && synced {
+		err = file.Sync()
+	}
+
+	cErr := file.Close()
+	if err == nil {
+		err = cErr
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return fileutil.Rename(tmpPath, path)
+}
+
+// readBloomFilterFrom loads a previously stored filter, ErrReadingFileContent is returned
+// when the file content is not valid
+func readBloomFilterFrom(path string) (*bloomFilter, error) {
+	file, err := fileutil.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	r := bufio.NewReader(file)
+
+	var b [8]byte
+
+	read := func(bs []byte) {
+		if err == nil {
+			_, err = io.ReadFull(r, bs)
+		}
+	}
+
+	readUint32 := func() uint32 {
+		read(b[:4])
+		return binary.BigEndian.Uint32(b[:4])
+	}
+
+	readUint64 := func() uint64 {
+		read(b[:])
+		return binary.BigEndian.Uint64(b[:])
+	}
+
+	read(b[:1])
+	if err == nil && b[0] != bloomFilterVersion {
+		return nil, ErrReadingFileContent
+	}
+
+	ts := readUint64()
+	bitsPerKey := readUint32()
+	filterCount := readUint32()
+
+	if err != nil {
+		return nil, ErrReadingFileContent
+	}
+
+	f := newBloomFilter(int(bitsPerKey))
+	f.ts = ts
+
+	capacity := uint64(bloomFilterInitialCapacity)
+
+	for i := 0; i < int(filterCount); i++ {
+		bs := newBloomBitset(capacity, f.bitsPerKey)
+
+		if readUint64() != capacity {
+			return nil, ErrReadingFileContent
+		}
+
+		bs.count = readUint64()
+		read(bs.bits)
+
+		if err != nil || bs.count > bs.capacity {
+			return nil, ErrReadingFileContent
+		}
+
+		f.filters = append(f.filters, bs)
+
+		capacity *= 2
+	}
+
+	return f, nil
+}
+
+// loadBloomFilter loads the filter stored when the tree was closed,
+// otherwise the filter is rebuilt from the keys in the tree
+func (t *TBtree) loadBloomFilter(bitsPerKey int) error {
+	bloomPath := filepath.Join(t.path, bloomFilterFilename)
+
+	f, err := readBloomFilterFrom(bloomPath)
+	if err != nil && !os.IsNotExist(err) && err != ErrReadingFileContent {
+		return err
+	}
+
+	if !t.readOnly {
+		// the filter is stored again when the tree gets closed,
+		// thus it's rebuilt if the tree was not properly closed
+		rErr := fileutil.Remove(bloomPath)
+		if rErr != nil && !os.IsNotExist(rErr) {
+			return rErr
+		}
+	}
+
+	if err == nil && f.bitsPerKey == bitsPerKey && f.ts >= t.root.ts() {
+		t.bloom = f
+		return nil
+	}
+
+	t.log.Infof("Building bloom filter of index '%s'...", t.path)
+
+	f = newBloomFilter(bitsPerKey)
+
+	err = t.addKeysToBloomFilter(f, t.root)
+	if err != nil {
+		return err
+	}
+
+	t.bloom = f
+
+	t.log.Infof("Bloom filter of index '%s' successfully built", t.path)
+
+	return nil
+}
+
+func (t *TBtree) addKeysToBloomFilter(f *bloomFilter, n node) error {
+	switch n := n.(type) {
+	case *nodeRef:
+		rn, err := t.nodeAt(n.off)
+		if err != nil {
+			return err
+		}
+		return t.addKeysToBloomFilter(f, rn)
+	case *innerNode:
+		for _, c := range n.nodes {
+			err := t.addKeysToBloomFilter(f, c)
+			if err != nil {
+				return err
+			}
+		}
+	case *leafNode:
+		for _, v := range n.values {
+			f.add(v.key, v.ts)
+		}
+	}
+
+	return nil
+}
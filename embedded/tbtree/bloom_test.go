@@ -0,0 +1,224 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package tbtree
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBloomFilter(t *testing.T) {
+	f := newBloomFilter(10)
+	require.False(t, f.mayContain([]byte("key")))
+
+	keyCount := 2*bloomFilterInitialCapacity + 1
+
+	key := func(i int) []byte {
+		var k [8]byte
+		binary.BigEndian.PutUint64(k[:], uint64(i))
+		return k[:]
+	}
+
+	for i := 0; i < keyCount; i++ {
+		f.add(key(i), uint64(i+1))
+	}
+
+	// new versions of existing keys are not counted
+	f.add(key(0), uint64(keyCount+1))
+
+	require.Len(t, f.filters, 2)
+	require.Equal(t, uint64(keyCount+1), f.ts)
+
+	var count uint64
+	for _, bs := range f.filters {
+		count += bs.count
+	}
+	require.LessOrEqual(t, count, uint64(keyCount))
+
+	for i := 0; i < keyCount; i++ {
+		require.True(t, f.mayContain(key(i)))
+	}
+
+	falsePositives := 0
+	for i := keyCount; i < 2*keyCount; i++ {
+		if f.mayContain(key(i)) {
+			falsePositives++
+		}
+	}
+	require.Less(t, falsePositives, keyCount/20)
+
+	path := "test_bloom_filter"
+	defer os.Remove(path)
+
+	err := f.writeTo(path, DefaultFileMode, false)
+	require.NoError(t, err)
+
+	rf, err := readBloomFilterFrom(path)
+	require.NoError(t, err)
+	require.Equal(t, f.bitsPerKey, rf.bitsPerKey)
+	require.Equal(t, f.hashCount, rf.hashCount)
+	require.Equal(t, f.ts, rf.ts)
+	require.Equal(t, f.filters, rf.filters)
+
+	err = ioutil.WriteFile(path, []byte{bloomFilterVersion, 0}, DefaultFileMode)
+	require.NoError(t, err)
+
+	_, err = readBloomFilterFrom(path)
+	require.Equal(t, ErrReadingFileContent, err)
+
+	err = ioutil.WriteFile(path, []byte{bloomFilterVersion + 1}, DefaultFileMode)
+	require.NoError(t, err)
+
+	_, err = readBloomFilterFrom(path)
+	require.Equal(t, ErrReadingFileContent, err)
+}
+
+func TestTBTreeWithBloomFilter(t *testing.T) {
+	dir := "test_tree_bloom"
+	defer os.RemoveAll(dir)
+
+	opts := DefaultOptions().WithBloomFilterBitsPerKey(10).WithMaxNodeSize(MinNodeSize * 2)
+
+	tree, err := Open(dir, opts)
+	require.NoError(t, err)
+	require.NotNil(t, tree.bloom)
+	require.Equal(t, 10, tree.GetOptions().bloomFilterBitsPerKey)
+
+	keyCount := 1000
+
+	for i := 0; i < keyCount; i++ {
+		err = tree.Insert([]byte(fmt.Sprintf("key%d", i)), []byte(fmt.Sprintf("value%d", i)))
+		require.NoError(t, err)
+	}
+
+	checkTree := func(tree *TBtree) {
+		for i := 0; i < keyCount; i++ {
+			v, _, _, err := tree.Get([]byte(fmt.Sprintf("key%d", i)))
+			require.NoError(t, err)
+			require.Equal(t, []byte(fmt.Sprintf("value%d", i)), v)
+		}
+
+		_, _, _, err := tree.Get([]byte("missing-key"))
+		require.Equal(t, ErrKeyNotFound, err)
+
+		_, err = tree.History([]byte("missing-key"), 0, false, 1)
+		require.Equal(t, ErrKeyNotFound, err)
+
+		snap, err := tree.Snapshot()
+		require.NoError(t, err)
+
+		_, _, _, err = snap.Get([]byte("key0"))
+		require.NoError(t, err)
+
+		_, _, _, err = snap.Get([]byte("missing-key"))
+		require.Equal(t, ErrKeyNotFound, err)
+
+		_, err = snap.History([]byte("missing-key"), 0, false, 1)
+		require.Equal(t, ErrKeyNotFound, err)
+
+		err = snap.Close()
+		require.NoError(t, err)
+	}
+
+	checkTree(tree)
+
+	err = tree.Close()
+	require.NoError(t, err)
+
+	bloomPath := filepath.Join(dir, bloomFilterFilename)
+	require.FileExists(t, bloomPath)
+
+	t.Run("stored bloom filter should be loaded", func(t *testing.T) {
+		tree, err := Open(dir, opts)
+		require.NoError(t, err)
+		require.Equal(t, uint64(keyCount), tree.bloom.ts)
+
+		// it will be stored again once the tree gets closed
+		require.NoFileExists(t, bloomPath)
+
+		checkTree(tree)
+
+		err = tree.Close()
+		require.NoError(t, err)
+		require.FileExists(t, bloomPath)
+	})
+
+	t.Run("stored bloom filter should be loaded in read-only mode", func(t *testing.T) {
+		tree, err := Open(dir, DefaultOptions().WithBloomFilterBitsPerKey(10).WithReadOnly(true))
+		require.NoError(t, err)
+
+		checkTree(tree)
+
+		err = tree.Close()
+		require.NoError(t, err)
+		require.FileExists(t, bloomPath)
+	})
+
+	t.Run("bloom filter should be rebuilt when not stored", func(t *testing.T) {
+		err := os.Remove(bloomPath)
+		require.NoError(t, err)
+
+		tree, err := Open(dir, opts)
+		require.NoError(t, err)
+		require.Equal(t, uint64(keyCount), tree.bloom.ts)
+
+		checkTree(tree)
+
+		err = tree.Close()
+		require.NoError(t, err)
+	})
+
+	t.Run("bloom filter should be rebuilt when corrupted", func(t *testing.T) {
+		err := ioutil.WriteFile(bloomPath, []byte{bloomFilterVersion}, DefaultFileMode)
+		require.NoError(t, err)
+
+		tree, err := Open(dir, opts)
+		require.NoError(t, err)
+
+		checkTree(tree)
+
+		err = tree.Close()
+		require.NoError(t, err)
+	})
+
+	t.Run("bloom filter should be rebuilt when created with different settings", func(t *testing.T) {
+		tree, err := Open(dir, DefaultOptions().WithBloomFilterBitsPerKey(8))
+		require.NoError(t, err)
+		require.Equal(t, 8, tree.bloom.bitsPerKey)
+
+		checkTree(tree)
+
+		err = tree.Close()
+		require.NoError(t, err)
+	})
+
+	t.Run("bloom filter should not be used when disabled", func(t *testing.T) {
+		tree, err := Open(dir, DefaultOptions())
+		require.NoError(t, err)
+		require.Nil(t, tree.bloom)
+
+		checkTree(tree)
+
+		err = tree.Close()
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,253 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package tbtree
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/codenotary/immudb/embedded/appendable/multiapp"
+	"github.com/codenotary/immudb/embedded/fileutil"
+)
+
+// A compaction writes its nodes and commit log next to the ones of the index. The commit log is
+// written once all the nodes are synced, thus a complete commit log marks a completed compaction.
+const (
+	compactedNodesPrefix  = "nodes_"
+	compactedCommitPrefix = "commit_"
+)
+
+// CompactionProgress reports the state of the compaction of the index
+type CompactionProgress struct {
+	InProgress bool
+	// timestamp of the snapshot being compacted
+	IndexID      uint64
+	WrittenBytes int64
+}
+
+// CompactionProgress returns the state of the ongoing compaction, if any
+func (t *TBtree) CompactionProgress() CompactionProgress {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if !t.compacting {
+		return CompactionProgress{}
+	}
+
+	return CompactionProgress{
+		InProgress:   true,
+		IndexID:      t.compactingIndexID,
+		WrittenBytes: atomic.LoadInt64(&t.compactedBytes),
+	}
+}
+
+// compactionWriter keeps track of the bytes written by a compaction and aborts it as soon as it gets cancelled
+type compactionWriter struct {
+	appendableWriter
+	written      *int64
+	cancellation <-chan struct{}
+}
+
+func (w *compactionWriter) Write(b []byte) (int, error) {
+	select {
+	case <-w.cancellation:
+		return 0, ErrCompactionCancelled
+	default:
+	}
+
+	n, err := w.appendableWriter.Write(b)
+	atomic.AddInt64(w.written, int64(n))
+
+	return n, err
+}
+
+func compactedNodesPath(path string, indexID uint64) string {
+	return filepath.Join(path, fmt.Sprintf("%s%d", compactedNodesPrefix, indexID))
+}
+
+func compactedCommitPath(path string, indexID uint64) string {
+	return filepath.Join(path, fmt.Sprintf("%s%d", compactedCommitPrefix, indexID))
+}
+
+func (t *TBtree) writeCompactedIndex(snapshot *Snapshot, indexID uint64, appendableOpts *multiapp.Options, cancellation <-chan struct{}) error {
+	appendableOpts.WithFileExt("n")
+	nLog, err := multiapp.Open(compactedNodesPath(t.path, indexID), appendableOpts)
+	if err != nil {
+		return err
+	}
+	defer nLog.Close()
+
+	wopts := &WriteOpts{
+		OnlyMutated:    false,
+		BaseNLogOffset: 0,
+		BaseHLogOffset: 0,
+	}
+
+	nw := &compactionWriter{
+		appendableWriter: appendableWriter{nLog},
+		written:          &t.compactedBytes,
+		cancellation:     cancellation,
+	}
+
+	offset, _, _, err := snapshot.WriteTo(nw, nil, wopts)
+	if err != nil {
+		return err
+	}
+
+	// nodes must be persisted before the commit log marks the compaction as completed
+	err = nLog.Sync()
+	if err != nil {
+		return err
+	}
+
+	appendableOpts.WithFileExt("ri")
+	cLog, err := multiapp.Open(compactedCommitPath(t.path, indexID), appendableOpts)
+	if err != nil {
+		return err
+	}
+	defer cLog.Close()
+
+	var cb [cLogEntrySize]byte
+	binary.BigEndian.PutUint64(cb[:], uint64(offset))
+	_, _, err = cLog.Append(cb[:])
+	if err != nil {
+		return err
+	}
+
+	return cLog.Sync()
+}
+
+func removeCompaction(path string, indexID uint64) error {
+	err := fileutil.RemoveAll(compactedNodesPath(path, indexID))
+	if err != nil {
+		return err
+	}
+
+	return fileutil.RemoveAll(compactedCommitPath(path, indexID))
+}
+
+func compactionCompleted(path string, indexID uint64) bool {
+	appendableOpts := multiapp.DefaultOptions().
+		WithReadOnly(true).
+		WithFileExt("ri")
+
+	cLog, err := multiapp.Open(compactedCommitPath(path, indexID), appendableOpts)
+	if err != nil {
+		return false
+	}
+	defer cLog.Close()
+
+	sz, err := cLog.Size()
+
+	return err == nil && sz >= cLogEntrySize
+}
+
+// applyCompaction resumes a compaction interrupted before the compacted index replaced the current one.
+// The latest completed compaction, if any, is applied while leftovers of other compactions are removed.
+func applyCompaction(path string, opts *Options) error {
+	fis, err := ioutil.ReadDir(path)
+	if err != nil {
+		return err
+	}
+
+	indexIDs := make(map[uint64]struct{})
+
+	for _, fi := range fis {
+		if !fi.IsDir() {
+			continue
+		}
+
+		var suffix string
+
+		if strings.HasPrefix(fi.Name(), compactedNodesPrefix) {
+			suffix = strings.TrimPrefix(fi.Name(), compactedNodesPrefix)
+		} else if strings.HasPrefix(fi.Name(), compactedCommitPrefix) {
+			suffix = strings.TrimPrefix(fi.Name(), compactedCommitPrefix)
+		} else {
+			continue
+		}
+
+		indexID, err := strconv.ParseUint(suffix, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		indexIDs[indexID] = struct{}{}
+	}
+
+	var completed bool
+	var compactedIndexID uint64
+
+	for indexID := range indexIDs {
+		if (!completed || indexID > compactedIndexID) && compactionCompleted(path, indexID) {
+			completed = true
+			compactedIndexID = indexID
+		}
+	}
+
+	for indexID := range indexIDs {
+		if completed && indexID == compactedIndexID {
+			continue
+		}
+
+		opts.log.Infof("Removing incomplete compaction '%d' of index '%s'...", indexID, path)
+
+		err = removeCompaction(path, indexID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !completed {
+		return nil
+	}
+
+	opts.log.Infof("Applying compaction '%d' of index '%s'...", compactedIndexID, path)
+
+	nLogPath := filepath.Join(path, "nodes")
+	cnLogPath := compactedNodesPath(path, compactedIndexID)
+
+	_, err = os.Stat(cnLogPath)
+	if err == nil {
+		err = fileutil.RemoveAll(nLogPath)
+		if err != nil {
+			return err
+		}
+
+		err = fileutil.Rename(cnLogPath, nLogPath)
+		if err != nil {
+			return err
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	// otherwise nodes were already replaced when the compaction got interrupted
+
+	cLogPath := filepath.Join(path, "commit")
+
+	err = fileutil.RemoveAll(cLogPath)
+	if err != nil {
+		return err
+	}
+
+	return fileutil.Rename(compactedCommitPath(path, compactedIndexID), cLogPath)
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package tbtree
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func compactionLeftovers(t *testing.T, path string) []string {
+	nodes, err := filepath.Glob(filepath.Join(path, compactedNodesPrefix+"*"))
+	require.NoError(t, err)
+
+	commits, err := filepath.Glob(filepath.Join(path, compactedCommitPrefix+"*"))
+	require.NoError(t, err)
+
+	return append(nodes, commits...)
+}
+
+func TestCompactIndexCancellation(t *testing.T) {
+	defer os.RemoveAll("test_tree_compaction_cancel")
+
+	tbtree, err := Open("test_tree_compaction_cancel", DefaultOptions().WithCompactionThld(1))
+	require.NoError(t, err)
+
+	keyCount := 1_000
+	monotonicInsertions(t, tbtree, 1, keyCount, true)
+
+	cancellation := make(chan struct{})
+	close(cancellation)
+
+	_, err = tbtree.CompactIndexWithCancellation(cancellation)
+	require.Equal(t, ErrCompactionCancelled, err)
+	require.Empty(t, compactionLeftovers(t, "test_tree_compaction_cancel"))
+	require.False(t, tbtree.CompactionProgress().InProgress)
+
+	_, err = tbtree.CompactIndex()
+	require.NoError(t, err)
+
+	checkAfterMonotonicInsertions(t, tbtree, 1, keyCount, true)
+
+	err = tbtree.Close()
+	require.NoError(t, err)
+}
+
+func TestCompactionAppliedOnOpen(t *testing.T) {
+	defer os.RemoveAll("test_tree_compaction_apply")
+
+	tbtree, err := Open("test_tree_compaction_apply", DefaultOptions().WithCompactionThld(1))
+	require.NoError(t, err)
+
+	keyCount := 1_000
+	monotonicInsertions(t, tbtree, 1, keyCount, true)
+
+	indexID, err := tbtree.CompactIndex()
+	require.NoError(t, err)
+	require.Equal(t, tbtree.Ts(), indexID)
+	require.Len(t, compactionLeftovers(t, "test_tree_compaction_apply"), 2)
+
+	err = tbtree.Close()
+	require.NoError(t, err)
+
+	tbtree, err = Open("test_tree_compaction_apply", DefaultOptions())
+	require.NoError(t, err)
+	require.Empty(t, compactionLeftovers(t, "test_tree_compaction_apply"))
+	require.Equal(t, indexID, tbtree.Ts())
+
+	checkAfterMonotonicInsertions(t, tbtree, 1, keyCount, true)
+
+	err = tbtree.Close()
+	require.NoError(t, err)
+}
+
+func TestInterruptedCompactionResumedOnOpen(t *testing.T) {
+	defer os.RemoveAll("test_tree_compaction_resume")
+
+	tbtree, err := Open("test_tree_compaction_resume", DefaultOptions().WithCompactionThld(1))
+	require.NoError(t, err)
+
+	keyCount := 1_000
+	monotonicInsertions(t, tbtree, 1, keyCount, true)
+
+	indexID, err := tbtree.CompactIndex()
+	require.NoError(t, err)
+
+	err = tbtree.Close()
+	require.NoError(t, err)
+
+	// replacement of the index interrupted right after its nodes were replaced
+	err = os.RemoveAll(filepath.Join("test_tree_compaction_resume", "nodes"))
+	require.NoError(t, err)
+
+	err = os.RemoveAll(filepath.Join("test_tree_compaction_resume", "commit"))
+	require.NoError(t, err)
+
+	err = os.Rename(compactedNodesPath("test_tree_compaction_resume", indexID), filepath.Join("test_tree_compaction_resume", "nodes"))
+	require.NoError(t, err)
+
+	// leftovers of an incomplete compaction
+	err = os.Mkdir(compactedNodesPath("test_tree_compaction_resume", indexID+1), 0700)
+	require.NoError(t, err)
+
+	err = os.Mkdir(compactedCommitPath("test_tree_compaction_resume", indexID+1), 0700)
+	require.NoError(t, err)
+
+	tbtree, err = Open("test_tree_compaction_resume", DefaultOptions())
+	require.NoError(t, err)
+	require.Empty(t, compactionLeftovers(t, "test_tree_compaction_resume"))
+	require.Equal(t, indexID, tbtree.Ts())
+
+	checkAfterMonotonicInsertions(t, tbtree, 1, keyCount, true)
+
+	err = tbtree.Close()
+	require.NoError(t, err)
+}
@@ -51,6 +51,9 @@ type Options struct {
 	compactionThld        int
 	delayDuringCompaction time.Duration
 
+	// bloom filter is disabled when set to zero
+	bloomFilterBitsPerKey int
+
 	// options below are only set during initialization and stored as metadata
 	maxNodeSize int
 	fileSize    int
@@ -85,6 +88,7 @@ func validOptions(opts *Options) bool {
 		opts.cacheSize >= MinCacheSize &&
 		opts.maxKeyLen > 0 &&
 		opts.compactionThld >= 0 &&
+		opts.bloomFilterBitsPerKey >= 0 &&
 		opts.log != nil
 }
 
@@ -152,3 +156,11 @@ func (opts *Options) WithDelayDuringCompaction(delay time.Duration) *Options {
 	opts.delayDuringCompaction = delay
 	return opts
 }
+
+// WithBloomFilterBitsPerKey enables a bloom filter holding all the keys in the tree, so lookups
+// of non-existent keys can usually be answered without reading nodes. Around 10 bits per key
+// results in a 1% false positive rate
+func (opts *Options) WithBloomFilterBitsPerKey(bitsPerKey int) *Options {
+	opts.bloomFilterBitsPerKey = bitsPerKey
+	return opts
+}
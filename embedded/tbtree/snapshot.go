@@ -16,6 +16,7 @@ limitations under the License.
 package tbtree
 
 import (
+	"bytes"
 	"encoding/binary"
 	"errors"
 	"io"
@@ -52,6 +53,10 @@ func (s *Snapshot) Get(key []byte) (value []byte, ts uint64, hc uint64, err erro
 		return nil, 0, 0, ErrIllegalArguments
 	}
 
+	if !s.t.mayContain(key) {
+		return nil, 0, 0, ErrKeyNotFound
+	}
+
 	return s.root.get(key)
 }
 
@@ -71,6 +76,10 @@ func (s *Snapshot) History(key []byte, offset uint64, descOrder bool, limit int)
 		return nil, ErrIllegalArguments
 	}
 
+	if !s.t.mayContain(key) {
+		return nil, ErrKeyNotFound
+	}
+
 	return s.root.history(key, offset, descOrder, limit)
 }
 
@@ -148,6 +157,133 @@ func (s *Snapshot) NewReader(spec *ReaderSpec) (r *Reader, err error) {
 	return r, nil
 }
 
+// EstimatedKeys returns an approximation of the number of keys k in the snapshot
+// such that startKey <= k < endKey. An empty endKey means the range is unbounded.
+// Only the nodes along the range boundaries are visited, the size of the subtrees
+// fully covered by the range is extrapolated from one of them.
+func (s *Snapshot) EstimatedKeys(startKey, endKey []byte) (uint64, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.closed {
+		return 0, ErrAlreadyClosed
+	}
+
+	if len(endKey) > 0 && bytes.Compare(startKey, endKey) >= 0 {
+		return 0, nil
+	}
+
+	return estimatedKeys(s.root, startKey, endKey)
+}
+
+func resolvedNode(n node) (node, error) {
+	r, ok := n.(*nodeRef)
+	if !ok {
+		return n, nil
+	}
+
+	return r.t.nodeAt(r.off)
+}
+
+func estimatedKeys(n node, startKey, endKey []byte) (uint64, error) {
+	n, err := resolvedNode(n)
+	if err != nil {
+		return 0, err
+	}
+
+	switch n := n.(type) {
+	case *leafNode:
+		{
+			count := uint64(0)
+
+			for _, v := range n.values {
+				if bytes.Compare(v.key, startKey) < 0 {
+					continue
+				}
+
+				if len(endKey) > 0 && bytes.Compare(v.key, endKey) >= 0 {
+					break
+				}
+
+				count++
+			}
+
+			return count, nil
+		}
+	case *innerNode:
+		{
+			count := uint64(0)
+
+			covered := 0
+			firstCovered := -1
+
+			for i, c := range n.nodes {
+				if bytes.Compare(c.maxKey(), startKey) < 0 {
+					continue
+				}
+
+				if len(endKey) > 0 && bytes.Compare(c.minKey(), endKey) >= 0 {
+					break
+				}
+
+				if bytes.Compare(c.minKey(), startKey) >= 0 &&
+					(len(endKey) == 0 || bytes.Compare(c.maxKey(), endKey) < 0) {
+					if firstCovered < 0 {
+						firstCovered = i
+					}
+					covered++
+					continue
+				}
+
+				partial, err := estimatedKeys(c, startKey, endKey)
+				if err != nil {
+					return 0, err
+				}
+
+				count += partial
+			}
+
+			if covered > 0 {
+				sample, err := subtreeKeys(n.nodes[firstCovered+covered/2])
+				if err != nil {
+					return 0, err
+				}
+
+				count += uint64(covered) * sample
+			}
+
+			return count, nil
+		}
+	}
+
+	return 0, ErrIllegalState
+}
+
+func subtreeKeys(n node) (uint64, error) {
+	n, err := resolvedNode(n)
+	if err != nil {
+		return 0, err
+	}
+
+	switch n := n.(type) {
+	case *leafNode:
+		{
+			return uint64(len(n.values)), nil
+		}
+	case *innerNode:
+		{
+			sample, err := subtreeKeys(n.nodes[len(n.nodes)/2])
+			if err != nil {
+				return 0, err
+			}
+
+			return uint64(len(n.nodes)) * sample, nil
+		}
+	}
+
+	return 0, ErrIllegalState
+}
+
 func (s *Snapshot) closedReader(id int) error {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
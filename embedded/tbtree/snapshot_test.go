@@ -17,6 +17,7 @@ package tbtree
 
 import (
 	"bytes"
+	"encoding/binary"
 	"os"
 	"testing"
 
@@ -119,6 +120,62 @@ func TestSnapshotClosing(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestSnapshotEstimatedKeys(t *testing.T) {
+	tbtree, err := Open("test_tree_estimated_keys", DefaultOptions().WithMaxNodeSize(MinNodeSize*4))
+	require.NoError(t, err)
+	defer os.RemoveAll("test_tree_estimated_keys")
+
+	keyCount := 10_000
+
+	key := func(i int) []byte {
+		k := make([]byte, 8)
+		binary.BigEndian.PutUint64(k, uint64(i))
+		return k
+	}
+
+	for i := 0; i < keyCount; i++ {
+		err = tbtree.Insert(key(i), key(i))
+		require.NoError(t, err)
+
+		if i == keyCount/2 {
+			_, _, err = tbtree.Flush()
+			require.NoError(t, err)
+		}
+	}
+
+	snapshot, err := tbtree.Snapshot()
+	require.NoError(t, err)
+
+	requireEstimation := func(startKey, endKey []byte, expected int) {
+		estimated, err := snapshot.EstimatedKeys(startKey, endKey)
+		require.NoError(t, err)
+		require.InDelta(t, expected, estimated, float64(expected)/2+1)
+	}
+
+	requireEstimation(nil, nil, keyCount)
+	requireEstimation(key(0), key(keyCount), keyCount)
+	requireEstimation(key(1000), key(3000), 2000)
+	requireEstimation(key(4000), nil, keyCount-4000)
+	requireEstimation(key(10), key(20), 10)
+
+	estimated, err := snapshot.EstimatedKeys(key(keyCount), nil)
+	require.NoError(t, err)
+	require.Zero(t, estimated)
+
+	estimated, err = snapshot.EstimatedKeys(key(20), key(10))
+	require.NoError(t, err)
+	require.Zero(t, estimated)
+
+	err = snapshot.Close()
+	require.NoError(t, err)
+
+	_, err = snapshot.EstimatedKeys(nil, nil)
+	require.Equal(t, ErrAlreadyClosed, err)
+
+	err = tbtree.Close()
+	require.NoError(t, err)
+}
+
 func TestSnapshotLoadFromFullDump(t *testing.T) {
 	tbtree, err := Open("test_tree_r", DefaultOptions().WithCompactionThld(1).WithDelayDuringCompaction(1))
 	require.NoError(t, err)
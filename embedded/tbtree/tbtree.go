@@ -19,16 +19,17 @@ import (
 	"bytes"
 	"encoding/binary"
 	"errors"
-	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/codenotary/immudb/embedded/appendable"
 	"github.com/codenotary/immudb/embedded/appendable/multiapp"
 	"github.com/codenotary/immudb/embedded/cache"
+	"github.com/codenotary/immudb/embedded/fileutil"
 	"github.com/codenotary/immudb/embedded/multierr"
 	"github.com/codenotary/immudb/pkg/logger"
 )
@@ -47,6 +48,8 @@ var ErrCorruptedFile = errors.New("file is corrupted")
 var ErrCorruptedCLog = errors.New("commit log is corrupted")
 var ErrCompactAlreadyInProgress = errors.New("compact already in progress")
 var ErrCompactionThresholdNotReached = errors.New("compaction threshold not yet reached")
+var ErrCompactionCancelled = errors.New("compaction cancelled")
+var ErrReadOnly = errors.New("index opened in read-only mode")
 
 const Version = 1
 
@@ -87,6 +90,8 @@ type TBtree struct {
 	compactionThld        int
 	delayDuringCompaction time.Duration
 
+	bloom *bloomFilter
+
 	greatestKey []byte
 
 	snapshots      map[uint64]*Snapshot
@@ -97,7 +102,9 @@ type TBtree struct {
 	committedNLogSize int64
 	committedHLogSize int64
 
-	compacting bool
+	compacting        bool
+	compactingIndexID uint64
+	compactedBytes    int64 // accessed atomically
 
 	closed bool
 	mutex  sync.Mutex
@@ -171,9 +178,16 @@ func Open(path string, opts *Options) (*TBtree, error) {
 		return nil, ErrIllegalArguments
 	}
 
+	// paths exceeding the length limit of the platform, if any, are supported
+	path, err := fileutil.LongPath(path)
+	if err != nil {
+		return nil, err
+	}
+
 	finfo, err := os.Stat(path)
 	if err != nil {
-		if os.IsNotExist(err) {
+		// nothing is created in read-only mode
+		if os.IsNotExist(err) && !opts.readOnly {
 			err = os.Mkdir(path, opts.fileMode)
 			if err != nil {
 				return nil, err
@@ -185,6 +199,13 @@ func Open(path string, opts *Options) (*TBtree, error) {
 		return nil, ErrorPathIsNotADirectory
 	}
 
+	if !opts.readOnly {
+		err = applyCompaction(path, opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	metadata := appendable.NewMetadata(nil)
 	metadata.PutInt(MetaVersion, Version)
 	metadata.PutInt(MetaMaxNodeSize, opts.maxNodeSize)
@@ -238,7 +259,11 @@ func OpenWith(path string, nLog, hLog, cLog appendable.Appendable, opts *Options
 	}
 
 	if cLogSize%cLogEntrySize > 0 {
-		return nil, ErrCorruptedCLog
+		return nil, &LogError{
+			Path:   filepath.Join(path, "commit"),
+			Offset: cLogSize - cLogSize%cLogEntrySize,
+			Err:    ErrCorruptedCLog,
+		}
 	}
 
 	hLogSize, err := hLog.Size()
@@ -299,6 +324,13 @@ func OpenWith(path string, nLog, hLog, cLog appendable.Appendable, opts *Options
 
 	t.root = root
 
+	if opts.bloomFilterBitsPerKey > 0 {
+		err = t.loadBloomFilter(opts.bloomFilterBitsPerKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return t, nil
 }
 
@@ -311,6 +343,11 @@ func greatestKeyOfSize(size int) []byte {
 }
 
 func (t *TBtree) GetOptions() *Options {
+	bloomFilterBitsPerKey := 0
+	if t.bloom != nil {
+		bloomFilterBitsPerKey = t.bloom.bitsPerKey
+	}
+
 	return DefaultOptions().
 		WithReadOnly(t.readOnly).
 		WithFileMode(t.fileMode).
@@ -324,7 +361,13 @@ func (t *TBtree) GetOptions() *Options {
 		WithMaxNodeSize(t.maxNodeSize).
 		WithRenewSnapRootAfter(t.renewSnapRootAfter).
 		WithCompactionThld(t.compactionThld).
-		WithDelayDuringCompaction(t.delayDuringCompaction)
+		WithDelayDuringCompaction(t.delayDuringCompaction).
+		WithBloomFilterBitsPerKey(bloomFilterBitsPerKey)
+}
+
+// mayContain returns false when the key was certainly never inserted into the tree
+func (t *TBtree) mayContain(key []byte) bool {
+	return t.bloom == nil || t.bloom.mayContain(key)
 }
 
 func (t *TBtree) cachePut(n node) {
@@ -393,7 +436,7 @@ func (t *TBtree) readNodeFrom(r *appendable.Reader) (node, error) {
 		return n, nil
 	}
 
-	return nil, ErrReadingFileContent
+	return nil, &LogError{Path: filepath.Join(t.path, "nodes"), Offset: off, Err: ErrReadingFileContent}
 }
 
 func (t *TBtree) readInnerNodeFrom(r *appendable.Reader) (*innerNode, error) {
@@ -575,6 +618,10 @@ func (t *TBtree) Get(key []byte) (value []byte, ts uint64, hc uint64, err error)
 		return nil, 0, 0, ErrIllegalArguments
 	}
 
+	if !t.mayContain(key) {
+		return nil, 0, 0, ErrKeyNotFound
+	}
+
 	return t.root.get(key)
 }
 
@@ -594,6 +641,10 @@ func (t *TBtree) History(key []byte, offset uint64, descOrder bool, limit int) (
 		return nil, ErrIllegalArguments
 	}
 
+	if !t.mayContain(key) {
+		return nil, ErrKeyNotFound
+	}
+
 	return t.root.history(key, offset, descOrder, limit)
 }
 
@@ -673,6 +724,13 @@ func (t *TBtree) warn(formattedMessage string, err error) error {
 }
 
 func (t *TBtree) flushTree() (wN int64, wH int64, err error) {
+	if t.readOnly {
+		// nodes are kept in memory, as if they were written
+		freeze(t.root)
+		t.insertionCount = 0
+		return 0, 0, nil
+	}
+
 	t.log.Infof("Flushing index '%s'...", t.path)
 
 	if !t.root.mutated() {
@@ -734,6 +792,29 @@ func (t *TBtree) flushTree() (wN int64, wH int64, err error) {
 	return wN, wH, nil
 }
 
+// freeze makes the mutated nodes immutable without writing them, thus further insertions copy them
+// instead of updating them, as it happens with nodes written into the node log
+func freeze(n node) {
+	switch n := n.(type) {
+	case *innerNode:
+		{
+			if !n.mut {
+				return
+			}
+
+			n.mut = false
+
+			for _, c := range n.nodes {
+				freeze(c)
+			}
+		}
+	case *leafNode:
+		{
+			n.mut = false
+		}
+	}
+}
+
 func (t *TBtree) currentSnapshot() (*Snapshot, error) {
 	_, _, err := t.flushTree()
 	if err != nil {
@@ -751,7 +832,15 @@ func (t *TBtree) storedSnapshotsCount() (int, error) {
 	return int(sz / cLogEntrySize), nil
 }
 
+// CompactIndex writes the nodes of the current snapshot into new files, leaving out the ones no longer in use.
+// The compacted index replaces the current one the next time the tree is opened.
 func (t *TBtree) CompactIndex() (uint64, error) {
+	return t.CompactIndexWithCancellation(nil)
+}
+
+// CompactIndexWithCancellation compacts the index unless cancellation gets closed before it completes,
+// in such case ErrCompactionCancelled is returned and partially written files are removed
+func (t *TBtree) CompactIndexWithCancellation(cancellation <-chan struct{}) (uint64, error) {
 	t.mutex.Lock()
 
 	if t.closed {
@@ -759,6 +848,11 @@ func (t *TBtree) CompactIndex() (uint64, error) {
 		return 0, ErrAlreadyClosed
 	}
 
+	if t.readOnly {
+		t.mutex.Unlock()
+		return 0, ErrReadOnly
+	}
+
 	if t.compacting {
 		t.mutex.Unlock()
 		return 0, ErrCompactAlreadyInProgress
@@ -796,14 +890,14 @@ func (t *TBtree) CompactIndex() (uint64, error) {
 
 	err = t.hLog.Sync()
 	if err != nil {
+		t.mutex.Unlock()
 		return 0, err
 	}
 
 	indexID := snapshot.Ts()
 
-	metadata := appendable.NewMetadata(nil)
-	metadata.PutInt(MetaVersion, Version)
-	metadata.PutInt(MetaMaxNodeSize, t.maxNodeSize)
+	t.compactingIndexID = indexID
+	atomic.StoreInt64(&t.compactedBytes, 0)
 
 	appendableOpts := multiapp.DefaultOptions().
 		WithReadOnly(false).
@@ -812,46 +906,16 @@ func (t *TBtree) CompactIndex() (uint64, error) {
 		WithFileMode(t.fileMode).
 		WithMetadata(t.cLog.Metadata())
 
-	appendableOpts.WithFileExt("n")
-	nLogPath := filepath.Join(t.path, fmt.Sprintf("nodes_%d", indexID))
-	nLog, err := multiapp.Open(nLogPath, appendableOpts)
-	if err != nil {
-		t.mutex.Unlock()
-		return 0, err
-	}
-	defer func() {
-		nLog.Sync()
-		nLog.Close()
-	}()
-
 	t.mutex.Unlock()
 
-	wopts := &WriteOpts{
-		OnlyMutated:    false,
-		BaseNLogOffset: 0,
-		BaseHLogOffset: 0,
-	}
-
-	offset, _, _, err := snapshot.WriteTo(&appendableWriter{nLog}, nil, wopts)
+	err = t.writeCompactedIndex(snapshot, indexID, appendableOpts, cancellation)
 	if err != nil {
-		return 0, err
-	}
-
-	appendableOpts.WithFileExt("ri")
-	cLogPath := filepath.Join(t.path, fmt.Sprintf("commit_%d", indexID))
-	cLog, err := multiapp.Open(cLogPath, appendableOpts)
-	if err != nil {
-		return 0, err
-	}
-	defer func() {
-		cLog.Sync()
-		cLog.Close()
-	}()
+		// a compaction is only taken into account once completed
+		rErr := removeCompaction(t.path, indexID)
+		if rErr != nil {
+			t.log.Warningf("Removing incomplete compaction of index '%s' returned: %v", t.path, rErr)
+		}
 
-	var cb [cLogEntrySize]byte
-	binary.BigEndian.PutUint64(cb[:], uint64(offset))
-	_, _, err = cLog.Append(cb[:])
-	if err != nil {
 		return 0, err
 	}
 
@@ -879,6 +943,13 @@ func (t *TBtree) Close() error {
 
 	errors := make([]error, 0)
 
+	if t.bloom != nil && !t.readOnly {
+		bErr := t.bloom.writeTo(filepath.Join(t.path, bloomFilterFilename), t.fileMode, t.synced)
+		if bErr != nil {
+			errors = append(errors, bErr)
+		}
+	}
+
 	nErr := t.nLog.Close()
 	if nErr != nil {
 		errors = append(errors, nErr)
@@ -904,6 +975,11 @@ func (t *TBtree) Close() error {
 type KV struct {
 	K []byte
 	V []byte
+
+	// timestamp the entry is inserted at, entries without it are inserted at the timestamp of the preceding
+	// entry of the bulk or, if none, at the one following the current timestamp of the tree.
+	// Timestamps must be greater than the one of the tree and must not decrease along the bulk
+	T uint64
 }
 
 func (t *TBtree) Insert(key []byte, value []byte) error {
@@ -937,13 +1013,31 @@ func (t *TBtree) BulkInsert(kvs []*KV) error {
 		return ErrIllegalArguments
 	}
 
+	lastTs := ts
+
+	// entries are validated before any of them is inserted, so a bulk is never partially inserted
+	// because of its timestamps
 	for _, kv := range kvs {
 		if kv.K == nil || kv.V == nil {
 			return ErrIllegalArguments
 		}
 
 		if len(kv.K)+len(kv.V)+45 > t.maxNodeSize {
-			return ErrorMaxKVLenExceeded
+			return &KeyError{Key: kv.K, Err: ErrorMaxKVLenExceeded}
+		}
+
+		if kv.T > 0 {
+			if kv.T < lastTs {
+				return ErrIllegalArguments
+			}
+
+			lastTs = kv.T
+		}
+	}
+
+	for _, kv := range kvs {
+		if kv.T > 0 {
+			ts = kv.T
 		}
 
 		k := make([]byte, len(kv.K))
@@ -957,6 +1051,10 @@ func (t *TBtree) BulkInsert(kvs []*KV) error {
 			return err
 		}
 
+		if t.bloom != nil {
+			t.bloom.add(k, ts)
+		}
+
 		if n2 == nil {
 			t.root = n1
 		} else {
@@ -991,6 +1089,14 @@ func (t *TBtree) Ts() uint64 {
 	return t.root.ts()
 }
 
+// ActiveSnapshots returns the number of snapshots not yet closed
+func (t *TBtree) ActiveSnapshots() int {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return len(t.snapshots)
+}
+
 func (t *TBtree) Snapshot() (*Snapshot, error) {
 	return t.SnapshotSince(0)
 }
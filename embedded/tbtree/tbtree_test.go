@@ -19,6 +19,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"errors"
+	"fmt"
 	"math/rand"
 	"os"
 	"sync"
@@ -87,7 +88,11 @@ func TestEdgeCases(t *testing.T) {
 	require.Equal(t, ErrorMaxKVLenExceeded, tree.warn("%v", ErrorMaxKVLenExceeded))
 
 	err = tree.Insert(make([]byte, tree.maxNodeSize), []byte{})
-	require.Equal(t, ErrorMaxKVLenExceeded, err)
+	require.True(t, errors.Is(err, ErrorMaxKVLenExceeded))
+
+	var keyErr *KeyError
+	require.True(t, errors.As(err, &keyErr))
+	require.Len(t, keyErr.Key, tree.maxNodeSize)
 
 	_, _, _, err = tree.Get(nil)
 	require.Equal(t, ErrIllegalArguments, err)
@@ -377,6 +382,148 @@ func TestTBTreeHistory(t *testing.T) {
 	require.Equal(t, 2, len(tss))
 }
 
+func TestTBTreeBulkInsertWithTimestamps(t *testing.T) {
+	opts := DefaultOptions().WithSynced(false).WithMaxNodeSize(256).WithFlushThld(100)
+	tbtree, err := Open("test_tree_bulk_ts", opts)
+	require.NoError(t, err)
+	defer os.RemoveAll("test_tree_bulk_ts")
+
+	err = tbtree.BulkInsert([]*KV{{K: []byte("k0"), V: []byte("v0")}})
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), tbtree.Ts())
+
+	// timestamps must be greater than the one of the tree
+	err = tbtree.BulkInsert([]*KV{{K: []byte("k1"), V: []byte("v1"), T: 1}})
+	require.Equal(t, ErrIllegalArguments, err)
+
+	// timestamps must not decrease along the bulk, nothing is inserted otherwise
+	err = tbtree.BulkInsert([]*KV{
+		{K: []byte("k1"), V: []byte("v1"), T: 3},
+		{K: []byte("k2"), V: []byte("v2"), T: 2},
+	})
+	require.Equal(t, ErrIllegalArguments, err)
+	require.Equal(t, uint64(1), tbtree.Ts())
+
+	_, _, _, err = tbtree.Get([]byte("k1"))
+	require.Equal(t, ErrKeyNotFound, err)
+
+	err = tbtree.BulkInsert([]*KV{
+		{K: []byte("k0"), V: []byte("v00"), T: 2},
+		{K: []byte("k1"), V: []byte("v1"), T: 2},
+		{K: []byte("k0"), V: []byte("v000"), T: 4},
+		{K: []byte("k2"), V: []byte("v2")},
+	})
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), tbtree.Ts())
+
+	v, ts, hc, err := tbtree.Get([]byte("k0"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("v000"), v)
+	require.Equal(t, uint64(4), ts)
+	require.Equal(t, uint64(3), hc)
+
+	tss, err := tbtree.History([]byte("k0"), 0, false, 10)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1, 2, 4}, tss)
+
+	_, ts, _, err = tbtree.Get([]byte("k1"))
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), ts)
+
+	// entries without timestamp are inserted at the one of the preceding entry
+	_, ts, _, err = tbtree.Get([]byte("k2"))
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), ts)
+
+	err = tbtree.Close()
+	require.NoError(t, err)
+}
+
+func TestTBTreeReadOnly(t *testing.T) {
+	dir := "test_tree_read_only"
+	defer os.RemoveAll(dir)
+
+	_, err := Open(dir, DefaultOptions().WithReadOnly(true))
+	require.True(t, os.IsNotExist(err))
+	require.NoDirExists(t, dir)
+
+	opts := DefaultOptions().WithSynced(false).WithMaxNodeSize(256)
+
+	tbtree, err := Open(dir, opts)
+	require.NoError(t, err)
+
+	keyCount := 100
+
+	for i := 0; i < keyCount; i++ {
+		err = tbtree.Insert([]byte(fmt.Sprintf("key%d", i)), []byte(fmt.Sprintf("value%d", i)))
+		require.NoError(t, err)
+	}
+
+	err = tbtree.Close()
+	require.NoError(t, err)
+
+	tbtree, err = Open(dir, opts.WithReadOnly(true))
+	require.NoError(t, err)
+
+	snap, err := tbtree.Snapshot()
+	require.NoError(t, err)
+
+	// insertions are only kept in memory
+	for i := keyCount; i < 2*keyCount; i++ {
+		err = tbtree.Insert([]byte(fmt.Sprintf("key%d", i)), []byte(fmt.Sprintf("value%d", i)))
+		require.NoError(t, err)
+
+		if i%10 == 0 {
+			_, _, err = tbtree.Flush()
+			require.NoError(t, err)
+		}
+	}
+
+	err = tbtree.Insert([]byte("key0"), []byte("value00"))
+	require.NoError(t, err)
+
+	require.Equal(t, uint64(2*keyCount+1), tbtree.Ts())
+
+	val, _, _, err := tbtree.Get([]byte("key0"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value00"), val)
+
+	val, _, _, err = tbtree.Get([]byte(fmt.Sprintf("key%d", 2*keyCount-1)))
+	require.NoError(t, err)
+	require.Equal(t, []byte(fmt.Sprintf("value%d", 2*keyCount-1)), val)
+
+	// snapshots are not affected by further insertions
+	val, _, _, err = snap.Get([]byte("key0"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value0"), val)
+
+	_, _, _, err = snap.Get([]byte(fmt.Sprintf("key%d", keyCount)))
+	require.Equal(t, ErrKeyNotFound, err)
+
+	err = snap.Close()
+	require.NoError(t, err)
+
+	_, err = tbtree.CompactIndex()
+	require.Equal(t, ErrReadOnly, err)
+
+	err = tbtree.Close()
+	require.NoError(t, err)
+
+	tbtree, err = Open(dir, opts.WithReadOnly(true))
+	require.NoError(t, err)
+	require.Equal(t, uint64(keyCount), tbtree.Ts())
+
+	val, _, _, err = tbtree.Get([]byte("key0"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value0"), val)
+
+	_, _, _, err = tbtree.Get([]byte(fmt.Sprintf("key%d", keyCount)))
+	require.Equal(t, ErrKeyNotFound, err)
+
+	err = tbtree.Close()
+	require.NoError(t, err)
+}
+
 func TestTBTreeInsertionInAscendingOrder(t *testing.T) {
 	opts := DefaultOptions().WithSynced(false).WithMaxNodeSize(256).WithFlushThld(100)
 	tbtree, err := Open("test_tree_iasc", opts)
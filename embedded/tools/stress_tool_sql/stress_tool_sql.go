@@ -177,7 +177,7 @@ func main() {
 	}
 
 	log.Printf("Creating tables\r\n")
-	_, _, err = engine.ExecStmt("CREATE TABLE IF NOT EXISTS entries (id INTEGER, value BLOB, ts INTEGER, PRIMARY KEY id);", map[string]interface{}{}, true)
+	_, _, err = engine.ExecStmt("CREATE TABLE IF NOT EXISTS entries (id INTEGER, value BLOB, ts TIMESTAMP, PRIMARY KEY id);", map[string]interface{}{}, true)
 	if err != nil {
 		panic(err)
 	}
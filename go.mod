@@ -3,6 +3,7 @@ module github.com/codenotary/immudb
 go 1.13
 
 require (
+	filippo.io/age v1.0.0
 	github.com/fatih/color v1.9.0
 	github.com/gizak/termui/v3 v3.1.0
 	github.com/golang/protobuf v1.5.2
@@ -11,6 +12,7 @@ require (
 	github.com/grpc-ecosystem/grpc-gateway v1.16.0
 	github.com/jaswdr/faker v1.0.2
 	github.com/lib/pq v1.10.1
+	github.com/mattn/go-sqlite3 v1.14.7
 	github.com/o1egl/paseto v1.0.0
 	github.com/olekukonko/tablewriter v0.0.5
 	github.com/peterh/liner v1.2.0
@@ -27,9 +29,9 @@ require (
 	github.com/spf13/viper v1.6.3
 	github.com/stretchr/testify v1.6.1
 	github.com/takama/daemon v0.12.0
-	golang.org/x/crypto v0.0.0-20201208171446-5f87f3452ae9
-	golang.org/x/net v0.0.0-20201209123823-ac852fbbde11
-	golang.org/x/sys v0.0.0-20201207223542-d4d67f95c62d
+	golang.org/x/crypto v0.0.0-20210817164053-32db794688a5
+	golang.org/x/net v0.0.0-20210226172049-e18ecbb05110
+	golang.org/x/sys v0.0.0-20210903071746-97244b99971b
 	golang.org/x/text v0.3.4 // indirect
 	google.golang.org/genproto v0.0.0-20201207150747-9ee31aac76e7
 	google.golang.org/grpc v1.37.0
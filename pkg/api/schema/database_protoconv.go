@@ -17,6 +17,7 @@ package schema
 
 import (
 	"crypto/sha256"
+	"time"
 
 	"github.com/codenotary/immudb/embedded/htree"
 	"github.com/codenotary/immudb/embedded/store"
@@ -29,10 +30,11 @@ func TxTo(tx *store.Tx) *Tx {
 		hValue := e.HVal()
 
 		entries[i] = &TxEntry{
-			Key:    e.Key(),
-			HValue: hValue[:],
-			VOff:   e.VOff(),
-			VLen:   int32(e.VLen()),
+			Key:      e.Key(),
+			HValue:   hValue[:],
+			VOff:     e.VOff(),
+			VLen:     int32(e.VLen()),
+			Metadata: KVMetadataTo(e.Metadata()),
 		}
 	}
 
@@ -46,7 +48,7 @@ func TxFrom(stx *Tx) *store.Tx {
 	entries := make([]*store.TxEntry, len(stx.Entries))
 
 	for i, e := range stx.Entries {
-		entries[i] = store.NewTxEntry(e.Key, int(e.VLen), DigestFrom(e.HValue), e.VOff)
+		entries[i] = store.NewTxEntry(e.Key, KVMetadataFrom(e.Metadata), int(e.VLen), DigestFrom(e.HValue), e.VOff)
 	}
 
 	tx := store.NewTxWithEntries(entries)
@@ -54,8 +56,10 @@ func TxFrom(stx *Tx) *store.Tx {
 	tx.ID = stx.Metadata.Id
 	tx.PrevAlh = DigestFrom(stx.Metadata.PrevAlh)
 	tx.Ts = stx.Metadata.Ts
+	tx.Version = int(stx.Metadata.Version)
 	tx.BlTxID = stx.Metadata.BlTxId
 	tx.BlRoot = DigestFrom(stx.Metadata.BlRoot)
+	tx.Attributes = TxAttributesFrom(stx.Metadata.Attributes)
 
 	tx.BuildHashTree()
 	tx.CalcAlh()
@@ -96,10 +100,13 @@ func TxMetatadaTo(txMetadata *store.TxMetadata) *TxMetadata {
 		Id:       txMetadata.ID,
 		PrevAlh:  txMetadata.PrevAlh[:],
 		Ts:       txMetadata.Ts,
+		Version:  int32(txMetadata.Version),
 		Nentries: int32(txMetadata.NEntries),
 		EH:       txMetadata.Eh[:],
 		BlTxId:   txMetadata.BlTxID,
 		BlRoot:   txMetadata.BlRoot[:],
+
+		Attributes: TxAttributesTo(txMetadata.Attributes),
 	}
 }
 
@@ -128,11 +135,106 @@ func TxMetadataFrom(txMetadata *TxMetadata) *store.TxMetadata {
 		ID:       txMetadata.Id,
 		PrevAlh:  DigestFrom(txMetadata.PrevAlh),
 		Ts:       txMetadata.Ts,
+		Version:  int(txMetadata.Version),
 		NEntries: int(txMetadata.Nentries),
 		Eh:       DigestFrom(txMetadata.EH),
 		BlTxID:   txMetadata.BlTxId,
 		BlRoot:   DigestFrom(txMetadata.BlRoot),
+
+		Attributes: TxAttributesFrom(txMetadata.Attributes),
+	}
+}
+
+func TxAttributesTo(attrs *store.TxAttributes) *TxAttributes {
+	if attrs == nil {
+		return nil
+	}
+
+	return &TxAttributes{
+		TraceID: attrs.TraceID(),
+	}
+}
+
+func TxAttributesFrom(attrs *TxAttributes) *store.TxAttributes {
+	if attrs == nil {
+		return nil
+	}
+
+	txAttrs := store.NewTxAttributes()
+
+	if len(attrs.TraceID) > 0 {
+		// an invalid trace-id is ignored so the tx fails verification
+		txAttrs.WithTraceID(attrs.TraceID)
+	}
+
+	return txAttrs
+}
+
+func KVMetadataTo(md *store.KVMetadata) *KVMetadata {
+	if md == nil {
+		return nil
 	}
+
+	kvmd := &KVMetadata{}
+
+	if md.IsExpirable() {
+		expTime, _ := md.ExpirationTime()
+		kvmd.Expiration = &Expiration{ExpiresAt: expTime.Unix()}
+	}
+
+	kvmd.Deleted = md.Deleted()
+
+	for _, index := range md.Indexes() {
+		kvmd.Indexes = append(kvmd.Indexes, &KVIndex{
+			Name:           index.Name,
+			Tag:            index.Tag,
+			ValuePrefixLen: uint32(index.ValuePrefixLen),
+		})
+	}
+
+	return kvmd
+}
+
+func KVMetadataFrom(md *KVMetadata) *store.KVMetadata {
+	if md == nil {
+		return nil
+	}
+
+	kvmd := store.NewKVMetadata()
+
+	if md.Expiration != nil {
+		kvmd.ExpiresAt(time.Unix(md.Expiration.ExpiresAt, 0))
+	}
+
+	kvmd.AsDeleted(md.Deleted)
+
+	// invalid index entries are not included, see ValidKVMetadata
+	for _, index := range md.Indexes {
+		if index == nil {
+			continue
+		}
+
+		if index.ValuePrefixLen > 0 {
+			kvmd.IndexedByValuePrefix(index.Name, int(index.ValuePrefixLen))
+		} else {
+			kvmd.IndexedBy(index.Name, index.Tag)
+		}
+	}
+
+	return kvmd
+}
+
+// ValidKVMetadata returns false if any of the secondary index entries declared by md is not valid
+func ValidKVMetadata(md *KVMetadata) bool {
+	if md == nil {
+		return true
+	}
+
+	if len(md.Indexes) > store.MaxKVIndexes {
+		return false
+	}
+
+	return len(KVMetadataFrom(md).Indexes()) == len(md.Indexes)
 }
 
 func LinearProofFrom(lproof *LinearProof) *store.LinearProof {
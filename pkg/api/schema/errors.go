@@ -26,4 +26,7 @@ var (
 	ErrDuplicatedKeysNotSupported       = status.New(codes.InvalidArgument, "duplicated keys are not supported in single batch transaction").Err()
 	ErrDuplicatedZAddNotSupported       = status.New(codes.InvalidArgument, "duplicated index inside zAdd insertions are not supported in single batch transaction").Err()
 	ErrDuplicatedReferencesNotSupported = status.New(codes.InvalidArgument, "duplicated references insertions are not supported in single batch transaction").Err()
+	ErrMalformedProof                   = status.New(codes.InvalidArgument, "malformed proof").Err()
+	ErrUnsupportedProofVersion          = status.New(codes.InvalidArgument, "unsupported proof format version").Err()
+	ErrUnexpectedProofKind              = status.New(codes.InvalidArgument, "unexpected proof kind").Err()
 )
@@ -0,0 +1,789 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package schema
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"math"
+)
+
+// ProofFormatVersion is the version of the canonical proof serialization produced by this package.
+//
+// Binary layout (all integers are big-endian):
+//
+//	proof         := version:u8 kind:u8 payload
+//	digests       := count:u32 digest:32*count
+//	inclusion     := leaf:u32 width:u32 terms:digests
+//	linear        := sourceTxId:u64 targetTxId:u64 terms:digests
+//	txMetadata    := id:u64 prevAlh:32 ts:i64 version:u32 nentries:u32 eh:32 blTxId:u64 blRoot:32 attributes
+//	attributes    := 0x00 | 0x01 traceIDLen:u16 traceID
+//	dual          := source:txMetadata target:txMetadata inclusionProof:digests consistencyProof:digests
+//	                 targetBlTxAlh:32 lastInclusionProof:digests linearProof:linear
+//
+// The JSON form carries the same fields, in the same order, with digests and byte strings hex encoded.
+const ProofFormatVersion = 1
+
+// ProofKind identifies the proof structure held by a serialized proof
+type ProofKind byte
+
+// proof kinds are part of the serialized proofs, they must never be reused
+const (
+	InclusionProofKind ProofKind = 1
+	LinearProofKind    ProofKind = 2
+	DualProofKind      ProofKind = 3
+)
+
+func (k ProofKind) String() string {
+	switch k {
+	case InclusionProofKind:
+		return "inclusion"
+	case LinearProofKind:
+		return "linear"
+	case DualProofKind:
+		return "dual"
+	}
+	return "unknown"
+}
+
+// EncodeInclusionProof returns the canonical binary serialization of an inclusion proof
+func EncodeInclusionProof(proof *InclusionProof) ([]byte, error) {
+	if proof == nil {
+		return nil, ErrMalformedProof
+	}
+
+	w := newProofWriter(InclusionProofKind)
+	err := w.inclusionProof(proof)
+	if err != nil {
+		return nil, err
+	}
+
+	return w.b, nil
+}
+
+// DecodeInclusionProof parses an inclusion proof serialized with EncodeInclusionProof
+func DecodeInclusionProof(b []byte) (*InclusionProof, error) {
+	r, err := newProofReader(b, InclusionProofKind)
+	if err != nil {
+		return nil, err
+	}
+
+	proof := r.inclusionProof()
+
+	return proof, r.close()
+}
+
+// EncodeLinearProof returns the canonical binary serialization of a linear proof
+func EncodeLinearProof(proof *LinearProof) ([]byte, error) {
+	if proof == nil {
+		return nil, ErrMalformedProof
+	}
+
+	w := newProofWriter(LinearProofKind)
+	err := w.linearProof(proof)
+	if err != nil {
+		return nil, err
+	}
+
+	return w.b, nil
+}
+
+// DecodeLinearProof parses a linear proof serialized with EncodeLinearProof
+func DecodeLinearProof(b []byte) (*LinearProof, error) {
+	r, err := newProofReader(b, LinearProofKind)
+	if err != nil {
+		return nil, err
+	}
+
+	proof := r.linearProof()
+
+	return proof, r.close()
+}
+
+// EncodeDualProof returns the canonical binary serialization of a dual proof,
+// including the consistency proof between both transactions
+func EncodeDualProof(proof *DualProof) ([]byte, error) {
+	if proof == nil || proof.SourceTxMetadata == nil || proof.TargetTxMetadata == nil || proof.LinearProof == nil {
+		return nil, ErrMalformedProof
+	}
+
+	w := newProofWriter(DualProofKind)
+
+	err := w.txMetadata(proof.SourceTxMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	err = w.txMetadata(proof.TargetTxMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	err = w.digests(proof.InclusionProof)
+	if err != nil {
+		return nil, err
+	}
+
+	err = w.digests(proof.ConsistencyProof)
+	if err != nil {
+		return nil, err
+	}
+
+	err = w.digest(proof.TargetBlTxAlh)
+	if err != nil {
+		return nil, err
+	}
+
+	err = w.digests(proof.LastInclusionProof)
+	if err != nil {
+		return nil, err
+	}
+
+	err = w.linearProof(proof.LinearProof)
+	if err != nil {
+		return nil, err
+	}
+
+	return w.b, nil
+}
+
+// DecodeDualProof parses a dual proof serialized with EncodeDualProof
+func DecodeDualProof(b []byte) (*DualProof, error) {
+	r, err := newProofReader(b, DualProofKind)
+	if err != nil {
+		return nil, err
+	}
+
+	proof := &DualProof{
+		SourceTxMetadata:   r.txMetadata(),
+		TargetTxMetadata:   r.txMetadata(),
+		InclusionProof:     r.digests(),
+		ConsistencyProof:   r.digests(),
+		TargetBlTxAlh:      r.digest(),
+		LastInclusionProof: r.digests(),
+		LinearProof:        r.linearProof(),
+	}
+
+	return proof, r.close()
+}
+
+type proofWriter struct {
+	b []byte
+}
+
+func newProofWriter(kind ProofKind) *proofWriter {
+	return &proofWriter{b: []byte{ProofFormatVersion, byte(kind)}}
+}
+
+func (w *proofWriter) uint16(v uint16) {
+	w.b = append(w.b, 0, 0)
+	binary.BigEndian.PutUint16(w.b[len(w.b)-2:], v)
+}
+
+func (w *proofWriter) uint32(v uint32) {
+	w.b = append(w.b, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(w.b[len(w.b)-4:], v)
+}
+
+func (w *proofWriter) uint64(v uint64) {
+	w.b = append(w.b, 0, 0, 0, 0, 0, 0, 0, 0)
+	binary.BigEndian.PutUint64(w.b[len(w.b)-8:], v)
+}
+
+func (w *proofWriter) int32(v int32) error {
+	if v < 0 {
+		return ErrMalformedProof
+	}
+	w.uint32(uint32(v))
+	return nil
+}
+
+func (w *proofWriter) digest(d []byte) error {
+	if len(d) != sha256.Size {
+		return ErrMalformedProof
+	}
+	w.b = append(w.b, d...)
+	return nil
+}
+
+func (w *proofWriter) digests(ds [][]byte) error {
+	if len(ds) > math.MaxUint32 {
+		return ErrMalformedProof
+	}
+
+	w.uint32(uint32(len(ds)))
+
+	for _, d := range ds {
+		err := w.digest(d)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *proofWriter) inclusionProof(proof *InclusionProof) error {
+	err := w.int32(proof.Leaf)
+	if err != nil {
+		return err
+	}
+
+	err = w.int32(proof.Width)
+	if err != nil {
+		return err
+	}
+
+	return w.digests(proof.Terms)
+}
+
+func (w *proofWriter) linearProof(proof *LinearProof) error {
+	w.uint64(proof.SourceTxId)
+	w.uint64(proof.TargetTxId)
+	return w.digests(proof.Terms)
+}
+
+func (w *proofWriter) txMetadata(md *TxMetadata) error {
+	w.uint64(md.Id)
+
+	err := w.digest(md.PrevAlh)
+	if err != nil {
+		return err
+	}
+
+	w.uint64(uint64(md.Ts))
+
+	err = w.int32(md.Version)
+	if err != nil {
+		return err
+	}
+
+	err = w.int32(md.Nentries)
+	if err != nil {
+		return err
+	}
+
+	err = w.digest(md.EH)
+	if err != nil {
+		return err
+	}
+
+	w.uint64(md.BlTxId)
+
+	err = w.digest(md.BlRoot)
+	if err != nil {
+		return err
+	}
+
+	if md.Attributes == nil {
+		w.b = append(w.b, 0)
+		return nil
+	}
+
+	if len(md.Attributes.TraceID) > math.MaxUint16 {
+		return ErrMalformedProof
+	}
+
+	w.b = append(w.b, 1)
+	w.uint16(uint16(len(md.Attributes.TraceID)))
+	w.b = append(w.b, md.Attributes.TraceID...)
+
+	return nil
+}
+
+// proofReader consumes a serialized proof, the first error is kept and reported by close
+type proofReader struct {
+	b   []byte
+	i   int
+	err error
+}
+
+func newProofReader(b []byte, kind ProofKind) (*proofReader, error) {
+	if len(b) < 2 {
+		return nil, ErrMalformedProof
+	}
+
+	if b[0] != ProofFormatVersion {
+		return nil, ErrUnsupportedProofVersion
+	}
+
+	if ProofKind(b[1]) != kind {
+		return nil, ErrUnexpectedProofKind
+	}
+
+	return &proofReader{b: b, i: 2}, nil
+}
+
+func (r *proofReader) next(n int) []byte {
+	if r.err != nil || n < 0 || len(r.b)-r.i < n {
+		r.err = ErrMalformedProof
+		return nil
+	}
+
+	bs := r.b[r.i : r.i+n]
+	r.i += n
+
+	return bs
+}
+
+func (r *proofReader) byte() byte {
+	bs := r.next(1)
+	if bs == nil {
+		return 0
+	}
+	return bs[0]
+}
+
+func (r *proofReader) uint16() uint16 {
+	bs := r.next(2)
+	if bs == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint16(bs)
+}
+
+func (r *proofReader) uint32() uint32 {
+	bs := r.next(4)
+	if bs == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint32(bs)
+}
+
+func (r *proofReader) uint64() uint64 {
+	bs := r.next(8)
+	if bs == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(bs)
+}
+
+func (r *proofReader) int32() int32 {
+	v := r.uint32()
+	if v > math.MaxInt32 {
+		r.err = ErrMalformedProof
+		return 0
+	}
+	return int32(v)
+}
+
+func (r *proofReader) digest() []byte {
+	bs := r.next(sha256.Size)
+	if bs == nil {
+		return nil
+	}
+
+	d := make([]byte, sha256.Size)
+	copy(d, bs)
+
+	return d
+}
+
+func (r *proofReader) digests() [][]byte {
+	n := r.uint32()
+
+	// the count is checked against the remaining bytes before allocating
+	if r.err != nil || uint64(n)*sha256.Size > uint64(len(r.b)-r.i) {
+		r.err = ErrMalformedProof
+		return nil
+	}
+
+	ds := make([][]byte, n)
+	for i := range ds {
+		ds[i] = r.digest()
+	}
+
+	return ds
+}
+
+func (r *proofReader) inclusionProof() *InclusionProof {
+	return &InclusionProof{
+		Leaf:  r.int32(),
+		Width: r.int32(),
+		Terms: r.digests(),
+	}
+}
+
+func (r *proofReader) linearProof() *LinearProof {
+	return &LinearProof{
+		SourceTxId: r.uint64(),
+		TargetTxId: r.uint64(),
+		Terms:      r.digests(),
+	}
+}
+
+func (r *proofReader) txMetadata() *TxMetadata {
+	md := &TxMetadata{
+		Id:       r.uint64(),
+		PrevAlh:  r.digest(),
+		Ts:       int64(r.uint64()),
+		Version:  r.int32(),
+		Nentries: r.int32(),
+		EH:       r.digest(),
+		BlTxId:   r.uint64(),
+		BlRoot:   r.digest(),
+	}
+
+	switch r.byte() {
+	case 0:
+	case 1:
+		traceID := r.next(int(r.uint16()))
+		md.Attributes = &TxAttributes{TraceID: append([]byte{}, traceID...)}
+	default:
+		r.err = ErrMalformedProof
+	}
+
+	return md
+}
+
+func (r *proofReader) close() error {
+	if r.err != nil {
+		return r.err
+	}
+
+	if r.i != len(r.b) {
+		return ErrMalformedProof
+	}
+
+	return nil
+}
+
+type jsonProofHeader struct {
+	Version int    `json:"version"`
+	Kind    string `json:"kind"`
+}
+
+type jsonInclusionProof struct {
+	jsonProofHeader
+	Leaf  int32    `json:"leaf"`
+	Width int32    `json:"width"`
+	Terms []string `json:"terms"`
+}
+
+type jsonLinearProof struct {
+	SourceTxID uint64   `json:"sourceTxId"`
+	TargetTxID uint64   `json:"targetTxId"`
+	Terms      []string `json:"terms"`
+}
+
+type jsonVersionedLinearProof struct {
+	jsonProofHeader
+	jsonLinearProof
+}
+
+type jsonTxAttributes struct {
+	TraceID string `json:"traceId"`
+}
+
+type jsonTxMetadata struct {
+	ID         uint64            `json:"id"`
+	PrevAlh    string            `json:"prevAlh"`
+	Ts         int64             `json:"ts"`
+	Version    int32             `json:"version"`
+	NEntries   int32             `json:"nentries"`
+	EH         string            `json:"eh"`
+	BlTxID     uint64            `json:"blTxId"`
+	BlRoot     string            `json:"blRoot"`
+	Attributes *jsonTxAttributes `json:"attributes"`
+}
+
+type jsonDualProof struct {
+	jsonProofHeader
+	SourceTxMetadata   *jsonTxMetadata  `json:"sourceTxMetadata"`
+	TargetTxMetadata   *jsonTxMetadata  `json:"targetTxMetadata"`
+	InclusionProof     []string         `json:"inclusionProof"`
+	ConsistencyProof   []string         `json:"consistencyProof"`
+	TargetBlTxAlh      string           `json:"targetBlTxAlh"`
+	LastInclusionProof []string         `json:"lastInclusionProof"`
+	LinearProof        *jsonLinearProof `json:"linearProof"`
+}
+
+// EncodeInclusionProofJSON returns the canonical JSON serialization of an inclusion proof
+func EncodeInclusionProofJSON(proof *InclusionProof) ([]byte, error) {
+	// the binary encoding performs the validation of the proof
+	_, err := EncodeInclusionProof(proof)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&jsonInclusionProof{
+		jsonProofHeader: jsonHeaderFor(InclusionProofKind),
+		Leaf:            proof.Leaf,
+		Width:           proof.Width,
+		Terms:           hexDigests(proof.Terms),
+	})
+}
+
+// DecodeInclusionProofJSON parses an inclusion proof serialized with EncodeInclusionProofJSON
+func DecodeInclusionProofJSON(b []byte) (*InclusionProof, error) {
+	var jproof jsonInclusionProof
+
+	err := decodeJSONProof(b, &jproof, &jproof.jsonProofHeader, InclusionProofKind)
+	if err != nil {
+		return nil, err
+	}
+
+	if jproof.Leaf < 0 || jproof.Width < 0 {
+		return nil, ErrMalformedProof
+	}
+
+	terms, err := unhexDigests(jproof.Terms)
+	if err != nil {
+		return nil, err
+	}
+
+	return &InclusionProof{
+		Leaf:  jproof.Leaf,
+		Width: jproof.Width,
+		Terms: terms,
+	}, nil
+}
+
+// EncodeLinearProofJSON returns the canonical JSON serialization of a linear proof
+func EncodeLinearProofJSON(proof *LinearProof) ([]byte, error) {
+	_, err := EncodeLinearProof(proof)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&jsonVersionedLinearProof{
+		jsonProofHeader: jsonHeaderFor(LinearProofKind),
+		jsonLinearProof: *jsonLinearProofFor(proof),
+	})
+}
+
+// DecodeLinearProofJSON parses a linear proof serialized with EncodeLinearProofJSON
+func DecodeLinearProofJSON(b []byte) (*LinearProof, error) {
+	var jproof jsonVersionedLinearProof
+
+	err := decodeJSONProof(b, &jproof, &jproof.jsonProofHeader, LinearProofKind)
+	if err != nil {
+		return nil, err
+	}
+
+	return linearProofFromJSON(&jproof.jsonLinearProof)
+}
+
+// EncodeDualProofJSON returns the canonical JSON serialization of a dual proof
+func EncodeDualProofJSON(proof *DualProof) ([]byte, error) {
+	_, err := EncodeDualProof(proof)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&jsonDualProof{
+		jsonProofHeader:    jsonHeaderFor(DualProofKind),
+		SourceTxMetadata:   jsonTxMetadataFor(proof.SourceTxMetadata),
+		TargetTxMetadata:   jsonTxMetadataFor(proof.TargetTxMetadata),
+		InclusionProof:     hexDigests(proof.InclusionProof),
+		ConsistencyProof:   hexDigests(proof.ConsistencyProof),
+		TargetBlTxAlh:      hex.EncodeToString(proof.TargetBlTxAlh),
+		LastInclusionProof: hexDigests(proof.LastInclusionProof),
+		LinearProof:        jsonLinearProofFor(proof.LinearProof),
+	})
+}
+
+// DecodeDualProofJSON parses a dual proof serialized with EncodeDualProofJSON
+func DecodeDualProofJSON(b []byte) (*DualProof, error) {
+	var jproof jsonDualProof
+
+	err := decodeJSONProof(b, &jproof, &jproof.jsonProofHeader, DualProofKind)
+	if err != nil {
+		return nil, err
+	}
+
+	if jproof.SourceTxMetadata == nil || jproof.TargetTxMetadata == nil || jproof.LinearProof == nil {
+		return nil, ErrMalformedProof
+	}
+
+	proof := &DualProof{}
+
+	proof.SourceTxMetadata, err = txMetadataFromJSON(jproof.SourceTxMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	proof.TargetTxMetadata, err = txMetadataFromJSON(jproof.TargetTxMetadata)
+	if err != nil {
+		return nil, err
+	}
+
+	proof.InclusionProof, err = unhexDigests(jproof.InclusionProof)
+	if err != nil {
+		return nil, err
+	}
+
+	proof.ConsistencyProof, err = unhexDigests(jproof.ConsistencyProof)
+	if err != nil {
+		return nil, err
+	}
+
+	proof.TargetBlTxAlh, err = unhexDigest(jproof.TargetBlTxAlh)
+	if err != nil {
+		return nil, err
+	}
+
+	proof.LastInclusionProof, err = unhexDigests(jproof.LastInclusionProof)
+	if err != nil {
+		return nil, err
+	}
+
+	proof.LinearProof, err = linearProofFromJSON(jproof.LinearProof)
+	if err != nil {
+		return nil, err
+	}
+
+	return proof, nil
+}
+
+func jsonHeaderFor(kind ProofKind) jsonProofHeader {
+	return jsonProofHeader{Version: ProofFormatVersion, Kind: kind.String()}
+}
+
+func decodeJSONProof(b []byte, jproof interface{}, header *jsonProofHeader, kind ProofKind) error {
+	err := json.Unmarshal(b, jproof)
+	if err != nil {
+		return ErrMalformedProof
+	}
+
+	if header.Version != ProofFormatVersion {
+		return ErrUnsupportedProofVersion
+	}
+
+	if header.Kind != kind.String() {
+		return ErrUnexpectedProofKind
+	}
+
+	return nil
+}
+
+func jsonLinearProofFor(proof *LinearProof) *jsonLinearProof {
+	return &jsonLinearProof{
+		SourceTxID: proof.SourceTxId,
+		TargetTxID: proof.TargetTxId,
+		Terms:      hexDigests(proof.Terms),
+	}
+}
+
+func linearProofFromJSON(jproof *jsonLinearProof) (*LinearProof, error) {
+	terms, err := unhexDigests(jproof.Terms)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LinearProof{
+		SourceTxId: jproof.SourceTxID,
+		TargetTxId: jproof.TargetTxID,
+		Terms:      terms,
+	}, nil
+}
+
+func jsonTxMetadataFor(md *TxMetadata) *jsonTxMetadata {
+	jmd := &jsonTxMetadata{
+		ID:       md.Id,
+		PrevAlh:  hex.EncodeToString(md.PrevAlh),
+		Ts:       md.Ts,
+		Version:  md.Version,
+		NEntries: md.Nentries,
+		EH:       hex.EncodeToString(md.EH),
+		BlTxID:   md.BlTxId,
+		BlRoot:   hex.EncodeToString(md.BlRoot),
+	}
+
+	if md.Attributes != nil {
+		jmd.Attributes = &jsonTxAttributes{TraceID: hex.EncodeToString(md.Attributes.TraceID)}
+	}
+
+	return jmd
+}
+
+func txMetadataFromJSON(jmd *jsonTxMetadata) (*TxMetadata, error) {
+	if jmd.Version < 0 || jmd.NEntries < 0 {
+		return nil, ErrMalformedProof
+	}
+
+	md := &TxMetadata{
+		Id:       jmd.ID,
+		Ts:       jmd.Ts,
+		Version:  jmd.Version,
+		Nentries: jmd.NEntries,
+		BlTxId:   jmd.BlTxID,
+	}
+
+	var err error
+
+	md.PrevAlh, err = unhexDigest(jmd.PrevAlh)
+	if err != nil {
+		return nil, err
+	}
+
+	md.EH, err = unhexDigest(jmd.EH)
+	if err != nil {
+		return nil, err
+	}
+
+	md.BlRoot, err = unhexDigest(jmd.BlRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	if jmd.Attributes != nil {
+		traceID, err := hex.DecodeString(jmd.Attributes.TraceID)
+		if err != nil || len(traceID) > math.MaxUint16 {
+			return nil, ErrMalformedProof
+		}
+
+		md.Attributes = &TxAttributes{TraceID: traceID}
+	}
+
+	return md, nil
+}
+
+func hexDigests(ds [][]byte) []string {
+	hds := make([]string, len(ds))
+
+	for i, d := range ds {
+		hds[i] = hex.EncodeToString(d)
+	}
+
+	return hds
+}
+
+func unhexDigest(hd string) ([]byte, error) {
+	d, err := hex.DecodeString(hd)
+	if err != nil || len(d) != sha256.Size {
+		return nil, ErrMalformedProof
+	}
+
+	return d, nil
+}
+
+func unhexDigests(hds []string) ([][]byte, error) {
+	ds := make([][]byte, len(hds))
+
+	for i, hd := range hds {
+		d, err := unhexDigest(hd)
+		if err != nil {
+			return nil, err
+		}
+
+		ds[i] = d
+	}
+
+	return ds, nil
+}
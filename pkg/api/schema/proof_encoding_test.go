@@ -0,0 +1,202 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package schema
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProofEncoding(t *testing.T) {
+	dir, err := ioutil.TempDir("", "proof_encoding")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	st, err := store.Open(dir, store.DefaultOptions().WithSynced(false))
+	require.NoError(t, err)
+	defer st.Close()
+
+	traceID := make([]byte, store.TraceIDSize)
+	traceID[0] = 1
+
+	attrs, err := store.NewTxAttributes().WithTraceID(traceID)
+	require.NoError(t, err)
+
+	for i := 0; i < 5; i++ {
+		kvs := []*store.KV{
+			{Key: []byte(fmt.Sprintf("key%d", i)), Value: []byte(fmt.Sprintf("value%d", i))},
+			{Key: []byte(fmt.Sprintf("key%d_", i)), Value: []byte(fmt.Sprintf("value%d_", i))},
+		}
+
+		_, err = st.CommitWithAttributes(kvs, attrs, nil, false)
+		require.NoError(t, err)
+	}
+
+	sourceTx := st.NewTx()
+	err = st.ReadTx(2, sourceTx)
+	require.NoError(t, err)
+
+	targetTx := st.NewTx()
+	err = st.ReadTx(5, targetTx)
+	require.NoError(t, err)
+
+	t.Run("inclusion proof", func(t *testing.T) {
+		proof, err := targetTx.Proof([]byte("key4_"))
+		require.NoError(t, err)
+
+		iproof := InclusionProofTo(proof)
+
+		b, err := EncodeInclusionProof(iproof)
+		require.NoError(t, err)
+		require.Equal(t, byte(ProofFormatVersion), b[0])
+		require.Equal(t, byte(InclusionProofKind), b[1])
+
+		decoded, err := DecodeInclusionProof(b)
+		require.NoError(t, err)
+		require.Equal(t, iproof.Leaf, decoded.Leaf)
+		require.Equal(t, iproof.Width, decoded.Width)
+		require.Equal(t, iproof.Terms, decoded.Terms)
+
+		jb, err := EncodeInclusionProofJSON(iproof)
+		require.NoError(t, err)
+
+		jdecoded, err := DecodeInclusionProofJSON(jb)
+		require.NoError(t, err)
+		require.Equal(t, decoded, jdecoded)
+
+		jb2, err := EncodeInclusionProofJSON(jdecoded)
+		require.NoError(t, err)
+		require.Equal(t, jb, jb2)
+
+		_, err = DecodeLinearProof(b)
+		require.Equal(t, ErrUnexpectedProofKind, err)
+
+		_, err = DecodeLinearProofJSON(jb)
+		require.Equal(t, ErrUnexpectedProofKind, err)
+	})
+
+	t.Run("dual proof", func(t *testing.T) {
+		proof, err := st.DualProof(sourceTx, targetTx)
+		require.NoError(t, err)
+
+		dproof := DualProofTo(proof)
+
+		b, err := EncodeDualProof(dproof)
+		require.NoError(t, err)
+
+		decoded, err := DecodeDualProof(b)
+		require.NoError(t, err)
+		require.Equal(t, traceID, decoded.TargetTxMetadata.Attributes.TraceID)
+
+		b2, err := EncodeDualProof(decoded)
+		require.NoError(t, err)
+		require.Equal(t, b, b2)
+
+		verifies := store.VerifyDualProof(DualProofFrom(decoded), sourceTx.ID, targetTx.ID, sourceTx.Alh, targetTx.Alh)
+		require.True(t, verifies)
+
+		jb, err := EncodeDualProofJSON(dproof)
+		require.NoError(t, err)
+
+		jdecoded, err := DecodeDualProofJSON(jb)
+		require.NoError(t, err)
+
+		verifies = store.VerifyDualProof(DualProofFrom(jdecoded), sourceTx.ID, targetTx.ID, sourceTx.Alh, targetTx.Alh)
+		require.True(t, verifies)
+
+		jb2, err := EncodeDualProofJSON(jdecoded)
+		require.NoError(t, err)
+		require.Equal(t, jb, jb2)
+
+		for i := 0; i < len(b); i++ {
+			_, err = DecodeDualProof(b[:i])
+			require.Error(t, err)
+		}
+
+		_, err = DecodeDualProof(append(b, 0))
+		require.Equal(t, ErrMalformedProof, err)
+	})
+
+	t.Run("linear proof", func(t *testing.T) {
+		lproof := &LinearProof{
+			SourceTxId: 2,
+			TargetTxId: 3,
+			Terms:      [][]byte{make([]byte, sha256.Size), make([]byte, sha256.Size)},
+		}
+
+		b, err := EncodeLinearProof(lproof)
+		require.NoError(t, err)
+
+		decoded, err := DecodeLinearProof(b)
+		require.NoError(t, err)
+		require.Equal(t, lproof, decoded)
+
+		jb, err := EncodeLinearProofJSON(lproof)
+		require.NoError(t, err)
+		require.Equal(t,
+			`{"version":1,"kind":"linear","sourceTxId":2,"targetTxId":3,"terms":[`+
+				`"0000000000000000000000000000000000000000000000000000000000000000",`+
+				`"0000000000000000000000000000000000000000000000000000000000000000"]}`,
+			string(jb))
+
+		jdecoded, err := DecodeLinearProofJSON(jb)
+		require.NoError(t, err)
+		require.Equal(t, lproof, jdecoded)
+
+		b[0] = ProofFormatVersion + 1
+		_, err = DecodeLinearProof(b)
+		require.Equal(t, ErrUnsupportedProofVersion, err)
+
+		_, err = DecodeLinearProofJSON([]byte(`{"version":2,"kind":"linear"}`))
+		require.Equal(t, ErrUnsupportedProofVersion, err)
+	})
+
+	t.Run("malformed proofs", func(t *testing.T) {
+		_, err := EncodeInclusionProof(nil)
+		require.Equal(t, ErrMalformedProof, err)
+
+		_, err = EncodeInclusionProof(&InclusionProof{Leaf: -1})
+		require.Equal(t, ErrMalformedProof, err)
+
+		_, err = EncodeLinearProof(&LinearProof{Terms: [][]byte{{1, 2, 3}}})
+		require.Equal(t, ErrMalformedProof, err)
+
+		_, err = EncodeDualProof(&DualProof{})
+		require.Equal(t, ErrMalformedProof, err)
+
+		_, err = DecodeInclusionProof(nil)
+		require.Equal(t, ErrMalformedProof, err)
+
+		// a terms count exceeding the remaining bytes must not be allocated
+		_, err = DecodeInclusionProof([]byte{ProofFormatVersion, byte(InclusionProofKind), 0, 0, 0, 0, 0, 0, 0, 0, 0xff, 0xff, 0xff, 0xff})
+		require.Equal(t, ErrMalformedProof, err)
+
+		_, err = DecodeInclusionProofJSON([]byte(`{`))
+		require.Equal(t, ErrMalformedProof, err)
+
+		_, err = DecodeInclusionProofJSON([]byte(`{"version":1,"kind":"inclusion","terms":["00"]}`))
+		require.Equal(t, ErrMalformedProof, err)
+
+		_, err = DecodeDualProofJSON([]byte(`{"version":1,"kind":"dual"}`))
+		require.Equal(t, ErrMalformedProof, err)
+	})
+}
@@ -20,6 +20,8 @@ import (
 	"encoding/hex"
 	"fmt"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/codenotary/immudb/embedded/sql"
 )
@@ -76,6 +78,42 @@ func (v *SQLValue_B) Equal(sqlv SqlValue) (bool, error) {
 	return v.B == b.B, nil
 }
 
+func (v *SQLValue_F) Equal(sqlv SqlValue) (bool, error) {
+	_, isNull := sqlv.(*SQLValue_Null)
+	if isNull {
+		return false, nil
+	}
+
+	f, isFloat := sqlv.(*SQLValue_F)
+	if !isFloat {
+		return false, sql.ErrNotComparableValues
+	}
+	return v.F == f.F, nil
+}
+
+func (v *SQLValue_Ts) Equal(sqlv SqlValue) (bool, error) {
+	_, isNull := sqlv.(*SQLValue_Null)
+	if isNull {
+		return false, nil
+	}
+
+	ts, isTimestamp := sqlv.(*SQLValue_Ts)
+	if !isTimestamp {
+		return false, sql.ErrNotComparableValues
+	}
+	return v.Ts == ts.Ts, nil
+}
+
+// TimeToInt64 returns the number of microseconds since the unix epoch, as timestamps are sent
+func TimeToInt64(t time.Time) int64 {
+	return t.Unix()*1e6 + int64(t.Nanosecond()/1e3)
+}
+
+// TimeFromInt64 returns the UTC time from the number of microseconds since the unix epoch
+func TimeFromInt64(t int64) time.Time {
+	return time.Unix(t/1e6, (t%1e6)*1e3).UTC()
+}
+
 func (v *SQLValue_Bs) Equal(sqlv SqlValue) (bool, error) {
 	_, isNull := sqlv.(*SQLValue_Null)
 	if isNull {
@@ -89,6 +127,41 @@ func (v *SQLValue_Bs) Equal(sqlv SqlValue) (bool, error) {
 	return bytes.Equal(v.Bs, b.Bs), nil
 }
 
+func (v *SQLValue_List) Equal(sqlv SqlValue) (bool, error) {
+	_, isNull := sqlv.(*SQLValue_Null)
+	if isNull {
+		return false, nil
+	}
+
+	l, isList := sqlv.(*SQLValue_List)
+	if !isList {
+		return false, sql.ErrNotComparableValues
+	}
+
+	if len(v.List.GetValues()) != len(l.List.GetValues()) {
+		return false, nil
+	}
+
+	for i, e := range v.List.GetValues() {
+		ev, isValue := e.Value.(SqlValue)
+		if !isValue {
+			return false, sql.ErrNotComparableValues
+		}
+
+		lv, isValue := l.List.Values[i].Value.(SqlValue)
+		if !isValue {
+			return false, sql.ErrNotComparableValues
+		}
+
+		eq, err := ev.Equal(lv)
+		if err != nil || !eq {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
 func RenderValue(op isSQLValue_Value) string {
 	switch v := op.(type) {
 	case *SQLValue_Null:
@@ -111,6 +184,23 @@ func RenderValue(op isSQLValue_Value) string {
 		{
 			return hex.EncodeToString(v.Bs)
 		}
+	case *SQLValue_F:
+		{
+			return strconv.FormatFloat(v.F, 'g', -1, 64)
+		}
+	case *SQLValue_Ts:
+		{
+			return TimeFromInt64(v.Ts).Format("2006-01-02 15:04:05.999999Z07:00")
+		}
+	case *SQLValue_List:
+		{
+			vals := make([]string, len(v.List.GetValues()))
+			for i, e := range v.List.GetValues() {
+				vals[i] = RenderValue(e.Value)
+			}
+
+			return "(" + strings.Join(vals, ", ") + ")"
+		}
 	}
 
 	return fmt.Sprintf("%v", op)
@@ -138,6 +228,19 @@ func RenderValueAsByte(op isSQLValue_Value) []byte {
 		{
 			return []byte(hex.EncodeToString(v.Bs))
 		}
+	case *SQLValue_F:
+		{
+			return []byte(strconv.FormatFloat(v.F, 'g', -1, 64))
+		}
+	case *SQLValue_Ts:
+		{
+			// as pgsql timestamps with time zone are rendered
+			return []byte(TimeFromInt64(v.Ts).Format("2006-01-02 15:04:05.999999-07"))
+		}
+	case *SQLValue_List:
+		{
+			return []byte(RenderValue(v))
+		}
 	}
 
 	return []byte(fmt.Sprintf("%v", op))
@@ -169,6 +272,23 @@ func RawValue(v *SQLValue) interface{} {
 		{
 			return tv.Bs
 		}
+	case *SQLValue_F:
+		{
+			return tv.F
+		}
+	case *SQLValue_Ts:
+		{
+			return TimeFromInt64(tv.Ts)
+		}
+	case *SQLValue_List:
+		{
+			vals := make([]interface{}, len(tv.List.GetValues()))
+			for i, e := range tv.List.GetValues() {
+				vals[i] = RawValue(e)
+			}
+
+			return vals
+		}
 	}
 
 	return nil
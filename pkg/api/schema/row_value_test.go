@@ -18,6 +18,7 @@ package schema
 import (
 	"encoding/hex"
 	"testing"
+	"time"
 
 	"github.com/codenotary/immudb/embedded/sql"
 	"github.com/stretchr/testify/require"
@@ -33,6 +34,12 @@ func TestRowComparison(t *testing.T) {
 	intValue2 := &SQLValue_N{N: 2}
 	blobValue1 := &SQLValue_Bs{Bs: nil}
 	blobValue2 := &SQLValue_Bs{Bs: []byte{1, 2, 3}}
+	floatValue1 := &SQLValue_F{F: 1.5}
+	floatValue2 := &SQLValue_F{F: -0.25}
+	tsValue1 := &SQLValue_Ts{Ts: TimeToInt64(time.Date(2021, 10, 1, 12, 30, 0, 250000000, time.UTC))}
+	tsValue2 := &SQLValue_Ts{Ts: TimeToInt64(time.Date(1960, 1, 1, 0, 0, 0, 0, time.UTC))}
+	listValue1 := &SQLValue_List{List: &SQLValueList{Values: []*SQLValue{{Value: intValue1}, {Value: nullValue}}}}
+	listValue2 := &SQLValue_List{List: &SQLValueList{Values: []*SQLValue{{Value: intValue1}, {Value: intValue2}}}}
 
 	equals, err := nullValue.Equal(nullValue)
 	require.NoError(t, err)
@@ -81,6 +88,40 @@ func TestRowComparison(t *testing.T) {
 	require.NoError(t, err)
 	require.False(t, equals)
 
+	equals, err = floatValue1.Equal(nullValue)
+	require.False(t, equals)
+
+	_, err = floatValue1.Equal(intValue1)
+	require.Equal(t, sql.ErrNotComparableValues, err)
+
+	equals, err = floatValue1.Equal(floatValue2)
+	require.NoError(t, err)
+	require.False(t, equals)
+
+	equals, err = tsValue1.Equal(nullValue)
+	require.False(t, equals)
+
+	_, err = tsValue1.Equal(intValue1)
+	require.Equal(t, sql.ErrNotComparableValues, err)
+
+	equals, err = tsValue1.Equal(tsValue2)
+	require.NoError(t, err)
+	require.False(t, equals)
+
+	equals, err = listValue1.Equal(nullValue)
+	require.False(t, equals)
+
+	_, err = listValue1.Equal(intValue1)
+	require.Equal(t, sql.ErrNotComparableValues, err)
+
+	equals, err = listValue1.Equal(listValue2)
+	require.NoError(t, err)
+	require.False(t, equals)
+
+	equals, err = listValue2.Equal(listValue2)
+	require.NoError(t, err)
+	require.True(t, equals)
+
 	rawNilValue := RawValue(nil)
 	require.Equal(t, nil, rawNilValue)
 
@@ -102,6 +143,15 @@ func TestRowComparison(t *testing.T) {
 	rawBlobValue := RawValue(&SQLValue{Value: blobValue2})
 	require.Equal(t, []byte{1, 2, 3}, rawBlobValue)
 
+	rawFloatValue := RawValue(&SQLValue{Value: floatValue2})
+	require.Equal(t, -0.25, rawFloatValue)
+
+	rawTsValue := RawValue(&SQLValue{Value: tsValue2})
+	require.Equal(t, time.Date(1960, 1, 1, 0, 0, 0, 0, time.UTC), rawTsValue)
+
+	rawListValue := RawValue(&SQLValue{Value: listValue1})
+	require.Equal(t, []interface{}{uint64(1), nil}, rawListValue)
+
 	nv := SQLValue{Value: nullValue}
 	bytesNullValue := RenderValueAsByte(nv.GetValue())
 	require.Equal(t, []byte(nil), bytesNullValue)
@@ -126,6 +176,14 @@ func TestRowComparison(t *testing.T) {
 	bytesBlobValue := RenderValueAsByte(bv.GetValue())
 	require.Equal(t, []byte(hex.EncodeToString([]byte{1, 2, 3})), bytesBlobValue)
 
+	fv := &SQLValue{Value: floatValue1}
+	bytesFloatValue := RenderValueAsByte(fv.GetValue())
+	require.Equal(t, []byte(`1.5`), bytesFloatValue)
+
+	tsv := &SQLValue{Value: tsValue1}
+	bytesTsValue := RenderValueAsByte(tsv.GetValue())
+	require.Equal(t, []byte(`2021-10-01 12:30:00.25+00`), bytesTsValue)
+
 	nv = SQLValue{Value: nullValue}
 	rNullValue := RenderValue(nv.GetValue())
 	require.Equal(t, "NULL", rNullValue)
@@ -149,4 +207,16 @@ func TestRowComparison(t *testing.T) {
 	bv = &SQLValue{Value: blobValue2}
 	rBlobValue := RenderValue(bv.GetValue())
 	require.Equal(t, "010203", rBlobValue)
+
+	fv = &SQLValue{Value: floatValue2}
+	rFloatValue := RenderValue(fv.GetValue())
+	require.Equal(t, "-0.25", rFloatValue)
+
+	tsv = &SQLValue{Value: tsValue2}
+	rTsValue := RenderValue(tsv.GetValue())
+	require.Equal(t, "1960-01-01 00:00:00Z", rTsValue)
+
+	lv := &SQLValue{Value: listValue1}
+	require.Equal(t, "(1, NULL)", RenderValue(lv.GetValue()))
+	require.Equal(t, []byte("(1, NULL)"), RenderValueAsByte(lv.GetValue()))
 }
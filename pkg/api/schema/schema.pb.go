@@ -48,6 +48,121 @@ const (
 // of the legacy proto package is being used.
 const _ = proto.ProtoPackageIsVersion4
 
+type ZAddScoreMode int32
+
+const (
+	// the score specified in the request is used
+	ZAddScoreMode_CLIENT_SCORE ZAddScoreMode = 0
+	// the id of the transaction including the entry is used as score
+	ZAddScoreMode_TX_ID_SCORE ZAddScoreMode = 1
+	// the commit time in microseconds since unix epoch is used as score, strictly increasing within a database
+	ZAddScoreMode_TIMESTAMP_SCORE ZAddScoreMode = 2
+)
+
+// Enum value maps for ZAddScoreMode.
+var (
+	ZAddScoreMode_name = map[int32]string{
+		0: "CLIENT_SCORE",
+		1: "TX_ID_SCORE",
+		2: "TIMESTAMP_SCORE",
+	}
+	ZAddScoreMode_value = map[string]int32{
+		"CLIENT_SCORE":    0,
+		"TX_ID_SCORE":     1,
+		"TIMESTAMP_SCORE": 2,
+	}
+)
+
+func (x ZAddScoreMode) Enum() *ZAddScoreMode {
+	p := new(ZAddScoreMode)
+	*p = x
+	return p
+}
+
+func (x ZAddScoreMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ZAddScoreMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_schema_proto_enumTypes[0].Descriptor()
+}
+
+func (ZAddScoreMode) Type() protoreflect.EnumType {
+	return &file_schema_proto_enumTypes[0]
+}
+
+func (x ZAddScoreMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ZAddScoreMode.Descriptor instead.
+func (ZAddScoreMode) EnumDescriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{0}
+}
+
+type TSAggregation int32
+
+const (
+	TSAggregation_AVG   TSAggregation = 0
+	TSAggregation_MIN   TSAggregation = 1
+	TSAggregation_MAX   TSAggregation = 2
+	TSAggregation_SUM   TSAggregation = 3
+	TSAggregation_COUNT TSAggregation = 4
+	// value of the sample with the lowest timestamp within the window
+	TSAggregation_FIRST TSAggregation = 5
+	// value of the sample with the highest timestamp within the window
+	TSAggregation_LAST TSAggregation = 6
+)
+
+// Enum value maps for TSAggregation.
+var (
+	TSAggregation_name = map[int32]string{
+		0: "AVG",
+		1: "MIN",
+		2: "MAX",
+		3: "SUM",
+		4: "COUNT",
+		5: "FIRST",
+		6: "LAST",
+	}
+	TSAggregation_value = map[string]int32{
+		"AVG":   0,
+		"MIN":   1,
+		"MAX":   2,
+		"SUM":   3,
+		"COUNT": 4,
+		"FIRST": 5,
+		"LAST":  6,
+	}
+)
+
+func (x TSAggregation) Enum() *TSAggregation {
+	p := new(TSAggregation)
+	*p = x
+	return p
+}
+
+func (x TSAggregation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TSAggregation) Descriptor() protoreflect.EnumDescriptor {
+	return file_schema_proto_enumTypes[1].Descriptor()
+}
+
+func (TSAggregation) Type() protoreflect.EnumType {
+	return &file_schema_proto_enumTypes[1]
+}
+
+func (x TSAggregation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TSAggregation.Descriptor instead.
+func (TSAggregation) EnumDescriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{1}
+}
+
 type PermissionAction int32
 
 const (
@@ -78,11 +193,11 @@ func (x PermissionAction) String() string {
 }
 
 func (PermissionAction) Descriptor() protoreflect.EnumDescriptor {
-	return file_schema_proto_enumTypes[0].Descriptor()
+	return file_schema_proto_enumTypes[2].Descriptor()
 }
 
 func (PermissionAction) Type() protoreflect.EnumType {
-	return &file_schema_proto_enumTypes[0]
+	return &file_schema_proto_enumTypes[2]
 }
 
 func (x PermissionAction) Number() protoreflect.EnumNumber {
@@ -91,7 +206,111 @@ func (x PermissionAction) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use PermissionAction.Descriptor instead.
 func (PermissionAction) EnumDescriptor() ([]byte, []int) {
-	return file_schema_proto_rawDescGZIP(), []int{0}
+	return file_schema_proto_rawDescGZIP(), []int{2}
+}
+
+type DocumentFilterOperator int32
+
+const (
+	DocumentFilterOperator_EQ DocumentFilterOperator = 0
+	DocumentFilterOperator_NE DocumentFilterOperator = 1
+	DocumentFilterOperator_LT DocumentFilterOperator = 2
+	DocumentFilterOperator_LE DocumentFilterOperator = 3
+	DocumentFilterOperator_GT DocumentFilterOperator = 4
+	DocumentFilterOperator_GE DocumentFilterOperator = 5
+)
+
+// Enum value maps for DocumentFilterOperator.
+var (
+	DocumentFilterOperator_name = map[int32]string{
+		0: "EQ",
+		1: "NE",
+		2: "LT",
+		3: "LE",
+		4: "GT",
+		5: "GE",
+	}
+	DocumentFilterOperator_value = map[string]int32{
+		"EQ": 0,
+		"NE": 1,
+		"LT": 2,
+		"LE": 3,
+		"GT": 4,
+		"GE": 5,
+	}
+)
+
+func (x DocumentFilterOperator) Enum() *DocumentFilterOperator {
+	p := new(DocumentFilterOperator)
+	*p = x
+	return p
+}
+
+func (x DocumentFilterOperator) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DocumentFilterOperator) Descriptor() protoreflect.EnumDescriptor {
+	return file_schema_proto_enumTypes[3].Descriptor()
+}
+
+func (DocumentFilterOperator) Type() protoreflect.EnumType {
+	return &file_schema_proto_enumTypes[3]
+}
+
+func (x DocumentFilterOperator) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DocumentFilterOperator.Descriptor instead.
+func (DocumentFilterOperator) EnumDescriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{3}
+}
+
+type IsolationLevel int32
+
+const (
+	IsolationLevel_SNAPSHOT       IsolationLevel = 0
+	IsolationLevel_READ_COMMITTED IsolationLevel = 1
+)
+
+// Enum value maps for IsolationLevel.
+var (
+	IsolationLevel_name = map[int32]string{
+		0: "SNAPSHOT",
+		1: "READ_COMMITTED",
+	}
+	IsolationLevel_value = map[string]int32{
+		"SNAPSHOT":       0,
+		"READ_COMMITTED": 1,
+	}
+)
+
+func (x IsolationLevel) Enum() *IsolationLevel {
+	p := new(IsolationLevel)
+	*p = x
+	return p
+}
+
+func (x IsolationLevel) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (IsolationLevel) Descriptor() protoreflect.EnumDescriptor {
+	return file_schema_proto_enumTypes[4].Descriptor()
+}
+
+func (IsolationLevel) Type() protoreflect.EnumType {
+	return &file_schema_proto_enumTypes[4]
+}
+
+func (x IsolationLevel) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use IsolationLevel.Descriptor instead.
+func (IsolationLevel) EnumDescriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{4}
 }
 
 type Key struct {
@@ -503,17 +722,17 @@ func (x *ChangePasswordRequest) GetNewPassword() []byte {
 	return nil
 }
 
-type LoginRequest struct {
+type ImportUsersResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	User     []byte `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
-	Password []byte `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	// tokens to be used by the imported users to choose their passwords, as passwords are not exported
+	Tokens []*PasswordResetToken `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"`
 }
 
-func (x *LoginRequest) Reset() {
-	*x = LoginRequest{}
+func (x *ImportUsersResponse) Reset() {
+	*x = ImportUsersResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -521,13 +740,13 @@ func (x *LoginRequest) Reset() {
 	}
 }
 
-func (x *LoginRequest) String() string {
+func (x *ImportUsersResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LoginRequest) ProtoMessage() {}
+func (*ImportUsersResponse) ProtoMessage() {}
 
-func (x *LoginRequest) ProtoReflect() protoreflect.Message {
+func (x *ImportUsersResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -539,36 +758,31 @@ func (x *LoginRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
-func (*LoginRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ImportUsersResponse.ProtoReflect.Descriptor instead.
+func (*ImportUsersResponse) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *LoginRequest) GetUser() []byte {
-	if x != nil {
-		return x.User
-	}
-	return nil
-}
-
-func (x *LoginRequest) GetPassword() []byte {
+func (x *ImportUsersResponse) GetTokens() []*PasswordResetToken {
 	if x != nil {
-		return x.Password
+		return x.Tokens
 	}
 	return nil
 }
 
-type LoginResponse struct {
+type PasswordResetToken struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Token   string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	Warning []byte `protobuf:"bytes,2,opt,name=warning,proto3" json:"warning,omitempty"`
+	User  []byte `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Token string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	// expiration time of the token, in unix seconds
+	ExpiresAt int64 `protobuf:"varint,3,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
 }
 
-func (x *LoginResponse) Reset() {
-	*x = LoginResponse{}
+func (x *PasswordResetToken) Reset() {
+	*x = PasswordResetToken{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -576,13 +790,13 @@ func (x *LoginResponse) Reset() {
 	}
 }
 
-func (x *LoginResponse) String() string {
+func (x *PasswordResetToken) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LoginResponse) ProtoMessage() {}
+func (*PasswordResetToken) ProtoMessage() {}
 
-func (x *LoginResponse) ProtoReflect() protoreflect.Message {
+func (x *PasswordResetToken) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -594,35 +808,44 @@ func (x *LoginResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LoginResponse.ProtoReflect.Descriptor instead.
-func (*LoginResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use PasswordResetToken.ProtoReflect.Descriptor instead.
+func (*PasswordResetToken) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *LoginResponse) GetToken() string {
+func (x *PasswordResetToken) GetUser() []byte {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *PasswordResetToken) GetToken() string {
 	if x != nil {
 		return x.Token
 	}
 	return ""
 }
 
-func (x *LoginResponse) GetWarning() []byte {
+func (x *PasswordResetToken) GetExpiresAt() int64 {
 	if x != nil {
-		return x.Warning
+		return x.ExpiresAt
 	}
-	return nil
+	return 0
 }
 
-type AuthConfig struct {
+type ResetPasswordRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Kind uint32 `protobuf:"varint,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	User        []byte `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Token       string `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	NewPassword []byte `protobuf:"bytes,3,opt,name=newPassword,proto3" json:"newPassword,omitempty"`
 }
 
-func (x *AuthConfig) Reset() {
-	*x = AuthConfig{}
+func (x *ResetPasswordRequest) Reset() {
+	*x = ResetPasswordRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -630,13 +853,13 @@ func (x *AuthConfig) Reset() {
 	}
 }
 
-func (x *AuthConfig) String() string {
+func (x *ResetPasswordRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AuthConfig) ProtoMessage() {}
+func (*ResetPasswordRequest) ProtoMessage() {}
 
-func (x *AuthConfig) ProtoReflect() protoreflect.Message {
+func (x *ResetPasswordRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -648,28 +871,43 @@ func (x *AuthConfig) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AuthConfig.ProtoReflect.Descriptor instead.
-func (*AuthConfig) Descriptor() ([]byte, []int) {
+// Deprecated: Use ResetPasswordRequest.ProtoReflect.Descriptor instead.
+func (*ResetPasswordRequest) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *AuthConfig) GetKind() uint32 {
+func (x *ResetPasswordRequest) GetUser() []byte {
 	if x != nil {
-		return x.Kind
+		return x.User
 	}
-	return 0
+	return nil
 }
 
-type MTLSConfig struct {
+func (x *ResetPasswordRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ResetPasswordRequest) GetNewPassword() []byte {
+	if x != nil {
+		return x.NewPassword
+	}
+	return nil
+}
+
+type LoginRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	User     []byte `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Password []byte `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
 }
 
-func (x *MTLSConfig) Reset() {
-	*x = MTLSConfig{}
+func (x *LoginRequest) Reset() {
+	*x = LoginRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -677,13 +915,13 @@ func (x *MTLSConfig) Reset() {
 	}
 }
 
-func (x *MTLSConfig) String() string {
+func (x *LoginRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MTLSConfig) ProtoMessage() {}
+func (*LoginRequest) ProtoMessage() {}
 
-func (x *MTLSConfig) ProtoReflect() protoreflect.Message {
+func (x *LoginRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -695,29 +933,36 @@ func (x *MTLSConfig) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MTLSConfig.ProtoReflect.Descriptor instead.
-func (*MTLSConfig) Descriptor() ([]byte, []int) {
+// Deprecated: Use LoginRequest.ProtoReflect.Descriptor instead.
+func (*LoginRequest) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *MTLSConfig) GetEnabled() bool {
+func (x *LoginRequest) GetUser() []byte {
 	if x != nil {
-		return x.Enabled
+		return x.User
 	}
-	return false
+	return nil
 }
 
-type KeyValue struct {
+func (x *LoginRequest) GetPassword() []byte {
+	if x != nil {
+		return x.Password
+	}
+	return nil
+}
+
+type LoginResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Key   []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Token   string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Warning []byte `protobuf:"bytes,2,opt,name=warning,proto3" json:"warning,omitempty"`
 }
 
-func (x *KeyValue) Reset() {
-	*x = KeyValue{}
+func (x *LoginResponse) Reset() {
+	*x = LoginResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -725,13 +970,13 @@ func (x *KeyValue) Reset() {
 	}
 }
 
-func (x *KeyValue) String() string {
+func (x *LoginResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*KeyValue) ProtoMessage() {}
+func (*LoginResponse) ProtoMessage() {}
 
-func (x *KeyValue) ProtoReflect() protoreflect.Message {
+func (x *LoginResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -743,38 +988,35 @@ func (x *KeyValue) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use KeyValue.ProtoReflect.Descriptor instead.
-func (*KeyValue) Descriptor() ([]byte, []int) {
+// Deprecated: Use LoginResponse.ProtoReflect.Descriptor instead.
+func (*LoginResponse) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *KeyValue) GetKey() []byte {
+func (x *LoginResponse) GetToken() string {
 	if x != nil {
-		return x.Key
+		return x.Token
 	}
-	return nil
+	return ""
 }
 
-func (x *KeyValue) GetValue() []byte {
+func (x *LoginResponse) GetWarning() []byte {
 	if x != nil {
-		return x.Value
+		return x.Warning
 	}
 	return nil
 }
 
-type Entry struct {
+type AuthConfig struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Tx           uint64     `protobuf:"varint,1,opt,name=tx,proto3" json:"tx,omitempty"`
-	Key          []byte     `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
-	Value        []byte     `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
-	ReferencedBy *Reference `protobuf:"bytes,4,opt,name=referencedBy,proto3" json:"referencedBy,omitempty"`
+	Kind uint32 `protobuf:"varint,1,opt,name=kind,proto3" json:"kind,omitempty"`
 }
 
-func (x *Entry) Reset() {
-	*x = Entry{}
+func (x *AuthConfig) Reset() {
+	*x = AuthConfig{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -782,13 +1024,13 @@ func (x *Entry) Reset() {
 	}
 }
 
-func (x *Entry) String() string {
+func (x *AuthConfig) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Entry) ProtoMessage() {}
+func (*AuthConfig) ProtoMessage() {}
 
-func (x *Entry) ProtoReflect() protoreflect.Message {
+func (x *AuthConfig) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -800,51 +1042,28 @@ func (x *Entry) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Entry.ProtoReflect.Descriptor instead.
-func (*Entry) Descriptor() ([]byte, []int) {
+// Deprecated: Use AuthConfig.ProtoReflect.Descriptor instead.
+func (*AuthConfig) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *Entry) GetTx() uint64 {
+func (x *AuthConfig) GetKind() uint32 {
 	if x != nil {
-		return x.Tx
+		return x.Kind
 	}
 	return 0
 }
 
-func (x *Entry) GetKey() []byte {
-	if x != nil {
-		return x.Key
-	}
-	return nil
-}
-
-func (x *Entry) GetValue() []byte {
-	if x != nil {
-		return x.Value
-	}
-	return nil
-}
-
-func (x *Entry) GetReferencedBy() *Reference {
-	if x != nil {
-		return x.ReferencedBy
-	}
-	return nil
-}
-
-type Reference struct {
+type MTLSConfig struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Tx   uint64 `protobuf:"varint,1,opt,name=tx,proto3" json:"tx,omitempty"`
-	Key  []byte `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
-	AtTx uint64 `protobuf:"varint,3,opt,name=atTx,proto3" json:"atTx,omitempty"`
+	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
 }
 
-func (x *Reference) Reset() {
-	*x = Reference{}
+func (x *MTLSConfig) Reset() {
+	*x = MTLSConfig{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -852,13 +1071,13 @@ func (x *Reference) Reset() {
 	}
 }
 
-func (x *Reference) String() string {
+func (x *MTLSConfig) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Reference) ProtoMessage() {}
+func (*MTLSConfig) ProtoMessage() {}
 
-func (x *Reference) ProtoReflect() protoreflect.Message {
+func (x *MTLSConfig) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -870,46 +1089,28 @@ func (x *Reference) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Reference.ProtoReflect.Descriptor instead.
-func (*Reference) Descriptor() ([]byte, []int) {
+// Deprecated: Use MTLSConfig.ProtoReflect.Descriptor instead.
+func (*MTLSConfig) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *Reference) GetTx() uint64 {
-	if x != nil {
-		return x.Tx
-	}
-	return 0
-}
-
-func (x *Reference) GetKey() []byte {
-	if x != nil {
-		return x.Key
-	}
-	return nil
-}
-
-func (x *Reference) GetAtTx() uint64 {
+func (x *MTLSConfig) GetEnabled() bool {
 	if x != nil {
-		return x.AtTx
+		return x.Enabled
 	}
-	return 0
+	return false
 }
 
-type Op struct {
+type Expiration struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Types that are assignable to Operation:
-	//	*Op_Kv
-	//	*Op_ZAdd
-	//	*Op_Ref
-	Operation isOp_Operation `protobuf_oneof:"operation"`
+	ExpiresAt int64 `protobuf:"varint,1,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
 }
 
-func (x *Op) Reset() {
-	*x = Op{}
+func (x *Expiration) Reset() {
+	*x = Expiration{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -917,13 +1118,13 @@ func (x *Op) Reset() {
 	}
 }
 
-func (x *Op) String() string {
+func (x *Expiration) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Op) ProtoMessage() {}
+func (*Expiration) ProtoMessage() {}
 
-func (x *Op) ProtoReflect() protoreflect.Message {
+func (x *Expiration) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -935,72 +1136,30 @@ func (x *Op) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Op.ProtoReflect.Descriptor instead.
-func (*Op) Descriptor() ([]byte, []int) {
+// Deprecated: Use Expiration.ProtoReflect.Descriptor instead.
+func (*Expiration) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{14}
 }
 
-func (m *Op) GetOperation() isOp_Operation {
-	if m != nil {
-		return m.Operation
-	}
-	return nil
-}
-
-func (x *Op) GetKv() *KeyValue {
-	if x, ok := x.GetOperation().(*Op_Kv); ok {
-		return x.Kv
-	}
-	return nil
-}
-
-func (x *Op) GetZAdd() *ZAddRequest {
-	if x, ok := x.GetOperation().(*Op_ZAdd); ok {
-		return x.ZAdd
-	}
-	return nil
-}
-
-func (x *Op) GetRef() *ReferenceRequest {
-	if x, ok := x.GetOperation().(*Op_Ref); ok {
-		return x.Ref
+func (x *Expiration) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
 	}
-	return nil
-}
-
-type isOp_Operation interface {
-	isOp_Operation()
-}
-
-type Op_Kv struct {
-	Kv *KeyValue `protobuf:"bytes,1,opt,name=kv,proto3,oneof"`
-}
-
-type Op_ZAdd struct {
-	ZAdd *ZAddRequest `protobuf:"bytes,2,opt,name=zAdd,proto3,oneof"`
-}
-
-type Op_Ref struct {
-	Ref *ReferenceRequest `protobuf:"bytes,3,opt,name=ref,proto3,oneof"`
+	return 0
 }
 
-func (*Op_Kv) isOp_Operation() {}
-
-func (*Op_ZAdd) isOp_Operation() {}
-
-func (*Op_Ref) isOp_Operation() {}
-
-type ExecAllRequest struct {
+type KVMetadata struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Operations []*Op `protobuf:"bytes,1,rep,name=Operations,proto3" json:"Operations,omitempty"`
-	NoWait     bool  `protobuf:"varint,2,opt,name=noWait,proto3" json:"noWait,omitempty"`
+	Expiration *Expiration `protobuf:"bytes,1,opt,name=expiration,proto3" json:"expiration,omitempty"`
+	Indexes    []*KVIndex  `protobuf:"bytes,2,rep,name=indexes,proto3" json:"indexes,omitempty"`
+	Deleted    bool        `protobuf:"varint,3,opt,name=deleted,proto3" json:"deleted,omitempty"`
 }
 
-func (x *ExecAllRequest) Reset() {
-	*x = ExecAllRequest{}
+func (x *KVMetadata) Reset() {
+	*x = KVMetadata{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1008,13 +1167,13 @@ func (x *ExecAllRequest) Reset() {
 	}
 }
 
-func (x *ExecAllRequest) String() string {
+func (x *KVMetadata) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ExecAllRequest) ProtoMessage() {}
+func (*KVMetadata) ProtoMessage() {}
 
-func (x *ExecAllRequest) ProtoReflect() protoreflect.Message {
+func (x *KVMetadata) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1026,35 +1185,44 @@ func (x *ExecAllRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ExecAllRequest.ProtoReflect.Descriptor instead.
-func (*ExecAllRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use KVMetadata.ProtoReflect.Descriptor instead.
+func (*KVMetadata) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *ExecAllRequest) GetOperations() []*Op {
+func (x *KVMetadata) GetExpiration() *Expiration {
 	if x != nil {
-		return x.Operations
+		return x.Expiration
 	}
 	return nil
 }
 
-func (x *ExecAllRequest) GetNoWait() bool {
+func (x *KVMetadata) GetIndexes() []*KVIndex {
 	if x != nil {
-		return x.NoWait
+		return x.Indexes
+	}
+	return nil
+}
+
+func (x *KVMetadata) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
 	}
 	return false
 }
 
-type Entries struct {
+type KVIndex struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Entries []*Entry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	Name           string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Tag            []byte `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"`
+	ValuePrefixLen uint32 `protobuf:"varint,3,opt,name=valuePrefixLen,proto3" json:"valuePrefixLen,omitempty"`
 }
 
-func (x *Entries) Reset() {
-	*x = Entries{}
+func (x *KVIndex) Reset() {
+	*x = KVIndex{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1062,13 +1230,13 @@ func (x *Entries) Reset() {
 	}
 }
 
-func (x *Entries) String() string {
+func (x *KVIndex) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Entries) ProtoMessage() {}
+func (*KVIndex) ProtoMessage() {}
 
-func (x *Entries) ProtoReflect() protoreflect.Message {
+func (x *KVIndex) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1080,32 +1248,44 @@ func (x *Entries) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Entries.ProtoReflect.Descriptor instead.
-func (*Entries) Descriptor() ([]byte, []int) {
+// Deprecated: Use KVIndex.ProtoReflect.Descriptor instead.
+func (*KVIndex) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *Entries) GetEntries() []*Entry {
+func (x *KVIndex) GetName() string {
 	if x != nil {
-		return x.Entries
+		return x.Name
+	}
+	return ""
+}
+
+func (x *KVIndex) GetTag() []byte {
+	if x != nil {
+		return x.Tag
 	}
 	return nil
 }
 
-type ZEntry struct {
+func (x *KVIndex) GetValuePrefixLen() uint32 {
+	if x != nil {
+		return x.ValuePrefixLen
+	}
+	return 0
+}
+
+type KeyValue struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Set   []byte  `protobuf:"bytes,1,opt,name=set,proto3" json:"set,omitempty"`
-	Key   []byte  `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
-	Entry *Entry  `protobuf:"bytes,3,opt,name=entry,proto3" json:"entry,omitempty"`
-	Score float64 `protobuf:"fixed64,4,opt,name=score,proto3" json:"score,omitempty"`
-	AtTx  uint64  `protobuf:"varint,5,opt,name=atTx,proto3" json:"atTx,omitempty"`
+	Key      []byte      `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value    []byte      `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Metadata *KVMetadata `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
 }
 
-func (x *ZEntry) Reset() {
-	*x = ZEntry{}
+func (x *KeyValue) Reset() {
+	*x = KeyValue{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1113,13 +1293,13 @@ func (x *ZEntry) Reset() {
 	}
 }
 
-func (x *ZEntry) String() string {
+func (x *KeyValue) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ZEntry) ProtoMessage() {}
+func (*KeyValue) ProtoMessage() {}
 
-func (x *ZEntry) ProtoReflect() protoreflect.Message {
+func (x *KeyValue) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1131,56 +1311,47 @@ func (x *ZEntry) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ZEntry.ProtoReflect.Descriptor instead.
-func (*ZEntry) Descriptor() ([]byte, []int) {
+// Deprecated: Use KeyValue.ProtoReflect.Descriptor instead.
+func (*KeyValue) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *ZEntry) GetSet() []byte {
-	if x != nil {
-		return x.Set
-	}
-	return nil
-}
-
-func (x *ZEntry) GetKey() []byte {
+func (x *KeyValue) GetKey() []byte {
 	if x != nil {
 		return x.Key
 	}
 	return nil
 }
 
-func (x *ZEntry) GetEntry() *Entry {
+func (x *KeyValue) GetValue() []byte {
 	if x != nil {
-		return x.Entry
+		return x.Value
 	}
 	return nil
 }
 
-func (x *ZEntry) GetScore() float64 {
-	if x != nil {
-		return x.Score
-	}
-	return 0
-}
-
-func (x *ZEntry) GetAtTx() uint64 {
+func (x *KeyValue) GetMetadata() *KVMetadata {
 	if x != nil {
-		return x.AtTx
+		return x.Metadata
 	}
-	return 0
+	return nil
 }
 
-type ZEntries struct {
+type Entry struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Entries []*ZEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	Tx           uint64      `protobuf:"varint,1,opt,name=tx,proto3" json:"tx,omitempty"`
+	Key          []byte      `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Value        []byte      `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	ReferencedBy *Reference  `protobuf:"bytes,4,opt,name=referencedBy,proto3" json:"referencedBy,omitempty"`
+	Metadata     *KVMetadata `protobuf:"bytes,5,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Revision     uint64      `protobuf:"varint,6,opt,name=revision,proto3" json:"revision,omitempty"`
 }
 
-func (x *ZEntries) Reset() {
-	*x = ZEntries{}
+func (x *Entry) Reset() {
+	*x = Entry{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1188,13 +1359,13 @@ func (x *ZEntries) Reset() {
 	}
 }
 
-func (x *ZEntries) String() string {
+func (x *Entry) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ZEntries) ProtoMessage() {}
+func (*Entry) ProtoMessage() {}
 
-func (x *ZEntries) ProtoReflect() protoreflect.Message {
+func (x *Entry) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1206,33 +1377,65 @@ func (x *ZEntries) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ZEntries.ProtoReflect.Descriptor instead.
-func (*ZEntries) Descriptor() ([]byte, []int) {
+// Deprecated: Use Entry.ProtoReflect.Descriptor instead.
+func (*Entry) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *ZEntries) GetEntries() []*ZEntry {
+func (x *Entry) GetTx() uint64 {
 	if x != nil {
-		return x.Entries
+		return x.Tx
 	}
-	return nil
+	return 0
 }
 
-type ScanRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *Entry) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
 
-	SeekKey []byte `protobuf:"bytes,1,opt,name=seekKey,proto3" json:"seekKey,omitempty"`
-	Prefix  []byte `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
-	Desc    bool   `protobuf:"varint,3,opt,name=desc,proto3" json:"desc,omitempty"`
-	Limit   uint64 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
-	SinceTx uint64 `protobuf:"varint,5,opt,name=sinceTx,proto3" json:"sinceTx,omitempty"`
-	NoWait  bool   `protobuf:"varint,6,opt,name=noWait,proto3" json:"noWait,omitempty"`
+func (x *Entry) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
 }
 
-func (x *ScanRequest) Reset() {
-	*x = ScanRequest{}
+func (x *Entry) GetReferencedBy() *Reference {
+	if x != nil {
+		return x.ReferencedBy
+	}
+	return nil
+}
+
+func (x *Entry) GetMetadata() *KVMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *Entry) GetRevision() uint64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+type Reference struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tx   uint64 `protobuf:"varint,1,opt,name=tx,proto3" json:"tx,omitempty"`
+	Key  []byte `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	AtTx uint64 `protobuf:"varint,3,opt,name=atTx,proto3" json:"atTx,omitempty"`
+}
+
+func (x *Reference) Reset() {
+	*x = Reference{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1240,13 +1443,13 @@ func (x *ScanRequest) Reset() {
 	}
 }
 
-func (x *ScanRequest) String() string {
+func (x *Reference) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ScanRequest) ProtoMessage() {}
+func (*Reference) ProtoMessage() {}
 
-func (x *ScanRequest) ProtoReflect() protoreflect.Message {
+func (x *Reference) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1258,63 +1461,46 @@ func (x *ScanRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ScanRequest.ProtoReflect.Descriptor instead.
-func (*ScanRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use Reference.ProtoReflect.Descriptor instead.
+func (*Reference) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *ScanRequest) GetSeekKey() []byte {
+func (x *Reference) GetTx() uint64 {
 	if x != nil {
-		return x.SeekKey
+		return x.Tx
 	}
-	return nil
+	return 0
 }
 
-func (x *ScanRequest) GetPrefix() []byte {
+func (x *Reference) GetKey() []byte {
 	if x != nil {
-		return x.Prefix
+		return x.Key
 	}
 	return nil
 }
 
-func (x *ScanRequest) GetDesc() bool {
-	if x != nil {
-		return x.Desc
-	}
-	return false
-}
-
-func (x *ScanRequest) GetLimit() uint64 {
-	if x != nil {
-		return x.Limit
-	}
-	return 0
-}
-
-func (x *ScanRequest) GetSinceTx() uint64 {
+func (x *Reference) GetAtTx() uint64 {
 	if x != nil {
-		return x.SinceTx
+		return x.AtTx
 	}
 	return 0
 }
 
-func (x *ScanRequest) GetNoWait() bool {
-	if x != nil {
-		return x.NoWait
-	}
-	return false
-}
-
-type KeyPrefix struct {
+type Op struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Prefix []byte `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// Types that are assignable to Operation:
+	//	*Op_Kv
+	//	*Op_ZAdd
+	//	*Op_Ref
+	Operation isOp_Operation `protobuf_oneof:"operation"`
 }
 
-func (x *KeyPrefix) Reset() {
-	*x = KeyPrefix{}
+func (x *Op) Reset() {
+	*x = Op{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1322,13 +1508,13 @@ func (x *KeyPrefix) Reset() {
 	}
 }
 
-func (x *KeyPrefix) String() string {
+func (x *Op) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*KeyPrefix) ProtoMessage() {}
+func (*Op) ProtoMessage() {}
 
-func (x *KeyPrefix) ProtoReflect() protoreflect.Message {
+func (x *Op) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1340,28 +1526,74 @@ func (x *KeyPrefix) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use KeyPrefix.ProtoReflect.Descriptor instead.
-func (*KeyPrefix) Descriptor() ([]byte, []int) {
+// Deprecated: Use Op.ProtoReflect.Descriptor instead.
+func (*Op) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *KeyPrefix) GetPrefix() []byte {
-	if x != nil {
-		return x.Prefix
+func (m *Op) GetOperation() isOp_Operation {
+	if m != nil {
+		return m.Operation
 	}
 	return nil
 }
 
-type EntryCount struct {
+func (x *Op) GetKv() *KeyValue {
+	if x, ok := x.GetOperation().(*Op_Kv); ok {
+		return x.Kv
+	}
+	return nil
+}
+
+func (x *Op) GetZAdd() *ZAddRequest {
+	if x, ok := x.GetOperation().(*Op_ZAdd); ok {
+		return x.ZAdd
+	}
+	return nil
+}
+
+func (x *Op) GetRef() *ReferenceRequest {
+	if x, ok := x.GetOperation().(*Op_Ref); ok {
+		return x.Ref
+	}
+	return nil
+}
+
+type isOp_Operation interface {
+	isOp_Operation()
+}
+
+type Op_Kv struct {
+	Kv *KeyValue `protobuf:"bytes,1,opt,name=kv,proto3,oneof"`
+}
+
+type Op_ZAdd struct {
+	ZAdd *ZAddRequest `protobuf:"bytes,2,opt,name=zAdd,proto3,oneof"`
+}
+
+type Op_Ref struct {
+	Ref *ReferenceRequest `protobuf:"bytes,3,opt,name=ref,proto3,oneof"`
+}
+
+func (*Op_Kv) isOp_Operation() {}
+
+func (*Op_ZAdd) isOp_Operation() {}
+
+func (*Op_Ref) isOp_Operation() {}
+
+type ExecAllRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Count uint64 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	Operations []*Op `protobuf:"bytes,1,rep,name=Operations,proto3" json:"Operations,omitempty"`
+	NoWait     bool  `protobuf:"varint,2,opt,name=noWait,proto3" json:"noWait,omitempty"`
+	// W3C trace-id recorded into the tx attributes
+	TraceID []byte `protobuf:"bytes,3,opt,name=traceID,proto3" json:"traceID,omitempty"`
 }
 
-func (x *EntryCount) Reset() {
-	*x = EntryCount{}
+func (x *ExecAllRequest) Reset() {
+	*x = ExecAllRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1369,13 +1601,13 @@ func (x *EntryCount) Reset() {
 	}
 }
 
-func (x *EntryCount) String() string {
+func (x *ExecAllRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EntryCount) ProtoMessage() {}
+func (*ExecAllRequest) ProtoMessage() {}
 
-func (x *EntryCount) ProtoReflect() protoreflect.Message {
+func (x *ExecAllRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1387,29 +1619,42 @@ func (x *EntryCount) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EntryCount.ProtoReflect.Descriptor instead.
-func (*EntryCount) Descriptor() ([]byte, []int) {
+// Deprecated: Use ExecAllRequest.ProtoReflect.Descriptor instead.
+func (*ExecAllRequest) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *EntryCount) GetCount() uint64 {
+func (x *ExecAllRequest) GetOperations() []*Op {
 	if x != nil {
-		return x.Count
+		return x.Operations
 	}
-	return 0
+	return nil
 }
 
-type Signature struct {
+func (x *ExecAllRequest) GetNoWait() bool {
+	if x != nil {
+		return x.NoWait
+	}
+	return false
+}
+
+func (x *ExecAllRequest) GetTraceID() []byte {
+	if x != nil {
+		return x.TraceID
+	}
+	return nil
+}
+
+type Entries struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PublicKey []byte `protobuf:"bytes,1,opt,name=publicKey,proto3" json:"publicKey,omitempty"`
-	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	Entries []*Entry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
 }
 
-func (x *Signature) Reset() {
-	*x = Signature{}
+func (x *Entries) Reset() {
+	*x = Entries{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1417,13 +1662,13 @@ func (x *Signature) Reset() {
 	}
 }
 
-func (x *Signature) String() string {
+func (x *Entries) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Signature) ProtoMessage() {}
+func (*Entries) ProtoMessage() {}
 
-func (x *Signature) ProtoReflect() protoreflect.Message {
+func (x *Entries) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1435,41 +1680,32 @@ func (x *Signature) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Signature.ProtoReflect.Descriptor instead.
-func (*Signature) Descriptor() ([]byte, []int) {
+// Deprecated: Use Entries.ProtoReflect.Descriptor instead.
+func (*Entries) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *Signature) GetPublicKey() []byte {
-	if x != nil {
-		return x.PublicKey
-	}
-	return nil
-}
-
-func (x *Signature) GetSignature() []byte {
+func (x *Entries) GetEntries() []*Entry {
 	if x != nil {
-		return x.Signature
+		return x.Entries
 	}
 	return nil
 }
 
-type TxMetadata struct {
+type ZEntry struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id       uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	PrevAlh  []byte `protobuf:"bytes,2,opt,name=prevAlh,proto3" json:"prevAlh,omitempty"`
-	Ts       int64  `protobuf:"varint,3,opt,name=ts,proto3" json:"ts,omitempty"`
-	Nentries int32  `protobuf:"varint,4,opt,name=nentries,proto3" json:"nentries,omitempty"`
-	EH       []byte `protobuf:"bytes,5,opt,name=eH,proto3" json:"eH,omitempty"`
-	BlTxId   uint64 `protobuf:"varint,6,opt,name=blTxId,proto3" json:"blTxId,omitempty"`
-	BlRoot   []byte `protobuf:"bytes,7,opt,name=blRoot,proto3" json:"blRoot,omitempty"`
+	Set   []byte  `protobuf:"bytes,1,opt,name=set,proto3" json:"set,omitempty"`
+	Key   []byte  `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Entry *Entry  `protobuf:"bytes,3,opt,name=entry,proto3" json:"entry,omitempty"`
+	Score float64 `protobuf:"fixed64,4,opt,name=score,proto3" json:"score,omitempty"`
+	AtTx  uint64  `protobuf:"varint,5,opt,name=atTx,proto3" json:"atTx,omitempty"`
 }
 
-func (x *TxMetadata) Reset() {
-	*x = TxMetadata{}
+func (x *ZEntry) Reset() {
+	*x = ZEntry{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1477,13 +1713,13 @@ func (x *TxMetadata) Reset() {
 	}
 }
 
-func (x *TxMetadata) String() string {
+func (x *ZEntry) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TxMetadata) ProtoMessage() {}
+func (*ZEntry) ProtoMessage() {}
 
-func (x *TxMetadata) ProtoReflect() protoreflect.Message {
+func (x *ZEntry) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1495,72 +1731,56 @@ func (x *TxMetadata) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TxMetadata.ProtoReflect.Descriptor instead.
-func (*TxMetadata) Descriptor() ([]byte, []int) {
+// Deprecated: Use ZEntry.ProtoReflect.Descriptor instead.
+func (*ZEntry) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *TxMetadata) GetId() uint64 {
+func (x *ZEntry) GetSet() []byte {
 	if x != nil {
-		return x.Id
+		return x.Set
 	}
-	return 0
+	return nil
 }
 
-func (x *TxMetadata) GetPrevAlh() []byte {
+func (x *ZEntry) GetKey() []byte {
 	if x != nil {
-		return x.PrevAlh
+		return x.Key
 	}
 	return nil
 }
 
-func (x *TxMetadata) GetTs() int64 {
+func (x *ZEntry) GetEntry() *Entry {
 	if x != nil {
-		return x.Ts
+		return x.Entry
 	}
-	return 0
+	return nil
 }
 
-func (x *TxMetadata) GetNentries() int32 {
+func (x *ZEntry) GetScore() float64 {
 	if x != nil {
-		return x.Nentries
+		return x.Score
 	}
 	return 0
 }
 
-func (x *TxMetadata) GetEH() []byte {
-	if x != nil {
-		return x.EH
-	}
-	return nil
-}
-
-func (x *TxMetadata) GetBlTxId() uint64 {
+func (x *ZEntry) GetAtTx() uint64 {
 	if x != nil {
-		return x.BlTxId
+		return x.AtTx
 	}
 	return 0
 }
 
-func (x *TxMetadata) GetBlRoot() []byte {
-	if x != nil {
-		return x.BlRoot
-	}
-	return nil
-}
-
-type LinearProof struct {
+type ZEntries struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SourceTxId uint64   `protobuf:"varint,1,opt,name=sourceTxId,proto3" json:"sourceTxId,omitempty"`
-	TargetTxId uint64   `protobuf:"varint,2,opt,name=TargetTxId,proto3" json:"TargetTxId,omitempty"`
-	Terms      [][]byte `protobuf:"bytes,3,rep,name=terms,proto3" json:"terms,omitempty"`
+	Entries []*ZEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
 }
 
-func (x *LinearProof) Reset() {
-	*x = LinearProof{}
+func (x *ZEntries) Reset() {
+	*x = ZEntries{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1568,13 +1788,13 @@ func (x *LinearProof) Reset() {
 	}
 }
 
-func (x *LinearProof) String() string {
+func (x *ZEntries) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LinearProof) ProtoMessage() {}
+func (*ZEntries) ProtoMessage() {}
 
-func (x *LinearProof) ProtoReflect() protoreflect.Message {
+func (x *ZEntries) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1586,48 +1806,33 @@ func (x *LinearProof) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LinearProof.ProtoReflect.Descriptor instead.
-func (*LinearProof) Descriptor() ([]byte, []int) {
+// Deprecated: Use ZEntries.ProtoReflect.Descriptor instead.
+func (*ZEntries) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *LinearProof) GetSourceTxId() uint64 {
-	if x != nil {
-		return x.SourceTxId
-	}
-	return 0
-}
-
-func (x *LinearProof) GetTargetTxId() uint64 {
-	if x != nil {
-		return x.TargetTxId
-	}
-	return 0
-}
-
-func (x *LinearProof) GetTerms() [][]byte {
+func (x *ZEntries) GetEntries() []*ZEntry {
 	if x != nil {
-		return x.Terms
+		return x.Entries
 	}
 	return nil
 }
 
-type DualProof struct {
+type ScanRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SourceTxMetadata   *TxMetadata  `protobuf:"bytes,1,opt,name=sourceTxMetadata,proto3" json:"sourceTxMetadata,omitempty"`
-	TargetTxMetadata   *TxMetadata  `protobuf:"bytes,2,opt,name=targetTxMetadata,proto3" json:"targetTxMetadata,omitempty"`
-	InclusionProof     [][]byte     `protobuf:"bytes,3,rep,name=inclusionProof,proto3" json:"inclusionProof,omitempty"`
-	ConsistencyProof   [][]byte     `protobuf:"bytes,4,rep,name=consistencyProof,proto3" json:"consistencyProof,omitempty"`
-	TargetBlTxAlh      []byte       `protobuf:"bytes,5,opt,name=targetBlTxAlh,proto3" json:"targetBlTxAlh,omitempty"`
-	LastInclusionProof [][]byte     `protobuf:"bytes,6,rep,name=lastInclusionProof,proto3" json:"lastInclusionProof,omitempty"`
-	LinearProof        *LinearProof `protobuf:"bytes,7,opt,name=linearProof,proto3" json:"linearProof,omitempty"`
+	SeekKey []byte `protobuf:"bytes,1,opt,name=seekKey,proto3" json:"seekKey,omitempty"`
+	Prefix  []byte `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Desc    bool   `protobuf:"varint,3,opt,name=desc,proto3" json:"desc,omitempty"`
+	Limit   uint64 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	SinceTx uint64 `protobuf:"varint,5,opt,name=sinceTx,proto3" json:"sinceTx,omitempty"`
+	NoWait  bool   `protobuf:"varint,6,opt,name=noWait,proto3" json:"noWait,omitempty"`
 }
 
-func (x *DualProof) Reset() {
-	*x = DualProof{}
+func (x *ScanRequest) Reset() {
+	*x = ScanRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1635,13 +1840,13 @@ func (x *DualProof) Reset() {
 	}
 }
 
-func (x *DualProof) String() string {
+func (x *ScanRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DualProof) ProtoMessage() {}
+func (*ScanRequest) ProtoMessage() {}
 
-func (x *DualProof) ProtoReflect() protoreflect.Message {
+func (x *ScanRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1653,71 +1858,69 @@ func (x *DualProof) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DualProof.ProtoReflect.Descriptor instead.
-func (*DualProof) Descriptor() ([]byte, []int) {
+// Deprecated: Use ScanRequest.ProtoReflect.Descriptor instead.
+func (*ScanRequest) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *DualProof) GetSourceTxMetadata() *TxMetadata {
-	if x != nil {
-		return x.SourceTxMetadata
-	}
-	return nil
-}
-
-func (x *DualProof) GetTargetTxMetadata() *TxMetadata {
+func (x *ScanRequest) GetSeekKey() []byte {
 	if x != nil {
-		return x.TargetTxMetadata
+		return x.SeekKey
 	}
 	return nil
 }
 
-func (x *DualProof) GetInclusionProof() [][]byte {
+func (x *ScanRequest) GetPrefix() []byte {
 	if x != nil {
-		return x.InclusionProof
+		return x.Prefix
 	}
 	return nil
 }
 
-func (x *DualProof) GetConsistencyProof() [][]byte {
+func (x *ScanRequest) GetDesc() bool {
 	if x != nil {
-		return x.ConsistencyProof
+		return x.Desc
 	}
-	return nil
+	return false
 }
 
-func (x *DualProof) GetTargetBlTxAlh() []byte {
+func (x *ScanRequest) GetLimit() uint64 {
 	if x != nil {
-		return x.TargetBlTxAlh
+		return x.Limit
 	}
-	return nil
+	return 0
 }
 
-func (x *DualProof) GetLastInclusionProof() [][]byte {
+func (x *ScanRequest) GetSinceTx() uint64 {
 	if x != nil {
-		return x.LastInclusionProof
+		return x.SinceTx
 	}
-	return nil
+	return 0
 }
 
-func (x *DualProof) GetLinearProof() *LinearProof {
+func (x *ScanRequest) GetNoWait() bool {
 	if x != nil {
-		return x.LinearProof
+		return x.NoWait
 	}
-	return nil
+	return false
 }
 
-type Tx struct {
+type IndexScanRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Metadata *TxMetadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	Entries  []*TxEntry  `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	Index    string `protobuf:"bytes,1,opt,name=index,proto3" json:"index,omitempty"`
+	StartKey []byte `protobuf:"bytes,2,opt,name=startKey,proto3" json:"startKey,omitempty"`
+	EndKey   []byte `protobuf:"bytes,3,opt,name=endKey,proto3" json:"endKey,omitempty"`
+	Desc     bool   `protobuf:"varint,4,opt,name=desc,proto3" json:"desc,omitempty"`
+	Limit    uint64 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	SinceTx  uint64 `protobuf:"varint,6,opt,name=sinceTx,proto3" json:"sinceTx,omitempty"`
+	NoWait   bool   `protobuf:"varint,7,opt,name=noWait,proto3" json:"noWait,omitempty"`
 }
 
-func (x *Tx) Reset() {
-	*x = Tx{}
+func (x *IndexScanRequest) Reset() {
+	*x = IndexScanRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1725,13 +1928,13 @@ func (x *Tx) Reset() {
 	}
 }
 
-func (x *Tx) String() string {
+func (x *IndexScanRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Tx) ProtoMessage() {}
+func (*IndexScanRequest) ProtoMessage() {}
 
-func (x *Tx) ProtoReflect() protoreflect.Message {
+func (x *IndexScanRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1743,38 +1946,70 @@ func (x *Tx) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Tx.ProtoReflect.Descriptor instead.
-func (*Tx) Descriptor() ([]byte, []int) {
+// Deprecated: Use IndexScanRequest.ProtoReflect.Descriptor instead.
+func (*IndexScanRequest) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *Tx) GetMetadata() *TxMetadata {
+func (x *IndexScanRequest) GetIndex() string {
 	if x != nil {
-		return x.Metadata
+		return x.Index
+	}
+	return ""
+}
+
+func (x *IndexScanRequest) GetStartKey() []byte {
+	if x != nil {
+		return x.StartKey
 	}
 	return nil
 }
 
-func (x *Tx) GetEntries() []*TxEntry {
+func (x *IndexScanRequest) GetEndKey() []byte {
 	if x != nil {
-		return x.Entries
+		return x.EndKey
 	}
 	return nil
 }
 
-type TxEntry struct {
+func (x *IndexScanRequest) GetDesc() bool {
+	if x != nil {
+		return x.Desc
+	}
+	return false
+}
+
+func (x *IndexScanRequest) GetLimit() uint64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *IndexScanRequest) GetSinceTx() uint64 {
+	if x != nil {
+		return x.SinceTx
+	}
+	return 0
+}
+
+func (x *IndexScanRequest) GetNoWait() bool {
+	if x != nil {
+		return x.NoWait
+	}
+	return false
+}
+
+type KeyPrefix struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Key    []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	HValue []byte `protobuf:"bytes,2,opt,name=hValue,proto3" json:"hValue,omitempty"`
-	VOff   int64  `protobuf:"varint,3,opt,name=vOff,proto3" json:"vOff,omitempty"`
-	VLen   int32  `protobuf:"varint,4,opt,name=vLen,proto3" json:"vLen,omitempty"`
+	Prefix []byte `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
 }
 
-func (x *TxEntry) Reset() {
-	*x = TxEntry{}
+func (x *KeyPrefix) Reset() {
+	*x = KeyPrefix{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1782,13 +2017,13 @@ func (x *TxEntry) Reset() {
 	}
 }
 
-func (x *TxEntry) String() string {
+func (x *KeyPrefix) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TxEntry) ProtoMessage() {}
+func (*KeyPrefix) ProtoMessage() {}
 
-func (x *TxEntry) ProtoReflect() protoreflect.Message {
+func (x *KeyPrefix) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1800,51 +2035,28 @@ func (x *TxEntry) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TxEntry.ProtoReflect.Descriptor instead.
-func (*TxEntry) Descriptor() ([]byte, []int) {
+// Deprecated: Use KeyPrefix.ProtoReflect.Descriptor instead.
+func (*KeyPrefix) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *TxEntry) GetKey() []byte {
-	if x != nil {
-		return x.Key
-	}
-	return nil
-}
-
-func (x *TxEntry) GetHValue() []byte {
+func (x *KeyPrefix) GetPrefix() []byte {
 	if x != nil {
-		return x.HValue
+		return x.Prefix
 	}
 	return nil
 }
 
-func (x *TxEntry) GetVOff() int64 {
-	if x != nil {
-		return x.VOff
-	}
-	return 0
-}
-
-func (x *TxEntry) GetVLen() int32 {
-	if x != nil {
-		return x.VLen
-	}
-	return 0
-}
-
-type VerifiableTx struct {
+type EntryCount struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Tx        *Tx        `protobuf:"bytes,1,opt,name=tx,proto3" json:"tx,omitempty"`
-	DualProof *DualProof `protobuf:"bytes,2,opt,name=dualProof,proto3" json:"dualProof,omitempty"`
-	Signature *Signature `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	Count uint64 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
 }
 
-func (x *VerifiableTx) Reset() {
-	*x = VerifiableTx{}
+func (x *EntryCount) Reset() {
+	*x = EntryCount{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1852,13 +2064,13 @@ func (x *VerifiableTx) Reset() {
 	}
 }
 
-func (x *VerifiableTx) String() string {
+func (x *EntryCount) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VerifiableTx) ProtoMessage() {}
+func (*EntryCount) ProtoMessage() {}
 
-func (x *VerifiableTx) ProtoReflect() protoreflect.Message {
+func (x *EntryCount) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1870,44 +2082,29 @@ func (x *VerifiableTx) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VerifiableTx.ProtoReflect.Descriptor instead.
-func (*VerifiableTx) Descriptor() ([]byte, []int) {
+// Deprecated: Use EntryCount.ProtoReflect.Descriptor instead.
+func (*EntryCount) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{28}
 }
 
-func (x *VerifiableTx) GetTx() *Tx {
-	if x != nil {
-		return x.Tx
-	}
-	return nil
-}
-
-func (x *VerifiableTx) GetDualProof() *DualProof {
-	if x != nil {
-		return x.DualProof
-	}
-	return nil
-}
-
-func (x *VerifiableTx) GetSignature() *Signature {
+func (x *EntryCount) GetCount() uint64 {
 	if x != nil {
-		return x.Signature
+		return x.Count
 	}
-	return nil
+	return 0
 }
 
-type VerifiableEntry struct {
+type Signature struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Entry          *Entry          `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
-	VerifiableTx   *VerifiableTx   `protobuf:"bytes,2,opt,name=verifiableTx,proto3" json:"verifiableTx,omitempty"`
-	InclusionProof *InclusionProof `protobuf:"bytes,3,opt,name=inclusionProof,proto3" json:"inclusionProof,omitempty"`
+	PublicKey []byte `protobuf:"bytes,1,opt,name=publicKey,proto3" json:"publicKey,omitempty"`
+	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
 }
 
-func (x *VerifiableEntry) Reset() {
-	*x = VerifiableEntry{}
+func (x *Signature) Reset() {
+	*x = Signature{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1915,13 +2112,13 @@ func (x *VerifiableEntry) Reset() {
 	}
 }
 
-func (x *VerifiableEntry) String() string {
+func (x *Signature) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VerifiableEntry) ProtoMessage() {}
+func (*Signature) ProtoMessage() {}
 
-func (x *VerifiableEntry) ProtoReflect() protoreflect.Message {
+func (x *Signature) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1933,44 +2130,43 @@ func (x *VerifiableEntry) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VerifiableEntry.ProtoReflect.Descriptor instead.
-func (*VerifiableEntry) Descriptor() ([]byte, []int) {
+// Deprecated: Use Signature.ProtoReflect.Descriptor instead.
+func (*Signature) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *VerifiableEntry) GetEntry() *Entry {
-	if x != nil {
-		return x.Entry
-	}
-	return nil
-}
-
-func (x *VerifiableEntry) GetVerifiableTx() *VerifiableTx {
+func (x *Signature) GetPublicKey() []byte {
 	if x != nil {
-		return x.VerifiableTx
+		return x.PublicKey
 	}
 	return nil
 }
 
-func (x *VerifiableEntry) GetInclusionProof() *InclusionProof {
+func (x *Signature) GetSignature() []byte {
 	if x != nil {
-		return x.InclusionProof
+		return x.Signature
 	}
 	return nil
 }
 
-type InclusionProof struct {
+type TxMetadata struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Leaf  int32    `protobuf:"varint,1,opt,name=leaf,proto3" json:"leaf,omitempty"`
-	Width int32    `protobuf:"varint,2,opt,name=width,proto3" json:"width,omitempty"`
-	Terms [][]byte `protobuf:"bytes,3,rep,name=terms,proto3" json:"terms,omitempty"`
+	Id         uint64        `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	PrevAlh    []byte        `protobuf:"bytes,2,opt,name=prevAlh,proto3" json:"prevAlh,omitempty"`
+	Ts         int64         `protobuf:"varint,3,opt,name=ts,proto3" json:"ts,omitempty"`
+	Nentries   int32         `protobuf:"varint,4,opt,name=nentries,proto3" json:"nentries,omitempty"`
+	EH         []byte        `protobuf:"bytes,5,opt,name=eH,proto3" json:"eH,omitempty"`
+	BlTxId     uint64        `protobuf:"varint,6,opt,name=blTxId,proto3" json:"blTxId,omitempty"`
+	BlRoot     []byte        `protobuf:"bytes,7,opt,name=blRoot,proto3" json:"blRoot,omitempty"`
+	Version    int32         `protobuf:"varint,8,opt,name=version,proto3" json:"version,omitempty"`
+	Attributes *TxAttributes `protobuf:"bytes,9,opt,name=attributes,proto3" json:"attributes,omitempty"`
 }
 
-func (x *InclusionProof) Reset() {
-	*x = InclusionProof{}
+func (x *TxMetadata) Reset() {
+	*x = TxMetadata{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1978,13 +2174,13 @@ func (x *InclusionProof) Reset() {
 	}
 }
 
-func (x *InclusionProof) String() string {
+func (x *TxMetadata) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*InclusionProof) ProtoMessage() {}
+func (*TxMetadata) ProtoMessage() {}
 
-func (x *InclusionProof) ProtoReflect() protoreflect.Message {
+func (x *TxMetadata) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1996,43 +2192,84 @@ func (x *InclusionProof) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use InclusionProof.ProtoReflect.Descriptor instead.
-func (*InclusionProof) Descriptor() ([]byte, []int) {
+// Deprecated: Use TxMetadata.ProtoReflect.Descriptor instead.
+func (*TxMetadata) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *InclusionProof) GetLeaf() int32 {
+func (x *TxMetadata) GetId() uint64 {
 	if x != nil {
-		return x.Leaf
+		return x.Id
 	}
 	return 0
 }
 
-func (x *InclusionProof) GetWidth() int32 {
+func (x *TxMetadata) GetPrevAlh() []byte {
 	if x != nil {
-		return x.Width
+		return x.PrevAlh
+	}
+	return nil
+}
+
+func (x *TxMetadata) GetTs() int64 {
+	if x != nil {
+		return x.Ts
 	}
 	return 0
 }
 
-func (x *InclusionProof) GetTerms() [][]byte {
+func (x *TxMetadata) GetNentries() int32 {
 	if x != nil {
-		return x.Terms
+		return x.Nentries
+	}
+	return 0
+}
+
+func (x *TxMetadata) GetEH() []byte {
+	if x != nil {
+		return x.EH
 	}
 	return nil
 }
 
-type SetRequest struct {
+func (x *TxMetadata) GetBlTxId() uint64 {
+	if x != nil {
+		return x.BlTxId
+	}
+	return 0
+}
+
+func (x *TxMetadata) GetBlRoot() []byte {
+	if x != nil {
+		return x.BlRoot
+	}
+	return nil
+}
+
+func (x *TxMetadata) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *TxMetadata) GetAttributes() *TxAttributes {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+type TxAttributes struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	KVs    []*KeyValue `protobuf:"bytes,1,rep,name=KVs,proto3" json:"KVs,omitempty"`
-	NoWait bool        `protobuf:"varint,2,opt,name=noWait,proto3" json:"noWait,omitempty"`
+	TraceID []byte `protobuf:"bytes,1,opt,name=traceID,proto3" json:"traceID,omitempty"`
 }
 
-func (x *SetRequest) Reset() {
-	*x = SetRequest{}
+func (x *TxAttributes) Reset() {
+	*x = TxAttributes{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2040,13 +2277,13 @@ func (x *SetRequest) Reset() {
 	}
 }
 
-func (x *SetRequest) String() string {
+func (x *TxAttributes) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetRequest) ProtoMessage() {}
+func (*TxAttributes) ProtoMessage() {}
 
-func (x *SetRequest) ProtoReflect() protoreflect.Message {
+func (x *TxAttributes) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2058,37 +2295,30 @@ func (x *SetRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetRequest.ProtoReflect.Descriptor instead.
-func (*SetRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use TxAttributes.ProtoReflect.Descriptor instead.
+func (*TxAttributes) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *SetRequest) GetKVs() []*KeyValue {
+func (x *TxAttributes) GetTraceID() []byte {
 	if x != nil {
-		return x.KVs
+		return x.TraceID
 	}
 	return nil
 }
 
-func (x *SetRequest) GetNoWait() bool {
-	if x != nil {
-		return x.NoWait
-	}
-	return false
-}
-
-type KeyRequest struct {
+type LinearProof struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Key     []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	AtTx    uint64 `protobuf:"varint,2,opt,name=atTx,proto3" json:"atTx,omitempty"`
-	SinceTx uint64 `protobuf:"varint,3,opt,name=sinceTx,proto3" json:"sinceTx,omitempty"`
+	SourceTxId uint64   `protobuf:"varint,1,opt,name=sourceTxId,proto3" json:"sourceTxId,omitempty"`
+	TargetTxId uint64   `protobuf:"varint,2,opt,name=TargetTxId,proto3" json:"TargetTxId,omitempty"`
+	Terms      [][]byte `protobuf:"bytes,3,rep,name=terms,proto3" json:"terms,omitempty"`
 }
 
-func (x *KeyRequest) Reset() {
-	*x = KeyRequest{}
+func (x *LinearProof) Reset() {
+	*x = LinearProof{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2096,13 +2326,13 @@ func (x *KeyRequest) Reset() {
 	}
 }
 
-func (x *KeyRequest) String() string {
+func (x *LinearProof) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*KeyRequest) ProtoMessage() {}
+func (*LinearProof) ProtoMessage() {}
 
-func (x *KeyRequest) ProtoReflect() protoreflect.Message {
+func (x *LinearProof) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2114,43 +2344,48 @@ func (x *KeyRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use KeyRequest.ProtoReflect.Descriptor instead.
-func (*KeyRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use LinearProof.ProtoReflect.Descriptor instead.
+func (*LinearProof) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *KeyRequest) GetKey() []byte {
+func (x *LinearProof) GetSourceTxId() uint64 {
 	if x != nil {
-		return x.Key
+		return x.SourceTxId
 	}
-	return nil
+	return 0
 }
 
-func (x *KeyRequest) GetAtTx() uint64 {
+func (x *LinearProof) GetTargetTxId() uint64 {
 	if x != nil {
-		return x.AtTx
+		return x.TargetTxId
 	}
 	return 0
 }
 
-func (x *KeyRequest) GetSinceTx() uint64 {
+func (x *LinearProof) GetTerms() [][]byte {
 	if x != nil {
-		return x.SinceTx
+		return x.Terms
 	}
-	return 0
+	return nil
 }
 
-type KeyListRequest struct {
+type DualProof struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Keys    [][]byte `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
-	SinceTx uint64   `protobuf:"varint,2,opt,name=sinceTx,proto3" json:"sinceTx,omitempty"`
+	SourceTxMetadata   *TxMetadata  `protobuf:"bytes,1,opt,name=sourceTxMetadata,proto3" json:"sourceTxMetadata,omitempty"`
+	TargetTxMetadata   *TxMetadata  `protobuf:"bytes,2,opt,name=targetTxMetadata,proto3" json:"targetTxMetadata,omitempty"`
+	InclusionProof     [][]byte     `protobuf:"bytes,3,rep,name=inclusionProof,proto3" json:"inclusionProof,omitempty"`
+	ConsistencyProof   [][]byte     `protobuf:"bytes,4,rep,name=consistencyProof,proto3" json:"consistencyProof,omitempty"`
+	TargetBlTxAlh      []byte       `protobuf:"bytes,5,opt,name=targetBlTxAlh,proto3" json:"targetBlTxAlh,omitempty"`
+	LastInclusionProof [][]byte     `protobuf:"bytes,6,rep,name=lastInclusionProof,proto3" json:"lastInclusionProof,omitempty"`
+	LinearProof        *LinearProof `protobuf:"bytes,7,opt,name=linearProof,proto3" json:"linearProof,omitempty"`
 }
 
-func (x *KeyListRequest) Reset() {
-	*x = KeyListRequest{}
+func (x *DualProof) Reset() {
+	*x = DualProof{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2158,13 +2393,13 @@ func (x *KeyListRequest) Reset() {
 	}
 }
 
-func (x *KeyListRequest) String() string {
+func (x *DualProof) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*KeyListRequest) ProtoMessage() {}
+func (*DualProof) ProtoMessage() {}
 
-func (x *KeyListRequest) ProtoReflect() protoreflect.Message {
+func (x *DualProof) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2176,36 +2411,71 @@ func (x *KeyListRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use KeyListRequest.ProtoReflect.Descriptor instead.
-func (*KeyListRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DualProof.ProtoReflect.Descriptor instead.
+func (*DualProof) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *KeyListRequest) GetKeys() [][]byte {
+func (x *DualProof) GetSourceTxMetadata() *TxMetadata {
 	if x != nil {
-		return x.Keys
+		return x.SourceTxMetadata
 	}
 	return nil
 }
 
-func (x *KeyListRequest) GetSinceTx() uint64 {
+func (x *DualProof) GetTargetTxMetadata() *TxMetadata {
 	if x != nil {
-		return x.SinceTx
+		return x.TargetTxMetadata
 	}
-	return 0
+	return nil
 }
 
-type VerifiableSetRequest struct {
+func (x *DualProof) GetInclusionProof() [][]byte {
+	if x != nil {
+		return x.InclusionProof
+	}
+	return nil
+}
+
+func (x *DualProof) GetConsistencyProof() [][]byte {
+	if x != nil {
+		return x.ConsistencyProof
+	}
+	return nil
+}
+
+func (x *DualProof) GetTargetBlTxAlh() []byte {
+	if x != nil {
+		return x.TargetBlTxAlh
+	}
+	return nil
+}
+
+func (x *DualProof) GetLastInclusionProof() [][]byte {
+	if x != nil {
+		return x.LastInclusionProof
+	}
+	return nil
+}
+
+func (x *DualProof) GetLinearProof() *LinearProof {
+	if x != nil {
+		return x.LinearProof
+	}
+	return nil
+}
+
+type Tx struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SetRequest   *SetRequest `protobuf:"bytes,1,opt,name=setRequest,proto3" json:"setRequest,omitempty"`
-	ProveSinceTx uint64      `protobuf:"varint,2,opt,name=proveSinceTx,proto3" json:"proveSinceTx,omitempty"`
+	Metadata *TxMetadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Entries  []*TxEntry  `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
 }
 
-func (x *VerifiableSetRequest) Reset() {
-	*x = VerifiableSetRequest{}
+func (x *Tx) Reset() {
+	*x = Tx{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2213,13 +2483,13 @@ func (x *VerifiableSetRequest) Reset() {
 	}
 }
 
-func (x *VerifiableSetRequest) String() string {
+func (x *Tx) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VerifiableSetRequest) ProtoMessage() {}
+func (*Tx) ProtoMessage() {}
 
-func (x *VerifiableSetRequest) ProtoReflect() protoreflect.Message {
+func (x *Tx) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2231,36 +2501,39 @@ func (x *VerifiableSetRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VerifiableSetRequest.ProtoReflect.Descriptor instead.
-func (*VerifiableSetRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use Tx.ProtoReflect.Descriptor instead.
+func (*Tx) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *VerifiableSetRequest) GetSetRequest() *SetRequest {
+func (x *Tx) GetMetadata() *TxMetadata {
 	if x != nil {
-		return x.SetRequest
+		return x.Metadata
 	}
 	return nil
 }
 
-func (x *VerifiableSetRequest) GetProveSinceTx() uint64 {
+func (x *Tx) GetEntries() []*TxEntry {
 	if x != nil {
-		return x.ProveSinceTx
+		return x.Entries
 	}
-	return 0
+	return nil
 }
 
-type VerifiableGetRequest struct {
+type TxEntry struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	KeyRequest   *KeyRequest `protobuf:"bytes,1,opt,name=keyRequest,proto3" json:"keyRequest,omitempty"`
-	ProveSinceTx uint64      `protobuf:"varint,2,opt,name=proveSinceTx,proto3" json:"proveSinceTx,omitempty"`
+	Key      []byte      `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	HValue   []byte      `protobuf:"bytes,2,opt,name=hValue,proto3" json:"hValue,omitempty"`
+	VOff     int64       `protobuf:"varint,3,opt,name=vOff,proto3" json:"vOff,omitempty"`
+	VLen     int32       `protobuf:"varint,4,opt,name=vLen,proto3" json:"vLen,omitempty"`
+	Metadata *KVMetadata `protobuf:"bytes,5,opt,name=metadata,proto3" json:"metadata,omitempty"`
 }
 
-func (x *VerifiableGetRequest) Reset() {
-	*x = VerifiableGetRequest{}
+func (x *TxEntry) Reset() {
+	*x = TxEntry{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[35]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2268,13 +2541,13 @@ func (x *VerifiableGetRequest) Reset() {
 	}
 }
 
-func (x *VerifiableGetRequest) String() string {
+func (x *TxEntry) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VerifiableGetRequest) ProtoMessage() {}
+func (*TxEntry) ProtoMessage() {}
 
-func (x *VerifiableGetRequest) ProtoReflect() protoreflect.Message {
+func (x *TxEntry) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[35]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2286,36 +2559,58 @@ func (x *VerifiableGetRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VerifiableGetRequest.ProtoReflect.Descriptor instead.
-func (*VerifiableGetRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use TxEntry.ProtoReflect.Descriptor instead.
+func (*TxEntry) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *VerifiableGetRequest) GetKeyRequest() *KeyRequest {
+func (x *TxEntry) GetKey() []byte {
 	if x != nil {
-		return x.KeyRequest
+		return x.Key
 	}
 	return nil
 }
 
-func (x *VerifiableGetRequest) GetProveSinceTx() uint64 {
+func (x *TxEntry) GetHValue() []byte {
 	if x != nil {
-		return x.ProveSinceTx
+		return x.HValue
+	}
+	return nil
+}
+
+func (x *TxEntry) GetVOff() int64 {
+	if x != nil {
+		return x.VOff
 	}
 	return 0
 }
 
-type HealthResponse struct {
+func (x *TxEntry) GetVLen() int32 {
+	if x != nil {
+		return x.VLen
+	}
+	return 0
+}
+
+func (x *TxEntry) GetMetadata() *KVMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type VerifiableTx struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status  bool   `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
-	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Tx        *Tx        `protobuf:"bytes,1,opt,name=tx,proto3" json:"tx,omitempty"`
+	DualProof *DualProof `protobuf:"bytes,2,opt,name=dualProof,proto3" json:"dualProof,omitempty"`
+	Signature *Signature `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
 }
 
-func (x *HealthResponse) Reset() {
-	*x = HealthResponse{}
+func (x *VerifiableTx) Reset() {
+	*x = VerifiableTx{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[36]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2323,13 +2618,13 @@ func (x *HealthResponse) Reset() {
 	}
 }
 
-func (x *HealthResponse) String() string {
+func (x *VerifiableTx) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HealthResponse) ProtoMessage() {}
+func (*VerifiableTx) ProtoMessage() {}
 
-func (x *HealthResponse) ProtoReflect() protoreflect.Message {
+func (x *VerifiableTx) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[36]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2341,38 +2636,44 @@ func (x *HealthResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HealthResponse.ProtoReflect.Descriptor instead.
-func (*HealthResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use VerifiableTx.ProtoReflect.Descriptor instead.
+func (*VerifiableTx) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *HealthResponse) GetStatus() bool {
+func (x *VerifiableTx) GetTx() *Tx {
 	if x != nil {
-		return x.Status
+		return x.Tx
 	}
-	return false
+	return nil
 }
 
-func (x *HealthResponse) GetVersion() string {
+func (x *VerifiableTx) GetDualProof() *DualProof {
 	if x != nil {
-		return x.Version
+		return x.DualProof
 	}
-	return ""
+	return nil
 }
 
-type ImmutableState struct {
+func (x *VerifiableTx) GetSignature() *Signature {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type VerifiableEntry struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Db        string     `protobuf:"bytes,1,opt,name=db,proto3" json:"db,omitempty"`
-	TxId      uint64     `protobuf:"varint,2,opt,name=txId,proto3" json:"txId,omitempty"`
-	TxHash    []byte     `protobuf:"bytes,3,opt,name=txHash,proto3" json:"txHash,omitempty"`
-	Signature *Signature `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	Entry          *Entry          `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	VerifiableTx   *VerifiableTx   `protobuf:"bytes,2,opt,name=verifiableTx,proto3" json:"verifiableTx,omitempty"`
+	InclusionProof *InclusionProof `protobuf:"bytes,3,opt,name=inclusionProof,proto3" json:"inclusionProof,omitempty"`
 }
 
-func (x *ImmutableState) Reset() {
-	*x = ImmutableState{}
+func (x *VerifiableEntry) Reset() {
+	*x = VerifiableEntry{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2380,13 +2681,13 @@ func (x *ImmutableState) Reset() {
 	}
 }
 
-func (x *ImmutableState) String() string {
+func (x *VerifiableEntry) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ImmutableState) ProtoMessage() {}
+func (*VerifiableEntry) ProtoMessage() {}
 
-func (x *ImmutableState) ProtoReflect() protoreflect.Message {
+func (x *VerifiableEntry) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2398,53 +2699,44 @@ func (x *ImmutableState) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ImmutableState.ProtoReflect.Descriptor instead.
-func (*ImmutableState) Descriptor() ([]byte, []int) {
+// Deprecated: Use VerifiableEntry.ProtoReflect.Descriptor instead.
+func (*VerifiableEntry) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *ImmutableState) GetDb() string {
-	if x != nil {
-		return x.Db
-	}
-	return ""
-}
-
-func (x *ImmutableState) GetTxId() uint64 {
+func (x *VerifiableEntry) GetEntry() *Entry {
 	if x != nil {
-		return x.TxId
+		return x.Entry
 	}
-	return 0
+	return nil
 }
 
-func (x *ImmutableState) GetTxHash() []byte {
+func (x *VerifiableEntry) GetVerifiableTx() *VerifiableTx {
 	if x != nil {
-		return x.TxHash
+		return x.VerifiableTx
 	}
 	return nil
 }
 
-func (x *ImmutableState) GetSignature() *Signature {
+func (x *VerifiableEntry) GetInclusionProof() *InclusionProof {
 	if x != nil {
-		return x.Signature
+		return x.InclusionProof
 	}
 	return nil
 }
 
-type ReferenceRequest struct {
+type InclusionProof struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Key           []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	ReferencedKey []byte `protobuf:"bytes,2,opt,name=referencedKey,proto3" json:"referencedKey,omitempty"`
-	AtTx          uint64 `protobuf:"varint,3,opt,name=atTx,proto3" json:"atTx,omitempty"`
-	BoundRef      bool   `protobuf:"varint,4,opt,name=boundRef,proto3" json:"boundRef,omitempty"`
-	NoWait        bool   `protobuf:"varint,5,opt,name=noWait,proto3" json:"noWait,omitempty"`
+	Leaf  int32    `protobuf:"varint,1,opt,name=leaf,proto3" json:"leaf,omitempty"`
+	Width int32    `protobuf:"varint,2,opt,name=width,proto3" json:"width,omitempty"`
+	Terms [][]byte `protobuf:"bytes,3,rep,name=terms,proto3" json:"terms,omitempty"`
 }
 
-func (x *ReferenceRequest) Reset() {
-	*x = ReferenceRequest{}
+func (x *InclusionProof) Reset() {
+	*x = InclusionProof{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[38]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2452,13 +2744,13 @@ func (x *ReferenceRequest) Reset() {
 	}
 }
 
-func (x *ReferenceRequest) String() string {
+func (x *InclusionProof) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReferenceRequest) ProtoMessage() {}
+func (*InclusionProof) ProtoMessage() {}
 
-func (x *ReferenceRequest) ProtoReflect() protoreflect.Message {
+func (x *InclusionProof) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[38]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2470,57 +2762,46 @@ func (x *ReferenceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReferenceRequest.ProtoReflect.Descriptor instead.
-func (*ReferenceRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use InclusionProof.ProtoReflect.Descriptor instead.
+func (*InclusionProof) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *ReferenceRequest) GetKey() []byte {
-	if x != nil {
-		return x.Key
-	}
-	return nil
-}
-
-func (x *ReferenceRequest) GetReferencedKey() []byte {
-	if x != nil {
-		return x.ReferencedKey
-	}
-	return nil
-}
-
-func (x *ReferenceRequest) GetAtTx() uint64 {
+func (x *InclusionProof) GetLeaf() int32 {
 	if x != nil {
-		return x.AtTx
+		return x.Leaf
 	}
 	return 0
 }
 
-func (x *ReferenceRequest) GetBoundRef() bool {
+func (x *InclusionProof) GetWidth() int32 {
 	if x != nil {
-		return x.BoundRef
+		return x.Width
 	}
-	return false
+	return 0
 }
 
-func (x *ReferenceRequest) GetNoWait() bool {
+func (x *InclusionProof) GetTerms() [][]byte {
 	if x != nil {
-		return x.NoWait
+		return x.Terms
 	}
-	return false
+	return nil
 }
 
-type VerifiableReferenceRequest struct {
+type SetRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ReferenceRequest *ReferenceRequest `protobuf:"bytes,1,opt,name=referenceRequest,proto3" json:"referenceRequest,omitempty"`
-	ProveSinceTx     uint64            `protobuf:"varint,2,opt,name=proveSinceTx,proto3" json:"proveSinceTx,omitempty"`
+	KVs           []*KeyValue     `protobuf:"bytes,1,rep,name=KVs,proto3" json:"KVs,omitempty"`
+	NoWait        bool            `protobuf:"varint,2,opt,name=noWait,proto3" json:"noWait,omitempty"`
+	Preconditions []*Precondition `protobuf:"bytes,3,rep,name=preconditions,proto3" json:"preconditions,omitempty"`
+	// W3C trace-id recorded into the tx attributes
+	TraceID []byte `protobuf:"bytes,4,opt,name=traceID,proto3" json:"traceID,omitempty"`
 }
 
-func (x *VerifiableReferenceRequest) Reset() {
-	*x = VerifiableReferenceRequest{}
+func (x *SetRequest) Reset() {
+	*x = SetRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[39]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2528,13 +2809,13 @@ func (x *VerifiableReferenceRequest) Reset() {
 	}
 }
 
-func (x *VerifiableReferenceRequest) String() string {
+func (x *SetRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VerifiableReferenceRequest) ProtoMessage() {}
+func (*SetRequest) ProtoMessage() {}
 
-func (x *VerifiableReferenceRequest) ProtoReflect() protoreflect.Message {
+func (x *SetRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[39]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2546,40 +2827,53 @@ func (x *VerifiableReferenceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VerifiableReferenceRequest.ProtoReflect.Descriptor instead.
-func (*VerifiableReferenceRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SetRequest.ProtoReflect.Descriptor instead.
+func (*SetRequest) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *VerifiableReferenceRequest) GetReferenceRequest() *ReferenceRequest {
+func (x *SetRequest) GetKVs() []*KeyValue {
 	if x != nil {
-		return x.ReferenceRequest
+		return x.KVs
 	}
 	return nil
 }
 
-func (x *VerifiableReferenceRequest) GetProveSinceTx() uint64 {
+func (x *SetRequest) GetNoWait() bool {
 	if x != nil {
-		return x.ProveSinceTx
+		return x.NoWait
 	}
-	return 0
+	return false
 }
 
-type ZAddRequest struct {
+func (x *SetRequest) GetPreconditions() []*Precondition {
+	if x != nil {
+		return x.Preconditions
+	}
+	return nil
+}
+
+func (x *SetRequest) GetTraceID() []byte {
+	if x != nil {
+		return x.TraceID
+	}
+	return nil
+}
+
+type Precondition struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Set      []byte  `protobuf:"bytes,1,opt,name=set,proto3" json:"set,omitempty"`
-	Score    float64 `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
-	Key      []byte  `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
-	AtTx     uint64  `protobuf:"varint,4,opt,name=atTx,proto3" json:"atTx,omitempty"`
-	BoundRef bool    `protobuf:"varint,5,opt,name=boundRef,proto3" json:"boundRef,omitempty"`
-	NoWait   bool    `protobuf:"varint,6,opt,name=noWait,proto3" json:"noWait,omitempty"`
+	// Types that are assignable to Precondition:
+	//	*Precondition_KeyMustNotExist
+	//	*Precondition_KeyMustHaveRevision
+	//	*Precondition_KeyNotModifiedAfterTX
+	Precondition isPrecondition_Precondition `protobuf_oneof:"precondition"`
 }
 
-func (x *ZAddRequest) Reset() {
-	*x = ZAddRequest{}
+func (x *Precondition) Reset() {
+	*x = Precondition{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[40]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2587,13 +2881,13 @@ func (x *ZAddRequest) Reset() {
 	}
 }
 
-func (x *ZAddRequest) String() string {
+func (x *Precondition) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ZAddRequest) ProtoMessage() {}
+func (*Precondition) ProtoMessage() {}
 
-func (x *ZAddRequest) ProtoReflect() protoreflect.Message {
+func (x *Precondition) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[40]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2605,63 +2899,74 @@ func (x *ZAddRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ZAddRequest.ProtoReflect.Descriptor instead.
-func (*ZAddRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use Precondition.ProtoReflect.Descriptor instead.
+func (*Precondition) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{40}
 }
 
-func (x *ZAddRequest) GetSet() []byte {
-	if x != nil {
-		return x.Set
+func (m *Precondition) GetPrecondition() isPrecondition_Precondition {
+	if m != nil {
+		return m.Precondition
 	}
 	return nil
 }
 
-func (x *ZAddRequest) GetScore() float64 {
-	if x != nil {
-		return x.Score
+func (x *Precondition) GetKeyMustNotExist() *Precondition_KeyMustNotExistPrecondition {
+	if x, ok := x.GetPrecondition().(*Precondition_KeyMustNotExist); ok {
+		return x.KeyMustNotExist
 	}
-	return 0
+	return nil
 }
 
-func (x *ZAddRequest) GetKey() []byte {
-	if x != nil {
-		return x.Key
+func (x *Precondition) GetKeyMustHaveRevision() *Precondition_KeyMustHaveRevisionPrecondition {
+	if x, ok := x.GetPrecondition().(*Precondition_KeyMustHaveRevision); ok {
+		return x.KeyMustHaveRevision
 	}
 	return nil
 }
 
-func (x *ZAddRequest) GetAtTx() uint64 {
-	if x != nil {
-		return x.AtTx
+func (x *Precondition) GetKeyNotModifiedAfterTX() *Precondition_KeyNotModifiedAfterTXPrecondition {
+	if x, ok := x.GetPrecondition().(*Precondition_KeyNotModifiedAfterTX); ok {
+		return x.KeyNotModifiedAfterTX
 	}
-	return 0
+	return nil
 }
 
-func (x *ZAddRequest) GetBoundRef() bool {
-	if x != nil {
-		return x.BoundRef
-	}
-	return false
+type isPrecondition_Precondition interface {
+	isPrecondition_Precondition()
 }
 
-func (x *ZAddRequest) GetNoWait() bool {
-	if x != nil {
-		return x.NoWait
-	}
-	return false
+type Precondition_KeyMustNotExist struct {
+	KeyMustNotExist *Precondition_KeyMustNotExistPrecondition `protobuf:"bytes,1,opt,name=keyMustNotExist,proto3,oneof"`
 }
 
-type Score struct {
+type Precondition_KeyMustHaveRevision struct {
+	KeyMustHaveRevision *Precondition_KeyMustHaveRevisionPrecondition `protobuf:"bytes,2,opt,name=keyMustHaveRevision,proto3,oneof"`
+}
+
+type Precondition_KeyNotModifiedAfterTX struct {
+	KeyNotModifiedAfterTX *Precondition_KeyNotModifiedAfterTXPrecondition `protobuf:"bytes,3,opt,name=keyNotModifiedAfterTX,proto3,oneof"`
+}
+
+func (*Precondition_KeyMustNotExist) isPrecondition_Precondition() {}
+
+func (*Precondition_KeyMustHaveRevision) isPrecondition_Precondition() {}
+
+func (*Precondition_KeyNotModifiedAfterTX) isPrecondition_Precondition() {}
+
+type KeyRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Score float64 `protobuf:"fixed64,1,opt,name=score,proto3" json:"score,omitempty"`
+	Key     []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	AtTx    uint64 `protobuf:"varint,2,opt,name=atTx,proto3" json:"atTx,omitempty"`
+	SinceTx uint64 `protobuf:"varint,3,opt,name=sinceTx,proto3" json:"sinceTx,omitempty"`
+	AsOfTs  int64  `protobuf:"varint,4,opt,name=asOfTs,proto3" json:"asOfTs,omitempty"`
 }
 
-func (x *Score) Reset() {
-	*x = Score{}
+func (x *KeyRequest) Reset() {
+	*x = KeyRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[41]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2669,13 +2974,13 @@ func (x *Score) Reset() {
 	}
 }
 
-func (x *Score) String() string {
+func (x *KeyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Score) ProtoMessage() {}
+func (*KeyRequest) ProtoMessage() {}
 
-func (x *Score) ProtoReflect() protoreflect.Message {
+func (x *KeyRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[41]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2687,38 +2992,50 @@ func (x *Score) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Score.ProtoReflect.Descriptor instead.
-func (*Score) Descriptor() ([]byte, []int) {
+// Deprecated: Use KeyRequest.ProtoReflect.Descriptor instead.
+func (*KeyRequest) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{41}
 }
 
-func (x *Score) GetScore() float64 {
+func (x *KeyRequest) GetKey() []byte {
 	if x != nil {
-		return x.Score
+		return x.Key
+	}
+	return nil
+}
+
+func (x *KeyRequest) GetAtTx() uint64 {
+	if x != nil {
+		return x.AtTx
 	}
 	return 0
 }
 
-type ZScanRequest struct {
+func (x *KeyRequest) GetSinceTx() uint64 {
+	if x != nil {
+		return x.SinceTx
+	}
+	return 0
+}
+
+func (x *KeyRequest) GetAsOfTs() int64 {
+	if x != nil {
+		return x.AsOfTs
+	}
+	return 0
+}
+
+type KeyListRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Set           []byte  `protobuf:"bytes,1,opt,name=set,proto3" json:"set,omitempty"`
-	SeekKey       []byte  `protobuf:"bytes,2,opt,name=seekKey,proto3" json:"seekKey,omitempty"`
-	SeekScore     float64 `protobuf:"fixed64,3,opt,name=seekScore,proto3" json:"seekScore,omitempty"`
-	SeekAtTx      uint64  `protobuf:"varint,4,opt,name=seekAtTx,proto3" json:"seekAtTx,omitempty"`
-	InclusiveSeek bool    `protobuf:"varint,5,opt,name=inclusiveSeek,proto3" json:"inclusiveSeek,omitempty"`
-	Limit         uint64  `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
-	Desc          bool    `protobuf:"varint,7,opt,name=desc,proto3" json:"desc,omitempty"`
-	MinScore      *Score  `protobuf:"bytes,8,opt,name=minScore,proto3" json:"minScore,omitempty"`
-	MaxScore      *Score  `protobuf:"bytes,9,opt,name=maxScore,proto3" json:"maxScore,omitempty"`
-	SinceTx       uint64  `protobuf:"varint,10,opt,name=sinceTx,proto3" json:"sinceTx,omitempty"`
-	NoWait        bool    `protobuf:"varint,11,opt,name=noWait,proto3" json:"noWait,omitempty"`
+	Keys    [][]byte `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	SinceTx uint64   `protobuf:"varint,2,opt,name=sinceTx,proto3" json:"sinceTx,omitempty"`
 }
 
-func (x *ZScanRequest) Reset() {
-	*x = ZScanRequest{}
+func (x *KeyListRequest) Reset() {
+	*x = KeyListRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[42]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2726,13 +3043,13 @@ func (x *ZScanRequest) Reset() {
 	}
 }
 
-func (x *ZScanRequest) String() string {
+func (x *KeyListRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ZScanRequest) ProtoMessage() {}
+func (*KeyListRequest) ProtoMessage() {}
 
-func (x *ZScanRequest) ProtoReflect() protoreflect.Message {
+func (x *KeyListRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[42]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2744,102 +3061,36 @@ func (x *ZScanRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ZScanRequest.ProtoReflect.Descriptor instead.
-func (*ZScanRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use KeyListRequest.ProtoReflect.Descriptor instead.
+func (*KeyListRequest) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *ZScanRequest) GetSet() []byte {
-	if x != nil {
-		return x.Set
-	}
-	return nil
-}
-
-func (x *ZScanRequest) GetSeekKey() []byte {
-	if x != nil {
-		return x.SeekKey
-	}
-	return nil
-}
-
-func (x *ZScanRequest) GetSeekScore() float64 {
-	if x != nil {
-		return x.SeekScore
-	}
-	return 0
-}
-
-func (x *ZScanRequest) GetSeekAtTx() uint64 {
-	if x != nil {
-		return x.SeekAtTx
-	}
-	return 0
-}
-
-func (x *ZScanRequest) GetInclusiveSeek() bool {
-	if x != nil {
-		return x.InclusiveSeek
-	}
-	return false
-}
-
-func (x *ZScanRequest) GetLimit() uint64 {
-	if x != nil {
-		return x.Limit
-	}
-	return 0
-}
-
-func (x *ZScanRequest) GetDesc() bool {
-	if x != nil {
-		return x.Desc
-	}
-	return false
-}
-
-func (x *ZScanRequest) GetMinScore() *Score {
-	if x != nil {
-		return x.MinScore
-	}
-	return nil
-}
-
-func (x *ZScanRequest) GetMaxScore() *Score {
+func (x *KeyListRequest) GetKeys() [][]byte {
 	if x != nil {
-		return x.MaxScore
+		return x.Keys
 	}
 	return nil
 }
 
-func (x *ZScanRequest) GetSinceTx() uint64 {
+func (x *KeyListRequest) GetSinceTx() uint64 {
 	if x != nil {
 		return x.SinceTx
 	}
 	return 0
 }
 
-func (x *ZScanRequest) GetNoWait() bool {
-	if x != nil {
-		return x.NoWait
-	}
-	return false
-}
-
-type HistoryRequest struct {
+type VerifiableSetRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Key     []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Offset  uint64 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
-	Limit   int32  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
-	Desc    bool   `protobuf:"varint,4,opt,name=desc,proto3" json:"desc,omitempty"`
-	SinceTx uint64 `protobuf:"varint,5,opt,name=sinceTx,proto3" json:"sinceTx,omitempty"`
+	SetRequest   *SetRequest `protobuf:"bytes,1,opt,name=setRequest,proto3" json:"setRequest,omitempty"`
+	ProveSinceTx uint64      `protobuf:"varint,2,opt,name=proveSinceTx,proto3" json:"proveSinceTx,omitempty"`
 }
 
-func (x *HistoryRequest) Reset() {
-	*x = HistoryRequest{}
+func (x *VerifiableSetRequest) Reset() {
+	*x = VerifiableSetRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[43]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2847,13 +3098,13 @@ func (x *HistoryRequest) Reset() {
 	}
 }
 
-func (x *HistoryRequest) String() string {
+func (x *VerifiableSetRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HistoryRequest) ProtoMessage() {}
+func (*VerifiableSetRequest) ProtoMessage() {}
 
-func (x *HistoryRequest) ProtoReflect() protoreflect.Message {
+func (x *VerifiableSetRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[43]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2865,57 +3116,36 @@ func (x *HistoryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HistoryRequest.ProtoReflect.Descriptor instead.
-func (*HistoryRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use VerifiableSetRequest.ProtoReflect.Descriptor instead.
+func (*VerifiableSetRequest) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{43}
 }
 
-func (x *HistoryRequest) GetKey() []byte {
+func (x *VerifiableSetRequest) GetSetRequest() *SetRequest {
 	if x != nil {
-		return x.Key
+		return x.SetRequest
 	}
 	return nil
 }
 
-func (x *HistoryRequest) GetOffset() uint64 {
-	if x != nil {
-		return x.Offset
-	}
-	return 0
-}
-
-func (x *HistoryRequest) GetLimit() int32 {
-	if x != nil {
-		return x.Limit
-	}
-	return 0
-}
-
-func (x *HistoryRequest) GetDesc() bool {
-	if x != nil {
-		return x.Desc
-	}
-	return false
-}
-
-func (x *HistoryRequest) GetSinceTx() uint64 {
+func (x *VerifiableSetRequest) GetProveSinceTx() uint64 {
 	if x != nil {
-		return x.SinceTx
+		return x.ProveSinceTx
 	}
 	return 0
 }
 
-type VerifiableZAddRequest struct {
+type VerifiableGetRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ZAddRequest  *ZAddRequest `protobuf:"bytes,1,opt,name=zAddRequest,proto3" json:"zAddRequest,omitempty"`
-	ProveSinceTx uint64       `protobuf:"varint,2,opt,name=proveSinceTx,proto3" json:"proveSinceTx,omitempty"`
+	KeyRequest   *KeyRequest `protobuf:"bytes,1,opt,name=keyRequest,proto3" json:"keyRequest,omitempty"`
+	ProveSinceTx uint64      `protobuf:"varint,2,opt,name=proveSinceTx,proto3" json:"proveSinceTx,omitempty"`
 }
 
-func (x *VerifiableZAddRequest) Reset() {
-	*x = VerifiableZAddRequest{}
+func (x *VerifiableGetRequest) Reset() {
+	*x = VerifiableGetRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[44]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2923,13 +3153,13 @@ func (x *VerifiableZAddRequest) Reset() {
 	}
 }
 
-func (x *VerifiableZAddRequest) String() string {
+func (x *VerifiableGetRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VerifiableZAddRequest) ProtoMessage() {}
+func (*VerifiableGetRequest) ProtoMessage() {}
 
-func (x *VerifiableZAddRequest) ProtoReflect() protoreflect.Message {
+func (x *VerifiableGetRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[44]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2941,35 +3171,36 @@ func (x *VerifiableZAddRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VerifiableZAddRequest.ProtoReflect.Descriptor instead.
-func (*VerifiableZAddRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use VerifiableGetRequest.ProtoReflect.Descriptor instead.
+func (*VerifiableGetRequest) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{44}
 }
 
-func (x *VerifiableZAddRequest) GetZAddRequest() *ZAddRequest {
+func (x *VerifiableGetRequest) GetKeyRequest() *KeyRequest {
 	if x != nil {
-		return x.ZAddRequest
+		return x.KeyRequest
 	}
 	return nil
 }
 
-func (x *VerifiableZAddRequest) GetProveSinceTx() uint64 {
+func (x *VerifiableGetRequest) GetProveSinceTx() uint64 {
 	if x != nil {
 		return x.ProveSinceTx
 	}
 	return 0
 }
 
-type TxRequest struct {
+type VerifiableHistoricalGetRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Tx uint64 `protobuf:"varint,1,opt,name=tx,proto3" json:"tx,omitempty"`
+	Key  []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	AtTx uint64 `protobuf:"varint,2,opt,name=atTx,proto3" json:"atTx,omitempty"`
 }
 
-func (x *TxRequest) Reset() {
-	*x = TxRequest{}
+func (x *VerifiableHistoricalGetRequest) Reset() {
+	*x = VerifiableHistoricalGetRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[45]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2977,13 +3208,13 @@ func (x *TxRequest) Reset() {
 	}
 }
 
-func (x *TxRequest) String() string {
+func (x *VerifiableHistoricalGetRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TxRequest) ProtoMessage() {}
+func (*VerifiableHistoricalGetRequest) ProtoMessage() {}
 
-func (x *TxRequest) ProtoReflect() protoreflect.Message {
+func (x *VerifiableHistoricalGetRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[45]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2995,29 +3226,36 @@ func (x *TxRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TxRequest.ProtoReflect.Descriptor instead.
-func (*TxRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use VerifiableHistoricalGetRequest.ProtoReflect.Descriptor instead.
+func (*VerifiableHistoricalGetRequest) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *TxRequest) GetTx() uint64 {
+func (x *VerifiableHistoricalGetRequest) GetKey() []byte {
 	if x != nil {
-		return x.Tx
+		return x.Key
+	}
+	return nil
+}
+
+func (x *VerifiableHistoricalGetRequest) GetAtTx() uint64 {
+	if x != nil {
+		return x.AtTx
 	}
 	return 0
 }
 
-type VerifiableTxRequest struct {
+type HealthResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Tx           uint64 `protobuf:"varint,1,opt,name=tx,proto3" json:"tx,omitempty"`
-	ProveSinceTx uint64 `protobuf:"varint,2,opt,name=proveSinceTx,proto3" json:"proveSinceTx,omitempty"`
+	Status  bool   `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
 }
 
-func (x *VerifiableTxRequest) Reset() {
-	*x = VerifiableTxRequest{}
+func (x *HealthResponse) Reset() {
+	*x = HealthResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[46]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3025,13 +3263,13 @@ func (x *VerifiableTxRequest) Reset() {
 	}
 }
 
-func (x *VerifiableTxRequest) String() string {
+func (x *HealthResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VerifiableTxRequest) ProtoMessage() {}
+func (*HealthResponse) ProtoMessage() {}
 
-func (x *VerifiableTxRequest) ProtoReflect() protoreflect.Message {
+func (x *HealthResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[46]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3043,37 +3281,33 @@ func (x *VerifiableTxRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VerifiableTxRequest.ProtoReflect.Descriptor instead.
-func (*VerifiableTxRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use HealthResponse.ProtoReflect.Descriptor instead.
+func (*HealthResponse) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{46}
 }
 
-func (x *VerifiableTxRequest) GetTx() uint64 {
+func (x *HealthResponse) GetStatus() bool {
 	if x != nil {
-		return x.Tx
+		return x.Status
 	}
-	return 0
+	return false
 }
 
-func (x *VerifiableTxRequest) GetProveSinceTx() uint64 {
+func (x *HealthResponse) GetVersion() string {
 	if x != nil {
-		return x.ProveSinceTx
+		return x.Version
 	}
-	return 0
+	return ""
 }
 
-type TxScanRequest struct {
+type ServerInfoRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	InitialTx uint64 `protobuf:"varint,1,opt,name=initialTx,proto3" json:"initialTx,omitempty"`
-	Limit     uint32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	Desc      bool   `protobuf:"varint,3,opt,name=desc,proto3" json:"desc,omitempty"`
 }
 
-func (x *TxScanRequest) Reset() {
-	*x = TxScanRequest{}
+func (x *ServerInfoRequest) Reset() {
+	*x = ServerInfoRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[47]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3081,13 +3315,13 @@ func (x *TxScanRequest) Reset() {
 	}
 }
 
-func (x *TxScanRequest) String() string {
+func (x *ServerInfoRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TxScanRequest) ProtoMessage() {}
+func (*ServerInfoRequest) ProtoMessage() {}
 
-func (x *TxScanRequest) ProtoReflect() protoreflect.Message {
+func (x *ServerInfoRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[47]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3099,42 +3333,22 @@ func (x *TxScanRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TxScanRequest.ProtoReflect.Descriptor instead.
-func (*TxScanRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ServerInfoRequest.ProtoReflect.Descriptor instead.
+func (*ServerInfoRequest) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *TxScanRequest) GetInitialTx() uint64 {
-	if x != nil {
-		return x.InitialTx
-	}
-	return 0
-}
-
-func (x *TxScanRequest) GetLimit() uint32 {
-	if x != nil {
-		return x.Limit
-	}
-	return 0
-}
-
-func (x *TxScanRequest) GetDesc() bool {
-	if x != nil {
-		return x.Desc
-	}
-	return false
-}
-
-type TxList struct {
+type ServerInfoResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Txs []*Tx `protobuf:"bytes,1,rep,name=txs,proto3" json:"txs,omitempty"`
+	Version  string           `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Features []*ServerFeature `protobuf:"bytes,2,rep,name=features,proto3" json:"features,omitempty"`
 }
 
-func (x *TxList) Reset() {
-	*x = TxList{}
+func (x *ServerInfoResponse) Reset() {
+	*x = ServerInfoResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[48]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3142,13 +3356,13 @@ func (x *TxList) Reset() {
 	}
 }
 
-func (x *TxList) String() string {
+func (x *ServerInfoResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TxList) ProtoMessage() {}
+func (*ServerInfoResponse) ProtoMessage() {}
 
-func (x *TxList) ProtoReflect() protoreflect.Message {
+func (x *ServerInfoResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[48]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3160,28 +3374,37 @@ func (x *TxList) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TxList.ProtoReflect.Descriptor instead.
-func (*TxList) Descriptor() ([]byte, []int) {
+// Deprecated: Use ServerInfoResponse.ProtoReflect.Descriptor instead.
+func (*ServerInfoResponse) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *TxList) GetTxs() []*Tx {
+func (x *ServerInfoResponse) GetVersion() string {
 	if x != nil {
-		return x.Txs
+		return x.Version
+	}
+	return ""
+}
+
+func (x *ServerInfoResponse) GetFeatures() []*ServerFeature {
+	if x != nil {
+		return x.Features
 	}
 	return nil
 }
 
-type Database struct {
+type ServerFeature struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	DatabaseName string `protobuf:"bytes,1,opt,name=databaseName,proto3" json:"databaseName,omitempty"`
+	Name         string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Enabled      bool   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Experimental bool   `protobuf:"varint,3,opt,name=experimental,proto3" json:"experimental,omitempty"`
 }
 
-func (x *Database) Reset() {
-	*x = Database{}
+func (x *ServerFeature) Reset() {
+	*x = ServerFeature{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[49]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3189,13 +3412,13 @@ func (x *Database) Reset() {
 	}
 }
 
-func (x *Database) String() string {
+func (x *ServerFeature) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Database) ProtoMessage() {}
+func (*ServerFeature) ProtoMessage() {}
 
-func (x *Database) ProtoReflect() protoreflect.Message {
+func (x *ServerFeature) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[49]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3207,28 +3430,45 @@ func (x *Database) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Database.ProtoReflect.Descriptor instead.
-func (*Database) Descriptor() ([]byte, []int) {
+// Deprecated: Use ServerFeature.ProtoReflect.Descriptor instead.
+func (*ServerFeature) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{49}
 }
 
-func (x *Database) GetDatabaseName() string {
+func (x *ServerFeature) GetName() string {
 	if x != nil {
-		return x.DatabaseName
+		return x.Name
 	}
 	return ""
 }
 
-type Table struct {
+func (x *ServerFeature) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *ServerFeature) GetExperimental() bool {
+	if x != nil {
+		return x.Experimental
+	}
+	return false
+}
+
+type ImmutableState struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TableName string `protobuf:"bytes,1,opt,name=tableName,proto3" json:"tableName,omitempty"`
+	Db        string     `protobuf:"bytes,1,opt,name=db,proto3" json:"db,omitempty"`
+	TxId      uint64     `protobuf:"varint,2,opt,name=txId,proto3" json:"txId,omitempty"`
+	TxHash    []byte     `protobuf:"bytes,3,opt,name=txHash,proto3" json:"txHash,omitempty"`
+	Signature *Signature `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
 }
 
-func (x *Table) Reset() {
-	*x = Table{}
+func (x *ImmutableState) Reset() {
+	*x = ImmutableState{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[50]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3236,13 +3476,13 @@ func (x *Table) Reset() {
 	}
 }
 
-func (x *Table) String() string {
+func (x *ImmutableState) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Table) ProtoMessage() {}
+func (*ImmutableState) ProtoMessage() {}
 
-func (x *Table) ProtoReflect() protoreflect.Message {
+func (x *ImmutableState) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[50]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3254,31 +3494,55 @@ func (x *Table) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Table.ProtoReflect.Descriptor instead.
-func (*Table) Descriptor() ([]byte, []int) {
+// Deprecated: Use ImmutableState.ProtoReflect.Descriptor instead.
+func (*ImmutableState) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{50}
 }
 
-func (x *Table) GetTableName() string {
+func (x *ImmutableState) GetDb() string {
 	if x != nil {
-		return x.TableName
+		return x.Db
 	}
 	return ""
 }
 
-type SQLGetRequest struct {
+func (x *ImmutableState) GetTxId() uint64 {
+	if x != nil {
+		return x.TxId
+	}
+	return 0
+}
+
+func (x *ImmutableState) GetTxHash() []byte {
+	if x != nil {
+		return x.TxHash
+	}
+	return nil
+}
+
+func (x *ImmutableState) GetSignature() *Signature {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type ReferenceRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Table   string    `protobuf:"bytes,1,opt,name=table,proto3" json:"table,omitempty"`
-	PkValue *SQLValue `protobuf:"bytes,2,opt,name=pkValue,proto3" json:"pkValue,omitempty"`
-	AtTx    uint64    `protobuf:"varint,3,opt,name=atTx,proto3" json:"atTx,omitempty"`
-	SinceTx uint64    `protobuf:"varint,4,opt,name=sinceTx,proto3" json:"sinceTx,omitempty"`
+	Key           []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	ReferencedKey []byte `protobuf:"bytes,2,opt,name=referencedKey,proto3" json:"referencedKey,omitempty"`
+	AtTx          uint64 `protobuf:"varint,3,opt,name=atTx,proto3" json:"atTx,omitempty"`
+	BoundRef      bool   `protobuf:"varint,4,opt,name=boundRef,proto3" json:"boundRef,omitempty"`
+	NoWait        bool   `protobuf:"varint,5,opt,name=noWait,proto3" json:"noWait,omitempty"`
+	// W3C trace-id recorded into the tx attributes
+	TraceID []byte `protobuf:"bytes,6,opt,name=traceID,proto3" json:"traceID,omitempty"`
 }
 
-func (x *SQLGetRequest) Reset() {
-	*x = SQLGetRequest{}
+func (x *ReferenceRequest) Reset() {
+	*x = ReferenceRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[51]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3286,13 +3550,13 @@ func (x *SQLGetRequest) Reset() {
 	}
 }
 
-func (x *SQLGetRequest) String() string {
+func (x *ReferenceRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SQLGetRequest) ProtoMessage() {}
+func (*ReferenceRequest) ProtoMessage() {}
 
-func (x *SQLGetRequest) ProtoReflect() protoreflect.Message {
+func (x *ReferenceRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[51]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3304,50 +3568,64 @@ func (x *SQLGetRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SQLGetRequest.ProtoReflect.Descriptor instead.
-func (*SQLGetRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReferenceRequest.ProtoReflect.Descriptor instead.
+func (*ReferenceRequest) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{51}
 }
 
-func (x *SQLGetRequest) GetTable() string {
+func (x *ReferenceRequest) GetKey() []byte {
 	if x != nil {
-		return x.Table
+		return x.Key
 	}
-	return ""
+	return nil
 }
 
-func (x *SQLGetRequest) GetPkValue() *SQLValue {
+func (x *ReferenceRequest) GetReferencedKey() []byte {
 	if x != nil {
-		return x.PkValue
+		return x.ReferencedKey
 	}
 	return nil
 }
 
-func (x *SQLGetRequest) GetAtTx() uint64 {
+func (x *ReferenceRequest) GetAtTx() uint64 {
 	if x != nil {
 		return x.AtTx
 	}
 	return 0
 }
 
-func (x *SQLGetRequest) GetSinceTx() uint64 {
+func (x *ReferenceRequest) GetBoundRef() bool {
 	if x != nil {
-		return x.SinceTx
+		return x.BoundRef
 	}
-	return 0
+	return false
 }
 
-type VerifiableSQLGetRequest struct {
+func (x *ReferenceRequest) GetNoWait() bool {
+	if x != nil {
+		return x.NoWait
+	}
+	return false
+}
+
+func (x *ReferenceRequest) GetTraceID() []byte {
+	if x != nil {
+		return x.TraceID
+	}
+	return nil
+}
+
+type VerifiableReferenceRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SqlGetRequest *SQLGetRequest `protobuf:"bytes,1,opt,name=sqlGetRequest,proto3" json:"sqlGetRequest,omitempty"`
-	ProveSinceTx  uint64         `protobuf:"varint,2,opt,name=proveSinceTx,proto3" json:"proveSinceTx,omitempty"`
+	ReferenceRequest *ReferenceRequest `protobuf:"bytes,1,opt,name=referenceRequest,proto3" json:"referenceRequest,omitempty"`
+	ProveSinceTx     uint64            `protobuf:"varint,2,opt,name=proveSinceTx,proto3" json:"proveSinceTx,omitempty"`
 }
 
-func (x *VerifiableSQLGetRequest) Reset() {
-	*x = VerifiableSQLGetRequest{}
+func (x *VerifiableReferenceRequest) Reset() {
+	*x = VerifiableReferenceRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[52]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3355,13 +3633,13 @@ func (x *VerifiableSQLGetRequest) Reset() {
 	}
 }
 
-func (x *VerifiableSQLGetRequest) String() string {
+func (x *VerifiableReferenceRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VerifiableSQLGetRequest) ProtoMessage() {}
+func (*VerifiableReferenceRequest) ProtoMessage() {}
 
-func (x *VerifiableSQLGetRequest) ProtoReflect() protoreflect.Message {
+func (x *VerifiableReferenceRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[52]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3373,37 +3651,44 @@ func (x *VerifiableSQLGetRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VerifiableSQLGetRequest.ProtoReflect.Descriptor instead.
-func (*VerifiableSQLGetRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use VerifiableReferenceRequest.ProtoReflect.Descriptor instead.
+func (*VerifiableReferenceRequest) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{52}
 }
 
-func (x *VerifiableSQLGetRequest) GetSqlGetRequest() *SQLGetRequest {
+func (x *VerifiableReferenceRequest) GetReferenceRequest() *ReferenceRequest {
 	if x != nil {
-		return x.SqlGetRequest
+		return x.ReferenceRequest
 	}
 	return nil
 }
 
-func (x *VerifiableSQLGetRequest) GetProveSinceTx() uint64 {
+func (x *VerifiableReferenceRequest) GetProveSinceTx() uint64 {
 	if x != nil {
 		return x.ProveSinceTx
 	}
 	return 0
 }
 
-type SQLEntry struct {
+type ZAddRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Tx    uint64 `protobuf:"varint,1,opt,name=tx,proto3" json:"tx,omitempty"`
-	Key   []byte `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
-	Value []byte `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	Set      []byte  `protobuf:"bytes,1,opt,name=set,proto3" json:"set,omitempty"`
+	Score    float64 `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	Key      []byte  `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	AtTx     uint64  `protobuf:"varint,4,opt,name=atTx,proto3" json:"atTx,omitempty"`
+	BoundRef bool    `protobuf:"varint,5,opt,name=boundRef,proto3" json:"boundRef,omitempty"`
+	NoWait   bool    `protobuf:"varint,6,opt,name=noWait,proto3" json:"noWait,omitempty"`
+	// W3C trace-id recorded into the tx attributes, ignored within ExecAll operations
+	TraceID []byte `protobuf:"bytes,7,opt,name=traceID,proto3" json:"traceID,omitempty"`
+	// score is assigned by the server when not CLIENT_SCORE, score must then be left unset
+	ScoreMode ZAddScoreMode `protobuf:"varint,8,opt,name=scoreMode,proto3,enum=immudb.schema.ZAddScoreMode" json:"scoreMode,omitempty"`
 }
 
-func (x *SQLEntry) Reset() {
-	*x = SQLEntry{}
+func (x *ZAddRequest) Reset() {
+	*x = ZAddRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[53]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3411,13 +3696,13 @@ func (x *SQLEntry) Reset() {
 	}
 }
 
-func (x *SQLEntry) String() string {
+func (x *ZAddRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SQLEntry) ProtoMessage() {}
+func (*ZAddRequest) ProtoMessage() {}
 
-func (x *SQLEntry) ProtoReflect() protoreflect.Message {
+func (x *ZAddRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[53]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3429,50 +3714,77 @@ func (x *SQLEntry) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SQLEntry.ProtoReflect.Descriptor instead.
-func (*SQLEntry) Descriptor() ([]byte, []int) {
+// Deprecated: Use ZAddRequest.ProtoReflect.Descriptor instead.
+func (*ZAddRequest) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{53}
 }
 
-func (x *SQLEntry) GetTx() uint64 {
+func (x *ZAddRequest) GetSet() []byte {
 	if x != nil {
-		return x.Tx
+		return x.Set
 	}
-	return 0
+	return nil
 }
 
-func (x *SQLEntry) GetKey() []byte {
+func (x *ZAddRequest) GetScore() float64 {
 	if x != nil {
-		return x.Key
+		return x.Score
+	}
+	return 0
+}
+
+func (x *ZAddRequest) GetKey() []byte {
+	if x != nil {
+		return x.Key
 	}
 	return nil
 }
 
-func (x *SQLEntry) GetValue() []byte {
+func (x *ZAddRequest) GetAtTx() uint64 {
 	if x != nil {
-		return x.Value
+		return x.AtTx
+	}
+	return 0
+}
+
+func (x *ZAddRequest) GetBoundRef() bool {
+	if x != nil {
+		return x.BoundRef
+	}
+	return false
+}
+
+func (x *ZAddRequest) GetNoWait() bool {
+	if x != nil {
+		return x.NoWait
+	}
+	return false
+}
+
+func (x *ZAddRequest) GetTraceID() []byte {
+	if x != nil {
+		return x.TraceID
 	}
 	return nil
 }
 
-type VerifiableSQLEntry struct {
+func (x *ZAddRequest) GetScoreMode() ZAddScoreMode {
+	if x != nil {
+		return x.ScoreMode
+	}
+	return ZAddScoreMode_CLIENT_SCORE
+}
+
+type Score struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SqlEntry       *SQLEntry         `protobuf:"bytes,1,opt,name=sqlEntry,proto3" json:"sqlEntry,omitempty"`
-	VerifiableTx   *VerifiableTx     `protobuf:"bytes,2,opt,name=verifiableTx,proto3" json:"verifiableTx,omitempty"`
-	InclusionProof *InclusionProof   `protobuf:"bytes,3,opt,name=inclusionProof,proto3" json:"inclusionProof,omitempty"`
-	DatabaseId     uint64            `protobuf:"varint,4,opt,name=DatabaseId,proto3" json:"DatabaseId,omitempty"`
-	TableId        uint64            `protobuf:"varint,5,opt,name=TableId,proto3" json:"TableId,omitempty"`
-	PKName         string            `protobuf:"bytes,6,opt,name=PKName,proto3" json:"PKName,omitempty"`
-	ColIdsById     map[uint64]string `protobuf:"bytes,8,rep,name=ColIdsById,proto3" json:"ColIdsById,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	ColIdsByName   map[string]uint64 `protobuf:"bytes,9,rep,name=ColIdsByName,proto3" json:"ColIdsByName,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
-	ColTypesById   map[uint64]string `protobuf:"bytes,10,rep,name=ColTypesById,proto3" json:"ColTypesById,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Score float64 `protobuf:"fixed64,1,opt,name=score,proto3" json:"score,omitempty"`
 }
 
-func (x *VerifiableSQLEntry) Reset() {
-	*x = VerifiableSQLEntry{}
+func (x *Score) Reset() {
+	*x = Score{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[54]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3480,13 +3792,13 @@ func (x *VerifiableSQLEntry) Reset() {
 	}
 }
 
-func (x *VerifiableSQLEntry) String() string {
+func (x *Score) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VerifiableSQLEntry) ProtoMessage() {}
+func (*Score) ProtoMessage() {}
 
-func (x *VerifiableSQLEntry) ProtoReflect() protoreflect.Message {
+func (x *Score) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[54]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3498,99 +3810,172 @@ func (x *VerifiableSQLEntry) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VerifiableSQLEntry.ProtoReflect.Descriptor instead.
-func (*VerifiableSQLEntry) Descriptor() ([]byte, []int) {
+// Deprecated: Use Score.ProtoReflect.Descriptor instead.
+func (*Score) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{54}
 }
 
-func (x *VerifiableSQLEntry) GetSqlEntry() *SQLEntry {
+func (x *Score) GetScore() float64 {
 	if x != nil {
-		return x.SqlEntry
+		return x.Score
 	}
-	return nil
+	return 0
 }
 
-func (x *VerifiableSQLEntry) GetVerifiableTx() *VerifiableTx {
+type ZScanRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Set           []byte  `protobuf:"bytes,1,opt,name=set,proto3" json:"set,omitempty"`
+	SeekKey       []byte  `protobuf:"bytes,2,opt,name=seekKey,proto3" json:"seekKey,omitempty"`
+	SeekScore     float64 `protobuf:"fixed64,3,opt,name=seekScore,proto3" json:"seekScore,omitempty"`
+	SeekAtTx      uint64  `protobuf:"varint,4,opt,name=seekAtTx,proto3" json:"seekAtTx,omitempty"`
+	InclusiveSeek bool    `protobuf:"varint,5,opt,name=inclusiveSeek,proto3" json:"inclusiveSeek,omitempty"`
+	Limit         uint64  `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
+	Desc          bool    `protobuf:"varint,7,opt,name=desc,proto3" json:"desc,omitempty"`
+	MinScore      *Score  `protobuf:"bytes,8,opt,name=minScore,proto3" json:"minScore,omitempty"`
+	MaxScore      *Score  `protobuf:"bytes,9,opt,name=maxScore,proto3" json:"maxScore,omitempty"`
+	SinceTx       uint64  `protobuf:"varint,10,opt,name=sinceTx,proto3" json:"sinceTx,omitempty"`
+	NoWait        bool    `protobuf:"varint,11,opt,name=noWait,proto3" json:"noWait,omitempty"`
+}
+
+func (x *ZScanRequest) Reset() {
+	*x = ZScanRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[55]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ZScanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ZScanRequest) ProtoMessage() {}
+
+func (x *ZScanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[55]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ZScanRequest.ProtoReflect.Descriptor instead.
+func (*ZScanRequest) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ZScanRequest) GetSet() []byte {
 	if x != nil {
-		return x.VerifiableTx
+		return x.Set
 	}
 	return nil
 }
 
-func (x *VerifiableSQLEntry) GetInclusionProof() *InclusionProof {
+func (x *ZScanRequest) GetSeekKey() []byte {
 	if x != nil {
-		return x.InclusionProof
+		return x.SeekKey
 	}
 	return nil
 }
 
-func (x *VerifiableSQLEntry) GetDatabaseId() uint64 {
+func (x *ZScanRequest) GetSeekScore() float64 {
 	if x != nil {
-		return x.DatabaseId
+		return x.SeekScore
 	}
 	return 0
 }
 
-func (x *VerifiableSQLEntry) GetTableId() uint64 {
+func (x *ZScanRequest) GetSeekAtTx() uint64 {
 	if x != nil {
-		return x.TableId
+		return x.SeekAtTx
 	}
 	return 0
 }
 
-func (x *VerifiableSQLEntry) GetPKName() string {
+func (x *ZScanRequest) GetInclusiveSeek() bool {
 	if x != nil {
-		return x.PKName
+		return x.InclusiveSeek
 	}
-	return ""
+	return false
 }
 
-func (x *VerifiableSQLEntry) GetColIdsById() map[uint64]string {
+func (x *ZScanRequest) GetLimit() uint64 {
 	if x != nil {
-		return x.ColIdsById
+		return x.Limit
 	}
-	return nil
+	return 0
 }
 
-func (x *VerifiableSQLEntry) GetColIdsByName() map[string]uint64 {
+func (x *ZScanRequest) GetDesc() bool {
 	if x != nil {
-		return x.ColIdsByName
+		return x.Desc
+	}
+	return false
+}
+
+func (x *ZScanRequest) GetMinScore() *Score {
+	if x != nil {
+		return x.MinScore
 	}
 	return nil
 }
 
-func (x *VerifiableSQLEntry) GetColTypesById() map[uint64]string {
+func (x *ZScanRequest) GetMaxScore() *Score {
 	if x != nil {
-		return x.ColTypesById
+		return x.MaxScore
 	}
 	return nil
 }
 
-type UseDatabaseReply struct {
+func (x *ZScanRequest) GetSinceTx() uint64 {
+	if x != nil {
+		return x.SinceTx
+	}
+	return 0
+}
+
+func (x *ZScanRequest) GetNoWait() bool {
+	if x != nil {
+		return x.NoWait
+	}
+	return false
+}
+
+type TSSample struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// timestamps are set by the client, samples of a series are sorted by timestamp
+	Timestamp int64   `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Value     float64 `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
 }
 
-func (x *UseDatabaseReply) Reset() {
-	*x = UseDatabaseReply{}
+func (x *TSSample) Reset() {
+	*x = TSSample{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_schema_proto_msgTypes[55]
+		mi := &file_schema_proto_msgTypes[56]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *UseDatabaseReply) String() string {
+func (x *TSSample) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UseDatabaseReply) ProtoMessage() {}
+func (*TSSample) ProtoMessage() {}
 
-func (x *UseDatabaseReply) ProtoReflect() protoreflect.Message {
-	mi := &file_schema_proto_msgTypes[55]
+func (x *TSSample) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[56]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3601,46 +3986,50 @@ func (x *UseDatabaseReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UseDatabaseReply.ProtoReflect.Descriptor instead.
-func (*UseDatabaseReply) Descriptor() ([]byte, []int) {
-	return file_schema_proto_rawDescGZIP(), []int{55}
+// Deprecated: Use TSSample.ProtoReflect.Descriptor instead.
+func (*TSSample) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{56}
 }
 
-func (x *UseDatabaseReply) GetToken() string {
+func (x *TSSample) GetTimestamp() int64 {
 	if x != nil {
-		return x.Token
+		return x.Timestamp
 	}
-	return ""
+	return 0
 }
 
-type ChangePermissionRequest struct {
+func (x *TSSample) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type TSSamples struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Action     PermissionAction `protobuf:"varint,1,opt,name=action,proto3,enum=immudb.schema.PermissionAction" json:"action,omitempty"`
-	Username   string           `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
-	Database   string           `protobuf:"bytes,3,opt,name=database,proto3" json:"database,omitempty"`
-	Permission uint32           `protobuf:"varint,4,opt,name=permission,proto3" json:"permission,omitempty"`
+	Samples []*TSSample `protobuf:"bytes,1,rep,name=samples,proto3" json:"samples,omitempty"`
 }
 
-func (x *ChangePermissionRequest) Reset() {
-	*x = ChangePermissionRequest{}
+func (x *TSSamples) Reset() {
+	*x = TSSamples{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_schema_proto_msgTypes[56]
+		mi := &file_schema_proto_msgTypes[57]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ChangePermissionRequest) String() string {
+func (x *TSSamples) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ChangePermissionRequest) ProtoMessage() {}
+func (*TSSamples) ProtoMessage() {}
 
-func (x *ChangePermissionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_schema_proto_msgTypes[56]
+func (x *TSSamples) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[57]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3651,65 +4040,48 @@ func (x *ChangePermissionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ChangePermissionRequest.ProtoReflect.Descriptor instead.
-func (*ChangePermissionRequest) Descriptor() ([]byte, []int) {
-	return file_schema_proto_rawDescGZIP(), []int{56}
-}
-
-func (x *ChangePermissionRequest) GetAction() PermissionAction {
-	if x != nil {
-		return x.Action
-	}
-	return PermissionAction_GRANT
-}
-
-func (x *ChangePermissionRequest) GetUsername() string {
-	if x != nil {
-		return x.Username
-	}
-	return ""
-}
-
-func (x *ChangePermissionRequest) GetDatabase() string {
-	if x != nil {
-		return x.Database
-	}
-	return ""
+// Deprecated: Use TSSamples.ProtoReflect.Descriptor instead.
+func (*TSSamples) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{57}
 }
 
-func (x *ChangePermissionRequest) GetPermission() uint32 {
+func (x *TSSamples) GetSamples() []*TSSample {
 	if x != nil {
-		return x.Permission
+		return x.Samples
 	}
-	return 0
+	return nil
 }
 
-type SetActiveUserRequest struct {
+type TSAppendRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Active   bool   `protobuf:"varint,1,opt,name=active,proto3" json:"active,omitempty"`
-	Username string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Series []byte `protobuf:"bytes,1,opt,name=series,proto3" json:"series,omitempty"`
+	// a sample overrides any previous one of the series with the same timestamp
+	Samples []*TSSample `protobuf:"bytes,2,rep,name=samples,proto3" json:"samples,omitempty"`
+	NoWait  bool        `protobuf:"varint,3,opt,name=noWait,proto3" json:"noWait,omitempty"`
+	// W3C trace-id recorded into the tx attributes
+	TraceID []byte `protobuf:"bytes,4,opt,name=traceID,proto3" json:"traceID,omitempty"`
 }
 
-func (x *SetActiveUserRequest) Reset() {
-	*x = SetActiveUserRequest{}
+func (x *TSAppendRequest) Reset() {
+	*x = TSAppendRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_schema_proto_msgTypes[57]
+		mi := &file_schema_proto_msgTypes[58]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SetActiveUserRequest) String() string {
+func (x *TSAppendRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetActiveUserRequest) ProtoMessage() {}
+func (*TSAppendRequest) ProtoMessage() {}
 
-func (x *SetActiveUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_schema_proto_msgTypes[57]
+func (x *TSAppendRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[58]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3720,50 +4092,75 @@ func (x *SetActiveUserRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetActiveUserRequest.ProtoReflect.Descriptor instead.
-func (*SetActiveUserRequest) Descriptor() ([]byte, []int) {
-	return file_schema_proto_rawDescGZIP(), []int{57}
+// Deprecated: Use TSAppendRequest.ProtoReflect.Descriptor instead.
+func (*TSAppendRequest) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{58}
 }
 
-func (x *SetActiveUserRequest) GetActive() bool {
+func (x *TSAppendRequest) GetSeries() []byte {
 	if x != nil {
-		return x.Active
+		return x.Series
+	}
+	return nil
+}
+
+func (x *TSAppendRequest) GetSamples() []*TSSample {
+	if x != nil {
+		return x.Samples
+	}
+	return nil
+}
+
+func (x *TSAppendRequest) GetNoWait() bool {
+	if x != nil {
+		return x.NoWait
 	}
 	return false
 }
 
-func (x *SetActiveUserRequest) GetUsername() string {
+func (x *TSAppendRequest) GetTraceID() []byte {
 	if x != nil {
-		return x.Username
+		return x.TraceID
 	}
-	return ""
+	return nil
 }
 
-type DatabaseListResponse struct {
+type TSRangeRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Databases []*Database `protobuf:"bytes,1,rep,name=databases,proto3" json:"databases,omitempty"`
-}
-
-func (x *DatabaseListResponse) Reset() {
-	*x = DatabaseListResponse{}
+	Series []byte `protobuf:"bytes,1,opt,name=series,proto3" json:"series,omitempty"`
+	// samples with timestamps within [start, end) are returned, no upper bound is applied when end is zero
+	Start int64 `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
+	End   int64 `protobuf:"varint,3,opt,name=end,proto3" json:"end,omitempty"`
+	// when greater than zero, samples are downsampled into windows aligned to multiples of it,
+	// one sample per non-empty window is returned, timestamped with the start of the window
+	Window      int64         `protobuf:"varint,4,opt,name=window,proto3" json:"window,omitempty"`
+	Aggregation TSAggregation `protobuf:"varint,5,opt,name=aggregation,proto3,enum=immudb.schema.TSAggregation" json:"aggregation,omitempty"`
+	Limit       uint64        `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
+	Desc        bool          `protobuf:"varint,7,opt,name=desc,proto3" json:"desc,omitempty"`
+	SinceTx     uint64        `protobuf:"varint,8,opt,name=sinceTx,proto3" json:"sinceTx,omitempty"`
+	NoWait      bool          `protobuf:"varint,9,opt,name=noWait,proto3" json:"noWait,omitempty"`
+}
+
+func (x *TSRangeRequest) Reset() {
+	*x = TSRangeRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_schema_proto_msgTypes[58]
+		mi := &file_schema_proto_msgTypes[59]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DatabaseListResponse) String() string {
+func (x *TSRangeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DatabaseListResponse) ProtoMessage() {}
+func (*TSRangeRequest) ProtoMessage() {}
 
-func (x *DatabaseListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_schema_proto_msgTypes[58]
+func (x *TSRangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[59]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3774,76 +4171,88 @@ func (x *DatabaseListResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DatabaseListResponse.ProtoReflect.Descriptor instead.
-func (*DatabaseListResponse) Descriptor() ([]byte, []int) {
-	return file_schema_proto_rawDescGZIP(), []int{58}
+// Deprecated: Use TSRangeRequest.ProtoReflect.Descriptor instead.
+func (*TSRangeRequest) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{59}
 }
 
-func (x *DatabaseListResponse) GetDatabases() []*Database {
+func (x *TSRangeRequest) GetSeries() []byte {
 	if x != nil {
-		return x.Databases
+		return x.Series
 	}
 	return nil
 }
 
-type Chunk struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *TSRangeRequest) GetStart() int64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
 
-	Content []byte `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+func (x *TSRangeRequest) GetEnd() int64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
 }
 
-func (x *Chunk) Reset() {
-	*x = Chunk{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_schema_proto_msgTypes[59]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *TSRangeRequest) GetWindow() int64 {
+	if x != nil {
+		return x.Window
 	}
+	return 0
 }
 
-func (x *Chunk) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *TSRangeRequest) GetAggregation() TSAggregation {
+	if x != nil {
+		return x.Aggregation
+	}
+	return TSAggregation_AVG
 }
 
-func (*Chunk) ProtoMessage() {}
+func (x *TSRangeRequest) GetLimit() uint64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
 
-func (x *Chunk) ProtoReflect() protoreflect.Message {
-	mi := &file_schema_proto_msgTypes[59]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *TSRangeRequest) GetDesc() bool {
+	if x != nil {
+		return x.Desc
 	}
-	return mi.MessageOf(x)
+	return false
 }
 
-// Deprecated: Use Chunk.ProtoReflect.Descriptor instead.
-func (*Chunk) Descriptor() ([]byte, []int) {
-	return file_schema_proto_rawDescGZIP(), []int{59}
+func (x *TSRangeRequest) GetSinceTx() uint64 {
+	if x != nil {
+		return x.SinceTx
+	}
+	return 0
 }
 
-func (x *Chunk) GetContent() []byte {
+func (x *TSRangeRequest) GetNoWait() bool {
 	if x != nil {
-		return x.Content
+		return x.NoWait
 	}
-	return nil
+	return false
 }
 
-type UseSnapshotRequest struct {
+type NotarizeRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SinceTx    uint64 `protobuf:"varint,1,opt,name=sinceTx,proto3" json:"sinceTx,omitempty"`
-	AsBeforeTx uint64 `protobuf:"varint,2,opt,name=asBeforeTx,proto3" json:"asBeforeTx,omitempty"`
+	// digests are notarized within the same tx, each of them at most once
+	Digests [][]byte `protobuf:"bytes,1,rep,name=digests,proto3" json:"digests,omitempty"`
+	NoWait  bool     `protobuf:"varint,2,opt,name=noWait,proto3" json:"noWait,omitempty"`
+	// W3C trace-id recorded into the tx attributes
+	TraceID []byte `protobuf:"bytes,3,opt,name=traceID,proto3" json:"traceID,omitempty"`
 }
 
-func (x *UseSnapshotRequest) Reset() {
-	*x = UseSnapshotRequest{}
+func (x *NotarizeRequest) Reset() {
+	*x = NotarizeRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[60]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3851,13 +4260,13 @@ func (x *UseSnapshotRequest) Reset() {
 	}
 }
 
-func (x *UseSnapshotRequest) String() string {
+func (x *NotarizeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UseSnapshotRequest) ProtoMessage() {}
+func (*NotarizeRequest) ProtoMessage() {}
 
-func (x *UseSnapshotRequest) ProtoReflect() protoreflect.Message {
+func (x *NotarizeRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[60]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3869,37 +4278,47 @@ func (x *UseSnapshotRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UseSnapshotRequest.ProtoReflect.Descriptor instead.
-func (*UseSnapshotRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use NotarizeRequest.ProtoReflect.Descriptor instead.
+func (*NotarizeRequest) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{60}
 }
 
-func (x *UseSnapshotRequest) GetSinceTx() uint64 {
+func (x *NotarizeRequest) GetDigests() [][]byte {
 	if x != nil {
-		return x.SinceTx
+		return x.Digests
 	}
-	return 0
+	return nil
 }
 
-func (x *UseSnapshotRequest) GetAsBeforeTx() uint64 {
+func (x *NotarizeRequest) GetNoWait() bool {
 	if x != nil {
-		return x.AsBeforeTx
+		return x.NoWait
 	}
-	return 0
+	return false
 }
 
-type SQLExecRequest struct {
+func (x *NotarizeRequest) GetTraceID() []byte {
+	if x != nil {
+		return x.TraceID
+	}
+	return nil
+}
+
+type NotarizationReceipt struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Sql    string        `protobuf:"bytes,1,opt,name=sql,proto3" json:"sql,omitempty"`
-	Params []*NamedParam `protobuf:"bytes,2,rep,name=params,proto3" json:"params,omitempty"`
-	NoWait bool          `protobuf:"varint,3,opt,name=noWait,proto3" json:"noWait,omitempty"`
+	Digest     []byte      `protobuf:"bytes,1,opt,name=digest,proto3" json:"digest,omitempty"`
+	TxMetadata *TxMetadata `protobuf:"bytes,2,opt,name=txMetadata,proto3" json:"txMetadata,omitempty"`
+	// inclusion of the digest in the tx
+	InclusionProof *InclusionProof `protobuf:"bytes,3,opt,name=inclusionProof,proto3" json:"inclusionProof,omitempty"`
+	// state right after the tx was committed, signed when the server has a signing key
+	State *ImmutableState `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
 }
 
-func (x *SQLExecRequest) Reset() {
-	*x = SQLExecRequest{}
+func (x *NotarizationReceipt) Reset() {
+	*x = NotarizationReceipt{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[61]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3907,13 +4326,13 @@ func (x *SQLExecRequest) Reset() {
 	}
 }
 
-func (x *SQLExecRequest) String() string {
+func (x *NotarizationReceipt) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SQLExecRequest) ProtoMessage() {}
+func (*NotarizationReceipt) ProtoMessage() {}
 
-func (x *SQLExecRequest) ProtoReflect() protoreflect.Message {
+func (x *NotarizationReceipt) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[61]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3925,44 +4344,49 @@ func (x *SQLExecRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SQLExecRequest.ProtoReflect.Descriptor instead.
-func (*SQLExecRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use NotarizationReceipt.ProtoReflect.Descriptor instead.
+func (*NotarizationReceipt) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{61}
 }
 
-func (x *SQLExecRequest) GetSql() string {
+func (x *NotarizationReceipt) GetDigest() []byte {
 	if x != nil {
-		return x.Sql
+		return x.Digest
 	}
-	return ""
+	return nil
 }
 
-func (x *SQLExecRequest) GetParams() []*NamedParam {
+func (x *NotarizationReceipt) GetTxMetadata() *TxMetadata {
 	if x != nil {
-		return x.Params
+		return x.TxMetadata
 	}
 	return nil
 }
 
-func (x *SQLExecRequest) GetNoWait() bool {
+func (x *NotarizationReceipt) GetInclusionProof() *InclusionProof {
 	if x != nil {
-		return x.NoWait
+		return x.InclusionProof
 	}
-	return false
+	return nil
 }
 
-type SQLQueryRequest struct {
+func (x *NotarizationReceipt) GetState() *ImmutableState {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+type NotarizationReceipts struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Sql           string        `protobuf:"bytes,1,opt,name=sql,proto3" json:"sql,omitempty"`
-	Params        []*NamedParam `protobuf:"bytes,2,rep,name=params,proto3" json:"params,omitempty"`
-	ReuseSnapshot bool          `protobuf:"varint,3,opt,name=reuseSnapshot,proto3" json:"reuseSnapshot,omitempty"`
+	Receipts []*NotarizationReceipt `protobuf:"bytes,1,rep,name=receipts,proto3" json:"receipts,omitempty"`
 }
 
-func (x *SQLQueryRequest) Reset() {
-	*x = SQLQueryRequest{}
+func (x *NotarizationReceipts) Reset() {
+	*x = NotarizationReceipts{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[62]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3970,13 +4394,13 @@ func (x *SQLQueryRequest) Reset() {
 	}
 }
 
-func (x *SQLQueryRequest) String() string {
+func (x *NotarizationReceipts) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SQLQueryRequest) ProtoMessage() {}
+func (*NotarizationReceipts) ProtoMessage() {}
 
-func (x *SQLQueryRequest) ProtoReflect() protoreflect.Message {
+func (x *NotarizationReceipts) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[62]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3988,43 +4412,28 @@ func (x *SQLQueryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SQLQueryRequest.ProtoReflect.Descriptor instead.
-func (*SQLQueryRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use NotarizationReceipts.ProtoReflect.Descriptor instead.
+func (*NotarizationReceipts) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{62}
 }
 
-func (x *SQLQueryRequest) GetSql() string {
-	if x != nil {
-		return x.Sql
-	}
-	return ""
-}
-
-func (x *SQLQueryRequest) GetParams() []*NamedParam {
+func (x *NotarizationReceipts) GetReceipts() []*NotarizationReceipt {
 	if x != nil {
-		return x.Params
+		return x.Receipts
 	}
 	return nil
 }
 
-func (x *SQLQueryRequest) GetReuseSnapshot() bool {
-	if x != nil {
-		return x.ReuseSnapshot
-	}
-	return false
-}
-
-type NamedParam struct {
+type NotarizationVerification struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name  string    `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Value *SQLValue `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Verified bool `protobuf:"varint,1,opt,name=verified,proto3" json:"verified,omitempty"`
 }
 
-func (x *NamedParam) Reset() {
-	*x = NamedParam{}
+func (x *NotarizationVerification) Reset() {
+	*x = NotarizationVerification{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[63]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -4032,13 +4441,13 @@ func (x *NamedParam) Reset() {
 	}
 }
 
-func (x *NamedParam) String() string {
+func (x *NotarizationVerification) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NamedParam) ProtoMessage() {}
+func (*NotarizationVerification) ProtoMessage() {}
 
-func (x *NamedParam) ProtoReflect() protoreflect.Message {
+func (x *NotarizationVerification) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[63]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -4050,36 +4459,33 @@ func (x *NamedParam) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NamedParam.ProtoReflect.Descriptor instead.
-func (*NamedParam) Descriptor() ([]byte, []int) {
+// Deprecated: Use NotarizationVerification.ProtoReflect.Descriptor instead.
+func (*NotarizationVerification) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{63}
 }
 
-func (x *NamedParam) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *NamedParam) GetValue() *SQLValue {
+func (x *NotarizationVerification) GetVerified() bool {
 	if x != nil {
-		return x.Value
+		return x.Verified
 	}
-	return nil
+	return false
 }
 
-type SQLExecResult struct {
+type HistoryRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ctxs []*TxMetadata `protobuf:"bytes,1,rep,name=ctxs,proto3" json:"ctxs,omitempty"`
-	Dtxs []*TxMetadata `protobuf:"bytes,2,rep,name=dtxs,proto3" json:"dtxs,omitempty"`
+	Key        []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Offset     uint64 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Limit      int32  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Desc       bool   `protobuf:"varint,4,opt,name=desc,proto3" json:"desc,omitempty"`
+	SinceTx    uint64 `protobuf:"varint,5,opt,name=sinceTx,proto3" json:"sinceTx,omitempty"`
+	AtRevision uint64 `protobuf:"varint,6,opt,name=atRevision,proto3" json:"atRevision,omitempty"`
 }
 
-func (x *SQLExecResult) Reset() {
-	*x = SQLExecResult{}
+func (x *HistoryRequest) Reset() {
+	*x = HistoryRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[64]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -4087,13 +4493,13 @@ func (x *SQLExecResult) Reset() {
 	}
 }
 
-func (x *SQLExecResult) String() string {
+func (x *HistoryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SQLExecResult) ProtoMessage() {}
+func (*HistoryRequest) ProtoMessage() {}
 
-func (x *SQLExecResult) ProtoReflect() protoreflect.Message {
+func (x *HistoryRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[64]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -4105,36 +4511,64 @@ func (x *SQLExecResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SQLExecResult.ProtoReflect.Descriptor instead.
-func (*SQLExecResult) Descriptor() ([]byte, []int) {
+// Deprecated: Use HistoryRequest.ProtoReflect.Descriptor instead.
+func (*HistoryRequest) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{64}
 }
 
-func (x *SQLExecResult) GetCtxs() []*TxMetadata {
+func (x *HistoryRequest) GetKey() []byte {
 	if x != nil {
-		return x.Ctxs
+		return x.Key
 	}
 	return nil
 }
 
-func (x *SQLExecResult) GetDtxs() []*TxMetadata {
+func (x *HistoryRequest) GetOffset() uint64 {
 	if x != nil {
-		return x.Dtxs
+		return x.Offset
 	}
-	return nil
+	return 0
 }
 
-type SQLQueryResult struct {
+func (x *HistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *HistoryRequest) GetDesc() bool {
+	if x != nil {
+		return x.Desc
+	}
+	return false
+}
+
+func (x *HistoryRequest) GetSinceTx() uint64 {
+	if x != nil {
+		return x.SinceTx
+	}
+	return 0
+}
+
+func (x *HistoryRequest) GetAtRevision() uint64 {
+	if x != nil {
+		return x.AtRevision
+	}
+	return 0
+}
+
+type VerifiableZAddRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Columns []*Column `protobuf:"bytes,2,rep,name=columns,proto3" json:"columns,omitempty"`
-	Rows    []*Row    `protobuf:"bytes,1,rep,name=rows,proto3" json:"rows,omitempty"`
+	ZAddRequest  *ZAddRequest `protobuf:"bytes,1,opt,name=zAddRequest,proto3" json:"zAddRequest,omitempty"`
+	ProveSinceTx uint64       `protobuf:"varint,2,opt,name=proveSinceTx,proto3" json:"proveSinceTx,omitempty"`
 }
 
-func (x *SQLQueryResult) Reset() {
-	*x = SQLQueryResult{}
+func (x *VerifiableZAddRequest) Reset() {
+	*x = VerifiableZAddRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[65]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -4142,13 +4576,13 @@ func (x *SQLQueryResult) Reset() {
 	}
 }
 
-func (x *SQLQueryResult) String() string {
+func (x *VerifiableZAddRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SQLQueryResult) ProtoMessage() {}
+func (*VerifiableZAddRequest) ProtoMessage() {}
 
-func (x *SQLQueryResult) ProtoReflect() protoreflect.Message {
+func (x *VerifiableZAddRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[65]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -4160,36 +4594,41 @@ func (x *SQLQueryResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SQLQueryResult.ProtoReflect.Descriptor instead.
-func (*SQLQueryResult) Descriptor() ([]byte, []int) {
+// Deprecated: Use VerifiableZAddRequest.ProtoReflect.Descriptor instead.
+func (*VerifiableZAddRequest) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{65}
 }
 
-func (x *SQLQueryResult) GetColumns() []*Column {
+func (x *VerifiableZAddRequest) GetZAddRequest() *ZAddRequest {
 	if x != nil {
-		return x.Columns
+		return x.ZAddRequest
 	}
 	return nil
 }
 
-func (x *SQLQueryResult) GetRows() []*Row {
+func (x *VerifiableZAddRequest) GetProveSinceTx() uint64 {
 	if x != nil {
-		return x.Rows
+		return x.ProveSinceTx
 	}
-	return nil
+	return 0
 }
 
-type Column struct {
+type TxRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Tx uint64 `protobuf:"varint,1,opt,name=tx,proto3" json:"tx,omitempty"`
+	// position of the first entry to be included
+	EntriesOffset uint32 `protobuf:"varint,2,opt,name=entriesOffset,proto3" json:"entriesOffset,omitempty"`
+	// maximum number of entries to be included, all the remaining entries are included when zero
+	EntriesLimit uint32 `protobuf:"varint,3,opt,name=entriesLimit,proto3" json:"entriesLimit,omitempty"`
+	// only the header of the tx is included, which holds the digest of its entries
+	DigestOnly bool `protobuf:"varint,4,opt,name=digestOnly,proto3" json:"digestOnly,omitempty"`
 }
 
-func (x *Column) Reset() {
-	*x = Column{}
+func (x *TxRequest) Reset() {
+	*x = TxRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[66]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -4197,13 +4636,13 @@ func (x *Column) Reset() {
 	}
 }
 
-func (x *Column) String() string {
+func (x *TxRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Column) ProtoMessage() {}
+func (*TxRequest) ProtoMessage() {}
 
-func (x *Column) ProtoReflect() protoreflect.Message {
+func (x *TxRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[66]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -4215,36 +4654,54 @@ func (x *Column) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Column.ProtoReflect.Descriptor instead.
-func (*Column) Descriptor() ([]byte, []int) {
+// Deprecated: Use TxRequest.ProtoReflect.Descriptor instead.
+func (*TxRequest) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{66}
 }
 
-func (x *Column) GetName() string {
+func (x *TxRequest) GetTx() uint64 {
 	if x != nil {
-		return x.Name
+		return x.Tx
 	}
-	return ""
+	return 0
 }
 
-func (x *Column) GetType() string {
+func (x *TxRequest) GetEntriesOffset() uint32 {
 	if x != nil {
-		return x.Type
+		return x.EntriesOffset
 	}
-	return ""
+	return 0
 }
 
-type Row struct {
+func (x *TxRequest) GetEntriesLimit() uint32 {
+	if x != nil {
+		return x.EntriesLimit
+	}
+	return 0
+}
+
+func (x *TxRequest) GetDigestOnly() bool {
+	if x != nil {
+		return x.DigestOnly
+	}
+	return false
+}
+
+type TxRangeRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Columns []string    `protobuf:"bytes,1,rep,name=columns,proto3" json:"columns,omitempty"`
-	Values  []*SQLValue `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+	// txs committed after sinceTx are included
+	SinceTx uint64 `protobuf:"varint,1,opt,name=sinceTx,proto3" json:"sinceTx,omitempty"`
+	// latest included tx, all the committed txs are included when zero
+	UpToTx uint64 `protobuf:"varint,2,opt,name=upToTx,proto3" json:"upToTx,omitempty"`
+	// age X25519 public keys the archive is encrypted to, the archive is not encrypted when empty
+	Recipients []string `protobuf:"bytes,3,rep,name=recipients,proto3" json:"recipients,omitempty"`
 }
 
-func (x *Row) Reset() {
-	*x = Row{}
+func (x *TxRangeRequest) Reset() {
+	*x = TxRangeRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[67]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -4252,13 +4709,13 @@ func (x *Row) Reset() {
 	}
 }
 
-func (x *Row) String() string {
+func (x *TxRangeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Row) ProtoMessage() {}
+func (*TxRangeRequest) ProtoMessage() {}
 
-func (x *Row) ProtoReflect() protoreflect.Message {
+func (x *TxRangeRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[67]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -4270,41 +4727,45 @@ func (x *Row) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Row.ProtoReflect.Descriptor instead.
-func (*Row) Descriptor() ([]byte, []int) {
+// Deprecated: Use TxRangeRequest.ProtoReflect.Descriptor instead.
+func (*TxRangeRequest) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{67}
 }
 
-func (x *Row) GetColumns() []string {
+func (x *TxRangeRequest) GetSinceTx() uint64 {
 	if x != nil {
-		return x.Columns
+		return x.SinceTx
 	}
-	return nil
+	return 0
 }
 
-func (x *Row) GetValues() []*SQLValue {
+func (x *TxRangeRequest) GetUpToTx() uint64 {
 	if x != nil {
-		return x.Values
+		return x.UpToTx
+	}
+	return 0
+}
+
+func (x *TxRangeRequest) GetRecipients() []string {
+	if x != nil {
+		return x.Recipients
 	}
 	return nil
 }
 
-type SQLValue struct {
+type TxRange struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Types that are assignable to Value:
-	//	*SQLValue_Null
-	//	*SQLValue_N
-	//	*SQLValue_S
-	//	*SQLValue_B
-	//	*SQLValue_Bs
-	Value isSQLValue_Value `protobuf_oneof:"value"`
+	SinceTx  uint64 `protobuf:"varint,1,opt,name=sinceTx,proto3" json:"sinceTx,omitempty"`
+	SinceAlh []byte `protobuf:"bytes,2,opt,name=sinceAlh,proto3" json:"sinceAlh,omitempty"`
+	UpToTx   uint64 `protobuf:"varint,3,opt,name=upToTx,proto3" json:"upToTx,omitempty"`
+	UpToAlh  []byte `protobuf:"bytes,4,opt,name=upToAlh,proto3" json:"upToAlh,omitempty"`
 }
 
-func (x *SQLValue) Reset() {
-	*x = SQLValue{}
+func (x *TxRange) Reset() {
+	*x = TxRange{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_schema_proto_msgTypes[68]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -4312,13 +4773,13 @@ func (x *SQLValue) Reset() {
 	}
 }
 
-func (x *SQLValue) String() string {
+func (x *TxRange) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SQLValue) ProtoMessage() {}
+func (*TxRange) ProtoMessage() {}
 
-func (x *SQLValue) ProtoReflect() protoreflect.Message {
+func (x *TxRange) ProtoReflect() protoreflect.Message {
 	mi := &file_schema_proto_msgTypes[68]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -4330,3391 +4791,9624 @@ func (x *SQLValue) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SQLValue.ProtoReflect.Descriptor instead.
-func (*SQLValue) Descriptor() ([]byte, []int) {
+// Deprecated: Use TxRange.ProtoReflect.Descriptor instead.
+func (*TxRange) Descriptor() ([]byte, []int) {
 	return file_schema_proto_rawDescGZIP(), []int{68}
 }
 
-func (m *SQLValue) GetValue() isSQLValue_Value {
-	if m != nil {
-		return m.Value
+func (x *TxRange) GetSinceTx() uint64 {
+	if x != nil {
+		return x.SinceTx
 	}
-	return nil
+	return 0
 }
 
-func (x *SQLValue) GetNull() _struct.NullValue {
-	if x, ok := x.GetValue().(*SQLValue_Null); ok {
-		return x.Null
+func (x *TxRange) GetSinceAlh() []byte {
+	if x != nil {
+		return x.SinceAlh
 	}
-	return _struct.NullValue_NULL_VALUE
+	return nil
 }
 
-func (x *SQLValue) GetN() uint64 {
-	if x, ok := x.GetValue().(*SQLValue_N); ok {
-		return x.N
+func (x *TxRange) GetUpToTx() uint64 {
+	if x != nil {
+		return x.UpToTx
 	}
 	return 0
 }
 
-func (x *SQLValue) GetS() string {
-	if x, ok := x.GetValue().(*SQLValue_S); ok {
-		return x.S
+func (x *TxRange) GetUpToAlh() []byte {
+	if x != nil {
+		return x.UpToAlh
 	}
-	return ""
+	return nil
 }
 
-func (x *SQLValue) GetB() bool {
-	if x, ok := x.GetValue().(*SQLValue_B); ok {
-		return x.B
+type VerifiableTxRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tx           uint64 `protobuf:"varint,1,opt,name=tx,proto3" json:"tx,omitempty"`
+	ProveSinceTx uint64 `protobuf:"varint,2,opt,name=proveSinceTx,proto3" json:"proveSinceTx,omitempty"`
+}
+
+func (x *VerifiableTxRequest) Reset() {
+	*x = VerifiableTxRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[69]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return false
 }
 
-func (x *SQLValue) GetBs() []byte {
-	if x, ok := x.GetValue().(*SQLValue_Bs); ok {
-		return x.Bs
+func (x *VerifiableTxRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifiableTxRequest) ProtoMessage() {}
+
+func (x *VerifiableTxRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[69]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-type isSQLValue_Value interface {
-	isSQLValue_Value()
+// Deprecated: Use VerifiableTxRequest.ProtoReflect.Descriptor instead.
+func (*VerifiableTxRequest) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{69}
 }
 
-type SQLValue_Null struct {
-	Null _struct.NullValue `protobuf:"varint,1,opt,name=null,proto3,enum=google.protobuf.NullValue,oneof"`
+func (x *VerifiableTxRequest) GetTx() uint64 {
+	if x != nil {
+		return x.Tx
+	}
+	return 0
 }
 
-type SQLValue_N struct {
-	N uint64 `protobuf:"varint,2,opt,name=n,proto3,oneof"`
+func (x *VerifiableTxRequest) GetProveSinceTx() uint64 {
+	if x != nil {
+		return x.ProveSinceTx
+	}
+	return 0
 }
 
-type SQLValue_S struct {
-	S string `protobuf:"bytes,3,opt,name=s,proto3,oneof"`
+type TxScanRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	InitialTx uint64 `protobuf:"varint,1,opt,name=initialTx,proto3" json:"initialTx,omitempty"`
+	Limit     uint32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Desc      bool   `protobuf:"varint,3,opt,name=desc,proto3" json:"desc,omitempty"`
 }
 
-type SQLValue_B struct {
-	B bool `protobuf:"varint,4,opt,name=b,proto3,oneof"`
+func (x *TxScanRequest) Reset() {
+	*x = TxScanRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[70]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-type SQLValue_Bs struct {
-	Bs []byte `protobuf:"bytes,5,opt,name=bs,proto3,oneof"`
+func (x *TxScanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SQLValue_Null) isSQLValue_Value() {}
+func (*TxScanRequest) ProtoMessage() {}
 
-func (*SQLValue_N) isSQLValue_Value() {}
+func (x *TxScanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[70]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
 
-func (*SQLValue_S) isSQLValue_Value() {}
+// Deprecated: Use TxScanRequest.ProtoReflect.Descriptor instead.
+func (*TxScanRequest) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{70}
+}
 
-func (*SQLValue_B) isSQLValue_Value() {}
+func (x *TxScanRequest) GetInitialTx() uint64 {
+	if x != nil {
+		return x.InitialTx
+	}
+	return 0
+}
 
-func (*SQLValue_Bs) isSQLValue_Value() {}
+func (x *TxScanRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
 
-var File_schema_proto protoreflect.FileDescriptor
+func (x *TxScanRequest) GetDesc() bool {
+	if x != nil {
+		return x.Desc
+	}
+	return false
+}
 
-var file_schema_proto_rawDesc = []byte{
-	0x0a, 0x0c, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0d,
-	0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x1a, 0x1c, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70,
-	0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x2c, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63,
-	0x2d, 0x67, 0x65, 0x6e, 0x2d, 0x73, 0x77, 0x61, 0x67, 0x67, 0x65, 0x72, 0x2f, 0x6f, 0x70, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x17, 0x0a, 0x03, 0x4b, 0x65, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
-	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0x48, 0x0a,
-	0x0a, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x64,
-	0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64,
-	0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x65, 0x72, 0x6d, 0x69,
-	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x70, 0x65, 0x72,
-	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xab, 0x01, 0x0a, 0x04, 0x55, 0x73, 0x65, 0x72,
-	0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04,
-	0x75, 0x73, 0x65, 0x72, 0x12, 0x3b, 0x0a, 0x0b, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69,
-	0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75,
-	0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73,
-	0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
-	0x73, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x62, 0x79, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x62, 0x79, 0x12,
-	0x1c, 0x0a, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x61, 0x74, 0x12, 0x16, 0x0a,
-	0x06, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x61,
-	0x63, 0x74, 0x69, 0x76, 0x65, 0x22, 0x35, 0x0a, 0x08, 0x55, 0x73, 0x65, 0x72, 0x4c, 0x69, 0x73,
-	0x74, 0x12, 0x29, 0x0a, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x13, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
-	0x2e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x22, 0x7f, 0x0a, 0x11,
-	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72,
-	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72,
-	0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f,
-	0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x22, 0x21, 0x0a,
-	0x0b, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
-	0x75, 0x73, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72,
-	0x22, 0x6f, 0x0a, 0x15, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f,
-	0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65,
-	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x20, 0x0a,
-	0x0b, 0x6f, 0x6c, 0x64, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x0b, 0x6f, 0x6c, 0x64, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12,
-	0x20, 0x0a, 0x0b, 0x6e, 0x65, 0x77, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x6e, 0x65, 0x77, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72,
-	0x64, 0x22, 0x3e, 0x0a, 0x0c, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72,
-	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72,
-	0x64, 0x22, 0x3f, 0x0a, 0x0d, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x77, 0x61, 0x72, 0x6e,
-	0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x77, 0x61, 0x72, 0x6e, 0x69,
-	0x6e, 0x67, 0x22, 0x20, 0x0a, 0x0a, 0x41, 0x75, 0x74, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04,
-	0x6b, 0x69, 0x6e, 0x64, 0x22, 0x26, 0x0a, 0x0a, 0x4d, 0x54, 0x4c, 0x53, 0x43, 0x6f, 0x6e, 0x66,
-	0x69, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x22, 0x32, 0x0a, 0x08,
-	0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x22, 0x7d, 0x0a, 0x05, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x78, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x74, 0x78, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x12, 0x3c, 0x0a, 0x0c, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x64, 0x42,
-	0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62,
-	0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
-	0x65, 0x52, 0x0c, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x64, 0x42, 0x79, 0x22,
-	0x41, 0x0a, 0x09, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x0e, 0x0a, 0x02,
-	0x74, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x74, 0x78, 0x12, 0x10, 0x0a, 0x03,
-	0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x12,
-	0x0a, 0x04, 0x61, 0x74, 0x54, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x61, 0x74,
-	0x54, 0x78, 0x22, 0xa3, 0x01, 0x0a, 0x02, 0x4f, 0x70, 0x12, 0x29, 0x0a, 0x02, 0x6b, 0x76, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73,
-	0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00,
-	0x52, 0x02, 0x6b, 0x76, 0x12, 0x30, 0x0a, 0x04, 0x7a, 0x41, 0x64, 0x64, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65,
-	0x6d, 0x61, 0x2e, 0x5a, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00,
-	0x52, 0x04, 0x7a, 0x41, 0x64, 0x64, 0x12, 0x33, 0x0a, 0x03, 0x72, 0x65, 0x66, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68,
-	0x65, 0x6d, 0x61, 0x2e, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x03, 0x72, 0x65, 0x66, 0x42, 0x0b, 0x0a, 0x09, 0x6f,
-	0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x5b, 0x0a, 0x0e, 0x45, 0x78, 0x65, 0x63,
-	0x41, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x31, 0x0a, 0x0a, 0x4f, 0x70,
-	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11,
-	0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x4f,
-	0x70, 0x52, 0x0a, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x16, 0x0a,
-	0x06, 0x6e, 0x6f, 0x57, 0x61, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6e,
-	0x6f, 0x57, 0x61, 0x69, 0x74, 0x22, 0x39, 0x0a, 0x07, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73,
-	0x12, 0x2e, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x14, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d,
-	0x61, 0x2e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73,
-	0x22, 0x82, 0x01, 0x0a, 0x06, 0x5a, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x73,
-	0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x73, 0x65, 0x74, 0x12, 0x10, 0x0a,
-	0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
-	0x2a, 0x0a, 0x05, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
-	0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x45,
-	0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x73,
-	0x63, 0x6f, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72,
-	0x65, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x74, 0x54, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52,
-	0x04, 0x61, 0x74, 0x54, 0x78, 0x22, 0x3b, 0x0a, 0x08, 0x5a, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65,
-	0x73, 0x12, 0x2f, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65,
-	0x6d, 0x61, 0x2e, 0x5a, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69,
-	0x65, 0x73, 0x22, 0x9b, 0x01, 0x0a, 0x0b, 0x53, 0x63, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x65, 0x6b, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x07, 0x73, 0x65, 0x65, 0x6b, 0x4b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06,
-	0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x72,
-	0x65, 0x66, 0x69, 0x78, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x65, 0x73, 0x63, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x04, 0x64, 0x65, 0x73, 0x63, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69,
-	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x18,
-	0x0a, 0x07, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52,
-	0x07, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x57, 0x61,
-	0x69, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6e, 0x6f, 0x57, 0x61, 0x69, 0x74,
-	0x22, 0x23, 0x0a, 0x09, 0x4b, 0x65, 0x79, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x16, 0x0a,
-	0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70,
-	0x72, 0x65, 0x66, 0x69, 0x78, 0x22, 0x22, 0x0a, 0x0a, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x43, 0x6f,
-	0x75, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x47, 0x0a, 0x09, 0x53, 0x69, 0x67,
-	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63,
-	0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69,
-	0x63, 0x4b, 0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
-	0x72, 0x65, 0x22, 0xa2, 0x01, 0x0a, 0x0a, 0x54, 0x78, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
-	0x61, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69,
-	0x64, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72, 0x65, 0x76, 0x41, 0x6c, 0x68, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x07, 0x70, 0x72, 0x65, 0x76, 0x41, 0x6c, 0x68, 0x12, 0x0e, 0x0a, 0x02, 0x74,
-	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6e,
-	0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6e,
-	0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x65, 0x48, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x02, 0x65, 0x48, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x6c, 0x54, 0x78, 0x49,
-	0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x62, 0x6c, 0x54, 0x78, 0x49, 0x64, 0x12,
-	0x16, 0x0a, 0x06, 0x62, 0x6c, 0x52, 0x6f, 0x6f, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x06, 0x62, 0x6c, 0x52, 0x6f, 0x6f, 0x74, 0x22, 0x63, 0x0a, 0x0b, 0x4c, 0x69, 0x6e, 0x65, 0x61,
-	0x72, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x54, 0x78, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x54, 0x78, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74,
-	0x54, 0x78, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x54, 0x61, 0x72, 0x67,
-	0x65, 0x74, 0x54, 0x78, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x65, 0x72, 0x6d, 0x73, 0x18,
-	0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x05, 0x74, 0x65, 0x72, 0x6d, 0x73, 0x22, 0x81, 0x03, 0x0a,
-	0x09, 0x44, 0x75, 0x61, 0x6c, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x45, 0x0a, 0x10, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x54, 0x78, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63,
-	0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54, 0x78, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52,
-	0x10, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x78, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
-	0x61, 0x12, 0x45, 0x0a, 0x10, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x54, 0x78, 0x4d, 0x65, 0x74,
-	0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x69, 0x6d,
-	0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54, 0x78, 0x4d, 0x65,
-	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x10, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x54, 0x78,
-	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x26, 0x0a, 0x0e, 0x69, 0x6e, 0x63, 0x6c,
-	0x75, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c,
-	0x52, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66,
-	0x12, 0x2a, 0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x50,
-	0x72, 0x6f, 0x6f, 0x66, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x10, 0x63, 0x6f, 0x6e, 0x73,
-	0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x24, 0x0a, 0x0d,
-	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x42, 0x6c, 0x54, 0x78, 0x41, 0x6c, 0x68, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x0d, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x42, 0x6c, 0x54, 0x78, 0x41,
-	0x6c, 0x68, 0x12, 0x2e, 0x0a, 0x12, 0x6c, 0x61, 0x73, 0x74, 0x49, 0x6e, 0x63, 0x6c, 0x75, 0x73,
-	0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x12,
-	0x6c, 0x61, 0x73, 0x74, 0x49, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f,
-	0x6f, 0x66, 0x12, 0x3c, 0x0a, 0x0b, 0x6c, 0x69, 0x6e, 0x65, 0x61, 0x72, 0x50, 0x72, 0x6f, 0x6f,
-	0x66, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62,
-	0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x4c, 0x69, 0x6e, 0x65, 0x61, 0x72, 0x50, 0x72,
-	0x6f, 0x6f, 0x66, 0x52, 0x0b, 0x6c, 0x69, 0x6e, 0x65, 0x61, 0x72, 0x50, 0x72, 0x6f, 0x6f, 0x66,
-	0x22, 0x6d, 0x0a, 0x02, 0x54, 0x78, 0x12, 0x35, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
-	0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64,
-	0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54, 0x78, 0x4d, 0x65, 0x74, 0x61, 0x64,
-	0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x30, 0x0a,
-	0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16,
-	0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54,
-	0x78, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22,
-	0x5b, 0x0a, 0x07, 0x54, 0x78, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
-	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06,
-	0x68, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x68, 0x56,
-	0x61, 0x6c, 0x75, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x76, 0x4f, 0x66, 0x66, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x04, 0x76, 0x4f, 0x66, 0x66, 0x12, 0x12, 0x0a, 0x04, 0x76, 0x4c, 0x65, 0x6e,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x76, 0x4c, 0x65, 0x6e, 0x22, 0xa1, 0x01, 0x0a,
-	0x0c, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x78, 0x12, 0x21, 0x0a,
-	0x02, 0x74, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x69, 0x6d, 0x6d, 0x75,
-	0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54, 0x78, 0x52, 0x02, 0x74, 0x78,
-	0x12, 0x36, 0x0a, 0x09, 0x64, 0x75, 0x61, 0x6c, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68,
-	0x65, 0x6d, 0x61, 0x2e, 0x44, 0x75, 0x61, 0x6c, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x09, 0x64,
-	0x75, 0x61, 0x6c, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x36, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e,
-	0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x69, 0x6d,
-	0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53, 0x69, 0x67, 0x6e,
-	0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
-	0x22, 0xc5, 0x01, 0x0a, 0x0f, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x45,
-	0x6e, 0x74, 0x72, 0x79, 0x12, 0x2a, 0x0a, 0x05, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68,
-	0x65, 0x6d, 0x61, 0x2e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x65, 0x6e, 0x74, 0x72, 0x79,
-	0x12, 0x3f, 0x0a, 0x0c, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x78,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e,
-	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c,
-	0x65, 0x54, 0x78, 0x52, 0x0c, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x54,
-	0x78, 0x12, 0x45, 0x0a, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x72,
-	0x6f, 0x6f, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x69, 0x6d, 0x6d, 0x75,
-	0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x49, 0x6e, 0x63, 0x6c, 0x75, 0x73,
-	0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x73,
-	0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0x50, 0x0a, 0x0e, 0x49, 0x6e, 0x63, 0x6c,
-	0x75, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x65,
-	0x61, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x6c, 0x65, 0x61, 0x66, 0x12, 0x14,
-	0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x77,
-	0x69, 0x64, 0x74, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x65, 0x72, 0x6d, 0x73, 0x18, 0x03, 0x20,
-	0x03, 0x28, 0x0c, 0x52, 0x05, 0x74, 0x65, 0x72, 0x6d, 0x73, 0x22, 0x4f, 0x0a, 0x0a, 0x53, 0x65,
-	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x03, 0x4b, 0x56, 0x73, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73,
-	0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x03,
-	0x4b, 0x56, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x57, 0x61, 0x69, 0x74, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x06, 0x6e, 0x6f, 0x57, 0x61, 0x69, 0x74, 0x22, 0x4c, 0x0a, 0x0a, 0x4b,
-	0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x61,
-	0x74, 0x54, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x61, 0x74, 0x54, 0x78, 0x12,
-	0x18, 0x0a, 0x07, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04,
-	0x52, 0x07, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78, 0x22, 0x3e, 0x0a, 0x0e, 0x4b, 0x65, 0x79,
-	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6b,
-	0x65, 0x79, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x12,
-	0x18, 0x0a, 0x07, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
-	0x52, 0x07, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78, 0x22, 0x75, 0x0a, 0x14, 0x56, 0x65, 0x72,
-	0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x39, 0x0a, 0x0a, 0x73, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73,
-	0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x52, 0x0a, 0x73, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c,
-	0x70, 0x72, 0x6f, 0x76, 0x65, 0x53, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x53, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78,
-	0x22, 0x75, 0x0a, 0x14, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x47, 0x65,
-	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x6b, 0x65, 0x79, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x69,
-	0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x4b, 0x65, 0x79,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x0a, 0x6b, 0x65, 0x79, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x53, 0x69, 0x6e, 0x63,
-	0x65, 0x54, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x76, 0x65,
-	0x53, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78, 0x22, 0x42, 0x0a, 0x0e, 0x48, 0x65, 0x61, 0x6c, 0x74,
-	0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x84, 0x01, 0x0a, 0x0e,
-	0x49, 0x6d, 0x6d, 0x75, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x0e,
-	0x0a, 0x02, 0x64, 0x62, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x64, 0x62, 0x12, 0x12,
-	0x0a, 0x04, 0x74, 0x78, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x78,
-	0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x78, 0x48, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x06, 0x74, 0x78, 0x48, 0x61, 0x73, 0x68, 0x12, 0x36, 0x0a, 0x09, 0x73, 0x69,
-	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e,
-	0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53, 0x69,
-	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
-	0x72, 0x65, 0x22, 0x92, 0x01, 0x0a, 0x10, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x24, 0x0a, 0x0d, 0x72, 0x65, 0x66,
-	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x64, 0x4b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x0d, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x64, 0x4b, 0x65, 0x79, 0x12,
-	0x12, 0x0a, 0x04, 0x61, 0x74, 0x54, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x61,
-	0x74, 0x54, 0x78, 0x12, 0x1a, 0x0a, 0x08, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x52, 0x65, 0x66, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x52, 0x65, 0x66, 0x12,
-	0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x57, 0x61, 0x69, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x06, 0x6e, 0x6f, 0x57, 0x61, 0x69, 0x74, 0x22, 0x8d, 0x01, 0x0a, 0x1a, 0x56, 0x65, 0x72, 0x69,
-	0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4b, 0x0a, 0x10, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65,
-	0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x1f, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
-	0x2e, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x52, 0x10, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x53, 0x69, 0x6e, 0x63,
-	0x65, 0x54, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x76, 0x65,
-	0x53, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78, 0x22, 0x8f, 0x01, 0x0a, 0x0b, 0x5a, 0x41, 0x64, 0x64,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x74, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x73, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f,
-	0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12,
-	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65,
-	0x79, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x74, 0x54, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52,
-	0x04, 0x61, 0x74, 0x54, 0x78, 0x12, 0x1a, 0x0a, 0x08, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x52, 0x65,
-	0x66, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x52, 0x65,
-	0x66, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x57, 0x61, 0x69, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x06, 0x6e, 0x6f, 0x57, 0x61, 0x69, 0x74, 0x22, 0x1d, 0x0a, 0x05, 0x53, 0x63, 0x6f,
-	0x72, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x01, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x22, 0xda, 0x02, 0x0a, 0x0c, 0x5a, 0x53, 0x63,
-	0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x74,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x73, 0x65, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x73,
-	0x65, 0x65, 0x6b, 0x4b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x73, 0x65,
-	0x65, 0x6b, 0x4b, 0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x65, 0x6b, 0x53, 0x63, 0x6f,
-	0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x73, 0x65, 0x65, 0x6b, 0x53, 0x63,
-	0x6f, 0x72, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x65, 0x6b, 0x41, 0x74, 0x54, 0x78, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x65, 0x65, 0x6b, 0x41, 0x74, 0x54, 0x78, 0x12,
-	0x24, 0x0a, 0x0d, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x76, 0x65, 0x53, 0x65, 0x65, 0x6b,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x76,
-	0x65, 0x53, 0x65, 0x65, 0x6b, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64,
-	0x65, 0x73, 0x63, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x64, 0x65, 0x73, 0x63, 0x12,
-	0x30, 0x0a, 0x08, 0x6d, 0x69, 0x6e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x14, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d,
-	0x61, 0x2e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x08, 0x6d, 0x69, 0x6e, 0x53, 0x63, 0x6f, 0x72,
-	0x65, 0x12, 0x30, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x09, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68,
-	0x65, 0x6d, 0x61, 0x2e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x53, 0x63,
-	0x6f, 0x72, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78, 0x18, 0x0a,
-	0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78, 0x12, 0x16, 0x0a,
-	0x06, 0x6e, 0x6f, 0x57, 0x61, 0x69, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6e,
-	0x6f, 0x57, 0x61, 0x69, 0x74, 0x22, 0x7e, 0x0a, 0x0e, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66,
-	0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65,
-	0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x65, 0x73, 0x63, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x64, 0x65, 0x73, 0x63, 0x12, 0x18, 0x0a, 0x07, 0x73,
-	0x69, 0x6e, 0x63, 0x65, 0x54, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x73, 0x69,
-	0x6e, 0x63, 0x65, 0x54, 0x78, 0x22, 0x79, 0x0a, 0x15, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61,
-	0x62, 0x6c, 0x65, 0x5a, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3c,
-	0x0a, 0x0b, 0x7a, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68,
-	0x65, 0x6d, 0x61, 0x2e, 0x5a, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52,
-	0x0b, 0x7a, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c,
-	0x70, 0x72, 0x6f, 0x76, 0x65, 0x53, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x53, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78,
-	0x22, 0x1b, 0x0a, 0x09, 0x54, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a,
-	0x02, 0x74, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x74, 0x78, 0x22, 0x49, 0x0a,
-	0x13, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x78, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
-	0x52, 0x02, 0x74, 0x78, 0x12, 0x22, 0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x53, 0x69, 0x6e,
-	0x63, 0x65, 0x54, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x76,
-	0x65, 0x53, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78, 0x22, 0x57, 0x0a, 0x0d, 0x54, 0x78, 0x53, 0x63,
-	0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x6e, 0x69,
-	0x74, 0x69, 0x61, 0x6c, 0x54, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x69, 0x6e,
-	0x69, 0x74, 0x69, 0x61, 0x6c, 0x54, 0x78, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x12, 0x0a,
-	0x04, 0x64, 0x65, 0x73, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x64, 0x65, 0x73,
-	0x63, 0x22, 0x2d, 0x0a, 0x06, 0x54, 0x78, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x03, 0x74,
-	0x78, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64,
-	0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54, 0x78, 0x52, 0x03, 0x74, 0x78, 0x73,
-	0x22, 0x2e, 0x0a, 0x08, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x0c,
-	0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x4e, 0x61, 0x6d, 0x65,
-	0x22, 0x25, 0x0a, 0x05, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x61, 0x62,
-	0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x61,
-	0x62, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x86, 0x01, 0x0a, 0x0d, 0x53, 0x51, 0x4c, 0x47,
-	0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x61, 0x62,
-	0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12,
-	0x31, 0x0a, 0x07, 0x70, 0x6b, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x17, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
-	0x2e, 0x53, 0x51, 0x4c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x07, 0x70, 0x6b, 0x56, 0x61, 0x6c,
-	0x75, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x74, 0x54, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04,
-	0x52, 0x04, 0x61, 0x74, 0x54, 0x78, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x54,
-	0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78,
-	0x22, 0x81, 0x01, 0x0a, 0x17, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x53,
-	0x51, 0x4c, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x42, 0x0a, 0x0d,
-	0x73, 0x71, 0x6c, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68,
-	0x65, 0x6d, 0x61, 0x2e, 0x53, 0x51, 0x4c, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x52, 0x0d, 0x73, 0x71, 0x6c, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x22, 0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x53, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x53, 0x69, 0x6e,
-	0x63, 0x65, 0x54, 0x78, 0x22, 0x42, 0x0a, 0x08, 0x53, 0x51, 0x4c, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x12, 0x0e, 0x0a, 0x02, 0x74, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x74, 0x78,
-	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b,
-	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xe9, 0x05, 0x0a, 0x12, 0x56, 0x65, 0x72,
-	0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x51, 0x4c, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
-	0x33, 0x0a, 0x08, 0x73, 0x71, 0x6c, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x17, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d,
-	0x61, 0x2e, 0x53, 0x51, 0x4c, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x73, 0x71, 0x6c, 0x45,
-	0x6e, 0x74, 0x72, 0x79, 0x12, 0x3f, 0x0a, 0x0c, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62,
-	0x6c, 0x65, 0x54, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x69, 0x6d, 0x6d,
-	0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66,
-	0x69, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x78, 0x52, 0x0c, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61,
-	0x62, 0x6c, 0x65, 0x54, 0x78, 0x12, 0x45, 0x0a, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69,
-	0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
-	0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x49, 0x6e,
-	0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x0e, 0x69, 0x6e,
-	0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x1e, 0x0a, 0x0a,
-	0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04,
-	0x52, 0x0a, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07,
-	0x54, 0x61, 0x62, 0x6c, 0x65, 0x49, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x54,
-	0x61, 0x62, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x50, 0x4b, 0x4e, 0x61, 0x6d, 0x65,
-	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x50, 0x4b, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x51,
-	0x0a, 0x0a, 0x43, 0x6f, 0x6c, 0x49, 0x64, 0x73, 0x42, 0x79, 0x49, 0x64, 0x18, 0x08, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x31, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65,
-	0x6d, 0x61, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x51, 0x4c,
-	0x45, 0x6e, 0x74, 0x72, 0x79, 0x2e, 0x43, 0x6f, 0x6c, 0x49, 0x64, 0x73, 0x42, 0x79, 0x49, 0x64,
-	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x43, 0x6f, 0x6c, 0x49, 0x64, 0x73, 0x42, 0x79, 0x49,
-	0x64, 0x12, 0x57, 0x0a, 0x0c, 0x43, 0x6f, 0x6c, 0x49, 0x64, 0x73, 0x42, 0x79, 0x4e, 0x61, 0x6d,
-	0x65, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62,
-	0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62,
-	0x6c, 0x65, 0x53, 0x51, 0x4c, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x2e, 0x43, 0x6f, 0x6c, 0x49, 0x64,
-	0x73, 0x42, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x43, 0x6f,
-	0x6c, 0x49, 0x64, 0x73, 0x42, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x57, 0x0a, 0x0c, 0x43, 0x6f,
-	0x6c, 0x54, 0x79, 0x70, 0x65, 0x73, 0x42, 0x79, 0x49, 0x64, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x33, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
-	0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x51, 0x4c, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x2e, 0x43, 0x6f, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x73, 0x42, 0x79, 0x49, 0x64,
-	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x43, 0x6f, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x73, 0x42,
-	0x79, 0x49, 0x64, 0x1a, 0x3d, 0x0a, 0x0f, 0x43, 0x6f, 0x6c, 0x49, 0x64, 0x73, 0x42, 0x79, 0x49,
-	0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x04, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
-	0x38, 0x01, 0x1a, 0x3f, 0x0a, 0x11, 0x43, 0x6f, 0x6c, 0x49, 0x64, 0x73, 0x42, 0x79, 0x4e, 0x61,
-	0x6d, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
-	0x02, 0x38, 0x01, 0x1a, 0x3f, 0x0a, 0x11, 0x43, 0x6f, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x73, 0x42,
-	0x79, 0x49, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x3a, 0x02, 0x38, 0x01, 0x22, 0x28, 0x0a, 0x10, 0x55, 0x73, 0x65, 0x44, 0x61, 0x74, 0x61, 0x62,
-	0x61, 0x73, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65,
-	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xaa,
-	0x01, 0x0a, 0x17, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73,
-	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x37, 0x0a, 0x06, 0x61, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x69, 0x6d, 0x6d,
-	0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x50, 0x65, 0x72, 0x6d, 0x69,
-	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x61, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12,
-	0x1a, 0x0a, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x70,
-	0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52,
-	0x0a, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x4a, 0x0a, 0x14, 0x53,
-	0x65, 0x74, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x75,
-	0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75,
-	0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x4d, 0x0a, 0x14, 0x44, 0x61, 0x74, 0x61, 0x62,
-	0x61, 0x73, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x35, 0x0a, 0x09, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65,
-	0x6d, 0x61, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x52, 0x09, 0x64, 0x61, 0x74,
-	0x61, 0x62, 0x61, 0x73, 0x65, 0x73, 0x22, 0x21, 0x0a, 0x05, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12,
-	0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0x4e, 0x0a, 0x12, 0x55, 0x73, 0x65,
-	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x18, 0x0a, 0x07, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
-	0x52, 0x07, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x73, 0x42,
-	0x65, 0x66, 0x6f, 0x72, 0x65, 0x54, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x61,
-	0x73, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x54, 0x78, 0x22, 0x6d, 0x0a, 0x0e, 0x53, 0x51, 0x4c,
-	0x45, 0x78, 0x65, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x73,
-	0x71, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x71, 0x6c, 0x12, 0x31, 0x0a,
-	0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e,
-	0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x4e, 0x61,
-	0x6d, 0x65, 0x64, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x52, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73,
-	0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x57, 0x61, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x06, 0x6e, 0x6f, 0x57, 0x61, 0x69, 0x74, 0x22, 0x7c, 0x0a, 0x0f, 0x53, 0x51, 0x4c, 0x51,
-	0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x73,
-	0x71, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x71, 0x6c, 0x12, 0x31, 0x0a,
-	0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e,
-	0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x4e, 0x61,
-	0x6d, 0x65, 0x64, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x52, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73,
-	0x12, 0x24, 0x0a, 0x0d, 0x72, 0x65, 0x75, 0x73, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
-	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x72, 0x65, 0x75, 0x73, 0x65, 0x53, 0x6e,
-	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x22, 0x4f, 0x0a, 0x0a, 0x4e, 0x61, 0x6d, 0x65, 0x64, 0x50,
-	0x61, 0x72, 0x61, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2d, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62,
-	0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53, 0x51, 0x4c, 0x56, 0x61, 0x6c, 0x75, 0x65,
-	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x6d, 0x0a, 0x0d, 0x53, 0x51, 0x4c, 0x45, 0x78,
-	0x65, 0x63, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x2d, 0x0a, 0x04, 0x63, 0x74, 0x78, 0x73,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e,
-	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54, 0x78, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
-	0x61, 0x52, 0x04, 0x63, 0x74, 0x78, 0x73, 0x12, 0x2d, 0x0a, 0x04, 0x64, 0x74, 0x78, 0x73, 0x18,
-	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73,
-	0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54, 0x78, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
-	0x52, 0x04, 0x64, 0x74, 0x78, 0x73, 0x22, 0x69, 0x0a, 0x0e, 0x53, 0x51, 0x4c, 0x51, 0x75, 0x65,
-	0x72, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x2f, 0x0a, 0x07, 0x63, 0x6f, 0x6c, 0x75,
-	0x6d, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x69, 0x6d, 0x6d, 0x75,
-	0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e,
-	0x52, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x12, 0x26, 0x0a, 0x04, 0x72, 0x6f, 0x77,
-	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62,
-	0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x52, 0x6f, 0x77, 0x52, 0x04, 0x72, 0x6f, 0x77,
-	0x73, 0x22, 0x30, 0x0a, 0x06, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
-	0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74,
-	0x79, 0x70, 0x65, 0x22, 0x50, 0x0a, 0x03, 0x52, 0x6f, 0x77, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f,
-	0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6c,
-	0x75, 0x6d, 0x6e, 0x73, 0x12, 0x2f, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x02,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63,
-	0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53, 0x51, 0x4c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x06, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0x87, 0x01, 0x0a, 0x08, 0x53, 0x51, 0x4c, 0x56, 0x61, 0x6c,
-	0x75, 0x65, 0x12, 0x30, 0x0a, 0x04, 0x6e, 0x75, 0x6c, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
-	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x4e, 0x75, 0x6c, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x04,
-	0x6e, 0x75, 0x6c, 0x6c, 0x12, 0x0e, 0x0a, 0x01, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x48,
-	0x00, 0x52, 0x01, 0x6e, 0x12, 0x0e, 0x0a, 0x01, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48,
-	0x00, 0x52, 0x01, 0x73, 0x12, 0x0e, 0x0a, 0x01, 0x62, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x48,
-	0x00, 0x52, 0x01, 0x62, 0x12, 0x10, 0x0a, 0x02, 0x62, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c,
-	0x48, 0x00, 0x52, 0x02, 0x62, 0x73, 0x42, 0x07, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x2a,
-	0x29, 0x0a, 0x10, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x41, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x12, 0x09, 0x0a, 0x05, 0x47, 0x52, 0x41, 0x4e, 0x54, 0x10, 0x00, 0x12, 0x0a,
-	0x0a, 0x06, 0x52, 0x45, 0x56, 0x4f, 0x4b, 0x45, 0x10, 0x01, 0x32, 0xdc, 0x21, 0x0a, 0x0b, 0x49,
-	0x6d, 0x6d, 0x75, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x50, 0x0a, 0x09, 0x4c, 0x69,
-	0x73, 0x74, 0x55, 0x73, 0x65, 0x72, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a,
-	0x17, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e,
-	0x55, 0x73, 0x65, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x12, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0c,
-	0x12, 0x0a, 0x2f, 0x75, 0x73, 0x65, 0x72, 0x2f, 0x6c, 0x69, 0x73, 0x74, 0x12, 0x58, 0x0a, 0x0a,
-	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x12, 0x20, 0x2e, 0x69, 0x6d, 0x6d,
-	0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
-	0x6d, 0x70, 0x74, 0x79, 0x22, 0x10, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0a, 0x22, 0x05, 0x2f, 0x75,
-	0x73, 0x65, 0x72, 0x3a, 0x01, 0x2a, 0x12, 0x70, 0x0a, 0x0e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
-	0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x24, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64,
-	0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x50,
-	0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16,
-	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x20, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1a, 0x22, 0x15,
-	0x2f, 0x75, 0x73, 0x65, 0x72, 0x2f, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x2f, 0x63,
-	0x68, 0x61, 0x6e, 0x67, 0x65, 0x3a, 0x01, 0x2a, 0x12, 0x47, 0x0a, 0x10, 0x55, 0x70, 0x64, 0x61,
-	0x74, 0x65, 0x41, 0x75, 0x74, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x19, 0x2e, 0x69,
-	0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x41, 0x75, 0x74,
-	0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22,
-	0x00, 0x12, 0x47, 0x0a, 0x10, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4d, 0x54, 0x4c, 0x53, 0x43,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73,
-	0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x4d, 0x54, 0x4c, 0x53, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x5a, 0x0a, 0x05, 0x4c, 0x6f,
-	0x67, 0x69, 0x6e, 0x12, 0x1b, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68,
-	0x65, 0x6d, 0x61, 0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x1c, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
-	0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x16,
-	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0b, 0x22, 0x06, 0x2f, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x3a, 0x01,
-	0x2a, 0x92, 0x41, 0x02, 0x62, 0x00, 0x12, 0x4c, 0x0a, 0x06, 0x4c, 0x6f, 0x67, 0x6f, 0x75, 0x74,
-	0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
-	0x22, 0x12, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0c, 0x22, 0x07, 0x2f, 0x6c, 0x6f, 0x67, 0x6f, 0x75,
-	0x74, 0x3a, 0x01, 0x2a, 0x12, 0x4f, 0x0a, 0x03, 0x53, 0x65, 0x74, 0x12, 0x19, 0x2e, 0x69, 0x6d,
-	0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53, 0x65, 0x74, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e,
-	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54, 0x78, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
-	0x61, 0x22, 0x12, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0c, 0x22, 0x07, 0x2f, 0x64, 0x62, 0x2f, 0x73,
-	0x65, 0x74, 0x3a, 0x01, 0x2a, 0x12, 0x70, 0x0a, 0x0d, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61,
-	0x62, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x12, 0x23, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e,
-	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c,
-	0x65, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x69, 0x6d,
-	0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x56, 0x65, 0x72, 0x69,
-	0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x78, 0x22, 0x1d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x17,
-	0x22, 0x12, 0x2f, 0x64, 0x62, 0x2f, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65,
-	0x2f, 0x73, 0x65, 0x74, 0x3a, 0x01, 0x2a, 0x12, 0x4d, 0x0a, 0x03, 0x47, 0x65, 0x74, 0x12, 0x19,
-	0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x4b,
-	0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x69, 0x6d, 0x6d, 0x75,
-	0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x22,
-	0x15, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0f, 0x12, 0x0d, 0x2f, 0x64, 0x62, 0x2f, 0x67, 0x65, 0x74,
-	0x2f, 0x7b, 0x6b, 0x65, 0x79, 0x7d, 0x12, 0x73, 0x0a, 0x0d, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69,
-	0x61, 0x62, 0x6c, 0x65, 0x47, 0x65, 0x74, 0x12, 0x23, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62,
-	0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62,
-	0x6c, 0x65, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x69,
-	0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x56, 0x65, 0x72,
-	0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x22, 0x1d, 0x82, 0xd3,
-	0xe4, 0x93, 0x02, 0x17, 0x22, 0x12, 0x2f, 0x64, 0x62, 0x2f, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69,
-	0x61, 0x62, 0x6c, 0x65, 0x2f, 0x67, 0x65, 0x74, 0x3a, 0x01, 0x2a, 0x12, 0x56, 0x0a, 0x06, 0x47,
-	0x65, 0x74, 0x41, 0x6c, 0x6c, 0x12, 0x1d, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73,
-	0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x4b, 0x65, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63,
-	0x68, 0x65, 0x6d, 0x61, 0x2e, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0x15, 0x82, 0xd3,
-	0xe4, 0x93, 0x02, 0x0f, 0x22, 0x0a, 0x2f, 0x64, 0x62, 0x2f, 0x67, 0x65, 0x74, 0x61, 0x6c, 0x6c,
-	0x3a, 0x01, 0x2a, 0x12, 0x5b, 0x0a, 0x07, 0x45, 0x78, 0x65, 0x63, 0x41, 0x6c, 0x6c, 0x12, 0x1d,
-	0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x45,
-	0x78, 0x65, 0x63, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e,
-	0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54, 0x78,
-	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x16, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x10,
-	0x22, 0x0b, 0x2f, 0x64, 0x62, 0x2f, 0x65, 0x78, 0x65, 0x63, 0x61, 0x6c, 0x6c, 0x3a, 0x01, 0x2a,
-	0x12, 0x4f, 0x0a, 0x04, 0x53, 0x63, 0x61, 0x6e, 0x12, 0x1a, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64,
-	0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53, 0x63, 0x61, 0x6e, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63,
-	0x68, 0x65, 0x6d, 0x61, 0x2e, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0x13, 0x82, 0xd3,
-	0xe4, 0x93, 0x02, 0x0d, 0x22, 0x08, 0x2f, 0x64, 0x62, 0x2f, 0x73, 0x63, 0x61, 0x6e, 0x3a, 0x01,
-	0x2a, 0x12, 0x58, 0x0a, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x18, 0x2e, 0x69, 0x6d, 0x6d,
-	0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x4b, 0x65, 0x79, 0x50, 0x72,
-	0x65, 0x66, 0x69, 0x78, 0x1a, 0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63,
-	0x68, 0x65, 0x6d, 0x61, 0x2e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22,
-	0x1a, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x14, 0x12, 0x12, 0x2f, 0x64, 0x62, 0x2f, 0x63, 0x6f, 0x75,
-	0x6e, 0x74, 0x2f, 0x7b, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x7d, 0x12, 0x53, 0x0a, 0x08, 0x43,
-	0x6f, 0x75, 0x6e, 0x74, 0x41, 0x6c, 0x6c, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a,
-	0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e,
-	0x45, 0x6e, 0x74, 0x72, 0x79, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x14, 0x82, 0xd3, 0xe4, 0x93,
-	0x02, 0x0e, 0x12, 0x0c, 0x2f, 0x64, 0x62, 0x2f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x61, 0x6c, 0x6c,
-	0x12, 0x4a, 0x0a, 0x06, 0x54, 0x78, 0x42, 0x79, 0x49, 0x64, 0x12, 0x18, 0x2e, 0x69, 0x6d, 0x6d,
-	0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54, 0x78, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63,
-	0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54, 0x78, 0x22, 0x13, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0d, 0x12,
-	0x0b, 0x2f, 0x64, 0x62, 0x2f, 0x74, 0x78, 0x2f, 0x7b, 0x74, 0x78, 0x7d, 0x12, 0x73, 0x0a, 0x10,
-	0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x78, 0x42, 0x79, 0x49, 0x64,
-	0x12, 0x22, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
-	0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x78, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63,
-	0x68, 0x65, 0x6d, 0x61, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x54,
-	0x78, 0x22, 0x1e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x18, 0x12, 0x16, 0x2f, 0x64, 0x62, 0x2f, 0x76,
-	0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x2f, 0x74, 0x78, 0x2f, 0x7b, 0x74, 0x78,
-	0x7d, 0x12, 0x50, 0x0a, 0x06, 0x54, 0x78, 0x53, 0x63, 0x61, 0x6e, 0x12, 0x1c, 0x2e, 0x69, 0x6d,
-	0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54, 0x78, 0x53, 0x63,
-	0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x69, 0x6d, 0x6d, 0x75,
-	0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54, 0x78, 0x4c, 0x69, 0x73, 0x74,
-	0x22, 0x11, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0b, 0x22, 0x06, 0x2f, 0x64, 0x62, 0x2f, 0x74, 0x78,
-	0x3a, 0x01, 0x2a, 0x12, 0x58, 0x0a, 0x07, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x1d,
-	0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x48,
-	0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e,
-	0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x45, 0x6e,
-	0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0x16, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x10, 0x22, 0x0b, 0x2f,
-	0x64, 0x62, 0x2f, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x3a, 0x01, 0x2a, 0x12, 0x55, 0x0a,
-	0x06, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a,
-	0x1d, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e,
-	0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x14,
-	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x09, 0x12, 0x07, 0x2f, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x92,
-	0x41, 0x02, 0x62, 0x00, 0x12, 0x5d, 0x0a, 0x0c, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x53,
-	0x74, 0x61, 0x74, 0x65, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1d, 0x2e, 0x69,
-	0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x49, 0x6d, 0x6d,
-	0x75, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x22, 0x16, 0x82, 0xd3, 0xe4,
-	0x93, 0x02, 0x0b, 0x12, 0x09, 0x2f, 0x64, 0x62, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x92, 0x41,
-	0x02, 0x62, 0x00, 0x12, 0x67, 0x0a, 0x0c, 0x53, 0x65, 0x74, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65,
-	0x6e, 0x63, 0x65, 0x12, 0x1f, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68,
-	0x65, 0x6d, 0x61, 0x2e, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63,
-	0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54, 0x78, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22,
-	0x1b, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x15, 0x22, 0x10, 0x2f, 0x64, 0x62, 0x2f, 0x73, 0x65, 0x74,
-	0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x3a, 0x01, 0x2a, 0x12, 0x88, 0x01, 0x0a,
-	0x16, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x52, 0x65,
-	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x29, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62,
-	0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62,
-	0x6c, 0x65, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65,
-	0x6d, 0x61, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x78, 0x22,
-	0x26, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x20, 0x22, 0x1b, 0x2f, 0x64, 0x62, 0x2f, 0x76, 0x65, 0x72,
-	0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x2f, 0x73, 0x65, 0x74, 0x72, 0x65, 0x66, 0x65, 0x72,
-	0x65, 0x6e, 0x63, 0x65, 0x3a, 0x01, 0x2a, 0x12, 0x52, 0x0a, 0x04, 0x5a, 0x41, 0x64, 0x64, 0x12,
-	0x1a, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e,
-	0x5a, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x69, 0x6d,
-	0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54, 0x78, 0x4d, 0x65,
-	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x13, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0d, 0x22, 0x08,
-	0x2f, 0x64, 0x62, 0x2f, 0x7a, 0x61, 0x64, 0x64, 0x3a, 0x01, 0x2a, 0x12, 0x73, 0x0a, 0x0e, 0x56,
-	0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x5a, 0x41, 0x64, 0x64, 0x12, 0x24, 0x2e,
-	0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x56, 0x65,
-	0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x5a, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68,
-	0x65, 0x6d, 0x61, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x78,
-	0x22, 0x1e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x18, 0x22, 0x13, 0x2f, 0x64, 0x62, 0x2f, 0x76, 0x65,
-	0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x2f, 0x7a, 0x61, 0x64, 0x64, 0x3a, 0x01, 0x2a,
-	0x12, 0x53, 0x0a, 0x05, 0x5a, 0x53, 0x63, 0x61, 0x6e, 0x12, 0x1b, 0x2e, 0x69, 0x6d, 0x6d, 0x75,
-	0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x5a, 0x53, 0x63, 0x61, 0x6e, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e,
-	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x5a, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22,
-	0x14, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0e, 0x22, 0x09, 0x2f, 0x64, 0x62, 0x2f, 0x7a, 0x73, 0x63,
-	0x61, 0x6e, 0x3a, 0x01, 0x2a, 0x12, 0x58, 0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44,
-	0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12, 0x17, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62,
-	0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65,
-	0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x15, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0f,
-	0x22, 0x0a, 0x2f, 0x64, 0x62, 0x2f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x3a, 0x01, 0x2a, 0x12,
-	0x60, 0x0a, 0x0c, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x12,
-	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x23, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62,
-	0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65,
-	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x13, 0x82, 0xd3,
-	0xe4, 0x93, 0x02, 0x0d, 0x22, 0x08, 0x2f, 0x64, 0x62, 0x2f, 0x6c, 0x69, 0x73, 0x74, 0x3a, 0x01,
-	0x2a, 0x12, 0x67, 0x0a, 0x0b, 0x55, 0x73, 0x65, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65,
-	0x12, 0x17, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
-	0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x1a, 0x1f, 0x2e, 0x69, 0x6d, 0x6d, 0x75,
-	0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x55, 0x73, 0x65, 0x44, 0x61, 0x74,
-	0x61, 0x62, 0x61, 0x73, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x1e, 0x82, 0xd3, 0xe4, 0x93,
-	0x02, 0x18, 0x12, 0x16, 0x2f, 0x64, 0x62, 0x2f, 0x75, 0x73, 0x65, 0x2f, 0x7b, 0x64, 0x61, 0x74,
-	0x61, 0x62, 0x61, 0x73, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x7d, 0x12, 0x54, 0x0a, 0x0a, 0x43, 0x6c,
-	0x65, 0x61, 0x6e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
-	0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x16, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x10,
-	0x12, 0x0e, 0x2f, 0x64, 0x62, 0x2f, 0x63, 0x6c, 0x65, 0x61, 0x6e, 0x69, 0x6e, 0x64, 0x65, 0x78,
-	0x12, 0x75, 0x0a, 0x10, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73,
-	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x26, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63,
-	0x68, 0x65, 0x6d, 0x61, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x65, 0x72, 0x6d, 0x69,
-	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
-	0x6d, 0x70, 0x74, 0x79, 0x22, 0x21, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1b, 0x22, 0x16, 0x2f, 0x75,
-	0x73, 0x65, 0x72, 0x2f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73,
-	0x73, 0x69, 0x6f, 0x6e, 0x3a, 0x01, 0x2a, 0x12, 0x6c, 0x0a, 0x0d, 0x53, 0x65, 0x74, 0x41, 0x63,
-	0x74, 0x69, 0x76, 0x65, 0x55, 0x73, 0x65, 0x72, 0x12, 0x23, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64,
-	0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53, 0x65, 0x74, 0x41, 0x63, 0x74, 0x69,
-	0x76, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x1e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x18, 0x22, 0x13, 0x2f,
-	0x75, 0x73, 0x65, 0x72, 0x2f, 0x73, 0x65, 0x74, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x55, 0x73,
-	0x65, 0x72, 0x3a, 0x01, 0x2a, 0x12, 0x40, 0x0a, 0x09, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x47,
-	0x65, 0x74, 0x12, 0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65,
-	0x6d, 0x61, 0x2e, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e,
-	0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x43, 0x68,
-	0x75, 0x6e, 0x6b, 0x22, 0x00, 0x30, 0x01, 0x12, 0x40, 0x0a, 0x09, 0x73, 0x74, 0x72, 0x65, 0x61,
-	0x6d, 0x53, 0x65, 0x74, 0x12, 0x14, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63,
-	0x68, 0x65, 0x6d, 0x61, 0x2e, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x1a, 0x19, 0x2e, 0x69, 0x6d, 0x6d,
-	0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54, 0x78, 0x4d, 0x65, 0x74,
-	0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x00, 0x28, 0x01, 0x12, 0x54, 0x0a, 0x13, 0x73, 0x74, 0x72,
-	0x65, 0x61, 0x6d, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x47, 0x65, 0x74,
-	0x12, 0x23, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
-	0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x47, 0x65, 0x74, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73,
-	0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x22, 0x00, 0x30, 0x01, 0x12,
-	0x4c, 0x0a, 0x13, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61,
-	0x62, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x12, 0x14, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e,
-	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x1a, 0x1b, 0x2e, 0x69,
-	0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x56, 0x65, 0x72,
-	0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x78, 0x22, 0x00, 0x28, 0x01, 0x12, 0x42, 0x0a,
-	0x0a, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x53, 0x63, 0x61, 0x6e, 0x12, 0x1a, 0x2e, 0x69, 0x6d,
-	0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53, 0x63, 0x61, 0x6e,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62,
-	0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x22, 0x00, 0x30,
-	0x01, 0x12, 0x44, 0x0a, 0x0b, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x5a, 0x53, 0x63, 0x61, 0x6e,
-	0x12, 0x1b, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
-	0x2e, 0x5a, 0x53, 0x63, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e,
-	0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x43, 0x68,
-	0x75, 0x6e, 0x6b, 0x22, 0x00, 0x30, 0x01, 0x12, 0x48, 0x0a, 0x0d, 0x73, 0x74, 0x72, 0x65, 0x61,
-	0x6d, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x1d, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64,
-	0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62,
-	0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x22, 0x00, 0x30,
-	0x01, 0x12, 0x44, 0x0a, 0x0d, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x45, 0x78, 0x65, 0x63, 0x41,
-	0x6c, 0x6c, 0x12, 0x14, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65,
-	0x6d, 0x61, 0x2e, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x1a, 0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64,
-	0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54, 0x78, 0x4d, 0x65, 0x74, 0x61, 0x64,
-	0x61, 0x74, 0x61, 0x22, 0x00, 0x28, 0x01, 0x12, 0x61, 0x0a, 0x0b, 0x55, 0x73, 0x65, 0x53, 0x6e,
-	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x21, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e,
-	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x55, 0x73, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
-	0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
-	0x79, 0x22, 0x17, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x11, 0x12, 0x0f, 0x2f, 0x64, 0x62, 0x2f, 0x75,
-	0x73, 0x65, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x5e, 0x0a, 0x07, 0x53, 0x51,
-	0x4c, 0x45, 0x78, 0x65, 0x63, 0x12, 0x1d, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73,
-	0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53, 0x51, 0x4c, 0x45, 0x78, 0x65, 0x63, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63,
-	0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53, 0x51, 0x4c, 0x45, 0x78, 0x65, 0x63, 0x52, 0x65, 0x73, 0x75,
-	0x6c, 0x74, 0x22, 0x16, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x10, 0x22, 0x0b, 0x2f, 0x64, 0x62, 0x2f,
-	0x73, 0x71, 0x6c, 0x65, 0x78, 0x65, 0x63, 0x3a, 0x01, 0x2a, 0x12, 0x62, 0x0a, 0x08, 0x53, 0x51,
-	0x4c, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x1e, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e,
-	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53, 0x51, 0x4c, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e,
-	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53, 0x51, 0x4c, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52,
-	0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x17, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x11, 0x22, 0x0c, 0x2f,
-	0x64, 0x62, 0x2f, 0x73, 0x71, 0x6c, 0x71, 0x75, 0x65, 0x72, 0x79, 0x3a, 0x01, 0x2a, 0x12, 0x5b,
-	0x0a, 0x0a, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x12, 0x16, 0x2e, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
-	0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1d, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63,
-	0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53, 0x51, 0x4c, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73,
-	0x75, 0x6c, 0x74, 0x22, 0x16, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x10, 0x12, 0x0e, 0x2f, 0x64, 0x62,
-	0x2f, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x2f, 0x6c, 0x69, 0x73, 0x74, 0x12, 0x5b, 0x0a, 0x0d, 0x44,
-	0x65, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x14, 0x2e, 0x69,
-	0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54, 0x61, 0x62,
-	0x6c, 0x65, 0x1a, 0x1d, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65,
-	0x6d, 0x61, 0x2e, 0x53, 0x51, 0x4c, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c,
-	0x74, 0x22, 0x15, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0f, 0x22, 0x0a, 0x2f, 0x64, 0x62, 0x2f, 0x74,
-	0x61, 0x62, 0x6c, 0x65, 0x73, 0x3a, 0x01, 0x2a, 0x12, 0x7f, 0x0a, 0x10, 0x56, 0x65, 0x72, 0x69,
-	0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x51, 0x4c, 0x47, 0x65, 0x74, 0x12, 0x26, 0x2e, 0x69,
-	0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x56, 0x65, 0x72,
-	0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x51, 0x4c, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63,
-	0x68, 0x65, 0x6d, 0x61, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x53,
-	0x51, 0x4c, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x22, 0x20, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1a, 0x22,
-	0x15, 0x2f, 0x64, 0x62, 0x2f, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x2f,
-	0x73, 0x71, 0x6c, 0x67, 0x65, 0x74, 0x3a, 0x01, 0x2a, 0x42, 0x8b, 0x03, 0x5a, 0x2b, 0x67, 0x69,
-	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6f, 0x64, 0x65, 0x6e, 0x6f, 0x74,
-	0x61, 0x72, 0x79, 0x2f, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61,
-	0x70, 0x69, 0x2f, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x92, 0x41, 0xda, 0x02, 0x12, 0xee, 0x01,
-	0x0a, 0x0f, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x20, 0x52, 0x45, 0x53, 0x54, 0x20, 0x41, 0x50,
-	0x49, 0x12, 0xda, 0x01, 0x3c, 0x62, 0x3e, 0x49, 0x4d, 0x50, 0x4f, 0x52, 0x54, 0x41, 0x4e, 0x54,
-	0x3c, 0x2f, 0x62, 0x3e, 0x3a, 0x20, 0x41, 0x6c, 0x6c, 0x20, 0x3c, 0x63, 0x6f, 0x64, 0x65, 0x3e,
-	0x67, 0x65, 0x74, 0x3c, 0x2f, 0x63, 0x6f, 0x64, 0x65, 0x3e, 0x20, 0x61, 0x6e, 0x64, 0x20, 0x3c,
-	0x63, 0x6f, 0x64, 0x65, 0x3e, 0x73, 0x61, 0x66, 0x65, 0x67, 0x65, 0x74, 0x3c, 0x2f, 0x63, 0x6f,
-	0x64, 0x65, 0x3e, 0x20, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x20, 0x72, 0x65,
-	0x74, 0x75, 0x72, 0x6e, 0x20, 0x3c, 0x75, 0x3e, 0x62, 0x61, 0x73, 0x65, 0x36, 0x34, 0x2d, 0x65,
-	0x6e, 0x63, 0x6f, 0x64, 0x65, 0x64, 0x3c, 0x2f, 0x75, 0x3e, 0x20, 0x6b, 0x65, 0x79, 0x73, 0x20,
-	0x61, 0x6e, 0x64, 0x20, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x2c, 0x20, 0x77, 0x68, 0x69, 0x6c,
-	0x65, 0x20, 0x61, 0x6c, 0x6c, 0x20, 0x3c, 0x63, 0x6f, 0x64, 0x65, 0x3e, 0x73, 0x65, 0x74, 0x3c,
-	0x2f, 0x63, 0x6f, 0x64, 0x65, 0x3e, 0x20, 0x61, 0x6e, 0x64, 0x20, 0x3c, 0x63, 0x6f, 0x64, 0x65,
-	0x3e, 0x73, 0x61, 0x66, 0x65, 0x73, 0x65, 0x74, 0x3c, 0x2f, 0x63, 0x6f, 0x64, 0x65, 0x3e, 0x20,
-	0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x20, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74,
-	0x20, 0x3c, 0x75, 0x3e, 0x62, 0x61, 0x73, 0x65, 0x36, 0x34, 0x2d, 0x65, 0x6e, 0x63, 0x6f, 0x64,
-	0x65, 0x64, 0x3c, 0x2f, 0x75, 0x3e, 0x20, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x2e, 0x5a, 0x59,
-	0x0a, 0x57, 0x0a, 0x06, 0x62, 0x65, 0x61, 0x72, 0x65, 0x72, 0x12, 0x4d, 0x08, 0x02, 0x12, 0x38,
-	0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x20, 0x74,
-	0x6f, 0x6b, 0x65, 0x6e, 0x2c, 0x20, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x65, 0x64, 0x20, 0x62,
-	0x79, 0x20, 0x42, 0x65, 0x61, 0x72, 0x65, 0x72, 0x3a, 0x20, 0x42, 0x65, 0x61, 0x72, 0x65, 0x72,
-	0x20, 0x3c, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x3e, 0x1a, 0x0d, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72,
-	0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x20, 0x02, 0x62, 0x0c, 0x0a, 0x0a, 0x0a, 0x06, 0x62,
-	0x65, 0x61, 0x72, 0x65, 0x72, 0x12, 0x00, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+type TxList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Txs []*Tx `protobuf:"bytes,1,rep,name=txs,proto3" json:"txs,omitempty"`
 }
 
-var (
-	file_schema_proto_rawDescOnce sync.Once
-	file_schema_proto_rawDescData = file_schema_proto_rawDesc
-)
+func (x *TxList) Reset() {
+	*x = TxList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[71]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_schema_proto_rawDescGZIP() []byte {
-	file_schema_proto_rawDescOnce.Do(func() {
-		file_schema_proto_rawDescData = protoimpl.X.CompressGZIP(file_schema_proto_rawDescData)
-	})
-	return file_schema_proto_rawDescData
+func (x *TxList) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_schema_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_schema_proto_msgTypes = make([]protoimpl.MessageInfo, 72)
-var file_schema_proto_goTypes = []interface{}{
-	(PermissionAction)(0),              // 0: immudb.schema.PermissionAction
-	(*Key)(nil),                        // 1: immudb.schema.Key
-	(*Permission)(nil),                 // 2: immudb.schema.Permission
-	(*User)(nil),                       // 3: immudb.schema.User
-	(*UserList)(nil),                   // 4: immudb.schema.UserList
-	(*CreateUserRequest)(nil),          // 5: immudb.schema.CreateUserRequest
-	(*UserRequest)(nil),                // 6: immudb.schema.UserRequest
-	(*ChangePasswordRequest)(nil),      // 7: immudb.schema.ChangePasswordRequest
-	(*LoginRequest)(nil),               // 8: immudb.schema.LoginRequest
-	(*LoginResponse)(nil),              // 9: immudb.schema.LoginResponse
-	(*AuthConfig)(nil),                 // 10: immudb.schema.AuthConfig
-	(*MTLSConfig)(nil),                 // 11: immudb.schema.MTLSConfig
-	(*KeyValue)(nil),                   // 12: immudb.schema.KeyValue
-	(*Entry)(nil),                      // 13: immudb.schema.Entry
-	(*Reference)(nil),                  // 14: immudb.schema.Reference
-	(*Op)(nil),                         // 15: immudb.schema.Op
-	(*ExecAllRequest)(nil),             // 16: immudb.schema.ExecAllRequest
-	(*Entries)(nil),                    // 17: immudb.schema.Entries
-	(*ZEntry)(nil),                     // 18: immudb.schema.ZEntry
-	(*ZEntries)(nil),                   // 19: immudb.schema.ZEntries
-	(*ScanRequest)(nil),                // 20: immudb.schema.ScanRequest
-	(*KeyPrefix)(nil),                  // 21: immudb.schema.KeyPrefix
-	(*EntryCount)(nil),                 // 22: immudb.schema.EntryCount
-	(*Signature)(nil),                  // 23: immudb.schema.Signature
-	(*TxMetadata)(nil),                 // 24: immudb.schema.TxMetadata
-	(*LinearProof)(nil),                // 25: immudb.schema.LinearProof
-	(*DualProof)(nil),                  // 26: immudb.schema.DualProof
-	(*Tx)(nil),                         // 27: immudb.schema.Tx
-	(*TxEntry)(nil),                    // 28: immudb.schema.TxEntry
-	(*VerifiableTx)(nil),               // 29: immudb.schema.VerifiableTx
-	(*VerifiableEntry)(nil),            // 30: immudb.schema.VerifiableEntry
-	(*InclusionProof)(nil),             // 31: immudb.schema.InclusionProof
-	(*SetRequest)(nil),                 // 32: immudb.schema.SetRequest
-	(*KeyRequest)(nil),                 // 33: immudb.schema.KeyRequest
-	(*KeyListRequest)(nil),             // 34: immudb.schema.KeyListRequest
-	(*VerifiableSetRequest)(nil),       // 35: immudb.schema.VerifiableSetRequest
-	(*VerifiableGetRequest)(nil),       // 36: immudb.schema.VerifiableGetRequest
-	(*HealthResponse)(nil),             // 37: immudb.schema.HealthResponse
-	(*ImmutableState)(nil),             // 38: immudb.schema.ImmutableState
-	(*ReferenceRequest)(nil),           // 39: immudb.schema.ReferenceRequest
-	(*VerifiableReferenceRequest)(nil), // 40: immudb.schema.VerifiableReferenceRequest
-	(*ZAddRequest)(nil),                // 41: immudb.schema.ZAddRequest
-	(*Score)(nil),                      // 42: immudb.schema.Score
-	(*ZScanRequest)(nil),               // 43: immudb.schema.ZScanRequest
-	(*HistoryRequest)(nil),             // 44: immudb.schema.HistoryRequest
-	(*VerifiableZAddRequest)(nil),      // 45: immudb.schema.VerifiableZAddRequest
-	(*TxRequest)(nil),                  // 46: immudb.schema.TxRequest
-	(*VerifiableTxRequest)(nil),        // 47: immudb.schema.VerifiableTxRequest
-	(*TxScanRequest)(nil),              // 48: immudb.schema.TxScanRequest
-	(*TxList)(nil),                     // 49: immudb.schema.TxList
-	(*Database)(nil),                   // 50: immudb.schema.Database
-	(*Table)(nil),                      // 51: immudb.schema.Table
-	(*SQLGetRequest)(nil),              // 52: immudb.schema.SQLGetRequest
-	(*VerifiableSQLGetRequest)(nil),    // 53: immudb.schema.VerifiableSQLGetRequest
-	(*SQLEntry)(nil),                   // 54: immudb.schema.SQLEntry
-	(*VerifiableSQLEntry)(nil),         // 55: immudb.schema.VerifiableSQLEntry
-	(*UseDatabaseReply)(nil),           // 56: immudb.schema.UseDatabaseReply
-	(*ChangePermissionRequest)(nil),    // 57: immudb.schema.ChangePermissionRequest
-	(*SetActiveUserRequest)(nil),       // 58: immudb.schema.SetActiveUserRequest
-	(*DatabaseListResponse)(nil),       // 59: immudb.schema.DatabaseListResponse
-	(*Chunk)(nil),                      // 60: immudb.schema.Chunk
-	(*UseSnapshotRequest)(nil),         // 61: immudb.schema.UseSnapshotRequest
-	(*SQLExecRequest)(nil),             // 62: immudb.schema.SQLExecRequest
-	(*SQLQueryRequest)(nil),            // 63: immudb.schema.SQLQueryRequest
-	(*NamedParam)(nil),                 // 64: immudb.schema.NamedParam
-	(*SQLExecResult)(nil),              // 65: immudb.schema.SQLExecResult
-	(*SQLQueryResult)(nil),             // 66: immudb.schema.SQLQueryResult
-	(*Column)(nil),                     // 67: immudb.schema.Column
-	(*Row)(nil),                        // 68: immudb.schema.Row
-	(*SQLValue)(nil),                   // 69: immudb.schema.SQLValue
-	nil,                                // 70: immudb.schema.VerifiableSQLEntry.ColIdsByIdEntry
-	nil,                                // 71: immudb.schema.VerifiableSQLEntry.ColIdsByNameEntry
-	nil,                                // 72: immudb.schema.VerifiableSQLEntry.ColTypesByIdEntry
-	(_struct.NullValue)(0),             // 73: google.protobuf.NullValue
-	(*empty.Empty)(nil),                // 74: google.protobuf.Empty
+func (*TxList) ProtoMessage() {}
+
+func (x *TxList) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[71]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_schema_proto_depIdxs = []int32{
-	2,  // 0: immudb.schema.User.permissions:type_name -> immudb.schema.Permission
-	3,  // 1: immudb.schema.UserList.users:type_name -> immudb.schema.User
-	14, // 2: immudb.schema.Entry.referencedBy:type_name -> immudb.schema.Reference
-	12, // 3: immudb.schema.Op.kv:type_name -> immudb.schema.KeyValue
-	41, // 4: immudb.schema.Op.zAdd:type_name -> immudb.schema.ZAddRequest
-	39, // 5: immudb.schema.Op.ref:type_name -> immudb.schema.ReferenceRequest
-	15, // 6: immudb.schema.ExecAllRequest.Operations:type_name -> immudb.schema.Op
-	13, // 7: immudb.schema.Entries.entries:type_name -> immudb.schema.Entry
-	13, // 8: immudb.schema.ZEntry.entry:type_name -> immudb.schema.Entry
-	18, // 9: immudb.schema.ZEntries.entries:type_name -> immudb.schema.ZEntry
-	24, // 10: immudb.schema.DualProof.sourceTxMetadata:type_name -> immudb.schema.TxMetadata
-	24, // 11: immudb.schema.DualProof.targetTxMetadata:type_name -> immudb.schema.TxMetadata
-	25, // 12: immudb.schema.DualProof.linearProof:type_name -> immudb.schema.LinearProof
-	24, // 13: immudb.schema.Tx.metadata:type_name -> immudb.schema.TxMetadata
-	28, // 14: immudb.schema.Tx.entries:type_name -> immudb.schema.TxEntry
-	27, // 15: immudb.schema.VerifiableTx.tx:type_name -> immudb.schema.Tx
-	26, // 16: immudb.schema.VerifiableTx.dualProof:type_name -> immudb.schema.DualProof
-	23, // 17: immudb.schema.VerifiableTx.signature:type_name -> immudb.schema.Signature
-	13, // 18: immudb.schema.VerifiableEntry.entry:type_name -> immudb.schema.Entry
-	29, // 19: immudb.schema.VerifiableEntry.verifiableTx:type_name -> immudb.schema.VerifiableTx
-	31, // 20: immudb.schema.VerifiableEntry.inclusionProof:type_name -> immudb.schema.InclusionProof
-	12, // 21: immudb.schema.SetRequest.KVs:type_name -> immudb.schema.KeyValue
-	32, // 22: immudb.schema.VerifiableSetRequest.setRequest:type_name -> immudb.schema.SetRequest
-	33, // 23: immudb.schema.VerifiableGetRequest.keyRequest:type_name -> immudb.schema.KeyRequest
-	23, // 24: immudb.schema.ImmutableState.signature:type_name -> immudb.schema.Signature
-	39, // 25: immudb.schema.VerifiableReferenceRequest.referenceRequest:type_name -> immudb.schema.ReferenceRequest
-	42, // 26: immudb.schema.ZScanRequest.minScore:type_name -> immudb.schema.Score
-	42, // 27: immudb.schema.ZScanRequest.maxScore:type_name -> immudb.schema.Score
-	41, // 28: immudb.schema.VerifiableZAddRequest.zAddRequest:type_name -> immudb.schema.ZAddRequest
-	27, // 29: immudb.schema.TxList.txs:type_name -> immudb.schema.Tx
-	69, // 30: immudb.schema.SQLGetRequest.pkValue:type_name -> immudb.schema.SQLValue
-	52, // 31: immudb.schema.VerifiableSQLGetRequest.sqlGetRequest:type_name -> immudb.schema.SQLGetRequest
-	54, // 32: immudb.schema.VerifiableSQLEntry.sqlEntry:type_name -> immudb.schema.SQLEntry
-	29, // 33: immudb.schema.VerifiableSQLEntry.verifiableTx:type_name -> immudb.schema.VerifiableTx
-	31, // 34: immudb.schema.VerifiableSQLEntry.inclusionProof:type_name -> immudb.schema.InclusionProof
-	70, // 35: immudb.schema.VerifiableSQLEntry.ColIdsById:type_name -> immudb.schema.VerifiableSQLEntry.ColIdsByIdEntry
-	71, // 36: immudb.schema.VerifiableSQLEntry.ColIdsByName:type_name -> immudb.schema.VerifiableSQLEntry.ColIdsByNameEntry
-	72, // 37: immudb.schema.VerifiableSQLEntry.ColTypesById:type_name -> immudb.schema.VerifiableSQLEntry.ColTypesByIdEntry
-	0,  // 38: immudb.schema.ChangePermissionRequest.action:type_name -> immudb.schema.PermissionAction
-	50, // 39: immudb.schema.DatabaseListResponse.databases:type_name -> immudb.schema.Database
-	64, // 40: immudb.schema.SQLExecRequest.params:type_name -> immudb.schema.NamedParam
-	64, // 41: immudb.schema.SQLQueryRequest.params:type_name -> immudb.schema.NamedParam
-	69, // 42: immudb.schema.NamedParam.value:type_name -> immudb.schema.SQLValue
-	24, // 43: immudb.schema.SQLExecResult.ctxs:type_name -> immudb.schema.TxMetadata
-	24, // 44: immudb.schema.SQLExecResult.dtxs:type_name -> immudb.schema.TxMetadata
-	67, // 45: immudb.schema.SQLQueryResult.columns:type_name -> immudb.schema.Column
-	68, // 46: immudb.schema.SQLQueryResult.rows:type_name -> immudb.schema.Row
-	69, // 47: immudb.schema.Row.values:type_name -> immudb.schema.SQLValue
-	73, // 48: immudb.schema.SQLValue.null:type_name -> google.protobuf.NullValue
-	74, // 49: immudb.schema.ImmuService.ListUsers:input_type -> google.protobuf.Empty
-	5,  // 50: immudb.schema.ImmuService.CreateUser:input_type -> immudb.schema.CreateUserRequest
-	7,  // 51: immudb.schema.ImmuService.ChangePassword:input_type -> immudb.schema.ChangePasswordRequest
-	10, // 52: immudb.schema.ImmuService.UpdateAuthConfig:input_type -> immudb.schema.AuthConfig
-	11, // 53: immudb.schema.ImmuService.UpdateMTLSConfig:input_type -> immudb.schema.MTLSConfig
-	8,  // 54: immudb.schema.ImmuService.Login:input_type -> immudb.schema.LoginRequest
-	74, // 55: immudb.schema.ImmuService.Logout:input_type -> google.protobuf.Empty
-	32, // 56: immudb.schema.ImmuService.Set:input_type -> immudb.schema.SetRequest
-	35, // 57: immudb.schema.ImmuService.VerifiableSet:input_type -> immudb.schema.VerifiableSetRequest
-	33, // 58: immudb.schema.ImmuService.Get:input_type -> immudb.schema.KeyRequest
-	36, // 59: immudb.schema.ImmuService.VerifiableGet:input_type -> immudb.schema.VerifiableGetRequest
-	34, // 60: immudb.schema.ImmuService.GetAll:input_type -> immudb.schema.KeyListRequest
-	16, // 61: immudb.schema.ImmuService.ExecAll:input_type -> immudb.schema.ExecAllRequest
-	20, // 62: immudb.schema.ImmuService.Scan:input_type -> immudb.schema.ScanRequest
-	21, // 63: immudb.schema.ImmuService.Count:input_type -> immudb.schema.KeyPrefix
-	74, // 64: immudb.schema.ImmuService.CountAll:input_type -> google.protobuf.Empty
-	46, // 65: immudb.schema.ImmuService.TxById:input_type -> immudb.schema.TxRequest
-	47, // 66: immudb.schema.ImmuService.VerifiableTxById:input_type -> immudb.schema.VerifiableTxRequest
-	48, // 67: immudb.schema.ImmuService.TxScan:input_type -> immudb.schema.TxScanRequest
-	44, // 68: immudb.schema.ImmuService.History:input_type -> immudb.schema.HistoryRequest
-	74, // 69: immudb.schema.ImmuService.Health:input_type -> google.protobuf.Empty
-	74, // 70: immudb.schema.ImmuService.CurrentState:input_type -> google.protobuf.Empty
-	39, // 71: immudb.schema.ImmuService.SetReference:input_type -> immudb.schema.ReferenceRequest
-	40, // 72: immudb.schema.ImmuService.VerifiableSetReference:input_type -> immudb.schema.VerifiableReferenceRequest
-	41, // 73: immudb.schema.ImmuService.ZAdd:input_type -> immudb.schema.ZAddRequest
-	45, // 74: immudb.schema.ImmuService.VerifiableZAdd:input_type -> immudb.schema.VerifiableZAddRequest
-	43, // 75: immudb.schema.ImmuService.ZScan:input_type -> immudb.schema.ZScanRequest
-	50, // 76: immudb.schema.ImmuService.CreateDatabase:input_type -> immudb.schema.Database
-	74, // 77: immudb.schema.ImmuService.DatabaseList:input_type -> google.protobuf.Empty
-	50, // 78: immudb.schema.ImmuService.UseDatabase:input_type -> immudb.schema.Database
-	74, // 79: immudb.schema.ImmuService.CleanIndex:input_type -> google.protobuf.Empty
-	57, // 80: immudb.schema.ImmuService.ChangePermission:input_type -> immudb.schema.ChangePermissionRequest
-	58, // 81: immudb.schema.ImmuService.SetActiveUser:input_type -> immudb.schema.SetActiveUserRequest
-	33, // 82: immudb.schema.ImmuService.streamGet:input_type -> immudb.schema.KeyRequest
-	60, // 83: immudb.schema.ImmuService.streamSet:input_type -> immudb.schema.Chunk
-	36, // 84: immudb.schema.ImmuService.streamVerifiableGet:input_type -> immudb.schema.VerifiableGetRequest
-	60, // 85: immudb.schema.ImmuService.streamVerifiableSet:input_type -> immudb.schema.Chunk
-	20, // 86: immudb.schema.ImmuService.streamScan:input_type -> immudb.schema.ScanRequest
-	43, // 87: immudb.schema.ImmuService.streamZScan:input_type -> immudb.schema.ZScanRequest
-	44, // 88: immudb.schema.ImmuService.streamHistory:input_type -> immudb.schema.HistoryRequest
-	60, // 89: immudb.schema.ImmuService.streamExecAll:input_type -> immudb.schema.Chunk
-	61, // 90: immudb.schema.ImmuService.UseSnapshot:input_type -> immudb.schema.UseSnapshotRequest
-	62, // 91: immudb.schema.ImmuService.SQLExec:input_type -> immudb.schema.SQLExecRequest
-	63, // 92: immudb.schema.ImmuService.SQLQuery:input_type -> immudb.schema.SQLQueryRequest
-	74, // 93: immudb.schema.ImmuService.ListTables:input_type -> google.protobuf.Empty
-	51, // 94: immudb.schema.ImmuService.DescribeTable:input_type -> immudb.schema.Table
-	53, // 95: immudb.schema.ImmuService.VerifiableSQLGet:input_type -> immudb.schema.VerifiableSQLGetRequest
-	4,  // 96: immudb.schema.ImmuService.ListUsers:output_type -> immudb.schema.UserList
-	74, // 97: immudb.schema.ImmuService.CreateUser:output_type -> google.protobuf.Empty
-	74, // 98: immudb.schema.ImmuService.ChangePassword:output_type -> google.protobuf.Empty
-	74, // 99: immudb.schema.ImmuService.UpdateAuthConfig:output_type -> google.protobuf.Empty
-	74, // 100: immudb.schema.ImmuService.UpdateMTLSConfig:output_type -> google.protobuf.Empty
-	9,  // 101: immudb.schema.ImmuService.Login:output_type -> immudb.schema.LoginResponse
-	74, // 102: immudb.schema.ImmuService.Logout:output_type -> google.protobuf.Empty
-	24, // 103: immudb.schema.ImmuService.Set:output_type -> immudb.schema.TxMetadata
-	29, // 104: immudb.schema.ImmuService.VerifiableSet:output_type -> immudb.schema.VerifiableTx
-	13, // 105: immudb.schema.ImmuService.Get:output_type -> immudb.schema.Entry
-	30, // 106: immudb.schema.ImmuService.VerifiableGet:output_type -> immudb.schema.VerifiableEntry
-	17, // 107: immudb.schema.ImmuService.GetAll:output_type -> immudb.schema.Entries
-	24, // 108: immudb.schema.ImmuService.ExecAll:output_type -> immudb.schema.TxMetadata
-	17, // 109: immudb.schema.ImmuService.Scan:output_type -> immudb.schema.Entries
-	22, // 110: immudb.schema.ImmuService.Count:output_type -> immudb.schema.EntryCount
-	22, // 111: immudb.schema.ImmuService.CountAll:output_type -> immudb.schema.EntryCount
-	27, // 112: immudb.schema.ImmuService.TxById:output_type -> immudb.schema.Tx
-	29, // 113: immudb.schema.ImmuService.VerifiableTxById:output_type -> immudb.schema.VerifiableTx
-	49, // 114: immudb.schema.ImmuService.TxScan:output_type -> immudb.schema.TxList
-	17, // 115: immudb.schema.ImmuService.History:output_type -> immudb.schema.Entries
-	37, // 116: immudb.schema.ImmuService.Health:output_type -> immudb.schema.HealthResponse
-	38, // 117: immudb.schema.ImmuService.CurrentState:output_type -> immudb.schema.ImmutableState
-	24, // 118: immudb.schema.ImmuService.SetReference:output_type -> immudb.schema.TxMetadata
-	29, // 119: immudb.schema.ImmuService.VerifiableSetReference:output_type -> immudb.schema.VerifiableTx
-	24, // 120: immudb.schema.ImmuService.ZAdd:output_type -> immudb.schema.TxMetadata
-	29, // 121: immudb.schema.ImmuService.VerifiableZAdd:output_type -> immudb.schema.VerifiableTx
-	19, // 122: immudb.schema.ImmuService.ZScan:output_type -> immudb.schema.ZEntries
-	74, // 123: immudb.schema.ImmuService.CreateDatabase:output_type -> google.protobuf.Empty
-	59, // 124: immudb.schema.ImmuService.DatabaseList:output_type -> immudb.schema.DatabaseListResponse
-	56, // 125: immudb.schema.ImmuService.UseDatabase:output_type -> immudb.schema.UseDatabaseReply
-	74, // 126: immudb.schema.ImmuService.CleanIndex:output_type -> google.protobuf.Empty
-	74, // 127: immudb.schema.ImmuService.ChangePermission:output_type -> google.protobuf.Empty
-	74, // 128: immudb.schema.ImmuService.SetActiveUser:output_type -> google.protobuf.Empty
-	60, // 129: immudb.schema.ImmuService.streamGet:output_type -> immudb.schema.Chunk
-	24, // 130: immudb.schema.ImmuService.streamSet:output_type -> immudb.schema.TxMetadata
-	60, // 131: immudb.schema.ImmuService.streamVerifiableGet:output_type -> immudb.schema.Chunk
-	29, // 132: immudb.schema.ImmuService.streamVerifiableSet:output_type -> immudb.schema.VerifiableTx
-	60, // 133: immudb.schema.ImmuService.streamScan:output_type -> immudb.schema.Chunk
-	60, // 134: immudb.schema.ImmuService.streamZScan:output_type -> immudb.schema.Chunk
-	60, // 135: immudb.schema.ImmuService.streamHistory:output_type -> immudb.schema.Chunk
-	24, // 136: immudb.schema.ImmuService.streamExecAll:output_type -> immudb.schema.TxMetadata
-	74, // 137: immudb.schema.ImmuService.UseSnapshot:output_type -> google.protobuf.Empty
-	65, // 138: immudb.schema.ImmuService.SQLExec:output_type -> immudb.schema.SQLExecResult
-	66, // 139: immudb.schema.ImmuService.SQLQuery:output_type -> immudb.schema.SQLQueryResult
-	66, // 140: immudb.schema.ImmuService.ListTables:output_type -> immudb.schema.SQLQueryResult
-	66, // 141: immudb.schema.ImmuService.DescribeTable:output_type -> immudb.schema.SQLQueryResult
-	55, // 142: immudb.schema.ImmuService.VerifiableSQLGet:output_type -> immudb.schema.VerifiableSQLEntry
-	96, // [96:143] is the sub-list for method output_type
-	49, // [49:96] is the sub-list for method input_type
-	49, // [49:49] is the sub-list for extension type_name
-	49, // [49:49] is the sub-list for extension extendee
-	0,  // [0:49] is the sub-list for field type_name
+
+// Deprecated: Use TxList.ProtoReflect.Descriptor instead.
+func (*TxList) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{71}
 }
 
-func init() { file_schema_proto_init() }
-func file_schema_proto_init() {
-	if File_schema_proto != nil {
-		return
+func (x *TxList) GetTxs() []*Tx {
+	if x != nil {
+		return x.Txs
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_schema_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Key); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_schema_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Permission); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_schema_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*User); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_schema_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UserList); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+	return nil
+}
+
+type SubscribeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	InitialTx uint64 `protobuf:"varint,1,opt,name=initialTx,proto3" json:"initialTx,omitempty"`
+	Limit     uint32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *SubscribeRequest) Reset() {
+	*x = SubscribeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[72]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRequest) ProtoMessage() {}
+
+func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[72]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateUserRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_schema_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UserRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_schema_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ChangePasswordRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_schema_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LoginRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *SubscribeRequest) GetInitialTx() uint64 {
+	if x != nil {
+		return x.InitialTx
+	}
+	return 0
+}
+
+func (x *SubscribeRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type Database struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DatabaseName string `protobuf:"bytes,1,opt,name=databaseName,proto3" json:"databaseName,omitempty"`
+}
+
+func (x *Database) Reset() {
+	*x = Database{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[73]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Database) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Database) ProtoMessage() {}
+
+func (x *Database) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[73]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LoginResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Database.ProtoReflect.Descriptor instead.
+func (*Database) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *Database) GetDatabaseName() string {
+	if x != nil {
+		return x.DatabaseName
+	}
+	return ""
+}
+
+type DatabaseSettings struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DatabaseName string `protobuf:"bytes,1,opt,name=databaseName,proto3" json:"databaseName,omitempty"`
+	// max length of keys, it can only be set when the database is created
+	MaxKeyLen uint32 `protobuf:"varint,2,opt,name=maxKeyLen,proto3" json:"maxKeyLen,omitempty"`
+	// max length of values, it can only be increased once the database is created
+	MaxValueLen uint32 `protobuf:"varint,3,opt,name=maxValueLen,proto3" json:"maxValueLen,omitempty"`
+	// max number of entries per tx, it can only be set when the database is created
+	MaxTxEntries uint32 `protobuf:"varint,4,opt,name=maxTxEntries,proto3" json:"maxTxEntries,omitempty"`
+}
+
+func (x *DatabaseSettings) Reset() {
+	*x = DatabaseSettings{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[74]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DatabaseSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DatabaseSettings) ProtoMessage() {}
+
+func (x *DatabaseSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[74]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AuthConfig); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DatabaseSettings.ProtoReflect.Descriptor instead.
+func (*DatabaseSettings) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *DatabaseSettings) GetDatabaseName() string {
+	if x != nil {
+		return x.DatabaseName
+	}
+	return ""
+}
+
+func (x *DatabaseSettings) GetMaxKeyLen() uint32 {
+	if x != nil {
+		return x.MaxKeyLen
+	}
+	return 0
+}
+
+func (x *DatabaseSettings) GetMaxValueLen() uint32 {
+	if x != nil {
+		return x.MaxValueLen
+	}
+	return 0
+}
+
+func (x *DatabaseSettings) GetMaxTxEntries() uint32 {
+	if x != nil {
+		return x.MaxTxEntries
+	}
+	return 0
+}
+
+type Table struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TableName string `protobuf:"bytes,1,opt,name=tableName,proto3" json:"tableName,omitempty"`
+}
+
+func (x *Table) Reset() {
+	*x = Table{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[75]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Table) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Table) ProtoMessage() {}
+
+func (x *Table) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[75]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MTLSConfig); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Table.ProtoReflect.Descriptor instead.
+func (*Table) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *Table) GetTableName() string {
+	if x != nil {
+		return x.TableName
+	}
+	return ""
+}
+
+type SQLGetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Table   string    `protobuf:"bytes,1,opt,name=table,proto3" json:"table,omitempty"`
+	PkValue *SQLValue `protobuf:"bytes,2,opt,name=pkValue,proto3" json:"pkValue,omitempty"`
+	AtTx    uint64    `protobuf:"varint,3,opt,name=atTx,proto3" json:"atTx,omitempty"`
+	SinceTx uint64    `protobuf:"varint,4,opt,name=sinceTx,proto3" json:"sinceTx,omitempty"`
+}
+
+func (x *SQLGetRequest) Reset() {
+	*x = SQLGetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[76]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SQLGetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SQLGetRequest) ProtoMessage() {}
+
+func (x *SQLGetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[76]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*KeyValue); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_schema_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Entry); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_schema_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Reference); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_schema_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Op); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SQLGetRequest.ProtoReflect.Descriptor instead.
+func (*SQLGetRequest) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *SQLGetRequest) GetTable() string {
+	if x != nil {
+		return x.Table
+	}
+	return ""
+}
+
+func (x *SQLGetRequest) GetPkValue() *SQLValue {
+	if x != nil {
+		return x.PkValue
+	}
+	return nil
+}
+
+func (x *SQLGetRequest) GetAtTx() uint64 {
+	if x != nil {
+		return x.AtTx
+	}
+	return 0
+}
+
+func (x *SQLGetRequest) GetSinceTx() uint64 {
+	if x != nil {
+		return x.SinceTx
+	}
+	return 0
+}
+
+type VerifiableSQLGetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SqlGetRequest *SQLGetRequest `protobuf:"bytes,1,opt,name=sqlGetRequest,proto3" json:"sqlGetRequest,omitempty"`
+	ProveSinceTx  uint64         `protobuf:"varint,2,opt,name=proveSinceTx,proto3" json:"proveSinceTx,omitempty"`
+}
+
+func (x *VerifiableSQLGetRequest) Reset() {
+	*x = VerifiableSQLGetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[77]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifiableSQLGetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifiableSQLGetRequest) ProtoMessage() {}
+
+func (x *VerifiableSQLGetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[77]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExecAllRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifiableSQLGetRequest.ProtoReflect.Descriptor instead.
+func (*VerifiableSQLGetRequest) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *VerifiableSQLGetRequest) GetSqlGetRequest() *SQLGetRequest {
+	if x != nil {
+		return x.SqlGetRequest
+	}
+	return nil
+}
+
+func (x *VerifiableSQLGetRequest) GetProveSinceTx() uint64 {
+	if x != nil {
+		return x.ProveSinceTx
+	}
+	return 0
+}
+
+type SQLEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tx    uint64 `protobuf:"varint,1,opt,name=tx,proto3" json:"tx,omitempty"`
+	Key   []byte `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Value []byte `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *SQLEntry) Reset() {
+	*x = SQLEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[78]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SQLEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SQLEntry) ProtoMessage() {}
+
+func (x *SQLEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[78]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Entries); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SQLEntry.ProtoReflect.Descriptor instead.
+func (*SQLEntry) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *SQLEntry) GetTx() uint64 {
+	if x != nil {
+		return x.Tx
+	}
+	return 0
+}
+
+func (x *SQLEntry) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *SQLEntry) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type VerifiableSQLEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SqlEntry       *SQLEntry         `protobuf:"bytes,1,opt,name=sqlEntry,proto3" json:"sqlEntry,omitempty"`
+	VerifiableTx   *VerifiableTx     `protobuf:"bytes,2,opt,name=verifiableTx,proto3" json:"verifiableTx,omitempty"`
+	InclusionProof *InclusionProof   `protobuf:"bytes,3,opt,name=inclusionProof,proto3" json:"inclusionProof,omitempty"`
+	DatabaseId     uint64            `protobuf:"varint,4,opt,name=DatabaseId,proto3" json:"DatabaseId,omitempty"`
+	TableId        uint64            `protobuf:"varint,5,opt,name=TableId,proto3" json:"TableId,omitempty"`
+	PKName         string            `protobuf:"bytes,6,opt,name=PKName,proto3" json:"PKName,omitempty"`
+	ColIdsById     map[uint64]string `protobuf:"bytes,8,rep,name=ColIdsById,proto3" json:"ColIdsById,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	ColIdsByName   map[string]uint64 `protobuf:"bytes,9,rep,name=ColIdsByName,proto3" json:"ColIdsByName,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	ColTypesById   map[uint64]string `protobuf:"bytes,10,rep,name=ColTypesById,proto3" json:"ColTypesById,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *VerifiableSQLEntry) Reset() {
+	*x = VerifiableSQLEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[79]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifiableSQLEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifiableSQLEntry) ProtoMessage() {}
+
+func (x *VerifiableSQLEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[79]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ZEntry); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_schema_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ZEntries); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_schema_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ScanRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_schema_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*KeyPrefix); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_schema_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EntryCount); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifiableSQLEntry.ProtoReflect.Descriptor instead.
+func (*VerifiableSQLEntry) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *VerifiableSQLEntry) GetSqlEntry() *SQLEntry {
+	if x != nil {
+		return x.SqlEntry
+	}
+	return nil
+}
+
+func (x *VerifiableSQLEntry) GetVerifiableTx() *VerifiableTx {
+	if x != nil {
+		return x.VerifiableTx
+	}
+	return nil
+}
+
+func (x *VerifiableSQLEntry) GetInclusionProof() *InclusionProof {
+	if x != nil {
+		return x.InclusionProof
+	}
+	return nil
+}
+
+func (x *VerifiableSQLEntry) GetDatabaseId() uint64 {
+	if x != nil {
+		return x.DatabaseId
+	}
+	return 0
+}
+
+func (x *VerifiableSQLEntry) GetTableId() uint64 {
+	if x != nil {
+		return x.TableId
+	}
+	return 0
+}
+
+func (x *VerifiableSQLEntry) GetPKName() string {
+	if x != nil {
+		return x.PKName
+	}
+	return ""
+}
+
+func (x *VerifiableSQLEntry) GetColIdsById() map[uint64]string {
+	if x != nil {
+		return x.ColIdsById
+	}
+	return nil
+}
+
+func (x *VerifiableSQLEntry) GetColIdsByName() map[string]uint64 {
+	if x != nil {
+		return x.ColIdsByName
+	}
+	return nil
+}
+
+func (x *VerifiableSQLEntry) GetColTypesById() map[uint64]string {
+	if x != nil {
+		return x.ColTypesById
+	}
+	return nil
+}
+
+type VerifiableSQLQueryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SqlQueryRequest *SQLQueryRequest `protobuf:"bytes,1,opt,name=sqlQueryRequest,proto3" json:"sqlQueryRequest,omitempty"`
+	ProveSinceTx    uint64           `protobuf:"varint,2,opt,name=proveSinceTx,proto3" json:"proveSinceTx,omitempty"`
+}
+
+func (x *VerifiableSQLQueryRequest) Reset() {
+	*x = VerifiableSQLQueryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[80]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifiableSQLQueryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifiableSQLQueryRequest) ProtoMessage() {}
+
+func (x *VerifiableSQLQueryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[80]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Signature); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifiableSQLQueryRequest.ProtoReflect.Descriptor instead.
+func (*VerifiableSQLQueryRequest) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *VerifiableSQLQueryRequest) GetSqlQueryRequest() *SQLQueryRequest {
+	if x != nil {
+		return x.SqlQueryRequest
+	}
+	return nil
+}
+
+func (x *VerifiableSQLQueryRequest) GetProveSinceTx() uint64 {
+	if x != nil {
+		return x.ProveSinceTx
+	}
+	return 0
+}
+
+type VerifiableSQLRow struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SqlEntry       *SQLEntry       `protobuf:"bytes,1,opt,name=sqlEntry,proto3" json:"sqlEntry,omitempty"`
+	InclusionProof *InclusionProof `protobuf:"bytes,2,opt,name=inclusionProof,proto3" json:"inclusionProof,omitempty"`
+	// proof linking the tx the row was written in to the tx the query was resolved at
+	DualProof *DualProof `protobuf:"bytes,3,opt,name=dualProof,proto3" json:"dualProof,omitempty"`
+}
+
+func (x *VerifiableSQLRow) Reset() {
+	*x = VerifiableSQLRow{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[81]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifiableSQLRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifiableSQLRow) ProtoMessage() {}
+
+func (x *VerifiableSQLRow) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[81]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TxMetadata); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifiableSQLRow.ProtoReflect.Descriptor instead.
+func (*VerifiableSQLRow) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *VerifiableSQLRow) GetSqlEntry() *SQLEntry {
+	if x != nil {
+		return x.SqlEntry
+	}
+	return nil
+}
+
+func (x *VerifiableSQLRow) GetInclusionProof() *InclusionProof {
+	if x != nil {
+		return x.InclusionProof
+	}
+	return nil
+}
+
+func (x *VerifiableSQLRow) GetDualProof() *DualProof {
+	if x != nil {
+		return x.DualProof
+	}
+	return nil
+}
+
+type VerifiableSQLQueryResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result *SQLQueryResult `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	// tx the query was resolved at, proven to be consistent with the tx the proof was requested since
+	VerifiableTx *VerifiableTx `protobuf:"bytes,2,opt,name=verifiableTx,proto3" json:"verifiableTx,omitempty"`
+	// stored rows, in the same order as the returned ones
+	Rows       []*VerifiableSQLRow `protobuf:"bytes,3,rep,name=rows,proto3" json:"rows,omitempty"`
+	DatabaseId uint64              `protobuf:"varint,4,opt,name=DatabaseId,proto3" json:"DatabaseId,omitempty"`
+	TableId    uint64              `protobuf:"varint,5,opt,name=TableId,proto3" json:"TableId,omitempty"`
+	TableName  string              `protobuf:"bytes,6,opt,name=TableName,proto3" json:"TableName,omitempty"`
+	// columns of the primary key, in the order their values make up the keys of the rows
+	PKNames      []string          `protobuf:"bytes,7,rep,name=PKNames,proto3" json:"PKNames,omitempty"`
+	ColIdsByName map[string]uint64 `protobuf:"bytes,8,rep,name=ColIdsByName,proto3" json:"ColIdsByName,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	ColTypesById map[uint64]string `protobuf:"bytes,9,rep,name=ColTypesById,proto3" json:"ColTypesById,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *VerifiableSQLQueryResult) Reset() {
+	*x = VerifiableSQLQueryResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[82]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifiableSQLQueryResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifiableSQLQueryResult) ProtoMessage() {}
+
+func (x *VerifiableSQLQueryResult) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[82]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LinearProof); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_schema_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DualProof); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_schema_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Tx); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_schema_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TxEntry); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifiableSQLQueryResult.ProtoReflect.Descriptor instead.
+func (*VerifiableSQLQueryResult) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *VerifiableSQLQueryResult) GetResult() *SQLQueryResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *VerifiableSQLQueryResult) GetVerifiableTx() *VerifiableTx {
+	if x != nil {
+		return x.VerifiableTx
+	}
+	return nil
+}
+
+func (x *VerifiableSQLQueryResult) GetRows() []*VerifiableSQLRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+func (x *VerifiableSQLQueryResult) GetDatabaseId() uint64 {
+	if x != nil {
+		return x.DatabaseId
+	}
+	return 0
+}
+
+func (x *VerifiableSQLQueryResult) GetTableId() uint64 {
+	if x != nil {
+		return x.TableId
+	}
+	return 0
+}
+
+func (x *VerifiableSQLQueryResult) GetTableName() string {
+	if x != nil {
+		return x.TableName
+	}
+	return ""
+}
+
+func (x *VerifiableSQLQueryResult) GetPKNames() []string {
+	if x != nil {
+		return x.PKNames
+	}
+	return nil
+}
+
+func (x *VerifiableSQLQueryResult) GetColIdsByName() map[string]uint64 {
+	if x != nil {
+		return x.ColIdsByName
+	}
+	return nil
+}
+
+func (x *VerifiableSQLQueryResult) GetColTypesById() map[uint64]string {
+	if x != nil {
+		return x.ColTypesById
+	}
+	return nil
+}
+
+type UseDatabaseReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+}
+
+func (x *UseDatabaseReply) Reset() {
+	*x = UseDatabaseReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[83]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UseDatabaseReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UseDatabaseReply) ProtoMessage() {}
+
+func (x *UseDatabaseReply) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[83]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VerifiableTx); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UseDatabaseReply.ProtoReflect.Descriptor instead.
+func (*UseDatabaseReply) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *UseDatabaseReply) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type ChangePermissionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Action     PermissionAction `protobuf:"varint,1,opt,name=action,proto3,enum=immudb.schema.PermissionAction" json:"action,omitempty"`
+	Username   string           `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Database   string           `protobuf:"bytes,3,opt,name=database,proto3" json:"database,omitempty"`
+	Permission uint32           `protobuf:"varint,4,opt,name=permission,proto3" json:"permission,omitempty"`
+}
+
+func (x *ChangePermissionRequest) Reset() {
+	*x = ChangePermissionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[84]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChangePermissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangePermissionRequest) ProtoMessage() {}
+
+func (x *ChangePermissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[84]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VerifiableEntry); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangePermissionRequest.ProtoReflect.Descriptor instead.
+func (*ChangePermissionRequest) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *ChangePermissionRequest) GetAction() PermissionAction {
+	if x != nil {
+		return x.Action
+	}
+	return PermissionAction_GRANT
+}
+
+func (x *ChangePermissionRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *ChangePermissionRequest) GetDatabase() string {
+	if x != nil {
+		return x.Database
+	}
+	return ""
+}
+
+func (x *ChangePermissionRequest) GetPermission() uint32 {
+	if x != nil {
+		return x.Permission
+	}
+	return 0
+}
+
+type SetActiveUserRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Active   bool   `protobuf:"varint,1,opt,name=active,proto3" json:"active,omitempty"`
+	Username string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+}
+
+func (x *SetActiveUserRequest) Reset() {
+	*x = SetActiveUserRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[85]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetActiveUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetActiveUserRequest) ProtoMessage() {}
+
+func (x *SetActiveUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[85]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*InclusionProof); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetActiveUserRequest.ProtoReflect.Descriptor instead.
+func (*SetActiveUserRequest) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *SetActiveUserRequest) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *SetActiveUserRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type DatabaseListResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Databases []*Database `protobuf:"bytes,1,rep,name=databases,proto3" json:"databases,omitempty"`
+}
+
+func (x *DatabaseListResponse) Reset() {
+	*x = DatabaseListResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[86]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DatabaseListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DatabaseListResponse) ProtoMessage() {}
+
+func (x *DatabaseListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[86]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DatabaseListResponse.ProtoReflect.Descriptor instead.
+func (*DatabaseListResponse) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *DatabaseListResponse) GetDatabases() []*Database {
+	if x != nil {
+		return x.Databases
+	}
+	return nil
+}
+
+type DeletedDatabase struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DatabaseName string `protobuf:"bytes,1,opt,name=databaseName,proto3" json:"databaseName,omitempty"`
+	DeletedAt    int64  `protobuf:"varint,2,opt,name=deletedAt,proto3" json:"deletedAt,omitempty"`
+	PurgeAt      int64  `protobuf:"varint,3,opt,name=purgeAt,proto3" json:"purgeAt,omitempty"`
+}
+
+func (x *DeletedDatabase) Reset() {
+	*x = DeletedDatabase{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[87]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeletedDatabase) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletedDatabase) ProtoMessage() {}
+
+func (x *DeletedDatabase) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[87]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*KeyRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletedDatabase.ProtoReflect.Descriptor instead.
+func (*DeletedDatabase) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *DeletedDatabase) GetDatabaseName() string {
+	if x != nil {
+		return x.DatabaseName
+	}
+	return ""
+}
+
+func (x *DeletedDatabase) GetDeletedAt() int64 {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return 0
+}
+
+func (x *DeletedDatabase) GetPurgeAt() int64 {
+	if x != nil {
+		return x.PurgeAt
+	}
+	return 0
+}
+
+type DatabaseExecAllRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Database string          `protobuf:"bytes,1,opt,name=database,proto3" json:"database,omitempty"`
+	Request  *ExecAllRequest `protobuf:"bytes,2,opt,name=request,proto3" json:"request,omitempty"`
+}
+
+func (x *DatabaseExecAllRequest) Reset() {
+	*x = DatabaseExecAllRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[88]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DatabaseExecAllRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DatabaseExecAllRequest) ProtoMessage() {}
+
+func (x *DatabaseExecAllRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[88]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*KeyListRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DatabaseExecAllRequest.ProtoReflect.Descriptor instead.
+func (*DatabaseExecAllRequest) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *DatabaseExecAllRequest) GetDatabase() string {
+	if x != nil {
+		return x.Database
+	}
+	return ""
+}
+
+func (x *DatabaseExecAllRequest) GetRequest() *ExecAllRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+type ExecMultiDbAllRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// committed atomically, each database can only be included once.
+	// If the tx can't be committed into some of them, a partial commit error is returned and it's rolled forward into them on restart
+	Requests []*DatabaseExecAllRequest `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+}
+
+func (x *ExecMultiDbAllRequest) Reset() {
+	*x = ExecMultiDbAllRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[89]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExecMultiDbAllRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecMultiDbAllRequest) ProtoMessage() {}
+
+func (x *ExecMultiDbAllRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[89]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VerifiableSetRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecMultiDbAllRequest.ProtoReflect.Descriptor instead.
+func (*ExecMultiDbAllRequest) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *ExecMultiDbAllRequest) GetRequests() []*DatabaseExecAllRequest {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+type ExecMultiDbAllResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// in the same order as the requests
+	Txs []*TxMetadata `protobuf:"bytes,1,rep,name=txs,proto3" json:"txs,omitempty"`
+}
+
+func (x *ExecMultiDbAllResponse) Reset() {
+	*x = ExecMultiDbAllResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[90]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExecMultiDbAllResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecMultiDbAllResponse) ProtoMessage() {}
+
+func (x *ExecMultiDbAllResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[90]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VerifiableGetRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_schema_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HealthResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecMultiDbAllResponse.ProtoReflect.Descriptor instead.
+func (*ExecMultiDbAllResponse) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *ExecMultiDbAllResponse) GetTxs() []*TxMetadata {
+	if x != nil {
+		return x.Txs
+	}
+	return nil
+}
+
+type Collection struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// letters, digits, '_' and '-', at most 28 characters
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// top-level fields documents are indexed by, at most 4 of them
+	IndexedFields []string `protobuf:"bytes,2,rep,name=indexedFields,proto3" json:"indexedFields,omitempty"`
+}
+
+func (x *Collection) Reset() {
+	*x = Collection{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[91]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Collection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Collection) ProtoMessage() {}
+
+func (x *Collection) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[91]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ImmutableState); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Collection.ProtoReflect.Descriptor instead.
+func (*Collection) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *Collection) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Collection) GetIndexedFields() []string {
+	if x != nil {
+		return x.IndexedFields
+	}
+	return nil
+}
+
+type CollectionList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Collections []*Collection `protobuf:"bytes,1,rep,name=collections,proto3" json:"collections,omitempty"`
+}
+
+func (x *CollectionList) Reset() {
+	*x = CollectionList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[92]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CollectionList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectionList) ProtoMessage() {}
+
+func (x *CollectionList) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[92]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReferenceRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollectionList.ProtoReflect.Descriptor instead.
+func (*CollectionList) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *CollectionList) GetCollections() []*Collection {
+	if x != nil {
+		return x.Collections
+	}
+	return nil
+}
+
+type DocumentInsertRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Collection string `protobuf:"bytes,1,opt,name=collection,proto3" json:"collection,omitempty"`
+	// the "_id" string field identifies a document, it's generated when missing.
+	// A document replaces any previous one with the same id
+	Documents []*_struct.Struct `protobuf:"bytes,2,rep,name=documents,proto3" json:"documents,omitempty"`
+	NoWait    bool              `protobuf:"varint,3,opt,name=noWait,proto3" json:"noWait,omitempty"`
+	// W3C trace-id recorded into the tx attributes
+	TraceID []byte `protobuf:"bytes,4,opt,name=traceID,proto3" json:"traceID,omitempty"`
+}
+
+func (x *DocumentInsertRequest) Reset() {
+	*x = DocumentInsertRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[93]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DocumentInsertRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DocumentInsertRequest) ProtoMessage() {}
+
+func (x *DocumentInsertRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[93]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VerifiableReferenceRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DocumentInsertRequest.ProtoReflect.Descriptor instead.
+func (*DocumentInsertRequest) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *DocumentInsertRequest) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+func (x *DocumentInsertRequest) GetDocuments() []*_struct.Struct {
+	if x != nil {
+		return x.Documents
+	}
+	return nil
+}
+
+func (x *DocumentInsertRequest) GetNoWait() bool {
+	if x != nil {
+		return x.NoWait
+	}
+	return false
+}
+
+func (x *DocumentInsertRequest) GetTraceID() []byte {
+	if x != nil {
+		return x.TraceID
+	}
+	return nil
+}
+
+type DocumentInsertResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TxMetadata *TxMetadata `protobuf:"bytes,1,opt,name=txMetadata,proto3" json:"txMetadata,omitempty"`
+	// in the same order as the documents
+	Ids []string `protobuf:"bytes,2,rep,name=ids,proto3" json:"ids,omitempty"`
+}
+
+func (x *DocumentInsertResponse) Reset() {
+	*x = DocumentInsertResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[94]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DocumentInsertResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DocumentInsertResponse) ProtoMessage() {}
+
+func (x *DocumentInsertResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[94]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ZAddRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DocumentInsertResponse.ProtoReflect.Descriptor instead.
+func (*DocumentInsertResponse) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *DocumentInsertResponse) GetTxMetadata() *TxMetadata {
+	if x != nil {
+		return x.TxMetadata
+	}
+	return nil
+}
+
+func (x *DocumentInsertResponse) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type DocumentGetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Collection string `protobuf:"bytes,1,opt,name=collection,proto3" json:"collection,omitempty"`
+	Id         string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	SinceTx    uint64 `protobuf:"varint,3,opt,name=sinceTx,proto3" json:"sinceTx,omitempty"`
+	NoWait     bool   `protobuf:"varint,4,opt,name=noWait,proto3" json:"noWait,omitempty"`
+}
+
+func (x *DocumentGetRequest) Reset() {
+	*x = DocumentGetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[95]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DocumentGetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DocumentGetRequest) ProtoMessage() {}
+
+func (x *DocumentGetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[95]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Score); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_schema_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ZScanRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_schema_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HistoryRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DocumentGetRequest.ProtoReflect.Descriptor instead.
+func (*DocumentGetRequest) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *DocumentGetRequest) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+func (x *DocumentGetRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DocumentGetRequest) GetSinceTx() uint64 {
+	if x != nil {
+		return x.SinceTx
+	}
+	return 0
+}
+
+func (x *DocumentGetRequest) GetNoWait() bool {
+	if x != nil {
+		return x.NoWait
+	}
+	return false
+}
+
+type Document struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Document *_struct.Struct `protobuf:"bytes,1,opt,name=document,proto3" json:"document,omitempty"`
+	// tx the document was inserted by
+	Tx uint64 `protobuf:"varint,2,opt,name=tx,proto3" json:"tx,omitempty"`
+}
+
+func (x *Document) Reset() {
+	*x = Document{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[96]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Document) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Document) ProtoMessage() {}
+
+func (x *Document) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[96]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VerifiableZAddRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Document.ProtoReflect.Descriptor instead.
+func (*Document) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *Document) GetDocument() *_struct.Struct {
+	if x != nil {
+		return x.Document
+	}
+	return nil
+}
+
+func (x *Document) GetTx() uint64 {
+	if x != nil {
+		return x.Tx
+	}
+	return 0
+}
+
+type DocumentFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// top-level field, documents without it never match
+	Field    string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Operator DocumentFilterOperator `protobuf:"varint,2,opt,name=operator,proto3,enum=immudb.schema.DocumentFilterOperator" json:"operator,omitempty"`
+	// numbers and strings are ordered, other values can only be compared by (in)equality
+	Value *_struct.Value `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *DocumentFilter) Reset() {
+	*x = DocumentFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[97]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DocumentFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DocumentFilter) ProtoMessage() {}
+
+func (x *DocumentFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[97]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TxRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DocumentFilter.ProtoReflect.Descriptor instead.
+func (*DocumentFilter) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *DocumentFilter) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *DocumentFilter) GetOperator() DocumentFilterOperator {
+	if x != nil {
+		return x.Operator
+	}
+	return DocumentFilterOperator_EQ
+}
+
+func (x *DocumentFilter) GetValue() *_struct.Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type DocumentSearchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Collection string `protobuf:"bytes,1,opt,name=collection,proto3" json:"collection,omitempty"`
+	// documents matching all the filters are returned sorted by id,
+	// an equality filter on an indexed field is resolved through the index
+	Filters []*DocumentFilter `protobuf:"bytes,2,rep,name=filters,proto3" json:"filters,omitempty"`
+	Limit   uint64            `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	SinceTx uint64            `protobuf:"varint,4,opt,name=sinceTx,proto3" json:"sinceTx,omitempty"`
+	NoWait  bool              `protobuf:"varint,5,opt,name=noWait,proto3" json:"noWait,omitempty"`
+}
+
+func (x *DocumentSearchRequest) Reset() {
+	*x = DocumentSearchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[98]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DocumentSearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DocumentSearchRequest) ProtoMessage() {}
+
+func (x *DocumentSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[98]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VerifiableTxRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DocumentSearchRequest.ProtoReflect.Descriptor instead.
+func (*DocumentSearchRequest) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{98}
+}
+
+func (x *DocumentSearchRequest) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+func (x *DocumentSearchRequest) GetFilters() []*DocumentFilter {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+func (x *DocumentSearchRequest) GetLimit() uint64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *DocumentSearchRequest) GetSinceTx() uint64 {
+	if x != nil {
+		return x.SinceTx
+	}
+	return 0
+}
+
+func (x *DocumentSearchRequest) GetNoWait() bool {
+	if x != nil {
+		return x.NoWait
+	}
+	return false
+}
+
+type DocumentList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Documents []*Document `protobuf:"bytes,1,rep,name=documents,proto3" json:"documents,omitempty"`
+}
+
+func (x *DocumentList) Reset() {
+	*x = DocumentList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[99]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DocumentList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DocumentList) ProtoMessage() {}
+
+func (x *DocumentList) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[99]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TxScanRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_schema_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TxList); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DocumentList.ProtoReflect.Descriptor instead.
+func (*DocumentList) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *DocumentList) GetDocuments() []*Document {
+	if x != nil {
+		return x.Documents
+	}
+	return nil
+}
+
+type LogOpenedFiles struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// directory of the tx, commit or value log
+	Path  string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Files []string `protobuf:"bytes,2,rep,name=files,proto3" json:"files,omitempty"`
+	// max number of files kept open for reading besides the one being appended
+	MaxOpenedFiles uint32 `protobuf:"varint,3,opt,name=maxOpenedFiles,proto3" json:"maxOpenedFiles,omitempty"`
+}
+
+func (x *LogOpenedFiles) Reset() {
+	*x = LogOpenedFiles{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[100]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LogOpenedFiles) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogOpenedFiles) ProtoMessage() {}
+
+func (x *LogOpenedFiles) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[100]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Database); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogOpenedFiles.ProtoReflect.Descriptor instead.
+func (*LogOpenedFiles) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *LogOpenedFiles) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *LogOpenedFiles) GetFiles() []string {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *LogOpenedFiles) GetMaxOpenedFiles() uint32 {
+	if x != nil {
+		return x.MaxOpenedFiles
+	}
+	return 0
+}
+
+type DatabaseOpenedFiles struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Database string            `protobuf:"bytes,1,opt,name=database,proto3" json:"database,omitempty"`
+	Logs     []*LogOpenedFiles `protobuf:"bytes,2,rep,name=logs,proto3" json:"logs,omitempty"`
+}
+
+func (x *DatabaseOpenedFiles) Reset() {
+	*x = DatabaseOpenedFiles{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[101]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DatabaseOpenedFiles) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DatabaseOpenedFiles) ProtoMessage() {}
+
+func (x *DatabaseOpenedFiles) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[101]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Table); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DatabaseOpenedFiles.ProtoReflect.Descriptor instead.
+func (*DatabaseOpenedFiles) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{101}
+}
+
+func (x *DatabaseOpenedFiles) GetDatabase() string {
+	if x != nil {
+		return x.Database
+	}
+	return ""
+}
+
+func (x *DatabaseOpenedFiles) GetLogs() []*LogOpenedFiles {
+	if x != nil {
+		return x.Logs
+	}
+	return nil
+}
+
+type OpenedFilesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Databases []*DatabaseOpenedFiles `protobuf:"bytes,1,rep,name=databases,proto3" json:"databases,omitempty"`
+	// files opened by the server process, 0 when it can't be determined
+	ProcessOpenedFiles uint64 `protobuf:"varint,2,opt,name=processOpenedFiles,proto3" json:"processOpenedFiles,omitempty"`
+	// max number of files the server process may open, 0 when it can't be determined
+	ProcessMaxOpenedFiles uint64 `protobuf:"varint,3,opt,name=processMaxOpenedFiles,proto3" json:"processMaxOpenedFiles,omitempty"`
+}
+
+func (x *OpenedFilesResponse) Reset() {
+	*x = OpenedFilesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[102]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OpenedFilesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenedFilesResponse) ProtoMessage() {}
+
+func (x *OpenedFilesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[102]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SQLGetRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenedFilesResponse.ProtoReflect.Descriptor instead.
+func (*OpenedFilesResponse) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{102}
+}
+
+func (x *OpenedFilesResponse) GetDatabases() []*DatabaseOpenedFiles {
+	if x != nil {
+		return x.Databases
+	}
+	return nil
+}
+
+func (x *OpenedFilesResponse) GetProcessOpenedFiles() uint64 {
+	if x != nil {
+		return x.ProcessOpenedFiles
+	}
+	return 0
+}
+
+func (x *OpenedFilesResponse) GetProcessMaxOpenedFiles() uint64 {
+	if x != nil {
+		return x.ProcessMaxOpenedFiles
+	}
+	return 0
+}
+
+type CloseCachedFilesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ClosedFiles uint32 `protobuf:"varint,1,opt,name=closedFiles,proto3" json:"closedFiles,omitempty"`
+}
+
+func (x *CloseCachedFilesResponse) Reset() {
+	*x = CloseCachedFilesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[103]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CloseCachedFilesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseCachedFilesResponse) ProtoMessage() {}
+
+func (x *CloseCachedFilesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[103]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VerifiableSQLGetRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseCachedFilesResponse.ProtoReflect.Descriptor instead.
+func (*CloseCachedFilesResponse) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{103}
+}
+
+func (x *CloseCachedFilesResponse) GetClosedFiles() uint32 {
+	if x != nil {
+		return x.ClosedFiles
+	}
+	return 0
+}
+
+type DeletedDatabaseListResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Databases []*DeletedDatabase `protobuf:"bytes,1,rep,name=databases,proto3" json:"databases,omitempty"`
+}
+
+func (x *DeletedDatabaseListResponse) Reset() {
+	*x = DeletedDatabaseListResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[104]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeletedDatabaseListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletedDatabaseListResponse) ProtoMessage() {}
+
+func (x *DeletedDatabaseListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[104]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SQLEntry); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_schema_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VerifiableSQLEntry); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_schema_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UseDatabaseReply); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletedDatabaseListResponse.ProtoReflect.Descriptor instead.
+func (*DeletedDatabaseListResponse) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{104}
+}
+
+func (x *DeletedDatabaseListResponse) GetDatabases() []*DeletedDatabase {
+	if x != nil {
+		return x.Databases
+	}
+	return nil
+}
+
+type RotateEncryptionKeysResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rotated uint32 `protobuf:"varint,1,opt,name=rotated,proto3" json:"rotated,omitempty"`
+}
+
+func (x *RotateEncryptionKeysResponse) Reset() {
+	*x = RotateEncryptionKeysResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[105]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RotateEncryptionKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateEncryptionKeysResponse) ProtoMessage() {}
+
+func (x *RotateEncryptionKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[105]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ChangePermissionRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateEncryptionKeysResponse.ProtoReflect.Descriptor instead.
+func (*RotateEncryptionKeysResponse) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{105}
+}
+
+func (x *RotateEncryptionKeysResponse) GetRotated() uint32 {
+	if x != nil {
+		return x.Rotated
+	}
+	return 0
+}
+
+type CancelApplicationRequestsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Application string `protobuf:"bytes,1,opt,name=application,proto3" json:"application,omitempty"`
+}
+
+func (x *CancelApplicationRequestsRequest) Reset() {
+	*x = CancelApplicationRequestsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[106]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelApplicationRequestsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelApplicationRequestsRequest) ProtoMessage() {}
+
+func (x *CancelApplicationRequestsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[106]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetActiveUserRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelApplicationRequestsRequest.ProtoReflect.Descriptor instead.
+func (*CancelApplicationRequestsRequest) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{106}
+}
+
+func (x *CancelApplicationRequestsRequest) GetApplication() string {
+	if x != nil {
+		return x.Application
+	}
+	return ""
+}
+
+type CancelApplicationRequestsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cancelled uint32 `protobuf:"varint,1,opt,name=cancelled,proto3" json:"cancelled,omitempty"`
+}
+
+func (x *CancelApplicationRequestsResponse) Reset() {
+	*x = CancelApplicationRequestsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[107]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelApplicationRequestsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelApplicationRequestsResponse) ProtoMessage() {}
+
+func (x *CancelApplicationRequestsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[107]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[58].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DatabaseListResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelApplicationRequestsResponse.ProtoReflect.Descriptor instead.
+func (*CancelApplicationRequestsResponse) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{107}
+}
+
+func (x *CancelApplicationRequestsResponse) GetCancelled() uint32 {
+	if x != nil {
+		return x.Cancelled
+	}
+	return 0
+}
+
+type Chunk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Content []byte `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+}
+
+func (x *Chunk) Reset() {
+	*x = Chunk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[108]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Chunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Chunk) ProtoMessage() {}
+
+func (x *Chunk) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[108]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[59].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Chunk); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Chunk.ProtoReflect.Descriptor instead.
+func (*Chunk) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{108}
+}
+
+func (x *Chunk) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+type UseSnapshotRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SinceTx        uint64         `protobuf:"varint,1,opt,name=sinceTx,proto3" json:"sinceTx,omitempty"`
+	AsBeforeTx     uint64         `protobuf:"varint,2,opt,name=asBeforeTx,proto3" json:"asBeforeTx,omitempty"`
+	IsolationLevel IsolationLevel `protobuf:"varint,3,opt,name=isolationLevel,proto3,enum=immudb.schema.IsolationLevel" json:"isolationLevel,omitempty"`
+}
+
+func (x *UseSnapshotRequest) Reset() {
+	*x = UseSnapshotRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[109]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UseSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UseSnapshotRequest) ProtoMessage() {}
+
+func (x *UseSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[109]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[60].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UseSnapshotRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_schema_proto_msgTypes[61].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SQLExecRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_schema_proto_msgTypes[62].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SQLQueryRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UseSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*UseSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{109}
+}
+
+func (x *UseSnapshotRequest) GetSinceTx() uint64 {
+	if x != nil {
+		return x.SinceTx
+	}
+	return 0
+}
+
+func (x *UseSnapshotRequest) GetAsBeforeTx() uint64 {
+	if x != nil {
+		return x.AsBeforeTx
+	}
+	return 0
+}
+
+func (x *UseSnapshotRequest) GetIsolationLevel() IsolationLevel {
+	if x != nil {
+		return x.IsolationLevel
+	}
+	return IsolationLevel_SNAPSHOT
+}
+
+type SQLExecRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sql    string        `protobuf:"bytes,1,opt,name=sql,proto3" json:"sql,omitempty"`
+	Params []*NamedParam `protobuf:"bytes,2,rep,name=params,proto3" json:"params,omitempty"`
+	NoWait bool          `protobuf:"varint,3,opt,name=noWait,proto3" json:"noWait,omitempty"`
+	// transaction the statements are deferred into, as returned when it was begun
+	TransactionId string `protobuf:"bytes,4,opt,name=transactionId,proto3" json:"transactionId,omitempty"`
+}
+
+func (x *SQLExecRequest) Reset() {
+	*x = SQLExecRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[110]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SQLExecRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SQLExecRequest) ProtoMessage() {}
+
+func (x *SQLExecRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[110]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[63].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NamedParam); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SQLExecRequest.ProtoReflect.Descriptor instead.
+func (*SQLExecRequest) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{110}
+}
+
+func (x *SQLExecRequest) GetSql() string {
+	if x != nil {
+		return x.Sql
+	}
+	return ""
+}
+
+func (x *SQLExecRequest) GetParams() []*NamedParam {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *SQLExecRequest) GetNoWait() bool {
+	if x != nil {
+		return x.NoWait
+	}
+	return false
+}
+
+func (x *SQLExecRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+type SQLBatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// statements are executed in order within a single transaction, each one with its own parameters
+	Stmts  []*SQLBatchStmt `protobuf:"bytes,1,rep,name=stmts,proto3" json:"stmts,omitempty"`
+	NoWait bool            `protobuf:"varint,2,opt,name=noWait,proto3" json:"noWait,omitempty"`
+}
+
+func (x *SQLBatchRequest) Reset() {
+	*x = SQLBatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[111]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SQLBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SQLBatchRequest) ProtoMessage() {}
+
+func (x *SQLBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[111]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[64].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SQLExecResult); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SQLBatchRequest.ProtoReflect.Descriptor instead.
+func (*SQLBatchRequest) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{111}
+}
+
+func (x *SQLBatchRequest) GetStmts() []*SQLBatchStmt {
+	if x != nil {
+		return x.Stmts
+	}
+	return nil
+}
+
+func (x *SQLBatchRequest) GetNoWait() bool {
+	if x != nil {
+		return x.NoWait
+	}
+	return false
+}
+
+type SQLBatchStmt struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sql    string        `protobuf:"bytes,1,opt,name=sql,proto3" json:"sql,omitempty"`
+	Params []*NamedParam `protobuf:"bytes,2,rep,name=params,proto3" json:"params,omitempty"`
+}
+
+func (x *SQLBatchStmt) Reset() {
+	*x = SQLBatchStmt{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[112]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SQLBatchStmt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SQLBatchStmt) ProtoMessage() {}
+
+func (x *SQLBatchStmt) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[112]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[65].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SQLQueryResult); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SQLBatchStmt.ProtoReflect.Descriptor instead.
+func (*SQLBatchStmt) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{112}
+}
+
+func (x *SQLBatchStmt) GetSql() string {
+	if x != nil {
+		return x.Sql
+	}
+	return ""
+}
+
+func (x *SQLBatchStmt) GetParams() []*NamedParam {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+type SQLBatchResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ctxs []*TxMetadata `protobuf:"bytes,1,rep,name=ctxs,proto3" json:"ctxs,omitempty"`
+	Dtxs []*TxMetadata `protobuf:"bytes,2,rep,name=dtxs,proto3" json:"dtxs,omitempty"`
+	// results holds the outcome of each statement, in the order they were given
+	Results []*SQLBatchStmtResult `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *SQLBatchResult) Reset() {
+	*x = SQLBatchResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[113]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SQLBatchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SQLBatchResult) ProtoMessage() {}
+
+func (x *SQLBatchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[113]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[66].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Column); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SQLBatchResult.ProtoReflect.Descriptor instead.
+func (*SQLBatchResult) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{113}
+}
+
+func (x *SQLBatchResult) GetCtxs() []*TxMetadata {
+	if x != nil {
+		return x.Ctxs
+	}
+	return nil
+}
+
+func (x *SQLBatchResult) GetDtxs() []*TxMetadata {
+	if x != nil {
+		return x.Dtxs
+	}
+	return nil
+}
+
+func (x *SQLBatchResult) GetResults() []*SQLBatchStmtResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type SQLBatchStmtResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// number of rows inserted, updated or deleted by the statement
+	UpdatedRows uint64 `protobuf:"varint,1,opt,name=updatedRows,proto3" json:"updatedRows,omitempty"`
+	// last value generated for the auto incremental primary key of each table the statement inserted rows into
+	LastInsertedPKs map[string]uint64 `protobuf:"bytes,2,rep,name=lastInsertedPKs,proto3" json:"lastInsertedPKs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (x *SQLBatchStmtResult) Reset() {
+	*x = SQLBatchStmtResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[114]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SQLBatchStmtResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SQLBatchStmtResult) ProtoMessage() {}
+
+func (x *SQLBatchStmtResult) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[114]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[67].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Row); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SQLBatchStmtResult.ProtoReflect.Descriptor instead.
+func (*SQLBatchStmtResult) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{114}
+}
+
+func (x *SQLBatchStmtResult) GetUpdatedRows() uint64 {
+	if x != nil {
+		return x.UpdatedRows
+	}
+	return 0
+}
+
+func (x *SQLBatchStmtResult) GetLastInsertedPKs() map[string]uint64 {
+	if x != nil {
+		return x.LastInsertedPKs
+	}
+	return nil
+}
+
+type SQLQueryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sql           string        `protobuf:"bytes,1,opt,name=sql,proto3" json:"sql,omitempty"`
+	Params        []*NamedParam `protobuf:"bytes,2,rep,name=params,proto3" json:"params,omitempty"`
+	ReuseSnapshot bool          `protobuf:"varint,3,opt,name=reuseSnapshot,proto3" json:"reuseSnapshot,omitempty"`
+}
+
+func (x *SQLQueryRequest) Reset() {
+	*x = SQLQueryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[115]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SQLQueryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SQLQueryRequest) ProtoMessage() {}
+
+func (x *SQLQueryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[115]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_schema_proto_msgTypes[68].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SQLValue); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SQLQueryRequest.ProtoReflect.Descriptor instead.
+func (*SQLQueryRequest) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{115}
+}
+
+func (x *SQLQueryRequest) GetSql() string {
+	if x != nil {
+		return x.Sql
+	}
+	return ""
+}
+
+func (x *SQLQueryRequest) GetParams() []*NamedParam {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *SQLQueryRequest) GetReuseSnapshot() bool {
+	if x != nil {
+		return x.ReuseSnapshot
+	}
+	return false
+}
+
+type NamedParam struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name  string    `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value *SQLValue `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *NamedParam) Reset() {
+	*x = NamedParam{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[116]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NamedParam) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NamedParam) ProtoMessage() {}
+
+func (x *NamedParam) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[116]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NamedParam.ProtoReflect.Descriptor instead.
+func (*NamedParam) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{116}
+}
+
+func (x *NamedParam) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NamedParam) GetValue() *SQLValue {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type SQLExecResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ctxs []*TxMetadata `protobuf:"bytes,1,rep,name=ctxs,proto3" json:"ctxs,omitempty"`
+	Dtxs []*TxMetadata `protobuf:"bytes,2,rep,name=dtxs,proto3" json:"dtxs,omitempty"`
+	// last value generated for the auto incremental primary key of each table the rows were inserted into
+	LastInsertedPKs map[string]uint64 `protobuf:"bytes,3,rep,name=lastInsertedPKs,proto3" json:"lastInsertedPKs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// transaction left open by the statements, empty if none was begun or it was committed or rolled back
+	TransactionId string `protobuf:"bytes,4,opt,name=transactionId,proto3" json:"transactionId,omitempty"`
+	// rows written by each statement with a RETURNING clause, as they were committed
+	ReturnedRows []*SQLQueryResult `protobuf:"bytes,5,rep,name=returnedRows,proto3" json:"returnedRows,omitempty"`
+}
+
+func (x *SQLExecResult) Reset() {
+	*x = SQLExecResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[117]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SQLExecResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SQLExecResult) ProtoMessage() {}
+
+func (x *SQLExecResult) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[117]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SQLExecResult.ProtoReflect.Descriptor instead.
+func (*SQLExecResult) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{117}
+}
+
+func (x *SQLExecResult) GetCtxs() []*TxMetadata {
+	if x != nil {
+		return x.Ctxs
+	}
+	return nil
+}
+
+func (x *SQLExecResult) GetDtxs() []*TxMetadata {
+	if x != nil {
+		return x.Dtxs
+	}
+	return nil
+}
+
+func (x *SQLExecResult) GetLastInsertedPKs() map[string]uint64 {
+	if x != nil {
+		return x.LastInsertedPKs
+	}
+	return nil
+}
+
+func (x *SQLExecResult) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *SQLExecResult) GetReturnedRows() []*SQLQueryResult {
+	if x != nil {
+		return x.ReturnedRows
+	}
+	return nil
+}
+
+type SQLQueryResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Columns []*Column `protobuf:"bytes,2,rep,name=columns,proto3" json:"columns,omitempty"`
+	Rows    []*Row    `protobuf:"bytes,1,rep,name=rows,proto3" json:"rows,omitempty"`
+}
+
+func (x *SQLQueryResult) Reset() {
+	*x = SQLQueryResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[118]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SQLQueryResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SQLQueryResult) ProtoMessage() {}
+
+func (x *SQLQueryResult) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[118]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SQLQueryResult.ProtoReflect.Descriptor instead.
+func (*SQLQueryResult) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{118}
+}
+
+func (x *SQLQueryResult) GetColumns() []*Column {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+func (x *SQLQueryResult) GetRows() []*Row {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+type SQLSchema struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ddl holds the statements re-creating the tables and indexes of a database
+	Ddl string `protobuf:"bytes,1,opt,name=ddl,proto3" json:"ddl,omitempty"`
+}
+
+func (x *SQLSchema) Reset() {
+	*x = SQLSchema{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[119]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SQLSchema) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SQLSchema) ProtoMessage() {}
+
+func (x *SQLSchema) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[119]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SQLSchema.ProtoReflect.Descriptor instead.
+func (*SQLSchema) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{119}
+}
+
+func (x *SQLSchema) GetDdl() string {
+	if x != nil {
+		return x.Ddl
+	}
+	return ""
+}
+
+type Column struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+}
+
+func (x *Column) Reset() {
+	*x = Column{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[120]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Column) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Column) ProtoMessage() {}
+
+func (x *Column) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[120]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Column.ProtoReflect.Descriptor instead.
+func (*Column) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{120}
+}
+
+func (x *Column) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Column) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+type Row struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Columns []string    `protobuf:"bytes,1,rep,name=columns,proto3" json:"columns,omitempty"`
+	Values  []*SQLValue `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+}
+
+func (x *Row) Reset() {
+	*x = Row{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[121]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Row) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Row) ProtoMessage() {}
+
+func (x *Row) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[121]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Row.ProtoReflect.Descriptor instead.
+func (*Row) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{121}
+}
+
+func (x *Row) GetColumns() []string {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+func (x *Row) GetValues() []*SQLValue {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type SQLValue struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Value:
+	//	*SQLValue_Null
+	//	*SQLValue_N
+	//	*SQLValue_S
+	//	*SQLValue_B
+	//	*SQLValue_Bs
+	//	*SQLValue_F
+	//	*SQLValue_Ts
+	//	*SQLValue_List
+	Value isSQLValue_Value `protobuf_oneof:"value"`
+}
+
+func (x *SQLValue) Reset() {
+	*x = SQLValue{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[122]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SQLValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SQLValue) ProtoMessage() {}
+
+func (x *SQLValue) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[122]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SQLValue.ProtoReflect.Descriptor instead.
+func (*SQLValue) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{122}
+}
+
+func (m *SQLValue) GetValue() isSQLValue_Value {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (x *SQLValue) GetNull() _struct.NullValue {
+	if x, ok := x.GetValue().(*SQLValue_Null); ok {
+		return x.Null
+	}
+	return _struct.NullValue_NULL_VALUE
+}
+
+func (x *SQLValue) GetN() uint64 {
+	if x, ok := x.GetValue().(*SQLValue_N); ok {
+		return x.N
+	}
+	return 0
+}
+
+func (x *SQLValue) GetS() string {
+	if x, ok := x.GetValue().(*SQLValue_S); ok {
+		return x.S
+	}
+	return ""
+}
+
+func (x *SQLValue) GetB() bool {
+	if x, ok := x.GetValue().(*SQLValue_B); ok {
+		return x.B
+	}
+	return false
+}
+
+func (x *SQLValue) GetBs() []byte {
+	if x, ok := x.GetValue().(*SQLValue_Bs); ok {
+		return x.Bs
+	}
+	return nil
+}
+
+func (x *SQLValue) GetF() float64 {
+	if x, ok := x.GetValue().(*SQLValue_F); ok {
+		return x.F
+	}
+	return 0
+}
+
+func (x *SQLValue) GetTs() int64 {
+	if x, ok := x.GetValue().(*SQLValue_Ts); ok {
+		return x.Ts
+	}
+	return 0
+}
+
+func (x *SQLValue) GetList() *SQLValueList {
+	if x, ok := x.GetValue().(*SQLValue_List); ok {
+		return x.List
+	}
+	return nil
+}
+
+type isSQLValue_Value interface {
+	isSQLValue_Value()
+}
+
+type SQLValue_Null struct {
+	Null _struct.NullValue `protobuf:"varint,1,opt,name=null,proto3,enum=google.protobuf.NullValue,oneof"`
+}
+
+type SQLValue_N struct {
+	N uint64 `protobuf:"varint,2,opt,name=n,proto3,oneof"`
+}
+
+type SQLValue_S struct {
+	S string `protobuf:"bytes,3,opt,name=s,proto3,oneof"`
+}
+
+type SQLValue_B struct {
+	B bool `protobuf:"varint,4,opt,name=b,proto3,oneof"`
+}
+
+type SQLValue_Bs struct {
+	Bs []byte `protobuf:"bytes,5,opt,name=bs,proto3,oneof"`
+}
+
+type SQLValue_F struct {
+	F float64 `protobuf:"fixed64,6,opt,name=f,proto3,oneof"`
+}
+
+type SQLValue_Ts struct {
+	Ts int64 `protobuf:"varint,7,opt,name=ts,proto3,oneof"`
+}
+
+type SQLValue_List struct {
+	List *SQLValueList `protobuf:"bytes,8,opt,name=list,proto3,oneof"`
+}
+
+func (*SQLValue_Null) isSQLValue_Value() {}
+
+func (*SQLValue_N) isSQLValue_Value() {}
+
+func (*SQLValue_S) isSQLValue_Value() {}
+
+func (*SQLValue_B) isSQLValue_Value() {}
+
+func (*SQLValue_Bs) isSQLValue_Value() {}
+
+func (*SQLValue_F) isSQLValue_Value() {}
+
+func (*SQLValue_Ts) isSQLValue_Value() {}
+
+func (*SQLValue_List) isSQLValue_Value() {}
+
+type SQLValueList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Values []*SQLValue `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+}
+
+func (x *SQLValueList) Reset() {
+	*x = SQLValueList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[123]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SQLValueList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SQLValueList) ProtoMessage() {}
+
+func (x *SQLValueList) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[123]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SQLValueList.ProtoReflect.Descriptor instead.
+func (*SQLValueList) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{123}
+}
+
+func (x *SQLValueList) GetValues() []*SQLValue {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// satisfied when the key does not exist or it has expired
+type Precondition_KeyMustNotExistPrecondition struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (x *Precondition_KeyMustNotExistPrecondition) Reset() {
+	*x = Precondition_KeyMustNotExistPrecondition{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[124]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Precondition_KeyMustNotExistPrecondition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Precondition_KeyMustNotExistPrecondition) ProtoMessage() {}
+
+func (x *Precondition_KeyMustNotExistPrecondition) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[124]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Precondition_KeyMustNotExistPrecondition.ProtoReflect.Descriptor instead.
+func (*Precondition_KeyMustNotExistPrecondition) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{40, 0}
+}
+
+func (x *Precondition_KeyMustNotExistPrecondition) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+// satisfied when the key has been set exactly revision times
+type Precondition_KeyMustHaveRevisionPrecondition struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key      []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Revision uint64 `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
+}
+
+func (x *Precondition_KeyMustHaveRevisionPrecondition) Reset() {
+	*x = Precondition_KeyMustHaveRevisionPrecondition{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[125]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Precondition_KeyMustHaveRevisionPrecondition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Precondition_KeyMustHaveRevisionPrecondition) ProtoMessage() {}
+
+func (x *Precondition_KeyMustHaveRevisionPrecondition) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[125]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Precondition_KeyMustHaveRevisionPrecondition.ProtoReflect.Descriptor instead.
+func (*Precondition_KeyMustHaveRevisionPrecondition) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{40, 1}
+}
+
+func (x *Precondition_KeyMustHaveRevisionPrecondition) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *Precondition_KeyMustHaveRevisionPrecondition) GetRevision() uint64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+// satisfied when the key was not set after the transaction txID
+type Precondition_KeyNotModifiedAfterTXPrecondition struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key  []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	TxID uint64 `protobuf:"varint,2,opt,name=txID,proto3" json:"txID,omitempty"`
+}
+
+func (x *Precondition_KeyNotModifiedAfterTXPrecondition) Reset() {
+	*x = Precondition_KeyNotModifiedAfterTXPrecondition{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_schema_proto_msgTypes[126]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Precondition_KeyNotModifiedAfterTXPrecondition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Precondition_KeyNotModifiedAfterTXPrecondition) ProtoMessage() {}
+
+func (x *Precondition_KeyNotModifiedAfterTXPrecondition) ProtoReflect() protoreflect.Message {
+	mi := &file_schema_proto_msgTypes[126]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Precondition_KeyNotModifiedAfterTXPrecondition.ProtoReflect.Descriptor instead.
+func (*Precondition_KeyNotModifiedAfterTXPrecondition) Descriptor() ([]byte, []int) {
+	return file_schema_proto_rawDescGZIP(), []int{40, 2}
+}
+
+func (x *Precondition_KeyNotModifiedAfterTXPrecondition) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *Precondition_KeyNotModifiedAfterTXPrecondition) GetTxID() uint64 {
+	if x != nil {
+		return x.TxID
+	}
+	return 0
+}
+
+var File_schema_proto protoreflect.FileDescriptor
+
+var file_schema_proto_rawDesc = []byte{
+	0x0a, 0x0c, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0d,
+	0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x1a, 0x1c, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70,
+	0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x2c, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63,
+	0x2d, 0x67, 0x65, 0x6e, 0x2d, 0x73, 0x77, 0x61, 0x67, 0x67, 0x65, 0x72, 0x2f, 0x6f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x17, 0x0a, 0x03, 0x4b, 0x65, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0x48, 0x0a,
+	0x0a, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x64,
+	0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64,
+	0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x65, 0x72, 0x6d, 0x69,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x70, 0x65, 0x72,
+	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xab, 0x01, 0x0a, 0x04, 0x55, 0x73, 0x65, 0x72,
+	0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04,
+	0x75, 0x73, 0x65, 0x72, 0x12, 0x3b, 0x0a, 0x0b, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75,
+	0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x62, 0x79, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x62, 0x79, 0x12,
+	0x1c, 0x0a, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x61, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x61,
+	0x63, 0x74, 0x69, 0x76, 0x65, 0x22, 0x35, 0x0a, 0x08, 0x55, 0x73, 0x65, 0x72, 0x4c, 0x69, 0x73,
+	0x74, 0x12, 0x29, 0x0a, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x13, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x2e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x22, 0x7f, 0x0a, 0x11,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72,
+	0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x22, 0x21, 0x0a,
+	0x0b, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x75, 0x73, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72,
+	0x22, 0x6f, 0x0a, 0x15, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f,
+	0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x20, 0x0a,
+	0x0b, 0x6f, 0x6c, 0x64, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0b, 0x6f, 0x6c, 0x64, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12,
+	0x20, 0x0a, 0x0b, 0x6e, 0x65, 0x77, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x6e, 0x65, 0x77, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72,
+	0x64, 0x22, 0x50, 0x0a, 0x13, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x55, 0x73, 0x65, 0x72, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x06, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64,
+	0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72,
+	0x64, 0x52, 0x65, 0x73, 0x65, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x06, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x73, 0x22, 0x5c, 0x0a, 0x12, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x52,
+	0x65, 0x73, 0x65, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x14, 0x0a,
+	0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41,
+	0x74, 0x22, 0x62, 0x0a, 0x14, 0x52, 0x65, 0x73, 0x65, 0x74, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f,
+	0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x14, 0x0a,
+	0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x6e, 0x65, 0x77, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f,
+	0x72, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x6e, 0x65, 0x77, 0x50, 0x61, 0x73,
+	0x73, 0x77, 0x6f, 0x72, 0x64, 0x22, 0x3e, 0x0a, 0x0c, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73,
+	0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x70, 0x61, 0x73,
+	0x73, 0x77, 0x6f, 0x72, 0x64, 0x22, 0x3f, 0x0a, 0x0d, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x18, 0x0a, 0x07,
+	0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x77,
+	0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x22, 0x20, 0x0a, 0x0a, 0x41, 0x75, 0x74, 0x68, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x22, 0x26, 0x0a, 0x0a, 0x4d, 0x54, 0x4c, 0x53,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64,
+	0x22, 0x2a, 0x0a, 0x0a, 0x45, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c,
+	0x0a, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x22, 0x93, 0x01, 0x0a,
+	0x0a, 0x4b, 0x56, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x39, 0x0a, 0x0a, 0x65,
+	0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e,
+	0x45, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x69,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x30, 0x0a, 0x07, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62,
+	0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x4b, 0x56, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x52,
+	0x07, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x64, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x64, 0x22, 0x57, 0x0a, 0x07, 0x4b, 0x56, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03,
+	0x74, 0x61, 0x67, 0x12, 0x26, 0x0a, 0x0e, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x50, 0x72, 0x65, 0x66,
+	0x69, 0x78, 0x4c, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x4c, 0x65, 0x6e, 0x22, 0x69, 0x0a, 0x08, 0x4b,
+	0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12,
+	0x35, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x2e, 0x4b, 0x56, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0xd0, 0x01, 0x0a, 0x05, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x0e, 0x0a, 0x02, 0x74, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x74, 0x78,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x3c, 0x0a, 0x0c, 0x72, 0x65, 0x66, 0x65,
+	0x72, 0x65, 0x6e, 0x63, 0x65, 0x64, 0x42, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x52,
+	0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x0c, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65,
+	0x6e, 0x63, 0x65, 0x64, 0x42, 0x79, 0x12, 0x35, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64,
+	0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x4b, 0x56, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1a, 0x0a,
+	0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x41, 0x0a, 0x09, 0x52, 0x65, 0x66,
+	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x78, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x02, 0x74, 0x78, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x74, 0x54, 0x78,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x61, 0x74, 0x54, 0x78, 0x22, 0xa3, 0x01, 0x0a,
+	0x02, 0x4f, 0x70, 0x12, 0x29, 0x0a, 0x02, 0x6b, 0x76, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x17, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e,
+	0x4b, 0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x02, 0x6b, 0x76, 0x12, 0x30,
+	0x0a, 0x04, 0x7a, 0x41, 0x64, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x69,
+	0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x5a, 0x41, 0x64,
+	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x04, 0x7a, 0x41, 0x64, 0x64,
+	0x12, 0x33, 0x0a, 0x03, 0x72, 0x65, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e,
+	0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x52, 0x65,
+	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00,
+	0x52, 0x03, 0x72, 0x65, 0x66, 0x42, 0x0b, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x22, 0x75, 0x0a, 0x0e, 0x45, 0x78, 0x65, 0x63, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x31, 0x0a, 0x0a, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64,
+	0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x4f, 0x70, 0x52, 0x0a, 0x4f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x57, 0x61, 0x69,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6e, 0x6f, 0x57, 0x61, 0x69, 0x74, 0x12,
+	0x18, 0x0a, 0x07, 0x74, 0x72, 0x61, 0x63, 0x65, 0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x07, 0x74, 0x72, 0x61, 0x63, 0x65, 0x49, 0x44, 0x22, 0x39, 0x0a, 0x07, 0x45, 0x6e, 0x74,
+	0x72, 0x69, 0x65, 0x73, 0x12, 0x2e, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x65, 0x6e, 0x74,
+	0x72, 0x69, 0x65, 0x73, 0x22, 0x82, 0x01, 0x0a, 0x06, 0x5a, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x73, 0x65,
+	0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x2a, 0x0a, 0x05, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x2e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05,
+	0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x74, 0x54, 0x78, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x04, 0x61, 0x74, 0x54, 0x78, 0x22, 0x3b, 0x0a, 0x08, 0x5a, 0x45, 0x6e,
+	0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x2f, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e,
+	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x5a, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x65,
+	0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0x9b, 0x01, 0x0a, 0x0b, 0x53, 0x63, 0x61, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x65, 0x6b, 0x4b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x73, 0x65, 0x65, 0x6b, 0x4b, 0x65, 0x79,
+	0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x65, 0x73, 0x63,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x64, 0x65, 0x73, 0x63, 0x12, 0x14, 0x0a, 0x05,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x07, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78, 0x12, 0x16, 0x0a, 0x06,
+	0x6e, 0x6f, 0x57, 0x61, 0x69, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6e, 0x6f,
+	0x57, 0x61, 0x69, 0x74, 0x22, 0xb8, 0x01, 0x0a, 0x10, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x53, 0x63,
+	0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64,
+	0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12,
+	0x1a, 0x0a, 0x08, 0x73, 0x74, 0x61, 0x72, 0x74, 0x4b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x08, 0x73, 0x74, 0x61, 0x72, 0x74, 0x4b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x65,
+	0x6e, 0x64, 0x4b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x65, 0x6e, 0x64,
+	0x4b, 0x65, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x65, 0x73, 0x63, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x04, 0x64, 0x65, 0x73, 0x63, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x18, 0x0a,
+	0x07, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07,
+	0x73, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x57, 0x61, 0x69,
+	0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6e, 0x6f, 0x57, 0x61, 0x69, 0x74, 0x22,
+	0x23, 0x0a, 0x09, 0x4b, 0x65, 0x79, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x16, 0x0a, 0x06,
+	0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x72,
+	0x65, 0x66, 0x69, 0x78, 0x22, 0x22, 0x0a, 0x0a, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x47, 0x0a, 0x09, 0x53, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63,
+	0x4b, 0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x22, 0xf9, 0x01, 0x0a, 0x0a, 0x54, 0x78, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x18, 0x0a, 0x07, 0x70, 0x72, 0x65, 0x76, 0x41, 0x6c, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x07, 0x70, 0x72, 0x65, 0x76, 0x41, 0x6c, 0x68, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x65,
+	0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6e, 0x65,
+	0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x65, 0x48, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x02, 0x65, 0x48, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x6c, 0x54, 0x78, 0x49, 0x64,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x62, 0x6c, 0x54, 0x78, 0x49, 0x64, 0x12, 0x16,
+	0x0a, 0x06, 0x62, 0x6c, 0x52, 0x6f, 0x6f, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06,
+	0x62, 0x6c, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x3b, 0x0a, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54, 0x78, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65,
+	0x73, 0x52, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x22, 0x28, 0x0a,
+	0x0c, 0x54, 0x78, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x12, 0x18, 0x0a,
+	0x07, 0x74, 0x72, 0x61, 0x63, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07,
+	0x74, 0x72, 0x61, 0x63, 0x65, 0x49, 0x44, 0x22, 0x63, 0x0a, 0x0b, 0x4c, 0x69, 0x6e, 0x65, 0x61,
+	0x72, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x54, 0x78, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x54, 0x78, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x54, 0x78, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x54, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x54, 0x78, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x65, 0x72, 0x6d, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x05, 0x74, 0x65, 0x72, 0x6d, 0x73, 0x22, 0x81, 0x03, 0x0a,
+	0x09, 0x44, 0x75, 0x61, 0x6c, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x45, 0x0a, 0x10, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x54, 0x78, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54, 0x78, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52,
+	0x10, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x54, 0x78, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x12, 0x45, 0x0a, 0x10, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x54, 0x78, 0x4d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x69, 0x6d,
+	0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54, 0x78, 0x4d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x10, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x54, 0x78,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x26, 0x0a, 0x0e, 0x69, 0x6e, 0x63, 0x6c,
+	0x75, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c,
+	0x52, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66,
+	0x12, 0x2a, 0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x50,
+	0x72, 0x6f, 0x6f, 0x66, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x10, 0x63, 0x6f, 0x6e, 0x73,
+	0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x24, 0x0a, 0x0d,
+	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x42, 0x6c, 0x54, 0x78, 0x41, 0x6c, 0x68, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x0d, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x42, 0x6c, 0x54, 0x78, 0x41,
+	0x6c, 0x68, 0x12, 0x2e, 0x0a, 0x12, 0x6c, 0x61, 0x73, 0x74, 0x49, 0x6e, 0x63, 0x6c, 0x75, 0x73,
+	0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x12,
+	0x6c, 0x61, 0x73, 0x74, 0x49, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f,
+	0x6f, 0x66, 0x12, 0x3c, 0x0a, 0x0b, 0x6c, 0x69, 0x6e, 0x65, 0x61, 0x72, 0x50, 0x72, 0x6f, 0x6f,
+	0x66, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62,
+	0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x4c, 0x69, 0x6e, 0x65, 0x61, 0x72, 0x50, 0x72,
+	0x6f, 0x6f, 0x66, 0x52, 0x0b, 0x6c, 0x69, 0x6e, 0x65, 0x61, 0x72, 0x50, 0x72, 0x6f, 0x6f, 0x66,
+	0x22, 0x6d, 0x0a, 0x02, 0x54, 0x78, 0x12, 0x35, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64,
+	0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54, 0x78, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x30, 0x0a,
+	0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54,
+	0x78, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22,
+	0x92, 0x01, 0x0a, 0x07, 0x54, 0x78, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a,
+	0x06, 0x68, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x68,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x76, 0x4f, 0x66, 0x66, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x04, 0x76, 0x4f, 0x66, 0x66, 0x12, 0x12, 0x0a, 0x04, 0x76, 0x4c, 0x65,
+	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x76, 0x4c, 0x65, 0x6e, 0x12, 0x35, 0x0a,
+	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e,
+	0x4b, 0x56, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x22, 0xa1, 0x01, 0x0a, 0x0c, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61,
+	0x62, 0x6c, 0x65, 0x54, 0x78, 0x12, 0x21, 0x0a, 0x02, 0x74, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x2e, 0x54, 0x78, 0x52, 0x02, 0x74, 0x78, 0x12, 0x36, 0x0a, 0x09, 0x64, 0x75, 0x61, 0x6c,
+	0x50, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x69, 0x6d,
+	0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x44, 0x75, 0x61, 0x6c,
+	0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x09, 0x64, 0x75, 0x61, 0x6c, 0x50, 0x72, 0x6f, 0x6f, 0x66,
+	0x12, 0x36, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x09, 0x73,
+	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0xc5, 0x01, 0x0a, 0x0f, 0x56, 0x65, 0x72,
+	0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x2a, 0x0a, 0x05,
+	0x65, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x69, 0x6d,
+	0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x05, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x3f, 0x0a, 0x0c, 0x76, 0x65, 0x72, 0x69,
+	0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x56,
+	0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x78, 0x52, 0x0c, 0x76, 0x65, 0x72,
+	0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x78, 0x12, 0x45, 0x0a, 0x0e, 0x69, 0x6e, 0x63,
+	0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1d, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x2e, 0x49, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66,
+	0x52, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66,
+	0x22, 0x50, 0x0a, 0x0e, 0x49, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f,
+	0x6f, 0x66, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x65, 0x61, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x04, 0x6c, 0x65, 0x61, 0x66, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x14, 0x0a, 0x05,
+	0x74, 0x65, 0x72, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x05, 0x74, 0x65, 0x72,
+	0x6d, 0x73, 0x22, 0xac, 0x01, 0x0a, 0x0a, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x29, 0x0a, 0x03, 0x4b, 0x56, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x4b,
+	0x65, 0x79, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x03, 0x4b, 0x56, 0x73, 0x12, 0x16, 0x0a, 0x06,
+	0x6e, 0x6f, 0x57, 0x61, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6e, 0x6f,
+	0x57, 0x61, 0x69, 0x74, 0x12, 0x41, 0x0a, 0x0d, 0x70, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x69, 0x6d,
+	0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x50, 0x72, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x70, 0x72, 0x65, 0x63, 0x6f, 0x6e,
+	0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x72, 0x61, 0x63, 0x65,
+	0x49, 0x44, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x74, 0x72, 0x61, 0x63, 0x65, 0x49,
+	0x44, 0x22, 0xb8, 0x04, 0x0a, 0x0c, 0x50, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x63, 0x0a, 0x0f, 0x6b, 0x65, 0x79, 0x4d, 0x75, 0x73, 0x74, 0x4e, 0x6f, 0x74,
+	0x45, 0x78, 0x69, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x37, 0x2e, 0x69, 0x6d,
+	0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x50, 0x72, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4b, 0x65, 0x79, 0x4d, 0x75, 0x73, 0x74,
+	0x4e, 0x6f, 0x74, 0x45, 0x78, 0x69, 0x73, 0x74, 0x50, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0f, 0x6b, 0x65, 0x79, 0x4d, 0x75, 0x73, 0x74, 0x4e,
+	0x6f, 0x74, 0x45, 0x78, 0x69, 0x73, 0x74, 0x12, 0x6f, 0x0a, 0x13, 0x6b, 0x65, 0x79, 0x4d, 0x75,
+	0x73, 0x74, 0x48, 0x61, 0x76, 0x65, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x3b, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x2e, 0x50, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x2e, 0x4b, 0x65, 0x79, 0x4d, 0x75, 0x73, 0x74, 0x48, 0x61, 0x76, 0x65, 0x52, 0x65, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x48, 0x00, 0x52, 0x13, 0x6b, 0x65, 0x79, 0x4d, 0x75, 0x73, 0x74, 0x48, 0x61, 0x76, 0x65,
+	0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x75, 0x0a, 0x15, 0x6b, 0x65, 0x79, 0x4e,
+	0x6f, 0x74, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64, 0x41, 0x66, 0x74, 0x65, 0x72, 0x54,
+	0x58, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3d, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62,
+	0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x50, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4b, 0x65, 0x79, 0x4e, 0x6f, 0x74, 0x4d, 0x6f, 0x64, 0x69, 0x66,
+	0x69, 0x65, 0x64, 0x41, 0x66, 0x74, 0x65, 0x72, 0x54, 0x58, 0x50, 0x72, 0x65, 0x63, 0x6f, 0x6e,
+	0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x15, 0x6b, 0x65, 0x79, 0x4e, 0x6f, 0x74,
+	0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64, 0x41, 0x66, 0x74, 0x65, 0x72, 0x54, 0x58, 0x1a,
+	0x2f, 0x0a, 0x1b, 0x4b, 0x65, 0x79, 0x4d, 0x75, 0x73, 0x74, 0x4e, 0x6f, 0x74, 0x45, 0x78, 0x69,
+	0x73, 0x74, 0x50, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x1a, 0x4f, 0x0a, 0x1f, 0x4b, 0x65, 0x79, 0x4d, 0x75, 0x73, 0x74, 0x48, 0x61, 0x76, 0x65, 0x52,
+	0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x1a, 0x49, 0x0a, 0x21, 0x4b, 0x65, 0x79, 0x4e, 0x6f, 0x74, 0x4d, 0x6f, 0x64, 0x69, 0x66,
+	0x69, 0x65, 0x64, 0x41, 0x66, 0x74, 0x65, 0x72, 0x54, 0x58, 0x50, 0x72, 0x65, 0x63, 0x6f, 0x6e,
+	0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x78, 0x49, 0x44,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x74, 0x78, 0x49, 0x44, 0x42, 0x0e, 0x0a, 0x0c,
+	0x70, 0x72, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x64, 0x0a, 0x0a,
+	0x4b, 0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x12, 0x0a, 0x04,
+	0x61, 0x74, 0x54, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x61, 0x74, 0x54, 0x78,
+	0x12, 0x18, 0x0a, 0x07, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x07, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x73,
+	0x4f, 0x66, 0x54, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x61, 0x73, 0x4f, 0x66,
+	0x54, 0x73, 0x22, 0x3e, 0x0a, 0x0e, 0x4b, 0x65, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0c, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x69, 0x6e, 0x63,
+	0x65, 0x54, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x73, 0x69, 0x6e, 0x63, 0x65,
+	0x54, 0x78, 0x22, 0x75, 0x0a, 0x14, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65,
+	0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x73, 0x65,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53,
+	0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x0a, 0x73, 0x65, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x53, 0x69,
+	0x6e, 0x63, 0x65, 0x54, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x70, 0x72, 0x6f,
+	0x76, 0x65, 0x53, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78, 0x22, 0x75, 0x0a, 0x14, 0x56, 0x65, 0x72,
+	0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x39, 0x0a, 0x0a, 0x6b, 0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x52, 0x0a, 0x6b, 0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c,
+	0x70, 0x72, 0x6f, 0x76, 0x65, 0x53, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x53, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78,
+	0x22, 0x46, 0x0a, 0x1e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x48, 0x69,
+	0x73, 0x74, 0x6f, 0x72, 0x69, 0x63, 0x61, 0x6c, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x74, 0x54, 0x78, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x04, 0x61, 0x74, 0x54, 0x78, 0x22, 0x42, 0x0a, 0x0e, 0x48, 0x65, 0x61, 0x6c,
+	0x74, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x13, 0x0a, 0x11,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x22, 0x68, 0x0a, 0x12, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x38, 0x0a, 0x08, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x52, 0x08, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x22, 0x61, 0x0a, 0x0d, 0x53,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x65, 0x78,
+	0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0c, 0x65, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x22, 0x84,
+	0x01, 0x0a, 0x0e, 0x49, 0x6d, 0x6d, 0x75, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x12, 0x0e, 0x0a, 0x02, 0x64, 0x62, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x64,
+	0x62, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x78, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x04, 0x74, 0x78, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x78, 0x48, 0x61, 0x73, 0x68, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x74, 0x78, 0x48, 0x61, 0x73, 0x68, 0x12, 0x36, 0x0a,
+	0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x18, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0xac, 0x01, 0x0a, 0x10, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65,
+	0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x24, 0x0a, 0x0d,
+	0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x64, 0x4b, 0x65, 0x79, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x0d, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x64, 0x4b,
+	0x65, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x74, 0x54, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x04, 0x61, 0x74, 0x54, 0x78, 0x12, 0x1a, 0x0a, 0x08, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x52,
+	0x65, 0x66, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x52,
+	0x65, 0x66, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x57, 0x61, 0x69, 0x74, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x06, 0x6e, 0x6f, 0x57, 0x61, 0x69, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x72,
+	0x61, 0x63, 0x65, 0x49, 0x44, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x74, 0x72, 0x61,
+	0x63, 0x65, 0x49, 0x44, 0x22, 0x8d, 0x01, 0x0a, 0x1a, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61,
+	0x62, 0x6c, 0x65, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x4b, 0x0a, 0x10, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e,
+	0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x52, 0x65,
+	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x10,
+	0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x22, 0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x53, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x53, 0x69, 0x6e,
+	0x63, 0x65, 0x54, 0x78, 0x22, 0xe5, 0x01, 0x0a, 0x0b, 0x5a, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x03, 0x73, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x12,
+	0x0a, 0x04, 0x61, 0x74, 0x54, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x61, 0x74,
+	0x54, 0x78, 0x12, 0x1a, 0x0a, 0x08, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x52, 0x65, 0x66, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x52, 0x65, 0x66, 0x12, 0x16,
+	0x0a, 0x06, 0x6e, 0x6f, 0x57, 0x61, 0x69, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
+	0x6e, 0x6f, 0x57, 0x61, 0x69, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x72, 0x61, 0x63, 0x65, 0x49,
+	0x44, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x74, 0x72, 0x61, 0x63, 0x65, 0x49, 0x44,
+	0x12, 0x3a, 0x0a, 0x09, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x2e, 0x5a, 0x41, 0x64, 0x64, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x4d, 0x6f, 0x64,
+	0x65, 0x52, 0x09, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x22, 0x1d, 0x0a, 0x05,
+	0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x22, 0xda, 0x02, 0x0a, 0x0c,
+	0x5a, 0x53, 0x63, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03,
+	0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x73, 0x65, 0x74, 0x12, 0x18,
+	0x0a, 0x07, 0x73, 0x65, 0x65, 0x6b, 0x4b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x07, 0x73, 0x65, 0x65, 0x6b, 0x4b, 0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x65, 0x6b,
+	0x53, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x73, 0x65, 0x65,
+	0x6b, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x65, 0x6b, 0x41, 0x74,
+	0x54, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x73, 0x65, 0x65, 0x6b, 0x41, 0x74,
+	0x54, 0x78, 0x12, 0x24, 0x0a, 0x0d, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x76, 0x65, 0x53,
+	0x65, 0x65, 0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x69, 0x6e, 0x63, 0x6c, 0x75,
+	0x73, 0x69, 0x76, 0x65, 0x53, 0x65, 0x65, 0x6b, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x64, 0x65, 0x73, 0x63, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x64, 0x65,
+	0x73, 0x63, 0x12, 0x30, 0x0a, 0x08, 0x6d, 0x69, 0x6e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x08, 0x6d, 0x69, 0x6e, 0x53,
+	0x63, 0x6f, 0x72, 0x65, 0x12, 0x30, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x53, 0x63, 0x6f, 0x72, 0x65,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e,
+	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x08, 0x6d, 0x61,
+	0x78, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x54,
+	0x78, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78,
+	0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x57, 0x61, 0x69, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x06, 0x6e, 0x6f, 0x57, 0x61, 0x69, 0x74, 0x22, 0x3e, 0x0a, 0x08, 0x54, 0x53, 0x53, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x3e, 0x0a, 0x09, 0x54, 0x53, 0x53, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x73, 0x12, 0x31, 0x0a, 0x07, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e,
+	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54, 0x53, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52,
+	0x07, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x22, 0x8e, 0x01, 0x0a, 0x0f, 0x54, 0x53, 0x41,
+	0x70, 0x70, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x65, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x65,
+	0x72, 0x69, 0x65, 0x73, 0x12, 0x31, 0x0a, 0x07, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54, 0x53, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x07,
+	0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x57, 0x61, 0x69,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6e, 0x6f, 0x57, 0x61, 0x69, 0x74, 0x12,
+	0x18, 0x0a, 0x07, 0x74, 0x72, 0x61, 0x63, 0x65, 0x49, 0x44, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x07, 0x74, 0x72, 0x61, 0x63, 0x65, 0x49, 0x44, 0x22, 0x84, 0x02, 0x0a, 0x0e, 0x54, 0x53,
+	0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x65, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x65,
+	0x72, 0x69, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06,
+	0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x77, 0x69,
+	0x6e, 0x64, 0x6f, 0x77, 0x12, 0x3e, 0x0a, 0x0b, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x69, 0x6d, 0x6d, 0x75,
+	0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54, 0x53, 0x41, 0x67, 0x67, 0x72,
+	0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x65,
+	0x73, 0x63, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x64, 0x65, 0x73, 0x63, 0x12, 0x18,
+	0x0a, 0x07, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x07, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x57, 0x61,
+	0x69, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6e, 0x6f, 0x57, 0x61, 0x69, 0x74,
+	0x22, 0x5d, 0x0a, 0x0f, 0x4e, 0x6f, 0x74, 0x61, 0x72, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0c, 0x52, 0x07, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x12, 0x16, 0x0a,
+	0x06, 0x6e, 0x6f, 0x57, 0x61, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6e,
+	0x6f, 0x57, 0x61, 0x69, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x72, 0x61, 0x63, 0x65, 0x49, 0x44,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x74, 0x72, 0x61, 0x63, 0x65, 0x49, 0x44, 0x22,
+	0xe4, 0x01, 0x0a, 0x13, 0x4e, 0x6f, 0x74, 0x61, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12,
+	0x39, 0x0a, 0x0a, 0x74, 0x78, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x2e, 0x54, 0x78, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x0a,
+	0x74, 0x78, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x45, 0x0a, 0x0e, 0x69, 0x6e,
+	0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x2e, 0x49, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f,
+	0x66, 0x52, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f,
+	0x66, 0x12, 0x33, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1d, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x2e, 0x49, 0x6d, 0x6d, 0x75, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52,
+	0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x22, 0x56, 0x0a, 0x14, 0x4e, 0x6f, 0x74, 0x61, 0x72, 0x69,
+	0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x73, 0x12, 0x3e,
+	0x0a, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x22, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x2e, 0x4e, 0x6f, 0x74, 0x61, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x63,
+	0x65, 0x69, 0x70, 0x74, 0x52, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x73, 0x22, 0x36,
+	0x0a, 0x18, 0x4e, 0x6f, 0x74, 0x61, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x56, 0x65,
+	0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x76, 0x65,
+	0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x76, 0x65,
+	0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x22, 0x9e, 0x01, 0x0a, 0x0e, 0x48, 0x69, 0x73, 0x74, 0x6f,
+	0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x6f,
+	0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6f, 0x66, 0x66,
+	0x73, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x65, 0x73,
+	0x63, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x64, 0x65, 0x73, 0x63, 0x12, 0x18, 0x0a,
+	0x07, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07,
+	0x73, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x74, 0x52, 0x65, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x61, 0x74, 0x52,
+	0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x79, 0x0a, 0x15, 0x56, 0x65, 0x72, 0x69, 0x66,
+	0x69, 0x61, 0x62, 0x6c, 0x65, 0x5a, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x3c, 0x0a, 0x0b, 0x7a, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x5a, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x52, 0x0b, 0x7a, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22,
+	0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x53, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x53, 0x69, 0x6e, 0x63, 0x65,
+	0x54, 0x78, 0x22, 0x85, 0x01, 0x0a, 0x09, 0x54, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x0e, 0x0a, 0x02, 0x74, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x74, 0x78,
+	0x12, 0x24, 0x0a, 0x0d, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x4f, 0x66, 0x66, 0x73, 0x65,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73,
+	0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65,
+	0x73, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x65, 0x6e,
+	0x74, 0x72, 0x69, 0x65, 0x73, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x69,
+	0x67, 0x65, 0x73, 0x74, 0x4f, 0x6e, 0x6c, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a,
+	0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x4f, 0x6e, 0x6c, 0x79, 0x22, 0x62, 0x0a, 0x0e, 0x54, 0x78,
+	0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07,
+	0x73, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x73,
+	0x69, 0x6e, 0x63, 0x65, 0x54, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x70, 0x54, 0x6f, 0x54, 0x78,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x75, 0x70, 0x54, 0x6f, 0x54, 0x78, 0x12, 0x1e,
+	0x0a, 0x0a, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x71,
+	0x0a, 0x07, 0x54, 0x78, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x69, 0x6e,
+	0x63, 0x65, 0x54, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x73, 0x69, 0x6e, 0x63,
+	0x65, 0x54, 0x78, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x41, 0x6c, 0x68, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x41, 0x6c, 0x68, 0x12,
+	0x16, 0x0a, 0x06, 0x75, 0x70, 0x54, 0x6f, 0x54, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x06, 0x75, 0x70, 0x54, 0x6f, 0x54, 0x78, 0x12, 0x18, 0x0a, 0x07, 0x75, 0x70, 0x54, 0x6f, 0x41,
+	0x6c, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x75, 0x70, 0x54, 0x6f, 0x41, 0x6c,
+	0x68, 0x22, 0x49, 0x0a, 0x13, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x54,
+	0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x78, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x74, 0x78, 0x12, 0x22, 0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x76,
+	0x65, 0x53, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c,
+	0x70, 0x72, 0x6f, 0x76, 0x65, 0x53, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78, 0x22, 0x57, 0x0a, 0x0d,
+	0x54, 0x78, 0x53, 0x63, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a,
+	0x09, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x54, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x09, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x54, 0x78, 0x12, 0x14, 0x0a, 0x05, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x65, 0x73, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x04, 0x64, 0x65, 0x73, 0x63, 0x22, 0x2d, 0x0a, 0x06, 0x54, 0x78, 0x4c, 0x69, 0x73, 0x74, 0x12,
+	0x23, 0x0a, 0x03, 0x74, 0x78, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x69,
+	0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54, 0x78, 0x52,
+	0x03, 0x74, 0x78, 0x73, 0x22, 0x46, 0x0a, 0x10, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x6e, 0x69, 0x74,
+	0x69, 0x61, 0x6c, 0x54, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x69, 0x6e, 0x69,
+	0x74, 0x69, 0x61, 0x6c, 0x54, 0x78, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x2e, 0x0a, 0x08,
+	0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x61, 0x74, 0x61,
+	0x62, 0x61, 0x73, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x9a, 0x01, 0x0a,
+	0x10, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
+	0x73, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x4e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73,
+	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x4b, 0x65, 0x79, 0x4c,
+	0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x4b, 0x65, 0x79,
+	0x4c, 0x65, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x4c,
+	0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x4c, 0x65, 0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x54, 0x78, 0x45, 0x6e,
+	0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x6d, 0x61, 0x78,
+	0x54, 0x78, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0x25, 0x0a, 0x05, 0x54, 0x61, 0x62,
+	0x6c, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65,
+	0x22, 0x86, 0x01, 0x0a, 0x0d, 0x53, 0x51, 0x4c, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x31, 0x0a, 0x07, 0x70, 0x6b, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x69, 0x6d, 0x6d, 0x75,
+	0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53, 0x51, 0x4c, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x52, 0x07, 0x70, 0x6b, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x61,
+	0x74, 0x54, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x61, 0x74, 0x54, 0x78, 0x12,
+	0x18, 0x0a, 0x07, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x07, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78, 0x22, 0x81, 0x01, 0x0a, 0x17, 0x56, 0x65,
+	0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x51, 0x4c, 0x47, 0x65, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x42, 0x0a, 0x0d, 0x73, 0x71, 0x6c, 0x47, 0x65, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x69,
+	0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53, 0x51, 0x4c,
+	0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x0d, 0x73, 0x71, 0x6c, 0x47,
+	0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x70, 0x72, 0x6f,
+	0x76, 0x65, 0x53, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0c, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x53, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78, 0x22, 0x42, 0x0a,
+	0x08, 0x53, 0x51, 0x4c, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x78, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x74, 0x78, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x22, 0xe9, 0x05, 0x0a, 0x12, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65,
+	0x53, 0x51, 0x4c, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x33, 0x0a, 0x08, 0x73, 0x71, 0x6c, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x69, 0x6d, 0x6d,
+	0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53, 0x51, 0x4c, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x08, 0x73, 0x71, 0x6c, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x3f, 0x0a,
+	0x0c, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x78, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x78,
+	0x52, 0x0c, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x78, 0x12, 0x45,
+	0x0a, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e,
+	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x49, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e,
+	0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e,
+	0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x1e, 0x0a, 0x0a, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73,
+	0x65, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x44, 0x61, 0x74, 0x61, 0x62,
+	0x61, 0x73, 0x65, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x49, 0x64,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x49, 0x64, 0x12,
+	0x16, 0x0a, 0x06, 0x50, 0x4b, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x50, 0x4b, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x51, 0x0a, 0x0a, 0x43, 0x6f, 0x6c, 0x49, 0x64,
+	0x73, 0x42, 0x79, 0x49, 0x64, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x31, 0x2e, 0x69, 0x6d,
+	0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x56, 0x65, 0x72, 0x69,
+	0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x51, 0x4c, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x2e, 0x43,
+	0x6f, 0x6c, 0x49, 0x64, 0x73, 0x42, 0x79, 0x49, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a,
+	0x43, 0x6f, 0x6c, 0x49, 0x64, 0x73, 0x42, 0x79, 0x49, 0x64, 0x12, 0x57, 0x0a, 0x0c, 0x43, 0x6f,
+	0x6c, 0x49, 0x64, 0x73, 0x42, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x33, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x51, 0x4c, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x2e, 0x43, 0x6f, 0x6c, 0x49, 0x64, 0x73, 0x42, 0x79, 0x4e, 0x61, 0x6d, 0x65,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x43, 0x6f, 0x6c, 0x49, 0x64, 0x73, 0x42, 0x79, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x57, 0x0a, 0x0c, 0x43, 0x6f, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x73, 0x42,
+	0x79, 0x49, 0x64, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x69, 0x6d, 0x6d, 0x75,
+	0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69,
+	0x61, 0x62, 0x6c, 0x65, 0x53, 0x51, 0x4c, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x2e, 0x43, 0x6f, 0x6c,
+	0x54, 0x79, 0x70, 0x65, 0x73, 0x42, 0x79, 0x49, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c,
+	0x43, 0x6f, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x73, 0x42, 0x79, 0x49, 0x64, 0x1a, 0x3d, 0x0a, 0x0f,
+	0x43, 0x6f, 0x6c, 0x49, 0x64, 0x73, 0x42, 0x79, 0x49, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3f, 0x0a, 0x11, 0x43,
+	0x6f, 0x6c, 0x49, 0x64, 0x73, 0x42, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3f, 0x0a, 0x11,
+	0x43, 0x6f, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x73, 0x42, 0x79, 0x49, 0x64, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x89, 0x01,
+	0x0a, 0x19, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x51, 0x4c, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x48, 0x0a, 0x0f, 0x73,
+	0x71, 0x6c, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53, 0x51, 0x4c, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x52, 0x0f, 0x73, 0x71, 0x6c, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x53, 0x69,
+	0x6e, 0x63, 0x65, 0x54, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x70, 0x72, 0x6f,
+	0x76, 0x65, 0x53, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78, 0x22, 0xc6, 0x01, 0x0a, 0x10, 0x56, 0x65,
+	0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x51, 0x4c, 0x52, 0x6f, 0x77, 0x12, 0x33,
+	0x0a, 0x08, 0x73, 0x71, 0x6c, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x2e, 0x53, 0x51, 0x4c, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x73, 0x71, 0x6c, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x45, 0x0a, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e,
+	0x50, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x69, 0x6d,
+	0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x49, 0x6e, 0x63, 0x6c,
+	0x75, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x0e, 0x69, 0x6e, 0x63, 0x6c,
+	0x75, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x36, 0x0a, 0x09, 0x64, 0x75,
+	0x61, 0x6c, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e,
+	0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x44, 0x75,
+	0x61, 0x6c, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x09, 0x64, 0x75, 0x61, 0x6c, 0x50, 0x72, 0x6f,
+	0x6f, 0x66, 0x22, 0xf9, 0x04, 0x0a, 0x18, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c,
+	0x65, 0x53, 0x51, 0x4c, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12,
+	0x35, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1d, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e,
+	0x53, 0x51, 0x4c, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x3f, 0x0a, 0x0c, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69,
+	0x61, 0x62, 0x6c, 0x65, 0x54, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x69,
+	0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x56, 0x65, 0x72,
+	0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x78, 0x52, 0x0c, 0x76, 0x65, 0x72, 0x69, 0x66,
+	0x69, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x78, 0x12, 0x33, 0x0a, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65,
+	0x53, 0x51, 0x4c, 0x52, 0x6f, 0x77, 0x52, 0x04, 0x72, 0x6f, 0x77, 0x73, 0x12, 0x1e, 0x0a, 0x0a,
+	0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0a, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07,
+	0x54, 0x61, 0x62, 0x6c, 0x65, 0x49, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x54,
+	0x61, 0x62, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x4e,
+	0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x54, 0x61, 0x62, 0x6c, 0x65,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x50, 0x4b, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x18,
+	0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x50, 0x4b, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x5d,
+	0x0a, 0x0c, 0x43, 0x6f, 0x6c, 0x49, 0x64, 0x73, 0x42, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x08,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x39, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x53,
+	0x51, 0x4c, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x43, 0x6f,
+	0x6c, 0x49, 0x64, 0x73, 0x42, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x0c, 0x43, 0x6f, 0x6c, 0x49, 0x64, 0x73, 0x42, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x5d, 0x0a,
+	0x0c, 0x43, 0x6f, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x73, 0x42, 0x79, 0x49, 0x64, 0x18, 0x09, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x39, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x51,
+	0x4c, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x43, 0x6f, 0x6c,
+	0x54, 0x79, 0x70, 0x65, 0x73, 0x42, 0x79, 0x49, 0x64, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c,
+	0x43, 0x6f, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x73, 0x42, 0x79, 0x49, 0x64, 0x1a, 0x3f, 0x0a, 0x11,
+	0x43, 0x6f, 0x6c, 0x49, 0x64, 0x73, 0x42, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3f, 0x0a,
+	0x11, 0x43, 0x6f, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x73, 0x42, 0x79, 0x49, 0x64, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x28,
+	0x0a, 0x10, 0x55, 0x73, 0x65, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xaa, 0x01, 0x0a, 0x17, 0x43, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x37, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x2e, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x41,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a,
+	0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x61, 0x74,
+	0x61, 0x62, 0x61, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x61, 0x74,
+	0x61, 0x62, 0x61, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x70, 0x65, 0x72, 0x6d, 0x69,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x4a, 0x0a, 0x14, 0x53, 0x65, 0x74, 0x41, 0x63, 0x74, 0x69,
+	0x76, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x61,
+	0x63, 0x74, 0x69, 0x76, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d,
+	0x65, 0x22, 0x4d, 0x0a, 0x14, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x4c, 0x69, 0x73,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x09, 0x64, 0x61, 0x74,
+	0x61, 0x62, 0x61, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x69,
+	0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x44, 0x61, 0x74,
+	0x61, 0x62, 0x61, 0x73, 0x65, 0x52, 0x09, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x73,
+	0x22, 0x6d, 0x0a, 0x0f, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x62,
+	0x61, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x4e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x62,
+	0x61, 0x73, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x64, 0x41, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x64, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x75, 0x72, 0x67, 0x65, 0x41, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x70, 0x75, 0x72, 0x67, 0x65, 0x41, 0x74, 0x22,
+	0x6d, 0x0a, 0x16, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x45, 0x78, 0x65, 0x63, 0x41,
+	0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x61, 0x74,
+	0x61, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x61, 0x74,
+	0x61, 0x62, 0x61, 0x73, 0x65, 0x12, 0x37, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e,
+	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x41, 0x6c, 0x6c, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x5a,
+	0x0a, 0x15, 0x45, 0x78, 0x65, 0x63, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x44, 0x62, 0x41, 0x6c, 0x6c,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x41, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x69, 0x6d, 0x6d, 0x75,
+	0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61,
+	0x73, 0x65, 0x45, 0x78, 0x65, 0x63, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x52, 0x08, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x22, 0x45, 0x0a, 0x16, 0x45, 0x78,
+	0x65, 0x63, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x44, 0x62, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x03, 0x74, 0x78, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x2e, 0x54, 0x78, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x03, 0x74, 0x78,
+	0x73, 0x22, 0x46, 0x0a, 0x0a, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x64, 0x46, 0x69,
+	0x65, 0x6c, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x69, 0x6e, 0x64, 0x65,
+	0x78, 0x65, 0x64, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x22, 0x4d, 0x0a, 0x0e, 0x43, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x3b, 0x0a, 0x0b, 0x63,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x63, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xa0, 0x01, 0x0a, 0x15, 0x44, 0x6f, 0x63,
+	0x75, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x35, 0x0a, 0x09, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x09,
+	0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x57,
+	0x61, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6e, 0x6f, 0x57, 0x61, 0x69,
+	0x74, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x72, 0x61, 0x63, 0x65, 0x49, 0x44, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x07, 0x74, 0x72, 0x61, 0x63, 0x65, 0x49, 0x44, 0x22, 0x65, 0x0a, 0x16, 0x44,
+	0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x0a, 0x74, 0x78, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75,
+	0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54, 0x78, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x52, 0x0a, 0x74, 0x78, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x69,
+	0x64, 0x73, 0x22, 0x76, 0x0a, 0x12, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x47, 0x65,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6c, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x69, 0x6e, 0x63,
+	0x65, 0x54, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x73, 0x69, 0x6e, 0x63, 0x65,
+	0x54, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x57, 0x61, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x06, 0x6e, 0x6f, 0x57, 0x61, 0x69, 0x74, 0x22, 0x4f, 0x0a, 0x08, 0x44, 0x6f,
+	0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x33, 0x0a, 0x08, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65,
+	0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63,
+	0x74, 0x52, 0x08, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x74,
+	0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x74, 0x78, 0x22, 0x97, 0x01, 0x0a, 0x0e,
+	0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x14,
+	0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66,
+	0x69, 0x65, 0x6c, 0x64, 0x12, 0x41, 0x0a, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e,
+	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x08, 0x6f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xb8, 0x01, 0x0a, 0x15, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65,
+	0x6e, 0x74, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x37, 0x0a, 0x07, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1d, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52,
+	0x07, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x18,
+	0x0a, 0x07, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x07, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x54, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x57, 0x61,
+	0x69, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6e, 0x6f, 0x57, 0x61, 0x69, 0x74,
+	0x22, 0x45, 0x0a, 0x0c, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x4c, 0x69, 0x73, 0x74,
+	0x12, 0x35, 0x0a, 0x09, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x09, 0x64, 0x6f,
+	0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x62, 0x0a, 0x0e, 0x4c, 0x6f, 0x67, 0x4f, 0x70,
+	0x65, 0x6e, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74,
+	0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x14, 0x0a,
+	0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69,
+	0x6c, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x4f, 0x70, 0x65, 0x6e, 0x65, 0x64,
+	0x46, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x6d, 0x61, 0x78,
+	0x4f, 0x70, 0x65, 0x6e, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x22, 0x64, 0x0a, 0x13, 0x44,
+	0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x4f, 0x70, 0x65, 0x6e, 0x65, 0x64, 0x46, 0x69, 0x6c,
+	0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12, 0x31,
+	0x0a, 0x04, 0x6c, 0x6f, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x69,
+	0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x4c, 0x6f, 0x67,
+	0x4f, 0x70, 0x65, 0x6e, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x04, 0x6c, 0x6f, 0x67,
+	0x73, 0x22, 0xbd, 0x01, 0x0a, 0x13, 0x4f, 0x70, 0x65, 0x6e, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x40, 0x0a, 0x09, 0x64, 0x61, 0x74,
+	0x61, 0x62, 0x61, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x69,
+	0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x44, 0x61, 0x74,
+	0x61, 0x62, 0x61, 0x73, 0x65, 0x4f, 0x70, 0x65, 0x6e, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73,
+	0x52, 0x09, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x73, 0x12, 0x2e, 0x0a, 0x12, 0x70,
+	0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x4f, 0x70, 0x65, 0x6e, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x12, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73,
+	0x4f, 0x70, 0x65, 0x6e, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x34, 0x0a, 0x15, 0x70,
+	0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x4d, 0x61, 0x78, 0x4f, 0x70, 0x65, 0x6e, 0x65, 0x64, 0x46,
+	0x69, 0x6c, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x15, 0x70, 0x72, 0x6f, 0x63,
+	0x65, 0x73, 0x73, 0x4d, 0x61, 0x78, 0x4f, 0x70, 0x65, 0x6e, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65,
+	0x73, 0x22, 0x3c, 0x0a, 0x18, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64,
+	0x46, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x20, 0x0a,
+	0x0b, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0b, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x22,
+	0x5b, 0x0a, 0x1b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61,
+	0x73, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c,
+	0x0a, 0x09, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1e, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73,
+	0x65, 0x52, 0x09, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x73, 0x22, 0x38, 0x0a, 0x1c,
+	0x52, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x45, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x4b, 0x65, 0x79, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x72, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x72,
+	0x6f, 0x74, 0x61, 0x74, 0x65, 0x64, 0x22, 0x44, 0x0a, 0x20, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c,
+	0x41, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x70,
+	0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x41, 0x0a, 0x21,
+	0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c, 0x65, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c, 0x65, 0x64, 0x22,
+	0x21, 0x0a, 0x05, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74,
+	0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65,
+	0x6e, 0x74, 0x22, 0x95, 0x01, 0x0a, 0x12, 0x55, 0x73, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x69, 0x6e,
+	0x63, 0x65, 0x54, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x73, 0x69, 0x6e, 0x63,
+	0x65, 0x54, 0x78, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x73, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x54,
+	0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x61, 0x73, 0x42, 0x65, 0x66, 0x6f, 0x72,
+	0x65, 0x54, 0x78, 0x12, 0x45, 0x0a, 0x0e, 0x69, 0x73, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x4c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x69, 0x6d,
+	0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x49, 0x73, 0x6f, 0x6c,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x0e, 0x69, 0x73, 0x6f, 0x6c,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x22, 0x93, 0x01, 0x0a, 0x0e, 0x53,
+	0x51, 0x4c, 0x45, 0x78, 0x65, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a,
+	0x03, 0x73, 0x71, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x71, 0x6c, 0x12,
+	0x31, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e,
+	0x4e, 0x61, 0x6d, 0x65, 0x64, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x52, 0x06, 0x70, 0x61, 0x72, 0x61,
+	0x6d, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x57, 0x61, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x06, 0x6e, 0x6f, 0x57, 0x61, 0x69, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x74, 0x72,
+	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64,
+	0x22, 0x5c, 0x0a, 0x0f, 0x53, 0x51, 0x4c, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x31, 0x0a, 0x05, 0x73, 0x74, 0x6d, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x2e, 0x53, 0x51, 0x4c, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x74, 0x6d, 0x74, 0x52,
+	0x05, 0x73, 0x74, 0x6d, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x57, 0x61, 0x69, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6e, 0x6f, 0x57, 0x61, 0x69, 0x74, 0x22, 0x53,
+	0x0a, 0x0c, 0x53, 0x51, 0x4c, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x74, 0x6d, 0x74, 0x12, 0x10,
+	0x0a, 0x03, 0x73, 0x71, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x71, 0x6c,
+	0x12, 0x31, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x64, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x52, 0x06, 0x70, 0x61, 0x72,
+	0x61, 0x6d, 0x73, 0x22, 0xab, 0x01, 0x0a, 0x0e, 0x53, 0x51, 0x4c, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x2d, 0x0a, 0x04, 0x63, 0x74, 0x78, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54, 0x78, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52,
+	0x04, 0x63, 0x74, 0x78, 0x73, 0x12, 0x2d, 0x0a, 0x04, 0x64, 0x74, 0x78, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x2e, 0x54, 0x78, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x04,
+	0x64, 0x74, 0x78, 0x73, 0x12, 0x3b, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53, 0x51, 0x4c, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x74,
+	0x6d, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x73, 0x22, 0xdc, 0x01, 0x0a, 0x12, 0x53, 0x51, 0x4c, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x74,
+	0x6d, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x75, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x64, 0x52, 0x6f, 0x77, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x75,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x52, 0x6f, 0x77, 0x73, 0x12, 0x60, 0x0a, 0x0f, 0x6c, 0x61,
+	0x73, 0x74, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x65, 0x64, 0x50, 0x4b, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x36, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x2e, 0x53, 0x51, 0x4c, 0x42, 0x61, 0x74, 0x63, 0x68, 0x53, 0x74, 0x6d, 0x74,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x4c, 0x61, 0x73, 0x74, 0x49, 0x6e, 0x73, 0x65, 0x72,
+	0x74, 0x65, 0x64, 0x50, 0x4b, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0f, 0x6c, 0x61, 0x73,
+	0x74, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x65, 0x64, 0x50, 0x4b, 0x73, 0x1a, 0x42, 0x0a, 0x14,
+	0x4c, 0x61, 0x73, 0x74, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x65, 0x64, 0x50, 0x4b, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x22, 0x7c, 0x0a, 0x0f, 0x53, 0x51, 0x4c, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x71, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x73, 0x71, 0x6c, 0x12, 0x31, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x64, 0x50, 0x61, 0x72, 0x61, 0x6d,
+	0x52, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x72, 0x65, 0x75, 0x73,
+	0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0d, 0x72, 0x65, 0x75, 0x73, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x22, 0x4f,
+	0x0a, 0x0a, 0x4e, 0x61, 0x6d, 0x65, 0x64, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x2d, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x17, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e,
+	0x53, 0x51, 0x4c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22,
+	0xf7, 0x02, 0x0a, 0x0d, 0x53, 0x51, 0x4c, 0x45, 0x78, 0x65, 0x63, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x12, 0x2d, 0x0a, 0x04, 0x63, 0x74, 0x78, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e,
+	0x54, 0x78, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x04, 0x63, 0x74, 0x78, 0x73,
+	0x12, 0x2d, 0x0a, 0x04, 0x64, 0x74, 0x78, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54,
+	0x78, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x04, 0x64, 0x74, 0x78, 0x73, 0x12,
+	0x5b, 0x0a, 0x0f, 0x6c, 0x61, 0x73, 0x74, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x65, 0x64, 0x50,
+	0x4b, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x31, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64,
+	0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53, 0x51, 0x4c, 0x45, 0x78, 0x65, 0x63,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x4c, 0x61, 0x73, 0x74, 0x49, 0x6e, 0x73, 0x65, 0x72,
+	0x74, 0x65, 0x64, 0x50, 0x4b, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0f, 0x6c, 0x61, 0x73,
+	0x74, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x65, 0x64, 0x50, 0x4b, 0x73, 0x12, 0x24, 0x0a, 0x0d,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x49, 0x64, 0x12, 0x41, 0x0a, 0x0c, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x65, 0x64, 0x52, 0x6f,
+	0x77, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64,
+	0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53, 0x51, 0x4c, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x0c, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x65,
+	0x64, 0x52, 0x6f, 0x77, 0x73, 0x1a, 0x42, 0x0a, 0x14, 0x4c, 0x61, 0x73, 0x74, 0x49, 0x6e, 0x73,
+	0x65, 0x72, 0x74, 0x65, 0x64, 0x50, 0x4b, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x69, 0x0a, 0x0e, 0x53, 0x51, 0x4c,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x2f, 0x0a, 0x07, 0x63,
+	0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x69,
+	0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x43, 0x6f, 0x6c,
+	0x75, 0x6d, 0x6e, 0x52, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x12, 0x26, 0x0a, 0x04,
+	0x72, 0x6f, 0x77, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x69, 0x6d, 0x6d,
+	0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x52, 0x6f, 0x77, 0x52, 0x04,
+	0x72, 0x6f, 0x77, 0x73, 0x22, 0x1d, 0x0a, 0x09, 0x53, 0x51, 0x4c, 0x53, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x64, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x64, 0x64, 0x6c, 0x22, 0x30, 0x0a, 0x06, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x50, 0x0a, 0x03, 0x52, 0x6f, 0x77, 0x12, 0x18, 0x0a, 0x07,
+	0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x63,
+	0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x12, 0x2f, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e,
+	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53, 0x51, 0x4c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52,
+	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0xdc, 0x01, 0x0a, 0x08, 0x53, 0x51, 0x4c, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x12, 0x30, 0x0a, 0x04, 0x6e, 0x75, 0x6c, 0x6c, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4e, 0x75, 0x6c, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00,
+	0x52, 0x04, 0x6e, 0x75, 0x6c, 0x6c, 0x12, 0x0e, 0x0a, 0x01, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x48, 0x00, 0x52, 0x01, 0x6e, 0x12, 0x0e, 0x0a, 0x01, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x48, 0x00, 0x52, 0x01, 0x73, 0x12, 0x0e, 0x0a, 0x01, 0x62, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x08, 0x48, 0x00, 0x52, 0x01, 0x62, 0x12, 0x10, 0x0a, 0x02, 0x62, 0x73, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0c, 0x48, 0x00, 0x52, 0x02, 0x62, 0x73, 0x12, 0x0e, 0x0a, 0x01, 0x66, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x01, 0x48, 0x00, 0x52, 0x01, 0x66, 0x12, 0x10, 0x0a, 0x02, 0x74, 0x73, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x03, 0x48, 0x00, 0x52, 0x02, 0x74, 0x73, 0x12, 0x31, 0x0a, 0x04, 0x6c, 0x69,
+	0x73, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64,
+	0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53, 0x51, 0x4c, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x4c, 0x69, 0x73, 0x74, 0x48, 0x00, 0x52, 0x04, 0x6c, 0x69, 0x73, 0x74, 0x42, 0x07, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x3f, 0x0a, 0x0c, 0x53, 0x51, 0x4c, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x2f, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e,
+	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53, 0x51, 0x4c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52,
+	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x2a, 0x47, 0x0a, 0x0d, 0x5a, 0x41, 0x64, 0x64, 0x53,
+	0x63, 0x6f, 0x72, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x10, 0x0a, 0x0c, 0x43, 0x4c, 0x49, 0x45,
+	0x4e, 0x54, 0x5f, 0x53, 0x43, 0x4f, 0x52, 0x45, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x54, 0x58,
+	0x5f, 0x49, 0x44, 0x5f, 0x53, 0x43, 0x4f, 0x52, 0x45, 0x10, 0x01, 0x12, 0x13, 0x0a, 0x0f, 0x54,
+	0x49, 0x4d, 0x45, 0x53, 0x54, 0x41, 0x4d, 0x50, 0x5f, 0x53, 0x43, 0x4f, 0x52, 0x45, 0x10, 0x02,
+	0x2a, 0x53, 0x0a, 0x0d, 0x54, 0x53, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x56, 0x47, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x4d, 0x49,
+	0x4e, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x4d, 0x41, 0x58, 0x10, 0x02, 0x12, 0x07, 0x0a, 0x03,
+	0x53, 0x55, 0x4d, 0x10, 0x03, 0x12, 0x09, 0x0a, 0x05, 0x43, 0x4f, 0x55, 0x4e, 0x54, 0x10, 0x04,
+	0x12, 0x09, 0x0a, 0x05, 0x46, 0x49, 0x52, 0x53, 0x54, 0x10, 0x05, 0x12, 0x08, 0x0a, 0x04, 0x4c,
+	0x41, 0x53, 0x54, 0x10, 0x06, 0x2a, 0x29, 0x0a, 0x10, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x09, 0x0a, 0x05, 0x47, 0x52, 0x41,
+	0x4e, 0x54, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x52, 0x45, 0x56, 0x4f, 0x4b, 0x45, 0x10, 0x01,
+	0x2a, 0x48, 0x0a, 0x16, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x46, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x06, 0x0a, 0x02, 0x45, 0x51,
+	0x10, 0x00, 0x12, 0x06, 0x0a, 0x02, 0x4e, 0x45, 0x10, 0x01, 0x12, 0x06, 0x0a, 0x02, 0x4c, 0x54,
+	0x10, 0x02, 0x12, 0x06, 0x0a, 0x02, 0x4c, 0x45, 0x10, 0x03, 0x12, 0x06, 0x0a, 0x02, 0x47, 0x54,
+	0x10, 0x04, 0x12, 0x06, 0x0a, 0x02, 0x47, 0x45, 0x10, 0x05, 0x2a, 0x32, 0x0a, 0x0e, 0x49, 0x73,
+	0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x0c, 0x0a, 0x08,
+	0x53, 0x4e, 0x41, 0x50, 0x53, 0x48, 0x4f, 0x54, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x52, 0x45,
+	0x41, 0x44, 0x5f, 0x43, 0x4f, 0x4d, 0x4d, 0x49, 0x54, 0x54, 0x45, 0x44, 0x10, 0x01, 0x32, 0xe0,
+	0x3e, 0x0a, 0x0b, 0x49, 0x6d, 0x6d, 0x75, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x50,
+	0x0a, 0x09, 0x4c, 0x69, 0x73, 0x74, 0x55, 0x73, 0x65, 0x72, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x1a, 0x17, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x12, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x0c, 0x12, 0x0a, 0x2f, 0x75, 0x73, 0x65, 0x72, 0x2f, 0x6c, 0x69, 0x73, 0x74,
+	0x12, 0x58, 0x0a, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x12, 0x20,
+	0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x10, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0a,
+	0x22, 0x05, 0x2f, 0x75, 0x73, 0x65, 0x72, 0x3a, 0x01, 0x2a, 0x12, 0x70, 0x0a, 0x0e, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x24, 0x2e, 0x69,
+	0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x43, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x20, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x1a, 0x22, 0x15, 0x2f, 0x75, 0x73, 0x65, 0x72, 0x2f, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f,
+	0x72, 0x64, 0x2f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x3a, 0x01, 0x2a, 0x12, 0x54, 0x0a, 0x0b,
+	0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x55, 0x73, 0x65, 0x72, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x1a, 0x17, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x14, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x0e, 0x12, 0x0c, 0x2f, 0x75, 0x73, 0x65, 0x72, 0x2f, 0x65, 0x78, 0x70, 0x6f,
+	0x72, 0x74, 0x12, 0x63, 0x0a, 0x0b, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x55, 0x73, 0x65, 0x72,
+	0x73, 0x12, 0x17, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x69, 0x6d, 0x6d,
+	0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72,
+	0x74, 0x55, 0x73, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x17,
+	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x11, 0x22, 0x0c, 0x2f, 0x75, 0x73, 0x65, 0x72, 0x2f, 0x69, 0x6d,
+	0x70, 0x6f, 0x72, 0x74, 0x3a, 0x01, 0x2a, 0x12, 0x72, 0x0a, 0x0d, 0x52, 0x65, 0x73, 0x65, 0x74,
+	0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x23, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64,
+	0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x74, 0x50, 0x61,
+	0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x24, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x19, 0x22, 0x14, 0x2f,
+	0x75, 0x73, 0x65, 0x72, 0x2f, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x2f, 0x72, 0x65,
+	0x73, 0x65, 0x74, 0x3a, 0x01, 0x2a, 0x92, 0x41, 0x02, 0x62, 0x00, 0x12, 0x47, 0x0a, 0x10, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x41, 0x75, 0x74, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12,
+	0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e,
+	0x41, 0x75, 0x74, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x22, 0x00, 0x12, 0x47, 0x0a, 0x10, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4d, 0x54,
+	0x4c, 0x53, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64,
+	0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x4d, 0x54, 0x4c, 0x53, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x5a, 0x0a,
+	0x05, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x12, 0x1b, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e,
+	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x16, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0b, 0x22, 0x06, 0x2f, 0x6c, 0x6f, 0x67, 0x69,
+	0x6e, 0x3a, 0x01, 0x2a, 0x92, 0x41, 0x02, 0x62, 0x00, 0x12, 0x4c, 0x0a, 0x06, 0x4c, 0x6f, 0x67,
+	0x6f, 0x75, 0x74, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x22, 0x12, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0c, 0x22, 0x07, 0x2f, 0x6c, 0x6f,
+	0x67, 0x6f, 0x75, 0x74, 0x3a, 0x01, 0x2a, 0x12, 0x4f, 0x0a, 0x03, 0x53, 0x65, 0x74, 0x12, 0x19,
+	0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53,
+	0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75,
+	0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54, 0x78, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x22, 0x12, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0c, 0x22, 0x07, 0x2f, 0x64,
+	0x62, 0x2f, 0x73, 0x65, 0x74, 0x3a, 0x01, 0x2a, 0x12, 0x70, 0x0a, 0x0d, 0x56, 0x65, 0x72, 0x69,
+	0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x12, 0x23, 0x2e, 0x69, 0x6d, 0x6d, 0x75,
+	0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69,
+	0x61, 0x62, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b,
+	0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x56,
+	0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x78, 0x22, 0x1d, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x17, 0x22, 0x12, 0x2f, 0x64, 0x62, 0x2f, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61,
+	0x62, 0x6c, 0x65, 0x2f, 0x73, 0x65, 0x74, 0x3a, 0x01, 0x2a, 0x12, 0x4d, 0x0a, 0x03, 0x47, 0x65,
+	0x74, 0x12, 0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x2e, 0x4b, 0x65, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x69,
+	0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x22, 0x15, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0f, 0x12, 0x0d, 0x2f, 0x64, 0x62, 0x2f,
+	0x67, 0x65, 0x74, 0x2f, 0x7b, 0x6b, 0x65, 0x79, 0x7d, 0x12, 0x73, 0x0a, 0x0d, 0x56, 0x65, 0x72,
+	0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x47, 0x65, 0x74, 0x12, 0x23, 0x2e, 0x69, 0x6d, 0x6d,
+	0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66,
+	0x69, 0x61, 0x62, 0x6c, 0x65, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1e, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e,
+	0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x22,
+	0x1d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x17, 0x22, 0x12, 0x2f, 0x64, 0x62, 0x2f, 0x76, 0x65, 0x72,
+	0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x2f, 0x67, 0x65, 0x74, 0x3a, 0x01, 0x2a, 0x12, 0x91,
+	0x01, 0x0a, 0x17, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x48, 0x69, 0x73,
+	0x74, 0x6f, 0x72, 0x69, 0x63, 0x61, 0x6c, 0x47, 0x65, 0x74, 0x12, 0x2d, 0x2e, 0x69, 0x6d, 0x6d,
+	0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66,
+	0x69, 0x61, 0x62, 0x6c, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x69, 0x63, 0x61, 0x6c, 0x47,
+	0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x69, 0x6d, 0x6d, 0x75,
+	0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69,
+	0x61, 0x62, 0x6c, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x22, 0x27, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x21, 0x22, 0x1c, 0x2f, 0x64, 0x62, 0x2f, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c,
+	0x65, 0x2f, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x69, 0x63, 0x61, 0x6c, 0x67, 0x65, 0x74, 0x3a,
+	0x01, 0x2a, 0x12, 0x56, 0x0a, 0x06, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x12, 0x1d, 0x2e, 0x69,
+	0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x4b, 0x65, 0x79,
+	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x69, 0x6d,
+	0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x45, 0x6e, 0x74, 0x72,
+	0x69, 0x65, 0x73, 0x22, 0x15, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0f, 0x22, 0x0a, 0x2f, 0x64, 0x62,
+	0x2f, 0x67, 0x65, 0x74, 0x61, 0x6c, 0x6c, 0x3a, 0x01, 0x2a, 0x12, 0x5b, 0x0a, 0x07, 0x45, 0x78,
+	0x65, 0x63, 0x41, 0x6c, 0x6c, 0x12, 0x1d, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54, 0x78, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22,
+	0x16, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x10, 0x22, 0x0b, 0x2f, 0x64, 0x62, 0x2f, 0x65, 0x78, 0x65,
+	0x63, 0x61, 0x6c, 0x6c, 0x3a, 0x01, 0x2a, 0x12, 0x7c, 0x0a, 0x0e, 0x45, 0x78, 0x65, 0x63, 0x4d,
+	0x75, 0x6c, 0x74, 0x69, 0x44, 0x62, 0x41, 0x6c, 0x6c, 0x12, 0x24, 0x2e, 0x69, 0x6d, 0x6d, 0x75,
+	0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x4d, 0x75,
+	0x6c, 0x74, 0x69, 0x44, 0x62, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x25, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e,
+	0x45, 0x78, 0x65, 0x63, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x44, 0x62, 0x41, 0x6c, 0x6c, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x17, 0x22, 0x12,
+	0x2f, 0x64, 0x62, 0x2f, 0x65, 0x78, 0x65, 0x63, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x64, 0x62, 0x61,
+	0x6c, 0x6c, 0x3a, 0x01, 0x2a, 0x12, 0x4f, 0x0a, 0x04, 0x53, 0x63, 0x61, 0x6e, 0x12, 0x1a, 0x2e,
+	0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53, 0x63,
+	0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x69, 0x6d, 0x6d, 0x75,
+	0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65,
+	0x73, 0x22, 0x13, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0d, 0x22, 0x08, 0x2f, 0x64, 0x62, 0x2f, 0x73,
+	0x63, 0x61, 0x6e, 0x3a, 0x01, 0x2a, 0x12, 0x5e, 0x0a, 0x09, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x53,
+	0x63, 0x61, 0x6e, 0x12, 0x1f, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x53, 0x63, 0x61, 0x6e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x2e, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0x18, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x12, 0x22, 0x0d, 0x2f, 0x64, 0x62, 0x2f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x73,
+	0x63, 0x61, 0x6e, 0x3a, 0x01, 0x2a, 0x12, 0x58, 0x0a, 0x05, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x18, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e,
+	0x4b, 0x65, 0x79, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x1a, 0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75,
+	0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x22, 0x1a, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x14, 0x12, 0x12, 0x2f, 0x64,
+	0x62, 0x2f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2f, 0x7b, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x7d,
+	0x12, 0x53, 0x0a, 0x08, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x41, 0x6c, 0x6c, 0x12, 0x16, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x1a, 0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x2e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22,
+	0x14, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0e, 0x12, 0x0c, 0x2f, 0x64, 0x62, 0x2f, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x61, 0x6c, 0x6c, 0x12, 0x4a, 0x0a, 0x06, 0x54, 0x78, 0x42, 0x79, 0x49, 0x64, 0x12,
+	0x18, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e,
+	0x54, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x69, 0x6d, 0x6d, 0x75,
+	0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54, 0x78, 0x22, 0x13, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x0d, 0x12, 0x0b, 0x2f, 0x64, 0x62, 0x2f, 0x74, 0x78, 0x2f, 0x7b, 0x74, 0x78,
+	0x7d, 0x12, 0x73, 0x0a, 0x10, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x54,
+	0x78, 0x42, 0x79, 0x49, 0x64, 0x12, 0x22, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65,
+	0x54, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x69, 0x6d, 0x6d, 0x75,
+	0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69,
+	0x61, 0x62, 0x6c, 0x65, 0x54, 0x78, 0x22, 0x1e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x18, 0x12, 0x16,
+	0x2f, 0x64, 0x62, 0x2f, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x2f, 0x74,
+	0x78, 0x2f, 0x7b, 0x74, 0x78, 0x7d, 0x12, 0x50, 0x0a, 0x06, 0x54, 0x78, 0x53, 0x63, 0x61, 0x6e,
+	0x12, 0x1c, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x2e, 0x54, 0x78, 0x53, 0x63, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15,
+	0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54,
+	0x78, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x11, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0b, 0x22, 0x06, 0x2f,
+	0x64, 0x62, 0x2f, 0x74, 0x78, 0x3a, 0x01, 0x2a, 0x12, 0x43, 0x0a, 0x09, 0x53, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x62, 0x65, 0x12, 0x1f, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e,
+	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54, 0x78, 0x22, 0x00, 0x30, 0x01, 0x12, 0x58, 0x0a,
+	0x07, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x1d, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64,
+	0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62,
+	0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22,
+	0x16, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x10, 0x22, 0x0b, 0x2f, 0x64, 0x62, 0x2f, 0x68, 0x69, 0x73,
+	0x74, 0x6f, 0x72, 0x79, 0x3a, 0x01, 0x2a, 0x12, 0x55, 0x0a, 0x06, 0x48, 0x65, 0x61, 0x6c, 0x74,
+	0x68, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1d, 0x2e, 0x69, 0x6d, 0x6d, 0x75,
+	0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x14, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x09,
+	0x12, 0x07, 0x2f, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x92, 0x41, 0x02, 0x62, 0x00, 0x12, 0x6b,
+	0x0a, 0x0a, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x20, 0x2e, 0x69,
+	0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21,
+	0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x18, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0d, 0x12, 0x0b, 0x2f, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x69, 0x6e, 0x66, 0x6f, 0x92, 0x41, 0x02, 0x62, 0x00, 0x12, 0x5d, 0x0a, 0x0c, 0x43,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x16, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x1a, 0x1d, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x2e, 0x49, 0x6d, 0x6d, 0x75, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x22, 0x16, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0b, 0x12, 0x09, 0x2f, 0x64, 0x62, 0x2f,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x92, 0x41, 0x02, 0x62, 0x00, 0x12, 0x67, 0x0a, 0x0c, 0x53, 0x65,
+	0x74, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x1f, 0x2e, 0x69, 0x6d, 0x6d,
+	0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x52, 0x65, 0x66, 0x65, 0x72,
+	0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x69, 0x6d,
+	0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54, 0x78, 0x4d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x1b, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x15, 0x22, 0x10,
+	0x2f, 0x64, 0x62, 0x2f, 0x73, 0x65, 0x74, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65,
+	0x3a, 0x01, 0x2a, 0x12, 0x88, 0x01, 0x0a, 0x16, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62,
+	0x6c, 0x65, 0x53, 0x65, 0x74, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x29,
+	0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x56,
+	0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e,
+	0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x69, 0x6d, 0x6d, 0x75,
+	0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69,
+	0x61, 0x62, 0x6c, 0x65, 0x54, 0x78, 0x22, 0x26, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x20, 0x22, 0x1b,
+	0x2f, 0x64, 0x62, 0x2f, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x2f, 0x73,
+	0x65, 0x74, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x3a, 0x01, 0x2a, 0x12, 0x52,
+	0x0a, 0x04, 0x5a, 0x41, 0x64, 0x64, 0x12, 0x1a, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e,
+	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x5a, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x2e, 0x54, 0x78, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x13, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x0d, 0x22, 0x08, 0x2f, 0x64, 0x62, 0x2f, 0x7a, 0x61, 0x64, 0x64, 0x3a,
+	0x01, 0x2a, 0x12, 0x73, 0x0a, 0x0e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65,
+	0x5a, 0x41, 0x64, 0x64, 0x12, 0x24, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x5a,
+	0x41, 0x64, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x69, 0x6d, 0x6d,
+	0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66,
+	0x69, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x78, 0x22, 0x1e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x18, 0x22,
+	0x13, 0x2f, 0x64, 0x62, 0x2f, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x2f,
+	0x7a, 0x61, 0x64, 0x64, 0x3a, 0x01, 0x2a, 0x12, 0x53, 0x0a, 0x05, 0x5a, 0x53, 0x63, 0x61, 0x6e,
+	0x12, 0x1b, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x2e, 0x5a, 0x53, 0x63, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e,
+	0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x5a, 0x45,
+	0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0x14, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0e, 0x22, 0x09,
+	0x2f, 0x64, 0x62, 0x2f, 0x7a, 0x73, 0x63, 0x61, 0x6e, 0x3a, 0x01, 0x2a, 0x12, 0x5f, 0x0a, 0x08,
+	0x54, 0x53, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x12, 0x1e, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64,
+	0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54, 0x53, 0x41, 0x70, 0x70, 0x65, 0x6e,
+	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64,
+	0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54, 0x78, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x22, 0x18, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x12, 0x22, 0x0d, 0x2f, 0x64, 0x62,
+	0x2f, 0x74, 0x73, 0x2f, 0x61, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x3a, 0x01, 0x2a, 0x12, 0x5b, 0x0a,
+	0x07, 0x54, 0x53, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x1d, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64,
+	0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54, 0x53, 0x52, 0x61, 0x6e, 0x67, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62,
+	0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54, 0x53, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x73, 0x22, 0x17, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x11, 0x22, 0x0c, 0x2f, 0x64, 0x62, 0x2f, 0x74,
+	0x73, 0x2f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x3a, 0x01, 0x2a, 0x12, 0x68, 0x0a, 0x08, 0x4e, 0x6f,
+	0x74, 0x61, 0x72, 0x69, 0x7a, 0x65, 0x12, 0x1e, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e,
+	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x4e, 0x6f, 0x74, 0x61, 0x72, 0x69, 0x7a, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e,
+	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x4e, 0x6f, 0x74, 0x61, 0x72, 0x69, 0x7a, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x73, 0x22, 0x17, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x11, 0x22, 0x0c, 0x2f, 0x64, 0x62, 0x2f, 0x6e, 0x6f, 0x74, 0x61, 0x72, 0x69, 0x7a,
+	0x65, 0x3a, 0x01, 0x2a, 0x12, 0x8c, 0x01, 0x0a, 0x19, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x4e,
+	0x6f, 0x74, 0x61, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x63, 0x65, 0x69,
+	0x70, 0x74, 0x12, 0x22, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x2e, 0x4e, 0x6f, 0x74, 0x61, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x1a, 0x27, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e,
+	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x4e, 0x6f, 0x74, 0x61, 0x72, 0x69, 0x7a, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22,
+	0x22, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1c, 0x22, 0x17, 0x2f, 0x64, 0x62, 0x2f, 0x6e, 0x6f, 0x74,
+	0x61, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x76, 0x65, 0x72, 0x69, 0x66, 0x79,
+	0x3a, 0x01, 0x2a, 0x12, 0x6b, 0x0a, 0x10, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62,
+	0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x1a, 0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x2e, 0x54, 0x78, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x21, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x1b, 0x22, 0x16, 0x2f, 0x64, 0x62, 0x2f, 0x63, 0x6f, 0x6c, 0x6c, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x3a, 0x01, 0x2a,
+	0x12, 0x66, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1d, 0x2e, 0x69, 0x6d,
+	0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x43, 0x6f, 0x6c, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x1c, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x16, 0x12, 0x14, 0x2f, 0x64, 0x62, 0x2f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x2f, 0x6c, 0x69, 0x73, 0x74, 0x12, 0x7f, 0x0a, 0x0f, 0x49, 0x6e, 0x73, 0x65,
+	0x72, 0x74, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x24, 0x2e, 0x69, 0x6d,
+	0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x44, 0x6f, 0x63, 0x75,
+	0x6d, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x25, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x73, 0x65, 0x72, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1f, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x19,
+	0x22, 0x14, 0x2f, 0x64, 0x62, 0x2f, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2f,
+	0x69, 0x6e, 0x73, 0x65, 0x72, 0x74, 0x3a, 0x01, 0x2a, 0x12, 0x67, 0x0a, 0x0b, 0x47, 0x65, 0x74,
+	0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x21, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64,
+	0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e,
+	0x74, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x69, 0x6d,
+	0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x44, 0x6f, 0x63, 0x75,
+	0x6d, 0x65, 0x6e, 0x74, 0x22, 0x1c, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x16, 0x22, 0x11, 0x2f, 0x64,
+	0x62, 0x2f, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x67, 0x65, 0x74, 0x3a,
+	0x01, 0x2a, 0x12, 0x75, 0x0a, 0x0f, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x44, 0x6f, 0x63, 0x75,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x24, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x69, 0x6d,
+	0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x44, 0x6f, 0x63, 0x75,
+	0x6d, 0x65, 0x6e, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x1f, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x19,
+	0x22, 0x14, 0x2f, 0x64, 0x62, 0x2f, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x2f,
+	0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x3a, 0x01, 0x2a, 0x12, 0x58, 0x0a, 0x0e, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12, 0x17, 0x2e, 0x69, 0x6d,
+	0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x44, 0x61, 0x74, 0x61,
+	0x62, 0x61, 0x73, 0x65, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x15, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x0f, 0x22, 0x0a, 0x2f, 0x64, 0x62, 0x2f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x3a, 0x01, 0x2a, 0x12, 0x68, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x44, 0x61, 0x74,
+	0x61, 0x62, 0x61, 0x73, 0x65, 0x57, 0x69, 0x74, 0x68, 0x12, 0x1f, 0x2e, 0x69, 0x6d, 0x6d, 0x75,
+	0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61,
+	0x73, 0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x22, 0x19, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x13, 0x22, 0x0e, 0x2f, 0x64, 0x62, 0x2f,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x77, 0x69, 0x74, 0x68, 0x3a, 0x01, 0x2a, 0x12, 0x60, 0x0a,
+	0x0e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12,
+	0x1f, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e,
+	0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73,
+	0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x15, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0f,
+	0x22, 0x0a, 0x2f, 0x64, 0x62, 0x2f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x3a, 0x01, 0x2a, 0x12,
+	0x67, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x53, 0x65,
+	0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1f,
+	0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x44,
+	0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x22,
+	0x17, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x11, 0x22, 0x0c, 0x2f, 0x64, 0x62, 0x2f, 0x73, 0x65, 0x74,
+	0x74, 0x69, 0x6e, 0x67, 0x73, 0x3a, 0x01, 0x2a, 0x12, 0x60, 0x0a, 0x0c, 0x44, 0x61, 0x74, 0x61,
+	0x62, 0x61, 0x73, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x1a, 0x23, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x13, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0d, 0x22, 0x08, 0x2f,
+	0x64, 0x62, 0x2f, 0x6c, 0x69, 0x73, 0x74, 0x3a, 0x01, 0x2a, 0x12, 0x58, 0x0a, 0x0e, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12, 0x17, 0x2e, 0x69,
+	0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x44, 0x61, 0x74,
+	0x61, 0x62, 0x61, 0x73, 0x65, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x15, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x0f, 0x22, 0x0a, 0x2f, 0x64, 0x62, 0x2f, 0x64, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x3a, 0x01, 0x2a, 0x12, 0x5c, 0x0a, 0x10, 0x55, 0x6e, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12, 0x17, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64,
+	0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73,
+	0x65, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x17, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x11, 0x22, 0x0c, 0x2f, 0x64, 0x62, 0x2f, 0x75, 0x6e, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x3a,
+	0x01, 0x2a, 0x12, 0x76, 0x0a, 0x13, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x44, 0x61, 0x74,
+	0x61, 0x62, 0x61, 0x73, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x1a, 0x2a, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73,
+	0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1b, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x15, 0x22, 0x10, 0x2f, 0x64, 0x62, 0x2f, 0x64, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x64, 0x2f, 0x6c, 0x69, 0x73, 0x74, 0x3a, 0x01, 0x2a, 0x12, 0x58, 0x0a, 0x0e, 0x53, 0x68,
+	0x72, 0x69, 0x6e, 0x6b, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12, 0x17, 0x2e, 0x69,
+	0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x44, 0x61, 0x74,
+	0x61, 0x62, 0x61, 0x73, 0x65, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x15, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x0f, 0x22, 0x0a, 0x2f, 0x64, 0x62, 0x2f, 0x73, 0x68, 0x72, 0x69, 0x6e,
+	0x6b, 0x3a, 0x01, 0x2a, 0x12, 0x81, 0x01, 0x0a, 0x14, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x45,
+	0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x16, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x2b, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x52, 0x6f, 0x74, 0x61, 0x74, 0x65, 0x45, 0x6e, 0x63, 0x72,
+	0x79, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x24, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1e, 0x22, 0x19, 0x2f, 0x64, 0x62, 0x2f,
+	0x65, 0x6e, 0x63, 0x72, 0x79, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x6b, 0x65, 0x79, 0x73, 0x2f, 0x72,
+	0x6f, 0x74, 0x61, 0x74, 0x65, 0x3a, 0x01, 0x2a, 0x12, 0x66, 0x0a, 0x0b, 0x4f, 0x70, 0x65, 0x6e,
+	0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x17, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62,
+	0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65,
+	0x1a, 0x22, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1a, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x14, 0x22, 0x0f, 0x2f, 0x64,
+	0x62, 0x2f, 0x6f, 0x70, 0x65, 0x6e, 0x65, 0x64, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x3a, 0x01, 0x2a,
+	0x12, 0x76, 0x0a, 0x10, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x46,
+	0x69, 0x6c, 0x65, 0x73, 0x12, 0x17, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x1a, 0x27, 0x2e,
+	0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x43, 0x6c,
+	0x6f, 0x73, 0x65, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x20, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1a, 0x22, 0x15,
+	0x2f, 0x64, 0x62, 0x2f, 0x6f, 0x70, 0x65, 0x6e, 0x65, 0x64, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x2f,
+	0x63, 0x6c, 0x6f, 0x73, 0x65, 0x3a, 0x01, 0x2a, 0x12, 0x9f, 0x01, 0x0a, 0x19, 0x43, 0x61, 0x6e,
+	0x63, 0x65, 0x6c, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x12, 0x2f, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e,
+	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x41, 0x70, 0x70,
+	0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x30, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62,
+	0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x41, 0x70,
+	0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1f, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x19, 0x22, 0x14, 0x2f, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x2f, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x3a, 0x01, 0x2a, 0x12, 0x67, 0x0a, 0x0b, 0x55, 0x73,
+	0x65, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12, 0x17, 0x2e, 0x69, 0x6d, 0x6d, 0x75,
+	0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61,
+	0x73, 0x65, 0x1a, 0x1f, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65,
+	0x6d, 0x61, 0x2e, 0x55, 0x73, 0x65, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x22, 0x1e, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x18, 0x12, 0x16, 0x2f, 0x64, 0x62,
+	0x2f, 0x75, 0x73, 0x65, 0x2f, 0x7b, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x4e, 0x61,
+	0x6d, 0x65, 0x7d, 0x12, 0x54, 0x0a, 0x0a, 0x43, 0x6c, 0x65, 0x61, 0x6e, 0x49, 0x6e, 0x64, 0x65,
+	0x78, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x22, 0x16, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x10, 0x12, 0x0e, 0x2f, 0x64, 0x62, 0x2f, 0x63,
+	0x6c, 0x65, 0x61, 0x6e, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x75, 0x0a, 0x10, 0x43, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x26, 0x2e,
+	0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x21, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x1b, 0x22, 0x16, 0x2f, 0x75, 0x73, 0x65, 0x72, 0x2f, 0x63, 0x68, 0x61,
+	0x6e, 0x67, 0x65, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x3a, 0x01, 0x2a,
+	0x12, 0x6c, 0x0a, 0x0d, 0x53, 0x65, 0x74, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x55, 0x73, 0x65,
+	0x72, 0x12, 0x23, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x2e, 0x53, 0x65, 0x74, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x55, 0x73, 0x65, 0x72, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x1e,
+	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x18, 0x22, 0x13, 0x2f, 0x75, 0x73, 0x65, 0x72, 0x2f, 0x73, 0x65,
+	0x74, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x55, 0x73, 0x65, 0x72, 0x3a, 0x01, 0x2a, 0x12, 0x40,
+	0x0a, 0x09, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x47, 0x65, 0x74, 0x12, 0x19, 0x2e, 0x69, 0x6d,
+	0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x4b, 0x65, 0x79, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e,
+	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x22, 0x00, 0x30, 0x01,
+	0x12, 0x40, 0x0a, 0x09, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x53, 0x65, 0x74, 0x12, 0x14, 0x2e,
+	0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x43, 0x68,
+	0x75, 0x6e, 0x6b, 0x1a, 0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x2e, 0x54, 0x78, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x00,
+	0x28, 0x01, 0x12, 0x54, 0x0a, 0x13, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x56, 0x65, 0x72, 0x69,
+	0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x47, 0x65, 0x74, 0x12, 0x23, 0x2e, 0x69, 0x6d, 0x6d, 0x75,
+	0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69,
+	0x61, 0x62, 0x6c, 0x65, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14,
+	0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x43,
+	0x68, 0x75, 0x6e, 0x6b, 0x22, 0x00, 0x30, 0x01, 0x12, 0x4c, 0x0a, 0x13, 0x73, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x12,
+	0x14, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e,
+	0x43, 0x68, 0x75, 0x6e, 0x6b, 0x1a, 0x1b, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65,
+	0x54, 0x78, 0x22, 0x00, 0x28, 0x01, 0x12, 0x42, 0x0a, 0x0a, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x53, 0x63, 0x61, 0x6e, 0x12, 0x1a, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63,
+	0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53, 0x63, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x14, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x2e, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x22, 0x00, 0x30, 0x01, 0x12, 0x44, 0x0a, 0x0b, 0x73, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x5a, 0x53, 0x63, 0x61, 0x6e, 0x12, 0x1b, 0x2e, 0x69, 0x6d, 0x6d, 0x75,
+	0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x5a, 0x53, 0x63, 0x61, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e,
+	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x22, 0x00, 0x30, 0x01,
+	0x12, 0x48, 0x0a, 0x0d, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72,
+	0x79, 0x12, 0x1d, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x2e, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x14, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x2e, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x22, 0x00, 0x30, 0x01, 0x12, 0x44, 0x0a, 0x0d, 0x73, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x45, 0x78, 0x65, 0x63, 0x41, 0x6c, 0x6c, 0x12, 0x14, 0x2e, 0x69, 0x6d,
+	0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x43, 0x68, 0x75, 0x6e,
+	0x6b, 0x1a, 0x19, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x2e, 0x54, 0x78, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x22, 0x00, 0x28, 0x01,
+	0x12, 0x3e, 0x0a, 0x08, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x54, 0x78, 0x12, 0x18, 0x2e, 0x69,
+	0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54, 0x78, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e,
+	0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x22, 0x00, 0x30, 0x01,
+	0x12, 0x48, 0x0a, 0x0d, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x54, 0x78, 0x52, 0x61, 0x6e, 0x67,
+	0x65, 0x12, 0x1d, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x2e, 0x54, 0x78, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x14, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x2e, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x22, 0x00, 0x30, 0x01, 0x12, 0x40, 0x0a, 0x0c, 0x61, 0x70,
+	0x70, 0x6c, 0x79, 0x54, 0x78, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x14, 0x2e, 0x69, 0x6d, 0x6d,
+	0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x43, 0x68, 0x75, 0x6e, 0x6b,
+	0x1a, 0x16, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61,
+	0x2e, 0x54, 0x78, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x22, 0x00, 0x28, 0x01, 0x12, 0x61, 0x0a, 0x0b,
+	0x55, 0x73, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x21, 0x2e, 0x69, 0x6d,
+	0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x55, 0x73, 0x65, 0x53,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x17, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x11, 0x12, 0x0f,
+	0x2f, 0x64, 0x62, 0x2f, 0x75, 0x73, 0x65, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12,
+	0x5e, 0x0a, 0x07, 0x53, 0x51, 0x4c, 0x45, 0x78, 0x65, 0x63, 0x12, 0x1d, 0x2e, 0x69, 0x6d, 0x6d,
+	0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53, 0x51, 0x4c, 0x45, 0x78,
+	0x65, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x69, 0x6d, 0x6d, 0x75,
+	0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53, 0x51, 0x4c, 0x45, 0x78, 0x65,
+	0x63, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x16, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x10, 0x22,
+	0x0b, 0x2f, 0x64, 0x62, 0x2f, 0x73, 0x71, 0x6c, 0x65, 0x78, 0x65, 0x63, 0x3a, 0x01, 0x2a, 0x12,
+	0x62, 0x0a, 0x08, 0x53, 0x51, 0x4c, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x1e, 0x2e, 0x69, 0x6d,
+	0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53, 0x51, 0x4c, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x69, 0x6d,
+	0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53, 0x51, 0x4c, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x17, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x11, 0x22, 0x0c, 0x2f, 0x64, 0x62, 0x2f, 0x73, 0x71, 0x6c, 0x62, 0x61, 0x74, 0x63, 0x68,
+	0x3a, 0x01, 0x2a, 0x12, 0x62, 0x0a, 0x08, 0x53, 0x51, 0x4c, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12,
+	0x1e, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e,
+	0x53, 0x51, 0x4c, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1d, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e,
+	0x53, 0x51, 0x4c, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x17,
+	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x11, 0x22, 0x0c, 0x2f, 0x64, 0x62, 0x2f, 0x73, 0x71, 0x6c, 0x71,
+	0x75, 0x65, 0x72, 0x79, 0x3a, 0x01, 0x2a, 0x12, 0x5b, 0x0a, 0x0a, 0x4c, 0x69, 0x73, 0x74, 0x54,
+	0x61, 0x62, 0x6c, 0x65, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1d, 0x2e,
+	0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53, 0x51,
+	0x4c, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x16, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x10, 0x12, 0x0e, 0x2f, 0x64, 0x62, 0x2f, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x2f,
+	0x6c, 0x69, 0x73, 0x74, 0x12, 0x5b, 0x0a, 0x0d, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65,
+	0x54, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x14, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x1a, 0x1d, 0x2e, 0x69, 0x6d,
+	0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53, 0x51, 0x4c, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x15, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x0f, 0x22, 0x0a, 0x2f, 0x64, 0x62, 0x2f, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x3a, 0x01,
+	0x2a, 0x12, 0x7f, 0x0a, 0x10, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x53,
+	0x51, 0x4c, 0x47, 0x65, 0x74, 0x12, 0x26, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65,
+	0x53, 0x51, 0x4c, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e,
+	0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x56, 0x65,
+	0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x51, 0x4c, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x22, 0x20, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1a, 0x22, 0x15, 0x2f, 0x64, 0x62, 0x2f, 0x76, 0x65,
+	0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x2f, 0x73, 0x71, 0x6c, 0x67, 0x65, 0x74, 0x3a,
+	0x01, 0x2a, 0x12, 0x8b, 0x01, 0x0a, 0x12, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c,
+	0x65, 0x53, 0x51, 0x4c, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x28, 0x2e, 0x69, 0x6d, 0x6d, 0x75,
+	0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69,
+	0x61, 0x62, 0x6c, 0x65, 0x53, 0x51, 0x4c, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x51,
+	0x4c, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x22, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x1c, 0x22, 0x17, 0x2f, 0x64, 0x62, 0x2f, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69,
+	0x61, 0x62, 0x6c, 0x65, 0x2f, 0x73, 0x71, 0x6c, 0x71, 0x75, 0x65, 0x72, 0x79, 0x3a, 0x01, 0x2a,
+	0x12, 0x5a, 0x0a, 0x0f, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x51, 0x4c, 0x53, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x18, 0x2e, 0x69, 0x6d,
+	0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53, 0x51, 0x4c, 0x53,
+	0x63, 0x68, 0x65, 0x6d, 0x61, 0x22, 0x15, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0f, 0x12, 0x0d, 0x2f,
+	0x64, 0x62, 0x2f, 0x73, 0x71, 0x6c, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x12, 0x63, 0x0a, 0x0f,
+	0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x51, 0x4c, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x12,
+	0x18, 0x2e, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e,
+	0x53, 0x51, 0x4c, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x1a, 0x1c, 0x2e, 0x69, 0x6d, 0x6d, 0x75,
+	0x64, 0x62, 0x2e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x2e, 0x53, 0x51, 0x4c, 0x45, 0x78, 0x65,
+	0x63, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x18, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x12, 0x22,
+	0x0d, 0x2f, 0x64, 0x62, 0x2f, 0x73, 0x71, 0x6c, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x3a, 0x01,
+	0x2a, 0x42, 0x8b, 0x03, 0x5a, 0x2b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x63, 0x6f, 0x64, 0x65, 0x6e, 0x6f, 0x74, 0x61, 0x72, 0x79, 0x2f, 0x69, 0x6d, 0x6d, 0x75,
+	0x64, 0x62, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x73, 0x63, 0x68, 0x65, 0x6d,
+	0x61, 0x92, 0x41, 0xda, 0x02, 0x12, 0xee, 0x01, 0x0a, 0x0f, 0x69, 0x6d, 0x6d, 0x75, 0x64, 0x62,
+	0x20, 0x52, 0x45, 0x53, 0x54, 0x20, 0x41, 0x50, 0x49, 0x12, 0xda, 0x01, 0x3c, 0x62, 0x3e, 0x49,
+	0x4d, 0x50, 0x4f, 0x52, 0x54, 0x41, 0x4e, 0x54, 0x3c, 0x2f, 0x62, 0x3e, 0x3a, 0x20, 0x41, 0x6c,
+	0x6c, 0x20, 0x3c, 0x63, 0x6f, 0x64, 0x65, 0x3e, 0x67, 0x65, 0x74, 0x3c, 0x2f, 0x63, 0x6f, 0x64,
+	0x65, 0x3e, 0x20, 0x61, 0x6e, 0x64, 0x20, 0x3c, 0x63, 0x6f, 0x64, 0x65, 0x3e, 0x73, 0x61, 0x66,
+	0x65, 0x67, 0x65, 0x74, 0x3c, 0x2f, 0x63, 0x6f, 0x64, 0x65, 0x3e, 0x20, 0x66, 0x75, 0x6e, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x20, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x20, 0x3c, 0x75, 0x3e,
+	0x62, 0x61, 0x73, 0x65, 0x36, 0x34, 0x2d, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x64, 0x3c, 0x2f,
+	0x75, 0x3e, 0x20, 0x6b, 0x65, 0x79, 0x73, 0x20, 0x61, 0x6e, 0x64, 0x20, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x73, 0x2c, 0x20, 0x77, 0x68, 0x69, 0x6c, 0x65, 0x20, 0x61, 0x6c, 0x6c, 0x20, 0x3c, 0x63,
+	0x6f, 0x64, 0x65, 0x3e, 0x73, 0x65, 0x74, 0x3c, 0x2f, 0x63, 0x6f, 0x64, 0x65, 0x3e, 0x20, 0x61,
+	0x6e, 0x64, 0x20, 0x3c, 0x63, 0x6f, 0x64, 0x65, 0x3e, 0x73, 0x61, 0x66, 0x65, 0x73, 0x65, 0x74,
+	0x3c, 0x2f, 0x63, 0x6f, 0x64, 0x65, 0x3e, 0x20, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x20, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x20, 0x3c, 0x75, 0x3e, 0x62, 0x61, 0x73, 0x65,
+	0x36, 0x34, 0x2d, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x64, 0x3c, 0x2f, 0x75, 0x3e, 0x20, 0x69,
+	0x6e, 0x70, 0x75, 0x74, 0x73, 0x2e, 0x5a, 0x59, 0x0a, 0x57, 0x0a, 0x06, 0x62, 0x65, 0x61, 0x72,
+	0x65, 0x72, 0x12, 0x4d, 0x08, 0x02, 0x12, 0x38, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x20, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x2c, 0x20, 0x70, 0x72,
+	0x65, 0x66, 0x69, 0x78, 0x65, 0x64, 0x20, 0x62, 0x79, 0x20, 0x42, 0x65, 0x61, 0x72, 0x65, 0x72,
+	0x3a, 0x20, 0x42, 0x65, 0x61, 0x72, 0x65, 0x72, 0x20, 0x3c, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x3e,
+	0x1a, 0x0d, 0x41, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x20,
+	0x02, 0x62, 0x0c, 0x0a, 0x0a, 0x0a, 0x06, 0x62, 0x65, 0x61, 0x72, 0x65, 0x72, 0x12, 0x00, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_schema_proto_rawDescOnce sync.Once
+	file_schema_proto_rawDescData = file_schema_proto_rawDesc
+)
+
+func file_schema_proto_rawDescGZIP() []byte {
+	file_schema_proto_rawDescOnce.Do(func() {
+		file_schema_proto_rawDescData = protoimpl.X.CompressGZIP(file_schema_proto_rawDescData)
+	})
+	return file_schema_proto_rawDescData
+}
+
+var file_schema_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_schema_proto_msgTypes = make([]protoimpl.MessageInfo, 134)
+var file_schema_proto_goTypes = []interface{}{
+	(ZAddScoreMode)(0),                                     // 0: immudb.schema.ZAddScoreMode
+	(TSAggregation)(0),                                     // 1: immudb.schema.TSAggregation
+	(PermissionAction)(0),                                  // 2: immudb.schema.PermissionAction
+	(DocumentFilterOperator)(0),                            // 3: immudb.schema.DocumentFilterOperator
+	(IsolationLevel)(0),                                    // 4: immudb.schema.IsolationLevel
+	(*Key)(nil),                                            // 5: immudb.schema.Key
+	(*Permission)(nil),                                     // 6: immudb.schema.Permission
+	(*User)(nil),                                           // 7: immudb.schema.User
+	(*UserList)(nil),                                       // 8: immudb.schema.UserList
+	(*CreateUserRequest)(nil),                              // 9: immudb.schema.CreateUserRequest
+	(*UserRequest)(nil),                                    // 10: immudb.schema.UserRequest
+	(*ChangePasswordRequest)(nil),                          // 11: immudb.schema.ChangePasswordRequest
+	(*ImportUsersResponse)(nil),                            // 12: immudb.schema.ImportUsersResponse
+	(*PasswordResetToken)(nil),                             // 13: immudb.schema.PasswordResetToken
+	(*ResetPasswordRequest)(nil),                           // 14: immudb.schema.ResetPasswordRequest
+	(*LoginRequest)(nil),                                   // 15: immudb.schema.LoginRequest
+	(*LoginResponse)(nil),                                  // 16: immudb.schema.LoginResponse
+	(*AuthConfig)(nil),                                     // 17: immudb.schema.AuthConfig
+	(*MTLSConfig)(nil),                                     // 18: immudb.schema.MTLSConfig
+	(*Expiration)(nil),                                     // 19: immudb.schema.Expiration
+	(*KVMetadata)(nil),                                     // 20: immudb.schema.KVMetadata
+	(*KVIndex)(nil),                                        // 21: immudb.schema.KVIndex
+	(*KeyValue)(nil),                                       // 22: immudb.schema.KeyValue
+	(*Entry)(nil),                                          // 23: immudb.schema.Entry
+	(*Reference)(nil),                                      // 24: immudb.schema.Reference
+	(*Op)(nil),                                             // 25: immudb.schema.Op
+	(*ExecAllRequest)(nil),                                 // 26: immudb.schema.ExecAllRequest
+	(*Entries)(nil),                                        // 27: immudb.schema.Entries
+	(*ZEntry)(nil),                                         // 28: immudb.schema.ZEntry
+	(*ZEntries)(nil),                                       // 29: immudb.schema.ZEntries
+	(*ScanRequest)(nil),                                    // 30: immudb.schema.ScanRequest
+	(*IndexScanRequest)(nil),                               // 31: immudb.schema.IndexScanRequest
+	(*KeyPrefix)(nil),                                      // 32: immudb.schema.KeyPrefix
+	(*EntryCount)(nil),                                     // 33: immudb.schema.EntryCount
+	(*Signature)(nil),                                      // 34: immudb.schema.Signature
+	(*TxMetadata)(nil),                                     // 35: immudb.schema.TxMetadata
+	(*TxAttributes)(nil),                                   // 36: immudb.schema.TxAttributes
+	(*LinearProof)(nil),                                    // 37: immudb.schema.LinearProof
+	(*DualProof)(nil),                                      // 38: immudb.schema.DualProof
+	(*Tx)(nil),                                             // 39: immudb.schema.Tx
+	(*TxEntry)(nil),                                        // 40: immudb.schema.TxEntry
+	(*VerifiableTx)(nil),                                   // 41: immudb.schema.VerifiableTx
+	(*VerifiableEntry)(nil),                                // 42: immudb.schema.VerifiableEntry
+	(*InclusionProof)(nil),                                 // 43: immudb.schema.InclusionProof
+	(*SetRequest)(nil),                                     // 44: immudb.schema.SetRequest
+	(*Precondition)(nil),                                   // 45: immudb.schema.Precondition
+	(*KeyRequest)(nil),                                     // 46: immudb.schema.KeyRequest
+	(*KeyListRequest)(nil),                                 // 47: immudb.schema.KeyListRequest
+	(*VerifiableSetRequest)(nil),                           // 48: immudb.schema.VerifiableSetRequest
+	(*VerifiableGetRequest)(nil),                           // 49: immudb.schema.VerifiableGetRequest
+	(*VerifiableHistoricalGetRequest)(nil),                 // 50: immudb.schema.VerifiableHistoricalGetRequest
+	(*HealthResponse)(nil),                                 // 51: immudb.schema.HealthResponse
+	(*ServerInfoRequest)(nil),                              // 52: immudb.schema.ServerInfoRequest
+	(*ServerInfoResponse)(nil),                             // 53: immudb.schema.ServerInfoResponse
+	(*ServerFeature)(nil),                                  // 54: immudb.schema.ServerFeature
+	(*ImmutableState)(nil),                                 // 55: immudb.schema.ImmutableState
+	(*ReferenceRequest)(nil),                               // 56: immudb.schema.ReferenceRequest
+	(*VerifiableReferenceRequest)(nil),                     // 57: immudb.schema.VerifiableReferenceRequest
+	(*ZAddRequest)(nil),                                    // 58: immudb.schema.ZAddRequest
+	(*Score)(nil),                                          // 59: immudb.schema.Score
+	(*ZScanRequest)(nil),                                   // 60: immudb.schema.ZScanRequest
+	(*TSSample)(nil),                                       // 61: immudb.schema.TSSample
+	(*TSSamples)(nil),                                      // 62: immudb.schema.TSSamples
+	(*TSAppendRequest)(nil),                                // 63: immudb.schema.TSAppendRequest
+	(*TSRangeRequest)(nil),                                 // 64: immudb.schema.TSRangeRequest
+	(*NotarizeRequest)(nil),                                // 65: immudb.schema.NotarizeRequest
+	(*NotarizationReceipt)(nil),                            // 66: immudb.schema.NotarizationReceipt
+	(*NotarizationReceipts)(nil),                           // 67: immudb.schema.NotarizationReceipts
+	(*NotarizationVerification)(nil),                       // 68: immudb.schema.NotarizationVerification
+	(*HistoryRequest)(nil),                                 // 69: immudb.schema.HistoryRequest
+	(*VerifiableZAddRequest)(nil),                          // 70: immudb.schema.VerifiableZAddRequest
+	(*TxRequest)(nil),                                      // 71: immudb.schema.TxRequest
+	(*TxRangeRequest)(nil),                                 // 72: immudb.schema.TxRangeRequest
+	(*TxRange)(nil),                                        // 73: immudb.schema.TxRange
+	(*VerifiableTxRequest)(nil),                            // 74: immudb.schema.VerifiableTxRequest
+	(*TxScanRequest)(nil),                                  // 75: immudb.schema.TxScanRequest
+	(*TxList)(nil),                                         // 76: immudb.schema.TxList
+	(*SubscribeRequest)(nil),                               // 77: immudb.schema.SubscribeRequest
+	(*Database)(nil),                                       // 78: immudb.schema.Database
+	(*DatabaseSettings)(nil),                               // 79: immudb.schema.DatabaseSettings
+	(*Table)(nil),                                          // 80: immudb.schema.Table
+	(*SQLGetRequest)(nil),                                  // 81: immudb.schema.SQLGetRequest
+	(*VerifiableSQLGetRequest)(nil),                        // 82: immudb.schema.VerifiableSQLGetRequest
+	(*SQLEntry)(nil),                                       // 83: immudb.schema.SQLEntry
+	(*VerifiableSQLEntry)(nil),                             // 84: immudb.schema.VerifiableSQLEntry
+	(*VerifiableSQLQueryRequest)(nil),                      // 85: immudb.schema.VerifiableSQLQueryRequest
+	(*VerifiableSQLRow)(nil),                               // 86: immudb.schema.VerifiableSQLRow
+	(*VerifiableSQLQueryResult)(nil),                       // 87: immudb.schema.VerifiableSQLQueryResult
+	(*UseDatabaseReply)(nil),                               // 88: immudb.schema.UseDatabaseReply
+	(*ChangePermissionRequest)(nil),                        // 89: immudb.schema.ChangePermissionRequest
+	(*SetActiveUserRequest)(nil),                           // 90: immudb.schema.SetActiveUserRequest
+	(*DatabaseListResponse)(nil),                           // 91: immudb.schema.DatabaseListResponse
+	(*DeletedDatabase)(nil),                                // 92: immudb.schema.DeletedDatabase
+	(*DatabaseExecAllRequest)(nil),                         // 93: immudb.schema.DatabaseExecAllRequest
+	(*ExecMultiDbAllRequest)(nil),                          // 94: immudb.schema.ExecMultiDbAllRequest
+	(*ExecMultiDbAllResponse)(nil),                         // 95: immudb.schema.ExecMultiDbAllResponse
+	(*Collection)(nil),                                     // 96: immudb.schema.Collection
+	(*CollectionList)(nil),                                 // 97: immudb.schema.CollectionList
+	(*DocumentInsertRequest)(nil),                          // 98: immudb.schema.DocumentInsertRequest
+	(*DocumentInsertResponse)(nil),                         // 99: immudb.schema.DocumentInsertResponse
+	(*DocumentGetRequest)(nil),                             // 100: immudb.schema.DocumentGetRequest
+	(*Document)(nil),                                       // 101: immudb.schema.Document
+	(*DocumentFilter)(nil),                                 // 102: immudb.schema.DocumentFilter
+	(*DocumentSearchRequest)(nil),                          // 103: immudb.schema.DocumentSearchRequest
+	(*DocumentList)(nil),                                   // 104: immudb.schema.DocumentList
+	(*LogOpenedFiles)(nil),                                 // 105: immudb.schema.LogOpenedFiles
+	(*DatabaseOpenedFiles)(nil),                            // 106: immudb.schema.DatabaseOpenedFiles
+	(*OpenedFilesResponse)(nil),                            // 107: immudb.schema.OpenedFilesResponse
+	(*CloseCachedFilesResponse)(nil),                       // 108: immudb.schema.CloseCachedFilesResponse
+	(*DeletedDatabaseListResponse)(nil),                    // 109: immudb.schema.DeletedDatabaseListResponse
+	(*RotateEncryptionKeysResponse)(nil),                   // 110: immudb.schema.RotateEncryptionKeysResponse
+	(*CancelApplicationRequestsRequest)(nil),               // 111: immudb.schema.CancelApplicationRequestsRequest
+	(*CancelApplicationRequestsResponse)(nil),              // 112: immudb.schema.CancelApplicationRequestsResponse
+	(*Chunk)(nil),                                          // 113: immudb.schema.Chunk
+	(*UseSnapshotRequest)(nil),                             // 114: immudb.schema.UseSnapshotRequest
+	(*SQLExecRequest)(nil),                                 // 115: immudb.schema.SQLExecRequest
+	(*SQLBatchRequest)(nil),                                // 116: immudb.schema.SQLBatchRequest
+	(*SQLBatchStmt)(nil),                                   // 117: immudb.schema.SQLBatchStmt
+	(*SQLBatchResult)(nil),                                 // 118: immudb.schema.SQLBatchResult
+	(*SQLBatchStmtResult)(nil),                             // 119: immudb.schema.SQLBatchStmtResult
+	(*SQLQueryRequest)(nil),                                // 120: immudb.schema.SQLQueryRequest
+	(*NamedParam)(nil),                                     // 121: immudb.schema.NamedParam
+	(*SQLExecResult)(nil),                                  // 122: immudb.schema.SQLExecResult
+	(*SQLQueryResult)(nil),                                 // 123: immudb.schema.SQLQueryResult
+	(*SQLSchema)(nil),                                      // 124: immudb.schema.SQLSchema
+	(*Column)(nil),                                         // 125: immudb.schema.Column
+	(*Row)(nil),                                            // 126: immudb.schema.Row
+	(*SQLValue)(nil),                                       // 127: immudb.schema.SQLValue
+	(*SQLValueList)(nil),                                   // 128: immudb.schema.SQLValueList
+	(*Precondition_KeyMustNotExistPrecondition)(nil),       // 129: immudb.schema.Precondition.KeyMustNotExistPrecondition
+	(*Precondition_KeyMustHaveRevisionPrecondition)(nil),   // 130: immudb.schema.Precondition.KeyMustHaveRevisionPrecondition
+	(*Precondition_KeyNotModifiedAfterTXPrecondition)(nil), // 131: immudb.schema.Precondition.KeyNotModifiedAfterTXPrecondition
+	nil,                    // 132: immudb.schema.VerifiableSQLEntry.ColIdsByIdEntry
+	nil,                    // 133: immudb.schema.VerifiableSQLEntry.ColIdsByNameEntry
+	nil,                    // 134: immudb.schema.VerifiableSQLEntry.ColTypesByIdEntry
+	nil,                    // 135: immudb.schema.VerifiableSQLQueryResult.ColIdsByNameEntry
+	nil,                    // 136: immudb.schema.VerifiableSQLQueryResult.ColTypesByIdEntry
+	nil,                    // 137: immudb.schema.SQLBatchStmtResult.LastInsertedPKsEntry
+	nil,                    // 138: immudb.schema.SQLExecResult.LastInsertedPKsEntry
+	(*_struct.Struct)(nil), // 139: google.protobuf.Struct
+	(*_struct.Value)(nil),  // 140: google.protobuf.Value
+	(_struct.NullValue)(0), // 141: google.protobuf.NullValue
+	(*empty.Empty)(nil),    // 142: google.protobuf.Empty
+}
+var file_schema_proto_depIdxs = []int32{
+	6,   // 0: immudb.schema.User.permissions:type_name -> immudb.schema.Permission
+	7,   // 1: immudb.schema.UserList.users:type_name -> immudb.schema.User
+	13,  // 2: immudb.schema.ImportUsersResponse.tokens:type_name -> immudb.schema.PasswordResetToken
+	19,  // 3: immudb.schema.KVMetadata.expiration:type_name -> immudb.schema.Expiration
+	21,  // 4: immudb.schema.KVMetadata.indexes:type_name -> immudb.schema.KVIndex
+	20,  // 5: immudb.schema.KeyValue.metadata:type_name -> immudb.schema.KVMetadata
+	24,  // 6: immudb.schema.Entry.referencedBy:type_name -> immudb.schema.Reference
+	20,  // 7: immudb.schema.Entry.metadata:type_name -> immudb.schema.KVMetadata
+	22,  // 8: immudb.schema.Op.kv:type_name -> immudb.schema.KeyValue
+	58,  // 9: immudb.schema.Op.zAdd:type_name -> immudb.schema.ZAddRequest
+	56,  // 10: immudb.schema.Op.ref:type_name -> immudb.schema.ReferenceRequest
+	25,  // 11: immudb.schema.ExecAllRequest.Operations:type_name -> immudb.schema.Op
+	23,  // 12: immudb.schema.Entries.entries:type_name -> immudb.schema.Entry
+	23,  // 13: immudb.schema.ZEntry.entry:type_name -> immudb.schema.Entry
+	28,  // 14: immudb.schema.ZEntries.entries:type_name -> immudb.schema.ZEntry
+	36,  // 15: immudb.schema.TxMetadata.attributes:type_name -> immudb.schema.TxAttributes
+	35,  // 16: immudb.schema.DualProof.sourceTxMetadata:type_name -> immudb.schema.TxMetadata
+	35,  // 17: immudb.schema.DualProof.targetTxMetadata:type_name -> immudb.schema.TxMetadata
+	37,  // 18: immudb.schema.DualProof.linearProof:type_name -> immudb.schema.LinearProof
+	35,  // 19: immudb.schema.Tx.metadata:type_name -> immudb.schema.TxMetadata
+	40,  // 20: immudb.schema.Tx.entries:type_name -> immudb.schema.TxEntry
+	20,  // 21: immudb.schema.TxEntry.metadata:type_name -> immudb.schema.KVMetadata
+	39,  // 22: immudb.schema.VerifiableTx.tx:type_name -> immudb.schema.Tx
+	38,  // 23: immudb.schema.VerifiableTx.dualProof:type_name -> immudb.schema.DualProof
+	34,  // 24: immudb.schema.VerifiableTx.signature:type_name -> immudb.schema.Signature
+	23,  // 25: immudb.schema.VerifiableEntry.entry:type_name -> immudb.schema.Entry
+	41,  // 26: immudb.schema.VerifiableEntry.verifiableTx:type_name -> immudb.schema.VerifiableTx
+	43,  // 27: immudb.schema.VerifiableEntry.inclusionProof:type_name -> immudb.schema.InclusionProof
+	22,  // 28: immudb.schema.SetRequest.KVs:type_name -> immudb.schema.KeyValue
+	45,  // 29: immudb.schema.SetRequest.preconditions:type_name -> immudb.schema.Precondition
+	129, // 30: immudb.schema.Precondition.keyMustNotExist:type_name -> immudb.schema.Precondition.KeyMustNotExistPrecondition
+	130, // 31: immudb.schema.Precondition.keyMustHaveRevision:type_name -> immudb.schema.Precondition.KeyMustHaveRevisionPrecondition
+	131, // 32: immudb.schema.Precondition.keyNotModifiedAfterTX:type_name -> immudb.schema.Precondition.KeyNotModifiedAfterTXPrecondition
+	44,  // 33: immudb.schema.VerifiableSetRequest.setRequest:type_name -> immudb.schema.SetRequest
+	46,  // 34: immudb.schema.VerifiableGetRequest.keyRequest:type_name -> immudb.schema.KeyRequest
+	54,  // 35: immudb.schema.ServerInfoResponse.features:type_name -> immudb.schema.ServerFeature
+	34,  // 36: immudb.schema.ImmutableState.signature:type_name -> immudb.schema.Signature
+	56,  // 37: immudb.schema.VerifiableReferenceRequest.referenceRequest:type_name -> immudb.schema.ReferenceRequest
+	0,   // 38: immudb.schema.ZAddRequest.scoreMode:type_name -> immudb.schema.ZAddScoreMode
+	59,  // 39: immudb.schema.ZScanRequest.minScore:type_name -> immudb.schema.Score
+	59,  // 40: immudb.schema.ZScanRequest.maxScore:type_name -> immudb.schema.Score
+	61,  // 41: immudb.schema.TSSamples.samples:type_name -> immudb.schema.TSSample
+	61,  // 42: immudb.schema.TSAppendRequest.samples:type_name -> immudb.schema.TSSample
+	1,   // 43: immudb.schema.TSRangeRequest.aggregation:type_name -> immudb.schema.TSAggregation
+	35,  // 44: immudb.schema.NotarizationReceipt.txMetadata:type_name -> immudb.schema.TxMetadata
+	43,  // 45: immudb.schema.NotarizationReceipt.inclusionProof:type_name -> immudb.schema.InclusionProof
+	55,  // 46: immudb.schema.NotarizationReceipt.state:type_name -> immudb.schema.ImmutableState
+	66,  // 47: immudb.schema.NotarizationReceipts.receipts:type_name -> immudb.schema.NotarizationReceipt
+	58,  // 48: immudb.schema.VerifiableZAddRequest.zAddRequest:type_name -> immudb.schema.ZAddRequest
+	39,  // 49: immudb.schema.TxList.txs:type_name -> immudb.schema.Tx
+	127, // 50: immudb.schema.SQLGetRequest.pkValue:type_name -> immudb.schema.SQLValue
+	81,  // 51: immudb.schema.VerifiableSQLGetRequest.sqlGetRequest:type_name -> immudb.schema.SQLGetRequest
+	83,  // 52: immudb.schema.VerifiableSQLEntry.sqlEntry:type_name -> immudb.schema.SQLEntry
+	41,  // 53: immudb.schema.VerifiableSQLEntry.verifiableTx:type_name -> immudb.schema.VerifiableTx
+	43,  // 54: immudb.schema.VerifiableSQLEntry.inclusionProof:type_name -> immudb.schema.InclusionProof
+	132, // 55: immudb.schema.VerifiableSQLEntry.ColIdsById:type_name -> immudb.schema.VerifiableSQLEntry.ColIdsByIdEntry
+	133, // 56: immudb.schema.VerifiableSQLEntry.ColIdsByName:type_name -> immudb.schema.VerifiableSQLEntry.ColIdsByNameEntry
+	134, // 57: immudb.schema.VerifiableSQLEntry.ColTypesById:type_name -> immudb.schema.VerifiableSQLEntry.ColTypesByIdEntry
+	120, // 58: immudb.schema.VerifiableSQLQueryRequest.sqlQueryRequest:type_name -> immudb.schema.SQLQueryRequest
+	83,  // 59: immudb.schema.VerifiableSQLRow.sqlEntry:type_name -> immudb.schema.SQLEntry
+	43,  // 60: immudb.schema.VerifiableSQLRow.inclusionProof:type_name -> immudb.schema.InclusionProof
+	38,  // 61: immudb.schema.VerifiableSQLRow.dualProof:type_name -> immudb.schema.DualProof
+	123, // 62: immudb.schema.VerifiableSQLQueryResult.result:type_name -> immudb.schema.SQLQueryResult
+	41,  // 63: immudb.schema.VerifiableSQLQueryResult.verifiableTx:type_name -> immudb.schema.VerifiableTx
+	86,  // 64: immudb.schema.VerifiableSQLQueryResult.rows:type_name -> immudb.schema.VerifiableSQLRow
+	135, // 65: immudb.schema.VerifiableSQLQueryResult.ColIdsByName:type_name -> immudb.schema.VerifiableSQLQueryResult.ColIdsByNameEntry
+	136, // 66: immudb.schema.VerifiableSQLQueryResult.ColTypesById:type_name -> immudb.schema.VerifiableSQLQueryResult.ColTypesByIdEntry
+	2,   // 67: immudb.schema.ChangePermissionRequest.action:type_name -> immudb.schema.PermissionAction
+	78,  // 68: immudb.schema.DatabaseListResponse.databases:type_name -> immudb.schema.Database
+	26,  // 69: immudb.schema.DatabaseExecAllRequest.request:type_name -> immudb.schema.ExecAllRequest
+	93,  // 70: immudb.schema.ExecMultiDbAllRequest.requests:type_name -> immudb.schema.DatabaseExecAllRequest
+	35,  // 71: immudb.schema.ExecMultiDbAllResponse.txs:type_name -> immudb.schema.TxMetadata
+	96,  // 72: immudb.schema.CollectionList.collections:type_name -> immudb.schema.Collection
+	139, // 73: immudb.schema.DocumentInsertRequest.documents:type_name -> google.protobuf.Struct
+	35,  // 74: immudb.schema.DocumentInsertResponse.txMetadata:type_name -> immudb.schema.TxMetadata
+	139, // 75: immudb.schema.Document.document:type_name -> google.protobuf.Struct
+	3,   // 76: immudb.schema.DocumentFilter.operator:type_name -> immudb.schema.DocumentFilterOperator
+	140, // 77: immudb.schema.DocumentFilter.value:type_name -> google.protobuf.Value
+	102, // 78: immudb.schema.DocumentSearchRequest.filters:type_name -> immudb.schema.DocumentFilter
+	101, // 79: immudb.schema.DocumentList.documents:type_name -> immudb.schema.Document
+	105, // 80: immudb.schema.DatabaseOpenedFiles.logs:type_name -> immudb.schema.LogOpenedFiles
+	106, // 81: immudb.schema.OpenedFilesResponse.databases:type_name -> immudb.schema.DatabaseOpenedFiles
+	92,  // 82: immudb.schema.DeletedDatabaseListResponse.databases:type_name -> immudb.schema.DeletedDatabase
+	4,   // 83: immudb.schema.UseSnapshotRequest.isolationLevel:type_name -> immudb.schema.IsolationLevel
+	121, // 84: immudb.schema.SQLExecRequest.params:type_name -> immudb.schema.NamedParam
+	117, // 85: immudb.schema.SQLBatchRequest.stmts:type_name -> immudb.schema.SQLBatchStmt
+	121, // 86: immudb.schema.SQLBatchStmt.params:type_name -> immudb.schema.NamedParam
+	35,  // 87: immudb.schema.SQLBatchResult.ctxs:type_name -> immudb.schema.TxMetadata
+	35,  // 88: immudb.schema.SQLBatchResult.dtxs:type_name -> immudb.schema.TxMetadata
+	119, // 89: immudb.schema.SQLBatchResult.results:type_name -> immudb.schema.SQLBatchStmtResult
+	137, // 90: immudb.schema.SQLBatchStmtResult.lastInsertedPKs:type_name -> immudb.schema.SQLBatchStmtResult.LastInsertedPKsEntry
+	121, // 91: immudb.schema.SQLQueryRequest.params:type_name -> immudb.schema.NamedParam
+	127, // 92: immudb.schema.NamedParam.value:type_name -> immudb.schema.SQLValue
+	35,  // 93: immudb.schema.SQLExecResult.ctxs:type_name -> immudb.schema.TxMetadata
+	35,  // 94: immudb.schema.SQLExecResult.dtxs:type_name -> immudb.schema.TxMetadata
+	138, // 95: immudb.schema.SQLExecResult.lastInsertedPKs:type_name -> immudb.schema.SQLExecResult.LastInsertedPKsEntry
+	123, // 96: immudb.schema.SQLExecResult.returnedRows:type_name -> immudb.schema.SQLQueryResult
+	125, // 97: immudb.schema.SQLQueryResult.columns:type_name -> immudb.schema.Column
+	126, // 98: immudb.schema.SQLQueryResult.rows:type_name -> immudb.schema.Row
+	127, // 99: immudb.schema.Row.values:type_name -> immudb.schema.SQLValue
+	141, // 100: immudb.schema.SQLValue.null:type_name -> google.protobuf.NullValue
+	128, // 101: immudb.schema.SQLValue.list:type_name -> immudb.schema.SQLValueList
+	127, // 102: immudb.schema.SQLValueList.values:type_name -> immudb.schema.SQLValue
+	142, // 103: immudb.schema.ImmuService.ListUsers:input_type -> google.protobuf.Empty
+	9,   // 104: immudb.schema.ImmuService.CreateUser:input_type -> immudb.schema.CreateUserRequest
+	11,  // 105: immudb.schema.ImmuService.ChangePassword:input_type -> immudb.schema.ChangePasswordRequest
+	142, // 106: immudb.schema.ImmuService.ExportUsers:input_type -> google.protobuf.Empty
+	8,   // 107: immudb.schema.ImmuService.ImportUsers:input_type -> immudb.schema.UserList
+	14,  // 108: immudb.schema.ImmuService.ResetPassword:input_type -> immudb.schema.ResetPasswordRequest
+	17,  // 109: immudb.schema.ImmuService.UpdateAuthConfig:input_type -> immudb.schema.AuthConfig
+	18,  // 110: immudb.schema.ImmuService.UpdateMTLSConfig:input_type -> immudb.schema.MTLSConfig
+	15,  // 111: immudb.schema.ImmuService.Login:input_type -> immudb.schema.LoginRequest
+	142, // 112: immudb.schema.ImmuService.Logout:input_type -> google.protobuf.Empty
+	44,  // 113: immudb.schema.ImmuService.Set:input_type -> immudb.schema.SetRequest
+	48,  // 114: immudb.schema.ImmuService.VerifiableSet:input_type -> immudb.schema.VerifiableSetRequest
+	46,  // 115: immudb.schema.ImmuService.Get:input_type -> immudb.schema.KeyRequest
+	49,  // 116: immudb.schema.ImmuService.VerifiableGet:input_type -> immudb.schema.VerifiableGetRequest
+	50,  // 117: immudb.schema.ImmuService.VerifiableHistoricalGet:input_type -> immudb.schema.VerifiableHistoricalGetRequest
+	47,  // 118: immudb.schema.ImmuService.GetAll:input_type -> immudb.schema.KeyListRequest
+	26,  // 119: immudb.schema.ImmuService.ExecAll:input_type -> immudb.schema.ExecAllRequest
+	94,  // 120: immudb.schema.ImmuService.ExecMultiDbAll:input_type -> immudb.schema.ExecMultiDbAllRequest
+	30,  // 121: immudb.schema.ImmuService.Scan:input_type -> immudb.schema.ScanRequest
+	31,  // 122: immudb.schema.ImmuService.IndexScan:input_type -> immudb.schema.IndexScanRequest
+	32,  // 123: immudb.schema.ImmuService.Count:input_type -> immudb.schema.KeyPrefix
+	142, // 124: immudb.schema.ImmuService.CountAll:input_type -> google.protobuf.Empty
+	71,  // 125: immudb.schema.ImmuService.TxById:input_type -> immudb.schema.TxRequest
+	74,  // 126: immudb.schema.ImmuService.VerifiableTxById:input_type -> immudb.schema.VerifiableTxRequest
+	75,  // 127: immudb.schema.ImmuService.TxScan:input_type -> immudb.schema.TxScanRequest
+	77,  // 128: immudb.schema.ImmuService.Subscribe:input_type -> immudb.schema.SubscribeRequest
+	69,  // 129: immudb.schema.ImmuService.History:input_type -> immudb.schema.HistoryRequest
+	142, // 130: immudb.schema.ImmuService.Health:input_type -> google.protobuf.Empty
+	52,  // 131: immudb.schema.ImmuService.ServerInfo:input_type -> immudb.schema.ServerInfoRequest
+	142, // 132: immudb.schema.ImmuService.CurrentState:input_type -> google.protobuf.Empty
+	56,  // 133: immudb.schema.ImmuService.SetReference:input_type -> immudb.schema.ReferenceRequest
+	57,  // 134: immudb.schema.ImmuService.VerifiableSetReference:input_type -> immudb.schema.VerifiableReferenceRequest
+	58,  // 135: immudb.schema.ImmuService.ZAdd:input_type -> immudb.schema.ZAddRequest
+	70,  // 136: immudb.schema.ImmuService.VerifiableZAdd:input_type -> immudb.schema.VerifiableZAddRequest
+	60,  // 137: immudb.schema.ImmuService.ZScan:input_type -> immudb.schema.ZScanRequest
+	63,  // 138: immudb.schema.ImmuService.TSAppend:input_type -> immudb.schema.TSAppendRequest
+	64,  // 139: immudb.schema.ImmuService.TSRange:input_type -> immudb.schema.TSRangeRequest
+	65,  // 140: immudb.schema.ImmuService.Notarize:input_type -> immudb.schema.NotarizeRequest
+	66,  // 141: immudb.schema.ImmuService.VerifyNotarizationReceipt:input_type -> immudb.schema.NotarizationReceipt
+	96,  // 142: immudb.schema.ImmuService.CreateCollection:input_type -> immudb.schema.Collection
+	142, // 143: immudb.schema.ImmuService.ListCollections:input_type -> google.protobuf.Empty
+	98,  // 144: immudb.schema.ImmuService.InsertDocuments:input_type -> immudb.schema.DocumentInsertRequest
+	100, // 145: immudb.schema.ImmuService.GetDocument:input_type -> immudb.schema.DocumentGetRequest
+	103, // 146: immudb.schema.ImmuService.SearchDocuments:input_type -> immudb.schema.DocumentSearchRequest
+	78,  // 147: immudb.schema.ImmuService.CreateDatabase:input_type -> immudb.schema.Database
+	79,  // 148: immudb.schema.ImmuService.CreateDatabaseWith:input_type -> immudb.schema.DatabaseSettings
+	79,  // 149: immudb.schema.ImmuService.UpdateDatabase:input_type -> immudb.schema.DatabaseSettings
+	142, // 150: immudb.schema.ImmuService.GetDatabaseSettings:input_type -> google.protobuf.Empty
+	142, // 151: immudb.schema.ImmuService.DatabaseList:input_type -> google.protobuf.Empty
+	78,  // 152: immudb.schema.ImmuService.DeleteDatabase:input_type -> immudb.schema.Database
+	78,  // 153: immudb.schema.ImmuService.UndeleteDatabase:input_type -> immudb.schema.Database
+	142, // 154: immudb.schema.ImmuService.DeletedDatabaseList:input_type -> google.protobuf.Empty
+	78,  // 155: immudb.schema.ImmuService.ShrinkDatabase:input_type -> immudb.schema.Database
+	142, // 156: immudb.schema.ImmuService.RotateEncryptionKeys:input_type -> google.protobuf.Empty
+	78,  // 157: immudb.schema.ImmuService.OpenedFiles:input_type -> immudb.schema.Database
+	78,  // 158: immudb.schema.ImmuService.CloseCachedFiles:input_type -> immudb.schema.Database
+	111, // 159: immudb.schema.ImmuService.CancelApplicationRequests:input_type -> immudb.schema.CancelApplicationRequestsRequest
+	78,  // 160: immudb.schema.ImmuService.UseDatabase:input_type -> immudb.schema.Database
+	142, // 161: immudb.schema.ImmuService.CleanIndex:input_type -> google.protobuf.Empty
+	89,  // 162: immudb.schema.ImmuService.ChangePermission:input_type -> immudb.schema.ChangePermissionRequest
+	90,  // 163: immudb.schema.ImmuService.SetActiveUser:input_type -> immudb.schema.SetActiveUserRequest
+	46,  // 164: immudb.schema.ImmuService.streamGet:input_type -> immudb.schema.KeyRequest
+	113, // 165: immudb.schema.ImmuService.streamSet:input_type -> immudb.schema.Chunk
+	49,  // 166: immudb.schema.ImmuService.streamVerifiableGet:input_type -> immudb.schema.VerifiableGetRequest
+	113, // 167: immudb.schema.ImmuService.streamVerifiableSet:input_type -> immudb.schema.Chunk
+	30,  // 168: immudb.schema.ImmuService.streamScan:input_type -> immudb.schema.ScanRequest
+	60,  // 169: immudb.schema.ImmuService.streamZScan:input_type -> immudb.schema.ZScanRequest
+	69,  // 170: immudb.schema.ImmuService.streamHistory:input_type -> immudb.schema.HistoryRequest
+	113, // 171: immudb.schema.ImmuService.streamExecAll:input_type -> immudb.schema.Chunk
+	71,  // 172: immudb.schema.ImmuService.exportTx:input_type -> immudb.schema.TxRequest
+	72,  // 173: immudb.schema.ImmuService.exportTxRange:input_type -> immudb.schema.TxRangeRequest
+	113, // 174: immudb.schema.ImmuService.applyTxRange:input_type -> immudb.schema.Chunk
+	114, // 175: immudb.schema.ImmuService.UseSnapshot:input_type -> immudb.schema.UseSnapshotRequest
+	115, // 176: immudb.schema.ImmuService.SQLExec:input_type -> immudb.schema.SQLExecRequest
+	116, // 177: immudb.schema.ImmuService.SQLBatch:input_type -> immudb.schema.SQLBatchRequest
+	120, // 178: immudb.schema.ImmuService.SQLQuery:input_type -> immudb.schema.SQLQueryRequest
+	142, // 179: immudb.schema.ImmuService.ListTables:input_type -> google.protobuf.Empty
+	80,  // 180: immudb.schema.ImmuService.DescribeTable:input_type -> immudb.schema.Table
+	82,  // 181: immudb.schema.ImmuService.VerifiableSQLGet:input_type -> immudb.schema.VerifiableSQLGetRequest
+	85,  // 182: immudb.schema.ImmuService.VerifiableSQLQuery:input_type -> immudb.schema.VerifiableSQLQueryRequest
+	142, // 183: immudb.schema.ImmuService.ExportSQLSchema:input_type -> google.protobuf.Empty
+	124, // 184: immudb.schema.ImmuService.ImportSQLSchema:input_type -> immudb.schema.SQLSchema
+	8,   // 185: immudb.schema.ImmuService.ListUsers:output_type -> immudb.schema.UserList
+	142, // 186: immudb.schema.ImmuService.CreateUser:output_type -> google.protobuf.Empty
+	142, // 187: immudb.schema.ImmuService.ChangePassword:output_type -> google.protobuf.Empty
+	8,   // 188: immudb.schema.ImmuService.ExportUsers:output_type -> immudb.schema.UserList
+	12,  // 189: immudb.schema.ImmuService.ImportUsers:output_type -> immudb.schema.ImportUsersResponse
+	142, // 190: immudb.schema.ImmuService.ResetPassword:output_type -> google.protobuf.Empty
+	142, // 191: immudb.schema.ImmuService.UpdateAuthConfig:output_type -> google.protobuf.Empty
+	142, // 192: immudb.schema.ImmuService.UpdateMTLSConfig:output_type -> google.protobuf.Empty
+	16,  // 193: immudb.schema.ImmuService.Login:output_type -> immudb.schema.LoginResponse
+	142, // 194: immudb.schema.ImmuService.Logout:output_type -> google.protobuf.Empty
+	35,  // 195: immudb.schema.ImmuService.Set:output_type -> immudb.schema.TxMetadata
+	41,  // 196: immudb.schema.ImmuService.VerifiableSet:output_type -> immudb.schema.VerifiableTx
+	23,  // 197: immudb.schema.ImmuService.Get:output_type -> immudb.schema.Entry
+	42,  // 198: immudb.schema.ImmuService.VerifiableGet:output_type -> immudb.schema.VerifiableEntry
+	42,  // 199: immudb.schema.ImmuService.VerifiableHistoricalGet:output_type -> immudb.schema.VerifiableEntry
+	27,  // 200: immudb.schema.ImmuService.GetAll:output_type -> immudb.schema.Entries
+	35,  // 201: immudb.schema.ImmuService.ExecAll:output_type -> immudb.schema.TxMetadata
+	95,  // 202: immudb.schema.ImmuService.ExecMultiDbAll:output_type -> immudb.schema.ExecMultiDbAllResponse
+	27,  // 203: immudb.schema.ImmuService.Scan:output_type -> immudb.schema.Entries
+	27,  // 204: immudb.schema.ImmuService.IndexScan:output_type -> immudb.schema.Entries
+	33,  // 205: immudb.schema.ImmuService.Count:output_type -> immudb.schema.EntryCount
+	33,  // 206: immudb.schema.ImmuService.CountAll:output_type -> immudb.schema.EntryCount
+	39,  // 207: immudb.schema.ImmuService.TxById:output_type -> immudb.schema.Tx
+	41,  // 208: immudb.schema.ImmuService.VerifiableTxById:output_type -> immudb.schema.VerifiableTx
+	76,  // 209: immudb.schema.ImmuService.TxScan:output_type -> immudb.schema.TxList
+	39,  // 210: immudb.schema.ImmuService.Subscribe:output_type -> immudb.schema.Tx
+	27,  // 211: immudb.schema.ImmuService.History:output_type -> immudb.schema.Entries
+	51,  // 212: immudb.schema.ImmuService.Health:output_type -> immudb.schema.HealthResponse
+	53,  // 213: immudb.schema.ImmuService.ServerInfo:output_type -> immudb.schema.ServerInfoResponse
+	55,  // 214: immudb.schema.ImmuService.CurrentState:output_type -> immudb.schema.ImmutableState
+	35,  // 215: immudb.schema.ImmuService.SetReference:output_type -> immudb.schema.TxMetadata
+	41,  // 216: immudb.schema.ImmuService.VerifiableSetReference:output_type -> immudb.schema.VerifiableTx
+	35,  // 217: immudb.schema.ImmuService.ZAdd:output_type -> immudb.schema.TxMetadata
+	41,  // 218: immudb.schema.ImmuService.VerifiableZAdd:output_type -> immudb.schema.VerifiableTx
+	29,  // 219: immudb.schema.ImmuService.ZScan:output_type -> immudb.schema.ZEntries
+	35,  // 220: immudb.schema.ImmuService.TSAppend:output_type -> immudb.schema.TxMetadata
+	62,  // 221: immudb.schema.ImmuService.TSRange:output_type -> immudb.schema.TSSamples
+	67,  // 222: immudb.schema.ImmuService.Notarize:output_type -> immudb.schema.NotarizationReceipts
+	68,  // 223: immudb.schema.ImmuService.VerifyNotarizationReceipt:output_type -> immudb.schema.NotarizationVerification
+	35,  // 224: immudb.schema.ImmuService.CreateCollection:output_type -> immudb.schema.TxMetadata
+	97,  // 225: immudb.schema.ImmuService.ListCollections:output_type -> immudb.schema.CollectionList
+	99,  // 226: immudb.schema.ImmuService.InsertDocuments:output_type -> immudb.schema.DocumentInsertResponse
+	101, // 227: immudb.schema.ImmuService.GetDocument:output_type -> immudb.schema.Document
+	104, // 228: immudb.schema.ImmuService.SearchDocuments:output_type -> immudb.schema.DocumentList
+	142, // 229: immudb.schema.ImmuService.CreateDatabase:output_type -> google.protobuf.Empty
+	142, // 230: immudb.schema.ImmuService.CreateDatabaseWith:output_type -> google.protobuf.Empty
+	142, // 231: immudb.schema.ImmuService.UpdateDatabase:output_type -> google.protobuf.Empty
+	79,  // 232: immudb.schema.ImmuService.GetDatabaseSettings:output_type -> immudb.schema.DatabaseSettings
+	91,  // 233: immudb.schema.ImmuService.DatabaseList:output_type -> immudb.schema.DatabaseListResponse
+	142, // 234: immudb.schema.ImmuService.DeleteDatabase:output_type -> google.protobuf.Empty
+	142, // 235: immudb.schema.ImmuService.UndeleteDatabase:output_type -> google.protobuf.Empty
+	109, // 236: immudb.schema.ImmuService.DeletedDatabaseList:output_type -> immudb.schema.DeletedDatabaseListResponse
+	142, // 237: immudb.schema.ImmuService.ShrinkDatabase:output_type -> google.protobuf.Empty
+	110, // 238: immudb.schema.ImmuService.RotateEncryptionKeys:output_type -> immudb.schema.RotateEncryptionKeysResponse
+	107, // 239: immudb.schema.ImmuService.OpenedFiles:output_type -> immudb.schema.OpenedFilesResponse
+	108, // 240: immudb.schema.ImmuService.CloseCachedFiles:output_type -> immudb.schema.CloseCachedFilesResponse
+	112, // 241: immudb.schema.ImmuService.CancelApplicationRequests:output_type -> immudb.schema.CancelApplicationRequestsResponse
+	88,  // 242: immudb.schema.ImmuService.UseDatabase:output_type -> immudb.schema.UseDatabaseReply
+	142, // 243: immudb.schema.ImmuService.CleanIndex:output_type -> google.protobuf.Empty
+	142, // 244: immudb.schema.ImmuService.ChangePermission:output_type -> google.protobuf.Empty
+	142, // 245: immudb.schema.ImmuService.SetActiveUser:output_type -> google.protobuf.Empty
+	113, // 246: immudb.schema.ImmuService.streamGet:output_type -> immudb.schema.Chunk
+	35,  // 247: immudb.schema.ImmuService.streamSet:output_type -> immudb.schema.TxMetadata
+	113, // 248: immudb.schema.ImmuService.streamVerifiableGet:output_type -> immudb.schema.Chunk
+	41,  // 249: immudb.schema.ImmuService.streamVerifiableSet:output_type -> immudb.schema.VerifiableTx
+	113, // 250: immudb.schema.ImmuService.streamScan:output_type -> immudb.schema.Chunk
+	113, // 251: immudb.schema.ImmuService.streamZScan:output_type -> immudb.schema.Chunk
+	113, // 252: immudb.schema.ImmuService.streamHistory:output_type -> immudb.schema.Chunk
+	35,  // 253: immudb.schema.ImmuService.streamExecAll:output_type -> immudb.schema.TxMetadata
+	113, // 254: immudb.schema.ImmuService.exportTx:output_type -> immudb.schema.Chunk
+	113, // 255: immudb.schema.ImmuService.exportTxRange:output_type -> immudb.schema.Chunk
+	73,  // 256: immudb.schema.ImmuService.applyTxRange:output_type -> immudb.schema.TxRange
+	142, // 257: immudb.schema.ImmuService.UseSnapshot:output_type -> google.protobuf.Empty
+	122, // 258: immudb.schema.ImmuService.SQLExec:output_type -> immudb.schema.SQLExecResult
+	118, // 259: immudb.schema.ImmuService.SQLBatch:output_type -> immudb.schema.SQLBatchResult
+	123, // 260: immudb.schema.ImmuService.SQLQuery:output_type -> immudb.schema.SQLQueryResult
+	123, // 261: immudb.schema.ImmuService.ListTables:output_type -> immudb.schema.SQLQueryResult
+	123, // 262: immudb.schema.ImmuService.DescribeTable:output_type -> immudb.schema.SQLQueryResult
+	84,  // 263: immudb.schema.ImmuService.VerifiableSQLGet:output_type -> immudb.schema.VerifiableSQLEntry
+	87,  // 264: immudb.schema.ImmuService.VerifiableSQLQuery:output_type -> immudb.schema.VerifiableSQLQueryResult
+	124, // 265: immudb.schema.ImmuService.ExportSQLSchema:output_type -> immudb.schema.SQLSchema
+	122, // 266: immudb.schema.ImmuService.ImportSQLSchema:output_type -> immudb.schema.SQLExecResult
+	185, // [185:267] is the sub-list for method output_type
+	103, // [103:185] is the sub-list for method input_type
+	103, // [103:103] is the sub-list for extension type_name
+	103, // [103:103] is the sub-list for extension extendee
+	0,   // [0:103] is the sub-list for field type_name
+}
+
+func init() { file_schema_proto_init() }
+func file_schema_proto_init() {
+	if File_schema_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_schema_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Key); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Permission); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*User); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UserList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateUserRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UserRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChangePasswordRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImportUsersResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PasswordResetToken); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResetPasswordRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LoginRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LoginResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuthConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MTLSConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Expiration); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KVMetadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KVIndex); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeyValue); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Entry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Reference); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Op); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExecAllRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Entries); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ZEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ZEntries); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScanRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IndexScanRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeyPrefix); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EntryCount); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Signature); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TxMetadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TxAttributes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LinearProof); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DualProof); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Tx); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TxEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifiableTx); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifiableEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InclusionProof); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Precondition); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeyRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeyListRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifiableSetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifiableGetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifiableHistoricalGetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HealthResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ServerInfoRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ServerInfoResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ServerFeature); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImmutableState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReferenceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifiableReferenceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ZAddRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Score); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ZScanRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TSSample); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TSSamples); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[58].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TSAppendRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[59].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TSRangeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[60].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NotarizeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[61].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NotarizationReceipt); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[62].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NotarizationReceipts); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[63].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NotarizationVerification); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[64].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HistoryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[65].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifiableZAddRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[66].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TxRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[67].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TxRangeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[68].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TxRange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[69].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifiableTxRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[70].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TxScanRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[71].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TxList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[72].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[73].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Database); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[74].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DatabaseSettings); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[75].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Table); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[76].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SQLGetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[77].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifiableSQLGetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[78].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SQLEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[79].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifiableSQLEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[80].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifiableSQLQueryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[81].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifiableSQLRow); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[82].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifiableSQLQueryResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[83].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UseDatabaseReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[84].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChangePermissionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[85].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetActiveUserRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[86].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DatabaseListResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[87].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeletedDatabase); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[88].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DatabaseExecAllRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[89].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExecMultiDbAllRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[90].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExecMultiDbAllResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[91].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Collection); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[92].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CollectionList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[93].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DocumentInsertRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[94].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DocumentInsertResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[95].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DocumentGetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[96].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Document); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[97].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DocumentFilter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[98].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DocumentSearchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[99].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DocumentList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[100].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LogOpenedFiles); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[101].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DatabaseOpenedFiles); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[102].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OpenedFilesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[103].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CloseCachedFilesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[104].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeletedDatabaseListResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[105].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RotateEncryptionKeysResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[106].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelApplicationRequestsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[107].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelApplicationRequestsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[108].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Chunk); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[109].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UseSnapshotRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[110].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SQLExecRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[111].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SQLBatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[112].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SQLBatchStmt); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[113].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SQLBatchResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[114].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SQLBatchStmtResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[115].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SQLQueryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[116].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NamedParam); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[117].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SQLExecResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[118].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SQLQueryResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[119].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SQLSchema); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[120].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Column); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[121].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Row); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[122].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SQLValue); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[123].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SQLValueList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[124].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Precondition_KeyMustNotExistPrecondition); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[125].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Precondition_KeyMustHaveRevisionPrecondition); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_schema_proto_msgTypes[126].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Precondition_KeyNotModifiedAfterTXPrecondition); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_schema_proto_msgTypes[20].OneofWrappers = []interface{}{
+		(*Op_Kv)(nil),
+		(*Op_ZAdd)(nil),
+		(*Op_Ref)(nil),
+	}
+	file_schema_proto_msgTypes[40].OneofWrappers = []interface{}{
+		(*Precondition_KeyMustNotExist)(nil),
+		(*Precondition_KeyMustHaveRevision)(nil),
+		(*Precondition_KeyNotModifiedAfterTX)(nil),
+	}
+	file_schema_proto_msgTypes[122].OneofWrappers = []interface{}{
+		(*SQLValue_Null)(nil),
+		(*SQLValue_N)(nil),
+		(*SQLValue_S)(nil),
+		(*SQLValue_B)(nil),
+		(*SQLValue_Bs)(nil),
+		(*SQLValue_F)(nil),
+		(*SQLValue_Ts)(nil),
+		(*SQLValue_List)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_schema_proto_rawDesc,
+			NumEnums:      5,
+			NumMessages:   134,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_schema_proto_goTypes,
+		DependencyIndexes: file_schema_proto_depIdxs,
+		EnumInfos:         file_schema_proto_enumTypes,
+		MessageInfos:      file_schema_proto_msgTypes,
+	}.Build()
+	File_schema_proto = out.File
+	file_schema_proto_rawDesc = nil
+	file_schema_proto_goTypes = nil
+	file_schema_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// ImmuServiceClient is the client API for ImmuService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type ImmuServiceClient interface {
+	ListUsers(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*UserList, error)
+	CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	ExportUsers(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*UserList, error)
+	ImportUsers(ctx context.Context, in *UserList, opts ...grpc.CallOption) (*ImportUsersResponse, error)
+	ResetPassword(ctx context.Context, in *ResetPasswordRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	UpdateAuthConfig(ctx context.Context, in *AuthConfig, opts ...grpc.CallOption) (*empty.Empty, error)
+	UpdateMTLSConfig(ctx context.Context, in *MTLSConfig, opts ...grpc.CallOption) (*empty.Empty, error)
+	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
+	Logout(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
+	Set(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*TxMetadata, error)
+	VerifiableSet(ctx context.Context, in *VerifiableSetRequest, opts ...grpc.CallOption) (*VerifiableTx, error)
+	Get(ctx context.Context, in *KeyRequest, opts ...grpc.CallOption) (*Entry, error)
+	VerifiableGet(ctx context.Context, in *VerifiableGetRequest, opts ...grpc.CallOption) (*VerifiableEntry, error)
+	VerifiableHistoricalGet(ctx context.Context, in *VerifiableHistoricalGetRequest, opts ...grpc.CallOption) (*VerifiableEntry, error)
+	GetAll(ctx context.Context, in *KeyListRequest, opts ...grpc.CallOption) (*Entries, error)
+	ExecAll(ctx context.Context, in *ExecAllRequest, opts ...grpc.CallOption) (*TxMetadata, error)
+	ExecMultiDbAll(ctx context.Context, in *ExecMultiDbAllRequest, opts ...grpc.CallOption) (*ExecMultiDbAllResponse, error)
+	Scan(ctx context.Context, in *ScanRequest, opts ...grpc.CallOption) (*Entries, error)
+	IndexScan(ctx context.Context, in *IndexScanRequest, opts ...grpc.CallOption) (*Entries, error)
+	Count(ctx context.Context, in *KeyPrefix, opts ...grpc.CallOption) (*EntryCount, error)
+	CountAll(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*EntryCount, error)
+	TxById(ctx context.Context, in *TxRequest, opts ...grpc.CallOption) (*Tx, error)
+	VerifiableTxById(ctx context.Context, in *VerifiableTxRequest, opts ...grpc.CallOption) (*VerifiableTx, error)
+	TxScan(ctx context.Context, in *TxScanRequest, opts ...grpc.CallOption) (*TxList, error)
+	// Subscribe streams committed transactions starting from initialTx, waiting for new ones to be committed
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (ImmuService_SubscribeClient, error)
+	History(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*Entries, error)
+	Health(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*HealthResponse, error)
+	ServerInfo(ctx context.Context, in *ServerInfoRequest, opts ...grpc.CallOption) (*ServerInfoResponse, error)
+	CurrentState(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ImmutableState, error)
+	SetReference(ctx context.Context, in *ReferenceRequest, opts ...grpc.CallOption) (*TxMetadata, error)
+	VerifiableSetReference(ctx context.Context, in *VerifiableReferenceRequest, opts ...grpc.CallOption) (*VerifiableTx, error)
+	ZAdd(ctx context.Context, in *ZAddRequest, opts ...grpc.CallOption) (*TxMetadata, error)
+	VerifiableZAdd(ctx context.Context, in *VerifiableZAddRequest, opts ...grpc.CallOption) (*VerifiableTx, error)
+	ZScan(ctx context.Context, in *ZScanRequest, opts ...grpc.CallOption) (*ZEntries, error)
+	TSAppend(ctx context.Context, in *TSAppendRequest, opts ...grpc.CallOption) (*TxMetadata, error)
+	TSRange(ctx context.Context, in *TSRangeRequest, opts ...grpc.CallOption) (*TSSamples, error)
+	Notarize(ctx context.Context, in *NotarizeRequest, opts ...grpc.CallOption) (*NotarizationReceipts, error)
+	VerifyNotarizationReceipt(ctx context.Context, in *NotarizationReceipt, opts ...grpc.CallOption) (*NotarizationVerification, error)
+	CreateCollection(ctx context.Context, in *Collection, opts ...grpc.CallOption) (*TxMetadata, error)
+	ListCollections(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*CollectionList, error)
+	InsertDocuments(ctx context.Context, in *DocumentInsertRequest, opts ...grpc.CallOption) (*DocumentInsertResponse, error)
+	GetDocument(ctx context.Context, in *DocumentGetRequest, opts ...grpc.CallOption) (*Document, error)
+	SearchDocuments(ctx context.Context, in *DocumentSearchRequest, opts ...grpc.CallOption) (*DocumentList, error)
+	CreateDatabase(ctx context.Context, in *Database, opts ...grpc.CallOption) (*empty.Empty, error)
+	CreateDatabaseWith(ctx context.Context, in *DatabaseSettings, opts ...grpc.CallOption) (*empty.Empty, error)
+	UpdateDatabase(ctx context.Context, in *DatabaseSettings, opts ...grpc.CallOption) (*empty.Empty, error)
+	GetDatabaseSettings(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*DatabaseSettings, error)
+	DatabaseList(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*DatabaseListResponse, error)
+	DeleteDatabase(ctx context.Context, in *Database, opts ...grpc.CallOption) (*empty.Empty, error)
+	UndeleteDatabase(ctx context.Context, in *Database, opts ...grpc.CallOption) (*empty.Empty, error)
+	DeletedDatabaseList(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*DeletedDatabaseListResponse, error)
+	ShrinkDatabase(ctx context.Context, in *Database, opts ...grpc.CallOption) (*empty.Empty, error)
+	RotateEncryptionKeys(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*RotateEncryptionKeysResponse, error)
+	// lists the files opened by the given database, or by all the loaded databases when no name is given
+	OpenedFiles(ctx context.Context, in *Database, opts ...grpc.CallOption) (*OpenedFilesResponse, error)
+	// closes the files kept open for reading by the given database, or by all the loaded databases when no name is given
+	CloseCachedFiles(ctx context.Context, in *Database, opts ...grpc.CallOption) (*CloseCachedFilesResponse, error)
+	// cancels the in-flight requests sent by the given application
+	CancelApplicationRequests(ctx context.Context, in *CancelApplicationRequestsRequest, opts ...grpc.CallOption) (*CancelApplicationRequestsResponse, error)
+	UseDatabase(ctx context.Context, in *Database, opts ...grpc.CallOption) (*UseDatabaseReply, error)
+	CleanIndex(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
+	ChangePermission(ctx context.Context, in *ChangePermissionRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	SetActiveUser(ctx context.Context, in *SetActiveUserRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	// Streams
+	StreamGet(ctx context.Context, in *KeyRequest, opts ...grpc.CallOption) (ImmuService_StreamGetClient, error)
+	StreamSet(ctx context.Context, opts ...grpc.CallOption) (ImmuService_StreamSetClient, error)
+	StreamVerifiableGet(ctx context.Context, in *VerifiableGetRequest, opts ...grpc.CallOption) (ImmuService_StreamVerifiableGetClient, error)
+	StreamVerifiableSet(ctx context.Context, opts ...grpc.CallOption) (ImmuService_StreamVerifiableSetClient, error)
+	StreamScan(ctx context.Context, in *ScanRequest, opts ...grpc.CallOption) (ImmuService_StreamScanClient, error)
+	StreamZScan(ctx context.Context, in *ZScanRequest, opts ...grpc.CallOption) (ImmuService_StreamZScanClient, error)
+	StreamHistory(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (ImmuService_StreamHistoryClient, error)
+	StreamExecAll(ctx context.Context, opts ...grpc.CallOption) (ImmuService_StreamExecAllClient, error)
+	// Replication
+	ExportTx(ctx context.Context, in *TxRequest, opts ...grpc.CallOption) (ImmuService_ExportTxClient, error)
+	// Incremental backups
+	ExportTxRange(ctx context.Context, in *TxRangeRequest, opts ...grpc.CallOption) (ImmuService_ExportTxRangeClient, error)
+	ApplyTxRange(ctx context.Context, opts ...grpc.CallOption) (ImmuService_ApplyTxRangeClient, error)
+	// SQL
+	UseSnapshot(ctx context.Context, in *UseSnapshotRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	SQLExec(ctx context.Context, in *SQLExecRequest, opts ...grpc.CallOption) (*SQLExecResult, error)
+	SQLBatch(ctx context.Context, in *SQLBatchRequest, opts ...grpc.CallOption) (*SQLBatchResult, error)
+	SQLQuery(ctx context.Context, in *SQLQueryRequest, opts ...grpc.CallOption) (*SQLQueryResult, error)
+	ListTables(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*SQLQueryResult, error)
+	DescribeTable(ctx context.Context, in *Table, opts ...grpc.CallOption) (*SQLQueryResult, error)
+	VerifiableSQLGet(ctx context.Context, in *VerifiableSQLGetRequest, opts ...grpc.CallOption) (*VerifiableSQLEntry, error)
+	VerifiableSQLQuery(ctx context.Context, in *VerifiableSQLQueryRequest, opts ...grpc.CallOption) (*VerifiableSQLQueryResult, error)
+	ExportSQLSchema(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*SQLSchema, error)
+	ImportSQLSchema(ctx context.Context, in *SQLSchema, opts ...grpc.CallOption) (*SQLExecResult, error)
+}
+
+type immuServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewImmuServiceClient(cc grpc.ClientConnInterface) ImmuServiceClient {
+	return &immuServiceClient{cc}
+}
+
+func (c *immuServiceClient) ListUsers(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*UserList, error) {
+	out := new(UserList)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/ListUsers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/CreateUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/ChangePassword", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) ExportUsers(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*UserList, error) {
+	out := new(UserList)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/ExportUsers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) ImportUsers(ctx context.Context, in *UserList, opts ...grpc.CallOption) (*ImportUsersResponse, error) {
+	out := new(ImportUsersResponse)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/ImportUsers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) ResetPassword(ctx context.Context, in *ResetPasswordRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/ResetPassword", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) UpdateAuthConfig(ctx context.Context, in *AuthConfig, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/UpdateAuthConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) UpdateMTLSConfig(ctx context.Context, in *MTLSConfig, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/UpdateMTLSConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
+	out := new(LoginResponse)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/Login", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) Logout(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/Logout", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) Set(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*TxMetadata, error) {
+	out := new(TxMetadata)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/Set", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) VerifiableSet(ctx context.Context, in *VerifiableSetRequest, opts ...grpc.CallOption) (*VerifiableTx, error) {
+	out := new(VerifiableTx)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/VerifiableSet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) Get(ctx context.Context, in *KeyRequest, opts ...grpc.CallOption) (*Entry, error) {
+	out := new(Entry)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/Get", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) VerifiableGet(ctx context.Context, in *VerifiableGetRequest, opts ...grpc.CallOption) (*VerifiableEntry, error) {
+	out := new(VerifiableEntry)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/VerifiableGet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) VerifiableHistoricalGet(ctx context.Context, in *VerifiableHistoricalGetRequest, opts ...grpc.CallOption) (*VerifiableEntry, error) {
+	out := new(VerifiableEntry)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/VerifiableHistoricalGet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) GetAll(ctx context.Context, in *KeyListRequest, opts ...grpc.CallOption) (*Entries, error) {
+	out := new(Entries)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/GetAll", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) ExecAll(ctx context.Context, in *ExecAllRequest, opts ...grpc.CallOption) (*TxMetadata, error) {
+	out := new(TxMetadata)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/ExecAll", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) ExecMultiDbAll(ctx context.Context, in *ExecMultiDbAllRequest, opts ...grpc.CallOption) (*ExecMultiDbAllResponse, error) {
+	out := new(ExecMultiDbAllResponse)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/ExecMultiDbAll", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) Scan(ctx context.Context, in *ScanRequest, opts ...grpc.CallOption) (*Entries, error) {
+	out := new(Entries)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/Scan", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) IndexScan(ctx context.Context, in *IndexScanRequest, opts ...grpc.CallOption) (*Entries, error) {
+	out := new(Entries)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/IndexScan", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) Count(ctx context.Context, in *KeyPrefix, opts ...grpc.CallOption) (*EntryCount, error) {
+	out := new(EntryCount)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/Count", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) CountAll(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*EntryCount, error) {
+	out := new(EntryCount)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/CountAll", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) TxById(ctx context.Context, in *TxRequest, opts ...grpc.CallOption) (*Tx, error) {
+	out := new(Tx)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/TxById", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) VerifiableTxById(ctx context.Context, in *VerifiableTxRequest, opts ...grpc.CallOption) (*VerifiableTx, error) {
+	out := new(VerifiableTx)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/VerifiableTxById", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) TxScan(ctx context.Context, in *TxScanRequest, opts ...grpc.CallOption) (*TxList, error) {
+	out := new(TxList)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/TxScan", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (ImmuService_SubscribeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ImmuService_serviceDesc.Streams[0], "/immudb.schema.ImmuService/Subscribe", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &immuServiceSubscribeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ImmuService_SubscribeClient interface {
+	Recv() (*Tx, error)
+	grpc.ClientStream
+}
+
+type immuServiceSubscribeClient struct {
+	grpc.ClientStream
+}
+
+func (x *immuServiceSubscribeClient) Recv() (*Tx, error) {
+	m := new(Tx)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *immuServiceClient) History(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*Entries, error) {
+	out := new(Entries)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/History", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) Health(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*HealthResponse, error) {
+	out := new(HealthResponse)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/Health", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) ServerInfo(ctx context.Context, in *ServerInfoRequest, opts ...grpc.CallOption) (*ServerInfoResponse, error) {
+	out := new(ServerInfoResponse)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/ServerInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) CurrentState(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ImmutableState, error) {
+	out := new(ImmutableState)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/CurrentState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) SetReference(ctx context.Context, in *ReferenceRequest, opts ...grpc.CallOption) (*TxMetadata, error) {
+	out := new(TxMetadata)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/SetReference", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) VerifiableSetReference(ctx context.Context, in *VerifiableReferenceRequest, opts ...grpc.CallOption) (*VerifiableTx, error) {
+	out := new(VerifiableTx)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/VerifiableSetReference", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) ZAdd(ctx context.Context, in *ZAddRequest, opts ...grpc.CallOption) (*TxMetadata, error) {
+	out := new(TxMetadata)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/ZAdd", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) VerifiableZAdd(ctx context.Context, in *VerifiableZAddRequest, opts ...grpc.CallOption) (*VerifiableTx, error) {
+	out := new(VerifiableTx)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/VerifiableZAdd", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) ZScan(ctx context.Context, in *ZScanRequest, opts ...grpc.CallOption) (*ZEntries, error) {
+	out := new(ZEntries)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/ZScan", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) TSAppend(ctx context.Context, in *TSAppendRequest, opts ...grpc.CallOption) (*TxMetadata, error) {
+	out := new(TxMetadata)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/TSAppend", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) TSRange(ctx context.Context, in *TSRangeRequest, opts ...grpc.CallOption) (*TSSamples, error) {
+	out := new(TSSamples)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/TSRange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) Notarize(ctx context.Context, in *NotarizeRequest, opts ...grpc.CallOption) (*NotarizationReceipts, error) {
+	out := new(NotarizationReceipts)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/Notarize", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) VerifyNotarizationReceipt(ctx context.Context, in *NotarizationReceipt, opts ...grpc.CallOption) (*NotarizationVerification, error) {
+	out := new(NotarizationVerification)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/VerifyNotarizationReceipt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) CreateCollection(ctx context.Context, in *Collection, opts ...grpc.CallOption) (*TxMetadata, error) {
+	out := new(TxMetadata)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/CreateCollection", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) ListCollections(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*CollectionList, error) {
+	out := new(CollectionList)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/ListCollections", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) InsertDocuments(ctx context.Context, in *DocumentInsertRequest, opts ...grpc.CallOption) (*DocumentInsertResponse, error) {
+	out := new(DocumentInsertResponse)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/InsertDocuments", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) GetDocument(ctx context.Context, in *DocumentGetRequest, opts ...grpc.CallOption) (*Document, error) {
+	out := new(Document)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/GetDocument", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) SearchDocuments(ctx context.Context, in *DocumentSearchRequest, opts ...grpc.CallOption) (*DocumentList, error) {
+	out := new(DocumentList)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/SearchDocuments", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) CreateDatabase(ctx context.Context, in *Database, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/CreateDatabase", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) CreateDatabaseWith(ctx context.Context, in *DatabaseSettings, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/CreateDatabaseWith", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) UpdateDatabase(ctx context.Context, in *DatabaseSettings, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/UpdateDatabase", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) GetDatabaseSettings(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*DatabaseSettings, error) {
+	out := new(DatabaseSettings)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/GetDatabaseSettings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) DatabaseList(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*DatabaseListResponse, error) {
+	out := new(DatabaseListResponse)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/DatabaseList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) DeleteDatabase(ctx context.Context, in *Database, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/DeleteDatabase", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) UndeleteDatabase(ctx context.Context, in *Database, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/UndeleteDatabase", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) DeletedDatabaseList(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*DeletedDatabaseListResponse, error) {
+	out := new(DeletedDatabaseListResponse)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/DeletedDatabaseList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) ShrinkDatabase(ctx context.Context, in *Database, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/ShrinkDatabase", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) RotateEncryptionKeys(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*RotateEncryptionKeysResponse, error) {
+	out := new(RotateEncryptionKeysResponse)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/RotateEncryptionKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) OpenedFiles(ctx context.Context, in *Database, opts ...grpc.CallOption) (*OpenedFilesResponse, error) {
+	out := new(OpenedFilesResponse)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/OpenedFiles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) CloseCachedFiles(ctx context.Context, in *Database, opts ...grpc.CallOption) (*CloseCachedFilesResponse, error) {
+	out := new(CloseCachedFilesResponse)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/CloseCachedFiles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) CancelApplicationRequests(ctx context.Context, in *CancelApplicationRequestsRequest, opts ...grpc.CallOption) (*CancelApplicationRequestsResponse, error) {
+	out := new(CancelApplicationRequestsResponse)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/CancelApplicationRequests", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) UseDatabase(ctx context.Context, in *Database, opts ...grpc.CallOption) (*UseDatabaseReply, error) {
+	out := new(UseDatabaseReply)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/UseDatabase", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) CleanIndex(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/CleanIndex", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) ChangePermission(ctx context.Context, in *ChangePermissionRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/ChangePermission", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) SetActiveUser(ctx context.Context, in *SetActiveUserRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/SetActiveUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *immuServiceClient) StreamGet(ctx context.Context, in *KeyRequest, opts ...grpc.CallOption) (ImmuService_StreamGetClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ImmuService_serviceDesc.Streams[1], "/immudb.schema.ImmuService/streamGet", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &immuServiceStreamGetClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ImmuService_StreamGetClient interface {
+	Recv() (*Chunk, error)
+	grpc.ClientStream
+}
+
+type immuServiceStreamGetClient struct {
+	grpc.ClientStream
+}
+
+func (x *immuServiceStreamGetClient) Recv() (*Chunk, error) {
+	m := new(Chunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *immuServiceClient) StreamSet(ctx context.Context, opts ...grpc.CallOption) (ImmuService_StreamSetClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ImmuService_serviceDesc.Streams[2], "/immudb.schema.ImmuService/streamSet", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &immuServiceStreamSetClient{stream}
+	return x, nil
+}
+
+type ImmuService_StreamSetClient interface {
+	Send(*Chunk) error
+	CloseAndRecv() (*TxMetadata, error)
+	grpc.ClientStream
+}
+
+type immuServiceStreamSetClient struct {
+	grpc.ClientStream
+}
+
+func (x *immuServiceStreamSetClient) Send(m *Chunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *immuServiceStreamSetClient) CloseAndRecv() (*TxMetadata, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(TxMetadata)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *immuServiceClient) StreamVerifiableGet(ctx context.Context, in *VerifiableGetRequest, opts ...grpc.CallOption) (ImmuService_StreamVerifiableGetClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ImmuService_serviceDesc.Streams[3], "/immudb.schema.ImmuService/streamVerifiableGet", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &immuServiceStreamVerifiableGetClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ImmuService_StreamVerifiableGetClient interface {
+	Recv() (*Chunk, error)
+	grpc.ClientStream
+}
+
+type immuServiceStreamVerifiableGetClient struct {
+	grpc.ClientStream
+}
+
+func (x *immuServiceStreamVerifiableGetClient) Recv() (*Chunk, error) {
+	m := new(Chunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *immuServiceClient) StreamVerifiableSet(ctx context.Context, opts ...grpc.CallOption) (ImmuService_StreamVerifiableSetClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ImmuService_serviceDesc.Streams[4], "/immudb.schema.ImmuService/streamVerifiableSet", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &immuServiceStreamVerifiableSetClient{stream}
+	return x, nil
+}
+
+type ImmuService_StreamVerifiableSetClient interface {
+	Send(*Chunk) error
+	CloseAndRecv() (*VerifiableTx, error)
+	grpc.ClientStream
+}
+
+type immuServiceStreamVerifiableSetClient struct {
+	grpc.ClientStream
+}
+
+func (x *immuServiceStreamVerifiableSetClient) Send(m *Chunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *immuServiceStreamVerifiableSetClient) CloseAndRecv() (*VerifiableTx, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(VerifiableTx)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *immuServiceClient) StreamScan(ctx context.Context, in *ScanRequest, opts ...grpc.CallOption) (ImmuService_StreamScanClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ImmuService_serviceDesc.Streams[5], "/immudb.schema.ImmuService/streamScan", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &immuServiceStreamScanClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ImmuService_StreamScanClient interface {
+	Recv() (*Chunk, error)
+	grpc.ClientStream
+}
+
+type immuServiceStreamScanClient struct {
+	grpc.ClientStream
+}
+
+func (x *immuServiceStreamScanClient) Recv() (*Chunk, error) {
+	m := new(Chunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	file_schema_proto_msgTypes[14].OneofWrappers = []interface{}{
-		(*Op_Kv)(nil),
-		(*Op_ZAdd)(nil),
-		(*Op_Ref)(nil),
+	return m, nil
+}
+
+func (c *immuServiceClient) StreamZScan(ctx context.Context, in *ZScanRequest, opts ...grpc.CallOption) (ImmuService_StreamZScanClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ImmuService_serviceDesc.Streams[6], "/immudb.schema.ImmuService/streamZScan", opts...)
+	if err != nil {
+		return nil, err
 	}
-	file_schema_proto_msgTypes[68].OneofWrappers = []interface{}{
-		(*SQLValue_Null)(nil),
-		(*SQLValue_N)(nil),
-		(*SQLValue_S)(nil),
-		(*SQLValue_B)(nil),
-		(*SQLValue_Bs)(nil),
+	x := &immuServiceStreamZScanClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
 	}
-	type x struct{}
-	out := protoimpl.TypeBuilder{
-		File: protoimpl.DescBuilder{
-			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_schema_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   72,
-			NumExtensions: 0,
-			NumServices:   1,
-		},
-		GoTypes:           file_schema_proto_goTypes,
-		DependencyIndexes: file_schema_proto_depIdxs,
-		EnumInfos:         file_schema_proto_enumTypes,
-		MessageInfos:      file_schema_proto_msgTypes,
-	}.Build()
-	File_schema_proto = out.File
-	file_schema_proto_rawDesc = nil
-	file_schema_proto_goTypes = nil
-	file_schema_proto_depIdxs = nil
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConnInterface
+type ImmuService_StreamZScanClient interface {
+	Recv() (*Chunk, error)
+	grpc.ClientStream
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion6
+type immuServiceStreamZScanClient struct {
+	grpc.ClientStream
+}
 
-// ImmuServiceClient is the client API for ImmuService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type ImmuServiceClient interface {
-	ListUsers(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*UserList, error)
-	CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	UpdateAuthConfig(ctx context.Context, in *AuthConfig, opts ...grpc.CallOption) (*empty.Empty, error)
-	UpdateMTLSConfig(ctx context.Context, in *MTLSConfig, opts ...grpc.CallOption) (*empty.Empty, error)
-	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
-	Logout(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
-	Set(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*TxMetadata, error)
-	VerifiableSet(ctx context.Context, in *VerifiableSetRequest, opts ...grpc.CallOption) (*VerifiableTx, error)
-	Get(ctx context.Context, in *KeyRequest, opts ...grpc.CallOption) (*Entry, error)
-	VerifiableGet(ctx context.Context, in *VerifiableGetRequest, opts ...grpc.CallOption) (*VerifiableEntry, error)
-	GetAll(ctx context.Context, in *KeyListRequest, opts ...grpc.CallOption) (*Entries, error)
-	ExecAll(ctx context.Context, in *ExecAllRequest, opts ...grpc.CallOption) (*TxMetadata, error)
-	Scan(ctx context.Context, in *ScanRequest, opts ...grpc.CallOption) (*Entries, error)
-	Count(ctx context.Context, in *KeyPrefix, opts ...grpc.CallOption) (*EntryCount, error)
-	CountAll(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*EntryCount, error)
-	TxById(ctx context.Context, in *TxRequest, opts ...grpc.CallOption) (*Tx, error)
-	VerifiableTxById(ctx context.Context, in *VerifiableTxRequest, opts ...grpc.CallOption) (*VerifiableTx, error)
-	TxScan(ctx context.Context, in *TxScanRequest, opts ...grpc.CallOption) (*TxList, error)
-	History(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*Entries, error)
-	Health(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*HealthResponse, error)
-	CurrentState(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ImmutableState, error)
-	SetReference(ctx context.Context, in *ReferenceRequest, opts ...grpc.CallOption) (*TxMetadata, error)
-	VerifiableSetReference(ctx context.Context, in *VerifiableReferenceRequest, opts ...grpc.CallOption) (*VerifiableTx, error)
-	ZAdd(ctx context.Context, in *ZAddRequest, opts ...grpc.CallOption) (*TxMetadata, error)
-	VerifiableZAdd(ctx context.Context, in *VerifiableZAddRequest, opts ...grpc.CallOption) (*VerifiableTx, error)
-	ZScan(ctx context.Context, in *ZScanRequest, opts ...grpc.CallOption) (*ZEntries, error)
-	CreateDatabase(ctx context.Context, in *Database, opts ...grpc.CallOption) (*empty.Empty, error)
-	DatabaseList(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*DatabaseListResponse, error)
-	UseDatabase(ctx context.Context, in *Database, opts ...grpc.CallOption) (*UseDatabaseReply, error)
-	CleanIndex(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error)
-	ChangePermission(ctx context.Context, in *ChangePermissionRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	SetActiveUser(ctx context.Context, in *SetActiveUserRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	// Streams
-	StreamGet(ctx context.Context, in *KeyRequest, opts ...grpc.CallOption) (ImmuService_StreamGetClient, error)
-	StreamSet(ctx context.Context, opts ...grpc.CallOption) (ImmuService_StreamSetClient, error)
-	StreamVerifiableGet(ctx context.Context, in *VerifiableGetRequest, opts ...grpc.CallOption) (ImmuService_StreamVerifiableGetClient, error)
-	StreamVerifiableSet(ctx context.Context, opts ...grpc.CallOption) (ImmuService_StreamVerifiableSetClient, error)
-	StreamScan(ctx context.Context, in *ScanRequest, opts ...grpc.CallOption) (ImmuService_StreamScanClient, error)
-	StreamZScan(ctx context.Context, in *ZScanRequest, opts ...grpc.CallOption) (ImmuService_StreamZScanClient, error)
-	StreamHistory(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (ImmuService_StreamHistoryClient, error)
-	StreamExecAll(ctx context.Context, opts ...grpc.CallOption) (ImmuService_StreamExecAllClient, error)
-	// SQL
-	UseSnapshot(ctx context.Context, in *UseSnapshotRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	SQLExec(ctx context.Context, in *SQLExecRequest, opts ...grpc.CallOption) (*SQLExecResult, error)
-	SQLQuery(ctx context.Context, in *SQLQueryRequest, opts ...grpc.CallOption) (*SQLQueryResult, error)
-	ListTables(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*SQLQueryResult, error)
-	DescribeTable(ctx context.Context, in *Table, opts ...grpc.CallOption) (*SQLQueryResult, error)
-	VerifiableSQLGet(ctx context.Context, in *VerifiableSQLGetRequest, opts ...grpc.CallOption) (*VerifiableSQLEntry, error)
+func (x *immuServiceStreamZScanClient) Recv() (*Chunk, error) {
+	m := new(Chunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
 
-type immuServiceClient struct {
-	cc grpc.ClientConnInterface
+func (c *immuServiceClient) StreamHistory(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (ImmuService_StreamHistoryClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ImmuService_serviceDesc.Streams[7], "/immudb.schema.ImmuService/streamHistory", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &immuServiceStreamHistoryClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func NewImmuServiceClient(cc grpc.ClientConnInterface) ImmuServiceClient {
-	return &immuServiceClient{cc}
+type ImmuService_StreamHistoryClient interface {
+	Recv() (*Chunk, error)
+	grpc.ClientStream
 }
 
-func (c *immuServiceClient) ListUsers(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*UserList, error) {
-	out := new(UserList)
-	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/ListUsers", in, out, opts...)
-	if err != nil {
+type immuServiceStreamHistoryClient struct {
+	grpc.ClientStream
+}
+
+func (x *immuServiceStreamHistoryClient) Recv() (*Chunk, error) {
+	m := new(Chunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return m, nil
 }
 
-func (c *immuServiceClient) CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/CreateUser", in, out, opts...)
+func (c *immuServiceClient) StreamExecAll(ctx context.Context, opts ...grpc.CallOption) (ImmuService_StreamExecAllClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ImmuService_serviceDesc.Streams[8], "/immudb.schema.ImmuService/streamExecAll", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	x := &immuServiceStreamExecAllClient{stream}
+	return x, nil
 }
 
-func (c *immuServiceClient) ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/ChangePassword", in, out, opts...)
-	if err != nil {
+type ImmuService_StreamExecAllClient interface {
+	Send(*Chunk) error
+	CloseAndRecv() (*TxMetadata, error)
+	grpc.ClientStream
+}
+
+type immuServiceStreamExecAllClient struct {
+	grpc.ClientStream
+}
+
+func (x *immuServiceStreamExecAllClient) Send(m *Chunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *immuServiceStreamExecAllClient) CloseAndRecv() (*TxMetadata, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
 		return nil, err
 	}
-	return out, nil
+	m := new(TxMetadata)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
 
-func (c *immuServiceClient) UpdateAuthConfig(ctx context.Context, in *AuthConfig, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/UpdateAuthConfig", in, out, opts...)
+func (c *immuServiceClient) ExportTx(ctx context.Context, in *TxRequest, opts ...grpc.CallOption) (ImmuService_ExportTxClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ImmuService_serviceDesc.Streams[9], "/immudb.schema.ImmuService/exportTx", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	x := &immuServiceExportTxClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func (c *immuServiceClient) UpdateMTLSConfig(ctx context.Context, in *MTLSConfig, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/UpdateMTLSConfig", in, out, opts...)
-	if err != nil {
+type ImmuService_ExportTxClient interface {
+	Recv() (*Chunk, error)
+	grpc.ClientStream
+}
+
+type immuServiceExportTxClient struct {
+	grpc.ClientStream
+}
+
+func (x *immuServiceExportTxClient) Recv() (*Chunk, error) {
+	m := new(Chunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return m, nil
 }
 
-func (c *immuServiceClient) Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
-	out := new(LoginResponse)
-	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/Login", in, out, opts...)
+func (c *immuServiceClient) ExportTxRange(ctx context.Context, in *TxRangeRequest, opts ...grpc.CallOption) (ImmuService_ExportTxRangeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ImmuService_serviceDesc.Streams[10], "/immudb.schema.ImmuService/exportTxRange", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	x := &immuServiceExportTxRangeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func (c *immuServiceClient) Logout(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/Logout", in, out, opts...)
+type ImmuService_ExportTxRangeClient interface {
+	Recv() (*Chunk, error)
+	grpc.ClientStream
+}
+
+type immuServiceExportTxRangeClient struct {
+	grpc.ClientStream
+}
+
+func (x *immuServiceExportTxRangeClient) Recv() (*Chunk, error) {
+	m := new(Chunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *immuServiceClient) ApplyTxRange(ctx context.Context, opts ...grpc.CallOption) (ImmuService_ApplyTxRangeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ImmuService_serviceDesc.Streams[11], "/immudb.schema.ImmuService/applyTxRange", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	x := &immuServiceApplyTxRangeClient{stream}
+	return x, nil
+}
+
+type ImmuService_ApplyTxRangeClient interface {
+	Send(*Chunk) error
+	CloseAndRecv() (*TxRange, error)
+	grpc.ClientStream
+}
+
+type immuServiceApplyTxRangeClient struct {
+	grpc.ClientStream
+}
+
+func (x *immuServiceApplyTxRangeClient) Send(m *Chunk) error {
+	return x.ClientStream.SendMsg(m)
 }
 
-func (c *immuServiceClient) Set(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*TxMetadata, error) {
-	out := new(TxMetadata)
-	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/Set", in, out, opts...)
-	if err != nil {
+func (x *immuServiceApplyTxRangeClient) CloseAndRecv() (*TxRange, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-func (c *immuServiceClient) VerifiableSet(ctx context.Context, in *VerifiableSetRequest, opts ...grpc.CallOption) (*VerifiableTx, error) {
-	out := new(VerifiableTx)
-	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/VerifiableSet", in, out, opts...)
-	if err != nil {
+	m := new(TxRange)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return m, nil
 }
 
-func (c *immuServiceClient) Get(ctx context.Context, in *KeyRequest, opts ...grpc.CallOption) (*Entry, error) {
-	out := new(Entry)
-	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/Get", in, out, opts...)
+func (c *immuServiceClient) UseSnapshot(ctx context.Context, in *UseSnapshotRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/UseSnapshot", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *immuServiceClient) VerifiableGet(ctx context.Context, in *VerifiableGetRequest, opts ...grpc.CallOption) (*VerifiableEntry, error) {
-	out := new(VerifiableEntry)
-	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/VerifiableGet", in, out, opts...)
+func (c *immuServiceClient) SQLExec(ctx context.Context, in *SQLExecRequest, opts ...grpc.CallOption) (*SQLExecResult, error) {
+	out := new(SQLExecResult)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/SQLExec", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *immuServiceClient) GetAll(ctx context.Context, in *KeyListRequest, opts ...grpc.CallOption) (*Entries, error) {
-	out := new(Entries)
-	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/GetAll", in, out, opts...)
+func (c *immuServiceClient) SQLBatch(ctx context.Context, in *SQLBatchRequest, opts ...grpc.CallOption) (*SQLBatchResult, error) {
+	out := new(SQLBatchResult)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/SQLBatch", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *immuServiceClient) ExecAll(ctx context.Context, in *ExecAllRequest, opts ...grpc.CallOption) (*TxMetadata, error) {
-	out := new(TxMetadata)
-	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/ExecAll", in, out, opts...)
+func (c *immuServiceClient) SQLQuery(ctx context.Context, in *SQLQueryRequest, opts ...grpc.CallOption) (*SQLQueryResult, error) {
+	out := new(SQLQueryResult)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/SQLQuery", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *immuServiceClient) Scan(ctx context.Context, in *ScanRequest, opts ...grpc.CallOption) (*Entries, error) {
-	out := new(Entries)
-	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/Scan", in, out, opts...)
+func (c *immuServiceClient) ListTables(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*SQLQueryResult, error) {
+	out := new(SQLQueryResult)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/ListTables", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *immuServiceClient) Count(ctx context.Context, in *KeyPrefix, opts ...grpc.CallOption) (*EntryCount, error) {
-	out := new(EntryCount)
-	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/Count", in, out, opts...)
+func (c *immuServiceClient) DescribeTable(ctx context.Context, in *Table, opts ...grpc.CallOption) (*SQLQueryResult, error) {
+	out := new(SQLQueryResult)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/DescribeTable", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *immuServiceClient) CountAll(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*EntryCount, error) {
-	out := new(EntryCount)
-	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/CountAll", in, out, opts...)
+func (c *immuServiceClient) VerifiableSQLGet(ctx context.Context, in *VerifiableSQLGetRequest, opts ...grpc.CallOption) (*VerifiableSQLEntry, error) {
+	out := new(VerifiableSQLEntry)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/VerifiableSQLGet", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *immuServiceClient) TxById(ctx context.Context, in *TxRequest, opts ...grpc.CallOption) (*Tx, error) {
-	out := new(Tx)
-	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/TxById", in, out, opts...)
+func (c *immuServiceClient) VerifiableSQLQuery(ctx context.Context, in *VerifiableSQLQueryRequest, opts ...grpc.CallOption) (*VerifiableSQLQueryResult, error) {
+	out := new(VerifiableSQLQueryResult)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/VerifiableSQLQuery", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *immuServiceClient) VerifiableTxById(ctx context.Context, in *VerifiableTxRequest, opts ...grpc.CallOption) (*VerifiableTx, error) {
-	out := new(VerifiableTx)
-	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/VerifiableTxById", in, out, opts...)
+func (c *immuServiceClient) ExportSQLSchema(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*SQLSchema, error) {
+	out := new(SQLSchema)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/ExportSQLSchema", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *immuServiceClient) TxScan(ctx context.Context, in *TxScanRequest, opts ...grpc.CallOption) (*TxList, error) {
-	out := new(TxList)
-	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/TxScan", in, out, opts...)
+func (c *immuServiceClient) ImportSQLSchema(ctx context.Context, in *SQLSchema, opts ...grpc.CallOption) (*SQLExecResult, error) {
+	out := new(SQLExecResult)
+	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/ImportSQLSchema", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *immuServiceClient) History(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*Entries, error) {
-	out := new(Entries)
-	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/History", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+// ImmuServiceServer is the server API for ImmuService service.
+type ImmuServiceServer interface {
+	ListUsers(context.Context, *empty.Empty) (*UserList, error)
+	CreateUser(context.Context, *CreateUserRequest) (*empty.Empty, error)
+	ChangePassword(context.Context, *ChangePasswordRequest) (*empty.Empty, error)
+	ExportUsers(context.Context, *empty.Empty) (*UserList, error)
+	ImportUsers(context.Context, *UserList) (*ImportUsersResponse, error)
+	ResetPassword(context.Context, *ResetPasswordRequest) (*empty.Empty, error)
+	UpdateAuthConfig(context.Context, *AuthConfig) (*empty.Empty, error)
+	UpdateMTLSConfig(context.Context, *MTLSConfig) (*empty.Empty, error)
+	Login(context.Context, *LoginRequest) (*LoginResponse, error)
+	Logout(context.Context, *empty.Empty) (*empty.Empty, error)
+	Set(context.Context, *SetRequest) (*TxMetadata, error)
+	VerifiableSet(context.Context, *VerifiableSetRequest) (*VerifiableTx, error)
+	Get(context.Context, *KeyRequest) (*Entry, error)
+	VerifiableGet(context.Context, *VerifiableGetRequest) (*VerifiableEntry, error)
+	VerifiableHistoricalGet(context.Context, *VerifiableHistoricalGetRequest) (*VerifiableEntry, error)
+	GetAll(context.Context, *KeyListRequest) (*Entries, error)
+	ExecAll(context.Context, *ExecAllRequest) (*TxMetadata, error)
+	ExecMultiDbAll(context.Context, *ExecMultiDbAllRequest) (*ExecMultiDbAllResponse, error)
+	Scan(context.Context, *ScanRequest) (*Entries, error)
+	IndexScan(context.Context, *IndexScanRequest) (*Entries, error)
+	Count(context.Context, *KeyPrefix) (*EntryCount, error)
+	CountAll(context.Context, *empty.Empty) (*EntryCount, error)
+	TxById(context.Context, *TxRequest) (*Tx, error)
+	VerifiableTxById(context.Context, *VerifiableTxRequest) (*VerifiableTx, error)
+	TxScan(context.Context, *TxScanRequest) (*TxList, error)
+	// Subscribe streams committed transactions starting from initialTx, waiting for new ones to be committed
+	Subscribe(*SubscribeRequest, ImmuService_SubscribeServer) error
+	History(context.Context, *HistoryRequest) (*Entries, error)
+	Health(context.Context, *empty.Empty) (*HealthResponse, error)
+	ServerInfo(context.Context, *ServerInfoRequest) (*ServerInfoResponse, error)
+	CurrentState(context.Context, *empty.Empty) (*ImmutableState, error)
+	SetReference(context.Context, *ReferenceRequest) (*TxMetadata, error)
+	VerifiableSetReference(context.Context, *VerifiableReferenceRequest) (*VerifiableTx, error)
+	ZAdd(context.Context, *ZAddRequest) (*TxMetadata, error)
+	VerifiableZAdd(context.Context, *VerifiableZAddRequest) (*VerifiableTx, error)
+	ZScan(context.Context, *ZScanRequest) (*ZEntries, error)
+	TSAppend(context.Context, *TSAppendRequest) (*TxMetadata, error)
+	TSRange(context.Context, *TSRangeRequest) (*TSSamples, error)
+	Notarize(context.Context, *NotarizeRequest) (*NotarizationReceipts, error)
+	VerifyNotarizationReceipt(context.Context, *NotarizationReceipt) (*NotarizationVerification, error)
+	CreateCollection(context.Context, *Collection) (*TxMetadata, error)
+	ListCollections(context.Context, *empty.Empty) (*CollectionList, error)
+	InsertDocuments(context.Context, *DocumentInsertRequest) (*DocumentInsertResponse, error)
+	GetDocument(context.Context, *DocumentGetRequest) (*Document, error)
+	SearchDocuments(context.Context, *DocumentSearchRequest) (*DocumentList, error)
+	CreateDatabase(context.Context, *Database) (*empty.Empty, error)
+	CreateDatabaseWith(context.Context, *DatabaseSettings) (*empty.Empty, error)
+	UpdateDatabase(context.Context, *DatabaseSettings) (*empty.Empty, error)
+	GetDatabaseSettings(context.Context, *empty.Empty) (*DatabaseSettings, error)
+	DatabaseList(context.Context, *empty.Empty) (*DatabaseListResponse, error)
+	DeleteDatabase(context.Context, *Database) (*empty.Empty, error)
+	UndeleteDatabase(context.Context, *Database) (*empty.Empty, error)
+	DeletedDatabaseList(context.Context, *empty.Empty) (*DeletedDatabaseListResponse, error)
+	ShrinkDatabase(context.Context, *Database) (*empty.Empty, error)
+	RotateEncryptionKeys(context.Context, *empty.Empty) (*RotateEncryptionKeysResponse, error)
+	// lists the files opened by the given database, or by all the loaded databases when no name is given
+	OpenedFiles(context.Context, *Database) (*OpenedFilesResponse, error)
+	// closes the files kept open for reading by the given database, or by all the loaded databases when no name is given
+	CloseCachedFiles(context.Context, *Database) (*CloseCachedFilesResponse, error)
+	// cancels the in-flight requests sent by the given application
+	CancelApplicationRequests(context.Context, *CancelApplicationRequestsRequest) (*CancelApplicationRequestsResponse, error)
+	UseDatabase(context.Context, *Database) (*UseDatabaseReply, error)
+	CleanIndex(context.Context, *empty.Empty) (*empty.Empty, error)
+	ChangePermission(context.Context, *ChangePermissionRequest) (*empty.Empty, error)
+	SetActiveUser(context.Context, *SetActiveUserRequest) (*empty.Empty, error)
+	// Streams
+	StreamGet(*KeyRequest, ImmuService_StreamGetServer) error
+	StreamSet(ImmuService_StreamSetServer) error
+	StreamVerifiableGet(*VerifiableGetRequest, ImmuService_StreamVerifiableGetServer) error
+	StreamVerifiableSet(ImmuService_StreamVerifiableSetServer) error
+	StreamScan(*ScanRequest, ImmuService_StreamScanServer) error
+	StreamZScan(*ZScanRequest, ImmuService_StreamZScanServer) error
+	StreamHistory(*HistoryRequest, ImmuService_StreamHistoryServer) error
+	StreamExecAll(ImmuService_StreamExecAllServer) error
+	// Replication
+	ExportTx(*TxRequest, ImmuService_ExportTxServer) error
+	// Incremental backups
+	ExportTxRange(*TxRangeRequest, ImmuService_ExportTxRangeServer) error
+	ApplyTxRange(ImmuService_ApplyTxRangeServer) error
+	// SQL
+	UseSnapshot(context.Context, *UseSnapshotRequest) (*empty.Empty, error)
+	SQLExec(context.Context, *SQLExecRequest) (*SQLExecResult, error)
+	SQLBatch(context.Context, *SQLBatchRequest) (*SQLBatchResult, error)
+	SQLQuery(context.Context, *SQLQueryRequest) (*SQLQueryResult, error)
+	ListTables(context.Context, *empty.Empty) (*SQLQueryResult, error)
+	DescribeTable(context.Context, *Table) (*SQLQueryResult, error)
+	VerifiableSQLGet(context.Context, *VerifiableSQLGetRequest) (*VerifiableSQLEntry, error)
+	VerifiableSQLQuery(context.Context, *VerifiableSQLQueryRequest) (*VerifiableSQLQueryResult, error)
+	ExportSQLSchema(context.Context, *empty.Empty) (*SQLSchema, error)
+	ImportSQLSchema(context.Context, *SQLSchema) (*SQLExecResult, error)
+}
+
+// UnimplementedImmuServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedImmuServiceServer struct {
+}
+
+func (*UnimplementedImmuServiceServer) ListUsers(context.Context, *empty.Empty) (*UserList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUsers not implemented")
+}
+func (*UnimplementedImmuServiceServer) CreateUser(context.Context, *CreateUserRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUser not implemented")
+}
+func (*UnimplementedImmuServiceServer) ChangePassword(context.Context, *ChangePasswordRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangePassword not implemented")
+}
+func (*UnimplementedImmuServiceServer) ExportUsers(context.Context, *empty.Empty) (*UserList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportUsers not implemented")
+}
+func (*UnimplementedImmuServiceServer) ImportUsers(context.Context, *UserList) (*ImportUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportUsers not implemented")
+}
+func (*UnimplementedImmuServiceServer) ResetPassword(context.Context, *ResetPasswordRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetPassword not implemented")
+}
+func (*UnimplementedImmuServiceServer) UpdateAuthConfig(context.Context, *AuthConfig) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateAuthConfig not implemented")
+}
+func (*UnimplementedImmuServiceServer) UpdateMTLSConfig(context.Context, *MTLSConfig) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateMTLSConfig not implemented")
+}
+func (*UnimplementedImmuServiceServer) Login(context.Context, *LoginRequest) (*LoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
+}
+func (*UnimplementedImmuServiceServer) Logout(context.Context, *empty.Empty) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
+}
+func (*UnimplementedImmuServiceServer) Set(context.Context, *SetRequest) (*TxMetadata, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Set not implemented")
+}
+func (*UnimplementedImmuServiceServer) VerifiableSet(context.Context, *VerifiableSetRequest) (*VerifiableTx, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifiableSet not implemented")
+}
+func (*UnimplementedImmuServiceServer) Get(context.Context, *KeyRequest) (*Entry, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (*UnimplementedImmuServiceServer) VerifiableGet(context.Context, *VerifiableGetRequest) (*VerifiableEntry, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifiableGet not implemented")
+}
+func (*UnimplementedImmuServiceServer) VerifiableHistoricalGet(context.Context, *VerifiableHistoricalGetRequest) (*VerifiableEntry, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifiableHistoricalGet not implemented")
+}
+func (*UnimplementedImmuServiceServer) GetAll(context.Context, *KeyListRequest) (*Entries, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAll not implemented")
+}
+func (*UnimplementedImmuServiceServer) ExecAll(context.Context, *ExecAllRequest) (*TxMetadata, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExecAll not implemented")
+}
+func (*UnimplementedImmuServiceServer) ExecMultiDbAll(context.Context, *ExecMultiDbAllRequest) (*ExecMultiDbAllResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExecMultiDbAll not implemented")
+}
+func (*UnimplementedImmuServiceServer) Scan(context.Context, *ScanRequest) (*Entries, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Scan not implemented")
+}
+func (*UnimplementedImmuServiceServer) IndexScan(context.Context, *IndexScanRequest) (*Entries, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IndexScan not implemented")
+}
+func (*UnimplementedImmuServiceServer) Count(context.Context, *KeyPrefix) (*EntryCount, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Count not implemented")
+}
+func (*UnimplementedImmuServiceServer) CountAll(context.Context, *empty.Empty) (*EntryCount, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CountAll not implemented")
+}
+func (*UnimplementedImmuServiceServer) TxById(context.Context, *TxRequest) (*Tx, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TxById not implemented")
+}
+func (*UnimplementedImmuServiceServer) VerifiableTxById(context.Context, *VerifiableTxRequest) (*VerifiableTx, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifiableTxById not implemented")
+}
+func (*UnimplementedImmuServiceServer) TxScan(context.Context, *TxScanRequest) (*TxList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TxScan not implemented")
+}
+func (*UnimplementedImmuServiceServer) Subscribe(*SubscribeRequest, ImmuService_SubscribeServer) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+func (*UnimplementedImmuServiceServer) History(context.Context, *HistoryRequest) (*Entries, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method History not implemented")
+}
+func (*UnimplementedImmuServiceServer) Health(context.Context, *empty.Empty) (*HealthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Health not implemented")
+}
+func (*UnimplementedImmuServiceServer) ServerInfo(context.Context, *ServerInfoRequest) (*ServerInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ServerInfo not implemented")
+}
+func (*UnimplementedImmuServiceServer) CurrentState(context.Context, *empty.Empty) (*ImmutableState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CurrentState not implemented")
+}
+func (*UnimplementedImmuServiceServer) SetReference(context.Context, *ReferenceRequest) (*TxMetadata, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetReference not implemented")
+}
+func (*UnimplementedImmuServiceServer) VerifiableSetReference(context.Context, *VerifiableReferenceRequest) (*VerifiableTx, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifiableSetReference not implemented")
+}
+func (*UnimplementedImmuServiceServer) ZAdd(context.Context, *ZAddRequest) (*TxMetadata, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ZAdd not implemented")
+}
+func (*UnimplementedImmuServiceServer) VerifiableZAdd(context.Context, *VerifiableZAddRequest) (*VerifiableTx, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifiableZAdd not implemented")
+}
+func (*UnimplementedImmuServiceServer) ZScan(context.Context, *ZScanRequest) (*ZEntries, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ZScan not implemented")
+}
+func (*UnimplementedImmuServiceServer) TSAppend(context.Context, *TSAppendRequest) (*TxMetadata, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TSAppend not implemented")
+}
+func (*UnimplementedImmuServiceServer) TSRange(context.Context, *TSRangeRequest) (*TSSamples, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TSRange not implemented")
+}
+func (*UnimplementedImmuServiceServer) Notarize(context.Context, *NotarizeRequest) (*NotarizationReceipts, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Notarize not implemented")
+}
+func (*UnimplementedImmuServiceServer) VerifyNotarizationReceipt(context.Context, *NotarizationReceipt) (*NotarizationVerification, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyNotarizationReceipt not implemented")
+}
+func (*UnimplementedImmuServiceServer) CreateCollection(context.Context, *Collection) (*TxMetadata, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCollection not implemented")
+}
+func (*UnimplementedImmuServiceServer) ListCollections(context.Context, *empty.Empty) (*CollectionList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCollections not implemented")
+}
+func (*UnimplementedImmuServiceServer) InsertDocuments(context.Context, *DocumentInsertRequest) (*DocumentInsertResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InsertDocuments not implemented")
+}
+func (*UnimplementedImmuServiceServer) GetDocument(context.Context, *DocumentGetRequest) (*Document, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDocument not implemented")
+}
+func (*UnimplementedImmuServiceServer) SearchDocuments(context.Context, *DocumentSearchRequest) (*DocumentList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchDocuments not implemented")
+}
+func (*UnimplementedImmuServiceServer) CreateDatabase(context.Context, *Database) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateDatabase not implemented")
+}
+func (*UnimplementedImmuServiceServer) CreateDatabaseWith(context.Context, *DatabaseSettings) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateDatabaseWith not implemented")
+}
+func (*UnimplementedImmuServiceServer) UpdateDatabase(context.Context, *DatabaseSettings) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateDatabase not implemented")
+}
+func (*UnimplementedImmuServiceServer) GetDatabaseSettings(context.Context, *empty.Empty) (*DatabaseSettings, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDatabaseSettings not implemented")
+}
+func (*UnimplementedImmuServiceServer) DatabaseList(context.Context, *empty.Empty) (*DatabaseListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DatabaseList not implemented")
+}
+func (*UnimplementedImmuServiceServer) DeleteDatabase(context.Context, *Database) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteDatabase not implemented")
+}
+func (*UnimplementedImmuServiceServer) UndeleteDatabase(context.Context, *Database) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UndeleteDatabase not implemented")
+}
+func (*UnimplementedImmuServiceServer) DeletedDatabaseList(context.Context, *empty.Empty) (*DeletedDatabaseListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletedDatabaseList not implemented")
+}
+func (*UnimplementedImmuServiceServer) ShrinkDatabase(context.Context, *Database) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShrinkDatabase not implemented")
+}
+func (*UnimplementedImmuServiceServer) RotateEncryptionKeys(context.Context, *empty.Empty) (*RotateEncryptionKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateEncryptionKeys not implemented")
+}
+func (*UnimplementedImmuServiceServer) OpenedFiles(context.Context, *Database) (*OpenedFilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OpenedFiles not implemented")
+}
+func (*UnimplementedImmuServiceServer) CloseCachedFiles(context.Context, *Database) (*CloseCachedFilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloseCachedFiles not implemented")
+}
+func (*UnimplementedImmuServiceServer) CancelApplicationRequests(context.Context, *CancelApplicationRequestsRequest) (*CancelApplicationRequestsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelApplicationRequests not implemented")
+}
+func (*UnimplementedImmuServiceServer) UseDatabase(context.Context, *Database) (*UseDatabaseReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UseDatabase not implemented")
+}
+func (*UnimplementedImmuServiceServer) CleanIndex(context.Context, *empty.Empty) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CleanIndex not implemented")
+}
+func (*UnimplementedImmuServiceServer) ChangePermission(context.Context, *ChangePermissionRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangePermission not implemented")
+}
+func (*UnimplementedImmuServiceServer) SetActiveUser(context.Context, *SetActiveUserRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetActiveUser not implemented")
+}
+func (*UnimplementedImmuServiceServer) StreamGet(*KeyRequest, ImmuService_StreamGetServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamGet not implemented")
 }
-
-func (c *immuServiceClient) Health(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*HealthResponse, error) {
-	out := new(HealthResponse)
-	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/Health", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (*UnimplementedImmuServiceServer) StreamSet(ImmuService_StreamSetServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamSet not implemented")
 }
-
-func (c *immuServiceClient) CurrentState(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ImmutableState, error) {
-	out := new(ImmutableState)
-	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/CurrentState", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (*UnimplementedImmuServiceServer) StreamVerifiableGet(*VerifiableGetRequest, ImmuService_StreamVerifiableGetServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamVerifiableGet not implemented")
 }
-
-func (c *immuServiceClient) SetReference(ctx context.Context, in *ReferenceRequest, opts ...grpc.CallOption) (*TxMetadata, error) {
-	out := new(TxMetadata)
-	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/SetReference", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (*UnimplementedImmuServiceServer) StreamVerifiableSet(ImmuService_StreamVerifiableSetServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamVerifiableSet not implemented")
 }
-
-func (c *immuServiceClient) VerifiableSetReference(ctx context.Context, in *VerifiableReferenceRequest, opts ...grpc.CallOption) (*VerifiableTx, error) {
-	out := new(VerifiableTx)
-	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/VerifiableSetReference", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (*UnimplementedImmuServiceServer) StreamScan(*ScanRequest, ImmuService_StreamScanServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamScan not implemented")
 }
-
-func (c *immuServiceClient) ZAdd(ctx context.Context, in *ZAddRequest, opts ...grpc.CallOption) (*TxMetadata, error) {
-	out := new(TxMetadata)
-	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/ZAdd", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (*UnimplementedImmuServiceServer) StreamZScan(*ZScanRequest, ImmuService_StreamZScanServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamZScan not implemented")
 }
-
-func (c *immuServiceClient) VerifiableZAdd(ctx context.Context, in *VerifiableZAddRequest, opts ...grpc.CallOption) (*VerifiableTx, error) {
-	out := new(VerifiableTx)
-	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/VerifiableZAdd", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (*UnimplementedImmuServiceServer) StreamHistory(*HistoryRequest, ImmuService_StreamHistoryServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamHistory not implemented")
 }
-
-func (c *immuServiceClient) ZScan(ctx context.Context, in *ZScanRequest, opts ...grpc.CallOption) (*ZEntries, error) {
-	out := new(ZEntries)
-	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/ZScan", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (*UnimplementedImmuServiceServer) StreamExecAll(ImmuService_StreamExecAllServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamExecAll not implemented")
 }
-
-func (c *immuServiceClient) CreateDatabase(ctx context.Context, in *Database, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/CreateDatabase", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (*UnimplementedImmuServiceServer) ExportTx(*TxRequest, ImmuService_ExportTxServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportTx not implemented")
 }
-
-func (c *immuServiceClient) DatabaseList(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*DatabaseListResponse, error) {
-	out := new(DatabaseListResponse)
-	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/DatabaseList", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (*UnimplementedImmuServiceServer) ExportTxRange(*TxRangeRequest, ImmuService_ExportTxRangeServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportTxRange not implemented")
 }
-
-func (c *immuServiceClient) UseDatabase(ctx context.Context, in *Database, opts ...grpc.CallOption) (*UseDatabaseReply, error) {
-	out := new(UseDatabaseReply)
-	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/UseDatabase", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (*UnimplementedImmuServiceServer) ApplyTxRange(ImmuService_ApplyTxRangeServer) error {
+	return status.Errorf(codes.Unimplemented, "method ApplyTxRange not implemented")
 }
-
-func (c *immuServiceClient) CleanIndex(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/CleanIndex", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (*UnimplementedImmuServiceServer) UseSnapshot(context.Context, *UseSnapshotRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UseSnapshot not implemented")
 }
-
-func (c *immuServiceClient) ChangePermission(ctx context.Context, in *ChangePermissionRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/ChangePermission", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (*UnimplementedImmuServiceServer) SQLExec(context.Context, *SQLExecRequest) (*SQLExecResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SQLExec not implemented")
 }
-
-func (c *immuServiceClient) SetActiveUser(ctx context.Context, in *SetActiveUserRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/SetActiveUser", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (*UnimplementedImmuServiceServer) SQLBatch(context.Context, *SQLBatchRequest) (*SQLBatchResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SQLBatch not implemented")
 }
-
-func (c *immuServiceClient) StreamGet(ctx context.Context, in *KeyRequest, opts ...grpc.CallOption) (ImmuService_StreamGetClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_ImmuService_serviceDesc.Streams[0], "/immudb.schema.ImmuService/streamGet", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &immuServiceStreamGetClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	return x, nil
+func (*UnimplementedImmuServiceServer) SQLQuery(context.Context, *SQLQueryRequest) (*SQLQueryResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SQLQuery not implemented")
 }
-
-type ImmuService_StreamGetClient interface {
-	Recv() (*Chunk, error)
-	grpc.ClientStream
+func (*UnimplementedImmuServiceServer) ListTables(context.Context, *empty.Empty) (*SQLQueryResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTables not implemented")
 }
-
-type immuServiceStreamGetClient struct {
-	grpc.ClientStream
+func (*UnimplementedImmuServiceServer) DescribeTable(context.Context, *Table) (*SQLQueryResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DescribeTable not implemented")
 }
-
-func (x *immuServiceStreamGetClient) Recv() (*Chunk, error) {
-	m := new(Chunk)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
+func (*UnimplementedImmuServiceServer) VerifiableSQLGet(context.Context, *VerifiableSQLGetRequest) (*VerifiableSQLEntry, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifiableSQLGet not implemented")
 }
-
-func (c *immuServiceClient) StreamSet(ctx context.Context, opts ...grpc.CallOption) (ImmuService_StreamSetClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_ImmuService_serviceDesc.Streams[1], "/immudb.schema.ImmuService/streamSet", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &immuServiceStreamSetClient{stream}
-	return x, nil
+func (*UnimplementedImmuServiceServer) VerifiableSQLQuery(context.Context, *VerifiableSQLQueryRequest) (*VerifiableSQLQueryResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifiableSQLQuery not implemented")
 }
-
-type ImmuService_StreamSetClient interface {
-	Send(*Chunk) error
-	CloseAndRecv() (*TxMetadata, error)
-	grpc.ClientStream
+func (*UnimplementedImmuServiceServer) ExportSQLSchema(context.Context, *empty.Empty) (*SQLSchema, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportSQLSchema not implemented")
 }
-
-type immuServiceStreamSetClient struct {
-	grpc.ClientStream
+func (*UnimplementedImmuServiceServer) ImportSQLSchema(context.Context, *SQLSchema) (*SQLExecResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportSQLSchema not implemented")
 }
 
-func (x *immuServiceStreamSetClient) Send(m *Chunk) error {
-	return x.ClientStream.SendMsg(m)
+func RegisterImmuServiceServer(s *grpc.Server, srv ImmuServiceServer) {
+	s.RegisterService(&_ImmuService_serviceDesc, srv)
 }
 
-func (x *immuServiceStreamSetClient) CloseAndRecv() (*TxMetadata, error) {
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	m := new(TxMetadata)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
+func _ImmuService_ListUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return m, nil
-}
-
-func (c *immuServiceClient) StreamVerifiableGet(ctx context.Context, in *VerifiableGetRequest, opts ...grpc.CallOption) (ImmuService_StreamVerifiableGetClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_ImmuService_serviceDesc.Streams[2], "/immudb.schema.ImmuService/streamVerifiableGet", opts...)
-	if err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).ListUsers(ctx, in)
 	}
-	x := &immuServiceStreamVerifiableGetClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/ListUsers",
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).ListUsers(ctx, req.(*empty.Empty))
 	}
-	return x, nil
-}
-
-type ImmuService_StreamVerifiableGetClient interface {
-	Recv() (*Chunk, error)
-	grpc.ClientStream
-}
-
-type immuServiceStreamVerifiableGetClient struct {
-	grpc.ClientStream
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *immuServiceStreamVerifiableGetClient) Recv() (*Chunk, error) {
-	m := new(Chunk)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
+func _ImmuService_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUserRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return m, nil
-}
-
-func (c *immuServiceClient) StreamVerifiableSet(ctx context.Context, opts ...grpc.CallOption) (ImmuService_StreamVerifiableSetClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_ImmuService_serviceDesc.Streams[3], "/immudb.schema.ImmuService/streamVerifiableSet", opts...)
-	if err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).CreateUser(ctx, in)
 	}
-	x := &immuServiceStreamVerifiableSetClient{stream}
-	return x, nil
-}
-
-type ImmuService_StreamVerifiableSetClient interface {
-	Send(*Chunk) error
-	CloseAndRecv() (*VerifiableTx, error)
-	grpc.ClientStream
-}
-
-type immuServiceStreamVerifiableSetClient struct {
-	grpc.ClientStream
-}
-
-func (x *immuServiceStreamVerifiableSetClient) Send(m *Chunk) error {
-	return x.ClientStream.SendMsg(m)
-}
-
-func (x *immuServiceStreamVerifiableSetClient) CloseAndRecv() (*VerifiableTx, error) {
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/CreateUser",
 	}
-	m := new(VerifiableTx)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).CreateUser(ctx, req.(*CreateUserRequest))
 	}
-	return m, nil
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *immuServiceClient) StreamScan(ctx context.Context, in *ScanRequest, opts ...grpc.CallOption) (ImmuService_StreamScanClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_ImmuService_serviceDesc.Streams[4], "/immudb.schema.ImmuService/streamScan", opts...)
-	if err != nil {
+func _ImmuService_ChangePassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangePasswordRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	x := &immuServiceStreamScanClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).ChangePassword(ctx, in)
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/ChangePassword",
 	}
-	return x, nil
-}
-
-type ImmuService_StreamScanClient interface {
-	Recv() (*Chunk, error)
-	grpc.ClientStream
-}
-
-type immuServiceStreamScanClient struct {
-	grpc.ClientStream
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).ChangePassword(ctx, req.(*ChangePasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *immuServiceStreamScanClient) Recv() (*Chunk, error) {
-	m := new(Chunk)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
+func _ImmuService_ExportUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return m, nil
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).ExportUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/ExportUsers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).ExportUsers(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *immuServiceClient) StreamZScan(ctx context.Context, in *ZScanRequest, opts ...grpc.CallOption) (ImmuService_StreamZScanClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_ImmuService_serviceDesc.Streams[5], "/immudb.schema.ImmuService/streamZScan", opts...)
-	if err != nil {
+func _ImmuService_ImportUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserList)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	x := &immuServiceStreamZScanClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).ImportUsers(ctx, in)
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/ImportUsers",
 	}
-	return x, nil
-}
-
-type ImmuService_StreamZScanClient interface {
-	Recv() (*Chunk, error)
-	grpc.ClientStream
-}
-
-type immuServiceStreamZScanClient struct {
-	grpc.ClientStream
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).ImportUsers(ctx, req.(*UserList))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *immuServiceStreamZScanClient) Recv() (*Chunk, error) {
-	m := new(Chunk)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
+func _ImmuService_ResetPassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetPasswordRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return m, nil
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).ResetPassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/ResetPassword",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).ResetPassword(ctx, req.(*ResetPasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *immuServiceClient) StreamHistory(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (ImmuService_StreamHistoryClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_ImmuService_serviceDesc.Streams[6], "/immudb.schema.ImmuService/streamHistory", opts...)
-	if err != nil {
+func _ImmuService_UpdateAuthConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthConfig)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	x := &immuServiceStreamHistoryClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).UpdateAuthConfig(ctx, in)
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/UpdateAuthConfig",
 	}
-	return x, nil
-}
-
-type ImmuService_StreamHistoryClient interface {
-	Recv() (*Chunk, error)
-	grpc.ClientStream
-}
-
-type immuServiceStreamHistoryClient struct {
-	grpc.ClientStream
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).UpdateAuthConfig(ctx, req.(*AuthConfig))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *immuServiceStreamHistoryClient) Recv() (*Chunk, error) {
-	m := new(Chunk)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
+func _ImmuService_UpdateMTLSConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MTLSConfig)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return m, nil
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).UpdateMTLSConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/UpdateMTLSConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).UpdateMTLSConfig(ctx, req.(*MTLSConfig))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *immuServiceClient) StreamExecAll(ctx context.Context, opts ...grpc.CallOption) (ImmuService_StreamExecAllClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_ImmuService_serviceDesc.Streams[7], "/immudb.schema.ImmuService/streamExecAll", opts...)
-	if err != nil {
+func _ImmuService_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	x := &immuServiceStreamExecAllClient{stream}
-	return x, nil
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).Login(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/Login",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).Login(ctx, req.(*LoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type ImmuService_StreamExecAllClient interface {
-	Send(*Chunk) error
-	CloseAndRecv() (*TxMetadata, error)
-	grpc.ClientStream
+func _ImmuService_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).Logout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/Logout",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).Logout(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type immuServiceStreamExecAllClient struct {
-	grpc.ClientStream
+func _ImmuService_Set_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).Set(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/Set",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).Set(ctx, req.(*SetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *immuServiceStreamExecAllClient) Send(m *Chunk) error {
-	return x.ClientStream.SendMsg(m)
+func _ImmuService_VerifiableSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifiableSetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).VerifiableSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/VerifiableSet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).VerifiableSet(ctx, req.(*VerifiableSetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *immuServiceStreamExecAllClient) CloseAndRecv() (*TxMetadata, error) {
-	if err := x.ClientStream.CloseSend(); err != nil {
+func _ImmuService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeyRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	m := new(TxMetadata)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).Get(ctx, in)
 	}
-	return m, nil
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).Get(ctx, req.(*KeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *immuServiceClient) UseSnapshot(ctx context.Context, in *UseSnapshotRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/UseSnapshot", in, out, opts...)
-	if err != nil {
+func _ImmuService_VerifiableGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifiableGetRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).VerifiableGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/VerifiableGet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).VerifiableGet(ctx, req.(*VerifiableGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *immuServiceClient) SQLExec(ctx context.Context, in *SQLExecRequest, opts ...grpc.CallOption) (*SQLExecResult, error) {
-	out := new(SQLExecResult)
-	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/SQLExec", in, out, opts...)
-	if err != nil {
+func _ImmuService_VerifiableHistoricalGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifiableHistoricalGetRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).VerifiableHistoricalGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/VerifiableHistoricalGet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).VerifiableHistoricalGet(ctx, req.(*VerifiableHistoricalGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *immuServiceClient) SQLQuery(ctx context.Context, in *SQLQueryRequest, opts ...grpc.CallOption) (*SQLQueryResult, error) {
-	out := new(SQLQueryResult)
-	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/SQLQuery", in, out, opts...)
-	if err != nil {
+func _ImmuService_GetAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeyListRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).GetAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/GetAll",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).GetAll(ctx, req.(*KeyListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *immuServiceClient) ListTables(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*SQLQueryResult, error) {
-	out := new(SQLQueryResult)
-	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/ListTables", in, out, opts...)
-	if err != nil {
+func _ImmuService_ExecAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecAllRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).ExecAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/ExecAll",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).ExecAll(ctx, req.(*ExecAllRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *immuServiceClient) DescribeTable(ctx context.Context, in *Table, opts ...grpc.CallOption) (*SQLQueryResult, error) {
-	out := new(SQLQueryResult)
-	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/DescribeTable", in, out, opts...)
-	if err != nil {
+func _ImmuService_ExecMultiDbAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecMultiDbAllRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).ExecMultiDbAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/ExecMultiDbAll",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).ExecMultiDbAll(ctx, req.(*ExecMultiDbAllRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *immuServiceClient) VerifiableSQLGet(ctx context.Context, in *VerifiableSQLGetRequest, opts ...grpc.CallOption) (*VerifiableSQLEntry, error) {
-	out := new(VerifiableSQLEntry)
-	err := c.cc.Invoke(ctx, "/immudb.schema.ImmuService/VerifiableSQLGet", in, out, opts...)
-	if err != nil {
+func _ImmuService_Scan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScanRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-// ImmuServiceServer is the server API for ImmuService service.
-type ImmuServiceServer interface {
-	ListUsers(context.Context, *empty.Empty) (*UserList, error)
-	CreateUser(context.Context, *CreateUserRequest) (*empty.Empty, error)
-	ChangePassword(context.Context, *ChangePasswordRequest) (*empty.Empty, error)
-	UpdateAuthConfig(context.Context, *AuthConfig) (*empty.Empty, error)
-	UpdateMTLSConfig(context.Context, *MTLSConfig) (*empty.Empty, error)
-	Login(context.Context, *LoginRequest) (*LoginResponse, error)
-	Logout(context.Context, *empty.Empty) (*empty.Empty, error)
-	Set(context.Context, *SetRequest) (*TxMetadata, error)
-	VerifiableSet(context.Context, *VerifiableSetRequest) (*VerifiableTx, error)
-	Get(context.Context, *KeyRequest) (*Entry, error)
-	VerifiableGet(context.Context, *VerifiableGetRequest) (*VerifiableEntry, error)
-	GetAll(context.Context, *KeyListRequest) (*Entries, error)
-	ExecAll(context.Context, *ExecAllRequest) (*TxMetadata, error)
-	Scan(context.Context, *ScanRequest) (*Entries, error)
-	Count(context.Context, *KeyPrefix) (*EntryCount, error)
-	CountAll(context.Context, *empty.Empty) (*EntryCount, error)
-	TxById(context.Context, *TxRequest) (*Tx, error)
-	VerifiableTxById(context.Context, *VerifiableTxRequest) (*VerifiableTx, error)
-	TxScan(context.Context, *TxScanRequest) (*TxList, error)
-	History(context.Context, *HistoryRequest) (*Entries, error)
-	Health(context.Context, *empty.Empty) (*HealthResponse, error)
-	CurrentState(context.Context, *empty.Empty) (*ImmutableState, error)
-	SetReference(context.Context, *ReferenceRequest) (*TxMetadata, error)
-	VerifiableSetReference(context.Context, *VerifiableReferenceRequest) (*VerifiableTx, error)
-	ZAdd(context.Context, *ZAddRequest) (*TxMetadata, error)
-	VerifiableZAdd(context.Context, *VerifiableZAddRequest) (*VerifiableTx, error)
-	ZScan(context.Context, *ZScanRequest) (*ZEntries, error)
-	CreateDatabase(context.Context, *Database) (*empty.Empty, error)
-	DatabaseList(context.Context, *empty.Empty) (*DatabaseListResponse, error)
-	UseDatabase(context.Context, *Database) (*UseDatabaseReply, error)
-	CleanIndex(context.Context, *empty.Empty) (*empty.Empty, error)
-	ChangePermission(context.Context, *ChangePermissionRequest) (*empty.Empty, error)
-	SetActiveUser(context.Context, *SetActiveUserRequest) (*empty.Empty, error)
-	// Streams
-	StreamGet(*KeyRequest, ImmuService_StreamGetServer) error
-	StreamSet(ImmuService_StreamSetServer) error
-	StreamVerifiableGet(*VerifiableGetRequest, ImmuService_StreamVerifiableGetServer) error
-	StreamVerifiableSet(ImmuService_StreamVerifiableSetServer) error
-	StreamScan(*ScanRequest, ImmuService_StreamScanServer) error
-	StreamZScan(*ZScanRequest, ImmuService_StreamZScanServer) error
-	StreamHistory(*HistoryRequest, ImmuService_StreamHistoryServer) error
-	StreamExecAll(ImmuService_StreamExecAllServer) error
-	// SQL
-	UseSnapshot(context.Context, *UseSnapshotRequest) (*empty.Empty, error)
-	SQLExec(context.Context, *SQLExecRequest) (*SQLExecResult, error)
-	SQLQuery(context.Context, *SQLQueryRequest) (*SQLQueryResult, error)
-	ListTables(context.Context, *empty.Empty) (*SQLQueryResult, error)
-	DescribeTable(context.Context, *Table) (*SQLQueryResult, error)
-	VerifiableSQLGet(context.Context, *VerifiableSQLGetRequest) (*VerifiableSQLEntry, error)
-}
-
-// UnimplementedImmuServiceServer can be embedded to have forward compatible implementations.
-type UnimplementedImmuServiceServer struct {
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).Scan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/Scan",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).Scan(ctx, req.(*ScanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (*UnimplementedImmuServiceServer) ListUsers(context.Context, *empty.Empty) (*UserList, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListUsers not implemented")
-}
-func (*UnimplementedImmuServiceServer) CreateUser(context.Context, *CreateUserRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateUser not implemented")
-}
-func (*UnimplementedImmuServiceServer) ChangePassword(context.Context, *ChangePasswordRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ChangePassword not implemented")
-}
-func (*UnimplementedImmuServiceServer) UpdateAuthConfig(context.Context, *AuthConfig) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateAuthConfig not implemented")
-}
-func (*UnimplementedImmuServiceServer) UpdateMTLSConfig(context.Context, *MTLSConfig) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateMTLSConfig not implemented")
-}
-func (*UnimplementedImmuServiceServer) Login(context.Context, *LoginRequest) (*LoginResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
-}
-func (*UnimplementedImmuServiceServer) Logout(context.Context, *empty.Empty) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
-}
-func (*UnimplementedImmuServiceServer) Set(context.Context, *SetRequest) (*TxMetadata, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Set not implemented")
-}
-func (*UnimplementedImmuServiceServer) VerifiableSet(context.Context, *VerifiableSetRequest) (*VerifiableTx, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method VerifiableSet not implemented")
-}
-func (*UnimplementedImmuServiceServer) Get(context.Context, *KeyRequest) (*Entry, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
-}
-func (*UnimplementedImmuServiceServer) VerifiableGet(context.Context, *VerifiableGetRequest) (*VerifiableEntry, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method VerifiableGet not implemented")
-}
-func (*UnimplementedImmuServiceServer) GetAll(context.Context, *KeyListRequest) (*Entries, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetAll not implemented")
-}
-func (*UnimplementedImmuServiceServer) ExecAll(context.Context, *ExecAllRequest) (*TxMetadata, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ExecAll not implemented")
-}
-func (*UnimplementedImmuServiceServer) Scan(context.Context, *ScanRequest) (*Entries, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Scan not implemented")
-}
-func (*UnimplementedImmuServiceServer) Count(context.Context, *KeyPrefix) (*EntryCount, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Count not implemented")
-}
-func (*UnimplementedImmuServiceServer) CountAll(context.Context, *empty.Empty) (*EntryCount, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CountAll not implemented")
-}
-func (*UnimplementedImmuServiceServer) TxById(context.Context, *TxRequest) (*Tx, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method TxById not implemented")
-}
-func (*UnimplementedImmuServiceServer) VerifiableTxById(context.Context, *VerifiableTxRequest) (*VerifiableTx, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method VerifiableTxById not implemented")
-}
-func (*UnimplementedImmuServiceServer) TxScan(context.Context, *TxScanRequest) (*TxList, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method TxScan not implemented")
-}
-func (*UnimplementedImmuServiceServer) History(context.Context, *HistoryRequest) (*Entries, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method History not implemented")
-}
-func (*UnimplementedImmuServiceServer) Health(context.Context, *empty.Empty) (*HealthResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Health not implemented")
-}
-func (*UnimplementedImmuServiceServer) CurrentState(context.Context, *empty.Empty) (*ImmutableState, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CurrentState not implemented")
-}
-func (*UnimplementedImmuServiceServer) SetReference(context.Context, *ReferenceRequest) (*TxMetadata, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SetReference not implemented")
-}
-func (*UnimplementedImmuServiceServer) VerifiableSetReference(context.Context, *VerifiableReferenceRequest) (*VerifiableTx, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method VerifiableSetReference not implemented")
-}
-func (*UnimplementedImmuServiceServer) ZAdd(context.Context, *ZAddRequest) (*TxMetadata, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ZAdd not implemented")
-}
-func (*UnimplementedImmuServiceServer) VerifiableZAdd(context.Context, *VerifiableZAddRequest) (*VerifiableTx, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method VerifiableZAdd not implemented")
-}
-func (*UnimplementedImmuServiceServer) ZScan(context.Context, *ZScanRequest) (*ZEntries, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ZScan not implemented")
-}
-func (*UnimplementedImmuServiceServer) CreateDatabase(context.Context, *Database) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateDatabase not implemented")
-}
-func (*UnimplementedImmuServiceServer) DatabaseList(context.Context, *empty.Empty) (*DatabaseListResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DatabaseList not implemented")
-}
-func (*UnimplementedImmuServiceServer) UseDatabase(context.Context, *Database) (*UseDatabaseReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UseDatabase not implemented")
-}
-func (*UnimplementedImmuServiceServer) CleanIndex(context.Context, *empty.Empty) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CleanIndex not implemented")
-}
-func (*UnimplementedImmuServiceServer) ChangePermission(context.Context, *ChangePermissionRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ChangePermission not implemented")
-}
-func (*UnimplementedImmuServiceServer) SetActiveUser(context.Context, *SetActiveUserRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SetActiveUser not implemented")
-}
-func (*UnimplementedImmuServiceServer) StreamGet(*KeyRequest, ImmuService_StreamGetServer) error {
-	return status.Errorf(codes.Unimplemented, "method StreamGet not implemented")
-}
-func (*UnimplementedImmuServiceServer) StreamSet(ImmuService_StreamSetServer) error {
-	return status.Errorf(codes.Unimplemented, "method StreamSet not implemented")
-}
-func (*UnimplementedImmuServiceServer) StreamVerifiableGet(*VerifiableGetRequest, ImmuService_StreamVerifiableGetServer) error {
-	return status.Errorf(codes.Unimplemented, "method StreamVerifiableGet not implemented")
-}
-func (*UnimplementedImmuServiceServer) StreamVerifiableSet(ImmuService_StreamVerifiableSetServer) error {
-	return status.Errorf(codes.Unimplemented, "method StreamVerifiableSet not implemented")
-}
-func (*UnimplementedImmuServiceServer) StreamScan(*ScanRequest, ImmuService_StreamScanServer) error {
-	return status.Errorf(codes.Unimplemented, "method StreamScan not implemented")
-}
-func (*UnimplementedImmuServiceServer) StreamZScan(*ZScanRequest, ImmuService_StreamZScanServer) error {
-	return status.Errorf(codes.Unimplemented, "method StreamZScan not implemented")
+func _ImmuService_IndexScan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IndexScanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).IndexScan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/IndexScan",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).IndexScan(ctx, req.(*IndexScanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (*UnimplementedImmuServiceServer) StreamHistory(*HistoryRequest, ImmuService_StreamHistoryServer) error {
-	return status.Errorf(codes.Unimplemented, "method StreamHistory not implemented")
+
+func _ImmuService_Count_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeyPrefix)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).Count(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/Count",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).Count(ctx, req.(*KeyPrefix))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (*UnimplementedImmuServiceServer) StreamExecAll(ImmuService_StreamExecAllServer) error {
-	return status.Errorf(codes.Unimplemented, "method StreamExecAll not implemented")
+
+func _ImmuService_CountAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).CountAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/CountAll",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).CountAll(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (*UnimplementedImmuServiceServer) UseSnapshot(context.Context, *UseSnapshotRequest) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UseSnapshot not implemented")
+
+func _ImmuService_TxById_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).TxById(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/TxById",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).TxById(ctx, req.(*TxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (*UnimplementedImmuServiceServer) SQLExec(context.Context, *SQLExecRequest) (*SQLExecResult, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SQLExec not implemented")
+
+func _ImmuService_VerifiableTxById_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifiableTxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).VerifiableTxById(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/VerifiableTxById",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).VerifiableTxById(ctx, req.(*VerifiableTxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (*UnimplementedImmuServiceServer) SQLQuery(context.Context, *SQLQueryRequest) (*SQLQueryResult, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SQLQuery not implemented")
+
+func _ImmuService_TxScan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxScanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).TxScan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/TxScan",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).TxScan(ctx, req.(*TxScanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (*UnimplementedImmuServiceServer) ListTables(context.Context, *empty.Empty) (*SQLQueryResult, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListTables not implemented")
+
+func _ImmuService_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ImmuServiceServer).Subscribe(m, &immuServiceSubscribeServer{stream})
 }
-func (*UnimplementedImmuServiceServer) DescribeTable(context.Context, *Table) (*SQLQueryResult, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DescribeTable not implemented")
+
+type ImmuService_SubscribeServer interface {
+	Send(*Tx) error
+	grpc.ServerStream
 }
-func (*UnimplementedImmuServiceServer) VerifiableSQLGet(context.Context, *VerifiableSQLGetRequest) (*VerifiableSQLEntry, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method VerifiableSQLGet not implemented")
+
+type immuServiceSubscribeServer struct {
+	grpc.ServerStream
 }
 
-func RegisterImmuServiceServer(s *grpc.Server, srv ImmuServiceServer) {
-	s.RegisterService(&_ImmuService_serviceDesc, srv)
+func (x *immuServiceSubscribeServer) Send(m *Tx) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func _ImmuService_ListUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _ImmuService_History_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).History(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/History",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).History(ctx, req.(*HistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ImmuService_Health_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(empty.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ImmuServiceServer).ListUsers(ctx, in)
+		return srv.(ImmuServiceServer).Health(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/immudb.schema.ImmuService/ListUsers",
+		FullMethod: "/immudb.schema.ImmuService/Health",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ImmuServiceServer).ListUsers(ctx, req.(*empty.Empty))
+		return srv.(ImmuServiceServer).Health(ctx, req.(*empty.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ImmuService_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateUserRequest)
+func _ImmuService_ServerInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServerInfoRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ImmuServiceServer).CreateUser(ctx, in)
+		return srv.(ImmuServiceServer).ServerInfo(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/immudb.schema.ImmuService/CreateUser",
+		FullMethod: "/immudb.schema.ImmuService/ServerInfo",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ImmuServiceServer).CreateUser(ctx, req.(*CreateUserRequest))
+		return srv.(ImmuServiceServer).ServerInfo(ctx, req.(*ServerInfoRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ImmuService_ChangePassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ChangePasswordRequest)
+func _ImmuService_CurrentState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ImmuServiceServer).ChangePassword(ctx, in)
+		return srv.(ImmuServiceServer).CurrentState(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/immudb.schema.ImmuService/ChangePassword",
+		FullMethod: "/immudb.schema.ImmuService/CurrentState",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ImmuServiceServer).ChangePassword(ctx, req.(*ChangePasswordRequest))
+		return srv.(ImmuServiceServer).CurrentState(ctx, req.(*empty.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ImmuService_UpdateAuthConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AuthConfig)
+func _ImmuService_SetReference_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReferenceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ImmuServiceServer).UpdateAuthConfig(ctx, in)
+		return srv.(ImmuServiceServer).SetReference(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/immudb.schema.ImmuService/UpdateAuthConfig",
+		FullMethod: "/immudb.schema.ImmuService/SetReference",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ImmuServiceServer).UpdateAuthConfig(ctx, req.(*AuthConfig))
+		return srv.(ImmuServiceServer).SetReference(ctx, req.(*ReferenceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ImmuService_UpdateMTLSConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(MTLSConfig)
+func _ImmuService_VerifiableSetReference_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifiableReferenceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ImmuServiceServer).UpdateMTLSConfig(ctx, in)
+		return srv.(ImmuServiceServer).VerifiableSetReference(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/immudb.schema.ImmuService/UpdateMTLSConfig",
+		FullMethod: "/immudb.schema.ImmuService/VerifiableSetReference",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ImmuServiceServer).UpdateMTLSConfig(ctx, req.(*MTLSConfig))
+		return srv.(ImmuServiceServer).VerifiableSetReference(ctx, req.(*VerifiableReferenceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ImmuService_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LoginRequest)
+func _ImmuService_ZAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ZAddRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ImmuServiceServer).Login(ctx, in)
+		return srv.(ImmuServiceServer).ZAdd(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/immudb.schema.ImmuService/Login",
+		FullMethod: "/immudb.schema.ImmuService/ZAdd",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ImmuServiceServer).Login(ctx, req.(*LoginRequest))
+		return srv.(ImmuServiceServer).ZAdd(ctx, req.(*ZAddRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ImmuService_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(empty.Empty)
+func _ImmuService_VerifiableZAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifiableZAddRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ImmuServiceServer).Logout(ctx, in)
+		return srv.(ImmuServiceServer).VerifiableZAdd(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/immudb.schema.ImmuService/Logout",
+		FullMethod: "/immudb.schema.ImmuService/VerifiableZAdd",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ImmuServiceServer).Logout(ctx, req.(*empty.Empty))
+		return srv.(ImmuServiceServer).VerifiableZAdd(ctx, req.(*VerifiableZAddRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ImmuService_Set_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SetRequest)
+func _ImmuService_ZScan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ZScanRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ImmuServiceServer).Set(ctx, in)
+		return srv.(ImmuServiceServer).ZScan(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/immudb.schema.ImmuService/Set",
+		FullMethod: "/immudb.schema.ImmuService/ZScan",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ImmuServiceServer).Set(ctx, req.(*SetRequest))
+		return srv.(ImmuServiceServer).ZScan(ctx, req.(*ZScanRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ImmuService_VerifiableSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(VerifiableSetRequest)
+func _ImmuService_TSAppend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TSAppendRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ImmuServiceServer).VerifiableSet(ctx, in)
+		return srv.(ImmuServiceServer).TSAppend(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/immudb.schema.ImmuService/VerifiableSet",
+		FullMethod: "/immudb.schema.ImmuService/TSAppend",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ImmuServiceServer).VerifiableSet(ctx, req.(*VerifiableSetRequest))
+		return srv.(ImmuServiceServer).TSAppend(ctx, req.(*TSAppendRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ImmuService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(KeyRequest)
+func _ImmuService_TSRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TSRangeRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ImmuServiceServer).Get(ctx, in)
+		return srv.(ImmuServiceServer).TSRange(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/immudb.schema.ImmuService/Get",
+		FullMethod: "/immudb.schema.ImmuService/TSRange",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ImmuServiceServer).Get(ctx, req.(*KeyRequest))
+		return srv.(ImmuServiceServer).TSRange(ctx, req.(*TSRangeRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ImmuService_VerifiableGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(VerifiableGetRequest)
+func _ImmuService_Notarize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NotarizeRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ImmuServiceServer).VerifiableGet(ctx, in)
+		return srv.(ImmuServiceServer).Notarize(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/immudb.schema.ImmuService/VerifiableGet",
+		FullMethod: "/immudb.schema.ImmuService/Notarize",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ImmuServiceServer).VerifiableGet(ctx, req.(*VerifiableGetRequest))
+		return srv.(ImmuServiceServer).Notarize(ctx, req.(*NotarizeRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ImmuService_GetAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(KeyListRequest)
+func _ImmuService_VerifyNotarizationReceipt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NotarizationReceipt)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ImmuServiceServer).GetAll(ctx, in)
+		return srv.(ImmuServiceServer).VerifyNotarizationReceipt(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/immudb.schema.ImmuService/GetAll",
+		FullMethod: "/immudb.schema.ImmuService/VerifyNotarizationReceipt",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ImmuServiceServer).GetAll(ctx, req.(*KeyListRequest))
+		return srv.(ImmuServiceServer).VerifyNotarizationReceipt(ctx, req.(*NotarizationReceipt))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ImmuService_ExecAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ExecAllRequest)
+func _ImmuService_CreateCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Collection)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ImmuServiceServer).ExecAll(ctx, in)
+		return srv.(ImmuServiceServer).CreateCollection(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/immudb.schema.ImmuService/ExecAll",
+		FullMethod: "/immudb.schema.ImmuService/CreateCollection",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ImmuServiceServer).ExecAll(ctx, req.(*ExecAllRequest))
+		return srv.(ImmuServiceServer).CreateCollection(ctx, req.(*Collection))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ImmuService_Scan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ScanRequest)
+func _ImmuService_ListCollections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ImmuServiceServer).Scan(ctx, in)
+		return srv.(ImmuServiceServer).ListCollections(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/immudb.schema.ImmuService/Scan",
+		FullMethod: "/immudb.schema.ImmuService/ListCollections",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ImmuServiceServer).Scan(ctx, req.(*ScanRequest))
+		return srv.(ImmuServiceServer).ListCollections(ctx, req.(*empty.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ImmuService_Count_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(KeyPrefix)
+func _ImmuService_InsertDocuments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DocumentInsertRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ImmuServiceServer).Count(ctx, in)
+		return srv.(ImmuServiceServer).InsertDocuments(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/immudb.schema.ImmuService/Count",
+		FullMethod: "/immudb.schema.ImmuService/InsertDocuments",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ImmuServiceServer).Count(ctx, req.(*KeyPrefix))
+		return srv.(ImmuServiceServer).InsertDocuments(ctx, req.(*DocumentInsertRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ImmuService_CountAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(empty.Empty)
+func _ImmuService_GetDocument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DocumentGetRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ImmuServiceServer).CountAll(ctx, in)
+		return srv.(ImmuServiceServer).GetDocument(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/immudb.schema.ImmuService/CountAll",
+		FullMethod: "/immudb.schema.ImmuService/GetDocument",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).GetDocument(ctx, req.(*DocumentGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ImmuService_SearchDocuments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DocumentSearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).SearchDocuments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/SearchDocuments",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ImmuServiceServer).CountAll(ctx, req.(*empty.Empty))
+		return srv.(ImmuServiceServer).SearchDocuments(ctx, req.(*DocumentSearchRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ImmuService_TxById_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TxRequest)
+func _ImmuService_CreateDatabase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Database)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ImmuServiceServer).TxById(ctx, in)
+		return srv.(ImmuServiceServer).CreateDatabase(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/immudb.schema.ImmuService/TxById",
+		FullMethod: "/immudb.schema.ImmuService/CreateDatabase",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ImmuServiceServer).TxById(ctx, req.(*TxRequest))
+		return srv.(ImmuServiceServer).CreateDatabase(ctx, req.(*Database))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ImmuService_VerifiableTxById_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(VerifiableTxRequest)
+func _ImmuService_CreateDatabaseWith_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DatabaseSettings)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ImmuServiceServer).VerifiableTxById(ctx, in)
+		return srv.(ImmuServiceServer).CreateDatabaseWith(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/immudb.schema.ImmuService/VerifiableTxById",
+		FullMethod: "/immudb.schema.ImmuService/CreateDatabaseWith",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ImmuServiceServer).VerifiableTxById(ctx, req.(*VerifiableTxRequest))
+		return srv.(ImmuServiceServer).CreateDatabaseWith(ctx, req.(*DatabaseSettings))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ImmuService_TxScan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TxScanRequest)
+func _ImmuService_UpdateDatabase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DatabaseSettings)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ImmuServiceServer).TxScan(ctx, in)
+		return srv.(ImmuServiceServer).UpdateDatabase(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/immudb.schema.ImmuService/TxScan",
+		FullMethod: "/immudb.schema.ImmuService/UpdateDatabase",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ImmuServiceServer).TxScan(ctx, req.(*TxScanRequest))
+		return srv.(ImmuServiceServer).UpdateDatabase(ctx, req.(*DatabaseSettings))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ImmuService_History_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(HistoryRequest)
+func _ImmuService_GetDatabaseSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ImmuServiceServer).History(ctx, in)
+		return srv.(ImmuServiceServer).GetDatabaseSettings(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/immudb.schema.ImmuService/History",
+		FullMethod: "/immudb.schema.ImmuService/GetDatabaseSettings",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ImmuServiceServer).History(ctx, req.(*HistoryRequest))
+		return srv.(ImmuServiceServer).GetDatabaseSettings(ctx, req.(*empty.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ImmuService_Health_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _ImmuService_DatabaseList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(empty.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ImmuServiceServer).Health(ctx, in)
+		return srv.(ImmuServiceServer).DatabaseList(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/immudb.schema.ImmuService/Health",
+		FullMethod: "/immudb.schema.ImmuService/DatabaseList",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ImmuServiceServer).Health(ctx, req.(*empty.Empty))
+		return srv.(ImmuServiceServer).DatabaseList(ctx, req.(*empty.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ImmuService_CurrentState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(empty.Empty)
+func _ImmuService_DeleteDatabase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Database)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ImmuServiceServer).CurrentState(ctx, in)
+		return srv.(ImmuServiceServer).DeleteDatabase(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/immudb.schema.ImmuService/CurrentState",
+		FullMethod: "/immudb.schema.ImmuService/DeleteDatabase",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ImmuServiceServer).CurrentState(ctx, req.(*empty.Empty))
+		return srv.(ImmuServiceServer).DeleteDatabase(ctx, req.(*Database))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ImmuService_SetReference_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ReferenceRequest)
+func _ImmuService_UndeleteDatabase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Database)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ImmuServiceServer).SetReference(ctx, in)
+		return srv.(ImmuServiceServer).UndeleteDatabase(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/immudb.schema.ImmuService/SetReference",
+		FullMethod: "/immudb.schema.ImmuService/UndeleteDatabase",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ImmuServiceServer).SetReference(ctx, req.(*ReferenceRequest))
+		return srv.(ImmuServiceServer).UndeleteDatabase(ctx, req.(*Database))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ImmuService_VerifiableSetReference_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(VerifiableReferenceRequest)
+func _ImmuService_DeletedDatabaseList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ImmuServiceServer).VerifiableSetReference(ctx, in)
+		return srv.(ImmuServiceServer).DeletedDatabaseList(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/immudb.schema.ImmuService/VerifiableSetReference",
+		FullMethod: "/immudb.schema.ImmuService/DeletedDatabaseList",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ImmuServiceServer).VerifiableSetReference(ctx, req.(*VerifiableReferenceRequest))
+		return srv.(ImmuServiceServer).DeletedDatabaseList(ctx, req.(*empty.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ImmuService_ZAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ZAddRequest)
+func _ImmuService_ShrinkDatabase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Database)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ImmuServiceServer).ZAdd(ctx, in)
+		return srv.(ImmuServiceServer).ShrinkDatabase(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/immudb.schema.ImmuService/ZAdd",
+		FullMethod: "/immudb.schema.ImmuService/ShrinkDatabase",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ImmuServiceServer).ZAdd(ctx, req.(*ZAddRequest))
+		return srv.(ImmuServiceServer).ShrinkDatabase(ctx, req.(*Database))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ImmuService_VerifiableZAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(VerifiableZAddRequest)
+func _ImmuService_RotateEncryptionKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ImmuServiceServer).VerifiableZAdd(ctx, in)
+		return srv.(ImmuServiceServer).RotateEncryptionKeys(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/immudb.schema.ImmuService/VerifiableZAdd",
+		FullMethod: "/immudb.schema.ImmuService/RotateEncryptionKeys",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ImmuServiceServer).VerifiableZAdd(ctx, req.(*VerifiableZAddRequest))
+		return srv.(ImmuServiceServer).RotateEncryptionKeys(ctx, req.(*empty.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ImmuService_ZScan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ZScanRequest)
+func _ImmuService_OpenedFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Database)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ImmuServiceServer).ZScan(ctx, in)
+		return srv.(ImmuServiceServer).OpenedFiles(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/immudb.schema.ImmuService/ZScan",
+		FullMethod: "/immudb.schema.ImmuService/OpenedFiles",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ImmuServiceServer).ZScan(ctx, req.(*ZScanRequest))
+		return srv.(ImmuServiceServer).OpenedFiles(ctx, req.(*Database))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ImmuService_CreateDatabase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _ImmuService_CloseCachedFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Database)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ImmuServiceServer).CreateDatabase(ctx, in)
+		return srv.(ImmuServiceServer).CloseCachedFiles(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/immudb.schema.ImmuService/CreateDatabase",
+		FullMethod: "/immudb.schema.ImmuService/CloseCachedFiles",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ImmuServiceServer).CreateDatabase(ctx, req.(*Database))
+		return srv.(ImmuServiceServer).CloseCachedFiles(ctx, req.(*Database))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ImmuService_DatabaseList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(empty.Empty)
+func _ImmuService_CancelApplicationRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelApplicationRequestsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ImmuServiceServer).DatabaseList(ctx, in)
+		return srv.(ImmuServiceServer).CancelApplicationRequests(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/immudb.schema.ImmuService/DatabaseList",
+		FullMethod: "/immudb.schema.ImmuService/CancelApplicationRequests",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ImmuServiceServer).DatabaseList(ctx, req.(*empty.Empty))
+		return srv.(ImmuServiceServer).CancelApplicationRequests(ctx, req.(*CancelApplicationRequestsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -7974,6 +14668,74 @@ func (x *immuServiceStreamExecAllServer) Recv() (*Chunk, error) {
 	return m, nil
 }
 
+func _ImmuService_ExportTx_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TxRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ImmuServiceServer).ExportTx(m, &immuServiceExportTxServer{stream})
+}
+
+type ImmuService_ExportTxServer interface {
+	Send(*Chunk) error
+	grpc.ServerStream
+}
+
+type immuServiceExportTxServer struct {
+	grpc.ServerStream
+}
+
+func (x *immuServiceExportTxServer) Send(m *Chunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ImmuService_ExportTxRange_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TxRangeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ImmuServiceServer).ExportTxRange(m, &immuServiceExportTxRangeServer{stream})
+}
+
+type ImmuService_ExportTxRangeServer interface {
+	Send(*Chunk) error
+	grpc.ServerStream
+}
+
+type immuServiceExportTxRangeServer struct {
+	grpc.ServerStream
+}
+
+func (x *immuServiceExportTxRangeServer) Send(m *Chunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ImmuService_ApplyTxRange_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ImmuServiceServer).ApplyTxRange(&immuServiceApplyTxRangeServer{stream})
+}
+
+type ImmuService_ApplyTxRangeServer interface {
+	SendAndClose(*TxRange) error
+	Recv() (*Chunk, error)
+	grpc.ServerStream
+}
+
+type immuServiceApplyTxRangeServer struct {
+	grpc.ServerStream
+}
+
+func (x *immuServiceApplyTxRangeServer) SendAndClose(m *TxRange) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *immuServiceApplyTxRangeServer) Recv() (*Chunk, error) {
+	m := new(Chunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _ImmuService_UseSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UseSnapshotRequest)
 	if err := dec(in); err != nil {
@@ -8010,6 +14772,24 @@ func _ImmuService_SQLExec_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ImmuService_SQLBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SQLBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).SQLBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/SQLBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).SQLBatch(ctx, req.(*SQLBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ImmuService_SQLQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SQLQueryRequest)
 	if err := dec(in); err != nil {
@@ -8082,6 +14862,60 @@ func _ImmuService_VerifiableSQLGet_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ImmuService_VerifiableSQLQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifiableSQLQueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).VerifiableSQLQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/VerifiableSQLQuery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).VerifiableSQLQuery(ctx, req.(*VerifiableSQLQueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ImmuService_ExportSQLSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).ExportSQLSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/ExportSQLSchema",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).ExportSQLSchema(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ImmuService_ImportSQLSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SQLSchema)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ImmuServiceServer).ImportSQLSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/immudb.schema.ImmuService/ImportSQLSchema",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ImmuServiceServer).ImportSQLSchema(ctx, req.(*SQLSchema))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ImmuService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "immudb.schema.ImmuService",
 	HandlerType: (*ImmuServiceServer)(nil),
@@ -8098,6 +14932,18 @@ var _ImmuService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ChangePassword",
 			Handler:    _ImmuService_ChangePassword_Handler,
 		},
+		{
+			MethodName: "ExportUsers",
+			Handler:    _ImmuService_ExportUsers_Handler,
+		},
+		{
+			MethodName: "ImportUsers",
+			Handler:    _ImmuService_ImportUsers_Handler,
+		},
+		{
+			MethodName: "ResetPassword",
+			Handler:    _ImmuService_ResetPassword_Handler,
+		},
 		{
 			MethodName: "UpdateAuthConfig",
 			Handler:    _ImmuService_UpdateAuthConfig_Handler,
@@ -8130,6 +14976,10 @@ var _ImmuService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "VerifiableGet",
 			Handler:    _ImmuService_VerifiableGet_Handler,
 		},
+		{
+			MethodName: "VerifiableHistoricalGet",
+			Handler:    _ImmuService_VerifiableHistoricalGet_Handler,
+		},
 		{
 			MethodName: "GetAll",
 			Handler:    _ImmuService_GetAll_Handler,
@@ -8138,10 +14988,18 @@ var _ImmuService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ExecAll",
 			Handler:    _ImmuService_ExecAll_Handler,
 		},
+		{
+			MethodName: "ExecMultiDbAll",
+			Handler:    _ImmuService_ExecMultiDbAll_Handler,
+		},
 		{
 			MethodName: "Scan",
 			Handler:    _ImmuService_Scan_Handler,
 		},
+		{
+			MethodName: "IndexScan",
+			Handler:    _ImmuService_IndexScan_Handler,
+		},
 		{
 			MethodName: "Count",
 			Handler:    _ImmuService_Count_Handler,
@@ -8170,6 +15028,10 @@ var _ImmuService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Health",
 			Handler:    _ImmuService_Health_Handler,
 		},
+		{
+			MethodName: "ServerInfo",
+			Handler:    _ImmuService_ServerInfo_Handler,
+		},
 		{
 			MethodName: "CurrentState",
 			Handler:    _ImmuService_CurrentState_Handler,
@@ -8194,14 +15056,94 @@ var _ImmuService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ZScan",
 			Handler:    _ImmuService_ZScan_Handler,
 		},
+		{
+			MethodName: "TSAppend",
+			Handler:    _ImmuService_TSAppend_Handler,
+		},
+		{
+			MethodName: "TSRange",
+			Handler:    _ImmuService_TSRange_Handler,
+		},
+		{
+			MethodName: "Notarize",
+			Handler:    _ImmuService_Notarize_Handler,
+		},
+		{
+			MethodName: "VerifyNotarizationReceipt",
+			Handler:    _ImmuService_VerifyNotarizationReceipt_Handler,
+		},
+		{
+			MethodName: "CreateCollection",
+			Handler:    _ImmuService_CreateCollection_Handler,
+		},
+		{
+			MethodName: "ListCollections",
+			Handler:    _ImmuService_ListCollections_Handler,
+		},
+		{
+			MethodName: "InsertDocuments",
+			Handler:    _ImmuService_InsertDocuments_Handler,
+		},
+		{
+			MethodName: "GetDocument",
+			Handler:    _ImmuService_GetDocument_Handler,
+		},
+		{
+			MethodName: "SearchDocuments",
+			Handler:    _ImmuService_SearchDocuments_Handler,
+		},
 		{
 			MethodName: "CreateDatabase",
 			Handler:    _ImmuService_CreateDatabase_Handler,
 		},
+		{
+			MethodName: "CreateDatabaseWith",
+			Handler:    _ImmuService_CreateDatabaseWith_Handler,
+		},
+		{
+			MethodName: "UpdateDatabase",
+			Handler:    _ImmuService_UpdateDatabase_Handler,
+		},
+		{
+			MethodName: "GetDatabaseSettings",
+			Handler:    _ImmuService_GetDatabaseSettings_Handler,
+		},
 		{
 			MethodName: "DatabaseList",
 			Handler:    _ImmuService_DatabaseList_Handler,
 		},
+		{
+			MethodName: "DeleteDatabase",
+			Handler:    _ImmuService_DeleteDatabase_Handler,
+		},
+		{
+			MethodName: "UndeleteDatabase",
+			Handler:    _ImmuService_UndeleteDatabase_Handler,
+		},
+		{
+			MethodName: "DeletedDatabaseList",
+			Handler:    _ImmuService_DeletedDatabaseList_Handler,
+		},
+		{
+			MethodName: "ShrinkDatabase",
+			Handler:    _ImmuService_ShrinkDatabase_Handler,
+		},
+		{
+			MethodName: "RotateEncryptionKeys",
+			Handler:    _ImmuService_RotateEncryptionKeys_Handler,
+		},
+		{
+			MethodName: "OpenedFiles",
+			Handler:    _ImmuService_OpenedFiles_Handler,
+		},
+		{
+			MethodName: "CloseCachedFiles",
+			Handler:    _ImmuService_CloseCachedFiles_Handler,
+		},
+		{
+			MethodName: "CancelApplicationRequests",
+			Handler:    _ImmuService_CancelApplicationRequests_Handler,
+		},
 		{
 			MethodName: "UseDatabase",
 			Handler:    _ImmuService_UseDatabase_Handler,
@@ -8226,6 +15168,10 @@ var _ImmuService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SQLExec",
 			Handler:    _ImmuService_SQLExec_Handler,
 		},
+		{
+			MethodName: "SQLBatch",
+			Handler:    _ImmuService_SQLBatch_Handler,
+		},
 		{
 			MethodName: "SQLQuery",
 			Handler:    _ImmuService_SQLQuery_Handler,
@@ -8242,8 +15188,25 @@ var _ImmuService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "VerifiableSQLGet",
 			Handler:    _ImmuService_VerifiableSQLGet_Handler,
 		},
+		{
+			MethodName: "VerifiableSQLQuery",
+			Handler:    _ImmuService_VerifiableSQLQuery_Handler,
+		},
+		{
+			MethodName: "ExportSQLSchema",
+			Handler:    _ImmuService_ExportSQLSchema_Handler,
+		},
+		{
+			MethodName: "ImportSQLSchema",
+			Handler:    _ImmuService_ImportSQLSchema_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Subscribe",
+			Handler:       _ImmuService_Subscribe_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "streamGet",
 			Handler:       _ImmuService_StreamGet_Handler,
@@ -8284,6 +15247,21 @@ var _ImmuService_serviceDesc = grpc.ServiceDesc{
 			Handler:       _ImmuService_StreamExecAll_Handler,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "exportTx",
+			Handler:       _ImmuService_ExportTx_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "exportTxRange",
+			Handler:       _ImmuService_ExportTxRange_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "applyTxRange",
+			Handler:       _ImmuService_ApplyTxRange_Handler,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "schema.proto",
 }
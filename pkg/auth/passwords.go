@@ -17,10 +17,12 @@ limitations under the License.
 package auth
 
 import (
+	"crypto/rand"
 	"encoding/base64"
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"unicode"
 
 	"golang.org/x/crypto/bcrypt"
@@ -40,6 +42,35 @@ func ComparePasswords(hashedPassword []byte, plainPassword []byte) error {
 	return bcrypt.CompareHashAndPassword(hashedPassword, plainPassword)
 }
 
+// mockHashedPassword is hashed from random bytes with the cost of stored passwords, it's generated once per process
+var mockHashedPassword struct {
+	once sync.Once
+	hash []byte
+	err  error
+}
+
+// CompareMockPassword takes as long as comparing a password against a stored one, but it always fails.
+// It's run for users that don't exist, so they can't be told apart by response time
+func CompareMockPassword(plainPassword []byte) error {
+	mockHashedPassword.once.Do(func() {
+		secret := make([]byte, maxPasswordLen)
+
+		_, mockHashedPassword.err = rand.Read(secret)
+		if mockHashedPassword.err != nil {
+			return
+		}
+
+		mockHashedPassword.hash, mockHashedPassword.err = bcrypt.GenerateFromPassword(secret, bcrypt.DefaultCost)
+	})
+	if mockHashedPassword.err != nil {
+		return mockHashedPassword.err
+	}
+
+	bcrypt.CompareHashAndPassword(mockHashedPassword.hash, plainPassword)
+
+	return bcrypt.ErrMismatchedHashAndPassword
+}
+
 const minPasswordLen = 8
 const maxPasswordLen = 32
 
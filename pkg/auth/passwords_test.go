@@ -20,6 +20,8 @@ import (
 	"encoding/base64"
 	"strings"
 	"testing"
+
+	"golang.org/x/crypto/bcrypt"
 )
 
 func TestIsStrongPassword(t *testing.T) {
@@ -61,3 +63,16 @@ func TestDecodeBase64Password(t *testing.T) {
 	}
 
 }
+
+func TestCompareMockPassword(t *testing.T) {
+	for _, pass := range []string{"", "immudb", "1~Password"} {
+		if err := CompareMockPassword([]byte(pass)); err != bcrypt.ErrMismatchedHashAndPassword {
+			t.Errorf("CompareMockPassword must always fail, got %v", err)
+		}
+	}
+
+	// the comparison must be as costly as the one against stored passwords
+	if cost, err := bcrypt.Cost(mockHashedPassword.hash); err != nil || cost != bcrypt.DefaultCost {
+		t.Errorf("unexpected cost of the mock hashed password: %d, %v", cost, err)
+	}
+}
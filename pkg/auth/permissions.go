@@ -31,57 +31,147 @@ const (
 
 var methodsPermissions = map[string][]uint32{
 	// readwrite methods
-	"Set":                    {PermissionSysAdmin, PermissionAdmin, PermissionRW},
-	"VerifiableSet":          {PermissionSysAdmin, PermissionAdmin, PermissionRW},
-	"StreamSet":              {PermissionSysAdmin, PermissionAdmin, PermissionRW},
-	"StreamVerifiableSet":    {PermissionSysAdmin, PermissionAdmin, PermissionRW},
-	"Get":                    {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
-	"VerifiableGet":          {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
-	"StreamGet":              {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
-	"StreamVerifiableGet":    {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
-	"GetAll":                 {PermissionSysAdmin, PermissionAdmin, PermissionRW},
-	"ExecAll":                {PermissionSysAdmin, PermissionAdmin, PermissionRW},
-	"StreamExecAll":          {PermissionSysAdmin, PermissionAdmin, PermissionRW},
-	"SetReference":           {PermissionSysAdmin, PermissionAdmin, PermissionRW},
-	"VerifiableSetReference": {PermissionSysAdmin, PermissionAdmin, PermissionRW},
-	"ZAdd":                   {PermissionSysAdmin, PermissionAdmin, PermissionRW},
-	"VerifiableZAdd":         {PermissionSysAdmin, PermissionAdmin, PermissionRW},
-	"ZScan":                  {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
-	"StreamZScan":            {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
-	"VerifiableTxByID":       {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
-	"IScan":                  {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
-	"Scan":                   {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
-	"StreamScan":             {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
-	"History":                {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
-	"StreamHistory":          {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
-	"TxByID":                 {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
-	"TxScan":                 {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
-	"Count":                  {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
-	"CountAll":               {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
-	"DatabaseList":           {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
-	"CurrentState":           {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
-	"SQLExec":                {PermissionSysAdmin, PermissionAdmin, PermissionRW},
-	"UseSnapshot":            {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
-	"SQLQuery":               {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
-	"ListTables":             {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
-	"DescribeTable":          {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
-	"VerifiableSQLGet":       {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
+	"Set":                       {PermissionSysAdmin, PermissionAdmin, PermissionRW},
+	"VerifiableSet":             {PermissionSysAdmin, PermissionAdmin, PermissionRW},
+	"StreamSet":                 {PermissionSysAdmin, PermissionAdmin, PermissionRW},
+	"StreamVerifiableSet":       {PermissionSysAdmin, PermissionAdmin, PermissionRW},
+	"Get":                       {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
+	"VerifiableGet":             {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
+	"VerifiableHistoricalGet":   {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
+	"StreamGet":                 {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
+	"StreamVerifiableGet":       {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
+	"GetAll":                    {PermissionSysAdmin, PermissionAdmin, PermissionRW},
+	"ExecAll":                   {PermissionSysAdmin, PermissionAdmin, PermissionRW},
+	"StreamExecAll":             {PermissionSysAdmin, PermissionAdmin, PermissionRW},
+	"ExecMultiDbAll":            {PermissionSysAdmin, PermissionAdmin, PermissionRW},
+	"SetReference":              {PermissionSysAdmin, PermissionAdmin, PermissionRW},
+	"VerifiableSetReference":    {PermissionSysAdmin, PermissionAdmin, PermissionRW},
+	"ZAdd":                      {PermissionSysAdmin, PermissionAdmin, PermissionRW},
+	"VerifiableZAdd":            {PermissionSysAdmin, PermissionAdmin, PermissionRW},
+	"ZScan":                     {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
+	"StreamZScan":               {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
+	"TSAppend":                  {PermissionSysAdmin, PermissionAdmin, PermissionRW},
+	"TSRange":                   {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
+	"Notarize":                  {PermissionSysAdmin, PermissionAdmin, PermissionRW},
+	"VerifyNotarizationReceipt": {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
+	"CreateCollection":          {PermissionSysAdmin, PermissionAdmin, PermissionRW},
+	"ListCollections":           {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
+	"InsertDocuments":           {PermissionSysAdmin, PermissionAdmin, PermissionRW},
+	"GetDocument":               {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
+	"SearchDocuments":           {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
+	"VerifiableTxByID":          {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
+	"IScan":                     {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
+	"Scan":                      {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
+	"IndexScan":                 {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
+	"StreamScan":                {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
+	"History":                   {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
+	"StreamHistory":             {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
+	"TxByID":                    {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
+	"TxScan":                    {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
+	"ExportTx":                  {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
+	"Subscribe":                 {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
+	"Count":                     {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
+	"CountAll":                  {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
+	"DatabaseList":              {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
+	"CurrentState":              {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
+	"SQLExec":                   {PermissionSysAdmin, PermissionAdmin, PermissionRW},
+	"SQLBatch":                  {PermissionSysAdmin, PermissionAdmin, PermissionRW},
+	"UseSnapshot":               {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
+	"SQLQuery":                  {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
+	"ListTables":                {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
+	"DescribeTable":             {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
+	"ExportSQLSchema":           {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
+	"ImportSQLSchema":           {PermissionSysAdmin, PermissionAdmin, PermissionRW},
+	"VerifiableSQLGet":          {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
+	"VerifiableSQLQuery":        {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
 
 	// admin methods
-	"ListUsers":        {PermissionSysAdmin, PermissionAdmin},
-	"CreateUser":       {PermissionSysAdmin, PermissionAdmin},
-	"ChangePassword":   {PermissionSysAdmin, PermissionAdmin},
-	"SetPermission":    {PermissionSysAdmin, PermissionAdmin},
-	"DeactivateUser":   {PermissionSysAdmin, PermissionAdmin},
-	"SetActiveUser":    {PermissionSysAdmin, PermissionAdmin},
-	"UpdateAuthConfig": {PermissionSysAdmin},
-	"UpdateMTLSConfig": {PermissionSysAdmin},
-	"CreateDatabase":   {PermissionSysAdmin},
-	"Dump":             {PermissionSysAdmin, PermissionAdmin},
-	"CleanIndex":       {PermissionSysAdmin, PermissionAdmin},
+	"ListUsers":                 {PermissionSysAdmin, PermissionAdmin},
+	"CreateUser":                {PermissionSysAdmin, PermissionAdmin},
+	"ChangePassword":            {PermissionSysAdmin, PermissionAdmin},
+	"SetPermission":             {PermissionSysAdmin, PermissionAdmin},
+	"DeactivateUser":            {PermissionSysAdmin, PermissionAdmin},
+	"SetActiveUser":             {PermissionSysAdmin, PermissionAdmin},
+	"ExportUsers":               {PermissionSysAdmin},
+	"ImportUsers":               {PermissionSysAdmin},
+	"UpdateAuthConfig":          {PermissionSysAdmin},
+	"UpdateMTLSConfig":          {PermissionSysAdmin},
+	"CreateDatabase":            {PermissionSysAdmin},
+	"CreateDatabaseWith":        {PermissionSysAdmin},
+	"UpdateDatabase":            {PermissionSysAdmin},
+	"GetDatabaseSettings":       {PermissionSysAdmin, PermissionAdmin, PermissionRW, PermissionR},
+	"DeleteDatabase":            {PermissionSysAdmin},
+	"UndeleteDatabase":          {PermissionSysAdmin},
+	"DeletedDatabaseList":       {PermissionSysAdmin},
+	"ShrinkDatabase":            {PermissionSysAdmin},
+	"RotateEncryptionKeys":      {PermissionSysAdmin},
+	"OpenedFiles":               {PermissionSysAdmin},
+	"CloseCachedFiles":          {PermissionSysAdmin},
+	"CancelApplicationRequests": {PermissionSysAdmin},
+	"Dump":                      {PermissionSysAdmin, PermissionAdmin},
+	"CleanIndex":                {PermissionSysAdmin, PermissionAdmin},
+	"ExportTxRange":             {PermissionSysAdmin, PermissionAdmin},
+	"ApplyTxRange":              {PermissionSysAdmin, PermissionAdmin},
 }
 
-//HasPermissionForMethod checks if userPermission can access method name
+// rawReadMethods read keys, values or txs straight from the store, thus the SQL rows they hold
+// are returned regardless of the policies of their tables
+var rawReadMethods = map[string]struct{}{
+	"Get":                     {},
+	"VerifiableGet":           {},
+	"VerifiableHistoricalGet": {},
+	"StreamGet":               {},
+	"StreamVerifiableGet":     {},
+	"GetAll":                  {},
+	"ZScan":                   {},
+	"StreamZScan":             {},
+	"VerifiableTxByID":        {},
+	"IScan":                   {},
+	"Scan":                    {},
+	"IndexScan":               {},
+	"StreamScan":              {},
+	"History":                 {},
+	"StreamHistory":           {},
+	"TxByID":                  {},
+	"TxScan":                  {},
+	"ExportTx":                {},
+	"Subscribe":               {},
+	"Dump":                    {},
+	"ExportTxRange":           {},
+	"Count":                   {},
+	"CountAll":                {},
+}
+
+// rawWriteMethods write keys and values straight into the store, thus bypassing the policies
+// of the tables of the database
+var rawWriteMethods = map[string]struct{}{
+	"Set":                    {},
+	"VerifiableSet":          {},
+	"StreamSet":              {},
+	"StreamVerifiableSet":    {},
+	"ExecAll":                {},
+	"StreamExecAll":          {},
+	"SetReference":           {},
+	"VerifiableSetReference": {},
+	"ZAdd":                   {},
+	"VerifiableZAdd":         {},
+	"ExecMultiDbAll":         {},
+	"ApplyTxRange":           {},
+}
+
+// IsRawReadMethod returns whether the method reads keys, values or txs without applying SQL policies
+func IsRawReadMethod(method string) bool {
+	_, ok := rawReadMethods[method]
+	return ok
+}
+
+// IsRawWriteMethod returns whether the method writes keys and values without applying SQL policies
+func IsRawWriteMethod(method string) bool {
+	_, ok := rawWriteMethods[method]
+	return ok
+}
+
+// HasPermissionForMethod checks if userPermission can access method name
 func HasPermissionForMethod(userPermission uint32, method string) bool {
 	methodPermissions, ok := methodsPermissions[method]
 	if !ok {
@@ -94,3 +184,18 @@ func HasPermissionForMethod(userPermission uint32, method string) bool {
 	}
 	return false
 }
+
+// PermissionName returns the name the permission is referred to by clients, or an empty string for PermissionNone
+func PermissionName(permission uint32) string {
+	switch permission {
+	case PermissionSysAdmin:
+		return "sysadmin"
+	case PermissionAdmin:
+		return "admin"
+	case PermissionRW:
+		return "readwrite"
+	case PermissionR:
+		return "read"
+	}
+	return ""
+}
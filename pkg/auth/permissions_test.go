@@ -38,3 +38,47 @@ func TestHasPermissionForMethod(t *testing.T) {
 		t.Errorf("expected PermissionNone to be insufficient for CountAll")
 	}
 }
+
+func TestPermissionName(t *testing.T) {
+	names := map[uint32]string{
+		PermissionSysAdmin: "sysadmin",
+		PermissionAdmin:    "admin",
+		PermissionRW:       "readwrite",
+		PermissionR:        "read",
+		PermissionNone:     "",
+	}
+
+	for permission, name := range names {
+		if PermissionName(permission) != name {
+			t.Errorf("expected permission %d to be named %s", permission, name)
+		}
+	}
+}
+
+func TestIsRawReadMethod(t *testing.T) {
+	for _, method := range []string{"Get", "Scan", "TxByID", "ExportTx", "ExportTxRange", "Count", "CountAll"} {
+		if !IsRawReadMethod(method) {
+			t.Errorf("expected %s to be a raw read method", method)
+		}
+	}
+
+	for _, method := range []string{"SQLQuery", "VerifiableSQLGet", "Set", "CurrentState"} {
+		if IsRawReadMethod(method) {
+			t.Errorf("expected %s not to be a raw read method", method)
+		}
+	}
+}
+
+func TestIsRawWriteMethod(t *testing.T) {
+	for _, method := range []string{"Set", "ExecAll", "StreamSet", "StreamExecAll", "SetReference", "ZAdd", "ExecMultiDbAll", "ApplyTxRange"} {
+		if !IsRawWriteMethod(method) {
+			t.Errorf("expected %s to be a raw write method", method)
+		}
+	}
+
+	for _, method := range []string{"SQLExec", "Get", "CurrentState"} {
+		if IsRawWriteMethod(method) {
+			t.Errorf("expected %s not to be a raw write method", method)
+		}
+	}
+}
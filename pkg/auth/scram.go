@@ -0,0 +1,181 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// SCRAMMechanism is the name of the SASL mechanism the SCRAM verifiers are meant for
+const SCRAMMechanism = "SCRAM-SHA-256"
+
+const scramIterations = 4096
+const scramSaltLen = 16
+
+// ErrInvalidSCRAMVerifier is returned when a stored SCRAM verifier can't be parsed
+var ErrInvalidSCRAMVerifier = errors.New("invalid SCRAM verifier")
+
+// SCRAMVerifier holds what is needed to check a SCRAM-SHA-256 client proof (RFC 5802, RFC 7677)
+// without knowing the password
+type SCRAMVerifier struct {
+	Iterations int
+	Salt       []byte
+	StoredKey  []byte
+	ServerKey  []byte
+}
+
+// NewSCRAMVerifier derives a verifier from the password using a random salt
+func NewSCRAMVerifier(plainPassword []byte) (*SCRAMVerifier, error) {
+	salt := make([]byte, scramSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	return newSCRAMVerifier(plainPassword, salt, scramIterations), nil
+}
+
+func newSCRAMVerifier(plainPassword []byte, salt []byte, iterations int) *SCRAMVerifier {
+	saltedPassword := pbkdf2.Key(plainPassword, salt, iterations, sha256.Size, sha256.New)
+	clientKey := scramHMAC(saltedPassword, []byte("Client Key"))
+	storedKey := sha256.Sum256(clientKey)
+
+	return &SCRAMVerifier{
+		Iterations: iterations,
+		Salt:       salt,
+		StoredKey:  storedKey[:],
+		ServerKey:  scramHMAC(saltedPassword, []byte("Server Key")),
+	}
+}
+
+// NewMockSCRAMVerifier returns a verifier no client proof matches, used to run the exchange for users that
+// don't exist. Its salt is derived from the user name and the secret, so it's the same across attempts as for real users
+func NewMockSCRAMVerifier(username string, secret []byte) *SCRAMVerifier {
+	return &SCRAMVerifier{
+		Iterations: scramIterations,
+		Salt:       scramHMAC(secret, []byte(username))[:scramSaltLen],
+		StoredKey:  make([]byte, sha256.Size),
+		ServerKey:  make([]byte, sha256.Size),
+	}
+}
+
+// ParseSCRAMVerifier parses a verifier in the format used by PostgreSQL:
+// SCRAM-SHA-256$<iterations>:<salt>$<StoredKey>:<ServerKey>
+func ParseSCRAMVerifier(s string) (*SCRAMVerifier, error) {
+	parts := strings.Split(s, "$")
+	if len(parts) != 3 || parts[0] != SCRAMMechanism {
+		return nil, ErrInvalidSCRAMVerifier
+	}
+
+	iterSalt := strings.Split(parts[1], ":")
+	keys := strings.Split(parts[2], ":")
+	if len(iterSalt) != 2 || len(keys) != 2 {
+		return nil, ErrInvalidSCRAMVerifier
+	}
+
+	iterations, err := strconv.Atoi(iterSalt[0])
+	if err != nil || iterations <= 0 {
+		return nil, ErrInvalidSCRAMVerifier
+	}
+
+	v := &SCRAMVerifier{Iterations: iterations}
+
+	for _, f := range []struct {
+		dst *[]byte
+		src string
+	}{{&v.Salt, iterSalt[1]}, {&v.StoredKey, keys[0]}, {&v.ServerKey, keys[1]}} {
+		*f.dst, err = base64.StdEncoding.DecodeString(f.src)
+		if err != nil {
+			return nil, ErrInvalidSCRAMVerifier
+		}
+	}
+
+	if len(v.StoredKey) != sha256.Size || len(v.ServerKey) != sha256.Size {
+		return nil, ErrInvalidSCRAMVerifier
+	}
+
+	return v, nil
+}
+
+// String encodes the verifier in the format used by PostgreSQL
+func (v *SCRAMVerifier) String() string {
+	return fmt.Sprintf("%s$%d:%s$%s:%s",
+		SCRAMMechanism,
+		v.Iterations,
+		base64.StdEncoding.EncodeToString(v.Salt),
+		base64.StdEncoding.EncodeToString(v.StoredKey),
+		base64.StdEncoding.EncodeToString(v.ServerKey),
+	)
+}
+
+// VerifyClientProof checks the proof sent by the client for the given auth message
+func (v *SCRAMVerifier) VerifyClientProof(authMessage, clientProof []byte) bool {
+	if len(clientProof) != sha256.Size {
+		return false
+	}
+
+	clientSignature := scramHMAC(v.StoredKey, authMessage)
+
+	clientKey := make([]byte, sha256.Size)
+	for i := range clientKey {
+		clientKey[i] = clientProof[i] ^ clientSignature[i]
+	}
+
+	storedKey := sha256.Sum256(clientKey)
+
+	return subtle.ConstantTimeCompare(storedKey[:], v.StoredKey) == 1
+}
+
+// ServerSignature returns the signature proving to the client that the server knows the verifier
+func (v *SCRAMVerifier) ServerSignature(authMessage []byte) []byte {
+	return scramHMAC(v.ServerKey, authMessage)
+}
+
+func scramHMAC(key, msg []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(msg)
+	return mac.Sum(nil)
+}
+
+// MD5Password returns the md5 password hash in the format used by PostgreSQL: "md5" followed by md5(password+username)
+func MD5Password(username string, plainPassword []byte) string {
+	return "md5" + md5Hex(append(append([]byte{}, plainPassword...), username...))
+}
+
+// VerifyMD5Response checks the response of a client to an md5 challenge: "md5" followed by md5(md5(password+username)+salt)
+func VerifyMD5Response(md5Password string, salt []byte, response string) bool {
+	if !strings.HasPrefix(md5Password, "md5") {
+		return false
+	}
+	expected := "md5" + md5Hex(append([]byte(md5Password[3:]), salt...))
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(response)) == 1
+}
+
+func md5Hex(b []byte) string {
+	sum := md5.Sum(b)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// test vector of RFC 7677
+func TestSCRAMVerifier(t *testing.T) {
+	salt, err := base64.StdEncoding.DecodeString("W22ZaJ0SNY7soEsUEjb6gQ==")
+	require.NoError(t, err)
+
+	v := newSCRAMVerifier([]byte("pencil"), salt, 4096)
+
+	authMessage := []byte("n=user,r=rOprNGfwEbeRWgbNEkqO," +
+		"r=rOprNGfwEbeRWgbNEkqO%hvYDpWUa2RaTCAfuxFIlj)hNlF$k0,s=W22ZaJ0SNY7soEsUEjb6gQ==,i=4096," +
+		"c=biws,r=rOprNGfwEbeRWgbNEkqO%hvYDpWUa2RaTCAfuxFIlj)hNlF$k0")
+
+	proof, err := base64.StdEncoding.DecodeString("dHzbZapWIk4jUhN+Ute9ytag9zjfMHgsqmmiz7AndVQ=")
+	require.NoError(t, err)
+
+	require.True(t, v.VerifyClientProof(authMessage, proof))
+	require.False(t, v.VerifyClientProof(authMessage[1:], proof))
+	require.False(t, v.VerifyClientProof(authMessage, proof[1:]))
+
+	require.Equal(t, "6rriTRBi23WpRR/wtup+mMhUZUn/dB5nLTJRsjl95G4=", base64.StdEncoding.EncodeToString(v.ServerSignature(authMessage)))
+
+	parsed, err := ParseSCRAMVerifier(v.String())
+	require.NoError(t, err)
+	require.Equal(t, v, parsed)
+
+	for _, s := range []string{
+		"",
+		"SCRAM-SHA-1$4096:W22ZaJ0SNY7soEsUEjb6gQ==$a:b",
+		"SCRAM-SHA-256$abc:W22ZaJ0SNY7soEsUEjb6gQ==$a:b",
+		"SCRAM-SHA-256$4096:W22ZaJ0SNY7soEsUEjb6gQ==$YQ==:YQ==",
+		"SCRAM-SHA-256$4096:!!$YQ==:YQ==",
+	} {
+		_, err = ParseSCRAMVerifier(s)
+		require.Equal(t, ErrInvalidSCRAMVerifier, err)
+	}
+}
+
+func TestMockSCRAMVerifier(t *testing.T) {
+	secret := []byte("secret")
+
+	v := NewMockSCRAMVerifier("unknown", secret)
+	require.Equal(t, scramIterations, v.Iterations)
+	require.Len(t, v.Salt, scramSaltLen)
+	require.Equal(t, v, NewMockSCRAMVerifier("unknown", secret))
+	require.NotEqual(t, v.Salt, NewMockSCRAMVerifier("other", secret).Salt)
+	require.NotEqual(t, v.Salt, NewMockSCRAMVerifier("unknown", []byte("other")).Salt)
+
+	require.False(t, v.VerifyClientProof([]byte("n=unknown,r=nonce"), make([]byte, 32)))
+}
+
+func TestMD5Password(t *testing.T) {
+	md5Password := MD5Password("immudb", []byte("immudb"))
+	require.Equal(t, "md5"+md5Hex([]byte("immudbimmudb")), md5Password)
+
+	salt := []byte{1, 2, 3, 4}
+	response := "md5" + md5Hex(append([]byte(md5Password[3:]), salt...))
+
+	require.True(t, VerifyMD5Response(md5Password, salt, response))
+	require.False(t, VerifyMD5Response(md5Password, []byte{4, 3, 2, 1}, response))
+	require.False(t, VerifyMD5Response("", salt, response))
+}
+
+func TestUserPgsqlVerifiers(t *testing.T) {
+	u := User{}
+	_, err := u.SetPassword([]byte("immudb"))
+	require.NoError(t, err)
+	require.Empty(t, u.MD5Password)
+
+	v, err := ParseSCRAMVerifier(u.SCRAMVerifier)
+	require.NoError(t, err)
+	require.Equal(t, newSCRAMVerifier([]byte("immudb"), v.Salt, v.Iterations), v)
+
+	u.Username = "immudb"
+	_, err = u.SetPassword([]byte("immudb"))
+	require.NoError(t, err)
+	require.Equal(t, MD5Password("immudb", []byte("immudb")), u.MD5Password)
+}
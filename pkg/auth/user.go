@@ -34,9 +34,11 @@ type User struct {
 	HashedPassword []byte       `json:"hashedpassword"`
 	Permissions    []Permission `json:"permissions"`
 	Active         bool         `json:"active"`
-	IsSysAdmin     bool         `json:"-"`         //for the sysadmin we'll use this instead of adding all db and permissions to Permissions, to save some cpu cycles
-	CreatedBy      string       `json:"createdBy"` //user which created this user
-	CreatedAt      time.Time    `json:"createdat"` //time in which this user is created/updated
+	IsSysAdmin     bool         `json:"-"`                       //for the sysadmin we'll use this instead of adding all db and permissions to Permissions, to save some cpu cycles
+	CreatedBy      string       `json:"createdBy"`               //user which created this user
+	CreatedAt      time.Time    `json:"createdat"`               //time in which this user is created/updated
+	SCRAMVerifier  string       `json:"scramverifier,omitempty"` //lets pgsql clients authenticate with SCRAM-SHA-256
+	MD5Password    string       `json:"md5password,omitempty"`   //lets pgsql clients authenticate with md5, it depends on the username
 }
 
 // SysAdminUsername the system admin username
@@ -45,7 +47,8 @@ var SysAdminUsername = "immudb"
 // SysAdminPassword the admin password (can be default or from command flags, config or env var)
 var SysAdminPassword = SysAdminUsername
 
-// SetPassword Hashes and salts the password and assigns it to hashedPassword of User
+// SetPassword Hashes and salts the password and assigns it to hashedPassword of User.
+// The verifiers of the pgsql authentication methods are derived as well, the md5 one only when Username is set
 func (u *User) SetPassword(plainPassword []byte) ([]byte, error) {
 	if len(plainPassword) == 0 {
 		return nil, fmt.Errorf("password is empty")
@@ -54,7 +57,16 @@ func (u *User) SetPassword(plainPassword []byte) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	scramVerifier, err := NewSCRAMVerifier(plainPassword)
+	if err != nil {
+		return nil, err
+	}
 	u.HashedPassword = hashedPassword
+	u.SCRAMVerifier = scramVerifier.String()
+	u.MD5Password = ""
+	if u.Username != "" {
+		u.MD5Password = MD5Password(u.Username, plainPassword)
+	}
 	return plainPassword, nil
 }
 
@@ -72,6 +72,7 @@ type defaultAuditor struct {
 	password            []byte
 	auditDatabases      []string
 	serverSigningPubKey *ecdsa.PublicKey
+	trustAnchors        map[string]*client.TrustAnchor
 	notificationConfig  AuditNotificationConfig
 	serviceClient       schema.ImmuServiceClient
 	uuidProvider        state.UUIDProvider
@@ -89,6 +90,7 @@ func DefaultAuditor(
 	passwordBase64 string,
 	auditDatabases []string,
 	serverSigningPubKey *ecdsa.PublicKey,
+	trustAnchors map[string]*client.TrustAnchor,
 	notificationConfig AuditNotificationConfig,
 	serviceClient schema.ImmuServiceClient,
 	uuidProvider state.UUIDProvider,
@@ -121,6 +123,7 @@ func DefaultAuditor(
 		[]byte(password),
 		auditDatabases,
 		serverSigningPubKey,
+		trustAnchors,
 		notificationConfig,
 		serviceClient,
 		uuidProvider,
@@ -266,6 +269,11 @@ func (a *defaultAuditor) audit() error {
 		return noErr
 	}
 
+	// the history of the database must be consistent with its trust anchor from the very first audit
+	if anchor, ok := a.trustAnchors[dbName]; ok && prevState == nil {
+		prevState = &schema.ImmutableState{Db: dbName, TxId: anchor.TxID, TxHash: anchor.TxHash}
+	}
+
 	if prevState != nil {
 		if isEmptyDB {
 			a.logger.Errorf(
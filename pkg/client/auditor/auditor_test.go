@@ -24,6 +24,7 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -62,6 +63,7 @@ func TestDefaultAuditor(t *testing.T) {
 		"immudb",
 		nil,
 		nil,
+		nil,
 		AuditNotificationConfig{},
 		nil,
 		nil,
@@ -91,6 +93,7 @@ func TestDefaultAuditorPasswordDecodeErr(t *testing.T) {
 		"enc:"+string([]byte{0}),
 		nil,
 		nil,
+		nil,
 		AuditNotificationConfig{},
 		nil,
 		nil,
@@ -124,6 +127,7 @@ func TestDefaultAuditorLoginErr(t *testing.T) {
 		"immudb",
 		nil,
 		nil,
+		nil,
 		AuditNotificationConfig{},
 		&serviceClient,
 		state.NewUUIDProvider(&serviceClient),
@@ -161,6 +165,7 @@ func TestDefaultAuditorDatabaseListErr(t *testing.T) {
 		"immudb",
 		nil,
 		nil,
+		nil,
 		AuditNotificationConfig{},
 		&serviceClient,
 		state.NewUUIDProvider(&serviceClient),
@@ -200,6 +205,7 @@ func TestDefaultAuditorDatabaseListEmpty(t *testing.T) {
 		"immudb",
 		nil,
 		nil,
+		nil,
 		AuditNotificationConfig{},
 		&serviceClient,
 		state.NewUUIDProvider(&serviceClient),
@@ -242,6 +248,7 @@ func TestDefaultAuditorUseDatabaseErr(t *testing.T) {
 		"immudb",
 		nil,
 		nil,
+		nil,
 		AuditNotificationConfig{},
 		&serviceClient,
 		state.NewUUIDProvider(&serviceClient),
@@ -287,6 +294,7 @@ func TestDefaultAuditorCurrentRootErr(t *testing.T) {
 		"immudb",
 		nil,
 		nil,
+		nil,
 		AuditNotificationConfig{},
 		&serviceClient,
 		state.NewUUIDProvider(&serviceClient),
@@ -324,6 +332,7 @@ func TestDefaultAuditorRunOnEmptyDb(t *testing.T) {
 		"immudb",
 		nil,
 		nil,
+		nil,
 		AuditNotificationConfig{},
 		serviceClient,
 		state.NewUUIDProvider(serviceClient),
@@ -384,6 +393,7 @@ func TestDefaultAuditorRunOnDb(t *testing.T) {
 		"immudb",
 		nil,
 		nil,
+		nil,
 		AuditNotificationConfig{},
 		serviceClient,
 		state.NewUUIDProvider(serviceClient),
@@ -399,6 +409,82 @@ func TestDefaultAuditorRunOnDb(t *testing.T) {
 	require.Nil(t, err)
 }
 
+func TestDefaultAuditorRunWithTrustAnchor(t *testing.T) {
+	defer os.RemoveAll(dirname)
+
+	bs := servertest.NewBufconnServer(server.DefaultOptions().WithDir(dirname).WithAuth(true).WithAdminPassword(auth.SysAdminPassword))
+	bs.Start()
+	defer bs.Stop()
+
+	ctx := context.Background()
+
+	dialOptions := []grpc.DialOption{
+		grpc.WithContextDialer(bs.Dialer), grpc.WithInsecure(),
+	}
+	ts := client.NewTokenService().WithTokenFileName("testTokenFile").WithHds(client.NewHomedirService())
+	cliopt := client.DefaultOptions().WithDialOptions(&dialOptions).WithTokenService(ts)
+
+	cli, err := client.NewImmuClient(cliopt)
+	require.NoError(t, err)
+	lresp, err := cli.Login(ctx, []byte("immudb"), []byte("immudb"))
+	require.NoError(t, err)
+
+	md := metadata.Pairs("authorization", lresp.Token)
+	ctx = metadata.NewOutgoingContext(context.Background(), md)
+
+	_, err = cli.Set(ctx, []byte(`key`), []byte(`val`))
+	require.NoError(t, err)
+
+	anchorState, err := cli.CurrentState(ctx)
+	require.NoError(t, err)
+
+	_, err = cli.Set(ctx, []byte(`key`), []byte(`val2`))
+	require.NoError(t, err)
+
+	clientConn, err := grpc.Dial("add", dialOptions...)
+	require.NoError(t, err)
+	serviceClient := schema.NewImmuServiceClient(clientConn)
+
+	audit := func(anchor *client.TrustAnchor, historyDir string) (checked, verified bool) {
+		da, err := DefaultAuditor(
+			time.Duration(0),
+			fmt.Sprintf("%s:%d", "address", 0),
+			&dialOptions,
+			"immudb",
+			"immudb",
+			[]string{"defaultdb"},
+			nil,
+			map[string]*client.TrustAnchor{"defaultdb": anchor},
+			AuditNotificationConfig{},
+			serviceClient,
+			state.NewUUIDProvider(serviceClient),
+			cache.NewHistoryFileCache(historyDir),
+			func(_, _ string, c, _, v bool, _, _ *schema.ImmutableState) {
+				checked, verified = c, v
+			},
+			logger.NewSimpleLogger("test", os.Stdout))
+		require.NoError(t, err)
+
+		err = da.(*defaultAuditor).audit()
+		require.NoError(t, err)
+
+		return checked, verified
+	}
+
+	// the first audit already checks the history of the database is consistent with the trust anchor
+	checked, verified := audit(&client.TrustAnchor{TxID: anchorState.TxId, TxHash: anchorState.TxHash}, filepath.Join(dirname, "genuine"))
+	require.True(t, checked)
+	require.True(t, verified)
+
+	tamperedHash := make([]byte, len(anchorState.TxHash))
+	copy(tamperedHash, anchorState.TxHash)
+	tamperedHash[0]++
+
+	checked, verified = audit(&client.TrustAnchor{TxID: anchorState.TxId, TxHash: tamperedHash}, filepath.Join(dirname, "tampered"))
+	require.True(t, checked)
+	require.False(t, verified)
+}
+
 func TestRepeatedAuditorRunOnDb(t *testing.T) {
 	defer os.RemoveAll(dirname)
 
@@ -460,6 +546,7 @@ func TestRepeatedAuditorRunOnDb(t *testing.T) {
 		"immudb",
 		[]string{"SomeNonExistentDb", ""},
 		nil,
+		nil,
 		alertConfig,
 		serviceClient,
 		state.NewUUIDProvider(serviceClient),
@@ -535,6 +622,7 @@ func TestDefaultAuditorRunOnDbWithSignature(t *testing.T) {
 		"immudb",
 		nil,
 		pk,
+		nil,
 		AuditNotificationConfig{},
 		serviceClient,
 		state.NewUUIDProvider(serviceClient),
@@ -590,6 +678,7 @@ func TestDefaultAuditorRunOnDbWithFailSignature(t *testing.T) {
 		"immudb",
 		nil,
 		pk,
+		nil,
 		AuditNotificationConfig{},
 		serviceClient,
 		state.NewUUIDProvider(serviceClient),
@@ -17,6 +17,7 @@ limitations under the License.
 package client
 
 import (
+	"bytes"
 	"context"
 	"crypto/ecdsa"
 	"crypto/sha256"
@@ -44,6 +45,7 @@ import (
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/grpclog"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
@@ -58,6 +60,7 @@ type ImmuClient interface {
 	IsConnected() bool
 	WaitForHealthCheck(ctx context.Context) (err error)
 	HealthCheck(ctx context.Context) error
+	ServerInfo(ctx context.Context, req *schema.ServerInfoRequest) (*schema.ServerInfoResponse, error)
 	Connect(ctx context.Context) (clientConn *grpc.ClientConn, err error)
 
 	Login(ctx context.Context, user []byte, pass []byte) (*schema.LoginResponse, error)
@@ -66,6 +69,9 @@ type ImmuClient interface {
 	CreateUser(ctx context.Context, user []byte, pass []byte, permission uint32, databasename string) error
 	ListUsers(ctx context.Context) (*schema.UserList, error)
 	ChangePassword(ctx context.Context, user []byte, oldPass []byte, newPass []byte) error
+	ExportUsers(ctx context.Context) (*schema.UserList, error)
+	ImportUsers(ctx context.Context, users *schema.UserList) (*schema.ImportUsersResponse, error)
+	ResetPassword(ctx context.Context, user []byte, token string, newPass []byte) error
 	ChangePermission(ctx context.Context, action schema.PermissionAction, username string, database string, permissions uint32) error
 	UpdateAuthConfig(ctx context.Context, kind auth.Kind) error
 	UpdateMTLSConfig(ctx context.Context, enabled bool) error
@@ -85,6 +91,17 @@ type ImmuClient interface {
 
 	DatabaseList(ctx context.Context) (*schema.DatabaseListResponse, error)
 	CreateDatabase(ctx context.Context, d *schema.Database) error
+	DeleteDatabase(ctx context.Context, d *schema.Database) error
+	UndeleteDatabase(ctx context.Context, d *schema.Database) error
+	DeletedDatabaseList(ctx context.Context) (*schema.DeletedDatabaseListResponse, error)
+	ShrinkDatabase(ctx context.Context, d *schema.Database) error
+	CreateDatabaseWith(ctx context.Context, settings *schema.DatabaseSettings) error
+	UpdateDatabase(ctx context.Context, settings *schema.DatabaseSettings) error
+	GetDatabaseSettings(ctx context.Context) (*schema.DatabaseSettings, error)
+	RotateEncryptionKeys(ctx context.Context) (*schema.RotateEncryptionKeysResponse, error)
+	OpenedFiles(ctx context.Context, d *schema.Database) (*schema.OpenedFilesResponse, error)
+	CloseCachedFiles(ctx context.Context, d *schema.Database) (*schema.CloseCachedFilesResponse, error)
+	CancelApplicationRequests(ctx context.Context, app string) (*schema.CancelApplicationRequestsResponse, error)
 	UseDatabase(ctx context.Context, d *schema.Database) (*schema.UseDatabaseReply, error)
 	SetActiveUser(ctx context.Context, u *schema.SetActiveUserRequest) error
 
@@ -98,10 +115,12 @@ type ImmuClient interface {
 	Get(ctx context.Context, key []byte) (*schema.Entry, error)
 	GetSince(ctx context.Context, key []byte, tx uint64) (*schema.Entry, error)
 	GetAt(ctx context.Context, key []byte, tx uint64) (*schema.Entry, error)
+	GetAsOf(ctx context.Context, key []byte, t time.Time) (*schema.Entry, error)
 
 	VerifiedGet(ctx context.Context, key []byte) (*schema.Entry, error)
 	VerifiedGetSince(ctx context.Context, key []byte, tx uint64) (*schema.Entry, error)
 	VerifiedGetAt(ctx context.Context, key []byte, tx uint64) (*schema.Entry, error)
+	VerifiedHistoricalGet(ctx context.Context, key []byte, atTx uint64) (*schema.Entry, error)
 
 	History(ctx context.Context, req *schema.HistoryRequest) (*schema.Entries, error)
 
@@ -109,14 +128,29 @@ type ImmuClient interface {
 	VerifiedZAdd(ctx context.Context, set []byte, score float64, key []byte) (*schema.TxMetadata, error)
 
 	ZAddAt(ctx context.Context, set []byte, score float64, key []byte, atTx uint64) (*schema.TxMetadata, error)
+	ZAddWithScoreMode(ctx context.Context, set []byte, scoreMode schema.ZAddScoreMode, key []byte) (*schema.TxMetadata, error)
 	VerifiedZAddAt(ctx context.Context, set []byte, score float64, key []byte, atTx uint64) (*schema.TxMetadata, error)
 
 	Scan(ctx context.Context, req *schema.ScanRequest) (*schema.Entries, error)
+	IndexScan(ctx context.Context, req *schema.IndexScanRequest) (*schema.Entries, error)
 	ZScan(ctx context.Context, req *schema.ZScanRequest) (*schema.ZEntries, error)
 
+	TSAppend(ctx context.Context, series []byte, samples []*schema.TSSample) (*schema.TxMetadata, error)
+	TSRange(ctx context.Context, req *schema.TSRangeRequest) (*schema.TSSamples, error)
+
+	Notarize(ctx context.Context, digests [][]byte) (*schema.NotarizationReceipts, error)
+	VerifyNotarizationReceipt(ctx context.Context, receipt *schema.NotarizationReceipt) (bool, error)
+
+	CreateCollection(ctx context.Context, name string, indexedFields []string) (*schema.TxMetadata, error)
+	ListCollections(ctx context.Context) (*schema.CollectionList, error)
+	InsertDocuments(ctx context.Context, collection string, documents []*structpb.Struct) (*schema.DocumentInsertResponse, error)
+	GetDocument(ctx context.Context, collection string, id string) (*schema.Document, error)
+	SearchDocuments(ctx context.Context, req *schema.DocumentSearchRequest) (*schema.DocumentList, error)
+
 	TxByID(ctx context.Context, tx uint64) (*schema.Tx, error)
 	VerifiedTxByID(ctx context.Context, tx uint64) (*schema.Tx, error)
 	TxScan(ctx context.Context, req *schema.TxScanRequest) (*schema.TxList, error)
+	Subscribe(ctx context.Context, req *schema.SubscribeRequest, handler func(tx *schema.Tx) error) error
 
 	Count(ctx context.Context, prefix []byte) (*schema.EntryCount, error)
 	CountAll(ctx context.Context) (*schema.EntryCount, error)
@@ -125,6 +159,7 @@ type ImmuClient interface {
 	GetAll(ctx context.Context, keys [][]byte) (*schema.Entries, error)
 
 	ExecAll(ctx context.Context, in *schema.ExecAllRequest) (*schema.TxMetadata, error)
+	ExecMultiDbAll(ctx context.Context, in *schema.ExecMultiDbAllRequest) (*schema.ExecMultiDbAllResponse, error)
 
 	SetReference(ctx context.Context, key []byte, referencedKey []byte) (*schema.TxMetadata, error)
 	VerifiedSetReference(ctx context.Context, key []byte, referencedKey []byte) (*schema.TxMetadata, error)
@@ -136,6 +171,7 @@ type ImmuClient interface {
 
 	StreamSet(ctx context.Context, kv []*stream.KeyValue) (*schema.TxMetadata, error)
 	StreamGet(ctx context.Context, k *schema.KeyRequest) (*schema.Entry, error)
+	StreamGetTo(ctx context.Context, k *schema.KeyRequest, w io.Writer) (*schema.Entry, error)
 	StreamVerifiedSet(ctx context.Context, kv []*stream.KeyValue) (*schema.TxMetadata, error)
 	StreamVerifiedGet(ctx context.Context, k *schema.VerifiableGetRequest) (*schema.Entry, error)
 	StreamScan(ctx context.Context, req *schema.ScanRequest) (*schema.Entries, error)
@@ -143,13 +179,24 @@ type ImmuClient interface {
 	StreamHistory(ctx context.Context, req *schema.HistoryRequest) (*schema.Entries, error)
 	StreamExecAll(ctx context.Context, req *stream.ExecAllRequest) (*schema.TxMetadata, error)
 
+	ExportTxRange(ctx context.Context, sinceTx, upToTx uint64, w io.Writer, recipients ...string) (int64, error)
+	ApplyTxRange(ctx context.Context, r io.Reader, size int) (*schema.TxRange, error)
+
 	SQLExec(ctx context.Context, sql string, params map[string]interface{}) (*schema.SQLExecResult, error)
+	SQLExecInTx(ctx context.Context, txID string, sql string, params map[string]interface{}) (*schema.SQLExecResult, error)
+	SQLBatch(ctx context.Context, stmts []*SQLBatchStmt, noWait bool) (*schema.SQLBatchResult, error)
 	UseSnapshot(ctx context.Context, sinceTx, asBeforeTx uint64) error
+	UseSnapshotWithIsolationLevel(ctx context.Context, sinceTx, asBeforeTx uint64, level schema.IsolationLevel) error
 	SQLQuery(ctx context.Context, sql string, params map[string]interface{}, renewSnapshot bool) (*schema.SQLQueryResult, error)
 	ListTables(ctx context.Context) (*schema.SQLQueryResult, error)
 	DescribeTable(ctx context.Context, tableName string) (*schema.SQLQueryResult, error)
+	ExportSQLSchema(ctx context.Context) (string, error)
+	ImportSQLSchema(ctx context.Context, ddl string) (*schema.SQLExecResult, error)
+	ExportTableParquet(ctx context.Context, table string, asOfTx uint64, w io.Writer) (uint64, error)
+	ExportQueryParquet(ctx context.Context, query string, params map[string]interface{}, w io.Writer) (uint64, error)
 
 	VerifyRow(ctx context.Context, row *schema.Row, table string, pkVal *schema.SQLValue) error
+	VerifiedSQLQuery(ctx context.Context, sql string, params map[string]interface{}) (*schema.SQLQueryResult, error)
 }
 
 const DefaultDB = "defaultdb"
@@ -232,6 +279,10 @@ func NewImmuClient(options *Options) (c ImmuClient, err error) {
 		return nil, logErr(l, "Unable to create state service: %s", err)
 	}
 
+	if len(options.TrustAnchors) > 0 {
+		stateService = newAnchoredStateService(stateService, serviceClient, options.TrustAnchors)
+	}
+
 	c.WithStateService(stateService)
 
 	return c, nil
@@ -311,6 +362,10 @@ func (c *immuClient) SetupDialOptions(options *Options) *[]grpc.DialOption {
 			opts = append(opts, grpc.WithStreamInterceptor(auth.ClientStreamInterceptor(token)))
 		}
 	}
+	if options.ApplicationName != "" {
+		uic = append(uic, ApplicationUnaryInterceptor(options.ApplicationName))
+		opts = append(opts, grpc.WithChainStreamInterceptor(ApplicationStreamInterceptor(options.ApplicationName)))
+	}
 	opts = append(opts, grpc.WithUnaryInterceptor(grpc_middleware.ChainUnaryClient(uic...)), grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(options.MaxRecvMsgSize)))
 
 	return &opts
@@ -423,6 +478,44 @@ func (c *immuClient) ChangePassword(ctx context.Context, user []byte, oldPass []
 	return err
 }
 
+// ExportUsers returns all the users of the server but the system admin, along with their permissions
+func (c *immuClient) ExportUsers(ctx context.Context) (*schema.UserList, error) {
+	if !c.IsConnected() {
+		return nil, ErrNotConnected
+	}
+
+	return c.ServiceClient.ExportUsers(ctx, new(empty.Empty))
+}
+
+// ImportUsers creates or updates the users previously exported with ExportUsers,
+// returning the tokens the new users need to choose their passwords
+func (c *immuClient) ImportUsers(ctx context.Context, users *schema.UserList) (*schema.ImportUsersResponse, error) {
+	if !c.IsConnected() {
+		return nil, ErrNotConnected
+	}
+
+	return c.ServiceClient.ImportUsers(ctx, users)
+}
+
+// ResetPassword sets the password of the user by means of a token returned by ImportUsers, no login is required
+func (c *immuClient) ResetPassword(ctx context.Context, user []byte, token string, newPass []byte) error {
+	start := time.Now()
+
+	if !c.IsConnected() {
+		return ErrNotConnected
+	}
+
+	_, err := c.ServiceClient.ResetPassword(ctx, &schema.ResetPasswordRequest{
+		User:        user,
+		Token:       token,
+		NewPassword: newPass,
+	})
+
+	c.Logger.Debugf("resetpassword finished in %s", time.Since(start))
+
+	return err
+}
+
 func (c *immuClient) UpdateAuthConfig(ctx context.Context, kind auth.Kind) error {
 	start := time.Now()
 
@@ -592,7 +685,7 @@ func (c *immuClient) verifiedGet(ctx context.Context, kReq *schema.KeyRequest) (
 
 	if vEntry.Entry.ReferencedBy == nil {
 		vTx = vEntry.Entry.Tx
-		kv = database.EncodeKV(kReq.Key, vEntry.Entry.Value)
+		kv = database.EncodeKVWithMetadata(kReq.Key, schema.KVMetadataFrom(vEntry.Entry.Metadata), vEntry.Entry.Value)
 	} else {
 		vTx = vEntry.Entry.ReferencedBy.Tx
 		kv = database.EncodeReference(vEntry.Entry.ReferencedBy.Key, vEntry.Entry.Key, vEntry.Entry.ReferencedBy.AtTx)
@@ -660,6 +753,147 @@ func (c *immuClient) verifiedGet(ctx context.Context, kReq *schema.KeyRequest) (
 	return vEntry.Entry, nil
 }
 
+// VerifiedHistoricalGet returns the entry the key resolved to right after tx atTx was committed.
+// Its inclusion is verified against the state at atTx, which is in turn verified to be consistent with the local state
+func (c *immuClient) VerifiedHistoricalGet(ctx context.Context, key []byte, atTx uint64) (*schema.Entry, error) {
+	err := c.StateService.CacheLock()
+	if err != nil {
+		return nil, err
+	}
+	defer c.StateService.CacheUnlock()
+
+	if !c.IsConnected() {
+		return nil, ErrNotConnected
+	}
+
+	start := time.Now()
+	defer c.Logger.Debugf("VerifiedHistoricalGet finished in %s", time.Since(start))
+
+	state, err := c.StateService.GetState(ctx, c.Options.CurrentDatabase)
+	if err != nil {
+		return nil, err
+	}
+
+	vEntry, err := c.ServiceClient.VerifiableHistoricalGet(ctx, &schema.VerifiableHistoricalGetRequest{
+		Key:  key,
+		AtTx: atTx,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	inclusionProof := schema.InclusionProofFrom(vEntry.InclusionProof)
+	dualProof := schema.DualProofFrom(vEntry.VerifiableTx.DualProof)
+
+	var vTx uint64
+	var kv *store.KV
+
+	if vEntry.Entry.ReferencedBy == nil {
+		vTx = vEntry.Entry.Tx
+		kv = database.EncodeKVWithMetadata(key, schema.KVMetadataFrom(vEntry.Entry.Metadata), vEntry.Entry.Value)
+	} else {
+		vTx = vEntry.Entry.ReferencedBy.Tx
+		kv = database.EncodeReference(vEntry.Entry.ReferencedBy.Key, vEntry.Entry.Key, vEntry.Entry.ReferencedBy.AtTx)
+	}
+
+	if dualProof.SourceTxMetadata == nil || dualProof.TargetTxMetadata == nil {
+		return nil, store.ErrCorruptedData
+	}
+
+	sourceAlh := dualProof.SourceTxMetadata.Alh()
+	rootAlh := dualProof.TargetTxMetadata.Alh()
+
+	verifies := store.VerifyInclusion(
+		inclusionProof,
+		kv,
+		schema.DigestFrom(vEntry.VerifiableTx.DualProof.SourceTxMetadata.EH))
+	if !verifies {
+		return nil, store.ErrCorruptedData
+	}
+
+	verifies = store.VerifyDualProof(
+		dualProof,
+		vTx,
+		atTx,
+		sourceAlh,
+		rootAlh,
+	)
+	if !verifies {
+		return nil, store.ErrCorruptedData
+	}
+
+	rootState := &schema.ImmutableState{
+		Db:        c.currentDatabase(),
+		TxId:      atTx,
+		TxHash:    rootAlh[:],
+		Signature: vEntry.VerifiableTx.Signature,
+	}
+
+	if c.serverSigningPubKey != nil {
+		ok, err := rootState.CheckSignature(c.serverSigningPubKey)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, store.ErrCorruptedData
+		}
+	}
+
+	if state.TxId == atTx {
+		if !bytes.Equal(state.TxHash, rootAlh[:]) {
+			return nil, store.ErrCorruptedData
+		}
+
+		return vEntry.Entry, nil
+	}
+
+	if state.TxId > 0 {
+		// the state at atTx must be consistent with the local state
+		vRootTx, err := c.ServiceClient.VerifiableTxById(ctx, &schema.VerifiableTxRequest{
+			Tx:           atTx,
+			ProveSinceTx: state.TxId,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var sourceID, targetID uint64
+		var sourceAlh, targetAlh [sha256.Size]byte
+
+		if state.TxId < atTx {
+			sourceID = state.TxId
+			sourceAlh = schema.DigestFrom(state.TxHash)
+			targetID = atTx
+			targetAlh = rootAlh
+		} else {
+			sourceID = atTx
+			sourceAlh = rootAlh
+			targetID = state.TxId
+			targetAlh = schema.DigestFrom(state.TxHash)
+		}
+
+		verifies := store.VerifyDualProof(
+			schema.DualProofFrom(vRootTx.DualProof),
+			sourceID,
+			targetID,
+			sourceAlh,
+			targetAlh,
+		)
+		if !verifies {
+			return nil, store.ErrCorruptedData
+		}
+	}
+
+	if state.TxId < atTx {
+		err = c.StateService.SetState(c.Options.CurrentDatabase, rootState)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return vEntry.Entry, nil
+}
+
 // GetSince ...
 func (c *immuClient) GetSince(ctx context.Context, key []byte, tx uint64) (*schema.Entry, error) {
 	if !c.IsConnected() {
@@ -684,6 +918,18 @@ func (c *immuClient) GetAt(ctx context.Context, key []byte, tx uint64) (*schema.
 	return c.ServiceClient.Get(ctx, &schema.KeyRequest{Key: key, AtTx: tx})
 }
 
+// GetAsOf returns the entry as it was right after the latest transaction committed at or before the given time
+func (c *immuClient) GetAsOf(ctx context.Context, key []byte, t time.Time) (*schema.Entry, error) {
+	if !c.IsConnected() {
+		return nil, ErrNotConnected
+	}
+
+	start := time.Now()
+	defer c.Logger.Debugf("get finished in %s", time.Since(start))
+
+	return c.ServiceClient.Get(ctx, &schema.KeyRequest{Key: key, AsOfTs: t.Unix()})
+}
+
 // Scan ...
 func (c *immuClient) Scan(ctx context.Context, req *schema.ScanRequest) (*schema.Entries, error) {
 	if !c.IsConnected() {
@@ -693,6 +939,15 @@ func (c *immuClient) Scan(ctx context.Context, req *schema.ScanRequest) (*schema
 	return c.ServiceClient.Scan(ctx, req)
 }
 
+// IndexScan ...
+func (c *immuClient) IndexScan(ctx context.Context, req *schema.IndexScanRequest) (*schema.Entries, error) {
+	if !c.IsConnected() {
+		return nil, ErrNotConnected
+	}
+
+	return c.ServiceClient.IndexScan(ctx, req)
+}
+
 // ZScan ...
 func (c *immuClient) ZScan(ctx context.Context, req *schema.ZScanRequest) (*schema.ZEntries, error) {
 	if !c.IsConnected() {
@@ -873,6 +1128,32 @@ func (c *immuClient) ExecAll(ctx context.Context, req *schema.ExecAllRequest) (*
 	return txmd, nil
 }
 
+// ExecMultiDbAll atomically executes the operations of each request into its database.
+// A partial commit error is returned if the txs were committed into only some of them, the server rolls them
+// forward into the remaining ones when restarted
+func (c *immuClient) ExecMultiDbAll(ctx context.Context, req *schema.ExecMultiDbAllRequest) (*schema.ExecMultiDbAllResponse, error) {
+	if !c.IsConnected() {
+		return nil, ErrNotConnected
+	}
+
+	res, err := c.ServiceClient.ExecMultiDbAll(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.Txs) != len(req.Requests) {
+		return nil, store.ErrCorruptedData
+	}
+
+	for i, txmd := range res.Txs {
+		if int(txmd.Nentries) != len(req.Requests[i].Request.Operations) {
+			return nil, store.ErrCorruptedData
+		}
+	}
+
+	return res, nil
+}
+
 // GetAll ...
 func (c *immuClient) GetAll(ctx context.Context, keys [][]byte) (*schema.Entries, error) {
 	if !c.IsConnected() {
@@ -1005,6 +1286,43 @@ func (c *immuClient) TxScan(ctx context.Context, req *schema.TxScanRequest) (*sc
 	return c.ServiceClient.TxScan(ctx, req)
 }
 
+// Subscribe calls the handler with every committed transaction, starting from req.InitialTx.
+// Transactions are received one at a time, a slow handler makes the server wait before sending new ones.
+// It returns once req.Limit transactions were handled, the context is done or the handler returns an error
+func (c *immuClient) Subscribe(ctx context.Context, req *schema.SubscribeRequest, handler func(tx *schema.Tx) error) error {
+	if !c.IsConnected() {
+		return ErrNotConnected
+	}
+
+	if handler == nil {
+		return ErrIllegalArguments
+	}
+
+	// the stream is released as soon as the subscription ends
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	str, err := c.ServiceClient.Subscribe(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	for {
+		tx, err := str.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		err = handler(tx)
+		if err != nil {
+			return err
+		}
+	}
+}
+
 // History ...
 func (c *immuClient) History(ctx context.Context, req *schema.HistoryRequest) (sl *schema.Entries, err error) {
 	if !c.IsConnected() {
@@ -1191,6 +1509,239 @@ func (c *immuClient) ZAddAt(ctx context.Context, set []byte, score float64, key
 	return txmd, nil
 }
 
+// ZAddWithScoreMode adds the key into the sorted set with a score assigned by the server,
+// either the id of the transaction or its commit time
+func (c *immuClient) ZAddWithScoreMode(ctx context.Context, set []byte, scoreMode schema.ZAddScoreMode, key []byte) (*schema.TxMetadata, error) {
+	if !c.IsConnected() {
+		return nil, ErrNotConnected
+	}
+
+	start := time.Now()
+	defer c.Logger.Debugf("zadd finished in %s", time.Since(start))
+
+	txmd, err := c.ServiceClient.ZAdd(ctx, &schema.ZAddRequest{
+		Set:       set,
+		Key:       key,
+		ScoreMode: scoreMode,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if int(txmd.Nentries) != 1 {
+		return nil, store.ErrCorruptedData
+	}
+
+	return txmd, nil
+}
+
+// TSAppend appends samples to a time series within a single transaction
+func (c *immuClient) TSAppend(ctx context.Context, series []byte, samples []*schema.TSSample) (*schema.TxMetadata, error) {
+	if !c.IsConnected() {
+		return nil, ErrNotConnected
+	}
+
+	start := time.Now()
+
+	txmd, err := c.ServiceClient.TSAppend(ctx, &schema.TSAppendRequest{
+		Series:  series,
+		Samples: samples,
+	})
+
+	c.Logger.Debugf("TSAppend finished in %s", time.Since(start))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if int(txmd.Nentries) != len(samples) {
+		return nil, store.ErrCorruptedData
+	}
+
+	return txmd, nil
+}
+
+// TSRange returns the samples of a time series within a range of timestamps, optionally downsampled by the server
+func (c *immuClient) TSRange(ctx context.Context, req *schema.TSRangeRequest) (*schema.TSSamples, error) {
+	if !c.IsConnected() {
+		return nil, ErrNotConnected
+	}
+
+	start := time.Now()
+
+	samples, err := c.ServiceClient.TSRange(ctx, req)
+
+	c.Logger.Debugf("TSRange finished in %s", time.Since(start))
+
+	return samples, err
+}
+
+// Notarize commits a batch of digests within a single transaction, the returned receipts are verified before being returned
+func (c *immuClient) Notarize(ctx context.Context, digests [][]byte) (*schema.NotarizationReceipts, error) {
+	if !c.IsConnected() {
+		return nil, ErrNotConnected
+	}
+
+	start := time.Now()
+
+	receipts, err := c.ServiceClient.Notarize(ctx, &schema.NotarizeRequest{Digests: digests})
+
+	c.Logger.Debugf("Notarize finished in %s", time.Since(start))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(receipts.Receipts) != len(digests) {
+		return nil, store.ErrCorruptedData
+	}
+
+	for i, r := range receipts.Receipts {
+		if !bytes.Equal(r.Digest, digests[i]) {
+			return nil, store.ErrCorruptedData
+		}
+
+		err = c.verifyNotarizationReceipt(r)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return receipts, nil
+}
+
+// VerifyNotarizationReceipt verifies the receipt locally and checks with the server it refers to a committed transaction
+func (c *immuClient) VerifyNotarizationReceipt(ctx context.Context, receipt *schema.NotarizationReceipt) (bool, error) {
+	if !c.IsConnected() {
+		return false, ErrNotConnected
+	}
+
+	err := c.verifyNotarizationReceipt(receipt)
+	if err == store.ErrCorruptedData {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	verification, err := c.ServiceClient.VerifyNotarizationReceipt(ctx, receipt)
+	if err != nil {
+		return false, err
+	}
+
+	return verification.Verified, nil
+}
+
+func (c *immuClient) verifyNotarizationReceipt(receipt *schema.NotarizationReceipt) error {
+	if !database.VerifyNotarizationReceipt(receipt) {
+		return store.ErrCorruptedData
+	}
+
+	if c.serverSigningPubKey != nil {
+		ok, err := receipt.State.CheckSignature(c.serverSigningPubKey)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return store.ErrCorruptedData
+		}
+	}
+
+	return nil
+}
+
+// CreateCollection creates a collection of JSON documents indexed by the given top-level fields
+func (c *immuClient) CreateCollection(ctx context.Context, name string, indexedFields []string) (*schema.TxMetadata, error) {
+	if !c.IsConnected() {
+		return nil, ErrNotConnected
+	}
+
+	start := time.Now()
+
+	txmd, err := c.ServiceClient.CreateCollection(ctx, &schema.Collection{
+		Name:          name,
+		IndexedFields: indexedFields,
+	})
+
+	c.Logger.Debugf("CreateCollection finished in %s", time.Since(start))
+
+	return txmd, err
+}
+
+// ListCollections returns the collections of the database
+func (c *immuClient) ListCollections(ctx context.Context) (*schema.CollectionList, error) {
+	if !c.IsConnected() {
+		return nil, ErrNotConnected
+	}
+
+	start := time.Now()
+
+	collections, err := c.ServiceClient.ListCollections(ctx, &empty.Empty{})
+
+	c.Logger.Debugf("ListCollections finished in %s", time.Since(start))
+
+	return collections, err
+}
+
+// InsertDocuments inserts documents into a collection within a single transaction, the ids of the documents are returned
+func (c *immuClient) InsertDocuments(ctx context.Context, collection string, documents []*structpb.Struct) (*schema.DocumentInsertResponse, error) {
+	if !c.IsConnected() {
+		return nil, ErrNotConnected
+	}
+
+	start := time.Now()
+
+	res, err := c.ServiceClient.InsertDocuments(ctx, &schema.DocumentInsertRequest{
+		Collection: collection,
+		Documents:  documents,
+	})
+
+	c.Logger.Debugf("InsertDocuments finished in %s", time.Since(start))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.Ids) != len(documents) {
+		return nil, store.ErrCorruptedData
+	}
+
+	return res, nil
+}
+
+// GetDocument returns the latest version of a document
+func (c *immuClient) GetDocument(ctx context.Context, collection string, id string) (*schema.Document, error) {
+	if !c.IsConnected() {
+		return nil, ErrNotConnected
+	}
+
+	start := time.Now()
+
+	doc, err := c.ServiceClient.GetDocument(ctx, &schema.DocumentGetRequest{
+		Collection: collection,
+		Id:         id,
+	})
+
+	c.Logger.Debugf("GetDocument finished in %s", time.Since(start))
+
+	return doc, err
+}
+
+// SearchDocuments returns the documents of a collection matching all the filters sorted by id
+func (c *immuClient) SearchDocuments(ctx context.Context, req *schema.DocumentSearchRequest) (*schema.DocumentList, error) {
+	if !c.IsConnected() {
+		return nil, ErrNotConnected
+	}
+
+	start := time.Now()
+
+	docs, err := c.ServiceClient.SearchDocuments(ctx, req)
+
+	c.Logger.Debugf("SearchDocuments finished in %s", time.Since(start))
+
+	return docs, err
+}
+
 // ZAdd ...
 func (c *immuClient) VerifiedZAdd(ctx context.Context, set []byte, score float64, key []byte) (*schema.TxMetadata, error) {
 	return c.VerifiedZAddAt(ctx, set, score, key, 0)
@@ -1335,6 +1886,15 @@ func (c *immuClient) HealthCheck(ctx context.Context) error {
 	return nil
 }
 
+// ServerInfo returns information about the server such as its version and the state of its features
+func (c *immuClient) ServerInfo(ctx context.Context, req *schema.ServerInfoRequest) (*schema.ServerInfoResponse, error) {
+	if !c.IsConnected() {
+		return nil, ErrNotConnected
+	}
+
+	return c.ServiceClient.ServerInfo(ctx, req)
+}
+
 func (c *immuClient) currentDatabase() string {
 	if c.Options.CurrentDatabase == "" {
 		return DefaultDB
@@ -1357,6 +1917,171 @@ func (c *immuClient) CreateDatabase(ctx context.Context, db *schema.Database) er
 	return err
 }
 
+// DeleteDatabase deletes a database, it's kept by the server during the configured retention period
+func (c *immuClient) DeleteDatabase(ctx context.Context, db *schema.Database) error {
+	start := time.Now()
+
+	if !c.IsConnected() {
+		return ErrNotConnected
+	}
+
+	_, err := c.ServiceClient.DeleteDatabase(ctx, db)
+
+	c.Logger.Debugf("DeleteDatabase finished in %s", time.Since(start))
+
+	return err
+}
+
+// UndeleteDatabase restores a deleted database which has not been purged yet
+func (c *immuClient) UndeleteDatabase(ctx context.Context, db *schema.Database) error {
+	start := time.Now()
+
+	if !c.IsConnected() {
+		return ErrNotConnected
+	}
+
+	_, err := c.ServiceClient.UndeleteDatabase(ctx, db)
+
+	c.Logger.Debugf("UndeleteDatabase finished in %s", time.Since(start))
+
+	return err
+}
+
+// DeletedDatabaseList returns the deleted databases which can still be restored
+func (c *immuClient) DeletedDatabaseList(ctx context.Context) (*schema.DeletedDatabaseListResponse, error) {
+	start := time.Now()
+
+	if !c.IsConnected() {
+		return nil, ErrNotConnected
+	}
+
+	result, err := c.ServiceClient.DeletedDatabaseList(ctx, &empty.Empty{})
+
+	c.Logger.Debugf("DeletedDatabaseList finished in %s", time.Since(start))
+
+	return result, err
+}
+
+// ShrinkDatabase rewrites a database into a compacted copy, reclaiming the space taken by discarded values
+func (c *immuClient) ShrinkDatabase(ctx context.Context, db *schema.Database) error {
+	start := time.Now()
+
+	if !c.IsConnected() {
+		return ErrNotConnected
+	}
+
+	_, err := c.ServiceClient.ShrinkDatabase(ctx, db)
+
+	c.Logger.Debugf("ShrinkDatabase finished in %s", time.Since(start))
+
+	return err
+}
+
+// CreateDatabaseWith creates a database with the given limits, zero valued limits take the defaults of the server
+func (c *immuClient) CreateDatabaseWith(ctx context.Context, settings *schema.DatabaseSettings) error {
+	start := time.Now()
+
+	if !c.IsConnected() {
+		return ErrNotConnected
+	}
+
+	_, err := c.ServiceClient.CreateDatabaseWith(ctx, settings)
+
+	c.Logger.Debugf("CreateDatabaseWith finished in %s", time.Since(start))
+
+	return err
+}
+
+// UpdateDatabase changes the settings of a database, only the max value length can be changed once it was created
+func (c *immuClient) UpdateDatabase(ctx context.Context, settings *schema.DatabaseSettings) error {
+	start := time.Now()
+
+	if !c.IsConnected() {
+		return ErrNotConnected
+	}
+
+	_, err := c.ServiceClient.UpdateDatabase(ctx, settings)
+
+	c.Logger.Debugf("UpdateDatabase finished in %s", time.Since(start))
+
+	return err
+}
+
+// GetDatabaseSettings returns the limits enforced by the database in use, so payloads can be validated before being sent
+func (c *immuClient) GetDatabaseSettings(ctx context.Context) (*schema.DatabaseSettings, error) {
+	start := time.Now()
+
+	if !c.IsConnected() {
+		return nil, ErrNotConnected
+	}
+
+	settings, err := c.ServiceClient.GetDatabaseSettings(ctx, &empty.Empty{})
+
+	c.Logger.Debugf("GetDatabaseSettings finished in %s", time.Since(start))
+
+	return settings, err
+}
+
+// RotateEncryptionKeys wraps the encryption key of every database with the current master key of the server key provider
+func (c *immuClient) RotateEncryptionKeys(ctx context.Context) (*schema.RotateEncryptionKeysResponse, error) {
+	start := time.Now()
+
+	if !c.IsConnected() {
+		return nil, ErrNotConnected
+	}
+
+	result, err := c.ServiceClient.RotateEncryptionKeys(ctx, &empty.Empty{})
+
+	c.Logger.Debugf("RotateEncryptionKeys finished in %s", time.Since(start))
+
+	return result, err
+}
+
+// CancelApplicationRequests cancels the in-flight requests tagged with the given application name
+func (c *immuClient) CancelApplicationRequests(ctx context.Context, app string) (*schema.CancelApplicationRequestsResponse, error) {
+	start := time.Now()
+
+	if !c.IsConnected() {
+		return nil, ErrNotConnected
+	}
+
+	result, err := c.ServiceClient.CancelApplicationRequests(ctx, &schema.CancelApplicationRequestsRequest{Application: app})
+
+	c.Logger.Debugf("CancelApplicationRequests finished in %s", time.Since(start))
+
+	return result, err
+}
+
+// OpenedFiles lists the files opened by a database, or by all the loaded databases when no name is given
+func (c *immuClient) OpenedFiles(ctx context.Context, db *schema.Database) (*schema.OpenedFilesResponse, error) {
+	start := time.Now()
+
+	if !c.IsConnected() {
+		return nil, ErrNotConnected
+	}
+
+	result, err := c.ServiceClient.OpenedFiles(ctx, db)
+
+	c.Logger.Debugf("OpenedFiles finished in %s", time.Since(start))
+
+	return result, err
+}
+
+// CloseCachedFiles closes the files kept open for reading by a database, or by all the loaded databases when no name is given
+func (c *immuClient) CloseCachedFiles(ctx context.Context, db *schema.Database) (*schema.CloseCachedFilesResponse, error) {
+	start := time.Now()
+
+	if !c.IsConnected() {
+		return nil, ErrNotConnected
+	}
+
+	result, err := c.ServiceClient.CloseCachedFiles(ctx, db)
+
+	c.Logger.Debugf("CloseCachedFiles finished in %s", time.Since(start))
+
+	return result, err
+}
+
 // UseDatabase create a new database by making a grpc call
 func (c *immuClient) UseDatabase(ctx context.Context, db *schema.Database) (*schema.UseDatabaseReply, error) {
 	start := time.Now()
@@ -38,7 +38,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
@@ -202,6 +204,11 @@ func testGet(ctx context.Context, t *testing.T, client ImmuClient) {
 	item, err = client.GetAt(ctx, []byte("key-n11"), txmd.Id)
 	require.NoError(t, err)
 	require.Equal(t, []byte("key-n11"), item.Key)
+
+	item, err = client.GetAsOf(ctx, []byte("key-n11"), time.Unix(txmd.Ts, 0))
+	require.NoError(t, err)
+	require.Equal(t, []byte("key-n11"), item.Key)
+	require.Equal(t, []byte("val-n11"), item.Value)
 }
 
 func testGetTxByID(ctx context.Context, t *testing.T, set []byte, scores []float64, keys [][]byte, values [][]byte, client ImmuClient) {
@@ -379,6 +386,9 @@ func TestImmuClientTampering(t *testing.T) {
 	_, err = client.ZAdd(ctx, []byte{7}, 1, []byte{1})
 	require.Equal(t, store.ErrCorruptedData, err)
 
+	_, err = client.ZAddWithScoreMode(ctx, []byte{7}, schema.ZAddScoreMode_TX_ID_SCORE, []byte{1})
+	require.Equal(t, store.ErrCorruptedData, err)
+
 	bs.Server.PostVerifiableZAddFn = func(ctx context.Context,
 		req *schema.VerifiableZAddRequest, res *schema.VerifiableTx, err error) (*schema.VerifiableTx, error) {
 
@@ -511,6 +521,7 @@ func TestImmuClientDisconnect(t *testing.T) {
 
 	require.Equal(t, ErrNotConnected, client.CreateUser(ctx, []byte("user"), []byte("passwd"), 1, "db"))
 	require.Equal(t, ErrNotConnected, client.ChangePassword(ctx, []byte("user"), []byte("oldPasswd"), []byte("newPasswd")))
+	require.Equal(t, ErrNotConnected, client.ResetPassword(ctx, []byte("user"), "token", []byte("newPasswd")))
 	require.Equal(t, ErrNotConnected, client.UpdateAuthConfig(ctx, auth.KindPassword))
 	require.Equal(t, ErrNotConnected, client.UpdateMTLSConfig(ctx, false))
 	require.Equal(t, ErrNotConnected, client.CleanIndex(ctx, &emptypb.Empty{}))
@@ -564,6 +575,9 @@ func TestImmuClientDisconnect(t *testing.T) {
 	_, err = client.TxScan(context.TODO(), nil)
 	require.Equal(t, ErrNotConnected, err)
 
+	err = client.Subscribe(context.TODO(), nil, nil)
+	require.Equal(t, ErrNotConnected, err)
+
 	_, err = client.History(context.TODO(), &schema.HistoryRequest{
 		Key: []byte("key"),
 	})
@@ -578,6 +592,15 @@ func TestImmuClientDisconnect(t *testing.T) {
 	_, err = client.ZAdd(context.TODO(), []byte("set"), 1, []byte("key"))
 	require.Equal(t, ErrNotConnected, err)
 
+	_, err = client.ZAddWithScoreMode(context.TODO(), []byte("set"), schema.ZAddScoreMode_TX_ID_SCORE, []byte("key"))
+	require.Equal(t, ErrNotConnected, err)
+
+	_, err = client.TSAppend(context.TODO(), []byte("series"), []*schema.TSSample{{Timestamp: 1, Value: 1}})
+	require.Equal(t, ErrNotConnected, err)
+
+	_, err = client.TSRange(context.TODO(), &schema.TSRangeRequest{Series: []byte("series")})
+	require.Equal(t, ErrNotConnected, err)
+
 	_, err = client.VerifiedZAdd(context.TODO(), []byte("set"), 1, []byte("key"))
 	require.Equal(t, ErrNotConnected, err)
 
@@ -590,10 +613,33 @@ func TestImmuClientDisconnect(t *testing.T) {
 	_, err = client.GetAt(context.TODO(), []byte("key"), 0)
 	require.Equal(t, ErrNotConnected, err)
 
+	_, err = client.GetAsOf(context.TODO(), []byte("key"), time.Now())
+	require.Equal(t, ErrNotConnected, err)
+
+	err = client.UseSnapshotWithIsolationLevel(context.TODO(), 0, 0, schema.IsolationLevel_READ_COMMITTED)
+	require.Equal(t, ErrNotConnected, err)
+
 	require.Equal(t, ErrNotConnected, client.HealthCheck(context.TODO()))
 
+	_, err = client.ServerInfo(context.TODO(), &schema.ServerInfoRequest{})
+	require.Equal(t, ErrNotConnected, err)
+
 	require.Equal(t, ErrNotConnected, client.CreateDatabase(context.TODO(), nil))
 
+	require.Equal(t, ErrNotConnected, client.DeleteDatabase(context.TODO(), nil))
+
+	require.Equal(t, ErrNotConnected, client.UndeleteDatabase(context.TODO(), nil))
+
+	_, err = client.DeletedDatabaseList(context.TODO())
+	require.Equal(t, ErrNotConnected, err)
+
+	require.Equal(t, ErrNotConnected, client.ShrinkDatabase(context.TODO(), nil))
+	require.Equal(t, ErrNotConnected, client.CreateDatabaseWith(context.TODO(), nil))
+	require.Equal(t, ErrNotConnected, client.UpdateDatabase(context.TODO(), nil))
+
+	_, err = client.GetDatabaseSettings(context.TODO())
+	require.Equal(t, ErrNotConnected, err)
+
 	_, err = client.UseDatabase(context.TODO(), nil)
 	require.Equal(t, ErrNotConnected, err)
 
@@ -647,6 +693,32 @@ func TestWaitForHealthCheck(t *testing.T) {
 	require.Nil(t, err)
 }
 
+func TestServerInfo(t *testing.T) {
+	options := server.DefaultOptions().WithAuth(true).WithFeatures("document-api")
+	bs := servertest.NewBufconnServer(options)
+
+	defer os.RemoveAll(options.Dir)
+	defer os.Remove(".state-")
+
+	bs.Start()
+	defer bs.Stop()
+
+	ts := NewTokenService().WithTokenFileName("testTokenFile").WithHds(DefaultHomedirServiceMock())
+	client, err := NewImmuClient(DefaultOptions().WithDialOptions(&[]grpc.DialOption{grpc.WithContextDialer(bs.Dialer), grpc.WithInsecure()}).WithTokenService(ts))
+	require.NoError(t, err)
+
+	info, err := client.ServerInfo(context.TODO(), &schema.ServerInfoRequest{})
+	require.NoError(t, err)
+
+	enabled := make(map[string]bool)
+	for _, f := range info.Features {
+		enabled[f.Name] = f.Enabled
+	}
+
+	require.True(t, enabled["document-api"])
+	require.False(t, enabled["async-replication"])
+}
+
 func TestWaitForHealthCheckFail(t *testing.T) {
 	client := DefaultClient()
 	err := client.WaitForHealthCheck(context.TODO())
@@ -770,6 +842,23 @@ func TestUserManagement(t *testing.T) {
 	require.Equal(t, "immudb", testUser.Createdby)
 	require.True(t, testUser.Active)
 
+	exported, err := client.ExportUsers(ctx)
+	require.NoError(t, err)
+	require.Len(t, exported.Users, 1)
+	require.Equal(t, "test", string(exported.Users[0].User))
+
+	exported.Users[0].User = []byte("imported")
+
+	imported, err := client.ImportUsers(ctx, exported)
+	require.NoError(t, err)
+	require.Len(t, imported.Tokens, 1)
+
+	err = client.ResetPassword(context.Background(), []byte("imported"), imported.Tokens[0].Token, []byte(userNewPassword))
+	require.NoError(t, err)
+
+	_, err = client.Login(context.TODO(), []byte("imported"), []byte(userNewPassword))
+	require.NoError(t, err)
+
 	client.Disconnect()
 }
 
@@ -844,6 +933,81 @@ func TestImmuClient_History(t *testing.T) {
 	client.Disconnect()
 }
 
+func TestImmuClient_ZAddWithScoreMode(t *testing.T) {
+	options := server.DefaultOptions().WithAuth(true)
+	bs := servertest.NewBufconnServer(options)
+
+	defer os.RemoveAll(options.Dir)
+	defer os.Remove(".state-")
+
+	bs.Start()
+	defer bs.Stop()
+
+	client, err := NewImmuClient(DefaultOptions().WithDialOptions(&[]grpc.DialOption{grpc.WithContextDialer(bs.Dialer), grpc.WithInsecure()}))
+	require.NoError(t, err)
+
+	lr, err := client.Login(context.TODO(), []byte(`immudb`), []byte(`immudb`))
+	require.NoError(t, err)
+
+	md := metadata.Pairs("authorization", lr.Token)
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	_, err = client.Set(ctx, []byte(`key1`), []byte(`val1`))
+	require.NoError(t, err)
+
+	txmd, err := client.ZAddWithScoreMode(ctx, []byte(`set1`), schema.ZAddScoreMode_TX_ID_SCORE, []byte(`key1`))
+	require.NoError(t, err)
+
+	entries, err := client.ZScan(ctx, &schema.ZScanRequest{Set: []byte(`set1`), SinceTx: txmd.Id})
+	require.NoError(t, err)
+	require.Len(t, entries.Entries, 1)
+	require.Equal(t, float64(txmd.Id), entries.Entries[0].Score)
+
+	client.Disconnect()
+}
+
+func TestImmuClient_TimeSeries(t *testing.T) {
+	options := server.DefaultOptions().WithAuth(true)
+	bs := servertest.NewBufconnServer(options)
+
+	defer os.RemoveAll(options.Dir)
+	defer os.Remove(".state-")
+
+	bs.Start()
+	defer bs.Stop()
+
+	client, err := NewImmuClient(DefaultOptions().WithDialOptions(&[]grpc.DialOption{grpc.WithContextDialer(bs.Dialer), grpc.WithInsecure()}))
+	require.NoError(t, err)
+
+	lr, err := client.Login(context.TODO(), []byte(`immudb`), []byte(`immudb`))
+	require.NoError(t, err)
+
+	md := metadata.Pairs("authorization", lr.Token)
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	txmd, err := client.TSAppend(ctx, []byte(`temperature`), []*schema.TSSample{
+		{Timestamp: 60, Value: 20},
+		{Timestamp: 90, Value: 22},
+		{Timestamp: 120, Value: 21},
+	})
+	require.NoError(t, err)
+
+	res, err := client.TSRange(ctx, &schema.TSRangeRequest{
+		Series:      []byte(`temperature`),
+		Window:      60,
+		Aggregation: schema.TSAggregation_MAX,
+		SinceTx:     txmd.Id,
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Samples, 2)
+	require.Equal(t, int64(60), res.Samples[0].Timestamp)
+	require.Equal(t, float64(22), res.Samples[0].Value)
+	require.Equal(t, int64(120), res.Samples[1].Timestamp)
+	require.Equal(t, float64(21), res.Samples[1].Value)
+
+	client.Disconnect()
+}
+
 func TestImmuClient_SetAll(t *testing.T) {
 	options := server.DefaultOptions().WithAuth(true)
 	options.StoreOptions.WithIndexOptions(options.StoreOptions.IndexOpts.WithCompactionThld(0))
@@ -1071,6 +1235,65 @@ func TestImmuClient_TxScan(t *testing.T) {
 	require.Equal(t, database.TrimPrefix(txls.Txs[0].Entries[0].Key), []byte(`key1`))
 }
 
+func TestImmuClient_Subscribe(t *testing.T) {
+	options := server.DefaultOptions().WithAuth(true)
+	bs := servertest.NewBufconnServer(options)
+
+	defer os.RemoveAll(options.Dir)
+	defer os.Remove(".state-")
+
+	bs.Start()
+	defer bs.Stop()
+
+	ts := NewTokenService().WithTokenFileName("testTokenFile").WithHds(DefaultHomedirServiceMock())
+	client, err := NewImmuClient(DefaultOptions().WithDialOptions(&[]grpc.DialOption{grpc.WithContextDialer(bs.Dialer), grpc.WithInsecure()}).WithTokenService(ts))
+	require.NoError(t, err)
+	defer client.Disconnect()
+
+	lr, err := client.Login(context.TODO(), []byte(`immudb`), []byte(`immudb`))
+	require.NoError(t, err)
+
+	md := metadata.Pairs("authorization", lr.Token)
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	err = client.Subscribe(ctx, &schema.SubscribeRequest{InitialTx: 1}, nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = client.Set(ctx, []byte(`key1`), []byte(`val1`))
+	require.NoError(t, err)
+
+	go func() {
+		client.Set(ctx, []byte(`key2`), []byte(`val2`))
+		client.Set(ctx, []byte(`key3`), []byte(`val3`))
+	}()
+
+	var keys [][]byte
+
+	err = client.Subscribe(ctx, &schema.SubscribeRequest{InitialTx: 1, Limit: 3}, func(tx *schema.Tx) error {
+		require.Equal(t, uint64(len(keys)+1), tx.Metadata.Id)
+		keys = append(keys, database.TrimPrefix(tx.Entries[0].Key))
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{[]byte(`key1`), []byte(`key2`), []byte(`key3`)}, keys)
+
+	errHandler := errors.New("handler error")
+
+	err = client.Subscribe(ctx, &schema.SubscribeRequest{InitialTx: 2}, func(tx *schema.Tx) error {
+		return errHandler
+	})
+	require.Equal(t, errHandler, err)
+
+	// waiting for transactions not yet committed is interrupted once the context is done
+	cctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+
+	err = client.Subscribe(cctx, &schema.SubscribeRequest{InitialTx: 4}, func(tx *schema.Tx) error {
+		return nil
+	})
+	require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+}
+
 func TestImmuClient_Logout(t *testing.T) {
 	options := server.DefaultOptions().WithAuth(true)
 	bs := servertest.NewBufconnServer(options)
@@ -1538,6 +1761,124 @@ func TestImmuClient_VerifiedGetSince(t *testing.T) {
 	client.Disconnect()
 }
 
+func TestImmuClient_VerifiedHistoricalGet(t *testing.T) {
+	options := server.DefaultOptions().WithAuth(true).WithSigningKey("./../../test/signer/ec1.key")
+	bs := servertest.NewBufconnServer(options)
+
+	defer os.RemoveAll(options.Dir)
+	defer os.Remove(".state-")
+
+	bs.Start()
+	defer bs.Stop()
+
+	ts := NewTokenService().WithTokenFileName("testTokenFile").WithHds(DefaultHomedirServiceMock())
+	opts := DefaultOptions().WithDialOptions(&[]grpc.DialOption{grpc.WithContextDialer(bs.Dialer), grpc.WithInsecure()}).WithTokenService(ts)
+	client, err := NewImmuClient(opts.WithServerSigningPubKey("./../../test/signer/ec1.pub"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	lr, err := client.Login(context.TODO(), []byte(`immudb`), []byte(`immudb`))
+	if err != nil {
+		log.Fatal(err)
+	}
+	md := metadata.Pairs("authorization", lr.Token)
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	txMeta1, err := client.Set(ctx, []byte(`key1`), []byte(`val1`))
+	require.NoError(t, err)
+	_, err = client.VerifiedSet(ctx, []byte(`key1`), []byte(`val2`))
+	require.NoError(t, err)
+
+	// historical root older than the local state
+	entry, err := client.VerifiedHistoricalGet(ctx, []byte(`key1`), txMeta1.Id)
+	require.NoError(t, err)
+	require.Equal(t, []byte(`val1`), entry.Value)
+	require.Equal(t, txMeta1.Id, entry.Tx)
+
+	txMeta3, err := client.Set(ctx, []byte(`key2`), []byte(`val3`))
+	require.NoError(t, err)
+
+	// historical root newer than the local state
+	entry, err = client.VerifiedHistoricalGet(ctx, []byte(`key1`), txMeta3.Id)
+	require.NoError(t, err)
+	require.Equal(t, []byte(`val2`), entry.Value)
+
+	// historical root matching the local state
+	entry, err = client.VerifiedHistoricalGet(ctx, []byte(`key2`), txMeta3.Id)
+	require.NoError(t, err)
+	require.Equal(t, []byte(`val3`), entry.Value)
+
+	_, err = client.VerifiedHistoricalGet(ctx, []byte(`key2`), txMeta1.Id)
+	require.Error(t, err)
+
+	client.Disconnect()
+
+	_, err = client.VerifiedHistoricalGet(ctx, []byte(`key1`), txMeta1.Id)
+	require.Equal(t, ErrNotConnected, err)
+}
+
+func TestImmuClient_Notarize(t *testing.T) {
+	options := server.DefaultOptions().WithAuth(true).WithSigningKey("./../../test/signer/ec1.key")
+	bs := servertest.NewBufconnServer(options)
+
+	defer os.RemoveAll(options.Dir)
+	defer os.Remove(".state-")
+
+	bs.Start()
+	defer bs.Stop()
+
+	ts := NewTokenService().WithTokenFileName("testTokenFile").WithHds(DefaultHomedirServiceMock())
+	opts := DefaultOptions().WithDialOptions(&[]grpc.DialOption{grpc.WithContextDialer(bs.Dialer), grpc.WithInsecure()}).WithTokenService(ts)
+	client, err := NewImmuClient(opts.WithServerSigningPubKey("./../../test/signer/ec1.pub"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	lr, err := client.Login(context.TODO(), []byte(`immudb`), []byte(`immudb`))
+	if err != nil {
+		log.Fatal(err)
+	}
+	md := metadata.Pairs("authorization", lr.Token)
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	digests := [][]byte{[]byte(`digest1`), []byte(`digest2`)}
+
+	receipts, err := client.Notarize(ctx, digests)
+	require.NoError(t, err)
+	require.Len(t, receipts.Receipts, 2)
+	require.NotNil(t, receipts.Receipts[0].State.Signature)
+
+	verified, err := client.VerifyNotarizationReceipt(ctx, receipts.Receipts[1])
+	require.NoError(t, err)
+	require.True(t, verified)
+
+	// the signature does not cover a different state
+	r := receipts.Receipts[1]
+
+	tampered := &schema.NotarizationReceipt{
+		Digest:         r.Digest,
+		TxMetadata:     r.TxMetadata,
+		InclusionProof: r.InclusionProof,
+		State: &schema.ImmutableState{
+			Db:        "otherdb",
+			TxId:      r.State.TxId,
+			TxHash:    r.State.TxHash,
+			Signature: r.State.Signature,
+		},
+	}
+
+	verified, err = client.VerifyNotarizationReceipt(ctx, tampered)
+	require.NoError(t, err)
+	require.False(t, verified)
+
+	client.Disconnect()
+
+	_, err = client.Notarize(ctx, digests)
+	require.Equal(t, ErrNotConnected, err)
+
+	_, err = client.VerifyNotarizationReceipt(ctx, receipts.Receipts[0])
+	require.Equal(t, ErrNotConnected, err)
+}
+
 func TestImmuClient_BackupAndRestoreUX(t *testing.T) {
 	stateFileDir := path.Join(os.TempDir(), "testStates")
 	dir := path.Join(os.TempDir(), "data")
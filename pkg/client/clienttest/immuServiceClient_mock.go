@@ -53,6 +53,7 @@ type ImmuServiceClientMock struct {
 	VerifiableTxByIdF       func(ctx context.Context, in *schema.VerifiableTxRequest, opts ...grpc.CallOption) (*schema.VerifiableTx, error)
 	HistoryF                func(ctx context.Context, in *schema.HistoryRequest, opts ...grpc.CallOption) (*schema.Entries, error)
 	HealthF                 func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*schema.HealthResponse, error)
+	ServerInfoF             func(ctx context.Context, in *schema.ServerInfoRequest, opts ...grpc.CallOption) (*schema.ServerInfoResponse, error)
 	CurrentStateF           func(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*schema.ImmutableState, error)
 	SetReferenceF           func(ctx context.Context, in *schema.ReferenceRequest, opts ...grpc.CallOption) (*schema.TxMetadata, error)
 	VerifiableSetReferenceF func(ctx context.Context, in *schema.VerifiableReferenceRequest, opts ...grpc.CallOption) (*schema.VerifiableTx, error)
@@ -154,6 +155,10 @@ func (icm *ImmuServiceClientMock) Health(ctx context.Context, in *empty.Empty, o
 	return icm.HealthF(ctx, in, opts...)
 }
 
+func (icm *ImmuServiceClientMock) ServerInfo(ctx context.Context, in *schema.ServerInfoRequest, opts ...grpc.CallOption) (*schema.ServerInfoResponse, error) {
+	return icm.ServerInfoF(ctx, in, opts...)
+}
+
 func (icm *ImmuServiceClientMock) CurrentState(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*schema.ImmutableState, error) {
 	return icm.CurrentStateF(ctx, in, opts...)
 }
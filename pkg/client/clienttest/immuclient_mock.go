@@ -62,6 +62,12 @@ type ImmuClientMock struct {
 	CountF                func(context.Context, []byte) (*schema.EntryCount, error)
 	CreateDatabaseF       func(context.Context, *schema.Database) error
 	DatabaseListF         func(context.Context) (*schema.DatabaseListResponse, error)
+	DeleteDatabaseF       func(context.Context, *schema.Database) error
+	UndeleteDatabaseF     func(context.Context, *schema.Database) error
+	ShrinkDatabaseF       func(context.Context, *schema.Database) error
+	CreateDatabaseWithF   func(context.Context, *schema.DatabaseSettings) error
+	UpdateDatabaseF       func(context.Context, *schema.DatabaseSettings) error
+	GetDatabaseSettingsF  func(context.Context) (*schema.DatabaseSettings, error)
 	ChangePasswordF       func(context.Context, []byte, []byte, []byte) error
 	CreateUserF           func(context.Context, []byte, []byte, uint32, string) error
 }
@@ -236,6 +242,36 @@ func (icm *ImmuClientMock) CreateDatabase(ctx context.Context, db *schema.Databa
 	return icm.CreateDatabaseF(ctx, db)
 }
 
+// DeleteDatabase ...
+func (icm *ImmuClientMock) DeleteDatabase(ctx context.Context, db *schema.Database) error {
+	return icm.DeleteDatabaseF(ctx, db)
+}
+
+// UndeleteDatabase ...
+func (icm *ImmuClientMock) UndeleteDatabase(ctx context.Context, db *schema.Database) error {
+	return icm.UndeleteDatabaseF(ctx, db)
+}
+
+// ShrinkDatabase ...
+func (icm *ImmuClientMock) ShrinkDatabase(ctx context.Context, db *schema.Database) error {
+	return icm.ShrinkDatabaseF(ctx, db)
+}
+
+// CreateDatabaseWith ...
+func (icm *ImmuClientMock) CreateDatabaseWith(ctx context.Context, settings *schema.DatabaseSettings) error {
+	return icm.CreateDatabaseWithF(ctx, settings)
+}
+
+// UpdateDatabase ...
+func (icm *ImmuClientMock) UpdateDatabase(ctx context.Context, settings *schema.DatabaseSettings) error {
+	return icm.UpdateDatabaseF(ctx, settings)
+}
+
+// GetDatabaseSettings ...
+func (icm *ImmuClientMock) GetDatabaseSettings(ctx context.Context) (*schema.DatabaseSettings, error) {
+	return icm.GetDatabaseSettingsF(ctx)
+}
+
 // DatabaseList ...
 func (icm *ImmuClientMock) DatabaseList(ctx context.Context) (*schema.DatabaseListResponse, error) {
 	return icm.DatabaseListF(ctx)
@@ -56,6 +56,10 @@ type Options struct {
 	LogFileName         string
 	ServerSigningPubKey string
 	StreamChunkSize     int
+	// ApplicationName tags the requests so the server can identify the application sending them
+	ApplicationName string
+	// TrustAnchors holds the states known to be genuine of some databases, by database name
+	TrustAnchors map[string]*TrustAnchor
 }
 
 // DefaultOptions ...
@@ -228,6 +232,29 @@ func (o *Options) WithStreamChunkSize(streamChunkSize int) *Options {
 	return o
 }
 
+// WithApplicationName sets the application name requests are tagged with, used by the server
+// for per-application metrics, quotas and cancellation
+func (o *Options) WithApplicationName(app string) *Options {
+	o.ApplicationName = app
+	return o
+}
+
+// WithTrustAnchor pins a state of the database known to be genuine, e.g. the one reached by its first transaction.
+// The states of the database received from the server must be consistent with it
+func (o *Options) WithTrustAnchor(db string, txID uint64, txHash []byte) *Options {
+	if o.TrustAnchors == nil {
+		o.TrustAnchors = make(map[string]*TrustAnchor)
+	}
+	o.TrustAnchors[db] = &TrustAnchor{TxID: txID, TxHash: txHash}
+	return o
+}
+
+// WithTrustAnchors sets the trust anchors of the databases, by database name
+func (o *Options) WithTrustAnchors(anchors map[string]*TrustAnchor) *Options {
+	o.TrustAnchors = anchors
+	return o
+}
+
 func (o *Options) String() string {
 	optionsJSON, err := json.Marshal(o)
 	if err != nil {
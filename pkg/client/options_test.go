@@ -45,7 +45,8 @@ func TestOptions(t *testing.T) {
 		WithUsername("some-username").
 		WithPassword("some-password").
 		WithDatabase("some-db").
-		WithStreamChunkSize(4096)
+		WithStreamChunkSize(4096).
+		WithApplicationName("reporting")
 
 	if op.LogFileName != "logfilename" ||
 		op.PrometheusHost != "localhost" ||
@@ -69,6 +70,7 @@ func TestOptions(t *testing.T) {
 		op.Password != "some-password" ||
 		op.Database != "some-db" ||
 		op.StreamChunkSize != 4096 ||
+		op.ApplicationName != "reporting" ||
 		op.Bind() != "127.0.0.1:4321" ||
 		len(op.String()) == 0 {
 		t.Fatal("Client options fail")
@@ -0,0 +1,293 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codenotary/immudb/embedded/sql"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/parquet"
+)
+
+// exportBatchSize is the number of rows fetched by each query while exporting a table,
+// it matches the maximum number of rows returned by the server for a single query
+const exportBatchSize = 1000
+
+var tableNameRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// ExportTableParquet writes the rows of a table to w as a Parquet file, returning the number of exported rows.
+// Rows are exported as they were right after asOfTx was committed or, when zero, as of the last tx committed
+// when the export starts, so rows fetched in different batches are consistent with each other
+func (c *immuClient) ExportTableParquet(ctx context.Context, table string, asOfTx uint64, w io.Writer) (uint64, error) {
+	if !tableNameRegexp.MatchString(table) || w == nil {
+		return 0, ErrIllegalArguments
+	}
+
+	if !c.IsConnected() {
+		return 0, ErrNotConnected
+	}
+
+	start := time.Now()
+
+	if asOfTx == 0 {
+		state, err := c.CurrentState(ctx)
+		if err != nil {
+			return 0, err
+		}
+		asOfTx = state.TxId
+	}
+
+	desc, err := c.DescribeTable(ctx, table)
+	if err != nil {
+		return 0, err
+	}
+
+	var pkName string
+
+	// columns are explicitly selected as the order of the columns selected with * is not defined
+	cols := make([]string, len(desc.Rows))
+	pkPos := -1
+
+	for i, r := range desc.Rows {
+		cols[i] = r.Values[0].GetS()
+
+		if r.Values[3].GetS() == "PRIMARY KEY" {
+			pkName = cols[i]
+			pkPos = i
+		}
+	}
+
+	if pkPos < 0 {
+		return 0, sql.ErrCorruptedData
+	}
+
+	from := fmt.Sprintf("SELECT %s FROM (%s BEFORE TX %d)", strings.Join(cols, ", "), table, asOfTx+1)
+
+	res, err := c.SQLQuery(ctx, fmt.Sprintf("%s LIMIT %d", from, exportBatchSize), nil, true)
+	if err != nil {
+		return 0, err
+	}
+
+	opts := parquet.DefaultOptions().
+		WithMetadata("immudb.database", c.currentDatabase()).
+		WithMetadata("immudb.table", table).
+		WithMetadata("immudb.tx", strconv.FormatUint(asOfTx, 10))
+
+	pw, err := newParquetWriter(w, res.Columns, opts)
+	if err != nil {
+		return 0, err
+	}
+
+	for {
+		err = writeParquetRows(pw, res.Rows)
+		if err != nil {
+			return 0, err
+		}
+
+		if len(res.Rows) < exportBatchSize {
+			break
+		}
+
+		lastPK := sqlValueToParquet(res.Rows[len(res.Rows)-1].Values[pkPos])
+
+		res, err = c.SQLQuery(
+			ctx,
+			fmt.Sprintf("%s WHERE %s > @lastpk LIMIT %d", from, pkName, exportBatchSize),
+			map[string]interface{}{"lastpk": lastPK},
+			true,
+		)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	err = pw.Close()
+	if err != nil {
+		return 0, err
+	}
+
+	c.Logger.Debugf("ExportTableParquet finished in %s", time.Since(start))
+
+	return uint64(pw.NumRows()), nil
+}
+
+// ExportQueryParquet writes the result of a query to w as a Parquet file, returning the number of exported rows.
+// The result is fetched with a single query, hence it is limited to the number of rows the server returns per query.
+// Tables may be queried as they were at a given tx with the BEFORE TX or AS OF TIMESTAMP clauses of the query
+func (c *immuClient) ExportQueryParquet(ctx context.Context, query string, params map[string]interface{}, w io.Writer) (uint64, error) {
+	if query == "" || w == nil {
+		return 0, ErrIllegalArguments
+	}
+
+	if !c.IsConnected() {
+		return 0, ErrNotConnected
+	}
+
+	start := time.Now()
+
+	res, err := c.SQLQuery(ctx, query, params, true)
+	if err != nil {
+		return 0, err
+	}
+
+	opts := parquet.DefaultOptions().
+		WithMetadata("immudb.database", c.currentDatabase()).
+		WithMetadata("immudb.query", query)
+
+	pw, err := newParquetWriter(w, res.Columns, opts)
+	if err != nil {
+		return 0, err
+	}
+
+	err = writeParquetRows(pw, res.Rows)
+	if err != nil {
+		return 0, err
+	}
+
+	err = pw.Close()
+	if err != nil {
+		return 0, err
+	}
+
+	c.Logger.Debugf("ExportQueryParquet finished in %s", time.Since(start))
+
+	return uint64(pw.NumRows()), nil
+}
+
+func newParquetWriter(w io.Writer, cols []*schema.Column, opts *parquet.Options) (*parquet.Writer, error) {
+	pcols, err := parquetColumns(cols)
+	if err != nil {
+		return nil, err
+	}
+
+	return parquet.NewWriter(w, pcols, opts)
+}
+
+// parquetColumns maps the columns of a query result into Parquet columns, selectors are reduced to the
+// name of the column, qualified by the table name when there are several columns with the same name
+func parquetColumns(cols []*schema.Column) ([]parquet.Column, error) {
+	pcols := make([]parquet.Column, len(cols))
+	tables := make([]string, len(cols))
+	occurrences := make(map[string]int, len(cols))
+
+	for i, c := range cols {
+		var typ parquet.Type
+
+		switch c.Type {
+		case sql.IntegerType:
+			typ = parquet.Uint64
+		case sql.BooleanType:
+			typ = parquet.Boolean
+		case sql.VarcharType:
+			typ = parquet.String
+		case sql.BLOBType:
+			typ = parquet.Bytes
+		default:
+			return nil, fmt.Errorf("%w: column %s of type %s can not be exported", ErrIllegalArguments, c.Name, c.Type)
+		}
+
+		aggFn, _, table, col := decodeSelector(c.Name)
+
+		name := col
+		if aggFn != "" && col == "*" {
+			name = strings.ToLower(aggFn)
+		} else if aggFn != "" {
+			name = strings.ToLower(aggFn) + "_" + col
+		}
+
+		pcols[i] = parquet.Column{Name: name, Type: typ}
+		tables[i] = table
+		occurrences[name]++
+	}
+
+	used := make(map[string]struct{}, len(cols))
+
+	for i := range pcols {
+		if occurrences[pcols[i].Name] > 1 && tables[i] != "" {
+			pcols[i].Name = tables[i] + "_" + pcols[i].Name
+		}
+
+		name := pcols[i].Name
+
+		for n := 2; ; n++ {
+			_, exists := used[name]
+			if !exists {
+				break
+			}
+			name = fmt.Sprintf("%s_%d", pcols[i].Name, n)
+		}
+
+		pcols[i].Name = name
+		used[name] = struct{}{}
+	}
+
+	return pcols, nil
+}
+
+// decodeSelector splits a selector as encoded by sql.EncodeSelector, anything else is taken as a column name
+func decodeSelector(selector string) (aggFn, db, table, col string) {
+	i := strings.Index(selector, "(")
+	if i < 0 || !strings.HasSuffix(selector, ")") {
+		return "", "", "", selector
+	}
+
+	parts := strings.SplitN(selector[i+1:len(selector)-1], ".", 3)
+	if len(parts) != 3 {
+		return "", "", "", selector
+	}
+
+	return selector[:i], parts[0], parts[1], parts[2]
+}
+
+func writeParquetRows(pw *parquet.Writer, rows []*schema.Row) error {
+	values := make([]interface{}, 0)
+
+	for _, r := range rows {
+		values = values[:0]
+
+		for _, v := range r.Values {
+			values = append(values, sqlValueToParquet(v))
+		}
+
+		err := pw.Write(values)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sqlValueToParquet(v *schema.SQLValue) interface{} {
+	switch tv := v.GetValue().(type) {
+	case *schema.SQLValue_N:
+		return tv.N
+	case *schema.SQLValue_S:
+		return tv.S
+	case *schema.SQLValue_B:
+		return tv.B
+	case *schema.SQLValue_Bs:
+		return tv.Bs
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/parquet"
+	"github.com/codenotary/immudb/pkg/server"
+	"github.com/codenotary/immudb/pkg/server/servertest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func readParquet(t *testing.T, b []byte) *parquet.File {
+	f, err := parquet.Read(bytes.NewReader(b), int64(len(b)))
+	require.NoError(t, err)
+	return f
+}
+
+func TestImmuClient_ExportParquet(t *testing.T) {
+	options := server.DefaultOptions().WithAuth(true)
+	bs := servertest.NewBufconnServer(options)
+
+	defer os.RemoveAll(options.Dir)
+	defer os.Remove(".state-")
+
+	bs.Start()
+	defer bs.Stop()
+
+	client, err := NewImmuClient(DefaultOptions().WithDialOptions(&[]grpc.DialOption{grpc.WithContextDialer(bs.Dialer), grpc.WithInsecure()}))
+	require.NoError(t, err)
+	lr, err := client.Login(context.TODO(), []byte(`immudb`), []byte(`immudb`))
+	require.NoError(t, err)
+
+	md := metadata.Pairs("authorization", lr.Token)
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	_, err = client.SQLExec(ctx, "CREATE TABLE table1(id INTEGER, title VARCHAR, active BOOLEAN, payload BLOB, PRIMARY KEY id)", nil)
+	require.NoError(t, err)
+
+	rowCount := 2*exportBatchSize + 10
+
+	for i := 0; i < rowCount; i += 500 {
+		values := make([]string, 0, 500)
+		for j := i; j < i+500 && j < rowCount; j++ {
+			values = append(values, fmt.Sprintf("(%d, 'title%d', %v, x'%02x')", j, j, j%2 == 0, j%256))
+		}
+
+		_, err = client.SQLExec(ctx, "UPSERT INTO table1(id, title, active, payload) VALUES "+strings.Join(values, ", "), nil)
+		require.NoError(t, err)
+	}
+
+	state, err := client.CurrentState(ctx)
+	require.NoError(t, err)
+
+	_, err = client.SQLExec(ctx, "UPSERT INTO table1(id, title) VALUES (0, 'updated'), (1, NULL)", nil)
+	require.NoError(t, err)
+
+	t.Run("table export should include every row", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		n, err := client.ExportTableParquet(ctx, "table1", 0, &buf)
+		require.NoError(t, err)
+		require.Equal(t, uint64(rowCount), n)
+
+		f := readParquet(t, buf.Bytes())
+		require.Equal(t, []parquet.Column{
+			{Name: "id", Type: parquet.Uint64},
+			{Name: "title", Type: parquet.String},
+			{Name: "active", Type: parquet.Boolean},
+			{Name: "payload", Type: parquet.Bytes},
+		}, f.Columns)
+		require.Len(t, f.Rows, rowCount)
+		require.Equal(t, "table1", f.Metadata["immudb.table"])
+		require.Equal(t, fmt.Sprintf("%d", state.TxId+1), f.Metadata["immudb.tx"])
+
+		for i, r := range f.Rows {
+			require.Equal(t, uint64(i), r[0])
+		}
+
+		require.Equal(t, []interface{}{uint64(0), "updated", nil, nil}, f.Rows[0])
+		require.Equal(t, []interface{}{uint64(1), nil, nil, nil}, f.Rows[1])
+		require.Equal(t, []interface{}{uint64(2), "title2", true, []byte{2}}, f.Rows[2])
+	})
+
+	t.Run("table export as of a tx should include rows as they were", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		n, err := client.ExportTableParquet(ctx, "table1", state.TxId, &buf)
+		require.NoError(t, err)
+		require.Equal(t, uint64(rowCount), n)
+
+		f := readParquet(t, buf.Bytes())
+		require.Equal(t, []interface{}{uint64(0), "title0", true, []byte{0}}, f.Rows[0])
+		require.Equal(t, []interface{}{uint64(1), "title1", false, []byte{1}}, f.Rows[1])
+		require.Equal(t, fmt.Sprintf("%d", state.TxId), f.Metadata["immudb.tx"])
+	})
+
+	t.Run("query export should map selectors into column names", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		n, err := client.ExportQueryParquet(ctx, "SELECT active, COUNT() AS total, MAX(id) AS last_id FROM table1 WHERE id >= @id AND id < 10 GROUP BY active", map[string]interface{}{"id": 2}, &buf)
+		require.NoError(t, err)
+		require.Equal(t, uint64(2), n)
+
+		f := readParquet(t, buf.Bytes())
+		require.Equal(t, []parquet.Column{
+			{Name: "active", Type: parquet.Boolean},
+			{Name: "total", Type: parquet.Uint64},
+			{Name: "last_id", Type: parquet.Uint64},
+		}, f.Columns)
+		require.Equal(t, "SELECT active, COUNT() AS total, MAX(id) AS last_id FROM table1 WHERE id >= @id AND id < 10 GROUP BY active", f.Metadata["immudb.query"])
+	})
+
+	t.Run("export should fail with invalid arguments", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		_, err := client.ExportTableParquet(ctx, "table1; DROP TABLE table1", 0, &buf)
+		require.Equal(t, ErrIllegalArguments, err)
+
+		_, err = client.ExportTableParquet(ctx, "table1", 0, nil)
+		require.Equal(t, ErrIllegalArguments, err)
+
+		_, err = client.ExportTableParquet(ctx, "table2", 0, &buf)
+		require.Error(t, err)
+
+		_, err = client.ExportQueryParquet(ctx, "", nil, &buf)
+		require.Equal(t, ErrIllegalArguments, err)
+
+		_, err = client.ExportQueryParquet(ctx, "SELECT id FROM table2", nil, &buf)
+		require.Error(t, err)
+	})
+
+	err = client.Disconnect()
+	require.NoError(t, err)
+
+	_, err = client.ExportTableParquet(ctx, "table1", 0, &bytes.Buffer{})
+	require.Equal(t, ErrNotConnected, err)
+
+	_, err = client.ExportQueryParquet(ctx, "SELECT id FROM table1", nil, &bytes.Buffer{})
+	require.Equal(t, ErrNotConnected, err)
+}
+
+func TestParquetColumns(t *testing.T) {
+	cols, err := parquetColumns([]*schema.Column{
+		{Name: "(db1.table1.id)", Type: "INTEGER"},
+		{Name: "(db1.table2.id)", Type: "INTEGER"},
+		{Name: "(db1.table2.title)", Type: "VARCHAR"},
+		{Name: "COUNT(db1.table1.*)", Type: "INTEGER"},
+		{Name: "title", Type: "BLOB"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []parquet.Column{
+		{Name: "table1_id", Type: parquet.Uint64},
+		{Name: "table2_id", Type: parquet.Uint64},
+		{Name: "table2_title", Type: parquet.String},
+		{Name: "count", Type: parquet.Uint64},
+		{Name: "title", Type: parquet.Bytes},
+	}, cols)
+
+	cols, err = parquetColumns([]*schema.Column{
+		{Name: "(db1.table1.id)", Type: "INTEGER"},
+		{Name: "(db1.table2.id)", Type: "INTEGER"},
+		{Name: "table1_id", Type: "VARCHAR"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []parquet.Column{
+		{Name: "table1_id", Type: parquet.Uint64},
+		{Name: "table2_id", Type: parquet.Uint64},
+		{Name: "table1_id_2", Type: parquet.String},
+	}, cols)
+
+	_, err = parquetColumns([]*schema.Column{{Name: "(db1.table1.ts)", Type: "TIMESTAMP"}})
+	require.True(t, errors.Is(err, ErrIllegalArguments))
+}
@@ -19,6 +19,8 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/binary"
+	"reflect"
+	"time"
 
 	"github.com/codenotary/immudb/embedded/sql"
 	"github.com/codenotary/immudb/embedded/store"
@@ -41,6 +43,57 @@ func (c *immuClient) SQLExec(ctx context.Context, sql string, params map[string]
 	return c.ServiceClient.SQLExec(ctx, &schema.SQLExecRequest{Sql: sql, Params: namedParams})
 }
 
+// SQLExecInTx executes the statements within the transaction identified by txID, as returned by the statements
+// which began it, or outside of any transaction when txID is empty. Statements executed within a transaction are
+// deferred until COMMIT is executed, when they get committed together, or discarded on ROLLBACK. The result holds
+// the identifier of the transaction left open, if any, e.g.
+//
+//	res, _ := client.SQLExecInTx(ctx, "", "BEGIN", nil)
+//	client.SQLExecInTx(ctx, res.TransactionId, "UPSERT INTO mytable(id, title) VALUES (1, 'title1')", nil)
+//	client.SQLExecInTx(ctx, res.TransactionId, "COMMIT", nil)
+func (c *immuClient) SQLExecInTx(ctx context.Context, txID string, sql string, params map[string]interface{}) (*schema.SQLExecResult, error) {
+	if !c.IsConnected() {
+		return nil, ErrNotConnected
+	}
+
+	namedParams, err := encodeParams(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.ServiceClient.SQLExec(ctx, &schema.SQLExecRequest{Sql: sql, Params: namedParams, TransactionId: txID})
+}
+
+// SQLBatchStmt is a statement of a batch, along with the parameters it's executed with
+type SQLBatchStmt struct {
+	SQL    string
+	Params map[string]interface{}
+}
+
+// SQLBatch executes the statements in a single round trip and within a single transaction, either all of them
+// get committed or none does. Repeating a statement with different parameters is the way to write many rows at once
+func (c *immuClient) SQLBatch(ctx context.Context, stmts []*SQLBatchStmt, noWait bool) (*schema.SQLBatchResult, error) {
+	if !c.IsConnected() {
+		return nil, ErrNotConnected
+	}
+
+	req := &schema.SQLBatchRequest{
+		Stmts:  make([]*schema.SQLBatchStmt, len(stmts)),
+		NoWait: noWait,
+	}
+
+	for i, stmt := range stmts {
+		namedParams, err := encodeParams(stmt.Params)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Stmts[i] = &schema.SQLBatchStmt{Sql: stmt.SQL, Params: namedParams}
+	}
+
+	return c.ServiceClient.SQLBatch(ctx, req)
+}
+
 func (c *immuClient) UseSnapshot(ctx context.Context, sinceTx, asBeforeTx uint64) error {
 	if !c.IsConnected() {
 		return ErrNotConnected
@@ -50,6 +103,17 @@ func (c *immuClient) UseSnapshot(ctx context.Context, sinceTx, asBeforeTx uint64
 	return err
 }
 
+// UseSnapshotWithIsolationLevel sets the snapshot used by queries and the isolation level determining
+// whether queries always read every committed tx (read-committed) or the snapshot in use (snapshot)
+func (c *immuClient) UseSnapshotWithIsolationLevel(ctx context.Context, sinceTx, asBeforeTx uint64, level schema.IsolationLevel) error {
+	if !c.IsConnected() {
+		return ErrNotConnected
+	}
+
+	_, err := c.ServiceClient.UseSnapshot(ctx, &schema.UseSnapshotRequest{SinceTx: sinceTx, AsBeforeTx: asBeforeTx, IsolationLevel: level})
+	return err
+}
+
 func (c *immuClient) SQLQuery(ctx context.Context, sql string, params map[string]interface{}, renewSnapshot bool) (*schema.SQLQueryResult, error) {
 	if !c.IsConnected() {
 		return nil, ErrNotConnected
@@ -77,6 +141,29 @@ func (c *immuClient) DescribeTable(ctx context.Context, tableName string) (*sche
 	return c.ServiceClient.DescribeTable(ctx, &schema.Table{TableName: tableName})
 }
 
+// ExportSQLSchema returns the statements re-creating the tables and indexes of the selected database
+func (c *immuClient) ExportSQLSchema(ctx context.Context) (string, error) {
+	if !c.IsConnected() {
+		return "", ErrNotConnected
+	}
+
+	res, err := c.ServiceClient.ExportSQLSchema(ctx, &emptypb.Empty{})
+	if err != nil {
+		return "", err
+	}
+
+	return res.Ddl, nil
+}
+
+// ImportSQLSchema applies a schema previously exported with ExportSQLSchema to the selected database
+func (c *immuClient) ImportSQLSchema(ctx context.Context, ddl string) (*schema.SQLExecResult, error) {
+	if !c.IsConnected() {
+		return nil, ErrNotConnected
+	}
+
+	return c.ServiceClient.ImportSQLSchema(ctx, &schema.SQLSchema{Ddl: ddl})
+}
+
 func encodeParams(params map[string]interface{}) ([]*schema.NamedParam, error) {
 	if params == nil {
 		return nil, nil
@@ -232,6 +319,196 @@ func (c *immuClient) VerifyRow(ctx context.Context, row *schema.Row, table strin
 	return nil
 }
 
+// VerifiedSQLQuery returns the rows of the query once each of them is verified to be a row stored in the database.
+// Queries must return rows of a single table, including the columns of its primary key
+func (c *immuClient) VerifiedSQLQuery(ctx context.Context, sql string, params map[string]interface{}) (*schema.SQLQueryResult, error) {
+	if !c.IsConnected() {
+		return nil, ErrNotConnected
+	}
+
+	namedParams, err := encodeParams(params)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.StateService.CacheLock()
+	if err != nil {
+		return nil, err
+	}
+	defer c.StateService.CacheUnlock()
+
+	state, err := c.StateService.GetState(ctx, c.currentDatabase())
+	if err != nil {
+		return nil, err
+	}
+
+	vRes, err := c.ServiceClient.VerifiableSQLQuery(ctx, &schema.VerifiableSQLQueryRequest{
+		SqlQueryRequest: &schema.SQLQueryRequest{Sql: sql, Params: namedParams},
+		ProveSinceTx:    state.TxId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if vRes.Result == nil || vRes.VerifiableTx == nil || vRes.VerifiableTx.Tx == nil || len(vRes.Rows) != len(vRes.Result.Rows) {
+		return nil, store.ErrCorruptedData
+	}
+
+	dualProof := schema.DualProofFrom(vRes.VerifiableTx.DualProof)
+
+	queryTxID := vRes.VerifiableTx.Tx.Metadata.Id
+
+	var queryAlh [sha256.Size]byte
+
+	var sourceID, targetID uint64
+	var sourceAlh, targetAlh [sha256.Size]byte
+
+	if state.TxId <= queryTxID {
+		queryAlh = dualProof.TargetTxMetadata.Alh()
+
+		sourceID = state.TxId
+		sourceAlh = schema.DigestFrom(state.TxHash)
+		targetID = queryTxID
+		targetAlh = queryAlh
+	} else {
+		queryAlh = dualProof.SourceTxMetadata.Alh()
+
+		sourceID = queryTxID
+		sourceAlh = queryAlh
+		targetID = state.TxId
+		targetAlh = schema.DigestFrom(state.TxHash)
+	}
+
+	if state.TxId > 0 {
+		verifies := store.VerifyDualProof(
+			dualProof,
+			sourceID,
+			targetID,
+			sourceAlh,
+			targetAlh,
+		)
+		if !verifies {
+			return nil, store.ErrCorruptedData
+		}
+	}
+
+	for i, row := range vRes.Result.Rows {
+		err = verifySQLRow(row, vRes.Rows[i], vRes, c.currentDatabase(), queryTxID, queryAlh)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	newState := &schema.ImmutableState{
+		Db:        c.currentDatabase(),
+		TxId:      targetID,
+		TxHash:    targetAlh[:],
+		Signature: vRes.VerifiableTx.Signature,
+	}
+
+	if c.serverSigningPubKey != nil {
+		ok, err := newState.CheckSignature(c.serverSigningPubKey)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, store.ErrCorruptedData
+		}
+	}
+
+	err = c.StateService.SetState(c.currentDatabase(), newState)
+	if err != nil {
+		return nil, err
+	}
+
+	return vRes.Result, nil
+}
+
+// verifySQLRow checks the row holds the values of the stored row whose key is made of its primary key values,
+// and that the stored row was written in a tx linked to the one the query was resolved at
+func verifySQLRow(row *schema.Row, vRow *schema.VerifiableSQLRow, vRes *schema.VerifiableSQLQueryResult, db string, queryTxID uint64, queryAlh [sha256.Size]byte) error {
+	if row == nil || vRow == nil || vRow.SqlEntry == nil || vRow.DualProof == nil || len(vRes.PKNames) == 0 ||
+		len(row.Columns) == 0 || len(row.Columns) != len(row.Values) {
+		return sql.ErrCorruptedData
+	}
+
+	var pkEncVal []byte
+	var pkID uint64
+
+	for i, pkName := range vRes.PKNames {
+		colID, ok := vRes.ColIdsByName[sql.EncodeSelector("", db, vRes.TableName, pkName)]
+		if !ok {
+			return sql.ErrCorruptedData
+		}
+
+		if i == 0 {
+			pkID = colID
+		}
+
+		colType, ok := vRes.ColTypesById[colID]
+		if !ok {
+			return sql.ErrCorruptedData
+		}
+
+		var pkVal *schema.SQLValue
+
+		for j, colName := range row.Columns {
+			if vRes.ColIdsByName[colName] == colID {
+				pkVal = row.Values[j]
+				break
+			}
+		}
+
+		if pkVal == nil {
+			return sql.ErrCorruptedData
+		}
+
+		encVal, err := sql.EncodeRawValue(schema.RawValue(pkVal), colType, true)
+		if err != nil {
+			return err
+		}
+
+		pkEncVal = append(pkEncVal, encVal...)
+	}
+
+	pkKey := sql.MapKey([]byte{SQLPrefix}, sql.RowPrefix, sql.EncodeID(vRes.DatabaseId), sql.EncodeID(vRes.TableId), sql.EncodeID(pkID), pkEncVal)
+
+	decodedRow, err := decodeRow(vRow.SqlEntry.Value, vRes.ColTypesById)
+	if err != nil {
+		return err
+	}
+
+	err = verifyRowAgainst(row, decodedRow, vRes.ColIdsByName)
+	if err != nil {
+		return err
+	}
+
+	dualProof := schema.DualProofFrom(vRow.DualProof)
+
+	kv := &store.KV{Key: pkKey, Value: vRow.SqlEntry.Value}
+
+	verifies := store.VerifyInclusion(
+		schema.InclusionProofFrom(vRow.InclusionProof),
+		kv,
+		schema.DigestFrom(vRow.DualProof.SourceTxMetadata.EH))
+	if !verifies {
+		return store.ErrCorruptedData
+	}
+
+	verifies = store.VerifyDualProof(
+		dualProof,
+		vRow.SqlEntry.Tx,
+		queryTxID,
+		dualProof.SourceTxMetadata.Alh(),
+		queryAlh,
+	)
+	if !verifies {
+		return store.ErrCorruptedData
+	}
+
+	return nil
+}
+
 func verifyRowAgainst(row *schema.Row, decodedRow map[uint64]*schema.SQLValue, colIdsByName map[string]uint64) error {
 	for i, colName := range row.Columns {
 		colID, ok := colIdsByName[colName]
@@ -329,6 +606,18 @@ func asSQLValue(v interface{}) (*schema.SQLValue, error) {
 		{
 			return &schema.SQLValue{Value: &schema.SQLValue_N{N: uint64(tv)}}, nil
 		}
+	case float64:
+		{
+			return &schema.SQLValue{Value: &schema.SQLValue_F{F: tv}}, nil
+		}
+	case time.Time:
+		{
+			return &schema.SQLValue{Value: &schema.SQLValue_Ts{Ts: schema.TimeToInt64(tv)}}, nil
+		}
+	case float32:
+		{
+			return &schema.SQLValue{Value: &schema.SQLValue_F{F: float64(tv)}}, nil
+		}
 	case string:
 		{
 			return &schema.SQLValue{Value: &schema.SQLValue_S{S: tv}}, nil
@@ -343,6 +632,23 @@ func asSQLValue(v interface{}) (*schema.SQLValue, error) {
 		}
 	}
 
+	// lists of values e.g. for IN conditions
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Slice {
+		list := &schema.SQLValueList{Values: make([]*schema.SQLValue, rv.Len())}
+
+		for i := range list.Values {
+			ev, err := asSQLValue(rv.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+
+			list.Values[i] = ev
+		}
+
+		return &schema.SQLValue{Value: &schema.SQLValue_List{List: list}}, nil
+	}
+
 	return nil, sql.ErrInvalidValue
 }
 
@@ -364,6 +670,18 @@ func typedValueToRowValue(tv sql.TypedValue) *schema.SQLValue {
 		{
 			return &schema.SQLValue{Value: &schema.SQLValue_Bs{Bs: tv.Value().([]byte)}}
 		}
+	case sql.FloatType:
+		{
+			return &schema.SQLValue{Value: &schema.SQLValue_F{F: tv.Value().(float64)}}
+		}
+	case sql.TimestampType:
+		{
+			return &schema.SQLValue{Value: &schema.SQLValue_Ts{Ts: schema.TimeToInt64(tv.Value().(time.Time))}}
+		}
+	case sql.JSONType:
+		{
+			return &schema.SQLValue{Value: &schema.SQLValue_S{S: tv.Value().(string)}}
+		}
 	}
 	return nil
 }
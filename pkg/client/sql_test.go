@@ -17,6 +17,7 @@ package client
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"testing"
 
@@ -72,6 +73,32 @@ func TestImmuClient_SQL(t *testing.T) {
 	_, err = client.SQLQuery(ctx, "SELECT id as uuid FROM table1", nil, true)
 	require.NoError(t, err)
 
+	inRes, err := client.SQLQuery(ctx, "SELECT id FROM table1 WHERE id IN @ids", map[string]interface{}{"ids": []int{3, 1}}, true)
+	require.NoError(t, err)
+	require.Len(t, inRes.Rows, 2)
+	require.Equal(t, uint64(1), inRes.Rows[0].Values[0].GetN())
+	require.Equal(t, uint64(3), inRes.Rows[1].Values[0].GetN())
+
+	inRes, err = client.SQLQuery(ctx, "SELECT id FROM table1 WHERE title NOT IN @titles", map[string]interface{}{"titles": []interface{}{"title1", nil}}, true)
+	require.NoError(t, err)
+	require.Empty(t, inRes.Rows)
+
+	_, err = client.SQLQuery(ctx, "SELECT id FROM table1 WHERE id IN @ids", map[string]interface{}{"ids": []interface{}{1, struct{}{}}}, true)
+	require.Equal(t, sql.ErrInvalidValue, err)
+
+	err = client.UseSnapshotWithIsolationLevel(ctx, 0, 0, schema.IsolationLevel_READ_COMMITTED)
+	require.NoError(t, err)
+
+	_, err = client.SQLExec(ctx, "INSERT INTO table1(id, title) VALUES (4, 'title4')", nil)
+	require.NoError(t, err)
+
+	rc, err := client.SQLQuery(ctx, "SELECT id FROM table1", nil, false)
+	require.NoError(t, err)
+	require.Len(t, rc.Rows, 4)
+
+	err = client.UseSnapshotWithIsolationLevel(ctx, 0, 0, schema.IsolationLevel_SNAPSHOT)
+	require.NoError(t, err)
+
 	for _, row := range res.Rows {
 		err := client.VerifyRow(ctx, row, "table1", row.Values[0])
 		require.Equal(t, sql.ErrColumnDoesNotExist, err)
@@ -109,3 +136,232 @@ func TestImmuClient_SQL(t *testing.T) {
 		require.NoError(t, err)
 	}
 }
+
+func TestImmuClient_VerifiedSQLQuery(t *testing.T) {
+	options := server.DefaultOptions().WithAuth(true)
+	bs := servertest.NewBufconnServer(options)
+
+	defer os.RemoveAll(options.Dir)
+	defer os.Remove(".state-")
+
+	bs.Start()
+	defer bs.Stop()
+
+	client, err := NewImmuClient(DefaultOptions().WithDialOptions(&[]grpc.DialOption{grpc.WithContextDialer(bs.Dialer), grpc.WithInsecure()}))
+	require.NoError(t, err)
+	lr, err := client.Login(context.TODO(), []byte(`immudb`), []byte(`immudb`))
+	require.NoError(t, err)
+
+	md := metadata.Pairs("authorization", lr.Token)
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	_, err = client.SQLExec(ctx, "CREATE TABLE table1(id INTEGER, title VARCHAR, active BOOLEAN, PRIMARY KEY id)", nil)
+	require.NoError(t, err)
+
+	_, err = client.SQLExec(ctx, "INSERT INTO table1(id, title, active) VALUES (1, 'title1', true), (2, 'title2', false), (3, NULL, true)", nil)
+	require.NoError(t, err)
+
+	_, err = client.SQLExec(ctx, "UPSERT INTO table1(id, title, active) VALUES (1, 'title1', false)", nil)
+	require.NoError(t, err)
+
+	res, err := client.VerifiedSQLQuery(ctx, "SELECT id, title FROM table1 WHERE active = @active", map[string]interface{}{"active": false})
+	require.NoError(t, err)
+	require.Len(t, res.Rows, 2)
+	require.Equal(t, "title1", res.Rows[0].Values[1].GetS())
+
+	state, err := client.CurrentState(ctx)
+	require.NoError(t, err)
+
+	// rows written in older txs get verified against the known state
+	_, err = client.SQLExec(ctx, "INSERT INTO table1(id, title) VALUES (4, 'title4')", nil)
+	require.NoError(t, err)
+
+	res, err = client.VerifiedSQLQuery(ctx, "SELECT * FROM table1", nil)
+	require.NoError(t, err)
+	require.Len(t, res.Rows, 4)
+
+	newState, err := client.CurrentState(ctx)
+	require.NoError(t, err)
+	require.Equal(t, state.TxId+1, newState.TxId)
+
+	_, err = client.VerifiedSQLQuery(ctx, "SELECT title FROM table1", nil)
+	require.Error(t, err)
+
+	vRes, err := client.GetServiceClient().VerifiableSQLQuery(ctx, &schema.VerifiableSQLQueryRequest{
+		SqlQueryRequest: &schema.SQLQueryRequest{Sql: "SELECT id, title FROM table1"},
+	})
+	require.NoError(t, err)
+
+	queryTxID := vRes.VerifiableTx.Tx.Metadata.Id
+	queryAlh := schema.TxMetadataFrom(vRes.VerifiableTx.Tx.Metadata).Alh()
+
+	row := vRes.Result.Rows[0]
+
+	err = verifySQLRow(row, vRes.Rows[0], vRes, "defaultdb", queryTxID, queryAlh)
+	require.NoError(t, err)
+
+	row.Values[1].Value = &schema.SQLValue_S{S: "tampered title"}
+
+	err = verifySQLRow(row, vRes.Rows[0], vRes, "defaultdb", queryTxID, queryAlh)
+	require.Equal(t, sql.ErrCorruptedData, err)
+
+	// a stored row can not be taken for a different one
+	row = vRes.Result.Rows[2]
+
+	err = verifySQLRow(row, vRes.Rows[1], vRes, "defaultdb", queryTxID, queryAlh)
+	require.Equal(t, sql.ErrCorruptedData, err)
+}
+
+func TestImmuClient_SQLBatch(t *testing.T) {
+	options := server.DefaultOptions().WithAuth(true)
+	bs := servertest.NewBufconnServer(options)
+
+	defer os.RemoveAll(options.Dir)
+	defer os.Remove(".state-")
+
+	bs.Start()
+	defer bs.Stop()
+
+	client, err := NewImmuClient(DefaultOptions().WithDialOptions(&[]grpc.DialOption{grpc.WithContextDialer(bs.Dialer), grpc.WithInsecure()}))
+	require.NoError(t, err)
+	lr, err := client.Login(context.TODO(), []byte(`immudb`), []byte(`immudb`))
+	require.NoError(t, err)
+
+	md := metadata.Pairs("authorization", lr.Token)
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	_, err = client.SQLExec(ctx, "CREATE TABLE table1(id INTEGER AUTO_INCREMENT, title VARCHAR, active BOOLEAN, PRIMARY KEY id)", nil)
+	require.NoError(t, err)
+
+	var stmts []*SQLBatchStmt
+
+	for i := 0; i < 10; i++ {
+		stmts = append(stmts, &SQLBatchStmt{
+			SQL:    "INSERT INTO table1(title, active) VALUES (@title, @active)",
+			Params: map[string]interface{}{"title": fmt.Sprintf("title%d", i), "active": i%2 == 0},
+		})
+	}
+
+	res, err := client.SQLBatch(ctx, stmts, false)
+	require.NoError(t, err)
+	require.Len(t, res.Dtxs, 1)
+	require.Len(t, res.Results, 10)
+
+	for i, r := range res.Results {
+		require.Equal(t, uint64(1), r.UpdatedRows)
+		require.Equal(t, map[string]uint64{"table1": uint64(i + 1)}, r.LastInsertedPKs)
+	}
+
+	qres, err := client.SQLQuery(ctx, "SELECT COUNT() AS c FROM table1 WHERE active", nil, true)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), qres.Rows[0].Values[0].GetN())
+
+	err = client.Disconnect()
+	require.NoError(t, err)
+
+	_, err = client.SQLBatch(ctx, stmts, false)
+	require.Equal(t, ErrNotConnected, err)
+}
+
+func TestImmuClient_SQLExecInTx(t *testing.T) {
+	options := server.DefaultOptions().WithAuth(true)
+	bs := servertest.NewBufconnServer(options)
+
+	defer os.RemoveAll(options.Dir)
+	defer os.Remove(".state-")
+
+	bs.Start()
+	defer bs.Stop()
+
+	client, err := NewImmuClient(DefaultOptions().WithDialOptions(&[]grpc.DialOption{grpc.WithContextDialer(bs.Dialer), grpc.WithInsecure()}))
+	require.NoError(t, err)
+	lr, err := client.Login(context.TODO(), []byte(`immudb`), []byte(`immudb`))
+	require.NoError(t, err)
+
+	md := metadata.Pairs("authorization", lr.Token)
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	_, err = client.SQLExec(ctx, "CREATE TABLE table1(id INTEGER AUTO_INCREMENT, title VARCHAR, PRIMARY KEY id)", nil)
+	require.NoError(t, err)
+
+	res, err := client.SQLExecInTx(ctx, "", "BEGIN", nil)
+	require.NoError(t, err)
+	require.NotEmpty(t, res.TransactionId)
+
+	txID := res.TransactionId
+
+	for i := 0; i < 3; i++ {
+		_, err = client.SQLExecInTx(ctx, txID, "INSERT INTO table1(title) VALUES (@title)", map[string]interface{}{"title": fmt.Sprintf("title%d", i)})
+		require.NoError(t, err)
+	}
+
+	res, err = client.SQLExecInTx(ctx, txID, "COMMIT", nil)
+	require.NoError(t, err)
+	require.Empty(t, res.TransactionId)
+	require.Len(t, res.Dtxs, 1)
+
+	qres, err := client.SQLQuery(ctx, "SELECT COUNT() AS c FROM table1", nil, true)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), qres.Rows[0].Values[0].GetN())
+
+	err = client.Disconnect()
+	require.NoError(t, err)
+
+	_, err = client.SQLExecInTx(ctx, txID, "COMMIT", nil)
+	require.Equal(t, ErrNotConnected, err)
+}
+
+func TestImmuClient_SQLSchemaExportAndImport(t *testing.T) {
+	options := server.DefaultOptions().WithAuth(true)
+	bs := servertest.NewBufconnServer(options)
+
+	defer os.RemoveAll(options.Dir)
+	defer os.Remove(".state-")
+
+	bs.Start()
+	defer bs.Stop()
+
+	client, err := NewImmuClient(DefaultOptions().WithDialOptions(&[]grpc.DialOption{grpc.WithContextDialer(bs.Dialer), grpc.WithInsecure()}))
+	require.NoError(t, err)
+	lr, err := client.Login(context.TODO(), []byte(`immudb`), []byte(`immudb`))
+	require.NoError(t, err)
+
+	md := metadata.Pairs("authorization", lr.Token)
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	_, err = client.SQLExec(ctx, "CREATE TABLE table1(id INTEGER, title VARCHAR, PRIMARY KEY id); CREATE INDEX ON table1(title)", nil)
+	require.NoError(t, err)
+
+	ddl, err := client.ExportSQLSchema(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, ddl)
+
+	err = client.CreateDatabase(ctx, &schema.Database{DatabaseName: "db1"})
+	require.NoError(t, err)
+
+	resp, err := client.UseDatabase(ctx, &schema.Database{DatabaseName: "db1"})
+	require.NoError(t, err)
+
+	md = metadata.Pairs("authorization", resp.Token)
+	ctx = metadata.NewOutgoingContext(context.Background(), md)
+
+	res, err := client.ImportSQLSchema(ctx, ddl)
+	require.NoError(t, err)
+	require.Len(t, res.Ctxs, 2)
+
+	ddl1, err := client.ExportSQLSchema(ctx)
+	require.NoError(t, err)
+	require.Equal(t, ddl, ddl1)
+
+	_, err = client.ImportSQLSchema(ctx, "INSERT INTO table1(id) VALUES (1)")
+	require.Error(t, err)
+
+	err = client.Disconnect()
+	require.NoError(t, err)
+
+	_, err = client.ExportSQLSchema(ctx)
+	require.Equal(t, ErrNotConnected, err)
+
+	_, err = client.ImportSQLSchema(ctx, ddl)
+	require.Equal(t, ErrNotConnected, err)
+}
@@ -85,9 +85,15 @@ func TestImmuClient_SetGetStream(t *testing.T) {
 
 	newSha := sha256.Sum256(entry.Value)
 
-	client.Disconnect()
-
 	require.Equal(t, oriSha, newSha[:])
+
+	hGot := sha256.New()
+	entry, err = client.StreamGetTo(ctx, &schema.KeyRequest{Key: []byte(tmpFile.Name())}, hGot)
+	require.NoError(t, err)
+	require.Equal(t, []byte(tmpFile.Name()), entry.Key)
+	require.Equal(t, oriSha, hGot.Sum(nil))
+
+	client.Disconnect()
 }
 
 func TestImmuClient_Set32MBStream(t *testing.T) {
@@ -134,6 +134,30 @@ func (c *immuClient) StreamGet(ctx context.Context, k *schema.KeyRequest) (*sche
 	}, nil
 }
 
+// StreamGetTo writes the value of the key into w as it's received, thus large values are not held in memory
+func (c *immuClient) StreamGetTo(ctx context.Context, k *schema.KeyRequest, w io.Writer) (*schema.Entry, error) {
+	gs, err := c.streamGet(ctx, k)
+	if err != nil {
+		return nil, err
+	}
+
+	kvr := c.StreamServiceFactory.NewKvStreamReceiver(c.StreamServiceFactory.NewMsgReceiver(gs))
+
+	key, vr, err := kvr.Next()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = io.Copy(w, stream.NewValueReader(vr))
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema.Entry{
+		Key: key,
+	}, nil
+}
+
 func (c *immuClient) StreamVerifiedSet(ctx context.Context, kvs []*stream.KeyValue) (*schema.TxMetadata, error) {
 	if len(kvs) == 0 {
 		return nil, errors.New("no key-values specified")
@@ -325,7 +349,7 @@ func (c *immuClient) StreamVerifiedGet(ctx context.Context, req *schema.Verifiab
 
 	if vEntry.Entry.ReferencedBy == nil {
 		vTx = vEntry.Entry.Tx
-		kv = database.EncodeKV(req.KeyRequest.Key, vEntry.Entry.Value)
+		kv = database.EncodeKVWithMetadata(req.KeyRequest.Key, schema.KVMetadataFrom(vEntry.Entry.Metadata), vEntry.Entry.Value)
 	} else {
 		vTx = vEntry.Entry.ReferencedBy.Tx
 		kv = database.EncodeReference(vEntry.Entry.ReferencedBy.Key, vEntry.Entry.Key, vEntry.Entry.ReferencedBy.AtTx)
@@ -496,3 +520,43 @@ func (c *immuClient) StreamExecAll(ctx context.Context, req *stream.ExecAllReque
 
 	return s.CloseAndRecv()
 }
+
+// ExportTxRange writes into w an archive holding the txs committed after sinceTx up to upToTx,
+// all the committed txs are included when upToTx is zero. The number of written bytes is returned.
+// The archive is encrypted to the recipients (age X25519 public keys) when any is provided.
+func (c *immuClient) ExportTxRange(ctx context.Context, sinceTx, upToTx uint64, w io.Writer, recipients ...string) (int64, error) {
+	if !c.IsConnected() {
+		return 0, ErrNotConnected
+	}
+
+	s, err := c.ServiceClient.ExportTxRange(ctx, &schema.TxRangeRequest{
+		SinceTx:    sinceTx,
+		UpToTx:     upToTx,
+		Recipients: recipients,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return io.Copy(w, stream.NewValueReader(c.StreamServiceFactory.NewMsgReceiver(s)))
+}
+
+// ApplyTxRange sends an archive of size bytes exported with ExportTxRange, its txs are committed
+// into the database as long as its latest tx is the one the archive was exported since
+func (c *immuClient) ApplyTxRange(ctx context.Context, r io.Reader, size int) (*schema.TxRange, error) {
+	if !c.IsConnected() {
+		return nil, ErrNotConnected
+	}
+
+	s, err := c.ServiceClient.ApplyTxRange(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.StreamServiceFactory.NewMsgSender(s).Send(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.CloseAndRecv()
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/client/state"
+)
+
+var ErrInvalidTrustAnchor = errors.New("invalid trust anchor, expected database:txID:txHash with a hex-encoded hash")
+var ErrTrustAnchorMismatch = errors.New("the history of the database on the server is not consistent with its trust anchor")
+
+// TrustAnchor is a state of a database known to be genuine, e.g. the one reached by its first transaction.
+// The states of the database received from the server must be consistent with it, thus the history of the
+// database can not be substituted, not even before the client had the chance to verify anything
+type TrustAnchor struct {
+	TxID   uint64
+	TxHash []byte
+}
+
+// ParseTrustAnchors parses a comma-separated list of trust anchors, each one given as database:txID:txHash
+func ParseTrustAnchors(s string) (map[string]*TrustAnchor, error) {
+	anchors := make(map[string]*TrustAnchor)
+
+	for _, a := range strings.Split(s, ",") {
+		a = strings.TrimSpace(a)
+		if a == "" {
+			continue
+		}
+
+		parts := strings.Split(a, ":")
+		if len(parts) != 3 || parts[0] == "" {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidTrustAnchor, a)
+		}
+
+		txID, err := strconv.ParseUint(parts[1], 10, 64)
+		if err != nil || txID == 0 {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidTrustAnchor, a)
+		}
+
+		txHash, err := hex.DecodeString(parts[2])
+		if err != nil || len(txHash) != sha256.Size {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidTrustAnchor, a)
+		}
+
+		anchors[parts[0]] = &TrustAnchor{TxID: txID, TxHash: txHash}
+	}
+
+	return anchors, nil
+}
+
+// VerifyTrustAnchor checks the state of the database selected within the session is consistent with its trust
+// anchor, by means of a proof provided by the server. ErrTrustAnchorMismatch is returned if it's not
+func VerifyTrustAnchor(ctx context.Context, serviceClient schema.ImmuServiceClient, anchor *TrustAnchor, st *schema.ImmutableState) error {
+	if anchor == nil || st == nil {
+		return ErrIllegalArguments
+	}
+
+	if st.TxId == anchor.TxID {
+		if !bytes.Equal(st.TxHash, anchor.TxHash) {
+			return ErrTrustAnchorMismatch
+		}
+		return nil
+	}
+
+	// an empty database has no history the trust anchor may belong to
+	if st.TxId == 0 {
+		return ErrTrustAnchorMismatch
+	}
+
+	sourceID, sourceAlh := anchor.TxID, schema.DigestFrom(anchor.TxHash)
+	targetID, targetAlh := st.TxId, schema.DigestFrom(st.TxHash)
+
+	if sourceID > targetID {
+		sourceID, sourceAlh, targetID, targetAlh = targetID, targetAlh, sourceID, sourceAlh
+	}
+
+	vTx, err := serviceClient.VerifiableTxById(ctx, &schema.VerifiableTxRequest{
+		Tx:           targetID,
+		ProveSinceTx: sourceID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if !store.VerifyDualProof(schema.DualProofFrom(vTx.DualProof), sourceID, targetID, sourceAlh, targetAlh) {
+		return ErrTrustAnchorMismatch
+	}
+
+	return nil
+}
+
+// anchoredStateService checks the state of each database having a trust anchor is consistent with it the first
+// time the state is used. Later states are proven to be consistent with the previous ones before they are set
+type anchoredStateService struct {
+	state.StateService
+
+	serviceClient schema.ImmuServiceClient
+	anchors       map[string]*TrustAnchor
+
+	verified map[string]bool
+	mutex    sync.Mutex
+}
+
+func newAnchoredStateService(stateService state.StateService, serviceClient schema.ImmuServiceClient, anchors map[string]*TrustAnchor) state.StateService {
+	return &anchoredStateService{
+		StateService:  stateService,
+		serviceClient: serviceClient,
+		anchors:       anchors,
+		verified:      make(map[string]bool),
+	}
+}
+
+func (s *anchoredStateService) GetState(ctx context.Context, db string) (*schema.ImmutableState, error) {
+	st, err := s.StateService.GetState(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	// the default database is used until another one is selected
+	if db == "" {
+		db = DefaultDB
+	}
+
+	anchor, ok := s.anchors[db]
+	if !ok {
+		return st, nil
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.verified[db] {
+		return st, nil
+	}
+
+	err = VerifyTrustAnchor(ctx, s.serviceClient, anchor, st)
+	if err != nil {
+		return nil, err
+	}
+
+	s.verified[db] = true
+
+	return st, nil
+}
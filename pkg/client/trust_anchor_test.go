@@ -0,0 +1,127 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/server"
+	"github.com/codenotary/immudb/pkg/server/servertest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestParseTrustAnchors(t *testing.T) {
+	hash := make([]byte, 32)
+	hash[0] = 1
+
+	anchors, err := ParseTrustAnchors("")
+	require.NoError(t, err)
+	require.Empty(t, anchors)
+
+	anchors, err = ParseTrustAnchors("defaultdb:1:" + hex.EncodeToString(hash) + ", db1:10:" + hex.EncodeToString(hash))
+	require.NoError(t, err)
+	require.Len(t, anchors, 2)
+	require.Equal(t, &TrustAnchor{TxID: 1, TxHash: hash}, anchors["defaultdb"])
+	require.Equal(t, uint64(10), anchors["db1"].TxID)
+
+	for _, s := range []string{
+		"defaultdb",
+		"defaultdb:1",
+		":1:" + hex.EncodeToString(hash),
+		"defaultdb:0:" + hex.EncodeToString(hash),
+		"defaultdb:a:" + hex.EncodeToString(hash),
+		"defaultdb:1:zz",
+		"defaultdb:1:" + hex.EncodeToString(hash[:16]),
+	} {
+		_, err = ParseTrustAnchors(s)
+		require.True(t, errors.Is(err, ErrInvalidTrustAnchor), s)
+	}
+}
+
+func TestImmuClient_TrustAnchor(t *testing.T) {
+	options := server.DefaultOptions().WithAuth(true)
+	bs := servertest.NewBufconnServer(options)
+
+	defer os.RemoveAll(options.Dir)
+
+	bs.Start()
+	defer bs.Stop()
+
+	newClient := func(anchor *TrustAnchor) (ImmuClient, context.Context) {
+		dir, err := ioutil.TempDir("", "trust_anchor")
+		require.NoError(t, err)
+		t.Cleanup(func() { os.RemoveAll(dir) })
+
+		opts := DefaultOptions().
+			WithDir(dir).
+			WithDialOptions(&[]grpc.DialOption{grpc.WithContextDialer(bs.Dialer), grpc.WithInsecure()})
+
+		if anchor != nil {
+			opts.WithTrustAnchor(DefaultDB, anchor.TxID, anchor.TxHash)
+		}
+
+		client, err := NewImmuClient(opts)
+		require.NoError(t, err)
+
+		lr, err := client.Login(context.TODO(), []byte(`immudb`), []byte(`immudb`))
+		require.NoError(t, err)
+
+		return client, metadata.NewOutgoingContext(context.Background(), metadata.Pairs("authorization", lr.Token))
+	}
+
+	client, ctx := newClient(nil)
+
+	_, err := client.Set(ctx, []byte("key1"), []byte("value1"))
+	require.NoError(t, err)
+
+	anchorState, err := client.CurrentState(ctx)
+	require.NoError(t, err)
+
+	_, err = client.Set(ctx, []byte("key2"), []byte("value2"))
+	require.NoError(t, err)
+
+	client, ctx = newClient(&TrustAnchor{TxID: anchorState.TxId, TxHash: anchorState.TxHash})
+
+	entry, err := client.VerifiedGet(ctx, []byte("key1"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1"), entry.Value)
+
+	_, err = client.VerifiedSet(ctx, []byte("key3"), []byte("value3"))
+	require.NoError(t, err)
+
+	tamperedHash := make([]byte, len(anchorState.TxHash))
+	copy(tamperedHash, anchorState.TxHash)
+	tamperedHash[0]++
+
+	client, ctx = newClient(&TrustAnchor{TxID: anchorState.TxId, TxHash: tamperedHash})
+
+	_, err = client.VerifiedGet(ctx, []byte("key1"))
+	require.True(t, errors.Is(err, ErrTrustAnchorMismatch))
+
+	// the history of the database on the server doesn't reach the trust anchor
+	client, ctx = newClient(&TrustAnchor{TxID: anchorState.TxId + 100, TxHash: anchorState.TxHash})
+
+	_, err = client.VerifiedGet(ctx, []byte("key1"))
+	require.Error(t, err)
+}
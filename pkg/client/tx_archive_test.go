@@ -0,0 +1,81 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/server"
+	"github.com/codenotary/immudb/pkg/server/servertest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestImmuClient_TxRange(t *testing.T) {
+	options := server.DefaultOptions().WithAuth(true)
+	bs := servertest.NewBufconnServer(options)
+
+	defer os.RemoveAll(options.Dir)
+	defer os.Remove(".state-")
+
+	bs.Start()
+	defer bs.Stop()
+
+	client, err := NewImmuClient(DefaultOptions().WithDialOptions(&[]grpc.DialOption{grpc.WithContextDialer(bs.Dialer), grpc.WithInsecure()}))
+	require.NoError(t, err)
+	lr, err := client.Login(context.TODO(), []byte(`immudb`), []byte(`immudb`))
+	require.NoError(t, err)
+
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs("authorization", lr.Token))
+
+	_, err = client.Set(ctx, []byte("key1"), []byte("value1"))
+	require.NoError(t, err)
+
+	md, err := client.Set(ctx, []byte("key2"), []byte("value2"))
+	require.NoError(t, err)
+
+	var b bytes.Buffer
+
+	n, err := client.ExportTxRange(ctx, 0, md.Id, &b)
+	require.NoError(t, err)
+	require.Equal(t, int64(b.Len()), n)
+
+	err = client.CreateDatabase(ctx, &schema.Database{DatabaseName: "backup"})
+	require.NoError(t, err)
+
+	ur, err := client.UseDatabase(ctx, &schema.Database{DatabaseName: "backup"})
+	require.NoError(t, err)
+
+	backupCtx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs("authorization", ur.Token))
+
+	txRange, err := client.ApplyTxRange(backupCtx, bytes.NewReader(b.Bytes()), b.Len())
+	require.NoError(t, err)
+	require.Equal(t, md.Id, txRange.UpToTx)
+
+	entry, err := client.GetSince(backupCtx, []byte("key2"), md.Id)
+	require.NoError(t, err)
+	require.Equal(t, []byte("value2"), entry.Value)
+
+	// archives can only be applied onto the tx they were exported since
+	_, err = client.ApplyTxRange(backupCtx, bytes.NewReader(b.Bytes()), b.Len())
+	require.Error(t, err)
+}
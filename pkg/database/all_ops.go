@@ -16,6 +16,7 @@ limitations under the License.
 package database
 
 import (
+	"context"
 	"crypto/sha256"
 
 	"github.com/codenotary/immudb/embedded/store"
@@ -25,7 +26,18 @@ import (
 // ExecAll like Set it permits many insertions at once.
 // The difference is that is possible to to specify a list of a mix of key value set and zAdd insertions.
 // If zAdd reference is not yet present on disk it's possible to add it as a regular key value and the reference is done onFly
-func (d *db) ExecAll(req *schema.ExecAllRequest) (*schema.TxMetadata, error) {
+func (d *db) ExecAll(ctx context.Context, req *schema.ExecAllRequest) (*schema.TxMetadata, error) {
+	tx, err := d.PrepareExecAll(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// PrepareExecAll prepares the operations of the request as the first phase of a two-phase commit,
+// the database remains locked until the prepared tx is either committed or aborted
+func (d *db) PrepareExecAll(ctx context.Context, req *schema.ExecAllRequest) (*PreparedTx, error) {
 	if req == nil {
 		return nil, store.ErrIllegalArguments
 	}
@@ -34,12 +46,16 @@ func (d *db) ExecAll(req *schema.ExecAllRequest) (*schema.TxMetadata, error) {
 		return nil, err
 	}
 
+	if d.isReplica() {
+		return nil, ErrIsReplica
+	}
+
 	d.mutex.Lock()
-	defer d.mutex.Unlock()
 
 	lastTxID, _ := d.st.Alh()
-	err := d.st.WaitForIndexingUpto(lastTxID, nil)
+	err := d.st.WaitForIndexingUpto(ctx, lastTxID)
 	if err != nil {
+		d.mutex.Unlock()
 		return nil, err
 	}
 
@@ -61,11 +77,11 @@ func (d *db) ExecAll(req *schema.ExecAllRequest) (*schema.TxMetadata, error) {
 			case *schema.Op_Kv:
 				kmap[sha256.Sum256(x.Kv.Key)] = true
 
-				if len(x.Kv.Key) == 0 {
+				if len(x.Kv.Key) == 0 || !schema.ValidKVMetadata(x.Kv.Metadata) {
 					return nil, store.ErrIllegalArguments
 				}
 
-				kv = EncodeKV(x.Kv.Key, x.Kv.Value)
+				kv = EncodeKVWithMetadata(x.Kv.Key, schema.KVMetadataFrom(x.Kv.Metadata), x.Kv.Value)
 
 			case *schema.Op_Ref:
 				if len(x.Ref.Key) == 0 || len(x.Ref.ReferencedKey) == 0 {
@@ -130,10 +146,15 @@ func (d *db) ExecAll(req *schema.ExecAllRequest) (*schema.TxMetadata, error) {
 
 				key := EncodeKey(x.ZAdd.Key)
 
+				score, err := d.zAddScore(x.ZAdd, txID)
+				if err != nil {
+					return nil, err
+				}
+
 				if x.ZAdd.BoundRef && x.ZAdd.AtTx == 0 {
-					kv = EncodeZAdd(x.ZAdd.Set, x.ZAdd.Score, key, txID)
+					kv = EncodeZAdd(x.ZAdd.Set, score, key, txID)
 				} else {
-					kv = EncodeZAdd(x.ZAdd.Set, x.ZAdd.Score, key, x.ZAdd.AtTx)
+					kv = EncodeZAdd(x.ZAdd.Set, score, key, x.ZAdd.AtTx)
 				}
 			}
 
@@ -143,10 +164,17 @@ func (d *db) ExecAll(req *schema.ExecAllRequest) (*schema.TxMetadata, error) {
 		return entries, nil
 	}
 
-	txMetatadata, err := d.st.CommitWith(callback, !req.NoWait)
+	attrs, err := txAttributesFor(req.TraceID)
+	if err != nil {
+		d.mutex.Unlock()
+		return nil, err
+	}
+
+	ptx, err := d.st.PrepareCommitWith(callback, attrs)
 	if err != nil {
+		d.mutex.Unlock()
 		return nil, err
 	}
 
-	return schema.TxMetatadaTo(txMetatadata), nil
+	return &PreparedTx{d: d, tx: ptx, noWait: req.NoWait}, nil
 }
@@ -16,6 +16,7 @@ limitations under the License.
 package database
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
@@ -55,7 +56,7 @@ func execAll(db DB, req *schema.ExecAllRequest, timeout time.Duration) error {
 	done := make(chan error)
 
 	go func(done chan<- error) {
-		_, err := db.ExecAll(req)
+		_, err := db.ExecAll(context.Background(), req)
 		done <- err
 	}(done)
 
@@ -151,19 +152,19 @@ func TestSetBatch(t *testing.T) {
 			}
 		}
 
-		md, err := db.Set(&schema.SetRequest{KVs: kvList})
+		md, err := db.Set(context.Background(), &schema.SetRequest{KVs: kvList})
 		require.NoError(t, err)
 		require.Equal(t, uint64(b+1), md.Id)
 
 		for i := 0; i < batchSize; i++ {
 			key := []byte(strconv.FormatUint(uint64(i), 10))
 			value := []byte(strconv.FormatUint(uint64(b*batchSize+batchSize+i), 10))
-			entry, err := db.Get(&schema.KeyRequest{Key: key, SinceTx: md.Id})
+			entry, err := db.Get(context.Background(), &schema.KeyRequest{Key: key, SinceTx: md.Id})
 			require.NoError(t, err)
 			require.Equal(t, value, entry.Value)
 			require.Equal(t, uint64(b+1), entry.Tx)
 
-			vitem, err := db.VerifiableGet(&schema.VerifiableGetRequest{KeyRequest: &schema.KeyRequest{Key: key}}) //no prev root
+			vitem, err := db.VerifiableGet(context.Background(), &schema.VerifiableGetRequest{KeyRequest: &schema.KeyRequest{Key: key}}) //no prev root
 			require.NoError(t, err)
 			require.Equal(t, key, vitem.Entry.Key)
 			require.Equal(t, value, vitem.Entry.Value)
@@ -186,7 +187,7 @@ func TestSetBatchInvalidKvKey(t *testing.T) {
 	db, closer := makeDb()
 	defer closer()
 
-	_, err := db.Set(&schema.SetRequest{
+	_, err := db.Set(context.Background(), &schema.SetRequest{
 		KVs: []*schema.KeyValue{
 			{
 				Key:   []byte{},
@@ -200,7 +201,7 @@ func TestSetBatchDuplicatedKey(t *testing.T) {
 	db, closer := makeDb()
 	defer closer()
 
-	_, err := db.Set(&schema.SetRequest{
+	_, err := db.Set(context.Background(), &schema.SetRequest{
 		KVs: []*schema.KeyValue{
 			{
 				Key:   []byte(`key`),
@@ -212,23 +213,23 @@ func TestSetBatchDuplicatedKey(t *testing.T) {
 			},
 		}},
 	)
-	require.Equal(t, store.ErrDuplicatedKey, err)
+	require.True(t, errors.Is(err, store.ErrDuplicatedKey))
 }
 
 func TestExecAllOps(t *testing.T) {
 	db, closer := makeDb()
 	defer closer()
 
-	_, err := db.ExecAll(nil)
+	_, err := db.ExecAll(context.Background(), nil)
 	require.Equal(t, store.ErrIllegalArguments, err)
 
-	_, err = db.ExecAll(&schema.ExecAllRequest{})
+	_, err = db.ExecAll(context.Background(), &schema.ExecAllRequest{})
 	require.Error(t, err)
 
-	_, err = db.ExecAll(&schema.ExecAllRequest{Operations: []*schema.Op{}})
+	_, err = db.ExecAll(context.Background(), &schema.ExecAllRequest{Operations: []*schema.Op{}})
 	require.Error(t, err)
 
-	_, err = db.ExecAll(&schema.ExecAllRequest{
+	_, err = db.ExecAll(context.Background(), &schema.ExecAllRequest{
 		Operations: []*schema.Op{
 			nil,
 		},
@@ -266,7 +267,7 @@ func TestExecAllOps(t *testing.T) {
 			}
 		}
 
-		idx, err := db.ExecAll(&schema.ExecAllRequest{Operations: atomicOps})
+		idx, err := db.ExecAll(context.Background(), &schema.ExecAllRequest{Operations: atomicOps})
 		require.NoError(t, err)
 		require.Equal(t, uint64(b+1), idx.Id)
 	}
@@ -274,7 +275,7 @@ func TestExecAllOps(t *testing.T) {
 	zScanOpt := &schema.ZScanRequest{
 		Set: []byte(`mySet`),
 	}
-	zList, err := db.ZScan(zScanOpt)
+	zList, err := db.ZScan(context.Background(), zScanOpt)
 	require.NoError(t, err)
 	println(len(zList.Entries))
 	require.Len(t, zList.Entries, batchSize)
@@ -284,7 +285,7 @@ func TestExecAllOpsZAddOnMixedAlreadyPersitedNotPersistedItems(t *testing.T) {
 	db, closer := makeDb()
 	defer closer()
 
-	idx, _ := db.Set(&schema.SetRequest{
+	idx, _ := db.Set(context.Background(), &schema.SetRequest{
 		KVs: []*schema.KeyValue{
 			{
 				Key:   []byte(`persistedKey`),
@@ -327,11 +328,11 @@ func TestExecAllOpsZAddOnMixedAlreadyPersitedNotPersistedItems(t *testing.T) {
 		},
 	}
 
-	index, err := db.ExecAll(aOps)
+	index, err := db.ExecAll(context.Background(), aOps)
 	require.NoError(t, err)
 	require.Equal(t, uint64(2), index.Id)
 
-	list, err := db.ZScan(&schema.ZScanRequest{
+	list, err := db.ZScan(context.Background(), &schema.ZScanRequest{
 		Set:     []byte(`mySet`),
 		SinceTx: index.Id,
 	})
@@ -347,7 +348,7 @@ func TestExecAllOpsEmptyList(t *testing.T) {
 	aOps := &schema.ExecAllRequest{
 		Operations: []*schema.Op{},
 	}
-	_, err := db.ExecAll(aOps)
+	_, err := db.ExecAll(context.Background(), aOps)
 	require.Equal(t, schema.ErrEmptySet, err)
 }
 
@@ -367,7 +368,7 @@ func TestExecAllOpsInvalidKvKey(t *testing.T) {
 			},
 		},
 	}
-	_, err := db.ExecAll(aOps)
+	_, err := db.ExecAll(context.Background(), aOps)
 	require.Equal(t, store.ErrIllegalArguments, err)
 
 	aOps = &schema.ExecAllRequest{
@@ -392,7 +393,7 @@ func TestExecAllOpsInvalidKvKey(t *testing.T) {
 			},
 		},
 	}
-	_, err = db.ExecAll(aOps)
+	_, err = db.ExecAll(context.Background(), aOps)
 	require.Equal(t, store.ErrIllegalArguments, err)
 
 	aOps = &schema.ExecAllRequest{
@@ -417,7 +418,7 @@ func TestExecAllOpsInvalidKvKey(t *testing.T) {
 			},
 		},
 	}
-	_, err = db.ExecAll(aOps)
+	_, err = db.ExecAll(context.Background(), aOps)
 	require.NoError(t, err)
 
 	// Ops payload
@@ -434,7 +435,7 @@ func TestExecAllOpsInvalidKvKey(t *testing.T) {
 			},
 		},
 	}
-	_, err = db.ExecAll(aOps)
+	_, err = db.ExecAll(context.Background(), aOps)
 	require.Equal(t, ErrReferencedKeyCannotBeAReference, err)
 }
 
@@ -457,7 +458,7 @@ func TestExecAllOpsZAddKeyNotFound(t *testing.T) {
 			},
 		},
 	}
-	_, err := db.ExecAll(aOps)
+	_, err := db.ExecAll(context.Background(), aOps)
 	require.Equal(t, store.ErrTxNotFound, err)
 }
 
@@ -476,7 +477,7 @@ func TestExecAllOpsNilElementFound(t *testing.T) {
 		},
 	}
 
-	_, err := db.ExecAll(&schema.ExecAllRequest{Operations: bOps})
+	_, err := db.ExecAll(context.Background(), &schema.ExecAllRequest{Operations: bOps})
 	require.Equal(t, store.ErrIllegalArguments, err)
 }
 
@@ -491,7 +492,7 @@ func TestSetOperationNilElementFound(t *testing.T) {
 			},
 		},
 	}
-	_, err := db.ExecAll(aOps)
+	_, err := db.ExecAll(context.Background(), aOps)
 	require.Error(t, err)
 }
 
@@ -506,7 +507,7 @@ func TestExecAllOpsUnexpectedType(t *testing.T) {
 			},
 		},
 	}
-	_, err := db.ExecAll(aOps)
+	_, err := db.ExecAll(context.Background(), aOps)
 	require.Error(t, err)
 }
 
@@ -543,7 +544,7 @@ func TestExecAllOpsDuplicatedKey(t *testing.T) {
 			},
 		},
 	}
-	_, err := db.ExecAll(aOps)
+	_, err := db.ExecAll(context.Background(), aOps)
 	require.Equal(t, schema.ErrDuplicatedKeysNotSupported, err)
 }
 
@@ -580,7 +581,7 @@ func TestExecAllOpsDuplicatedKeyZAdd(t *testing.T) {
 		},
 	}
 
-	_, err := db.ExecAll(aOps)
+	_, err := db.ExecAll(context.Background(), aOps)
 	require.Equal(t, schema.ErrDuplicatedZAddNotSupported, err)
 }
 
@@ -629,7 +630,7 @@ func TestStore_ExecAllOpsConcurrent(t *testing.T) {
 		}
 
 		go func() {
-			idx, err := db.ExecAll(aOps)
+			idx, err := db.ExecAll(context.Background(), aOps)
 			require.NoError(t, err)
 			require.NotNil(t, idx)
 			wg.Done()
@@ -642,7 +643,7 @@ func TestStore_ExecAllOpsConcurrent(t *testing.T) {
 	for i := 1; i <= 10; i++ {
 		set := strconv.FormatUint(uint64(i), 10)
 
-		zList, err := db.ZScan(&schema.ZScanRequest{
+		zList, err := db.ZScan(context.Background(), &schema.ZScanRequest{
 			Set:     []byte(set),
 			SinceTx: 10,
 		})
@@ -988,7 +989,7 @@ func TestOps_ReferenceKeyAlreadyPersisted(t *testing.T) {
 	db, closer := makeDb()
 	defer closer()
 
-	idx0, _ := db.Set(&schema.SetRequest{
+	idx0, _ := db.Set(context.Background(), &schema.SetRequest{
 		KVs: []*schema.KeyValue{
 			{
 				Key:   []byte(`persistedKey`),
@@ -1010,7 +1011,7 @@ func TestOps_ReferenceKeyAlreadyPersisted(t *testing.T) {
 			},
 		},
 	}
-	_, err := db.ExecAll(aOps)
+	_, err := db.ExecAll(context.Background(), aOps)
 	require.Equal(t, store.ErrIllegalArguments, err)
 
 	// Ops payload
@@ -1028,7 +1029,7 @@ func TestOps_ReferenceKeyAlreadyPersisted(t *testing.T) {
 			},
 		},
 	}
-	idx1, err := db.ExecAll(aOps)
+	idx1, err := db.ExecAll(context.Background(), aOps)
 	require.NoError(t, err)
 
 	aOps = &schema.ExecAllRequest{
@@ -1045,7 +1046,7 @@ func TestOps_ReferenceKeyAlreadyPersisted(t *testing.T) {
 			},
 		},
 	}
-	_, err = db.ExecAll(aOps)
+	_, err = db.ExecAll(context.Background(), aOps)
 	require.Equal(t, ErrReferencedKeyCannotBeAReference, err)
 
 	aOps = &schema.ExecAllRequest{
@@ -1062,10 +1063,10 @@ func TestOps_ReferenceKeyAlreadyPersisted(t *testing.T) {
 			},
 		},
 	}
-	_, err = db.ExecAll(aOps)
+	_, err = db.ExecAll(context.Background(), aOps)
 	require.Equal(t, ErrFinalKeyCannotBeConvertedIntoReference, err)
 
-	ref, err := db.Get(&schema.KeyRequest{Key: []byte(`myReference`), SinceTx: idx1.Id})
+	ref, err := db.Get(context.Background(), &schema.KeyRequest{Key: []byte(`myReference`), SinceTx: idx1.Id})
 	require.NoError(t, err)
 	require.NotEmptyf(t, ref, "Should not be empty")
 	require.Equal(t, []byte(`persistedVal`), ref.Value, "Should have referenced item value")
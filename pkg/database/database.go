@@ -16,12 +16,15 @@ limitations under the License.
 package database
 
 import (
+	"context"
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/codenotary/immudb/embedded/sql"
 	"github.com/codenotary/immudb/embedded/store"
@@ -36,43 +39,82 @@ const MaxKeyScanLimit = 1000
 
 var ErrMaxKeyResolutionLimitReached = errors.New("max key resolution limit reached. It may be due to cyclic references")
 var ErrMaxKeyScanLimitExceeded = errors.New("max key scan limit exceeded")
+var ErrIsReplica = errors.New("database is read-only because it's a replica")
+var ErrNotReplica = errors.New("database is not a replica")
 var ErrIllegalArguments = store.ErrIllegalArguments
 var ErrIllegalState = store.ErrIllegalState
+var ErrPreconditionFailed = store.ErrPreconditionFailed
 
 type DB interface {
 	Health(e *empty.Empty) (*schema.HealthResponse, error)
 	CurrentState() (*schema.ImmutableState, error)
-	Set(req *schema.SetRequest) (*schema.TxMetadata, error)
-	Get(req *schema.KeyRequest) (*schema.Entry, error)
-	VerifiableSet(req *schema.VerifiableSetRequest) (*schema.VerifiableTx, error)
-	VerifiableGet(req *schema.VerifiableGetRequest) (*schema.VerifiableEntry, error)
-	GetAll(req *schema.KeyListRequest) (*schema.Entries, error)
-	ExecAll(operations *schema.ExecAllRequest) (*schema.TxMetadata, error)
+	Set(ctx context.Context, req *schema.SetRequest) (*schema.TxMetadata, error)
+	Get(ctx context.Context, req *schema.KeyRequest) (*schema.Entry, error)
+	NewStreamingTx(ctx context.Context) (*StreamingTx, error)
+	GetStreamed(ctx context.Context, req *schema.KeyRequest) (entry *schema.Entry, value io.Reader, valueLen int, err error)
+	VerifiableSet(ctx context.Context, req *schema.VerifiableSetRequest) (*schema.VerifiableTx, error)
+	VerifiableGet(ctx context.Context, req *schema.VerifiableGetRequest) (*schema.VerifiableEntry, error)
+	VerifiableHistoricalGet(ctx context.Context, req *schema.VerifiableHistoricalGetRequest) (*schema.VerifiableEntry, error)
+	GetAll(ctx context.Context, req *schema.KeyListRequest) (*schema.Entries, error)
+	ExecAll(ctx context.Context, operations *schema.ExecAllRequest) (*schema.TxMetadata, error)
+	PrepareExecAll(ctx context.Context, operations *schema.ExecAllRequest) (*PreparedTx, error)
 	Size() (uint64, error)
+	OpenedFiles() ([]*schema.LogOpenedFiles, error)
+	CloseCachedFiles() (int, error)
 	Count(prefix *schema.KeyPrefix) (*schema.EntryCount, error)
 	CountAll() (*schema.EntryCount, error)
 	TxByID(req *schema.TxRequest) (*schema.Tx, error)
 	VerifiableTxByID(req *schema.VerifiableTxRequest) (*schema.VerifiableTx, error)
-	TxScan(req *schema.TxScanRequest) (*schema.TxList, error)
-	History(req *schema.HistoryRequest) (*schema.Entries, error)
-	SetReference(req *schema.ReferenceRequest) (*schema.TxMetadata, error)
-	VerifiableSetReference(req *schema.VerifiableReferenceRequest) (*schema.VerifiableTx, error)
-	ZAdd(req *schema.ZAddRequest) (*schema.TxMetadata, error)
-	ZScan(req *schema.ZScanRequest) (*schema.ZEntries, error)
-	VerifiableZAdd(req *schema.VerifiableZAddRequest) (*schema.VerifiableTx, error)
-	Scan(req *schema.ScanRequest) (*schema.Entries, error)
+	TxScan(ctx context.Context, req *schema.TxScanRequest) (*schema.TxList, error)
+	Subscribe(req *schema.SubscribeRequest, send func(tx *schema.Tx) error, cancellation <-chan struct{}) error
+	History(ctx context.Context, req *schema.HistoryRequest) (*schema.Entries, error)
+	SetReference(ctx context.Context, req *schema.ReferenceRequest) (*schema.TxMetadata, error)
+	VerifiableSetReference(ctx context.Context, req *schema.VerifiableReferenceRequest) (*schema.VerifiableTx, error)
+	ZAdd(ctx context.Context, req *schema.ZAddRequest) (*schema.TxMetadata, error)
+	ZScan(ctx context.Context, req *schema.ZScanRequest) (*schema.ZEntries, error)
+	VerifiableZAdd(ctx context.Context, req *schema.VerifiableZAddRequest) (*schema.VerifiableTx, error)
+	TSAppend(ctx context.Context, req *schema.TSAppendRequest) (*schema.TxMetadata, error)
+	TSRange(ctx context.Context, req *schema.TSRangeRequest) (*schema.TSSamples, error)
+	Notarize(ctx context.Context, req *schema.NotarizeRequest) (*schema.NotarizationReceipts, error)
+	VerifyNotarizationReceipt(receipt *schema.NotarizationReceipt) (*schema.NotarizationVerification, error)
+	CreateCollection(ctx context.Context, req *schema.Collection) (*schema.TxMetadata, error)
+	ListCollections(ctx context.Context) (*schema.CollectionList, error)
+	InsertDocuments(ctx context.Context, req *schema.DocumentInsertRequest) (*schema.DocumentInsertResponse, error)
+	GetDocument(ctx context.Context, req *schema.DocumentGetRequest) (*schema.Document, error)
+	SearchDocuments(ctx context.Context, req *schema.DocumentSearchRequest) (*schema.DocumentList, error)
+	Scan(ctx context.Context, req *schema.ScanRequest) (*schema.Entries, error)
+	IndexScan(ctx context.Context, req *schema.IndexScanRequest) (*schema.Entries, error)
 	Close() error
 	GetOptions() *DbOptions
 	CompactIndex() error
-	VerifiableSQLGet(req *schema.VerifiableSQLGetRequest) (*schema.VerifiableSQLEntry, error)
-	SQLExec(req *schema.SQLExecRequest) (*schema.SQLExecResult, error)
-	SQLExecPrepared(stmts []sql.SQLStmt, namedParams []*schema.NamedParam, waitForIndexing bool) (*schema.SQLExecResult, error)
+	PrepareShrinkTo(dstPath string) error
+	ShrinkTo(dstPath string) error
+	VerifiableSQLGet(ctx context.Context, req *schema.VerifiableSQLGetRequest) (*schema.VerifiableSQLEntry, error)
+	VerifiableSQLQuery(ctx context.Context, req *schema.VerifiableSQLQueryRequest) (*schema.VerifiableSQLQueryResult, error)
+	SQLExec(ctx context.Context, req *schema.SQLExecRequest) (*schema.SQLExecResult, error)
+	SQLExecPrepared(ctx context.Context, stmts []sql.SQLStmt, namedParams []*schema.NamedParam, waitForIndexing bool) (*schema.SQLExecResult, error)
+	SQLBatch(ctx context.Context, req *schema.SQLBatchRequest) (*schema.SQLBatchResult, error)
+	SQLExecPreparedInTx(ctx context.Context, txID string, stmts []sql.SQLStmt, namedParams []*schema.NamedParam, waitForIndexing bool) (*schema.SQLExecResult, error)
 	UseSnapshot(req *schema.UseSnapshotRequest) error
-	SQLQuery(req *schema.SQLQueryRequest) (*schema.SQLQueryResult, error)
-	SQLQueryPrepared(stmt *sql.SelectStmt, namedParams []*schema.NamedParam, renewSnapshot bool) (*schema.SQLQueryResult, error)
+	SQLQuery(ctx context.Context, req *schema.SQLQueryRequest) (*schema.SQLQueryResult, error)
+	SQLQueryPrepared(ctx context.Context, stmt *sql.SelectStmt, namedParams []*schema.NamedParam, renewSnapshot bool) (*schema.SQLQueryResult, error)
+	SQLQueryColumnsPrepared(ctx context.Context, stmt *sql.SelectStmt, namedParams []*schema.NamedParam) ([]*schema.Column, error)
+	SQLQueryRowsPrepared(ctx context.Context, stmt *sql.SelectStmt, namedParams []*schema.NamedParam, renewSnapshot bool) (SQLRows, error)
+	InferParametersPrepared(stmt sql.SQLStmt) (map[string]sql.SQLValueType, error)
 	ListTables() (*schema.SQLQueryResult, error)
 	DescribeTable(table string) (*schema.SQLQueryResult, error)
+	SQLTables() ([]*SQLTableInfo, error)
+	HasSQLPolicies() bool
+	ExportSQLSchema() (*schema.SQLSchema, error)
+	ImportSQLSchema(ctx context.Context, req *schema.SQLSchema) (*schema.SQLExecResult, error)
 	GetName() string
+	StoreMetrics() *store.Metrics
+	Settings() *schema.DatabaseSettings
+	ExportTx(req *schema.TxRequest) ([]byte, error)
+	ReplicateTx(exportedTx []byte) (*schema.TxMetadata, error)
+	ExportedTxMetadata(exportedTx []byte) (*schema.TxMetadata, error)
+	ExportTxRange(req *schema.TxRangeRequest, w io.Writer) (*schema.TxRange, error)
+	ApplyTxRange(r io.Reader) (*schema.TxRange, error)
 }
 
 //IDB database instance
@@ -84,6 +126,12 @@ type db struct {
 	tx1, tx2 *store.Tx
 	mutex    sync.RWMutex
 
+	// transactions begun with BEGIN, whose statements are deferred until COMMIT
+	sqlTxs sqlTxs
+
+	// latest score assigned from the commit time, in microseconds
+	lastTimestampScore int64
+
 	Logger  logger.Logger
 	options *DbOptions
 
@@ -107,7 +155,7 @@ func OpenDb(op *DbOptions, catalogDB DB, log logger.Logger) (DB, error) {
 		return nil, fmt.Errorf("Missing database directories")
 	}
 
-	dbi.st, err = store.Open(dbDir, op.GetStoreOptions().WithLog(log))
+	dbi.st, err = store.Open(dbDir, storeOptionsFor(op, log))
 	if err != nil {
 		return nil, logErr(dbi.Logger, "Unable to open store: %s", err)
 	}
@@ -136,6 +184,19 @@ func OpenDb(op *DbOptions, catalogDB DB, log logger.Logger) (DB, error) {
 	return dbi, nil
 }
 
+// storeOptionsFor returns the options the store of the database is opened with. Replicas only hold the txs
+// of their primary, thus values out of the retention period are not discarded by vacuuming them periodically
+func storeOptionsFor(op *DbOptions, log logger.Logger) *store.Options {
+	opts := *op.GetStoreOptions()
+	opts.WithLog(log)
+
+	if op.replica {
+		opts.WithVacuumInterval(0)
+	}
+
+	return &opts
+}
+
 // NewDb Creates a new Database along with it's directories and files
 func NewDb(op *DbOptions, catalogDB DB, log logger.Logger) (DB, error) {
 	var err error
@@ -156,7 +217,7 @@ func NewDb(op *DbOptions, catalogDB DB, log logger.Logger) (DB, error) {
 		return nil, logErr(dbi.Logger, "Unable to create data folder: %s", err)
 	}
 
-	dbi.st, err = store.Open(dbDir, op.GetStoreOptions().WithLog(log))
+	dbi.st, err = store.Open(dbDir, storeOptionsFor(op, log))
 	if err != nil {
 		return nil, logErr(dbi.Logger, "Unable to open store: %s", err)
 	}
@@ -177,7 +238,7 @@ func NewDb(op *DbOptions, catalogDB DB, log logger.Logger) (DB, error) {
 		return nil, logErr(dbi.Logger, "Unable to open store: %s", err)
 	}
 
-	_, _, err = dbi.sqlEngine.ExecPreparedStmts([]sql.SQLStmt{&sql.CreateDatabaseStmt{DB: dbi.options.dbName}}, nil, true)
+	_, _, err = dbi.sqlEngine.ExecPreparedStmts(context.Background(), []sql.SQLStmt{&sql.CreateDatabaseStmt{DB: dbi.options.dbName}}, nil, true)
 	if err != nil {
 		return nil, logErr(dbi.Logger, "Unable to open store: %s", err)
 	}
@@ -209,38 +270,118 @@ func (d *db) CompactIndex() error {
 }
 
 // Set ...
-func (d *db) Set(req *schema.SetRequest) (*schema.TxMetadata, error) {
+func (d *db) Set(ctx context.Context, req *schema.SetRequest) (*schema.TxMetadata, error) {
 	d.mutex.RLock()
 	defer d.mutex.RUnlock()
 
-	return d.set(req)
+	return d.set(ctx, req)
 }
 
-func (d *db) set(req *schema.SetRequest) (*schema.TxMetadata, error) {
+func (d *db) set(ctx context.Context, req *schema.SetRequest) (*schema.TxMetadata, error) {
 	if req == nil {
 		return nil, ErrIllegalArguments
 	}
 
+	if d.isReplica() {
+		return nil, ErrIsReplica
+	}
+
 	entries := make([]*store.KV, len(req.KVs))
 
 	for i, kv := range req.KVs {
-		if len(kv.Key) == 0 {
+		if len(kv.Key) == 0 || !schema.ValidKVMetadata(kv.Metadata) {
 			return nil, ErrIllegalArguments
 		}
 
-		entries[i] = EncodeKV(kv.Key, kv.Value)
+		entries[i] = EncodeKVWithMetadata(kv.Key, schema.KVMetadataFrom(kv.Metadata), kv.Value)
+	}
+
+	preconditions := make([]store.Precondition, len(req.Preconditions))
+
+	for i, c := range req.Preconditions {
+		p, err := preconditionFrom(c)
+		if err != nil {
+			return nil, err
+		}
+
+		preconditions[i] = p
+	}
+
+	attrs, err := txAttributesFor(req.TraceID)
+	if err != nil {
+		return nil, err
 	}
 
-	txMetatadata, err := d.st.Commit(entries, !req.NoWait)
+	txMetatadata, err := d.st.CommitWithAttributes(entries, attrs, preconditions, false)
 	if err != nil {
 		return nil, err
 	}
 
+	if !req.NoWait {
+		err = d.st.WaitForIndexingUpto(ctx, txMetatadata.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return schema.TxMetatadaTo(txMetatadata), nil
 }
 
+// txAttributesFor returns the attributes recording the given trace-id, nil if no trace-id is provided
+func txAttributesFor(traceID []byte) (*store.TxAttributes, error) {
+	if len(traceID) == 0 {
+		return nil, nil
+	}
+
+	attrs, err := store.NewTxAttributes().WithTraceID(traceID)
+	if err != nil {
+		return nil, ErrIllegalArguments
+	}
+
+	return attrs, nil
+}
+
+func preconditionFrom(c *schema.Precondition) (store.Precondition, error) {
+	if c == nil {
+		return nil, ErrIllegalArguments
+	}
+
+	switch p := c.Precondition.(type) {
+	case *schema.Precondition_KeyMustNotExist:
+		if p.KeyMustNotExist == nil || len(p.KeyMustNotExist.Key) == 0 {
+			return nil, ErrIllegalArguments
+		}
+
+		return &store.PreconditionKeyMustNotExist{
+			Key: EncodeKey(p.KeyMustNotExist.Key),
+		}, nil
+
+	case *schema.Precondition_KeyMustHaveRevision:
+		if p.KeyMustHaveRevision == nil || len(p.KeyMustHaveRevision.Key) == 0 {
+			return nil, ErrIllegalArguments
+		}
+
+		return &store.PreconditionKeyMustHaveRevision{
+			Key:      EncodeKey(p.KeyMustHaveRevision.Key),
+			Revision: p.KeyMustHaveRevision.Revision,
+		}, nil
+
+	case *schema.Precondition_KeyNotModifiedAfterTX:
+		if p.KeyNotModifiedAfterTX == nil || len(p.KeyNotModifiedAfterTX.Key) == 0 {
+			return nil, ErrIllegalArguments
+		}
+
+		return &store.PreconditionKeyNotModifiedAfterTx{
+			Key:  EncodeKey(p.KeyNotModifiedAfterTX.Key),
+			TxID: p.KeyNotModifiedAfterTX.TxID,
+		}, nil
+	}
+
+	return nil, ErrIllegalArguments
+}
+
 //Get ...
-func (d *db) Get(req *schema.KeyRequest) (*schema.Entry, error) {
+func (d *db) Get(ctx context.Context, req *schema.KeyRequest) (*schema.Entry, error) {
 	if req == nil || len(req.Key) == 0 {
 		return nil, ErrIllegalArguments
 	}
@@ -249,7 +390,15 @@ func (d *db) Get(req *schema.KeyRequest) (*schema.Entry, error) {
 		return nil, ErrIllegalArguments
 	}
 
-	err := d.st.WaitForIndexingUpto(req.SinceTx, nil)
+	if req.AsOfTs != 0 && (req.AsOfTs < 0 || req.AtTx > 0 || req.SinceTx > 0) {
+		return nil, ErrIllegalArguments
+	}
+
+	if req.AsOfTs > 0 {
+		return d.getAsOf(ctx, EncodeKey(req.Key), time.Unix(req.AsOfTs, 0))
+	}
+
+	err := d.st.WaitForIndexingUpto(ctx, req.SinceTx)
 	if err != nil {
 		return nil, err
 	}
@@ -260,6 +409,73 @@ func (d *db) Get(req *schema.KeyRequest) (*schema.Entry, error) {
 	return d.getAt(EncodeKey(req.Key), req.AtTx, 0, d.st, d.tx1)
 }
 
+// getAsOf resolves the key, and the references it may point to, as they were
+// right after the latest tx committed at or before the given time
+func (d *db) getAsOf(ctx context.Context, key []byte, t time.Time) (*schema.Entry, error) {
+	txID, err := d.st.LastTxUntil(t)
+	if err == store.ErrTxNotFound {
+		return nil, store.ErrKeyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.st.WaitForIndexingUpto(ctx, txID)
+	if err != nil {
+		return nil, err
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.getAt(key, 0, 0, &asOfIndex{ctx: ctx, db: d, txID: txID}, d.tx1)
+}
+
+// asOfIndex resolves keys as they were right after the given tx was committed,
+// the history of the key is not traversed further once the context gets done
+type asOfIndex struct {
+	ctx  context.Context
+	db   *db
+	txID uint64
+}
+
+func (idx *asOfIndex) Get(key []byte) (val []byte, tx uint64, hc uint64, err error) {
+	var offset uint64
+
+	for {
+		if err := idx.ctx.Err(); err != nil {
+			return nil, 0, 0, err
+		}
+
+		txs, err := idx.db.st.History(key, offset, true, MaxKeyScanLimit)
+		if err == store.ErrOffsetOutOfRange {
+			return nil, 0, 0, store.ErrKeyNotFound
+		}
+		if err != nil {
+			return nil, 0, 0, err
+		}
+
+		for _, txID := range txs {
+			if txID > idx.txID {
+				continue
+			}
+
+			val, err := idx.db.readValue(key, txID, idx.db.tx1)
+			if err != nil {
+				return nil, 0, 0, err
+			}
+
+			return val, txID, 0, nil
+		}
+
+		if len(txs) < MaxKeyScanLimit {
+			return nil, 0, 0, store.ErrKeyNotFound
+		}
+
+		offset += uint64(len(txs))
+	}
+}
+
 func (d *db) get(key []byte, index store.KeyIndex, tx *store.Tx) (*schema.Entry, error) {
 	return d.getAt(key, 0, 0, index, tx)
 }
@@ -317,6 +533,15 @@ func (d *db) readValue(key []byte, atTx uint64, tx *store.Tx) ([]byte, error) {
 	return d.st.ReadValue(tx, key)
 }
 
+func entryMetadata(tx *store.Tx, key []byte) (*schema.KVMetadata, error) {
+	i, err := tx.IndexOf(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return schema.KVMetadataTo(tx.Entries()[i].Metadata()), nil
+}
+
 //Health ...
 func (d *db) Health(*empty.Empty) (*schema.HealthResponse, error) {
 	return &schema.HealthResponse{Status: true, Version: fmt.Sprintf("%d", store.Version)}, nil
@@ -336,7 +561,7 @@ func (d *db) CurrentState() (*schema.ImmutableState, error) {
 }
 
 //VerifiableSet ...
-func (d *db) VerifiableSet(req *schema.VerifiableSetRequest) (*schema.VerifiableTx, error) {
+func (d *db) VerifiableSet(ctx context.Context, req *schema.VerifiableSetRequest) (*schema.VerifiableTx, error) {
 	if req == nil {
 		return nil, ErrIllegalArguments
 	}
@@ -346,7 +571,7 @@ func (d *db) VerifiableSet(req *schema.VerifiableSetRequest) (*schema.Verifiable
 		return nil, ErrIllegalState
 	}
 
-	txMetatadata, err := d.Set(req.SetRequest)
+	txMetatadata, err := d.Set(ctx, req.SetRequest)
 	if err != nil {
 		return nil, err
 	}
@@ -386,7 +611,7 @@ func (d *db) VerifiableSet(req *schema.VerifiableSetRequest) (*schema.Verifiable
 }
 
 //VerifiableGet ...
-func (d *db) VerifiableGet(req *schema.VerifiableGetRequest) (*schema.VerifiableEntry, error) {
+func (d *db) VerifiableGet(ctx context.Context, req *schema.VerifiableGetRequest) (*schema.VerifiableEntry, error) {
 	if req == nil {
 		return nil, ErrIllegalArguments
 	}
@@ -396,7 +621,30 @@ func (d *db) VerifiableGet(req *schema.VerifiableGetRequest) (*schema.Verifiable
 		return nil, ErrIllegalState
 	}
 
-	e, err := d.Get(req.KeyRequest)
+	e, err := d.Get(ctx, req.KeyRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.verifiableEntry(e, req.ProveSinceTx)
+}
+
+// VerifiableHistoricalGet returns the entry the key resolved to right after the given tx was committed,
+// together with the proofs of its inclusion in the tx that wrote it and of that tx in the state at the given tx
+func (d *db) VerifiableHistoricalGet(ctx context.Context, req *schema.VerifiableHistoricalGetRequest) (*schema.VerifiableEntry, error) {
+	if req == nil || len(req.Key) == 0 || req.AtTx == 0 {
+		return nil, ErrIllegalArguments
+	}
+
+	lastTxID, _ := d.st.Alh()
+	if lastTxID < req.AtTx {
+		return nil, ErrIllegalState
+	}
+
+	err := d.st.WaitForIndexingUpto(ctx, req.AtTx)
 	if err != nil {
 		return nil, err
 	}
@@ -404,6 +652,17 @@ func (d *db) VerifiableGet(req *schema.VerifiableGetRequest) (*schema.Verifiable
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
 
+	e, err := d.getAt(EncodeKey(req.Key), 0, 0, &asOfIndex{ctx: ctx, db: d, txID: req.AtTx}, d.tx1)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.verifiableEntry(e, req.AtTx)
+}
+
+// verifiableEntry builds the proofs of the given entry, linking the tx it was written in with the one of rootTxID.
+// When rootTxID is zero, the tx of the entry is used
+func (d *db) verifiableEntry(e *schema.Entry, rootTxID uint64) (*schema.VerifiableEntry, error) {
 	txEntry := d.tx1
 
 	var vTxID uint64
@@ -418,7 +677,7 @@ func (d *db) VerifiableGet(req *schema.VerifiableGetRequest) (*schema.Verifiable
 	}
 
 	// key-value inclusion proof
-	err = d.st.ReadTx(vTxID, txEntry)
+	err := d.st.ReadTx(vTxID, txEntry)
 	if err != nil {
 		return nil, err
 	}
@@ -428,14 +687,21 @@ func (d *db) VerifiableGet(req *schema.VerifiableGetRequest) (*schema.Verifiable
 		return nil, err
 	}
 
+	if e.ReferencedBy == nil {
+		e.Metadata, err = entryMetadata(txEntry, EncodeKey(vKey))
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	var rootTx *store.Tx
 
-	if req.ProveSinceTx == 0 {
+	if rootTxID == 0 {
 		rootTx = txEntry
 	} else {
 		rootTx = d.tx2
 
-		err = d.st.ReadTx(req.ProveSinceTx, rootTx)
+		err = d.st.ReadTx(rootTxID, rootTx)
 		if err != nil {
 			return nil, err
 		}
@@ -443,7 +709,7 @@ func (d *db) VerifiableGet(req *schema.VerifiableGetRequest) (*schema.Verifiable
 
 	var sourceTx, targetTx *store.Tx
 
-	if req.ProveSinceTx <= vTxID {
+	if rootTxID <= vTxID {
 		sourceTx = rootTx
 		targetTx = txEntry
 	} else {
@@ -469,8 +735,8 @@ func (d *db) VerifiableGet(req *schema.VerifiableGetRequest) (*schema.Verifiable
 }
 
 //GetAll ...
-func (d *db) GetAll(req *schema.KeyListRequest) (*schema.Entries, error) {
-	err := d.st.WaitForIndexingUpto(req.SinceTx, nil)
+func (d *db) GetAll(ctx context.Context, req *schema.KeyListRequest) (*schema.Entries, error) {
+	err := d.st.WaitForIndexingUpto(ctx, req.SinceTx)
 	if err != nil {
 		return nil, err
 	}
@@ -487,6 +753,10 @@ func (d *db) GetAll(req *schema.KeyListRequest) (*schema.Entries, error) {
 	list := &schema.Entries{}
 
 	for _, key := range req.Keys {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		e, err := d.get(EncodeKey(key), snapshot, d.tx1)
 		if err == nil || err == store.ErrKeyNotFound {
 			if e != nil {
@@ -508,6 +778,31 @@ func (d *db) Size() (uint64, error) {
 	return d.st.TxCount(), nil
 }
 
+// OpenedFiles returns the files currently open by the tx, commit and value logs of the database
+func (d *db) OpenedFiles() ([]*schema.LogOpenedFiles, error) {
+	logs, err := d.st.OpenedFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*schema.LogOpenedFiles, len(logs))
+
+	for i, l := range logs {
+		res[i] = &schema.LogOpenedFiles{
+			Path:           l.Path,
+			Files:          l.OpenedFiles,
+			MaxOpenedFiles: uint32(l.MaxOpenedFiles),
+		}
+	}
+
+	return res, nil
+}
+
+// CloseCachedFiles closes the files the logs of the database keep open for reading, they get reopened when read again
+func (d *db) CloseCachedFiles() (int, error) {
+	return d.st.CloseCachedFiles()
+}
+
 //Count ...
 func (d *db) Count(prefix *schema.KeyPrefix) (*schema.EntryCount, error) {
 	return nil, fmt.Errorf("Functionality not yet supported: %s", "Count")
@@ -533,7 +828,26 @@ func (d *db) TxByID(req *schema.TxRequest) (*schema.Tx, error) {
 		return nil, err
 	}
 
-	return schema.TxTo(d.tx1), nil
+	tx := schema.TxTo(d.tx1)
+
+	// entries of large txs may be fetched in pages, while
+	// the header alone is enough to verify the tx
+	if req.DigestOnly {
+		tx.Entries = nil
+		return tx, nil
+	}
+
+	if int(req.EntriesOffset) > len(tx.Entries) {
+		return nil, ErrIllegalArguments
+	}
+
+	tx.Entries = tx.Entries[req.EntriesOffset:]
+
+	if req.EntriesLimit > 0 && int(req.EntriesLimit) < len(tx.Entries) {
+		tx.Entries = tx.Entries[:req.EntriesLimit]
+	}
+
+	return tx, nil
 }
 
 //VerifiableTxByID ...
@@ -592,8 +906,8 @@ func (d *db) VerifiableTxByID(req *schema.VerifiableTxRequest) (*schema.Verifiab
 	}, nil
 }
 
-//TxScan ...
-func (d *db) TxScan(req *schema.TxScanRequest) (*schema.TxList, error) {
+// TxScan reads up to req.Limit txs, txs are not read once the context gets done
+func (d *db) TxScan(ctx context.Context, req *schema.TxScanRequest) (*schema.TxList, error) {
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
 
@@ -619,6 +933,10 @@ func (d *db) TxScan(req *schema.TxScanRequest) (*schema.TxList, error) {
 	txList := &schema.TxList{}
 
 	for i := 0; i < limit; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		tx, err := txReader.Read()
 		if err == store.ErrNoMoreEntries {
 			break
@@ -633,8 +951,36 @@ func (d *db) TxScan(req *schema.TxScanRequest) (*schema.TxList, error) {
 	return txList, nil
 }
 
+// Subscribe sends committed transactions starting from req.InitialTx, blocking until new ones are committed.
+// Transactions are sent one at a time so a slow consumer throttles the subscription.
+// It returns once req.Limit transactions were sent (no limit if zero), the cancellation channel is closed or send fails
+func (d *db) Subscribe(req *schema.SubscribeRequest, send func(tx *schema.Tx) error, cancellation <-chan struct{}) error {
+	if req == nil || send == nil {
+		return ErrIllegalArguments
+	}
+
+	sub, err := d.st.Subscribe(req.InitialTx, d.st.NewTx(), cancellation)
+	if err != nil {
+		return err
+	}
+
+	for i := uint32(0); req.Limit == 0 || i < req.Limit; i++ {
+		tx, err := sub.Next()
+		if err != nil {
+			return err
+		}
+
+		err = send(schema.TxTo(tx))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 //History ...
-func (d *db) History(req *schema.HistoryRequest) (*schema.Entries, error) {
+func (d *db) History(ctx context.Context, req *schema.HistoryRequest) (*schema.Entries, error) {
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
 
@@ -646,7 +992,7 @@ func (d *db) History(req *schema.HistoryRequest) (*schema.Entries, error) {
 		return nil, ErrMaxKeyScanLimitExceeded
 	}
 
-	err := d.st.WaitForIndexingUpto(req.SinceTx, nil)
+	err := d.st.WaitForIndexingUpto(ctx, req.SinceTx)
 	if err != nil {
 		return nil, err
 	}
@@ -659,17 +1005,21 @@ func (d *db) History(req *schema.HistoryRequest) (*schema.Entries, error) {
 
 	key := EncodeKey(req.Key)
 
-	txs, err := d.st.History(key, req.Offset, req.Desc, limit)
+	revs, err := d.st.HistoryAt(key, req.AtRevision, req.Offset, req.Desc, limit)
 	if err != nil && err != store.ErrOffsetOutOfRange {
 		return nil, err
 	}
 
 	list := &schema.Entries{
-		Entries: make([]*schema.Entry, len(txs)),
+		Entries: make([]*schema.Entry, len(revs)),
 	}
 
-	for i, tx := range txs {
-		err = d.st.ReadTx(tx, d.tx1)
+	for i, rev := range revs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		err = d.st.ReadTx(rev.Tx, d.tx1)
 		if err != nil {
 			return nil, err
 		}
@@ -679,7 +1029,18 @@ func (d *db) History(req *schema.HistoryRequest) (*schema.Entries, error) {
 			return nil, err
 		}
 
-		list.Entries[i] = &schema.Entry{Key: req.Key, Value: TrimPrefix(val), Tx: tx}
+		md, err := entryMetadata(d.tx1, key)
+		if err != nil {
+			return nil, err
+		}
+
+		list.Entries[i] = &schema.Entry{
+			Key:      req.Key,
+			Value:    TrimPrefix(val),
+			Tx:       rev.Tx,
+			Metadata: md,
+			Revision: rev.Revision,
+		}
 	}
 
 	return list, nil
@@ -698,11 +1059,59 @@ func (d *db) Close() error {
 	return d.st.Close()
 }
 
+// PrepareShrinkTo discards the values out of the retention period and writes into dstPath a compacted copy of
+// the changes made so far, which can be opened to build its index before being completed by ShrinkTo
+func (d *db) PrepareShrinkTo(dstPath string) error {
+	err := d.st.Vacuum()
+	if err != nil {
+		return err
+	}
+
+	err = d.st.PrepareShrinkTo(dstPath)
+	if err != nil {
+		return err
+	}
+
+	// the index of the copy is built in advance, so it only has to be updated
+	// with the changes made in the meantime once the copy is completed
+	st, err := store.Open(dstPath, d.options.GetStoreOptions().WithLog(d.Logger))
+	if err != nil {
+		return err
+	}
+
+	err = st.WaitForIndexingUpto(context.Background(), st.TxCount())
+	if err != nil {
+		st.Close()
+		return err
+	}
+
+	return st.Close()
+}
+
+// ShrinkTo completes the compacted copy prepared into dstPath, or writes it if it was not prepared.
+// Changes are accepted while the copy is being made, but not anymore once completed so the copy can take its place
+func (d *db) ShrinkTo(dstPath string) error {
+	_, err := os.Stat(dstPath)
+	if os.IsNotExist(err) {
+		err = d.PrepareShrinkTo(dstPath)
+	}
+	if err != nil {
+		return err
+	}
+
+	return d.st.ShrinkTo(dstPath)
+}
+
 // GetName ...
 func (d *db) GetName() string {
 	return d.name
 }
 
+// StoreMetrics returns the current values of the internal gauges of the underlying store
+func (d *db) StoreMetrics() *store.Metrics {
+	return d.st.Metrics()
+}
+
 //GetOptions ...
 func (d *db) GetOptions() *DbOptions {
 	return d.options
@@ -714,3 +1123,13 @@ func logErr(log logger.Logger, formattedMessage string, err error) error {
 	}
 	return err
 }
+
+// Settings returns the limits enforced by the underlying store
+func (d *db) Settings() *schema.DatabaseSettings {
+	return &schema.DatabaseSettings{
+		DatabaseName: d.name,
+		MaxKeyLen:    uint32(d.st.MaxKeyLen()),
+		MaxValueLen:  uint32(d.st.MaxValueLen()),
+		MaxTxEntries: uint32(d.st.MaxTxEntries()),
+	}
+}
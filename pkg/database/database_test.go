@@ -16,7 +16,9 @@ limitations under the License.
 package database
 
 import (
+	"context"
 	"crypto/sha256"
+	"errors"
 	"log"
 	"os"
 	"path"
@@ -195,16 +197,55 @@ func TestDbSynchronousSet(t *testing.T) {
 	defer closer()
 
 	for _, kv := range kvs {
-		_, err := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{kv}})
+		_, err := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{kv}})
 		require.NoError(t, err)
 
-		item, err := db.Get(&schema.KeyRequest{Key: kv.Key})
+		item, err := db.Get(context.Background(), &schema.KeyRequest{Key: kv.Key})
 		require.NoError(t, err)
 		require.Equal(t, kv.Key, item.Key)
 		require.Equal(t, kv.Value, item.Value)
 	}
 }
 
+func TestDbGetWithContext(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	txMetadata, err := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{kvs[0]}})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = db.Get(ctx, &schema.KeyRequest{Key: kvs[0].Key, SinceTx: txMetadata.Id + 1})
+	require.Equal(t, context.DeadlineExceeded, err)
+
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = db.Scan(cancelledCtx, &schema.ScanRequest{Prefix: kvs[0].Key})
+	require.Equal(t, context.Canceled, err)
+
+	_, err = db.SQLExec(cancelledCtx, &schema.SQLExecRequest{Sql: "CREATE TABLE mytable(id INTEGER, PRIMARY KEY id)"})
+	require.Equal(t, context.Canceled, err)
+
+	_, err = db.History(cancelledCtx, &schema.HistoryRequest{Key: kvs[0].Key})
+	require.Equal(t, context.Canceled, err)
+
+	_, err = db.GetAll(cancelledCtx, &schema.KeyListRequest{Keys: [][]byte{kvs[0].Key}})
+	require.Equal(t, context.Canceled, err)
+
+	_, err = db.Get(cancelledCtx, &schema.KeyRequest{Key: kvs[0].Key, AsOfTs: time.Now().Unix()})
+	require.Equal(t, context.Canceled, err)
+
+	_, err = db.TxScan(cancelledCtx, &schema.TxScanRequest{InitialTx: 1})
+	require.Equal(t, context.Canceled, err)
+
+	item, err := db.Get(context.Background(), &schema.KeyRequest{Key: kvs[0].Key, SinceTx: txMetadata.Id})
+	require.NoError(t, err)
+	require.Equal(t, kvs[0].Value, item.Value)
+}
+
 func TestDbSetGet(t *testing.T) {
 	db, closer := makeDb()
 	defer closer()
@@ -212,14 +253,14 @@ func TestDbSetGet(t *testing.T) {
 	var trustedAlh [sha256.Size]byte
 	var trustedIndex uint64
 
-	_, err := db.Set(nil)
+	_, err := db.Set(context.Background(), nil)
 	require.Equal(t, ErrIllegalArguments, err)
 
-	_, err = db.VerifiableGet(nil)
+	_, err = db.VerifiableGet(context.Background(), nil)
 	require.Equal(t, ErrIllegalArguments, err)
 
 	for i, kv := range kvs {
-		txMetadata, err := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{kv}})
+		txMetadata, err := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{kv}})
 		require.NoError(t, err)
 		require.Equal(t, uint64(i+1), txMetadata.Id)
 
@@ -231,15 +272,15 @@ func TestDbSetGet(t *testing.T) {
 
 		keyReq := &schema.KeyRequest{Key: kv.Key, SinceTx: txMetadata.Id}
 
-		item, err := db.Get(keyReq)
+		item, err := db.Get(context.Background(), keyReq)
 		require.NoError(t, err)
 		require.Equal(t, kv.Key, item.Key)
 		require.Equal(t, kv.Value, item.Value)
 
-		_, err = db.Get(&schema.KeyRequest{Key: kv.Key, SinceTx: txMetadata.Id, AtTx: txMetadata.Id})
+		_, err = db.Get(context.Background(), &schema.KeyRequest{Key: kv.Key, SinceTx: txMetadata.Id, AtTx: txMetadata.Id})
 		require.Equal(t, ErrIllegalArguments, err)
 
-		vitem, err := db.VerifiableGet(&schema.VerifiableGetRequest{
+		vitem, err := db.VerifiableGet(context.Background(), &schema.VerifiableGetRequest{
 			KeyRequest:   keyReq,
 			ProveSinceTx: trustedIndex,
 		})
@@ -285,7 +326,7 @@ func TestDbSetGet(t *testing.T) {
 		require.True(t, verifies)
 	}
 
-	_, err = db.Get(&schema.KeyRequest{Key: []byte{}})
+	_, err = db.Get(context.Background(), &schema.KeyRequest{Key: []byte{}})
 	require.Error(t, err)
 }
 
@@ -294,7 +335,7 @@ func TestCurrentState(t *testing.T) {
 	defer closer()
 
 	for ind, val := range kvs {
-		txMetadata, err := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: val.Key, Value: val.Value}}})
+		txMetadata, err := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: val.Key, Value: val.Value}}})
 		require.NoError(t, err)
 		require.Equal(t, uint64(ind+1), txMetadata.Id)
 
@@ -313,10 +354,10 @@ func TestSafeSetGet(t *testing.T) {
 	state, err := db.CurrentState()
 	require.NoError(t, err)
 
-	_, err = db.VerifiableSet(nil)
+	_, err = db.VerifiableSet(context.Background(), nil)
 	require.Equal(t, ErrIllegalArguments, err)
 
-	_, err = db.VerifiableSet(&schema.VerifiableSetRequest{
+	_, err = db.VerifiableSet(context.Background(), &schema.VerifiableSetRequest{
 		SetRequest: &schema.SetRequest{
 			KVs: []*schema.KeyValue{
 				{
@@ -366,11 +407,11 @@ func TestSafeSetGet(t *testing.T) {
 	}
 
 	for ind, val := range kv {
-		vtx, err := db.VerifiableSet(val)
+		vtx, err := db.VerifiableSet(context.Background(), val)
 		require.NoError(t, err)
 		require.NotNil(t, vtx)
 
-		vit, err := db.VerifiableGet(&schema.VerifiableGetRequest{
+		vit, err := db.VerifiableGet(context.Background(), &schema.VerifiableGetRequest{
 			KeyRequest: &schema.KeyRequest{
 				Key:     val.SetRequest.KVs[0].Key,
 				SinceTx: vtx.Tx.Metadata.Id,
@@ -381,6 +422,109 @@ func TestSafeSetGet(t *testing.T) {
 	}
 }
 
+func TestSetGetWithExpiration(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	expiresAt := time.Now().Add(1 * time.Hour).Unix()
+
+	txMetadata, err := db.Set(context.Background(), &schema.SetRequest{
+		KVs: []*schema.KeyValue{
+			{
+				Key:      []byte("expirableKey"),
+				Value:    []byte("expirableValue"),
+				Metadata: &schema.KVMetadata{Expiration: &schema.Expiration{ExpiresAt: expiresAt}},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(store.TxVersion1), txMetadata.Version)
+
+	vitem, err := db.VerifiableGet(context.Background(), &schema.VerifiableGetRequest{
+		KeyRequest: &schema.KeyRequest{Key: []byte("expirableKey")},
+	})
+	require.NoError(t, err)
+	require.Equal(t, expiresAt, vitem.Entry.Metadata.Expiration.ExpiresAt)
+
+	tx := schema.TxFrom(vitem.VerifiableTx.Tx)
+	require.Equal(t, schema.DigestFrom(vitem.VerifiableTx.DualProof.TargetTxMetadata.EH), tx.Eh())
+
+	kv := EncodeKVWithMetadata([]byte("expirableKey"), schema.KVMetadataFrom(vitem.Entry.Metadata), []byte("expirableValue"))
+	require.True(t, store.VerifyInclusion(schema.InclusionProofFrom(vitem.InclusionProof), kv, tx.Eh()))
+
+	_, err = db.Set(context.Background(), &schema.SetRequest{
+		KVs: []*schema.KeyValue{
+			{
+				Key:      []byte("expirableKey"),
+				Value:    []byte("expiredValue"),
+				Metadata: &schema.KVMetadata{Expiration: &schema.Expiration{ExpiresAt: time.Now().Add(-1 * time.Hour).Unix()}},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = db.Get(context.Background(), &schema.KeyRequest{Key: []byte("expirableKey")})
+	require.Equal(t, store.ErrKeyNotFound, err)
+
+	entries, err := db.Scan(context.Background(), &schema.ScanRequest{Prefix: []byte("expirable")})
+	require.NoError(t, err)
+	require.Empty(t, entries.Entries)
+
+	history, err := db.History(context.Background(), &schema.HistoryRequest{Key: []byte("expirableKey")})
+	require.NoError(t, err)
+	require.Len(t, history.Entries, 2)
+	require.Equal(t, []byte("expiredValue"), history.Entries[1].Value)
+	require.NotNil(t, history.Entries[1].Metadata.Expiration)
+}
+
+func TestSetWithPreconditions(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	kvs := []*schema.KeyValue{{Key: []byte("key"), Value: []byte("value")}}
+
+	mustNotExist := &schema.Precondition{
+		Precondition: &schema.Precondition_KeyMustNotExist{
+			KeyMustNotExist: &schema.Precondition_KeyMustNotExistPrecondition{Key: []byte("key")},
+		},
+	}
+
+	_, err := db.Set(context.Background(), &schema.SetRequest{KVs: kvs, Preconditions: []*schema.Precondition{nil}})
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = db.Set(context.Background(), &schema.SetRequest{KVs: kvs, Preconditions: []*schema.Precondition{{}}})
+	require.Equal(t, ErrIllegalArguments, err)
+
+	txMetadata, err := db.Set(context.Background(), &schema.SetRequest{KVs: kvs, Preconditions: []*schema.Precondition{mustNotExist}})
+	require.NoError(t, err)
+
+	_, err = db.Set(context.Background(), &schema.SetRequest{KVs: kvs, Preconditions: []*schema.Precondition{mustNotExist}})
+	require.True(t, errors.Is(err, ErrPreconditionFailed))
+
+	revision := func(rev uint64) *schema.Precondition {
+		return &schema.Precondition{
+			Precondition: &schema.Precondition_KeyMustHaveRevision{
+				KeyMustHaveRevision: &schema.Precondition_KeyMustHaveRevisionPrecondition{Key: []byte("key"), Revision: rev},
+			},
+		}
+	}
+
+	_, err = db.Set(context.Background(), &schema.SetRequest{KVs: kvs, Preconditions: []*schema.Precondition{revision(2)}})
+	require.True(t, errors.Is(err, ErrPreconditionFailed))
+
+	_, err = db.Set(context.Background(), &schema.SetRequest{KVs: kvs, Preconditions: []*schema.Precondition{revision(1)}})
+	require.NoError(t, err)
+
+	notModifiedAfter := &schema.Precondition{
+		Precondition: &schema.Precondition_KeyNotModifiedAfterTX{
+			KeyNotModifiedAfterTX: &schema.Precondition_KeyNotModifiedAfterTXPrecondition{Key: []byte("key"), TxID: txMetadata.Id},
+		},
+	}
+
+	_, err = db.Set(context.Background(), &schema.SetRequest{KVs: kvs, Preconditions: []*schema.Precondition{notModifiedAfter}})
+	require.True(t, errors.Is(err, ErrPreconditionFailed))
+}
+
 func TestSetGetAll(t *testing.T) {
 	db, closer := makeDb()
 	defer closer()
@@ -400,14 +544,14 @@ func TestSetGetAll(t *testing.T) {
 		},
 	}
 
-	txMetadata, err := db.Set(&schema.SetRequest{KVs: kvs})
+	txMetadata, err := db.Set(context.Background(), &schema.SetRequest{KVs: kvs})
 	require.NoError(t, err)
 	require.Equal(t, uint64(1), txMetadata.Id)
 
 	err = db.CompactIndex()
 	require.NoError(t, err)
 
-	itList, err := db.GetAll(&schema.KeyListRequest{
+	itList, err := db.GetAll(context.Background(), &schema.KeyListRequest{
 		Keys: [][]byte{
 			[]byte("Alberto"),
 			[]byte("Jean-Claude"),
@@ -430,7 +574,7 @@ func TestTxByID(t *testing.T) {
 	require.Error(t, ErrIllegalArguments, err)
 
 	for ind, val := range kvs {
-		txMetadata, err := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: val.Key, Value: val.Value}}})
+		txMetadata, err := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: val.Key, Value: val.Value}}})
 		require.NoError(t, err)
 		require.Equal(t, uint64(ind+1), txMetadata.Id)
 	}
@@ -439,6 +583,40 @@ func TestTxByID(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestTxByIDWithEntriesPagination(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	txMetadata, err := db.Set(context.Background(), &schema.SetRequest{KVs: kvs})
+	require.NoError(t, err)
+
+	tx, err := db.TxByID(&schema.TxRequest{Tx: txMetadata.Id})
+	require.NoError(t, err)
+	require.Len(t, tx.Entries, len(kvs))
+
+	page, err := db.TxByID(&schema.TxRequest{Tx: txMetadata.Id, EntriesOffset: 1, EntriesLimit: 1})
+	require.NoError(t, err)
+	require.Len(t, page.Entries, 1)
+	require.Equal(t, tx.Entries[1].Key, page.Entries[0].Key)
+	require.Equal(t, tx.Metadata.EH, page.Metadata.EH)
+
+	page, err = db.TxByID(&schema.TxRequest{Tx: txMetadata.Id, EntriesOffset: 1, EntriesLimit: uint32(len(kvs))})
+	require.NoError(t, err)
+	require.Len(t, page.Entries, len(kvs)-1)
+
+	page, err = db.TxByID(&schema.TxRequest{Tx: txMetadata.Id, EntriesOffset: uint32(len(kvs))})
+	require.NoError(t, err)
+	require.Empty(t, page.Entries)
+
+	_, err = db.TxByID(&schema.TxRequest{Tx: txMetadata.Id, EntriesOffset: uint32(len(kvs) + 1)})
+	require.Equal(t, ErrIllegalArguments, err)
+
+	header, err := db.TxByID(&schema.TxRequest{Tx: txMetadata.Id, DigestOnly: true})
+	require.NoError(t, err)
+	require.Empty(t, header.Entries)
+	require.Equal(t, tx.Metadata, header.Metadata)
+}
+
 func TestVerifiableTxByID(t *testing.T) {
 	db, closer := makeDb()
 	defer closer()
@@ -447,7 +625,7 @@ func TestVerifiableTxByID(t *testing.T) {
 	require.Error(t, ErrIllegalArguments, err)
 
 	for _, val := range kvs {
-		_, err := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: val.Key, Value: val.Value}}})
+		_, err := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: val.Key, Value: val.Value}}})
 		require.NoError(t, err)
 	}
 
@@ -458,30 +636,75 @@ func TestVerifiableTxByID(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestSubscribe(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	send := func(tx *schema.Tx) error { return nil }
+
+	err := db.Subscribe(nil, send, nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	err = db.Subscribe(&schema.SubscribeRequest{InitialTx: 1}, nil, nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	err = db.Subscribe(&schema.SubscribeRequest{InitialTx: 0}, send, nil)
+	require.Equal(t, store.ErrIllegalArguments, err)
+
+	for _, val := range kvs {
+		_, err := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: val.Key, Value: val.Value}}})
+		require.NoError(t, err)
+	}
+
+	var txs []*schema.Tx
+
+	err = db.Subscribe(&schema.SubscribeRequest{InitialTx: 2, Limit: 2}, func(tx *schema.Tx) error {
+		txs = append(txs, tx)
+		return nil
+	}, nil)
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+	require.Equal(t, uint64(2), txs[0].Metadata.Id)
+	require.Equal(t, uint64(3), txs[1].Metadata.Id)
+
+	errSend := errors.New("send error")
+
+	err = db.Subscribe(&schema.SubscribeRequest{InitialTx: 1}, func(tx *schema.Tx) error {
+		return errSend
+	}, nil)
+	require.Equal(t, errSend, err)
+
+	cancellation := make(chan struct{})
+	close(cancellation)
+
+	err = db.Subscribe(&schema.SubscribeRequest{InitialTx: uint64(len(kvs) + 1)}, send, cancellation)
+	require.Equal(t, store.ErrCancellationRequested, err)
+}
+
 func TestTxScan(t *testing.T) {
 	db, closer := makeDb()
 	defer closer()
 
 	for _, val := range kvs {
-		_, err := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: val.Key, Value: val.Value}}})
+		_, err := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: val.Key, Value: val.Value}}})
 		require.NoError(t, err)
 	}
 
-	_, err := db.TxScan(nil)
+	_, err := db.TxScan(context.Background(), nil)
 	require.Equal(t, ErrIllegalArguments, err)
 
-	_, err = db.TxScan(&schema.TxScanRequest{
+	_, err = db.TxScan(context.Background(), &schema.TxScanRequest{
 		InitialTx: 0,
 	})
 	require.Equal(t, ErrIllegalArguments, err)
 
-	_, err = db.TxScan(&schema.TxScanRequest{
+	_, err = db.TxScan(context.Background(), &schema.TxScanRequest{
 		InitialTx: 1,
 		Limit:     MaxKeyScanLimit + 1,
 	})
 	require.Equal(t, ErrMaxKeyScanLimitExceeded, err)
 
-	txList, err := db.TxScan(&schema.TxScanRequest{
+	txList, err := db.TxScan(context.Background(), &schema.TxScanRequest{
 		InitialTx: 1,
 	})
 	require.NoError(t, err)
@@ -499,7 +722,7 @@ func TestHistory(t *testing.T) {
 	var lastTx uint64
 
 	for _, val := range kvs {
-		meta, err := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: val.Key, Value: val.Value}}})
+		meta, err := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: val.Key, Value: val.Value}}})
 		require.NoError(t, err)
 
 		lastTx = meta.Id
@@ -507,17 +730,17 @@ func TestHistory(t *testing.T) {
 
 	time.Sleep(1 * time.Millisecond)
 
-	_, err := db.History(nil)
+	_, err := db.History(context.Background(), nil)
 	require.Equal(t, ErrIllegalArguments, err)
 
-	_, err = db.History(&schema.HistoryRequest{
+	_, err = db.History(context.Background(), &schema.HistoryRequest{
 		Key:     kvs[0].Key,
 		SinceTx: lastTx,
 		Limit:   MaxKeyScanLimit + 1,
 	})
 	require.Equal(t, ErrMaxKeyScanLimitExceeded, err)
 
-	inc, err := db.History(&schema.HistoryRequest{
+	inc, err := db.History(context.Background(), &schema.HistoryRequest{
 		Key:     kvs[0].Key,
 		SinceTx: lastTx,
 	})
@@ -528,7 +751,7 @@ func TestHistory(t *testing.T) {
 		require.Equal(t, kvs[0].Value, val.Value)
 	}
 
-	inc, err = db.History(&schema.HistoryRequest{
+	inc, err = db.History(context.Background(), &schema.HistoryRequest{
 		Key:     kvs[0].Key,
 		Offset:  uint64(len(kvs) + 1),
 		SinceTx: lastTx,
@@ -537,6 +760,160 @@ func TestHistory(t *testing.T) {
 	require.Empty(t, inc.Entries)
 }
 
+func TestHistoryPagination(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	key := []byte("key1")
+
+	var lastTx uint64
+
+	for i := 0; i < 5; i++ {
+		meta, err := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: key, Value: []byte{byte(i)}}}})
+		require.NoError(t, err)
+
+		lastTx = meta.Id
+	}
+
+	page, err := db.History(context.Background(), &schema.HistoryRequest{
+		Key:     key,
+		Desc:    true,
+		Limit:   2,
+		SinceTx: lastTx,
+	})
+	require.NoError(t, err)
+	require.Len(t, page.Entries, 2)
+	require.Equal(t, uint64(5), page.Entries[0].Revision)
+	require.Equal(t, []byte{4}, page.Entries[0].Value)
+	require.Equal(t, uint64(4), page.Entries[1].Revision)
+
+	atRevision := page.Entries[0].Revision
+
+	meta, err := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: key, Value: []byte{5}}}})
+	require.NoError(t, err)
+
+	page, err = db.History(context.Background(), &schema.HistoryRequest{
+		Key:        key,
+		Offset:     2,
+		Desc:       true,
+		Limit:      10,
+		SinceTx:    meta.Id,
+		AtRevision: atRevision,
+	})
+	require.NoError(t, err)
+	require.Len(t, page.Entries, 3)
+
+	for i, e := range page.Entries {
+		require.Equal(t, uint64(3-i), e.Revision)
+		require.Equal(t, []byte{byte(2 - i)}, e.Value)
+	}
+
+	page, err = db.History(context.Background(), &schema.HistoryRequest{
+		Key:     key,
+		Offset:  4,
+		SinceTx: meta.Id,
+	})
+	require.NoError(t, err)
+	require.Len(t, page.Entries, 2)
+	require.Equal(t, uint64(5), page.Entries[0].Revision)
+	require.Equal(t, uint64(6), page.Entries[1].Revision)
+}
+
+func TestIndexScan(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	_, err := db.IndexScan(context.Background(), nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = db.IndexScan(context.Background(), &schema.IndexScanRequest{Index: "byTag", Limit: MaxKeyScanLimit + 1})
+	require.Equal(t, ErrMaxKeyScanLimitExceeded, err)
+
+	_, err = db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{
+		Key:      []byte("key0"),
+		Value:    []byte("value0"),
+		Metadata: &schema.KVMetadata{Indexes: []*schema.KVIndex{{Name: "byTag"}}},
+	}}})
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = db.ExecAll(context.Background(), &schema.ExecAllRequest{Operations: []*schema.Op{{
+		Operation: &schema.Op_Kv{Kv: &schema.KeyValue{
+			Key:      []byte("key0"),
+			Value:    []byte("value0"),
+			Metadata: &schema.KVMetadata{Indexes: []*schema.KVIndex{{Name: "by tag", Tag: []byte("t")}}},
+		}},
+	}}})
+	require.Equal(t, ErrIllegalArguments, err)
+
+	kvs := []*schema.KeyValue{
+		{
+			Key:   []byte("alice"),
+			Value: []byte("ES:madrid"),
+			Metadata: &schema.KVMetadata{Indexes: []*schema.KVIndex{
+				{Name: "byTag", Tag: []byte("admin")},
+				{Name: "byCountry", ValuePrefixLen: 2},
+			}},
+		},
+		{
+			Key:   []byte("bob"),
+			Value: []byte("IT:rome"),
+			Metadata: &schema.KVMetadata{Indexes: []*schema.KVIndex{
+				{Name: "byCountry", ValuePrefixLen: 2},
+			}},
+		},
+		{
+			Key:   []byte("carol"),
+			Value: []byte("ES:sevilla"),
+			Metadata: &schema.KVMetadata{Indexes: []*schema.KVIndex{
+				{Name: "byTag", Tag: []byte("admin")},
+				{Name: "byCountry", ValuePrefixLen: 2},
+			}},
+		},
+		{
+			Key:   []byte("dave"),
+			Value: []byte("FR:paris"),
+		},
+	}
+
+	meta, err := db.Set(context.Background(), &schema.SetRequest{KVs: kvs})
+	require.NoError(t, err)
+
+	admins, err := db.IndexScan(context.Background(), &schema.IndexScanRequest{
+		Index:    "byTag",
+		StartKey: []byte("admin"),
+		EndKey:   []byte("admin\x00"),
+		SinceTx:  meta.Id,
+	})
+	require.NoError(t, err)
+	require.Len(t, admins.Entries, 2)
+	require.Equal(t, []byte("alice"), admins.Entries[0].Key)
+	require.Equal(t, []byte("ES:madrid"), admins.Entries[0].Value)
+	require.Equal(t, meta.Id, admins.Entries[0].Tx)
+	require.Equal(t, []byte("carol"), admins.Entries[1].Key)
+
+	spanish, err := db.IndexScan(context.Background(), &schema.IndexScanRequest{
+		Index:    "byCountry",
+		StartKey: []byte("ES"),
+		EndKey:   []byte("ES\x00"),
+		Desc:     true,
+		Limit:    1,
+	})
+	require.NoError(t, err)
+	require.Len(t, spanish.Entries, 1)
+	require.Equal(t, []byte("carol"), spanish.Entries[0].Key)
+
+	all, err := db.IndexScan(context.Background(), &schema.IndexScanRequest{Index: "byCountry"})
+	require.NoError(t, err)
+	require.Len(t, all.Entries, 3)
+	require.Equal(t, []byte("alice"), all.Entries[0].Key)
+	require.Equal(t, []byte("carol"), all.Entries[1].Key)
+	require.Equal(t, []byte("bob"), all.Entries[2].Key)
+
+	none, err := db.IndexScan(context.Background(), &schema.IndexScanRequest{Index: "unknown"})
+	require.NoError(t, err)
+	require.Empty(t, none.Entries)
+}
+
 func TestHealth(t *testing.T) {
 	db, closer := makeDb()
 	defer closer()
@@ -923,3 +1300,277 @@ func TestDb_SetBatchAtomicOperations(t *testing.T) {
 	require.NoError(t, err)
 }
 */
+
+func TestGetAsOf(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	_, err := db.Get(context.Background(), &schema.KeyRequest{Key: []byte("key"), AsOfTs: -1})
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = db.Get(context.Background(), &schema.KeyRequest{Key: []byte("key"), AsOfTs: time.Now().Unix(), AtTx: 1})
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = db.Get(context.Background(), &schema.KeyRequest{Key: []byte("key"), AsOfTs: time.Now().Unix(), SinceTx: 1})
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = db.Get(context.Background(), &schema.KeyRequest{Key: []byte("key"), AsOfTs: time.Now().Unix()})
+	require.Equal(t, store.ErrKeyNotFound, err)
+
+	tx1, err := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key"), Value: []byte("value1")}}})
+	require.NoError(t, err)
+
+	tx2, err := db.SetReference(context.Background(), &schema.ReferenceRequest{Key: []byte("ref"), ReferencedKey: []byte("key")})
+	require.NoError(t, err)
+
+	// let the clock move on so that the next tx gets a different timestamp
+	time.Sleep(time.Until(time.Unix(tx2.Ts+1, 0)))
+
+	tx3, err := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key"), Value: []byte("value2")}}})
+	require.NoError(t, err)
+	require.Greater(t, tx3.Ts, tx2.Ts)
+
+	_, err = db.Get(context.Background(), &schema.KeyRequest{Key: []byte("key"), AsOfTs: tx1.Ts - 1})
+	require.Equal(t, store.ErrKeyNotFound, err)
+
+	entry, err := db.Get(context.Background(), &schema.KeyRequest{Key: []byte("key"), AsOfTs: tx1.Ts})
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1"), entry.Value)
+	require.Equal(t, tx1.Id, entry.Tx)
+
+	entry, err = db.Get(context.Background(), &schema.KeyRequest{Key: []byte("ref"), AsOfTs: tx2.Ts})
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1"), entry.Value)
+	require.NotNil(t, entry.ReferencedBy)
+
+	entry, err = db.Get(context.Background(), &schema.KeyRequest{Key: []byte("key"), AsOfTs: tx3.Ts})
+	require.NoError(t, err)
+	require.Equal(t, []byte("value2"), entry.Value)
+	require.Equal(t, tx3.Id, entry.Tx)
+
+	entry, err = db.Get(context.Background(), &schema.KeyRequest{Key: []byte("ref"), AsOfTs: tx3.Ts})
+	require.NoError(t, err)
+	require.Equal(t, []byte("value2"), entry.Value)
+
+	_, err = db.Get(context.Background(), &schema.KeyRequest{Key: []byte("unknown"), AsOfTs: tx3.Ts})
+	require.Equal(t, store.ErrKeyNotFound, err)
+}
+
+func TestVerifiableHistoricalGet(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	_, err := db.VerifiableHistoricalGet(context.Background(), nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = db.VerifiableHistoricalGet(context.Background(), &schema.VerifiableHistoricalGetRequest{Key: []byte("key")})
+	require.Equal(t, ErrIllegalArguments, err)
+
+	tx1, err := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key"), Value: []byte("value1")}}})
+	require.NoError(t, err)
+
+	tx2, err := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("other"), Value: []byte("value")}}})
+	require.NoError(t, err)
+
+	tx3, err := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key"), Value: []byte("value2")}}})
+	require.NoError(t, err)
+
+	_, err = db.VerifiableHistoricalGet(context.Background(), &schema.VerifiableHistoricalGetRequest{Key: []byte("key"), AtTx: tx3.Id + 1})
+	require.Equal(t, ErrIllegalState, err)
+
+	_, err = db.VerifiableHistoricalGet(context.Background(), &schema.VerifiableHistoricalGetRequest{Key: []byte("other"), AtTx: tx1.Id})
+	require.Equal(t, store.ErrKeyNotFound, err)
+
+	for _, c := range []struct {
+		atTx    *schema.TxMetadata
+		entryTx *schema.TxMetadata
+		value   []byte
+	}{
+		{tx1, tx1, []byte("value1")},
+		{tx2, tx1, []byte("value1")},
+		{tx3, tx3, []byte("value2")},
+	} {
+		vitem, err := db.VerifiableHistoricalGet(context.Background(), &schema.VerifiableHistoricalGetRequest{
+			Key:  []byte("key"),
+			AtTx: c.atTx.Id,
+		})
+		require.NoError(t, err)
+		require.Equal(t, c.value, vitem.Entry.Value)
+		require.Equal(t, c.entryTx.Id, vitem.Entry.Tx)
+
+		inclusionProof := schema.InclusionProofFrom(vitem.InclusionProof)
+		dualProof := schema.DualProofFrom(vitem.VerifiableTx.DualProof)
+
+		verifies := store.VerifyInclusion(
+			inclusionProof,
+			EncodeKVWithMetadata(vitem.Entry.Key, schema.KVMetadataFrom(vitem.Entry.Metadata), vitem.Entry.Value),
+			dualProof.SourceTxMetadata.Eh,
+		)
+		require.True(t, verifies)
+
+		verifies = store.VerifyDualProof(
+			dualProof,
+			c.entryTx.Id,
+			c.atTx.Id,
+			schema.TxMetadataFrom(c.entryTx).Alh(),
+			schema.TxMetadataFrom(c.atTx).Alh(),
+		)
+		require.True(t, verifies)
+	}
+}
+
+func TestShrinkTo(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	_, err := db.Set(context.Background(), &schema.SetRequest{KVs: kvs})
+	require.NoError(t, err)
+
+	dstPath := filepath.Join(db.GetOptions().GetDbRootPath(), "db_shrunk")
+
+	err = db.ShrinkTo(dstPath)
+	require.NoError(t, err)
+
+	_, err = db.Set(context.Background(), &schema.SetRequest{KVs: kvs})
+	require.Equal(t, store.ErrStoreShrunk, err)
+
+	// reads are still served until the compacted copy takes its place
+	item, err := db.Get(context.Background(), &schema.KeyRequest{Key: kvs[0].Key})
+	require.NoError(t, err)
+	require.Equal(t, kvs[0].Value, item.Value)
+
+	state, err := db.CurrentState()
+	require.NoError(t, err)
+
+	st, err := store.Open(dstPath, db.GetOptions().GetStoreOptions())
+	require.NoError(t, err)
+
+	require.Equal(t, state.TxId, st.TxCount())
+
+	err = st.Close()
+	require.NoError(t, err)
+}
+
+func TestPrepareShrinkTo(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	_, err := db.Set(context.Background(), &schema.SetRequest{KVs: kvs})
+	require.NoError(t, err)
+
+	dstPath := filepath.Join(db.GetOptions().GetDbRootPath(), "db_shrunk")
+
+	err = db.PrepareShrinkTo(dstPath)
+	require.NoError(t, err)
+
+	require.DirExists(t, filepath.Join(dstPath, "index"))
+
+	// changes are still accepted once the copy is prepared
+	_, err = db.Set(context.Background(), &schema.SetRequest{KVs: kvs})
+	require.NoError(t, err)
+
+	err = db.ShrinkTo(dstPath)
+	require.NoError(t, err)
+
+	_, err = db.Set(context.Background(), &schema.SetRequest{KVs: kvs})
+	require.Equal(t, store.ErrStoreShrunk, err)
+
+	state, err := db.CurrentState()
+	require.NoError(t, err)
+
+	st, err := store.Open(dstPath, db.GetOptions().GetStoreOptions())
+	require.NoError(t, err)
+
+	require.Equal(t, state.TxId, st.TxCount())
+
+	err = st.WaitForIndexingUpto(context.Background(), state.TxId)
+	require.NoError(t, err)
+
+	v, _, _, err := st.Get(EncodeKey(kvs[0].Key))
+	require.NoError(t, err)
+	require.Equal(t, kvs[0].Value, TrimPrefix(v))
+
+	err = st.Close()
+	require.NoError(t, err)
+}
+
+func TestStoreOptionsForReplica(t *testing.T) {
+	log := logger.NewSimpleLogger("immudb ", os.Stderr)
+
+	opts := DefaultOption()
+	opts.GetStoreOptions().WithRetentionPeriod(time.Hour)
+
+	require.Equal(t, store.DefaultVacuumInterval, storeOptionsFor(opts, log).VacuumInterval)
+
+	// replicas do not vacuum their values on their own
+	replicaOpts := storeOptionsFor(opts.WithReplica(true), log)
+	require.Zero(t, replicaOpts.VacuumInterval)
+	require.Equal(t, time.Hour, replicaOpts.RetentionPeriod)
+
+	require.Equal(t, store.DefaultVacuumInterval, opts.GetStoreOptions().VacuumInterval)
+}
+
+func TestTimeFunc(t *testing.T) {
+	rootPath := "data_time_func"
+	defer os.RemoveAll(rootPath)
+
+	now := time.Unix(1600000000, 0)
+
+	options := DefaultOption().WithDbRootPath(rootPath).WithDbName("db").WithCorruptionChecker(false)
+	options.GetStoreOptions().WithTimeFunc(func() time.Time { return now })
+
+	db, err := NewDb(options, nil, logger.NewSimpleLogger("immudb ", os.Stderr))
+	require.NoError(t, err)
+	defer db.Close()
+
+	meta, err := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key1"), Value: []byte("value1")}}})
+	require.NoError(t, err)
+	require.Equal(t, now.Unix(), meta.Ts)
+
+	now = now.Add(time.Hour)
+
+	meta, err = db.ZAdd(context.Background(), &schema.ZAddRequest{
+		Set:       []byte("set1"),
+		Key:       []byte("key1"),
+		ScoreMode: schema.ZAddScoreMode_TIMESTAMP_SCORE,
+	})
+	require.NoError(t, err)
+	require.Equal(t, now.Unix(), meta.Ts)
+
+	tx, err := db.TxByID(&schema.TxRequest{Tx: meta.Id})
+	require.NoError(t, err)
+	require.Equal(t, now.Unix(), tx.Metadata.Ts)
+
+	list, err := db.ZScan(context.Background(), &schema.ZScanRequest{Set: []byte("set1"), SinceTx: meta.Id})
+	require.NoError(t, err)
+	require.Len(t, list.Entries, 1)
+	require.Equal(t, float64(now.UnixNano()/int64(time.Microsecond)), list.Entries[0].Score)
+
+	entry, err := db.Get(context.Background(), &schema.KeyRequest{Key: []byte("key1"), AsOfTs: now.Add(-time.Minute).Unix()})
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1"), entry.Value)
+}
+
+func TestOpenedFiles(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	_, err := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key1"), Value: []byte("value1")}}})
+	require.NoError(t, err)
+
+	logs, err := db.OpenedFiles()
+	require.NoError(t, err)
+	require.Len(t, logs, 2+db.GetOptions().GetStoreOptions().MaxIOConcurrency)
+
+	for _, l := range logs {
+		require.NotEmpty(t, l.Files)
+		require.Greater(t, l.MaxOpenedFiles, uint32(0))
+	}
+
+	_, err = db.CloseCachedFiles()
+	require.NoError(t, err)
+
+	entry, err := db.Get(context.Background(), &schema.KeyRequest{Key: []byte("key1")})
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1"), entry.Value)
+}
@@ -16,7 +16,11 @@ limitations under the License.
 
 package database
 
-import "github.com/codenotary/immudb/embedded/store"
+import (
+	"time"
+
+	"github.com/codenotary/immudb/embedded/store"
+)
 
 //DbOptions database instance options
 type DbOptions struct {
@@ -25,6 +29,14 @@ type DbOptions struct {
 	dbRootPath        string
 	corruptionChecker bool
 	storeOpts         *store.Options
+
+	// replicas only accept txs replicated from their primary
+	replica bool
+
+	// idempotent single-statement writes conflicting with concurrent txs are retried up to sqlMaxRetries times,
+	// waiting a random period up to sqlRetryBackoff before the first retry, which is doubled on every retry
+	sqlMaxRetries   int
+	sqlRetryBackoff time.Duration
 }
 
 // DefaultOption Initialise Db Optionts to default values
@@ -34,7 +46,10 @@ func DefaultOption() *DbOptions {
 		dbName:            "db_name",
 		dbRootPath:        "./data",
 		corruptionChecker: true,
-		storeOpts:         store.DefaultOptions(),
+		storeOpts: store.DefaultOptions().
+			WithIndexOptions(store.DefaultIndexOptions().
+				WithSecondaryIndexes(true).
+				WithValuePrefixOffset(1)),
 	}
 }
 
@@ -81,3 +96,32 @@ func (o *DbOptions) WithStoreOptions(storeOpts *store.Options) *DbOptions {
 func (o *DbOptions) GetStoreOptions() *store.Options {
 	return o.storeOpts
 }
+
+// WithReplica sets if the database is a replica, replicas reject any write not coming from replication
+func (o *DbOptions) WithReplica(replica bool) *DbOptions {
+	o.replica = replica
+	return o
+}
+
+// IsReplica returns if the database is a replica
+func (o *DbOptions) IsReplica() bool {
+	return o.replica
+}
+
+// WithSQLRetries sets how many times idempotent single-statement writes conflicting with concurrent txs are retried
+// and the maximum period waited before the first retry, which is doubled on every retry. Writes are not retried by default
+func (o *DbOptions) WithSQLRetries(maxRetries int, backoff time.Duration) *DbOptions {
+	o.sqlMaxRetries = maxRetries
+	o.sqlRetryBackoff = backoff
+	return o
+}
+
+// GetSQLMaxRetries returns how many times idempotent single-statement writes conflicting with concurrent txs are retried
+func (o *DbOptions) GetSQLMaxRetries() int {
+	return o.sqlMaxRetries
+}
+
+// GetSQLRetryBackoff returns the maximum period waited before retrying a conflicting write for the first time
+func (o *DbOptions) GetSQLRetryBackoff() time.Duration {
+	return o.sqlRetryBackoff
+}
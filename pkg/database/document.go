@@ -0,0 +1,643 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package database
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"strings"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/rs/xid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// DocumentIDField is the field identifying a document within its collection
+const DocumentIDField = "_id"
+
+// MaxDocumentIDLen is the max length of the id of a document
+const MaxDocumentIDLen = 256
+
+// documents of a collection are indexed by the secondary index named after it
+const collectionIndexPrefix = "doc_"
+
+// MaxCollectionNameLen is the max length of the name of a collection
+const MaxCollectionNameLen = store.MaxKVIndexNameLen - len(collectionIndexPrefix)
+
+const (
+	collectionKeyTag byte = iota
+	documentKeyTag
+)
+
+// types of the values documents are indexed by
+const (
+	nullValueTag byte = iota
+	boolValueTag
+	numberValueTag
+	stringValueTag
+)
+
+// CreateCollection creates a collection whose documents get indexed by the given top-level fields
+func (d *db) CreateCollection(ctx context.Context, req *schema.Collection) (*schema.TxMetadata, error) {
+	if req == nil || !validCollectionName(req.Name) || len(req.IndexedFields) > store.MaxKVIndexes {
+		return nil, ErrIllegalArguments
+	}
+
+	for i, f := range req.IndexedFields {
+		if len(f) == 0 {
+			return nil, ErrIllegalArguments
+		}
+
+		for _, other := range req.IndexedFields[:i] {
+			if f == other {
+				return nil, ErrIllegalArguments
+			}
+		}
+	}
+
+	if d.isReplica() {
+		return nil, ErrIsReplica
+	}
+
+	val, err := proto.Marshal(&schema.Collection{Name: req.Name, IndexedFields: req.IndexedFields})
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []*store.KV{{Key: collectionKey(req.Name), Value: val, Unique: true}}
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	txMetatadata, err := d.st.Commit(entries, false)
+	if err == store.ErrKeyAlreadyExists {
+		return nil, ErrCollectionAlreadyExists
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// documents are validated against the indexed collection
+	err = d.st.WaitForIndexingUpto(ctx, txMetatadata.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return schema.TxMetatadaTo(txMetatadata), nil
+}
+
+// ListCollections returns all the collections sorted by name
+func (d *db) ListCollections(ctx context.Context) (*schema.CollectionList, error) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	snap, err := d.documentSnapshot(ctx, 0, false)
+	if err != nil {
+		return nil, err
+	}
+	defer snap.Close()
+
+	var collections []*schema.Collection
+
+	err = snap.Iterate([]byte{DocumentKeyPrefix, collectionKeyTag}, func(key, val []byte, tx uint64) (bool, error) {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+
+		collection, err := decodeCollection(val)
+		if err != nil {
+			return false, err
+		}
+
+		collections = append(collections, collection)
+
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema.CollectionList{Collections: collections}, nil
+}
+
+// InsertDocuments inserts documents into a collection, all of them are committed within the same tx.
+// Documents without an id get one generated, a document replaces the previous one with the same id
+func (d *db) InsertDocuments(ctx context.Context, req *schema.DocumentInsertRequest) (*schema.DocumentInsertResponse, error) {
+	if req == nil || len(req.Documents) == 0 {
+		return nil, ErrIllegalArguments
+	}
+
+	if d.isReplica() {
+		return nil, ErrIsReplica
+	}
+
+	collection, err := d.getCollection(req.Collection)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*store.KV, len(req.Documents))
+	ids := make([]string, len(req.Documents))
+	inserted := make(map[string]struct{}, len(req.Documents))
+
+	for i, doc := range req.Documents {
+		if doc == nil {
+			return nil, ErrIllegalArguments
+		}
+
+		fields := doc.AsMap()
+
+		id, err := documentID(fields)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := inserted[id]; ok {
+			return nil, ErrIllegalArguments
+		}
+		inserted[id] = struct{}{}
+
+		val, err := json.Marshal(fields)
+		if err != nil {
+			return nil, err
+		}
+
+		var md *store.KVMetadata
+
+		for pos, f := range collection.IndexedFields {
+			tag, ok := documentIndexTag(pos, doc.Fields[f])
+			if !ok {
+				continue
+			}
+
+			if md == nil {
+				md = store.NewKVMetadata()
+			}
+
+			_, err = md.IndexedBy(collectionIndexName(collection.Name), tag)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		entries[i] = &store.KV{Key: documentKey(collection.Name, id), Metadata: md, Value: val}
+		ids[i] = id
+	}
+
+	attrs, err := txAttributesFor(req.TraceID)
+	if err != nil {
+		return nil, err
+	}
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	txMetatadata, err := d.st.CommitWithAttributes(entries, attrs, nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if !req.NoWait {
+		err = d.st.WaitForIndexingUpto(ctx, txMetatadata.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &schema.DocumentInsertResponse{TxMetadata: schema.TxMetatadaTo(txMetatadata), Ids: ids}, nil
+}
+
+// GetDocument returns the latest version of a document
+func (d *db) GetDocument(ctx context.Context, req *schema.DocumentGetRequest) (*schema.Document, error) {
+	if req == nil || !validCollectionName(req.Collection) || len(req.Id) == 0 || len(req.Id) > MaxDocumentIDLen {
+		return nil, ErrIllegalArguments
+	}
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	snap, err := d.documentSnapshot(ctx, req.SinceTx, req.NoWait)
+	if err != nil {
+		return nil, err
+	}
+	defer snap.Close()
+
+	_, _, _, err = snap.Get(collectionKey(req.Collection))
+	if err == store.ErrKeyNotFound {
+		return nil, ErrCollectionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	val, tx, _, err := snap.Get(documentKey(req.Collection, req.Id))
+	if err == store.ErrKeyNotFound {
+		return nil, ErrDocumentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeDocument(val, tx)
+}
+
+// SearchDocuments returns the documents of a collection matching all the filters sorted by id.
+// An equality filter on an indexed field is resolved through the index of the collection,
+// otherwise all the documents of the collection are read
+func (d *db) SearchDocuments(ctx context.Context, req *schema.DocumentSearchRequest) (*schema.DocumentList, error) {
+	if req == nil || !validCollectionName(req.Collection) {
+		return nil, ErrIllegalArguments
+	}
+
+	for _, f := range req.Filters {
+		if f == nil || len(f.Field) == 0 || f.Value == nil {
+			return nil, ErrIllegalArguments
+		}
+
+		if f.Operator < schema.DocumentFilterOperator_EQ || f.Operator > schema.DocumentFilterOperator_GE {
+			return nil, ErrIllegalArguments
+		}
+	}
+
+	if req.Limit > MaxKeyScanLimit {
+		return nil, ErrMaxKeyScanLimitExceeded
+	}
+
+	limit := int(req.Limit)
+
+	if req.Limit == 0 {
+		limit = MaxKeyScanLimit
+	}
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	snap, err := d.documentSnapshot(ctx, req.SinceTx, req.NoWait)
+	if err != nil {
+		return nil, err
+	}
+	defer snap.Close()
+
+	val, _, _, err := snap.Get(collectionKey(req.Collection))
+	if err == store.ErrKeyNotFound {
+		return nil, ErrCollectionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	collection, err := decodeCollection(val)
+	if err != nil {
+		return nil, err
+	}
+
+	var docs []*schema.Document
+
+	add := func(val []byte, tx uint64) (bool, error) {
+		doc, err := decodeDocument(val, tx)
+		if err != nil {
+			return false, err
+		}
+
+		if matchesAll(doc.Document, req.Filters) {
+			docs = append(docs, doc)
+		}
+
+		return len(docs) < limit, nil
+	}
+
+	indexed, err := d.searchIndexedDocuments(ctx, snap, collection, req.Filters, add)
+	if err != nil {
+		return nil, err
+	}
+
+	if !indexed {
+		err = searchAllDocuments(ctx, snap, collection, add)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &schema.DocumentList{Documents: docs}, nil
+}
+
+// searchIndexedDocuments reads the documents indexed under the first equality filter on an indexed field.
+// Index entries of documents replaced afterwards are not removed, thus documents are read from the snapshot
+// and filters evaluated on them. It returns false when no filter can be resolved through the index
+func (d *db) searchIndexedDocuments(ctx context.Context, snap *store.Snapshot, collection *schema.Collection,
+	filters []*schema.DocumentFilter, add func(val []byte, tx uint64) (bool, error)) (bool, error) {
+
+	var tag []byte
+
+	for _, f := range filters {
+		if f.Operator != schema.DocumentFilterOperator_EQ {
+			continue
+		}
+
+		for pos, field := range collection.IndexedFields {
+			if field != f.Field {
+				continue
+			}
+
+			if t, ok := documentIndexTag(pos, f.Value); ok {
+				tag = t
+			}
+		}
+
+		if tag != nil {
+			break
+		}
+	}
+
+	if tag == nil {
+		return false, nil
+	}
+
+	r, err := d.st.IndexScan(collectionIndexName(collection.Name), &store.ScanBySpec{
+		StartKey: tag,
+		EndKey:   append(append([]byte{}, tag...), 0),
+		SinceTx:  snap.Ts(),
+	})
+	if err == store.ErrSecondaryIndexesDisabled {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer r.Close()
+
+	prefix := documentsPrefix(collection.Name)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return true, err
+		}
+
+		_, key, _, _, err := r.Read()
+		if err == store.ErrNoMoreEntries {
+			return true, nil
+		}
+		if err != nil {
+			return true, err
+		}
+
+		if !bytes.HasPrefix(key, prefix) {
+			continue
+		}
+
+		val, tx, _, err := snap.Get(key)
+		if err == store.ErrKeyNotFound {
+			continue
+		}
+		if err != nil {
+			return true, err
+		}
+
+		more, err := add(val, tx)
+		if err != nil || !more {
+			return true, err
+		}
+	}
+}
+
+func searchAllDocuments(ctx context.Context, snap *store.Snapshot, collection *schema.Collection,
+	add func(val []byte, tx uint64) (bool, error)) error {
+
+	return snap.Iterate(documentsPrefix(collection.Name), func(key, val []byte, tx uint64) (bool, error) {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+
+		return add(val, tx)
+	})
+}
+
+func (d *db) documentSnapshot(ctx context.Context, sinceTx uint64, noWait bool) (*store.Snapshot, error) {
+	waitUntilTx := sinceTx
+
+	if waitUntilTx == 0 {
+		waitUntilTx, _ = d.st.Alh()
+	}
+
+	if !noWait {
+		err := d.st.WaitForIndexingUpto(ctx, waitUntilTx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return d.st.SnapshotSince(waitUntilTx)
+}
+
+func (d *db) getCollection(name string) (*schema.Collection, error) {
+	if !validCollectionName(name) {
+		return nil, ErrIllegalArguments
+	}
+
+	val, _, _, err := d.st.Get(collectionKey(name))
+	if err == store.ErrKeyNotFound {
+		return nil, ErrCollectionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeCollection(val)
+}
+
+func validCollectionName(name string) bool {
+	if len(name) == 0 || len(name) > MaxCollectionNameLen {
+		return false
+	}
+
+	for _, c := range name {
+		if !(c >= 'a' && c <= 'z') && !(c >= 'A' && c <= 'Z') && !(c >= '0' && c <= '9') && c != '_' && c != '-' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func collectionIndexName(collection string) string {
+	return collectionIndexPrefix + collection
+}
+
+func collectionKey(name string) []byte {
+	return append([]byte{DocumentKeyPrefix, collectionKeyTag}, name...)
+}
+
+func documentsPrefix(collection string) []byte {
+	return append([]byte{DocumentKeyPrefix, documentKeyTag, byte(len(collection))}, collection...)
+}
+
+func documentKey(collection, id string) []byte {
+	return append(documentsPrefix(collection), id...)
+}
+
+func decodeCollection(val []byte) (*schema.Collection, error) {
+	collection := &schema.Collection{}
+
+	err := proto.Unmarshal(val, collection)
+	if err != nil {
+		return nil, store.ErrCorruptedData
+	}
+
+	return collection, nil
+}
+
+func decodeDocument(val []byte, tx uint64) (*schema.Document, error) {
+	var fields map[string]interface{}
+
+	err := json.Unmarshal(val, &fields)
+	if err != nil {
+		return nil, store.ErrCorruptedData
+	}
+
+	doc, err := structpb.NewStruct(fields)
+	if err != nil {
+		return nil, store.ErrCorruptedData
+	}
+
+	return &schema.Document{Document: doc, Tx: tx}, nil
+}
+
+// documentID returns the id of the document, one is generated and set when missing
+func documentID(fields map[string]interface{}) (string, error) {
+	v, ok := fields[DocumentIDField]
+	if !ok {
+		id := xid.New().String()
+		fields[DocumentIDField] = id
+		return id, nil
+	}
+
+	id, ok := v.(string)
+	if !ok || len(id) == 0 || len(id) > MaxDocumentIDLen {
+		return "", ErrIllegalArguments
+	}
+
+	return id, nil
+}
+
+// documentIndexTag encodes the value of the indexed field at position pos, objects and lists are not indexed.
+// Strings are truncated to fit within the max tag length, thus matching documents must still be filtered
+func documentIndexTag(pos int, v *structpb.Value) ([]byte, bool) {
+	tag := []byte{byte(pos), 0}
+
+	switch k := v.GetKind().(type) {
+	case *structpb.Value_NullValue:
+		tag[1] = nullValueTag
+	case *structpb.Value_BoolValue:
+		tag[1] = boolValueTag
+		if k.BoolValue {
+			tag = append(tag, 1)
+		} else {
+			tag = append(tag, 0)
+		}
+	case *structpb.Value_NumberValue:
+		tag[1] = numberValueTag
+
+		n := k.NumberValue
+		if n == 0 {
+			// -0 and 0 are equal
+			n = 0
+		}
+
+		var b [8]byte
+		binary.BigEndian.PutUint64(b[:], math.Float64bits(n))
+		tag = append(tag, b[:]...)
+	case *structpb.Value_StringValue:
+		tag[1] = stringValueTag
+
+		s := k.StringValue
+		if len(s) > store.MaxKVIndexKeyLen-len(tag) {
+			s = s[:store.MaxKVIndexKeyLen-len(tag)]
+		}
+
+		tag = append(tag, s...)
+	default:
+		return nil, false
+	}
+
+	return tag, true
+}
+
+func matchesAll(doc *structpb.Struct, filters []*schema.DocumentFilter) bool {
+	for _, f := range filters {
+		if !matches(doc, f) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matches evaluates the filter on the document, documents without the field never match.
+// Numbers and strings are ordered, other values can only be compared by (in)equality
+func matches(doc *structpb.Struct, f *schema.DocumentFilter) bool {
+	v, ok := doc.GetFields()[f.Field]
+	if !ok {
+		return false
+	}
+
+	switch f.Operator {
+	case schema.DocumentFilterOperator_EQ:
+		return proto.Equal(v, f.Value)
+	case schema.DocumentFilterOperator_NE:
+		return !proto.Equal(v, f.Value)
+	}
+
+	var cmp int
+
+	switch k := v.GetKind().(type) {
+	case *structpb.Value_NumberValue:
+		n, ok := f.Value.GetKind().(*structpb.Value_NumberValue)
+		if !ok {
+			return false
+		}
+
+		if k.NumberValue < n.NumberValue {
+			cmp = -1
+		} else if k.NumberValue > n.NumberValue {
+			cmp = 1
+		}
+	case *structpb.Value_StringValue:
+		s, ok := f.Value.GetKind().(*structpb.Value_StringValue)
+		if !ok {
+			return false
+		}
+
+		cmp = strings.Compare(k.StringValue, s.StringValue)
+	default:
+		return false
+	}
+
+	switch f.Operator {
+	case schema.DocumentFilterOperator_LT:
+		return cmp < 0
+	case schema.DocumentFilterOperator_LE:
+		return cmp <= 0
+	case schema.DocumentFilterOperator_GT:
+		return cmp > 0
+	default:
+		return cmp >= 0
+	}
+}
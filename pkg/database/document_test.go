@@ -0,0 +1,237 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package database
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func newDocument(t *testing.T, fields map[string]interface{}) *structpb.Struct {
+	doc, err := structpb.NewStruct(fields)
+	require.NoError(t, err)
+	return doc
+}
+
+func documentIDs(docs *schema.DocumentList) []string {
+	ids := make([]string, len(docs.Documents))
+
+	for i, doc := range docs.Documents {
+		ids[i] = doc.Document.Fields[DocumentIDField].GetStringValue()
+	}
+
+	return ids
+}
+
+func TestCollections(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	_, err := db.CreateCollection(context.Background(), nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = db.CreateCollection(context.Background(), &schema.Collection{Name: "invalid name"})
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = db.CreateCollection(context.Background(), &schema.Collection{Name: strings.Repeat("c", MaxCollectionNameLen+1)})
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = db.CreateCollection(context.Background(), &schema.Collection{Name: "c", IndexedFields: []string{"a", "a"}})
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = db.CreateCollection(context.Background(), &schema.Collection{Name: "c", IndexedFields: []string{"a", "b", "c", "d", "e"}})
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = db.CreateCollection(context.Background(), &schema.Collection{Name: "users", IndexedFields: []string{"name", "age"}})
+	require.NoError(t, err)
+
+	_, err = db.CreateCollection(context.Background(), &schema.Collection{Name: "users"})
+	require.Equal(t, ErrCollectionAlreadyExists, err)
+
+	_, err = db.CreateCollection(context.Background(), &schema.Collection{Name: "orders"})
+	require.NoError(t, err)
+
+	collections, err := db.ListCollections(context.Background())
+	require.NoError(t, err)
+	require.Len(t, collections.Collections, 2)
+	require.Equal(t, "orders", collections.Collections[0].Name)
+	require.Empty(t, collections.Collections[0].IndexedFields)
+	require.Equal(t, "users", collections.Collections[1].Name)
+	require.Equal(t, []string{"name", "age"}, collections.Collections[1].IndexedFields)
+}
+
+func TestDocuments(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	_, err := db.CreateCollection(context.Background(), &schema.Collection{Name: "users", IndexedFields: []string{"name", "active"}})
+	require.NoError(t, err)
+
+	_, err = db.InsertDocuments(context.Background(), nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = db.InsertDocuments(context.Background(), &schema.DocumentInsertRequest{
+		Collection: "missing",
+		Documents:  []*structpb.Struct{newDocument(t, nil)},
+	})
+	require.Equal(t, ErrCollectionNotFound, err)
+
+	_, err = db.InsertDocuments(context.Background(), &schema.DocumentInsertRequest{
+		Collection: "users",
+		Documents:  []*structpb.Struct{newDocument(t, map[string]interface{}{DocumentIDField: 1})},
+	})
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = db.InsertDocuments(context.Background(), &schema.DocumentInsertRequest{
+		Collection: "users",
+		Documents: []*structpb.Struct{
+			newDocument(t, map[string]interface{}{DocumentIDField: "u1"}),
+			newDocument(t, map[string]interface{}{DocumentIDField: "u1"}),
+		},
+	})
+	require.Equal(t, ErrIllegalArguments, err)
+
+	res, err := db.InsertDocuments(context.Background(), &schema.DocumentInsertRequest{
+		Collection: "users",
+		Documents: []*structpb.Struct{
+			newDocument(t, map[string]interface{}{DocumentIDField: "u1", "name": "alice", "age": 30, "active": true}),
+			newDocument(t, map[string]interface{}{DocumentIDField: "u2", "name": "bob", "age": 25, "active": false}),
+			newDocument(t, map[string]interface{}{"name": "carol", "age": 41, "tags": []interface{}{"admin"}}),
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(3), res.TxMetadata.Nentries)
+	require.Len(t, res.Ids, 3)
+	require.Equal(t, "u1", res.Ids[0])
+	require.Equal(t, "u2", res.Ids[1])
+	require.NotEmpty(t, res.Ids[2])
+
+	_, err = db.GetDocument(context.Background(), &schema.DocumentGetRequest{Collection: "missing", Id: "u1"})
+	require.Equal(t, ErrCollectionNotFound, err)
+
+	_, err = db.GetDocument(context.Background(), &schema.DocumentGetRequest{Collection: "users", Id: "u3"})
+	require.Equal(t, ErrDocumentNotFound, err)
+
+	doc, err := db.GetDocument(context.Background(), &schema.DocumentGetRequest{Collection: "users", Id: res.Ids[2]})
+	require.NoError(t, err)
+	require.Equal(t, res.TxMetadata.Id, doc.Tx)
+	require.Equal(t, "carol", doc.Document.Fields["name"].GetStringValue())
+	require.Equal(t, float64(41), doc.Document.Fields["age"].GetNumberValue())
+	require.Equal(t, res.Ids[2], doc.Document.Fields[DocumentIDField].GetStringValue())
+
+	_, err = db.SearchDocuments(context.Background(), &schema.DocumentSearchRequest{
+		Collection: "users",
+		Filters:    []*schema.DocumentFilter{{Field: "name"}},
+	})
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = db.SearchDocuments(context.Background(), &schema.DocumentSearchRequest{Collection: "users", Limit: MaxKeyScanLimit + 1})
+	require.Equal(t, ErrMaxKeyScanLimitExceeded, err)
+
+	_, err = db.SearchDocuments(context.Background(), &schema.DocumentSearchRequest{Collection: "missing"})
+	require.Equal(t, ErrCollectionNotFound, err)
+
+	docs, err := db.SearchDocuments(context.Background(), &schema.DocumentSearchRequest{Collection: "users"})
+	require.NoError(t, err)
+	require.Len(t, docs.Documents, 3)
+
+	docs, err = db.SearchDocuments(context.Background(), &schema.DocumentSearchRequest{Collection: "users", Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, docs.Documents, 1)
+
+	// resolved through the index
+	docs, err = db.SearchDocuments(context.Background(), &schema.DocumentSearchRequest{
+		Collection: "users",
+		Filters: []*schema.DocumentFilter{
+			{Field: "active", Operator: schema.DocumentFilterOperator_EQ, Value: structpb.NewBoolValue(true)},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"u1"}, documentIDs(docs))
+
+	// resolved by reading all the documents, documents without the field never match
+	docs, err = db.SearchDocuments(context.Background(), &schema.DocumentSearchRequest{
+		Collection: "users",
+		Filters: []*schema.DocumentFilter{
+			{Field: "age", Operator: schema.DocumentFilterOperator_GE, Value: structpb.NewNumberValue(30)},
+			{Field: "active", Operator: schema.DocumentFilterOperator_NE, Value: structpb.NewBoolValue(false)},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"u1"}, documentIDs(docs))
+
+	docs, err = db.SearchDocuments(context.Background(), &schema.DocumentSearchRequest{
+		Collection: "users",
+		Filters: []*schema.DocumentFilter{
+			{Field: "name", Operator: schema.DocumentFilterOperator_LT, Value: structpb.NewStringValue("c")},
+			{Field: "age", Operator: schema.DocumentFilterOperator_LT, Value: structpb.NewStringValue("c")},
+		},
+	})
+	require.NoError(t, err)
+	require.Empty(t, docs.Documents)
+
+	// a replaced document is no longer found by its previous value
+	_, err = db.InsertDocuments(context.Background(), &schema.DocumentInsertRequest{
+		Collection: "users",
+		Documents: []*structpb.Struct{
+			newDocument(t, map[string]interface{}{DocumentIDField: "u1", "name": "alice", "age": 31, "active": false}),
+		},
+	})
+	require.NoError(t, err)
+
+	for _, active := range []bool{true, false} {
+		docs, err = db.SearchDocuments(context.Background(), &schema.DocumentSearchRequest{
+			Collection: "users",
+			Filters: []*schema.DocumentFilter{
+				{Field: "active", Operator: schema.DocumentFilterOperator_EQ, Value: structpb.NewBoolValue(active)},
+			},
+		})
+		require.NoError(t, err)
+
+		if active {
+			require.Empty(t, docs.Documents)
+		} else {
+			require.Equal(t, []string{"u1", "u2"}, documentIDs(docs))
+			require.Equal(t, float64(31), docs.Documents[0].Document.Fields["age"].GetNumberValue())
+		}
+	}
+
+	// long strings are indexed by their prefix
+	longName := strings.Repeat("x", 100)
+
+	_, err = db.InsertDocuments(context.Background(), &schema.DocumentInsertRequest{
+		Collection: "users",
+		Documents: []*structpb.Struct{
+			newDocument(t, map[string]interface{}{DocumentIDField: "u4", "name": longName + "a"}),
+			newDocument(t, map[string]interface{}{DocumentIDField: "u5", "name": longName + "b"}),
+		},
+	})
+	require.NoError(t, err)
+
+	docs, err = db.SearchDocuments(context.Background(), &schema.DocumentSearchRequest{
+		Collection: "users",
+		Filters: []*schema.DocumentFilter{
+			{Field: "name", Operator: schema.DocumentFilterOperator_EQ, Value: structpb.NewStringValue(longName + "b")},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"u5"}, documentIDs(docs))
+}
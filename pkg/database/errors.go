@@ -22,10 +22,13 @@ import (
 )
 
 var (
-	ErrIndexKeyMismatch      = status.New(codes.InvalidArgument, "mismatch between provided index and key").Err()
-	ErrNoReferenceProvided   = status.New(codes.InvalidArgument, "provided argument is not a reference").Err()
-	ErrReferenceKeyMissing   = status.New(codes.InvalidArgument, "reference key not provided").Err()
-	ErrZAddIndexMissing      = status.New(codes.InvalidArgument, "zAdd index not provided").Err()
-	ErrReferenceIndexMissing = status.New(codes.InvalidArgument, "reference index not provided").Err()
-	ErrDatabaseNotExists     = status.New(codes.NotFound, "database does not exist").Err()
+	ErrIndexKeyMismatch        = status.New(codes.InvalidArgument, "mismatch between provided index and key").Err()
+	ErrNoReferenceProvided     = status.New(codes.InvalidArgument, "provided argument is not a reference").Err()
+	ErrReferenceKeyMissing     = status.New(codes.InvalidArgument, "reference key not provided").Err()
+	ErrZAddIndexMissing        = status.New(codes.InvalidArgument, "zAdd index not provided").Err()
+	ErrReferenceIndexMissing   = status.New(codes.InvalidArgument, "reference index not provided").Err()
+	ErrDatabaseNotExists       = status.New(codes.NotFound, "database does not exist").Err()
+	ErrCollectionNotFound      = status.New(codes.NotFound, "collection does not exist").Err()
+	ErrCollectionAlreadyExists = status.New(codes.AlreadyExists, "collection already exists").Err()
+	ErrDocumentNotFound        = status.New(codes.NotFound, "document does not exist").Err()
 )
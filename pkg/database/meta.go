@@ -27,6 +27,9 @@ const (
 	SetKeyPrefix byte = iota
 	SortedSetKeyPrefix
 	SQLPrefix
+	TimeSeriesKeyPrefix
+	NotarizationKeyPrefix
+	DocumentKeyPrefix
 )
 
 const (
@@ -57,6 +60,14 @@ func EncodeKV(key []byte, value []byte) *store.KV {
 	}
 }
 
+func EncodeKVWithMetadata(key []byte, md *store.KVMetadata, value []byte) *store.KV {
+	return &store.KV{
+		Key:      WrapWithPrefix(key, SetKeyPrefix),
+		Metadata: md,
+		Value:    WrapWithPrefix(value, PlainValuePrefix),
+	}
+}
+
 func EncodeReference(key, referencedKey []byte, atTx uint64) *store.KV {
 	return &store.KV{
 		Key:   WrapWithPrefix(key, SetKeyPrefix),
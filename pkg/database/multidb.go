@@ -0,0 +1,284 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package database
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"google.golang.org/protobuf/proto"
+)
+
+var ErrDuplicatedDatabase = errors.New("database included more than once")
+var ErrPartialCommit = errors.New("multi-database tx partially committed")
+
+// PartialCommitError annotates ErrPartialCommit with the databases the multi-database tx was committed into
+// and the ones it's still pending on, together with the error its commit failed with.
+// The commit decision was already persisted, thus the tx is rolled forward into the pending ones on recovery.
+type PartialCommitError struct {
+	Committed []string
+	Pending   []string
+	Err       error
+}
+
+func (e *PartialCommitError) Error() string {
+	return fmt.Sprintf("%v: committed into %v but pending on %v (%v)", ErrPartialCommit, e.Committed, e.Pending, e.Err)
+}
+
+func (e *PartialCommitError) Unwrap() error {
+	return ErrPartialCommit
+}
+
+// MultiDbTxRecord is the decision record of a multi-database tx, it's persisted once the tx was prepared
+// in every database and before committing any of them, so that it can be rolled forward after a crash
+type MultiDbTxRecord struct {
+	Txs []*MultiDbTxRecordEntry `json:"txs"`
+}
+
+// MultiDbTxRecordEntry holds the tx prepared in a database together with the request it was prepared from,
+// the entries hash identifies the tx regardless of when it gets committed
+type MultiDbTxRecordEntry struct {
+	Database string `json:"database"`
+	TxID     uint64 `json:"txId"`
+	EH       []byte `json:"eh"`
+	Request  []byte `json:"request"`
+}
+
+// MultiDbTxLog persists the decision records of multi-database txs
+type MultiDbTxLog interface {
+	// Decide persists the record, the prepared txs are committed only if it succeeds otherwise they're aborted
+	Decide(ctx context.Context, rec *MultiDbTxRecord) error
+	// Resolve discards the record once the tx was committed into all of its databases
+	Resolve(ctx context.Context, rec *MultiDbTxRecord) error
+}
+
+// PreparedTx is a tx validated and linked to the latest one of its database but not yet committed,
+// either Commit or Abort must be called to unlock the database
+type PreparedTx struct {
+	d      *db
+	tx     *store.PreparedTx
+	noWait bool
+}
+
+// TxID returns the id the prepared tx is committed with
+func (tx *PreparedTx) TxID() uint64 {
+	return tx.tx.TxID()
+}
+
+// Commit commits the prepared tx and waits for it to be indexed unless it was requested not to
+func (tx *PreparedTx) Commit(ctx context.Context) (*schema.TxMetadata, error) {
+	md, err := tx.commit()
+	if err != nil {
+		return nil, err
+	}
+
+	if !tx.noWait {
+		err = tx.d.st.WaitForIndexingUpto(ctx, md.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return schema.TxMetatadaTo(md), nil
+}
+
+func (tx *PreparedTx) commit() (*store.TxMetadata, error) {
+	defer tx.d.mutex.Unlock()
+
+	return tx.tx.Commit()
+}
+
+// Abort discards the prepared tx
+func (tx *PreparedTx) Abort() error {
+	defer tx.d.mutex.Unlock()
+
+	return tx.tx.Abort()
+}
+
+// ExecMultiDbAll coordinates a two-phase commit of the ExecAll requests over their databases.
+// A tx is first prepared in every database, in name order so that concurrent coordinators can't deadlock,
+// otherwise all of them are aborted. Databases remain locked in between, thus no other tx gets committed into them.
+// Once all of them are prepared, the decision to commit them is persisted into the log and only then they get committed.
+// If some of them can't be committed, a PartialCommitError is returned and the record is kept, so that the tx
+// is rolled forward into the remaining databases through RecoverMultiDbTx.
+func ExecMultiDbAll(ctx context.Context, dbs []DB, reqs []*schema.ExecAllRequest, log MultiDbTxLog) ([]*schema.TxMetadata, error) {
+	if len(dbs) == 0 || len(dbs) != len(reqs) || log == nil {
+		return nil, ErrIllegalArguments
+	}
+
+	order := make([]int, len(dbs))
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.Slice(order, func(i, j int) bool {
+		return dbs[order[i]].GetName() < dbs[order[j]].GetName()
+	})
+
+	for i := 1; i < len(order); i++ {
+		if dbs[order[i-1]].GetName() == dbs[order[i]].GetName() {
+			return nil, ErrDuplicatedDatabase
+		}
+	}
+
+	txs := make([]*PreparedTx, len(dbs))
+
+	abort := func() {
+		for _, ptx := range txs {
+			if ptx != nil {
+				ptx.Abort()
+			}
+		}
+	}
+
+	rec := &MultiDbTxRecord{Txs: make([]*MultiDbTxRecordEntry, len(dbs))}
+
+	for n, i := range order {
+		tx, err := dbs[i].PrepareExecAll(ctx, reqs[i])
+		if err != nil {
+			abort()
+			return nil, err
+		}
+
+		txs[i] = tx
+
+		req, err := proto.Marshal(reqs[i])
+		if err != nil {
+			abort()
+			return nil, err
+		}
+
+		eh := tx.tx.Eh()
+
+		rec.Txs[n] = &MultiDbTxRecordEntry{
+			Database: dbs[i].GetName(),
+			TxID:     tx.TxID(),
+			EH:       eh[:],
+			Request:  req,
+		}
+	}
+
+	err := log.Decide(ctx, rec)
+	if err != nil {
+		abort()
+		return nil, err
+	}
+
+	// the decision was persisted, thus every tx must be committed even if some other one fails
+	mds := make([]*store.TxMetadata, len(dbs))
+
+	var committed, pending []string
+	var commitErr error
+
+	for _, i := range order {
+		md, err := txs[i].commit()
+		if err != nil {
+			pending = append(pending, dbs[i].GetName())
+
+			if commitErr == nil {
+				commitErr = err
+			}
+
+			continue
+		}
+
+		committed = append(committed, dbs[i].GetName())
+		mds[i] = md
+	}
+
+	if commitErr != nil {
+		return nil, &PartialCommitError{Committed: committed, Pending: pending, Err: commitErr}
+	}
+
+	// a record left unresolved is harmless, txs already committed are not committed again on recovery
+	log.Resolve(ctx, rec)
+
+	res := make([]*schema.TxMetadata, len(dbs))
+
+	for i, md := range mds {
+		if !txs[i].noWait {
+			err := txs[i].d.st.WaitForIndexingUpto(ctx, md.ID)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		res[i] = schema.TxMetatadaTo(md)
+	}
+
+	return res, nil
+}
+
+// RecoverMultiDbTx rolls the multi-database tx of the record forward, dbs holds the database of each entry.
+// The tx is committed again into the databases it's not committed into, whose tx at the recorded id either
+// doesn't exist or doesn't hold the recorded entries.
+func RecoverMultiDbTx(ctx context.Context, rec *MultiDbTxRecord, dbs []DB) error {
+	if rec == nil || len(rec.Txs) != len(dbs) {
+		return ErrIllegalArguments
+	}
+
+	for i, e := range rec.Txs {
+		if dbs[i].GetName() != e.Database {
+			return ErrIllegalArguments
+		}
+
+		committed, err := isCommitted(dbs[i], e)
+		if err != nil {
+			return fmt.Errorf("%w: database '%s'", err, e.Database)
+		}
+
+		if committed {
+			continue
+		}
+
+		var req schema.ExecAllRequest
+
+		err = proto.Unmarshal(e.Request, &req)
+		if err != nil {
+			return fmt.Errorf("%w: database '%s'", err, e.Database)
+		}
+
+		_, err = dbs[i].ExecAll(ctx, &req)
+		if err != nil {
+			return fmt.Errorf("%w: database '%s'", err, e.Database)
+		}
+	}
+
+	return nil
+}
+
+func isCommitted(db DB, e *MultiDbTxRecordEntry) (bool, error) {
+	state, err := db.CurrentState()
+	if err != nil {
+		return false, err
+	}
+
+	if state.TxId < e.TxID {
+		return false, nil
+	}
+
+	tx, err := db.TxByID(&schema.TxRequest{Tx: e.TxID, DigestOnly: true})
+	if err != nil {
+		return false, err
+	}
+
+	return bytes.Equal(tx.Metadata.EH, e.EH), nil
+}
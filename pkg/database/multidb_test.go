@@ -0,0 +1,221 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package database
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/logger"
+	"github.com/stretchr/testify/require"
+)
+
+func kvOps(kvs ...string) *schema.ExecAllRequest {
+	req := &schema.ExecAllRequest{}
+
+	for i := 0; i < len(kvs); i += 2 {
+		req.Operations = append(req.Operations, &schema.Op{
+			Operation: &schema.Op_Kv{Kv: &schema.KeyValue{Key: []byte(kvs[i]), Value: []byte(kvs[i+1])}},
+		})
+	}
+
+	return req
+}
+
+type memMultiDbTxLog struct {
+	decided   []*MultiDbTxRecord
+	resolved  []*MultiDbTxRecord
+	decideErr error
+}
+
+func (l *memMultiDbTxLog) Decide(ctx context.Context, rec *MultiDbTxRecord) error {
+	if l.decideErr != nil {
+		return l.decideErr
+	}
+
+	l.decided = append(l.decided, rec)
+	return nil
+}
+
+func (l *memMultiDbTxLog) Resolve(ctx context.Context, rec *MultiDbTxRecord) error {
+	l.resolved = append(l.resolved, rec)
+	return nil
+}
+
+func newMultiDbTestDbs(t *testing.T, rootPath string, names ...string) []DB {
+	dbs := make([]DB, len(names))
+
+	for i, name := range names {
+		db, err := NewDb(DefaultOption().WithDbRootPath(rootPath).WithDbName(name).WithCorruptionChecker(false),
+			nil, logger.NewSimpleLogger("immudb ", os.Stderr))
+		require.NoError(t, err)
+		t.Cleanup(func() { db.Close() })
+
+		dbs[i] = db
+	}
+
+	return dbs
+}
+
+func TestExecMultiDbAll(t *testing.T) {
+	rootPath := "data_multidb"
+	defer os.RemoveAll(rootPath)
+
+	dbs := newMultiDbTestDbs(t, rootPath, "db2", "db1")
+
+	log := &memMultiDbTxLog{}
+
+	_, err := ExecMultiDbAll(context.Background(), nil, nil, log)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = ExecMultiDbAll(context.Background(), dbs, []*schema.ExecAllRequest{kvOps("k", "v")}, log)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = ExecMultiDbAll(context.Background(), dbs, []*schema.ExecAllRequest{kvOps("k", "v"), kvOps("k", "v")}, nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = ExecMultiDbAll(context.Background(), []DB{dbs[0], dbs[0]},
+		[]*schema.ExecAllRequest{kvOps("k", "v"), kvOps("k", "v")}, log)
+	require.Equal(t, ErrDuplicatedDatabase, err)
+
+	txs, err := ExecMultiDbAll(context.Background(), dbs,
+		[]*schema.ExecAllRequest{kvOps("k1", "v1", "k2", "v2"), kvOps("k1", "v1")}, log)
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+	require.Equal(t, int32(2), txs[0].Nentries)
+	require.Equal(t, int32(1), txs[1].Nentries)
+
+	// the decision was persisted before committing, in name order, and resolved afterwards
+	require.Len(t, log.decided, 1)
+	require.Equal(t, log.decided, log.resolved)
+	require.Equal(t, "db1", log.decided[0].Txs[0].Database)
+	require.Equal(t, txs[1].Id, log.decided[0].Txs[0].TxID)
+	require.Equal(t, txs[1].EH, log.decided[0].Txs[0].EH)
+	require.Equal(t, "db2", log.decided[0].Txs[1].Database)
+	require.Equal(t, txs[0].Id, log.decided[0].Txs[1].TxID)
+
+	for _, db := range dbs {
+		entry, err := db.Get(context.Background(), &schema.KeyRequest{Key: []byte("k1")})
+		require.NoError(t, err)
+		require.Equal(t, []byte("v1"), entry.Value)
+	}
+
+	committedTxs := make([]uint64, len(dbs))
+
+	for i, db := range dbs {
+		state, err := db.CurrentState()
+		require.NoError(t, err)
+
+		committedTxs[i] = state.TxId
+	}
+
+	// the reference can't be created in the second database thus nothing is committed into the first one
+	invalidRef := &schema.ExecAllRequest{
+		Operations: []*schema.Op{
+			{Operation: &schema.Op_Ref{Ref: &schema.ReferenceRequest{Key: []byte("ref"), ReferencedKey: []byte("missing")}}},
+		},
+	}
+
+	_, err = ExecMultiDbAll(context.Background(), dbs, []*schema.ExecAllRequest{kvOps("k3", "v3"), invalidRef}, log)
+	require.Error(t, err)
+
+	// txs are aborted as well if the decision can't be persisted
+	log.decideErr = errors.New("decision not persisted")
+
+	_, err = ExecMultiDbAll(context.Background(), dbs, []*schema.ExecAllRequest{kvOps("k3", "v3"), kvOps("k3", "v3")}, log)
+	require.Equal(t, log.decideErr, err)
+	require.Len(t, log.decided, 1)
+
+	for i, db := range dbs {
+		_, err = db.Get(context.Background(), &schema.KeyRequest{Key: []byte("k3")})
+		require.Error(t, err)
+
+		state, err := db.CurrentState()
+		require.NoError(t, err)
+		require.Equal(t, committedTxs[i], state.TxId)
+	}
+
+	// databases are unlocked once the multi-database tx is aborted
+	_, err = dbs[0].ExecAll(context.Background(), kvOps("k3", "v3"))
+	require.NoError(t, err)
+
+	_, err = dbs[1].ExecAll(context.Background(), kvOps("k3", "v3"))
+	require.NoError(t, err)
+}
+
+func TestRecoverMultiDbTx(t *testing.T) {
+	rootPath := "data_multidb_recovery"
+	defer os.RemoveAll(rootPath)
+
+	log := &memMultiDbTxLog{}
+
+	dbs := newMultiDbTestDbs(t, filepath.Join(rootPath, "committed"), "db1", "db2")
+
+	txs, err := ExecMultiDbAll(context.Background(), dbs,
+		[]*schema.ExecAllRequest{kvOps("k1", "v1"), kvOps("k2", "v2")}, log)
+	require.NoError(t, err)
+	require.Len(t, log.decided, 1)
+
+	rec := log.decided[0]
+
+	err = RecoverMultiDbTx(context.Background(), nil, dbs)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	err = RecoverMultiDbTx(context.Background(), rec, dbs[:1])
+	require.Equal(t, ErrIllegalArguments, err)
+
+	err = RecoverMultiDbTx(context.Background(), rec, []DB{dbs[1], dbs[0]})
+	require.Equal(t, ErrIllegalArguments, err)
+
+	// txs already committed are not committed again
+	err = RecoverMultiDbTx(context.Background(), rec, dbs)
+	require.NoError(t, err)
+
+	for i, db := range dbs {
+		state, err := db.CurrentState()
+		require.NoError(t, err)
+		require.Equal(t, txs[i].Id, state.TxId)
+	}
+
+	// databases in the same state as the ones the txs were prepared in, but into which they were not committed
+	crashedDbs := newMultiDbTestDbs(t, filepath.Join(rootPath, "crashed"), "db1", "db2")
+
+	err = RecoverMultiDbTx(context.Background(), rec, crashedDbs)
+	require.NoError(t, err)
+
+	for i, db := range crashedDbs {
+		tx, err := db.TxByID(&schema.TxRequest{Tx: txs[i].Id, DigestOnly: true})
+		require.NoError(t, err)
+		require.Equal(t, txs[i].EH, tx.Metadata.EH)
+	}
+
+	entry, err := crashedDbs[1].Get(context.Background(), &schema.KeyRequest{Key: []byte("k2")})
+	require.NoError(t, err)
+	require.Equal(t, []byte("v2"), entry.Value)
+
+	err = RecoverMultiDbTx(context.Background(), rec, crashedDbs)
+	require.NoError(t, err)
+
+	for i, db := range crashedDbs {
+		state, err := db.CurrentState()
+		require.NoError(t, err)
+		require.Equal(t, txs[i].Id, state.TxId)
+	}
+}
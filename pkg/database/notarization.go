@@ -0,0 +1,157 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package database
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+)
+
+// Notarize commits the given digests within the same tx, a receipt is returned for each of them.
+// Receipts can be verified without further interaction by checking the inclusion of the digest in
+// the tx and, when signed, the signature of the state
+func (d *db) Notarize(ctx context.Context, req *schema.NotarizeRequest) (*schema.NotarizationReceipts, error) {
+	if req == nil || len(req.Digests) == 0 {
+		return nil, ErrIllegalArguments
+	}
+
+	if d.isReplica() {
+		return nil, ErrIsReplica
+	}
+
+	entries := make([]*store.KV, len(req.Digests))
+
+	for i, digest := range req.Digests {
+		if len(digest) == 0 {
+			return nil, ErrIllegalArguments
+		}
+
+		entries[i] = EncodeNotarization(digest)
+	}
+
+	attrs, err := txAttributesFor(req.TraceID)
+	if err != nil {
+		return nil, err
+	}
+
+	d.mutex.RLock()
+	txMetatadata, err := d.st.CommitWithAttributes(entries, attrs, nil, false)
+	d.mutex.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	if !req.NoWait {
+		err = d.st.WaitForIndexingUpto(ctx, txMetatadata.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	tx := d.tx1
+
+	err = d.st.ReadTx(txMetatadata.ID, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	alh := tx.Alh
+	md := schema.TxMetatadaTo(tx.Metadata())
+
+	receipts := make([]*schema.NotarizationReceipt, len(req.Digests))
+
+	for i, e := range entries {
+		inclusionProof, err := tx.Proof(e.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		receipts[i] = &schema.NotarizationReceipt{
+			Digest:         req.Digests[i],
+			TxMetadata:     md,
+			InclusionProof: schema.InclusionProofTo(inclusionProof),
+			State: &schema.ImmutableState{
+				Db:     d.name,
+				TxId:   txMetatadata.ID,
+				TxHash: alh[:],
+			},
+		}
+	}
+
+	return &schema.NotarizationReceipts{Receipts: receipts}, nil
+}
+
+// VerifyNotarizationReceipt checks the receipt is consistent and that it refers to a tx committed into the database
+func (d *db) VerifyNotarizationReceipt(receipt *schema.NotarizationReceipt) (*schema.NotarizationVerification, error) {
+	if receipt == nil || receipt.TxMetadata == nil || receipt.InclusionProof == nil || receipt.State == nil {
+		return nil, ErrIllegalArguments
+	}
+
+	if receipt.State.Db != d.name || !VerifyNotarizationReceipt(receipt) {
+		return &schema.NotarizationVerification{Verified: false}, nil
+	}
+
+	lastTxID, _ := d.st.Alh()
+	if lastTxID < receipt.TxMetadata.Id {
+		return &schema.NotarizationVerification{Verified: false}, nil
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	err := d.st.ReadTx(receipt.TxMetadata.Id, d.tx1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema.NotarizationVerification{
+		Verified: bytes.Equal(d.tx1.Alh[:], receipt.State.TxHash),
+	}, nil
+}
+
+// VerifyNotarizationReceipt returns true when the digest is included in the tx of the receipt
+// and the state of the receipt corresponds to the same tx. The signature, if any, is not checked
+func VerifyNotarizationReceipt(receipt *schema.NotarizationReceipt) bool {
+	if receipt == nil || receipt.TxMetadata == nil || receipt.InclusionProof == nil || receipt.State == nil {
+		return false
+	}
+
+	md := schema.TxMetadataFrom(receipt.TxMetadata)
+	alh := md.Alh()
+
+	if receipt.State.TxId != md.ID || !bytes.Equal(receipt.State.TxHash, alh[:]) {
+		return false
+	}
+
+	return store.VerifyInclusion(
+		schema.InclusionProofFrom(receipt.InclusionProof),
+		EncodeNotarization(receipt.Digest),
+		md.Eh,
+	)
+}
+
+func EncodeNotarization(digest []byte) *store.KV {
+	return &store.KV{
+		Key:   WrapWithPrefix(digest, NotarizationKeyPrefix),
+		Value: nil,
+	}
+}
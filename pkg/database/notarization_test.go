@@ -0,0 +1,110 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"testing"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotarization(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	_, err := db.Notarize(context.Background(), nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = db.Notarize(context.Background(), &schema.NotarizeRequest{})
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = db.Notarize(context.Background(), &schema.NotarizeRequest{Digests: [][]byte{{}}})
+	require.Equal(t, ErrIllegalArguments, err)
+
+	d1 := sha256.Sum256([]byte("doc1"))
+	d2 := sha256.Sum256([]byte("doc2"))
+
+	_, err = db.Notarize(context.Background(), &schema.NotarizeRequest{Digests: [][]byte{d1[:], d1[:]}})
+	require.True(t, errors.Is(err, store.ErrDuplicatedKey))
+
+	receipts, err := db.Notarize(context.Background(), &schema.NotarizeRequest{Digests: [][]byte{d1[:], d2[:]}})
+	require.NoError(t, err)
+	require.Len(t, receipts.Receipts, 2)
+
+	for i, r := range receipts.Receipts {
+		require.Equal(t, receipts.Receipts[0].TxMetadata.Id, r.TxMetadata.Id)
+		require.Equal(t, [][]byte{d1[:], d2[:]}[i], r.Digest)
+		require.True(t, VerifyNotarizationReceipt(r))
+
+		verification, err := db.VerifyNotarizationReceipt(r)
+		require.NoError(t, err)
+		require.True(t, verification.Verified)
+	}
+
+	// notarized digests are not visible as regular keys
+	_, err = db.Get(context.Background(), &schema.KeyRequest{Key: d1[:]})
+	require.Equal(t, store.ErrKeyNotFound, err)
+
+	_, err = db.VerifyNotarizationReceipt(nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	r := receipts.Receipts[0]
+
+	tampered := &schema.NotarizationReceipt{
+		Digest:         d2[:],
+		TxMetadata:     r.TxMetadata,
+		InclusionProof: r.InclusionProof,
+		State:          r.State,
+	}
+	require.False(t, VerifyNotarizationReceipt(tampered))
+
+	verification, err := db.VerifyNotarizationReceipt(tampered)
+	require.NoError(t, err)
+	require.False(t, verification.Verified)
+
+	tampered = &schema.NotarizationReceipt{
+		Digest:         r.Digest,
+		TxMetadata:     r.TxMetadata,
+		InclusionProof: r.InclusionProof,
+		State:          &schema.ImmutableState{Db: "otherdb", TxId: r.State.TxId, TxHash: r.State.TxHash},
+	}
+
+	verification, err = db.VerifyNotarizationReceipt(tampered)
+	require.NoError(t, err)
+	require.False(t, verification.Verified)
+
+	// a consistent receipt of a tx not committed into the database
+	otherDb, otherCloser := makeDb()
+	defer otherCloser()
+
+	for i := 0; i < 3; i++ {
+		_, err = otherDb.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key"), Value: []byte("value")}}})
+		require.NoError(t, err)
+	}
+
+	otherReceipts, err := otherDb.Notarize(context.Background(), &schema.NotarizeRequest{Digests: [][]byte{d1[:]}})
+	require.NoError(t, err)
+	require.True(t, VerifyNotarizationReceipt(otherReceipts.Receipts[0]))
+
+	verification, err = db.VerifyNotarizationReceipt(otherReceipts.Receipts[0])
+	require.NoError(t, err)
+	require.False(t, verification.Verified)
+}
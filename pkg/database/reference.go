@@ -17,6 +17,7 @@ limitations under the License.
 package database
 
 import (
+	"context"
 	"errors"
 
 	"github.com/codenotary/immudb/embedded/store"
@@ -27,7 +28,7 @@ var ErrReferencedKeyCannotBeAReference = errors.New("referenced key cannot be a
 var ErrFinalKeyCannotBeConvertedIntoReference = errors.New("final key cannot be converted into a reference")
 
 //Reference ...
-func (d *db) SetReference(req *schema.ReferenceRequest) (*schema.TxMetadata, error) {
+func (d *db) SetReference(ctx context.Context, req *schema.ReferenceRequest) (*schema.TxMetadata, error) {
 	if req == nil || len(req.Key) == 0 || len(req.ReferencedKey) == 0 {
 		return nil, store.ErrIllegalArguments
 	}
@@ -36,11 +37,15 @@ func (d *db) SetReference(req *schema.ReferenceRequest) (*schema.TxMetadata, err
 		return nil, store.ErrIllegalArguments
 	}
 
+	if d.isReplica() {
+		return nil, ErrIsReplica
+	}
+
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
 
 	lastTxID, _ := d.st.Alh()
-	err := d.st.WaitForIndexingUpto(lastTxID, nil)
+	err := d.st.WaitForIndexingUpto(ctx, lastTxID)
 	if err != nil {
 		return nil, err
 	}
@@ -63,16 +68,28 @@ func (d *db) SetReference(req *schema.ReferenceRequest) (*schema.TxMetadata, err
 		return nil, ErrReferencedKeyCannotBeAReference
 	}
 
-	meta, err := d.st.Commit([]*store.KV{EncodeReference(req.Key, req.ReferencedKey, req.AtTx)}, !req.NoWait)
+	attrs, err := txAttributesFor(req.TraceID)
+	if err != nil {
+		return nil, err
+	}
+
+	meta, err := d.st.CommitWithAttributes([]*store.KV{EncodeReference(req.Key, req.ReferencedKey, req.AtTx)}, attrs, nil, false)
 	if err != nil {
 		return nil, err
 	}
 
+	if !req.NoWait {
+		err = d.st.WaitForIndexingUpto(ctx, meta.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return schema.TxMetatadaTo(meta), err
 }
 
 //SafeReference ...
-func (d *db) VerifiableSetReference(req *schema.VerifiableReferenceRequest) (*schema.VerifiableTx, error) {
+func (d *db) VerifiableSetReference(ctx context.Context, req *schema.VerifiableReferenceRequest) (*schema.VerifiableTx, error) {
 	if req == nil {
 		return nil, store.ErrIllegalArguments
 	}
@@ -82,7 +99,7 @@ func (d *db) VerifiableSetReference(req *schema.VerifiableReferenceRequest) (*sc
 		return nil, store.ErrIllegalArguments
 	}
 
-	txMetatadata, err := d.SetReference(req.ReferenceRequest)
+	txMetatadata, err := d.SetReference(ctx, req.ReferenceRequest)
 	if err != nil {
 		return nil, err
 	}
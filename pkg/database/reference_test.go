@@ -17,6 +17,7 @@ limitations under the License.
 package database
 
 import (
+	"context"
 	"crypto/sha256"
 	"strconv"
 	"testing"
@@ -31,9 +32,9 @@ func TestStoreReference(t *testing.T) {
 	defer closer()
 
 	req := &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`firstKey`), Value: []byte(`firstValue`)}}}
-	meta, err := db.Set(req)
+	meta, err := db.Set(context.Background(), req)
 
-	item, err := db.Get(&schema.KeyRequest{Key: []byte(`firstKey`), SinceTx: meta.Id})
+	item, err := db.Get(context.Background(), &schema.KeyRequest{Key: []byte(`firstKey`), SinceTx: meta.Id})
 	require.NoError(t, err)
 	require.Equal(t, []byte(`firstKey`), item.Key)
 	require.Equal(t, []byte(`firstValue`), item.Value)
@@ -42,7 +43,7 @@ func TestStoreReference(t *testing.T) {
 		Key:           []byte(`myTag`),
 		ReferencedKey: []byte(`secondKey`),
 	}
-	meta, err = db.SetReference(refOpts)
+	meta, err = db.SetReference(context.Background(), refOpts)
 	require.Equal(t, store.ErrKeyNotFound, err)
 
 	refOpts = &schema.ReferenceRequest{
@@ -51,31 +52,31 @@ func TestStoreReference(t *testing.T) {
 		AtTx:          0,
 		BoundRef:      true,
 	}
-	_, err = db.SetReference(refOpts)
+	_, err = db.SetReference(context.Background(), refOpts)
 	require.Equal(t, store.ErrIllegalArguments, err)
 
 	refOpts = &schema.ReferenceRequest{
 		Key:           []byte(`firstKey`),
 		ReferencedKey: []byte(`firstKey`),
 	}
-	meta, err = db.SetReference(refOpts)
+	meta, err = db.SetReference(context.Background(), refOpts)
 	require.Equal(t, ErrFinalKeyCannotBeConvertedIntoReference, err)
 
 	refOpts = &schema.ReferenceRequest{
 		Key:           []byte(`myTag`),
 		ReferencedKey: []byte(`firstKey`),
 	}
-	meta, err = db.SetReference(refOpts)
+	meta, err = db.SetReference(context.Background(), refOpts)
 	require.NoError(t, err)
 	require.Equal(t, uint64(2), meta.Id)
 
 	keyReq := &schema.KeyRequest{Key: []byte(`myTag`), SinceTx: meta.Id}
 
-	firstItemRet, err := db.Get(keyReq)
+	firstItemRet, err := db.Get(context.Background(), keyReq)
 	require.NoError(t, err)
 	require.Equal(t, []byte(`firstValue`), firstItemRet.Value, "Should have referenced item value")
 
-	vitem, err := db.VerifiableGet(&schema.VerifiableGetRequest{
+	vitem, err := db.VerifiableGet(context.Background(), &schema.VerifiableGetRequest{
 		KeyRequest:   keyReq,
 		ProveSinceTx: 1,
 	})
@@ -100,16 +101,16 @@ func TestStore_GetReferenceWithIndexResolution(t *testing.T) {
 	db, closer := makeDb()
 	defer closer()
 
-	set, err := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`aaa`), Value: []byte(`value1`)}}})
+	set, err := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`aaa`), Value: []byte(`value1`)}}})
 	require.NoError(t, err)
 
-	_, err = db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`aaa`), Value: []byte(`value2`)}}})
+	_, err = db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`aaa`), Value: []byte(`value2`)}}})
 	require.NoError(t, err)
 
-	ref, err := db.SetReference(&schema.ReferenceRequest{Key: []byte(`myTag1`), ReferencedKey: []byte(`aaa`), AtTx: set.Id, BoundRef: true})
+	ref, err := db.SetReference(context.Background(), &schema.ReferenceRequest{Key: []byte(`myTag1`), ReferencedKey: []byte(`aaa`), AtTx: set.Id, BoundRef: true})
 	require.NoError(t, err)
 
-	tag3, err := db.Get(&schema.KeyRequest{Key: []byte(`myTag1`), SinceTx: ref.Id})
+	tag3, err := db.Get(context.Background(), &schema.KeyRequest{Key: []byte(`myTag1`), SinceTx: ref.Id})
 	require.NoError(t, err)
 	require.Equal(t, []byte(`aaa`), tag3.Key)
 	require.Equal(t, []byte(`value1`), tag3.Value)
@@ -120,15 +121,15 @@ func TestStoreInvalidReferenceToReference(t *testing.T) {
 	defer closer()
 
 	req := &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`firstKey`), Value: []byte(`firstValue`)}}}
-	meta, err := db.Set(req)
+	meta, err := db.Set(context.Background(), req)
 
-	ref1, err := db.SetReference(&schema.ReferenceRequest{Key: []byte(`myTag1`), ReferencedKey: []byte(`firstKey`), AtTx: meta.Id, BoundRef: true})
+	ref1, err := db.SetReference(context.Background(), &schema.ReferenceRequest{Key: []byte(`myTag1`), ReferencedKey: []byte(`firstKey`), AtTx: meta.Id, BoundRef: true})
 	require.NoError(t, err)
 
-	_, err = db.Get(&schema.KeyRequest{Key: []byte(`myTag1`), SinceTx: ref1.Id})
+	_, err = db.Get(context.Background(), &schema.KeyRequest{Key: []byte(`myTag1`), SinceTx: ref1.Id})
 	require.NoError(t, err)
 
-	_, err = db.SetReference(&schema.ReferenceRequest{Key: []byte(`myTag2`), ReferencedKey: []byte(`myTag1`)})
+	_, err = db.SetReference(context.Background(), &schema.ReferenceRequest{Key: []byte(`myTag2`), ReferencedKey: []byte(`myTag1`)})
 	require.Equal(t, ErrReferencedKeyCannotBeAReference, err)
 }
 
@@ -136,10 +137,10 @@ func TestStoreReferenceAsyncCommit(t *testing.T) {
 	db, closer := makeDb()
 	defer closer()
 
-	firstIndex, err := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`firstKey`), Value: []byte(`firstValue`)}}})
+	firstIndex, err := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`firstKey`), Value: []byte(`firstValue`)}}})
 	require.NoError(t, err)
 
-	secondIndex, err := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`secondKey`), Value: []byte(`secondValue`)}}})
+	secondIndex, err := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`secondKey`), Value: []byte(`secondValue`)}}})
 	require.NoError(t, err)
 
 	for n := uint64(0); n <= 64; n++ {
@@ -162,7 +163,7 @@ func TestStoreReferenceAsyncCommit(t *testing.T) {
 			BoundRef:      true,
 		}
 
-		ref, err := db.SetReference(refOpts)
+		ref, err := db.SetReference(context.Background(), refOpts)
 		require.NoError(t, err, "n=%d", n)
 		require.Equal(t, n+1+2, ref.Id, "n=%d", n)
 	}
@@ -182,7 +183,7 @@ func TestStoreReferenceAsyncCommit(t *testing.T) {
 			index = secondIndex.Id
 		}
 
-		item, err := db.Get(&schema.KeyRequest{Key: tag, SinceTx: 67})
+		item, err := db.Get(context.Background(), &schema.KeyRequest{Key: tag, SinceTx: 67})
 		require.NoError(t, err, "n=%d", n)
 		require.Equal(t, index, item.Tx, "n=%d", n)
 		require.Equal(t, itemVal, item.Value, "n=%d", n)
@@ -194,10 +195,10 @@ func TestStoreMultipleReferenceOnSameKey(t *testing.T) {
 	db, closer := makeDb()
 	defer closer()
 
-	idx0, err := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`firstKey`), Value: []byte(`firstValue`)}}})
+	idx0, err := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`firstKey`), Value: []byte(`firstValue`)}}})
 	require.NoError(t, err)
 
-	idx1, err := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`secondKey`), Value: []byte(`secondValue`)}}})
+	idx1, err := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`secondKey`), Value: []byte(`secondValue`)}}})
 	require.NoError(t, err)
 
 	refOpts1 := &schema.ReferenceRequest{
@@ -207,7 +208,7 @@ func TestStoreMultipleReferenceOnSameKey(t *testing.T) {
 		BoundRef:      true,
 	}
 
-	reference1, err := db.SetReference(refOpts1)
+	reference1, err := db.SetReference(context.Background(), refOpts1)
 	require.NoError(t, err)
 	require.Exactly(t, uint64(3), reference1.Id)
 	require.NotEmptyf(t, reference1, "Should not be empty")
@@ -218,7 +219,7 @@ func TestStoreMultipleReferenceOnSameKey(t *testing.T) {
 		AtTx:          idx0.Id,
 		BoundRef:      true,
 	}
-	reference2, err := db.SetReference(refOpts2)
+	reference2, err := db.SetReference(context.Background(), refOpts2)
 	require.NoError(t, err)
 	require.Exactly(t, uint64(4), reference2.Id)
 	require.NotEmptyf(t, reference2, "Should not be empty")
@@ -229,22 +230,22 @@ func TestStoreMultipleReferenceOnSameKey(t *testing.T) {
 		AtTx:          idx1.Id,
 		BoundRef:      true,
 	}
-	reference3, err := db.SetReference(refOpts3)
+	reference3, err := db.SetReference(context.Background(), refOpts3)
 	require.NoError(t, err)
 	require.Exactly(t, uint64(5), reference3.Id)
 	require.NotEmptyf(t, reference3, "Should not be empty")
 
-	firstTagRet, err := db.Get(&schema.KeyRequest{Key: []byte(`myTag1`), SinceTx: reference3.Id})
+	firstTagRet, err := db.Get(context.Background(), &schema.KeyRequest{Key: []byte(`myTag1`), SinceTx: reference3.Id})
 	require.NoError(t, err)
 	require.NotEmptyf(t, firstTagRet, "Should not be empty")
 	require.Equal(t, []byte(`firstValue`), firstTagRet.Value, "Should have referenced item value")
 
-	secondTagRet, err := db.Get(&schema.KeyRequest{Key: []byte(`myTag2`), SinceTx: reference3.Id})
+	secondTagRet, err := db.Get(context.Background(), &schema.KeyRequest{Key: []byte(`myTag2`), SinceTx: reference3.Id})
 	require.NoError(t, err)
 	require.NotEmptyf(t, secondTagRet, "Should not be empty")
 	require.Equal(t, []byte(`firstValue`), secondTagRet.Value, "Should have referenced item value")
 
-	thirdItemRet, err := db.Get(&schema.KeyRequest{Key: []byte(`myTag3`), SinceTx: reference3.Id})
+	thirdItemRet, err := db.Get(context.Background(), &schema.KeyRequest{Key: []byte(`myTag3`), SinceTx: reference3.Id})
 	require.NoError(t, err)
 	require.NotEmptyf(t, thirdItemRet, "Should not be empty")
 	require.Equal(t, []byte(`secondValue`), thirdItemRet.Value, "Should have referenced item value")
@@ -254,24 +255,24 @@ func TestStoreIndexReference(t *testing.T) {
 	db, closer := makeDb()
 	defer closer()
 
-	idx1, err := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`aaa`), Value: []byte(`item1`)}}})
+	idx1, err := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`aaa`), Value: []byte(`item1`)}}})
 	require.NoError(t, err)
 
-	idx2, err := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`aaa`), Value: []byte(`item2`)}}})
+	idx2, err := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`aaa`), Value: []byte(`item2`)}}})
 	require.NoError(t, err)
 
-	ref, err := db.SetReference(&schema.ReferenceRequest{ReferencedKey: []byte(`aaa`), Key: []byte(`myTag1`), AtTx: idx1.Id, BoundRef: true})
+	ref, err := db.SetReference(context.Background(), &schema.ReferenceRequest{ReferencedKey: []byte(`aaa`), Key: []byte(`myTag1`), AtTx: idx1.Id, BoundRef: true})
 	require.NoError(t, err)
 
-	ref, err = db.SetReference(&schema.ReferenceRequest{ReferencedKey: []byte(`aaa`), Key: []byte(`myTag2`), AtTx: idx2.Id, BoundRef: true})
+	ref, err = db.SetReference(context.Background(), &schema.ReferenceRequest{ReferencedKey: []byte(`aaa`), Key: []byte(`myTag2`), AtTx: idx2.Id, BoundRef: true})
 	require.NoError(t, err)
 
-	tag1, err := db.Get(&schema.KeyRequest{Key: []byte(`myTag1`), SinceTx: ref.Id})
+	tag1, err := db.Get(context.Background(), &schema.KeyRequest{Key: []byte(`myTag1`), SinceTx: ref.Id})
 	require.NoError(t, err)
 	require.Equal(t, []byte(`aaa`), tag1.Key)
 	require.Equal(t, []byte(`item1`), tag1.Value)
 
-	tag2, err := db.Get(&schema.KeyRequest{Key: []byte(`myTag2`), SinceTx: ref.Id})
+	tag2, err := db.Get(context.Background(), &schema.KeyRequest{Key: []byte(`myTag2`), SinceTx: ref.Id})
 	require.NoError(t, err)
 	require.Equal(t, []byte(`aaa`), tag2.Key)
 	require.Equal(t, []byte(`item2`), tag2.Value)
@@ -280,7 +281,7 @@ func TestStoreIndexReference(t *testing.T) {
 func TestStoreReferenceKeyNotProvided(t *testing.T) {
 	db, closer := makeDb()
 	defer closer()
-	_, err := db.SetReference(&schema.ReferenceRequest{Key: []byte(`myTag1`), AtTx: 123, BoundRef: true})
+	_, err := db.SetReference(context.Background(), &schema.ReferenceRequest{Key: []byte(`myTag1`), AtTx: 123, BoundRef: true})
 	require.Equal(t, store.ErrIllegalArguments, err)
 }
 
@@ -288,24 +289,24 @@ func TestStore_GetOnReferenceOnSameKeyReturnsAlwaysLastValue(t *testing.T) {
 	db, closer := makeDb()
 	defer closer()
 
-	idx1, err := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`aaa`), Value: []byte(`item1`)}}})
+	idx1, err := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`aaa`), Value: []byte(`item1`)}}})
 	require.NoError(t, err)
 
-	_, err = db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`aaa`), Value: []byte(`item2`)}}})
+	_, err = db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`aaa`), Value: []byte(`item2`)}}})
 	require.NoError(t, err)
 
-	_, err = db.SetReference(&schema.ReferenceRequest{Key: []byte(`myTag1`), ReferencedKey: []byte(`aaa`)})
+	_, err = db.SetReference(context.Background(), &schema.ReferenceRequest{Key: []byte(`myTag1`), ReferencedKey: []byte(`aaa`)})
 	require.NoError(t, err)
 
-	ref, err := db.SetReference(&schema.ReferenceRequest{Key: []byte(`myTag2`), ReferencedKey: []byte(`aaa`), AtTx: idx1.Id, BoundRef: true})
+	ref, err := db.SetReference(context.Background(), &schema.ReferenceRequest{Key: []byte(`myTag2`), ReferencedKey: []byte(`aaa`), AtTx: idx1.Id, BoundRef: true})
 	require.NoError(t, err)
 
-	tag2, err := db.Get(&schema.KeyRequest{Key: []byte(`myTag2`), SinceTx: ref.Id})
+	tag2, err := db.Get(context.Background(), &schema.KeyRequest{Key: []byte(`myTag2`), SinceTx: ref.Id})
 	require.NoError(t, err)
 	require.Equal(t, []byte(`aaa`), tag2.Key)
 	require.Equal(t, []byte(`item1`), tag2.Value)
 
-	tag1b, err := db.Get(&schema.KeyRequest{Key: []byte(`myTag1`), SinceTx: ref.Id})
+	tag1b, err := db.Get(context.Background(), &schema.KeyRequest{Key: []byte(`myTag1`), SinceTx: ref.Id})
 	require.NoError(t, err)
 	require.Equal(t, []byte(`aaa`), tag1b.Key)
 	require.Equal(t, []byte(`item2`), tag1b.Value)
@@ -315,7 +316,7 @@ func TestStore_ReferenceIllegalArgument(t *testing.T) {
 	db, closer := makeDb()
 	defer closer()
 
-	_, err := db.SetReference(nil)
+	_, err := db.SetReference(context.Background(), nil)
 	require.Equal(t, err, store.ErrIllegalArguments)
 }
 
@@ -323,7 +324,7 @@ func TestStore_ReferencedItemNotFound(t *testing.T) {
 	db, closer := makeDb()
 	defer closer()
 
-	_, err := db.SetReference(&schema.ReferenceRequest{ReferencedKey: []byte(`aaa`), Key: []byte(`notExists`)})
+	_, err := db.SetReference(context.Background(), &schema.ReferenceRequest{ReferencedKey: []byte(`aaa`), Key: []byte(`notExists`)})
 	require.Equal(t, store.ErrKeyNotFound, err)
 }
 
@@ -331,14 +332,14 @@ func TestStoreVerifiableReference(t *testing.T) {
 	db, closer := makeDb()
 	defer closer()
 
-	_, err := db.VerifiableSetReference(nil)
+	_, err := db.VerifiableSetReference(context.Background(), nil)
 	require.Equal(t, store.ErrIllegalArguments, err)
 
 	req := &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`firstKey`), Value: []byte(`firstValue`)}}}
-	meta, err := db.Set(req)
+	meta, err := db.Set(context.Background(), req)
 	require.NoError(t, err)
 
-	_, err = db.VerifiableSetReference(&schema.VerifiableReferenceRequest{
+	_, err = db.VerifiableSetReference(context.Background(), &schema.VerifiableReferenceRequest{
 		ReferenceRequest: nil,
 		ProveSinceTx:     meta.Id,
 	})
@@ -349,13 +350,13 @@ func TestStoreVerifiableReference(t *testing.T) {
 		ReferencedKey: []byte(`firstKey`),
 	}
 
-	_, err = db.VerifiableSetReference(&schema.VerifiableReferenceRequest{
+	_, err = db.VerifiableSetReference(context.Background(), &schema.VerifiableReferenceRequest{
 		ReferenceRequest: refReq,
 		ProveSinceTx:     meta.Id + 1,
 	})
 	require.Equal(t, store.ErrIllegalArguments, err)
 
-	vtx, err := db.VerifiableSetReference(&schema.VerifiableReferenceRequest{
+	vtx, err := db.VerifiableSetReference(context.Background(), &schema.VerifiableReferenceRequest{
 		ReferenceRequest: refReq,
 		ProveSinceTx:     meta.Id,
 	})
@@ -375,7 +376,7 @@ func TestStoreVerifiableReference(t *testing.T) {
 
 	keyReq := &schema.KeyRequest{Key: []byte(`myTag`), SinceTx: vtx.Tx.Metadata.Id}
 
-	firstItemRet, err := db.Get(keyReq)
+	firstItemRet, err := db.Get(context.Background(), keyReq)
 	require.NoError(t, err)
 	require.Equal(t, []byte(`firstValue`), firstItemRet.Value, "Should have referenced item value")
 }
@@ -0,0 +1,65 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package database
+
+import (
+	"github.com/codenotary/immudb/pkg/api/schema"
+)
+
+func (d *db) isReplica() bool {
+	return d.options.replica
+}
+
+// ExportTx returns the tx together with its values, serialized so it can be replicated into a replica
+func (d *db) ExportTx(req *schema.TxRequest) ([]byte, error) {
+	if req == nil || req.Tx == 0 {
+		return nil, ErrIllegalArguments
+	}
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	return d.st.ExportTx(req.Tx, d.st.NewTx())
+}
+
+// ReplicateTx commits a tx exported from the primary database, it must be the next tx of the replica
+// and be linked to the same history
+func (d *db) ReplicateTx(exportedTx []byte) (*schema.TxMetadata, error) {
+	if !d.isReplica() {
+		return nil, ErrNotReplica
+	}
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	md, err := d.st.ReplicateTx(exportedTx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return schema.TxMetatadaTo(md), nil
+}
+
+// ExportedTxMetadata returns the metadata of a tx exported from the primary database,
+// so it can be verified before being replicated
+func (d *db) ExportedTxMetadata(exportedTx []byte) (*schema.TxMetadata, error) {
+	md, err := d.st.ExportedTxMetadata(exportedTx)
+	if err != nil {
+		return nil, err
+	}
+
+	return schema.TxMetatadaTo(md), nil
+}
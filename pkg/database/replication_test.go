@@ -0,0 +1,128 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package database
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/logger"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplication(t *testing.T) {
+	primary, closer := makeDb()
+	defer closer()
+
+	rootPath := "data_replica"
+	defer os.RemoveAll(rootPath)
+
+	catalogDB, err := NewDb(DefaultOption().WithDbRootPath(rootPath).WithDbName("catalog"), nil, logger.NewSimpleLogger("immudb ", os.Stderr))
+	require.NoError(t, err)
+	defer catalogDB.Close()
+
+	replica, err := NewDb(DefaultOption().WithDbRootPath(rootPath).WithDbName("db").WithReplica(true), catalogDB, logger.NewSimpleLogger("immudb ", os.Stderr))
+	require.NoError(t, err)
+	defer replica.Close()
+
+	require.True(t, replica.GetOptions().IsReplica())
+
+	_, err = primary.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key1"), Value: []byte("value1")}}})
+	require.NoError(t, err)
+
+	_, err = primary.ZAdd(context.Background(), &schema.ZAddRequest{Set: []byte("set1"), Score: 1, Key: []byte("key1")})
+	require.NoError(t, err)
+
+	_, err = primary.SetReference(context.Background(), &schema.ReferenceRequest{Key: []byte("ref1"), ReferencedKey: []byte("key1")})
+	require.NoError(t, err)
+
+	md, err := primary.TSAppend(context.Background(), &schema.TSAppendRequest{Series: []byte("cpu"), Samples: []*schema.TSSample{{Timestamp: 1, Value: 1}}})
+	require.NoError(t, err)
+
+	t.Run("replicas reject writes", func(t *testing.T) {
+		_, err := replica.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key1"), Value: []byte("value1")}}})
+		require.Equal(t, ErrIsReplica, err)
+
+		_, err = replica.ExecAll(context.Background(), &schema.ExecAllRequest{
+			Operations: []*schema.Op{{Operation: &schema.Op_Kv{Kv: &schema.KeyValue{Key: []byte("key1"), Value: []byte("value1")}}}},
+		})
+		require.Equal(t, ErrIsReplica, err)
+
+		_, err = replica.ZAdd(context.Background(), &schema.ZAddRequest{Set: []byte("set1"), Score: 1, Key: []byte("key1")})
+		require.Equal(t, ErrIsReplica, err)
+
+		_, err = replica.SetReference(context.Background(), &schema.ReferenceRequest{Key: []byte("ref1"), ReferencedKey: []byte("key1")})
+		require.Equal(t, ErrIsReplica, err)
+
+		_, err = replica.TSAppend(context.Background(), &schema.TSAppendRequest{Series: []byte("cpu"), Samples: []*schema.TSSample{{Timestamp: 1, Value: 1}}})
+		require.Equal(t, ErrIsReplica, err)
+
+		_, err = replica.NewStreamingTx(context.Background())
+		require.Equal(t, ErrIsReplica, err)
+
+		_, err = replica.SQLExec(context.Background(), &schema.SQLExecRequest{Sql: "CREATE TABLE mytable(id INTEGER, PRIMARY KEY id)"})
+		require.Equal(t, ErrIsReplica, err)
+	})
+
+	_, err = primary.ExportTx(nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = primary.ExportTx(&schema.TxRequest{Tx: md.Id + 1})
+	require.Equal(t, store.ErrTxNotFound, err)
+
+	exportedTx, err := primary.ExportTx(&schema.TxRequest{Tx: 1})
+	require.NoError(t, err)
+
+	_, err = primary.ReplicateTx(exportedTx)
+	require.Equal(t, ErrNotReplica, err)
+
+	for txID := uint64(1); txID <= md.Id; txID++ {
+		exportedTx, err := primary.ExportTx(&schema.TxRequest{Tx: txID})
+		require.NoError(t, err)
+
+		replicatedMd, err := replica.ReplicateTx(exportedTx)
+		require.NoError(t, err)
+		require.Equal(t, txID, replicatedMd.Id)
+	}
+
+	primaryState, err := primary.CurrentState()
+	require.NoError(t, err)
+
+	replicaState, err := replica.CurrentState()
+	require.NoError(t, err)
+	require.Equal(t, primaryState.TxId, replicaState.TxId)
+	require.Equal(t, primaryState.TxHash, replicaState.TxHash)
+
+	entry, err := replica.Get(context.Background(), &schema.KeyRequest{Key: []byte("ref1"), SinceTx: md.Id})
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1"), entry.Value)
+
+	zentries, err := replica.ZScan(context.Background(), &schema.ZScanRequest{Set: []byte("set1"), SinceTx: md.Id})
+	require.NoError(t, err)
+	require.Len(t, zentries.Entries, 1)
+
+	samples, err := replica.TSRange(context.Background(), &schema.TSRangeRequest{Series: []byte("cpu"), SinceTx: md.Id})
+	require.NoError(t, err)
+	require.Equal(t, []*schema.TSSample{{Timestamp: 1, Value: 1}}, samples.Samples)
+
+	// txs can only be replicated once
+	_, err = replica.ReplicateTx(exportedTx)
+	require.True(t, errors.Is(err, store.ErrReplicatedTxMismatch))
+}
@@ -16,12 +16,14 @@ limitations under the License.
 package database
 
 import (
+	"context"
+
 	"github.com/codenotary/immudb/embedded/store"
 	"github.com/codenotary/immudb/pkg/api/schema"
 )
 
 //Scan ...
-func (d *db) Scan(req *schema.ScanRequest) (*schema.Entries, error) {
+func (d *db) Scan(ctx context.Context, req *schema.ScanRequest) (*schema.Entries, error) {
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
 
@@ -40,7 +42,7 @@ func (d *db) Scan(req *schema.ScanRequest) (*schema.Entries, error) {
 	}
 
 	if !req.NoWait {
-		err := d.st.WaitForIndexingUpto(waitUntilTx, nil)
+		err := d.st.WaitForIndexingUpto(ctx, waitUntilTx)
 		if err != nil {
 			return nil, err
 		}
@@ -79,6 +81,10 @@ func (d *db) Scan(req *schema.ScanRequest) (*schema.Entries, error) {
 	defer r.Close()
 
 	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		key, _, tx, _, err := r.Read()
 		if err == store.ErrNoMoreEntries {
 			break
@@ -102,3 +108,82 @@ func (d *db) Scan(req *schema.ScanRequest) (*schema.Entries, error) {
 		Entries: entries,
 	}, nil
 }
+
+//IndexScan ...
+func (d *db) IndexScan(ctx context.Context, req *schema.IndexScanRequest) (*schema.Entries, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if req == nil {
+		return nil, store.ErrIllegalArguments
+	}
+
+	if req.Limit > MaxKeyScanLimit {
+		return nil, ErrMaxKeyScanLimitExceeded
+	}
+
+	waitUntilTx := req.SinceTx
+
+	if waitUntilTx == 0 {
+		waitUntilTx, _ = d.st.Alh()
+	}
+
+	if !req.NoWait {
+		err := d.st.WaitForIndexingUpto(ctx, waitUntilTx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	limit := req.Limit
+
+	if req.Limit == 0 {
+		limit = MaxKeyScanLimit
+	}
+
+	r, err := d.st.IndexScan(req.Index, &store.ScanBySpec{
+		StartKey:  req.StartKey,
+		EndKey:    req.EndKey,
+		DescOrder: req.Desc,
+		SinceTx:   waitUntilTx,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	var entries []*schema.Entry
+	i := uint64(0)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		_, key, _, tx, err := r.Read()
+		if err == store.ErrNoMoreEntries {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if len(key) == 0 || key[0] != SetKeyPrefix {
+			continue
+		}
+
+		e, err := d.getAt(key, tx, 0, nil, d.tx1)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, e)
+		if i++; i == limit {
+			break
+		}
+	}
+
+	return &schema.Entries{
+		Entries: entries,
+	}, nil
+}
@@ -16,8 +16,10 @@ limitations under the License.
 package database
 
 import (
+	"context"
 	"encoding/binary"
 	"math"
+	"time"
 
 	"github.com/codenotary/immudb/embedded/store"
 	"github.com/codenotary/immudb/pkg/api/schema"
@@ -32,7 +34,7 @@ const txIDLen = 8
 // As a parameter of ZAddOptions is possible to provide the associated index of the provided key. In this way, when resolving reference, the specified version of the key will be returned.
 // If the index is not provided the resolution will use only the key and last version of the item will be returned
 // If ZAddOptions.index is provided key is optional
-func (d *db) ZAdd(req *schema.ZAddRequest) (*schema.TxMetadata, error) {
+func (d *db) ZAdd(ctx context.Context, req *schema.ZAddRequest) (*schema.TxMetadata, error) {
 	if req == nil || len(req.Set) == 0 || len(req.Key) == 0 {
 		return nil, store.ErrIllegalArguments
 	}
@@ -41,11 +43,15 @@ func (d *db) ZAdd(req *schema.ZAddRequest) (*schema.TxMetadata, error) {
 		return nil, store.ErrIllegalArguments
 	}
 
+	if d.isReplica() {
+		return nil, ErrIsReplica
+	}
+
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
 
 	lastTxID, _ := d.st.Alh()
-	err := d.st.WaitForIndexingUpto(lastTxID, nil)
+	err := d.st.WaitForIndexingUpto(ctx, lastTxID)
 	if err != nil {
 		return nil, err
 	}
@@ -61,13 +67,83 @@ func (d *db) ZAdd(req *schema.ZAddRequest) (*schema.TxMetadata, error) {
 		return nil, ErrReferencedKeyCannotBeAReference
 	}
 
-	meta, err := d.st.Commit([]*store.KV{EncodeZAdd(req.Set, req.Score, key, req.AtTx)}, !req.NoWait)
+	attrs, err := txAttributesFor(req.TraceID)
+	if err != nil {
+		return nil, err
+	}
+
+	callback := func(txID uint64, index store.KeyIndex) ([]*store.KV, error) {
+		score, err := d.zAddScore(req, txID)
+		if err != nil {
+			return nil, err
+		}
+
+		return []*store.KV{EncodeZAdd(req.Set, score, key, req.AtTx)}, nil
+	}
+
+	meta, err := d.st.CommitWithCallback(callback, attrs, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if !req.NoWait {
+		err = d.st.WaitForIndexingUpto(ctx, meta.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return schema.TxMetatadaTo(meta), nil
+}
+
+// zAddScore returns the score of the entry to be committed in the tx txID. Scores assigned by the server are
+// calculated while the tx is being committed, thus they follow the commit order.
+// The caller must hold the db mutex.
+func (d *db) zAddScore(req *schema.ZAddRequest, txID uint64) (float64, error) {
+	switch req.ScoreMode {
+	case schema.ZAddScoreMode_CLIENT_SCORE:
+		return req.Score, nil
+	case schema.ZAddScoreMode_TX_ID_SCORE:
+		if req.Score != 0 {
+			return 0, ErrIllegalArguments
+		}
+		return float64(txID), nil
+	case schema.ZAddScoreMode_TIMESTAMP_SCORE:
+		if req.Score != 0 {
+			return 0, ErrIllegalArguments
+		}
+		return d.nextTimestampScore(txID)
+	}
+
+	return 0, ErrIllegalArguments
+}
+
+// nextTimestampScore returns the current time in microseconds, made greater than any previously assigned one.
+// Microseconds since unix epoch are exactly represented as float64 scores.
+func (d *db) nextTimestampScore(txID uint64) (float64, error) {
+	if d.lastTimestampScore == 0 && txID > 1 {
+		// scores assigned before the db was opened were taken before the commit time of the latest tx,
+		// which is truncated to seconds
+		err := d.st.ReadTx(txID-1, d.tx2)
+		if err != nil {
+			return 0, err
+		}
+
+		d.lastTimestampScore = (d.tx2.Ts + 1) * int64(time.Second/time.Microsecond)
+	}
+
+	ts := d.st.Now().UnixNano() / int64(time.Microsecond)
+	if ts <= d.lastTimestampScore {
+		ts = d.lastTimestampScore + 1
+	}
+
+	d.lastTimestampScore = ts
 
-	return schema.TxMetatadaTo(meta), err
+	return float64(ts), nil
 }
 
 // ZScan ...
-func (d *db) ZScan(req *schema.ZScanRequest) (*schema.ZEntries, error) {
+func (d *db) ZScan(ctx context.Context, req *schema.ZScanRequest) (*schema.ZEntries, error) {
 	d.mutex.Lock()
 	defer d.mutex.Unlock()
 
@@ -119,7 +195,7 @@ func (d *db) ZScan(req *schema.ZScanRequest) (*schema.ZEntries, error) {
 	}
 
 	if !req.NoWait {
-		err := d.st.WaitForIndexingUpto(waitUntilTx, nil)
+		err := d.st.WaitForIndexingUpto(ctx, waitUntilTx)
 		if err != nil {
 			return nil, err
 		}
@@ -147,6 +223,10 @@ func (d *db) ZScan(req *schema.ZScanRequest) (*schema.ZEntries, error) {
 	i := uint64(0)
 
 	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		zKey, _, _, _, err := r.Read()
 		if err == store.ErrNoMoreEntries {
 			break
@@ -198,7 +278,7 @@ func (d *db) ZScan(req *schema.ZScanRequest) (*schema.ZEntries, error) {
 }
 
 //VerifiableZAdd ...
-func (d *db) VerifiableZAdd(req *schema.VerifiableZAddRequest) (*schema.VerifiableTx, error) {
+func (d *db) VerifiableZAdd(ctx context.Context, req *schema.VerifiableZAddRequest) (*schema.VerifiableTx, error) {
 	if req == nil {
 		return nil, store.ErrIllegalArguments
 	}
@@ -208,7 +288,7 @@ func (d *db) VerifiableZAdd(req *schema.VerifiableZAddRequest) (*schema.Verifiab
 		return nil, store.ErrIllegalArguments
 	}
 
-	txMetatadata, err := d.ZAdd(req.ZAddRequest)
+	txMetatadata, err := d.ZAdd(ctx, req.ZAddRequest)
 	if err != nil {
 		return nil, err
 	}
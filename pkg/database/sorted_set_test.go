@@ -16,8 +16,10 @@ limitations under the License.
 package database
 
 import (
+	"context"
 	"math"
 	"testing"
+	"time"
 
 	"github.com/codenotary/immudb/embedded/store"
 	"github.com/codenotary/immudb/pkg/api/schema"
@@ -28,10 +30,10 @@ func TestStoreIndexExists(t *testing.T) {
 	db, closer := makeDb()
 	defer closer()
 
-	db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`myFirstElementKey`), Value: []byte(`firstValue`)}}})
-	db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`mySecondElementKey`), Value: []byte(`secondValue`)}}})
+	db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`myFirstElementKey`), Value: []byte(`firstValue`)}}})
+	db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`mySecondElementKey`), Value: []byte(`secondValue`)}}})
 
-	_, err := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`myThirdElementKey`), Value: []byte(`thirdValue`)}}})
+	_, err := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`myThirdElementKey`), Value: []byte(`thirdValue`)}}})
 	require.NoError(t, err)
 
 	zaddOpts1 := &schema.ZAddRequest{
@@ -40,7 +42,7 @@ func TestStoreIndexExists(t *testing.T) {
 		Score: float64(14.6),
 	}
 
-	reference1, err1 := db.ZAdd(zaddOpts1)
+	reference1, err1 := db.ZAdd(context.Background(), zaddOpts1)
 	require.NoError(t, err1)
 	require.Exactly(t, reference1.Id, uint64(4))
 	require.NotEmptyf(t, reference1, "Should not be empty")
@@ -51,7 +53,7 @@ func TestStoreIndexExists(t *testing.T) {
 		Score: float64(6),
 	}
 
-	reference2, err2 := db.ZAdd(zaddOpts2)
+	reference2, err2 := db.ZAdd(context.Background(), zaddOpts2)
 	require.NoError(t, err2)
 	require.Exactly(t, reference2.Id, uint64(5))
 	require.NotEmptyf(t, reference2, "Should not be empty")
@@ -63,7 +65,7 @@ func TestStoreIndexExists(t *testing.T) {
 		AtTx:     0,
 		BoundRef: true,
 	}
-	_, err2 = db.ZAdd(zaddOpts2)
+	_, err2 = db.ZAdd(context.Background(), zaddOpts2)
 	require.Equal(t, ErrIllegalArguments, err2)
 
 	zaddOpts3 := &schema.ZAddRequest{
@@ -72,7 +74,7 @@ func TestStoreIndexExists(t *testing.T) {
 		Score: float64(14.5),
 	}
 
-	reference3, err3 := db.ZAdd(zaddOpts3)
+	reference3, err3 := db.ZAdd(context.Background(), zaddOpts3)
 	require.NoError(t, err3)
 	require.Exactly(t, reference3.Id, uint64(6))
 	require.NotEmptyf(t, reference3, "Should not be empty")
@@ -82,7 +84,7 @@ func TestStoreIndexExists(t *testing.T) {
 		Limit: MaxKeyScanLimit + 1,
 	}
 
-	_, err = db.ZScan(zscanOpts)
+	_, err = db.ZScan(context.Background(), zscanOpts)
 	require.Equal(t, ErrMaxKeyScanLimitExceeded, err)
 
 	//try to retrieve directly the value or full scan to debug
@@ -91,7 +93,7 @@ func TestStoreIndexExists(t *testing.T) {
 		Set: []byte(`firstIndex`),
 	}
 
-	itemList1, err := db.ZScan(zscanOpts1)
+	itemList1, err := db.ZScan(context.Background(), zscanOpts1)
 	require.NoError(t, err)
 	require.Len(t, itemList1.Entries, 3)
 	require.Equal(t, []byte(`mySecondElementKey`), itemList1.Entries[0].Entry.Key)
@@ -104,7 +106,7 @@ func TestStoreIndexExists(t *testing.T) {
 		Desc:     true,
 	}
 
-	itemList2, err := db.ZScan(zscanOpts2)
+	itemList2, err := db.ZScan(context.Background(), zscanOpts2)
 	require.NoError(t, err)
 	require.Len(t, itemList2.Entries, 3)
 	require.Equal(t, []byte(`myFirstElementKey`), itemList2.Entries[0].Entry.Key)
@@ -116,14 +118,14 @@ func TestStoreIndexEqualKeys(t *testing.T) {
 	db, closer := makeDb()
 	defer closer()
 
-	_, err := db.ZAdd(nil)
+	_, err := db.ZAdd(context.Background(), nil)
 	require.Equal(t, store.ErrIllegalArguments, err)
 
-	i1, _ := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`SignerId1`), Value: []byte(`firstValue`)}}})
-	i2, _ := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`SignerId1`), Value: []byte(`secondValue`)}}})
-	i3, _ := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`SignerId2`), Value: []byte(`thirdValue`)}}})
+	i1, _ := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`SignerId1`), Value: []byte(`firstValue`)}}})
+	i2, _ := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`SignerId1`), Value: []byte(`secondValue`)}}})
+	i3, _ := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`SignerId2`), Value: []byte(`thirdValue`)}}})
 
-	i, err := db.SetReference(&schema.ReferenceRequest{Key: []byte(`myTag1`), ReferencedKey: []byte(`SignerId1`), AtTx: i1.Id, BoundRef: true})
+	i, err := db.SetReference(context.Background(), &schema.ReferenceRequest{Key: []byte(`myTag1`), ReferencedKey: []byte(`SignerId1`), AtTx: i1.Id, BoundRef: true})
 	require.NoError(t, err)
 
 	zaddOpts := &schema.ZAddRequest{
@@ -134,7 +136,7 @@ func TestStoreIndexEqualKeys(t *testing.T) {
 		BoundRef: true,
 	}
 
-	reference1, err := db.ZAdd(zaddOpts)
+	reference1, err := db.ZAdd(context.Background(), zaddOpts)
 	require.Equal(t, ErrReferencedKeyCannotBeAReference, err)
 
 	zaddOpts1 := &schema.ZAddRequest{
@@ -145,7 +147,7 @@ func TestStoreIndexEqualKeys(t *testing.T) {
 		BoundRef: true,
 	}
 
-	reference1, err1 := db.ZAdd(zaddOpts1)
+	reference1, err1 := db.ZAdd(context.Background(), zaddOpts1)
 	require.NoError(t, err1)
 	require.Exactly(t, reference1.Id, uint64(5))
 	require.NotEmptyf(t, reference1, "Should not be empty")
@@ -158,7 +160,7 @@ func TestStoreIndexEqualKeys(t *testing.T) {
 		BoundRef: true,
 	}
 
-	reference2, err2 := db.ZAdd(zaddOpts2)
+	reference2, err2 := db.ZAdd(context.Background(), zaddOpts2)
 	require.NoError(t, err2)
 	require.Exactly(t, reference2.Id, uint64(6))
 	require.NotEmptyf(t, reference2, "Should not be empty")
@@ -171,7 +173,7 @@ func TestStoreIndexEqualKeys(t *testing.T) {
 		BoundRef: true,
 	}
 
-	reference3, err3 := db.ZAdd(zaddOpts3)
+	reference3, err3 := db.ZAdd(context.Background(), zaddOpts3)
 	require.NoError(t, err3)
 	require.Exactly(t, reference3.Id, uint64(7))
 	require.NotEmptyf(t, reference3, "Should not be empty")
@@ -182,7 +184,7 @@ func TestStoreIndexEqualKeys(t *testing.T) {
 		SinceTx: reference3.Id,
 	}
 
-	itemList1, err := db.ZScan(zscanOpts1)
+	itemList1, err := db.ZScan(context.Background(), zscanOpts1)
 	require.NoError(t, err)
 	require.Len(t, itemList1.Entries, 3)
 	require.Equal(t, []byte(`SignerId1`), itemList1.Entries[0].Entry.Key)
@@ -194,9 +196,9 @@ func TestStoreIndexEqualKeysEqualScores(t *testing.T) {
 	db, closer := makeDb()
 	defer closer()
 
-	i1, _ := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`SignerId1`), Value: []byte(`firstValue`)}}})
-	i2, _ := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`SignerId1`), Value: []byte(`secondValue`)}}})
-	i3, _ := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`SignerId2`), Value: []byte(`thirdValue`)}}})
+	i1, _ := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`SignerId1`), Value: []byte(`firstValue`)}}})
+	i2, _ := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`SignerId1`), Value: []byte(`secondValue`)}}})
+	i3, _ := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`SignerId2`), Value: []byte(`thirdValue`)}}})
 
 	score := float64(1.1)
 
@@ -208,7 +210,7 @@ func TestStoreIndexEqualKeysEqualScores(t *testing.T) {
 		BoundRef: true,
 	}
 
-	reference1, err1 := db.ZAdd(zaddOpts1)
+	reference1, err1 := db.ZAdd(context.Background(), zaddOpts1)
 
 	require.NoError(t, err1)
 	require.Exactly(t, uint64(4), reference1.Id)
@@ -222,7 +224,7 @@ func TestStoreIndexEqualKeysEqualScores(t *testing.T) {
 		BoundRef: true,
 	}
 
-	reference2, err2 := db.ZAdd(zaddOpts2)
+	reference2, err2 := db.ZAdd(context.Background(), zaddOpts2)
 
 	require.NoError(t, err2)
 	require.Exactly(t, uint64(5), reference2.Id)
@@ -236,7 +238,7 @@ func TestStoreIndexEqualKeysEqualScores(t *testing.T) {
 		BoundRef: true,
 	}
 
-	reference3, err3 := db.ZAdd(zaddOpts3)
+	reference3, err3 := db.ZAdd(context.Background(), zaddOpts3)
 
 	require.NoError(t, err3)
 	require.Exactly(t, uint64(6), reference3.Id)
@@ -248,7 +250,7 @@ func TestStoreIndexEqualKeysEqualScores(t *testing.T) {
 		SinceTx: reference3.Id,
 	}
 
-	itemList1, err := db.ZScan(zscanOpts1)
+	itemList1, err := db.ZScan(context.Background(), zscanOpts1)
 
 	require.NoError(t, err)
 	require.Len(t, itemList1.Entries, 3)
@@ -265,7 +267,7 @@ func TestStoreIndexEqualKeysMismatchError(t *testing.T) {
 	db, closer := makeDb()
 	defer closer()
 
-	i1, _ := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`SignerId1`), Value: []byte(`firstValue`)}}})
+	i1, _ := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`SignerId1`), Value: []byte(`firstValue`)}}})
 
 	zaddOpts1 := &schema.ZAddRequest{
 		Set:      []byte(`hashA`),
@@ -275,7 +277,7 @@ func TestStoreIndexEqualKeysMismatchError(t *testing.T) {
 		BoundRef: true,
 	}
 
-	_, err := db.ZAdd(zaddOpts1)
+	_, err := db.ZAdd(context.Background(), zaddOpts1)
 
 	require.Equal(t, store.ErrKeyNotFound, err)
 }
@@ -293,12 +295,12 @@ func TestStore_ZScanPagination(t *testing.T) {
 	defer closer()
 
 	setName := []byte(`set1`)
-	i1, _ := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`key1`), Value: []byte(`val1`)}}})
-	i2, _ := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`key2`), Value: []byte(`val2`)}}})
-	i3, _ := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`key3`), Value: []byte(`val3`)}}})
-	i4, _ := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`key4`), Value: []byte(`val4`)}}})
-	i5, _ := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`key5`), Value: []byte(`val5`)}}})
-	i6, _ := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`key6`), Value: []byte(`val6`)}}})
+	i1, _ := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`key1`), Value: []byte(`val1`)}}})
+	i2, _ := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`key2`), Value: []byte(`val2`)}}})
+	i3, _ := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`key3`), Value: []byte(`val3`)}}})
+	i4, _ := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`key4`), Value: []byte(`val4`)}}})
+	i5, _ := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`key5`), Value: []byte(`val5`)}}})
+	i6, _ := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`key6`), Value: []byte(`val6`)}}})
 
 	zaddOpts1 := &schema.ZAddRequest{
 		Set:      setName,
@@ -343,13 +345,13 @@ func TestStore_ZScanPagination(t *testing.T) {
 		BoundRef: true,
 	}
 
-	db.ZAdd(zaddOpts1)
-	db.ZAdd(zaddOpts2)
-	db.ZAdd(zaddOpts3)
-	db.ZAdd(zaddOpts4)
-	db.ZAdd(zaddOpts5)
+	db.ZAdd(context.Background(), zaddOpts1)
+	db.ZAdd(context.Background(), zaddOpts2)
+	db.ZAdd(context.Background(), zaddOpts3)
+	db.ZAdd(context.Background(), zaddOpts4)
+	db.ZAdd(context.Background(), zaddOpts5)
 
-	meta, err := db.ZAdd(zaddOpts6)
+	meta, err := db.ZAdd(context.Background(), zaddOpts6)
 	require.NoError(t, err)
 
 	zScanOption0 := &schema.ZScanRequest{
@@ -358,7 +360,7 @@ func TestStore_ZScanPagination(t *testing.T) {
 		SinceTx:  meta.Id,
 	}
 
-	list0, err := db.ZScan(zScanOption0)
+	list0, err := db.ZScan(context.Background(), zScanOption0)
 	require.NoError(t, err)
 	require.Empty(t, list0.Entries)
 
@@ -372,7 +374,7 @@ func TestStore_ZScanPagination(t *testing.T) {
 		SinceTx:  meta.Id,
 	}
 
-	list1, err := db.ZScan(zScanOption1)
+	list1, err := db.ZScan(context.Background(), zScanOption1)
 	require.NoError(t, err)
 	require.Len(t, list1.Entries, 2)
 	require.Equal(t, list1.Entries[0].Entry.Key, []byte(`key3`))
@@ -392,7 +394,7 @@ func TestStore_ZScanPagination(t *testing.T) {
 		SinceTx:   meta.Id,
 	}
 
-	list, err := db.ZScan(zScanOption2)
+	list, err := db.ZScan(context.Background(), zScanOption2)
 	require.NoError(t, err)
 	require.Len(t, list.Entries, 2)
 	require.Equal(t, list.Entries[0].Entry.Key, []byte(`key5`))
@@ -412,12 +414,12 @@ func TestStore_ZScanReversePagination(t *testing.T) {
 	defer closer()
 
 	setName := []byte(`set1`)
-	i1, _ := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`key1`), Value: []byte(`val1`)}}})
-	i2, _ := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`key2`), Value: []byte(`val2`)}}})
-	i3, _ := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`key3`), Value: []byte(`val3`)}}})
-	i4, _ := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`key4`), Value: []byte(`val4`)}}})
-	i5, _ := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`key5`), Value: []byte(`val5`)}}})
-	i6, _ := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`key6`), Value: []byte(`val6`)}}})
+	i1, _ := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`key1`), Value: []byte(`val1`)}}})
+	i2, _ := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`key2`), Value: []byte(`val2`)}}})
+	i3, _ := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`key3`), Value: []byte(`val3`)}}})
+	i4, _ := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`key4`), Value: []byte(`val4`)}}})
+	i5, _ := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`key5`), Value: []byte(`val5`)}}})
+	i6, _ := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`key6`), Value: []byte(`val6`)}}})
 
 	zaddOpts1 := &schema.ZAddRequest{
 		Set:      setName,
@@ -462,12 +464,12 @@ func TestStore_ZScanReversePagination(t *testing.T) {
 		BoundRef: true,
 	}
 
-	db.ZAdd(zaddOpts1)
-	db.ZAdd(zaddOpts2)
-	db.ZAdd(zaddOpts3)
-	db.ZAdd(zaddOpts4)
-	db.ZAdd(zaddOpts5)
-	meta, err := db.ZAdd(zaddOpts6)
+	db.ZAdd(context.Background(), zaddOpts1)
+	db.ZAdd(context.Background(), zaddOpts2)
+	db.ZAdd(context.Background(), zaddOpts3)
+	db.ZAdd(context.Background(), zaddOpts4)
+	db.ZAdd(context.Background(), zaddOpts5)
+	meta, err := db.ZAdd(context.Background(), zaddOpts6)
 	require.NoError(t, err)
 
 	zScanOption1 := &schema.ZScanRequest{
@@ -482,7 +484,7 @@ func TestStore_ZScanReversePagination(t *testing.T) {
 		SinceTx:       meta.Id,
 	}
 
-	list1, err := db.ZScan(zScanOption1)
+	list1, err := db.ZScan(context.Background(), zScanOption1)
 	require.NoError(t, err)
 	require.Len(t, list1.Entries, 2)
 	require.Equal(t, list1.Entries[0].Entry.Key, []byte(`key6`))
@@ -501,7 +503,7 @@ func TestStore_ZScanReversePagination(t *testing.T) {
 		SinceTx:       meta.Id,
 	}
 
-	list2, err := db.ZScan(zScanOption2)
+	list2, err := db.ZScan(context.Background(), zScanOption2)
 	require.NoError(t, err)
 	require.Len(t, list2.Entries, 2)
 	require.Equal(t, list2.Entries[0].Entry.Key, []byte(`key5`))
@@ -518,7 +520,7 @@ func TestStore_ZScanReversePagination(t *testing.T) {
 		SinceTx:       meta.Id,
 	}
 
-	list3, err := db.ZScan(zScanOption3)
+	list3, err := db.ZScan(context.Background(), zScanOption3)
 	require.NoError(t, err)
 	require.Len(t, list3.Entries, 2)
 	require.Equal(t, list3.Entries[0].Entry.Key, []byte(`key4`))
@@ -532,7 +534,7 @@ func TestStore_ZScanInvalidSet(t *testing.T) {
 	opt := &schema.ZScanRequest{
 		Set: nil,
 	}
-	_, err := db.ZScan(opt)
+	_, err := db.ZScan(context.Background(), opt)
 	require.Equal(t, store.ErrIllegalArguments, err)
 }
 
@@ -540,37 +542,37 @@ func TestStore_ZScanOnEqualKeysWithSameScoreAreReturnedOrderedByTS(t *testing.T)
 	db, closer := makeDb()
 	defer closer()
 
-	idx0, _ := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`key1`), Value: []byte(`val1-A`)}}})
-	db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`key2`), Value: []byte(`val2-A`)}}})
-	idx2, _ := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`key1`), Value: []byte(`val1-B`)}}})
-	db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`key3`), Value: []byte(`val3-A`)}}})
-	idx4, _ := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`key1`), Value: []byte(`val1-C`)}}})
+	idx0, _ := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`key1`), Value: []byte(`val1-A`)}}})
+	db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`key2`), Value: []byte(`val2-A`)}}})
+	idx2, _ := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`key1`), Value: []byte(`val1-B`)}}})
+	db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`key3`), Value: []byte(`val3-A`)}}})
+	idx4, _ := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`key1`), Value: []byte(`val1-C`)}}})
 
-	db.ZAdd(&schema.ZAddRequest{
+	db.ZAdd(context.Background(), &schema.ZAddRequest{
 		Set:      []byte(`mySet`),
 		Score:    0,
 		Key:      []byte(`key1`),
 		AtTx:     idx2.Id,
 		BoundRef: true,
 	})
-	db.ZAdd(&schema.ZAddRequest{
+	db.ZAdd(context.Background(), &schema.ZAddRequest{
 		Set:      []byte(`mySet`),
 		Score:    0,
 		Key:      []byte(`key1`),
 		AtTx:     idx0.Id,
 		BoundRef: true,
 	})
-	db.ZAdd(&schema.ZAddRequest{
+	db.ZAdd(context.Background(), &schema.ZAddRequest{
 		Set:   []byte(`mySet`),
 		Score: 0,
 		Key:   []byte(`key2`),
 	})
-	db.ZAdd(&schema.ZAddRequest{
+	db.ZAdd(context.Background(), &schema.ZAddRequest{
 		Set:   []byte(`mySet`),
 		Score: 0,
 		Key:   []byte(`key3`),
 	})
-	meta, _ := db.ZAdd(&schema.ZAddRequest{
+	meta, _ := db.ZAdd(context.Background(), &schema.ZAddRequest{
 		Set:      []byte(`mySet`),
 		Score:    0,
 		Key:      []byte(`key1`),
@@ -583,7 +585,7 @@ func TestStore_ZScanOnEqualKeysWithSameScoreAreReturnedOrderedByTS(t *testing.T)
 		SinceTx: meta.Id,
 	}
 
-	list, err := db.ZScan(ZScanRequest)
+	list, err := db.ZScan(context.Background(), ZScanRequest)
 	require.NoError(t, err)
 	// same key, sorted by internal timestamp
 	require.Exactly(t, []byte(`val1-A`), list.Entries[0].Entry.Value)
@@ -597,9 +599,9 @@ func TestStoreZScanOnZAddIndexReference(t *testing.T) {
 	db, closer := makeDb()
 	defer closer()
 
-	i1, _ := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`SignerId1`), Value: []byte(`firstValue`)}}})
-	i2, _ := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`SignerId1`), Value: []byte(`secondValue`)}}})
-	i3, _ := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`SignerId2`), Value: []byte(`thirdValue`)}}})
+	i1, _ := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`SignerId1`), Value: []byte(`firstValue`)}}})
+	i2, _ := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`SignerId1`), Value: []byte(`secondValue`)}}})
+	i3, _ := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`SignerId2`), Value: []byte(`thirdValue`)}}})
 
 	zaddOpts1 := &schema.ZAddRequest{
 		Set:      []byte(`hashA`),
@@ -609,7 +611,7 @@ func TestStoreZScanOnZAddIndexReference(t *testing.T) {
 		BoundRef: true,
 	}
 
-	reference1, err1 := db.ZAdd(zaddOpts1)
+	reference1, err1 := db.ZAdd(context.Background(), zaddOpts1)
 	require.NoError(t, err1)
 	require.Exactly(t, uint64(4), reference1.Id)
 	require.NotEmptyf(t, reference1, "Should not be empty")
@@ -622,7 +624,7 @@ func TestStoreZScanOnZAddIndexReference(t *testing.T) {
 		BoundRef: true,
 	}
 
-	reference2, err2 := db.ZAdd(zaddOpts2)
+	reference2, err2 := db.ZAdd(context.Background(), zaddOpts2)
 	require.NoError(t, err2)
 	require.Exactly(t, uint64(5), reference2.Id)
 	require.NotEmptyf(t, reference2, "Should not be empty")
@@ -635,7 +637,7 @@ func TestStoreZScanOnZAddIndexReference(t *testing.T) {
 		BoundRef: true,
 	}
 
-	reference3, err3 := db.ZAdd(zaddOpts3)
+	reference3, err3 := db.ZAdd(context.Background(), zaddOpts3)
 	require.NoError(t, err3)
 	require.Exactly(t, uint64(6), reference3.Id)
 	require.NotEmptyf(t, reference3, "Should not be empty")
@@ -646,7 +648,7 @@ func TestStoreZScanOnZAddIndexReference(t *testing.T) {
 		SinceTx: reference3.Id,
 	}
 
-	itemList1, err := db.ZScan(zscanOpts1)
+	itemList1, err := db.ZScan(context.Background(), zscanOpts1)
 	require.NoError(t, err)
 	require.Len(t, itemList1.Entries, 3)
 	require.Equal(t, []byte(`SignerId1`), itemList1.Entries[0].Entry.Key)
@@ -659,18 +661,18 @@ func TestStoreVerifiableZAdd(t *testing.T) {
 	db, closer := makeDb()
 	defer closer()
 
-	_, err := db.VerifiableZAdd(nil)
+	_, err := db.VerifiableZAdd(context.Background(), nil)
 	require.Equal(t, store.ErrIllegalArguments, err)
 
-	i1, _ := db.Set(&schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`key1`), Value: []byte(`value1`)}}})
+	i1, _ := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(`key1`), Value: []byte(`value1`)}}})
 
-	vtx, err := db.VerifiableZAdd(&schema.VerifiableZAddRequest{
+	vtx, err := db.VerifiableZAdd(context.Background(), &schema.VerifiableZAddRequest{
 		ZAddRequest:  nil,
 		ProveSinceTx: i1.Id + 1,
 	})
 	require.Equal(t, store.ErrIllegalArguments, err)
 
-	vtx, err = db.VerifiableZAdd(&schema.VerifiableZAddRequest{
+	vtx, err = db.VerifiableZAdd(context.Background(), &schema.VerifiableZAddRequest{
 		ZAddRequest:  nil,
 		ProveSinceTx: i1.Id,
 	})
@@ -684,7 +686,7 @@ func TestStoreVerifiableZAdd(t *testing.T) {
 		BoundRef: true,
 	}
 
-	vtx, err = db.VerifiableZAdd(&schema.VerifiableZAddRequest{
+	vtx, err = db.VerifiableZAdd(context.Background(), &schema.VerifiableZAddRequest{
 		ZAddRequest:  req,
 		ProveSinceTx: i1.Id,
 	})
@@ -711,9 +713,82 @@ func TestStoreVerifiableZAdd(t *testing.T) {
 		SinceTx: vtx.Tx.Metadata.Id,
 	}
 
-	itemList1, err := db.ZScan(zscanReq)
+	itemList1, err := db.ZScan(context.Background(), zscanReq)
 	require.NoError(t, err)
 	require.Len(t, itemList1.Entries, 1)
 	require.Equal(t, req.Key, itemList1.Entries[0].Entry.Key)
 	require.Equal(t, req.Score, itemList1.Entries[0].Score)
 }
+
+func TestStoreZAddWithScoreMode(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	keys := [][]byte{[]byte(`key3`), []byte(`key1`), []byte(`key2`)}
+
+	for _, k := range keys {
+		_, err := db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: k, Value: k}}})
+		require.NoError(t, err)
+	}
+
+	_, err := db.ZAdd(context.Background(), &schema.ZAddRequest{
+		Set:       []byte(`txSet`),
+		Score:     1,
+		Key:       keys[0],
+		ScoreMode: schema.ZAddScoreMode_TX_ID_SCORE,
+	})
+	require.Equal(t, ErrIllegalArguments, err)
+
+	var lastTx uint64
+
+	for _, k := range keys {
+		meta, err := db.ZAdd(context.Background(), &schema.ZAddRequest{
+			Set:       []byte(`txSet`),
+			Key:       k,
+			ScoreMode: schema.ZAddScoreMode_TX_ID_SCORE,
+		})
+		require.NoError(t, err)
+
+		_, err = db.ZAdd(context.Background(), &schema.ZAddRequest{
+			Set:       []byte(`tsSet`),
+			Key:       k,
+			ScoreMode: schema.ZAddScoreMode_TIMESTAMP_SCORE,
+		})
+		require.NoError(t, err)
+
+		lastTx = meta.Id
+	}
+
+	// timestamp scores are strictly increasing even within the same transaction
+	meta, err := db.ExecAll(context.Background(), &schema.ExecAllRequest{
+		Operations: []*schema.Op{
+			{Operation: &schema.Op_ZAdd{ZAdd: &schema.ZAddRequest{Set: []byte(`tsSet`), Key: keys[0], ScoreMode: schema.ZAddScoreMode_TIMESTAMP_SCORE}}},
+			{Operation: &schema.Op_ZAdd{ZAdd: &schema.ZAddRequest{Set: []byte(`tsSet`), Key: keys[1], ScoreMode: schema.ZAddScoreMode_TIMESTAMP_SCORE}}},
+		},
+	})
+	require.NoError(t, err)
+
+	txList, err := db.ZScan(context.Background(), &schema.ZScanRequest{Set: []byte(`txSet`), SinceTx: meta.Id})
+	require.NoError(t, err)
+	require.Len(t, txList.Entries, len(keys))
+
+	for i, e := range txList.Entries {
+		require.Equal(t, keys[i], e.Key)
+		require.Equal(t, float64(lastTx-2*uint64(len(keys)-1-i)), e.Score)
+	}
+
+	tsList, err := db.ZScan(context.Background(), &schema.ZScanRequest{Set: []byte(`tsSet`), SinceTx: meta.Id})
+	require.NoError(t, err)
+	require.Len(t, tsList.Entries, len(keys)+2)
+
+	for i, e := range tsList.Entries {
+		if i < len(keys) {
+			require.Equal(t, keys[i], e.Key)
+		}
+		if i > 0 {
+			require.Greater(t, e.Score, tsList.Entries[i-1].Score)
+		}
+	}
+
+	require.InDelta(t, float64(time.Now().UnixNano()/int64(time.Microsecond)), tsList.Entries[len(keys)+1].Score, float64(time.Minute/time.Microsecond))
+}
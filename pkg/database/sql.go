@@ -16,15 +16,26 @@ limitations under the License.
 package database
 
 import (
+	"context"
+	"encoding/binary"
 	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/codenotary/immudb/embedded/sql"
 	"github.com/codenotary/immudb/embedded/store"
 	"github.com/codenotary/immudb/pkg/api/schema"
+	"google.golang.org/protobuf/proto"
 )
 
-func (d *db) VerifiableSQLGet(req *schema.VerifiableSQLGetRequest) (*schema.VerifiableSQLEntry, error) {
+var ErrNotSchemaStmt = errors.New("only statements defining tables, columns, indexes and policies can be imported")
+var ErrNotBatchableStmt = errors.New("each batched statement must be a single statement changing data or tables")
+var ErrNotVerifiableQuery = errors.New("only queries returning stored rows of a single table, including their primary key, can be verified")
+
+func (d *db) VerifiableSQLGet(ctx context.Context, req *schema.VerifiableSQLGetRequest) (*schema.VerifiableSQLEntry, error) {
 	if req == nil {
 		return nil, ErrIllegalArguments
 	}
@@ -44,6 +55,11 @@ func (d *db) VerifiableSQLGet(req *schema.VerifiableSQLGetRequest) (*schema.Veri
 		return nil, err
 	}
 
+	// rows are verified by a single primary key value
+	if len(table.PrimaryKeyCols()) > 1 {
+		return nil, fmt.Errorf("%w: rows of tables with composite primary keys can not be verified", ErrIllegalArguments)
+	}
+
 	pkEncVal, err := sql.EncodeRawValue(schema.RawValue(req.SqlGetRequest.PkValue), table.PrimaryKey().Type(), true)
 	if err != nil {
 		return nil, err
@@ -57,6 +73,15 @@ func (d *db) VerifiableSQLGet(req *schema.VerifiableSQLGetRequest) (*schema.Veri
 		return nil, err
 	}
 
+	// rows not accessible within the user session are reported as missing so their existence is not disclosed
+	accessible, err := d.sqlEngine.IsRowAccessible(ctx, table, e.Value)
+	if err != nil {
+		return nil, err
+	}
+	if !accessible {
+		return nil, store.ErrKeyNotFound
+	}
+
 	// key-value inclusion proof
 	err = d.st.ReadTx(e.Tx, txEntry)
 	if err != nil {
@@ -124,6 +149,280 @@ func (d *db) VerifiableSQLGet(req *schema.VerifiableSQLGetRequest) (*schema.Veri
 	}, nil
 }
 
+// VerifiableSQLQuery returns the rows of the query together with the proofs of the stored rows they were read from.
+// Each stored row is found by the primary key of the returned row and proven to be included in the tx it was written in,
+// which is in turn linked to the tx the query was resolved at
+func (d *db) VerifiableSQLQuery(ctx context.Context, req *schema.VerifiableSQLQueryRequest) (*schema.VerifiableSQLQueryResult, error) {
+	if req == nil || req.SqlQueryRequest == nil {
+		return nil, ErrIllegalArguments
+	}
+
+	lastTxID, _ := d.st.Alh()
+	if lastTxID < req.ProveSinceTx {
+		return nil, ErrIllegalState
+	}
+
+	stmts, err := sql.Parse(strings.NewReader(req.SqlQueryRequest.Sql))
+	if err != nil {
+		return nil, err
+	}
+
+	stmt, ok := stmts[0].(*sql.SelectStmt)
+	if !ok {
+		return nil, ErrIllegalArguments
+	}
+
+	if stmt.Limit() > MaxKeyScanLimit {
+		return nil, ErrMaxKeyScanLimitExceeded
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	params := make(map[string]interface{})
+
+	for _, p := range req.SqlQueryRequest.Params {
+		params[p.Name] = schema.RawValue(p.Value)
+	}
+
+	r, err := d.sqlEngine.QueryPreparedStmt(ctx, stmt, params, !req.SqlQueryRequest.ReuseSnapshot)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	res, err := sqlQueryResultFrom(r, MaxKeyScanLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	// stored rows are read from the snapshot the query was resolved with
+	snap, err := d.sqlEngine.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	table, err := d.verifiableTable(res)
+	if err != nil {
+		return nil, err
+	}
+
+	colIdsByName := make(map[string]uint64, len(table.ColsByName()))
+	colTypesById := make(map[uint64]string, len(table.ColsByID()))
+
+	for _, col := range table.ColsByID() {
+		colIdsByName[sql.EncodeSelector("", table.Database().Name(), table.Name(), col.Name())] = col.ID()
+		colTypesById[col.ID()] = col.Type()
+	}
+
+	pkNames := make([]string, len(table.PrimaryKeyCols()))
+	pkPositions := make([]int, len(table.PrimaryKeyCols()))
+
+	for i, pkCol := range table.PrimaryKeyCols() {
+		pkNames[i] = pkCol.Name()
+		pkPositions[i] = -1
+
+		for j, c := range res.Columns {
+			if colIdsByName[c.Name] == pkCol.ID() {
+				pkPositions[i] = j
+				break
+			}
+		}
+
+		if pkPositions[i] < 0 {
+			return nil, fmt.Errorf("%w: column '%s' of the primary key is not selected", ErrNotVerifiableQuery, pkCol.Name())
+		}
+	}
+
+	queryTx := d.tx2
+
+	err = d.st.ReadTx(snap.Ts(), queryTx)
+	if err != nil {
+		return nil, err
+	}
+
+	rowTx := d.tx1
+
+	vRows := make([]*schema.VerifiableSQLRow, len(res.Rows))
+
+	for i, row := range res.Rows {
+		var pkEncVal []byte
+
+		for j, pkCol := range table.PrimaryKeyCols() {
+			encVal, err := sql.EncodeRawValue(schema.RawValue(row.Values[pkPositions[j]]), pkCol.Type(), true)
+			if err != nil {
+				return nil, err
+			}
+
+			pkEncVal = append(pkEncVal, encVal...)
+		}
+
+		pkKey := sql.MapKey([]byte{SQLPrefix}, sql.RowPrefix, sql.EncodeID(table.Database().ID()), sql.EncodeID(table.ID()), sql.EncodeID(table.PrimaryKey().ID()), pkEncVal)
+
+		e, err := d.sqlGetAt(pkKey, 0, snap, rowTx)
+		if err != nil {
+			return nil, err
+		}
+
+		err = verifyStoredRow(row, e.Value, colIdsByName, colTypesById)
+		if err != nil {
+			return nil, err
+		}
+
+		err = d.st.ReadTx(e.Tx, rowTx)
+		if err != nil {
+			return nil, err
+		}
+
+		inclusionProof, err := rowTx.Proof(e.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		dualProof, err := d.st.DualProof(rowTx, queryTx)
+		if err != nil {
+			return nil, err
+		}
+
+		vRows[i] = &schema.VerifiableSQLRow{
+			SqlEntry:       e,
+			InclusionProof: schema.InclusionProofTo(inclusionProof),
+			DualProof:      schema.DualProofTo(dualProof),
+		}
+	}
+
+	var rootTx *store.Tx
+
+	if req.ProveSinceTx == 0 {
+		rootTx = queryTx
+	} else {
+		rootTx = rowTx
+
+		err = d.st.ReadTx(req.ProveSinceTx, rootTx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var sourceTx, targetTx *store.Tx
+
+	if req.ProveSinceTx <= queryTx.ID {
+		sourceTx = rootTx
+		targetTx = queryTx
+	} else {
+		sourceTx = queryTx
+		targetTx = rootTx
+	}
+
+	dualProof, err := d.st.DualProof(sourceTx, targetTx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema.VerifiableSQLQueryResult{
+		Result: res,
+		VerifiableTx: &schema.VerifiableTx{
+			Tx:        schema.TxTo(queryTx),
+			DualProof: schema.DualProofTo(dualProof),
+		},
+		Rows:         vRows,
+		DatabaseId:   table.Database().ID(),
+		TableId:      table.ID(),
+		TableName:    table.Name(),
+		PKNames:      pkNames,
+		ColIdsByName: colIdsByName,
+		ColTypesById: colTypesById,
+	}, nil
+}
+
+// verifiableTable returns the table the columns of the result belong to, columns of other tables or
+// aggregated ones can not be matched with the columns of the stored rows
+func (d *db) verifiableTable(res *schema.SQLQueryResult) (*sql.Table, error) {
+	var table *sql.Table
+
+	for _, c := range res.Columns {
+		if !strings.HasPrefix(c.Name, "(") || !strings.HasSuffix(c.Name, ")") {
+			return nil, fmt.Errorf("%w: column '%s' is not a column of a table", ErrNotVerifiableQuery, c.Name)
+		}
+
+		// selectors are encoded as (db.table.col)
+		sel := strings.Split(c.Name[1:len(c.Name)-1], ".")
+		if len(sel) != 3 || sel[0] != d.options.dbName {
+			return nil, fmt.Errorf("%w: column '%s' is not a column of a table", ErrNotVerifiableQuery, c.Name)
+		}
+
+		if table == nil {
+			t, err := d.sqlEngine.Catalog().GetTableByName(d.options.dbName, sel[1])
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrNotVerifiableQuery, err)
+			}
+
+			table = t
+		}
+
+		if sel[1] != table.Name() {
+			return nil, fmt.Errorf("%w: columns of tables '%s' and '%s' are selected", ErrNotVerifiableQuery, table.Name(), sel[1])
+		}
+
+		_, err := table.GetColumnByName(sel[2])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrNotVerifiableQuery, err)
+		}
+	}
+
+	return table, nil
+}
+
+// verifyStoredRow checks the returned row holds the values of the stored one, which is not the case when past versions
+// of the rows are read or values are renamed after other columns
+func verifyStoredRow(row *schema.Row, encodedRow []byte, colIdsByName map[string]uint64, colTypesById map[uint64]string) error {
+	stored := make(map[uint64]sql.TypedValue, len(colTypesById))
+
+	if len(encodedRow) < sql.EncLenLen {
+		return sql.ErrCorruptedData
+	}
+
+	colsCount := binary.BigEndian.Uint32(encodedRow)
+	off := sql.EncLenLen
+
+	for i := 0; i < int(colsCount); i++ {
+		if len(encodedRow) < off+sql.EncIDLen {
+			return sql.ErrCorruptedData
+		}
+
+		colID := binary.BigEndian.Uint64(encodedRow[off:])
+		off += sql.EncIDLen
+
+		colType, ok := colTypesById[colID]
+		if !ok {
+			return sql.ErrCorruptedData
+		}
+
+		val, n, err := sql.DecodeValue(encodedRow[off:], colType)
+		if err != nil {
+			return err
+		}
+
+		stored[colID] = val
+		off += n
+	}
+
+	for i, colName := range row.Columns {
+		storedVal := &schema.SQLValue{Value: &schema.SQLValue_Null{}}
+
+		val, ok := stored[colIdsByName[colName]]
+		if ok {
+			storedVal = TypedValueToRowValue(val)
+		}
+
+		if !proto.Equal(row.Values[i], storedVal) {
+			return fmt.Errorf("%w: values of column '%s' don't match the stored ones", ErrNotVerifiableQuery, colName)
+		}
+	}
+
+	return nil
+}
+
 func (d *db) sqlGetAt(key []byte, atTx uint64, index store.KeyIndex, tx *store.Tx) (entry *schema.SQLEntry, err error) {
 	var ktx uint64
 	var val []byte
@@ -178,10 +477,21 @@ func (d *db) DescribeTable(tableName string) (*schema.SQLQueryResult, error) {
 		{Name: "INDEX", Type: sql.VarcharType},
 	}}
 
-	for _, c := range table.ColsByID() {
+	colsByID := table.ColsByID()
+
+	// columns are described in the order they were defined
+	colIDs := make([]uint64, 0, len(colsByID))
+	for id := range colsByID {
+		colIDs = append(colIDs, id)
+	}
+	sort.Slice(colIDs, func(i, j int) bool { return colIDs[i] < colIDs[j] })
+
+	for _, id := range colIDs {
+		c := colsByID[id]
+
 		index := "NO"
 
-		if table.PrimaryKey().Name() == c.Name() {
+		if table.IsPrimaryKey(c.Name()) {
 			index = "PRIMARY KEY"
 		}
 
@@ -193,6 +503,14 @@ func (d *db) DescribeTable(tableName string) (*schema.SQLQueryResult, error) {
 			index = "YES"
 		}
 
+		unique, err := table.IsUnique(c.Name())
+		if err != nil {
+			return nil, err
+		}
+		if unique {
+			index = "UNIQUE"
+		}
+
 		res.Rows = append(res.Rows, &schema.Row{
 			Values: []*schema.SQLValue{
 				{Value: &schema.SQLValue_S{S: c.Name()}},
@@ -206,7 +524,129 @@ func (d *db) DescribeTable(tableName string) (*schema.SQLQueryResult, error) {
 	return res, nil
 }
 
-func (d *db) SQLExec(req *schema.SQLExecRequest) (*schema.SQLExecResult, error) {
+// SQLTableInfo describes a table of the SQL catalog, the id is unique within the database and is never reused
+type SQLTableInfo struct {
+	ID         uint64
+	Name       string
+	Columns    []*SQLColumnInfo
+	PrimaryKey []string
+}
+
+// SQLColumnInfo describes a column of a table of the SQL catalog
+type SQLColumnInfo struct {
+	Name          string
+	Type          sql.SQLValueType
+	Nullable      bool
+	AutoIncrement bool
+}
+
+// HasSQLPolicies returns whether rows of any table of the database are restricted by policies.
+// Policies only apply to SQL statements, the raw keys and txs holding the rows are not filtered
+func (d *db) HasSQLPolicies() bool {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	db, err := d.sqlEngine.Catalog().GetDatabaseByName(d.options.dbName)
+	if err != nil {
+		return false
+	}
+
+	for _, t := range db.GetTables() {
+		if t.HasPolicies() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SQLTables describes the tables of the database sorted by name, their columns are in the order they were defined
+func (d *db) SQLTables() ([]*SQLTableInfo, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	db, err := d.sqlEngine.Catalog().GetDatabaseByName(d.options.dbName)
+	if err != nil {
+		return nil, err
+	}
+
+	var tables []*SQLTableInfo
+
+	for _, t := range db.GetTables() {
+		info := &SQLTableInfo{ID: t.ID(), Name: t.Name()}
+
+		colsByID := t.ColsByID()
+
+		colIDs := make([]uint64, 0, len(colsByID))
+		for id := range colsByID {
+			colIDs = append(colIDs, id)
+		}
+		sort.Slice(colIDs, func(i, j int) bool { return colIDs[i] < colIDs[j] })
+
+		for _, id := range colIDs {
+			c := colsByID[id]
+
+			info.Columns = append(info.Columns, &SQLColumnInfo{
+				Name:          c.Name(),
+				Type:          c.Type(),
+				Nullable:      c.IsNullable(),
+				AutoIncrement: c.IsAutoIncremental(),
+			})
+		}
+
+		for _, c := range t.PrimaryKeyCols() {
+			info.PrimaryKey = append(info.PrimaryKey, c.Name())
+		}
+
+		tables = append(tables, info)
+	}
+
+	sort.Slice(tables, func(i, j int) bool { return tables[i].Name < tables[j].Name })
+
+	return tables, nil
+}
+
+// ExportSQLSchema returns the statements re-creating the tables and indexes of the database
+func (d *db) ExportSQLSchema() (*schema.SQLSchema, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	db, err := d.sqlEngine.Catalog().GetDatabaseByName(d.options.dbName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema.SQLSchema{Ddl: db.DDL()}, nil
+}
+
+// ImportSQLSchema applies a schema exported with ExportSQLSchema, any statement not defining
+// tables, columns, indexes or policies is rejected before anything gets executed
+func (d *db) ImportSQLSchema(ctx context.Context, req *schema.SQLSchema) (*schema.SQLExecResult, error) {
+	if req == nil {
+		return nil, ErrIllegalArguments
+	}
+
+	if len(strings.TrimSpace(req.Ddl)) == 0 {
+		return &schema.SQLExecResult{}, nil
+	}
+
+	stmts, err := sql.Parse(strings.NewReader(req.Ddl))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, stmt := range stmts {
+		switch stmt.(type) {
+		case *sql.CreateTableStmt, *sql.CreateIndexStmt, *sql.AddColumnStmt, *sql.RenameColumnStmt, *sql.CreatePolicyStmt:
+		default:
+			return nil, ErrNotSchemaStmt
+		}
+	}
+
+	return d.SQLExecPrepared(ctx, stmts, nil, true)
+}
+
+func (d *db) SQLExec(ctx context.Context, req *schema.SQLExecRequest) (*schema.SQLExecResult, error) {
 	if req == nil {
 		return nil, ErrIllegalArguments
 	}
@@ -229,14 +669,153 @@ func (d *db) SQLExec(req *schema.SQLExecRequest) (*schema.SQLExecResult, error)
 		}
 	}
 
-	return d.SQLExecPrepared(stmts, req.Params, !req.NoWait)
+	if req.TransactionId != "" || includesSQLTxStmt(stmts) {
+		return d.SQLExecPreparedInTx(ctx, req.TransactionId, stmts, req.Params, !req.NoWait)
+	}
+
+	if len(stmts) == 1 && d.options.sqlMaxRetries > 0 && d.sqlEngine.IsIdempotent(stmts[0]) {
+		return retryOnConflict(ctx, d.options.sqlMaxRetries, d.options.sqlRetryBackoff, func() (*schema.SQLExecResult, error) {
+			return d.SQLExecPrepared(ctx, stmts, req.Params, !req.NoWait)
+		})
+	}
+
+	return d.SQLExecPrepared(ctx, stmts, req.Params, !req.NoWait)
 }
 
-func (d *db) SQLExecPrepared(stmts []sql.SQLStmt, namedParams []*schema.NamedParam, waitForIndexing bool) (*schema.SQLExecResult, error) {
+func includesSQLTxStmt(stmts []sql.SQLStmt) bool {
+	for _, stmt := range stmts {
+		switch stmt.(type) {
+		case *sql.BeginTransactionStmt, *sql.CommitStmt, *sql.RollbackStmt:
+			return true
+		}
+	}
+
+	return false
+}
+
+// isConflict returns whether the error is caused by txs being committed concurrently, thus the write may succeed if retried
+func isConflict(err error) bool {
+	return errors.Is(err, store.ErrMaxConcurrencyLimitExceeded)
+}
+
+// maxSQLRetryBackoff bounds the period waited before retrying a conflicting write
+const maxSQLRetryBackoff = time.Second
+
+// retryOnConflict executes the write until it doesn't fail because of a conflict, up to maxRetries times after the first
+// attempt. A random period is waited before each retry, so conflicting writes don't keep conflicting, up to backoff
+// before the first retry and doubling it afterwards, up to maxSQLRetryBackoff
+func retryOnConflict(ctx context.Context, maxRetries int, backoff time.Duration, exec func() (*schema.SQLExecResult, error)) (*schema.SQLExecResult, error) {
+	for retries := 0; ; retries++ {
+		res, err := exec()
+		if err == nil || retries == maxRetries || !isConflict(err) {
+			return res, err
+		}
+
+		var wait time.Duration
+		if backoff > 0 {
+			wait = time.Duration(rand.Int63n(int64(backoff)))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+
+		backoff *= 2
+
+		if backoff > maxSQLRetryBackoff {
+			backoff = maxSQLRetryBackoff
+		}
+	}
+}
+
+// SQLBatch executes the statements of the batch within a single transaction, each one with its own parameters.
+// Statements are parsed once, even if they are repeated along the batch
+func (d *db) SQLBatch(ctx context.Context, req *schema.SQLBatchRequest) (*schema.SQLBatchResult, error) {
+	if req == nil || len(req.Stmts) == 0 {
+		return nil, ErrIllegalArguments
+	}
+
+	if d.isReplica() {
+		return nil, ErrIsReplica
+	}
+
+	parsed := make(map[string]sql.SQLStmt)
+
+	batch := make([]*sql.BatchStmt, len(req.Stmts))
+
+	for i, s := range req.Stmts {
+		stmt, ok := parsed[s.Sql]
+		if !ok {
+			stmts, err := sql.Parse(strings.NewReader(s.Sql))
+			if err != nil {
+				return nil, err
+			}
+
+			if len(stmts) != 1 {
+				return nil, ErrNotBatchableStmt
+			}
+
+			switch stmts[0].(type) {
+			case *sql.UseDatabaseStmt, *sql.CreateDatabaseStmt, *sql.TxStmt, *sql.SelectStmt:
+				return nil, ErrNotBatchableStmt
+			}
+
+			stmt = stmts[0]
+			parsed[s.Sql] = stmt
+		}
+
+		params := make(map[string]interface{})
+
+		for _, p := range s.Params {
+			params[p.Name] = schema.RawValue(p.Value)
+		}
+
+		batch[i] = &sql.BatchStmt{Stmt: stmt, Params: params}
+	}
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	summary, stmtSummaries, err := d.sqlEngine.ExecBatch(ctx, batch, !req.NoWait)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &schema.SQLBatchResult{
+		Ctxs:    make([]*schema.TxMetadata, len(summary.DDTxs)),
+		Dtxs:    make([]*schema.TxMetadata, len(summary.DMTxs)),
+		Results: make([]*schema.SQLBatchStmtResult, len(stmtSummaries)),
+	}
+
+	for i, md := range summary.DDTxs {
+		res.Ctxs[i] = schema.TxMetatadaTo(md)
+	}
+
+	for i, md := range summary.DMTxs {
+		res.Dtxs[i] = schema.TxMetatadaTo(md)
+	}
+
+	for i, s := range stmtSummaries {
+		res.Results[i] = &schema.SQLBatchStmtResult{
+			UpdatedRows:     uint64(s.UpdatedRows),
+			LastInsertedPKs: s.LastInsertedPKs,
+		}
+	}
+
+	return res, nil
+}
+
+func (d *db) SQLExecPrepared(ctx context.Context, stmts []sql.SQLStmt, namedParams []*schema.NamedParam, waitForIndexing bool) (*schema.SQLExecResult, error) {
 	if len(stmts) == 0 {
 		return nil, ErrIllegalArguments
 	}
 
+	if d.isReplica() {
+		return nil, ErrIsReplica
+	}
+
 	d.mutex.RLock()
 	defer d.mutex.RUnlock()
 
@@ -246,24 +825,39 @@ func (d *db) SQLExecPrepared(stmts []sql.SQLStmt, namedParams []*schema.NamedPar
 		params[p.Name] = schema.RawValue(p.Value)
 	}
 
-	ddTxs, dmTxs, err := d.sqlEngine.ExecPreparedStmts(stmts, params, waitForIndexing)
+	summary, err := d.sqlEngine.ExecPreparedStmtsWithSummary(ctx, stmts, params, waitForIndexing)
 	if err != nil {
 		return nil, err
 	}
 
+	return sqlExecResultFrom(summary)
+}
+
+// sqlExecResultFrom returns the result of the execution of the statements, including all the rows they returned
+func sqlExecResultFrom(summary *sql.ExecSummary) (*schema.SQLExecResult, error) {
 	res := &schema.SQLExecResult{
-		Ctxs: make([]*schema.TxMetadata, len(ddTxs)),
-		Dtxs: make([]*schema.TxMetadata, len(dmTxs)),
+		Ctxs:            make([]*schema.TxMetadata, len(summary.DDTxs)),
+		Dtxs:            make([]*schema.TxMetadata, len(summary.DMTxs)),
+		LastInsertedPKs: summary.LastInsertedPKs,
 	}
 
-	for i, md := range ddTxs {
+	for i, md := range summary.DDTxs {
 		res.Ctxs[i] = schema.TxMetatadaTo(md)
 	}
 
-	for i, md := range dmTxs {
+	for i, md := range summary.DMTxs {
 		res.Dtxs[i] = schema.TxMetatadaTo(md)
 	}
 
+	for _, r := range summary.ReturnedRows {
+		rows, err := sqlQueryResultFrom(r, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		res.ReturnedRows = append(res.ReturnedRows, rows)
+	}
+
 	return res, nil
 }
 
@@ -272,10 +866,15 @@ func (d *db) UseSnapshot(req *schema.UseSnapshotRequest) error {
 		return ErrIllegalArguments
 	}
 
+	err := d.sqlEngine.SetIsolationLevel(sql.IsolationLevel(req.IsolationLevel))
+	if err != nil {
+		return err
+	}
+
 	return d.sqlEngine.UseSnapshot(req.SinceTx, req.AsBeforeTx)
 }
 
-func (d *db) SQLQuery(req *schema.SQLQueryRequest) (*schema.SQLQueryResult, error) {
+func (d *db) SQLQuery(ctx context.Context, req *schema.SQLQueryRequest) (*schema.SQLQueryResult, error) {
 	if req == nil {
 		return nil, ErrIllegalArguments
 	}
@@ -290,10 +889,10 @@ func (d *db) SQLQuery(req *schema.SQLQueryRequest) (*schema.SQLQueryResult, erro
 		return nil, ErrIllegalArguments
 	}
 
-	return d.SQLQueryPrepared(stmt, req.Params, !req.ReuseSnapshot)
+	return d.SQLQueryPrepared(ctx, stmt, req.Params, !req.ReuseSnapshot)
 }
 
-func (d *db) SQLQueryPrepared(stmt *sql.SelectStmt, namedParams []*schema.NamedParam, renewSnapshot bool) (*schema.SQLQueryResult, error) {
+func (d *db) SQLQueryPrepared(ctx context.Context, stmt *sql.SelectStmt, namedParams []*schema.NamedParam, renewSnapshot bool) (*schema.SQLQueryResult, error) {
 	if stmt == nil {
 		return nil, ErrIllegalArguments
 	}
@@ -311,26 +910,121 @@ func (d *db) SQLQueryPrepared(stmt *sql.SelectStmt, namedParams []*schema.NamedP
 		params[p.Name] = schema.RawValue(p.Value)
 	}
 
-	r, err := d.sqlEngine.QueryPreparedStmt(stmt, params, renewSnapshot)
+	r, err := d.sqlEngine.QueryPreparedStmt(ctx, stmt, params, renewSnapshot)
 	if err != nil {
 		return nil, err
 	}
 	defer r.Close()
 
-	colDescriptors, err := r.Columns()
+	return sqlQueryResultFrom(r, MaxKeyScanLimit)
+}
+
+// SQLQueryColumnsPrepared returns the columns of the rows returned by the query, without reading them
+func (d *db) SQLQueryColumnsPrepared(ctx context.Context, stmt *sql.SelectStmt, namedParams []*schema.NamedParam) ([]*schema.Column, error) {
+	if stmt == nil {
+		return nil, ErrIllegalArguments
+	}
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	params := make(map[string]interface{})
+
+	for _, p := range namedParams {
+		params[p.Name] = schema.RawValue(p.Value)
+	}
+
+	r, err := d.sqlEngine.QueryPreparedStmt(ctx, stmt, params, true)
 	if err != nil {
 		return nil, err
 	}
+	defer r.Close()
 
-	cols := make([]*schema.Column, len(colDescriptors))
+	return columnsFrom(r)
+}
 
-	for i, c := range colDescriptors {
-		cols[i] = &schema.Column{Name: c.Selector, Type: c.Type}
+// InferParametersPrepared returns the type of each parameter of the statement
+func (d *db) InferParametersPrepared(stmt sql.SQLStmt) (map[string]sql.SQLValueType, error) {
+	if stmt == nil {
+		return nil, ErrIllegalArguments
+	}
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	return d.sqlEngine.InferParametersPreparedStmts([]sql.SQLStmt{stmt})
+}
+
+// SQLQueryRowsPrepared resolves the query and returns a reader of its rows, which are read one at a time
+// instead of being materialized, thus no row limit applies. The reader keeps a snapshot of the database
+// open, it must be closed as soon as it's no longer needed
+func (d *db) SQLQueryRowsPrepared(ctx context.Context, stmt *sql.SelectStmt, namedParams []*schema.NamedParam, renewSnapshot bool) (SQLRows, error) {
+	if stmt == nil {
+		return nil, ErrIllegalArguments
+	}
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	params := make(map[string]interface{})
+
+	for _, p := range namedParams {
+		params[p.Name] = schema.RawValue(p.Value)
+	}
+
+	r, err := d.sqlEngine.QueryPreparedStmt(ctx, stmt, params, renewSnapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	cols, err := columnsFrom(r)
+	if err != nil {
+		r.Close()
+		return nil, err
+	}
+
+	return &sqlRows{r: r, cols: cols}, nil
+}
+
+// SQLRows reads the rows of a query one at a time, sql.ErrNoMoreRows is returned once all of them were read
+type SQLRows interface {
+	Columns() []*schema.Column
+	Read() (*schema.Row, error)
+	Close() error
+}
+
+type sqlRows struct {
+	r    sql.RowReader
+	cols []*schema.Column
+}
+
+func (rs *sqlRows) Columns() []*schema.Column {
+	return rs.cols
+}
+
+func (rs *sqlRows) Read() (*schema.Row, error) {
+	row, err := rs.r.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	return rowFrom(row, rs.cols), nil
+}
+
+func (rs *sqlRows) Close() error {
+	return rs.r.Close()
+}
+
+// sqlQueryResultFrom returns the rows read by the reader, up to limit rows unless it's zero
+func sqlQueryResultFrom(r sql.RowReader, limit int) (*schema.SQLQueryResult, error) {
+	cols, err := columnsFrom(r)
+	if err != nil {
+		return nil, err
 	}
 
 	res := &schema.SQLQueryResult{Columns: cols}
 
-	for l := 0; l < MaxKeyScanLimit; l++ {
+	for l := 0; limit == 0 || l < limit; l++ {
 		row, err := r.Read()
 		if err == sql.ErrNoMoreRows {
 			break
@@ -339,31 +1033,51 @@ func (d *db) SQLQueryPrepared(stmt *sql.SelectStmt, namedParams []*schema.NamedP
 			return nil, err
 		}
 
-		rrow := &schema.Row{
-			Columns: make([]string, len(res.Columns)),
-			Values:  make([]*schema.SQLValue, len(res.Columns)),
-		}
+		res.Rows = append(res.Rows, rowFrom(row, cols))
+	}
 
-		for i, c := range res.Columns {
-			rrow.Columns[i] = c.Name
+	return res, nil
+}
 
-			v := row.Values[c.Name]
+func columnsFrom(r sql.RowReader) ([]*schema.Column, error) {
+	colDescriptors, err := r.Columns()
+	if err != nil {
+		return nil, err
+	}
 
-			_, isNull := v.(*sql.NullValue)
-			if isNull {
-				rrow.Values[i] = &schema.SQLValue{Value: &schema.SQLValue_Null{}}
-			} else {
-				rrow.Values[i] = typedValueToRowValue(v)
-			}
-		}
+	cols := make([]*schema.Column, len(colDescriptors))
 
-		res.Rows = append(res.Rows, rrow)
+	for i, c := range colDescriptors {
+		cols[i] = &schema.Column{Name: c.Selector, Type: c.Type}
 	}
 
-	return res, nil
+	return cols, nil
+}
+
+func rowFrom(row *sql.Row, cols []*schema.Column) *schema.Row {
+	rrow := &schema.Row{
+		Columns: make([]string, len(cols)),
+		Values:  make([]*schema.SQLValue, len(cols)),
+	}
+
+	for i, c := range cols {
+		rrow.Columns[i] = c.Name
+
+		v := row.Values[c.Name]
+
+		_, isNull := v.(*sql.NullValue)
+		if isNull {
+			rrow.Values[i] = &schema.SQLValue{Value: &schema.SQLValue_Null{}}
+		} else {
+			rrow.Values[i] = TypedValueToRowValue(v)
+		}
+	}
+
+	return rrow
 }
 
-func typedValueToRowValue(tv sql.TypedValue) *schema.SQLValue {
+// TypedValueToRowValue returns the value as it is returned in the rows of a query
+func TypedValueToRowValue(tv sql.TypedValue) *schema.SQLValue {
 	switch tv.Type() {
 	case sql.IntegerType:
 		{
@@ -381,6 +1095,18 @@ func typedValueToRowValue(tv sql.TypedValue) *schema.SQLValue {
 		{
 			return &schema.SQLValue{Value: &schema.SQLValue_Bs{Bs: tv.Value().([]byte)}}
 		}
+	case sql.FloatType:
+		{
+			return &schema.SQLValue{Value: &schema.SQLValue_F{F: tv.Value().(float64)}}
+		}
+	case sql.TimestampType:
+		{
+			return &schema.SQLValue{Value: &schema.SQLValue_Ts{Ts: schema.TimeToInt64(tv.Value().(time.Time))}}
+		}
+	case sql.JSONType:
+		{
+			return &schema.SQLValue{Value: &schema.SQLValue_S{S: tv.Value().(string)}}
+		}
 	}
 	return nil
 }
@@ -16,11 +16,20 @@ limitations under the License.
 package database
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/codenotary/immudb/embedded/sql"
 	"github.com/codenotary/immudb/embedded/store"
 	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/logger"
 	"github.com/stretchr/testify/require"
 )
 
@@ -28,22 +37,22 @@ func TestSQLExecAndQuery(t *testing.T) {
 	db, closer := makeDb()
 	defer closer()
 
-	_, err := db.SQLExecPrepared(nil, nil, false)
+	_, err := db.SQLExecPrepared(context.Background(), nil, nil, false)
 	require.Equal(t, ErrIllegalArguments, err)
 
-	_, err = db.SQLExec(nil)
+	_, err = db.SQLExec(context.Background(), nil)
 	require.Equal(t, ErrIllegalArguments, err)
 
-	_, err = db.SQLExec(&schema.SQLExecRequest{Sql: "invalid sql statement"})
+	_, err = db.SQLExec(context.Background(), &schema.SQLExecRequest{Sql: "invalid sql statement"})
 	require.Error(t, err)
 
-	_, err = db.SQLExec(&schema.SQLExecRequest{Sql: "CREATE DATABASE db1"})
+	_, err = db.SQLExec(context.Background(), &schema.SQLExecRequest{Sql: "CREATE DATABASE db1"})
 	require.Error(t, err)
 
-	_, err = db.SQLExec(&schema.SQLExecRequest{Sql: "USE DATABASE db1"})
+	_, err = db.SQLExec(context.Background(), &schema.SQLExecRequest{Sql: "USE DATABASE db1"})
 	require.Error(t, err)
 
-	md, err := db.SQLExec(&schema.SQLExecRequest{Sql: `
+	md, err := db.SQLExec(context.Background(), &schema.SQLExecRequest{Sql: `
 		CREATE TABLE table1(id INTEGER, title VARCHAR, active BOOLEAN, payload BLOB, PRIMARY KEY id)
 	`})
 	require.NoError(t, err)
@@ -55,13 +64,13 @@ func TestSQLExecAndQuery(t *testing.T) {
 	require.Len(t, res.Rows, 1)
 
 	_, err = db.DescribeTable("table2")
-	require.Equal(t, sql.ErrTableDoesNotExist, err)
+	require.True(t, errors.Is(err, sql.ErrTableDoesNotExist))
 
 	res, err = db.DescribeTable("table1")
 	require.NoError(t, err)
 	require.Len(t, res.Rows, 4)
 
-	md, err = db.SQLExec(&schema.SQLExecRequest{Sql: `
+	md, err = db.SQLExec(context.Background(), &schema.SQLExecRequest{Sql: `
 		INSERT INTO table1(id, title, active, payload) VALUES (1, 'title1', null, null), (2, 'title2', true, null), (3, 'title3', false, x'AADD')
 	`})
 	require.NoError(t, err)
@@ -77,60 +86,770 @@ func TestSQLExecAndQuery(t *testing.T) {
 	err = db.UseSnapshot(&schema.UseSnapshotRequest{SinceTx: 0})
 	require.NoError(t, err)
 
-	_, err = db.SQLQueryPrepared(nil, nil, false)
+	_, err = db.SQLQueryPrepared(context.Background(), nil, nil, false)
 	require.Equal(t, ErrIllegalArguments, err)
 
-	_, err = db.SQLQuery(nil)
+	_, err = db.SQLQuery(context.Background(), nil)
 	require.Equal(t, ErrIllegalArguments, err)
 
-	_, err = db.SQLQuery(&schema.SQLQueryRequest{Sql: "invalid sql statement"})
+	_, err = db.SQLQuery(context.Background(), &schema.SQLQueryRequest{Sql: "invalid sql statement"})
 	require.Error(t, err)
 
-	_, err = db.SQLQuery(&schema.SQLQueryRequest{Sql: "CREATE INDEX ON table1(title)"})
+	_, err = db.SQLQuery(context.Background(), &schema.SQLQueryRequest{Sql: "CREATE INDEX ON table1(title)"})
 	require.Equal(t, ErrIllegalArguments, err)
 
-	res, err = db.SQLQuery(&schema.SQLQueryRequest{Sql: "SELECT t.id, t.id as id2, title, active, payload FROM (table1 as t) WHERE id <= 3 AND active != @active", Params: params})
+	res, err = db.SQLQuery(context.Background(), &schema.SQLQueryRequest{Sql: "SELECT t.id, t.id as id2, title, active, payload FROM (table1 as t) WHERE id <= 3 AND (active IS NULL OR active != @active)", Params: params})
 	require.NoError(t, err)
 	require.Len(t, res.Rows, 2)
 
-	_, err = db.VerifiableSQLGet(nil)
+	_, err = db.VerifiableSQLGet(context.Background(), nil)
 	require.Equal(t, store.ErrIllegalArguments, err)
 
-	_, err = db.VerifiableSQLGet(&schema.VerifiableSQLGetRequest{
+	_, err = db.VerifiableSQLGet(context.Background(), &schema.VerifiableSQLGetRequest{
 		SqlGetRequest: &schema.SQLGetRequest{Table: "table1", PkValue: &schema.SQLValue{Value: &schema.SQLValue_N{N: 1}}},
 		ProveSinceTx:  2,
 	})
 	require.Equal(t, store.ErrIllegalState, err)
 
-	_, err = db.VerifiableSQLGet(&schema.VerifiableSQLGetRequest{
+	_, err = db.VerifiableSQLGet(context.Background(), &schema.VerifiableSQLGetRequest{
 		SqlGetRequest: &schema.SQLGetRequest{Table: "table2", PkValue: &schema.SQLValue{Value: &schema.SQLValue_N{N: 1}}},
 		ProveSinceTx:  0,
 	})
-	require.Equal(t, sql.ErrTableDoesNotExist, err)
+	require.True(t, errors.Is(err, sql.ErrTableDoesNotExist))
 
-	_, err = db.VerifiableSQLGet(&schema.VerifiableSQLGetRequest{
+	_, err = db.VerifiableSQLGet(context.Background(), &schema.VerifiableSQLGetRequest{
 		SqlGetRequest: &schema.SQLGetRequest{Table: "table1", PkValue: &schema.SQLValue{Value: &schema.SQLValue_B{B: true}}},
 		ProveSinceTx:  0,
 	})
 	require.Equal(t, sql.ErrInvalidValue, err)
 
-	_, err = db.VerifiableSQLGet(&schema.VerifiableSQLGetRequest{
+	_, err = db.VerifiableSQLGet(context.Background(), &schema.VerifiableSQLGetRequest{
 		SqlGetRequest: &schema.SQLGetRequest{Table: "table1", PkValue: &schema.SQLValue{Value: &schema.SQLValue_N{N: 4}}},
 		ProveSinceTx:  0,
 	})
 	require.Equal(t, store.ErrKeyNotFound, err)
 
-	ve, err := db.VerifiableSQLGet(&schema.VerifiableSQLGetRequest{
+	ve, err := db.VerifiableSQLGet(context.Background(), &schema.VerifiableSQLGetRequest{
 		SqlGetRequest: &schema.SQLGetRequest{Table: "table1", PkValue: &schema.SQLValue{Value: &schema.SQLValue_N{N: 1}}},
 		ProveSinceTx:  0,
 	})
 	require.NoError(t, err)
 	require.NotNil(t, ve)
 
-	_, err = db.VerifiableSQLGet(&schema.VerifiableSQLGetRequest{
+	_, err = db.VerifiableSQLGet(context.Background(), &schema.VerifiableSQLGetRequest{
 		SqlGetRequest: &schema.SQLGetRequest{Table: "table1", PkValue: &schema.SQLValue{Value: &schema.SQLValue_N{N: 4}}},
 		ProveSinceTx:  0,
 	})
 	require.Equal(t, store.ErrKeyNotFound, err)
 
 }
+
+func TestVerifiableSQLQuery(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	_, err := db.SQLExec(context.Background(), &schema.SQLExecRequest{Sql: `
+		CREATE TABLE table1(country VARCHAR, id INTEGER, title VARCHAR, amount INTEGER, PRIMARY KEY (country, id))
+	`})
+	require.NoError(t, err)
+
+	_, err = db.SQLExec(context.Background(), &schema.SQLExecRequest{Sql: `
+		INSERT INTO table1(country, id, title, amount) VALUES ('es', 1, 'title1', 10), ('it', 2, 'title2', 20), ('es', 3, 'title3', null)
+	`})
+	require.NoError(t, err)
+
+	_, err = db.SQLExec(context.Background(), &schema.SQLExecRequest{Sql: `
+		UPSERT INTO table1(country, id, title, amount) VALUES ('it', 2, 'title2', 25)
+	`})
+	require.NoError(t, err)
+
+	_, err = db.VerifiableSQLQuery(context.Background(), nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = db.VerifiableSQLQuery(context.Background(), &schema.VerifiableSQLQueryRequest{
+		SqlQueryRequest: &schema.SQLQueryRequest{Sql: "SELECT * FROM table1"},
+		ProveSinceTx:    100,
+	})
+	require.Equal(t, ErrIllegalState, err)
+
+	_, err = db.VerifiableSQLQuery(context.Background(), &schema.VerifiableSQLQueryRequest{
+		SqlQueryRequest: &schema.SQLQueryRequest{Sql: "INSERT INTO table1(country, id) VALUES ('fr', 4)"},
+	})
+	require.Equal(t, ErrIllegalArguments, err)
+
+	state, err := db.CurrentState()
+	require.NoError(t, err)
+
+	lastTxID := state.TxId
+
+	params := []*schema.NamedParam{{Name: "amount", Value: &schema.SQLValue{Value: &schema.SQLValue_N{N: 15}}}}
+
+	res, err := db.VerifiableSQLQuery(context.Background(), &schema.VerifiableSQLQueryRequest{
+		SqlQueryRequest: &schema.SQLQueryRequest{Sql: "SELECT id, title, amount, country FROM table1 WHERE amount IS NULL OR amount > @amount ORDER BY id", Params: params},
+		ProveSinceTx:    1,
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Result.Rows, 2)
+	require.Len(t, res.Rows, 2)
+	require.Equal(t, "table1", res.TableName)
+	require.Equal(t, []string{"country", "id"}, res.PKNames)
+	require.Equal(t, lastTxID, res.VerifiableTx.Tx.Metadata.Id)
+
+	queryTxID := res.VerifiableTx.Tx.Metadata.Id
+	queryAlh := schema.TxMetadataFrom(res.VerifiableTx.Tx.Metadata).Alh()
+
+	require.True(t, store.VerifyDualProof(
+		schema.DualProofFrom(res.VerifiableTx.DualProof),
+		1,
+		queryTxID,
+		schema.DualProofFrom(res.VerifiableTx.DualProof).SourceTxMetadata.Alh(),
+		queryAlh,
+	))
+
+	// the updated row is proven as written by the last tx
+	require.Equal(t, uint64(2), res.Result.Rows[0].Values[0].GetN())
+	require.Equal(t, uint64(25), res.Result.Rows[0].Values[2].GetN())
+	require.Equal(t, lastTxID, res.Rows[0].SqlEntry.Tx)
+	require.Less(t, res.Rows[1].SqlEntry.Tx, lastTxID)
+
+	for _, vRow := range res.Rows {
+		dualProof := schema.DualProofFrom(vRow.DualProof)
+
+		require.True(t, store.VerifyInclusion(
+			schema.InclusionProofFrom(vRow.InclusionProof),
+			&store.KV{Key: vRow.SqlEntry.Key, Value: vRow.SqlEntry.Value},
+			dualProof.SourceTxMetadata.Eh,
+		))
+
+		require.True(t, store.VerifyDualProof(
+			dualProof,
+			vRow.SqlEntry.Tx,
+			queryTxID,
+			dualProof.SourceTxMetadata.Alh(),
+			queryAlh,
+		))
+	}
+
+	res, err = db.VerifiableSQLQuery(context.Background(), &schema.VerifiableSQLQueryRequest{
+		SqlQueryRequest: &schema.SQLQueryRequest{Sql: "SELECT * FROM table1 WHERE amount > 100"},
+	})
+	require.NoError(t, err)
+	require.Empty(t, res.Result.Rows)
+	require.Empty(t, res.Rows)
+
+	for _, q := range []string{
+		"SELECT id, title FROM table1",
+		"SELECT COUNT() FROM table1",
+		"SELECT country, id, title AS amount FROM table1",
+		"SELECT country, id, amount FROM table1 BEFORE TX " + strconv.FormatUint(lastTxID, 10),
+	} {
+		_, err = db.VerifiableSQLQuery(context.Background(), &schema.VerifiableSQLQueryRequest{
+			SqlQueryRequest: &schema.SQLQueryRequest{Sql: q},
+		})
+		require.True(t, errors.Is(err, ErrNotVerifiableQuery), q)
+	}
+}
+
+func TestSQLExecWithAutoIncrementPK(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	_, err := db.SQLExec(context.Background(), &schema.SQLExecRequest{Sql: `
+		CREATE TABLE table1(id INTEGER AUTO_INCREMENT, title VARCHAR, PRIMARY KEY id)
+	`})
+	require.NoError(t, err)
+
+	res, err := db.SQLExec(context.Background(), &schema.SQLExecRequest{Sql: `
+		INSERT INTO table1(title) VALUES ('title1'), ('title2')
+	`})
+	require.NoError(t, err)
+	require.Len(t, res.Dtxs, 1)
+	require.Equal(t, map[string]uint64{"table1": 2}, res.LastInsertedPKs)
+	require.Empty(t, res.ReturnedRows)
+
+	res, err = db.SQLExec(context.Background(), &schema.SQLExecRequest{Sql: `
+		INSERT INTO table1(title) VALUES ('title3') RETURNING id, title
+	`})
+	require.NoError(t, err)
+	require.Len(t, res.ReturnedRows, 1)
+	require.Equal(t, []*schema.Column{{Name: "(db.table1.id)", Type: sql.IntegerType}, {Name: "(db.table1.title)", Type: sql.VarcharType}}, res.ReturnedRows[0].Columns)
+	require.Len(t, res.ReturnedRows[0].Rows, 1)
+	require.Equal(t, uint64(3), res.ReturnedRows[0].Rows[0].Values[0].GetN())
+	require.Equal(t, "title3", res.ReturnedRows[0].Rows[0].Values[1].GetS())
+}
+
+func TestSQLExecWithUniqueConstraint(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	_, err := db.SQLExec(context.Background(), &schema.SQLExecRequest{Sql: `
+		CREATE TABLE table1(id INTEGER, email VARCHAR UNIQUE, PRIMARY KEY id);
+		INSERT INTO table1(id, email) VALUES (1, 'a@immudb.io')
+	`})
+	require.NoError(t, err)
+
+	_, err = db.SQLExec(context.Background(), &schema.SQLExecRequest{Sql: `
+		INSERT INTO table1(id, email) VALUES (2, 'a@immudb.io')
+	`})
+	require.True(t, errors.Is(err, sql.ErrUniqueConstraintViolation))
+
+	res, err := db.DescribeTable("table1")
+	require.NoError(t, err)
+	require.Equal(t, "UNIQUE", res.Rows[1].Values[3].GetS())
+}
+
+func TestSQLBatch(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	_, err := db.SQLBatch(context.Background(), &schema.SQLBatchRequest{})
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = db.SQLExec(context.Background(), &schema.SQLExecRequest{Sql: `
+		CREATE TABLE table1(id INTEGER AUTO_INCREMENT, title VARCHAR, PRIMARY KEY id)
+	`})
+	require.NoError(t, err)
+
+	insert := func(title string) *schema.SQLBatchStmt {
+		return &schema.SQLBatchStmt{
+			Sql:    "INSERT INTO table1(title) VALUES (@title)",
+			Params: []*schema.NamedParam{{Name: "title", Value: &schema.SQLValue{Value: &schema.SQLValue_S{S: title}}}},
+		}
+	}
+
+	res, err := db.SQLBatch(context.Background(), &schema.SQLBatchRequest{Stmts: []*schema.SQLBatchStmt{
+		insert("title1"),
+		insert("title2"),
+		{Sql: "UPDATE table1 SET title = 'title' WHERE id < 10"},
+	}})
+	require.NoError(t, err)
+	require.Len(t, res.Dtxs, 1)
+	require.Len(t, res.Results, 3)
+	require.Equal(t, map[string]uint64{"table1": 2}, res.Results[1].LastInsertedPKs)
+
+	// statements don't read the rows written by the ones preceding them in the batch
+	require.Equal(t, uint64(0), res.Results[2].UpdatedRows)
+
+	_, err = db.SQLBatch(context.Background(), &schema.SQLBatchRequest{Stmts: []*schema.SQLBatchStmt{
+		insert("title3"),
+		{Sql: "SELECT id FROM table1"},
+	}})
+	require.Equal(t, ErrNotBatchableStmt, err)
+
+	_, err = db.SQLBatch(context.Background(), &schema.SQLBatchRequest{Stmts: []*schema.SQLBatchStmt{
+		{Sql: "INSERT INTO table1(title) VALUES ('title3'); INSERT INTO table1(title) VALUES ('title4')"},
+	}})
+	require.Equal(t, ErrNotBatchableStmt, err)
+
+	_, err = db.SQLBatch(context.Background(), &schema.SQLBatchRequest{Stmts: []*schema.SQLBatchStmt{
+		insert("title3"),
+		{Sql: "INSERT INTO table2(title) VALUES ('title4')"},
+	}})
+	require.True(t, errors.Is(err, sql.ErrTableDoesNotExist))
+
+	res1, err := db.SQLQuery(context.Background(), &schema.SQLQueryRequest{Sql: "SELECT id, title FROM table1"})
+	require.NoError(t, err)
+	require.Len(t, res1.Rows, 2)
+}
+
+func TestSQLExecInTx(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	_, err := db.SQLExec(context.Background(), &schema.SQLExecRequest{Sql: `
+		CREATE TABLE table1(id INTEGER AUTO_INCREMENT, title VARCHAR, PRIMARY KEY id)
+	`})
+	require.NoError(t, err)
+
+	countRows := func() int {
+		res, err := db.SQLQuery(context.Background(), &schema.SQLQueryRequest{Sql: "SELECT id FROM table1"})
+		require.NoError(t, err)
+		return len(res.Rows)
+	}
+
+	res, err := db.SQLExec(context.Background(), &schema.SQLExecRequest{Sql: "BEGIN"})
+	require.NoError(t, err)
+	require.NotEmpty(t, res.TransactionId)
+
+	txID := res.TransactionId
+
+	_, err = db.SQLExec(context.Background(), &schema.SQLExecRequest{Sql: "BEGIN", TransactionId: txID})
+	require.Equal(t, ErrSQLTxAlreadyInProgress, err)
+
+	res, err = db.SQLExec(context.Background(), &schema.SQLExecRequest{
+		Sql:           "INSERT INTO table1(title) VALUES (@title)",
+		Params:        []*schema.NamedParam{{Name: "title", Value: &schema.SQLValue{Value: &schema.SQLValue_S{S: "title1"}}}},
+		TransactionId: txID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, txID, res.TransactionId)
+	require.Empty(t, res.Dtxs)
+
+	_, err = db.SQLExec(context.Background(), &schema.SQLExecRequest{Sql: "INSERT INTO table1(title) VALUES ('title2')", TransactionId: txID})
+	require.NoError(t, err)
+
+	_, err = db.SQLExec(context.Background(), &schema.SQLExecRequest{Sql: "SELECT id FROM table1", TransactionId: txID})
+	require.Equal(t, ErrNotDeferrableStmt, err)
+
+	// statements are deferred until the transaction is committed
+	require.Equal(t, 0, countRows())
+
+	res, err = db.SQLExec(context.Background(), &schema.SQLExecRequest{Sql: "COMMIT", TransactionId: txID})
+	require.NoError(t, err)
+	require.Empty(t, res.TransactionId)
+	require.Len(t, res.Dtxs, 1)
+	require.Equal(t, map[string]uint64{"table1": 2}, res.LastInsertedPKs)
+	require.Equal(t, 2, countRows())
+
+	_, err = db.SQLExec(context.Background(), &schema.SQLExecRequest{Sql: "COMMIT", TransactionId: txID})
+	require.Equal(t, ErrSQLTxNotFound, err)
+
+	_, err = db.SQLExec(context.Background(), &schema.SQLExecRequest{Sql: "ROLLBACK"})
+	require.Equal(t, ErrNoSQLTxInProgress, err)
+
+	res, err = db.SQLExec(context.Background(), &schema.SQLExecRequest{Sql: "BEGIN; INSERT INTO table1(title) VALUES ('title3')"})
+	require.NoError(t, err)
+	require.NotEmpty(t, res.TransactionId)
+
+	res, err = db.SQLExec(context.Background(), &schema.SQLExecRequest{Sql: "ROLLBACK", TransactionId: res.TransactionId})
+	require.NoError(t, err)
+	require.Empty(t, res.TransactionId)
+	require.Equal(t, 2, countRows())
+
+	res, err = db.SQLExec(context.Background(), &schema.SQLExecRequest{Sql: `
+		INSERT INTO table1(title) VALUES ('title3');
+		BEGIN TRANSACTION;
+		INSERT INTO table1(title) VALUES ('title4');
+		INSERT INTO table1(title) VALUES ('title5');
+		COMMIT TRANSACTION;
+	`})
+	require.NoError(t, err)
+	require.Empty(t, res.TransactionId)
+	require.Len(t, res.Dtxs, 2)
+	require.Equal(t, 5, countRows())
+
+	_, err = db.SQLExec(context.Background(), &schema.SQLExecRequest{Sql: "INSERT INTO table1(title) VALUES ('title6')", TransactionId: "unknown"})
+	require.Equal(t, ErrSQLTxNotFound, err)
+}
+
+func TestRetryOnConflict(t *testing.T) {
+	attempts := 0
+
+	conflicting := func(conflicts int) func() (*schema.SQLExecResult, error) {
+		attempts = 0
+
+		return func() (*schema.SQLExecResult, error) {
+			attempts++
+
+			if attempts <= conflicts {
+				return nil, store.ErrMaxConcurrencyLimitExceeded
+			}
+			return &schema.SQLExecResult{}, nil
+		}
+	}
+
+	_, err := retryOnConflict(context.Background(), 3, time.Millisecond, conflicting(3))
+	require.NoError(t, err)
+	require.Equal(t, 4, attempts)
+
+	_, err = retryOnConflict(context.Background(), 3, time.Millisecond, conflicting(4))
+	require.True(t, errors.Is(err, store.ErrMaxConcurrencyLimitExceeded))
+	require.Equal(t, 4, attempts)
+
+	_, err = retryOnConflict(context.Background(), 3, 0, func() (*schema.SQLExecResult, error) {
+		attempts++
+		return nil, sql.ErrTableDoesNotExist
+	})
+	require.Equal(t, sql.ErrTableDoesNotExist, err)
+	require.Equal(t, 5, attempts)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = retryOnConflict(ctx, 3, time.Hour, conflicting(1))
+	require.Equal(t, context.Canceled, err)
+	require.Equal(t, 1, attempts)
+}
+
+func TestSQLExecWithRetries(t *testing.T) {
+	rootPath := "data_sql_retries"
+	defer os.RemoveAll(rootPath)
+
+	options := DefaultOption().
+		WithDbRootPath(rootPath).
+		WithDbName("db").
+		WithCorruptionChecker(false).
+		WithSQLRetries(100, time.Millisecond)
+
+	// a single tx can be committed at a time, concurrent commits fail
+	options.storeOpts.WithMaxConcurrency(1)
+
+	db, err := NewDb(options, nil, logger.NewSimpleLogger("immudb ", os.Stderr))
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.SQLExec(context.Background(), &schema.SQLExecRequest{Sql: `
+		CREATE TABLE table1(id INTEGER, title VARCHAR, PRIMARY KEY id)
+	`})
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 200)
+
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			for j := 0; j < 10; j++ {
+				_, err := db.SQLExec(context.Background(), &schema.SQLExecRequest{
+					Sql:    fmt.Sprintf("UPSERT INTO table1(id, title) VALUES (%d, 'title%d')", i*10+j, j),
+					NoWait: true,
+				})
+				errs <- err
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	// rows are read once every tx gets indexed
+	_, err = db.SQLExec(context.Background(), &schema.SQLExecRequest{Sql: "UPSERT INTO table1(id, title) VALUES (0, 'title0')"})
+	require.NoError(t, err)
+
+	res, err := db.SQLQuery(context.Background(), &schema.SQLQueryRequest{Sql: "SELECT id FROM table1"})
+	require.NoError(t, err)
+	require.Len(t, res.Rows, 200)
+}
+
+func TestSQLSchemaExportAndImport(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	_, err := db.ImportSQLSchema(context.Background(), nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	res, err := db.ExportSQLSchema()
+	require.NoError(t, err)
+	require.Empty(t, res.Ddl)
+
+	_, err = db.SQLExec(context.Background(), &schema.SQLExecRequest{Sql: `
+		CREATE TABLE table1(id INTEGER, title VARCHAR, active BOOLEAN, PRIMARY KEY id);
+		CREATE INDEX ON table1(title);
+		INSERT INTO table1(id, title, active) VALUES (1, 'title1', true)
+	`})
+	require.NoError(t, err)
+
+	res, err = db.ExportSQLSchema()
+	require.NoError(t, err)
+	require.Equal(t, "CREATE TABLE table1 (id INTEGER NOT NULL, title VARCHAR, active BOOLEAN, PRIMARY KEY id);\n"+
+		"CREATE INDEX ON table1(title);\n", res.Ddl)
+
+	db2, closer2 := makeDb()
+	defer closer2()
+
+	md, err := db2.ImportSQLSchema(context.Background(), &schema.SQLSchema{Ddl: "   "})
+	require.NoError(t, err)
+	require.Empty(t, md.Ctxs)
+
+	_, err = db2.ImportSQLSchema(context.Background(), &schema.SQLSchema{Ddl: "invalid sql statement"})
+	require.Error(t, err)
+
+	_, err = db2.ImportSQLSchema(context.Background(), &schema.SQLSchema{Ddl: res.Ddl + "INSERT INTO table1(id) VALUES (1)"})
+	require.Equal(t, ErrNotSchemaStmt, err)
+
+	md, err = db2.ImportSQLSchema(context.Background(), res)
+	require.NoError(t, err)
+	require.Len(t, md.Ctxs, 2)
+	require.Empty(t, md.Dtxs)
+
+	res2, err := db2.ExportSQLSchema()
+	require.NoError(t, err)
+	require.Equal(t, res.Ddl, res2.Ddl)
+
+	rows, err := db2.SQLQuery(context.Background(), &schema.SQLQueryRequest{Sql: "SELECT id FROM table1"})
+	require.NoError(t, err)
+	require.Empty(t, rows.Rows)
+
+	_, err = db2.ImportSQLSchema(context.Background(), res)
+	require.True(t, errors.Is(err, sql.ErrTableAlreadyExists))
+}
+
+func TestSQLPolicies(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	_, err := db.SQLExec(context.Background(), &schema.SQLExecRequest{Sql: `
+		CREATE TABLE orders(id INTEGER, tenant VARCHAR, PRIMARY KEY id);
+		CREATE POLICY acme_rows ON orders TO alice USING (tenant = 'acme');
+		INSERT INTO orders(id, tenant) VALUES (1, 'acme'), (2, 'globex')
+	`})
+	require.NoError(t, err)
+
+	alice := sql.WithUser(context.Background(), "alice", "read")
+
+	res, err := db.SQLQuery(alice, &schema.SQLQueryRequest{Sql: "SELECT id FROM orders"})
+	require.NoError(t, err)
+	require.Len(t, res.Rows, 1)
+	require.Equal(t, uint64(1), res.Rows[0].Values[0].GetN())
+
+	_, err = db.SQLExec(alice, &schema.SQLExecRequest{Sql: "INSERT INTO orders(id, tenant) VALUES (3, 'globex')"})
+	require.Equal(t, sql.ErrPolicyViolation, err)
+
+	ve, err := db.VerifiableSQLGet(alice, &schema.VerifiableSQLGetRequest{
+		SqlGetRequest: &schema.SQLGetRequest{Table: "orders", PkValue: &schema.SQLValue{Value: &schema.SQLValue_N{N: 1}}},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, ve)
+
+	// rows not satisfying the policies are reported as missing
+	_, err = db.VerifiableSQLGet(alice, &schema.VerifiableSQLGetRequest{
+		SqlGetRequest: &schema.SQLGetRequest{Table: "orders", PkValue: &schema.SQLValue{Value: &schema.SQLValue_N{N: 2}}},
+	})
+	require.Equal(t, store.ErrKeyNotFound, err)
+
+	_, err = db.VerifiableSQLGet(context.Background(), &schema.VerifiableSQLGetRequest{
+		SqlGetRequest: &schema.SQLGetRequest{Table: "orders", PkValue: &schema.SQLValue{Value: &schema.SQLValue_N{N: 2}}},
+	})
+	require.NoError(t, err)
+
+	ddl, err := db.ExportSQLSchema()
+	require.NoError(t, err)
+	require.Equal(t, "CREATE TABLE orders (id INTEGER NOT NULL, tenant VARCHAR, PRIMARY KEY id);\n"+
+		"CREATE POLICY acme_rows ON orders TO alice USING ((tenant = 'acme'));\n", ddl.Ddl)
+
+	db2, closer2 := makeDb()
+	defer closer2()
+
+	_, err = db2.ImportSQLSchema(context.Background(), ddl)
+	require.NoError(t, err)
+
+	_, err = db2.ImportSQLSchema(alice, &schema.SQLSchema{Ddl: "CREATE POLICY all_rows ON orders USING (TRUE)"})
+	require.Equal(t, sql.ErrPolicyChangeNotAllowed, err)
+
+	ddl2, err := db2.ExportSQLSchema()
+	require.NoError(t, err)
+	require.Equal(t, ddl.Ddl, ddl2.Ddl)
+}
+
+func TestSQLQueryWithIsolationLevel(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	_, err := db.SQLExec(context.Background(), &schema.SQLExecRequest{Sql: "CREATE TABLE table1 (id INTEGER, PRIMARY KEY id)"})
+	require.NoError(t, err)
+
+	err = db.UseSnapshot(&schema.UseSnapshotRequest{IsolationLevel: schema.IsolationLevel(99)})
+	require.Equal(t, ErrIllegalArguments, err)
+
+	err = db.UseSnapshot(&schema.UseSnapshotRequest{IsolationLevel: schema.IsolationLevel_READ_COMMITTED})
+	require.NoError(t, err)
+
+	for i := 1; i <= 3; i++ {
+		_, err = db.SQLExec(context.Background(), &schema.SQLExecRequest{Sql: fmt.Sprintf("INSERT INTO table1 (id) VALUES (%d)", i), NoWait: true})
+		require.NoError(t, err)
+
+		// every committed tx is visible even if the snapshot in use is requested to be reused
+		res, err := db.SQLQuery(context.Background(), &schema.SQLQueryRequest{Sql: "SELECT id FROM table1", ReuseSnapshot: true})
+		require.NoError(t, err)
+		require.Len(t, res.Rows, i)
+	}
+
+	err = db.UseSnapshot(&schema.UseSnapshotRequest{IsolationLevel: schema.IsolationLevel_SNAPSHOT})
+	require.NoError(t, err)
+
+	_, err = db.SQLExec(context.Background(), &schema.SQLExecRequest{Sql: "INSERT INTO table1 (id) VALUES (4)"})
+	require.NoError(t, err)
+
+	res, err := db.SQLQuery(context.Background(), &schema.SQLQueryRequest{Sql: "SELECT id FROM table1", ReuseSnapshot: true})
+	require.NoError(t, err)
+	require.Len(t, res.Rows, 3)
+
+	res, err = db.SQLQuery(context.Background(), &schema.SQLQueryRequest{Sql: "SELECT id FROM table1"})
+	require.NoError(t, err)
+	require.Len(t, res.Rows, 4)
+}
+
+func TestSQLTemporalQuery(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	res, err := db.SQLExec(context.Background(), &schema.SQLExecRequest{Sql: `
+		CREATE TABLE table1 (id INTEGER, amount INTEGER, PRIMARY KEY id);
+		CREATE INDEX ON table1(amount);
+		INSERT INTO table1 (id, amount) VALUES (1, 10), (2, 20)
+	`})
+	require.NoError(t, err)
+	require.Len(t, res.Dtxs, 1)
+
+	res, err = db.SQLExec(context.Background(), &schema.SQLExecRequest{Sql: "UPDATE table1 SET amount = 200 WHERE id = 1"})
+	require.NoError(t, err)
+	require.Len(t, res.Dtxs, 1)
+
+	updateTx := res.Dtxs[0].Id
+
+	qres, err := db.SQLQuery(context.Background(), &schema.SQLQueryRequest{Sql: fmt.Sprintf("SELECT id, amount FROM table1 BEFORE TX %d ORDER BY amount", updateTx)})
+	require.NoError(t, err)
+	require.Len(t, qres.Rows, 2)
+	require.Equal(t, uint64(10), qres.Rows[0].Values[1].GetN())
+	require.Equal(t, uint64(20), qres.Rows[1].Values[1].GetN())
+
+	qres, err = db.SQLQuery(context.Background(), &schema.SQLQueryRequest{Sql: fmt.Sprintf("SELECT id, amount FROM table1 SINCE TX %d", updateTx)})
+	require.NoError(t, err)
+	require.Len(t, qres.Rows, 1)
+	require.Equal(t, uint64(1), qres.Rows[0].Values[0].GetN())
+	require.Equal(t, uint64(200), qres.Rows[0].Values[1].GetN())
+}
+
+func TestSQLQueryColumnsAndParametersPrepared(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	_, err := db.SQLQueryColumnsPrepared(context.Background(), nil, nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = db.InferParametersPrepared(nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = db.SQLExec(context.Background(), &schema.SQLExecRequest{Sql: `
+		CREATE TABLE table1(id INTEGER, title VARCHAR, active BOOLEAN, PRIMARY KEY id)
+	`})
+	require.NoError(t, err)
+
+	stmts, err := sql.Parse(strings.NewReader("SELECT id, title FROM table1 WHERE active = $1 AND id > $2"))
+	require.NoError(t, err)
+
+	types, err := db.InferParametersPrepared(stmts[0])
+	require.NoError(t, err)
+	require.Equal(t, map[string]sql.SQLValueType{"param1": sql.BooleanType, "param2": sql.IntegerType}, types)
+
+	params := []*schema.NamedParam{
+		{Name: "param1", Value: &schema.SQLValue{Value: &schema.SQLValue_B{B: true}}},
+		{Name: "param2", Value: &schema.SQLValue{Value: &schema.SQLValue_N{N: 0}}},
+	}
+
+	cols, err := db.SQLQueryColumnsPrepared(context.Background(), stmts[0].(*sql.SelectStmt), params)
+	require.NoError(t, err)
+	require.Equal(t, []*schema.Column{
+		{Name: "(db.table1.id)", Type: sql.IntegerType},
+		{Name: "(db.table1.title)", Type: sql.VarcharType},
+	}, cols)
+}
+
+func TestSQLQueryRowsPrepared(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	_, err := db.SQLQueryRowsPrepared(context.Background(), nil, nil, true)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = db.SQLExec(context.Background(), &schema.SQLExecRequest{Sql: `
+		CREATE TABLE table1(id INTEGER, title VARCHAR, PRIMARY KEY id)
+	`})
+	require.NoError(t, err)
+
+	// rows are not limited by MaxKeyScanLimit as they're not materialized
+	rowCount := MaxKeyScanLimit + 10
+
+	for i := 0; i < rowCount; i += 100 {
+		var sb strings.Builder
+		sb.WriteString("INSERT INTO table1(id, title) VALUES ")
+		for j := i; j < i+100 && j < rowCount; j++ {
+			if j > i {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(fmt.Sprintf("(%d, 'title%d')", j, j))
+		}
+
+		_, err = db.SQLExec(context.Background(), &schema.SQLExecRequest{Sql: sb.String()})
+		require.NoError(t, err)
+	}
+
+	stmts, err := sql.Parse(strings.NewReader("SELECT id, title FROM table1 WHERE id >= @id"))
+	require.NoError(t, err)
+
+	params := []*schema.NamedParam{{Name: "id", Value: &schema.SQLValue{Value: &schema.SQLValue_N{N: 0}}}}
+
+	rows, err := db.SQLQueryRowsPrepared(context.Background(), stmts[0].(*sql.SelectStmt), params, true)
+	require.NoError(t, err)
+	require.Equal(t, []*schema.Column{
+		{Name: "(db.table1.id)", Type: sql.IntegerType},
+		{Name: "(db.table1.title)", Type: sql.VarcharType},
+	}, rows.Columns())
+
+	for i := 0; i < rowCount; i++ {
+		row, err := rows.Read()
+		require.NoError(t, err)
+		require.Equal(t, uint64(i), row.Values[0].GetN())
+		require.Equal(t, fmt.Sprintf("title%d", i), row.Values[1].GetS())
+	}
+
+	_, err = rows.Read()
+	require.Equal(t, sql.ErrNoMoreRows, err)
+
+	require.NoError(t, rows.Close())
+}
+
+func TestSQLTables(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	tables, err := db.SQLTables()
+	require.NoError(t, err)
+	require.Empty(t, tables)
+
+	_, err = db.SQLExec(context.Background(), &schema.SQLExecRequest{Sql: `
+		CREATE TABLE table2(id INTEGER AUTO_INCREMENT, title VARCHAR NOT NULL, PRIMARY KEY id);
+		CREATE TABLE table1(name VARCHAR, amount INTEGER, active BOOLEAN, PRIMARY KEY (name, amount));
+	`})
+	require.NoError(t, err)
+
+	tables, err = db.SQLTables()
+	require.NoError(t, err)
+	require.Len(t, tables, 2)
+
+	require.Equal(t, "table1", tables[0].Name)
+	require.Equal(t, []string{"name", "amount"}, tables[0].PrimaryKey)
+	require.Equal(t, []*SQLColumnInfo{
+		{Name: "name", Type: sql.VarcharType, Nullable: false},
+		{Name: "amount", Type: sql.IntegerType, Nullable: false},
+		{Name: "active", Type: sql.BooleanType, Nullable: true},
+	}, tables[0].Columns)
+
+	require.Equal(t, "table2", tables[1].Name)
+	require.Equal(t, []string{"id"}, tables[1].PrimaryKey)
+	require.Equal(t, []*SQLColumnInfo{
+		{Name: "id", Type: sql.IntegerType, Nullable: false, AutoIncrement: true},
+		{Name: "title", Type: sql.VarcharType, Nullable: false},
+	}, tables[1].Columns)
+
+	require.NotEqual(t, tables[0].ID, tables[1].ID)
+}
+
+func TestHasSQLPolicies(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	require.False(t, db.HasSQLPolicies())
+
+	_, err := db.SQLExec(context.Background(), &schema.SQLExecRequest{Sql: `
+		CREATE TABLE table1(id INTEGER, PRIMARY KEY id);
+		CREATE TABLE table2(id INTEGER, PRIMARY KEY id);
+	`})
+	require.NoError(t, err)
+	require.False(t, db.HasSQLPolicies())
+
+	_, err = db.SQLExec(context.Background(), &schema.SQLExecRequest{Sql: "CREATE POLICY first_rows ON table2 USING (id < 3)"})
+	require.NoError(t, err)
+	require.True(t, db.HasSQLPolicies())
+
+	_, err = db.SQLExec(context.Background(), &schema.SQLExecRequest{Sql: "DROP POLICY first_rows ON table2"})
+	require.NoError(t, err)
+	require.False(t, db.HasSQLPolicies())
+}
@@ -0,0 +1,255 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package database
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/codenotary/immudb/embedded/sql"
+	"github.com/codenotary/immudb/pkg/api/schema"
+)
+
+var ErrSQLTxAlreadyInProgress = errors.New("a transaction is already in progress")
+var ErrNoSQLTxInProgress = errors.New("no transaction in progress")
+var ErrSQLTxNotFound = errors.New("transaction not found, it may have been committed, rolled back or expired")
+var ErrNotDeferrableStmt = errors.New("only statements changing data or tables can be executed within a transaction")
+
+// sqlTxIdleTimeout is the period after which transactions not receiving statements are discarded
+const sqlTxIdleTimeout = 10 * time.Minute
+
+// sqlTx holds the statements of a transaction begun with BEGIN, they are committed together on COMMIT
+type sqlTx struct {
+	stmts    []*sql.BatchStmt
+	lastUsed time.Time
+}
+
+type sqlTxs struct {
+	txs   map[string]*sqlTx
+	mutex sync.Mutex
+}
+
+func (t *sqlTxs) begin() (string, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.txs == nil {
+		t.txs = make(map[string]*sqlTx)
+	}
+
+	now := time.Now()
+
+	for id, tx := range t.txs {
+		if now.Sub(tx.lastUsed) > sqlTxIdleTimeout {
+			delete(t.txs, id)
+		}
+	}
+
+	var b [16]byte
+
+	_, err := rand.Read(b[:])
+	if err != nil {
+		return "", err
+	}
+
+	id := hex.EncodeToString(b[:])
+
+	t.txs[id] = &sqlTx{lastUsed: now}
+
+	return id, nil
+}
+
+func (t *sqlTxs) add(id string, stmt *sql.BatchStmt) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	tx, ok := t.txs[id]
+	if !ok {
+		return ErrSQLTxNotFound
+	}
+
+	tx.stmts = append(tx.stmts, stmt)
+	tx.lastUsed = time.Now()
+
+	return nil
+}
+
+// remove discards the transaction, returning the statements added to it
+func (t *sqlTxs) remove(id string) ([]*sql.BatchStmt, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	tx, ok := t.txs[id]
+	if !ok {
+		return nil, ErrSQLTxNotFound
+	}
+
+	delete(t.txs, id)
+
+	return tx.stmts, nil
+}
+
+// SQLExecPreparedInTx executes the statements within the transaction identified by txID, or outside of any
+// transaction when txID is empty. Statements following BEGIN are deferred until COMMIT, when they are all
+// committed within a single transaction, or ROLLBACK, when they are discarded. The transaction is discarded as
+// well if it fails to be committed. The identifier of the transaction left open by the statements, if any, is
+// included in the result
+func (d *db) SQLExecPreparedInTx(ctx context.Context, txID string, stmts []sql.SQLStmt, namedParams []*schema.NamedParam, waitForIndexing bool) (*schema.SQLExecResult, error) {
+	if len(stmts) == 0 {
+		return nil, ErrIllegalArguments
+	}
+
+	if d.isReplica() {
+		return nil, ErrIsReplica
+	}
+
+	res := &schema.SQLExecResult{LastInsertedPKs: make(map[string]uint64)}
+
+	var pending []sql.SQLStmt
+
+	// statements not within a transaction are executed as they are received
+	flush := func() error {
+		if len(pending) == 0 {
+			return nil
+		}
+
+		r, err := d.SQLExecPrepared(ctx, pending, namedParams, waitForIndexing)
+		if err != nil {
+			return err
+		}
+
+		mergeSQLExecResult(res, r)
+		pending = nil
+
+		return nil
+	}
+
+	for _, stmt := range stmts {
+		switch stmt.(type) {
+		case *sql.BeginTransactionStmt:
+			{
+				if txID != "" {
+					return nil, ErrSQLTxAlreadyInProgress
+				}
+
+				err := flush()
+				if err != nil {
+					return nil, err
+				}
+
+				txID, err = d.sqlTxs.begin()
+				if err != nil {
+					return nil, err
+				}
+			}
+		case *sql.CommitStmt:
+			{
+				if txID == "" {
+					return nil, ErrNoSQLTxInProgress
+				}
+
+				batch, err := d.sqlTxs.remove(txID)
+				if err != nil {
+					return nil, err
+				}
+
+				txID = ""
+
+				if len(batch) == 0 {
+					continue
+				}
+
+				r, err := d.execSQLBatch(ctx, batch, waitForIndexing)
+				if err != nil {
+					return nil, err
+				}
+
+				mergeSQLExecResult(res, r)
+			}
+		case *sql.RollbackStmt:
+			{
+				if txID == "" {
+					return nil, ErrNoSQLTxInProgress
+				}
+
+				_, err := d.sqlTxs.remove(txID)
+				if err != nil {
+					return nil, err
+				}
+
+				txID = ""
+			}
+		default:
+			{
+				if txID == "" {
+					pending = append(pending, stmt)
+					continue
+				}
+
+				switch stmt.(type) {
+				case *sql.UseDatabaseStmt, *sql.CreateDatabaseStmt, *sql.TxStmt, *sql.SelectStmt:
+					return nil, ErrNotDeferrableStmt
+				}
+
+				params := make(map[string]interface{})
+
+				for _, p := range namedParams {
+					params[p.Name] = schema.RawValue(p.Value)
+				}
+
+				err := d.sqlTxs.add(txID, &sql.BatchStmt{Stmt: stmt, Params: params})
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	err := flush()
+	if err != nil {
+		return nil, err
+	}
+
+	res.TransactionId = txID
+
+	return res, nil
+}
+
+func (d *db) execSQLBatch(ctx context.Context, batch []*sql.BatchStmt, waitForIndexing bool) (*schema.SQLExecResult, error) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	summary, _, err := d.sqlEngine.ExecBatch(ctx, batch, waitForIndexing)
+	if err != nil {
+		return nil, err
+	}
+
+	return sqlExecResultFrom(summary)
+}
+
+func mergeSQLExecResult(res, r *schema.SQLExecResult) {
+	res.Ctxs = append(res.Ctxs, r.Ctxs...)
+	res.Dtxs = append(res.Dtxs, r.Dtxs...)
+	res.ReturnedRows = append(res.ReturnedRows, r.ReturnedRows...)
+
+	for table, pk := range r.LastInsertedPKs {
+		res.LastInsertedPKs[table] = pk
+	}
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package database
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+)
+
+// StreamingTx sets key-values whose values are written into the store while being read,
+// either Commit or Cancel must be called
+type StreamingTx struct {
+	ctx context.Context
+	tx  *store.StreamingTx
+}
+
+// NewStreamingTx starts a transaction where large values can be set without holding them in memory
+func (d *db) NewStreamingTx(ctx context.Context) (*StreamingTx, error) {
+	if d.isReplica() {
+		return nil, ErrIsReplica
+	}
+
+	tx, err := d.st.NewStreamingTx()
+	if err != nil {
+		return nil, err
+	}
+
+	return &StreamingTx{ctx: ctx, tx: tx}, nil
+}
+
+// Add sets the key to the value read from r, returning the length of the value
+func (tx *StreamingTx) Add(key []byte, r io.Reader) (int, error) {
+	if len(key) == 0 || r == nil {
+		return 0, ErrIllegalArguments
+	}
+
+	if err := tx.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	n, err := tx.tx.Add(EncodeKey(key), nil, io.MultiReader(bytes.NewReader([]byte{PlainValuePrefix}), r))
+	if err != nil {
+		return 0, err
+	}
+
+	return n - 1, nil
+}
+
+// Commit commits the key-values added so far
+func (tx *StreamingTx) Commit(noWait bool) (*schema.TxMetadata, error) {
+	if err := tx.ctx.Err(); err != nil {
+		tx.tx.Cancel()
+		return nil, err
+	}
+
+	md, err := tx.tx.Commit(tx.ctx, !noWait)
+	if err != nil {
+		return nil, err
+	}
+
+	return schema.TxMetatadaTo(md), nil
+}
+
+// Cancel discards the key-values added so far
+func (tx *StreamingTx) Cancel() {
+	tx.tx.Cancel()
+}
+
+// GetStreamed returns the entry without its value and a reader of the value,
+// which is read from the store in chunks as long as it's not a reference
+func (d *db) GetStreamed(ctx context.Context, req *schema.KeyRequest) (entry *schema.Entry, value io.Reader, valueLen int, err error) {
+	if req == nil || len(req.Key) == 0 {
+		return nil, nil, 0, ErrIllegalArguments
+	}
+
+	if req.AtTx > 0 || req.AsOfTs != 0 {
+		return d.getBuffered(ctx, req)
+	}
+
+	err = d.st.WaitForIndexingUpto(ctx, req.SinceTx)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	valRef, tx, _, err := d.st.GetRef(EncodeKey(req.Key))
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	r, err := valRef.Reader()
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	var prefix [1]byte
+
+	_, err = io.ReadFull(r, prefix[:])
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	if prefix[0] != PlainValuePrefix {
+		// references are resolved as usual
+		return d.getBuffered(ctx, req)
+	}
+
+	return &schema.Entry{Key: req.Key, Tx: tx}, r, valRef.Len() - 1, nil
+}
+
+func (d *db) getBuffered(ctx context.Context, req *schema.KeyRequest) (*schema.Entry, io.Reader, int, error) {
+	entry, err := d.Get(ctx, req)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	value := entry.Value
+	entry.Value = nil
+
+	return entry, bytes.NewReader(value), len(value), nil
+}
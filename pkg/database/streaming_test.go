@@ -0,0 +1,106 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package database
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/logger"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamingTx(t *testing.T) {
+	rootPath := "data_streaming_tx"
+	defer os.RemoveAll(rootPath)
+
+	options := DefaultOption().WithDbRootPath(rootPath).WithCorruptionChecker(false)
+	options.storeOpts.WithMaxValueLen(1 << 20)
+
+	db, err := NewDb(options, nil, logger.NewSimpleLogger("immudb ", os.Stderr))
+	require.NoError(t, err)
+	defer db.Close()
+
+	largeValue := make([]byte, 1<<20-1)
+	rand.Read(largeValue)
+
+	tx, err := db.NewStreamingTx(context.Background())
+	require.NoError(t, err)
+
+	_, err = tx.Add(nil, bytes.NewReader(largeValue))
+	require.Equal(t, ErrIllegalArguments, err)
+
+	n, err := tx.Add([]byte("key1"), bytes.NewReader(largeValue))
+	require.NoError(t, err)
+	require.Equal(t, len(largeValue), n)
+
+	txMetadata, err := tx.Commit(false)
+	require.NoError(t, err)
+
+	_, err = db.SetReference(context.Background(), &schema.ReferenceRequest{
+		Key:           []byte("ref1"),
+		ReferencedKey: []byte("key1"),
+	})
+	require.NoError(t, err)
+
+	entry, vr, vLen, err := db.GetStreamed(context.Background(), &schema.KeyRequest{Key: []byte("key1")})
+	require.NoError(t, err)
+	require.Equal(t, []byte("key1"), entry.Key)
+	require.Equal(t, txMetadata.Id, entry.Tx)
+	require.Equal(t, len(largeValue), vLen)
+
+	val, err := ioutil.ReadAll(vr)
+	require.NoError(t, err)
+	require.Equal(t, largeValue, val)
+
+	// references and values at a given tx are read as usual
+	for _, req := range []*schema.KeyRequest{
+		{Key: []byte("ref1")},
+		{Key: []byte("key1"), AtTx: txMetadata.Id},
+	} {
+		_, vr, vLen, err = db.GetStreamed(context.Background(), req)
+		require.NoError(t, err)
+		require.Equal(t, len(largeValue), vLen)
+
+		val, err = ioutil.ReadAll(vr)
+		require.NoError(t, err)
+		require.Equal(t, largeValue, val)
+	}
+
+	_, _, _, err = db.GetStreamed(context.Background(), &schema.KeyRequest{})
+	require.Equal(t, ErrIllegalArguments, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	tx, err = db.NewStreamingTx(ctx)
+	require.NoError(t, err)
+
+	_, err = tx.Add([]byte("key2"), bytes.NewReader(largeValue))
+	require.NoError(t, err)
+
+	cancel()
+
+	_, err = tx.Commit(false)
+	require.Equal(t, context.Canceled, err)
+
+	_, err = db.Get(context.Background(), &schema.KeyRequest{Key: []byte("key2")})
+	require.Error(t, err)
+}
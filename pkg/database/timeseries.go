@@ -0,0 +1,297 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package database
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+)
+
+const seriesLenLen = 8
+const tsLen = 8
+
+// TSAppend appends samples to a time series, all of them are committed within the same tx
+func (d *db) TSAppend(ctx context.Context, req *schema.TSAppendRequest) (*schema.TxMetadata, error) {
+	if req == nil || len(req.Series) == 0 || len(req.Samples) == 0 {
+		return nil, ErrIllegalArguments
+	}
+
+	if d.isReplica() {
+		return nil, ErrIsReplica
+	}
+
+	entries := make([]*store.KV, len(req.Samples))
+
+	for i, s := range req.Samples {
+		if s == nil {
+			return nil, ErrIllegalArguments
+		}
+
+		entries[i] = EncodeTSSample(req.Series, s.Timestamp, s.Value)
+	}
+
+	attrs, err := txAttributesFor(req.TraceID)
+	if err != nil {
+		return nil, err
+	}
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	txMetatadata, err := d.st.CommitWithAttributes(entries, attrs, nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if !req.NoWait {
+		err = d.st.WaitForIndexingUpto(ctx, txMetatadata.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return schema.TxMetatadaTo(txMetatadata), nil
+}
+
+// TSRange returns the samples of a time series within a range of timestamps, optionally downsampled
+func (d *db) TSRange(ctx context.Context, req *schema.TSRangeRequest) (*schema.TSSamples, error) {
+	if req == nil || len(req.Series) == 0 || req.Window < 0 {
+		return nil, ErrIllegalArguments
+	}
+
+	if req.End != 0 && req.End <= req.Start {
+		return nil, ErrIllegalArguments
+	}
+
+	if req.Aggregation < schema.TSAggregation_AVG || req.Aggregation > schema.TSAggregation_LAST {
+		return nil, ErrIllegalArguments
+	}
+
+	if req.Limit > MaxKeyScanLimit {
+		return nil, ErrMaxKeyScanLimitExceeded
+	}
+
+	limit := int(req.Limit)
+
+	if req.Limit == 0 {
+		limit = MaxKeyScanLimit
+	}
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	waitUntilTx := req.SinceTx
+
+	if waitUntilTx == 0 {
+		waitUntilTx, _ = d.st.Alh()
+	}
+
+	if !req.NoWait {
+		err := d.st.WaitForIndexingUpto(ctx, waitUntilTx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	snap, err := d.st.SnapshotSince(waitUntilTx)
+	if err != nil {
+		return nil, err
+	}
+	defer snap.Close()
+
+	prefix := tsSeriesPrefix(req.Series)
+
+	var seekKey []byte
+
+	if req.Desc {
+		if req.End == 0 {
+			seekKey = tsSampleKey(req.Series, math.MaxInt64)
+		} else {
+			seekKey = tsSampleKey(req.Series, req.End-1)
+		}
+	} else {
+		seekKey = tsSampleKey(req.Series, req.Start)
+	}
+
+	r, err := snap.NewKeyReader(
+		&store.KeyReaderSpec{
+			SeekKey:       seekKey,
+			Prefix:        prefix,
+			InclusiveSeek: true,
+			DescOrder:     req.Desc,
+		})
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	var samples []*schema.TSSample
+	var w *tsWindow
+
+	for len(samples) < limit {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		key, valRef, _, _, err := r.Read()
+		if err == store.ErrNoMoreEntries {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		ts := decodeTSTimestamp(key[len(prefix):])
+
+		if ts < req.Start || (req.End != 0 && ts >= req.End) {
+			// samples are read in timestamp order
+			break
+		}
+
+		val, err := valRef.Resolve()
+		if err != nil {
+			return nil, err
+		}
+		if len(val) != 8 {
+			return nil, store.ErrCorruptedData
+		}
+
+		sample := &schema.TSSample{
+			Timestamp: ts,
+			Value:     math.Float64frombits(binary.BigEndian.Uint64(val)),
+		}
+
+		if req.Window == 0 {
+			samples = append(samples, sample)
+			continue
+		}
+
+		start := tsWindowStart(ts, req.Window)
+
+		if w != nil && w.start != start {
+			samples = append(samples, w.sample(req.Aggregation))
+			w = nil
+		}
+
+		if w == nil {
+			w = &tsWindow{start: start, first: sample, last: sample, min: sample.Value, max: sample.Value}
+		}
+
+		w.add(sample)
+	}
+
+	if w != nil && len(samples) < limit {
+		samples = append(samples, w.sample(req.Aggregation))
+	}
+
+	return &schema.TSSamples{Samples: samples}, nil
+}
+
+// tsWindow aggregates the samples of a downsampling window
+type tsWindow struct {
+	start int64
+
+	first *schema.TSSample
+	last  *schema.TSSample
+
+	min   float64
+	max   float64
+	sum   float64
+	count int
+}
+
+func (w *tsWindow) add(s *schema.TSSample) {
+	if s.Timestamp < w.first.Timestamp {
+		w.first = s
+	}
+	if s.Timestamp > w.last.Timestamp {
+		w.last = s
+	}
+
+	w.min = math.Min(w.min, s.Value)
+	w.max = math.Max(w.max, s.Value)
+	w.sum += s.Value
+	w.count++
+}
+
+func (w *tsWindow) sample(aggregation schema.TSAggregation) *schema.TSSample {
+	var v float64
+
+	switch aggregation {
+	case schema.TSAggregation_AVG:
+		v = w.sum / float64(w.count)
+	case schema.TSAggregation_MIN:
+		v = w.min
+	case schema.TSAggregation_MAX:
+		v = w.max
+	case schema.TSAggregation_SUM:
+		v = w.sum
+	case schema.TSAggregation_COUNT:
+		v = float64(w.count)
+	case schema.TSAggregation_FIRST:
+		v = w.first.Value
+	case schema.TSAggregation_LAST:
+		v = w.last.Value
+	}
+
+	return &schema.TSSample{Timestamp: w.start, Value: v}
+}
+
+// tsWindowStart returns the start of the window including ts, windows are aligned to multiples of their size
+func tsWindowStart(ts, window int64) int64 {
+	start := ts - ts%window
+	if start > ts {
+		// ts is negative and not a multiple of the window
+		start -= window
+	}
+	return start
+}
+
+// EncodeTSSample returns the entry holding a sample of a time series
+func EncodeTSSample(series []byte, ts int64, value float64) *store.KV {
+	val := make([]byte, 8)
+	binary.BigEndian.PutUint64(val, math.Float64bits(value))
+
+	return &store.KV{
+		Key:   tsSampleKey(series, ts),
+		Value: val,
+	}
+}
+
+func tsSeriesPrefix(series []byte) []byte {
+	prefix := make([]byte, 1+seriesLenLen+len(series))
+	prefix[0] = TimeSeriesKeyPrefix
+	binary.BigEndian.PutUint64(prefix[1:], uint64(len(series)))
+	copy(prefix[1+seriesLenLen:], series)
+	return prefix
+}
+
+// tsSampleKey = [TimeSeriesKeyPrefix][len(series)][series][timestamp]
+// the sign bit of the timestamp is flipped so negative timestamps are sorted first
+func tsSampleKey(series []byte, ts int64) []byte {
+	key := make([]byte, 1+seriesLenLen+len(series)+tsLen)
+	copy(key, tsSeriesPrefix(series))
+	binary.BigEndian.PutUint64(key[1+seriesLenLen+len(series):], uint64(ts)^(1<<63))
+	return key
+}
+
+func decodeTSTimestamp(b []byte) int64 {
+	return int64(binary.BigEndian.Uint64(b) ^ (1 << 63))
+}
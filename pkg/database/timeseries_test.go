@@ -0,0 +1,176 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package database
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeSeries(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	_, err := db.TSAppend(context.Background(), nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = db.TSAppend(context.Background(), &schema.TSAppendRequest{Series: []byte("cpu")})
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = db.TSAppend(context.Background(), &schema.TSAppendRequest{Samples: []*schema.TSSample{{Timestamp: 1}}})
+	require.Equal(t, ErrIllegalArguments, err)
+
+	samples := []*schema.TSSample{
+		{Timestamp: -15, Value: -1},
+		{Timestamp: -5, Value: 0},
+		{Timestamp: 0, Value: 1},
+		{Timestamp: 5, Value: 2},
+		{Timestamp: 12, Value: 3},
+		{Timestamp: 18, Value: 4},
+		{Timestamp: 25, Value: 5},
+	}
+
+	// appended in two txs and out of order
+	_, err = db.TSAppend(context.Background(), &schema.TSAppendRequest{Series: []byte("cpu"), Samples: samples[4:]})
+	require.NoError(t, err)
+
+	meta, err := db.TSAppend(context.Background(), &schema.TSAppendRequest{Series: []byte("cpu"), Samples: samples[:4]})
+	require.NoError(t, err)
+	require.Equal(t, int32(4), meta.Nentries)
+
+	// samples of other series are not returned
+	_, err = db.TSAppend(context.Background(), &schema.TSAppendRequest{Series: []byte("cpu2"), Samples: samples})
+	require.NoError(t, err)
+
+	_, err = db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("cpu"), Value: []byte("cpu")}}})
+	require.NoError(t, err)
+
+	t.Run("invalid ranges", func(t *testing.T) {
+		_, err := db.TSRange(context.Background(), nil)
+		require.Equal(t, ErrIllegalArguments, err)
+
+		_, err = db.TSRange(context.Background(), &schema.TSRangeRequest{})
+		require.Equal(t, ErrIllegalArguments, err)
+
+		_, err = db.TSRange(context.Background(), &schema.TSRangeRequest{Series: []byte("cpu"), Start: 10, End: 10})
+		require.Equal(t, ErrIllegalArguments, err)
+
+		_, err = db.TSRange(context.Background(), &schema.TSRangeRequest{Series: []byte("cpu"), Window: -1})
+		require.Equal(t, ErrIllegalArguments, err)
+
+		_, err = db.TSRange(context.Background(), &schema.TSRangeRequest{Series: []byte("cpu"), Aggregation: schema.TSAggregation(7)})
+		require.Equal(t, ErrIllegalArguments, err)
+
+		_, err = db.TSRange(context.Background(), &schema.TSRangeRequest{Series: []byte("cpu"), Limit: MaxKeyScanLimit + 1})
+		require.Equal(t, ErrMaxKeyScanLimitExceeded, err)
+	})
+
+	t.Run("raw samples", func(t *testing.T) {
+		res, err := db.TSRange(context.Background(), &schema.TSRangeRequest{Series: []byte("cpu"), Start: math.MinInt64})
+		require.NoError(t, err)
+		require.Len(t, res.Samples, len(samples))
+
+		for i, s := range res.Samples {
+			require.Equal(t, samples[i].Timestamp, s.Timestamp)
+			require.Equal(t, samples[i].Value, s.Value)
+		}
+
+		res, err = db.TSRange(context.Background(), &schema.TSRangeRequest{Series: []byte("cpu"), Start: 0, End: 18})
+		require.NoError(t, err)
+		require.Len(t, res.Samples, 3)
+		require.Equal(t, int64(0), res.Samples[0].Timestamp)
+		require.Equal(t, int64(12), res.Samples[2].Timestamp)
+
+		res, err = db.TSRange(context.Background(), &schema.TSRangeRequest{Series: []byte("cpu"), Start: -10, End: 18, Desc: true, Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, res.Samples, 2)
+		require.Equal(t, int64(12), res.Samples[0].Timestamp)
+		require.Equal(t, int64(5), res.Samples[1].Timestamp)
+
+		res, err = db.TSRange(context.Background(), &schema.TSRangeRequest{Series: []byte("cpu"), Start: 26})
+		require.NoError(t, err)
+		require.Empty(t, res.Samples)
+	})
+
+	t.Run("downsampled samples", func(t *testing.T) {
+		expected := map[schema.TSAggregation][]float64{
+			schema.TSAggregation_AVG:   {-1, 0, 1.5, 3.5, 5},
+			schema.TSAggregation_MIN:   {-1, 0, 1, 3, 5},
+			schema.TSAggregation_MAX:   {-1, 0, 2, 4, 5},
+			schema.TSAggregation_SUM:   {-1, 0, 3, 7, 5},
+			schema.TSAggregation_COUNT: {1, 1, 2, 2, 1},
+			schema.TSAggregation_FIRST: {-1, 0, 1, 3, 5},
+			schema.TSAggregation_LAST:  {-1, 0, 2, 4, 5},
+		}
+
+		windows := []int64{-20, -10, 0, 10, 20}
+
+		for aggregation, values := range expected {
+			for _, desc := range []bool{false, true} {
+				res, err := db.TSRange(context.Background(), &schema.TSRangeRequest{
+					Series:      []byte("cpu"),
+					Start:       math.MinInt64,
+					Window:      10,
+					Aggregation: aggregation,
+					Desc:        desc,
+				})
+				require.NoError(t, err)
+				require.Len(t, res.Samples, len(values))
+
+				for i, s := range res.Samples {
+					j := i
+					if desc {
+						j = len(values) - 1 - i
+					}
+
+					require.Equal(t, windows[j], s.Timestamp)
+					require.Equal(t, values[j], s.Value, aggregation.String())
+				}
+			}
+		}
+
+		res, err := db.TSRange(context.Background(), &schema.TSRangeRequest{
+			Series:      []byte("cpu"),
+			Start:       0,
+			Window:      10,
+			Aggregation: schema.TSAggregation_SUM,
+			Limit:       2,
+		})
+		require.NoError(t, err)
+		require.Len(t, res.Samples, 2)
+		require.Equal(t, int64(0), res.Samples[0].Timestamp)
+		require.Equal(t, float64(3), res.Samples[0].Value)
+		require.Equal(t, int64(10), res.Samples[1].Timestamp)
+		require.Equal(t, float64(7), res.Samples[1].Value)
+	})
+
+	t.Run("overridden samples", func(t *testing.T) {
+		_, err := db.TSAppend(context.Background(), &schema.TSAppendRequest{
+			Series:  []byte("cpu"),
+			Samples: []*schema.TSSample{{Timestamp: 5, Value: 20}},
+		})
+		require.NoError(t, err)
+
+		res, err := db.TSRange(context.Background(), &schema.TSRangeRequest{Series: []byte("cpu"), Start: 5, End: 6})
+		require.NoError(t, err)
+		require.Len(t, res.Samples, 1)
+		require.Equal(t, float64(20), res.Samples[0].Value)
+	})
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/fs"
+)
+
+// ExportTxRange writes into w an archive holding the txs in the requested range, it can be applied onto
+// a backup of the database holding sinceTx as its latest tx.
+// The archive is encrypted with age when recipients are requested, thus only the holders of their
+// private keys can apply it.
+func (d *db) ExportTxRange(req *schema.TxRangeRequest, w io.Writer) (*schema.TxRange, error) {
+	if req == nil || w == nil {
+		return nil, ErrIllegalArguments
+	}
+
+	var ew io.WriteCloser
+
+	if len(req.Recipients) > 0 {
+		var err error
+
+		ew, err = fs.NewEncryptingWriter(w, req.Recipients)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrIllegalArguments, err)
+		}
+		w = ew
+	}
+
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	hdr, err := d.st.ExportTxRange(req.SinceTx, req.UpToTx, w)
+	if err != nil {
+		return nil, err
+	}
+
+	// the encryption is completed once the encrypting writer is closed
+	if ew != nil {
+		err = ew.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return txRangeTo(hdr), nil
+}
+
+// ApplyTxRange commits the txs held by an archive exported from a database sharing the same history,
+// the latest tx of the database must be the one the archive was exported since
+func (d *db) ApplyTxRange(r io.Reader) (*schema.TxRange, error) {
+	if r == nil {
+		return nil, ErrIllegalArguments
+	}
+
+	if d.isReplica() {
+		return nil, ErrIsReplica
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	hdr, err := d.st.ApplyTxRange(r, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return txRangeTo(hdr), nil
+}
+
+func txRangeTo(hdr *store.TxArchiveHeader) *schema.TxRange {
+	return &schema.TxRange{
+		SinceTx:  hdr.SinceTx,
+		SinceAlh: hdr.SinceAlh[:],
+		UpToTx:   hdr.UpToTx,
+		UpToAlh:  hdr.UpToAlh[:],
+	}
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package database
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/logger"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxArchive(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	rootPath := "data_tx_archive"
+	defer os.RemoveAll(rootPath)
+
+	catalogDB, err := NewDb(DefaultOption().WithDbRootPath(rootPath).WithDbName("catalog"), nil, logger.NewSimpleLogger("immudb ", os.Stderr))
+	require.NoError(t, err)
+	defer catalogDB.Close()
+
+	backupDB, err := NewDb(DefaultOption().WithDbRootPath(rootPath).WithDbName("backup"), catalogDB, logger.NewSimpleLogger("immudb ", os.Stderr))
+	require.NoError(t, err)
+	defer backupDB.Close()
+
+	replicaDB, err := NewDb(DefaultOption().WithDbRootPath(rootPath).WithDbName("replica").WithReplica(true), catalogDB, logger.NewSimpleLogger("immudb ", os.Stderr))
+	require.NoError(t, err)
+	defer replicaDB.Close()
+
+	set := func(n int) *schema.TxMetadata {
+		var md *schema.TxMetadata
+		for i := 0; i < n; i++ {
+			md, err = db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(fmt.Sprintf("key%d", i)), Value: []byte(fmt.Sprintf("value%d", i))}}})
+			require.NoError(t, err)
+		}
+		return md
+	}
+
+	md := set(5)
+
+	var b bytes.Buffer
+
+	_, err = db.ExportTxRange(nil, &b)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = db.ExportTxRange(&schema.TxRangeRequest{}, nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	txRange, err := db.ExportTxRange(&schema.TxRangeRequest{}, &b)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), txRange.SinceTx)
+	require.Equal(t, md.Id, txRange.UpToTx)
+
+	_, err = backupDB.ApplyTxRange(nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = replicaDB.ApplyTxRange(bytes.NewReader(b.Bytes()))
+	require.Equal(t, ErrIsReplica, err)
+
+	appliedRange, err := backupDB.ApplyTxRange(bytes.NewReader(b.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, txRange, appliedRange)
+
+	md = set(3)
+
+	var incB bytes.Buffer
+
+	txRange, err = db.ExportTxRange(&schema.TxRangeRequest{SinceTx: txRange.UpToTx}, &incB)
+	require.NoError(t, err)
+	require.Equal(t, md.Id, txRange.UpToTx)
+
+	// the full archive can not be applied anymore
+	_, err = backupDB.ApplyTxRange(bytes.NewReader(b.Bytes()))
+	require.True(t, errors.Is(err, store.ErrTxArchiveMismatch))
+
+	_, err = backupDB.ApplyTxRange(&incB)
+	require.NoError(t, err)
+
+	state, err := db.CurrentState()
+	require.NoError(t, err)
+
+	backupState, err := backupDB.CurrentState()
+	require.NoError(t, err)
+	require.Equal(t, state.TxId, backupState.TxId)
+	require.Equal(t, state.TxHash, backupState.TxHash)
+
+	entry, err := backupDB.Get(context.Background(), &schema.KeyRequest{Key: []byte("key2"), SinceTx: md.Id})
+	require.NoError(t, err)
+	require.Equal(t, []byte("value2"), entry.Value)
+}
+
+func TestEncryptedTxArchive(t *testing.T) {
+	db, closer := makeDb()
+	defer closer()
+
+	rootPath := "data_encrypted_tx_archive"
+	defer os.RemoveAll(rootPath)
+
+	catalogDB, err := NewDb(DefaultOption().WithDbRootPath(rootPath).WithDbName("catalog"), nil, logger.NewSimpleLogger("immudb ", os.Stderr))
+	require.NoError(t, err)
+	defer catalogDB.Close()
+
+	backupDB, err := NewDb(DefaultOption().WithDbRootPath(rootPath).WithDbName("backup"), catalogDB, logger.NewSimpleLogger("immudb ", os.Stderr))
+	require.NoError(t, err)
+	defer backupDB.Close()
+
+	_, err = db.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key"), Value: []byte("value")}}})
+	require.NoError(t, err)
+
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	var b bytes.Buffer
+
+	_, err = db.ExportTxRange(&schema.TxRangeRequest{Recipients: []string{"age1invalid"}}, &b)
+	require.True(t, errors.Is(err, ErrIllegalArguments))
+
+	txRange, err := db.ExportTxRange(&schema.TxRangeRequest{Recipients: []string{identity.Recipient().String()}}, &b)
+	require.NoError(t, err)
+
+	// the encrypted archive can not be applied as it is
+	_, err = backupDB.ApplyTxRange(bytes.NewReader(b.Bytes()))
+	require.True(t, errors.Is(err, store.ErrInvalidTxArchive))
+
+	r, err := age.Decrypt(&b, identity)
+	require.NoError(t, err)
+
+	appliedRange, err := backupDB.ApplyTxRange(r)
+	require.NoError(t, err)
+	require.Equal(t, txRange, appliedRange)
+}
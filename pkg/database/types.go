@@ -22,7 +22,12 @@ import "sync"
 type DatabaseList interface {
 	Append(database DB)
 	GetByIndex(index int64) DB
+	Acquire(index int64) (DB, error)
+	Release(database DB)
+	Drained(database DB) <-chan struct{}
 	GetByName(string) (DB, error)
+	Delete(dbname string) (DB, error)
+	Replace(database DB) (DB, error)
 	GetId(dbname string) int64
 	Length() int
 }
@@ -30,14 +35,23 @@ type DatabaseList interface {
 type databaseList struct {
 	databases           []DB
 	databasenameToIndex map[string]int64
+	// usages holds the databases currently acquired, see Acquire
+	usages map[DB]*databaseUsage
 	sync.RWMutex
 }
 
+type databaseUsage struct {
+	acquired int
+	// closed once the database is released by all the ones which acquired it
+	released chan struct{}
+}
+
 //NewDatabaseList constructs a new database list
 func NewDatabaseList() DatabaseList {
 	return &databaseList{
 		databasenameToIndex: make(map[string]int64),
 		databases:           make([]DB, 0),
+		usages:              make(map[DB]*databaseUsage),
 	}
 }
 func (d *databaseList) Append(database DB) {
@@ -46,12 +60,108 @@ func (d *databaseList) Append(database DB) {
 	d.databasenameToIndex[database.GetName()] = int64(len(d.databases))
 	d.databases = append(d.databases, database)
 }
+
+// Delete removes the database from the list. Indexes are preserved, thus the slot of a
+// deleted database is left empty and GetByIndex returns nil for it
+func (d *databaseList) Delete(dbname string) (DB, error) {
+	d.Lock()
+	defer d.Unlock()
+
+	index, ok := d.databasenameToIndex[dbname]
+	if !ok {
+		return nil, ErrDatabaseNotExists
+	}
+
+	db := d.databases[index]
+
+	delete(d.databasenameToIndex, dbname)
+	d.databases[index] = nil
+
+	return db, nil
+}
+
+// Replace puts the database in place of the one having the same name, which is returned.
+// Its index is preserved so sessions using it are not affected
+func (d *databaseList) Replace(database DB) (DB, error) {
+	d.Lock()
+	defer d.Unlock()
+
+	index, ok := d.databasenameToIndex[database.GetName()]
+	if !ok {
+		return nil, ErrDatabaseNotExists
+	}
+
+	db := d.databases[index]
+
+	d.databases[index] = database
+
+	return db, nil
+}
+
 func (d *databaseList) GetByIndex(index int64) DB {
 	d.RLock()
 	defer d.RUnlock()
 
 	return d.databases[index]
 }
+
+// Acquire returns the database at the given index, which is considered in use until released.
+// Databases removed from the list are not closed until released, see Drained
+func (d *databaseList) Acquire(index int64) (DB, error) {
+	d.Lock()
+	defer d.Unlock()
+
+	if index < 0 || index >= int64(len(d.databases)) || d.databases[index] == nil {
+		return nil, ErrDatabaseNotExists
+	}
+
+	db := d.databases[index]
+
+	usage, ok := d.usages[db]
+	if !ok {
+		usage = &databaseUsage{released: make(chan struct{})}
+		d.usages[db] = usage
+	}
+
+	usage.acquired++
+
+	return db, nil
+}
+
+// Release signals the database, previously acquired, is not in use anymore
+func (d *databaseList) Release(db DB) {
+	d.Lock()
+	defer d.Unlock()
+
+	usage, ok := d.usages[db]
+	if !ok {
+		return
+	}
+
+	usage.acquired--
+
+	if usage.acquired == 0 {
+		delete(d.usages, db)
+		close(usage.released)
+	}
+}
+
+// Drained returns a channel which gets closed once the database is not in use anymore.
+// The database is expected to be removed from the list, thus it can't be acquired again
+func (d *databaseList) Drained(db DB) <-chan struct{} {
+	d.Lock()
+	defer d.Unlock()
+
+	usage, ok := d.usages[db]
+	if ok {
+		return usage.released
+	}
+
+	released := make(chan struct{})
+	close(released)
+
+	return released
+}
 func (d *databaseList) GetByName(dbname string) (DB, error) {
 	d.RLock()
 	defer d.RUnlock()
@@ -0,0 +1,172 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fs
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"filippo.io/age"
+	"github.com/codenotary/immudb/pkg/immuos"
+)
+
+// EncryptedExt is the extension of the files encrypted with age
+const EncryptedExt = ".age"
+
+// Encrypter ...
+type Encrypter interface {
+	EncryptIt(src string, dst string, recipients []string) error
+	DecryptIt(src string, dst string, identityFile string) error
+}
+
+// StandardEncrypter ...
+type StandardEncrypter struct {
+	OS         immuos.OS
+	EncryptItF func(src string, dst string, recipients []string) error
+	DecryptItF func(src string, dst string, identityFile string) error
+}
+
+// NewStandardEncrypter ...
+func NewStandardEncrypter() *StandardEncrypter {
+	se := &StandardEncrypter{
+		OS: immuos.NewStandardOS(),
+	}
+	se.EncryptItF = se.encryptIt
+	se.DecryptItF = se.decryptIt
+	return se
+}
+
+// EncryptIt ...
+func (se *StandardEncrypter) EncryptIt(src string, dst string, recipients []string) error {
+	return se.EncryptItF(src, dst, recipients)
+}
+
+// DecryptIt ...
+func (se *StandardEncrypter) DecryptIt(src string, dst string, identityFile string) error {
+	return se.DecryptItF(src, dst, identityFile)
+}
+
+// ParseRecipients parses age X25519 public keys (age1...)
+func ParseRecipients(recipients []string) ([]age.Recipient, error) {
+	rs := make([]age.Recipient, len(recipients))
+
+	for i, r := range recipients {
+		rcpt, err := age.ParseX25519Recipient(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid recipient '%s': %v", r, err)
+		}
+		rs[i] = rcpt
+	}
+
+	return rs, nil
+}
+
+// NewEncryptingWriter returns a writer encrypting to the recipients everything written into w,
+// it must be closed for the encryption to be completed
+func NewEncryptingWriter(w io.Writer, recipients []string) (io.WriteCloser, error) {
+	rs, err := ParseRecipients(recipients)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rs) == 0 {
+		return nil, fmt.Errorf("no recipient was provided")
+	}
+
+	return age.Encrypt(w, rs...)
+}
+
+// encryptIt writes into dst the content of src encrypted to the recipients
+func (se *StandardEncrypter) encryptIt(src string, dst string, recipients []string) error {
+	if _, err := se.OS.Stat(dst); err == nil {
+		return os.ErrExist
+	}
+
+	srcFile, err := se.OS.Open(src)
+	if err != nil {
+		return err
+	}
+	defer srcFile.Close()
+
+	dstFile, err := se.OS.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer dstFile.Close()
+
+	w, err := NewEncryptingWriter(dstFile, recipients)
+	if err != nil {
+		se.OS.Remove(dst)
+		return err
+	}
+
+	if _, err = io.Copy(w, srcFile); err != nil {
+		se.OS.Remove(dst)
+		return err
+	}
+
+	if err = w.Close(); err != nil {
+		se.OS.Remove(dst)
+		return err
+	}
+
+	return nil
+}
+
+// decryptIt writes into dst the content of src decrypted with any of the identities (age private keys)
+// held by identityFile
+func (se *StandardEncrypter) decryptIt(src string, dst string, identityFile string) error {
+	idFile, err := se.OS.Open(identityFile)
+	if err != nil {
+		return err
+	}
+	defer idFile.Close()
+
+	identities, err := age.ParseIdentities(idFile)
+	if err != nil {
+		return fmt.Errorf("invalid identity file %s: %v", identityFile, err)
+	}
+
+	if _, err = se.OS.Stat(dst); err == nil {
+		return os.ErrExist
+	}
+
+	srcFile, err := se.OS.Open(src)
+	if err != nil {
+		return err
+	}
+	defer srcFile.Close()
+
+	r, err := age.Decrypt(srcFile, identities...)
+	if err != nil {
+		return err
+	}
+
+	dstFile, err := se.OS.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer dstFile.Close()
+
+	if _, err = io.Copy(dstFile, r); err != nil {
+		se.OS.Remove(dst)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fs
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/codenotary/immudb/pkg/immuos"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	dir, err := ioutil.TempDir("", "encrypt_decrypt")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	otherIdentity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	identityFile := filepath.Join(dir, "key.txt")
+	require.NoError(t, ioutil.WriteFile(identityFile, []byte(identity.String()+"\n"), 0600))
+	otherIdentityFile := filepath.Join(dir, "other_key.txt")
+	require.NoError(t, ioutil.WriteFile(otherIdentityFile, []byte(otherIdentity.String()+"\n"), 0600))
+
+	content := []byte("some archive content")
+	src := filepath.Join(dir, "archive.tar.gz")
+	require.NoError(t, ioutil.WriteFile(src, content, 0644))
+
+	encrypter := NewStandardEncrypter()
+
+	encrypted := src + EncryptedExt
+	require.NoError(t, encrypter.EncryptIt(src, encrypted, []string{identity.Recipient().String()}))
+	require.Equal(t, os.ErrExist, encrypter.EncryptIt(src, encrypted, []string{identity.Recipient().String()}))
+
+	encryptedContent, err := ioutil.ReadFile(encrypted)
+	require.NoError(t, err)
+	require.False(t, bytes.Contains(encryptedContent, content))
+
+	decrypted := filepath.Join(dir, "decrypted.tar.gz")
+	require.Error(t, encrypter.DecryptIt(encrypted, decrypted, otherIdentityFile))
+
+	require.NoError(t, encrypter.DecryptIt(encrypted, decrypted, identityFile))
+	require.Equal(t, os.ErrExist, encrypter.DecryptIt(encrypted, decrypted, identityFile))
+
+	decryptedContent, err := ioutil.ReadFile(decrypted)
+	require.NoError(t, err)
+	require.Equal(t, content, decryptedContent)
+
+	require.Error(t, encrypter.DecryptIt(encrypted, filepath.Join(dir, "none"), src))
+	require.Error(t, encrypter.DecryptIt(encrypted, filepath.Join(dir, "none"), filepath.Join(dir, "non-existent")))
+}
+
+func TestEncryptInvalidRecipients(t *testing.T) {
+	dir, err := ioutil.TempDir("", "encrypt_invalid_recipients")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	src := filepath.Join(dir, "archive.tar.gz")
+	require.NoError(t, ioutil.WriteFile(src, []byte("content"), 0644))
+
+	encrypter := NewStandardEncrypter()
+
+	dst := src + EncryptedExt
+	require.Error(t, encrypter.EncryptIt(src, dst, nil))
+	require.Error(t, encrypter.EncryptIt(src, dst, []string{"age1invalid"}))
+
+	_, err = os.Stat(dst)
+	require.True(t, os.IsNotExist(err))
+
+	_, err = ParseRecipients([]string{"ssh-ed25519 AAAA"})
+	require.Error(t, err)
+}
+
+func TestEncryptCreateError(t *testing.T) {
+	src := "some-encrypt-src"
+	require.NoError(t, ioutil.WriteFile(src, []byte("content"), 0644))
+	defer os.Remove(src)
+
+	encrypter := NewStandardEncrypter()
+	errCreate := errors.New("Create error")
+	encrypter.OS.(*immuos.StandardOS).CreateF = func(name string) (*os.File, error) {
+		return nil, errCreate
+	}
+
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	require.Equal(t, errCreate, encrypter.EncryptIt(src, "dst", []string{identity.Recipient().String()}))
+}
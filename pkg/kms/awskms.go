@@ -0,0 +1,224 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kms
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+const awsKMSTargetPrefix = "TrentService."
+const awsKMSService = "kms"
+
+type AWSCredentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// AWSKeyProvider wraps keys with a customer managed key of AWS KMS, rotation of the key is managed by AWS,
+// which keeps unwrapping keys wrapped with previous key material
+type AWSKeyProvider struct {
+	region   string
+	keyID    string
+	creds    *AWSCredentials
+	endpoint string
+	client   *http.Client
+	now      func() time.Time
+}
+
+func NewAWSKeyProvider(region, keyID string, creds *AWSCredentials) *AWSKeyProvider {
+	return &AWSKeyProvider{
+		region:   region,
+		keyID:    keyID,
+		creds:    creds,
+		endpoint: fmt.Sprintf("https://kms.%s.amazonaws.com", region),
+		client:   newHTTPClient(),
+		now:      time.Now,
+	}
+}
+
+// WithEndpoint sets the endpoint requests are sent to e.g. a VPC endpoint, the regional one is used when empty
+func (p *AWSKeyProvider) WithEndpoint(endpoint string) *AWSKeyProvider {
+	if endpoint != "" {
+		p.endpoint = strings.TrimSuffix(endpoint, "/")
+	}
+	return p
+}
+
+type awsKMSResponse struct {
+	CiphertextBlob []byte `json:"CiphertextBlob"`
+	Plaintext      []byte `json:"Plaintext"`
+	Type           string `json:"__type"`
+	Message        string `json:"message"`
+}
+
+func (p *AWSKeyProvider) WrapKey(key []byte) ([]byte, error) {
+	res, err := p.do("Encrypt", map[string]interface{}{"KeyId": p.keyID, "Plaintext": key})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.CiphertextBlob) == 0 {
+		return nil, fmt.Errorf("%w: aws kms returned no ciphertext", ErrKeyProviderFailure)
+	}
+
+	return res.CiphertextBlob, nil
+}
+
+func (p *AWSKeyProvider) UnwrapKey(wrapped []byte) ([]byte, error) {
+	if len(wrapped) == 0 {
+		return nil, ErrInvalidWrappedKey
+	}
+
+	res, err := p.do("Decrypt", map[string]interface{}{"KeyId": p.keyID, "CiphertextBlob": wrapped})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.Plaintext) == 0 {
+		return nil, fmt.Errorf("%w: aws kms returned no plaintext", ErrKeyProviderFailure)
+	}
+
+	return res.Plaintext, nil
+}
+
+func (p *AWSKeyProvider) do(op string, body map[string]interface{}) (*awsKMSResponse, error) {
+	// []byte values are encoded as base64 as expected by the api
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, p.endpoint+"/", bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", awsKMSTargetPrefix+op)
+
+	signAWSRequest(req, payload, awsKMSService, p.region, p.creds, p.now())
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrKeyProviderFailure, err)
+	}
+	defer resp.Body.Close()
+
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrKeyProviderFailure, err)
+	}
+
+	var res awsKMSResponse
+
+	err = json.Unmarshal(content, &res)
+	if err != nil && resp.StatusCode == http.StatusOK {
+		return nil, fmt.Errorf("%w: %v", ErrKeyProviderFailure, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: aws kms responded with status %d %s %s", ErrKeyProviderFailure, resp.StatusCode, res.Type, res.Message)
+	}
+
+	return &res, nil
+}
+
+// signAWSRequest adds the headers of the AWS Signature Version 4, all the headers of the request are signed
+func signAWSRequest(req *http.Request, payload []byte, service, region string, creds *AWSCredentials, t time.Time) {
+	amzDate := t.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	if creds.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for k, v := range req.Header {
+		headers[strings.ToLower(k)] = strings.TrimSpace(strings.Join(v, ","))
+	}
+
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, k := range names {
+		canonicalHeaders.WriteString(k + ":" + headers[k] + "\n")
+	}
+
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+
+	payloadHash := sha256.Sum256(payload)
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+
+	canonicalRequestHash := sha256.Sum256([]byte(canonicalRequest))
+
+	scope := strings.Join([]string{date, region, service, "aws4_request"}, "/")
+
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hex.EncodeToString(canonicalRequestHash[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+creds.SecretAccessKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		creds.AccessKeyID, scope, signedHeaders, signature,
+	))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kms
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAWSRequest(t *testing.T) {
+	// example request from the AWS Signature Version 4 documentation
+	req, err := http.NewRequest(http.MethodGet, "https://iam.amazonaws.com/?Action=ListUsers&Version=2010-05-08", nil)
+	require.NoError(t, err)
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+
+	creds := &AWSCredentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"}
+
+	signAWSRequest(req, nil, "iam", "us-east-1", creds, time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+	require.Equal(t, "20150830T123600Z", req.Header.Get("X-Amz-Date"))
+	require.Equal(t,
+		"AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, "+
+			"SignedHeaders=content-type;host;x-amz-date, "+
+			"Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7",
+		req.Header.Get("Authorization"))
+}
+
+func TestAWSKeyProvider(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=akid/"))
+		require.Equal(t, "token", r.Header.Get("X-Amz-Security-Token"))
+
+		var body struct {
+			KeyId          string
+			Plaintext      []byte
+			CiphertextBlob []byte
+		}
+
+		err := json.NewDecoder(r.Body).Decode(&body)
+		require.NoError(t, err)
+
+		if body.KeyId != "alias/immudb" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"__type":"NotFoundException","message":"key not found"}`))
+			return
+		}
+
+		switch r.Header.Get("X-Amz-Target") {
+		case "TrentService.Encrypt":
+			json.NewEncoder(w).Encode(map[string][]byte{"CiphertextBlob": append([]byte("sealed:"), body.Plaintext...)})
+		case "TrentService.Decrypt":
+			json.NewEncoder(w).Encode(map[string][]byte{"Plaintext": body.CiphertextBlob[len("sealed:"):]})
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	creds := &AWSCredentials{AccessKeyID: "akid", SecretAccessKey: "secret", SessionToken: "token"}
+
+	p := NewAWSKeyProvider("eu-west-1", "alias/immudb", creds).WithEndpoint(srv.URL)
+
+	wrapped, err := p.WrapKey([]byte("data key"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("sealed:data key"), wrapped)
+
+	key, err := p.UnwrapKey(wrapped)
+	require.NoError(t, err)
+	require.Equal(t, []byte("data key"), key)
+
+	_, err = p.UnwrapKey(nil)
+	require.Equal(t, ErrInvalidWrappedKey, err)
+
+	p = NewAWSKeyProvider("eu-west-1", "alias/unknown", creds).WithEndpoint(srv.URL)
+
+	_, err = p.WrapKey([]byte("data key"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "NotFoundException")
+}
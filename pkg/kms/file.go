@@ -0,0 +1,165 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kms
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const fileWrappedKeyVersion byte = 1
+const masterKeyIDLen = 8
+
+// FileKeyProvider holds hex-encoded master keys in a file, one per line, the last one being used to wrap keys.
+// The file is read on every operation, so master keys can be rotated by appending a new one
+// while previous ones are still needed to unwrap keys not yet rewrapped
+type FileKeyProvider struct {
+	path string
+}
+
+func NewFileKeyProvider(path string) *FileKeyProvider {
+	return &FileKeyProvider{path: path}
+}
+
+type masterKey struct {
+	id  []byte
+	key []byte
+}
+
+func (p *FileKeyProvider) masterKeys() ([]*masterKey, error) {
+	f, err := os.Open(p.path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var keys []*masterKey
+
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, err := hex.DecodeString(line)
+		if err != nil || (len(key) != 16 && len(key) != 24 && len(key) != 32) {
+			return nil, fmt.Errorf("%w: invalid master key in '%s'", ErrKeyProviderFailure, p.path)
+		}
+
+		id := sha256.Sum256(key)
+
+		keys = append(keys, &masterKey{id: id[:masterKeyIDLen], key: key})
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("%w: no master key found in '%s'", ErrKeyProviderFailure, p.path)
+	}
+
+	return keys, nil
+}
+
+// WrapKey encrypts the key with the current master key,
+// the result is version + master key id + nonce + sealed key
+func (p *FileKeyProvider) WrapKey(key []byte) ([]byte, error) {
+	keys, err := p.masterKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	mk := keys[len(keys)-1]
+
+	aead, err := newAEAD(mk.key)
+	if err != nil {
+		return nil, err
+	}
+
+	wrapped := make([]byte, 1+masterKeyIDLen+aead.NonceSize(), 1+masterKeyIDLen+aead.NonceSize()+len(key)+aead.Overhead())
+	wrapped[0] = fileWrappedKeyVersion
+	copy(wrapped[1:], mk.id)
+
+	nonce := wrapped[1+masterKeyIDLen:]
+
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(wrapped, nonce, key, wrapped[:1+masterKeyIDLen]), nil
+}
+
+// UnwrapKey decrypts the key with the master key it was wrapped with
+func (p *FileKeyProvider) UnwrapKey(wrapped []byte) ([]byte, error) {
+	if len(wrapped) < 1+masterKeyIDLen || wrapped[0] != fileWrappedKeyVersion {
+		return nil, ErrInvalidWrappedKey
+	}
+
+	keys, err := p.masterKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, mk := range keys {
+		if !bytes.Equal(mk.id, wrapped[1:1+masterKeyIDLen]) {
+			continue
+		}
+
+		aead, err := newAEAD(mk.key)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(wrapped) < 1+masterKeyIDLen+aead.NonceSize() {
+			return nil, ErrInvalidWrappedKey
+		}
+
+		nonce := wrapped[1+masterKeyIDLen : 1+masterKeyIDLen+aead.NonceSize()]
+
+		key, err := aead.Open(nil, nonce, wrapped[1+masterKeyIDLen+aead.NonceSize():], wrapped[:1+masterKeyIDLen])
+		if err != nil {
+			return nil, ErrInvalidWrappedKey
+		}
+
+		return key, nil
+	}
+
+	return nil, ErrUnknownMasterKey
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kms
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileKeyProvider(t *testing.T) {
+	path := "master.keys"
+	defer os.Remove(path)
+
+	p := NewFileKeyProvider(path)
+
+	_, err := p.WrapKey([]byte("data key"))
+	require.True(t, os.IsNotExist(err))
+
+	err = ioutil.WriteFile(path, []byte("# no keys yet\n\n"), 0600)
+	require.NoError(t, err)
+
+	_, err = p.WrapKey([]byte("data key"))
+	require.True(t, errors.Is(err, ErrKeyProviderFailure))
+
+	err = ioutil.WriteFile(path, []byte("not an hex key\n"), 0600)
+	require.NoError(t, err)
+
+	_, err = p.WrapKey([]byte("data key"))
+	require.True(t, errors.Is(err, ErrKeyProviderFailure))
+
+	masterKey1 := "000102030405060708090a0b0c0d0e0f000102030405060708090a0b0c0d0e0f"
+	masterKey2 := "0f0e0d0c0b0a09080706050403020100"
+
+	err = ioutil.WriteFile(path, []byte(masterKey1+"\n"), 0600)
+	require.NoError(t, err)
+
+	wrapped1, err := p.WrapKey([]byte("data key"))
+	require.NoError(t, err)
+
+	key, err := p.UnwrapKey(wrapped1)
+	require.NoError(t, err)
+	require.Equal(t, []byte("data key"), key)
+
+	// rotation, keys wrapped with the previous master key can still be unwrapped
+	err = ioutil.WriteFile(path, []byte(masterKey1+"\n"+masterKey2+"\n"), 0600)
+	require.NoError(t, err)
+
+	wrapped2, err := p.WrapKey([]byte("data key"))
+	require.NoError(t, err)
+	require.NotEqual(t, wrapped1[:1+masterKeyIDLen], wrapped2[:1+masterKeyIDLen])
+
+	key, err = p.UnwrapKey(wrapped1)
+	require.NoError(t, err)
+	require.Equal(t, []byte("data key"), key)
+
+	key, err = p.UnwrapKey(wrapped2)
+	require.NoError(t, err)
+	require.Equal(t, []byte("data key"), key)
+
+	tampered := append([]byte{}, wrapped2...)
+	tampered[len(tampered)-1] ^= 1
+
+	_, err = p.UnwrapKey(tampered)
+	require.Equal(t, ErrInvalidWrappedKey, err)
+
+	_, err = p.UnwrapKey(wrapped2[:1+masterKeyIDLen+1])
+	require.Equal(t, ErrInvalidWrappedKey, err)
+
+	_, err = p.UnwrapKey([]byte{2})
+	require.Equal(t, ErrInvalidWrappedKey, err)
+
+	// the previous master key is removed once every key was rewrapped
+	err = ioutil.WriteFile(path, []byte(masterKey2+"\n"), 0600)
+	require.NoError(t, err)
+
+	_, err = p.UnwrapKey(wrapped1)
+	require.Equal(t, ErrUnknownMasterKey, err)
+
+	key, err = p.UnwrapKey(wrapped2)
+	require.NoError(t, err)
+	require.Equal(t, []byte("data key"), key)
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kms
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+var ErrUnsupportedKeyProvider = errors.New("unsupported key provider")
+var ErrInvalidWrappedKey = errors.New("invalid wrapped key")
+var ErrUnknownMasterKey = errors.New("unknown master key")
+var ErrKeyProviderFailure = errors.New("key provider failure")
+
+const requestTimeout = 30 * time.Second
+
+// KeyProvider wraps data keys with a master key it holds, so master keys never need to be stored along with the data.
+// Master keys can be rotated, keys wrapped with a previous master key can still be unwrapped
+type KeyProvider interface {
+	WrapKey(key []byte) ([]byte, error)
+	UnwrapKey(wrapped []byte) ([]byte, error)
+}
+
+// NewKeyProvider returns the key provider described by the given url:
+//  file:///path/to/master.keys
+//  vault://transit/key-name?address=https://vault:8200, the token is read from VAULT_TOKEN
+//  awskms://alias/key-name?region=eu-west-1, credentials are read from AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN
+func NewKeyProvider(providerURL string) (KeyProvider, error) {
+	u, err := url.Parse(providerURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedKeyProvider, err)
+	}
+
+	name := strings.TrimPrefix(u.Host+u.Path, "/")
+	query := u.Query()
+
+	switch u.Scheme {
+	case "file":
+		if name == "" {
+			return nil, fmt.Errorf("%w: missing master keys file", ErrUnsupportedKeyProvider)
+		}
+
+		return NewFileKeyProvider(u.Host + u.Path), nil
+
+	case "vault":
+		i := strings.Index(name, "/")
+		if i <= 0 || i == len(name)-1 {
+			return nil, fmt.Errorf("%w: vault key must be specified as mount/key-name", ErrUnsupportedKeyProvider)
+		}
+
+		address := query.Get("address")
+		if address == "" {
+			address = os.Getenv("VAULT_ADDR")
+		}
+		if address == "" {
+			address = defaultVaultAddress
+		}
+
+		return NewVaultKeyProvider(address, name[:i], name[i+1:], os.Getenv("VAULT_TOKEN")), nil
+
+	case "awskms":
+		if name == "" {
+			return nil, fmt.Errorf("%w: missing aws kms key id", ErrUnsupportedKeyProvider)
+		}
+
+		region := query.Get("region")
+		if region == "" {
+			region = os.Getenv("AWS_REGION")
+		}
+		if region == "" {
+			return nil, fmt.Errorf("%w: missing aws region", ErrUnsupportedKeyProvider)
+		}
+
+		creds := &AWSCredentials{
+			AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+		}
+
+		return NewAWSKeyProvider(region, name, creds).WithEndpoint(query.Get("endpoint")), nil
+	}
+
+	return nil, fmt.Errorf("%w: '%s'", ErrUnsupportedKeyProvider, u.Scheme)
+}
+
+func newHTTPClient() *http.Client {
+	return &http.Client{Timeout: requestTimeout}
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kms
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewKeyProvider(t *testing.T) {
+	p, err := NewKeyProvider("file:///etc/immudb/master.keys")
+	require.NoError(t, err)
+	require.Equal(t, "/etc/immudb/master.keys", p.(*FileKeyProvider).path)
+
+	p, err = NewKeyProvider("file://master.keys")
+	require.NoError(t, err)
+	require.Equal(t, "master.keys", p.(*FileKeyProvider).path)
+
+	p, err = NewKeyProvider("vault://transit/immudb?address=https://vault:8200")
+	require.NoError(t, err)
+	require.Equal(t, "https://vault:8200", p.(*VaultKeyProvider).address)
+	require.Equal(t, "transit", p.(*VaultKeyProvider).mount)
+	require.Equal(t, "immudb", p.(*VaultKeyProvider).key)
+
+	p, err = NewKeyProvider("awskms://alias/immudb?region=eu-west-1")
+	require.NoError(t, err)
+	require.Equal(t, "alias/immudb", p.(*AWSKeyProvider).keyID)
+	require.Equal(t, "https://kms.eu-west-1.amazonaws.com", p.(*AWSKeyProvider).endpoint)
+
+	p, err = NewKeyProvider("awskms:///arn:aws:kms:eu-west-1:111122223333:key/1234?region=eu-west-1&endpoint=https://kms.internal/")
+	require.NoError(t, err)
+	require.Equal(t, "arn:aws:kms:eu-west-1:111122223333:key/1234", p.(*AWSKeyProvider).keyID)
+	require.Equal(t, "https://kms.internal", p.(*AWSKeyProvider).endpoint)
+
+	for _, invalid := range []string{
+		"",
+		"master.keys",
+		"file://",
+		"vault://transit",
+		"vault://transit/",
+		"awskms://?region=eu-west-1",
+		"gcpkms://projects/p/locations/l/keyRings/r/cryptoKeys/k",
+		"://",
+	} {
+		_, err = NewKeyProvider(invalid)
+		require.True(t, errors.Is(err, ErrUnsupportedKeyProvider), invalid)
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kms
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+const defaultVaultAddress = "http://127.0.0.1:8200"
+
+// VaultKeyProvider wraps keys with a key of the transit secrets engine of HashiCorp Vault,
+// master keys are rotated in Vault, which keeps unwrapping keys wrapped with previous versions
+type VaultKeyProvider struct {
+	address string
+	mount   string
+	key     string
+	token   string
+	client  *http.Client
+}
+
+func NewVaultKeyProvider(address, mount, key, token string) *VaultKeyProvider {
+	return &VaultKeyProvider{
+		address: strings.TrimSuffix(address, "/"),
+		mount:   mount,
+		key:     key,
+		token:   token,
+		client:  newHTTPClient(),
+	}
+}
+
+type vaultResponse struct {
+	Data struct {
+		Ciphertext string `json:"ciphertext"`
+		Plaintext  string `json:"plaintext"`
+	} `json:"data"`
+	Errors []string `json:"errors"`
+}
+
+func (p *VaultKeyProvider) WrapKey(key []byte) ([]byte, error) {
+	res, err := p.do("encrypt", map[string]string{"plaintext": base64.StdEncoding.EncodeToString(key)})
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Data.Ciphertext == "" {
+		return nil, fmt.Errorf("%w: vault returned no ciphertext", ErrKeyProviderFailure)
+	}
+
+	return []byte(res.Data.Ciphertext), nil
+}
+
+func (p *VaultKeyProvider) UnwrapKey(wrapped []byte) ([]byte, error) {
+	if !bytes.HasPrefix(wrapped, []byte("vault:")) {
+		return nil, ErrInvalidWrappedKey
+	}
+
+	res, err := p.do("decrypt", map[string]string{"ciphertext": string(wrapped)})
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := base64.StdEncoding.DecodeString(res.Data.Plaintext)
+	if err != nil || len(key) == 0 {
+		return nil, fmt.Errorf("%w: vault returned an invalid plaintext", ErrKeyProviderFailure)
+	}
+
+	return key, nil
+}
+
+func (p *VaultKeyProvider) do(op string, body map[string]string) (*vaultResponse, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/v1/%s/%s/%s", p.address, p.mount, op, p.key), bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Vault-Token", p.token)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrKeyProviderFailure, err)
+	}
+	defer resp.Body.Close()
+
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrKeyProviderFailure, err)
+	}
+
+	var res vaultResponse
+
+	err = json.Unmarshal(content, &res)
+	if err != nil && resp.StatusCode == http.StatusOK {
+		return nil, fmt.Errorf("%w: %v", ErrKeyProviderFailure, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: vault responded with status %d %s", ErrKeyProviderFailure, resp.StatusCode, strings.Join(res.Errors, ", "))
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kms
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVaultKeyProvider(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+
+		var body map[string]string
+
+		err := json.NewDecoder(r.Body).Decode(&body)
+		require.NoError(t, err)
+
+		switch r.URL.Path {
+		case "/v1/transit/encrypt/immudb":
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]string{"ciphertext": "vault:v1:" + body["plaintext"]}})
+		case "/v1/transit/decrypt/immudb":
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]string{"plaintext": strings.TrimPrefix(body["ciphertext"], "vault:v1:")}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors":[]}`))
+		}
+	}))
+	defer srv.Close()
+
+	p := NewVaultKeyProvider(srv.URL+"/", "transit", "immudb", "token")
+
+	wrapped, err := p.WrapKey([]byte("data key"))
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(string(wrapped), "vault:v1:"))
+
+	key, err := p.UnwrapKey(wrapped)
+	require.NoError(t, err)
+	require.Equal(t, []byte("data key"), key)
+
+	_, err = p.UnwrapKey([]byte("not wrapped by vault"))
+	require.Equal(t, ErrInvalidWrappedKey, err)
+
+	_, err = NewVaultKeyProvider(srv.URL, "transit", "immudb", "wrong token").WrapKey([]byte("data key"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "permission denied")
+
+	_, err = NewVaultKeyProvider(srv.URL, "transit", "unknown", "token").UnwrapKey(wrapped)
+	require.Error(t, err)
+}
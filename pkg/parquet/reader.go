@@ -0,0 +1,415 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parquet
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// File is the decoded content of a Parquet file
+type File struct {
+	Columns  []Column
+	Rows     [][]interface{}
+	Metadata map[string]string
+}
+
+// Read decodes a whole file as written by Writer, i.e. flat optional or required columns with
+// uncompressed and plain encoded data pages. Any other file is rejected with ErrUnsupportedFile
+func Read(r io.ReaderAt, size int64) (*File, error) {
+	if size < int64(2*len(magic)+4) {
+		return nil, ErrCorruptedFile
+	}
+
+	var tail [4 + len(magic)]byte
+
+	_, err := r.ReadAt(tail[:], size-int64(len(tail)))
+	if err != nil {
+		return nil, err
+	}
+
+	if string(tail[4:]) != magic {
+		return nil, ErrCorruptedFile
+	}
+
+	footerLen := int64(binary.LittleEndian.Uint32(tail[:]))
+	if footerLen > size-int64(len(tail)+len(magic)) {
+		return nil, ErrCorruptedFile
+	}
+
+	footer := make([]byte, footerLen)
+
+	_, err = r.ReadAt(footer, size-int64(len(tail))-footerLen)
+	if err != nil {
+		return nil, err
+	}
+
+	meta, err := newThriftReader(footer).readStruct()
+	if err != nil {
+		return nil, err
+	}
+
+	file, required, err := readSchema(meta)
+	if err != nil {
+		return nil, err
+	}
+
+	rowGroups, err := meta.structList(4)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rg := range rowGroups {
+		numRows, err := rg.int(3)
+		if err != nil {
+			return nil, err
+		}
+
+		chunks, err := rg.structList(1)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(chunks) != len(file.Columns) || numRows < 0 {
+			return nil, ErrCorruptedFile
+		}
+
+		rows := make([][]interface{}, numRows)
+		for i := range rows {
+			rows[i] = make([]interface{}, len(file.Columns))
+		}
+
+		for i, chunk := range chunks {
+			values, err := readColumnChunk(r, size, chunk, file.Columns[i].Type, required[i])
+			if err != nil {
+				return nil, err
+			}
+
+			if len(values) != len(rows) {
+				return nil, ErrCorruptedFile
+			}
+
+			for j, v := range values {
+				rows[j][i] = v
+			}
+		}
+
+		file.Rows = append(file.Rows, rows...)
+	}
+
+	if _, ok := meta[5]; ok {
+		kvs, err := meta.structList(5)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, kv := range kvs {
+			k, err := kv.string(1)
+			if err != nil {
+				return nil, err
+			}
+
+			v, _ := kv.string(2)
+
+			file.Metadata[k] = v
+		}
+	}
+
+	return file, nil
+}
+
+func readSchema(meta thriftFields) (*File, []bool, error) {
+	elements, err := meta.structList(2)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(elements) < 2 {
+		return nil, nil, ErrUnsupportedFile
+	}
+
+	children, err := elements[0].int(5)
+	if err != nil || children != int64(len(elements)-1) {
+		return nil, nil, ErrUnsupportedFile
+	}
+
+	file := &File{Metadata: make(map[string]string)}
+	required := make([]bool, len(elements)-1)
+
+	for i, e := range elements[1:] {
+		name, err := e.string(4)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		physical, err := e.int(1)
+		if err != nil {
+			return nil, nil, ErrUnsupportedFile
+		}
+
+		repetition, err := e.int(3)
+		if err != nil || (repetition != repetitionOptional && repetition != repetitionRequired) {
+			return nil, nil, ErrUnsupportedFile
+		}
+		required[i] = repetition == repetitionRequired
+
+		converted, err := e.int(6)
+		annotated := err == nil
+
+		var typ Type
+
+		switch {
+		case physical == physicalBoolean && !annotated:
+			typ = Boolean
+		case physical == physicalInt64 && (!annotated || converted == convertedUint64):
+			typ = Uint64
+		case physical == physicalByteArray && annotated && converted == convertedUTF8:
+			typ = String
+		case physical == physicalByteArray && !annotated:
+			typ = Bytes
+		default:
+			return nil, nil, ErrUnsupportedFile
+		}
+
+		file.Columns = append(file.Columns, Column{Name: name, Type: typ})
+	}
+
+	return file, required, nil
+}
+
+func readColumnChunk(r io.ReaderAt, size int64, chunk thriftFields, typ Type, required bool) ([]interface{}, error) {
+	meta, err := chunk.structValue(3)
+	if err != nil {
+		return nil, err
+	}
+
+	codec, err := meta.int(4)
+	if err != nil || codec != codecUncompressed {
+		return nil, ErrUnsupportedFile
+	}
+
+	numValues, err := meta.int(5)
+	if err != nil {
+		return nil, err
+	}
+
+	chunkSize, err := meta.int(7)
+	if err != nil {
+		return nil, err
+	}
+
+	offset, err := meta.int(9)
+	if err != nil {
+		return nil, err
+	}
+
+	if offset < int64(len(magic)) || chunkSize < 0 || offset+chunkSize > size || numValues < 0 {
+		return nil, ErrCorruptedFile
+	}
+
+	data := make([]byte, chunkSize)
+
+	_, err = r.ReadAt(data, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	var values []interface{}
+
+	for int64(len(values)) < numValues {
+		pr := newThriftReader(data)
+
+		header, err := pr.readStruct()
+		if err != nil {
+			return nil, err
+		}
+
+		pageType, err := header.int(1)
+		if err != nil || pageType != pageTypeData {
+			return nil, ErrUnsupportedFile
+		}
+
+		pageSize, err := header.int(3)
+		if err != nil {
+			return nil, err
+		}
+
+		dataHeader, err := header.structValue(5)
+		if err != nil {
+			return nil, err
+		}
+
+		pageValues, err := dataHeader.int(1)
+		if err != nil {
+			return nil, err
+		}
+
+		encoding, err := dataHeader.int(2)
+		if err != nil || encoding != encodingPlain {
+			return nil, ErrUnsupportedFile
+		}
+
+		data = data[pr.consumed():]
+		if pageSize < 0 || pageSize > int64(len(data)) || pageValues < 0 {
+			return nil, ErrCorruptedFile
+		}
+
+		page, err := decodePage(data[:pageSize], int(pageValues), typ, required)
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, page...)
+		data = data[pageSize:]
+	}
+
+	if int64(len(values)) != numValues {
+		return nil, ErrCorruptedFile
+	}
+
+	return values, nil
+}
+
+func decodePage(page []byte, numValues int, typ Type, required bool) ([]interface{}, error) {
+	defined := make([]bool, numValues)
+
+	if required {
+		for i := range defined {
+			defined[i] = true
+		}
+	} else {
+		if len(page) < 4 {
+			return nil, ErrCorruptedFile
+		}
+
+		levelsLen := int(binary.LittleEndian.Uint32(page))
+		if levelsLen > len(page)-4 {
+			return nil, ErrCorruptedFile
+		}
+
+		err := decodeDefinitionLevels(page[4:4+levelsLen], defined)
+		if err != nil {
+			return nil, err
+		}
+
+		page = page[4+levelsLen:]
+	}
+
+	values := make([]interface{}, numValues)
+
+	bit := 0
+
+	for i := range values {
+		if !defined[i] {
+			continue
+		}
+
+		switch typ {
+		case Boolean:
+			{
+				if bit/8 >= len(page) {
+					return nil, ErrCorruptedFile
+				}
+				values[i] = page[bit/8]&(1<<(bit%8)) != 0
+				bit++
+			}
+		case Uint64:
+			{
+				if len(page) < 8 {
+					return nil, ErrCorruptedFile
+				}
+				values[i] = binary.LittleEndian.Uint64(page)
+				page = page[8:]
+			}
+		default:
+			{
+				if len(page) < 4 {
+					return nil, ErrCorruptedFile
+				}
+				l := int(binary.LittleEndian.Uint32(page))
+				if l > len(page)-4 {
+					return nil, ErrCorruptedFile
+				}
+
+				b := make([]byte, l)
+				copy(b, page[4:4+l])
+				page = page[4+l:]
+
+				if typ == String {
+					values[i] = string(b)
+				} else {
+					values[i] = b
+				}
+			}
+		}
+	}
+
+	return values, nil
+}
+
+// decodeDefinitionLevels decodes levels encoded with the RLE hybrid encoding using a bit width of one
+func decodeDefinitionLevels(b []byte, defined []bool) error {
+	r := newThriftReader(b)
+
+	for i := 0; i < len(defined); {
+		header, err := r.uvarint()
+		if err != nil {
+			return err
+		}
+
+		if header&1 == 0 {
+			// rle run, the repeated value takes a single byte
+			v, err := r.r.ReadByte()
+			if err != nil {
+				return ErrCorruptedFile
+			}
+
+			count := int(header >> 1)
+			if count == 0 || count > len(defined)-i {
+				return ErrCorruptedFile
+			}
+
+			for j := 0; j < count; j++ {
+				defined[i+j] = v == 1
+			}
+			i += count
+
+			continue
+		}
+
+		// bit-packed run of groups of eight values
+		groups := int(header >> 1)
+
+		for j := 0; j < groups; j++ {
+			v, err := r.r.ReadByte()
+			if err != nil {
+				return ErrCorruptedFile
+			}
+
+			for k := 0; k < 8 && i < len(defined); k++ {
+				defined[i] = v&(1<<k) != 0
+				i++
+			}
+		}
+
+		if groups == 0 {
+			return ErrCorruptedFile
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,341 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+)
+
+// Parquet metadata is serialized with the thrift compact protocol, only the subset
+// of the protocol needed by the structures of the format is implemented
+
+const (
+	thriftStop        = 0
+	thriftTrue        = 1
+	thriftFalse       = 2
+	thriftByte        = 3
+	thriftI16         = 4
+	thriftI32         = 5
+	thriftI64         = 6
+	thriftDouble      = 7
+	thriftBinary      = 8
+	thriftList        = 9
+	thriftSet         = 10
+	thriftMap         = 11
+	thriftStruct      = 12
+	maxThriftNesting  = 32
+	maxThriftListSize = 1 << 24
+)
+
+type thriftWriter struct {
+	buf     bytes.Buffer
+	lastIDs []int16
+	lastID  int16
+}
+
+func (w *thriftWriter) bytes() []byte {
+	return w.buf.Bytes()
+}
+
+func (w *thriftWriter) uvarint(v uint64) {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(b[:], v)
+	w.buf.Write(b[:n])
+}
+
+func (w *thriftWriter) varint(v int64) {
+	w.uvarint(uint64((v << 1) ^ (v >> 63)))
+}
+
+func (w *thriftWriter) fieldHeader(id int16, typ byte) {
+	delta := id - w.lastID
+
+	if delta > 0 && delta <= 15 {
+		w.buf.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		w.buf.WriteByte(typ)
+		w.varint(int64(id))
+	}
+
+	w.lastID = id
+}
+
+func (w *thriftWriter) structBegin() {
+	w.lastIDs = append(w.lastIDs, w.lastID)
+	w.lastID = 0
+}
+
+func (w *thriftWriter) structEnd() {
+	w.buf.WriteByte(thriftStop)
+	w.lastID = w.lastIDs[len(w.lastIDs)-1]
+	w.lastIDs = w.lastIDs[:len(w.lastIDs)-1]
+}
+
+func (w *thriftWriter) listBegin(elemType byte, size int) {
+	if size < 15 {
+		w.buf.WriteByte(byte(size)<<4 | elemType)
+		return
+	}
+
+	w.buf.WriteByte(0xf0 | elemType)
+	w.uvarint(uint64(size))
+}
+
+func (w *thriftWriter) i32Field(id int16, v int32) {
+	w.fieldHeader(id, thriftI32)
+	w.varint(int64(v))
+}
+
+func (w *thriftWriter) i64Field(id int16, v int64) {
+	w.fieldHeader(id, thriftI64)
+	w.varint(v)
+}
+
+func (w *thriftWriter) binary(v []byte) {
+	w.uvarint(uint64(len(v)))
+	w.buf.Write(v)
+}
+
+func (w *thriftWriter) stringField(id int16, v string) {
+	w.fieldHeader(id, thriftBinary)
+	w.binary([]byte(v))
+}
+
+func (w *thriftWriter) structField(id int16) {
+	w.fieldHeader(id, thriftStruct)
+	w.structBegin()
+}
+
+func (w *thriftWriter) listField(id int16, elemType byte, size int) {
+	w.fieldHeader(id, thriftList)
+	w.listBegin(elemType, size)
+}
+
+// thriftFields is a decoded struct, fields are indexed by id. Values are int64 for
+// integers, bool, []byte for binaries, []interface{} for lists and thriftFields for structs
+type thriftFields map[int16]interface{}
+
+type thriftReader struct {
+	r     *bytes.Reader
+	depth int
+}
+
+func newThriftReader(b []byte) *thriftReader {
+	return &thriftReader{r: bytes.NewReader(b)}
+}
+
+// consumed returns the number of bytes read so far
+func (r *thriftReader) consumed() int {
+	return int(r.r.Size()) - r.r.Len()
+}
+
+func (r *thriftReader) uvarint() (uint64, error) {
+	v, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		return 0, ErrCorruptedFile
+	}
+	return v, nil
+}
+
+func (r *thriftReader) varint() (int64, error) {
+	v, err := r.uvarint()
+	if err != nil {
+		return 0, err
+	}
+	return int64(v>>1) ^ -int64(v&1), nil
+}
+
+func (r *thriftReader) readStruct() (thriftFields, error) {
+	r.depth++
+	defer func() { r.depth-- }()
+
+	if r.depth > maxThriftNesting {
+		return nil, ErrCorruptedFile
+	}
+
+	fields := make(thriftFields)
+
+	var lastID int16
+
+	for {
+		b, err := r.r.ReadByte()
+		if err != nil {
+			return nil, ErrCorruptedFile
+		}
+
+		typ := b & 0x0f
+		if typ == thriftStop {
+			return fields, nil
+		}
+
+		id := lastID + int16(b>>4)
+		if b>>4 == 0 {
+			v, err := r.varint()
+			if err != nil {
+				return nil, err
+			}
+			id = int16(v)
+		}
+		lastID = id
+
+		if typ == thriftTrue || typ == thriftFalse {
+			fields[id] = typ == thriftTrue
+			continue
+		}
+
+		fields[id], err = r.readValue(typ)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (r *thriftReader) readValue(typ byte) (interface{}, error) {
+	switch typ {
+	case thriftTrue, thriftFalse:
+		{
+			b, err := r.r.ReadByte()
+			if err != nil {
+				return nil, ErrCorruptedFile
+			}
+			return b == thriftTrue, nil
+		}
+	case thriftByte:
+		{
+			b, err := r.r.ReadByte()
+			if err != nil {
+				return nil, ErrCorruptedFile
+			}
+			return int64(int8(b)), nil
+		}
+	case thriftI16, thriftI32, thriftI64:
+		{
+			return r.varint()
+		}
+	case thriftDouble:
+		{
+			var b [8]byte
+			_, err := io.ReadFull(r.r, b[:])
+			if err != nil {
+				return nil, ErrCorruptedFile
+			}
+			return b[:], nil
+		}
+	case thriftBinary:
+		{
+			size, err := r.uvarint()
+			if err != nil {
+				return nil, err
+			}
+			if size > uint64(r.r.Len()) {
+				return nil, ErrCorruptedFile
+			}
+			b := make([]byte, size)
+			_, err = io.ReadFull(r.r, b)
+			if err != nil {
+				return nil, ErrCorruptedFile
+			}
+			return b, nil
+		}
+	case thriftList, thriftSet:
+		{
+			return r.readList()
+		}
+	case thriftStruct:
+		{
+			return r.readStruct()
+		}
+	}
+
+	return nil, ErrCorruptedFile
+}
+
+func (r *thriftReader) readList() ([]interface{}, error) {
+	b, err := r.r.ReadByte()
+	if err != nil {
+		return nil, ErrCorruptedFile
+	}
+
+	elemType := b & 0x0f
+	size := uint64(b >> 4)
+
+	if size == 15 {
+		size, err = r.uvarint()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if size > maxThriftListSize {
+		return nil, ErrCorruptedFile
+	}
+
+	list := make([]interface{}, size)
+
+	for i := range list {
+		list[i], err = r.readValue(elemType)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return list, nil
+}
+
+func (f thriftFields) int(id int16) (int64, error) {
+	v, ok := f[id].(int64)
+	if !ok {
+		return 0, ErrCorruptedFile
+	}
+	return v, nil
+}
+
+func (f thriftFields) string(id int16) (string, error) {
+	v, ok := f[id].([]byte)
+	if !ok {
+		return "", ErrCorruptedFile
+	}
+	return string(v), nil
+}
+
+func (f thriftFields) structList(id int16) ([]thriftFields, error) {
+	list, ok := f[id].([]interface{})
+	if !ok {
+		return nil, ErrCorruptedFile
+	}
+
+	structs := make([]thriftFields, len(list))
+
+	for i, e := range list {
+		structs[i], ok = e.(thriftFields)
+		if !ok {
+			return nil, ErrCorruptedFile
+		}
+	}
+
+	return structs, nil
+}
+
+func (f thriftFields) structValue(id int16) (thriftFields, error) {
+	v, ok := f[id].(thriftFields)
+	if !ok {
+		return nil, ErrCorruptedFile
+	}
+	return v, nil
+}
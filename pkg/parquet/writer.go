@@ -0,0 +1,513 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+var ErrIllegalArguments = errors.New("illegal arguments")
+var ErrInvalidValue = errors.New("invalid value")
+var ErrAlreadyClosed = errors.New("already closed")
+var ErrCorruptedFile = errors.New("corrupted parquet file")
+var ErrUnsupportedFile = errors.New("unsupported parquet file")
+
+const magic = "PAR1"
+
+const DefaultRowGroupSize = 64 << 20
+
+const createdBy = "immudb"
+
+// Type is the type of the values of a column, every column is optional so values may be null as well
+type Type int
+
+const (
+	// Boolean columns hold bool values
+	Boolean Type = iota
+	// Uint64 columns hold uint64 values, stored as INT64 annotated as UINT_64
+	Uint64
+	// String columns hold string values, stored as BYTE_ARRAY annotated as UTF8
+	String
+	// Bytes columns hold []byte values, stored as BYTE_ARRAY
+	Bytes
+)
+
+func (t Type) String() string {
+	switch t {
+	case Boolean:
+		return "BOOLEAN"
+	case Uint64:
+		return "UINT64"
+	case String:
+		return "STRING"
+	case Bytes:
+		return "BYTES"
+	}
+	return "UNKNOWN"
+}
+
+// physical types, converted types, encodings and enums as defined by parquet.thrift
+const (
+	physicalBoolean   = 0
+	physicalInt64     = 2
+	physicalByteArray = 6
+
+	convertedUTF8   = 0
+	convertedUint64 = 14
+
+	repetitionRequired = 0
+	repetitionOptional = 1
+
+	encodingPlain = 0
+	encodingRLE   = 3
+
+	codecUncompressed = 0
+
+	pageTypeData = 0
+)
+
+func (t Type) physicalType() int32 {
+	switch t {
+	case Boolean:
+		return physicalBoolean
+	case Uint64:
+		return physicalInt64
+	}
+	return physicalByteArray
+}
+
+// Column describes a column of the file
+type Column struct {
+	Name string
+	Type Type
+}
+
+type Options struct {
+	// RowGroupSize is the approximate size in bytes of the values buffered before a row group is written
+	RowGroupSize int
+
+	// Metadata is stored as key-value metadata in the footer of the file
+	Metadata map[string]string
+}
+
+func DefaultOptions() *Options {
+	return &Options{
+		RowGroupSize: DefaultRowGroupSize,
+		Metadata:     make(map[string]string),
+	}
+}
+
+func (opts *Options) WithRowGroupSize(size int) *Options {
+	opts.RowGroupSize = size
+	return opts
+}
+
+func (opts *Options) WithMetadata(key, value string) *Options {
+	if opts.Metadata == nil {
+		opts.Metadata = make(map[string]string)
+	}
+	opts.Metadata[key] = value
+	return opts
+}
+
+// columnBuffer holds the values of a column written since the last row group
+type columnBuffer struct {
+	defined []bool
+	bools   []bool
+	values  bytes.Buffer
+}
+
+type columnChunk struct {
+	offset           int64
+	numValues        int64
+	uncompressedSize int64
+}
+
+type rowGroup struct {
+	chunks  []columnChunk
+	size    int64
+	numRows int64
+}
+
+// Writer writes rows into a Parquet file, all the rows of a row group are buffered in memory
+// and written as a single uncompressed, plain encoded data page per column
+type Writer struct {
+	w    io.Writer
+	cols []Column
+	opts *Options
+
+	offset  int64
+	buffers []*columnBuffer
+
+	buffered  int
+	rows      int64
+	numRows   int64
+	rowGroups []rowGroup
+
+	closed bool
+}
+
+// NewWriter creates a writer of a file holding the given columns, the magic number is written right away
+func NewWriter(w io.Writer, cols []Column, opts *Options) (*Writer, error) {
+	if w == nil || len(cols) == 0 || opts == nil || opts.RowGroupSize <= 0 {
+		return nil, ErrIllegalArguments
+	}
+
+	names := make(map[string]struct{}, len(cols))
+
+	for _, c := range cols {
+		if c.Name == "" || c.Type < Boolean || c.Type > Bytes {
+			return nil, ErrIllegalArguments
+		}
+
+		_, duplicated := names[c.Name]
+		if duplicated {
+			return nil, fmt.Errorf("%w: duplicated column %s", ErrIllegalArguments, c.Name)
+		}
+		names[c.Name] = struct{}{}
+	}
+
+	pw := &Writer{
+		w:       w,
+		cols:    cols,
+		opts:    opts,
+		buffers: make([]*columnBuffer, len(cols)),
+	}
+
+	for i := range pw.buffers {
+		pw.buffers[i] = &columnBuffer{}
+	}
+
+	err := pw.write([]byte(magic))
+	if err != nil {
+		return nil, err
+	}
+
+	return pw, nil
+}
+
+func (w *Writer) write(b []byte) error {
+	n, err := w.w.Write(b)
+	w.offset += int64(n)
+	return err
+}
+
+// Write adds a row to the file, values are given in the order of the columns and nil is written as null
+func (w *Writer) Write(values []interface{}) error {
+	if w.closed {
+		return ErrAlreadyClosed
+	}
+
+	if len(values) != len(w.cols) {
+		return ErrIllegalArguments
+	}
+
+	for i, v := range values {
+		if v == nil {
+			continue
+		}
+
+		valid := false
+
+		switch w.cols[i].Type {
+		case Boolean:
+			_, valid = v.(bool)
+		case Uint64:
+			_, valid = v.(uint64)
+		case String:
+			_, valid = v.(string)
+		case Bytes:
+			_, valid = v.([]byte)
+		}
+
+		if !valid {
+			return fmt.Errorf("%w: column %s expects %s values", ErrInvalidValue, w.cols[i].Name, w.cols[i].Type)
+		}
+	}
+
+	for i, v := range values {
+		buf := w.buffers[i]
+
+		buf.defined = append(buf.defined, v != nil)
+		w.buffered++
+
+		if v == nil {
+			continue
+		}
+
+		switch tv := v.(type) {
+		case bool:
+			buf.bools = append(buf.bools, tv)
+		case uint64:
+			var b [8]byte
+			binary.LittleEndian.PutUint64(b[:], tv)
+			buf.values.Write(b[:])
+			w.buffered += 8
+		case string:
+			w.writeByteArray(buf, []byte(tv))
+		case []byte:
+			w.writeByteArray(buf, tv)
+		}
+	}
+
+	w.rows++
+
+	if w.buffered >= w.opts.RowGroupSize {
+		return w.flush()
+	}
+
+	return nil
+}
+
+func (w *Writer) writeByteArray(buf *columnBuffer, v []byte) {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], uint32(len(v)))
+	buf.values.Write(b[:])
+	buf.values.Write(v)
+	w.buffered += 4 + len(v)
+}
+
+// flush writes the buffered rows as a new row group
+func (w *Writer) flush() error {
+	if w.rows == 0 {
+		return nil
+	}
+
+	rg := rowGroup{
+		chunks:  make([]columnChunk, len(w.cols)),
+		numRows: w.rows,
+	}
+
+	for i, buf := range w.buffers {
+		page := encodeDefinitionLevels(buf.defined)
+
+		if w.cols[i].Type == Boolean {
+			page = append(page, packBools(buf.bools)...)
+		} else {
+			page = append(page, buf.values.Bytes()...)
+		}
+
+		header := &thriftWriter{}
+		header.structBegin()
+		header.i32Field(1, pageTypeData)
+		header.i32Field(2, int32(len(page)))
+		header.i32Field(3, int32(len(page)))
+		header.structField(5)
+		header.i32Field(1, int32(len(buf.defined)))
+		header.i32Field(2, encodingPlain)
+		header.i32Field(3, encodingRLE)
+		header.i32Field(4, encodingRLE)
+		header.structEnd()
+		header.structEnd()
+
+		rg.chunks[i] = columnChunk{
+			offset:           w.offset,
+			numValues:        int64(len(buf.defined)),
+			uncompressedSize: int64(len(header.bytes()) + len(page)),
+		}
+		rg.size += rg.chunks[i].uncompressedSize
+
+		err := w.write(header.bytes())
+		if err != nil {
+			return err
+		}
+
+		err = w.write(page)
+		if err != nil {
+			return err
+		}
+
+		w.buffers[i] = &columnBuffer{}
+	}
+
+	w.rowGroups = append(w.rowGroups, rg)
+	w.numRows += w.rows
+	w.rows = 0
+	w.buffered = 0
+
+	return nil
+}
+
+// encodeDefinitionLevels encodes the definition levels of optional values with the RLE hybrid
+// encoding using a bit width of one, prefixed with the length of the encoded data
+func encodeDefinitionLevels(defined []bool) []byte {
+	enc := &thriftWriter{}
+
+	for i := 0; i < len(defined); {
+		j := i + 1
+		for j < len(defined) && defined[j] == defined[i] {
+			j++
+		}
+
+		enc.uvarint(uint64(j-i) << 1)
+
+		if defined[i] {
+			enc.buf.WriteByte(1)
+		} else {
+			enc.buf.WriteByte(0)
+		}
+
+		i = j
+	}
+
+	b := make([]byte, 4+enc.buf.Len())
+	binary.LittleEndian.PutUint32(b, uint32(enc.buf.Len()))
+	copy(b[4:], enc.buf.Bytes())
+
+	return b
+}
+
+// packBools plain encodes booleans, one bit per value starting from the least significant one
+func packBools(values []bool) []byte {
+	b := make([]byte, (len(values)+7)/8)
+
+	for i, v := range values {
+		if v {
+			b[i/8] |= 1 << (i % 8)
+		}
+	}
+
+	return b
+}
+
+// Close writes the remaining rows and the footer of the file, the underlying writer is not closed
+func (w *Writer) Close() error {
+	if w.closed {
+		return ErrAlreadyClosed
+	}
+
+	w.closed = true
+
+	err := w.flush()
+	if err != nil {
+		return err
+	}
+
+	footer := w.fileMetadata()
+
+	err = w.write(footer)
+	if err != nil {
+		return err
+	}
+
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], uint32(len(footer)))
+
+	err = w.write(b[:])
+	if err != nil {
+		return err
+	}
+
+	return w.write([]byte(magic))
+}
+
+// NumRows returns the number of rows written so far
+func (w *Writer) NumRows() int64 {
+	return w.numRows + w.rows
+}
+
+func (w *Writer) fileMetadata() []byte {
+	m := &thriftWriter{}
+
+	m.structBegin()
+	m.i32Field(1, 1)
+
+	m.listField(2, thriftStruct, len(w.cols)+1)
+
+	m.structBegin()
+	m.stringField(4, "schema")
+	m.i32Field(5, int32(len(w.cols)))
+	m.structEnd()
+
+	for _, c := range w.cols {
+		m.structBegin()
+		m.i32Field(1, c.Type.physicalType())
+		m.i32Field(3, repetitionOptional)
+		m.stringField(4, c.Name)
+
+		switch c.Type {
+		case Uint64:
+			m.i32Field(6, convertedUint64)
+		case String:
+			m.i32Field(6, convertedUTF8)
+		}
+
+		m.structEnd()
+	}
+
+	m.i64Field(3, w.numRows)
+
+	m.listField(4, thriftStruct, len(w.rowGroups))
+
+	for _, rg := range w.rowGroups {
+		m.structBegin()
+
+		m.listField(1, thriftStruct, len(rg.chunks))
+
+		for i, chunk := range rg.chunks {
+			m.structBegin()
+			m.i64Field(2, chunk.offset)
+
+			m.structField(3)
+			m.i32Field(1, w.cols[i].Type.physicalType())
+			m.listField(2, thriftI32, 2)
+			m.varint(encodingPlain)
+			m.varint(encodingRLE)
+			m.listField(3, thriftBinary, 1)
+			m.binary([]byte(w.cols[i].Name))
+			m.i32Field(4, codecUncompressed)
+			m.i64Field(5, chunk.numValues)
+			m.i64Field(6, chunk.uncompressedSize)
+			m.i64Field(7, chunk.uncompressedSize)
+			m.i64Field(9, chunk.offset)
+			m.structEnd()
+
+			m.structEnd()
+		}
+
+		m.i64Field(2, rg.size)
+		m.i64Field(3, rg.numRows)
+		m.structEnd()
+	}
+
+	if len(w.opts.Metadata) > 0 {
+		keys := make([]string, 0, len(w.opts.Metadata))
+		for k := range w.opts.Metadata {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		m.listField(5, thriftStruct, len(keys))
+
+		for _, k := range keys {
+			m.structBegin()
+			m.stringField(1, k)
+			m.stringField(2, w.opts.Metadata[k])
+			m.structEnd()
+		}
+	}
+
+	m.stringField(6, createdBy)
+	m.structEnd()
+
+	return m.bytes()
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parquet
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testColumns = []Column{
+	{Name: "id", Type: Uint64},
+	{Name: "title", Type: String},
+	{Name: "active", Type: Boolean},
+	{Name: "payload", Type: Bytes},
+}
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+
+	w, err := NewWriter(&buf, testColumns, DefaultOptions().WithRowGroupSize(256).WithMetadata("immudb.table", "table1"))
+	require.NoError(t, err)
+
+	var rows [][]interface{}
+
+	for i := 0; i < 100; i++ {
+		row := []interface{}{uint64(i), fmt.Sprintf("title%d", i), i%3 == 0, []byte{byte(i), 0}}
+
+		if i%5 == 0 {
+			row[1] = nil
+		}
+		if i%7 == 0 {
+			row[2] = nil
+			row[3] = nil
+		}
+
+		err = w.Write(row)
+		require.NoError(t, err)
+
+		rows = append(rows, row)
+	}
+
+	require.Equal(t, int64(100), w.NumRows())
+
+	err = w.Close()
+	require.NoError(t, err)
+
+	err = w.Close()
+	require.Equal(t, ErrAlreadyClosed, err)
+
+	err = w.Write(rows[0])
+	require.Equal(t, ErrAlreadyClosed, err)
+
+	b := buf.Bytes()
+	require.Equal(t, magic, string(b[:4]))
+	require.Equal(t, magic, string(b[len(b)-4:]))
+
+	require.Greater(t, len(w.rowGroups), 1)
+
+	f, err := Read(bytes.NewReader(b), int64(len(b)))
+	require.NoError(t, err)
+	require.Equal(t, testColumns, f.Columns)
+	require.Equal(t, rows, f.Rows)
+	require.Equal(t, map[string]string{"immudb.table": "table1"}, f.Metadata)
+}
+
+func TestWriterWithoutRows(t *testing.T) {
+	var buf bytes.Buffer
+
+	w, err := NewWriter(&buf, testColumns, DefaultOptions())
+	require.NoError(t, err)
+
+	err = w.Close()
+	require.NoError(t, err)
+
+	f, err := Read(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.Equal(t, testColumns, f.Columns)
+	require.Empty(t, f.Rows)
+	require.Empty(t, f.Metadata)
+}
+
+func TestWriterLongDefinitionLevelRuns(t *testing.T) {
+	var buf bytes.Buffer
+
+	cols := []Column{{Name: "flag", Type: Boolean}}
+
+	w, err := NewWriter(&buf, cols, DefaultOptions())
+	require.NoError(t, err)
+
+	var rows [][]interface{}
+
+	for i := 0; i < 1000; i++ {
+		var v interface{}
+		if i >= 300 && i < 700 {
+			v = i%2 == 0
+		}
+
+		err = w.Write([]interface{}{v})
+		require.NoError(t, err)
+
+		rows = append(rows, []interface{}{v})
+	}
+
+	err = w.Close()
+	require.NoError(t, err)
+
+	f, err := Read(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.Equal(t, rows, f.Rows)
+}
+
+func TestWriterInvalidArguments(t *testing.T) {
+	var buf bytes.Buffer
+
+	_, err := NewWriter(nil, testColumns, DefaultOptions())
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = NewWriter(&buf, nil, DefaultOptions())
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = NewWriter(&buf, testColumns, nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = NewWriter(&buf, testColumns, DefaultOptions().WithRowGroupSize(0))
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = NewWriter(&buf, []Column{{Name: "", Type: Bytes}}, DefaultOptions())
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = NewWriter(&buf, []Column{{Name: "c", Type: Bytes + 1}}, DefaultOptions())
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = NewWriter(&buf, []Column{{Name: "c", Type: Bytes}, {Name: "c", Type: String}}, DefaultOptions())
+	require.True(t, errors.Is(err, ErrIllegalArguments))
+
+	w, err := NewWriter(&buf, testColumns, DefaultOptions())
+	require.NoError(t, err)
+
+	err = w.Write([]interface{}{uint64(1)})
+	require.Equal(t, ErrIllegalArguments, err)
+
+	err = w.Write([]interface{}{1, "title", true, nil})
+	require.True(t, errors.Is(err, ErrInvalidValue))
+
+	err = w.Write([]interface{}{uint64(1), []byte("title"), true, nil})
+	require.True(t, errors.Is(err, ErrInvalidValue))
+
+	require.Equal(t, int64(0), w.NumRows())
+}
+
+func TestReadCorruptedFile(t *testing.T) {
+	var buf bytes.Buffer
+
+	w, err := NewWriter(&buf, testColumns, DefaultOptions())
+	require.NoError(t, err)
+
+	err = w.Write([]interface{}{uint64(1), "title1", true, []byte{1}})
+	require.NoError(t, err)
+
+	err = w.Close()
+	require.NoError(t, err)
+
+	b := buf.Bytes()
+
+	_, err = Read(bytes.NewReader(b[:8]), 8)
+	require.Equal(t, ErrCorruptedFile, err)
+
+	_, err = Read(bytes.NewReader(b[:len(b)-1]), int64(len(b)-1))
+	require.Equal(t, ErrCorruptedFile, err)
+
+	corrupted := append([]byte{}, b...)
+	corrupted[len(corrupted)-8] = 0xff
+
+	_, err = Read(bytes.NewReader(corrupted), int64(len(corrupted)))
+	require.Equal(t, ErrCorruptedFile, err)
+}
@@ -0,0 +1,333 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/codenotary/immudb/pkg/auth"
+	bm "github.com/codenotary/immudb/pkg/pgsql/server/bmessages"
+	fm "github.com/codenotary/immudb/pkg/pgsql/server/fmessages"
+)
+
+// authentication methods of the clients, named as in pg_hba.conf
+const (
+	AuthMethodPassword    = "password"
+	AuthMethodMD5         = "md5"
+	AuthMethodSCRAMSHA256 = "scram-sha-256"
+)
+
+const scramNonceLen = 18
+
+const mockAuthSecretLen = 32
+
+// mockAuthSecret derives the salts of the SCRAM exchanges run for unknown users, it's generated once per process
+var mockAuthSecret struct {
+	once   sync.Once
+	secret []byte
+	err    error
+}
+
+func getMockAuthSecret() ([]byte, error) {
+	mockAuthSecret.once.Do(func() {
+		mockAuthSecret.secret = make([]byte, mockAuthSecretLen)
+		_, mockAuthSecret.err = rand.Read(mockAuthSecret.secret)
+	})
+
+	return mockAuthSecret.secret, mockAuthSecret.err
+}
+
+// authenticate runs the authentication flow of the method of the server and returns the authenticated user
+func (s *session) authenticate() (*auth.User, error) {
+	switch s.authMethod {
+	case AuthMethodMD5:
+		return s.md5Authentication()
+	case AuthMethodSCRAMSHA256:
+		return s.scramAuthentication()
+	default:
+		return s.cleartextAuthentication()
+	}
+}
+
+func (s *session) cleartextAuthentication() (*auth.User, error) {
+	if _, err := s.writeMessage(bm.AuthenticationCleartextPassword()); err != nil {
+		return nil, err
+	}
+
+	pw, err := s.readPassword()
+	if err != nil {
+		return nil, err
+	}
+
+	usr, err := s.lookupUser()
+	if err != nil {
+		return nil, err
+	}
+
+	// unknown users take as long as wrong passwords to be rejected
+	if usr == nil {
+		auth.CompareMockPassword([]byte(pw))
+		return nil, ErrAuthenticationFailed
+	}
+
+	if usr.ComparePasswords([]byte(pw)) != nil {
+		return nil, ErrAuthenticationFailed
+	}
+
+	return usr, nil
+}
+
+func (s *session) md5Authentication() (*auth.User, error) {
+	var salt [4]byte
+	if _, err := rand.Read(salt[:]); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.writeMessage(bm.AuthenticationMD5Password(salt)); err != nil {
+		return nil, err
+	}
+
+	pw, err := s.readPassword()
+	if err != nil {
+		return nil, err
+	}
+
+	usr, err := s.lookupUser()
+	if err != nil {
+		return nil, err
+	}
+
+	if usr == nil || usr.MD5Password == "" {
+		if usr != nil {
+			s.log.Warningf("pgsql: %v (user '%s')", ErrMissingPasswordVerifier, s.username)
+		}
+
+		// the response is checked against a mock password, so unknown users take as long as wrong passwords to be rejected
+		secret, err := getMockAuthSecret()
+		if err != nil {
+			return nil, err
+		}
+
+		auth.VerifyMD5Response(auth.MD5Password(s.username, secret), salt[:], pw)
+
+		return nil, ErrAuthenticationFailed
+	}
+
+	if !auth.VerifyMD5Response(usr.MD5Password, salt[:], pw) {
+		return nil, ErrAuthenticationFailed
+	}
+
+	return usr, nil
+}
+
+// scramAuthentication implements SCRAM-SHA-256 without channel binding, as described in
+// https://www.postgresql.org/docs/current/sasl-authentication.html
+func (s *session) scramAuthentication() (*auth.User, error) {
+	if _, err := s.writeMessage(bm.AuthenticationSASL(auth.SCRAMMechanism)); err != nil {
+		return nil, err
+	}
+
+	payload, err := s.readPasswordPayload()
+	if err != nil {
+		return nil, err
+	}
+
+	initial, err := fm.ParseSASLInitialResponseMsg(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if initial.Mechanism != auth.SCRAMMechanism {
+		return nil, ErrUnsupportedSASLMechanism
+	}
+
+	gs2Header, clientFirstBare, clientNonce, err := parseSCRAMClientFirst(string(initial.Data))
+	if err != nil {
+		return nil, err
+	}
+
+	usr, verifier, err := s.scramVerifier()
+	if err != nil {
+		return nil, err
+	}
+
+	serverNonce := make([]byte, scramNonceLen)
+	if _, err := rand.Read(serverNonce); err != nil {
+		return nil, err
+	}
+
+	nonce := clientNonce + base64.StdEncoding.EncodeToString(serverNonce)
+
+	serverFirst := fmt.Sprintf("r=%s,s=%s,i=%d", nonce, base64.StdEncoding.EncodeToString(verifier.Salt), verifier.Iterations)
+
+	if _, err := s.writeMessage(bm.AuthenticationSASLContinue([]byte(serverFirst))); err != nil {
+		return nil, err
+	}
+
+	payload, err = s.readPasswordPayload()
+	if err != nil {
+		return nil, err
+	}
+
+	clientFinalWithoutProof, proof, err := parseSCRAMClientFinal(string(fm.ParseSASLResponseMsg(payload).Data), gs2Header, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	authMessage := []byte(clientFirstBare + "," + serverFirst + "," + clientFinalWithoutProof)
+
+	// users that don't exist are only told apart once the exchange is over, as in PostgreSQL
+	if usr == nil || !verifier.VerifyClientProof(authMessage, proof) {
+		return nil, ErrAuthenticationFailed
+	}
+
+	serverFinal := "v=" + base64.StdEncoding.EncodeToString(verifier.ServerSignature(authMessage))
+
+	if _, err := s.writeMessage(bm.AuthenticationSASLFinal([]byte(serverFinal))); err != nil {
+		return nil, err
+	}
+
+	return usr, nil
+}
+
+// scramVerifier returns the user and its SCRAM verifier. A mock verifier and a nil user are returned if the
+// user doesn't exist or its verifier wasn't stored, so the exchange goes on without revealing it
+func (s *session) scramVerifier() (*auth.User, *auth.SCRAMVerifier, error) {
+	usr, err := s.lookupUser()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if usr != nil && usr.SCRAMVerifier != "" {
+		verifier, err := auth.ParseSCRAMVerifier(usr.SCRAMVerifier)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return usr, verifier, nil
+	}
+
+	if usr != nil {
+		s.log.Warningf("pgsql: %v (user '%s')", ErrMissingPasswordVerifier, s.username)
+	}
+
+	secret, err := getMockAuthSecret()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return nil, auth.NewMockSCRAMVerifier(s.username, secret), nil
+}
+
+// parseSCRAMClientFirst splits the client-first-message into its gs2 header and its bare part.
+// The user name it holds is ignored, the one of the startup message is used instead
+func parseSCRAMClientFirst(msg string) (gs2Header, bare, nonce string, err error) {
+	parts := strings.SplitN(msg, ",", 3)
+	if len(parts) != 3 {
+		return "", "", "", fm.ErrMalformedMessage
+	}
+
+	switch {
+	case parts[0] == "n", parts[0] == "y":
+	case strings.HasPrefix(parts[0], "p="):
+		return "", "", "", ErrChannelBindingNotSupported
+	default:
+		return "", "", "", fm.ErrMalformedMessage
+	}
+
+	gs2Header = parts[0] + "," + parts[1] + ","
+	bare = parts[2]
+
+	attrs := strings.Split(bare, ",")
+	if len(attrs) < 2 || !strings.HasPrefix(attrs[0], "n=") || !strings.HasPrefix(attrs[1], "r=") || len(attrs[1]) == 2 {
+		return "", "", "", fm.ErrMalformedMessage
+	}
+
+	return gs2Header, bare, attrs[1][2:], nil
+}
+
+// parseSCRAMClientFinal checks the channel binding and the nonce of the client-final-message,
+// and splits the proof off of it
+func parseSCRAMClientFinal(msg, gs2Header, nonce string) (withoutProof string, proof []byte, err error) {
+	i := strings.LastIndex(msg, ",p=")
+	if i < 0 {
+		return "", nil, fm.ErrMalformedMessage
+	}
+
+	withoutProof = msg[:i]
+
+	proof, err = base64.StdEncoding.DecodeString(msg[i+3:])
+	if err != nil {
+		return "", nil, fm.ErrMalformedMessage
+	}
+
+	attrs := strings.Split(withoutProof, ",")
+	if len(attrs) < 2 ||
+		attrs[0] != "c="+base64.StdEncoding.EncodeToString([]byte(gs2Header)) ||
+		attrs[1] != "r="+nonce {
+		return "", nil, fm.ErrMalformedMessage
+	}
+
+	return withoutProof, proof, nil
+}
+
+// readPassword reads the password message sent in response to a cleartext or md5 authentication request
+func (s *session) readPassword() (string, error) {
+	payload, err := s.readPasswordPayload()
+	if err != nil {
+		return "", err
+	}
+
+	pw := fm.ParsePasswordMsg(payload)
+	if pw.GetSecret() == "" {
+		return "", ErrPwNotprovided
+	}
+
+	return pw.GetSecret(), nil
+}
+
+// readPasswordPayload reads the payload of a 'p' message, its content depends on the authentication in progress
+func (s *session) readPasswordPayload() ([]byte, error) {
+	msg, err := s.mr.ReadRawMessage()
+	if err != nil {
+		return nil, err
+	}
+
+	if msg.t != 'p' {
+		return nil, ErrPwNotprovided
+	}
+
+	return msg.payload, nil
+}
+
+// lookupUser returns the user of the session, or nil if it doesn't exist. Authentication methods
+// must fail the same way for unknown users and for wrong passwords, so users can't be enumerated
+func (s *session) lookupUser() (*auth.User, error) {
+	usr, err := s.getUser([]byte(s.username))
+	if err != nil {
+		if strings.Contains(err.Error(), "key not found") {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return usr, nil
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	bm "github.com/codenotary/immudb/pkg/pgsql/server/bmessages"
+	fm "github.com/codenotary/immudb/pkg/pgsql/server/fmessages"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSCRAMClientFirst(t *testing.T) {
+	gs2Header, bare, nonce, err := parseSCRAMClientFirst("n,,n=,r=rOprNGfwEbeRWgbNEkqO")
+	require.NoError(t, err)
+	require.Equal(t, "n,,", gs2Header)
+	require.Equal(t, "n=,r=rOprNGfwEbeRWgbNEkqO", bare)
+	require.Equal(t, "rOprNGfwEbeRWgbNEkqO", nonce)
+
+	_, _, _, err = parseSCRAMClientFirst("p=tls-server-end-point,,n=,r=rOprNGfwEbeRWgbNEkqO")
+	require.Equal(t, ErrChannelBindingNotSupported, err)
+
+	for _, msg := range []string{"", "n,,", "x,,n=,r=abc", "n,,r=abc", "n,,n=,r="} {
+		_, _, _, err = parseSCRAMClientFirst(msg)
+		require.Equal(t, fm.ErrMalformedMessage, err)
+	}
+}
+
+func TestParseSCRAMClientFinal(t *testing.T) {
+	proof := base64.StdEncoding.EncodeToString([]byte("proof"))
+
+	withoutProof, p, err := parseSCRAMClientFinal("c=biws,r=nonce,p="+proof, "n,,", "nonce")
+	require.NoError(t, err)
+	require.Equal(t, "c=biws,r=nonce", withoutProof)
+	require.Equal(t, []byte("proof"), p)
+
+	for _, msg := range []string{
+		"c=biws,r=nonce",
+		"c=biws,r=nonce,p=!!",
+		"c=eSws,r=nonce,p=" + proof,
+		"c=biws,r=other,p=" + proof,
+	} {
+		_, _, err = parseSCRAMClientFinal(msg, "n,,", "nonce")
+		require.Equal(t, fm.ErrMalformedMessage, err)
+	}
+}
+
+func TestServer_InitializeUnsupportedAuthMethod(t *testing.T) {
+	srv := New(Port(0), AuthMethod("trust"))
+
+	err := srv.Initialize()
+	require.True(t, errors.Is(err, ErrUnsupportedAuthMethod))
+}
+
+func TestAuthenticationMessages(t *testing.T) {
+	require.Equal(t, []byte{'R', 0, 0, 0, 12, 0, 0, 0, 5, 1, 2, 3, 4}, bm.AuthenticationMD5Password([4]byte{1, 2, 3, 4}))
+	require.Equal(t, append([]byte{'R', 0, 0, 0, 23, 0, 0, 0, 10}, "SCRAM-SHA-256\x00\x00"...), bm.AuthenticationSASL("SCRAM-SHA-256"))
+	require.Equal(t, append([]byte{'R', 0, 0, 0, 11, 0, 0, 0, 11}, "r=a"...), bm.AuthenticationSASLContinue([]byte("r=a")))
+	require.Equal(t, append([]byte{'R', 0, 0, 0, 11, 0, 0, 0, 12}, "v=a"...), bm.AuthenticationSASLFinal([]byte("v=a")))
+
+	msg, err := fm.ParseSASLInitialResponseMsg(append([]byte("SCRAM-SHA-256\x00\x00\x00\x00\x03"), "n,,"...))
+	require.NoError(t, err)
+	require.Equal(t, "SCRAM-SHA-256", msg.Mechanism)
+	require.Equal(t, []byte("n,,"), msg.Data)
+
+	_, err = fm.ParseSASLInitialResponseMsg([]byte("SCRAM-SHA-256"))
+	require.Equal(t, fm.ErrMalformedMessage, err)
+}
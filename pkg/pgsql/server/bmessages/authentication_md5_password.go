@@ -0,0 +1,32 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bmessages
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// AuthenticationMD5Password asks the client for the md5 hash of its password, salted with the 4 bytes of salt
+func AuthenticationMD5Password(salt [4]byte) []byte {
+	messageType := []byte(`R`)
+	messageLength := make([]byte, 4)
+	message := make([]byte, 4)
+	binary.BigEndian.PutUint32(messageLength, uint32(12))
+	binary.BigEndian.PutUint32(message, uint32(5))
+	return bytes.Join([][]byte{messageType, messageLength, message, salt[:]}, nil)
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bmessages
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// AuthenticationSASL starts a SASL authentication, listing the mechanisms supported by the server
+func AuthenticationSASL(mechanisms ...string) []byte {
+	var list []byte
+	for _, m := range mechanisms {
+		list = append(list, m...)
+		list = append(list, 0)
+	}
+	list = append(list, 0)
+
+	return authenticationSASLMessage(10, list)
+}
+
+// AuthenticationSASLContinue carries the SASL challenge of the server
+func AuthenticationSASLContinue(data []byte) []byte {
+	return authenticationSASLMessage(11, data)
+}
+
+// AuthenticationSASLFinal carries the additional data sent by the server when the SASL authentication completes
+func AuthenticationSASLFinal(data []byte) []byte {
+	return authenticationSASLMessage(12, data)
+}
+
+func authenticationSASLMessage(code uint32, data []byte) []byte {
+	messageType := []byte(`R`)
+	messageLength := make([]byte, 4)
+	message := make([]byte, 4)
+	binary.BigEndian.PutUint32(messageLength, uint32(8+len(data)))
+	binary.BigEndian.PutUint32(message, code)
+	return bytes.Join([][]byte{messageType, messageLength, message, data}, nil)
+}
@@ -19,10 +19,15 @@ package bmessages
 import (
 	"bytes"
 	"encoding/binary"
+	"math"
+
 	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/pgsql/server/pgmeta"
 )
 
-func DataRow(rows []*schema.Row, colNumb int, binaryFormat bool) []byte {
+// DataRow renders the values in the format given by the format codes, as they're sent by the Bind message.
+// No format codes stand for text format for all the columns, a single one applies to all of them
+func DataRow(rows []*schema.Row, colNumb int, formatCodes []int16) []byte {
 	rowsB := make([]byte, 0)
 	for _, row := range rows {
 		rowB := make([]byte, 0)
@@ -35,7 +40,7 @@ func DataRow(rows []*schema.Row, colNumb int, binaryFormat bool) []byte {
 		columnNumb := make([]byte, 2)
 		binary.BigEndian.PutUint16(columnNumb, uint16(colNumb))
 
-		for _, val := range row.Values {
+		for i, val := range row.Values {
 			if val == nil {
 				return nil
 			}
@@ -43,8 +48,11 @@ func DataRow(rows []*schema.Row, colNumb int, binaryFormat bool) []byte {
 			valueLength := make([]byte, 4)
 			value := make([]byte, 0)
 
-			// only text format is allowed in simple query
-			value = schema.RenderValueAsByte(val.Value)
+			if FormatCode(formatCodes, i) == BinaryFormat {
+				value = renderValueAsBinary(val.Value)
+			} else {
+				value = schema.RenderValueAsByte(val.Value)
+			}
 
 			binary.BigEndian.PutUint32(valueLength, uint32(len(value)))
 			//  As a special case, -1 indicates a NULL column value. No value bytes follow in the NULL case.
@@ -64,3 +72,42 @@ func DataRow(rows []*schema.Row, colNumb int, binaryFormat bool) []byte {
 	}
 	return rowsB
 }
+
+// renderValueAsBinary renders the value as the binary format of the pgsql type the immudb type is mapped to
+func renderValueAsBinary(v interface{}) []byte {
+	switch tv := v.(type) {
+	case *schema.SQLValue_Null:
+		return nil
+	case *schema.SQLValue_N:
+		{
+			b := make([]byte, 8)
+			binary.BigEndian.PutUint64(b, tv.N)
+			return b
+		}
+	case *schema.SQLValue_B:
+		{
+			if tv.B {
+				return []byte{1}
+			}
+			return []byte{0}
+		}
+	case *schema.SQLValue_Bs:
+		return tv.Bs
+	case *schema.SQLValue_F:
+		{
+			b := make([]byte, 8)
+			binary.BigEndian.PutUint64(b, math.Float64bits(tv.F))
+			return b
+		}
+	case *schema.SQLValue_Ts:
+		{
+			b := make([]byte, 8)
+			binary.BigEndian.PutUint64(b, uint64(schema.TimeFromInt64(tv.Ts).Sub(pgmeta.PgEpoch).Microseconds()))
+			return b
+		}
+	case *schema.SQLValue_S:
+		return []byte(tv.S)
+	}
+
+	return nil
+}
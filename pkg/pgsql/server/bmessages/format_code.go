@@ -0,0 +1,39 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bmessages
+
+const (
+	TextFormat   int16 = 0
+	BinaryFormat int16 = 1
+)
+
+// FormatCode returns the format of the i-th value. No format codes stand for text format for all the values,
+// a single one applies to all of them
+func FormatCode(formatCodes []int16, i int) int16 {
+	switch len(formatCodes) {
+	case 0:
+		return TextFormat
+	case 1:
+		return formatCodes[0]
+	}
+
+	if i < len(formatCodes) {
+		return formatCodes[i]
+	}
+
+	return TextFormat
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bmessages
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// ParameterDescription gives the object IDs of the types of the parameters of a prepared statement
+func ParameterDescription(oids []int32) []byte {
+	messageType := []byte(`t`)
+
+	// The number of parameters used by the statement (can be zero).
+	// Int16
+	paramsNumb := make([]byte, 2)
+	binary.BigEndian.PutUint16(paramsNumb, uint16(len(oids)))
+
+	// Specifies the object ID of the parameter data type.
+	// Int32[]
+	paramsB := make([]byte, 4*len(oids))
+	for i, oid := range oids {
+		binary.BigEndian.PutUint32(paramsB[4*i:], uint32(oid))
+	}
+
+	selfMessageLength := make([]byte, 4)
+	binary.BigEndian.PutUint32(selfMessageLength, uint32(4+2+len(paramsB)))
+
+	return bytes.Join([][]byte{messageType, selfMessageLength, paramsNumb, paramsB}, nil)
+}
@@ -22,9 +22,17 @@ import (
 )
 
 func ReadyForQuery() []byte {
+	return readyForQuery([]byte(`I`))
+}
+
+// ReadyForQueryInTransaction is sent instead of ReadyForQuery while a transaction block is in progress
+func ReadyForQueryInTransaction() []byte {
+	return readyForQuery([]byte(`T`))
+}
+
+func readyForQuery(txStatus []byte) []byte {
 	messageType := []byte(`Z`)
 	message := make([]byte, 4)
-	idle := []byte(`I`)
 	binary.BigEndian.PutUint32(message, uint32(5))
-	return bytes.Join([][]byte{messageType, message, idle}, nil)
+	return bytes.Join([][]byte{messageType, message, txStatus}, nil)
 }
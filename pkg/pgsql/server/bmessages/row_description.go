@@ -23,7 +23,8 @@ import (
 	"github.com/codenotary/immudb/pkg/pgsql/server/pgmeta"
 )
 
-func RowDescription(cols []*schema.Column) []byte {
+// RowDescription describes the columns of the rows, as they're rendered with the given format codes
+func RowDescription(cols []*schema.Column, formatCodes []int16) []byte {
 	////##-> dataRowDescription
 	//Byte1('T')
 	messageType := []byte(`T`)
@@ -69,7 +70,7 @@ func RowDescription(cols []*schema.Column) []byte {
 		// Int16
 		// In simple Query mode, the format of retrieved values is always text, except when the given command is a FETCH from a cursor declared with the BINARY option. In that case, the retrieved values are in binary format. The format codes given in the RowDescription message tell which format is being used.
 		formatCode := make([]byte, 2)
-		binary.BigEndian.PutUint16(formatCode, uint16(0))
+		binary.BigEndian.PutUint16(formatCode, uint16(FormatCode(formatCodes, n)))
 
 		rowDescMessageB = append(rowDescMessageB, bytes.Join([][]byte{fieldName, id, attributeNumber, objectId, dataTypeSize, typeModifier, formatCode}, nil)...)
 	}
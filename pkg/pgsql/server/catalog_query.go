@@ -0,0 +1,119 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"fmt"
+
+	"github.com/codenotary/immudb/embedded/sql"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	bm "github.com/codenotary/immudb/pkg/pgsql/server/bmessages"
+	"github.com/codenotary/immudb/pkg/pgsql/server/pgcatalog"
+)
+
+// catalog returns the catalog of the selected database, listing the databases the user has a permission on
+func (s *session) catalog() (*pgcatalog.Catalog, error) {
+	tables, err := s.database.SQLTables()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &pgcatalog.Catalog{
+		Database: s.database.GetName(),
+		Tables:   tables,
+		User:     s.username,
+	}
+
+	for i := 0; i < s.dbList.Length(); i++ {
+		// deleted databases leave their slot empty
+		db := s.dbList.GetByIndex(int64(i))
+		if db == nil || s.user == nil || s.user.WhichPermission(db.GetName()) == auth.PermissionNone {
+			continue
+		}
+
+		c.Databases = append(c.Databases, &pgcatalog.DatabaseInfo{ID: int64(i), Name: db.GetName()})
+	}
+
+	return c, nil
+}
+
+// catalogQuery returns the rows of the query reading pg_catalog or information_schema
+func (s *session) catalogQuery(q *pgcatalog.Query, params []*schema.NamedParam) (*catalogRows, error) {
+	c, err := s.catalog()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.Execute(c, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &catalogRows{cols: q.Columns(), rows: rows}, nil
+}
+
+// catalogQueryMsg answers the simple query reading pg_catalog or information_schema. Unlike other queries,
+// rows are described even when there are none, as clients expect it from introspection queries
+func (s *session) catalogQueryMsg(statements string) error {
+	q, err := pgcatalog.Parse(statements)
+	if err != nil {
+		return err
+	}
+
+	rows, err := s.catalogQuery(q, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.writeMessage(bm.RowDescription(rows.cols, nil)); err != nil {
+		return err
+	}
+
+	n, _, err := s.streamRows(rows, nil, nil, 0)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.writeMessage(bm.CommandComplete([]byte(fmt.Sprintf("SELECT %d", n))))
+	return err
+}
+
+// catalogRows are the rows of a catalog query, read in memory as the catalog is small
+type catalogRows struct {
+	cols []*schema.Column
+	rows []*schema.Row
+}
+
+func (r *catalogRows) Columns() []*schema.Column {
+	return r.cols
+}
+
+func (r *catalogRows) Read() (*schema.Row, error) {
+	if len(r.rows) == 0 {
+		return nil, sql.ErrNoMoreRows
+	}
+
+	row := r.rows[0]
+	r.rows = r.rows[1:]
+
+	return row, nil
+}
+
+func (r *catalogRows) Close() error {
+	return nil
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/codenotary/immudb/pkg/auth"
+	"github.com/codenotary/immudb/pkg/database"
+)
+
+// selectDatabase makes the database the one the statements of the session are executed on.
+// As with UseDatabase, the user needs a permission on it unless it's a system admin
+func (s *session) selectDatabase(name string) error {
+	db, err := s.dbList.GetByName(name)
+	if err != nil {
+		if errors.Is(err, database.ErrDatabaseNotExists) {
+			return ErrDBNotExists
+		}
+		return err
+	}
+
+	if s.user == nil || s.user.WhichPermission(db.GetName()) == auth.PermissionNone {
+		return fmt.Errorf("%w '%s'", ErrNoDatabasePermission, name)
+	}
+
+	s.database = db
+	s.log.Debugf("selected %s database", name)
+
+	return nil
+}
+
+// useDatabase handles USE DATABASE statements. The transaction in progress belongs to the selected database,
+// thus it must be ended first
+func (s *session) useDatabase(name string) error {
+	if s.txID != "" {
+		return ErrDatabaseStmtInTx
+	}
+
+	return s.selectDatabase(name)
+}
+
+// createDatabaseStmt handles CREATE DATABASE statements, which only system admins are allowed to issue
+func (s *session) createDatabaseStmt(name string) error {
+	if s.createDatabase == nil {
+		return ErrCreateDBStatementNotSupported
+	}
+
+	if s.txID != "" {
+		return ErrDatabaseStmtInTx
+	}
+
+	if s.user == nil || !s.user.IsSysAdmin {
+		return ErrCreateDBPermissionDenied
+	}
+
+	return s.createDatabase(s.user, name)
+}
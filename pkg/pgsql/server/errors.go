@@ -18,7 +18,10 @@ package server
 
 import (
 	"errors"
+	"github.com/codenotary/immudb/embedded/sql"
 	bm "github.com/codenotary/immudb/pkg/pgsql/server/bmessages"
+	fm "github.com/codenotary/immudb/pkg/pgsql/server/fmessages"
+	"github.com/codenotary/immudb/pkg/pgsql/server/pgcatalog"
 	"github.com/codenotary/immudb/pkg/pgsql/server/pgmeta"
 	"strings"
 )
@@ -30,9 +33,30 @@ var ErrPwNotprovided = errors.New("password not provided")
 var ErrDBNotExists = errors.New("selected db doesn't exists")
 var ErrUsernameNotFound = errors.New("user not found")
 var ErrExpectedQueryMessage = errors.New("expected query message")
-var ErrUseDBStatementNotSupported = errors.New("SQL statement not supported. Please use `UseDatabase` operation instead")
 var ErrCreateDBStatementNotSupported = errors.New("SQL statement not supported. Please use `CreateDatabase` operation instead")
 var ErrSSLNotSupported = errors.New("SSL not supported")
+var ErrSSLRequired = errors.New("SSL connection is required")
+var ErrSSLRequiredWithoutCertificate = errors.New("SSL can't be required without a certificate and a private key")
+var ErrUnexpectedNegotiationRequest = errors.New("encryption was already negotiated")
+var ErrPreparedStmtNotFound = errors.New("prepared statement not found")
+var ErrPreparedStmtAlreadyExists = errors.New("prepared statement already exists")
+var ErrPortalNotFound = errors.New("portal not found")
+var ErrPortalAlreadyExists = errors.New("portal already exists")
+var ErrMaxStmtNumberExceeded = errors.New("prepared statements can hold a single SQL statement")
+var ErrParametersNumberMismatch = errors.New("the number of parameter values doesn't match the parameters of the statement")
+var ErrInvalidParameterValue = errors.New("invalid parameter value")
+var ErrInvalidSessionCmd = errors.New("invalid SET or SHOW statement")
+var ErrInvalidSessionParamValue = errors.New("invalid value")
+var ErrReadOnlySessionParam = errors.New("parameter can't be changed")
+var ErrUnknownSessionParam = errors.New("unrecognized configuration parameter")
+var ErrUnsupportedAuthMethod = errors.New("unsupported authentication method")
+var ErrAuthenticationFailed = errors.New("password authentication failed")
+var ErrMissingPasswordVerifier = errors.New("the password of the user can't be checked with the authentication method of the server")
+var ErrUnsupportedSASLMechanism = errors.New("unsupported SASL mechanism")
+var ErrChannelBindingNotSupported = errors.New("SCRAM channel binding is not supported")
+var ErrNoDatabasePermission = errors.New("user has no permission on database")
+var ErrCreateDBPermissionDenied = errors.New("only system admins can create databases")
+var ErrDatabaseStmtInTx = errors.New("databases can't be created or selected within a transaction")
 
 func MapPgError(err error) (er bm.ErrorResp) {
 	switch {
@@ -48,6 +72,47 @@ func MapPgError(err error) (er bm.ErrorResp) {
 			bm.Message(ErrDBNotExists.Error()),
 			bm.Hint("please provide a valid database name or use immuclient to create a new one"),
 		)
+	case errors.Is(err, ErrNoDatabasePermission), errors.Is(err, ErrCreateDBPermissionDenied):
+		er = bm.ErrorResponse(bm.Severity(pgmeta.PgSeverityError),
+			bm.Code(pgmeta.PgServerErrInsufficientPrivilege),
+			bm.Message(err.Error()),
+		)
+	case errors.Is(err, ErrDatabaseStmtInTx):
+		er = bm.ErrorResponse(bm.Severity(pgmeta.PgSeverityError),
+			bm.Code(pgmeta.PgServerErrActiveSqlTransaction),
+			bm.Message(err.Error()),
+			bm.Hint("commit or rollback the transaction in progress first"),
+		)
+	case errors.Is(err, pgcatalog.ErrUnsupportedQuery):
+		er = bm.ErrorResponse(bm.Severity(pgmeta.PgSeverityError),
+			bm.Code(pgmeta.PgServerErrFeatureNotSupported),
+			bm.Message(err.Error()),
+		)
+	case errors.Is(err, pgcatalog.ErrUndefinedRelation):
+		er = bm.ErrorResponse(bm.Severity(pgmeta.PgSeverityError),
+			bm.Code(pgmeta.PgServerErrUndefinedTable),
+			bm.Message(err.Error()),
+		)
+	case errors.Is(err, pgcatalog.ErrUndefinedColumn):
+		er = bm.ErrorResponse(bm.Severity(pgmeta.PgSeverityError),
+			bm.Code(pgmeta.PgServerErrUndefinedColumn),
+			bm.Message(err.Error()),
+		)
+	case errors.Is(err, pgcatalog.ErrAmbiguousColumn):
+		er = bm.ErrorResponse(bm.Severity(pgmeta.PgSeverityError),
+			bm.Code(pgmeta.PgServerErrAmbiguousColumn),
+			bm.Message(err.Error()),
+		)
+	case errors.Is(err, pgcatalog.ErrUndefinedFunction):
+		er = bm.ErrorResponse(bm.Severity(pgmeta.PgSeverityError),
+			bm.Code(pgmeta.PgServerErrUndefinedFunction),
+			bm.Message(err.Error()),
+		)
+	case errors.Is(err, pgcatalog.ErrInvalidValue):
+		er = bm.ErrorResponse(bm.Severity(pgmeta.PgSeverityError),
+			bm.Code(pgmeta.PgServerErrInvalidParameterValue),
+			bm.Message(err.Error()),
+		)
 	case strings.Contains(err.Error(), "syntax error"):
 		er = bm.ErrorResponse(bm.Severity(pgmeta.PgSeverityError),
 			bm.Code(pgmeta.PgServerErrSyntaxError),
@@ -59,12 +124,88 @@ func MapPgError(err error) (er bm.ErrorResp) {
 			bm.Message(err.Error()),
 			bm.Hint("submitted message is not yet implemented"),
 		)
+	case errors.Is(err, sql.ErrUniqueConstraintViolation):
+		er = bm.ErrorResponse(bm.Severity(pgmeta.PgSeverityError),
+			bm.Code(pgmeta.PgServerErrUniqueViolation),
+			bm.Message(err.Error()),
+		)
 	case errors.Is(err, ErrSSLNotSupported):
 		er = bm.ErrorResponse(bm.Severity(pgmeta.PgSeverityError),
 			bm.Code(pgmeta.PgServerErrConnectionFailure),
 			bm.Message(err.Error()),
 			bm.Hint("launch immudb with a certificate and a private key"),
 		)
+	case errors.Is(err, ErrSSLRequired):
+		er = bm.ErrorResponse(bm.Severity(pgmeta.PgSeverityFaral),
+			bm.Code(pgmeta.PgServerErrInvalidAuthorizationSpecification),
+			bm.Message(err.Error()),
+			bm.Hint("connect with sslmode=require"),
+		)
+	case errors.Is(err, ErrInvalidSessionCmd):
+		er = bm.ErrorResponse(bm.Severity(pgmeta.PgSeverityError),
+			bm.Code(pgmeta.PgServerErrSyntaxError),
+			bm.Message(err.Error()),
+		)
+	case errors.Is(err, ErrInvalidSessionParamValue):
+		er = bm.ErrorResponse(bm.Severity(pgmeta.PgSeverityError),
+			bm.Code(pgmeta.PgServerErrInvalidParameterValue),
+			bm.Message(err.Error()),
+		)
+	case errors.Is(err, ErrReadOnlySessionParam):
+		er = bm.ErrorResponse(bm.Severity(pgmeta.PgSeverityError),
+			bm.Code(pgmeta.PgServerErrCantChangeRuntimeParam),
+			bm.Message(err.Error()),
+		)
+	case errors.Is(err, ErrUnknownSessionParam):
+		er = bm.ErrorResponse(bm.Severity(pgmeta.PgSeverityError),
+			bm.Code(pgmeta.PgServerErrUndefinedObject),
+			bm.Message(err.Error()),
+		)
+	case errors.Is(err, ErrAuthenticationFailed):
+		er = bm.ErrorResponse(bm.Severity(pgmeta.PgSeverityFaral),
+			bm.Code(pgmeta.PgServerErrInvalidPassword),
+			bm.Message(err.Error()),
+		)
+	case errors.Is(err, ErrUnsupportedSASLMechanism), errors.Is(err, ErrChannelBindingNotSupported):
+		er = bm.ErrorResponse(bm.Severity(pgmeta.PgSeverityFaral),
+			bm.Code(pgmeta.PgServerErrProtocolViolation),
+			bm.Message(err.Error()),
+		)
+	case errors.Is(err, ErrUnexpectedNegotiationRequest):
+		er = bm.ErrorResponse(bm.Severity(pgmeta.PgSeverityFaral),
+			bm.Code(pgmeta.PgServerErrProtocolViolation),
+			bm.Message(err.Error()),
+		)
+	case errors.Is(err, ErrPreparedStmtNotFound):
+		er = bm.ErrorResponse(bm.Severity(pgmeta.PgSeverityError),
+			bm.Code(pgmeta.PgServerErrInvalidSqlStatementName),
+			bm.Message(err.Error()),
+		)
+	case errors.Is(err, ErrPreparedStmtAlreadyExists):
+		er = bm.ErrorResponse(bm.Severity(pgmeta.PgSeverityError),
+			bm.Code(pgmeta.PgServerErrDuplicatePreparedStatement),
+			bm.Message(err.Error()),
+		)
+	case errors.Is(err, ErrPortalNotFound):
+		er = bm.ErrorResponse(bm.Severity(pgmeta.PgSeverityError),
+			bm.Code(pgmeta.PgServerErrInvalidCursorName),
+			bm.Message(err.Error()),
+		)
+	case errors.Is(err, ErrPortalAlreadyExists):
+		er = bm.ErrorResponse(bm.Severity(pgmeta.PgSeverityError),
+			bm.Code(pgmeta.PgServerErrDuplicateCursor),
+			bm.Message(err.Error()),
+		)
+	case errors.Is(err, ErrParametersNumberMismatch), errors.Is(err, fm.ErrMalformedMessage):
+		er = bm.ErrorResponse(bm.Severity(pgmeta.PgSeverityError),
+			bm.Code(pgmeta.PgServerErrProtocolViolation),
+			bm.Message(err.Error()),
+		)
+	case errors.Is(err, ErrInvalidParameterValue):
+		er = bm.ErrorResponse(bm.Severity(pgmeta.PgSeverityError),
+			bm.Code(pgmeta.PgServerErrInvalidTextRepresentation),
+			bm.Message(err.Error()),
+		)
 	default:
 		er = bm.ErrorResponse(bm.Severity(pgmeta.PgSeverityError),
 			bm.Message(err.Error()),
@@ -1,8 +1,12 @@
 package server
 
 import (
-	"github.com/stretchr/testify/require"
+	"fmt"
 	"testing"
+
+	"github.com/codenotary/immudb/embedded/sql"
+	"github.com/codenotary/immudb/pkg/pgsql/server/pgmeta"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMapPgError(t *testing.T) {
@@ -10,3 +14,10 @@ func TestMapPgError(t *testing.T) {
 	be := MapPgError(err)
 	require.NotNil(t, be)
 }
+
+func TestMapPgErrorUniqueViolation(t *testing.T) {
+	err := fmt.Errorf("%w: table1(email)", sql.ErrUniqueConstraintViolation)
+	be := MapPgError(err)
+	require.Contains(t, be.ToString(), pgmeta.PgServerErrUniqueViolation)
+	require.Contains(t, be.ToString(), "table1(email)")
+}
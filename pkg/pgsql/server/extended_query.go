@@ -0,0 +1,631 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codenotary/immudb/embedded/sql"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/database"
+	bm "github.com/codenotary/immudb/pkg/pgsql/server/bmessages"
+	fm "github.com/codenotary/immudb/pkg/pgsql/server/fmessages"
+	"github.com/codenotary/immudb/pkg/pgsql/server/pgcatalog"
+	"github.com/codenotary/immudb/pkg/pgsql/server/pgmeta"
+)
+
+// preparedStmt is the statement created by a Parse message
+type preparedStmt struct {
+	// nil when the query string holds no statement, or it's handled without being executed
+	stmt sql.SQLStmt
+	// type of each positional parameter, $1 being the first one
+	paramsTypes []sql.SQLValueType
+	// columns of the rows returned by queries, nil for the other statements
+	cols []*schema.Column
+	// SET and SHOW statements are handled by the server, as it's done for simple queries
+	session *sessionCmd
+	version bool
+	// queries reading pg_catalog or information_schema are answered by the server
+	catalog *pgcatalog.Query
+}
+
+// portal is a prepared statement bound to the values of its parameters
+type portal struct {
+	stmt          *preparedStmt
+	params        []*schema.NamedParam
+	resultFormats []int16
+	// reader of the rows of a query, kept open while the portal is suspended as the row limit of the
+	// Execute message was reached. It's nil once the rows were all returned
+	rows     database.SQLRows
+	executed bool
+}
+
+// close releases the reader of the rows not yet returned, if any, and the snapshot it reads from
+func (p *portal) close() {
+	if p.rows != nil {
+		p.rows.Close()
+		p.rows = nil
+	}
+}
+
+// closePortals closes all the portals, as it's done when the transaction they were created in ends
+func (s *session) closePortals() {
+	for name, p := range s.portals {
+		p.close()
+		delete(s.portals, name)
+	}
+}
+
+// extendedQueryMsg handles the messages of the extended query protocol but Sync and Flush
+func (s *session) extendedQueryMsg(msg interface{}) error {
+	if s.statements == nil {
+		s.statements = make(map[string]*preparedStmt)
+	}
+
+	if s.portals == nil {
+		s.portals = make(map[string]*portal)
+	}
+
+	switch v := msg.(type) {
+	case fm.ParseMsg:
+		return s.parseMsg(v)
+	case fm.BindMsg:
+		return s.bindMsg(v)
+	case fm.DescribeMsg:
+		return s.describeMsg(v)
+	case fm.ExecuteMsg:
+		return s.executeMsg(v)
+	case fm.CloseMsg:
+		return s.closeMsg(v)
+	}
+
+	return ErrUnknowMessageType
+}
+
+func (s *session) parseMsg(msg fm.ParseMsg) error {
+	name := msg.DestPreparedStatementName
+
+	// the unnamed prepared statement is replaced, named ones must be closed first
+	if _, exists := s.statements[name]; exists && name != "" {
+		return fmt.Errorf("%w: '%s'", ErrPreparedStmtAlreadyExists, name)
+	}
+
+	ps, err := s.prepare(msg.Statements, msg.ParamsOIDs)
+	if err != nil {
+		return err
+	}
+
+	s.statements[name] = ps
+
+	_, err = s.writeMessage(bm.ParseComplete())
+	return err
+}
+
+func (s *session) prepare(statements string, paramsOIDs []int32) (*preparedStmt, error) {
+	if isSessionCmd(statements) {
+		cmds, err := parseSessionCmds(statements)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(cmds) > 1 {
+			return nil, ErrMaxStmtNumberExceeded
+		}
+
+		ps := &preparedStmt{session: cmds[0]}
+		if cmds[0].show {
+			ps.cols = cmds[0].showCols()
+		}
+
+		return ps, nil
+	}
+
+	if versionStmtRegexp.MatchString(statements) {
+		cols, _ := versionInfo()
+		return &preparedStmt{version: true, cols: cols}, nil
+	}
+
+	if pgcatalog.IsCatalogQuery(statements) {
+		return prepareCatalogQuery(statements, paramsOIDs)
+	}
+
+	if strings.TrimSpace(statements) == "" {
+		return &preparedStmt{}, nil
+	}
+
+	stmts, err := sql.Parse(strings.NewReader(statements))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(stmts) > 1 {
+		return nil, ErrMaxStmtNumberExceeded
+	}
+
+	stmt := stmts[0]
+
+	switch stmt.(type) {
+	case *sql.UseDatabaseStmt, *sql.CreateDatabaseStmt:
+		// handled by the server, they take no parameter
+		return &preparedStmt{stmt: stmt}, nil
+	}
+
+	types, err := s.database.InferParametersPrepared(stmt)
+	if err != nil {
+		return nil, err
+	}
+
+	paramsTypes := clientParamsTypes(positionalParamsTypes(types), paramsOIDs)
+
+	ps := &preparedStmt{stmt: stmt, paramsTypes: paramsTypes}
+
+	sel, isQuery := stmt.(*sql.SelectStmt)
+	if isQuery {
+		// columns are told by resolving the query with placeholder values, no row is read
+		ps.cols, err = s.database.SQLQueryColumnsPrepared(s.sqlContext(), sel, placeholderParams(paramsTypes))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ps, nil
+}
+
+// prepareCatalogQuery prepares the query reading pg_catalog or information_schema, parameters are VARCHAR
+// unless their types are given by the client
+func prepareCatalogQuery(statements string, paramsOIDs []int32) (*preparedStmt, error) {
+	q, err := pgcatalog.Parse(statements)
+	if err != nil {
+		return nil, err
+	}
+
+	paramsTypes := make([]sql.SQLValueType, q.ParamsCount())
+	for i := range paramsTypes {
+		paramsTypes[i] = sql.VarcharType
+	}
+
+	return &preparedStmt{catalog: q, cols: q.Columns(), paramsTypes: clientParamsTypes(paramsTypes, paramsOIDs)}, nil
+}
+
+// clientParamsTypes returns the types of the parameters, types given by the client prevail over the inferred
+// ones. Parameters not used by the statement are allowed
+func clientParamsTypes(paramsTypes []sql.SQLValueType, paramsOIDs []int32) []sql.SQLValueType {
+	for i, oid := range paramsOIDs {
+		t, ok := pgmeta.PgTypeOids[oid]
+		if !ok {
+			continue
+		}
+
+		for len(paramsTypes) <= i {
+			paramsTypes = append(paramsTypes, sql.VarcharType)
+		}
+
+		paramsTypes[i] = t
+	}
+
+	return paramsTypes
+}
+
+// positionalParamsTypes returns the types of the parameters by position, those not used by the statement are VARCHAR
+func positionalParamsTypes(types map[string]sql.SQLValueType) []sql.SQLValueType {
+	n := 0
+
+	for id := range types {
+		pos, err := strconv.Atoi(strings.TrimPrefix(id, "param"))
+		if err == nil && id == sql.PositionalParam(pos) && pos > n {
+			n = pos
+		}
+	}
+
+	paramsTypes := make([]sql.SQLValueType, n)
+
+	for i := range paramsTypes {
+		t, ok := types[sql.PositionalParam(i+1)]
+		if !ok {
+			t = sql.VarcharType
+		}
+
+		paramsTypes[i] = t
+	}
+
+	return paramsTypes
+}
+
+// placeholderParams returns a value of the type of each parameter
+func placeholderParams(paramsTypes []sql.SQLValueType) []*schema.NamedParam {
+	params := make([]*schema.NamedParam, len(paramsTypes))
+
+	for i, t := range paramsTypes {
+		var v *schema.SQLValue
+
+		switch t {
+		case sql.IntegerType:
+			v = &schema.SQLValue{Value: &schema.SQLValue_N{}}
+		case sql.BooleanType:
+			v = &schema.SQLValue{Value: &schema.SQLValue_B{}}
+		case sql.BLOBType:
+			v = &schema.SQLValue{Value: &schema.SQLValue_Bs{Bs: []byte{}}}
+		case sql.FloatType:
+			v = &schema.SQLValue{Value: &schema.SQLValue_F{}}
+		case sql.TimestampType:
+			v = &schema.SQLValue{Value: &schema.SQLValue_Ts{}}
+		default:
+			v = &schema.SQLValue{Value: &schema.SQLValue_S{}}
+		}
+
+		params[i] = &schema.NamedParam{Name: sql.PositionalParam(i + 1), Value: v}
+	}
+
+	return params
+}
+
+func (s *session) bindMsg(msg fm.BindMsg) error {
+	ps, ok := s.statements[msg.PreparedStatementName]
+	if !ok {
+		return fmt.Errorf("%w: '%s'", ErrPreparedStmtNotFound, msg.PreparedStatementName)
+	}
+
+	name := msg.DestPortalName
+
+	if _, exists := s.portals[name]; exists && name != "" {
+		return fmt.Errorf("%w: '%s'", ErrPortalAlreadyExists, name)
+	}
+
+	if len(msg.ParamsValues) != len(ps.paramsTypes) {
+		return fmt.Errorf("%w: expected %d, got %d", ErrParametersNumberMismatch, len(ps.paramsTypes), len(msg.ParamsValues))
+	}
+
+	if len(msg.ParamsFormatCodes) > 1 && len(msg.ParamsFormatCodes) != len(msg.ParamsValues) {
+		return fm.ErrMalformedMessage
+	}
+
+	params := make([]*schema.NamedParam, len(msg.ParamsValues))
+
+	for i, b := range msg.ParamsValues {
+		v, err := decodeParam(b, bm.FormatCode(msg.ParamsFormatCodes, i), ps.paramsTypes[i])
+		if err != nil {
+			return fmt.Errorf("%w: parameter $%d: %v", ErrInvalidParameterValue, i+1, err)
+		}
+
+		params[i] = &schema.NamedParam{Name: sql.PositionalParam(i + 1), Value: v}
+	}
+
+	// the unnamed portal is replaced
+	if p, exists := s.portals[name]; exists {
+		p.close()
+	}
+
+	s.portals[name] = &portal{
+		stmt:          ps,
+		params:        params,
+		resultFormats: msg.ResultColumnsFormatCodes,
+	}
+
+	_, err := s.writeMessage(bm.BindComplete())
+	return err
+}
+
+func (s *session) describeMsg(msg fm.DescribeMsg) error {
+	if msg.DescType == 'S' {
+		ps, ok := s.statements[msg.Name]
+		if !ok {
+			return fmt.Errorf("%w: '%s'", ErrPreparedStmtNotFound, msg.Name)
+		}
+
+		oids := make([]int32, len(ps.paramsTypes))
+		for i, t := range ps.paramsTypes {
+			oids[i] = int32(pgmeta.PgTypeMap[t][pgmeta.PgTypeMapOid])
+		}
+
+		if _, err := s.writeMessage(bm.ParameterDescription(oids)); err != nil {
+			return err
+		}
+
+		return s.describeRows(ps.cols, nil)
+	}
+
+	p, ok := s.portals[msg.Name]
+	if !ok {
+		return fmt.Errorf("%w: '%s'", ErrPortalNotFound, msg.Name)
+	}
+
+	return s.describeRows(p.stmt.cols, p.resultFormats)
+}
+
+// describeRows writes the description of the rows, rows returned by statements with a RETURNING clause
+// are not described as their columns can't be told without executing them
+func (s *session) describeRows(cols []*schema.Column, formatCodes []int16) error {
+	if cols == nil {
+		_, err := s.writeMessage(bm.NoData())
+		return err
+	}
+
+	_, err := s.writeMessage(bm.RowDescription(cols, formatCodes))
+	return err
+}
+
+func (s *session) executeMsg(msg fm.ExecuteMsg) error {
+	p, ok := s.portals[msg.PortalName]
+	if !ok {
+		return fmt.Errorf("%w: '%s'", ErrPortalNotFound, msg.PortalName)
+	}
+
+	ps := p.stmt
+
+	switch {
+	case ps.session != nil:
+		commandTag, err := s.execSessionCmd(ps.session, false)
+		if err != nil {
+			return err
+		}
+
+		_, err = s.writeMessage(bm.CommandComplete([]byte(commandTag)))
+		return err
+	case ps.cols != nil:
+		return s.executeQuery(p, int(msg.MaxRows))
+	case ps.stmt == nil:
+		_, err := s.writeMessage(bm.EmptyQueryResponse())
+		return err
+	}
+
+	commandTag, err := s.execStmt(ps.stmt, p.params)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.writeMessage(bm.CommandComplete([]byte(commandTag)))
+	return err
+}
+
+// executeQuery streams the rows of the query, up to maxRows unless it's zero. The portal is suspended
+// when the limit is reached, keeping the reader open so the following Execute messages go on from there
+func (s *session) executeQuery(p *portal, maxRows int) error {
+	if !p.executed {
+		p.executed = true
+
+		if p.stmt.version {
+			_, rows := versionInfo()
+			if _, err := s.writeMessage(bm.DataRow(rows, len(p.stmt.cols), p.resultFormats)); err != nil {
+				return err
+			}
+
+			_, err := s.writeMessage(bm.CommandComplete([]byte(fmt.Sprintf("SELECT %d", len(rows)))))
+			return err
+		}
+
+		var rows database.SQLRows
+		var err error
+
+		if p.stmt.catalog != nil {
+			rows, err = s.catalogQuery(p.stmt.catalog, p.params)
+		} else {
+			// queries are not deferred, thus they don't see the changes made within the transaction in progress
+			rows, err = s.database.SQLQueryRowsPrepared(s.sqlContext(), p.stmt.stmt.(*sql.SelectStmt), p.params, true)
+		}
+		if err != nil {
+			return err
+		}
+
+		p.rows = rows
+	}
+
+	n := 0
+
+	if p.rows != nil {
+		var limitReached bool
+		var err error
+
+		n, limitReached, err = s.streamRows(p.rows, nil, p.resultFormats, maxRows)
+		if err != nil {
+			p.close()
+			return err
+		}
+
+		if limitReached {
+			_, err := s.writeMessage(bm.PortalSuspended())
+			return err
+		}
+
+		p.close()
+	}
+
+	_, err := s.writeMessage(bm.CommandComplete([]byte(fmt.Sprintf("SELECT %d", n))))
+	return err
+}
+
+// execStmt executes a statement other than a query, rows returned by statements with a RETURNING clause are
+// discarded as they were not described
+func (s *session) execStmt(stmt sql.SQLStmt, params []*schema.NamedParam) (commandTag string, err error) {
+	switch st := stmt.(type) {
+	case *sql.UseDatabaseStmt:
+		return `USE`, s.useDatabase(st.DB)
+	case *sql.CreateDatabaseStmt:
+		return `CREATE DATABASE`, s.createDatabaseStmt(st.DB)
+	case *sql.BeginTransactionStmt:
+		_, err = s.execInTx(stmt, params)
+		return `BEGIN`, err
+	case *sql.CommitStmt:
+		_, err = s.execInTx(stmt, params)
+		return `COMMIT`, err
+	case *sql.RollbackStmt:
+		_, err = s.execInTx(stmt, params)
+		return `ROLLBACK`, err
+	}
+
+	if s.txID != "" {
+		_, err = s.execInTx(stmt, params)
+		return `ok`, err
+	}
+
+	_, err = s.database.SQLExecPrepared(s.sqlContext(), []sql.SQLStmt{stmt}, params, true)
+	return `ok`, err
+}
+
+func (s *session) closeMsg(msg fm.CloseMsg) error {
+	// closing a prepared statement or a portal that doesn't exist is not an error
+	if msg.CloseType == 'S' {
+		delete(s.statements, msg.Name)
+	} else {
+		if p, exists := s.portals[msg.Name]; exists {
+			p.close()
+			delete(s.portals, msg.Name)
+		}
+	}
+
+	_, err := s.writeMessage(bm.CloseComplete())
+	return err
+}
+
+// decodeParam converts the value given in text or binary format to the type of the parameter, nil stands for NULL
+func decodeParam(b []byte, formatCode int16, t sql.SQLValueType) (*schema.SQLValue, error) {
+	if b == nil {
+		return &schema.SQLValue{Value: &schema.SQLValue_Null{}}, nil
+	}
+
+	if formatCode == bm.BinaryFormat {
+		return decodeBinaryParam(b, t)
+	}
+
+	if formatCode != bm.TextFormat {
+		return nil, fmt.Errorf("unknown format code %d", formatCode)
+	}
+
+	s := string(b)
+
+	switch t {
+	case sql.IntegerType:
+		{
+			n, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			return &schema.SQLValue{Value: &schema.SQLValue_N{N: uint64(n)}}, nil
+		}
+	case sql.FloatType:
+		{
+			f, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				return nil, err
+			}
+			return &schema.SQLValue{Value: &schema.SQLValue_F{F: f}}, nil
+		}
+	case sql.BooleanType:
+		{
+			switch strings.ToLower(s) {
+			case "t", "true", "y", "yes", "on", "1":
+				return &schema.SQLValue{Value: &schema.SQLValue_B{B: true}}, nil
+			case "f", "false", "n", "no", "off", "0":
+				return &schema.SQLValue{Value: &schema.SQLValue_B{B: false}}, nil
+			}
+			return nil, fmt.Errorf("'%s' is not a boolean", s)
+		}
+	case sql.BLOBType:
+		{
+			// hex format, as it's used by pgsql by default
+			if strings.HasPrefix(s, `\x`) {
+				bs, err := hex.DecodeString(s[2:])
+				if err != nil {
+					return nil, err
+				}
+				return &schema.SQLValue{Value: &schema.SQLValue_Bs{Bs: bs}}, nil
+			}
+			return &schema.SQLValue{Value: &schema.SQLValue_Bs{Bs: b}}, nil
+		}
+	case sql.TimestampType:
+		{
+			ts, err := parseTimestamp(s)
+			if err != nil {
+				return nil, err
+			}
+			return &schema.SQLValue{Value: &schema.SQLValue_Ts{Ts: schema.TimeToInt64(ts)}}, nil
+		}
+	}
+
+	return &schema.SQLValue{Value: &schema.SQLValue_S{S: s}}, nil
+}
+
+func decodeBinaryParam(b []byte, t sql.SQLValueType) (*schema.SQLValue, error) {
+	switch t {
+	case sql.IntegerType:
+		{
+			switch len(b) {
+			case 2:
+				return &schema.SQLValue{Value: &schema.SQLValue_N{N: uint64(int16(binary.BigEndian.Uint16(b)))}}, nil
+			case 4:
+				return &schema.SQLValue{Value: &schema.SQLValue_N{N: uint64(int32(binary.BigEndian.Uint32(b)))}}, nil
+			case 8:
+				return &schema.SQLValue{Value: &schema.SQLValue_N{N: binary.BigEndian.Uint64(b)}}, nil
+			}
+		}
+	case sql.FloatType:
+		{
+			switch len(b) {
+			case 4:
+				return &schema.SQLValue{Value: &schema.SQLValue_F{F: float64(math.Float32frombits(binary.BigEndian.Uint32(b)))}}, nil
+			case 8:
+				return &schema.SQLValue{Value: &schema.SQLValue_F{F: math.Float64frombits(binary.BigEndian.Uint64(b))}}, nil
+			}
+		}
+	case sql.BooleanType:
+		{
+			if len(b) == 1 {
+				return &schema.SQLValue{Value: &schema.SQLValue_B{B: b[0] != 0}}, nil
+			}
+		}
+	case sql.TimestampType:
+		{
+			if len(b) == 8 {
+				ts := pgmeta.PgEpoch.Add(time.Duration(int64(binary.BigEndian.Uint64(b))) * time.Microsecond)
+				return &schema.SQLValue{Value: &schema.SQLValue_Ts{Ts: schema.TimeToInt64(ts)}}, nil
+			}
+		}
+	case sql.BLOBType:
+		return &schema.SQLValue{Value: &schema.SQLValue_Bs{Bs: b}}, nil
+	default:
+		return &schema.SQLValue{Value: &schema.SQLValue_S{S: string(b)}}, nil
+	}
+
+	return nil, fmt.Errorf("unexpected length %d of a binary %s value", len(b), t)
+}
+
+// timestampLayouts are the layouts of the timestamps clients render parameters with
+var timestampLayouts = []string{
+	"2006-01-02 15:04:05.999999999Z07:00:00",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z07",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999Z07:00",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+}
+
+func parseTimestamp(s string) (time.Time, error) {
+	for _, layout := range timestampLayouts {
+		ts, err := time.Parse(layout, s)
+		if err == nil {
+			return ts.UTC(), nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("'%s' is not a timestamp", s)
+}
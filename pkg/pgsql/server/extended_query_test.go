@@ -0,0 +1,82 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/codenotary/immudb/embedded/sql"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	bm "github.com/codenotary/immudb/pkg/pgsql/server/bmessages"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeParam(t *testing.T) {
+	ts := time.Date(2021, 12, 7, 14, 21, 32, 0, time.UTC)
+	minusTen := int64(-10)
+
+	testCases := []struct {
+		b          []byte
+		formatCode int16
+		t          sql.SQLValueType
+		expected   *schema.SQLValue
+	}{
+		{nil, bm.TextFormat, sql.IntegerType, &schema.SQLValue{Value: &schema.SQLValue_Null{}}},
+		{[]byte("-10"), bm.TextFormat, sql.IntegerType, &schema.SQLValue{Value: &schema.SQLValue_N{N: uint64(minusTen)}}},
+		{[]byte("1.5"), bm.TextFormat, sql.FloatType, &schema.SQLValue{Value: &schema.SQLValue_F{F: 1.5}}},
+		{[]byte("t"), bm.TextFormat, sql.BooleanType, &schema.SQLValue{Value: &schema.SQLValue_B{B: true}}},
+		{[]byte("false"), bm.TextFormat, sql.BooleanType, &schema.SQLValue{Value: &schema.SQLValue_B{B: false}}},
+		{[]byte(`\xcafe`), bm.TextFormat, sql.BLOBType, &schema.SQLValue{Value: &schema.SQLValue_Bs{Bs: []byte{0xca, 0xfe}}}},
+		{[]byte("2021-12-07 14:21:32Z"), bm.TextFormat, sql.TimestampType, &schema.SQLValue{Value: &schema.SQLValue_Ts{Ts: schema.TimeToInt64(ts)}}},
+		{[]byte("2021-12-07 15:21:32+01:00"), bm.TextFormat, sql.TimestampType, &schema.SQLValue{Value: &schema.SQLValue_Ts{Ts: schema.TimeToInt64(ts)}}},
+		{[]byte("title"), bm.TextFormat, sql.VarcharType, &schema.SQLValue{Value: &schema.SQLValue_S{S: "title"}}},
+		{[]byte{0, 0, 0, 10}, bm.BinaryFormat, sql.IntegerType, &schema.SQLValue{Value: &schema.SQLValue_N{N: 10}}},
+		{[]byte{0, 0, 0, 0, 0, 0, 0, 10}, bm.BinaryFormat, sql.IntegerType, &schema.SQLValue{Value: &schema.SQLValue_N{N: 10}}},
+		{[]byte{0x3f, 0xf8, 0, 0, 0, 0, 0, 0}, bm.BinaryFormat, sql.FloatType, &schema.SQLValue{Value: &schema.SQLValue_F{F: 1.5}}},
+		{[]byte{1}, bm.BinaryFormat, sql.BooleanType, &schema.SQLValue{Value: &schema.SQLValue_B{B: true}}},
+		{[]byte{0xca, 0xfe}, bm.BinaryFormat, sql.BLOBType, &schema.SQLValue{Value: &schema.SQLValue_Bs{Bs: []byte{0xca, 0xfe}}}},
+		{[]byte("title"), bm.BinaryFormat, sql.VarcharType, &schema.SQLValue{Value: &schema.SQLValue_S{S: "title"}}},
+	}
+
+	for _, tc := range testCases {
+		v, err := decodeParam(tc.b, tc.formatCode, tc.t)
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, v)
+	}
+
+	_, err := decodeParam([]byte("ten"), bm.TextFormat, sql.IntegerType)
+	require.Error(t, err)
+
+	_, err = decodeParam([]byte("maybe"), bm.TextFormat, sql.BooleanType)
+	require.Error(t, err)
+
+	_, err = decodeParam([]byte{0, 10}, bm.BinaryFormat, sql.FloatType)
+	require.Error(t, err)
+
+	_, err = decodeParam([]byte("10"), 2, sql.IntegerType)
+	require.Error(t, err)
+}
+
+func TestPositionalParamsTypes(t *testing.T) {
+	types := positionalParamsTypes(map[string]sql.SQLValueType{
+		"param1": sql.IntegerType,
+		"param3": sql.BooleanType,
+		"title":  sql.VarcharType,
+	})
+	require.Equal(t, []sql.SQLValueType{sql.IntegerType, sql.VarcharType, sql.BooleanType}, types)
+}
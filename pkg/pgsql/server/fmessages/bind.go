@@ -0,0 +1,77 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fmessages
+
+// BindMsg gives the name of the source prepared statement, the name of the destination portal (an empty string
+// selects the unnamed portal), and the values to use for any parameter placeholders present in the prepared statement
+type BindMsg struct {
+	DestPortalName           string
+	PreparedStatementName    string
+	ParamsFormatCodes        []int16
+	ParamsValues             [][]byte
+	ResultColumnsFormatCodes []int16
+}
+
+func ParseBindMsg(b []byte) (BindMsg, error) {
+	p := &payload{b: b}
+
+	portal, err := p.string()
+	if err != nil {
+		return BindMsg{}, err
+	}
+
+	stmt, err := p.string()
+	if err != nil {
+		return BindMsg{}, err
+	}
+
+	paramsFormats, err := p.int16s()
+	if err != nil {
+		return BindMsg{}, err
+	}
+
+	n, err := p.int16()
+	if err != nil {
+		return BindMsg{}, err
+	}
+
+	if n < 0 {
+		return BindMsg{}, ErrMalformedMessage
+	}
+
+	values := make([][]byte, n)
+
+	for i := range values {
+		values[i], err = p.bytes()
+		if err != nil {
+			return BindMsg{}, err
+		}
+	}
+
+	resultFormats, err := p.int16s()
+	if err != nil {
+		return BindMsg{}, err
+	}
+
+	return BindMsg{
+		DestPortalName:           portal,
+		PreparedStatementName:    stmt,
+		ParamsFormatCodes:        paramsFormats,
+		ParamsValues:             values,
+		ResultColumnsFormatCodes: resultFormats,
+	}, nil
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fmessages
+
+// CloseMsg closes a prepared statement ('S') or a portal ('P')
+type CloseMsg struct {
+	CloseType byte
+	Name      string
+}
+
+func ParseCloseMsg(b []byte) (CloseMsg, error) {
+	p := &payload{b: b}
+
+	t, err := p.byte()
+	if err != nil {
+		return CloseMsg{}, err
+	}
+
+	if t != 'S' && t != 'P' {
+		return CloseMsg{}, ErrMalformedMessage
+	}
+
+	name, err := p.string()
+	if err != nil {
+		return CloseMsg{}, err
+	}
+
+	return CloseMsg{CloseType: t, Name: name}, nil
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fmessages
+
+// ExecuteMsg runs the portal, returning at most MaxRows rows. Zero denotes no limit
+type ExecuteMsg struct {
+	PortalName string
+	MaxRows    int32
+}
+
+func ParseExecuteMsg(b []byte) (ExecuteMsg, error) {
+	p := &payload{b: b}
+
+	name, err := p.string()
+	if err != nil {
+		return ExecuteMsg{}, err
+	}
+
+	maxRows, err := p.int32()
+	if err != nil {
+		return ExecuteMsg{}, err
+	}
+
+	return ExecuteMsg{PortalName: name, MaxRows: maxRows}, nil
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fmessages
+
+// ParseMsg contains a textual query string, optionally some information about data types of parameter placeholders,
+// and the name of a destination prepared-statement object (an empty string selects the unnamed prepared statement)
+type ParseMsg struct {
+	DestPreparedStatementName string
+	Statements                string
+	// object IDs of the parameter data types, zero leaves the type unspecified
+	ParamsOIDs []int32
+}
+
+func ParseParseMsg(b []byte) (ParseMsg, error) {
+	p := &payload{b: b}
+
+	name, err := p.string()
+	if err != nil {
+		return ParseMsg{}, err
+	}
+
+	stmts, err := p.string()
+	if err != nil {
+		return ParseMsg{}, err
+	}
+
+	n, err := p.int16()
+	if err != nil {
+		return ParseMsg{}, err
+	}
+
+	if n < 0 {
+		return ParseMsg{}, ErrMalformedMessage
+	}
+
+	oids := make([]int32, n)
+
+	for i := range oids {
+		oids[i], err = p.int32()
+		if err != nil {
+			return ParseMsg{}, err
+		}
+	}
+
+	return ParseMsg{DestPreparedStatementName: name, Statements: stmts, ParamsOIDs: oids}, nil
+}
@@ -21,6 +21,9 @@ type PasswordMsg struct {
 }
 
 func ParsePasswordMsg(payload []byte) PasswordMsg {
+	if len(payload) == 0 {
+		return PasswordMsg{}
+	}
 	password := payload[:len(payload)-1] //-1 A null-terminated string
 	return PasswordMsg{secret: string(password)}
 }
@@ -0,0 +1,118 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fmessages
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+)
+
+var ErrMalformedMessage = errors.New("malformed message")
+
+// payload reads the fields of a message one after the other
+type payload struct {
+	b []byte
+}
+
+func (p *payload) string() (string, error) {
+	i := bytes.IndexByte(p.b, 0)
+	if i < 0 {
+		return "", ErrMalformedMessage
+	}
+
+	s := string(p.b[:i])
+	p.b = p.b[i+1:]
+
+	return s, nil
+}
+
+func (p *payload) byte() (byte, error) {
+	if len(p.b) < 1 {
+		return 0, ErrMalformedMessage
+	}
+
+	v := p.b[0]
+	p.b = p.b[1:]
+
+	return v, nil
+}
+
+func (p *payload) int16() (int16, error) {
+	if len(p.b) < 2 {
+		return 0, ErrMalformedMessage
+	}
+
+	v := int16(binary.BigEndian.Uint16(p.b))
+	p.b = p.b[2:]
+
+	return v, nil
+}
+
+func (p *payload) int32() (int32, error) {
+	if len(p.b) < 4 {
+		return 0, ErrMalformedMessage
+	}
+
+	v := int32(binary.BigEndian.Uint32(p.b))
+	p.b = p.b[4:]
+
+	return v, nil
+}
+
+func (p *payload) int16s() ([]int16, error) {
+	n, err := p.int16()
+	if err != nil {
+		return nil, err
+	}
+
+	if n < 0 {
+		return nil, ErrMalformedMessage
+	}
+
+	vs := make([]int16, n)
+
+	for i := range vs {
+		vs[i], err = p.int16()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return vs, nil
+}
+
+// bytes reads a value preceded by its length, nil is returned for NULL values
+func (p *payload) bytes() ([]byte, error) {
+	l, err := p.int32()
+	if err != nil {
+		return nil, err
+	}
+
+	if l == -1 {
+		return nil, nil
+	}
+
+	if l < -1 || int(l) > len(p.b) {
+		return nil, ErrMalformedMessage
+	}
+
+	v := p.b[:l]
+	p.b = p.b[l:]
+
+	return v, nil
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fmessages
+
+// SASLInitialResponseMsg selects the SASL mechanism and carries the first message of the client.
+// It shares the 'p' type with PasswordMsg, the authentication in progress tells them apart
+type SASLInitialResponseMsg struct {
+	Mechanism string
+	Data      []byte
+}
+
+func ParseSASLInitialResponseMsg(b []byte) (SASLInitialResponseMsg, error) {
+	p := &payload{b: b}
+
+	mechanism, err := p.string()
+	if err != nil {
+		return SASLInitialResponseMsg{}, err
+	}
+
+	data, err := p.bytes()
+	if err != nil {
+		return SASLInitialResponseMsg{}, err
+	}
+
+	return SASLInitialResponseMsg{Mechanism: mechanism, Data: data}, nil
+}
+
+// SASLResponseMsg carries a further message of the client during a SASL authentication
+type SASLResponseMsg struct {
+	Data []byte
+}
+
+func ParseSASLResponseMsg(b []byte) SASLResponseMsg {
+	return SASLResponseMsg{Data: b}
+}
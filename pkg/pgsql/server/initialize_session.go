@@ -19,14 +19,18 @@ package server
 import (
 	"bufio"
 	"bytes"
+	"crypto/tls"
 	"encoding/binary"
 	"errors"
 	"fmt"
 	"github.com/codenotary/immudb/pkg/database"
 	bm "github.com/codenotary/immudb/pkg/pgsql/server/bmessages"
-	fm "github.com/codenotary/immudb/pkg/pgsql/server/fmessages"
-	"github.com/codenotary/immudb/pkg/pgsql/server/pgmeta"
-	"strings"
+)
+
+// codes sent in place of the protocol version by the requests negotiating the encryption of the connection
+const (
+	sslRequestVersion    = "1234.5679"
+	gssEncRequestVersion = "1234.5680"
 )
 
 // InitializeSession
@@ -38,46 +42,54 @@ func (s *session) InitializeSession() (err error) {
 		}
 	}()
 
-	lb := make([]byte, 4)
-	if _, err := s.mr.Read(lb); err != nil {
-		return err
-	}
-	pvb := make([]byte, 4)
-	if _, err := s.mr.Read(pvb); err != nil {
+	lb, pvb, err := s.readStartupHeader()
+	if err != nil {
 		return err
 	}
 
 	s.protocolVersion = parseProtocolVersion(pvb)
 
+	// GSSAPI encryption is not supported, clients go on with the SSL negotiation or with plaintext
+	if s.protocolVersion == gssEncRequestVersion {
+		if _, err = s.writeMessage([]byte(`N`)); err != nil {
+			return err
+		}
+
+		if lb, pvb, err = s.readStartupHeader(); err != nil {
+			return err
+		}
+
+		s.protocolVersion = parseProtocolVersion(pvb)
+	}
+
 	// SSL Request packet
-	if s.protocolVersion == "1234.5679" {
+	if s.protocolVersion == sslRequestVersion {
 		if s.tlsConfig == nil || len(s.tlsConfig.Certificates) == 0 {
+			// clients may go on with plaintext, unless they require SSL themselves
 			if _, err = s.writeMessage([]byte(`N`)); err != nil {
 				return err
 			}
-			return ErrSSLNotSupported
-		}
-
-		if _, err = s.writeMessage([]byte(`S`)); err != nil {
-			return err
-		}
+		} else {
+			if _, err = s.writeMessage([]byte(`S`)); err != nil {
+				return err
+			}
 
-		if err = s.handshake(); err != nil {
-			return err
+			if err = s.handshake(); err != nil {
+				return err
+			}
 		}
 
-		lb = make([]byte, 4)
-		if _, err := s.mr.Read(lb); err != nil {
-			return err
-		}
-		pvb = make([]byte, 4)
-		if _, err := s.mr.Read(pvb); err != nil {
+		if lb, pvb, err = s.readStartupHeader(); err != nil {
 			return err
 		}
 
 		s.protocolVersion = parseProtocolVersion(pvb)
 	}
 
+	if s.protocolVersion == sslRequestVersion || s.protocolVersion == gssEncRequestVersion {
+		return ErrUnexpectedNegotiationRequest
+	}
+
 	// startup message
 	connStringLenght := int(binary.BigEndian.Uint32(lb) - 4)
 	connString := make([]byte, connStringLenght)
@@ -86,6 +98,11 @@ func (s *session) InitializeSession() (err error) {
 		return err
 	}
 
+	// the startup message is read before being refused, so the client gets the error
+	if _, isTLS := s.mr.Connection().(*tls.Conn); s.requireSSL && !isTLS {
+		return ErrSSLRequired
+	}
+
 	pr := bufio.NewScanner(bytes.NewBuffer(connString))
 
 	split := func(data []byte, atEOF bool) (int, []byte, error) {
@@ -139,54 +156,53 @@ func (s *session) HandleStartup(dbList database.DatabaseList) (err error) {
 	if !ok {
 		return ErrDBNotprovided
 	}
-	s.database, err = dbList.GetByName(db)
-	if err != nil {
+	s.dbList = dbList
+	// the database is looked up before authenticating the user, as it's done by PostgreSQL
+	if _, err := dbList.GetByName(db); err != nil {
 		if errors.Is(err, database.ErrDatabaseNotExists) {
 			return ErrDBNotExists
 		}
 		return err
 	}
-	s.log.Debugf("selected %s database", s.database.GetName())
 
-	if _, err = s.writeMessage(bm.AuthenticationCleartextPassword()); err != nil {
-		return err
-	}
-	msg, err := s.nextMessage()
+	usr, err := s.authenticate()
 	if err != nil {
 		return err
 	}
-	if pw, ok := msg.(fm.PasswordMsg); ok {
-		if !ok || pw.GetSecret() == "" {
-			return ErrPwNotprovided
-		}
-		usr, err := s.getUser([]byte(s.username))
-		if err != nil {
-			if strings.Contains(err.Error(), "key not found") {
-				return ErrUsernameNotFound
-			}
-		}
-		if err := usr.ComparePasswords([]byte(pw.GetSecret())); err != nil {
-			return err
-		}
-		s.log.Debugf("authentication successful for %s", s.username)
-		if _, err := s.writeMessage(bm.AuthenticationOk()); err != nil {
-			return err
-		}
+	s.user = usr
+	s.log.Debugf("authentication successful for %s", s.username)
+
+	if err := s.selectDatabase(db); err != nil {
+		return err
 	}
-	if _, err := s.writeMessage(bm.ParameterStatus([]byte("standard_conforming_strings"), []byte("on"))); err != nil {
+	if _, err := s.writeMessage(bm.AuthenticationOk()); err != nil {
 		return err
 	}
-	if _, err := s.writeMessage(bm.ParameterStatus([]byte("client_encoding"), []byte("UTF8"))); err != nil {
+	// parameters given in the startup message are applied as if they were set by the client
+	if err := s.initSessionParams(); err != nil {
 		return err
 	}
-	// todo this is needed by jdbc driver. Here is added the minor supported version at the moment
-	if _, err := s.writeMessage(bm.ParameterStatus([]byte("server_version"), []byte(pgmeta.PgsqlProtocolVersion))); err != nil {
+	// server_version is needed by jdbc driver. Here is reported the minor supported version at the moment
+	if err := s.writeReportedParams(); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// readStartupHeader reads the length and the protocol version of a startup message, or the code of a negotiation request
+func (s *session) readStartupHeader() (lb, pvb []byte, err error) {
+	lb = make([]byte, 4)
+	if _, err := s.mr.Read(lb); err != nil {
+		return nil, nil, err
+	}
+	pvb = make([]byte, 4)
+	if _, err := s.mr.Read(pvb); err != nil {
+		return nil, nil, err
+	}
+	return lb, pvb, nil
+}
+
 func parseProtocolVersion(payload []byte) string {
 	major := int(binary.BigEndian.Uint16(payload[0:2]))
 	minor := int(binary.BigEndian.Uint16(payload[2:4]))
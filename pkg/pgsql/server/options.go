@@ -18,6 +18,7 @@ package server
 
 import (
 	"crypto/tls"
+	"github.com/codenotary/immudb/pkg/auth"
 	"github.com/codenotary/immudb/pkg/database"
 	"github.com/codenotary/immudb/pkg/logger"
 )
@@ -54,6 +55,30 @@ func TlsConfig(tlsConfig *tls.Config) Option {
 	}
 }
 
+// RequireSSL makes the server refuse clients not negotiating SSL
+func RequireSSL(require bool) Option {
+	return func(args *srv) {
+		args.requireSSL = require
+	}
+}
+
+// AuthMethod sets the authentication method of the clients: password (cleartext), md5 or scram-sha-256
+func AuthMethod(method string) Option {
+	return func(args *srv) {
+		args.authMethod = method
+	}
+}
+
+// CreateDatabaseFunc creates a database on behalf of the user, as requested by a CREATE DATABASE statement
+type CreateDatabaseFunc func(user *auth.User, name string) error
+
+// DatabaseCreator enables CREATE DATABASE statements, which are refused if it's not set
+func DatabaseCreator(f CreateDatabaseFunc) Option {
+	return func(args *srv) {
+		args.createDatabase = f
+	}
+}
+
 func SessFactory(sf SessionFactory) Option {
 	return func(args *srv) {
 		args.SessionFactory = sf
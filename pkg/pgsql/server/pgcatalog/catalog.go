@@ -0,0 +1,518 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgcatalog
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/codenotary/immudb/embedded/sql"
+	"github.com/codenotary/immudb/pkg/auth"
+	"github.com/codenotary/immudb/pkg/database"
+	"github.com/codenotary/immudb/pkg/pgsql/server/pgmeta"
+)
+
+var ErrSyntax = errors.New("syntax error")
+var ErrUnsupportedQuery = errors.New("catalog query not supported")
+var ErrUndefinedRelation = errors.New("relation does not exist")
+var ErrUndefinedColumn = errors.New("column does not exist")
+var ErrAmbiguousColumn = errors.New("column reference is ambiguous")
+var ErrUndefinedFunction = errors.New("function does not exist")
+var ErrInvalidValue = errors.New("invalid value")
+
+const (
+	// owner of all the objects, reported as the system admin
+	ownerOid = 10
+
+	pgCatalogOid         = 11
+	publicOid            = 2200
+	informationSchemaOid = 13000
+
+	heapAmOid  = 2
+	btreeAmOid = 403
+
+	utf8EncodingID = 6
+
+	// oids of the objects created by users start from here, as in PostgreSQL
+	firstUserOid = 16384
+)
+
+// schemas holds the namespaces by oid, tables of the database belong to the public one
+var schemas = map[int64]string{
+	pgCatalogOid:         "pg_catalog",
+	publicOid:            "public",
+	informationSchemaOid: "information_schema",
+}
+
+var schemasOids = []int64{pgCatalogOid, publicOid, informationSchemaOid}
+
+// Catalog is what the virtual relations are built from, as seen by the session issuing the query
+type Catalog struct {
+	// Database is the database selected by the session
+	Database string
+	// Databases are those the user has a permission on
+	Databases []*DatabaseInfo
+	// Tables of the selected database, they're in the public schema
+	Tables []*database.SQLTableInfo
+	User   string
+}
+
+// DatabaseInfo identifies a database, the id is its index in the list of databases
+type DatabaseInfo struct {
+	ID   int64
+	Name string
+}
+
+// pgType is a type of PostgreSQL, as described by pg_type and format_type
+type pgType struct {
+	oid     int64
+	name    string
+	sqlName string
+	len     int64
+}
+
+var pgTypes = []*pgType{
+	{oid: 16, name: "bool", sqlName: "boolean", len: 1},
+	{oid: 17, name: "bytea", sqlName: "bytea", len: -1},
+	{oid: 19, name: "name", sqlName: "name", len: 64},
+	{oid: 20, name: "int8", sqlName: "bigint", len: 8},
+	{oid: 23, name: "int4", sqlName: "integer", len: 4},
+	{oid: 25, name: "text", sqlName: "text", len: -1},
+	{oid: 26, name: "oid", sqlName: "oid", len: 4},
+	{oid: 114, name: "json", sqlName: "json", len: -1},
+	{oid: 701, name: "float8", sqlName: "double precision", len: 8},
+	{oid: 1043, name: "varchar", sqlName: "character varying", len: -1},
+	{oid: 1184, name: "timestamptz", sqlName: "timestamp with time zone", len: 8},
+}
+
+func pgTypeByOid(oid int64) *pgType {
+	for _, t := range pgTypes {
+		if t.oid == oid {
+			return t
+		}
+	}
+	return nil
+}
+
+// typeOf returns the type a column of the given immudb type is exposed as by the pgsql server
+func typeOf(t sql.SQLValueType) *pgType {
+	m, ok := pgmeta.PgTypeMap[t]
+	if ok {
+		if pt := pgTypeByOid(int64(m[pgmeta.PgTypeMapOid])); pt != nil {
+			return pt
+		}
+	}
+	return pgTypeByOid(25)
+}
+
+type column struct {
+	name string
+	// INTEGER, VARCHAR or BOOLEAN, the values of the rows are int64, string and bool respectively
+	typ sql.SQLValueType
+}
+
+// relation is a virtual relation of pg_catalog or information_schema, its rows are built on each query
+type relation struct {
+	oid       int64
+	namespace int64
+	name      string
+	// relkind, 'r' for tables and 'v' for views
+	kind string
+	cols []*column
+	rows func(c *Catalog) [][]interface{}
+}
+
+func cols(defs ...string) []*column {
+	cs := make([]*column, 0, len(defs)/2)
+	for i := 0; i < len(defs); i += 2 {
+		cs = append(cs, &column{name: defs[i], typ: defs[i+1]})
+	}
+	return cs
+}
+
+// relations are set on init as some of them describe the relations themselves
+var relations []*relation
+
+func init() {
+	const (
+		i = sql.IntegerType
+		v = sql.VarcharType
+		b = sql.BooleanType
+	)
+
+	relations = []*relation{
+		{
+			oid: 2615, namespace: pgCatalogOid, name: "pg_namespace", kind: "r",
+			cols: cols("oid", i, "nspname", v, "nspowner", i, "nspacl", v),
+			rows: namespaceRows,
+		},
+		{
+			oid: 1259, namespace: pgCatalogOid, name: "pg_class", kind: "r",
+			cols: cols(
+				"oid", i, "relname", v, "relnamespace", i, "reltype", i, "reloftype", i, "relowner", i, "relam", i,
+				"reltablespace", i, "reltoastrelid", i, "relhasindex", b, "relpersistence", v, "relkind", v,
+				"relnatts", i, "relchecks", i, "relhasrules", b, "relhastriggers", b, "relrowsecurity", b,
+				"relforcerowsecurity", b, "relispartition", b, "relreplident", v, "relacl", v, "reloptions", v,
+			),
+			rows: classRows,
+		},
+		{
+			oid: 1249, namespace: pgCatalogOid, name: "pg_attribute", kind: "r",
+			cols: cols(
+				"attrelid", i, "attname", v, "atttypid", i, "attlen", i, "attnum", i, "atttypmod", i,
+				"attnotnull", b, "atthasdef", b, "attidentity", v, "attgenerated", v, "attisdropped", b,
+				"attcollation", i,
+			),
+			rows: attributeRows,
+		},
+		{
+			oid: 1247, namespace: pgCatalogOid, name: "pg_type", kind: "r",
+			cols: cols(
+				"oid", i, "typname", v, "typnamespace", i, "typowner", i, "typlen", i, "typtype", v,
+				"typnotnull", b, "typbasetype", i, "typtypmod", i, "typelem", i, "typrelid", i,
+			),
+			rows: typeRows,
+		},
+		{
+			oid: 1262, namespace: pgCatalogOid, name: "pg_database", kind: "r",
+			cols: cols(
+				"oid", i, "datname", v, "datdba", i, "encoding", i, "datcollate", v, "datctype", v,
+				"datistemplate", b, "datallowconn", b, "datconnlimit", i, "datacl", v,
+			),
+			rows: databaseRows,
+		},
+		{
+			oid: 2601, namespace: pgCatalogOid, name: "pg_am", kind: "r",
+			cols: cols("oid", i, "amname", v, "amtype", v),
+			rows: func(c *Catalog) [][]interface{} {
+				return [][]interface{}{{int64(heapAmOid), "heap", "t"}, {int64(btreeAmOid), "btree", "i"}}
+			},
+		},
+		{
+			oid: 12000, namespace: pgCatalogOid, name: "pg_tables", kind: "v",
+			cols: cols(
+				"schemaname", v, "tablename", v, "tableowner", v, "tablespace", v, "hasindexes", b,
+				"hasrules", b, "hastriggers", b, "rowsecurity", b,
+			),
+			rows: tablesViewRows,
+		},
+		{
+			oid: 13001, namespace: informationSchemaOid, name: "schemata", kind: "v",
+			cols: cols("catalog_name", v, "schema_name", v, "schema_owner", v),
+			rows: schemataRows,
+		},
+		{
+			oid: 13002, namespace: informationSchemaOid, name: "tables", kind: "v",
+			cols: cols(
+				"table_catalog", v, "table_schema", v, "table_name", v, "table_type", v,
+				"is_insertable_into", v, "is_typed", v,
+			),
+			rows: infoTablesRows,
+		},
+		{
+			oid: 13003, namespace: informationSchemaOid, name: "columns", kind: "v",
+			cols: cols(
+				"table_catalog", v, "table_schema", v, "table_name", v, "column_name", v, "ordinal_position", i,
+				"column_default", v, "is_nullable", v, "data_type", v, "character_maximum_length", i,
+				"udt_name", v, "is_identity", v, "is_updatable", v,
+			),
+			rows: infoColumnsRows,
+		},
+		{
+			oid: 13004, namespace: informationSchemaOid, name: "table_constraints", kind: "v",
+			cols: cols(
+				"constraint_catalog", v, "constraint_schema", v, "constraint_name", v, "table_catalog", v,
+				"table_schema", v, "table_name", v, "constraint_type", v, "is_deferrable", v, "initially_deferred", v,
+			),
+			rows: infoTableConstraintsRows,
+		},
+		{
+			oid: 13005, namespace: informationSchemaOid, name: "key_column_usage", kind: "v",
+			cols: cols(
+				"constraint_catalog", v, "constraint_schema", v, "constraint_name", v, "table_catalog", v,
+				"table_schema", v, "table_name", v, "column_name", v, "ordinal_position", i,
+			),
+			rows: infoKeyColumnUsageRows,
+		},
+	}
+}
+
+// lookupRelation returns the relation by its name, relations of pg_catalog are found without giving the schema
+func lookupRelation(schema, name string) *relation {
+	if schema == "" {
+		schema = "pg_catalog"
+	}
+
+	for _, r := range relations {
+		if schemas[r.namespace] == schema && r.name == name {
+			return r
+		}
+	}
+
+	return nil
+}
+
+// relationOid returns the oid of the relation named as given, which may be qualified by its schema.
+// It's used to cast names to regclass
+func relationOid(c *Catalog, name string) (int64, bool) {
+	schema := ""
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		schema, name = name[:i], name[i+1:]
+	}
+
+	if schema == "" || schema == "public" {
+		for _, t := range c.Tables {
+			if t.Name == name {
+				return tableOid(t), true
+			}
+		}
+	}
+
+	if r := lookupRelation(schema, name); r != nil {
+		return r.oid, true
+	}
+
+	return 0, false
+}
+
+func tableOid(t *database.SQLTableInfo) int64 {
+	return firstUserOid + int64(t.ID)
+}
+
+func owner() string {
+	return auth.SysAdminUsername
+}
+
+func namespaceRows(c *Catalog) [][]interface{} {
+	var rows [][]interface{}
+	for _, oid := range schemasOids {
+		rows = append(rows, []interface{}{oid, schemas[oid], int64(ownerOid), nil})
+	}
+	return rows
+}
+
+// relationInfo is what relations and tables have in common, as described by pg_class and information_schema
+type relationInfo struct {
+	oid       int64
+	namespace int64
+	name      string
+	kind      string
+	cols      []*columnInfo
+	pk        []string
+}
+
+type columnInfo struct {
+	name          string
+	typ           *pgType
+	nullable      bool
+	autoIncrement bool
+}
+
+// allRelations returns the virtual relations followed by the tables of the database
+func allRelations(c *Catalog) []*relationInfo {
+	var rs []*relationInfo
+
+	for _, r := range relations {
+		ri := &relationInfo{oid: r.oid, namespace: r.namespace, name: r.name, kind: r.kind}
+		for _, col := range r.cols {
+			ri.cols = append(ri.cols, &columnInfo{name: col.name, typ: typeOf(col.typ), nullable: true})
+		}
+		rs = append(rs, ri)
+	}
+
+	for _, t := range c.Tables {
+		ri := &relationInfo{oid: tableOid(t), namespace: publicOid, name: t.Name, kind: "r", pk: t.PrimaryKey}
+		for _, col := range t.Columns {
+			ri.cols = append(ri.cols, &columnInfo{
+				name:          col.Name,
+				typ:           typeOf(col.Type),
+				nullable:      col.Nullable,
+				autoIncrement: col.AutoIncrement,
+			})
+		}
+		rs = append(rs, ri)
+	}
+
+	return rs
+}
+
+func classRows(c *Catalog) [][]interface{} {
+	var rows [][]interface{}
+
+	for _, r := range allRelations(c) {
+		am := int64(0)
+		if r.kind == "r" {
+			am = heapAmOid
+		}
+
+		rows = append(rows, []interface{}{
+			r.oid, r.name, r.namespace, int64(0), int64(0), int64(ownerOid), am,
+			int64(0), int64(0), len(r.pk) > 0, "p", r.kind,
+			int64(len(r.cols)), int64(0), false, false, false,
+			false, false, "d", nil, nil,
+		})
+	}
+
+	return rows
+}
+
+func attributeRows(c *Catalog) [][]interface{} {
+	var rows [][]interface{}
+
+	for _, r := range allRelations(c) {
+		for i, col := range r.cols {
+			identity := ""
+			if col.autoIncrement {
+				identity = "d"
+			}
+
+			rows = append(rows, []interface{}{
+				r.oid, col.name, col.typ.oid, col.typ.len, int64(i + 1), int64(-1),
+				!col.nullable, false, identity, "", false,
+				int64(0),
+			})
+		}
+	}
+
+	return rows
+}
+
+func typeRows(c *Catalog) [][]interface{} {
+	var rows [][]interface{}
+
+	for _, t := range pgTypes {
+		rows = append(rows, []interface{}{
+			t.oid, t.name, int64(pgCatalogOid), int64(ownerOid), t.len, "b",
+			false, int64(0), int64(-1), int64(0), int64(0),
+		})
+	}
+
+	return rows
+}
+
+func databaseRows(c *Catalog) [][]interface{} {
+	var rows [][]interface{}
+
+	for _, db := range c.Databases {
+		rows = append(rows, []interface{}{
+			firstUserOid + db.ID, db.Name, int64(ownerOid), int64(utf8EncodingID), "C", "C",
+			false, true, int64(-1), nil,
+		})
+	}
+
+	return rows
+}
+
+func tablesViewRows(c *Catalog) [][]interface{} {
+	var rows [][]interface{}
+
+	for _, r := range allRelations(c) {
+		if r.kind != "r" {
+			continue
+		}
+
+		rows = append(rows, []interface{}{
+			schemas[r.namespace], r.name, owner(), nil, len(r.pk) > 0,
+			false, false, false,
+		})
+	}
+
+	return rows
+}
+
+func schemataRows(c *Catalog) [][]interface{} {
+	var rows [][]interface{}
+	for _, oid := range schemasOids {
+		rows = append(rows, []interface{}{c.Database, schemas[oid], owner()})
+	}
+	return rows
+}
+
+func infoTablesRows(c *Catalog) [][]interface{} {
+	var rows [][]interface{}
+
+	for _, r := range allRelations(c) {
+		tableType, insertable := "BASE TABLE", "YES"
+		if r.kind == "v" || r.namespace != publicOid {
+			insertable = "NO"
+		}
+		if r.kind == "v" {
+			tableType = "VIEW"
+		}
+
+		rows = append(rows, []interface{}{c.Database, schemas[r.namespace], r.name, tableType, insertable, "NO"})
+	}
+
+	return rows
+}
+
+func infoColumnsRows(c *Catalog) [][]interface{} {
+	var rows [][]interface{}
+
+	for _, r := range allRelations(c) {
+		for i, col := range r.cols {
+			nullable, identity := "YES", "NO"
+			if !col.nullable {
+				nullable = "NO"
+			}
+			if col.autoIncrement {
+				identity = "YES"
+			}
+
+			updatable := "YES"
+			if r.namespace != publicOid {
+				updatable = "NO"
+			}
+
+			rows = append(rows, []interface{}{
+				c.Database, schemas[r.namespace], r.name, col.name, int64(i + 1),
+				nil, nullable, col.typ.sqlName, nil,
+				col.typ.name, identity, updatable,
+			})
+		}
+	}
+
+	return rows
+}
+
+func infoTableConstraintsRows(c *Catalog) [][]interface{} {
+	var rows [][]interface{}
+
+	for _, t := range c.Tables {
+		rows = append(rows, []interface{}{
+			c.Database, "public", t.Name + "_pkey", c.Database,
+			"public", t.Name, "PRIMARY KEY", "NO", "NO",
+		})
+	}
+
+	return rows
+}
+
+func infoKeyColumnUsageRows(c *Catalog) [][]interface{} {
+	var rows [][]interface{}
+
+	for _, t := range c.Tables {
+		for i, col := range t.PrimaryKey {
+			rows = append(rows, []interface{}{
+				c.Database, "public", t.Name + "_pkey", c.Database,
+				"public", t.Name, col, int64(i + 1),
+			})
+		}
+	}
+
+	return rows
+}
@@ -0,0 +1,656 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgcatalog
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/codenotary/immudb/embedded/sql"
+)
+
+// env is what expressions are evaluated against: a row of the relations of the query, joined together
+type env struct {
+	row     []interface{}
+	params  []interface{}
+	catalog *Catalog
+}
+
+type expr interface {
+	// bind resolves the column references against the relations of the query
+	bind(s *scope) error
+	typ() sql.SQLValueType
+	eval(e *env) (interface{}, error)
+}
+
+type constant struct {
+	val interface{}
+	t   sql.SQLValueType
+}
+
+func (c *constant) bind(s *scope) error   { return nil }
+func (c *constant) typ() sql.SQLValueType { return c.t }
+func (c *constant) eval(e *env) (interface{}, error) {
+	return c.val, nil
+}
+
+// param is a positional parameter, $1 being the first one. Parameters are given as text
+type param struct {
+	pos int
+}
+
+func (p *param) bind(s *scope) error   { return nil }
+func (p *param) typ() sql.SQLValueType { return sql.VarcharType }
+func (p *param) eval(e *env) (interface{}, error) {
+	if p.pos > len(e.params) {
+		return nil, fmt.Errorf("%w: no value for parameter $%d", ErrInvalidValue, p.pos)
+	}
+	return e.params[p.pos-1], nil
+}
+
+type colRef struct {
+	qualifier string
+	name      string
+	idx       int
+	t         sql.SQLValueType
+}
+
+func (c *colRef) bind(s *scope) (err error) {
+	c.idx, c.t, err = s.resolve(c.qualifier, c.name)
+	return err
+}
+
+func (c *colRef) typ() sql.SQLValueType { return c.t }
+func (c *colRef) eval(e *env) (interface{}, error) {
+	return e.row[c.idx], nil
+}
+
+type notExp struct {
+	exp expr
+}
+
+func (n *notExp) bind(s *scope) error   { return n.exp.bind(s) }
+func (n *notExp) typ() sql.SQLValueType { return sql.BooleanType }
+func (n *notExp) eval(e *env) (interface{}, error) {
+	v, err := n.exp.eval(e)
+	if err != nil || v == nil {
+		return nil, err
+	}
+
+	bv, err := toBool(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return !bv, nil
+}
+
+type negExp struct {
+	exp expr
+}
+
+func (n *negExp) bind(s *scope) error   { return n.exp.bind(s) }
+func (n *negExp) typ() sql.SQLValueType { return sql.IntegerType }
+func (n *negExp) eval(e *env) (interface{}, error) {
+	v, err := n.exp.eval(e)
+	if err != nil || v == nil {
+		return nil, err
+	}
+
+	iv, err := toInt(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return -iv, nil
+}
+
+// logicExp is AND or OR, evaluated with the three-valued logic of SQL
+type logicExp struct {
+	and  bool
+	l, r expr
+}
+
+func (l *logicExp) bind(s *scope) error {
+	if err := l.l.bind(s); err != nil {
+		return err
+	}
+	return l.r.bind(s)
+}
+
+func (l *logicExp) typ() sql.SQLValueType { return sql.BooleanType }
+func (l *logicExp) eval(e *env) (interface{}, error) {
+	var hasNull bool
+
+	for _, exp := range []expr{l.l, l.r} {
+		v, err := exp.eval(e)
+		if err != nil {
+			return nil, err
+		}
+
+		if v == nil {
+			hasNull = true
+			continue
+		}
+
+		bv, err := toBool(v)
+		if err != nil {
+			return nil, err
+		}
+
+		// false decides AND as true decides OR
+		if bv != l.and {
+			return bv, nil
+		}
+	}
+
+	if hasNull {
+		return nil, nil
+	}
+
+	return l.and, nil
+}
+
+// binaryExp is a comparison, an arithmetic operation, a concatenation or a match against a pattern
+type binaryExp struct {
+	op   string
+	l, r expr
+	// patterns of LIKE and regular expression matches, compiled once
+	patterns map[string]*regexp.Regexp
+}
+
+func (b *binaryExp) bind(s *scope) error {
+	if err := b.l.bind(s); err != nil {
+		return err
+	}
+	return b.r.bind(s)
+}
+
+func (b *binaryExp) typ() sql.SQLValueType {
+	switch b.op {
+	case "||":
+		return sql.VarcharType
+	case "+", "-":
+		return sql.IntegerType
+	}
+	return sql.BooleanType
+}
+
+func (b *binaryExp) eval(e *env) (interface{}, error) {
+	lv, err := b.l.eval(e)
+	if err != nil {
+		return nil, err
+	}
+
+	rv, err := b.r.eval(e)
+	if err != nil {
+		return nil, err
+	}
+
+	if lv == nil || rv == nil {
+		return nil, nil
+	}
+
+	switch b.op {
+	case "||":
+		return toString(lv) + toString(rv), nil
+	case "+", "-":
+		li, err := toInt(lv)
+		if err != nil {
+			return nil, err
+		}
+		ri, err := toInt(rv)
+		if err != nil {
+			return nil, err
+		}
+		if b.op == "+" {
+			return li + ri, nil
+		}
+		return li - ri, nil
+	case "~", "!~", "~*", "!~*", "like", "not like", "ilike", "not ilike":
+		re, err := b.pattern(toString(rv))
+		if err != nil {
+			return nil, err
+		}
+		negated := strings.HasPrefix(b.op, "!") || strings.HasPrefix(b.op, "not ")
+		return re.MatchString(toString(lv)) != negated, nil
+	}
+
+	cmp, err := compareValues(lv, rv)
+	if err != nil {
+		return nil, err
+	}
+
+	switch b.op {
+	case "=":
+		return cmp == 0, nil
+	case "<>", "!=":
+		return cmp != 0, nil
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case ">":
+		return cmp > 0, nil
+	case ">=":
+		return cmp >= 0, nil
+	}
+
+	return nil, fmt.Errorf("%w: operator %s", ErrUnsupportedQuery, b.op)
+}
+
+func (b *binaryExp) pattern(p string) (*regexp.Regexp, error) {
+	if re, ok := b.patterns[p]; ok {
+		return re, nil
+	}
+
+	src := p
+
+	if strings.HasSuffix(b.op, "like") {
+		src = "^" + likeToRegexp(p) + "$"
+	}
+
+	if strings.HasSuffix(b.op, "*") || strings.HasSuffix(b.op, "ilike") {
+		src = "(?i)" + src
+	}
+
+	re, err := regexp.Compile(src)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid pattern '%s'", ErrInvalidValue, p)
+	}
+
+	if b.patterns == nil {
+		b.patterns = make(map[string]*regexp.Regexp)
+	}
+	b.patterns[p] = re
+
+	return re, nil
+}
+
+// likeToRegexp translates a LIKE pattern, where backslash escapes the following char
+func likeToRegexp(p string) string {
+	var sb strings.Builder
+
+	rs := []rune(p)
+
+	for i := 0; i < len(rs); i++ {
+		switch rs[i] {
+		case '%':
+			sb.WriteString("(?s:.*)")
+		case '_':
+			sb.WriteString("(?s:.)")
+		case '\\':
+			if i+1 < len(rs) {
+				i++
+			}
+			sb.WriteString(regexp.QuoteMeta(string(rs[i])))
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(rs[i])))
+		}
+	}
+
+	return sb.String()
+}
+
+// isExp is IS [NOT] NULL, IS [NOT] TRUE and IS [NOT] FALSE
+type isExp struct {
+	exp expr
+	not bool
+	// nil for IS NULL
+	val interface{}
+}
+
+func (i *isExp) bind(s *scope) error   { return i.exp.bind(s) }
+func (i *isExp) typ() sql.SQLValueType { return sql.BooleanType }
+func (i *isExp) eval(e *env) (interface{}, error) {
+	v, err := i.exp.eval(e)
+	if err != nil {
+		return nil, err
+	}
+
+	res := v == nil
+
+	if i.val != nil && v != nil {
+		bv, err := toBool(v)
+		if err != nil {
+			return nil, err
+		}
+		res = bv == i.val
+	}
+
+	return res != i.not, nil
+}
+
+type inExp struct {
+	exp  expr
+	list []expr
+	not  bool
+}
+
+func (in *inExp) bind(s *scope) error {
+	for _, exp := range append([]expr{in.exp}, in.list...) {
+		if err := exp.bind(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (in *inExp) typ() sql.SQLValueType { return sql.BooleanType }
+func (in *inExp) eval(e *env) (interface{}, error) {
+	v, err := in.exp.eval(e)
+	if err != nil || v == nil {
+		return nil, err
+	}
+
+	var hasNull bool
+
+	for _, exp := range in.list {
+		lv, err := exp.eval(e)
+		if err != nil {
+			return nil, err
+		}
+
+		if lv == nil {
+			hasNull = true
+			continue
+		}
+
+		cmp, err := compareValues(v, lv)
+		if err != nil {
+			return nil, err
+		}
+
+		if cmp == 0 {
+			return !in.not, nil
+		}
+	}
+
+	if hasNull {
+		return nil, nil
+	}
+
+	return in.not, nil
+}
+
+type whenClause struct {
+	cond   expr
+	result expr
+}
+
+// caseExp is either a simple CASE, when there is an operand, or a searched one
+type caseExp struct {
+	operand expr
+	whens   []*whenClause
+	els     expr
+}
+
+func (c *caseExp) bind(s *scope) error {
+	exps := []expr{}
+	if c.operand != nil {
+		exps = append(exps, c.operand)
+	}
+	for _, w := range c.whens {
+		exps = append(exps, w.cond, w.result)
+	}
+	if c.els != nil {
+		exps = append(exps, c.els)
+	}
+
+	for _, exp := range exps {
+		if err := exp.bind(s); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *caseExp) typ() sql.SQLValueType {
+	for _, w := range c.whens {
+		if cnst, isConst := w.result.(*constant); !isConst || cnst.val != nil {
+			return w.result.typ()
+		}
+	}
+	if c.els != nil {
+		return c.els.typ()
+	}
+	return sql.VarcharType
+}
+
+func (c *caseExp) eval(e *env) (interface{}, error) {
+	var operand interface{}
+
+	if c.operand != nil {
+		v, err := c.operand.eval(e)
+		if err != nil {
+			return nil, err
+		}
+		operand = v
+	}
+
+	for _, w := range c.whens {
+		v, err := w.cond.eval(e)
+		if err != nil {
+			return nil, err
+		}
+
+		var matches bool
+
+		if c.operand != nil {
+			if operand != nil && v != nil {
+				cmp, err := compareValues(operand, v)
+				if err != nil {
+					return nil, err
+				}
+				matches = cmp == 0
+			}
+		} else if v != nil {
+			matches, err = toBool(v)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if matches {
+			return w.result.eval(e)
+		}
+	}
+
+	if c.els == nil {
+		return nil, nil
+	}
+
+	return c.els.eval(e)
+}
+
+type castExp struct {
+	exp      expr
+	typeName string
+	t        sql.SQLValueType
+}
+
+// castType returns the type values are converted to by casting them to the named type
+func castType(name string) (sql.SQLValueType, bool) {
+	switch name {
+	case "int", "int2", "int4", "int8", "integer", "smallint", "bigint",
+		"oid", "regclass", "regtype", "regproc", "regnamespace", "regrole":
+		return sql.IntegerType, true
+	case "bool", "boolean":
+		return sql.BooleanType, true
+	case "text", "varchar", "character varying", "char", "character", "bpchar", "name":
+		return sql.VarcharType, true
+	}
+	return "", false
+}
+
+func (c *castExp) bind(s *scope) error   { return c.exp.bind(s) }
+func (c *castExp) typ() sql.SQLValueType { return c.t }
+func (c *castExp) eval(e *env) (interface{}, error) {
+	v, err := c.exp.eval(e)
+	if err != nil || v == nil {
+		return nil, err
+	}
+
+	switch c.t {
+	case sql.VarcharType:
+		return toString(v), nil
+	case sql.BooleanType:
+		return toBool(v)
+	}
+
+	s, isString := v.(string)
+
+	switch {
+	case isString && c.typeName == "regclass":
+		oid, ok := relationOid(e.catalog, s)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUndefinedRelation, s)
+		}
+		return oid, nil
+	case isString && c.typeName == "regtype":
+		for _, t := range pgTypes {
+			if t.name == s || t.sqlName == s {
+				return t.oid, nil
+			}
+		}
+		return nil, fmt.Errorf("%w: type %s does not exist", ErrInvalidValue, s)
+	}
+
+	return toInt(v)
+}
+
+type funcCall struct {
+	name string
+	args []expr
+	fn   *function
+}
+
+func (f *funcCall) bind(s *scope) error {
+	for _, arg := range f.args {
+		if err := arg.bind(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *funcCall) typ() sql.SQLValueType {
+	if f.fn.t == "" {
+		return f.args[0].typ()
+	}
+	return f.fn.t
+}
+
+func (f *funcCall) eval(e *env) (interface{}, error) {
+	args := make([]interface{}, len(f.args))
+
+	for i, arg := range f.args {
+		v, err := arg.eval(e)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = v
+	}
+
+	return f.fn.call(e, args)
+}
+
+func toBool(v interface{}) (bool, error) {
+	switch tv := v.(type) {
+	case bool:
+		return tv, nil
+	case string:
+		switch strings.ToLower(strings.TrimSpace(tv)) {
+		case "t", "true", "y", "yes", "on", "1":
+			return true, nil
+		case "f", "false", "n", "no", "off", "0":
+			return false, nil
+		}
+	}
+	return false, fmt.Errorf("%w: '%s' is not a boolean", ErrInvalidValue, toString(v))
+}
+
+func toInt(v interface{}) (int64, error) {
+	switch tv := v.(type) {
+	case int64:
+		return tv, nil
+	case bool:
+		if tv {
+			return 1, nil
+		}
+		return 0, nil
+	case string:
+		iv, err := strconv.ParseInt(strings.TrimSpace(tv), 10, 64)
+		if err == nil {
+			return iv, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: '%s' is not an integer", ErrInvalidValue, toString(v))
+}
+
+func toString(v interface{}) string {
+	switch tv := v.(type) {
+	case string:
+		return tv
+	case int64:
+		return strconv.FormatInt(tv, 10)
+	case bool:
+		return strconv.FormatBool(tv)
+	}
+	return ""
+}
+
+// compareValues compares values of the same type. Text compared to integers or booleans, as given
+// by parameters or by literals, is converted to their type first
+func compareValues(a, b interface{}) (int, error) {
+	switch av := a.(type) {
+	case int64:
+		bv, err := toInt(b)
+		if err != nil {
+			return 0, err
+		}
+		switch {
+		case av < bv:
+			return -1, nil
+		case av > bv:
+			return 1, nil
+		}
+		return 0, nil
+	case bool:
+		bv, err := toBool(b)
+		if err != nil {
+			return 0, err
+		}
+		switch {
+		case av == bv:
+			return 0, nil
+		case !av:
+			return -1, nil
+		}
+		return 1, nil
+	case string:
+		if _, isString := b.(string); !isString {
+			cmp, err := compareValues(b, a)
+			return -cmp, err
+		}
+		return strings.Compare(av, b.(string)), nil
+	}
+	return 0, fmt.Errorf("%w: values can't be compared", ErrInvalidValue)
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgcatalog
+
+import (
+	"strings"
+
+	"github.com/codenotary/immudb/embedded/sql"
+	"github.com/codenotary/immudb/pkg/pgsql/server/pgmeta"
+)
+
+// function is a function of PostgreSQL, as far as needed to answer catalog queries
+type function struct {
+	minArgs int
+	// -1 if there is no limit
+	maxArgs int
+	// empty if it's the type of the first argument
+	t    sql.SQLValueType
+	call func(e *env, args []interface{}) (interface{}, error)
+}
+
+func constantFn(minArgs, maxArgs int, t sql.SQLValueType, val interface{}) *function {
+	return &function{minArgs: minArgs, maxArgs: maxArgs, t: t, call: func(e *env, args []interface{}) (interface{}, error) {
+		return val, nil
+	}}
+}
+
+// with no ACL nor search_path, all the objects are visible and accessible to the user,
+// who is only restricted by its permission on the database
+var visibleFn = constantFn(1, 1, sql.BooleanType, true)
+var privilegeFn = constantFn(2, 4, sql.BooleanType, true)
+
+// the catalog holds no description, default expression, definition nor array, thus they're all NULL
+var nullFn = constantFn(1, 3, sql.VarcharType, nil)
+
+var functions = map[string]*function{
+	"pg_table_is_visible":    visibleFn,
+	"pg_type_is_visible":     visibleFn,
+	"pg_function_is_visible": visibleFn,
+	"has_table_privilege":    privilegeFn,
+	"has_schema_privilege":   privilegeFn,
+	"has_database_privilege": privilegeFn,
+	"has_column_privilege":   privilegeFn,
+	"obj_description":        nullFn,
+	"col_description":        nullFn,
+	"shobj_description":      nullFn,
+	"pg_get_expr":            nullFn,
+	"pg_get_indexdef":        nullFn,
+	"pg_get_constraintdef":   nullFn,
+	"pg_get_viewdef":         nullFn,
+	"array_to_string":        nullFn,
+	"pg_get_userbyid": {minArgs: 1, maxArgs: 1, t: sql.VarcharType, call: func(e *env, args []interface{}) (interface{}, error) {
+		return owner(), nil
+	}},
+	"format_type": {minArgs: 2, maxArgs: 2, t: sql.VarcharType, call: func(e *env, args []interface{}) (interface{}, error) {
+		if args[0] == nil {
+			return nil, nil
+		}
+		oid, err := toInt(args[0])
+		if err != nil {
+			return nil, err
+		}
+		if t := pgTypeByOid(oid); t != nil {
+			return t.sqlName, nil
+		}
+		return "???", nil
+	}},
+	"pg_encoding_to_char": {minArgs: 1, maxArgs: 1, t: sql.VarcharType, call: func(e *env, args []interface{}) (interface{}, error) {
+		if id, err := toInt(args[0]); err == nil && id == utf8EncodingID {
+			return "UTF8", nil
+		}
+		return "", nil
+	}},
+	"current_schema": constantFn(0, 0, sql.VarcharType, "public"),
+	"current_database": {maxArgs: 0, t: sql.VarcharType, call: func(e *env, args []interface{}) (interface{}, error) {
+		return e.catalog.Database, nil
+	}},
+	"current_user": {maxArgs: 0, t: sql.VarcharType, call: func(e *env, args []interface{}) (interface{}, error) {
+		return e.catalog.User, nil
+	}},
+	"version": constantFn(0, 0, sql.VarcharType, pgmeta.PgsqlProtocolVersionMessage),
+	"lower": {minArgs: 1, maxArgs: 1, t: sql.VarcharType, call: func(e *env, args []interface{}) (interface{}, error) {
+		if args[0] == nil {
+			return nil, nil
+		}
+		return strings.ToLower(toString(args[0])), nil
+	}},
+	"upper": {minArgs: 1, maxArgs: 1, t: sql.VarcharType, call: func(e *env, args []interface{}) (interface{}, error) {
+		if args[0] == nil {
+			return nil, nil
+		}
+		return strings.ToUpper(toString(args[0])), nil
+	}},
+	"quote_ident": {minArgs: 1, maxArgs: 1, t: sql.VarcharType, call: func(e *env, args []interface{}) (interface{}, error) {
+		if args[0] == nil {
+			return nil, nil
+		}
+		return quoteIdent(toString(args[0])), nil
+	}},
+	"coalesce": {minArgs: 1, maxArgs: -1, call: func(e *env, args []interface{}) (interface{}, error) {
+		for _, arg := range args {
+			if arg != nil {
+				return arg, nil
+			}
+		}
+		return nil, nil
+	}},
+}
+
+func init() {
+	// functions named after keywords, they're called without parentheses too
+	functions["current_catalog"] = functions["current_database"]
+	functions["session_user"] = functions["current_user"]
+	functions["user"] = functions["current_user"]
+}
+
+// quoteIdent quotes the identifier unless it's made of lowercase letters, digits and underscores only
+func quoteIdent(s string) string {
+	plain := s != "" && !(s[0] >= '0' && s[0] <= '9')
+
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '_') {
+			plain = false
+			break
+		}
+	}
+
+	if plain {
+		return s
+	}
+
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
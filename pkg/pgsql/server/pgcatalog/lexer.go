@@ -0,0 +1,187 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgcatalog
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokQuotedIdent
+	tokString
+	tokNumber
+	tokParam
+	tokOp
+)
+
+type token struct {
+	kind tokenKind
+	// identifiers not enclosed in double quotes are lowercased, as done by PostgreSQL
+	val string
+}
+
+func (t token) String() string {
+	if t.kind == tokEOF {
+		return "end of input"
+	}
+	return t.val
+}
+
+// operators made of more than one char, longest first
+var multiCharOps = []string{"!~*", "::", "<>", "!=", "<=", ">=", "||", "!~", "~*"}
+
+// tokenize splits the query into tokens, comments are discarded
+func tokenize(q string) ([]token, error) {
+	var tokens []token
+
+	rs := []rune(q)
+
+	for i := 0; i < len(rs); {
+		r := rs[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '-' && i+1 < len(rs) && rs[i+1] == '-':
+			for i < len(rs) && rs[i] != '\n' {
+				i++
+			}
+		case r == '/' && i+1 < len(rs) && rs[i+1] == '*':
+			j := i + 2
+			for j+1 < len(rs) && !(rs[j] == '*' && rs[j+1] == '/') {
+				j++
+			}
+			if j+1 >= len(rs) {
+				return nil, fmt.Errorf("%w: unterminated comment", ErrSyntax)
+			}
+			i = j + 2
+		case r == '\'':
+			s, n, err := scanString(rs[i:], false)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokString, val: s})
+			i += n
+		case (r == 'e' || r == 'E') && i+1 < len(rs) && rs[i+1] == '\'':
+			s, n, err := scanString(rs[i+1:], true)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokString, val: s})
+			i += 1 + n
+		case r == '"':
+			var sb strings.Builder
+			j := i + 1
+			for ; j < len(rs); j++ {
+				if rs[j] == '"' {
+					if j+1 < len(rs) && rs[j+1] == '"' {
+						sb.WriteRune('"')
+						j++
+						continue
+					}
+					break
+				}
+				sb.WriteRune(rs[j])
+			}
+			if j == len(rs) {
+				return nil, fmt.Errorf("%w: unterminated quoted identifier", ErrSyntax)
+			}
+			tokens = append(tokens, token{kind: tokQuotedIdent, val: sb.String()})
+			i = j + 1
+		case r == '$' && i+1 < len(rs) && unicode.IsDigit(rs[i+1]):
+			j := i + 1
+			for j < len(rs) && unicode.IsDigit(rs[j]) {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokParam, val: string(rs[i+1 : j])})
+			i = j
+		case unicode.IsDigit(r):
+			j := i
+			for j < len(rs) && unicode.IsDigit(rs[j]) {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokNumber, val: string(rs[i:j])})
+			i = j
+		case unicode.IsLetter(r) || r == '_':
+			j := i
+			for j < len(rs) && (unicode.IsLetter(rs[j]) || unicode.IsDigit(rs[j]) || rs[j] == '_' || rs[j] == '$') {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokIdent, val: strings.ToLower(string(rs[i:j]))})
+			i = j
+		default:
+			op := string(r)
+			for _, mop := range multiCharOps {
+				if strings.HasPrefix(string(rs[i:]), mop) {
+					op = mop
+					break
+				}
+			}
+			if !strings.Contains("(),.;*=<>~+-[]", op) && len(op) == 1 {
+				return nil, fmt.Errorf("%w at or near \"%s\"", ErrSyntax, op)
+			}
+			tokens = append(tokens, token{kind: tokOp, val: op})
+			i += len([]rune(op))
+		}
+	}
+
+	return append(tokens, token{kind: tokEOF}), nil
+}
+
+// scanString reads a string constant starting at its opening quote. Quotes are escaped by doubling them
+// and, in escape strings (E'...'), backslash escapes are recognized too. It returns the number of runes read
+func scanString(rs []rune, escapes bool) (string, int, error) {
+	var sb strings.Builder
+
+	for j := 1; j < len(rs); j++ {
+		switch {
+		case rs[j] == '\'':
+			if j+1 < len(rs) && rs[j+1] == '\'' {
+				sb.WriteRune('\'')
+				j++
+				continue
+			}
+			return sb.String(), j + 1, nil
+		case escapes && rs[j] == '\\' && j+1 < len(rs):
+			j++
+			switch rs[j] {
+			case 'n':
+				sb.WriteRune('\n')
+			case 't':
+				sb.WriteRune('\t')
+			case 'r':
+				sb.WriteRune('\r')
+			case 'b':
+				sb.WriteRune('\b')
+			case 'f':
+				sb.WriteRune('\f')
+			default:
+				sb.WriteRune(rs[j])
+			}
+		default:
+			sb.WriteRune(rs[j])
+		}
+	}
+
+	return "", 0, fmt.Errorf("%w: unterminated string", ErrSyntax)
+}
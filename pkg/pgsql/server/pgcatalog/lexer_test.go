@@ -0,0 +1,63 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgcatalog
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenize(t *testing.T) {
+	toks, err := tokenize(`SELECT "Schema", c.RelName::text -- comment
+	/* block
+	comment */ FROM t WHERE x !~* E'it\'s' AND y <> 'a''b' AND z = $12;`)
+	require.NoError(t, err)
+
+	require.Equal(t, []token{
+		{kind: tokIdent, val: "select"},
+		{kind: tokQuotedIdent, val: "Schema"},
+		{kind: tokOp, val: ","},
+		{kind: tokIdent, val: "c"},
+		{kind: tokOp, val: "."},
+		{kind: tokIdent, val: "relname"},
+		{kind: tokOp, val: "::"},
+		{kind: tokIdent, val: "text"},
+		{kind: tokIdent, val: "from"},
+		{kind: tokIdent, val: "t"},
+		{kind: tokIdent, val: "where"},
+		{kind: tokIdent, val: "x"},
+		{kind: tokOp, val: "!~*"},
+		{kind: tokString, val: "it's"},
+		{kind: tokIdent, val: "and"},
+		{kind: tokIdent, val: "y"},
+		{kind: tokOp, val: "<>"},
+		{kind: tokString, val: "a'b"},
+		{kind: tokIdent, val: "and"},
+		{kind: tokIdent, val: "z"},
+		{kind: tokOp, val: "="},
+		{kind: tokParam, val: "12"},
+		{kind: tokOp, val: ";"},
+		{kind: tokEOF},
+	}, toks)
+
+	for _, q := range []string{"SELECT 'a", `SELECT "a`, "SELECT 1 /* a", "SELECT a ? b"} {
+		_, err := tokenize(q)
+		require.True(t, errors.Is(err, ErrSyntax), q)
+	}
+}
@@ -0,0 +1,924 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgcatalog
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/codenotary/immudb/embedded/sql"
+)
+
+// reserved are the keywords which can't be used as aliases without AS
+var reserved = map[string]bool{
+	"select": true, "from": true, "where": true, "group": true, "having": true, "order": true, "limit": true,
+	"offset": true, "join": true, "inner": true, "left": true, "right": true, "full": true, "cross": true,
+	"outer": true, "on": true, "as": true, "union": true, "intersect": true, "except": true, "and": true,
+	"or": true, "not": true, "is": true, "in": true, "like": true, "ilike": true, "case": true, "when": true,
+	"then": true, "else": true, "end": true, "null": true, "true": true, "false": true, "distinct": true,
+	"by": true, "asc": true, "desc": true, "nulls": true, "collate": true, "operator": true, "for": true,
+	"window": true, "between": true, "similar": true, "isnull": true, "notnull": true,
+}
+
+// keywordFunctions are the functions which are called without parentheses
+var keywordFunctions = map[string]bool{
+	"current_user": true, "session_user": true, "user": true, "current_catalog": true, "current_schema": true,
+}
+
+// aggregateFunctions are known to PostgreSQL, but catalog queries don't support aggregations
+var aggregateFunctions = map[string]bool{
+	"count": true, "sum": true, "min": true, "max": true, "avg": true, "array_agg": true, "string_agg": true,
+}
+
+type parser struct {
+	toks   []token
+	pos    int
+	params int
+}
+
+func (p *parser) peek() token {
+	return p.toks[p.pos]
+}
+
+func (p *parser) peekAt(n int) token {
+	if p.pos+n >= len(p.toks) {
+		return token{kind: tokEOF}
+	}
+	return p.toks[p.pos+n]
+}
+
+func (p *parser) next() token {
+	t := p.toks[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) isKeyword(kw string) bool {
+	t := p.peek()
+	return t.kind == tokIdent && t.val == kw
+}
+
+func (p *parser) acceptKeyword(kw string) bool {
+	if p.isKeyword(kw) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expectKeyword(kw string) error {
+	if !p.acceptKeyword(kw) {
+		return p.unexpected()
+	}
+	return nil
+}
+
+func (p *parser) isOp(op string) bool {
+	t := p.peek()
+	return t.kind == tokOp && t.val == op
+}
+
+func (p *parser) acceptOp(op string) bool {
+	if p.isOp(op) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expectOp(op string) error {
+	if !p.acceptOp(op) {
+		return p.unexpected()
+	}
+	return nil
+}
+
+func (p *parser) unexpected() error {
+	return fmt.Errorf("%w at or near \"%s\"", ErrSyntax, p.peek())
+}
+
+func (p *parser) unsupported(what string) error {
+	return fmt.Errorf("%w: %s", ErrUnsupportedQuery, what)
+}
+
+// identifier reads a name, keywords are accepted only if allowed
+func (p *parser) identifier(allowKeywords bool) (string, error) {
+	t := p.peek()
+
+	if t.kind == tokQuotedIdent || (t.kind == tokIdent && (allowKeywords || !reserved[t.val])) {
+		p.pos++
+		return t.val, nil
+	}
+
+	return "", p.unexpected()
+}
+
+func (p *parser) parseQuery() (*Query, error) {
+	q := &Query{}
+
+	if err := p.expectKeyword("select"); err != nil {
+		return nil, err
+	}
+
+	q.distinct = p.acceptKeyword("distinct")
+
+	for {
+		t, err := p.parseTarget()
+		if err != nil {
+			return nil, err
+		}
+		q.targets = append(q.targets, t)
+
+		if !p.acceptOp(",") {
+			break
+		}
+	}
+
+	if p.acceptKeyword("from") {
+		if err := p.parseFrom(q); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.acceptKeyword("where") {
+		where, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		q.where = where
+	}
+
+	for _, kw := range []string{"group", "having", "union", "intersect", "except", "window"} {
+		if p.isKeyword(kw) {
+			return nil, p.unsupported(kw)
+		}
+	}
+
+	if p.acceptKeyword("order") {
+		if err := p.expectKeyword("by"); err != nil {
+			return nil, err
+		}
+
+		for {
+			exp, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+
+			item := &orderItem{exp: exp}
+
+			if p.acceptKeyword("desc") {
+				item.desc = true
+			} else {
+				p.acceptKeyword("asc")
+			}
+
+			if p.acceptKeyword("nulls") {
+				return nil, p.unsupported("nulls ordering")
+			}
+
+			q.orderBy = append(q.orderBy, item)
+
+			if !p.acceptOp(",") {
+				break
+			}
+		}
+	}
+
+	for {
+		var dst *expr
+
+		switch {
+		case p.acceptKeyword("limit"):
+			dst = &q.limit
+		case p.acceptKeyword("offset"):
+			dst = &q.offset
+		}
+
+		if dst == nil {
+			break
+		}
+
+		if p.acceptKeyword("all") {
+			continue
+		}
+
+		exp, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		*dst = exp
+	}
+
+	if p.isKeyword("for") {
+		return nil, p.unsupported("for")
+	}
+
+	p.acceptOp(";")
+
+	if p.peek().kind != tokEOF {
+		return nil, p.unexpected()
+	}
+
+	q.paramsCount = p.params
+
+	return q, nil
+}
+
+func (p *parser) parseTarget() (*target, error) {
+	if p.acceptOp("*") {
+		return &target{star: true}, nil
+	}
+
+	if (p.peek().kind == tokIdent || p.peek().kind == tokQuotedIdent) && p.peekAt(1).val == "." && p.peekAt(2).val == "*" {
+		qualifier := p.next().val
+		p.pos += 2
+		return &target{star: true, qualifier: qualifier}, nil
+	}
+
+	exp, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+
+	t := &target{exp: exp, name: defaultName(exp)}
+
+	if p.acceptKeyword("as") {
+		t.name, err = p.identifier(true)
+		if err != nil {
+			return nil, err
+		}
+	} else if name, err := p.identifier(false); err == nil {
+		t.name = name
+	}
+
+	return t, nil
+}
+
+// defaultName is the name of the output column when it's not given, as chosen by PostgreSQL
+func defaultName(exp expr) string {
+	name, _ := figureName(exp)
+	return name
+}
+
+// figureName returns the name of the column and whether it was taken from a column or a function,
+// names given by casts and CASE expressions are weaker and are overridden by outer casts
+func figureName(exp expr) (string, bool) {
+	switch e := exp.(type) {
+	case *colRef:
+		return e.name, true
+	case *funcCall:
+		return e.name, true
+	case *castExp:
+		if name, strong := figureName(e.exp); strong {
+			return name, true
+		}
+		return e.typeName, false
+	case *caseExp:
+		return "case", false
+	}
+	return "?column?", false
+}
+
+func (p *parser) parseFrom(q *Query) error {
+	item, err := p.parseRelation()
+	if err != nil {
+		return err
+	}
+	q.from = append(q.from, item)
+
+	for {
+		cross, left := false, false
+
+		switch {
+		case p.acceptOp(","):
+			cross = true
+		case p.acceptKeyword("cross"):
+			cross = true
+			if err := p.expectKeyword("join"); err != nil {
+				return err
+			}
+		case p.acceptKeyword("join"):
+		case p.acceptKeyword("inner"):
+			if err := p.expectKeyword("join"); err != nil {
+				return err
+			}
+		case p.isKeyword("left"):
+			p.pos++
+			p.acceptKeyword("outer")
+			if err := p.expectKeyword("join"); err != nil {
+				return err
+			}
+			left = true
+		case p.isKeyword("right"), p.isKeyword("full"), p.isKeyword("natural"):
+			return p.unsupported(p.peek().val + " join")
+		default:
+			return nil
+		}
+
+		item, err := p.parseRelation()
+		if err != nil {
+			return err
+		}
+
+		item.left = left
+
+		if !cross {
+			if err := p.expectKeyword("on"); err != nil {
+				return err
+			}
+
+			item.on, err = p.parseExpr()
+			if err != nil {
+				return err
+			}
+		}
+
+		q.from = append(q.from, item)
+	}
+}
+
+func (p *parser) parseRelation() (*fromItem, error) {
+	if p.isOp("(") {
+		return nil, p.unsupported("subqueries")
+	}
+
+	name, err := p.identifier(false)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := ""
+
+	if p.acceptOp(".") {
+		schema = name
+
+		name, err = p.identifier(true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rel := lookupRelation(schema, name)
+	if rel == nil {
+		if schema != "" {
+			name = schema + "." + name
+		}
+		return nil, fmt.Errorf("%w: %s", ErrUndefinedRelation, name)
+	}
+
+	item := &fromItem{rel: rel, alias: rel.name}
+
+	if p.acceptKeyword("as") {
+		item.alias, err = p.identifier(true)
+		if err != nil {
+			return nil, err
+		}
+	} else if alias, err := p.identifier(false); err == nil {
+		item.alias = alias
+	}
+
+	return item, nil
+}
+
+func (p *parser) parseExpr() (expr, error) {
+	return p.parseOr()
+}
+
+func (p *parser) parseOr() (expr, error) {
+	l, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.acceptKeyword("or") {
+		r, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l = &logicExp{l: l, r: r}
+	}
+
+	return l, nil
+}
+
+func (p *parser) parseAnd() (expr, error) {
+	l, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.acceptKeyword("and") {
+		r, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		l = &logicExp{and: true, l: l, r: r}
+	}
+
+	return l, nil
+}
+
+func (p *parser) parseNot() (expr, error) {
+	if p.acceptKeyword("not") {
+		exp, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &notExp{exp: exp}, nil
+	}
+
+	return p.parseIs()
+}
+
+func (p *parser) parseIs() (expr, error) {
+	exp, err := p.parseComparison()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		switch {
+		case p.acceptKeyword("isnull"):
+			exp = &isExp{exp: exp}
+		case p.acceptKeyword("notnull"):
+			exp = &isExp{exp: exp, not: true}
+		case p.acceptKeyword("is"):
+			is := &isExp{exp: exp, not: p.acceptKeyword("not")}
+
+			switch {
+			case p.acceptKeyword("null"):
+			case p.acceptKeyword("true"):
+				is.val = true
+			case p.acceptKeyword("false"):
+				is.val = false
+			case p.isKeyword("distinct"):
+				return nil, p.unsupported("is distinct from")
+			default:
+				return nil, p.unexpected()
+			}
+
+			exp = is
+		default:
+			return exp, nil
+		}
+	}
+}
+
+var comparisonOps = map[string]bool{"=": true, "<>": true, "!=": true, "<": true, "<=": true, ">": true, ">=": true}
+
+func (p *parser) parseComparison() (expr, error) {
+	l, err := p.parsePattern()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.peek(); t.kind == tokOp && comparisonOps[t.val] {
+		p.pos++
+
+		r, err := p.parsePattern()
+		if err != nil {
+			return nil, err
+		}
+
+		return &binaryExp{op: t.val, l: l, r: r}, nil
+	}
+
+	return l, nil
+}
+
+// parsePattern parses LIKE, ILIKE and IN, which bind tighter than comparisons
+func (p *parser) parsePattern() (expr, error) {
+	l, err := p.parseOther()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		not := false
+
+		if p.isKeyword("not") {
+			if n := p.peekAt(1); n.kind == tokIdent && (n.val == "like" || n.val == "ilike" || n.val == "in") {
+				p.pos++
+				not = true
+			}
+		}
+
+		switch {
+		case p.isKeyword("like"), p.isKeyword("ilike"):
+			op := p.next().val
+			if not {
+				op = "not " + op
+			}
+
+			r, err := p.parseOther()
+			if err != nil {
+				return nil, err
+			}
+
+			l = &binaryExp{op: op, l: l, r: r}
+		case p.acceptKeyword("in"):
+			if err := p.expectOp("("); err != nil {
+				return nil, err
+			}
+
+			if p.isKeyword("select") {
+				return nil, p.unsupported("subqueries")
+			}
+
+			in := &inExp{exp: l, not: not}
+
+			for {
+				exp, err := p.parseExpr()
+				if err != nil {
+					return nil, err
+				}
+				in.list = append(in.list, exp)
+
+				if !p.acceptOp(",") {
+					break
+				}
+			}
+
+			if err := p.expectOp(")"); err != nil {
+				return nil, err
+			}
+
+			l = in
+		case p.isKeyword("between"), p.isKeyword("similar"):
+			return nil, p.unsupported(p.peek().val)
+		default:
+			return l, nil
+		}
+	}
+}
+
+var otherOps = map[string]bool{"~": true, "!~": true, "~*": true, "!~*": true, "||": true}
+
+// parseOther parses the operators which are not comparisons, as regular expression matches and
+// concatenation. Operators can also be given as OPERATOR(pg_catalog.~)
+func (p *parser) parseOther() (expr, error) {
+	l, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		var op string
+
+		switch t := p.peek(); {
+		case t.kind == tokOp && otherOps[t.val]:
+			p.pos++
+			op = t.val
+		case p.acceptKeyword("operator"):
+			if err := p.expectOp("("); err != nil {
+				return nil, err
+			}
+
+			if p.peek().kind == tokIdent && p.peekAt(1).val == "." {
+				if schema := p.next().val; schema != "pg_catalog" {
+					return nil, p.unsupported("operators of schema " + schema)
+				}
+				p.pos++
+			}
+
+			t := p.next()
+			if t.kind != tokOp || !(otherOps[t.val] || comparisonOps[t.val]) {
+				return nil, fmt.Errorf("%w at or near \"%s\"", ErrSyntax, t)
+			}
+			op = t.val
+
+			if err := p.expectOp(")"); err != nil {
+				return nil, err
+			}
+		default:
+			return l, nil
+		}
+
+		r, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+
+		l = &binaryExp{op: op, l: l, r: r}
+	}
+}
+
+func (p *parser) parseAdditive() (expr, error) {
+	l, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isOp("+") || p.isOp("-") {
+		op := p.next().val
+
+		r, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		l = &binaryExp{op: op, l: l, r: r}
+	}
+
+	return l, nil
+}
+
+func (p *parser) parseUnary() (expr, error) {
+	if p.acceptOp("-") {
+		exp, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &negExp{exp: exp}, nil
+	}
+
+	return p.parsePostfix()
+}
+
+func (p *parser) parsePostfix() (expr, error) {
+	exp, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		switch {
+		case p.acceptOp("::"):
+			exp, err = p.parseCast(exp)
+			if err != nil {
+				return nil, err
+			}
+		case p.acceptKeyword("collate"):
+			// collations are ignored, strings are compared by their bytes
+			if _, err := p.identifier(true); err != nil {
+				return nil, err
+			}
+			if p.acceptOp(".") {
+				if _, err := p.identifier(true); err != nil {
+					return nil, err
+				}
+			}
+		case p.isOp("["):
+			return nil, p.unsupported("arrays")
+		default:
+			return exp, nil
+		}
+	}
+}
+
+// parseCast parses the type the expression is cast to, which may be qualified by pg_catalog
+func (p *parser) parseCast(exp expr) (expr, error) {
+	name, err := p.identifier(true)
+	if err != nil {
+		return nil, err
+	}
+
+	if name == "pg_catalog" && p.acceptOp(".") {
+		name, err = p.identifier(true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if name == "character" && p.acceptKeyword("varying") {
+		name = "character varying"
+	}
+
+	// the length of the type is ignored
+	if p.acceptOp("(") {
+		if p.next().kind != tokNumber {
+			return nil, p.unexpected()
+		}
+		if err := p.expectOp(")"); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.isOp("[") {
+		return nil, p.unsupported("arrays")
+	}
+
+	t, ok := castType(name)
+	if !ok {
+		return nil, p.unsupported("type " + name)
+	}
+
+	return &castExp{exp: exp, typeName: name, t: t}, nil
+}
+
+func (p *parser) parsePrimary() (expr, error) {
+	t := p.peek()
+
+	switch t.kind {
+	case tokNumber:
+		p.pos++
+		n, err := strconv.ParseInt(t.val, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w at or near \"%s\"", ErrSyntax, t)
+		}
+		return &constant{val: n, t: sql.IntegerType}, nil
+	case tokString:
+		p.pos++
+		return &constant{val: t.val, t: sql.VarcharType}, nil
+	case tokParam:
+		p.pos++
+		n, err := strconv.Atoi(t.val)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("%w at or near \"$%s\"", ErrSyntax, t)
+		}
+		if n > p.params {
+			p.params = n
+		}
+		return &param{pos: n}, nil
+	case tokOp:
+		if !p.acceptOp("(") {
+			return nil, p.unexpected()
+		}
+
+		if p.isKeyword("select") {
+			return nil, p.unsupported("subqueries")
+		}
+
+		exp, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.expectOp(")"); err != nil {
+			return nil, err
+		}
+
+		return exp, nil
+	case tokQuotedIdent:
+		return p.parseNameExp()
+	case tokIdent:
+		switch t.val {
+		case "null":
+			p.pos++
+			return &constant{t: sql.VarcharType}, nil
+		case "true", "false":
+			p.pos++
+			return &constant{val: t.val == "true", t: sql.BooleanType}, nil
+		case "case":
+			p.pos++
+			return p.parseCase()
+		case "cast":
+			if p.peekAt(1).val == "(" {
+				p.pos += 2
+
+				exp, err := p.parseExpr()
+				if err != nil {
+					return nil, err
+				}
+
+				if err := p.expectKeyword("as"); err != nil {
+					return nil, err
+				}
+
+				exp, err = p.parseCast(exp)
+				if err != nil {
+					return nil, err
+				}
+
+				return exp, p.expectOp(")")
+			}
+		case "exists", "array", "any", "all", "some":
+			return nil, p.unsupported(t.val)
+		}
+
+		if keywordFunctions[t.val] && p.peekAt(1).val != "(" {
+			p.pos++
+			return &funcCall{name: t.val, fn: functions[t.val]}, nil
+		}
+
+		if reserved[t.val] {
+			return nil, p.unexpected()
+		}
+
+		return p.parseNameExp()
+	}
+
+	return nil, p.unexpected()
+}
+
+// parseNameExp parses a column reference or a function call, both of them may be qualified
+func (p *parser) parseNameExp() (expr, error) {
+	name := p.next().val
+
+	qualifier := ""
+
+	if p.acceptOp(".") {
+		qualifier = name
+
+		var err error
+
+		name, err = p.identifier(true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !p.acceptOp("(") {
+		return &colRef{qualifier: qualifier, name: name}, nil
+	}
+
+	if qualifier != "" && qualifier != "pg_catalog" {
+		return nil, fmt.Errorf("%w: %s.%s", ErrUndefinedFunction, qualifier, name)
+	}
+
+	if aggregateFunctions[name] {
+		return nil, p.unsupported("aggregate functions")
+	}
+
+	fn, ok := functions[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUndefinedFunction, name)
+	}
+
+	call := &funcCall{name: name, fn: fn}
+
+	if !p.acceptOp(")") {
+		for {
+			arg, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			call.args = append(call.args, arg)
+
+			if !p.acceptOp(",") {
+				break
+			}
+		}
+
+		if err := p.expectOp(")"); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(call.args) < fn.minArgs || (fn.maxArgs >= 0 && len(call.args) > fn.maxArgs) {
+		return nil, fmt.Errorf("%w: %s with %d arguments", ErrUndefinedFunction, name, len(call.args))
+	}
+
+	return call, nil
+}
+
+func (p *parser) parseCase() (expr, error) {
+	c := &caseExp{}
+
+	if !p.isKeyword("when") {
+		operand, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		c.operand = operand
+	}
+
+	for p.acceptKeyword("when") {
+		cond, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.expectKeyword("then"); err != nil {
+			return nil, err
+		}
+
+		result, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+
+		c.whens = append(c.whens, &whenClause{cond: cond, result: result})
+	}
+
+	if len(c.whens) == 0 {
+		return nil, p.unexpected()
+	}
+
+	if p.acceptKeyword("else") {
+		els, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		c.els = els
+	}
+
+	return c, p.expectKeyword("end")
+}
@@ -0,0 +1,524 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package pgcatalog answers the introspection queries clients issue against pg_catalog and
+// information_schema, from virtual relations built out of the SQL catalog of the database.
+// Queries are single SELECT statements, joining relations without subqueries nor aggregations
+package pgcatalog
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/codenotary/immudb/embedded/sql"
+	"github.com/codenotary/immudb/pkg/api/schema"
+)
+
+var selectRegexp = regexp.MustCompile(`(?i)^\s*select\b`)
+var catalogRegexp = regexp.MustCompile(`(?i)\b(pg_catalog|information_schema)\s*\.|\b(from|join)\s+pg_[a-z_]+\b|\bcurrent_(schema|database|catalog)\b`)
+
+// IsCatalogQuery tells whether the query reads pg_catalog, information_schema or the names of the
+// current database and schema, which are not known to the SQL engine
+func IsCatalogQuery(q string) bool {
+	return selectRegexp.MatchString(q) && catalogRegexp.MatchString(q)
+}
+
+// Query is a parsed catalog query, it can be executed more than once
+type Query struct {
+	distinct    bool
+	targets     []*target
+	from        []*fromItem
+	where       expr
+	orderBy     []*orderItem
+	limit       expr
+	offset      expr
+	paramsCount int
+
+	// targets with the stars expanded, and their columns
+	exps []expr
+	cols []*schema.Column
+	// number of values of the joined rows of the relations
+	width int
+}
+
+type target struct {
+	exp       expr
+	name      string
+	star      bool
+	qualifier string
+}
+
+type fromItem struct {
+	rel   *relation
+	alias string
+	left  bool
+	on    expr
+	// position of the first column of the relation in the joined rows
+	offset int
+}
+
+type orderItem struct {
+	exp  expr
+	desc bool
+	// index of the output column rows are sorted by, -1 if they're sorted by an expression over the relations
+	col int
+}
+
+type scope struct {
+	items []*fromItem
+}
+
+func (s *scope) resolve(qualifier, name string) (int, sql.SQLValueType, error) {
+	idx := -1
+	var t sql.SQLValueType
+
+	for _, item := range s.items {
+		if qualifier != "" && qualifier != item.alias {
+			continue
+		}
+
+		for i, col := range item.rel.cols {
+			if col.name != name {
+				continue
+			}
+
+			if idx >= 0 {
+				return 0, "", fmt.Errorf("%w: %s", ErrAmbiguousColumn, name)
+			}
+
+			idx, t = item.offset+i, col.typ
+		}
+	}
+
+	if idx < 0 {
+		if qualifier != "" {
+			name = qualifier + "." + name
+		}
+		return 0, "", fmt.Errorf("%w: %s", ErrUndefinedColumn, name)
+	}
+
+	return idx, t, nil
+}
+
+// Parse parses the query and resolves the columns it refers to
+func Parse(q string) (*Query, error) {
+	toks, err := tokenize(q)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{toks: toks}
+
+	query, err := p.parseQuery()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := query.bind(); err != nil {
+		return nil, err
+	}
+
+	return query, nil
+}
+
+func (q *Query) bind() error {
+	s := &scope{items: q.from}
+
+	for _, item := range q.from {
+		item.offset = q.width
+		q.width += len(item.rel.cols)
+	}
+
+	for _, item := range q.from {
+		if item.on != nil {
+			if err := item.on.bind(s); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, t := range q.targets {
+		if !t.star {
+			if err := t.exp.bind(s); err != nil {
+				return err
+			}
+
+			q.exps = append(q.exps, t.exp)
+			q.cols = append(q.cols, &schema.Column{Name: t.name, Type: t.exp.typ()})
+
+			continue
+		}
+
+		expanded := false
+
+		for _, item := range q.from {
+			if t.qualifier != "" && t.qualifier != item.alias {
+				continue
+			}
+
+			for i, col := range item.rel.cols {
+				q.exps = append(q.exps, &colRef{qualifier: item.alias, name: col.name, idx: item.offset + i, t: col.typ})
+				q.cols = append(q.cols, &schema.Column{Name: col.name, Type: col.typ})
+			}
+
+			expanded = true
+		}
+
+		if !expanded {
+			return fmt.Errorf("%w: %s", ErrUndefinedRelation, t.qualifier)
+		}
+	}
+
+	if q.where != nil {
+		if err := q.where.bind(s); err != nil {
+			return err
+		}
+	}
+
+	for _, item := range q.orderBy {
+		item.col = q.outputColumn(item.exp)
+
+		if item.col == -2 {
+			return fmt.Errorf("%w: ORDER BY position is not in select list", ErrInvalidValue)
+		}
+
+		if item.col < 0 {
+			if err := item.exp.bind(s); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// outputColumn returns the output column the rows are sorted by when it's given by its position or
+// by its name. It's -1 otherwise, and -2 if the position is out of range
+func (q *Query) outputColumn(exp expr) int {
+	switch e := exp.(type) {
+	case *constant:
+		n, isInt := e.val.(int64)
+		if !isInt {
+			return -1
+		}
+		if n < 1 || int(n) > len(q.cols) {
+			return -2
+		}
+		return int(n) - 1
+	case *colRef:
+		if e.qualifier != "" {
+			return -1
+		}
+		for i, t := range q.targets {
+			if !t.star && t.name == e.name {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// Columns returns the columns of the rows returned by the query
+func (q *Query) Columns() []*schema.Column {
+	return q.cols
+}
+
+// ParamsCount returns the number of positional parameters of the query
+func (q *Query) ParamsCount() int {
+	return q.paramsCount
+}
+
+type resultRow struct {
+	vals []interface{}
+	keys []interface{}
+}
+
+// Execute returns the rows of the query over the relations built from the catalog.
+// Parameters are named as positional ones, see sql.PositionalParam
+func (q *Query) Execute(c *Catalog, params []*schema.NamedParam) ([]*schema.Row, error) {
+	e := &env{catalog: c, params: make([]interface{}, q.paramsCount)}
+
+	for _, p := range params {
+		for i := range e.params {
+			if p.Name == sql.PositionalParam(i+1) {
+				e.params[i] = fromSQLValue(p.Value)
+			}
+		}
+	}
+
+	rows, err := q.join(e)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []*resultRow
+	seen := make(map[string]bool)
+
+	for _, row := range rows {
+		e.row = row
+
+		if q.where != nil {
+			ok, err := isTrue(q.where, e)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+		}
+
+		r := &resultRow{}
+
+		for _, exp := range q.exps {
+			v, err := exp.eval(e)
+			if err != nil {
+				return nil, err
+			}
+			r.vals = append(r.vals, v)
+		}
+
+		if q.distinct {
+			key := fmt.Sprintf("%#v", r.vals)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+		}
+
+		for _, item := range q.orderBy {
+			if item.col >= 0 {
+				r.keys = append(r.keys, r.vals[item.col])
+				continue
+			}
+
+			v, err := item.exp.eval(e)
+			if err != nil {
+				return nil, err
+			}
+			r.keys = append(r.keys, v)
+		}
+
+		res = append(res, r)
+	}
+
+	if err := q.sort(res); err != nil {
+		return nil, err
+	}
+
+	res, err = q.page(e, res)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*schema.Row, len(res))
+
+	for i, r := range res {
+		row := &schema.Row{Columns: make([]string, len(q.cols)), Values: make([]*schema.SQLValue, len(q.cols))}
+
+		for j, col := range q.cols {
+			row.Columns[j] = col.Name
+
+			row.Values[j], err = toSQLValue(r.vals[j], col.Type)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		out[i] = row
+	}
+
+	return out, nil
+}
+
+// join returns the rows of the relations joined together, each of them holding the values of all the relations
+func (q *Query) join(e *env) ([][]interface{}, error) {
+	rows := [][]interface{}{make([]interface{}, q.width)}
+
+	for _, item := range q.from {
+		relRows := item.rel.rows(e.catalog)
+
+		var joined [][]interface{}
+
+		for _, l := range rows {
+			matched := false
+
+			for _, r := range relRows {
+				row := make([]interface{}, q.width)
+				copy(row, l)
+				copy(row[item.offset:], r)
+
+				if item.on != nil {
+					e.row = row
+
+					ok, err := isTrue(item.on, e)
+					if err != nil {
+						return nil, err
+					}
+					if !ok {
+						continue
+					}
+				}
+
+				joined = append(joined, row)
+				matched = true
+			}
+
+			// the values of the relation are left NULL
+			if item.left && !matched {
+				joined = append(joined, l)
+			}
+		}
+
+		rows = joined
+	}
+
+	return rows, nil
+}
+
+func (q *Query) sort(rows []*resultRow) (err error) {
+	if len(q.orderBy) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		for k, item := range q.orderBy {
+			a, b := rows[i].keys[k], rows[j].keys[k]
+
+			var cmp int
+
+			switch {
+			case a == nil && b == nil:
+				continue
+			// NULLs come last in ascending order, as in PostgreSQL
+			case a == nil:
+				cmp = 1
+			case b == nil:
+				cmp = -1
+			default:
+				var cerr error
+				cmp, cerr = compareValues(a, b)
+				if cerr != nil && err == nil {
+					err = cerr
+				}
+			}
+
+			if cmp == 0 {
+				continue
+			}
+
+			if item.desc {
+				return cmp > 0
+			}
+			return cmp < 0
+		}
+
+		return false
+	})
+
+	return err
+}
+
+// page applies OFFSET and LIMIT
+func (q *Query) page(e *env, rows []*resultRow) ([]*resultRow, error) {
+	if q.offset != nil {
+		n, err := evalCount(q.offset, e)
+		if err != nil {
+			return nil, err
+		}
+		if n >= 0 && n < len(rows) {
+			rows = rows[n:]
+		} else if n >= len(rows) {
+			rows = nil
+		}
+	}
+
+	if q.limit != nil {
+		n, err := evalCount(q.limit, e)
+		if err != nil {
+			return nil, err
+		}
+		if n >= 0 && n < len(rows) {
+			rows = rows[:n]
+		}
+	}
+
+	return rows, nil
+}
+
+// evalCount evaluates the value of LIMIT or OFFSET, NULL stands for no limit
+func evalCount(exp expr, e *env) (int, error) {
+	v, err := exp.eval(e)
+	if err != nil || v == nil {
+		return -1, err
+	}
+
+	n, err := toInt(v)
+	if err != nil {
+		return 0, err
+	}
+
+	if n < 0 {
+		return 0, fmt.Errorf("%w: LIMIT and OFFSET must not be negative", ErrInvalidValue)
+	}
+
+	return int(n), nil
+}
+
+func isTrue(exp expr, e *env) (bool, error) {
+	v, err := exp.eval(e)
+	if err != nil || v == nil {
+		return false, err
+	}
+	return toBool(v)
+}
+
+func fromSQLValue(v *schema.SQLValue) interface{} {
+	switch tv := v.GetValue().(type) {
+	case *schema.SQLValue_Null, nil:
+		return nil
+	case *schema.SQLValue_N:
+		return int64(tv.N)
+	case *schema.SQLValue_B:
+		return tv.B
+	case *schema.SQLValue_S:
+		return tv.S
+	}
+	return string(schema.RenderValueAsByte(v.GetValue()))
+}
+
+func toSQLValue(v interface{}, t sql.SQLValueType) (*schema.SQLValue, error) {
+	if v == nil {
+		return &schema.SQLValue{Value: &schema.SQLValue_Null{}}, nil
+	}
+
+	switch t {
+	case sql.IntegerType:
+		n, err := toInt(v)
+		if err != nil {
+			return nil, err
+		}
+		return &schema.SQLValue{Value: &schema.SQLValue_N{N: uint64(n)}}, nil
+	case sql.BooleanType:
+		b, err := toBool(v)
+		if err != nil {
+			return nil, err
+		}
+		return &schema.SQLValue{Value: &schema.SQLValue_B{B: b}}, nil
+	}
+
+	return &schema.SQLValue{Value: &schema.SQLValue_S{S: toString(v)}}, nil
+}
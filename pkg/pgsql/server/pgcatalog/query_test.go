@@ -0,0 +1,234 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pgcatalog
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/codenotary/immudb/embedded/sql"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/database"
+	"github.com/stretchr/testify/require"
+)
+
+func testCatalog() *Catalog {
+	return &Catalog{
+		Database: "db1",
+		User:     "user1",
+		Databases: []*DatabaseInfo{
+			{ID: 0, Name: "defaultdb"},
+			{ID: 2, Name: "db1"},
+		},
+		Tables: []*database.SQLTableInfo{
+			{
+				ID:         1,
+				Name:       "customers",
+				PrimaryKey: []string{"id"},
+				Columns: []*database.SQLColumnInfo{
+					{Name: "id", Type: sql.IntegerType, AutoIncrement: true},
+					{Name: "name", Type: sql.VarcharType, Nullable: true},
+					{Name: "active", Type: sql.BooleanType, Nullable: true},
+				},
+			},
+			{
+				ID:         2,
+				Name:       "orders",
+				PrimaryKey: []string{"customer", "id"},
+				Columns: []*database.SQLColumnInfo{
+					{Name: "customer", Type: sql.IntegerType},
+					{Name: "id", Type: sql.IntegerType},
+					{Name: "data", Type: sql.BLOBType, Nullable: true},
+				},
+			},
+		},
+	}
+}
+
+func runQuery(t *testing.T, q string, params ...*schema.NamedParam) ([]string, [][]string) {
+	query, err := Parse(q)
+	require.NoError(t, err, q)
+
+	rows, err := query.Execute(testCatalog(), params)
+	require.NoError(t, err, q)
+
+	var cols []string
+	for _, col := range query.Columns() {
+		cols = append(cols, col.Name)
+	}
+
+	var vals [][]string
+	for _, row := range rows {
+		var rowVals []string
+		for _, v := range row.Values {
+			rowVals = append(rowVals, string(schema.RenderValueAsByte(v.Value)))
+		}
+		vals = append(vals, rowVals)
+	}
+
+	return cols, vals
+}
+
+func TestIsCatalogQuery(t *testing.T) {
+	for _, q := range []string{
+		"SELECT * FROM pg_catalog.pg_class",
+		"select table_name from INFORMATION_SCHEMA.tables",
+		"SELECT oid, typname FROM pg_type",
+		"select current_schema()",
+	} {
+		require.True(t, IsCatalogQuery(q), q)
+	}
+
+	for _, q := range []string{
+		"SELECT * FROM customers",
+		"INSERT INTO pg_class(id) VALUES (1)",
+		"SELECT pg_stuff FROM customers",
+	} {
+		require.False(t, IsCatalogQuery(q), q)
+	}
+}
+
+func TestListTables(t *testing.T) {
+	// as issued by psql for \dt
+	cols, rows := runQuery(t, `SELECT n.nspname as "Schema",
+  c.relname as "Name",
+  CASE c.relkind WHEN 'r' THEN 'table' WHEN 'v' THEN 'view' WHEN 'm' THEN 'materialized view' WHEN 'i' THEN 'index' WHEN 'S' THEN 'sequence' WHEN 's' THEN 'special' WHEN 'f' THEN 'foreign table' WHEN 'p' THEN 'partitioned table' WHEN 'I' THEN 'partitioned index' END as "Type",
+  pg_catalog.pg_get_userbyid(c.relowner) as "Owner"
+FROM pg_catalog.pg_class c
+     LEFT JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+WHERE c.relkind IN ('r','p','')
+      AND n.nspname <> 'pg_catalog'
+      AND n.nspname <> 'information_schema'
+      AND n.nspname !~ '^pg_toast'
+  AND pg_catalog.pg_table_is_visible(c.oid)
+ORDER BY 1,2;`)
+
+	require.Equal(t, []string{"Schema", "Name", "Type", "Owner"}, cols)
+	require.Equal(t, [][]string{
+		{"public", "customers", "table", "immudb"},
+		{"public", "orders", "table", "immudb"},
+	}, rows)
+}
+
+func TestDescribeTable(t *testing.T) {
+	// as issued by psql for \d customers
+	_, rows := runQuery(t, `SELECT c.oid,
+  n.nspname,
+  c.relname
+FROM pg_catalog.pg_class c
+     LEFT JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+WHERE c.relname OPERATOR(pg_catalog.~) '^(customers)$' COLLATE pg_catalog.default
+  AND pg_catalog.pg_table_is_visible(c.oid)
+ORDER BY 2, 3;`)
+	require.Equal(t, [][]string{{"16385", "public", "customers"}}, rows)
+
+	cols, rows := runQuery(t, `SELECT a.attname,
+  pg_catalog.format_type(a.atttypid, a.atttypmod),
+  a.attnotnull,
+  a.attnum
+FROM pg_catalog.pg_attribute a
+WHERE a.attrelid = '16385' AND a.attnum > 0 AND NOT a.attisdropped
+ORDER BY a.attnum;`)
+	require.Equal(t, []string{"attname", "format_type", "attnotnull", "attnum"}, cols)
+	require.Equal(t, [][]string{
+		{"id", "bigint", "true", "1"},
+		{"name", "text", "false", "2"},
+		{"active", "boolean", "false", "3"},
+	}, rows)
+}
+
+func TestListDatabases(t *testing.T) {
+	_, rows := runQuery(t, `SELECT d.datname as "Name",
+       pg_catalog.pg_get_userbyid(d.datdba) as "Owner",
+       pg_catalog.pg_encoding_to_char(d.encoding) as "Encoding"
+FROM pg_catalog.pg_database d
+ORDER BY 1 DESC`)
+	require.Equal(t, [][]string{
+		{"defaultdb", "immudb", "UTF8"},
+		{"db1", "immudb", "UTF8"},
+	}, rows)
+}
+
+func TestInformationSchema(t *testing.T) {
+	cols, rows := runQuery(t, `select table_name, column_name, data_type, is_nullable
+from information_schema.columns
+where table_schema = $1 and table_name = $2
+order by table_name, ordinal_position`,
+		&schema.NamedParam{Name: sql.PositionalParam(1), Value: &schema.SQLValue{Value: &schema.SQLValue_S{S: "public"}}},
+		&schema.NamedParam{Name: sql.PositionalParam(2), Value: &schema.SQLValue{Value: &schema.SQLValue_S{S: "orders"}}},
+	)
+	require.Equal(t, []string{"table_name", "column_name", "data_type", "is_nullable"}, cols)
+	require.Equal(t, [][]string{
+		{"orders", "customer", "bigint", "NO"},
+		{"orders", "id", "bigint", "NO"},
+		{"orders", "data", "bytea", "YES"},
+	}, rows)
+
+	_, rows = runQuery(t, `SELECT kcu.table_name, kcu.column_name
+FROM information_schema.table_constraints tc
+JOIN information_schema.key_column_usage kcu ON tc.constraint_name = kcu.constraint_name
+WHERE tc.constraint_type = 'PRIMARY KEY'
+ORDER BY kcu.table_name, kcu.ordinal_position`)
+	require.Equal(t, [][]string{
+		{"customers", "id"},
+		{"orders", "customer"},
+		{"orders", "id"},
+	}, rows)
+
+	_, rows = runQuery(t, "SELECT DISTINCT table_schema FROM information_schema.tables ORDER BY table_schema LIMIT 2 OFFSET 1")
+	require.Equal(t, [][]string{{"pg_catalog"}, {"public"}}, rows)
+}
+
+func TestSessionFunctions(t *testing.T) {
+	cols, rows := runQuery(t, "select current_database(), current_schema, current_user, 'customers'::regclass::oid, upper(quote_ident('My Table'))")
+	require.Equal(t, []string{"current_database", "current_schema", "current_user", "oid", "upper"}, cols)
+	require.Equal(t, [][]string{{"db1", "public", "user1", "16385", `"MY TABLE"`}}, rows)
+}
+
+func TestLeftJoin(t *testing.T) {
+	_, rows := runQuery(t, `SELECT n.nspname, c.relname
+FROM pg_catalog.pg_namespace n
+LEFT OUTER JOIN pg_catalog.pg_class c ON c.relnamespace = n.oid AND c.relname LIKE 'cust%'
+WHERE n.nspname IN ('public', 'information_schema')
+ORDER BY c.relname, n.nspname`)
+	require.Equal(t, [][]string{
+		{"public", "customers"},
+		{"information_schema", ""},
+	}, rows)
+}
+
+func TestParseErrors(t *testing.T) {
+	testCases := []struct {
+		q   string
+		err error
+	}{
+		{"SELECT * FROM pg_catalog.pg_nothing", ErrUndefinedRelation},
+		{"SELECT nothing FROM pg_catalog.pg_class", ErrUndefinedColumn},
+		{"SELECT oid FROM pg_class, pg_namespace", ErrAmbiguousColumn},
+		{"SELECT no_function()", ErrUndefinedFunction},
+		{"SELECT count(*) FROM pg_class GROUP BY relkind", ErrUnsupportedQuery},
+		{"SELECT relname FROM pg_class WHERE oid IN (SELECT oid FROM pg_class)", ErrUnsupportedQuery},
+		{"SELECT relname FROM pg_class ORDER BY 2", ErrInvalidValue},
+		{"SELECT relname FROM pg_class WHERE", ErrSyntax},
+		{"SELECT relname FROM pg_class c d", ErrSyntax},
+	}
+
+	for _, tc := range testCases {
+		_, err := Parse(tc.q)
+		require.True(t, errors.Is(err, tc.err), "%s: %v", tc.q, err)
+	}
+}
@@ -16,7 +16,10 @@ limitations under the License.
 
 package pgmeta
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 const PgTypeMapOid = 0
 const PgTypeMapLength = 1
@@ -29,11 +32,33 @@ var PgsqlProtocolVersionMessage = fmt.Sprintf("pgsql wire protocol %s or greater
 // First int is the oid value (retrieved with select * from pg_type;)
 // Second int is the length of the value. -1 for dynamic.
 var PgTypeMap = map[string][]int{
-	"BOOLEAN":   {16, 1},  //bool
-	"BLOB":      {17, -1}, //bytea
-	"TIMESTAMP": {20, 8},  //int8
-	"INTEGER":   {20, 8},  //int8
-	"VARCHAR":   {25, -1}, //text
+	"BOOLEAN":   {16, 1},   //bool
+	"BLOB":      {17, -1},  //bytea
+	"TIMESTAMP": {1184, 8}, //timestamptz
+	"INTEGER":   {20, 8},   //int8
+	"VARCHAR":   {25, -1},  //text
+	"FLOAT":     {701, 8},  //float8
+	"JSON":      {114, -1}, //json
+}
+
+// PgEpoch is the origin of timestamps in binary format
+var PgEpoch = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// PgTypeOids maps the oids of the pgsql types clients may give to parameters to the immudb type they're handled as
+var PgTypeOids = map[int32]string{
+	16:   "BOOLEAN",   //bool
+	17:   "BLOB",      //bytea
+	20:   "INTEGER",   //int8
+	21:   "INTEGER",   //int2
+	23:   "INTEGER",   //int4
+	25:   "VARCHAR",   //text
+	114:  "JSON",      //json
+	700:  "FLOAT",     //float4
+	701:  "FLOAT",     //float8
+	1042: "VARCHAR",   //bpchar
+	1043: "VARCHAR",   //varchar
+	1114: "TIMESTAMP", //timestamp
+	1184: "TIMESTAMP", //timestamptz
 }
 
 const PgSeverityError = "ERROR"
@@ -49,17 +74,46 @@ const PgServerErrRejectedEstablishmentOfSqlconnection = "08004"
 const PgServerErrSyntaxError = "42601"
 const PgServerErrProtocolViolation = "08P01"
 const PgServerErrConnectionFailure = "08006"
+const PgServerErrUniqueViolation = "23505"
+const PgServerErrInvalidTextRepresentation = "22P02"
+const PgServerErrInvalidAuthorizationSpecification = "28000"
+const PgServerErrInvalidPassword = "28P01"
+const PgServerErrInvalidSqlStatementName = "26000"
+const PgServerErrInvalidCursorName = "34000"
+const PgServerErrDuplicatePreparedStatement = "42P05"
+const PgServerErrDuplicateCursor = "42P03"
+const PgServerErrInvalidParameterValue = "22023"
+const PgServerErrCantChangeRuntimeParam = "55P02"
+const PgServerErrUndefinedObject = "42704"
+const PgServerErrInsufficientPrivilege = "42501"
+const PgServerErrActiveSqlTransaction = "25001"
+const PgServerErrFeatureNotSupported = "0A000"
+const PgServerErrUndefinedTable = "42P01"
+const PgServerErrUndefinedColumn = "42703"
+const PgServerErrAmbiguousColumn = "42702"
+const PgServerErrUndefinedFunction = "42883"
 
+// MTypes names the message types, some of them identify both a frontend and a backend message
 var MTypes = map[byte]string{
 	'Q': "query",
 	'T': "rowDescription",
-	'D': "dataRow",
-	'C': "commandComplete",
+	'D': "dataRow/describe",
+	'C': "commandComplete/close",
 	'Z': "readyForQuery",
 	'R': "authentication",
-	'p': "passwordMessage",
+	'p': "passwordMessage/saslResponse",
 	'U': "unknown",
 	'X': "terminate",
-	'S': "parameterStatus",
-	'E': "error",
+	'S': "parameterStatus/sync",
+	'E': "error/execute",
+	'P': "parse",
+	'B': "bind",
+	'H': "flush",
+	'1': "parseComplete",
+	'2': "bindComplete",
+	'3': "closeComplete",
+	'n': "noData",
+	't': "parameterDescription",
+	's': "portalSuspended",
+	'I': "emptyQueryResponse",
 }
@@ -21,7 +21,7 @@ import (
 )
 
 func (s *srv) handleRequest(conn net.Conn) (err error) {
-	ss := s.SessionFactory.NewSession(conn, s.Logger, s.sysDb, s.tlsConfig)
+	ss := s.SessionFactory.NewSession(conn, s.Logger, s.sysDb, s.tlsConfig, s.requireSSL, s.authMethod, s.createDatabase)
 
 	// initialize session
 	err = ss.InitializeSession()
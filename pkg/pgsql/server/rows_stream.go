@@ -0,0 +1,72 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"github.com/codenotary/immudb/embedded/sql"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/database"
+	bm "github.com/codenotary/immudb/pkg/pgsql/server/bmessages"
+)
+
+// dataRowsBufferSize is the size DataRow messages are buffered up to before being written,
+// so rows are streamed without writing each of them on its own
+const dataRowsBufferSize = 32 * 1024
+
+// streamRows writes the rows read from the reader in DataRow messages, up to maxRows unless it's zero.
+// The first row may have already been read from the reader. It returns the number of rows written and
+// whether the row limit was reached, in which case there may be rows left to be read
+func (s *session) streamRows(rows database.SQLRows, first *schema.Row, formatCodes []int16, maxRows int) (n int, limitReached bool, err error) {
+	colNumb := len(rows.Columns())
+
+	var buf []byte
+
+	flush := func() error {
+		if len(buf) == 0 {
+			return nil
+		}
+		_, err := s.writeMessage(buf)
+		buf = buf[:0]
+		return err
+	}
+
+	row := first
+
+	for maxRows == 0 || n < maxRows {
+		if row == nil {
+			row, err = rows.Read()
+			if err == sql.ErrNoMoreRows {
+				return n, false, flush()
+			}
+			if err != nil {
+				return n, false, err
+			}
+		}
+
+		buf = append(buf, bm.DataRow([]*schema.Row{row}, colNumb, formatCodes)...)
+		n++
+		row = nil
+
+		if len(buf) >= dataRowsBufferSize {
+			if err := flush(); err != nil {
+				return n, false, err
+			}
+		}
+	}
+
+	return n, true, flush()
+}
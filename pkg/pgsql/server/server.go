@@ -33,6 +33,9 @@ type srv struct {
 	running        bool
 	maxConnections int
 	tlsConfig      *tls.Config
+	requireSSL     bool
+	authMethod     string
+	createDatabase CreateDatabaseFunc
 	SessionFactory SessionFactory
 	Logger         logger.Logger
 	Port           int
@@ -55,6 +58,7 @@ func New(setters ...Option) *srv {
 		running:        true,
 		maxConnections: 1000,
 		tlsConfig:      &tls.Config{},
+		authMethod:     AuthMethodPassword,
 		SessionFactory: NewSessionFactory(),
 		Logger:         logger.NewSimpleLogger("sqlSrv", os.Stderr),
 		Port:           5432,
@@ -69,6 +73,16 @@ func New(setters ...Option) *srv {
 
 // Initialize initialize listener. If provided port is zero os auto assign a free one.
 func (s *srv) Initialize() (err error) {
+	if s.requireSSL && (s.tlsConfig == nil || len(s.tlsConfig.Certificates) == 0) {
+		return ErrSSLRequiredWithoutCertificate
+	}
+
+	switch s.authMethod {
+	case AuthMethodPassword, AuthMethodMD5, AuthMethodSCRAMSHA256:
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedAuthMethod, s.authMethod)
+	}
+
 	s.listener, err = net.Listen("tcp", fmt.Sprintf(":%d", s.Port))
 	if err != nil {
 		return err
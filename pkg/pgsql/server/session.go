@@ -17,8 +17,10 @@ limitations under the License.
 package server
 
 import (
+	"context"
 	"crypto/tls"
 	"encoding/json"
+	"github.com/codenotary/immudb/embedded/sql"
 	"github.com/codenotary/immudb/pkg/api/schema"
 	"github.com/codenotary/immudb/pkg/auth"
 	"github.com/codenotary/immudb/pkg/database"
@@ -31,13 +33,26 @@ import (
 
 type session struct {
 	tlsConfig       *tls.Config
+	requireSSL      bool
+	authMethod      string
 	log             logger.Logger
 	mr              MessageReader
 	username        string
+	user            *auth.User
 	database        database.DB
+	dbList          database.DatabaseList
 	sysDb           database.DB
 	connParams      map[string]string
 	protocolVersion string
+	// session parameters set by the client, by lowercase name
+	params map[string]string
+	// nil if CREATE DATABASE statements are not enabled
+	createDatabase CreateDatabaseFunc
+	// transaction begun with BEGIN, empty if none is in progress
+	txID string
+	// prepared statements and portals of the extended query protocol, by name. The unnamed ones are keyed by ""
+	statements map[string]*preparedStmt
+	portals    map[string]*portal
 	sync.Mutex
 }
 
@@ -48,12 +63,15 @@ type Session interface {
 	ErrorHandle(err error)
 }
 
-func NewSession(c net.Conn, log logger.Logger, sysDb database.DB, tlsConfig *tls.Config) *session {
+func NewSession(c net.Conn, log logger.Logger, sysDb database.DB, tlsConfig *tls.Config, requireSSL bool, authMethod string, createDatabase CreateDatabaseFunc) *session {
 	s := &session{
-		tlsConfig: tlsConfig,
-		log:       log,
-		mr:        NewMessageReader(c),
-		sysDb:     sysDb,
+		tlsConfig:      tlsConfig,
+		requireSSL:     requireSSL,
+		authMethod:     authMethod,
+		createDatabase: createDatabase,
+		log:            log,
+		mr:             NewMessageReader(c),
+		sysDb:          sysDb,
 	}
 	return s
 }
@@ -75,19 +93,33 @@ func (s *session) nextMessage() (interface{}, error) {
 		return nil, err
 	}
 	s.log.Debugf("received %s - %s message", string(msg.t), pgmeta.MTypes[msg.t])
-	return s.parseRawMessage(msg), nil
+	return s.parseRawMessage(msg)
 }
 
-func (s *session) parseRawMessage(msg *rawMessage) interface{} {
+func (s *session) parseRawMessage(msg *rawMessage) (interface{}, error) {
 	switch msg.t {
 	case 'p':
-		return fm.ParsePasswordMsg(msg.payload)
+		return fm.ParsePasswordMsg(msg.payload), nil
 	case 'Q':
-		return fm.ParseQueryMsg(msg.payload)
+		return fm.ParseQueryMsg(msg.payload), nil
 	case 'X':
-		return fm.ParseTerminateMsg(msg.payload)
+		return fm.ParseTerminateMsg(msg.payload), nil
+	case 'P':
+		return fm.ParseParseMsg(msg.payload)
+	case 'B':
+		return fm.ParseBindMsg(msg.payload)
+	case 'D':
+		return fm.ParseDescribeMsg(msg.payload)
+	case 'E':
+		return fm.ParseExecuteMsg(msg.payload)
+	case 'C':
+		return fm.ParseCloseMsg(msg.payload)
+	case 'S':
+		return fm.ParseSyncMsg(msg.payload), nil
+	case 'H':
+		return fm.ParseFlushMsg(msg.payload), nil
 	}
-	return nil
+	return nil, nil
 }
 
 func (s *session) writeMessage(msg []byte) (int, error) {
@@ -107,7 +139,7 @@ func (s *session) getUser(username []byte) (*auth.User, error) {
 	key[0] = 1
 	copy(key[1:], username)
 
-	item, err := s.sysDb.Get(&schema.KeyRequest{Key: key})
+	item, err := s.sysDb.Get(context.Background(), &schema.KeyRequest{Key: key})
 	if err != nil {
 		return nil, err
 	}
@@ -119,5 +151,24 @@ func (s *session) getUser(username []byte) (*auth.User, error) {
 		return nil, err
 	}
 
+	// as on Login, the flag is not stored along with the user
+	usr.IsSysAdmin = usr.Username == auth.SysAdminUsername
+
 	return &usr, nil
 }
+
+// sqlContext binds SQL statements to the session user and to its permission on the database as role,
+// so the policies restricting the rows of the tables get applied. System admins are not restricted
+func (s *session) sqlContext() context.Context {
+	ctx := context.Background()
+
+	if s.user == nil {
+		return sql.WithUser(ctx, s.username)
+	}
+
+	if s.user.IsSysAdmin {
+		return ctx
+	}
+
+	return sql.WithUser(ctx, s.username, auth.PermissionName(s.user.WhichPermission(s.database.GetName())))
+}
@@ -26,13 +26,13 @@ import (
 type sessionFactory struct{}
 
 type SessionFactory interface {
-	NewSession(conn net.Conn, log logger.Logger, sysDb database.DB, tlsConfig *tls.Config) Session
+	NewSession(conn net.Conn, log logger.Logger, sysDb database.DB, tlsConfig *tls.Config, requireSSL bool, authMethod string, createDatabase CreateDatabaseFunc) Session
 }
 
 func NewSessionFactory() sessionFactory {
 	return sessionFactory{}
 }
 
-func (sm sessionFactory) NewSession(conn net.Conn, log logger.Logger, sysDb database.DB, tlsConfig *tls.Config) Session {
-	return NewSession(conn, log, sysDb, tlsConfig)
+func (sm sessionFactory) NewSession(conn net.Conn, log logger.Logger, sysDb database.DB, tlsConfig *tls.Config, requireSSL bool, authMethod string, createDatabase CreateDatabaseFunc) Session {
+	return NewSession(conn, log, sysDb, tlsConfig, requireSSL, authMethod, createDatabase)
 }
@@ -31,6 +31,6 @@ func NewSessionFactoryMock(s Session) sessionFactoryMock {
 	return sessionFactoryMock{s: s}
 }
 
-func (sm sessionFactoryMock) NewSession(conn net.Conn, log logger.Logger, sysDb database.DB, tlsConfig *tls.Config) Session {
+func (sm sessionFactoryMock) NewSession(conn net.Conn, log logger.Logger, sysDb database.DB, tlsConfig *tls.Config, requireSSL bool, authMethod string, createDatabase CreateDatabaseFunc) Session {
 	return sm.s
 }
@@ -0,0 +1,432 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/codenotary/immudb/embedded/sql"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	bm "github.com/codenotary/immudb/pkg/pgsql/server/bmessages"
+	"github.com/codenotary/immudb/pkg/pgsql/server/pgmeta"
+)
+
+// sessionParamDef describes a session parameter known by the server
+type sessionParamDef struct {
+	// name as it's reported and shown, lookups are case-insensitive
+	name string
+	def  string
+	// reported parameters are sent in ParameterStatus messages on startup and whenever they're changed
+	reported bool
+	readOnly bool
+	// check validates and normalizes the values set by the clients, any value is accepted when it's nil
+	check func(v string) (string, error)
+}
+
+// sessionParamDefs are the parameters PostgreSQL reports to the clients, parameters not listed here are
+// stored as they're set, as ORMs set many of them on connect
+var sessionParamDefs = []*sessionParamDef{
+	{name: "application_name", reported: true},
+	{name: "client_encoding", def: "UTF8", reported: true, check: checkClientEncoding},
+	{name: "DateStyle", def: "ISO, MDY", reported: true},
+	{name: "integer_datetimes", def: "on", reported: true, readOnly: true},
+	{name: "IntervalStyle", def: "postgres", reported: true},
+	{name: "server_encoding", def: "UTF8", reported: true, readOnly: true},
+	{name: "server_version", def: pgmeta.PgsqlProtocolVersion, reported: true, readOnly: true},
+	{name: "standard_conforming_strings", def: "on", reported: true, check: checkStandardConformingStrings},
+	// timestamps are rendered along with their offset, thus clients read them right whatever the time zone
+	{name: "TimeZone", def: "UTC", reported: true, check: checkTimeZone},
+}
+
+var sessionParamDefsByName = func() map[string]*sessionParamDef {
+	defs := make(map[string]*sessionParamDef, len(sessionParamDefs))
+	for _, d := range sessionParamDefs {
+		defs[strings.ToLower(d.name)] = d
+	}
+	return defs
+}()
+
+// startup parameters which are not session parameters
+var startupOnlyParams = map[string]bool{"user": true, "database": true, "options": true, "replication": true}
+
+func checkClientEncoding(v string) (string, error) {
+	switch strings.ToUpper(strings.ReplaceAll(v, "-", "")) {
+	case "UTF8", "UNICODE":
+		return "UTF8", nil
+	}
+	return "", errors.New("only UTF8 is supported")
+}
+
+func checkStandardConformingStrings(v string) (string, error) {
+	if !strings.EqualFold(v, "on") {
+		return "", errors.New("only on is supported")
+	}
+	return "on", nil
+}
+
+func checkTimeZone(v string) (string, error) {
+	if _, err := time.LoadLocation(v); err != nil {
+		return "", fmt.Errorf("unknown time zone %s", v)
+	}
+	return v, nil
+}
+
+var setStmtRegexp = regexp.MustCompile(`(?i)^\s*set\s+.+`)
+var showStmtRegexp = regexp.MustCompile(`(?i)^\s*show\s+.+`)
+var setParamRegexp = regexp.MustCompile(`(?is)^set\s+(?:(?:session|local)\s+)?(?:time\s+zone\s+(.+?)|([a-z_][a-z0-9_.]*)\s*(?:=|\s+to\s+)\s*(.+?))$`)
+var showParamRegexp = regexp.MustCompile(`(?is)^show\s+(?:(time\s+zone)|([a-z_][a-z0-9_.]*))$`)
+
+// sessionCmd is a SET or a SHOW statement, they're handled by the server instead of being executed
+type sessionCmd struct {
+	show bool
+	// SHOW ALL
+	all bool
+	// empty when SET is not about a session parameter e.g. SET TRANSACTION, which is acknowledged without effect
+	name string
+	// nil for SET name TO DEFAULT
+	value *string
+}
+
+// isSessionCmd tells if the statements begin with SET or SHOW
+func isSessionCmd(statements string) bool {
+	return setStmtRegexp.MatchString(statements) || showStmtRegexp.MatchString(statements)
+}
+
+// parseSessionCmds parses the SET and SHOW statements separated by semicolons,
+// they can't be mixed with other statements
+func parseSessionCmds(statements string) ([]*sessionCmd, error) {
+	var cmds []*sessionCmd
+
+	for _, stmt := range splitStatements(statements) {
+		if !isSessionCmd(stmt) {
+			return nil, fmt.Errorf("%w: SET and SHOW can't be mixed with other statements", ErrInvalidSessionCmd)
+		}
+
+		cmd, err := parseSessionCmd(stmt)
+		if err != nil {
+			return nil, err
+		}
+
+		cmds = append(cmds, cmd)
+	}
+
+	return cmds, nil
+}
+
+// splitStatements splits the statements at the semicolons not within quotes, empty ones are left out
+func splitStatements(statements string) []string {
+	var stmts []string
+
+	inQuotes := false
+	start := 0
+
+	for i := 0; i <= len(statements); i++ {
+		if i < len(statements) {
+			if statements[i] == '\'' {
+				inQuotes = !inQuotes
+			}
+			if inQuotes || statements[i] != ';' {
+				continue
+			}
+		}
+
+		if stmt := strings.TrimSpace(statements[start:i]); stmt != "" {
+			stmts = append(stmts, stmt)
+		}
+		start = i + 1
+	}
+
+	return stmts
+}
+
+func parseSessionCmd(statement string) (*sessionCmd, error) {
+	statement = strings.TrimSpace(statement)
+
+	if m := showParamRegexp.FindStringSubmatch(statement); m != nil {
+		switch {
+		case m[1] != "":
+			return &sessionCmd{show: true, name: "TimeZone"}, nil
+		case strings.EqualFold(m[2], "all"):
+			return &sessionCmd{show: true, all: true}, nil
+		}
+		return &sessionCmd{show: true, name: m[2]}, nil
+	}
+
+	if len(statement) >= 4 && strings.EqualFold(statement[:4], "show") {
+		return nil, ErrInvalidSessionCmd
+	}
+
+	m := setParamRegexp.FindStringSubmatch(statement)
+	if m == nil {
+		return &sessionCmd{}, nil
+	}
+
+	name, rawValue := m[2], m[3]
+	if m[1] != "" {
+		name, rawValue = "TimeZone", m[1]
+	}
+
+	if strings.EqualFold(rawValue, "default") || (name == "TimeZone" && strings.EqualFold(rawValue, "local")) {
+		return &sessionCmd{name: name}, nil
+	}
+
+	value, err := parseSessionParamValue(rawValue)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sessionCmd{name: name, value: &value}, nil
+}
+
+// parseSessionParamValue parses a value or a list of values, each of them being either a quoted string or a word
+func parseSessionParamValue(raw string) (string, error) {
+	var items []string
+	var item strings.Builder
+
+	quoted, inQuotes := false, false
+
+	for i := 0; i < len(raw); i++ {
+		c := raw[i]
+
+		switch {
+		case inQuotes && c == '\'' && i+1 < len(raw) && raw[i+1] == '\'':
+			item.WriteByte(c)
+			i++
+		case c == '\'':
+			if !inQuotes {
+				if quoted || strings.TrimSpace(item.String()) != "" {
+					return "", ErrInvalidSessionCmd
+				}
+				// whitespace preceding the quotes
+				item.Reset()
+			}
+			inQuotes = !inQuotes
+			quoted = true
+		case !inQuotes && c == ',':
+			items = append(items, strings.TrimSpace(item.String()))
+			item.Reset()
+			quoted = false
+		case !inQuotes && quoted && c != ' ' && c != '\t':
+			return "", ErrInvalidSessionCmd
+		default:
+			item.WriteByte(c)
+		}
+	}
+
+	if inQuotes {
+		return "", ErrInvalidSessionCmd
+	}
+
+	if quoted {
+		items = append(items, item.String())
+	} else {
+		items = append(items, strings.TrimSpace(item.String()))
+	}
+
+	return strings.Join(items, ", "), nil
+}
+
+// initSessionParams sets the parameters given in the startup message
+func (s *session) initSessionParams() error {
+	for name, value := range s.connParams {
+		if startupOnlyParams[strings.ToLower(name)] {
+			continue
+		}
+
+		if _, err := s.setSessionParam(name, &value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setSessionParam sets the value of the parameter, nil resetting it to its default. The definition of
+// the parameter is returned when it's a known one
+func (s *session) setSessionParam(name string, value *string) (*sessionParamDef, error) {
+	key := strings.ToLower(name)
+	def := sessionParamDefsByName[key]
+
+	if s.params == nil {
+		s.params = make(map[string]string)
+	}
+
+	if value == nil {
+		delete(s.params, key)
+		return def, nil
+	}
+
+	v := *value
+
+	if def != nil {
+		if def.readOnly {
+			return nil, fmt.Errorf("%w: \"%s\"", ErrReadOnlySessionParam, def.name)
+		}
+
+		if def.check != nil {
+			var err error
+			if v, err = def.check(v); err != nil {
+				return nil, fmt.Errorf("%w for parameter \"%s\": %v", ErrInvalidSessionParamValue, def.name, err)
+			}
+		}
+	}
+
+	s.params[key] = v
+
+	return def, nil
+}
+
+// sessionParam returns the value of the parameter, the name of unknown parameters is returned as it was given
+func (s *session) sessionParam(name string) (displayName, value string, ok bool) {
+	key := strings.ToLower(name)
+
+	if def, isDef := sessionParamDefsByName[key]; isDef {
+		if v, isSet := s.params[key]; isSet {
+			return def.name, v, true
+		}
+		return def.name, def.def, true
+	}
+
+	v, isSet := s.params[key]
+
+	return key, v, isSet
+}
+
+// writeReportedParams writes a ParameterStatus message for each reported parameter, as it's done on startup
+func (s *session) writeReportedParams() error {
+	for _, def := range sessionParamDefs {
+		if !def.reported {
+			continue
+		}
+
+		name, value, _ := s.sessionParam(def.name)
+
+		if _, err := s.writeMessage(bm.ParameterStatus([]byte(name), []byte(value))); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// showCols returns the description of the rows answering SHOW, named after the parameter
+func (cmd *sessionCmd) showCols() []*schema.Column {
+	if cmd.all {
+		return []*schema.Column{{Name: "name", Type: sql.VarcharType}, {Name: "setting", Type: sql.VarcharType}}
+	}
+
+	name := strings.ToLower(cmd.name)
+	if def, ok := sessionParamDefsByName[name]; ok {
+		name = def.name
+	}
+
+	return []*schema.Column{{Name: name, Type: sql.VarcharType}}
+}
+
+// execSessionCmd executes the SET or SHOW statement and returns the command tag, the row answering SHOW
+// is described first when describe is set, as it's done for simple queries
+func (s *session) execSessionCmd(cmd *sessionCmd, describe bool) (commandTag string, err error) {
+	if !cmd.show {
+		if cmd.name == "" {
+			return `SET`, nil
+		}
+
+		def, err := s.setSessionParam(cmd.name, cmd.value)
+		if err != nil {
+			return "", err
+		}
+
+		if def != nil && def.reported {
+			name, value, _ := s.sessionParam(def.name)
+
+			if _, err := s.writeMessage(bm.ParameterStatus([]byte(name), []byte(value))); err != nil {
+				return "", err
+			}
+		}
+
+		return `SET`, nil
+	}
+
+	cols := cmd.showCols()
+
+	var rows []*schema.Row
+
+	if cmd.all {
+		for _, name := range s.sessionParamNames() {
+			name, value, _ := s.sessionParam(name)
+			rows = append(rows, showRow(cols, name, value))
+		}
+	} else {
+		_, value, ok := s.sessionParam(cmd.name)
+		if !ok {
+			return "", fmt.Errorf("%w: \"%s\"", ErrUnknownSessionParam, cmd.name)
+		}
+		rows = append(rows, showRow(cols, value))
+	}
+
+	if describe {
+		if _, err := s.writeMessage(bm.RowDescription(cols, nil)); err != nil {
+			return "", err
+		}
+	}
+
+	if _, err := s.writeMessage(bm.DataRow(rows, len(cols), nil)); err != nil {
+		return "", err
+	}
+
+	return `SHOW`, nil
+}
+
+// sessionParamNames returns the names of the known parameters followed by the ones set by the client, sorted
+func (s *session) sessionParamNames() []string {
+	var names []string
+
+	for _, def := range sessionParamDefs {
+		names = append(names, def.name)
+	}
+
+	var others []string
+
+	for key := range s.params {
+		if _, isDef := sessionParamDefsByName[key]; !isDef {
+			others = append(others, key)
+		}
+	}
+
+	sort.Strings(others)
+
+	return append(names, others...)
+}
+
+func showRow(cols []*schema.Column, values ...string) *schema.Row {
+	row := &schema.Row{
+		Columns: make([]string, len(cols)),
+		Values:  make([]*schema.SQLValue, len(cols)),
+	}
+
+	for i, c := range cols {
+		row.Columns[i] = c.Name
+		row.Values[i] = &schema.SQLValue{Value: &schema.SQLValue_S{S: values[i]}}
+	}
+
+	return row
+}
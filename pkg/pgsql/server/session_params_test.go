@@ -0,0 +1,129 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSessionCmd(t *testing.T) {
+	value := func(v string) *string { return &v }
+
+	testCases := []struct {
+		stmt string
+		cmd  *sessionCmd
+	}{
+		{"SET application_name = 'my app'", &sessionCmd{name: "application_name", value: value("my app")}},
+		{"set SESSION client_encoding TO UTF8", &sessionCmd{name: "client_encoding", value: value("UTF8")}},
+		{"SET LOCAL search_path TO public, 'my schema'", &sessionCmd{name: "search_path", value: value("public, my schema")}},
+		{"SET extra_float_digits=3", &sessionCmd{name: "extra_float_digits", value: value("3")}},
+		{"SET application_name = 'it''s'", &sessionCmd{name: "application_name", value: value("it's")}},
+		{"SET application_name TO DEFAULT", &sessionCmd{name: "application_name"}},
+		{"SET application_name TO 'default'", &sessionCmd{name: "application_name", value: value("default")}},
+		{"SET TIME ZONE 'Europe/Rome'", &sessionCmd{name: "TimeZone", value: value("Europe/Rome")}},
+		{"SET TIME ZONE LOCAL", &sessionCmd{name: "TimeZone"}},
+		{"SET TRANSACTION ISOLATION LEVEL READ COMMITTED", &sessionCmd{}},
+		{"SHOW client_encoding", &sessionCmd{show: true, name: "client_encoding"}},
+		{"show time zone", &sessionCmd{show: true, name: "TimeZone"}},
+		{"SHOW ALL", &sessionCmd{show: true, all: true}},
+	}
+
+	for _, tc := range testCases {
+		cmd, err := parseSessionCmd(tc.stmt)
+		require.NoError(t, err, tc.stmt)
+		require.Equal(t, tc.cmd, cmd, tc.stmt)
+	}
+
+	for _, stmt := range []string{"SHOW", "SHOW a b", "SET a = 'b", "SET a = 'b' c", "SET a = b 'c'"} {
+		_, err := parseSessionCmd(stmt)
+		require.True(t, errors.Is(err, ErrInvalidSessionCmd), stmt)
+	}
+}
+
+func TestParseSessionCmds(t *testing.T) {
+	cmds, err := parseSessionCmds("SET a = 'x;y'; SHOW a;")
+	require.NoError(t, err)
+	require.Len(t, cmds, 2)
+	require.Equal(t, "x;y", *cmds[0].value)
+	require.True(t, cmds[1].show)
+
+	_, err = parseSessionCmds("SET a = 1; SELECT 1")
+	require.True(t, errors.Is(err, ErrInvalidSessionCmd))
+}
+
+func TestSessionParams(t *testing.T) {
+	s := &session{connParams: map[string]string{
+		"user":             "immudb",
+		"database":         "defaultdb",
+		"application_name": "psql",
+		"client_encoding":  "utf-8",
+	}}
+
+	require.NoError(t, s.initSessionParams())
+
+	name, value, ok := s.sessionParam("APPLICATION_NAME")
+	require.True(t, ok)
+	require.Equal(t, "application_name", name)
+	require.Equal(t, "psql", value)
+
+	_, value, _ = s.sessionParam("client_encoding")
+	require.Equal(t, "UTF8", value)
+
+	name, value, ok = s.sessionParam("timezone")
+	require.True(t, ok)
+	require.Equal(t, "TimeZone", name)
+	require.Equal(t, "UTC", value)
+
+	_, _, ok = s.sessionParam("user")
+	require.False(t, ok)
+
+	for name, v := range map[string]string{
+		"client_encoding":             "LATIN1",
+		"standard_conforming_strings": "off",
+		"TimeZone":                    "Nowhere/Unknown",
+	} {
+		_, err := s.setSessionParam(name, &v)
+		require.True(t, errors.Is(err, ErrInvalidSessionParamValue), name)
+	}
+
+	v := "10"
+	_, err := s.setSessionParam("server_version", &v)
+	require.True(t, errors.Is(err, ErrReadOnlySessionParam))
+
+	def, err := s.setSessionParam("extra_float_digits", &v)
+	require.NoError(t, err)
+	require.Nil(t, def)
+
+	_, value, ok = s.sessionParam("EXTRA_FLOAT_DIGITS")
+	require.True(t, ok)
+	require.Equal(t, "10", value)
+
+	_, err = s.setSessionParam("application_name", nil)
+	require.NoError(t, err)
+
+	_, value, _ = s.sessionParam("application_name")
+	require.Equal(t, "", value)
+
+	names := s.sessionParamNames()
+	require.Equal(t, "extra_float_digits", names[len(names)-1])
+
+	s = &session{connParams: map[string]string{"client_encoding": "SQL_ASCII"}}
+	require.True(t, errors.Is(s.initSessionParams(), ErrInvalidSessionParamValue))
+}
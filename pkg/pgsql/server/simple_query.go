@@ -21,124 +21,298 @@ import (
 	"github.com/codenotary/immudb/pkg/api/schema"
 	bm "github.com/codenotary/immudb/pkg/pgsql/server/bmessages"
 	fm "github.com/codenotary/immudb/pkg/pgsql/server/fmessages"
+	"github.com/codenotary/immudb/pkg/pgsql/server/pgcatalog"
 	"github.com/codenotary/immudb/pkg/pgsql/server/pgmeta"
 	"io"
 	"regexp"
 	"strings"
 )
 
+var versionStmtRegexp = regexp.MustCompile(`(?i)select\s+version\(\s*\)`)
+
 // HandleSimpleQueries errors are returned and handled in the caller
 func (s *session) HandleSimpleQueries() (err error) {
 	s.Lock()
 	defer s.Unlock()
+
+	defer s.closePortals()
+
+	// an extended query cycle is ended by a Sync message, the server is ready for a new query only then.
+	// Messages following an error within the cycle are discarded
+	inExtendedQuery := false
+	discardUntilSync := false
+
 	for {
-		if _, err := s.writeMessage(bm.ReadyForQuery()); err != nil {
-			return err
+		if !inExtendedQuery {
+			// portals don't outlive the transaction they were created in, the implicit one ends with the cycle
+			if s.txID == "" {
+				s.closePortals()
+			}
+
+			ready := bm.ReadyForQuery()
+			if s.txID != "" {
+				ready = bm.ReadyForQueryInTransaction()
+			}
+			if _, err := s.writeMessage(ready); err != nil {
+				return err
+			}
 		}
 		msg, err := s.nextMessage()
 		if err != nil {
 			if err == io.EOF {
 				s.log.Warningf("connection is closed")
+				s.rollbackTx()
 				return nil
 			}
 			s.ErrorHandle(err)
+			discardUntilSync = inExtendedQuery
+			continue
+		}
+
+		if _, isSync := msg.(fm.SyncMsg); discardUntilSync && !isSync {
 			continue
 		}
 
+		commandTag := `ok`
+
 		switch v := msg.(type) {
 		case fm.TerminateMsg:
+			s.rollbackTx()
 			return s.mr.CloseConnection()
+		case fm.SyncMsg:
+			inExtendedQuery = false
+			discardUntilSync = false
+			continue
+		case fm.FlushMsg:
+			continue
+		case fm.ParseMsg, fm.BindMsg, fm.DescribeMsg, fm.ExecuteMsg, fm.CloseMsg:
+			inExtendedQuery = true
+			if err := s.extendedQueryMsg(v); err != nil {
+				s.ErrorHandle(err)
+				discardUntilSync = true
+			}
+			continue
 		case fm.QueryMsg:
-			var set = regexp.MustCompile(`(?i)set\s+.+`)
-			if set.MatchString(v.GetStatements()) {
-				if _, err := s.writeMessage(bm.CommandComplete([]byte(`ok`))); err != nil {
+			if isSessionCmd(v.GetStatements()) {
+				if err := s.sessionCmdsMsg(v.GetStatements()); err != nil {
 					s.ErrorHandle(err)
 				}
 				continue
 			}
-			var version = regexp.MustCompile(`(?i)select\s+version\(\s*\)`)
-			if version.MatchString(v.GetStatements()) {
+			if versionStmtRegexp.MatchString(v.GetStatements()) {
 				if err = s.writeVersionInfo(); err != nil {
 					s.ErrorHandle(err)
 					continue
 				}
 				continue
 			}
-			if err = s.queryMsg(v); err != nil {
+			if pgcatalog.IsCatalogQuery(v.GetStatements()) {
+				if err := s.catalogQueryMsg(v.GetStatements()); err != nil {
+					s.ErrorHandle(err)
+				}
+				continue
+			}
+			if commandTag, err = s.queryMsg(v); err != nil {
 				s.ErrorHandle(err)
 				continue
 			}
 		default:
 			s.ErrorHandle(ErrUnknowMessageType)
+			discardUntilSync = inExtendedQuery
 			continue
 		}
-		if _, err := s.writeMessage(bm.CommandComplete([]byte(`ok`))); err != nil {
+		if _, err := s.writeMessage(bm.CommandComplete([]byte(commandTag))); err != nil {
 			s.ErrorHandle(err)
 			continue
 		}
 	}
 }
 
-func (s *session) queryMsg(v fm.QueryMsg) error {
-	stmts, err := sql.Parse(strings.NewReader(v.GetStatements()))
+// sessionCmdsMsg executes the SET and SHOW statements of the query, each of them is completed on its own
+func (s *session) sessionCmdsMsg(statements string) error {
+	cmds, err := parseSessionCmds(statements)
 	if err != nil {
 		return err
 	}
+
+	for _, cmd := range cmds {
+		commandTag, err := s.execSessionCmd(cmd, true)
+		if err != nil {
+			return err
+		}
+
+		if _, err := s.writeMessage(bm.CommandComplete([]byte(commandTag))); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// queryMsg executes the statements of the query, the returned command tag names the transaction statement
+// when it's the last one, as clients check it when beginning or ending transactions
+func (s *session) queryMsg(v fm.QueryMsg) (commandTag string, err error) {
+	commandTag = `ok`
+
+	stmts, err := sql.Parse(strings.NewReader(v.GetStatements()))
+	if err != nil {
+		return commandTag, err
+	}
 	for _, stmt := range stmts {
+		commandTag = `ok`
+
 		switch st := stmt.(type) {
 		case *sql.UseDatabaseStmt:
-			{
-				return ErrUseDBStatementNotSupported
+			if err := s.useDatabase(st.DB); err != nil {
+				return commandTag, err
 			}
+			commandTag = `USE`
 		case *sql.CreateDatabaseStmt:
-			{
-				return ErrCreateDBStatementNotSupported
+			if err := s.createDatabaseStmt(st.DB); err != nil {
+				return commandTag, err
 			}
+			commandTag = `CREATE DATABASE`
 		case *sql.SelectStmt:
+			// queries are not deferred, thus they don't see the changes made within the transaction in progress
 			err := s.selectStatement(st)
 			if err != nil {
-				return err
+				return commandTag, err
+			}
+		case *sql.BeginTransactionStmt:
+			if err := s.execInTxWritingRows(st); err != nil {
+				return commandTag, err
 			}
+			commandTag = `BEGIN`
+		case *sql.CommitStmt:
+			if err := s.execInTxWritingRows(st); err != nil {
+				return commandTag, err
+			}
+			commandTag = `COMMIT`
+		case *sql.RollbackStmt:
+			if err := s.execInTxWritingRows(st); err != nil {
+				return commandTag, err
+			}
+			commandTag = `ROLLBACK`
 		case sql.SQLStmt:
-			_, err = s.database.SQLExecPrepared([]sql.SQLStmt{st}, nil, true)
+			if s.txID != "" {
+				err := s.execInTxWritingRows(st)
+				if err != nil {
+					return commandTag, err
+				}
+				continue
+			}
+
+			res, err := s.database.SQLExecPrepared(s.sqlContext(), []sql.SQLStmt{st}, nil, true)
 			if err != nil {
-				return err
+				return commandTag, err
+			}
+
+			err = s.writeReturnedRows(res)
+			if err != nil {
+				return commandTag, err
 			}
 		}
 	}
-	return nil
+	return commandTag, nil
 }
 
-func (s *session) selectStatement(st *sql.SelectStmt) error {
-	res, err := s.database.SQLQueryPrepared(st, nil, true)
+// execInTxWritingRows executes the statement within the transaction in progress, rows of statements deferred
+// into the transaction are returned once it's committed
+func (s *session) execInTxWritingRows(st sql.SQLStmt) error {
+	res, err := s.execInTx(st, nil)
 	if err != nil {
 		return err
 	}
-	if res != nil && len(res.Rows) > 0 {
-		if _, err = s.writeMessage(bm.RowDescription(res.Columns)); err != nil {
+
+	return s.writeReturnedRows(res)
+}
+
+// execInTx executes the statement within the transaction in progress, keeping track of the one left open
+func (s *session) execInTx(st sql.SQLStmt, params []*schema.NamedParam) (*schema.SQLExecResult, error) {
+	res, err := s.database.SQLExecPreparedInTx(s.sqlContext(), s.txID, []sql.SQLStmt{st}, params, true)
+	if err != nil {
+		// the transaction is discarded even if it fails to be committed
+		if _, isCommit := st.(*sql.CommitStmt); isCommit {
+			s.txID = ""
+		}
+		return nil, err
+	}
+
+	s.txID = res.TransactionId
+
+	return res, nil
+}
+
+// writeReturnedRows writes the rows returned by statements with a RETURNING clause, one result set after the other
+func (s *session) writeReturnedRows(res *schema.SQLExecResult) error {
+	for _, rows := range res.ReturnedRows {
+		if _, err := s.writeMessage(bm.RowDescription(rows.Columns, nil)); err != nil {
 			return err
 		}
-		if _, err = s.writeMessage(bm.DataRow(res.Rows, len(res.Columns), false)); err != nil {
+		if _, err := s.writeMessage(bm.DataRow(rows.Rows, len(rows.Columns), nil)); err != nil {
 			return err
 		}
-		return nil
-	}
-	if _, err = s.writeMessage(bm.EmptyQueryResponse()); err != nil {
-		return err
 	}
+
 	return nil
 }
 
-func (s *session) writeVersionInfo() error {
-	cols := []*schema.Column{{Name: "version", Type: "VARCHAR"}}
-	if _, err := s.writeMessage(bm.RowDescription(cols)); err != nil {
+// rollbackTx discards the transaction in progress, if any, when the connection is closed
+func (s *session) rollbackTx() {
+	if s.txID == "" || s.database == nil {
+		return
+	}
+
+	_, err := s.database.SQLExecPreparedInTx(s.sqlContext(), s.txID, []sql.SQLStmt{&sql.RollbackStmt{}}, nil, true)
+	if err != nil {
+		s.log.Warningf("unable to rollback transaction: %v", err)
+	}
+
+	s.txID = ""
+}
+
+// selectStatement streams the rows of the query as they're read, so results are not materialized
+func (s *session) selectStatement(st *sql.SelectStmt) error {
+	rows, err := s.database.SQLQueryRowsPrepared(s.sqlContext(), st, nil, true)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	// the first row is read in advance, as no description is written when there are no rows
+	first, err := rows.Read()
+	if err == sql.ErrNoMoreRows {
+		_, err = s.writeMessage(bm.EmptyQueryResponse())
+		return err
+	}
+	if err != nil {
 		return err
 	}
+
+	if _, err = s.writeMessage(bm.RowDescription(rows.Columns(), nil)); err != nil {
+		return err
+	}
+
+	_, _, err = s.streamRows(rows, first, nil, 0)
+	return err
+}
+
+// versionInfo returns the columns and the rows answering version queries
+func versionInfo() ([]*schema.Column, []*schema.Row) {
+	cols := []*schema.Column{{Name: "version", Type: "VARCHAR"}}
 	rows := []*schema.Row{{
 		Columns: []string{"version"},
 		Values:  []*schema.SQLValue{{Value: &schema.SQLValue_S{S: pgmeta.PgsqlProtocolVersionMessage}}},
 	}}
-	if _, err := s.writeMessage(bm.DataRow(rows, len(cols), false)); err != nil {
+	return cols, rows
+}
+
+func (s *session) writeVersionInfo() error {
+	cols, rows := versionInfo()
+	if _, err := s.writeMessage(bm.RowDescription(cols, nil)); err != nil {
+		return err
+	}
+	if _, err := s.writeMessage(bm.DataRow(rows, len(cols), nil)); err != nil {
 		return err
 	}
 	if _, err := s.writeMessage(bm.CommandComplete([]byte(`ok`))); err != nil {
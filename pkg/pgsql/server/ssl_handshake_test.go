@@ -18,6 +18,7 @@ package server
 
 import (
 	"crypto/tls"
+	"encoding/binary"
 	"github.com/codenotary/immudb/pkg/logger"
 	"github.com/stretchr/testify/require"
 	"net"
@@ -74,3 +75,83 @@ EKTcWGekdmdDPsHloRNtsiCa697B2O9IFA==
 
 	require.Error(t, err)
 }
+
+// startupMessage returns the startup message with the given parameters, or the negotiation request of the given code
+func startupMessage(code uint32, params ...string) []byte {
+	var payload []byte
+	for _, p := range params {
+		payload = append(payload, append([]byte(p), 0)...)
+	}
+	if len(params) > 0 {
+		payload = append(payload, 0)
+	}
+
+	msg := make([]byte, 8, 8+len(payload))
+	binary.BigEndian.PutUint32(msg, uint32(8+len(payload)))
+	binary.BigEndian.PutUint32(msg[4:], code)
+
+	return append(msg, payload...)
+}
+
+func TestSession_InitializeSessionSSLNotEnabled(t *testing.T) {
+	c1, c2 := net.Pipe()
+
+	s := NewSession(c1, logger.NewSimpleLogger("test", os.Stdout), nil, &tls.Config{}, false, AuthMethodPassword, nil)
+
+	go func() {
+		// GSSENCRequest
+		c2.Write(startupMessage(80877104))
+		negotiation := make([]byte, 1)
+		c2.Read(negotiation)
+		// SSLRequest
+		c2.Write(startupMessage(80877103))
+		c2.Read(negotiation)
+		// plaintext startup
+		c2.Write(startupMessage(196608, "user", "immudb", "database", "defaultdb"))
+	}()
+
+	err := s.InitializeSession()
+	require.NoError(t, err)
+	require.Equal(t, "3.0", s.protocolVersion)
+	require.Equal(t, map[string]string{"user": "immudb", "database": "defaultdb"}, s.connParams)
+}
+
+func TestSession_InitializeSessionSSLRequired(t *testing.T) {
+	c1, c2 := net.Pipe()
+
+	s := NewSession(c1, logger.NewSimpleLogger("test", os.Stdout), nil, &tls.Config{}, true, AuthMethodPassword, nil)
+
+	go func() {
+		c2.Write(startupMessage(196608, "user", "immudb", "database", "defaultdb"))
+		errorResponse := make([]byte, 500)
+		c2.Read(errorResponse)
+	}()
+
+	err := s.InitializeSession()
+	require.Equal(t, ErrSSLRequired, err)
+}
+
+func TestSession_InitializeSessionRepeatedSSLRequest(t *testing.T) {
+	c1, c2 := net.Pipe()
+
+	s := NewSession(c1, logger.NewSimpleLogger("test", os.Stdout), nil, &tls.Config{}, false, AuthMethodPassword, nil)
+
+	go func() {
+		c2.Write(startupMessage(80877103))
+		negotiation := make([]byte, 1)
+		c2.Read(negotiation)
+		c2.Write(startupMessage(80877103))
+		errorResponse := make([]byte, 500)
+		c2.Read(errorResponse)
+	}()
+
+	err := s.InitializeSession()
+	require.Equal(t, ErrUnexpectedNegotiationRequest, err)
+}
+
+func TestServer_InitializeRequireSSLWithoutCertificate(t *testing.T) {
+	srv := New(Port(0), RequireSSL(true))
+
+	err := srv.Initialize()
+	require.Equal(t, ErrSSLRequiredWithoutCertificate, err)
+}
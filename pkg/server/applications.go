@@ -0,0 +1,211 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"regexp"
+	"sync"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// applicationHeader is the header clients tag their requests with the name of the application sending them
+const applicationHeader = "immudb-application"
+
+// label used for requests not tagged with an application name
+const untaggedApplicationLabel = "_untagged"
+
+// maximum number of applications having their own label on per-application metrics
+const maxLabeledApplications = 100
+
+// application names can not start with an underscore so they can't clash with labels used on metrics
+var applicationNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.\-]{0,63}$`)
+
+// applications keeps track of the in-flight requests of each application, limiting
+// how many of them may be served concurrently and allowing them to be cancelled
+type applications struct {
+	defaultQuota int
+	quotas       map[string]int
+
+	inflight map[string]map[uint64]context.CancelFunc
+	nextID   uint64
+	mutex    sync.Mutex
+}
+
+func newApplications(defaultQuota int, quotas map[string]int) *applications {
+	return &applications{
+		defaultQuota: defaultQuota,
+		quotas:       quotas,
+		inflight:     make(map[string]map[uint64]context.CancelFunc),
+	}
+}
+
+// applicationFromContext returns the application name the request was tagged with, an empty one if untagged
+func applicationFromContext(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", nil
+	}
+
+	values := md.Get(applicationHeader)
+	if len(values) == 0 {
+		return "", nil
+	}
+
+	if len(values) > 1 || !applicationNameRegexp.MatchString(values[0]) {
+		return "", ErrInvalidApplicationName
+	}
+
+	return values[0], nil
+}
+
+func (a *applications) quota(app string) int {
+	quota, ok := a.quotas[app]
+	if ok {
+		return quota
+	}
+	return a.defaultQuota
+}
+
+// begin registers a new in-flight request of the application, untagged requests are not limited.
+// The returned context is cancelled either when the request is done or when the requests of the application get cancelled
+func (a *applications) begin(ctx context.Context, app string) (context.Context, func(), error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	requests := a.inflight[app]
+
+	quota := a.quota(app)
+	if app != "" && quota > 0 && len(requests) >= quota {
+		return nil, nil, ErrApplicationQuotaExceeded
+	}
+
+	if requests == nil {
+		requests = make(map[uint64]context.CancelFunc)
+		a.inflight[app] = requests
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	id := a.nextID
+	a.nextID++
+
+	requests[id] = cancel
+
+	done := func() {
+		a.mutex.Lock()
+		defer a.mutex.Unlock()
+
+		delete(requests, id)
+		if len(requests) == 0 {
+			delete(a.inflight, app)
+		}
+
+		cancel()
+	}
+
+	return ctx, done, nil
+}
+
+// cancel cancels the in-flight requests of the application, returning how many of them were cancelled
+func (a *applications) cancel(app string) int {
+	if a == nil {
+		return 0
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	requests := a.inflight[app]
+
+	for _, cancel := range requests {
+		cancel()
+	}
+
+	return len(requests)
+}
+
+// applicationServerStream replaces the context of a stream with the one of the in-flight request
+type applicationServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *applicationServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// ApplicationInterceptor applies the quotas of the application the request was tagged with and updates its metrics
+func (s *ImmuServer) ApplicationInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	app, err := applicationFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, done, err := s.applications.begin(ctx, app)
+
+	Metrics.UpdateApplicationRPCMetrics(app, err != nil)
+
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	return handler(ctx, req)
+}
+
+// ApplicationStreamInterceptor applies the quotas of the application the stream was tagged with and updates its metrics
+func (s *ImmuServer) ApplicationStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	app, err := applicationFromContext(ss.Context())
+	if err != nil {
+		return err
+	}
+
+	ctx, done, err := s.applications.begin(ss.Context(), app)
+
+	Metrics.UpdateApplicationRPCMetrics(app, err != nil)
+
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	return handler(srv, &applicationServerStream{ServerStream: ss, ctx: ctx})
+}
+
+// CancelApplicationRequests cancels the in-flight requests tagged with the given application name
+func (s *ImmuServer) CancelApplicationRequests(ctx context.Context, req *schema.CancelApplicationRequestsRequest) (*schema.CancelApplicationRequestsResponse, error) {
+	s.Logger.Debugf("CancelApplicationRequests %+v", req)
+
+	if req == nil || !applicationNameRegexp.MatchString(req.Application) {
+		return nil, ErrIllegalArguments
+	}
+
+	err := s.checkSysAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cancelled := s.applications.cancel(req.Application)
+
+	s.Logger.Infof("%d in-flight request(s) of application '%s' cancelled", cancelled, req.Application)
+
+	return &schema.CancelApplicationRequestsResponse{Cancelled: uint32(cancelled)}, nil
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestApplicationFromContext(t *testing.T) {
+	app, err := applicationFromContext(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, app)
+
+	for _, c := range []struct {
+		values []string
+		app    string
+		err    error
+	}{
+		{nil, "", nil},
+		{[]string{"reporting"}, "reporting", nil},
+		{[]string{"billing-service.v2"}, "billing-service.v2", nil},
+		{[]string{""}, "", ErrInvalidApplicationName},
+		{[]string{"_untagged"}, "", ErrInvalidApplicationName},
+		{[]string{"with spaces"}, "", ErrInvalidApplicationName},
+		{[]string{"reporting", "billing"}, "", ErrInvalidApplicationName},
+	} {
+		md := metadata.MD{}
+		if c.values != nil {
+			md.Set(applicationHeader, c.values...)
+		}
+
+		app, err := applicationFromContext(metadata.NewIncomingContext(context.Background(), md))
+		require.Equal(t, c.err, err, c.values)
+		require.Equal(t, c.app, app)
+	}
+}
+
+func TestApplicationsQuotas(t *testing.T) {
+	apps := newApplications(2, map[string]int{"reporting": 1, "batch": 0})
+
+	_, done1, err := apps.begin(context.Background(), "billing")
+	require.NoError(t, err)
+
+	_, done2, err := apps.begin(context.Background(), "billing")
+	require.NoError(t, err)
+
+	_, _, err = apps.begin(context.Background(), "billing")
+	require.Equal(t, ErrApplicationQuotaExceeded, err)
+
+	done1()
+
+	_, done3, err := apps.begin(context.Background(), "billing")
+	require.NoError(t, err)
+
+	done2()
+	done3()
+	require.Empty(t, apps.inflight)
+
+	_, done, err := apps.begin(context.Background(), "reporting")
+	require.NoError(t, err)
+
+	_, _, err = apps.begin(context.Background(), "reporting")
+	require.Equal(t, ErrApplicationQuotaExceeded, err)
+
+	done()
+
+	// neither untagged requests nor applications without quota are limited
+	for i := 0; i < 5; i++ {
+		_, _, err = apps.begin(context.Background(), "")
+		require.NoError(t, err)
+
+		_, _, err = apps.begin(context.Background(), "batch")
+		require.NoError(t, err)
+	}
+}
+
+func TestApplicationsCancel(t *testing.T) {
+	var apps *applications
+	require.Equal(t, 0, apps.cancel("reporting"))
+
+	apps = newApplications(0, nil)
+
+	ctx1, done1, err := apps.begin(context.Background(), "reporting")
+	require.NoError(t, err)
+
+	ctx2, done2, err := apps.begin(context.Background(), "reporting")
+	require.NoError(t, err)
+
+	ctx3, done3, err := apps.begin(context.Background(), "billing")
+	require.NoError(t, err)
+	defer done3()
+
+	require.Equal(t, 2, apps.cancel("reporting"))
+
+	require.Equal(t, context.Canceled, ctx1.Err())
+	require.Equal(t, context.Canceled, ctx2.Err())
+	require.NoError(t, ctx3.Err())
+
+	done1()
+	done2()
+
+	require.Equal(t, 0, apps.cancel("reporting"))
+}
+
+func TestServerApplicationRequests(t *testing.T) {
+	dir := "data_applications"
+	defer os.RemoveAll(dir)
+
+	serverOptions := DefaultOptions().
+		WithDir(dir).
+		WithListener(bufconn.Listen(1024 * 1024)).
+		WithMetricsServer(false).
+		WithAdminPassword(auth.SysAdminPassword).
+		WithMaxRequestsPerApplication(1)
+
+	s := DefaultServer().WithOptions(serverOptions).(*ImmuServer)
+
+	err := s.Initialize()
+	require.NoError(t, err)
+
+	lr, err := s.Login(context.Background(), &schema.LoginRequest{
+		User:     []byte(auth.SysAdminUsername),
+		Password: []byte(auth.SysAdminPassword),
+	})
+	require.NoError(t, err)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", lr.Token))
+
+	_, err = s.CancelApplicationRequests(ctx, nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = s.CancelApplicationRequests(ctx, &schema.CancelApplicationRequestsRequest{})
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = s.CancelApplicationRequests(context.Background(), &schema.CancelApplicationRequestsRequest{Application: "reporting"})
+	require.Error(t, err)
+
+	appCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"authorization", lr.Token,
+		applicationHeader, "reporting",
+	))
+
+	rpcs := testutil.ToFloat64(Metrics.RPCsPerApplicationCounters.WithLabelValues("reporting"))
+	rejected := testutil.ToFloat64(Metrics.RejectedRPCsPerApplicationCounters.WithLabelValues("reporting"))
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/immudb.schema.ImmuService/Set"}
+
+	_, err = s.ApplicationInterceptor(appCtx, nil, info, func(reqCtx context.Context, req interface{}) (interface{}, error) {
+		// the quota of the application is exhausted while the request is in-flight
+		_, err := s.ApplicationInterceptor(appCtx, nil, info, func(context.Context, interface{}) (interface{}, error) {
+			return nil, nil
+		})
+		require.Equal(t, ErrApplicationQuotaExceeded, err)
+
+		res, err := s.CancelApplicationRequests(ctx, &schema.CancelApplicationRequestsRequest{Application: "reporting"})
+		require.NoError(t, err)
+		require.Equal(t, uint32(1), res.Cancelled)
+
+		return nil, reqCtx.Err()
+	})
+	require.Equal(t, context.Canceled, err)
+
+	require.Equal(t, rpcs+2, testutil.ToFloat64(Metrics.RPCsPerApplicationCounters.WithLabelValues("reporting")))
+	require.Equal(t, rejected+1, testutil.ToFloat64(Metrics.RejectedRPCsPerApplicationCounters.WithLabelValues("reporting")))
+
+	// the quota is released once the request is done
+	_, err = s.ApplicationInterceptor(appCtx, nil, info, func(context.Context, interface{}) (interface{}, error) {
+		return nil, nil
+	})
+	require.NoError(t, err)
+
+	invalidCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(applicationHeader, "with spaces"))
+
+	_, err = s.ApplicationInterceptor(invalidCtx, nil, info, func(context.Context, interface{}) (interface{}, error) {
+		return nil, nil
+	})
+	require.Equal(t, ErrInvalidApplicationName, err)
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/codenotary/immudb/embedded/store"
 	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/database"
 )
 
 // GetAll ...
@@ -13,15 +14,16 @@ func (s *ImmuServer) GetAll(ctx context.Context, req *schema.KeyListRequest) (*s
 		return nil, store.ErrIllegalArguments
 	}
 
-	ind, err := s.getDbIndexFromCtx(ctx, "GetAll")
+	db, err := s.getDbFromCtx(ctx, "GetAll")
 	if err != nil {
 		return nil, err
 	}
+	defer s.dbList.Release(db)
 
 	list := &schema.Entries{}
 
 	for _, key := range req.Keys {
-		e, err := s.dbList.GetByIndex(ind).Get(&schema.KeyRequest{Key: key, SinceTx: req.SinceTx})
+		e, err := db.Get(ctx, &schema.KeyRequest{Key: key, SinceTx: req.SinceTx})
 		if err != nil {
 			return nil, err
 		}
@@ -34,10 +36,52 @@ func (s *ImmuServer) GetAll(ctx context.Context, req *schema.KeyListRequest) (*s
 func (s *ImmuServer) ExecAll(ctx context.Context, req *schema.ExecAllRequest) (*schema.TxMetadata, error) {
 	s.Logger.Debugf("set atomic operations")
 
-	ind, err := s.getDbIndexFromCtx(ctx, "ExecAll")
+	db, err := s.getDbFromCtx(ctx, "ExecAll")
 	if err != nil {
 		return nil, err
 	}
+	defer s.dbList.Release(db)
 
-	return s.dbList.GetByIndex(ind).ExecAll(req)
+	if req != nil {
+		req.TraceID = s.traceIDFor(ctx, req.TraceID)
+	}
+
+	return db.ExecAll(ctx, req)
+}
+
+// ExecMultiDbAll atomically commits the operations of each request into its database through a two-phase commit
+// whose decision is persisted into the system database, the logged in user must be allowed to run it on every one of the databases
+func (s *ImmuServer) ExecMultiDbAll(ctx context.Context, req *schema.ExecMultiDbAllRequest) (*schema.ExecMultiDbAllResponse, error) {
+	if req == nil || len(req.Requests) == 0 {
+		return nil, ErrIllegalArguments
+	}
+
+	dbs := make([]database.DB, len(req.Requests))
+	reqs := make([]*schema.ExecAllRequest, len(req.Requests))
+
+	for i, r := range req.Requests {
+		if r == nil {
+			return nil, ErrIllegalArguments
+		}
+
+		db, err := s.getDbByNameFromCtx(ctx, r.Database, "ExecMultiDbAll")
+		if err != nil {
+			return nil, err
+		}
+		defer s.dbList.Release(db)
+
+		if r.Request != nil {
+			r.Request.TraceID = s.traceIDFor(ctx, r.Request.TraceID)
+		}
+
+		dbs[i] = db
+		reqs[i] = r.Request
+	}
+
+	txs, err := database.ExecMultiDbAll(ctx, dbs, reqs, s.multiDbTxLog())
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema.ExecMultiDbAllResponse{Txs: txs}, nil
 }
@@ -0,0 +1,242 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	"github.com/codenotary/immudb/pkg/database"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+func pendingMultiDbTxs(t *testing.T, s *ImmuServer) []*schema.Entry {
+	state, err := s.sysDb.CurrentState()
+	require.NoError(t, err)
+
+	itemList, err := s.sysDb.Scan(context.Background(), &schema.ScanRequest{
+		Prefix:  []byte{KeyPrefixMultiDbTx},
+		SinceTx: state.TxId,
+	})
+	require.NoError(t, err)
+
+	return itemList.Entries
+}
+
+func TestServerExecMultiDbAll(t *testing.T) {
+	dir := "data_exec_multidb"
+	defer os.RemoveAll(dir)
+
+	serverOptions := DefaultOptions().
+		WithDir(dir).
+		WithListener(bufconn.Listen(1024 * 1024)).
+		WithMetricsServer(false).
+		WithAdminPassword(auth.SysAdminPassword)
+
+	s := DefaultServer().WithOptions(serverOptions).(*ImmuServer)
+
+	err := s.Initialize()
+	require.NoError(t, err)
+	defer s.CloseDatabases()
+
+	login := func(user, password string) context.Context {
+		lr, err := s.Login(context.Background(), &schema.LoginRequest{
+			User:     []byte(user),
+			Password: []byte(password),
+		})
+		require.NoError(t, err)
+
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", lr.Token))
+	}
+
+	ctx := login(auth.SysAdminUsername, auth.SysAdminPassword)
+
+	_, err = s.CreateDatabase(ctx, &schema.Database{DatabaseName: "db1"})
+	require.NoError(t, err)
+
+	_, err = s.CreateDatabase(ctx, &schema.Database{DatabaseName: "db2"})
+	require.NoError(t, err)
+
+	kv := func(key, value string) *schema.ExecAllRequest {
+		return &schema.ExecAllRequest{
+			Operations: []*schema.Op{
+				{Operation: &schema.Op_Kv{Kv: &schema.KeyValue{Key: []byte(key), Value: []byte(value)}}},
+			},
+		}
+	}
+
+	_, err = s.ExecMultiDbAll(ctx, nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = s.ExecMultiDbAll(ctx, &schema.ExecMultiDbAllRequest{
+		Requests: []*schema.DatabaseExecAllRequest{{Database: SystemdbName, Request: kv("key1", "value1")}},
+	})
+	require.Error(t, err)
+
+	_, err = s.ExecMultiDbAll(ctx, &schema.ExecMultiDbAllRequest{
+		Requests: []*schema.DatabaseExecAllRequest{{Database: "db3", Request: kv("key1", "value1")}},
+	})
+	require.Error(t, err)
+
+	res, err := s.ExecMultiDbAll(ctx, &schema.ExecMultiDbAllRequest{
+		Requests: []*schema.DatabaseExecAllRequest{
+			{Database: "db1", Request: kv("key1", "value1")},
+			{Database: "db2", Request: kv("key2", "value2")},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Txs, 2)
+
+	db1, err := s.dbList.GetByName("db1")
+	require.NoError(t, err)
+
+	entry, err := db1.Get(context.Background(), &schema.KeyRequest{Key: []byte("key1")})
+	require.NoError(t, err)
+	require.Equal(t, res.Txs[0].Id, entry.Tx)
+
+	db2, err := s.dbList.GetByName("db2")
+	require.NoError(t, err)
+
+	entry, err = db2.Get(context.Background(), &schema.KeyRequest{Key: []byte("key2")})
+	require.NoError(t, err)
+	require.Equal(t, res.Txs[1].Id, entry.Tx)
+
+	// the decision record is discarded once committed into every database
+	require.Empty(t, pendingMultiDbTxs(t, s))
+
+	// write permission is required on every database
+	_, err = s.CreateUser(ctx, &schema.CreateUserRequest{
+		User:       []byte("rwuser"),
+		Password:   []byte("rwuserPas@1"),
+		Database:   "db1",
+		Permission: auth.PermissionRW,
+	})
+	require.NoError(t, err)
+
+	_, err = s.ChangePermission(ctx, &schema.ChangePermissionRequest{
+		Action:     schema.PermissionAction_GRANT,
+		Database:   "db2",
+		Permission: auth.PermissionR,
+		Username:   "rwuser",
+	})
+	require.NoError(t, err)
+
+	userCtx := login("rwuser", "rwuserPas@1")
+
+	_, err = s.ExecMultiDbAll(userCtx, &schema.ExecMultiDbAllRequest{
+		Requests: []*schema.DatabaseExecAllRequest{
+			{Database: "db1", Request: kv("key3", "value3")},
+			{Database: "db2", Request: kv("key3", "value3")},
+		},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "permission")
+
+	_, err = db1.Get(context.Background(), &schema.KeyRequest{Key: []byte("key3")})
+	require.Error(t, err)
+}
+
+func TestServerRecoverMultiDbTxs(t *testing.T) {
+	dir := "data_recover_multidb"
+	defer os.RemoveAll(dir)
+
+	serverOptions := DefaultOptions().
+		WithDir(dir).
+		WithListener(bufconn.Listen(1024 * 1024)).
+		WithMetricsServer(false).
+		WithAdminPassword(auth.SysAdminPassword)
+
+	s := DefaultServer().WithOptions(serverOptions).(*ImmuServer)
+
+	err := s.Initialize()
+	require.NoError(t, err)
+
+	lr, err := s.Login(context.Background(), &schema.LoginRequest{
+		User:     []byte(auth.SysAdminUsername),
+		Password: []byte(auth.SysAdminPassword),
+	})
+	require.NoError(t, err)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", lr.Token))
+
+	for _, name := range []string{"db1", "db2"} {
+		_, err = s.CreateDatabase(ctx, &schema.Database{DatabaseName: name})
+		require.NoError(t, err)
+	}
+
+	recordFor := func(dbname string, key string) *database.MultiDbTxRecordEntry {
+		db, err := s.dbList.GetByName(dbname)
+		require.NoError(t, err)
+
+		state, err := db.CurrentState()
+		require.NoError(t, err)
+
+		req, err := proto.Marshal(&schema.ExecAllRequest{
+			Operations: []*schema.Op{
+				{Operation: &schema.Op_Kv{Kv: &schema.KeyValue{Key: []byte(key), Value: []byte("recovered")}}},
+			},
+		})
+		require.NoError(t, err)
+
+		return &database.MultiDbTxRecordEntry{Database: dbname, TxID: state.TxId + 1, Request: req}
+	}
+
+	// a crash happened after persisting the decision but before committing the txs
+	err = s.multiDbTxLog().Decide(context.Background(), &database.MultiDbTxRecord{
+		Txs: []*database.MultiDbTxRecordEntry{recordFor("db1", "key1"), recordFor("db2", "key2")},
+	})
+	require.NoError(t, err)
+
+	// the record of a database which is not loaded is kept until it can be recovered
+	err = s.multiDbTxLog().Decide(context.Background(), &database.MultiDbTxRecord{
+		Txs: []*database.MultiDbTxRecordEntry{
+			{Database: "db0", TxID: 1, Request: recordFor("db1", "key3").Request},
+			recordFor("db1", "key3"),
+		},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, pendingMultiDbTxs(t, s), 2)
+
+	err = s.CloseDatabases()
+	require.NoError(t, err)
+
+	s = DefaultServer().WithOptions(serverOptions).(*ImmuServer)
+
+	err = s.Initialize()
+	require.NoError(t, err)
+	defer s.CloseDatabases()
+
+	for dbname, key := range map[string]string{"db1": "key1", "db2": "key2"} {
+		db, err := s.dbList.GetByName(dbname)
+		require.NoError(t, err)
+
+		entry, err := db.Get(context.Background(), &schema.KeyRequest{Key: []byte(key)})
+		require.NoError(t, err)
+		require.Equal(t, []byte("recovered"), entry.Value)
+	}
+
+	pending := pendingMultiDbTxs(t, s)
+	require.Len(t, pending, 1)
+	require.Contains(t, string(pending[0].Value), "db0")
+}
@@ -0,0 +1,30 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"github.com/codenotary/immudb/embedded/store"
+)
+
+// corruptionHandler reports inconsistencies detected by the store of the database as soon as they occur,
+// so they can be alerted on through logs and the number_of_corruptions_detected metric
+func (s *ImmuServer) corruptionHandler(dbname string) store.CorruptionHandler {
+	return func(op string, err error) {
+		s.Logger.Errorf("Corruption detected in database '%s' while %s: %v", dbname, op, err)
+		Metrics.UpdateCorruptionMetrics(dbname)
+	}
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"testing"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCorruptionHandler(t *testing.T) {
+	s := DefaultServer().WithOptions(DefaultOptions()).(*ImmuServer)
+	s.Logger = &mockLogger{}
+
+	opts, err := s.storeOptionsFor("db1", DefaultStoreOptions(), false)
+	require.NoError(t, err)
+	require.NotNil(t, opts.OnCorruption)
+
+	counter := Metrics.CorruptionsPerDBCounters.WithLabelValues("db1")
+	before := testutil.ToFloat64(counter)
+
+	opts.OnCorruption("reading value", store.ErrCorruptedData)
+	opts.OnCorruption("indexing", &store.TxError{TxID: 2, Err: store.ErrorCorruptedTxData})
+
+	require.Equal(t, before+2, testutil.ToFloat64(counter))
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/database"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// databaseSettingsFile is the file, inside the folder of a database, holding the settings
+// changed after the database was created. Settings fixed at creation are persisted by the store itself.
+const databaseSettingsFile = "settings.json"
+
+// minKeyLen is the lowest max key length accepted for a database, keys of the SQL catalog must still fit
+const minKeyLen = 64
+
+// maxValueLen is the highest max value length accepted for a database
+const maxValueLen = math.MaxInt32
+
+type persistedDatabaseSettings struct {
+	MaxValueLen int `json:"maxValueLen,omitempty"`
+}
+
+func errInvalidDatabaseSettings(reason string, args ...interface{}) error {
+	return status.Errorf(codes.InvalidArgument, "invalid database settings: "+reason, args...)
+}
+
+func (s *ImmuServer) readDatabaseSettings(dbDir string) (*persistedDatabaseSettings, error) {
+	var settings persistedDatabaseSettings
+
+	content, err := ioutil.ReadFile(s.OS.Join(dbDir, databaseSettingsFile))
+	if os.IsNotExist(err) {
+		return &settings, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(content, &settings)
+	if err != nil {
+		return nil, fmt.Errorf("invalid database settings at '%s': %v", dbDir, err)
+	}
+
+	return &settings, nil
+}
+
+func (s *ImmuServer) writeDatabaseSettings(dbDir string, settings *persistedDatabaseSettings) error {
+	content, err := json.Marshal(settings)
+	if err != nil {
+		return err
+	}
+
+	settingsPath := s.OS.Join(dbDir, databaseSettingsFile)
+	tmpPath := settingsPath + ".tmp"
+
+	err = ioutil.WriteFile(tmpPath, content, 0600)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, settingsPath)
+}
+
+// withDatabaseSettings returns a copy of the store options with the limits set in the settings,
+// zero valued settings are left to the options of the server
+func withDatabaseSettings(storeOpts *store.Options, settings *schema.DatabaseSettings) (*store.Options, error) {
+	dbStoreOpts := *storeOpts
+
+	if settings.MaxKeyLen > 0 {
+		if settings.MaxKeyLen < minKeyLen || settings.MaxKeyLen > store.MaxKeyLen {
+			return nil, errInvalidDatabaseSettings("max key length must be between %d and %d", minKeyLen, store.MaxKeyLen)
+		}
+
+		dbStoreOpts.MaxKeyLen = int(settings.MaxKeyLen)
+	}
+
+	if settings.MaxValueLen > 0 {
+		if settings.MaxValueLen > maxValueLen {
+			return nil, errInvalidDatabaseSettings("max value length can not be greater than %d", maxValueLen)
+		}
+
+		dbStoreOpts.MaxValueLen = int(settings.MaxValueLen)
+	}
+
+	if settings.MaxTxEntries > 0 {
+		if settings.MaxTxEntries > store.MaxTxEntries {
+			return nil, errInvalidDatabaseSettings("max number of entries per tx can not be greater than %d", store.MaxTxEntries)
+		}
+
+		dbStoreOpts.MaxTxEntries = int(settings.MaxTxEntries)
+	}
+
+	return &dbStoreOpts, nil
+}
+
+// CreateDatabaseWith creates a new database with the given limits, zero valued limits take the defaults of the server
+func (s *ImmuServer) CreateDatabaseWith(ctx context.Context, req *schema.DatabaseSettings) (*empty.Empty, error) {
+	s.Logger.Debugf("CreateDatabaseWith %+v", req)
+
+	if req == nil {
+		return nil, ErrIllegalArguments
+	}
+
+	return s.createDatabase(ctx, req)
+}
+
+// UpdateDatabase changes the settings of an existing database. The max key length and the max number of entries
+// per tx are fixed at creation, while the max value length can be increased. The database is reopened to apply them.
+func (s *ImmuServer) UpdateDatabase(ctx context.Context, req *schema.DatabaseSettings) (*empty.Empty, error) {
+	s.Logger.Debugf("UpdateDatabase %+v", req)
+
+	if req == nil {
+		return nil, ErrIllegalArguments
+	}
+
+	err := s.checkSysAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.DatabaseName == SystemdbName {
+		return nil, ErrSystemDatabaseUpdate
+	}
+
+	s.dbDeletionMux.Lock()
+	defer s.dbDeletionMux.Unlock()
+
+	db, err := s.dbList.GetByName(req.DatabaseName)
+	if err != nil {
+		return nil, err
+	}
+
+	current := db.Settings()
+
+	if req.MaxKeyLen > 0 && req.MaxKeyLen != current.MaxKeyLen {
+		return nil, errInvalidDatabaseSettings("max key length can only be set when the database is created")
+	}
+
+	if req.MaxTxEntries > 0 && req.MaxTxEntries != current.MaxTxEntries {
+		return nil, errInvalidDatabaseSettings("max number of entries per tx can only be set when the database is created")
+	}
+
+	if req.MaxValueLen == 0 || req.MaxValueLen == current.MaxValueLen {
+		return &empty.Empty{}, nil
+	}
+
+	if req.MaxValueLen < current.MaxValueLen {
+		return nil, errInvalidDatabaseSettings("max value length can only be increased")
+	}
+
+	storeOpts, err := withDatabaseSettings(db.GetOptions().GetStoreOptions(), &schema.DatabaseSettings{MaxValueLen: req.MaxValueLen})
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.writeDatabaseSettings(s.OS.Join(s.Options.Dir, req.DatabaseName), &persistedDatabaseSettings{MaxValueLen: int(req.MaxValueLen)})
+	if err != nil {
+		return nil, logErr(s.Logger, "Unable to store database settings: %v", err)
+	}
+
+	err = db.Close()
+	if err != nil {
+		return nil, logErr(s.Logger, "Unable to close database: %v", err)
+	}
+
+	newDb, err := database.OpenDb(db.GetOptions().WithStoreOptions(storeOpts), s.sysDb, s.Logger)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.dbList.Replace(newDb)
+	if err != nil {
+		return nil, err
+	}
+
+	s.Logger.Infof("Max value length of database '%s' set to %d", req.DatabaseName, req.MaxValueLen)
+
+	return &empty.Empty{}, nil
+}
+
+// GetDatabaseSettings returns the limits enforced by the database in use,
+// so payloads can be validated before being sent
+func (s *ImmuServer) GetDatabaseSettings(ctx context.Context, _ *empty.Empty) (*schema.DatabaseSettings, error) {
+	s.Logger.Debugf("GetDatabaseSettings")
+
+	db, err := s.getDbFromCtx(ctx, "GetDatabaseSettings")
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbList.Release(db)
+
+	return db.Settings(), nil
+}
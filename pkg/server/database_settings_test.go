@@ -0,0 +1,138 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	"github.com/codenotary/immudb/pkg/database"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestServerDatabaseSettings(t *testing.T) {
+	dir := "data_database_settings"
+	defer os.RemoveAll(dir)
+
+	serverOptions := DefaultOptions().
+		WithDir(dir).
+		WithListener(bufconn.Listen(1024 * 1024)).
+		WithMetricsServer(false).
+		WithAdminPassword(auth.SysAdminPassword)
+
+	newServer := func() (*ImmuServer, context.Context) {
+		s := DefaultServer().WithOptions(serverOptions).(*ImmuServer)
+
+		err := s.Initialize()
+		require.NoError(t, err)
+
+		lr, err := s.Login(context.Background(), &schema.LoginRequest{
+			User:     []byte(auth.SysAdminUsername),
+			Password: []byte(auth.SysAdminPassword),
+		})
+		require.NoError(t, err)
+
+		return s, metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", lr.Token))
+	}
+
+	s, ctx := newServer()
+
+	_, err := s.CreateDatabaseWith(ctx, nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = s.CreateDatabaseWith(ctx, &schema.DatabaseSettings{DatabaseName: "db1", MaxKeyLen: 8})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = s.CreateDatabaseWith(ctx, &schema.DatabaseSettings{DatabaseName: "db1", MaxKeyLen: store.MaxKeyLen + 1})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = s.CreateDatabaseWith(ctx, &schema.DatabaseSettings{DatabaseName: "db1", MaxTxEntries: store.MaxTxEntries + 1})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = s.CreateDatabaseWith(ctx, &schema.DatabaseSettings{
+		DatabaseName: "db1",
+		MaxKeyLen:    128,
+		MaxValueLen:  16,
+		MaxTxEntries: 2,
+	})
+	require.NoError(t, err)
+
+	ur, err := s.UseDatabase(ctx, &schema.Database{DatabaseName: "db1"})
+	require.NoError(t, err)
+
+	dbCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", ur.Token))
+
+	settings, err := s.GetDatabaseSettings(dbCtx, nil)
+	require.NoError(t, err)
+	require.Equal(t, "db1", settings.DatabaseName)
+	require.Equal(t, uint32(128), settings.MaxKeyLen)
+	require.Equal(t, uint32(16), settings.MaxValueLen)
+	require.Equal(t, uint32(2), settings.MaxTxEntries)
+
+	_, err = s.Set(dbCtx, &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key1"), Value: make([]byte, 17)}}})
+	require.Equal(t, codes.InvalidArgument, status.Code(mapServerError(err)))
+
+	_, err = s.UpdateDatabase(ctx, nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = s.UpdateDatabase(ctx, &schema.DatabaseSettings{DatabaseName: SystemdbName, MaxValueLen: 32})
+	require.Equal(t, ErrSystemDatabaseUpdate, err)
+
+	_, err = s.UpdateDatabase(ctx, &schema.DatabaseSettings{DatabaseName: "db2", MaxValueLen: 32})
+	require.Equal(t, database.ErrDatabaseNotExists, err)
+
+	_, err = s.UpdateDatabase(ctx, &schema.DatabaseSettings{DatabaseName: "db1", MaxKeyLen: 256})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = s.UpdateDatabase(ctx, &schema.DatabaseSettings{DatabaseName: "db1", MaxTxEntries: 4})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = s.UpdateDatabase(ctx, &schema.DatabaseSettings{DatabaseName: "db1", MaxValueLen: 8})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = s.UpdateDatabase(ctx, &schema.DatabaseSettings{DatabaseName: "db1", MaxKeyLen: 128, MaxValueLen: 32})
+	require.NoError(t, err)
+
+	_, err = s.Set(dbCtx, &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key1"), Value: make([]byte, 17)}}})
+	require.NoError(t, err)
+
+	err = s.CloseDatabases()
+	require.NoError(t, err)
+
+	// the increased max value length is kept once the database gets loaded again
+	s, ctx = newServer()
+	defer s.CloseDatabases()
+
+	ur, err = s.UseDatabase(ctx, &schema.Database{DatabaseName: "db1"})
+	require.NoError(t, err)
+
+	dbCtx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", ur.Token))
+
+	settings, err = s.GetDatabaseSettings(dbCtx, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint32(128), settings.MaxKeyLen)
+	require.Equal(t, uint32(32), settings.MaxValueLen)
+	require.Equal(t, uint32(2), settings.MaxTxEntries)
+}
@@ -0,0 +1,337 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/database"
+	"github.com/golang/protobuf/ptypes/empty"
+)
+
+// deletedDatabasesDir is the folder, inside the data dir, where deleted databases are moved to.
+// Database names can not start with a dot so it can't clash with a regular database.
+const deletedDatabasesDir = ".deleted"
+
+// deletedDatabaseDrainTimeout is the time given to the requests using a deleted database to complete,
+// the database is closed afterwards even if long-lived ones, such as subscriptions, are still using it
+const deletedDatabaseDrainTimeout = 5 * time.Second
+
+type deletedDatabase struct {
+	name      string
+	deletedAt time.Time
+	dir       string
+}
+
+// deletedDatabaseDirName returns the folder name of a deleted database, the deletion time is part of it
+// so that the same name can be deleted more than once.
+func deletedDatabaseDirName(dbname string, deletedAt time.Time) string {
+	return fmt.Sprintf("%s.%d", dbname, deletedAt.Unix())
+}
+
+func deletedDatabaseFrom(dirName string) (*deletedDatabase, bool) {
+	i := strings.LastIndex(dirName, ".")
+	if i <= 0 {
+		return nil, false
+	}
+
+	deletedAt, err := strconv.ParseInt(dirName[i+1:], 10, 64)
+	if err != nil {
+		return nil, false
+	}
+
+	return &deletedDatabase{
+		name:      dirName[:i],
+		deletedAt: time.Unix(deletedAt, 0),
+		dir:       dirName,
+	}, true
+}
+
+func (s *ImmuServer) deletedDatabasesPath() string {
+	return s.OS.Join(s.Options.Dir, deletedDatabasesDir)
+}
+
+// purgeAt returns the time at which a deleted database will be purged, or false if it's retained forever
+func (s *ImmuServer) purgeAt(ddb *deletedDatabase) (time.Time, bool) {
+	if s.Options.DeletedDatabasesRetention <= 0 {
+		return time.Time{}, false
+	}
+
+	return ddb.deletedAt.Add(s.Options.DeletedDatabasesRetention), true
+}
+
+// deletedDatabases returns deleted databases sorted by name and deletion time
+func (s *ImmuServer) deletedDatabases() ([]*deletedDatabase, error) {
+	files, err := ioutil.ReadDir(s.deletedDatabasesPath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var ddbs []*deletedDatabase
+
+	for _, f := range files {
+		if !f.IsDir() {
+			continue
+		}
+
+		ddb, ok := deletedDatabaseFrom(f.Name())
+		if !ok {
+			s.Logger.Warningf("Unexpected folder '%s' found among deleted databases", f.Name())
+			continue
+		}
+
+		ddbs = append(ddbs, ddb)
+	}
+
+	sort.Slice(ddbs, func(i, j int) bool {
+		if ddbs[i].name == ddbs[j].name {
+			return ddbs[i].deletedAt.Before(ddbs[j].deletedAt)
+		}
+		return ddbs[i].name < ddbs[j].name
+	})
+
+	return ddbs, nil
+}
+
+// lastDeletedDatabase returns the most recently deleted database with the given name
+func (s *ImmuServer) lastDeletedDatabase(dbname string) (*deletedDatabase, error) {
+	ddbs, err := s.deletedDatabases()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(ddbs) - 1; i >= 0; i-- {
+		if ddbs[i].name == dbname {
+			return ddbs[i], nil
+		}
+	}
+
+	return nil, ErrNoDeletedDatabase
+}
+
+// purgeDeletedDatabases permanently removes deleted databases whose retention period is over
+func (s *ImmuServer) purgeDeletedDatabases() error {
+	ddbs, err := s.deletedDatabases()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	for _, ddb := range ddbs {
+		purgeAt, purgeable := s.purgeAt(ddb)
+		if !purgeable || purgeAt.After(now) {
+			continue
+		}
+
+		s.Logger.Infof("Purging database '%s' deleted at %v", ddb.name, ddb.deletedAt)
+
+		err = s.OS.RemoveAll(s.OS.Join(s.deletedDatabasesPath(), ddb.dir))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *ImmuServer) checkSysAdmin(ctx context.Context) error {
+	if !s.Options.GetAuth() {
+		return fmt.Errorf("this command is available only with authentication on")
+	}
+
+	_, user, err := s.getLoggedInUserdataFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf("could not get loggedin user data")
+	}
+
+	if !user.IsSysAdmin {
+		return fmt.Errorf("Logged In user does not have permissions for this operation")
+	}
+
+	return nil
+}
+
+// DeleteDatabase closes the database and moves it among deleted databases,
+// where it's kept during the configured retention period
+func (s *ImmuServer) DeleteDatabase(ctx context.Context, req *schema.Database) (*empty.Empty, error) {
+	s.Logger.Debugf("DeleteDatabase %+v", req)
+
+	if req == nil {
+		return nil, ErrIllegalArguments
+	}
+
+	err := s.checkSysAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.Options.hasSeparateDirs() {
+		return nil, ErrSeparateDirs
+	}
+
+	if req.DatabaseName == SystemdbName || req.DatabaseName == s.Options.GetDefaultDbName() {
+		return nil, ErrReservedDatabase
+	}
+
+	s.dbDeletionMux.Lock()
+	defer s.dbDeletionMux.Unlock()
+
+	deletedDir := s.OS.Join(s.deletedDatabasesPath(), deletedDatabaseDirName(req.DatabaseName, time.Now()))
+
+	if _, err := s.OS.Stat(deletedDir); err == nil {
+		return nil, fmt.Errorf("database %s was just deleted, please retry", req.DatabaseName)
+	}
+
+	db, err := s.dbList.Delete(req.DatabaseName)
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-s.dbList.Drained(db):
+	case <-time.After(deletedDatabaseDrainTimeout):
+		s.Logger.Warningf("Closing database '%s' while still in use", req.DatabaseName)
+	}
+
+	err = db.Close()
+	if err != nil {
+		return nil, logErr(s.Logger, "Unable to close database: %v", err)
+	}
+
+	err = s.OS.MkdirAll(s.deletedDatabasesPath(), 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.OS.Rename(s.OS.Join(s.Options.Dir, req.DatabaseName), deletedDir)
+	if err != nil {
+		return nil, logErr(s.Logger, "Unable to move deleted database: %v", err)
+	}
+
+	s.Logger.Infof("Database '%s' successfully deleted", req.DatabaseName)
+
+	err = s.purgeDeletedDatabases()
+	logErr(s.Logger, "Unable to purge deleted databases: %v", err)
+
+	return &empty.Empty{}, nil
+}
+
+// UndeleteDatabase restores the most recently deleted database with the given name
+func (s *ImmuServer) UndeleteDatabase(ctx context.Context, req *schema.Database) (*empty.Empty, error) {
+	s.Logger.Debugf("UndeleteDatabase %+v", req)
+
+	if req == nil {
+		return nil, ErrIllegalArguments
+	}
+
+	err := s.checkSysAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.Options.hasSeparateDirs() {
+		return nil, ErrSeparateDirs
+	}
+
+	s.dbDeletionMux.Lock()
+	defer s.dbDeletionMux.Unlock()
+
+	if s.dbList.GetId(req.DatabaseName) >= 0 {
+		return nil, fmt.Errorf("database %s already exists", req.DatabaseName)
+	}
+
+	ddb, err := s.lastDeletedDatabase(req.DatabaseName)
+	if err != nil {
+		return nil, err
+	}
+
+	storeOpts, err := s.storeOptionsFor(req.DatabaseName, s.Options.StoreOptions, false)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.OS.Rename(
+		s.OS.Join(s.deletedDatabasesPath(), ddb.dir),
+		s.OS.Join(s.Options.Dir, req.DatabaseName),
+	)
+	if err != nil {
+		return nil, logErr(s.Logger, "Unable to restore deleted database: %v", err)
+	}
+
+	op := database.DefaultOption().
+		WithDbName(req.DatabaseName).
+		WithDbRootPath(s.Options.Dir).
+		WithStoreOptions(storeOpts).
+		WithReplica(s.Options.IsReplica()).
+		WithSQLRetries(s.Options.SQLMaxRetries, s.Options.SQLRetryBackoff)
+
+	db, err := database.OpenDb(op, s.sysDb, s.Logger)
+	if err != nil {
+		return nil, err
+	}
+
+	s.dbList.Append(db)
+	s.multidbmode = true
+
+	s.Logger.Infof("Database '%s' successfully restored", req.DatabaseName)
+
+	return &empty.Empty{}, nil
+}
+
+// DeletedDatabaseList returns the deleted databases which can still be restored
+func (s *ImmuServer) DeletedDatabaseList(ctx context.Context, req *empty.Empty) (*schema.DeletedDatabaseListResponse, error) {
+	s.Logger.Debugf("DeletedDatabaseList")
+
+	err := s.checkSysAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ddbs, err := s.deletedDatabases()
+	if err != nil {
+		return nil, err
+	}
+
+	res := &schema.DeletedDatabaseListResponse{}
+
+	for _, ddb := range ddbs {
+		d := &schema.DeletedDatabase{
+			DatabaseName: ddb.name,
+			DeletedAt:    ddb.deletedAt.Unix(),
+		}
+
+		if purgeAt, purgeable := s.purgeAt(ddb); purgeable {
+			d.PurgeAt = purgeAt.Unix()
+		}
+
+		res.Databases = append(res.Databases, d)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,316 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	"github.com/codenotary/immudb/pkg/database"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestDeletedDatabaseDirName(t *testing.T) {
+	deletedAt := time.Unix(1630000000, 0)
+
+	ddb, ok := deletedDatabaseFrom(deletedDatabaseDirName("db1", deletedAt))
+	require.True(t, ok)
+	require.Equal(t, "db1", ddb.name)
+	require.Equal(t, deletedAt, ddb.deletedAt)
+
+	_, ok = deletedDatabaseFrom("db1")
+	require.False(t, ok)
+
+	_, ok = deletedDatabaseFrom(".1630000000")
+	require.False(t, ok)
+
+	_, ok = deletedDatabaseFrom("db1.abc")
+	require.False(t, ok)
+}
+
+func TestServerDeleteUndeleteDatabase(t *testing.T) {
+	dir := "data_deleted_databases"
+	defer os.RemoveAll(dir)
+
+	serverOptions := DefaultOptions().
+		WithDir(dir).
+		WithListener(bufconn.Listen(1024 * 1024)).
+		WithMetricsServer(false).
+		WithAdminPassword(auth.SysAdminPassword)
+
+	s := DefaultServer().WithOptions(serverOptions).(*ImmuServer)
+
+	err := s.Initialize()
+	require.NoError(t, err)
+
+	lr, err := s.Login(context.Background(), &schema.LoginRequest{
+		User:     []byte(auth.SysAdminUsername),
+		Password: []byte(auth.SysAdminPassword),
+	})
+	require.NoError(t, err)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", lr.Token))
+
+	_, err = s.DeleteDatabase(ctx, nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = s.DeleteDatabase(ctx, &schema.Database{DatabaseName: DefaultdbName})
+	require.Equal(t, ErrReservedDatabase, err)
+
+	_, err = s.DeleteDatabase(ctx, &schema.Database{DatabaseName: SystemdbName})
+	require.Equal(t, ErrReservedDatabase, err)
+
+	_, err = s.DeleteDatabase(ctx, &schema.Database{DatabaseName: "db1"})
+	require.Equal(t, database.ErrDatabaseNotExists, err)
+
+	_, err = s.UndeleteDatabase(ctx, &schema.Database{DatabaseName: "db1"})
+	require.Equal(t, ErrNoDeletedDatabase, err)
+
+	_, err = s.CreateDatabase(ctx, &schema.Database{DatabaseName: "db1"})
+	require.NoError(t, err)
+
+	ur, err := s.UseDatabase(ctx, &schema.Database{DatabaseName: "db1"})
+	require.NoError(t, err)
+
+	dbCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", ur.Token))
+
+	_, err = s.Set(dbCtx, &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key1"), Value: []byte("value1")}}})
+	require.NoError(t, err)
+
+	_, err = s.DeleteDatabase(ctx, &schema.Database{DatabaseName: "db1"})
+	require.NoError(t, err)
+
+	_, err = s.Get(dbCtx, &schema.KeyRequest{Key: []byte("key1")})
+	require.Equal(t, ErrDatabaseDeleted, err)
+
+	_, err = s.ListUsers(dbCtx, &emptypb.Empty{})
+	require.Equal(t, ErrDatabaseDeleted, err)
+
+	_, err = s.UseDatabase(ctx, &schema.Database{DatabaseName: "db1"})
+	require.Error(t, err)
+
+	dbs, err := s.DatabaseList(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	for _, db := range dbs.Databases {
+		require.NotEqual(t, "db1", db.DatabaseName)
+	}
+
+	ddbs, err := s.DeletedDatabaseList(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	require.Len(t, ddbs.Databases, 1)
+	require.Equal(t, "db1", ddbs.Databases[0].DatabaseName)
+	require.Equal(t, ddbs.Databases[0].DeletedAt+int64(DefaultDeletedDatabasesRetention/time.Second), ddbs.Databases[0].PurgeAt)
+
+	_, err = s.CreateDatabase(ctx, &schema.Database{DatabaseName: "db1"})
+	require.Error(t, err)
+
+	_, err = s.UndeleteDatabase(ctx, &schema.Database{DatabaseName: "db1"})
+	require.NoError(t, err)
+
+	_, err = s.UndeleteDatabase(ctx, &schema.Database{DatabaseName: "db1"})
+	require.Error(t, err)
+
+	ur, err = s.UseDatabase(ctx, &schema.Database{DatabaseName: "db1"})
+	require.NoError(t, err)
+
+	dbCtx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", ur.Token))
+
+	entry, err := s.Get(dbCtx, &schema.KeyRequest{Key: []byte("key1")})
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1"), entry.Value)
+
+	ddbs, err = s.DeletedDatabaseList(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	require.Empty(t, ddbs.Databases)
+
+	err = s.CloseDatabases()
+	require.NoError(t, err)
+}
+
+func TestServerDeleteDatabaseWhileInUse(t *testing.T) {
+	dir := "data_deleted_databases_in_use"
+	defer os.RemoveAll(dir)
+
+	serverOptions := DefaultOptions().
+		WithDir(dir).
+		WithListener(bufconn.Listen(1024 * 1024)).
+		WithMetricsServer(false).
+		WithAdminPassword(auth.SysAdminPassword)
+
+	s := DefaultServer().WithOptions(serverOptions).(*ImmuServer)
+
+	err := s.Initialize()
+	require.NoError(t, err)
+
+	defer s.CloseDatabases()
+
+	lr, err := s.Login(context.Background(), &schema.LoginRequest{
+		User:     []byte(auth.SysAdminUsername),
+		Password: []byte(auth.SysAdminPassword),
+	})
+	require.NoError(t, err)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", lr.Token))
+
+	_, err = s.CreateDatabase(ctx, &schema.Database{DatabaseName: "db1"})
+	require.NoError(t, err)
+
+	ur, err := s.UseDatabase(ctx, &schema.Database{DatabaseName: "db1"})
+	require.NoError(t, err)
+
+	dbCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", ur.Token))
+
+	_, err = s.Set(dbCtx, &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key1"), Value: []byte("value1")}}})
+	require.NoError(t, err)
+
+	t.Run("deletion waits for in-flight requests", func(t *testing.T) {
+		db, err := s.getDbFromCtx(dbCtx, "Get")
+		require.NoError(t, err)
+
+		deleted := make(chan error, 1)
+
+		go func() {
+			_, err := s.DeleteDatabase(ctx, &schema.Database{DatabaseName: "db1"})
+			deleted <- err
+		}()
+
+		select {
+		case err := <-deleted:
+			require.Fail(t, "database deleted while still in use", err)
+		case <-time.After(100 * time.Millisecond):
+		}
+
+		entry, err := db.Get(context.Background(), &schema.KeyRequest{Key: []byte("key1")})
+		require.NoError(t, err)
+		require.Equal(t, []byte("value1"), entry.Value)
+
+		s.dbList.Release(db)
+
+		require.NoError(t, <-deleted)
+
+		_, err = s.Get(dbCtx, &schema.KeyRequest{Key: []byte("key1")})
+		require.Equal(t, ErrDatabaseDeleted, err)
+	})
+
+	_, err = s.UndeleteDatabase(ctx, &schema.Database{DatabaseName: "db1"})
+	require.NoError(t, err)
+
+	ur, err = s.UseDatabase(ctx, &schema.Database{DatabaseName: "db1"})
+	require.NoError(t, err)
+
+	dbCtx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", ur.Token))
+
+	t.Run("concurrent reads and writes during deletion", func(t *testing.T) {
+		var wg sync.WaitGroup
+		done := make(chan struct{})
+
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+
+			go func(i int) {
+				defer wg.Done()
+
+				for {
+					select {
+					case <-done:
+						return
+					default:
+					}
+
+					key := []byte(fmt.Sprintf("key%d", i))
+
+					_, err := s.Set(dbCtx, &schema.SetRequest{KVs: []*schema.KeyValue{{Key: key, Value: key}}})
+					if err != nil {
+						require.Equal(t, ErrDatabaseDeleted, err)
+						return
+					}
+
+					_, err = s.Get(dbCtx, &schema.KeyRequest{Key: []byte("key1")})
+					if err != nil {
+						require.Equal(t, ErrDatabaseDeleted, err)
+						return
+					}
+				}
+			}(i)
+		}
+
+		time.Sleep(50 * time.Millisecond)
+
+		_, err := s.DeleteDatabase(ctx, &schema.Database{DatabaseName: "db1"})
+		require.NoError(t, err)
+
+		close(done)
+		wg.Wait()
+
+		_, err = s.Get(dbCtx, &schema.KeyRequest{Key: []byte("key1")})
+		require.Equal(t, ErrDatabaseDeleted, err)
+	})
+}
+
+func TestServerPurgeDeletedDatabases(t *testing.T) {
+	dir := "data_purge_deleted_databases"
+	defer os.RemoveAll(dir)
+
+	serverOptions := DefaultOptions().
+		WithDir(dir).
+		WithListener(bufconn.Listen(1024 * 1024)).
+		WithMetricsServer(false).
+		WithAdminPassword(auth.SysAdminPassword).
+		WithDeletedDatabasesRetention(0)
+
+	s := DefaultServer().WithOptions(serverOptions).(*ImmuServer)
+
+	deletedDir := filepath.Join(dir, deletedDatabasesDir)
+
+	oldDeletion := filepath.Join(deletedDir, deletedDatabaseDirName("db1", time.Now().Add(-2*time.Hour)))
+	recentDeletion := filepath.Join(deletedDir, deletedDatabaseDirName("db2", time.Now()))
+
+	require.NoError(t, os.MkdirAll(oldDeletion, 0755))
+	require.NoError(t, os.MkdirAll(recentDeletion, 0755))
+
+	err := s.Initialize()
+	require.NoError(t, err)
+	defer s.CloseDatabases()
+
+	// deleted databases must not be loaded as regular databases
+	require.Equal(t, int64(-1), s.dbList.GetId(deletedDatabasesDir))
+
+	ddbs, err := s.deletedDatabases()
+	require.NoError(t, err)
+	require.Len(t, ddbs, 2)
+
+	s.Options.WithDeletedDatabasesRetention(time.Hour)
+
+	err = s.purgeDeletedDatabases()
+	require.NoError(t, err)
+
+	_, err = os.Stat(oldDeletion)
+	require.True(t, os.IsNotExist(err))
+
+	_, err = os.Stat(recentDeletion)
+	require.NoError(t, err)
+}
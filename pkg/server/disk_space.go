@@ -0,0 +1,34 @@
+// +build linux darwin freebsd
+
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import "syscall"
+
+// freeDiskSpace returns the number of bytes available to the process in the filesystem holding the folder,
+// 0 when it can't be determined
+func freeDiskSpace(dir string) uint64 {
+	var st syscall.Statfs_t
+
+	err := syscall.Statfs(dir, &st)
+	if err != nil {
+		return 0
+	}
+
+	return uint64(st.Bavail) * uint64(st.Bsize)
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/golang/protobuf/ptypes/empty"
+)
+
+// CreateCollection creates a collection of JSON documents indexed by the given fields
+func (s *ImmuServer) CreateCollection(ctx context.Context, req *schema.Collection) (*schema.TxMetadata, error) {
+	if !s.Options.IsFeatureEnabled(FeatureDocumentAPI) {
+		return nil, ErrFeatureDisabled
+	}
+
+	db, err := s.getDbFromCtx(ctx, "CreateCollection")
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbList.Release(db)
+
+	return db.CreateCollection(ctx, req)
+}
+
+// ListCollections returns the collections of the database
+func (s *ImmuServer) ListCollections(ctx context.Context, _ *empty.Empty) (*schema.CollectionList, error) {
+	if !s.Options.IsFeatureEnabled(FeatureDocumentAPI) {
+		return nil, ErrFeatureDisabled
+	}
+
+	db, err := s.getDbFromCtx(ctx, "ListCollections")
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbList.Release(db)
+
+	return db.ListCollections(ctx)
+}
+
+// InsertDocuments inserts documents into a collection within the same tx
+func (s *ImmuServer) InsertDocuments(ctx context.Context, req *schema.DocumentInsertRequest) (*schema.DocumentInsertResponse, error) {
+	if !s.Options.IsFeatureEnabled(FeatureDocumentAPI) {
+		return nil, ErrFeatureDisabled
+	}
+
+	db, err := s.getDbFromCtx(ctx, "InsertDocuments")
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbList.Release(db)
+
+	if req != nil {
+		req.TraceID = s.traceIDFor(ctx, req.TraceID)
+	}
+
+	return db.InsertDocuments(ctx, req)
+}
+
+// GetDocument returns the latest version of a document
+func (s *ImmuServer) GetDocument(ctx context.Context, req *schema.DocumentGetRequest) (*schema.Document, error) {
+	if !s.Options.IsFeatureEnabled(FeatureDocumentAPI) {
+		return nil, ErrFeatureDisabled
+	}
+
+	db, err := s.getDbFromCtx(ctx, "GetDocument")
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbList.Release(db)
+
+	return db.GetDocument(ctx, req)
+}
+
+// SearchDocuments returns the documents of a collection matching all the filters
+func (s *ImmuServer) SearchDocuments(ctx context.Context, req *schema.DocumentSearchRequest) (*schema.DocumentList, error) {
+	if !s.Options.IsFeatureEnabled(FeatureDocumentAPI) {
+		return nil, ErrFeatureDisabled
+	}
+
+	db, err := s.getDbFromCtx(ctx, "SearchDocuments")
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbList.Release(db)
+
+	return db.SearchDocuments(ctx, req)
+}
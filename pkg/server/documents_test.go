@@ -0,0 +1,101 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestServerDocuments(t *testing.T) {
+	dir := "data_documents"
+	defer os.RemoveAll(dir)
+
+	serverOptions := DefaultOptions().
+		WithDir(dir).
+		WithListener(bufconn.Listen(1024 * 1024)).
+		WithMetricsServer(false).
+		WithAdminPassword(auth.SysAdminPassword)
+
+	s := DefaultServer().WithOptions(serverOptions).(*ImmuServer)
+
+	err := s.Initialize()
+	require.NoError(t, err)
+	defer s.CloseDatabases()
+
+	lr, err := s.Login(context.Background(), &schema.LoginRequest{
+		User:     []byte(auth.SysAdminUsername),
+		Password: []byte(auth.SysAdminPassword),
+	})
+	require.NoError(t, err)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", lr.Token))
+
+	doc, err := structpb.NewStruct(map[string]interface{}{"_id": "d1", "title": "immudb"})
+	require.NoError(t, err)
+
+	_, err = s.CreateCollection(ctx, &schema.Collection{Name: "docs"})
+	require.Equal(t, ErrFeatureDisabled, err)
+
+	_, err = s.ListCollections(ctx, &empty.Empty{})
+	require.Equal(t, ErrFeatureDisabled, err)
+
+	_, err = s.InsertDocuments(ctx, &schema.DocumentInsertRequest{Collection: "docs", Documents: []*structpb.Struct{doc}})
+	require.Equal(t, ErrFeatureDisabled, err)
+
+	_, err = s.GetDocument(ctx, &schema.DocumentGetRequest{Collection: "docs", Id: "d1"})
+	require.Equal(t, ErrFeatureDisabled, err)
+
+	_, err = s.SearchDocuments(ctx, &schema.DocumentSearchRequest{Collection: "docs"})
+	require.Equal(t, ErrFeatureDisabled, err)
+
+	s.Options.WithFeatures(string(FeatureDocumentAPI))
+
+	_, err = s.CreateCollection(ctx, &schema.Collection{Name: "docs", IndexedFields: []string{"title"}})
+	require.NoError(t, err)
+
+	collections, err := s.ListCollections(ctx, &empty.Empty{})
+	require.NoError(t, err)
+	require.Len(t, collections.Collections, 1)
+
+	res, err := s.InsertDocuments(ctx, &schema.DocumentInsertRequest{Collection: "docs", Documents: []*structpb.Struct{doc}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"d1"}, res.Ids)
+
+	d, err := s.GetDocument(ctx, &schema.DocumentGetRequest{Collection: "docs", Id: "d1"})
+	require.NoError(t, err)
+	require.Equal(t, res.TxMetadata.Id, d.Tx)
+	require.Equal(t, "immudb", d.Document.Fields["title"].GetStringValue())
+
+	docs, err := s.SearchDocuments(ctx, &schema.DocumentSearchRequest{
+		Collection: "docs",
+		Filters: []*schema.DocumentFilter{
+			{Field: "title", Operator: schema.DocumentFilterOperator_EQ, Value: structpb.NewStringValue("immudb")},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, docs.Documents, 1)
+}
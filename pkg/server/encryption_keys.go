@@ -0,0 +1,281 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/golang/protobuf/ptypes/empty"
+)
+
+const encryptionKeySize = 32 // AES-256
+const encryptionKeyFileExt = ".key"
+const wrappedEncryptionKeyFileExt = ".wkey"
+
+func (s *ImmuServer) encryptionKeyPath(dbname string) string {
+	return s.OS.Join(s.Options.EncryptionKeysDir, dbname+encryptionKeyFileExt)
+}
+
+func (s *ImmuServer) wrappedEncryptionKeyPath(dbname string) string {
+	return s.OS.Join(s.Options.EncryptionKeysDir, dbname+wrappedEncryptionKeyFileExt)
+}
+
+// encryptionKey returns the key stored for the database, or nil if there is none.
+// A new key is generated when create is set and the database has no key yet.
+// When a key provider is configured keys are stored wrapped with its master key,
+// plain keys stored before the provider was configured get wrapped on first use.
+func (s *ImmuServer) encryptionKey(dbname string, create bool) ([]byte, error) {
+	if s.Options.EncryptionKeysDir == "" {
+		return nil, nil
+	}
+
+	s.encryptionKeysMux.Lock()
+	defer s.encryptionKeysMux.Unlock()
+
+	wrappedKeyPath := s.wrappedEncryptionKeyPath(dbname)
+
+	wrappedKey, err := readHexFile(wrappedKeyPath)
+	if err == nil {
+		if s.keyProvider == nil {
+			return nil, fmt.Errorf("%w: '%s' is wrapped but no key provider is configured", ErrInvalidEncryptionKey, wrappedKeyPath)
+		}
+
+		key, err := s.keyProvider.UnwrapKey(wrappedKey)
+		if err != nil {
+			return nil, fmt.Errorf("%w: '%s': %v", ErrInvalidEncryptionKey, wrappedKeyPath, err)
+		}
+
+		return key, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	keyPath := s.encryptionKeyPath(dbname)
+
+	key, err := readHexFile(keyPath)
+	if err == nil {
+		if s.keyProvider == nil {
+			return key, nil
+		}
+
+		err = s.storeWrappedKey(dbname, key)
+		if err != nil {
+			return nil, err
+		}
+
+		err = os.Remove(keyPath)
+		if err != nil {
+			return nil, err
+		}
+
+		s.Logger.Infof("Encryption key of database '%s' wrapped into '%s'", dbname, wrappedKeyPath)
+
+		return key, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	if !create {
+		return nil, nil
+	}
+
+	key = make([]byte, encryptionKeySize)
+
+	_, err = rand.Read(key)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.OS.MkdirAll(s.Options.EncryptionKeysDir, 0700)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.keyProvider != nil {
+		err = s.storeWrappedKey(dbname, key)
+		if err != nil {
+			return nil, err
+		}
+
+		s.Logger.Infof("Encryption key of database '%s' stored wrapped at '%s'", dbname, wrappedKeyPath)
+
+		return key, nil
+	}
+
+	err = ioutil.WriteFile(keyPath, []byte(hex.EncodeToString(key)), 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	s.Logger.Infof("Encryption key of database '%s' stored at '%s'", dbname, keyPath)
+
+	return key, nil
+}
+
+// storeWrappedKey wraps the key with the current master key of the provider,
+// the previous wrapped key, if any, is atomically replaced
+func (s *ImmuServer) storeWrappedKey(dbname string, key []byte) error {
+	wrappedKey, err := s.keyProvider.WrapKey(key)
+	if err != nil {
+		return err
+	}
+
+	wrappedKeyPath := s.wrappedEncryptionKeyPath(dbname)
+	tmpPath := wrappedKeyPath + ".tmp"
+
+	err = ioutil.WriteFile(tmpPath, []byte(hex.EncodeToString(wrappedKey)), 0600)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, wrappedKeyPath)
+}
+
+func readHexFile(path string) ([]byte, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := hex.DecodeString(strings.TrimSpace(string(content)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: '%s'", ErrInvalidEncryptionKey, path)
+	}
+
+	return b, nil
+}
+
+// storeOptionsFor returns the store options of the database, including its own encryption key if it has one,
+// the handler reporting the inconsistencies detected in its data and the folders its files are placed into
+func (s *ImmuServer) storeOptionsFor(dbname string, storeOpts *store.Options, create bool) (*store.Options, error) {
+	key, err := s.encryptionKey(dbname, create)
+	if err != nil {
+		return nil, err
+	}
+
+	dbStoreOpts := *storeOpts
+	dbStoreOpts.OnCorruption = s.corruptionHandler(dbname)
+
+	settings, err := s.readDatabaseSettings(s.OS.Join(s.Options.Dir, dbname))
+	if err != nil {
+		return nil, err
+	}
+	if settings.MaxValueLen > 0 {
+		dbStoreOpts.MaxValueLen = settings.MaxValueLen
+	}
+
+	if s.Options.TxLogDir != "" {
+		dbStoreOpts.TxLogDir = s.OS.Join(s.Options.TxLogDir, dbname)
+	}
+	if s.Options.ValueLogDir != "" {
+		dbStoreOpts.ValueLogDir = s.OS.Join(s.Options.ValueLogDir, dbname)
+	}
+	if s.Options.IndexDir != "" {
+		dbStoreOpts.IndexDir = s.OS.Join(s.Options.IndexDir, dbname)
+	}
+
+	if s.Options.IsFeatureEnabled(FeatureIndexFormatV2) && dbStoreOpts.IndexOpts.BloomFilterBitsPerKey == 0 {
+		indexOpts := *dbStoreOpts.IndexOpts
+		indexOpts.BloomFilterBitsPerKey = indexBloomFilterBitsPerKey
+		dbStoreOpts.IndexOpts = &indexOpts
+	}
+
+	if key == nil {
+		return &dbStoreOpts, nil
+	}
+
+	return dbStoreOpts.WithEncryptionKey(key), nil
+}
+
+// RotateEncryptionKeys wraps the encryption key of every database with the current master key of the key provider.
+// Stored data is not rewritten as database keys are kept, previous master keys can be retired once it completes.
+func (s *ImmuServer) RotateEncryptionKeys(ctx context.Context, _ *empty.Empty) (*schema.RotateEncryptionKeysResponse, error) {
+	s.Logger.Debugf("RotateEncryptionKeys")
+
+	err := s.checkSysAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.keyProvider == nil {
+		return nil, fmt.Errorf("%w: no key provider is configured", ErrFeatureDisabled)
+	}
+
+	s.encryptionKeysMux.Lock()
+	defer s.encryptionKeysMux.Unlock()
+
+	files, err := ioutil.ReadDir(s.Options.EncryptionKeysDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var rotated uint32
+
+	for _, f := range files {
+		var key []byte
+		dbname := strings.TrimSuffix(f.Name(), filepath.Ext(f.Name()))
+
+		switch filepath.Ext(f.Name()) {
+		case wrappedEncryptionKeyFileExt:
+			wrappedKey, err := readHexFile(s.wrappedEncryptionKeyPath(dbname))
+			if err != nil {
+				return nil, err
+			}
+
+			key, err = s.keyProvider.UnwrapKey(wrappedKey)
+			if err != nil {
+				return nil, fmt.Errorf("%w: '%s': %v", ErrInvalidEncryptionKey, s.wrappedEncryptionKeyPath(dbname), err)
+			}
+		case encryptionKeyFileExt:
+			key, err = readHexFile(s.encryptionKeyPath(dbname))
+			if err != nil {
+				return nil, err
+			}
+		default:
+			continue
+		}
+
+		err = s.storeWrappedKey(dbname, key)
+		if err != nil {
+			return nil, err
+		}
+
+		if filepath.Ext(f.Name()) == encryptionKeyFileExt {
+			err = os.Remove(s.encryptionKeyPath(dbname))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		rotated++
+	}
+
+	s.Logger.Infof("%d encryption key(s) wrapped with the current master key", rotated)
+
+	return &schema.RotateEncryptionKeysResponse{Rotated: rotated}, nil
+}
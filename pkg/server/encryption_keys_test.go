@@ -0,0 +1,296 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	"github.com/codenotary/immudb/pkg/kms"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestServerPerDatabaseEncryption(t *testing.T) {
+	dir := "data_encrypted_databases"
+	keysDir := "data_encryption_keys"
+
+	defer os.RemoveAll(dir)
+	defer os.RemoveAll(keysDir)
+
+	newServer := func() *ImmuServer {
+		serverOptions := DefaultOptions().
+			WithDir(dir).
+			WithListener(bufconn.Listen(1024 * 1024)).
+			WithMetricsServer(false).
+			WithAdminPassword(auth.SysAdminPassword).
+			WithEncryptionKeysDir(keysDir)
+
+		return DefaultServer().WithOptions(serverOptions).(*ImmuServer)
+	}
+
+	s := newServer()
+
+	err := s.Initialize()
+	require.NoError(t, err)
+
+	lr, err := s.Login(context.Background(), &schema.LoginRequest{
+		User:     []byte(auth.SysAdminUsername),
+		Password: []byte(auth.SysAdminPassword),
+	})
+	require.NoError(t, err)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", lr.Token))
+
+	_, err = s.CreateDatabase(ctx, &schema.Database{DatabaseName: "db1"})
+	require.NoError(t, err)
+
+	_, err = s.CreateDatabase(ctx, &schema.Database{DatabaseName: "db2"})
+	require.NoError(t, err)
+
+	keys := make(map[string]string)
+
+	for _, dbname := range []string{SystemdbName, DefaultdbName, "db1", "db2"} {
+		key, err := s.encryptionKey(dbname, false)
+		require.NoError(t, err)
+		require.Len(t, key, encryptionKeySize)
+
+		keys[string(key)] = dbname
+	}
+
+	// every database has its own key
+	require.Len(t, keys, 4)
+
+	err = s.CloseDatabases()
+	require.NoError(t, err)
+
+	db1KeyPath := filepath.Join(keysDir, "db1"+encryptionKeyFileExt)
+
+	db1Key, err := ioutil.ReadFile(db1KeyPath)
+	require.NoError(t, err)
+
+	err = os.Remove(db1KeyPath)
+	require.NoError(t, err)
+
+	s = newServer()
+
+	err = s.Initialize()
+	require.Equal(t, store.ErrMissingEncryptionKey, err)
+
+	s.CloseDatabases()
+
+	err = ioutil.WriteFile(db1KeyPath, []byte("not an hex key"), 0600)
+	require.NoError(t, err)
+
+	s = newServer()
+
+	err = s.Initialize()
+	require.True(t, errors.Is(err, ErrInvalidEncryptionKey))
+
+	s.CloseDatabases()
+
+	err = ioutil.WriteFile(db1KeyPath, db1Key, 0600)
+	require.NoError(t, err)
+
+	s = newServer()
+
+	err = s.Initialize()
+	require.NoError(t, err)
+	require.True(t, s.dbList.GetId("db1") >= 0)
+
+	err = s.CloseDatabases()
+	require.NoError(t, err)
+}
+
+func TestServerEncryptionKeysDisabled(t *testing.T) {
+	s := DefaultServer().WithOptions(DefaultOptions()).(*ImmuServer)
+
+	key, err := s.encryptionKey("db1", true)
+	require.NoError(t, err)
+	require.Nil(t, key)
+
+	storeOpts := DefaultStoreOptions()
+
+	opts, err := s.storeOptionsFor("db1", storeOpts, true)
+	require.NoError(t, err)
+	require.Nil(t, opts.EncryptionKey)
+	require.Nil(t, storeOpts.OnCorruption)
+}
+
+func TestServerWrappedEncryptionKeys(t *testing.T) {
+	dir := "data_wrapped_keys_databases"
+	keysDir := "data_wrapped_keys"
+	masterKeysPath := "data_wrapped_keys_master.keys"
+
+	defer os.RemoveAll(dir)
+	defer os.RemoveAll(keysDir)
+	defer os.Remove(masterKeysPath)
+
+	masterKey1 := "000102030405060708090a0b0c0d0e0f000102030405060708090a0b0c0d0e0f"
+	masterKey2 := "0f0e0d0c0b0a09080706050403020100"
+
+	err := ioutil.WriteFile(masterKeysPath, []byte(masterKey1+"\n"), 0600)
+	require.NoError(t, err)
+
+	newServer := func(keyProvider string) *ImmuServer {
+		serverOptions := DefaultOptions().
+			WithDir(dir).
+			WithListener(bufconn.Listen(1024 * 1024)).
+			WithMetricsServer(false).
+			WithAdminPassword(auth.SysAdminPassword).
+			WithEncryptionKeysDir(keysDir).
+			WithEncryptionKeyProvider(keyProvider)
+
+		return DefaultServer().WithOptions(serverOptions).(*ImmuServer)
+	}
+
+	login := func(s *ImmuServer) context.Context {
+		lr, err := s.Login(context.Background(), &schema.LoginRequest{
+			User:     []byte(auth.SysAdminUsername),
+			Password: []byte(auth.SysAdminPassword),
+		})
+		require.NoError(t, err)
+
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", lr.Token))
+	}
+
+	// keys of databases created before the key provider was configured are stored in plain
+	s := newServer("")
+
+	err = s.Initialize()
+	require.NoError(t, err)
+
+	ctx := login(s)
+
+	_, err = s.RotateEncryptionKeys(ctx, &emptypb.Empty{})
+	require.True(t, errors.Is(err, ErrFeatureDisabled))
+
+	_, err = s.CreateDatabase(ctx, &schema.Database{DatabaseName: "db1"})
+	require.NoError(t, err)
+
+	db1Key, err := s.encryptionKey("db1", false)
+	require.NoError(t, err)
+
+	err = s.CloseDatabases()
+	require.NoError(t, err)
+
+	// plain keys get wrapped once the key provider is configured
+	s = newServer("file://" + masterKeysPath)
+
+	err = s.Initialize()
+	require.NoError(t, err)
+
+	ctx = login(s)
+
+	_, err = os.Stat(filepath.Join(keysDir, "db1"+encryptionKeyFileExt))
+	require.True(t, os.IsNotExist(err))
+
+	wrappedDb1Key, err := ioutil.ReadFile(filepath.Join(keysDir, "db1"+wrappedEncryptionKeyFileExt))
+	require.NoError(t, err)
+	require.NotContains(t, string(wrappedDb1Key), string(db1Key))
+
+	key, err := s.encryptionKey("db1", false)
+	require.NoError(t, err)
+	require.Equal(t, db1Key, key)
+
+	// new databases get wrapped keys
+	_, err = s.CreateDatabase(ctx, &schema.Database{DatabaseName: "db2"})
+	require.NoError(t, err)
+
+	_, err = os.Stat(filepath.Join(keysDir, "db2"+wrappedEncryptionKeyFileExt))
+	require.NoError(t, err)
+
+	// master key rotation doesn't rewrite stored data
+	err = ioutil.WriteFile(masterKeysPath, []byte(masterKey1+"\n"+masterKey2+"\n"), 0600)
+	require.NoError(t, err)
+
+	res, err := s.RotateEncryptionKeys(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	require.Equal(t, uint32(4), res.Rotated)
+
+	rotatedDb1Key, err := ioutil.ReadFile(filepath.Join(keysDir, "db1"+wrappedEncryptionKeyFileExt))
+	require.NoError(t, err)
+	require.NotEqual(t, wrappedDb1Key, rotatedDb1Key)
+
+	err = s.CloseDatabases()
+	require.NoError(t, err)
+
+	// the previous master key can be retired
+	err = ioutil.WriteFile(masterKeysPath, []byte(masterKey2+"\n"), 0600)
+	require.NoError(t, err)
+
+	s = newServer("file://" + masterKeysPath)
+
+	err = s.Initialize()
+	require.NoError(t, err)
+
+	key, err = s.encryptionKey("db1", false)
+	require.NoError(t, err)
+	require.Equal(t, db1Key, key)
+
+	err = s.CloseDatabases()
+	require.NoError(t, err)
+
+	// wrapped keys can not be used without the key provider
+	s = newServer("")
+
+	err = s.Initialize()
+	require.True(t, errors.Is(err, ErrInvalidEncryptionKey))
+
+	s.CloseDatabases()
+
+	// nor with an unknown master key
+	err = ioutil.WriteFile(masterKeysPath, []byte(masterKey1+"\n"), 0600)
+	require.NoError(t, err)
+
+	s = newServer("file://" + masterKeysPath)
+
+	err = s.Initialize()
+	require.True(t, errors.Is(err, ErrInvalidEncryptionKey))
+
+	s.CloseDatabases()
+}
+
+func TestServerKeyProviderOptions(t *testing.T) {
+	s := DefaultServer().WithOptions(DefaultOptions().
+		WithAdminPassword(auth.SysAdminPassword).
+		WithMetricsServer(false).
+		WithEncryptionKeyProvider("file://master.keys"),
+	).(*ImmuServer)
+
+	err := s.Initialize()
+	require.True(t, errors.Is(err, ErrIllegalArguments))
+
+	s = DefaultServer().WithOptions(DefaultOptions().
+		WithAdminPassword(auth.SysAdminPassword).
+		WithMetricsServer(false).
+		WithEncryptionKeysDir("data_key_provider_options").
+		WithEncryptionKeyProvider("unknown://master.keys"),
+	).(*ImmuServer)
+
+	err = s.Initialize()
+	require.True(t, errors.Is(err, kms.ErrUnsupportedKeyProvider))
+}
@@ -1,6 +1,9 @@
 package server
 
 import (
+	"errors"
+
+	"github.com/codenotary/immudb/embedded/sql"
 	"github.com/codenotary/immudb/embedded/store"
 	"github.com/codenotary/immudb/pkg/database"
 	"google.golang.org/grpc/codes"
@@ -8,9 +11,28 @@ import (
 )
 
 var (
-	ErrIllegalArguments   = status.Error(codes.InvalidArgument, database.ErrIllegalArguments.Error())
-	ErrIllegalState       = status.Error(codes.InvalidArgument, database.ErrIllegalState.Error())
-	ErrEmptyAdminPassword = status.Error(codes.InvalidArgument, "Admin password cannot be empty")
+	ErrIllegalArguments         = status.Error(codes.InvalidArgument, database.ErrIllegalArguments.Error())
+	ErrIllegalState             = status.Error(codes.InvalidArgument, database.ErrIllegalState.Error())
+	ErrEmptyAdminPassword       = status.Error(codes.InvalidArgument, "Admin password cannot be empty")
+	ErrUnknownFeature           = status.Error(codes.InvalidArgument, "unknown feature")
+	ErrReservedDatabase         = status.Error(codes.InvalidArgument, "this database can not be deleted")
+	ErrDatabaseDeleted          = status.Error(codes.NotFound, "selected database has been deleted")
+	ErrNoDeletedDatabase        = status.Error(codes.NotFound, "no deleted database found with the given name")
+	ErrInvalidEncryptionKey     = status.Error(codes.FailedPrecondition, "invalid database encryption key")
+	ErrSystemDatabaseShrink     = status.Error(codes.InvalidArgument, "the system database can not be shrunk")
+	ErrShrunkDatabaseMismatch   = status.Error(codes.Internal, "the compacted copy of the database does not match it")
+	ErrFeatureDisabled          = status.Error(codes.FailedPrecondition, "feature is disabled")
+	ErrReplicaDiverged          = status.Error(codes.FailedPrecondition, "the history of the replica diverged from the one of the primary")
+	ErrReplicationAuthRequired  = status.Error(codes.FailedPrecondition, "databases other than the default one can only be replicated with the credentials of a user of the primary")
+	ErrSeparateDirs             = status.Error(codes.FailedPrecondition, "not supported when database files are placed on separate folders")
+	ErrInvalidApplicationName   = status.Error(codes.InvalidArgument, "invalid application name")
+	ErrApplicationQuotaExceeded = status.Error(codes.ResourceExhausted, "maximum number of concurrent requests of the application exceeded")
+	ErrStartupChecksFailed      = status.Error(codes.FailedPrecondition, "startup checks failed")
+	ErrSystemDatabaseUpdate     = status.Error(codes.InvalidArgument, "the system database can not be updated")
+	ErrSourceDiverged           = status.Error(codes.FailedPrecondition, "the history of the source database diverged from the one already verified")
+	ErrInvalidResetToken        = status.Error(codes.PermissionDenied, "invalid or expired password reset token")
+	ErrRawReadRestricted        = status.Error(codes.PermissionDenied, "rows of the database are restricted by policies, keys and txs can only be read by system admins")
+	ErrRawWriteRestricted       = status.Error(codes.PermissionDenied, "rows of the database are restricted by policies, keys can only be written by system admins")
 )
 
 func mapServerError(err error) error {
@@ -20,5 +42,17 @@ func mapServerError(err error) error {
 	case store.ErrIllegalArguments:
 		return ErrIllegalArguments
 	}
+
+	// limits of the database are reported as invalid arguments, so clients can tell them apart
+	if errors.Is(err, store.ErrorMaxKeyLenExceeded) ||
+		errors.Is(err, store.ErrorMaxValueLenExceeded) ||
+		errors.Is(err, store.ErrorMaxTxEntriesLimitExceeded) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if errors.Is(err, sql.ErrUniqueConstraintViolation) {
+		return status.Error(codes.AlreadyExists, err.Error())
+	}
+
 	return err
 }
@@ -0,0 +1,79 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Feature identifies a subsystem which can be enabled or disabled per deployment
+type Feature string
+
+// Experimental features are shipped disabled and need to be explicitly enabled
+const (
+	FeatureDocumentAPI      Feature = "document-api"
+	FeatureAsyncReplication Feature = "async-replication"
+	// FeatureIndexFormatV2 persists a bloom filter alongside the index of each database,
+	// so lookups of keys that don't exist can usually be answered without reading index nodes
+	FeatureIndexFormatV2 Feature = "index-format-v2"
+)
+
+// indexBloomFilterBitsPerKey sizes the bloom filters of the indexes for a false positive rate around 1%
+const indexBloomFilterBitsPerKey = 10
+
+// knownFeatures lists all the features the server is aware of along with their default state
+var knownFeatures = map[Feature]bool{
+	FeatureDocumentAPI:      false,
+	FeatureAsyncReplication: false,
+	FeatureIndexFormatV2:    false,
+}
+
+// KnownFeatures returns the name of all the features supported by the server, sorted by name
+func KnownFeatures() []Feature {
+	features := make([]Feature, 0, len(knownFeatures))
+
+	for f := range knownFeatures {
+		features = append(features, f)
+	}
+
+	sort.Slice(features, func(i, j int) bool { return features[i] < features[j] })
+
+	return features
+}
+
+// IsExperimental returns true if the feature is not enabled by default
+func (f Feature) IsExperimental() bool {
+	enabledByDefault, known := knownFeatures[f]
+	return known && !enabledByDefault
+}
+
+func featureFrom(name string) Feature {
+	return Feature(strings.ToLower(strings.TrimSpace(name)))
+}
+
+// validateFeatures ensures all the feature names are known by the server
+func validateFeatures(names []string) error {
+	for _, name := range names {
+		if _, known := knownFeatures[featureFrom(name)]; !known {
+			return fmt.Errorf("%w: '%s'", ErrUnknownFeature, name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestFeatures(t *testing.T) {
+	features := KnownFeatures()
+	require.Len(t, features, 3)
+
+	for i := 1; i < len(features); i++ {
+		require.Less(t, string(features[i-1]), string(features[i]))
+	}
+
+	require.True(t, FeatureDocumentAPI.IsExperimental())
+	require.False(t, Feature("unknown").IsExperimental())
+
+	require.NoError(t, validateFeatures(nil))
+	require.NoError(t, validateFeatures([]string{" Document-API ", "async-replication"}))
+
+	err := validateFeatures([]string{"document-api", "unknown"})
+	require.True(t, errors.Is(err, ErrUnknownFeature))
+}
+
+func TestOptionsFeatures(t *testing.T) {
+	op := DefaultOptions()
+	require.False(t, op.IsFeatureEnabled(FeatureDocumentAPI))
+	require.NotContains(t, op.String(), "Features")
+
+	op.WithFeatures("DOCUMENT-API", "unknown")
+	require.True(t, op.IsFeatureEnabled(FeatureDocumentAPI))
+	require.False(t, op.IsFeatureEnabled(FeatureAsyncReplication))
+	require.False(t, op.IsFeatureEnabled(Feature("unknown")))
+	require.Contains(t, op.String(), "DOCUMENT-API,unknown")
+}
+
+func TestServerWithUnknownFeature(t *testing.T) {
+	serverOptions := DefaultOptions().
+		WithMetricsServer(false).
+		WithAdminPassword(auth.SysAdminPassword).
+		WithFeatures("unknown")
+
+	s := DefaultServer().WithOptions(serverOptions).(*ImmuServer)
+	defer os.RemoveAll(s.Options.Dir)
+
+	err := s.Initialize()
+	require.True(t, errors.Is(err, ErrUnknownFeature))
+}
+
+func TestServerInfo(t *testing.T) {
+	serverOptions := DefaultOptions().
+		WithMetricsServer(false).
+		WithFeatures(string(FeatureAsyncReplication))
+
+	s := DefaultServer().WithOptions(serverOptions).(*ImmuServer)
+
+	info, err := s.ServerInfo(context.Background(), &schema.ServerInfoRequest{})
+	require.NoError(t, err)
+	require.Len(t, info.Features, len(KnownFeatures()))
+
+	for _, f := range info.Features {
+		require.Equal(t, f.Name == string(FeatureAsyncReplication), f.Enabled)
+		require.True(t, f.Experimental)
+	}
+}
+
+func TestFeatureIndexFormatV2(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		dir := fmt.Sprintf("data_feature_index_format_v2_%t", enabled)
+
+		serverOptions := DefaultOptions().
+			WithDir(dir).
+			WithListener(bufconn.Listen(1024 * 1024)).
+			WithMetricsServer(false)
+
+		if enabled {
+			serverOptions.WithFeatures(string(FeatureIndexFormatV2))
+		}
+
+		s := DefaultServer().WithOptions(serverOptions).(*ImmuServer)
+
+		err := s.Initialize()
+		require.NoError(t, err)
+
+		db, err := s.dbList.GetByName(DefaultdbName)
+		require.NoError(t, err)
+
+		bitsPerKey := db.GetOptions().GetStoreOptions().IndexOpts.BloomFilterBitsPerKey
+
+		if enabled {
+			require.Equal(t, indexBloomFilterBitsPerKey, bitsPerKey)
+		} else {
+			require.Zero(t, bitsPerKey)
+		}
+
+		// the options shared by all the databases are left untouched
+		require.Zero(t, s.Options.StoreOptions.IndexOpts.BloomFilterBitsPerKey)
+
+		err = s.CloseDatabases()
+		require.NoError(t, err)
+
+		os.RemoveAll(dir)
+	}
+}
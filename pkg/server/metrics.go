@@ -20,12 +20,14 @@ import (
 	"context"
 	"expvar"
 	"net/http"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"google.golang.org/grpc/peer"
 
+	"github.com/codenotary/immudb/embedded/store"
 	"github.com/codenotary/immudb/pkg/logger"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -41,8 +43,22 @@ type MetricsCollection struct {
 	computeDBEntries func() map[string]float64
 	DBEntriesGauges  *prometheus.GaugeVec
 
+	computeStoreMetrics func() map[string]*store.Metrics
+
+	ReplicationLagGauges *prometheus.GaugeVec
+
 	RPCsPerClientCounters        *prometheus.CounterVec
 	LastMessageAtPerClientGauges *prometheus.GaugeVec
+
+	RPCsPerDBCounters *prometheus.CounterVec
+
+	CorruptionsPerDBCounters *prometheus.CounterVec
+
+	RPCsPerApplicationCounters         *prometheus.CounterVec
+	RejectedRPCsPerApplicationCounters *prometheus.CounterVec
+
+	dbLabels          *dbLabels
+	applicationLabels *dbLabels
 }
 
 var metricsNamespace = "immudb"
@@ -71,6 +87,49 @@ func (mc *MetricsCollection) UpdateClientMetrics(ctx context.Context) {
 	}
 }
 
+// WithDatabaseLabels limits the label values used by per-database metrics.
+// Only databases in allowlist (all of them if empty) get their own label, up to
+// maxDatabases of them (unlimited if zero); the remaining ones are reported together
+// under the "_other" label. When aggregatedOnly is set, every database is reported
+// under the "_all" label.
+func (mc *MetricsCollection) WithDatabaseLabels(allowlist []string, maxDatabases int, aggregatedOnly bool) {
+	mc.dbLabels = newDBLabels(allowlist, maxDatabases, aggregatedOnly)
+}
+
+// UpdateDBRPCMetrics ...
+func (mc *MetricsCollection) UpdateDBRPCMetrics(db string) {
+	if mc.RPCsPerDBCounters == nil {
+		return
+	}
+	mc.RPCsPerDBCounters.WithLabelValues(mc.dbLabels.label(db)).Inc()
+}
+
+// UpdateCorruptionMetrics counts an inconsistency detected by the store of the database
+func (mc *MetricsCollection) UpdateCorruptionMetrics(db string) {
+	if mc.CorruptionsPerDBCounters == nil {
+		return
+	}
+	mc.CorruptionsPerDBCounters.WithLabelValues(mc.dbLabels.label(db)).Inc()
+}
+
+// UpdateApplicationRPCMetrics counts a request of the application, rejected when exceeding its quota.
+// Metrics of untagged requests are reported under the "_untagged" label and those of applications
+// exceeding the maximum number of labeled applications under the "_other" label.
+func (mc *MetricsCollection) UpdateApplicationRPCMetrics(app string, rejected bool) {
+	label := untaggedApplicationLabel
+	if app != "" {
+		label = mc.applicationLabels.label(app)
+	}
+
+	if mc.RPCsPerApplicationCounters != nil {
+		mc.RPCsPerApplicationCounters.WithLabelValues(label).Inc()
+	}
+
+	if rejected && mc.RejectedRPCsPerApplicationCounters != nil {
+		mc.RejectedRPCsPerApplicationCounters.WithLabelValues(label).Inc()
+	}
+}
+
 // WithComputeDBSizes ...
 func (mc *MetricsCollection) WithComputeDBSizes(f func() map[string]float64) {
 	mc.computeDBSizes = f
@@ -81,17 +140,44 @@ func (mc *MetricsCollection) WithComputeDBEntries(f func() map[string]float64) {
 	mc.computeDBEntries = f
 }
 
+// WithComputeStoreMetrics sets the function used to read the internal gauges of the store of each database
+func (mc *MetricsCollection) WithComputeStoreMetrics(f func() map[string]*store.Metrics) {
+	mc.computeStoreMetrics = f
+}
+
 // UpdateDBMetrics ...
 func (mc *MetricsCollection) UpdateDBMetrics() {
 	if mc.computeDBSizes != nil {
-		for db, size := range mc.computeDBSizes() {
-			mc.DBSizeGauges.WithLabelValues(db).Set(size)
-		}
+		mc.setDBGauges(mc.DBSizeGauges, mc.computeDBSizes())
 	}
 	if mc.computeDBEntries != nil {
-		for db, nbEntries := range mc.computeDBEntries() {
-			mc.DBEntriesGauges.WithLabelValues(db).Set(nbEntries)
-		}
+		mc.setDBGauges(mc.DBEntriesGauges, mc.computeDBEntries())
+	}
+}
+
+// UpdateReplicationLags sets the number of txs each replicated database is behind its primary
+func (mc *MetricsCollection) UpdateReplicationLags(lags map[string]float64) {
+	mc.setDBGauges(mc.ReplicationLagGauges, lags)
+}
+
+// setDBGauges sets the gauges to the per-database values, summing up values
+// of databases sharing the same label. Series of databases not present anymore are removed.
+func (mc *MetricsCollection) setDBGauges(gauges *prometheus.GaugeVec, values map[string]float64) {
+	dbs := make([]string, 0, len(values))
+	for db := range values {
+		dbs = append(dbs, db)
+	}
+	// sorted so labels are assigned deterministically when the number of labeled databases is limited
+	sort.Strings(dbs)
+
+	perLabel := make(map[string]float64)
+	for _, db := range dbs {
+		perLabel[mc.dbLabels.label(db)] += values[db]
+	}
+
+	gauges.Reset()
+	for label, v := range perLabel {
+		gauges.WithLabelValues(label).Set(v)
 	}
 }
 
@@ -121,6 +207,14 @@ var Metrics = MetricsCollection{
 		},
 		[]string{"db"},
 	),
+	ReplicationLagGauges: promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "replication_lag_txs",
+			Help:      "Number of transactions committed by the primary and not yet replicated.",
+		},
+		[]string{"db"},
+	),
 	LastMessageAtPerClientGauges: promauto.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace: metricsNamespace,
@@ -129,6 +223,39 @@ var Metrics = MetricsCollection{
 		},
 		[]string{"ip"},
 	),
+	RPCsPerDBCounters: promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "number_of_rpcs_per_db",
+			Help:      "Number of handled RPCs per database.",
+		},
+		[]string{"db"},
+	),
+	CorruptionsPerDBCounters: promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "number_of_corruptions_detected",
+			Help:      "Number of inconsistencies detected in the data of each database.",
+		},
+		[]string{"db"},
+	),
+	RPCsPerApplicationCounters: promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "number_of_rpcs_per_application",
+			Help:      "Number of RPCs per application the requests were tagged with.",
+		},
+		[]string{"application"},
+	),
+	RejectedRPCsPerApplicationCounters: promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "number_of_rejected_rpcs_per_application",
+			Help:      "Number of RPCs rejected because of exceeding the quota of the application.",
+		},
+		[]string{"application"},
+	),
+	applicationLabels: newDBLabels(nil, maxLabeledApplications, false),
 }
 
 // StartMetrics listens and servers the HTTP metrics server in a new goroutine.
@@ -139,11 +266,13 @@ func StartMetrics(
 	uptimeCounter func() float64,
 	computeDBSizes func() map[string]float64,
 	computeDBEntries func() map[string]float64,
+	computeStoreMetrics func() map[string]*store.Metrics,
 ) *http.Server {
 
 	Metrics.WithUptimeCounter(uptimeCounter)
 	Metrics.WithComputeDBSizes(computeDBSizes)
 	Metrics.WithComputeDBEntries(computeDBEntries)
+	Metrics.WithComputeStoreMetrics(computeStoreMetrics)
 
 	go func() {
 		Metrics.UpdateDBMetrics()
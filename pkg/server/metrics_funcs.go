@@ -21,6 +21,8 @@ import (
 	"os"
 	"path/filepath"
 	"time"
+
+	"github.com/codenotary/immudb/embedded/store"
 )
 
 func (s *ImmuServer) metricFuncServerUptimeCounter() float64 {
@@ -53,6 +55,9 @@ func (s *ImmuServer) metricFuncComputeDBSizes() (dbSizes map[string]float64) {
 	if s.dbList != nil {
 		for i := 0; i < s.dbList.Length(); i++ {
 			db := s.dbList.GetByIndex(int64(i))
+			if db == nil {
+				continue
+			}
 			dbName := db.GetOptions().GetDbName()
 			dbSize, err := dirSize(filepath.Join(s.Options.Dir, dbName))
 			if err != nil {
@@ -89,6 +94,9 @@ func (s *ImmuServer) metricFuncComputeDBEntries() (nbEntriesPerDB map[string]flo
 	if s.dbList != nil {
 		for i := 0; i < s.dbList.Length(); i++ {
 			db := s.dbList.GetByIndex(int64(i))
+			if db == nil {
+				continue
+			}
 			dbName := db.GetOptions().GetDbName()
 			state, err := db.CurrentState()
 			if err != nil {
@@ -122,3 +130,30 @@ func (s *ImmuServer) metricFuncComputeDBEntries() (nbEntriesPerDB map[string]flo
 
 	return
 }
+
+func (s *ImmuServer) metricFuncComputeStoreMetrics() (storeMetricsPerDB map[string]*store.Metrics) {
+	storeMetricsPerDB = make(map[string]*store.Metrics)
+
+	if s.dbList != nil {
+		for i := 0; i < s.dbList.Length(); i++ {
+			db := s.dbList.GetByIndex(int64(i))
+			if db == nil {
+				continue
+			}
+			storeMetricsPerDB[db.GetOptions().GetDbName()] = db.StoreMetrics()
+		}
+	} else {
+		s.Logger.Warningf(
+			"current update of store metrics for regular dbs was skipped: db list is nil")
+	}
+
+	// add systemdb
+	if s.sysDb != nil {
+		storeMetricsPerDB[s.sysDb.GetOptions().GetDbName()] = s.sysDb.StoreMetrics()
+	} else {
+		s.Logger.Warningf(
+			"current update of store metrics for system db was skipped: system db is nil")
+	}
+
+	return
+}
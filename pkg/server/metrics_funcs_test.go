@@ -24,6 +24,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/codenotary/immudb/embedded/store"
 	"github.com/codenotary/immudb/pkg/api/schema"
 	"github.com/codenotary/immudb/pkg/database"
 	"github.com/codenotary/immudb/pkg/logger"
@@ -58,6 +59,40 @@ func (dbm dbMock) GetName() string {
 	return ""
 }
 
+func (dbm dbMock) StoreMetrics() *store.Metrics {
+	return &store.Metrics{CommittedTxID: 99}
+}
+
+func TestMetricFuncComputeStoreMetrics(t *testing.T) {
+	dbList := database.NewDatabaseList()
+	dbList.Append(dbMock{})
+
+	sysDB := dbMock{
+		getOptionsF: func() *database.DbOptions {
+			return database.DefaultOption().WithDbName(SystemdbName)
+		},
+	}
+
+	var sw strings.Builder
+	s := ImmuServer{
+		dbList: dbList,
+		sysDb:  sysDB,
+		Logger: logger.NewSimpleLoggerWithLevel(
+			"TestMetricFuncComputeStoreMetrics",
+			&sw,
+			logger.LogError),
+	}
+
+	storeMetrics := s.metricFuncComputeStoreMetrics()
+	require.Len(t, storeMetrics, 2)
+	require.Equal(t, uint64(99), storeMetrics[SystemdbName].CommittedTxID)
+
+	// test warning paths (when dbList and sysDb are nil)
+	s.dbList = nil
+	s.sysDb = nil
+	require.Empty(t, s.metricFuncComputeStoreMetrics())
+}
+
 func TestMetricFuncComputeDBEntries(t *testing.T) {
 
 	currentStateSuccessfulOnce := func(callCounter *int) (*schema.ImmutableState, error) {
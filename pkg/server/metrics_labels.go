@@ -0,0 +1,89 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import "sync"
+
+// label used for every database when only aggregated metrics are exported
+const aggregatedDBLabel = "_all"
+
+// label used for databases that are not allowed to have their own label
+const otherDBLabel = "_other"
+
+// dbLabels maps database names into metric label values, bounding the number
+// of distinct series produced by per-database metrics
+type dbLabels struct {
+	allowlist      map[string]struct{}
+	maxDatabases   int
+	aggregatedOnly bool
+
+	labeled map[string]struct{}
+	mutex   sync.Mutex
+}
+
+func newDBLabels(allowlist []string, maxDatabases int, aggregatedOnly bool) *dbLabels {
+	l := &dbLabels{
+		maxDatabases:   maxDatabases,
+		aggregatedOnly: aggregatedOnly,
+		labeled:        make(map[string]struct{}),
+	}
+
+	if len(allowlist) > 0 {
+		l.allowlist = make(map[string]struct{}, len(allowlist))
+		for _, db := range allowlist {
+			l.allowlist[db] = struct{}{}
+		}
+	}
+
+	return l
+}
+
+// label returns the label value under which metrics of the given database are reported.
+// Databases outside the allowlist or exceeding the maximum number of labeled databases
+// are reported together under a shared label. Once a database gets its own label, it's kept.
+func (l *dbLabels) label(db string) string {
+	if l == nil {
+		return db
+	}
+
+	if l.aggregatedOnly {
+		return aggregatedDBLabel
+	}
+
+	if l.allowlist != nil {
+		_, allowed := l.allowlist[db]
+		if !allowed {
+			return otherDBLabel
+		}
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	_, labeled := l.labeled[db]
+	if labeled {
+		return db
+	}
+
+	if l.maxDatabases > 0 && len(l.labeled) >= l.maxDatabases {
+		return otherDBLabel
+	}
+
+	l.labeled[db] = struct{}{}
+
+	return db
+}
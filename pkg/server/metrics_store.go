@@ -0,0 +1,159 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"sort"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func init() {
+	prometheus.MustRegister(newStoreMetricsCollector(&Metrics))
+}
+
+// storeMetricsCollector exports the internal gauges of the store of each database,
+// they are read from the stores every time metrics are scraped
+type storeMetricsCollector struct {
+	mc *MetricsCollection
+
+	committedTxs    *prometheus.Desc
+	indexingLag     *prometheus.Desc
+	txCacheHitRatio *prometheus.Desc
+	commitLatency   *prometheus.Desc
+	activeSnapshots *prometheus.Desc
+
+	indexCompactions            *prometheus.Desc
+	indexCompactionWrittenBytes *prometheus.Desc
+}
+
+func newStoreMetricsCollector(mc *MetricsCollection) *storeMetricsCollector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(metricsNamespace, "", name), help, []string{"db"}, nil)
+	}
+
+	return &storeMetricsCollector{
+		mc:              mc,
+		committedTxs:    desc("committed_txs", "Number of transactions committed by the database."),
+		indexingLag:     desc("indexing_lag_txs", "Number of committed transactions not yet indexed."),
+		txCacheHitRatio: desc("tx_cache_hit_ratio", "Ratio of transaction reads served by the transaction cache."),
+		commitLatency:   desc("commit_latency_seconds", "Time taken to commit transactions, without waiting for them to be indexed."),
+		activeSnapshots: desc("active_snapshots", "Number of index snapshots currently in use."),
+
+		indexCompactions:            desc("index_compactions_in_progress", "Number of index compactions in progress."),
+		indexCompactionWrittenBytes: desc("index_compaction_written_bytes", "Bytes written so far by the index compactions in progress."),
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *storeMetricsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.committedTxs
+	ch <- c.indexingLag
+	ch <- c.txCacheHitRatio
+	ch <- c.commitLatency
+	ch <- c.activeSnapshots
+	ch <- c.indexCompactions
+	ch <- c.indexCompactionWrittenBytes
+}
+
+// Collect implements prometheus.Collector, metrics of databases sharing the same label are summed up
+func (c *storeMetricsCollector) Collect(ch chan<- prometheus.Metric) {
+	if c.mc.computeStoreMetrics == nil {
+		return
+	}
+
+	perDB := c.mc.computeStoreMetrics()
+
+	dbs := make([]string, 0, len(perDB))
+	for db := range perDB {
+		dbs = append(dbs, db)
+	}
+	// sorted so labels are assigned deterministically when the number of labeled databases is limited
+	sort.Strings(dbs)
+
+	perLabel := make(map[string]*storeMetrics)
+	for _, db := range dbs {
+		label := c.mc.dbLabels.label(db)
+
+		m, ok := perLabel[label]
+		if !ok {
+			m = newStoreMetrics()
+			perLabel[label] = m
+		}
+
+		m.add(perDB[db])
+	}
+
+	for label, m := range perLabel {
+		ch <- prometheus.MustNewConstMetric(c.committedTxs, prometheus.GaugeValue, float64(m.committedTxs), label)
+		ch <- prometheus.MustNewConstMetric(c.indexingLag, prometheus.GaugeValue, float64(m.indexingLag), label)
+		ch <- prometheus.MustNewConstMetric(c.txCacheHitRatio, prometheus.GaugeValue, m.txCacheHitRatio(), label)
+		ch <- prometheus.MustNewConstMetric(c.activeSnapshots, prometheus.GaugeValue, float64(m.activeSnapshots), label)
+		ch <- prometheus.MustNewConstMetric(c.indexCompactions, prometheus.GaugeValue, float64(m.indexCompactions), label)
+		ch <- prometheus.MustNewConstMetric(c.indexCompactionWrittenBytes, prometheus.GaugeValue, float64(m.indexCompactionWrittenBytes), label)
+		ch <- prometheus.MustNewConstHistogram(c.commitLatency, m.commitLatencyCount, m.commitLatencySum, m.commitLatencyBuckets, label)
+	}
+}
+
+// storeMetrics accumulates the metrics of the stores reported under the same label
+type storeMetrics struct {
+	committedTxs    uint64
+	indexingLag     uint64
+	txCacheHits     uint64
+	txCacheMisses   uint64
+	activeSnapshots int
+
+	indexCompactions            int
+	indexCompactionWrittenBytes int64
+
+	commitLatencyCount   uint64
+	commitLatencySum     float64
+	commitLatencyBuckets map[float64]uint64
+}
+
+func newStoreMetrics() *storeMetrics {
+	return &storeMetrics{commitLatencyBuckets: make(map[float64]uint64, len(store.CommitLatencyBuckets))}
+}
+
+func (m *storeMetrics) add(sm *store.Metrics) {
+	m.committedTxs += sm.CommittedTxID
+	m.indexingLag += sm.IndexingLag()
+	m.txCacheHits += sm.TxCacheHits
+	m.txCacheMisses += sm.TxCacheMisses
+	m.activeSnapshots += sm.ActiveSnapshots
+
+	if sm.IndexCompactionInProgress {
+		m.indexCompactions++
+		m.indexCompactionWrittenBytes += sm.IndexCompactionWrittenBytes
+	}
+
+	m.commitLatencyCount += sm.CommitLatency.Count
+	m.commitLatencySum += sm.CommitLatency.Sum.Seconds()
+
+	for i, upperBound := range store.CommitLatencyBuckets {
+		m.commitLatencyBuckets[upperBound.Seconds()] += sm.CommitLatency.BucketCounts[i]
+	}
+}
+
+func (m *storeMetrics) txCacheHitRatio() float64 {
+	reads := m.txCacheHits + m.txCacheMisses
+	if reads == 0 {
+		return 0
+	}
+	return float64(m.txCacheHits) / float64(reads)
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+)
+
+func storeMetricsFor(committedTxID, indexedTxID uint64, cacheHits, cacheMisses uint64) *store.Metrics {
+	bucketCounts := make([]uint64, len(store.CommitLatencyBuckets))
+	for i := range bucketCounts {
+		bucketCounts[i] = committedTxID
+	}
+
+	return &store.Metrics{
+		CommittedTxID: committedTxID,
+		IndexedTxID:   indexedTxID,
+		TxCacheHits:   cacheHits,
+		TxCacheMisses: cacheMisses,
+		CommitLatency: store.LatencyHistogram{
+			BucketCounts: bucketCounts,
+			Count:        committedTxID,
+			Sum:          time.Duration(committedTxID) * time.Millisecond,
+		},
+		ActiveSnapshots: 1,
+	}
+}
+
+func gatherStoreMetrics(t *testing.T, mc *MetricsCollection) map[string]*dto.MetricFamily {
+	reg := prometheus.NewPedanticRegistry()
+	require.NoError(t, reg.Register(newStoreMetricsCollector(mc)))
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+
+	perName := make(map[string]*dto.MetricFamily)
+	for _, f := range families {
+		perName[f.GetName()] = f
+	}
+
+	return perName
+}
+
+func TestStoreMetricsCollector(t *testing.T) {
+	mc := &MetricsCollection{}
+
+	require.Empty(t, gatherStoreMetrics(t, mc))
+
+	mc.WithComputeStoreMetrics(func() map[string]*store.Metrics {
+		db2 := storeMetricsFor(20, 20, 0, 0)
+		db2.IndexCompactionInProgress = true
+		db2.IndexCompactionWrittenBytes = 4096
+
+		return map[string]*store.Metrics{
+			"db1": storeMetricsFor(10, 8, 3, 1),
+			"db2": db2,
+		}
+	})
+
+	families := gatherStoreMetrics(t, mc)
+	require.Len(t, families, 7)
+
+	lag := families["immudb_indexing_lag_txs"].GetMetric()
+	require.Len(t, lag, 2)
+	require.Equal(t, "db1", lag[0].GetLabel()[0].GetValue())
+	require.Equal(t, float64(2), lag[0].GetGauge().GetValue())
+	require.Equal(t, float64(0), lag[1].GetGauge().GetValue())
+
+	hitRatio := families["immudb_tx_cache_hit_ratio"].GetMetric()
+	require.Equal(t, 0.75, hitRatio[0].GetGauge().GetValue())
+
+	compactions := families["immudb_index_compactions_in_progress"].GetMetric()
+	require.Equal(t, float64(0), compactions[0].GetGauge().GetValue())
+	require.Equal(t, float64(1), compactions[1].GetGauge().GetValue())
+
+	compactionBytes := families["immudb_index_compaction_written_bytes"].GetMetric()
+	require.Equal(t, float64(4096), compactionBytes[1].GetGauge().GetValue())
+
+	// metrics are summed up when databases share the same label
+	mc.WithDatabaseLabels(nil, 0, true)
+
+	families = gatherStoreMetrics(t, mc)
+
+	committedTxs := families["immudb_committed_txs"].GetMetric()
+	require.Len(t, committedTxs, 1)
+	require.Equal(t, aggregatedDBLabel, committedTxs[0].GetLabel()[0].GetValue())
+	require.Equal(t, float64(30), committedTxs[0].GetGauge().GetValue())
+
+	latency := families["immudb_commit_latency_seconds"].GetMetric()[0].GetHistogram()
+	require.Equal(t, uint64(30), latency.GetSampleCount())
+	require.InDelta(t, 0.03, latency.GetSampleSum(), 1e-9)
+	require.Len(t, latency.GetBucket(), len(store.CommitLatencyBuckets))
+
+	require.Equal(t, float64(2), families["immudb_active_snapshots"].GetMetric()[0].GetGauge().GetValue())
+}
@@ -22,8 +22,11 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/codenotary/immudb/embedded/store"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/peer"
 )
 
@@ -34,6 +37,7 @@ func TestStartMetrics(t *testing.T) {
 		func() float64 { return 0 },
 		func() map[string]float64 { return make(map[string]float64) },
 		func() map[string]float64 { return make(map[string]float64) },
+		func() map[string]*store.Metrics { return make(map[string]*store.Metrics) },
 	)
 	defer server.Close()
 
@@ -115,3 +119,67 @@ func TestMetricsCollection_UpdateDBMetrics(t *testing.T) {
 
 	assert.IsType(t, MetricsCollection{}, mc)
 }
+
+func TestMetricsCollection_DatabaseLabels(t *testing.T) {
+	newGauges := func() *prometheus.GaugeVec {
+		return prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "db_size_bytes",
+				Help:      "Database size in bytes.",
+			},
+			[]string{"db"},
+		)
+	}
+
+	sizes := map[string]float64{"db1": 1, "db2": 2, "db3": 4, "db4": 8}
+
+	mc := MetricsCollection{
+		DBSizeGauges: newGauges(),
+		RPCsPerDBCounters: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Name:      "number_of_rpcs_per_db",
+				Help:      "Number of handled RPCs per database.",
+			},
+			[]string{"db"},
+		),
+	}
+	mc.WithComputeDBSizes(func() map[string]float64 { return sizes })
+
+	t.Run("no limits", func(t *testing.T) {
+		mc.UpdateDBMetrics()
+		require.Equal(t, 4, testutil.CollectAndCount(mc.DBSizeGauges))
+		require.Equal(t, float64(4), testutil.ToFloat64(mc.DBSizeGauges.WithLabelValues("db3")))
+	})
+
+	t.Run("allowlist and max databases", func(t *testing.T) {
+		mc.DBSizeGauges = newGauges()
+		mc.WithDatabaseLabels([]string{"db1", "db2", "db4"}, 2, false)
+
+		mc.UpdateDBMetrics()
+		require.Equal(t, 3, testutil.CollectAndCount(mc.DBSizeGauges))
+		require.Equal(t, float64(1), testutil.ToFloat64(mc.DBSizeGauges.WithLabelValues("db1")))
+		require.Equal(t, float64(2), testutil.ToFloat64(mc.DBSizeGauges.WithLabelValues("db2")))
+		require.Equal(t, float64(12), testutil.ToFloat64(mc.DBSizeGauges.WithLabelValues(otherDBLabel)))
+
+		mc.UpdateDBRPCMetrics("db2")
+		mc.UpdateDBRPCMetrics("db3")
+		mc.UpdateDBRPCMetrics("db4")
+		require.Equal(t, float64(1), testutil.ToFloat64(mc.RPCsPerDBCounters.WithLabelValues("db2")))
+		require.Equal(t, float64(2), testutil.ToFloat64(mc.RPCsPerDBCounters.WithLabelValues(otherDBLabel)))
+
+		delete(sizes, "db2")
+		mc.UpdateDBMetrics()
+		require.Equal(t, 2, testutil.CollectAndCount(mc.DBSizeGauges))
+	})
+
+	t.Run("aggregated only", func(t *testing.T) {
+		mc.DBSizeGauges = newGauges()
+		mc.WithDatabaseLabels(nil, 0, true)
+
+		mc.UpdateDBMetrics()
+		require.Equal(t, 1, testutil.CollectAndCount(mc.DBSizeGauges))
+		require.Equal(t, float64(13), testutil.ToFloat64(mc.DBSizeGauges.WithLabelValues(aggregatedDBLabel)))
+	})
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/database"
+)
+
+// multiDbTxLog persists the decision records of multi-database txs into the system database,
+// records are deleted once resolved thus only the pending ones are found when scanning them
+type multiDbTxLog struct {
+	sysDb database.DB
+}
+
+// multiDbTxKey returns the key of a decision record, the tx prepared in the first database of the record
+// is unique thus it identifies the record
+func multiDbTxKey(rec *database.MultiDbTxRecord) []byte {
+	id := fmt.Sprintf("%s.%d", rec.Txs[0].Database, rec.Txs[0].TxID)
+
+	key := make([]byte, 1+len(id))
+	key[0] = KeyPrefixMultiDbTx
+	copy(key[1:], id)
+	return key
+}
+
+func (l *multiDbTxLog) Decide(ctx context.Context, rec *database.MultiDbTxRecord) error {
+	value, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+
+	_, err = l.sysDb.Set(ctx, &schema.SetRequest{
+		KVs:    []*schema.KeyValue{{Key: multiDbTxKey(rec), Value: value}},
+		NoWait: true,
+	})
+	return err
+}
+
+func (l *multiDbTxLog) Resolve(ctx context.Context, rec *database.MultiDbTxRecord) error {
+	_, err := l.sysDb.Set(ctx, &schema.SetRequest{
+		KVs: []*schema.KeyValue{{
+			Key:      multiDbTxKey(rec),
+			Metadata: &schema.KVMetadata{Deleted: true},
+		}},
+		NoWait: true,
+	})
+	return err
+}
+
+func (s *ImmuServer) multiDbTxLog() database.MultiDbTxLog {
+	return &multiDbTxLog{sysDb: s.sysDb}
+}
+
+// recoverMultiDbTxs rolls forward the multi-database txs whose commit decision was persisted
+// but which were not committed into all of their databases, e.g. because of a crash.
+// Records whose databases are not loaded or whose tx can't be committed are kept and logged,
+// so that they're recovered on a later startup.
+func (s *ImmuServer) recoverMultiDbTxs() error {
+	ctx := context.Background()
+
+	state, err := s.sysDb.CurrentState()
+	if err != nil {
+		return err
+	}
+
+	// records not yet indexed must be found as well
+	itemList, err := s.sysDb.Scan(ctx, &schema.ScanRequest{
+		Prefix:  []byte{KeyPrefixMultiDbTx},
+		SinceTx: state.TxId,
+	})
+	if err != nil {
+		return err
+	}
+
+	log := s.multiDbTxLog()
+
+	for _, item := range itemList.Entries {
+		var rec database.MultiDbTxRecord
+
+		err = json.Unmarshal(item.Value, &rec)
+		if err != nil {
+			return err
+		}
+
+		if len(rec.Txs) == 0 {
+			return fmt.Errorf("%w: multi-database tx record '%s' holds no txs", ErrIllegalArguments, item.Key[1:])
+		}
+
+		dbs, err := s.multiDbTxDatabases(&rec)
+		if err != nil {
+			s.Logger.Warningf("multi-database tx '%s' can not be recovered yet: %v", item.Key[1:], err)
+			continue
+		}
+
+		err = database.RecoverMultiDbTx(ctx, &rec, dbs)
+		if err != nil {
+			s.Logger.Errorf("multi-database tx '%s' could not be recovered: %v", item.Key[1:], err)
+			continue
+		}
+
+		err = log.Resolve(ctx, &rec)
+		if err != nil {
+			return err
+		}
+
+		s.Logger.Infof("multi-database tx '%s' recovered", item.Key[1:])
+	}
+
+	return nil
+}
+
+func (s *ImmuServer) multiDbTxDatabases(rec *database.MultiDbTxRecord) ([]database.DB, error) {
+	dbs := make([]database.DB, len(rec.Txs))
+
+	for i, e := range rec.Txs {
+		db, err := s.dbList.GetByName(e.Database)
+		if err != nil {
+			return nil, fmt.Errorf("%w: '%s'", err, e.Database)
+		}
+
+		dbs[i] = db
+	}
+
+	return dbs, nil
+}
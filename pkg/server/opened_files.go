@@ -0,0 +1,139 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"io/ioutil"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/database"
+)
+
+// OpenedFiles lists the files opened by the logs of a database, or of all the loaded databases when no name is given,
+// along with the limits they are bound to, in order to track down "too many open files" conditions
+func (s *ImmuServer) OpenedFiles(ctx context.Context, req *schema.Database) (*schema.OpenedFilesResponse, error) {
+	s.Logger.Debugf("OpenedFiles %+v", req)
+
+	if req == nil {
+		return nil, ErrIllegalArguments
+	}
+
+	err := s.checkSysAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dbs, err := s.databasesFor(req.DatabaseName)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &schema.OpenedFilesResponse{
+		ProcessOpenedFiles:    processOpenedFiles(),
+		ProcessMaxOpenedFiles: processMaxOpenedFiles(),
+	}
+
+	for _, db := range dbs {
+		logs, err := db.OpenedFiles()
+		if err != nil {
+			return nil, err
+		}
+
+		res.Databases = append(res.Databases, &schema.DatabaseOpenedFiles{
+			Database: db.GetName(),
+			Logs:     logs,
+		})
+	}
+
+	return res, nil
+}
+
+// CloseCachedFiles closes the files kept open for reading by a database, or by all the loaded databases when
+// no name is given. Files being appended remain open while the others get reopened when read again
+func (s *ImmuServer) CloseCachedFiles(ctx context.Context, req *schema.Database) (*schema.CloseCachedFilesResponse, error) {
+	s.Logger.Debugf("CloseCachedFiles %+v", req)
+
+	if req == nil {
+		return nil, ErrIllegalArguments
+	}
+
+	err := s.checkSysAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dbs, err := s.databasesFor(req.DatabaseName)
+	if err != nil {
+		return nil, err
+	}
+
+	var closed uint32
+
+	for _, db := range dbs {
+		n, err := db.CloseCachedFiles()
+		closed += uint32(n)
+		if err != nil {
+			return nil, err
+		}
+
+		s.Logger.Infof("%d cached files of database '%s' were closed", n, db.GetName())
+	}
+
+	return &schema.CloseCachedFilesResponse{ClosedFiles: closed}, nil
+}
+
+// databasesFor returns the database with the given name, the system one included,
+// or all the loaded databases when no name is given
+func (s *ImmuServer) databasesFor(dbname string) ([]database.DB, error) {
+	if dbname == SystemdbName {
+		return []database.DB{s.sysDb}, nil
+	}
+
+	if dbname != "" {
+		db, err := s.dbList.GetByName(dbname)
+		if err != nil {
+			return nil, err
+		}
+
+		return []database.DB{db}, nil
+	}
+
+	dbs := []database.DB{s.sysDb}
+
+	for i := 0; i < s.dbList.Length(); i++ {
+		db := s.dbList.GetByIndex(int64(i))
+		if db == nil {
+			// deleted database
+			continue
+		}
+
+		dbs = append(dbs, db)
+	}
+
+	return dbs, nil
+}
+
+// processOpenedFiles returns the number of files opened by the process, 0 when it can't be determined
+func processOpenedFiles() uint64 {
+	fds, err := ioutil.ReadDir("/proc/self/fd")
+	if err != nil {
+		return 0
+	}
+
+	return uint64(len(fds))
+}
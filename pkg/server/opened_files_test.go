@@ -0,0 +1,102 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	"github.com/codenotary/immudb/pkg/database"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestServerOpenedFiles(t *testing.T) {
+	dir := "data_opened_files"
+	defer os.RemoveAll(dir)
+
+	serverOptions := DefaultOptions().
+		WithDir(dir).
+		WithListener(bufconn.Listen(1024 * 1024)).
+		WithMetricsServer(false).
+		WithAdminPassword(auth.SysAdminPassword)
+
+	s := DefaultServer().WithOptions(serverOptions).(*ImmuServer)
+
+	err := s.Initialize()
+	require.NoError(t, err)
+	defer s.CloseDatabases()
+
+	lr, err := s.Login(context.Background(), &schema.LoginRequest{
+		User:     []byte(auth.SysAdminUsername),
+		Password: []byte(auth.SysAdminPassword),
+	})
+	require.NoError(t, err)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", lr.Token))
+
+	_, err = s.OpenedFiles(ctx, nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = s.CloseCachedFiles(ctx, nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = s.OpenedFiles(ctx, &schema.Database{DatabaseName: "db1"})
+	require.Equal(t, database.ErrDatabaseNotExists, err)
+
+	_, err = s.CreateDatabase(ctx, &schema.Database{DatabaseName: "db1"})
+	require.NoError(t, err)
+
+	res, err := s.OpenedFiles(ctx, &schema.Database{})
+	require.NoError(t, err)
+	require.Len(t, res.Databases, 3)
+	require.Equal(t, SystemdbName, res.Databases[0].Database)
+	require.Equal(t, DefaultdbName, res.Databases[1].Database)
+	require.Equal(t, "db1", res.Databases[2].Database)
+
+	for _, db := range res.Databases {
+		require.NotEmpty(t, db.Logs)
+
+		for _, l := range db.Logs {
+			require.NotEmpty(t, l.Files)
+		}
+	}
+
+	res, err = s.OpenedFiles(ctx, &schema.Database{DatabaseName: "db1"})
+	require.NoError(t, err)
+	require.Len(t, res.Databases, 1)
+	require.Equal(t, "db1", res.Databases[0].Database)
+
+	_, err = s.CloseCachedFiles(ctx, &schema.Database{})
+	require.NoError(t, err)
+
+	_, err = s.CloseCachedFiles(ctx, &schema.Database{DatabaseName: SystemdbName})
+	require.NoError(t, err)
+
+	// databases remain readable once their cached files are closed
+	ur, err := s.UseDatabase(ctx, &schema.Database{DatabaseName: DefaultdbName})
+	require.NoError(t, err)
+
+	dbCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", ur.Token))
+
+	_, err = s.CurrentState(dbCtx, nil)
+	require.NoError(t, err)
+}
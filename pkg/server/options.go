@@ -22,16 +22,24 @@ import (
 	"net"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/codenotary/immudb/pkg/stream"
 
 	"github.com/codenotary/immudb/embedded/store"
 	"github.com/codenotary/immudb/pkg/auth"
+	pgsqlsrv "github.com/codenotary/immudb/pkg/pgsql/server"
 )
 
 const SystemdbName = "systemdb"
 const DefaultdbName = "defaultdb"
 
+// DefaultDeletedDatabasesRetention is the period deleted databases are kept before being purged
+const DefaultDeletedDatabasesRetention = 7 * 24 * time.Hour
+
+// DefaultSQLRetryBackoff is the maximum period waited before retrying a conflicting SQL write for the first time
+const DefaultSQLRetryBackoff = 10 * time.Millisecond
+
 // Options server options list
 type Options struct {
 	Dir                 string
@@ -63,6 +71,222 @@ type Options struct {
 	TokenExpiryTimeMin  int
 	PgsqlServer         bool
 	PgsqlServerPort     int
+	// PgsqlServerTLSConfig is used by the pgsql server instead of TLSConfig when set
+	PgsqlServerTLSConfig *tls.Config
+	// PgsqlServerRequireSSL makes the pgsql server refuse clients not negotiating SSL
+	PgsqlServerRequireSSL bool
+	// PgsqlServerAuthMethod is the authentication method of the pgsql clients: password, md5 or scram-sha-256
+	PgsqlServerAuthMethod string
+	Features              []string
+	// DeletedDatabasesRetention is the period deleted databases are kept before being purged, 0 means forever
+	DeletedDatabasesRetention time.Duration
+	// TxLogDir, ValueLogDir and IndexDir hold, within a folder per database, the tx and commit logs, the value logs
+	// and the indexes respectively e.g. to place them on different devices, they are kept in the database folder when empty
+	TxLogDir    string
+	ValueLogDir string
+	IndexDir    string
+	// EncryptionKeysDir holds one encryption key per database, values are stored in plain when empty
+	EncryptionKeysDir string
+	// EncryptionKeyProvider is the URL of the key provider wrapping the encryption keys, keys are stored in plain when empty
+	EncryptionKeyProvider string
+	// MetricsDatabases lists the databases having their own label on per-database metrics, all of them when empty
+	MetricsDatabases []string
+	// MetricsMaxDatabases is the maximum number of databases having their own label on per-database metrics, 0 means no limit
+	MetricsMaxDatabases int
+	// MetricsAggregatedOnly reports per-database metrics aggregated over all databases
+	MetricsAggregatedOnly bool
+	// RecordTraceIDs records the W3C trace-id of write requests into the attributes of the committed tx
+	RecordTraceIDs bool
+	// MaxRequestsPerApplication is the maximum number of concurrent requests of each application, 0 means no limit
+	MaxRequestsPerApplication int
+	// ApplicationQuotas overrides the maximum number of concurrent requests of some applications, 0 means no limit
+	ApplicationQuotas map[string]int
+	// ReplicationOptions makes the server a replica of the given primary, the server is a primary when nil
+	ReplicationOptions *ReplicationOptions
+	// SkipStartupChecks starts the server without checking the environment and probing the databases first
+	SkipStartupChecks bool
+	// SQLMaxRetries is the number of times idempotent single-statement writes conflicting with concurrent txs
+	// are retried by the server, 0 means they are not retried
+	SQLMaxRetries int
+	// SQLRetryBackoff is the maximum period waited before the first retry of a conflicting write, doubled on every retry
+	SQLRetryBackoff time.Duration
+	// TableSyncs mirrors tables, or keys under a prefix, from source databases into local databases
+	TableSyncs []*TableSyncOptions
+}
+
+// ReplicationOptions sets how a replica server pulls the txs committed into the primary server
+type ReplicationOptions struct {
+	MasterAddress    string
+	MasterPort       int
+	FollowerUsername string
+	FollowerPassword string `json:"-"`
+	// PollInterval is the time waited before asking the primary for new txs once a replica is up to date
+	PollInterval time.Duration
+}
+
+// DefaultReplicationOptions returns default replication options
+func DefaultReplicationOptions() *ReplicationOptions {
+	return &ReplicationOptions{
+		MasterAddress: "127.0.0.1",
+		MasterPort:    3322,
+		PollInterval:  time.Second,
+	}
+}
+
+// WithMasterAddress sets the address of the primary server
+func (o *ReplicationOptions) WithMasterAddress(address string) *ReplicationOptions {
+	o.MasterAddress = address
+	return o
+}
+
+// WithMasterPort sets the port of the primary server
+func (o *ReplicationOptions) WithMasterPort(port int) *ReplicationOptions {
+	o.MasterPort = port
+	return o
+}
+
+// WithFollowerUsername sets the user the replica logs in with, it must be allowed to read all the replicated databases.
+// Txs of databases with SQL policies can only be exported to system admins
+func (o *ReplicationOptions) WithFollowerUsername(username string) *ReplicationOptions {
+	o.FollowerUsername = username
+	return o
+}
+
+// WithFollowerPassword sets the password of the user the replica logs in with
+func (o *ReplicationOptions) WithFollowerPassword(password string) *ReplicationOptions {
+	o.FollowerPassword = password
+	return o
+}
+
+// WithPollInterval sets the time waited before asking the primary for new txs once a replica is up to date
+func (o *ReplicationOptions) WithPollInterval(pollInterval time.Duration) *ReplicationOptions {
+	o.PollInterval = pollInterval
+	return o
+}
+
+// TableSyncOptions sets a table sync job, which continuously mirrors the rows of a SQL table, or the keys under
+// a prefix, from a source database into a local target database. The source database is held by the local
+// server unless the address of a remote server is set. Rows and keys are verified before being mirrored.
+type TableSyncOptions struct {
+	SourceAddress  string
+	SourcePort     int
+	SourceUsername string
+	SourcePassword string `json:"-"`
+	SourceDatabase string
+	// Table is the SQL table being mirrored, it's created in the target database when missing
+	Table string
+	// Prefix is the prefix of the keys being mirrored when no table is set
+	Prefix         []byte
+	TargetDatabase string
+	// PollInterval is the time waited before checking the source database for changes once the target is up to date
+	PollInterval time.Duration
+}
+
+// DefaultTableSyncOptions returns default table sync options
+func DefaultTableSyncOptions() *TableSyncOptions {
+	return &TableSyncOptions{
+		SourcePort:   3322,
+		PollInterval: time.Second,
+	}
+}
+
+// WithSourceAddress sets the address of the remote server holding the source database, the local server is used when empty
+func (o *TableSyncOptions) WithSourceAddress(address string) *TableSyncOptions {
+	o.SourceAddress = address
+	return o
+}
+
+// WithSourcePort sets the port of the remote server holding the source database
+func (o *TableSyncOptions) WithSourcePort(port int) *TableSyncOptions {
+	o.SourcePort = port
+	return o
+}
+
+// WithSourceUsername sets the user the job logs in the remote server with, it must be allowed to read the source database
+func (o *TableSyncOptions) WithSourceUsername(username string) *TableSyncOptions {
+	o.SourceUsername = username
+	return o
+}
+
+// WithSourcePassword sets the password of the user the job logs in the remote server with
+func (o *TableSyncOptions) WithSourcePassword(password string) *TableSyncOptions {
+	o.SourcePassword = password
+	return o
+}
+
+// WithSourceDatabase sets the database rows are read from
+func (o *TableSyncOptions) WithSourceDatabase(dbName string) *TableSyncOptions {
+	o.SourceDatabase = dbName
+	return o
+}
+
+// WithTable sets the SQL table being mirrored
+func (o *TableSyncOptions) WithTable(table string) *TableSyncOptions {
+	o.Table = table
+	return o
+}
+
+// WithPrefix sets the prefix of the keys being mirrored
+func (o *TableSyncOptions) WithPrefix(prefix []byte) *TableSyncOptions {
+	o.Prefix = prefix
+	return o
+}
+
+// WithTargetDatabase sets the local database rows are mirrored into
+func (o *TableSyncOptions) WithTargetDatabase(dbName string) *TableSyncOptions {
+	o.TargetDatabase = dbName
+	return o
+}
+
+// WithPollInterval sets the time waited before checking the source database for changes once the target is up to date
+func (o *TableSyncOptions) WithPollInterval(pollInterval time.Duration) *TableSyncOptions {
+	o.PollInterval = pollInterval
+	return o
+}
+
+// IsRemote returns true if the source database is held by a remote server
+func (o *TableSyncOptions) IsRemote() bool {
+	return o.SourceAddress != ""
+}
+
+// String identifies the job e.g. db1/table1 -> db2 or 10.0.0.1:3322/db1/table1 -> db2
+func (o *TableSyncOptions) String() string {
+	source := o.SourceDatabase
+
+	if o.IsRemote() {
+		source = fmt.Sprintf("%s:%d/%s", o.SourceAddress, o.SourcePort, o.SourceDatabase)
+	}
+
+	if o.Table != "" {
+		return fmt.Sprintf("%s/%s -> %s", source, o.Table, o.TargetDatabase)
+	}
+
+	return fmt.Sprintf("%s?prefix=%s -> %s", source, o.Prefix, o.TargetDatabase)
+}
+
+// Validate checks the options are consistent
+func (o *TableSyncOptions) Validate() error {
+	if o.SourceDatabase == "" || o.TargetDatabase == "" {
+		return fmt.Errorf("%w: source and target databases must be set", ErrIllegalArguments)
+	}
+
+	if (o.Table == "") == (len(o.Prefix) == 0) {
+		return fmt.Errorf("%w: either a table or a prefix must be set", ErrIllegalArguments)
+	}
+
+	if !o.IsRemote() && o.SourceDatabase == o.TargetDatabase {
+		return fmt.Errorf("%w: source and target databases must be different", ErrIllegalArguments)
+	}
+
+	if o.TargetDatabase == SystemdbName {
+		return fmt.Errorf("%w: the system database can not be a target", ErrIllegalArguments)
+	}
+
+	if o.PollInterval <= 0 {
+		return fmt.Errorf("%w: poll interval must be greater than zero", ErrIllegalArguments)
+	}
+
+	return nil
 }
 
 // DefaultOptions returns default server options
@@ -95,11 +319,18 @@ func DefaultOptions() *Options {
 		TokenExpiryTimeMin:  1440,
 		PgsqlServer:         false,
 		PgsqlServerPort:     5432,
+
+		PgsqlServerAuthMethod:     pgsqlsrv.AuthMethodPassword,
+		DeletedDatabasesRetention: DefaultDeletedDatabasesRetention,
+		SQLRetryBackoff:           DefaultSQLRetryBackoff,
 	}
 }
 
 func DefaultStoreOptions() *store.Options {
-	indexOptions := store.DefaultIndexOptions().WithRenewSnapRootAfter(0)
+	indexOptions := store.DefaultIndexOptions().
+		WithRenewSnapRootAfter(0).
+		WithSecondaryIndexes(true).
+		WithValuePrefixOffset(1)
 	return store.DefaultOptions().
 		WithIndexOptions(indexOptions).
 		WithMaxLinearProofLen(0).
@@ -219,6 +450,11 @@ func (o *Options) String() string {
 	opts = append(opts, rightPad("Address", fmt.Sprintf("%s:%d", o.Address, o.Port)))
 	if o.MetricsServer {
 		opts = append(opts, rightPad("Metrics address", fmt.Sprintf("%s:%d/metrics", o.Address, o.MetricsPort)))
+		if o.MetricsAggregatedOnly {
+			opts = append(opts, rightPad("Metrics per db", "aggregated"))
+		} else if len(o.MetricsDatabases) > 0 || o.MetricsMaxDatabases > 0 {
+			opts = append(opts, rightPad("Metrics per db", fmt.Sprintf("%v (max %d)", o.MetricsDatabases, o.MetricsMaxDatabases)))
+		}
 	}
 	if o.Config != "" {
 		opts = append(opts, rightPad("Config file", o.Config))
@@ -235,6 +471,51 @@ func (o *Options) String() string {
 	opts = append(opts, rightPad("Default database", o.defaultDbName))
 	opts = append(opts, rightPad("Maintenance mode", o.maintenance))
 	opts = append(opts, rightPad("Synced mode", o.StoreOptions.Synced))
+	if o.StoreOptions.InlineValueThld > 0 {
+		opts = append(opts, rightPad("Inline values up to", o.StoreOptions.InlineValueThld))
+	}
+	if len(o.Features) > 0 {
+		opts = append(opts, rightPad("Features", strings.Join(o.Features, ",")))
+	}
+	if o.RecordTraceIDs {
+		opts = append(opts, rightPad("Trace IDs", "recorded"))
+	}
+	if o.MaxRequestsPerApplication > 0 || len(o.ApplicationQuotas) > 0 {
+		opts = append(opts, rightPad("App quotas", fmt.Sprintf("%d %v", o.MaxRequestsPerApplication, o.ApplicationQuotas)))
+	}
+	if o.TxLogDir != "" {
+		opts = append(opts, rightPad("Tx logs dir", o.TxLogDir))
+	}
+	if o.ValueLogDir != "" {
+		opts = append(opts, rightPad("Value logs dir", o.ValueLogDir))
+	}
+	if o.IndexDir != "" {
+		opts = append(opts, rightPad("Indexes dir", o.IndexDir))
+	}
+	if o.EncryptionKeysDir != "" {
+		opts = append(opts, rightPad("Encryption keys", o.EncryptionKeysDir))
+	}
+	if o.EncryptionKeyProvider != "" {
+		opts = append(opts, rightPad("Key provider", o.EncryptionKeyProvider))
+	}
+	if o.PgsqlServer && o.PgsqlServerRequireSSL {
+		opts = append(opts, rightPad("Pgsql SSL", "required"))
+	}
+	if o.PgsqlServer && o.PgsqlServerAuthMethod != pgsqlsrv.AuthMethodPassword {
+		opts = append(opts, rightPad("Pgsql auth method", o.PgsqlServerAuthMethod))
+	}
+	if o.SkipStartupChecks {
+		opts = append(opts, rightPad("Startup checks", "skipped"))
+	}
+	if o.SQLMaxRetries > 0 {
+		opts = append(opts, rightPad("SQL retries", fmt.Sprintf("%d (backoff %s)", o.SQLMaxRetries, o.SQLRetryBackoff)))
+	}
+	if o.ReplicationOptions != nil {
+		opts = append(opts, rightPad("Replica of", fmt.Sprintf("%s:%d", o.ReplicationOptions.MasterAddress, o.ReplicationOptions.MasterPort)))
+	}
+	for _, ts := range o.TableSyncs {
+		opts = append(opts, rightPad("Table sync", ts.String()))
+	}
 	opts = append(opts, "----------------------------------------")
 	opts = append(opts, "Superadmin default credentials")
 	opts = append(opts, rightPad("   Username", auth.SysAdminUsername))
@@ -332,3 +613,169 @@ func (o *Options) WithPgsqlServerPort(port int) *Options {
 	o.PgsqlServerPort = port
 	return o
 }
+
+// WithPgsqlServerTLS sets the tls config of the pgsql server, the one of the grpc server is used when it's nil
+func (o *Options) WithPgsqlServerTLS(tls *tls.Config) *Options {
+	o.PgsqlServerTLSConfig = tls
+	return o
+}
+
+// WithPgsqlServerRequireSSL makes the pgsql server refuse clients not negotiating SSL
+func (o *Options) WithPgsqlServerRequireSSL(require bool) *Options {
+	o.PgsqlServerRequireSSL = require
+	return o
+}
+
+// WithPgsqlServerAuthMethod sets the authentication method of the pgsql clients: password (cleartext), md5 or scram-sha-256
+func (o *Options) WithPgsqlServerAuthMethod(method string) *Options {
+	o.PgsqlServerAuthMethod = method
+	return o
+}
+
+// PgsqlTLSConfig returns the tls config of the pgsql server
+func (o *Options) PgsqlTLSConfig() *tls.Config {
+	if o.PgsqlServerTLSConfig != nil {
+		return o.PgsqlServerTLSConfig
+	}
+	return o.TLSConfig
+}
+
+// WithDeletedDatabasesRetention sets the period deleted databases are kept before being purged, 0 means forever
+func (o *Options) WithDeletedDatabasesRetention(retention time.Duration) *Options {
+	o.DeletedDatabasesRetention = retention
+	return o
+}
+
+// WithTxLogDir sets the folder where the tx and commit logs of each database are placed within their own folder
+func (o *Options) WithTxLogDir(dir string) *Options {
+	o.TxLogDir = dir
+	return o
+}
+
+// WithValueLogDir sets the folder where the value logs of each database are placed within their own folder
+func (o *Options) WithValueLogDir(dir string) *Options {
+	o.ValueLogDir = dir
+	return o
+}
+
+// WithIndexDir sets the folder where the indexes of each database are placed within their own folder
+func (o *Options) WithIndexDir(dir string) *Options {
+	o.IndexDir = dir
+	return o
+}
+
+// hasSeparateDirs tells whether any database file is placed outside of the database folder
+func (o *Options) hasSeparateDirs() bool {
+	return o.TxLogDir != "" || o.ValueLogDir != "" || o.IndexDir != ""
+}
+
+// WithEncryptionKeysDir sets the folder holding the encryption key of each database,
+// keys of new databases are generated and stored into it
+func (o *Options) WithEncryptionKeysDir(dir string) *Options {
+	o.EncryptionKeysDir = dir
+	return o
+}
+
+// WithEncryptionKeyProvider sets the URL of the key provider wrapping the encryption key of each database
+// e.g. file:///etc/immudb/master.keys, vault://transit/immudb or awskms://alias/immudb
+func (o *Options) WithEncryptionKeyProvider(url string) *Options {
+	o.EncryptionKeyProvider = url
+	return o
+}
+
+// WithMetricsDatabases sets the databases having their own label on per-database metrics,
+// metrics of the remaining ones are reported together
+func (o *Options) WithMetricsDatabases(databases ...string) *Options {
+	o.MetricsDatabases = databases
+	return o
+}
+
+// WithMetricsMaxDatabases sets the maximum number of databases having their own label on per-database metrics
+func (o *Options) WithMetricsMaxDatabases(maxDatabases int) *Options {
+	o.MetricsMaxDatabases = maxDatabases
+	return o
+}
+
+// WithMetricsAggregatedOnly sets whether per-database metrics are only reported aggregated over all databases
+func (o *Options) WithMetricsAggregatedOnly(aggregatedOnly bool) *Options {
+	o.MetricsAggregatedOnly = aggregatedOnly
+	return o
+}
+
+// WithRecordTraceIDs sets whether the W3C trace-id of write requests is recorded into the committed tx
+func (o *Options) WithRecordTraceIDs(recordTraceIDs bool) *Options {
+	o.RecordTraceIDs = recordTraceIDs
+	return o
+}
+
+// WithMaxRequestsPerApplication sets the maximum number of concurrent requests of each application,
+// requests not tagged with an application name are not limited
+func (o *Options) WithMaxRequestsPerApplication(maxRequests int) *Options {
+	o.MaxRequestsPerApplication = maxRequests
+	return o
+}
+
+// WithApplicationQuotas sets the maximum number of concurrent requests of some applications,
+// overriding the one set for every application
+func (o *Options) WithApplicationQuotas(quotas map[string]int) *Options {
+	o.ApplicationQuotas = quotas
+	return o
+}
+
+// WithFeatures sets the features enabled on top of the ones enabled by default
+func (o *Options) WithFeatures(features ...string) *Options {
+	o.Features = features
+	return o
+}
+
+// WithSkipStartupChecks starts the server without checking the environment and probing the databases first
+func (o *Options) WithSkipStartupChecks(skip bool) *Options {
+	o.SkipStartupChecks = skip
+	return o
+}
+
+// WithSQLMaxRetries sets how many times idempotent single-statement writes conflicting with concurrent txs are retried
+func (o *Options) WithSQLMaxRetries(maxRetries int) *Options {
+	o.SQLMaxRetries = maxRetries
+	return o
+}
+
+// WithSQLRetryBackoff sets the maximum period waited before the first retry of a conflicting write, doubled on every retry
+func (o *Options) WithSQLRetryBackoff(backoff time.Duration) *Options {
+	o.SQLRetryBackoff = backoff
+	return o
+}
+
+// WithTableSyncs sets the table sync jobs run by the server
+func (o *Options) WithTableSyncs(tableSyncs ...*TableSyncOptions) *Options {
+	o.TableSyncs = tableSyncs
+	return o
+}
+
+// WithReplicationOptions makes the server a replica of the primary set in the replication options
+func (o *Options) WithReplicationOptions(replicationOptions *ReplicationOptions) *Options {
+	o.ReplicationOptions = replicationOptions
+	return o
+}
+
+// IsReplica returns true if the server replicates the databases of a primary server
+func (o *Options) IsReplica() bool {
+	return o.ReplicationOptions != nil
+}
+
+// IsFeatureEnabled returns true if the feature is enabled by default or it was explicitly enabled
+func (o *Options) IsFeatureEnabled(f Feature) bool {
+	if enabledByDefault, known := knownFeatures[f]; !known {
+		return false
+	} else if enabledByDefault {
+		return true
+	}
+
+	for _, name := range o.Features {
+		if featureFrom(name) == f {
+			return true
+		}
+	}
+
+	return false
+}
@@ -19,6 +19,7 @@ package server
 import (
 	"crypto/tls"
 	"testing"
+	"time"
 
 	"github.com/codenotary/immudb/embedded/store"
 	"github.com/codenotary/immudb/pkg/stream"
@@ -68,6 +69,8 @@ func TestSetOptions(t *testing.T) {
 		WithTokenExpiryTime(52).
 		WithWebServer(false).
 		WithStoreOptions(storeOptions).
+		WithSQLMaxRetries(5).
+		WithSQLRetryBackoff(time.Second).
 		WithTLS(tlsConfig)
 
 	if op.GetAuth() != false ||
@@ -92,6 +95,8 @@ func TestSetOptions(t *testing.T) {
 		op.WebServer != false ||
 		op.StoreOptions != storeOptions ||
 		op.TLSConfig != tlsConfig ||
+		op.SQLMaxRetries != 5 ||
+		op.SQLRetryBackoff != time.Second ||
 		op.TokenExpiryTimeMin != 52 {
 		t.Errorf("database default options mismatch")
 	}
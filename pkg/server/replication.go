@@ -0,0 +1,80 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+)
+
+// ExportTx streams a committed tx together with its values, replicas pull the txs of their primary through it
+func (s *ImmuServer) ExportTx(req *schema.TxRequest, str schema.ImmuService_ExportTxServer) error {
+	if !s.Options.IsFeatureEnabled(FeatureAsyncReplication) {
+		return ErrFeatureDisabled
+	}
+
+	db, err := s.getDbFromCtx(str.Context(), "ExportTx")
+	if err != nil {
+		return err
+	}
+	defer s.dbList.Release(db)
+
+	exportedTx, err := db.ExportTx(req)
+	if err != nil {
+		return err
+	}
+
+	return s.StreamServiceFactory.NewMsgSender(str).Send(bytes.NewReader(exportedTx), len(exportedTx))
+}
+
+// ReplicationLag returns the number of txs the database was behind its primary after the latest replication round
+func (s *ImmuServer) ReplicationLag(dbName string) uint64 {
+	if s.replicator == nil {
+		return 0
+	}
+
+	return s.replicator.lag(dbName)
+}
+
+func (s *ImmuServer) startReplication() error {
+	if !s.Options.IsReplica() {
+		return nil
+	}
+
+	s.replicator = newReplicator(s, s.Options.ReplicationOptions)
+
+	err := s.replicator.start()
+	if err != nil {
+		return logErr(s.Logger, "Unable to start replication: %v", err)
+	}
+
+	s.Logger.Infof("Replicating databases from %s:%d", s.Options.ReplicationOptions.MasterAddress, s.Options.ReplicationOptions.MasterPort)
+
+	return nil
+}
+
+func (s *ImmuServer) stopReplication() error {
+	if s.replicator == nil {
+		return nil
+	}
+
+	err := s.replicator.stop()
+	s.replicator = nil
+
+	return err
+}
@@ -0,0 +1,328 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/database"
+	"github.com/codenotary/immudb/pkg/stream"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// replicationBatchSize is the maximum number of txs verified and replicated at once
+const replicationBatchSize = 100
+
+// replicator pulls the txs committed into the databases of the primary server and replicates them
+// into the local databases with the same name. Txs are replicated only once the primary has proven
+// its history extends the one of the replica.
+// Databases need to be created in the replica as well, neither users nor the SQL catalog are replicated
+// as they are held by the system database.
+type replicator struct {
+	s    *ImmuServer
+	opts *ReplicationOptions
+
+	dialOpts []grpc.DialOption
+	conn     *grpc.ClientConn
+	client   schema.ImmuServiceClient
+
+	// tokens holds the token used to access each database of the primary
+	tokens map[string]string
+
+	lags      map[string]uint64
+	lagsMutex sync.RWMutex
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newReplicator(s *ImmuServer, opts *ReplicationOptions, dialOpts ...grpc.DialOption) *replicator {
+	return &replicator{
+		s:        s,
+		opts:     opts,
+		dialOpts: append([]grpc.DialOption{grpc.WithInsecure()}, dialOpts...),
+		tokens:   make(map[string]string),
+		lags:     make(map[string]uint64),
+	}
+}
+
+// start connects to the primary server and replicates its txs in background until stopped
+func (r *replicator) start() error {
+	conn, err := grpc.Dial(fmt.Sprintf("%s:%d", r.opts.MasterAddress, r.opts.MasterPort), r.dialOpts...)
+	if err != nil {
+		return err
+	}
+
+	r.conn = conn
+	r.client = schema.NewImmuServiceClient(conn)
+
+	r.ctx, r.cancel = context.WithCancel(context.Background())
+
+	r.wg.Add(1)
+	go r.run()
+
+	return nil
+}
+
+func (r *replicator) stop() error {
+	r.cancel()
+	r.wg.Wait()
+
+	return r.conn.Close()
+}
+
+func (r *replicator) run() {
+	defer r.wg.Done()
+
+	for {
+		caughtUp := r.replicateAll()
+
+		if !caughtUp {
+			if r.ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-time.After(r.opts.PollInterval):
+		}
+	}
+}
+
+// replicateAll makes a replication round over all the databases, it returns true
+// if no tx was replicated i.e. all of them were up to date or could not be replicated
+func (r *replicator) replicateAll() bool {
+	caughtUp := true
+
+	lags := make(map[string]uint64)
+	lagMetrics := make(map[string]float64)
+
+	for i := 0; i < r.s.dbList.Length(); i++ {
+		db, err := r.s.dbList.Acquire(int64(i))
+		if err != nil {
+			// deleted database
+			continue
+		}
+
+		replicated, lag, err := r.replicateDB(db)
+		r.s.dbList.Release(db)
+		if err != nil {
+			r.s.Logger.Warningf("Unable to replicate database '%s': %v", db.GetName(), err)
+			// the token is renewed as it may have expired
+			delete(r.tokens, db.GetName())
+		}
+
+		if replicated > 0 {
+			caughtUp = false
+		}
+
+		lags[db.GetName()] = lag
+		lagMetrics[db.GetName()] = float64(lag)
+	}
+
+	r.lagsMutex.Lock()
+	r.lags = lags
+	r.lagsMutex.Unlock()
+
+	Metrics.UpdateReplicationLags(lagMetrics)
+
+	return caughtUp
+}
+
+// replicateDB replicates the txs committed into the primary database since the latest replicated tx,
+// it returns the number of replicated txs and the number of txs the database is still behind the primary
+func (r *replicator) replicateDB(db database.DB) (replicated int, lag uint64, err error) {
+	ctx, err := r.contextFor(db.GetName())
+	if err != nil {
+		return 0, r.lag(db.GetName()), err
+	}
+
+	state, err := db.CurrentState()
+	if err != nil {
+		return 0, r.lag(db.GetName()), err
+	}
+
+	primaryState, err := r.client.CurrentState(ctx, &empty.Empty{})
+	if err != nil {
+		return 0, r.lag(db.GetName()), err
+	}
+
+	if primaryState.TxId < state.TxId {
+		return 0, 0, fmt.Errorf("%w: the replica holds more txs than the primary", ErrReplicaDiverged)
+	}
+
+	if primaryState.TxId == state.TxId {
+		if !bytes.Equal(primaryState.TxHash, state.TxHash) {
+			return 0, 0, ErrReplicaDiverged
+		}
+		return 0, 0, nil
+	}
+
+	lag = primaryState.TxId - state.TxId
+
+	// txs are replicated in batches, the ones of a batch are held in memory until all of them are verified
+	targetTxID := primaryState.TxId
+	if lag > replicationBatchSize {
+		targetTxID = state.TxId + replicationBatchSize
+	}
+
+	vtx, err := r.client.VerifiableTxById(ctx, &schema.VerifiableTxRequest{
+		Tx:           targetTxID,
+		ProveSinceTx: state.TxId,
+	})
+	if err != nil {
+		return 0, lag, err
+	}
+
+	if vtx.DualProof == nil || vtx.Tx == nil || vtx.Tx.Metadata == nil || vtx.Tx.Metadata.Id != targetTxID {
+		return 0, lag, store.ErrCorruptedData
+	}
+
+	dualProof := schema.DualProofFrom(vtx.DualProof)
+	targetAlh := dualProof.TargetTxMetadata.Alh()
+
+	if state.TxId > 0 {
+		verifies := store.VerifyDualProof(
+			dualProof,
+			state.TxId,
+			targetTxID,
+			schema.DigestFrom(state.TxHash),
+			targetAlh,
+		)
+		if !verifies {
+			return 0, lag, fmt.Errorf("%w: the primary could not prove its history extends the one of the replica", ErrReplicaDiverged)
+		}
+	}
+
+	// each tx must be linked to the previous one, from the latest tx of the replica up to the proven tx,
+	// thus no tx gets replicated unless it belongs to the proven history of the primary
+	exportedTxs := make([][]byte, 0, targetTxID-state.TxId)
+	prevAlh := schema.DigestFrom(state.TxHash)
+
+	for txID := state.TxId + 1; txID <= targetTxID; txID++ {
+		if r.ctx.Err() != nil {
+			return 0, lag, nil
+		}
+
+		exportedTx, err := r.fetchTx(ctx, txID)
+		if err != nil {
+			return 0, lag, err
+		}
+
+		md, err := db.ExportedTxMetadata(exportedTx)
+		if err != nil {
+			return 0, lag, err
+		}
+
+		txMetadata := schema.TxMetadataFrom(md)
+
+		if txMetadata.ID != txID || txMetadata.PrevAlh != prevAlh {
+			return 0, lag, fmt.Errorf("%w: tx %d is not linked to the history of the replica", ErrReplicaDiverged, txID)
+		}
+
+		prevAlh = txMetadata.Alh()
+
+		exportedTxs = append(exportedTxs, exportedTx)
+	}
+
+	if prevAlh != targetAlh {
+		return 0, lag, fmt.Errorf("%w: exported txs do not match the proven state of the primary", ErrReplicaDiverged)
+	}
+
+	for _, exportedTx := range exportedTxs {
+		_, err = db.ReplicateTx(exportedTx)
+		if err != nil {
+			return replicated, lag, err
+		}
+
+		replicated++
+		lag--
+	}
+
+	// txs committed meanwhile into the primary will be replicated in the next round
+	return replicated, lag, nil
+}
+
+func (r *replicator) fetchTx(ctx context.Context, txID uint64) ([]byte, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	str, err := r.client.ExportTx(ctx, &schema.TxRequest{Tx: txID})
+	if err != nil {
+		return nil, err
+	}
+
+	return stream.ReadValue(stream.NewMsgReceiver(str), r.s.Options.StreamChunkSize)
+}
+
+// contextFor returns the context used to access the primary database, the replica logs in and
+// selects the database the first time it's accessed
+func (r *replicator) contextFor(dbName string) (context.Context, error) {
+	if r.opts.FollowerUsername == "" {
+		// authentication is disabled in the primary, thus no database can be selected
+		// and calls are resolved against its default database
+		if dbName != DefaultdbName {
+			return nil, ErrReplicationAuthRequired
+		}
+
+		return r.ctx, nil
+	}
+
+	token, ok := r.tokens[dbName]
+
+	if !ok {
+		lr, err := r.client.Login(r.ctx, &schema.LoginRequest{
+			User:     []byte(r.opts.FollowerUsername),
+			Password: []byte(r.opts.FollowerPassword),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		ctx := metadata.NewOutgoingContext(r.ctx, metadata.Pairs("authorization", lr.Token))
+
+		ur, err := r.client.UseDatabase(ctx, &schema.Database{DatabaseName: dbName})
+		if err != nil {
+			return nil, err
+		}
+
+		token = ur.Token
+		r.tokens[dbName] = token
+	}
+
+	return metadata.NewOutgoingContext(r.ctx, metadata.Pairs("authorization", token)), nil
+}
+
+// lag returns the number of txs the database was behind the primary after the latest replication round
+func (r *replicator) lag(dbName string) uint64 {
+	r.lagsMutex.RLock()
+	defer r.lagsMutex.RUnlock()
+
+	return r.lags[dbName]
+}
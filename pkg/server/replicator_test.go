@@ -0,0 +1,180 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	"github.com/codenotary/immudb/pkg/database"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestReplication(t *testing.T) {
+	primaryDir := "data_replication_primary"
+	defer os.RemoveAll(primaryDir)
+
+	replicaDir := "data_replication_replica"
+	defer os.RemoveAll(replicaDir)
+
+	primaryListener := bufconn.Listen(1024 * 1024)
+
+	primary := DefaultServer().WithOptions(DefaultOptions().
+		WithDir(primaryDir).
+		WithListener(primaryListener).
+		WithTLS(nil).
+		WithMetricsServer(false).
+		WithAdminPassword(auth.SysAdminPassword).
+		WithFeatures(string(FeatureAsyncReplication)),
+	).(*ImmuServer)
+
+	err := primary.Initialize()
+	require.NoError(t, err)
+
+	go primary.GrpcServer.Serve(primaryListener)
+	defer primary.GrpcServer.Stop()
+	defer primary.CloseDatabases()
+
+	replicationOpts := DefaultReplicationOptions().
+		WithFollowerUsername(auth.SysAdminUsername).
+		WithFollowerPassword(auth.SysAdminPassword).
+		WithPollInterval(10 * time.Millisecond)
+
+	replicaOpts := DefaultOptions().
+		WithDir(replicaDir).
+		WithListener(bufconn.Listen(1024 * 1024)).
+		WithMetricsServer(false).
+		WithAdminPassword(auth.SysAdminPassword).
+		WithReplicationOptions(replicationOpts)
+
+	replica := DefaultServer().WithOptions(replicaOpts).(*ImmuServer)
+
+	err = replica.Initialize()
+	require.True(t, errors.Is(err, ErrFeatureDisabled))
+
+	replica = DefaultServer().WithOptions(replicaOpts.WithFeatures(string(FeatureAsyncReplication))).(*ImmuServer)
+
+	err = replica.Initialize()
+	require.NoError(t, err)
+	defer replica.CloseDatabases()
+
+	primaryCtx := loginForReplication(t, primary, DefaultdbName)
+	replicaCtx := loginForReplication(t, replica, DefaultdbName)
+
+	_, err = primary.CreateDatabase(primaryCtx, &schema.Database{DatabaseName: "db1"})
+	require.NoError(t, err)
+
+	_, err = replica.CreateDatabase(replicaCtx, &schema.Database{DatabaseName: "db1"})
+	require.NoError(t, err)
+
+	primaryDb1Ctx := loginForReplication(t, primary, "db1")
+	replicaDb1Ctx := loginForReplication(t, replica, "db1")
+
+	// txs are replicated in more than one batch
+	for i := 0; i < replicationBatchSize+10; i++ {
+		_, err = primary.Set(primaryCtx, &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte(fmt.Sprintf("key%d", i)), Value: []byte(fmt.Sprintf("value%d", i))}}})
+		require.NoError(t, err)
+	}
+
+	_, err = primary.Set(primaryDb1Ctx, &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key"), Value: []byte("db1")}}})
+	require.NoError(t, err)
+
+	replica.replicator = newReplicator(replica, replicationOpts, grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+		return primaryListener.Dial()
+	}))
+
+	err = replica.replicator.start()
+	require.NoError(t, err)
+
+	// replicas are up to date once they hold the same state of their primary
+	waitForReplication := func(primaryCtx, replicaCtx context.Context) uint64 {
+		primaryState, err := primary.CurrentState(primaryCtx, &empty.Empty{})
+		require.NoError(t, err)
+
+		require.Eventually(t, func() bool {
+			replicaState, err := replica.CurrentState(replicaCtx, &empty.Empty{})
+			require.NoError(t, err)
+			return replicaState.TxId == primaryState.TxId
+		}, 10*time.Second, 10*time.Millisecond)
+
+		replicaState, err := replica.CurrentState(replicaCtx, &empty.Empty{})
+		require.NoError(t, err)
+		require.Equal(t, primaryState.TxHash, replicaState.TxHash)
+
+		return replicaState.TxId
+	}
+
+	txID := waitForReplication(primaryCtx, replicaCtx)
+	db1TxID := waitForReplication(primaryDb1Ctx, replicaDb1Ctx)
+
+	// replicated txs are indexed asynchronously
+	entry, err := replica.Get(replicaCtx, &schema.KeyRequest{Key: []byte("key9"), SinceTx: txID})
+	require.NoError(t, err)
+	require.Equal(t, []byte("value9"), entry.Value)
+
+	entry, err = replica.Get(replicaDb1Ctx, &schema.KeyRequest{Key: []byte("key"), SinceTx: db1TxID})
+	require.NoError(t, err)
+	require.Equal(t, []byte("db1"), entry.Value)
+
+	// txs committed afterwards are replicated as well
+	_, err = primary.Set(primaryCtx, &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key9"), Value: []byte("value10")}}})
+	require.NoError(t, err)
+
+	waitForReplication(primaryCtx, replicaCtx)
+
+	require.Eventually(t, func() bool { return replica.ReplicationLag(DefaultdbName) == 0 }, 10*time.Second, 10*time.Millisecond)
+
+	_, err = replica.Set(replicaCtx, &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key"), Value: []byte("replica")}}})
+	require.Equal(t, database.ErrIsReplica, err)
+
+	err = DefaultServer().ExportTx(&schema.TxRequest{Tx: 1}, nil)
+	require.Equal(t, ErrFeatureDisabled, err)
+
+	err = replica.stopReplication()
+	require.NoError(t, err)
+	require.Zero(t, replica.ReplicationLag(DefaultdbName))
+
+	// without credentials no database of the primary can be selected
+	_, err = newReplicator(replica, DefaultReplicationOptions()).contextFor("db1")
+	require.Equal(t, ErrReplicationAuthRequired, err)
+}
+
+func loginForReplication(t *testing.T, s *ImmuServer, dbName string) context.Context {
+	lr, err := s.Login(context.Background(), &schema.LoginRequest{
+		User:     []byte(auth.SysAdminUsername),
+		Password: []byte(auth.SysAdminPassword),
+	})
+	require.NoError(t, err)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", lr.Token))
+
+	ur, err := s.UseDatabase(ctx, &schema.Database{DatabaseName: dbName})
+	require.NoError(t, err)
+
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", ur.Token))
+}
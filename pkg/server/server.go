@@ -38,6 +38,7 @@ import (
 	"github.com/codenotary/immudb/pkg/stream"
 
 	"github.com/codenotary/immudb/pkg/database"
+	"github.com/codenotary/immudb/pkg/kms"
 
 	"github.com/codenotary/immudb/pkg/logger"
 	"github.com/codenotary/immudb/pkg/signer"
@@ -58,6 +59,10 @@ import (
 const (
 	//KeyPrefixUser All user keys in the key/value store are prefixed by this keys to distinguish them from keys that have other purposes
 	KeyPrefixUser = iota + 1
+	//KeyPrefixPasswordReset All password reset tokens of the users are prefixed by this key
+	KeyPrefixPasswordReset
+	//KeyPrefixMultiDbTx All decision records of multi-database txs not yet committed into all of their databases are prefixed by this key
+	KeyPrefixMultiDbTx
 )
 
 var startedAt time.Time
@@ -88,8 +93,37 @@ func (s *ImmuServer) Initialize() error {
 		return ErrEmptyAdminPassword
 	}
 
+	if err = validateFeatures(s.Options.Features); err != nil {
+		return logErr(s.Logger, "Invalid server options: %v", err)
+	}
+
+	if s.Options.IsReplica() && !s.Options.IsFeatureEnabled(FeatureAsyncReplication) {
+		return logErr(s.Logger, "Invalid server options: %v", fmt.Errorf("%w: replicas require the '%s' feature", ErrFeatureDisabled, FeatureAsyncReplication))
+	}
+
+	if s.Options.EncryptionKeyProvider != "" {
+		if s.Options.EncryptionKeysDir == "" {
+			return logErr(s.Logger, "Invalid server options: %v", fmt.Errorf("%w: a key provider requires an encryption keys folder", ErrIllegalArguments))
+		}
+
+		s.keyProvider, err = kms.NewKeyProvider(s.Options.EncryptionKeyProvider)
+		if err != nil {
+			return logErr(s.Logger, "Invalid server options: %v", err)
+		}
+	}
+
+	if !s.Options.SkipStartupChecks {
+		if err = s.checkEnvironment(); err != nil {
+			return logErr(s.Logger, "Unable to start: %v", err)
+		}
+	}
+
 	dataDir := s.Options.Dir
 
+	if err = s.recoverShrunkDatabases(); err != nil {
+		return logErr(s.Logger, "Unable to recover shrunk databases: %v", err)
+	}
+
 	if err = s.loadSystemDatabase(dataDir, adminPassword); err != nil {
 		return logErr(s.Logger, "Unable load system database: %v", err)
 	}
@@ -102,6 +136,20 @@ func (s *ImmuServer) Initialize() error {
 		return logErr(s.Logger, "Unable load databases: %v", err)
 	}
 
+	if err = s.recoverMultiDbTxs(); err != nil {
+		return logErr(s.Logger, "Unable to recover multi-database txs: %v", err)
+	}
+
+	if err = s.purgeDeletedDatabases(); err != nil {
+		return logErr(s.Logger, "Unable to purge deleted databases: %v", err)
+	}
+
+	if !s.Options.SkipStartupChecks {
+		if err = s.probeDatabases(); err != nil {
+			return logErr(s.Logger, "Unable to start: %v", err)
+		}
+	}
+
 	s.multidbmode = s.mandatoryAuth()
 	if !s.Options.GetAuth() && s.multidbmode {
 		s.Logger.Infof("Authentication must be on.")
@@ -167,17 +215,21 @@ func (s *ImmuServer) Initialize() error {
 
 	uuidContext := NewUUIDContext(s.UUID)
 
+	s.applications = newApplications(s.Options.MaxRequestsPerApplication, s.Options.ApplicationQuotas)
+
 	uis := []grpc.UnaryServerInterceptor{
 		ErrorMapper, // converts errors in gRPC ones. Need to be the first
 		uuidContext.UUIDContextSetter,
 		grpc_prometheus.UnaryServerInterceptor,
 		auth.ServerUnaryInterceptor,
+		s.ApplicationInterceptor,
 	}
 	sss := []grpc.StreamServerInterceptor{
 		ErrorMapperStream, // converts errors in gRPC ones. Need to be the first
 		uuidContext.UUIDStreamContextSetter,
 		grpc_prometheus.StreamServerInterceptor,
 		auth.ServerStreamInterceptor,
+		s.ApplicationStreamInterceptor,
 	}
 	grpcSrvOpts = append(
 		grpcSrvOpts,
@@ -190,7 +242,7 @@ func (s *ImmuServer) Initialize() error {
 	schema.RegisterImmuServiceServer(s.GrpcServer, s)
 	grpc_prometheus.Register(s.GrpcServer)
 
-	s.PgsqlSrv = pgsqlsrv.New(pgsqlsrv.Port(s.Options.PgsqlServerPort), pgsqlsrv.DatabaseList(s.dbList), pgsqlsrv.SysDb(s.sysDb), pgsqlsrv.TlsConfig(s.Options.TLSConfig))
+	s.PgsqlSrv = pgsqlsrv.New(pgsqlsrv.Port(s.Options.PgsqlServerPort), pgsqlsrv.DatabaseList(s.dbList), pgsqlsrv.SysDb(s.sysDb), pgsqlsrv.TlsConfig(s.Options.PgsqlTLSConfig()), pgsqlsrv.RequireSSL(s.Options.PgsqlServerRequireSSL), pgsqlsrv.AuthMethod(s.Options.PgsqlServerAuthMethod), pgsqlsrv.DatabaseCreator(s.createPgsqlDatabase))
 	if s.Options.PgsqlServer {
 		if err = s.PgsqlSrv.Initialize(); err != nil {
 			return err
@@ -251,6 +303,14 @@ func (s *ImmuServer) Start() (err error) {
 		}()
 	}
 
+	if err := s.startReplication(); err != nil {
+		return err
+	}
+
+	if err := s.startTableSyncs(); err != nil {
+		return err
+	}
+
 	s.mux.Unlock()
 	s.pgsqlMux.Unlock()
 	<-s.quit
@@ -276,12 +336,19 @@ func (s *ImmuServer) setupPidFile() error {
 }
 
 func (s *ImmuServer) setUpMetricsServer() error {
+	Metrics.WithDatabaseLabels(
+		s.Options.MetricsDatabases,
+		s.Options.MetricsMaxDatabases,
+		s.Options.MetricsAggregatedOnly,
+	)
+
 	s.metricsServer = StartMetrics(
 		s.Options.MetricsBind(),
 		s.Logger,
 		s.metricFuncServerUptimeCounter,
 		s.metricFuncComputeDBSizes,
 		s.metricFuncComputeDBEntries,
+		s.metricFuncComputeStoreMetrics,
 	)
 	return nil
 }
@@ -324,7 +391,12 @@ func (s *ImmuServer) loadSystemDatabase(dataDir string, adminPassword string) er
 
 	systemDbRootDir := s.OS.Join(dataDir, s.Options.GetSystemAdminDbName())
 
-	storeOpts := DefaultStoreOptions().WithSynced(true)
+	_, sysDbErr := s.OS.Stat(systemDbRootDir)
+
+	storeOpts, err := s.storeOptionsFor(s.Options.GetSystemAdminDbName(), DefaultStoreOptions().WithSynced(true), s.OS.IsNotExist(sysDbErr))
+	if err != nil {
+		return err
+	}
 
 	op := database.DefaultOption().
 		WithDbName(s.Options.GetSystemAdminDbName()).
@@ -332,7 +404,6 @@ func (s *ImmuServer) loadSystemDatabase(dataDir string, adminPassword string) er
 		WithDbRootPath(s.Options.Dir).
 		WithStoreOptions(storeOpts)
 
-	_, sysDbErr := s.OS.Stat(systemDbRootDir)
 	if s.OS.IsNotExist(sysDbErr) {
 		db, err := database.NewDb(op, nil, s.Logger)
 		if err != nil {
@@ -367,13 +438,21 @@ func (s *ImmuServer) loadDefaultDatabase(dataDir string) error {
 
 	defaultDbRootDir := s.OS.Join(dataDir, s.Options.GetDefaultDbName())
 
+	_, defaultDbErr := s.OS.Stat(defaultDbRootDir)
+
+	storeOpts, err := s.storeOptionsFor(s.Options.GetDefaultDbName(), s.Options.StoreOptions, s.OS.IsNotExist(defaultDbErr))
+	if err != nil {
+		return err
+	}
+
 	op := database.DefaultOption().
 		WithDbName(s.Options.GetDefaultDbName()).
 		WithDbRootPath(dataDir).
 		WithDbRootPath(s.Options.Dir).
-		WithStoreOptions(s.Options.StoreOptions)
+		WithStoreOptions(storeOpts).
+		WithReplica(s.Options.IsReplica()).
+		WithSQLRetries(s.Options.SQLMaxRetries, s.Options.SQLRetryBackoff)
 
-	_, defaultDbErr := s.OS.Stat(defaultDbRootDir)
 	if s.OS.IsNotExist(defaultDbErr) {
 		db, err := database.NewDb(op, s.sysDb, s.Logger)
 		if err != nil {
@@ -405,7 +484,9 @@ func (s *ImmuServer) loadUserDatabases(dataDir string) error {
 	for _, f := range files {
 		if !f.IsDir() ||
 			f.Name() == s.Options.GetSystemAdminDbName() ||
-			f.Name() == s.Options.GetDefaultDbName() {
+			f.Name() == s.Options.GetDefaultDbName() ||
+			f.Name() == deletedDatabasesDir ||
+			f.Name() == shrinkingDatabasesDir {
 			continue
 		}
 
@@ -419,11 +500,18 @@ func (s *ImmuServer) loadUserDatabases(dataDir string) error {
 		pathparts := strings.Split(val, string(filepath.Separator))
 		dbname := pathparts[len(pathparts)-1]
 
+		storeOpts, err := s.storeOptionsFor(dbname, s.Options.StoreOptions, false)
+		if err != nil {
+			return err
+		}
+
 		op := database.DefaultOption().
 			WithDbName(dbname).
 			WithDbRootPath(dataDir).
 			WithDbRootPath(s.Options.Dir).
-			WithStoreOptions(s.Options.StoreOptions)
+			WithStoreOptions(storeOpts).
+			WithReplica(s.Options.IsReplica()).
+			WithSQLRetries(s.Options.SQLMaxRetries, s.Options.SQLRetryBackoff)
 
 		db, err := database.OpenDb(op, s.sysDb, s.Logger)
 		if err != nil {
@@ -450,6 +538,12 @@ func (s *ImmuServer) Stop() error {
 		defer func() { s.GrpcServer = nil }()
 	}
 
+	err := s.stopReplication()
+	logErr(s.Logger, "Unable to stop replication: %v", err)
+
+	err = s.stopTableSyncs()
+	logErr(s.Logger, "Unable to stop table syncs: %v", err)
+
 	return s.CloseDatabases()
 }
 
@@ -457,6 +551,10 @@ func (s *ImmuServer) Stop() error {
 func (s *ImmuServer) CloseDatabases() error {
 	for i := 0; i < s.dbList.Length(); i++ {
 		val := s.dbList.GetByIndex(int64(i))
+		if val == nil {
+			// deleted database
+			continue
+		}
 		val.Close()
 	}
 
@@ -562,7 +660,7 @@ func (s *ImmuServer) updateConfigItem(key string, newOrUpdatedLine string, uncha
 
 // UpdateAuthConfig ...
 func (s *ImmuServer) UpdateAuthConfig(ctx context.Context, req *schema.AuthConfig) (*empty.Empty, error) {
-	_, err := s.getDbIndexFromCtx(ctx, "UpdateAuthConfig")
+	err := s.checkMethodAccessFromCtx(ctx, "UpdateAuthConfig")
 	if err != nil {
 		return nil, err
 	}
@@ -591,7 +689,7 @@ func (s *ImmuServer) UpdateAuthConfig(ctx context.Context, req *schema.AuthConfi
 
 // UpdateMTLSConfig ...
 func (s *ImmuServer) UpdateMTLSConfig(ctx context.Context, req *schema.MTLSConfig) (*empty.Empty, error) {
-	_, err := s.getDbIndexFromCtx(ctx, "UpdateMTLSConfig")
+	err := s.checkMethodAccessFromCtx(ctx, "UpdateMTLSConfig")
 	if err != nil {
 		return nil, err
 	}
@@ -617,28 +715,49 @@ func (s *ImmuServer) UpdateMTLSConfig(ctx context.Context, req *schema.MTLSConfi
 
 // Health ...
 func (s *ImmuServer) Health(ctx context.Context, e *empty.Empty) (*schema.HealthResponse, error) {
-	ind, _ := s.getDbIndexFromCtx(ctx, "Health")
-
-	if ind < 0 { //probably immuclient hasn't logged in yet
+	db, err := s.getDbFromCtx(ctx, "Health")
+	if err != nil { //probably immuclient hasn't logged in yet
 		return s.dbList.GetByIndex(DefaultDbIndex).Health(e)
 	}
+	defer s.dbList.Release(db)
 
-	return s.dbList.GetByIndex(ind).Health(e)
+	return db.Health(e)
+}
+
+// ServerInfo returns information about the server such as its version and the state of its features
+func (s *ImmuServer) ServerInfo(ctx context.Context, req *schema.ServerInfoRequest) (*schema.ServerInfoResponse, error) {
+	knownFeatures := KnownFeatures()
+
+	features := make([]*schema.ServerFeature, len(knownFeatures))
+
+	for i, f := range knownFeatures {
+		features[i] = &schema.ServerFeature{
+			Name:         string(f),
+			Enabled:      s.Options.IsFeatureEnabled(f),
+			Experimental: f.IsExperimental(),
+		}
+	}
+
+	return &schema.ServerInfoResponse{
+		Version:  version.Version,
+		Features: features,
+	}, nil
 }
 
 // CurrentState ...
 func (s *ImmuServer) CurrentState(ctx context.Context, e *empty.Empty) (*schema.ImmutableState, error) {
-	ind, err := s.getDbIndexFromCtx(ctx, "CurrentState")
+	db, err := s.getDbFromCtx(ctx, "CurrentState")
 	if err != nil {
 		return nil, err
 	}
+	defer s.dbList.Release(db)
 
-	state, err := s.dbList.GetByIndex(ind).CurrentState()
+	state, err := db.CurrentState()
 	if err != nil {
 		return nil, err
 	}
 
-	state.Db = s.dbList.GetByIndex(ind).GetOptions().GetDbName()
+	state.Db = db.GetOptions().GetDbName()
 
 	if s.Options.SigningKey != "" {
 		err = s.StateSigner.Sign(state)
@@ -652,23 +771,37 @@ func (s *ImmuServer) CurrentState(ctx context.Context, e *empty.Empty) (*schema.
 
 // Set ...
 func (s *ImmuServer) Set(ctx context.Context, kv *schema.SetRequest) (*schema.TxMetadata, error) {
-	ind, err := s.getDbIndexFromCtx(ctx, "Set")
-
+	db, err := s.getDbFromCtx(ctx, "Set")
 	if err != nil {
 		return nil, err
 	}
+	defer s.dbList.Release(db)
+
+	if kv != nil {
+		kv.TraceID = s.traceIDFor(ctx, kv.TraceID)
+	}
+
+	md, err := db.Set(ctx, kv)
+	if errors.Is(err, database.ErrPreconditionFailed) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
 
-	return s.dbList.GetByIndex(ind).Set(kv)
+	return md, err
 }
 
 // VerifiableSet ...
 func (s *ImmuServer) VerifiableSet(ctx context.Context, req *schema.VerifiableSetRequest) (*schema.VerifiableTx, error) {
-	ind, err := s.getDbIndexFromCtx(ctx, "VerifiableSet")
+	db, err := s.getDbFromCtx(ctx, "VerifiableSet")
 	if err != nil {
 		return nil, err
 	}
+	defer s.dbList.Release(db)
+
+	if req != nil && req.SetRequest != nil {
+		req.SetRequest.TraceID = s.traceIDFor(ctx, req.SetRequest.TraceID)
+	}
 
-	vtx, err := s.dbList.GetByIndex(ind).VerifiableSet(req)
+	vtx, err := db.VerifiableSet(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -678,7 +811,7 @@ func (s *ImmuServer) VerifiableSet(ctx context.Context, req *schema.VerifiableSe
 		alh := md.Alh()
 
 		newState := &schema.ImmutableState{
-			Db:     s.dbList.GetByIndex(ind).GetOptions().GetDbName(),
+			Db:     db.GetOptions().GetDbName(),
 			TxId:   md.ID,
 			TxHash: alh[:],
 		}
@@ -696,22 +829,58 @@ func (s *ImmuServer) VerifiableSet(ctx context.Context, req *schema.VerifiableSe
 
 // Get ...
 func (s *ImmuServer) Get(ctx context.Context, req *schema.KeyRequest) (*schema.Entry, error) {
-	ind, err := s.getDbIndexFromCtx(ctx, "Get")
+	db, err := s.getDbFromCtx(ctx, "Get")
 	if err != nil {
 		return nil, err
 	}
+	defer s.dbList.Release(db)
 
-	return s.dbList.GetByIndex(ind).Get(req)
+	return db.Get(ctx, req)
 }
 
 // VerifiableGet ...
 func (s *ImmuServer) VerifiableGet(ctx context.Context, req *schema.VerifiableGetRequest) (*schema.VerifiableEntry, error) {
-	ind, err := s.getDbIndexFromCtx(ctx, "VerifiableGet")
+	db, err := s.getDbFromCtx(ctx, "VerifiableGet")
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbList.Release(db)
+
+	vEntry, err := db.VerifiableGet(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.Options.SigningKey != "" {
+		md := schema.TxMetadataFrom(vEntry.VerifiableTx.DualProof.TargetTxMetadata)
+		alh := md.Alh()
+
+		newState := &schema.ImmutableState{
+			Db:     db.GetOptions().GetDbName(),
+			TxId:   md.ID,
+			TxHash: alh[:],
+		}
+
+		err = s.StateSigner.Sign(newState)
+		if err != nil {
+			return nil, err
+		}
+
+		vEntry.VerifiableTx.Signature = newState.Signature
+	}
+
+	return vEntry, nil
+}
+
+// VerifiableHistoricalGet ...
+func (s *ImmuServer) VerifiableHistoricalGet(ctx context.Context, req *schema.VerifiableHistoricalGetRequest) (*schema.VerifiableEntry, error) {
+	db, err := s.getDbFromCtx(ctx, "VerifiableHistoricalGet")
 	if err != nil {
 		return nil, err
 	}
+	defer s.dbList.Release(db)
 
-	vEntry, err := s.dbList.GetByIndex(ind).VerifiableGet(req)
+	vEntry, err := db.VerifiableHistoricalGet(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -721,7 +890,7 @@ func (s *ImmuServer) VerifiableGet(ctx context.Context, req *schema.VerifiableGe
 		alh := md.Alh()
 
 		newState := &schema.ImmutableState{
-			Db:     s.dbList.GetByIndex(ind).GetOptions().GetDbName(),
+			Db:     db.GetOptions().GetDbName(),
 			TxId:   md.ID,
 			TxHash: alh[:],
 		}
@@ -739,58 +908,75 @@ func (s *ImmuServer) VerifiableGet(ctx context.Context, req *schema.VerifiableGe
 
 // Scan ...
 func (s *ImmuServer) Scan(ctx context.Context, req *schema.ScanRequest) (*schema.Entries, error) {
-	ind, err := s.getDbIndexFromCtx(ctx, "Scan")
+	db, err := s.getDbFromCtx(ctx, "Scan")
 	if err != nil {
 		return nil, err
 	}
+	defer s.dbList.Release(db)
 
-	return s.dbList.GetByIndex(ind).Scan(req)
+	return db.Scan(ctx, req)
+}
+
+// IndexScan ...
+func (s *ImmuServer) IndexScan(ctx context.Context, req *schema.IndexScanRequest) (*schema.Entries, error) {
+	db, err := s.getDbFromCtx(ctx, "IndexScan")
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbList.Release(db)
+
+	return db.IndexScan(ctx, req)
 }
 
 // Count ...
 func (s *ImmuServer) Count(ctx context.Context, prefix *schema.KeyPrefix) (*schema.EntryCount, error) {
 	/*s.Logger.Debugf("count %s", prefix.Prefix)
-	ind, err := s.getDbIndexFromCtx(ctx, "Count")
+	db, err := s.getDbFromCtx(ctx, "Count")
 	if err != nil {
 		return nil, err
 	}
+	defer s.dbList.Release(db)
 
-	return s.dbList.GetByIndex(ind).Count(prefix)
+	return db.Count(prefix)
 	*/
 	return nil, errors.New("Functionality not yet supported")
 }
 
 // CountAll ...
 func (s *ImmuServer) CountAll(ctx context.Context, e *empty.Empty) (*schema.EntryCount, error) {
-	/*ind, err := s.getDbIndexFromCtx(ctx, "CountAll")
-	s.Logger.Debugf("count all for db index %d", ind)
+	/*db, err := s.getDbFromCtx(ctx, "CountAll")
 	if err != nil {
 		return nil, err
 	}
+	defer s.dbList.Release(db)
+
+	s.Logger.Debugf("count all for db %s", db.GetName())
 
-	return s.dbList.GetByIndex(ind).CountAll()
+	return db.CountAll()
 	*/
 	return nil, errors.New("Functionality not yet supported")
 }
 
 // TxByID ...
 func (s *ImmuServer) TxById(ctx context.Context, req *schema.TxRequest) (*schema.Tx, error) {
-	ind, err := s.getDbIndexFromCtx(ctx, "TxByID")
+	db, err := s.getDbFromCtx(ctx, "TxByID")
 	if err != nil {
 		return nil, err
 	}
+	defer s.dbList.Release(db)
 
-	return s.dbList.GetByIndex(ind).TxByID(req)
+	return db.TxByID(req)
 }
 
 // VerifiableTxByID ...
 func (s *ImmuServer) VerifiableTxById(ctx context.Context, req *schema.VerifiableTxRequest) (*schema.VerifiableTx, error) {
-	ind, err := s.getDbIndexFromCtx(ctx, "VerifiableTxByID")
+	db, err := s.getDbFromCtx(ctx, "VerifiableTxByID")
 	if err != nil {
 		return nil, err
 	}
+	defer s.dbList.Release(db)
 
-	vtx, err := s.dbList.GetByIndex(ind).VerifiableTxByID(req)
+	vtx, err := db.VerifiableTxByID(req)
 	if err != nil {
 		return nil, err
 	}
@@ -800,7 +986,7 @@ func (s *ImmuServer) VerifiableTxById(ctx context.Context, req *schema.Verifiabl
 		alh := md.Alh()
 
 		newState := &schema.ImmutableState{
-			Db:     s.dbList.GetByIndex(ind).GetOptions().GetDbName(),
+			Db:     db.GetOptions().GetDbName(),
 			TxId:   md.ID,
 			TxHash: alh[:],
 		}
@@ -818,42 +1004,54 @@ func (s *ImmuServer) VerifiableTxById(ctx context.Context, req *schema.Verifiabl
 
 // TxScan ...
 func (s *ImmuServer) TxScan(ctx context.Context, req *schema.TxScanRequest) (*schema.TxList, error) {
-	ind, err := s.getDbIndexFromCtx(ctx, "TxScan")
+	db, err := s.getDbFromCtx(ctx, "TxScan")
 	if err != nil {
 		return nil, err
 	}
+	defer s.dbList.Release(db)
 
-	return s.dbList.GetByIndex(ind).TxScan(req)
+	return db.TxScan(ctx, req)
 }
 
 // History ...
 func (s *ImmuServer) History(ctx context.Context, req *schema.HistoryRequest) (*schema.Entries, error) {
-	ind, err := s.getDbIndexFromCtx(ctx, "History")
+	db, err := s.getDbFromCtx(ctx, "History")
 	if err != nil {
 		return nil, err
 	}
+	defer s.dbList.Release(db)
 
-	return s.dbList.GetByIndex(ind).History(req)
+	return db.History(ctx, req)
 }
 
 // SetReference ...
 func (s *ImmuServer) SetReference(ctx context.Context, req *schema.ReferenceRequest) (*schema.TxMetadata, error) {
-	ind, err := s.getDbIndexFromCtx(ctx, "SetReference")
+	db, err := s.getDbFromCtx(ctx, "SetReference")
 	if err != nil {
 		return nil, err
 	}
+	defer s.dbList.Release(db)
 
-	return s.dbList.GetByIndex(ind).SetReference(req)
+	if req != nil {
+		req.TraceID = s.traceIDFor(ctx, req.TraceID)
+	}
+
+	return db.SetReference(ctx, req)
 }
 
 // VerifibleSetReference ...
 func (s *ImmuServer) VerifiableSetReference(ctx context.Context, req *schema.VerifiableReferenceRequest) (*schema.VerifiableTx, error) {
-	ind, err := s.getDbIndexFromCtx(ctx, "VerifiableSetReference")
+	db, err := s.getDbFromCtx(ctx, "VerifiableSetReference")
 	if err != nil {
 		return nil, err
 	}
+	defer s.dbList.Release(db)
+
+	if req != nil && req.ReferenceRequest != nil {
+		req.ReferenceRequest.TraceID = s.traceIDFor(ctx, req.ReferenceRequest.TraceID)
+	}
 
-	vtx, err := s.dbList.GetByIndex(ind).VerifiableSetReference(req)
+	vtx, err := db.VerifiableSetReference(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -863,7 +1061,7 @@ func (s *ImmuServer) VerifiableSetReference(ctx context.Context, req *schema.Ver
 		alh := md.Alh()
 
 		newState := &schema.ImmutableState{
-			Db:     s.dbList.GetByIndex(ind).GetOptions().GetDbName(),
+			Db:     db.GetOptions().GetDbName(),
 			TxId:   md.ID,
 			TxHash: alh[:],
 		}
@@ -881,32 +1079,114 @@ func (s *ImmuServer) VerifiableSetReference(ctx context.Context, req *schema.Ver
 
 // ZAdd ...
 func (s *ImmuServer) ZAdd(ctx context.Context, req *schema.ZAddRequest) (*schema.TxMetadata, error) {
-	ind, err := s.getDbIndexFromCtx(ctx, "ZAdd")
+	db, err := s.getDbFromCtx(ctx, "ZAdd")
 	if err != nil {
 		return nil, err
 	}
+	defer s.dbList.Release(db)
+
+	if req != nil {
+		req.TraceID = s.traceIDFor(ctx, req.TraceID)
+	}
 
-	return s.dbList.GetByIndex(ind).ZAdd(req)
+	return db.ZAdd(ctx, req)
 }
 
 // ZScan ...
 func (s *ImmuServer) ZScan(ctx context.Context, req *schema.ZScanRequest) (*schema.ZEntries, error) {
-	ind, err := s.getDbIndexFromCtx(ctx, "ZScan")
+	db, err := s.getDbFromCtx(ctx, "ZScan")
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbList.Release(db)
+
+	return db.ZScan(ctx, req)
+}
+
+// TSAppend appends samples to a time series
+func (s *ImmuServer) TSAppend(ctx context.Context, req *schema.TSAppendRequest) (*schema.TxMetadata, error) {
+	db, err := s.getDbFromCtx(ctx, "TSAppend")
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbList.Release(db)
+
+	if req != nil {
+		req.TraceID = s.traceIDFor(ctx, req.TraceID)
+	}
+
+	return db.TSAppend(ctx, req)
+}
+
+// TSRange returns the samples of a time series within a range of timestamps, optionally downsampled
+func (s *ImmuServer) TSRange(ctx context.Context, req *schema.TSRangeRequest) (*schema.TSSamples, error) {
+	db, err := s.getDbFromCtx(ctx, "TSRange")
 	if err != nil {
 		return nil, err
 	}
+	defer s.dbList.Release(db)
 
-	return s.dbList.GetByIndex(ind).ZScan(req)
+	return db.TSRange(ctx, req)
+}
+
+// Notarize commits a batch of digests within the same tx and returns a receipt for each of them
+func (s *ImmuServer) Notarize(ctx context.Context, req *schema.NotarizeRequest) (*schema.NotarizationReceipts, error) {
+	db, err := s.getDbFromCtx(ctx, "Notarize")
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbList.Release(db)
+
+	if req != nil {
+		req.TraceID = s.traceIDFor(ctx, req.TraceID)
+	}
+
+	receipts, err := db.Notarize(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.Options.SigningKey != "" && len(receipts.Receipts) > 0 {
+		// all the receipts share the same state
+		state := receipts.Receipts[0].State
+
+		err = s.StateSigner.Sign(state)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range receipts.Receipts {
+			r.State.Signature = state.Signature
+		}
+	}
+
+	return receipts, nil
+}
+
+// VerifyNotarizationReceipt checks the receipt refers to a tx committed into the database
+func (s *ImmuServer) VerifyNotarizationReceipt(ctx context.Context, receipt *schema.NotarizationReceipt) (*schema.NotarizationVerification, error) {
+	db, err := s.getDbFromCtx(ctx, "VerifyNotarizationReceipt")
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbList.Release(db)
+
+	return db.VerifyNotarizationReceipt(receipt)
 }
 
 // VerifiableZAdd ...
 func (s *ImmuServer) VerifiableZAdd(ctx context.Context, req *schema.VerifiableZAddRequest) (*schema.VerifiableTx, error) {
-	ind, err := s.getDbIndexFromCtx(ctx, "VerifiableZAdd")
+	db, err := s.getDbFromCtx(ctx, "VerifiableZAdd")
 	if err != nil {
 		return nil, err
 	}
+	defer s.dbList.Release(db)
+
+	if req != nil && req.ZAddRequest != nil {
+		req.ZAddRequest.TraceID = s.traceIDFor(ctx, req.ZAddRequest.TraceID)
+	}
 
-	vtx, err := s.dbList.GetByIndex(ind).VerifiableZAdd(req)
+	vtx, err := db.VerifiableZAdd(ctx, req)
 	if err != nil {
 		return nil, err
 	}
@@ -916,7 +1196,7 @@ func (s *ImmuServer) VerifiableZAdd(ctx context.Context, req *schema.VerifiableZ
 		alh := md.Alh()
 
 		newState := &schema.ImmutableState{
-			Db:     s.dbList.GetByIndex(ind).GetOptions().GetDbName(),
+			Db:     db.GetOptions().GetDbName(),
 			TxId:   md.ID,
 			TxHash: alh[:],
 		}
@@ -1010,6 +1290,10 @@ func (s *ImmuServer) ChangePassword(ctx context.Context, r *schema.ChangePasswor
 func (s *ImmuServer) CreateDatabase(ctx context.Context, newdb *schema.Database) (*empty.Empty, error) {
 	s.Logger.Debugf("createdatabase")
 
+	return s.createDatabase(ctx, &schema.DatabaseSettings{DatabaseName: newdb.DatabaseName})
+}
+
+func (s *ImmuServer) createDatabase(ctx context.Context, newdb *schema.DatabaseSettings) (*empty.Empty, error) {
 	if !s.Options.GetAuth() {
 		return nil, fmt.Errorf("this command is available only with authentication on")
 	}
@@ -1019,6 +1303,11 @@ func (s *ImmuServer) CreateDatabase(ctx context.Context, newdb *schema.Database)
 		return nil, fmt.Errorf("could not get loggedin user data")
 	}
 
+	return s.createDatabaseAs(user, newdb)
+}
+
+// createDatabaseAs creates the database on behalf of the user, who needs to be a system admin
+func (s *ImmuServer) createDatabaseAs(user *auth.User, newdb *schema.DatabaseSettings) (*empty.Empty, error) {
 	if !user.IsSysAdmin {
 		return nil, fmt.Errorf("Logged In user does not have permissions for this operation")
 	}
@@ -1032,7 +1321,7 @@ func (s *ImmuServer) CreateDatabase(ctx context.Context, newdb *schema.Database)
 	}
 
 	newdb.DatabaseName = strings.ToLower(newdb.DatabaseName)
-	if err = IsAllowedDbName(newdb.DatabaseName); err != nil {
+	if err := IsAllowedDbName(newdb.DatabaseName); err != nil {
 		return nil, err
 	}
 
@@ -1041,13 +1330,29 @@ func (s *ImmuServer) CreateDatabase(ctx context.Context, newdb *schema.Database)
 		return nil, fmt.Errorf("database %s already exists", newdb.GetDatabaseName())
 	}
 
+	if _, err := s.lastDeletedDatabase(newdb.GetDatabaseName()); err == nil {
+		return nil, fmt.Errorf("database %s was deleted, it can be restored with UndeleteDatabase", newdb.GetDatabaseName())
+	}
+
 	dataDir := s.Options.Dir
 
+	storeOpts, err := withDatabaseSettings(s.Options.StoreOptions, newdb)
+	if err != nil {
+		return nil, err
+	}
+
+	storeOpts, err = s.storeOptionsFor(newdb.DatabaseName, storeOpts, true)
+	if err != nil {
+		return nil, err
+	}
+
 	op := database.DefaultOption().
 		WithDbName(newdb.DatabaseName).
 		WithDbRootPath(dataDir).
 		WithDbRootPath(s.Options.Dir).
-		WithStoreOptions(s.Options.StoreOptions)
+		WithStoreOptions(storeOpts).
+		WithReplica(s.Options.IsReplica()).
+		WithSQLRetries(s.Options.SQLMaxRetries, s.Options.SQLRetryBackoff)
 
 	db, err := database.NewDb(op, s.sysDb, s.Logger)
 	if err != nil {
@@ -1061,6 +1366,13 @@ func (s *ImmuServer) CreateDatabase(ctx context.Context, newdb *schema.Database)
 	return &empty.Empty{}, nil
 }
 
+// createPgsqlDatabase creates a database with the default settings, as requested by a CREATE DATABASE
+// statement received by the pgsql server
+func (s *ImmuServer) createPgsqlDatabase(user *auth.User, name string) error {
+	_, err := s.createDatabaseAs(user, &schema.DatabaseSettings{DatabaseName: name})
+	return err
+}
+
 // CreateUser Creates a new user
 func (s *ImmuServer) CreateUser(ctx context.Context, r *schema.CreateUserRequest) (*empty.Empty, error) {
 	s.Logger.Debugf("CreateUser")
@@ -1144,9 +1456,13 @@ func (s *ImmuServer) ListUsers(ctx context.Context, req *empty.Empty) (*schema.U
 		if err != nil {
 			return nil, fmt.Errorf("please login")
 		}
+
+		if dbInd >= 0 && s.dbList.GetByIndex(dbInd) == nil {
+			return nil, ErrDatabaseDeleted
+		}
 	}
 
-	itemList, err := s.sysDb.Scan(&schema.ScanRequest{
+	itemList, err := s.sysDb.Scan(ctx, &schema.ScanRequest{
 		Prefix:  []byte{KeyPrefixUser},
 		SinceTx: math.MaxUint64,
 		NoWait:  true,
@@ -1260,7 +1576,7 @@ func (s *ImmuServer) DatabaseList(ctx context.Context, req *empty.Empty) (*schem
 	if loggedInuser.IsSysAdmin || s.Options.GetMaintenance() {
 		for i := 0; i < s.dbList.Length(); i++ {
 			val := s.dbList.GetByIndex(int64(i))
-			if val.GetOptions().GetDbName() == SystemdbName {
+			if val == nil || val.GetOptions().GetDbName() == SystemdbName {
 				//do not put sysemdb in the list
 				continue
 			}
@@ -1343,12 +1659,13 @@ func (s *ImmuServer) CleanIndex(ctx context.Context, req *empty.Empty) (*empty.E
 		return nil, ErrIllegalArguments
 	}
 
-	ind, err := s.getDbIndexFromCtx(ctx, "CleanIndex")
+	db, err := s.getDbFromCtx(ctx, "CleanIndex")
 	if err != nil {
 		return nil, err
 	}
+	defer s.dbList.Release(db)
 
-	err = s.dbList.GetByIndex(ind).CompactIndex()
+	err = db.CompactIndex()
 
 	return &empty.Empty{}, err
 }
@@ -1487,40 +1804,161 @@ func (s *ImmuServer) SetActiveUser(ctx context.Context, r *schema.SetActiveUserR
 	return new(empty.Empty), nil
 }
 
-// getDbIndexFromCtx checks if user (loggedin from context) has access to methodname.
-// returns index of database
-func (s *ImmuServer) getDbIndexFromCtx(ctx context.Context, methodname string) (int64, error) {
-	//if auth is disabled return index zero (defaultdb) as it is the first database created/loaded
+// getDbFromCtx checks if user (loggedin from context) has access to methodname.
+// returns the database selected by the session, which must be released once not used anymore
+func (s *ImmuServer) getDbFromCtx(ctx context.Context, methodname string) (database.DB, error) {
+	//if auth is disabled use defaultdb as it is the first database created/loaded
 	if !s.Options.auth {
 		if !s.multidbmode {
-			return DefaultDbIndex, nil
+			db, err := s.acquireDb(DefaultDbIndex)
+			if err != nil {
+				return nil, err
+			}
+
+			s.updateDBRPCMetrics(db)
+
+			return db, nil
 		}
 	}
 
 	ind, usr, err := s.getLoggedInUserdataFromCtx(ctx)
 	if err != nil {
 		if strings.HasPrefix(fmt.Sprintf("%s", err), "token has expired") {
-			return 0, status.Error(codes.PermissionDenied, err.Error())
+			return nil, status.Error(codes.PermissionDenied, err.Error())
 		}
 		if s.Options.GetMaintenance() {
-			return 0, fmt.Errorf("please select database first")
+			return nil, fmt.Errorf("please select database first")
 		}
-		return 0, fmt.Errorf("please login first")
+		return nil, fmt.Errorf("please login first")
 	}
 
 	if ind < 0 {
-		return 0, fmt.Errorf("please select a database first")
+		return nil, fmt.Errorf("please select a database first")
 	}
 
-	if usr.IsSysAdmin {
-		return ind, nil
+	db, err := s.acquireDb(ind)
+	if err != nil {
+		return nil, err
 	}
 
-	if ok := auth.HasPermissionForMethod(usr.WhichPermission(s.dbList.GetByIndex(ind).GetOptions().GetDbName()), methodname); !ok {
-		return 0, fmt.Errorf("you do not have permission for this operation")
+	if !usr.IsSysAdmin {
+		err = checkPermissionForMethod(db, usr, methodname)
+		if err != nil {
+			s.dbList.Release(db)
+			return nil, err
+		}
 	}
 
-	return ind, nil
+	s.updateDBRPCMetrics(db)
+
+	return db, nil
+}
+
+// checkMethodAccessFromCtx checks the logged in user is allowed to run the method
+// on the selected database, without keeping it in use
+func (s *ImmuServer) checkMethodAccessFromCtx(ctx context.Context, methodname string) error {
+	if !s.Options.auth && !s.multidbmode {
+		return nil
+	}
+
+	db, err := s.getDbFromCtx(ctx, methodname)
+	if err != nil {
+		return err
+	}
+
+	s.dbList.Release(db)
+
+	return nil
+}
+
+// getDbByNameFromCtx returns the database with the given name, regardless of the one selected by the session,
+// as long as the logged in user is allowed to run the method on it. It must be released once not used anymore
+func (s *ImmuServer) getDbByNameFromCtx(ctx context.Context, dbName string, methodname string) (database.DB, error) {
+	if dbName == SystemdbName {
+		return nil, fmt.Errorf("this database can not be selected")
+	}
+
+	ind := s.dbList.GetId(dbName)
+	if ind < 0 {
+		return nil, status.Errorf(codes.NotFound, "%s does not exist", dbName)
+	}
+
+	db, err := s.acquireDb(ind)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.Options.auth || s.multidbmode {
+		_, usr, err := s.getLoggedInUserdataFromCtx(ctx)
+		if err != nil {
+			s.dbList.Release(db)
+
+			if strings.HasPrefix(fmt.Sprintf("%s", err), "token has expired") {
+				return nil, status.Error(codes.PermissionDenied, err.Error())
+			}
+			return nil, fmt.Errorf("please login first")
+		}
+
+		if !usr.IsSysAdmin {
+			err = checkPermissionForMethod(db, usr, methodname)
+			if err != nil {
+				s.dbList.Release(db)
+				return nil, err
+			}
+		}
+	}
+
+	s.updateDBRPCMetrics(db)
+
+	return db, nil
+}
+
+// acquireDb returns the database at the given index, it's not closed while in use i.e. until released
+func (s *ImmuServer) acquireDb(ind int64) (database.DB, error) {
+	db, err := s.dbList.Acquire(ind)
+	if err == database.ErrDatabaseNotExists {
+		return nil, ErrDatabaseDeleted
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// checkPermissionForMethod checks the user, who must not be a system admin, is allowed to run the method on the database
+func checkPermissionForMethod(db database.DB, usr *auth.User, methodname string) error {
+	if !auth.HasPermissionForMethod(usr.WhichPermission(db.GetOptions().GetDbName()), methodname) {
+		return fmt.Errorf("you do not have permission for this operation")
+	}
+
+	return checkRawAccess(db, methodname)
+}
+
+// checkRawAccess prevents users other than system admins from reading raw keys and txs of databases
+// with policies, as they would disclose rows the policies restrict, and from writing raw keys into them,
+// as they would bypass the policies. It must not be called for system admins
+func checkRawAccess(db database.DB, methodname string) error {
+	isRawRead := auth.IsRawReadMethod(methodname)
+	isRawWrite := auth.IsRawWriteMethod(methodname)
+
+	if !isRawRead && !isRawWrite {
+		return nil
+	}
+
+	if !db.HasSQLPolicies() {
+		return nil
+	}
+
+	if isRawRead {
+		return ErrRawReadRestricted
+	}
+
+	return ErrRawWriteRestricted
+}
+
+func (s *ImmuServer) updateDBRPCMetrics(db database.DB) {
+	Metrics.UpdateDBRPCMetrics(db.GetOptions().GetDbName())
 }
 
 func (s *ImmuServer) getLoggedInUserdataFromCtx(ctx context.Context) (int64, *auth.User, error) {
@@ -1564,13 +2002,14 @@ func (s *ImmuServer) insertNewUser(username []byte, plainPassword []byte, permis
 	}
 
 	userdata := new(auth.User)
+	// the username is set first, the md5 verifier of the pgsql server depends on it
+	userdata.Username = string(username)
 	plainpassword, err := userdata.SetPassword(plainPassword)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	userdata.Active = true
-	userdata.Username = string(username)
 	userdata.Permissions = append(userdata.Permissions, auth.Permission{Permission: permission, Database: database})
 	userdata.CreatedBy = createdBy
 	userdata.CreatedAt = time.Now()
@@ -1608,7 +2047,7 @@ func (s *ImmuServer) getUser(username []byte, includeDeactivated bool) (*auth.Us
 	key[0] = KeyPrefixUser
 	copy(key[1:], username)
 
-	item, err := s.sysDb.Get(&schema.KeyRequest{Key: key})
+	item, err := s.sysDb.Get(context.Background(), &schema.KeyRequest{Key: key})
 	if err != nil {
 		return nil, err
 	}
@@ -1640,7 +2079,7 @@ func (s *ImmuServer) saveUser(user *auth.User) error {
 	copy(userKey[1:], []byte(user.Username))
 
 	userKV := &schema.KeyValue{Key: userKey, Value: userData}
-	_, err = s.sysDb.Set(&schema.SetRequest{KVs: []*schema.KeyValue{userKV}})
+	_, err = s.sysDb.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{userKV}})
 
 	time.Sleep(time.Duration(10) * time.Millisecond)
 
@@ -1696,7 +2135,8 @@ func (s *ImmuServer) mandatoryAuth() bool {
 	//check if there are user created databases, should be zero for auth to be off
 	for i := 0; i < s.dbList.Length(); i++ {
 		val := s.dbList.GetByIndex(int64(i))
-		if (val.GetOptions().GetDbName() != s.Options.defaultDbName) &&
+		if val != nil &&
+			(val.GetOptions().GetDbName() != s.Options.defaultDbName) &&
 			(val.GetOptions().GetDbName() != s.Options.systemAdminDbName) {
 			return true
 		}
@@ -1709,7 +2149,7 @@ func (s *ImmuServer) mandatoryAuth() bool {
 
 	if s.sysDb != nil {
 		//check if there is only sysadmin on systemdb and no other user
-		itemList, err := s.sysDb.Scan(&schema.ScanRequest{
+		itemList, err := s.sysDb.Scan(context.Background(), &schema.ScanRequest{
 			Prefix: []byte{KeyPrefixUser},
 		})
 
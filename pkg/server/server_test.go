@@ -42,6 +42,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -961,6 +962,18 @@ func testServerReference(ctx context.Context, s *ImmuServer, t *testing.T) {
 	require.Equal(t, kvs[0].Value, item.Value)
 }
 
+func testServerSetWithPreconditions(ctx context.Context, s *ImmuServer, t *testing.T) {
+	_, err := s.Set(ctx, &schema.SetRequest{
+		KVs: []*schema.KeyValue{kvs[0]},
+		Preconditions: []*schema.Precondition{{
+			Precondition: &schema.Precondition_KeyMustNotExist{
+				KeyMustNotExist: &schema.Precondition_KeyMustNotExistPrecondition{Key: kvs[0].Key},
+			},
+		}},
+	})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
 func testServerGetReference(ctx context.Context, s *ImmuServer, t *testing.T) {
 	_, err := s.Set(ctx, &schema.SetRequest{KVs: []*schema.KeyValue{kvs[0]}})
 	if err != nil {
@@ -1072,6 +1085,29 @@ func testServerScan(ctx context.Context, s *ImmuServer, t *testing.T) {
 	}
 }
 
+func testServerIndexScan(ctx context.Context, s *ImmuServer, t *testing.T) {
+	meta, err := s.Set(ctx, &schema.SetRequest{KVs: []*schema.KeyValue{{
+		Key:      kvs[0].Key,
+		Value:    kvs[0].Value,
+		Metadata: &schema.KVMetadata{Indexes: []*schema.KVIndex{{Name: "tags", Tag: []byte("indexed")}}},
+	}}})
+	require.NoError(t, err)
+
+	entries, err := s.IndexScan(ctx, &schema.IndexScanRequest{
+		Index:    "tags",
+		StartKey: []byte("indexed"),
+		EndKey:   []byte("indexed\x00"),
+		SinceTx:  meta.Id,
+	})
+	require.NoError(t, err)
+	require.Len(t, entries.Entries, 1)
+	require.Equal(t, kvs[0].Key, entries.Entries[0].Key)
+	require.Equal(t, kvs[0].Value, entries.Entries[0].Value)
+
+	_, err = s.IndexScan(ctx, &schema.IndexScanRequest{Index: "invalid index"})
+	require.Error(t, err)
+}
+
 func testServerScanError(ctx context.Context, s *ImmuServer, t *testing.T) {
 	_, err := s.Scan(context.Background(), &schema.ScanRequest{
 		SeekKey: nil,
@@ -1267,9 +1303,11 @@ func TestServerDbOperations(t *testing.T) {
 	testServerHealthError(ctx, s, t)
 	testServerReference(ctx, s, t)
 	testServerReferenceError(ctx, s, t)
+	testServerSetWithPreconditions(ctx, s, t)
 	testServerZAdd(ctx, s, t)
 	testServerZAddError(ctx, s, t)
 	testServerScan(ctx, s, t)
+	testServerIndexScan(ctx, s, t)
 	testServerScanError(ctx, s, t)
 	testServerTxScan(ctx, s, t)
 	testServerSafeReference(ctx, s, t)
@@ -1430,11 +1468,11 @@ func TestServerErrors(t *testing.T) {
 	require.Equal(t, errors.New("logged in user data not found"), err)
 	s.userdata.Userdata[username] = userdata
 
-	// getDbIndexFromCtx errors
+	// getDbFromCtx errors
 	adminUserdata := s.userdata.Userdata[auth.SysAdminUsername]
 	delete(s.userdata.Userdata, auth.SysAdminUsername)
 	s.Options.maintenance = true
-	_, err = s.getDbIndexFromCtx(ctx, "ListUsers")
+	_, err = s.getDbFromCtx(ctx, "ListUsers")
 	require.Equal(t, errors.New("please select database first"), err)
 	s.userdata.Userdata[auth.SysAdminUsername] = adminUserdata
 	s.Options.maintenance = false
@@ -1950,3 +1988,52 @@ func TestServerLoginAttempWithEmptyPassword(t *testing.T) {
 
 	require.Contains(t, err.Error(), "invalid user name or password")
 }
+
+func TestServerSeparateDirs(t *testing.T) {
+	dir := "data_separate_dirs"
+	defer os.RemoveAll(dir)
+
+	txLogDir := path.Join(dir, "txlogs")
+	vLogDir := path.Join(dir, "vlogs")
+	indexDir := path.Join(dir, "indexes")
+
+	serverOptions := DefaultOptions().
+		WithDir(path.Join(dir, "databases")).
+		WithListener(bufconn.Listen(1024 * 1024)).
+		WithMetricsServer(false).
+		WithAdminPassword(auth.SysAdminPassword).
+		WithTxLogDir(txLogDir).
+		WithValueLogDir(vLogDir).
+		WithIndexDir(indexDir)
+
+	s := DefaultServer().WithOptions(serverOptions).(*ImmuServer)
+
+	err := s.Initialize()
+	require.NoError(t, err)
+	defer s.CloseDatabases()
+
+	lr, err := s.Login(context.Background(), &schema.LoginRequest{
+		User:     []byte(auth.SysAdminUsername),
+		Password: []byte(auth.SysAdminPassword),
+	})
+	require.NoError(t, err)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", lr.Token))
+
+	_, err = s.CreateDatabase(ctx, &schema.Database{DatabaseName: "db1"})
+	require.NoError(t, err)
+
+	for _, dbname := range []string{SystemdbName, DefaultdbName, "db1"} {
+		for _, f := range []string{path.Join(txLogDir, dbname, "tx"), path.Join(txLogDir, dbname, "commit"),
+			path.Join(vLogDir, dbname, "val_0"), path.Join(indexDir, dbname, "index")} {
+			_, err = os.Stat(f)
+			require.NoError(t, err)
+		}
+	}
+
+	_, err = s.DeleteDatabase(ctx, &schema.Database{DatabaseName: "db1"})
+	require.Equal(t, ErrSeparateDirs, err)
+
+	_, err = s.ShrinkDatabase(ctx, &schema.Database{DatabaseName: "db1"})
+	require.Equal(t, ErrSeparateDirs, err)
+}
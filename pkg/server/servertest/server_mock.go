@@ -45,6 +45,18 @@ func (s *ServerMock) StreamExecAll(allServer schema.ImmuService_StreamExecAllSer
 	return s.Srv.StreamExecAll(allServer)
 }
 
+func (s *ServerMock) ExportTx(req *schema.TxRequest, txsServer schema.ImmuService_ExportTxServer) error {
+	return s.Srv.ExportTx(req, txsServer)
+}
+
+func (s *ServerMock) ExportTxRange(req *schema.TxRangeRequest, txsServer schema.ImmuService_ExportTxRangeServer) error {
+	return s.Srv.ExportTxRange(req, txsServer)
+}
+
+func (s *ServerMock) ApplyTxRange(txsServer schema.ImmuService_ApplyTxRangeServer) error {
+	return s.Srv.ApplyTxRange(txsServer)
+}
+
 func (s *ServerMock) StreamGet(request *schema.KeyRequest, getServer schema.ImmuService_StreamGetServer) error {
 	return s.Srv.StreamGet(request, getServer)
 }
@@ -85,6 +97,18 @@ func (s *ServerMock) ChangePassword(ctx context.Context, req *schema.ChangePassw
 	return s.Srv.ChangePassword(ctx, req)
 }
 
+func (s *ServerMock) ExportUsers(ctx context.Context, req *empty.Empty) (*schema.UserList, error) {
+	return s.Srv.ExportUsers(ctx, req)
+}
+
+func (s *ServerMock) ImportUsers(ctx context.Context, req *schema.UserList) (*schema.ImportUsersResponse, error) {
+	return s.Srv.ImportUsers(ctx, req)
+}
+
+func (s *ServerMock) ResetPassword(ctx context.Context, req *schema.ResetPasswordRequest) (*empty.Empty, error) {
+	return s.Srv.ResetPassword(ctx, req)
+}
+
 func (s *ServerMock) UpdateAuthConfig(ctx context.Context, req *schema.AuthConfig) (*empty.Empty, error) {
 	return s.Srv.UpdateAuthConfig(ctx, req)
 }
@@ -127,6 +151,10 @@ func (s *ServerMock) VerifiableGet(ctx context.Context, req *schema.VerifiableGe
 	return s.Srv.VerifiableGet(ctx, req)
 }
 
+func (s *ServerMock) VerifiableHistoricalGet(ctx context.Context, req *schema.VerifiableHistoricalGetRequest) (*schema.VerifiableEntry, error) {
+	return s.Srv.VerifiableHistoricalGet(ctx, req)
+}
+
 func (s *ServerMock) GetAll(ctx context.Context, req *schema.KeyListRequest) (*schema.Entries, error) {
 	return s.Srv.GetAll(ctx, req)
 }
@@ -140,10 +168,18 @@ func (s *ServerMock) ExecAll(ctx context.Context, req *schema.ExecAllRequest) (*
 	return s.PostExecAllFn(ctx, req, rsp, err)
 }
 
+func (s *ServerMock) ExecMultiDbAll(ctx context.Context, req *schema.ExecMultiDbAllRequest) (*schema.ExecMultiDbAllResponse, error) {
+	return s.Srv.ExecMultiDbAll(ctx, req)
+}
+
 func (s *ServerMock) Scan(ctx context.Context, req *schema.ScanRequest) (*schema.Entries, error) {
 	return s.Srv.Scan(ctx, req)
 }
 
+func (s *ServerMock) IndexScan(ctx context.Context, req *schema.IndexScanRequest) (*schema.Entries, error) {
+	return s.Srv.IndexScan(ctx, req)
+}
+
 func (s *ServerMock) Count(ctx context.Context, req *schema.KeyPrefix) (*schema.EntryCount, error) {
 	return s.Srv.Count(ctx, req)
 }
@@ -164,6 +200,10 @@ func (s *ServerMock) TxScan(ctx context.Context, req *schema.TxScanRequest) (*sc
 	return s.Srv.TxScan(ctx, req)
 }
 
+func (s *ServerMock) Subscribe(req *schema.SubscribeRequest, str schema.ImmuService_SubscribeServer) error {
+	return s.Srv.Subscribe(req, str)
+}
+
 func (s *ServerMock) History(ctx context.Context, req *schema.HistoryRequest) (*schema.Entries, error) {
 	return s.Srv.History(ctx, req)
 }
@@ -172,6 +212,10 @@ func (s *ServerMock) Health(ctx context.Context, req *empty.Empty) (*schema.Heal
 	return s.Srv.Health(ctx, req)
 }
 
+func (s *ServerMock) ServerInfo(ctx context.Context, req *schema.ServerInfoRequest) (*schema.ServerInfoResponse, error) {
+	return s.Srv.ServerInfo(ctx, req)
+}
+
 func (s *ServerMock) CurrentState(ctx context.Context, req *empty.Empty) (*schema.ImmutableState, error) {
 	return s.Srv.CurrentState(ctx, req)
 }
@@ -220,6 +264,86 @@ func (s *ServerMock) CreateDatabase(ctx context.Context, req *schema.Database) (
 	return s.Srv.CreateDatabase(ctx, req)
 }
 
+func (s *ServerMock) DeleteDatabase(ctx context.Context, req *schema.Database) (*empty.Empty, error) {
+	return s.Srv.DeleteDatabase(ctx, req)
+}
+
+func (s *ServerMock) UndeleteDatabase(ctx context.Context, req *schema.Database) (*empty.Empty, error) {
+	return s.Srv.UndeleteDatabase(ctx, req)
+}
+
+func (s *ServerMock) DeletedDatabaseList(ctx context.Context, req *empty.Empty) (*schema.DeletedDatabaseListResponse, error) {
+	return s.Srv.DeletedDatabaseList(ctx, req)
+}
+
+func (s *ServerMock) TSAppend(ctx context.Context, req *schema.TSAppendRequest) (*schema.TxMetadata, error) {
+	return s.Srv.TSAppend(ctx, req)
+}
+
+func (s *ServerMock) TSRange(ctx context.Context, req *schema.TSRangeRequest) (*schema.TSSamples, error) {
+	return s.Srv.TSRange(ctx, req)
+}
+
+func (s *ServerMock) Notarize(ctx context.Context, req *schema.NotarizeRequest) (*schema.NotarizationReceipts, error) {
+	return s.Srv.Notarize(ctx, req)
+}
+
+func (s *ServerMock) VerifyNotarizationReceipt(ctx context.Context, receipt *schema.NotarizationReceipt) (*schema.NotarizationVerification, error) {
+	return s.Srv.VerifyNotarizationReceipt(ctx, receipt)
+}
+
+func (s *ServerMock) CreateCollection(ctx context.Context, req *schema.Collection) (*schema.TxMetadata, error) {
+	return s.Srv.CreateCollection(ctx, req)
+}
+
+func (s *ServerMock) ListCollections(ctx context.Context, req *empty.Empty) (*schema.CollectionList, error) {
+	return s.Srv.ListCollections(ctx, req)
+}
+
+func (s *ServerMock) InsertDocuments(ctx context.Context, req *schema.DocumentInsertRequest) (*schema.DocumentInsertResponse, error) {
+	return s.Srv.InsertDocuments(ctx, req)
+}
+
+func (s *ServerMock) GetDocument(ctx context.Context, req *schema.DocumentGetRequest) (*schema.Document, error) {
+	return s.Srv.GetDocument(ctx, req)
+}
+
+func (s *ServerMock) SearchDocuments(ctx context.Context, req *schema.DocumentSearchRequest) (*schema.DocumentList, error) {
+	return s.Srv.SearchDocuments(ctx, req)
+}
+
+func (s *ServerMock) CreateDatabaseWith(ctx context.Context, req *schema.DatabaseSettings) (*empty.Empty, error) {
+	return s.Srv.CreateDatabaseWith(ctx, req)
+}
+
+func (s *ServerMock) UpdateDatabase(ctx context.Context, req *schema.DatabaseSettings) (*empty.Empty, error) {
+	return s.Srv.UpdateDatabase(ctx, req)
+}
+
+func (s *ServerMock) GetDatabaseSettings(ctx context.Context, req *empty.Empty) (*schema.DatabaseSettings, error) {
+	return s.Srv.GetDatabaseSettings(ctx, req)
+}
+
+func (s *ServerMock) ShrinkDatabase(ctx context.Context, req *schema.Database) (*empty.Empty, error) {
+	return s.Srv.ShrinkDatabase(ctx, req)
+}
+
+func (s *ServerMock) CancelApplicationRequests(ctx context.Context, req *schema.CancelApplicationRequestsRequest) (*schema.CancelApplicationRequestsResponse, error) {
+	return s.Srv.CancelApplicationRequests(ctx, req)
+}
+
+func (s *ServerMock) RotateEncryptionKeys(ctx context.Context, req *empty.Empty) (*schema.RotateEncryptionKeysResponse, error) {
+	return s.Srv.RotateEncryptionKeys(ctx, req)
+}
+
+func (s *ServerMock) OpenedFiles(ctx context.Context, req *schema.Database) (*schema.OpenedFilesResponse, error) {
+	return s.Srv.OpenedFiles(ctx, req)
+}
+
+func (s *ServerMock) CloseCachedFiles(ctx context.Context, req *schema.Database) (*schema.CloseCachedFilesResponse, error) {
+	return s.Srv.CloseCachedFiles(ctx, req)
+}
+
 func (s *ServerMock) DatabaseList(ctx context.Context, req *empty.Empty) (*schema.DatabaseListResponse, error) {
 	return s.Srv.DatabaseList(ctx, req)
 }
@@ -256,6 +380,10 @@ func (s *ServerMock) SQLExec(ctx context.Context, req *schema.SQLExecRequest) (*
 	return s.Srv.SQLExec(ctx, req)
 }
 
+func (s *ServerMock) SQLBatch(ctx context.Context, req *schema.SQLBatchRequest) (*schema.SQLBatchResult, error) {
+	return s.Srv.SQLBatch(ctx, req)
+}
+
 func (s *ServerMock) UseSnapshot(ctx context.Context, req *schema.UseSnapshotRequest) (*empty.Empty, error) {
 	return s.Srv.UseSnapshot(ctx, req)
 }
@@ -272,6 +400,18 @@ func (s *ServerMock) DescribeTable(ctx context.Context, req *schema.Table) (*sch
 	return s.Srv.DescribeTable(ctx, req)
 }
 
+func (s *ServerMock) ExportSQLSchema(ctx context.Context, req *empty.Empty) (*schema.SQLSchema, error) {
+	return s.Srv.ExportSQLSchema(ctx, req)
+}
+
+func (s *ServerMock) ImportSQLSchema(ctx context.Context, req *schema.SQLSchema) (*schema.SQLExecResult, error) {
+	return s.Srv.ImportSQLSchema(ctx, req)
+}
+
 func (s *ServerMock) VerifiableSQLGet(ctx context.Context, req *schema.VerifiableSQLGetRequest) (*schema.VerifiableSQLEntry, error) {
 	return s.Srv.VerifiableSQLGet(ctx, req)
 }
+
+func (s *ServerMock) VerifiableSQLQuery(ctx context.Context, req *schema.VerifiableSQLQueryRequest) (*schema.VerifiableSQLQueryResult, error) {
+	return s.Srv.VerifiableSQLQuery(ctx, req)
+}
@@ -0,0 +1,252 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/database"
+	"github.com/golang/protobuf/ptypes/empty"
+)
+
+// shrinkingDatabasesDir is the folder, inside the data dir, where compacted copies of databases
+// are written while being shrunk. Database names can not start with a dot so it can't clash with a regular database.
+const shrinkingDatabasesDir = ".shrinking"
+
+// suffix of the folder where a shrunk database is moved to until its compacted copy takes its place
+const shrunkDatabaseSuffix = ".old"
+
+func (s *ImmuServer) shrinkingDatabasesPath() string {
+	return s.OS.Join(s.Options.Dir, shrinkingDatabasesDir)
+}
+
+// ShrinkDatabase rewrites the database into a compacted copy, without the values discarded because of retention,
+// which takes its place once completed. The database is kept online while being copied and while the index
+// of the copy is built, writes are only blocked from the moment the latest changes are copied.
+func (s *ImmuServer) ShrinkDatabase(ctx context.Context, req *schema.Database) (*empty.Empty, error) {
+	s.Logger.Debugf("ShrinkDatabase %+v", req)
+
+	if req == nil {
+		return nil, ErrIllegalArguments
+	}
+
+	err := s.checkSysAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.Options.hasSeparateDirs() {
+		return nil, ErrSeparateDirs
+	}
+
+	if req.DatabaseName == SystemdbName {
+		return nil, ErrSystemDatabaseShrink
+	}
+
+	s.dbDeletionMux.Lock()
+	defer s.dbDeletionMux.Unlock()
+
+	db, err := s.dbList.GetByName(req.DatabaseName)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.OS.MkdirAll(s.shrinkingDatabasesPath(), 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	dbDir := s.OS.Join(s.Options.Dir, req.DatabaseName)
+	copyDir := s.OS.Join(s.shrinkingDatabasesPath(), req.DatabaseName)
+	oldDir := copyDir + shrunkDatabaseSuffix
+
+	s.Logger.Infof("Shrinking database '%s'...", req.DatabaseName)
+
+	// the copy is prepared, and indexed, while the database keeps accepting commits,
+	// thus only the ones made in the meantime are copied once writes are blocked
+	err = db.PrepareShrinkTo(copyDir)
+	if err != nil {
+		s.OS.RemoveAll(copyDir)
+		return nil, logErr(s.Logger, "Unable to shrink database: %v", err)
+	}
+
+	err = db.ShrinkTo(copyDir)
+	if err != nil {
+		s.OS.RemoveAll(copyDir)
+		return nil, logErr(s.Logger, "Unable to shrink database: %v", err)
+	}
+
+	// the shrunk database does not accept commits anymore, thus it's reopened if its copy can't take its place
+	err = s.checkShrunkCopy(db, dbDir, copyDir)
+	if err != nil {
+		s.OS.RemoveAll(copyDir)
+		return nil, s.reopenDatabase(db, logErr(s.Logger, "Unable to use compacted database: %v", err))
+	}
+
+	err = db.Close()
+	if err != nil {
+		s.OS.RemoveAll(copyDir)
+		return nil, s.reopenDatabase(db, logErr(s.Logger, "Unable to close database: %v", err))
+	}
+
+	err = s.OS.Rename(dbDir, oldDir)
+	if err != nil {
+		s.OS.RemoveAll(copyDir)
+		return nil, s.reopenDatabase(db, logErr(s.Logger, "Unable to move shrunk database: %v", err))
+	}
+
+	err = s.OS.Rename(copyDir, dbDir)
+	if err != nil {
+		return nil, s.restoreShrunkDatabase(db, dbDir, oldDir, logErr(s.Logger, "Unable to move compacted database: %v", err))
+	}
+
+	newDb, err := database.OpenDb(db.GetOptions(), s.sysDb, s.Logger)
+	if err != nil {
+		return nil, s.restoreShrunkDatabase(db, dbDir, oldDir, logErr(s.Logger, "Unable to open compacted database: %v", err))
+	}
+
+	_, err = s.dbList.Replace(newDb)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.OS.RemoveAll(oldDir)
+	if err != nil {
+		return nil, logErr(s.Logger, "Unable to remove shrunk database: %v", err)
+	}
+
+	s.Logger.Infof("Database '%s' successfully shrunk", req.DatabaseName)
+
+	return &empty.Empty{}, nil
+}
+
+// checkShrunkCopy copies the settings of the database into its compacted copy, then checks the copy
+// can be opened and holds the same transactions
+func (s *ImmuServer) checkShrunkCopy(db database.DB, dbDir, copyDir string) error {
+	// settings changed after the database was created are kept by the compacted copy
+	settings, err := s.readDatabaseSettings(dbDir)
+	if err == nil && settings.MaxValueLen > 0 {
+		err = s.writeDatabaseSettings(copyDir, settings)
+	}
+	if err != nil {
+		return err
+	}
+
+	copyOpts := *db.GetOptions()
+
+	copyDb, err := database.OpenDb(copyOpts.WithDbRootPath(s.shrinkingDatabasesPath()), s.sysDb, s.Logger)
+	if err != nil {
+		return err
+	}
+
+	copyState, err := copyDb.CurrentState()
+	if err != nil {
+		copyDb.Close()
+		return err
+	}
+
+	err = copyDb.Close()
+	if err != nil {
+		return err
+	}
+
+	state, err := db.CurrentState()
+	if err != nil {
+		return err
+	}
+
+	if copyState.TxId != state.TxId || !bytes.Equal(copyState.TxHash, state.TxHash) {
+		return ErrShrunkDatabaseMismatch
+	}
+
+	return nil
+}
+
+// restoreShrunkDatabase moves the shrunk database back into its folder, replacing the compacted copy which
+// couldn't take its place, and reopens it. The error preventing the copy from being used is returned
+func (s *ImmuServer) restoreShrunkDatabase(db database.DB, dbDir, oldDir string, cause error) error {
+	err := s.OS.RemoveAll(dbDir)
+	if err == nil {
+		err = s.OS.Rename(oldDir, dbDir)
+	}
+	if err != nil {
+		// the database is restored by recoverShrunkDatabases when the server is restarted
+		s.Logger.Errorf("Unable to restore shrunk database '%s': %v", db.GetName(), err)
+		return cause
+	}
+
+	return s.reopenDatabase(db, cause)
+}
+
+// reopenDatabase replaces the database, which was shrunk or closed, with a newly opened instance of it.
+// The error preventing the database from being shrunk is returned
+func (s *ImmuServer) reopenDatabase(db database.DB, cause error) error {
+	// the database may have already been closed
+	db.Close()
+
+	newDb, err := database.OpenDb(db.GetOptions(), s.sysDb, s.Logger)
+	if err == nil {
+		_, err = s.dbList.Replace(newDb)
+	}
+	if err != nil {
+		s.Logger.Errorf("Unable to reopen database '%s': %v", db.GetName(), err)
+	}
+
+	return cause
+}
+
+// recoverShrunkDatabases moves back the databases which were moved away while being shrunk,
+// if their compacted copy didn't take their place. Compacted copies which were not completed are removed.
+func (s *ImmuServer) recoverShrunkDatabases() error {
+	files, err := ioutil.ReadDir(s.shrinkingDatabasesPath())
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		if !f.IsDir() || !strings.HasSuffix(f.Name(), shrunkDatabaseSuffix) {
+			continue
+		}
+
+		dbDir := s.OS.Join(s.Options.Dir, strings.TrimSuffix(f.Name(), shrunkDatabaseSuffix))
+
+		_, err := s.OS.Stat(dbDir)
+		if err == nil {
+			continue
+		}
+		if !os.IsNotExist(err) {
+			return err
+		}
+
+		s.Logger.Warningf("Restoring database '%s' which was being shrunk", dbDir)
+
+		err = s.OS.Rename(s.OS.Join(s.shrinkingDatabasesPath(), f.Name()), dbDir)
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.OS.RemoveAll(s.shrinkingDatabasesPath())
+}
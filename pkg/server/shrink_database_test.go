@@ -0,0 +1,212 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	"github.com/codenotary/immudb/pkg/database"
+	"github.com/codenotary/immudb/pkg/immuos"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestServerShrinkDatabase(t *testing.T) {
+	dir := "data_shrink_databases"
+	defer os.RemoveAll(dir)
+
+	serverOptions := DefaultOptions().
+		WithDir(dir).
+		WithListener(bufconn.Listen(1024 * 1024)).
+		WithMetricsServer(false).
+		WithAdminPassword(auth.SysAdminPassword)
+
+	s := DefaultServer().WithOptions(serverOptions).(*ImmuServer)
+
+	err := s.Initialize()
+	require.NoError(t, err)
+
+	lr, err := s.Login(context.Background(), &schema.LoginRequest{
+		User:     []byte(auth.SysAdminUsername),
+		Password: []byte(auth.SysAdminPassword),
+	})
+	require.NoError(t, err)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", lr.Token))
+
+	_, err = s.ShrinkDatabase(ctx, nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = s.ShrinkDatabase(ctx, &schema.Database{DatabaseName: SystemdbName})
+	require.Equal(t, ErrSystemDatabaseShrink, err)
+
+	_, err = s.ShrinkDatabase(ctx, &schema.Database{DatabaseName: "db1"})
+	require.Equal(t, database.ErrDatabaseNotExists, err)
+
+	_, err = s.CreateDatabase(ctx, &schema.Database{DatabaseName: "db1"})
+	require.NoError(t, err)
+
+	ur, err := s.UseDatabase(ctx, &schema.Database{DatabaseName: "db1"})
+	require.NoError(t, err)
+
+	dbCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", ur.Token))
+
+	_, err = s.Set(dbCtx, &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key1"), Value: []byte("value1")}}})
+	require.NoError(t, err)
+
+	stateBefore, err := s.CurrentState(dbCtx, nil)
+	require.NoError(t, err)
+
+	_, err = s.ShrinkDatabase(ctx, &schema.Database{DatabaseName: "db1"})
+	require.NoError(t, err)
+
+	require.NoDirExists(t, filepath.Join(dir, shrinkingDatabasesDir, "db1"+shrunkDatabaseSuffix))
+
+	// the session keeps using the database, which now is the compacted copy
+	stateAfter, err := s.CurrentState(dbCtx, nil)
+	require.NoError(t, err)
+	require.Equal(t, stateBefore.TxId, stateAfter.TxId)
+	require.Equal(t, stateBefore.TxHash, stateAfter.TxHash)
+
+	entry, err := s.Get(dbCtx, &schema.KeyRequest{Key: []byte("key1"), SinceTx: stateAfter.TxId})
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1"), entry.Value)
+
+	_, err = s.Set(dbCtx, &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key2"), Value: []byte("value2")}}})
+	require.NoError(t, err)
+
+	err = s.CloseDatabases()
+	require.NoError(t, err)
+}
+
+func TestServerShrinkDatabaseOpenFailure(t *testing.T) {
+	dir := "data_shrink_database_failure"
+	defer os.RemoveAll(dir)
+
+	serverOptions := DefaultOptions().
+		WithDir(dir).
+		WithListener(bufconn.Listen(1024 * 1024)).
+		WithMetricsServer(false).
+		WithAdminPassword(auth.SysAdminPassword)
+
+	s := DefaultServer().WithOptions(serverOptions).(*ImmuServer)
+
+	// the compacted copy gets corrupted once it takes the place of the database, so it can't be opened
+	sos := immuos.NewStandardOS()
+	sos.RenameF = func(oldpath, newpath string) error {
+		err := os.Rename(oldpath, newpath)
+		if err != nil || oldpath != filepath.Join(dir, shrinkingDatabasesDir, "db1") {
+			return err
+		}
+
+		err = os.RemoveAll(filepath.Join(newpath, "commit"))
+		if err != nil {
+			return err
+		}
+
+		return ioutil.WriteFile(filepath.Join(newpath, "commit"), []byte("corrupted"), 0644)
+	}
+	s.OS = sos
+
+	err := s.Initialize()
+	require.NoError(t, err)
+
+	lr, err := s.Login(context.Background(), &schema.LoginRequest{
+		User:     []byte(auth.SysAdminUsername),
+		Password: []byte(auth.SysAdminPassword),
+	})
+	require.NoError(t, err)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", lr.Token))
+
+	_, err = s.CreateDatabase(ctx, &schema.Database{DatabaseName: "db1"})
+	require.NoError(t, err)
+
+	ur, err := s.UseDatabase(ctx, &schema.Database{DatabaseName: "db1"})
+	require.NoError(t, err)
+
+	dbCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", ur.Token))
+
+	_, err = s.Set(dbCtx, &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key1"), Value: []byte("value1")}}})
+	require.NoError(t, err)
+
+	stateBefore, err := s.CurrentState(dbCtx, nil)
+	require.NoError(t, err)
+
+	_, err = s.ShrinkDatabase(ctx, &schema.Database{DatabaseName: "db1"})
+	require.Error(t, err)
+
+	require.NoDirExists(t, filepath.Join(dir, shrinkingDatabasesDir, "db1"))
+	require.NoDirExists(t, filepath.Join(dir, shrinkingDatabasesDir, "db1"+shrunkDatabaseSuffix))
+
+	// the original database was moved back and reopened, it keeps accepting commits
+	stateAfter, err := s.CurrentState(dbCtx, nil)
+	require.NoError(t, err)
+	require.Equal(t, stateBefore.TxId, stateAfter.TxId)
+	require.Equal(t, stateBefore.TxHash, stateAfter.TxHash)
+
+	entry, err := s.Get(dbCtx, &schema.KeyRequest{Key: []byte("key1")})
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1"), entry.Value)
+
+	_, err = s.Set(dbCtx, &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key2"), Value: []byte("value2")}}})
+	require.NoError(t, err)
+
+	err = s.CloseDatabases()
+	require.NoError(t, err)
+}
+
+func TestServerRecoverShrunkDatabases(t *testing.T) {
+	dir := "data_recover_shrunk_databases"
+	defer os.RemoveAll(dir)
+
+	s := DefaultServer().WithOptions(DefaultOptions().WithDir(dir)).(*ImmuServer)
+
+	err := s.recoverShrunkDatabases()
+	require.NoError(t, err)
+
+	// db1 was moved away but its compacted copy didn't take its place
+	err = os.MkdirAll(filepath.Join(dir, shrinkingDatabasesDir, "db1"+shrunkDatabaseSuffix), 0755)
+	require.NoError(t, err)
+
+	err = ioutil.WriteFile(filepath.Join(dir, shrinkingDatabasesDir, "db1"+shrunkDatabaseSuffix, "file"), []byte("content"), 0644)
+	require.NoError(t, err)
+
+	err = os.MkdirAll(filepath.Join(dir, shrinkingDatabasesDir, "db1"), 0755)
+	require.NoError(t, err)
+
+	// db2 was shrunk but the old copy was not removed
+	err = os.MkdirAll(filepath.Join(dir, shrinkingDatabasesDir, "db2"+shrunkDatabaseSuffix), 0755)
+	require.NoError(t, err)
+
+	err = os.MkdirAll(filepath.Join(dir, "db2"), 0755)
+	require.NoError(t, err)
+
+	err = s.recoverShrunkDatabases()
+	require.NoError(t, err)
+
+	require.FileExists(t, filepath.Join(dir, "db1", "file"))
+	require.DirExists(t, filepath.Join(dir, "db2"))
+	require.NoDirExists(t, filepath.Join(dir, shrinkingDatabasesDir))
+}
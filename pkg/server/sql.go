@@ -17,54 +17,167 @@ package server
 
 import (
 	"context"
+	"sync"
 
+	"github.com/codenotary/immudb/embedded/sql"
 	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	"github.com/codenotary/immudb/pkg/database"
 	"github.com/golang/protobuf/ptypes/empty"
 )
 
+// withSQLUser binds SQL statements to the logged in user and to its permission on the database as role,
+// so the policies restricting the rows of the tables get applied. System admins are not restricted,
+// neither is any user when authentication is disabled. As policies don't apply to raw keys and txs,
+// other users can't read nor write them on databases with policies, see checkRawAccess
+func (s *ImmuServer) withSQLUser(ctx context.Context, db database.DB) (context.Context, error) {
+	if !s.Options.auth {
+		return ctx, nil
+	}
+
+	_, usr, err := s.getLoggedInUserdataFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if usr.IsSysAdmin {
+		return ctx, nil
+	}
+
+	permission := usr.WhichPermission(db.GetOptions().GetDbName())
+
+	return sql.WithUser(ctx, usr.Username, auth.PermissionName(permission)), nil
+}
+
 func (s *ImmuServer) VerifiableSQLGet(ctx context.Context, req *schema.VerifiableSQLGetRequest) (*schema.VerifiableSQLEntry, error) {
-	ind, err := s.getDbIndexFromCtx(ctx, "VerifiableSQLGet")
+	db, err := s.getDbFromCtx(ctx, "VerifiableSQLGet")
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbList.Release(db)
+
+	ctx, err = s.withSQLUser(ctx, db)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.dbList.GetByIndex(ind).VerifiableSQLGet(req)
+	return db.VerifiableSQLGet(ctx, req)
+}
+
+func (s *ImmuServer) VerifiableSQLQuery(ctx context.Context, req *schema.VerifiableSQLQueryRequest) (*schema.VerifiableSQLQueryResult, error) {
+	db, err := s.getDbFromCtx(ctx, "VerifiableSQLQuery")
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbList.Release(db)
+
+	ctx, err = s.withSQLUser(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	return db.VerifiableSQLQuery(ctx, req)
 }
 
 func (s *ImmuServer) SQLExec(ctx context.Context, req *schema.SQLExecRequest) (*schema.SQLExecResult, error) {
-	ind, err := s.getDbIndexFromCtx(ctx, "SQLExec")
+	db, err := s.getDbFromCtx(ctx, "SQLExec")
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbList.Release(db)
+
+	ctx, err = s.withSQLUser(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	return db.SQLExec(ctx, req)
+}
+
+func (s *ImmuServer) SQLBatch(ctx context.Context, req *schema.SQLBatchRequest) (*schema.SQLBatchResult, error) {
+	db, err := s.getDbFromCtx(ctx, "SQLBatch")
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbList.Release(db)
+
+	ctx, err = s.withSQLUser(ctx, db)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.dbList.GetByIndex(ind).SQLExec(req)
+	return db.SQLBatch(ctx, req)
 }
 
 func (s *ImmuServer) UseSnapshot(ctx context.Context, req *schema.UseSnapshotRequest) (*empty.Empty, error) {
-	ind, err := s.getDbIndexFromCtx(ctx, "UseSnapshot")
+	db, err := s.getDbFromCtx(ctx, "UseSnapshot")
 	if err != nil {
 		return nil, err
 	}
+	defer s.dbList.Release(db)
 
-	return new(empty.Empty), s.dbList.GetByIndex(ind).UseSnapshot(req)
+	return new(empty.Empty), db.UseSnapshot(req)
 }
 
 func (s *ImmuServer) SQLQuery(ctx context.Context, req *schema.SQLQueryRequest) (*schema.SQLQueryResult, error) {
-	ind, err := s.getDbIndexFromCtx(ctx, "SQLQuery")
+	db, err := s.getDbFromCtx(ctx, "SQLQuery")
 	if err != nil {
 		return nil, err
 	}
+	defer s.dbList.Release(db)
 
-	return s.dbList.GetByIndex(ind).SQLQuery(req)
+	ctx, err = s.withSQLUser(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	return db.SQLQuery(ctx, req)
+}
+
+// sqlQueryRows returns a reader of the rows of the query, so they don't need to be held in memory at once.
+// The database is kept in use until the reader is closed
+func (s *ImmuServer) sqlQueryRows(ctx context.Context, stmt *sql.SelectStmt, params []*schema.NamedParam) (database.SQLRows, error) {
+	db, err := s.getDbFromCtx(ctx, "SQLQuery")
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err = s.withSQLUser(ctx, db)
+	if err != nil {
+		s.dbList.Release(db)
+		return nil, err
+	}
+
+	rows, err := db.SQLQueryRowsPrepared(ctx, stmt, params, true)
+	if err != nil {
+		s.dbList.Release(db)
+		return nil, err
+	}
+
+	return &releasingSQLRows{SQLRows: rows, release: func() { s.dbList.Release(db) }}, nil
+}
+
+// releasingSQLRows releases the database the rows are read from once closed
+type releasingSQLRows struct {
+	database.SQLRows
+	release  func()
+	released sync.Once
+}
+
+func (r *releasingSQLRows) Close() error {
+	defer r.released.Do(r.release)
+
+	return r.SQLRows.Close()
 }
 
 func (s *ImmuServer) ListTables(ctx context.Context, _ *empty.Empty) (*schema.SQLQueryResult, error) {
-	ind, err := s.getDbIndexFromCtx(ctx, "ListTables")
+	db, err := s.getDbFromCtx(ctx, "ListTables")
 	if err != nil {
 		return nil, err
 	}
+	defer s.dbList.Release(db)
 
-	return s.dbList.GetByIndex(ind).ListTables()
+	return db.ListTables()
 }
 
 func (s *ImmuServer) DescribeTable(ctx context.Context, req *schema.Table) (*schema.SQLQueryResult, error) {
@@ -72,10 +185,36 @@ func (s *ImmuServer) DescribeTable(ctx context.Context, req *schema.Table) (*sch
 		return nil, ErrIllegalArguments
 	}
 
-	ind, err := s.getDbIndexFromCtx(ctx, "DescribeTable")
+	db, err := s.getDbFromCtx(ctx, "DescribeTable")
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbList.Release(db)
+
+	return db.DescribeTable(req.TableName)
+}
+
+func (s *ImmuServer) ExportSQLSchema(ctx context.Context, _ *empty.Empty) (*schema.SQLSchema, error) {
+	db, err := s.getDbFromCtx(ctx, "ExportSQLSchema")
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbList.Release(db)
+
+	return db.ExportSQLSchema()
+}
+
+func (s *ImmuServer) ImportSQLSchema(ctx context.Context, req *schema.SQLSchema) (*schema.SQLExecResult, error) {
+	db, err := s.getDbFromCtx(ctx, "ImportSQLSchema")
+	if err != nil {
+		return nil, err
+	}
+	defer s.dbList.Release(db)
+
+	ctx, err = s.withSQLUser(ctx, db)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.dbList.GetByIndex(ind).DescribeTable(req.TableName)
+	return db.ImportSQLSchema(ctx, req)
 }
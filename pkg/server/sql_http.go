@@ -0,0 +1,425 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codenotary/immudb/embedded/sql"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/database"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// SQLQueryHTTPPath is the path of the web server endpoint running read-only SQL queries
+const SQLQueryHTTPPath = "/api/sql/query"
+
+const maxSQLQueryHTTPRequestSize = 64 * 1024
+const sqlQueryHTTPFlushRows = 100
+
+// trailers of CSV responses, the only way to report how a CSV response ended once its rows were sent
+const (
+	SQLQueryHTTPTruncatedTrailer = "X-Immudb-Truncated"
+	SQLQueryHTTPErrorTrailer     = "X-Immudb-Error"
+)
+
+// SQLQueryHTTPRequest is the JSON body accepted by the SQL query endpoint
+type SQLQueryHTTPRequest struct {
+	SQL    string                 `json:"sql"`
+	Params map[string]interface{} `json:"params"`
+	// Limit is the maximum number of rows to be returned, database.MaxKeyScanLimit when 0
+	Limit int `json:"limit"`
+	// Format is either json (default) or csv, the Accept header is used when empty.
+	// CSV responses report truncation and failures while reading rows in their trailers
+	Format string `json:"format"`
+}
+
+type sqlQueryHTTPColumn struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// sqlRowsQuerier is implemented by servers able to read the rows of a query one at a time
+type sqlRowsQuerier interface {
+	sqlQueryRows(ctx context.Context, stmt *sql.SelectStmt, params []*schema.NamedParam) (database.SQLRows, error)
+}
+
+// sqlQueryHTTPHandler runs a single SELECT statement on the database selected by the bearer token
+// and writes its rows as JSON or CSV while they are being read. Once rows were sent, a failure
+// can't change the status of the response, thus it's reported in the error field of the JSON object
+func sqlQueryHTTPHandler(s schema.ImmuServiceServer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeSQLQueryHTTPError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+			return
+		}
+
+		var req SQLQueryHTTPRequest
+
+		dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxSQLQueryHTTPRequestSize))
+		dec.UseNumber()
+
+		err := dec.Decode(&req)
+		if err != nil {
+			writeSQLQueryHTTPError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		format := sqlQueryHTTPFormat(req.Format, r.Header.Get("Accept"))
+		if format == "" {
+			writeSQLQueryHTTPError(w, http.StatusBadRequest, fmt.Errorf("unsupported format '%s'", req.Format))
+			return
+		}
+
+		if req.Limit < 0 || req.Limit > database.MaxKeyScanLimit {
+			writeSQLQueryHTTPError(w, http.StatusBadRequest, fmt.Errorf("limit must be between 0 and %d", database.MaxKeyScanLimit))
+			return
+		}
+
+		stmts, err := sql.Parse(strings.NewReader(req.SQL))
+		if err != nil {
+			writeSQLQueryHTTPError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		stmt, isSelect := stmts[0].(*sql.SelectStmt)
+		if len(stmts) != 1 || !isSelect {
+			writeSQLQueryHTTPError(w, http.StatusBadRequest, fmt.Errorf("only a single SELECT statement is accepted"))
+			return
+		}
+
+		params, err := sqlQueryHTTPParams(req.Params)
+		if err != nil {
+			writeSQLQueryHTTPError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		ctx := r.Context()
+
+		if token := r.Header.Get("Authorization"); token != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", token))
+		}
+
+		rows, err := sqlQueryHTTPRows(ctx, s, stmt, req.SQL, params)
+		if err != nil {
+			writeSQLQueryHTTPError(w, sqlQueryHTTPStatus(r, err), err)
+			return
+		}
+		defer rows.Close()
+
+		// the first row is read before writing the response, so most failures still get their status
+		first, err := rows.Read()
+		if errors.Is(err, sql.ErrNoMoreRows) {
+			first = nil
+		} else if err != nil {
+			writeSQLQueryHTTPError(w, sqlQueryHTTPStatus(r, err), err)
+			return
+		}
+
+		limit := req.Limit
+		if limit == 0 {
+			limit = database.MaxKeyScanLimit
+		}
+
+		if format == "csv" {
+			w.Header().Set("Content-Type", "text/csv")
+			w.Header().Set("Trailer", SQLQueryHTTPTruncatedTrailer+", "+SQLQueryHTTPErrorTrailer)
+			writeSQLQueryHTTPCSV(w, first, rows, limit)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		writeSQLQueryHTTPJSON(w, first, rows, limit)
+	})
+}
+
+func sqlQueryHTTPStatus(r *http.Request, err error) int {
+	if status.Code(err) == codes.Unknown && r.Header.Get("Authorization") == "" {
+		return http.StatusUnauthorized
+	}
+
+	return runtime.HTTPStatusFromCode(status.Code(err))
+}
+
+// sqlQueryHTTPRows returns a reader of the rows of the query, servers not able to read them
+// one at a time are asked for the whole result
+func sqlQueryHTTPRows(ctx context.Context, s schema.ImmuServiceServer, stmt *sql.SelectStmt, sqlStr string, params []*schema.NamedParam) (database.SQLRows, error) {
+	if rq, ok := s.(sqlRowsQuerier); ok {
+		return rq.sqlQueryRows(ctx, stmt, params)
+	}
+
+	res, err := s.SQLQuery(ctx, &schema.SQLQueryRequest{Sql: sqlStr, Params: params})
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqlResultRows{res: res}, nil
+}
+
+// sqlResultRows reads the rows of an already materialized result
+type sqlResultRows struct {
+	res  *schema.SQLQueryResult
+	next int
+}
+
+func (rs *sqlResultRows) Columns() []*schema.Column {
+	return rs.res.Columns
+}
+
+func (rs *sqlResultRows) Read() (*schema.Row, error) {
+	if rs.next == len(rs.res.Rows) {
+		return nil, sql.ErrNoMoreRows
+	}
+
+	rs.next++
+
+	return rs.res.Rows[rs.next-1], nil
+}
+
+func (rs *sqlResultRows) Close() error {
+	return nil
+}
+
+func sqlQueryHTTPFormat(format, accept string) string {
+	switch strings.ToLower(format) {
+	case "json":
+		return "json"
+	case "csv":
+		return "csv"
+	case "":
+		if strings.Contains(accept, "text/csv") {
+			return "csv"
+		}
+		return "json"
+	}
+
+	return ""
+}
+
+func sqlQueryHTTPParams(params map[string]interface{}) ([]*schema.NamedParam, error) {
+	namedParams := make([]*schema.NamedParam, 0, len(params))
+
+	for name, v := range params {
+		sqlVal, err := sqlQueryHTTPParam(name, v)
+		if err != nil {
+			return nil, err
+		}
+
+		namedParams = append(namedParams, &schema.NamedParam{Name: name, Value: sqlVal})
+	}
+
+	return namedParams, nil
+}
+
+// sqlQueryHTTPParam returns the value of a JSON param, arrays are sent as lists of values e.g. for IN conditions
+func sqlQueryHTTPParam(name string, v interface{}) (*schema.SQLValue, error) {
+	switch tv := v.(type) {
+	case nil:
+		return &schema.SQLValue{Value: &schema.SQLValue_Null{}}, nil
+	case json.Number:
+		n, err := strconv.ParseInt(tv.String(), 10, 64)
+		if err == nil {
+			return &schema.SQLValue{Value: &schema.SQLValue_N{N: uint64(n)}}, nil
+		}
+
+		f, err := strconv.ParseFloat(tv.String(), 64)
+		if err != nil {
+			return nil, fmt.Errorf("param '%s' is not a number", name)
+		}
+		return &schema.SQLValue{Value: &schema.SQLValue_F{F: f}}, nil
+	case string:
+		return &schema.SQLValue{Value: &schema.SQLValue_S{S: tv}}, nil
+	case bool:
+		return &schema.SQLValue{Value: &schema.SQLValue_B{B: tv}}, nil
+	case []interface{}:
+		list := &schema.SQLValueList{Values: make([]*schema.SQLValue, len(tv))}
+
+		for i, e := range tv {
+			_, isList := e.([]interface{})
+			if isList {
+				return nil, fmt.Errorf("param '%s' has an unsupported type", name)
+			}
+
+			ev, err := sqlQueryHTTPParam(name, e)
+			if err != nil {
+				return nil, err
+			}
+
+			list.Values[i] = ev
+		}
+
+		return &schema.SQLValue{Value: &schema.SQLValue_List{List: list}}, nil
+	}
+
+	return nil, fmt.Errorf("param '%s' has an unsupported type", name)
+}
+
+func sqlQueryHTTPValue(v *schema.SQLValue) interface{} {
+	switch tv := v.GetValue().(type) {
+	case *schema.SQLValue_N:
+		return int64(tv.N)
+	case *schema.SQLValue_S:
+		return tv.S
+	case *schema.SQLValue_B:
+		return tv.B
+	case *schema.SQLValue_Bs:
+		return hex.EncodeToString(tv.Bs)
+	case *schema.SQLValue_F:
+		return tv.F
+	case *schema.SQLValue_Ts:
+		return schema.TimeFromInt64(tv.Ts).Format(time.RFC3339Nano)
+	}
+
+	return nil
+}
+
+// readSQLQueryHTTPRows calls fn with the first row, if any, and with the ones read after it, up to limit rows.
+// It returns whether more rows were left to be read
+func readSQLQueryHTTPRows(first *schema.Row, rows database.SQLRows, limit int, fn func(i int, row *schema.Row)) (truncated bool, err error) {
+	row := first
+
+	for i := 0; row != nil; i++ {
+		if i == limit {
+			return true, nil
+		}
+
+		fn(i, row)
+
+		row, err = rows.Read()
+		if errors.Is(err, sql.ErrNoMoreRows) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return false, nil
+}
+
+func writeSQLQueryHTTPJSON(w http.ResponseWriter, first *schema.Row, rows database.SQLRows, limit int) {
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+
+	jsonCols := make([]sqlQueryHTTPColumn, len(rows.Columns()))
+	for i, c := range rows.Columns() {
+		jsonCols[i] = sqlQueryHTTPColumn{Name: c.Name, Type: c.Type}
+	}
+
+	bw.WriteString(`{"columns":`)
+	enc.Encode(jsonCols)
+	bw.WriteString(`,"rows":[`)
+
+	truncated, err := readSQLQueryHTTPRows(first, rows, limit, func(i int, row *schema.Row) {
+		if i > 0 {
+			bw.WriteString(",")
+		}
+
+		values := make([]interface{}, len(row.Values))
+		for j, v := range row.Values {
+			values[j] = sqlQueryHTTPValue(v)
+		}
+
+		enc.Encode(values)
+
+		if (i+1)%sqlQueryHTTPFlushRows == 0 {
+			flushSQLQueryHTTP(bw, w)
+		}
+	})
+
+	fmt.Fprintf(bw, `],"truncated":%t`, truncated)
+
+	if err != nil {
+		bw.WriteString(`,"error":`)
+		enc.Encode(err.Error())
+	}
+
+	bw.WriteString("}\n")
+
+	flushSQLQueryHTTP(bw, w)
+}
+
+// writeSQLQueryHTTPCSV writes the rows as CSV, a failure while reading them ends the response early.
+// Whether rows were left out and the failure, if any, are set in the trailers
+func writeSQLQueryHTTPCSV(w http.ResponseWriter, first *schema.Row, rows database.SQLRows, limit int) {
+	cw := csv.NewWriter(w)
+
+	header := make([]string, len(rows.Columns()))
+	for i, c := range rows.Columns() {
+		header[i] = c.Name
+	}
+
+	cw.Write(header)
+
+	truncated, err := readSQLQueryHTTPRows(first, rows, limit, func(i int, row *schema.Row) {
+		record := make([]string, len(row.Values))
+		for j, v := range row.Values {
+			record[j] = string(schema.RenderValueAsByte(v.GetValue()))
+		}
+
+		cw.Write(record)
+
+		if (i+1)%sqlQueryHTTPFlushRows == 0 {
+			cw.Flush()
+			if f, ok := w.(http.Flusher); ok {
+				f.Flush()
+			}
+		}
+	})
+
+	cw.Flush()
+
+	w.Header().Set(SQLQueryHTTPTruncatedTrailer, strconv.FormatBool(truncated))
+
+	if err != nil {
+		w.Header().Set(SQLQueryHTTPErrorTrailer, strings.ReplaceAll(err.Error(), "\n", " "))
+	}
+}
+
+func flushSQLQueryHTTP(bw *bufio.Writer, w http.ResponseWriter) {
+	bw.Flush()
+
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func writeSQLQueryHTTPError(w http.ResponseWriter, code int, err error) {
+	msg := err.Error()
+	if st, ok := status.FromError(err); ok {
+		msg = st.Message()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	json.NewEncoder(w).Encode(map[string]string{"error": msg})
+}
@@ -0,0 +1,289 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestSQLQueryHTTP(t *testing.T) {
+	serverOptions := DefaultOptions().
+		WithDir("data_sql_query_http").
+		WithListener(bufconn.Listen(1024 * 1024)).
+		WithMetricsServer(false).
+		WithAdminPassword(auth.SysAdminPassword)
+
+	s := DefaultServer().WithOptions(serverOptions).(*ImmuServer)
+	defer os.RemoveAll(s.Options.Dir)
+
+	err := s.Initialize()
+	require.NoError(t, err)
+
+	lr, err := s.Login(context.Background(), &schema.LoginRequest{
+		User:     []byte(auth.SysAdminUsername),
+		Password: []byte(auth.SysAdminPassword),
+	})
+	require.NoError(t, err)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", lr.Token))
+
+	_, err = s.SQLExec(ctx, &schema.SQLExecRequest{Sql: `
+		CREATE TABLE products (id INTEGER, name VARCHAR, active BOOLEAN, code BLOB, PRIMARY KEY id);
+		INSERT INTO products (id, name, active, code) VALUES (1, 'coffee', true, x'c0ffee'), (2, 'tea', false, NULL), (3, 'milk, fresh', true, NULL);
+	`})
+	require.NoError(t, err)
+
+	handler := sqlQueryHTTPHandler(s)
+
+	query := func(body, token, accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, SQLQueryHTTPPath, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	t.Run("list params", func(t *testing.T) {
+		rec := query(`{"sql": "SELECT id FROM products WHERE id IN @ids", "params": {"ids": [2, 1, null]}}`, lr.Token, "")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		var res struct {
+			Rows [][]interface{} `json:"rows"`
+		}
+
+		err := json.Unmarshal(rec.Body.Bytes(), &res)
+		require.NoError(t, err)
+		require.Equal(t, [][]interface{}{{float64(1)}, {float64(2)}}, res.Rows)
+	})
+
+	t.Run("json results", func(t *testing.T) {
+		rec := query(`{"sql": "SELECT id, name, active, code FROM products WHERE id <= @maxid", "params": {"maxid": 2}}`, lr.Token, "")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+		var res struct {
+			Columns []struct {
+				Name string `json:"name"`
+				Type string `json:"type"`
+			} `json:"columns"`
+			Rows      [][]interface{} `json:"rows"`
+			Truncated bool            `json:"truncated"`
+		}
+
+		err := json.Unmarshal(rec.Body.Bytes(), &res)
+		require.NoError(t, err)
+		require.Len(t, res.Columns, 4)
+		require.Equal(t, "(defaultdb.products.id)", res.Columns[0].Name)
+		require.Equal(t, "INTEGER", res.Columns[0].Type)
+		require.Equal(t, [][]interface{}{
+			{float64(1), "coffee", true, "c0ffee"},
+			{float64(2), "tea", false, nil},
+		}, res.Rows)
+		require.False(t, res.Truncated)
+	})
+
+	t.Run("csv results", func(t *testing.T) {
+		rec := query(`{"sql": "SELECT id, name FROM products", "limit": 2}`, lr.Token, "text/csv")
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, "text/csv", rec.Header().Get("Content-Type"))
+		require.Equal(t, "(defaultdb.products.id),(defaultdb.products.name)\n1,coffee\n2,tea\n", rec.Body.String())
+		require.Equal(t, "true", rec.Result().Trailer.Get(SQLQueryHTTPTruncatedTrailer))
+		require.Empty(t, rec.Result().Trailer.Get(SQLQueryHTTPErrorTrailer))
+
+		rec = query(`{"sql": "SELECT id, name FROM products WHERE id = 3", "format": "csv"}`, lr.Token, "")
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, "(defaultdb.products.id),(defaultdb.products.name)\n3,\"milk, fresh\"\n", rec.Body.String())
+		require.Equal(t, "false", rec.Result().Trailer.Get(SQLQueryHTTPTruncatedTrailer))
+		require.Empty(t, rec.Result().Trailer.Get(SQLQueryHTTPErrorTrailer))
+	})
+
+	t.Run("limited results", func(t *testing.T) {
+		rec := query(`{"sql": "SELECT id FROM products", "limit": 1}`, lr.Token, "")
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.JSONEq(t, `{"columns":[{"name":"(defaultdb.products.id)","type":"INTEGER"}],"rows":[[1]],"truncated":true}`, rec.Body.String())
+	})
+
+	t.Run("streamed results", func(t *testing.T) {
+		_, err := s.SQLExec(ctx, &schema.SQLExecRequest{Sql: "CREATE TABLE numbers (n INTEGER, PRIMARY KEY n)"})
+		require.NoError(t, err)
+
+		for i := 0; i < 2*sqlQueryHTTPFlushRows+50; i++ {
+			_, err = s.SQLExec(ctx, &schema.SQLExecRequest{
+				Sql:    "INSERT INTO numbers (n) VALUES (@n)",
+				Params: []*schema.NamedParam{{Name: "n", Value: &schema.SQLValue{Value: &schema.SQLValue_N{N: uint64(i)}}}},
+			})
+			require.NoError(t, err)
+		}
+
+		// servers not reading rows one at a time get the whole result written the same way
+		materialized := sqlQueryHTTPHandler(struct{ schema.ImmuServiceServer }{s})
+
+		for _, c := range []struct {
+			limit     int
+			rows      int
+			truncated bool
+		}{
+			{limit: 2*sqlQueryHTTPFlushRows + 50, rows: 2*sqlQueryHTTPFlushRows + 50},
+			{limit: 2 * sqlQueryHTTPFlushRows, rows: 2 * sqlQueryHTTPFlushRows, truncated: true},
+			{limit: 0, rows: 2*sqlQueryHTTPFlushRows + 50},
+		} {
+			body := fmt.Sprintf(`{"sql": "SELECT n FROM numbers", "limit": %d}`, c.limit)
+
+			rec := query(body, lr.Token, "")
+			require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+			require.True(t, rec.Flushed)
+
+			var res struct {
+				Rows      [][]interface{} `json:"rows"`
+				Truncated bool            `json:"truncated"`
+			}
+
+			err := json.Unmarshal(rec.Body.Bytes(), &res)
+			require.NoError(t, err)
+			require.Len(t, res.Rows, c.rows)
+			require.Equal(t, c.truncated, res.Truncated)
+
+			for i, row := range res.Rows {
+				require.Equal(t, []interface{}{float64(i)}, row)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, SQLQueryHTTPPath, strings.NewReader(body))
+			req.Header.Set("Authorization", "Bearer "+lr.Token)
+
+			mrec := httptest.NewRecorder()
+			materialized.ServeHTTP(mrec, req)
+			require.Equal(t, http.StatusOK, mrec.Code)
+			require.Equal(t, rec.Body.String(), mrec.Body.String())
+		}
+
+		rec := query(`{"sql": "SELECT n FROM numbers WHERE n < 0", "format": "csv"}`, lr.Token, "")
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, "(defaultdb.numbers.n)\n", rec.Body.String())
+	})
+
+	t.Run("read-only enforcement", func(t *testing.T) {
+		for _, stmt := range []string{
+			"DELETE FROM products",
+			"INSERT INTO products (id) VALUES (4)",
+			"SELECT id FROM products; UPSERT INTO products (id) VALUES (4)",
+			"CREATE TABLE t (id INTEGER, PRIMARY KEY id)",
+		} {
+			body, err := json.Marshal(map[string]string{"sql": stmt})
+			require.NoError(t, err)
+
+			rec := query(string(body), lr.Token, "")
+			require.Equal(t, http.StatusBadRequest, rec.Code, stmt)
+			require.Contains(t, rec.Body.String(), `"error"`)
+		}
+
+		rec := query(`{"sql": "SELECT COUNT() FROM products"}`, lr.Token, "")
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Contains(t, rec.Body.String(), `"rows":[[3]`)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		rec := query(`{"sql": "SELECT id FROM products"}`, "", "")
+		require.Equal(t, http.StatusUnauthorized, rec.Code)
+
+		rec = query(`{"sql": "SELECT id FROM products"}`, "invalid token", "")
+		require.NotEqual(t, http.StatusOK, rec.Code)
+
+		rec = query(`{"sql": "SELECT id FROM products", "limit": 1001}`, lr.Token, "")
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+
+		rec = query(`{"sql": "SELECT id FROM products", "format": "xml"}`, lr.Token, "")
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+
+		rec = query(`{"sql": "SELECT id FROM products WHERE id = @id", "params": {"id": {"value": 1}}}`, lr.Token, "")
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+
+		rec = query(`{"sql": "SELECT id FROM products WHERE id IN @ids", "params": {"ids": [[1]]}}`, lr.Token, "")
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+
+		rec = query(`{"sql": "SELECT id FROM products WHERE id = @id", "params": {"id": [1]}}`, lr.Token, "")
+		require.NotEqual(t, http.StatusOK, rec.Code)
+
+		rec = query(`not json`, lr.Token, "")
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+
+		rec = query(`{"sql": "SELECT id FROM unknown_table"}`, lr.Token, "")
+		require.NotEqual(t, http.StatusOK, rec.Code)
+
+		req := httptest.NewRequest(http.MethodGet, SQLQueryHTTPPath, nil)
+		grec := httptest.NewRecorder()
+		handler.ServeHTTP(grec, req)
+		require.Equal(t, http.StatusMethodNotAllowed, grec.Code)
+	})
+}
+
+// failingSQLRows returns a single row and then fails
+type failingSQLRows struct {
+	read bool
+}
+
+func (rs *failingSQLRows) Columns() []*schema.Column {
+	return []*schema.Column{{Name: "(defaultdb.t.id)", Type: "INTEGER"}}
+}
+
+func (rs *failingSQLRows) Read() (*schema.Row, error) {
+	if rs.read {
+		return nil, errors.New("read failure")
+	}
+
+	rs.read = true
+
+	return &schema.Row{Values: []*schema.SQLValue{{Value: &schema.SQLValue_N{N: 2}}}}, nil
+}
+
+func (rs *failingSQLRows) Close() error {
+	return nil
+}
+
+func TestSQLQueryHTTPReadFailure(t *testing.T) {
+	first := &schema.Row{Values: []*schema.SQLValue{{Value: &schema.SQLValue_N{N: 1}}}}
+
+	rec := httptest.NewRecorder()
+	writeSQLQueryHTTPJSON(rec, first, &failingSQLRows{}, 10)
+	require.JSONEq(t, `{"columns":[{"name":"(defaultdb.t.id)","type":"INTEGER"}],"rows":[[1],[2]],"truncated":false,"error":"read failure"}`, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	rec.Header().Set("Trailer", SQLQueryHTTPTruncatedTrailer+", "+SQLQueryHTTPErrorTrailer)
+	writeSQLQueryHTTPCSV(rec, first, &failingSQLRows{}, 10)
+	require.Equal(t, "(defaultdb.t.id)\n1\n2\n", rec.Body.String())
+	require.Equal(t, "false", rec.Result().Trailer.Get(SQLQueryHTTPTruncatedTrailer))
+	require.Equal(t, "read failure", rec.Result().Trailer.Get(SQLQueryHTTPErrorTrailer))
+}
@@ -17,13 +17,17 @@ package server
 
 import (
 	"context"
+	"errors"
 	"os"
 	"testing"
 
+	"github.com/codenotary/immudb/embedded/sql"
 	"github.com/codenotary/immudb/pkg/api/schema"
 	"github.com/codenotary/immudb/pkg/auth"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -57,6 +61,12 @@ func TestSQLInteraction(t *testing.T) {
 	_, err = s.VerifiableSQLGet(ctx, nil)
 	require.Error(t, err)
 
+	_, err = s.ExportSQLSchema(ctx, &emptypb.Empty{})
+	require.Error(t, err)
+
+	_, err = s.ImportSQLSchema(ctx, nil)
+	require.Error(t, err)
+
 	r := &schema.LoginRequest{
 		User:     []byte(auth.SysAdminUsername),
 		Password: []byte(auth.SysAdminPassword),
@@ -81,6 +91,13 @@ func TestSQLInteraction(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, res.Rows, 1)
 
+	sch, err := s.ExportSQLSchema(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	require.Equal(t, "CREATE TABLE table1 (id INTEGER NOT NULL, PRIMARY KEY id);\n", sch.Ddl)
+
+	_, err = s.ImportSQLSchema(ctx, sch)
+	require.True(t, errors.Is(err, sql.ErrTableAlreadyExists))
+
 	xres, err = s.SQLExec(ctx, &schema.SQLExecRequest{Sql: "INSERT INTO table1 (id) VALUES (1),(2),(3)"})
 	require.NoError(t, err)
 	require.Len(t, xres.Ctxs, 0)
@@ -100,3 +117,244 @@ func TestSQLInteraction(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, e)
 }
+
+func TestSQLPolicies(t *testing.T) {
+	serverOptions := DefaultOptions().WithMetricsServer(false).WithAdminPassword(auth.SysAdminPassword)
+	s := DefaultServer().WithOptions(serverOptions).(*ImmuServer)
+	defer os.RemoveAll(s.Options.Dir)
+
+	s.Initialize()
+
+	lr, err := s.Login(context.Background(), &schema.LoginRequest{
+		User:     []byte(auth.SysAdminUsername),
+		Password: []byte(auth.SysAdminPassword),
+	})
+	require.NoError(t, err)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", lr.Token))
+
+	_, err = s.SQLExec(ctx, &schema.SQLExecRequest{Sql: `
+		CREATE TABLE table1 (id INTEGER, PRIMARY KEY id);
+		CREATE POLICY first_rows ON table1 TO read USING (id < 3);
+		INSERT INTO table1 (id) VALUES (1),(2),(3)
+	`})
+	require.NoError(t, err)
+
+	_, err = s.CreateUser(ctx, &schema.CreateUserRequest{
+		User:       testUsername,
+		Password:   testPassword,
+		Database:   DefaultdbName,
+		Permission: auth.PermissionR,
+	})
+	require.NoError(t, err)
+
+	// system admins are not restricted by policies
+	res, err := s.SQLQuery(ctx, &schema.SQLQueryRequest{Sql: "SELECT id FROM table1"})
+	require.NoError(t, err)
+	require.Len(t, res.Rows, 3)
+
+	lr, err = s.Login(context.Background(), &schema.LoginRequest{User: testUsername, Password: testPassword})
+	require.NoError(t, err)
+
+	userCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", lr.Token))
+
+	ur, err := s.UseDatabase(userCtx, &schema.Database{DatabaseName: DefaultdbName})
+	require.NoError(t, err)
+
+	userCtx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", ur.Token))
+
+	res, err = s.SQLQuery(userCtx, &schema.SQLQueryRequest{Sql: "SELECT id FROM table1"})
+	require.NoError(t, err)
+	require.Len(t, res.Rows, 2)
+
+	_, err = s.VerifiableSQLGet(userCtx, &schema.VerifiableSQLGetRequest{
+		SqlGetRequest: &schema.SQLGetRequest{Table: "table1", PkValue: &schema.SQLValue{Value: &schema.SQLValue_N{N: 3}}},
+	})
+	require.Error(t, err)
+}
+
+func TestSQLPoliciesRawReads(t *testing.T) {
+	serverOptions := DefaultOptions().
+		WithDir("data_sql_policies_raw_reads").
+		WithListener(bufconn.Listen(1024 * 1024)).
+		WithMetricsServer(false).
+		WithAdminPassword(auth.SysAdminPassword).
+		WithFeatures(string(FeatureAsyncReplication))
+	s := DefaultServer().WithOptions(serverOptions).(*ImmuServer)
+	defer os.RemoveAll(s.Options.Dir)
+
+	err := s.Initialize()
+	require.NoError(t, err)
+
+	lr, err := s.Login(context.Background(), &schema.LoginRequest{
+		User:     []byte(auth.SysAdminUsername),
+		Password: []byte(auth.SysAdminPassword),
+	})
+	require.NoError(t, err)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", lr.Token))
+
+	res, err := s.SQLExec(ctx, &schema.SQLExecRequest{Sql: `
+		CREATE TABLE table1 (id INTEGER, secret VARCHAR, PRIMARY KEY id);
+		INSERT INTO table1 (id, secret) VALUES (1, 'public'),(2, 'public'),(3, 'restricted')
+	`})
+	require.NoError(t, err)
+
+	rowsTx := res.Dtxs[len(res.Dtxs)-1].Id
+
+	_, err = s.CreateUser(ctx, &schema.CreateUserRequest{
+		User:       testUsername,
+		Password:   testPassword,
+		Database:   DefaultdbName,
+		Permission: auth.PermissionR,
+	})
+	require.NoError(t, err)
+
+	lr, err = s.Login(context.Background(), &schema.LoginRequest{User: testUsername, Password: testPassword})
+	require.NoError(t, err)
+
+	userCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", lr.Token))
+
+	ur, err := s.UseDatabase(userCtx, &schema.Database{DatabaseName: DefaultdbName})
+	require.NoError(t, err)
+
+	userCtx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", ur.Token))
+
+	// raw reads are allowed as long as no table has policies
+	_, err = s.TxById(userCtx, &schema.TxRequest{Tx: rowsTx})
+	require.NoError(t, err)
+
+	err = s.ExportTx(&schema.TxRequest{Tx: rowsTx}, &exportTxServerMock{ctx: userCtx})
+	require.NoError(t, err)
+
+	_, err = s.SQLExec(ctx, &schema.SQLExecRequest{Sql: "CREATE POLICY public_rows ON table1 TO read USING (secret = 'public')"})
+	require.NoError(t, err)
+
+	qres, err := s.SQLQuery(userCtx, &schema.SQLQueryRequest{Sql: "SELECT id FROM table1"})
+	require.NoError(t, err)
+	require.Len(t, qres.Rows, 2)
+
+	// the restricted row is held by the exported tx, thus it can't be read by users subject to policies
+	exported := &exportTxServerMock{ctx: userCtx}
+
+	err = s.ExportTx(&schema.TxRequest{Tx: rowsTx}, exported)
+	require.Equal(t, ErrRawReadRestricted, err)
+	require.Zero(t, exported.chunks)
+
+	_, err = s.TxById(userCtx, &schema.TxRequest{Tx: rowsTx})
+	require.Equal(t, ErrRawReadRestricted, err)
+
+	_, err = s.Scan(userCtx, &schema.ScanRequest{})
+	require.Equal(t, ErrRawReadRestricted, err)
+
+	_, err = s.Get(userCtx, &schema.KeyRequest{Key: []byte("key1")})
+	require.Equal(t, ErrRawReadRestricted, err)
+
+	// system admins are not restricted by policies
+	exported = &exportTxServerMock{ctx: ctx}
+
+	err = s.ExportTx(&schema.TxRequest{Tx: rowsTx}, exported)
+	require.NoError(t, err)
+	require.NotZero(t, exported.chunks)
+}
+
+func TestSQLPoliciesRawWrites(t *testing.T) {
+	serverOptions := DefaultOptions().
+		WithDir("data_sql_policies_raw_writes").
+		WithListener(bufconn.Listen(1024 * 1024)).
+		WithMetricsServer(false).
+		WithAdminPassword(auth.SysAdminPassword)
+	s := DefaultServer().WithOptions(serverOptions).(*ImmuServer)
+	defer os.RemoveAll(s.Options.Dir)
+
+	err := s.Initialize()
+	require.NoError(t, err)
+
+	lr, err := s.Login(context.Background(), &schema.LoginRequest{
+		User:     []byte(auth.SysAdminUsername),
+		Password: []byte(auth.SysAdminPassword),
+	})
+	require.NoError(t, err)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", lr.Token))
+
+	_, err = s.SQLExec(ctx, &schema.SQLExecRequest{Sql: `
+		CREATE TABLE table1 (id INTEGER, secret VARCHAR, PRIMARY KEY id);
+		INSERT INTO table1 (id, secret) VALUES (1, 'public')
+	`})
+	require.NoError(t, err)
+
+	_, err = s.CreateUser(ctx, &schema.CreateUserRequest{
+		User:       testUsername,
+		Password:   testPassword,
+		Database:   DefaultdbName,
+		Permission: auth.PermissionRW,
+	})
+	require.NoError(t, err)
+
+	lr, err = s.Login(context.Background(), &schema.LoginRequest{User: testUsername, Password: testPassword})
+	require.NoError(t, err)
+
+	userCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", lr.Token))
+
+	ur, err := s.UseDatabase(userCtx, &schema.Database{DatabaseName: DefaultdbName})
+	require.NoError(t, err)
+
+	userCtx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", ur.Token))
+
+	setReq := &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key1"), Value: []byte("value1")}}}
+
+	execAllReq := &schema.ExecAllRequest{Operations: []*schema.Op{
+		{Operation: &schema.Op_Kv{Kv: &schema.KeyValue{Key: []byte("key2"), Value: []byte("value2")}}},
+	}}
+
+	// raw writes are allowed as long as no table has policies
+	_, err = s.Set(userCtx, setReq)
+	require.NoError(t, err)
+
+	_, err = s.ExecAll(userCtx, execAllReq)
+	require.NoError(t, err)
+
+	_, err = s.SQLExec(ctx, &schema.SQLExecRequest{Sql: "CREATE POLICY public_rows ON table1 TO readwrite USING (secret = 'public')"})
+	require.NoError(t, err)
+
+	_, err = s.Set(userCtx, setReq)
+	require.Equal(t, ErrRawWriteRestricted, err)
+
+	_, err = s.ExecAll(userCtx, execAllReq)
+	require.Equal(t, ErrRawWriteRestricted, err)
+
+	_, err = s.SetReference(userCtx, &schema.ReferenceRequest{Key: []byte("ref1"), ReferencedKey: []byte("key1")})
+	require.Equal(t, ErrRawWriteRestricted, err)
+
+	_, err = s.ZAdd(userCtx, &schema.ZAddRequest{Set: []byte("set1"), Key: []byte("key1"), Score: 1})
+	require.Equal(t, ErrRawWriteRestricted, err)
+
+	_, err = s.ExecMultiDbAll(userCtx, &schema.ExecMultiDbAllRequest{
+		Requests: []*schema.DatabaseExecAllRequest{{Database: DefaultdbName, Request: execAllReq}},
+	})
+	require.Equal(t, ErrRawWriteRestricted, err)
+
+	// rows are still written through SQL statements, subject to the policies
+	_, err = s.SQLExec(userCtx, &schema.SQLExecRequest{Sql: "INSERT INTO table1 (id, secret) VALUES (2, 'public')"})
+	require.NoError(t, err)
+
+	// system admins are not restricted by policies
+	_, err = s.Set(ctx, setReq)
+	require.NoError(t, err)
+}
+
+type exportTxServerMock struct {
+	grpc.ServerStream
+	ctx    context.Context
+	chunks int
+}
+
+func (s *exportTxServerMock) Send(chunk *schema.Chunk) error {
+	s.chunks++
+	return nil
+}
+
+func (s *exportTxServerMock) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/database"
+)
+
+// minFreeDiskSpace is the free space required within the filesystem of each data folder
+const minFreeDiskSpace = 64 << 20
+
+// minMaxOpenedFiles is the lowest limit on the number of files the process may open
+const minMaxOpenedFiles = 256
+
+// openedFilesHeadroom is the number of files left to be opened once databases are loaded e.g. by client connections
+const openedFilesHeadroom = 128
+
+// maxClockSkew is how far ahead of the system clock the last transaction of a database may have been committed
+const maxClockSkew = time.Minute
+
+// earliestClock is a point in time the system clock can not be behind of
+var earliestClock = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// checkEnvironment checks the environment the server is about to run on before any database is loaded,
+// every failing check is reported so they can be fixed at once
+func (s *ImmuServer) checkEnvironment() error {
+	var failures []string
+
+	for _, dir := range s.dataDirs() {
+		err := checkDirWritable(dir)
+		if err != nil {
+			failures = append(failures, err.Error())
+			continue
+		}
+
+		err = checkFreeDiskSpace(dir, freeDiskSpace(dir))
+		if err != nil {
+			failures = append(failures, err.Error())
+		}
+	}
+
+	err := checkClock(time.Now())
+	if err != nil {
+		failures = append(failures, err.Error())
+	}
+
+	err = checkMaxOpenedFiles(processMaxOpenedFiles())
+	if err != nil {
+		failures = append(failures, err.Error())
+	}
+
+	return startupChecksError(failures)
+}
+
+// probeDatabases quickly checks the integrity of the loaded databases, along with the number of files left to be opened
+func (s *ImmuServer) probeDatabases() error {
+	var failures []string
+
+	dbs := []database.DB{s.sysDb}
+
+	for i := 0; i < s.dbList.Length(); i++ {
+		db := s.dbList.GetByIndex(int64(i))
+		if db != nil {
+			dbs = append(dbs, db)
+		}
+	}
+
+	now := time.Now()
+
+	for _, db := range dbs {
+		err := probeDatabase(db, now)
+		if err != nil {
+			failures = append(failures, err.Error())
+		}
+	}
+
+	err := checkOpenedFiles(processOpenedFiles(), processMaxOpenedFiles())
+	if err != nil {
+		failures = append(failures, err.Error())
+	}
+
+	return startupChecksError(failures)
+}
+
+func startupChecksError(failures []string) error {
+	if len(failures) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w:\n - %s\n(checks can be skipped with --skip-checks)", ErrStartupChecksFailed, strings.Join(failures, "\n - "))
+}
+
+// dataDirs returns the folders databases and their keys are stored in
+func (s *ImmuServer) dataDirs() []string {
+	dirs := []string{s.Options.Dir}
+
+	for _, dir := range []string{s.Options.TxLogDir, s.Options.ValueLogDir, s.Options.IndexDir, s.Options.EncryptionKeysDir} {
+		if dir != "" {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	return dirs
+}
+
+func checkDirWritable(dir string) error {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return fmt.Errorf("folder %s can not be created: %v, check the permissions of its parent folder", dir, err)
+	}
+
+	f, err := ioutil.TempFile(dir, ".startup-check")
+	if err != nil {
+		return fmt.Errorf("folder %s is not writable: %v, check its owner and permissions", dir, err)
+	}
+
+	f.Close()
+
+	return os.Remove(f.Name())
+}
+
+func checkFreeDiskSpace(dir string, free uint64) error {
+	// free space is not checked when it can't be determined
+	if free > 0 && free < minFreeDiskSpace {
+		return fmt.Errorf("only %d MB of disk space are left for folder %s while at least %d MB are required, free up some space or move the folder to a larger disk",
+			free>>20, dir, minFreeDiskSpace>>20)
+	}
+
+	return nil
+}
+
+func checkClock(now time.Time) error {
+	if now.Before(earliestClock) {
+		return fmt.Errorf("the system clock is set to %s, synchronize it e.g. with NTP", now.UTC().Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+func checkMaxOpenedFiles(limit uint64) error {
+	// the limit is not checked when it can't be determined
+	if limit > 0 && limit < minMaxOpenedFiles {
+		return fmt.Errorf("the process may open up to %d files while at least %d are required, raise the limit e.g. with 'ulimit -n'",
+			limit, minMaxOpenedFiles)
+	}
+
+	return nil
+}
+
+func checkOpenedFiles(opened, limit uint64) error {
+	if limit > 0 && opened+openedFilesHeadroom > limit {
+		return fmt.Errorf("%d of the %d files the process may open are already in use once databases are loaded, raise the limit e.g. with 'ulimit -n'",
+			opened, limit)
+	}
+
+	return nil
+}
+
+// probeDatabase reads the last committed transaction of the database, checking it matches the state of the database
+// and that it was not committed ahead of the system clock
+func probeDatabase(db database.DB, now time.Time) error {
+	state, err := db.CurrentState()
+	if err != nil {
+		return fmt.Errorf("database %s: state can not be read: %v", db.GetName(), err)
+	}
+
+	if state.TxId == 0 {
+		return nil
+	}
+
+	tx, err := db.TxByID(&schema.TxRequest{Tx: state.TxId})
+	if err != nil {
+		return fmt.Errorf("database %s: last transaction %d can not be read: %v, the database may be corrupted", db.GetName(), state.TxId, err)
+	}
+
+	stx := schema.TxFrom(tx)
+
+	if !bytes.Equal(stx.Alh[:], state.TxHash) {
+		return fmt.Errorf("database %s: last transaction %d does not match the state of the database, the database may be corrupted",
+			db.GetName(), state.TxId)
+	}
+
+	committedAt := time.Unix(stx.Ts, 0)
+
+	if committedAt.After(now.Add(maxClockSkew)) {
+		return fmt.Errorf("database %s: last transaction %d was committed at %s, ahead of the system clock (%s), synchronize it e.g. with NTP",
+			db.GetName(), state.TxId, committedAt.UTC().Format(time.RFC3339), now.UTC().Format(time.RFC3339))
+	}
+
+	return nil
+}
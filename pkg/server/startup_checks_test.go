@@ -0,0 +1,126 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestCheckDirWritable(t *testing.T) {
+	dir, err := ioutil.TempDir("", "startup_checks")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	err = checkDirWritable(filepath.Join(dir, "data"))
+	require.NoError(t, err)
+
+	file := filepath.Join(dir, "file")
+	err = ioutil.WriteFile(file, []byte("content"), 0644)
+	require.NoError(t, err)
+
+	err = checkDirWritable(file)
+	require.Error(t, err)
+
+	err = checkDirWritable(filepath.Join(file, "data"))
+	require.Error(t, err)
+}
+
+func TestCheckFreeDiskSpace(t *testing.T) {
+	require.NoError(t, checkFreeDiskSpace("data", 0))
+	require.NoError(t, checkFreeDiskSpace("data", minFreeDiskSpace))
+	require.Error(t, checkFreeDiskSpace("data", 1<<20))
+}
+
+func TestCheckClock(t *testing.T) {
+	require.NoError(t, checkClock(time.Now()))
+	require.Error(t, checkClock(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestCheckOpenedFiles(t *testing.T) {
+	require.NoError(t, checkMaxOpenedFiles(0))
+	require.NoError(t, checkMaxOpenedFiles(minMaxOpenedFiles))
+	require.Error(t, checkMaxOpenedFiles(100))
+
+	require.NoError(t, checkOpenedFiles(1000, 0))
+	require.NoError(t, checkOpenedFiles(100, 1024))
+	require.Error(t, checkOpenedFiles(1000, 1024))
+}
+
+func TestStartupChecksError(t *testing.T) {
+	require.NoError(t, startupChecksError(nil))
+
+	err := startupChecksError([]string{"first failure", "second failure"})
+	require.True(t, errors.Is(err, ErrStartupChecksFailed))
+	require.Contains(t, err.Error(), "first failure")
+	require.Contains(t, err.Error(), "second failure")
+	require.Contains(t, err.Error(), "--skip-checks")
+}
+
+func TestProbeDatabase(t *testing.T) {
+	dataDir := "test-probe-database"
+	defer os.RemoveAll(dataDir)
+
+	s := DefaultServer().WithOptions(DefaultOptions().
+		WithAuth(false).
+		WithMaintenance(false).
+		WithDir(dataDir).
+		WithListener(bufconn.Listen(1024 * 1024))).(*ImmuServer)
+
+	err := s.Initialize()
+	require.NoError(t, err)
+	defer s.CloseDatabases()
+
+	require.NoError(t, s.probeDatabases())
+
+	require.NoError(t, probeDatabase(s.sysDb, time.Now()))
+
+	err = probeDatabase(s.sysDb, time.Now().Add(-time.Hour))
+	require.Error(t, err)
+}
+
+func TestInitializeWithFailingStartupChecks(t *testing.T) {
+	dataFile := "test-failing-startup-checks"
+	err := ioutil.WriteFile(dataFile, []byte("content"), 0644)
+	require.NoError(t, err)
+	defer os.Remove(dataFile)
+
+	s := DefaultServer().WithOptions(DefaultOptions().
+		WithAuth(false).
+		WithMaintenance(false).
+		WithDir(dataFile)).(*ImmuServer)
+
+	err = s.Initialize()
+	require.True(t, errors.Is(err, ErrStartupChecksFailed))
+
+	s = DefaultServer().WithOptions(DefaultOptions().
+		WithAuth(false).
+		WithMaintenance(false).
+		WithDir(dataFile).
+		WithSkipStartupChecks(true)).(*ImmuServer)
+
+	err = s.Initialize()
+	require.Error(t, err)
+	require.False(t, errors.Is(err, ErrStartupChecksFailed))
+}
@@ -19,6 +19,7 @@ package server
 import (
 	"bufio"
 	"bytes"
+	"errors"
 	"io"
 
 	"github.com/codenotary/immudb/embedded/store"
@@ -31,14 +32,16 @@ import (
 
 // StreamGet return a stream of key-values to the client
 func (s *ImmuServer) StreamGet(kr *schema.KeyRequest, str schema.ImmuService_StreamGetServer) error {
-	ind, err := s.getDbIndexFromCtx(str.Context(), "StreamGet")
+	db, err := s.getDbFromCtx(str.Context(), "StreamGet")
 	if err != nil {
 		return err
 	}
+	defer s.dbList.Release(db)
 
 	kvsr := s.StreamServiceFactory.NewKvStreamSender(s.StreamServiceFactory.NewMsgSender(str))
 
-	entry, err := s.dbList.GetByIndex(ind).Get(kr)
+	// the value is read from the store while being sent
+	entry, value, valueLen, err := db.GetStreamed(str.Context(), kr)
 	if err != nil {
 		return err
 	}
@@ -49,8 +52,8 @@ func (s *ImmuServer) StreamGet(kr *schema.KeyRequest, str schema.ImmuService_Str
 			Size:    len(entry.Key),
 		},
 		Value: &stream.ValueSize{
-			Content: bufio.NewReader(bytes.NewBuffer(entry.Value)),
-			Size:    len(entry.Value),
+			Content: bufio.NewReader(value),
+			Size:    valueLen,
 		},
 	}
 
@@ -59,14 +62,19 @@ func (s *ImmuServer) StreamGet(kr *schema.KeyRequest, str schema.ImmuService_Str
 
 // StreamSet set a stream of key-values in the internal store
 func (s *ImmuServer) StreamSet(str schema.ImmuService_StreamSetServer) error {
-	ind, err := s.getDbIndexFromCtx(str.Context(), "StreamSet")
+	db, err := s.getDbFromCtx(str.Context(), "StreamSet")
 	if err != nil {
 		return err
 	}
+	defer s.dbList.Release(db)
 
 	kvsr := s.StreamServiceFactory.NewKvStreamReceiver(s.StreamServiceFactory.NewMsgReceiver(str))
 
-	var kvs = make([]*schema.KeyValue, 0)
+	// values are written into the store while being received
+	tx, err := db.NewStreamingTx(str.Context())
+	if err != nil {
+		return err
+	}
 
 	vlength := 0
 	for {
@@ -75,29 +83,28 @@ func (s *ImmuServer) StreamSet(str schema.ImmuService_StreamSetServer) error {
 			if err == io.EOF {
 				break
 			}
+			tx.Cancel()
 			return err
 		}
 
-		value, err := stream.ReadValue(vr, s.Options.StreamChunkSize)
+		n, err := tx.Add(key, stream.NewValueReader(vr))
+		if errors.Is(err, store.ErrorMaxValueLenExceeded) {
+			tx.Cancel()
+			return stream.ErrMaxValueLenExceeded
+		}
 		if err != nil {
-			if err == io.EOF {
-				kvs = append(kvs, &schema.KeyValue{Key: key, Value: value})
-				break
-			}
+			tx.Cancel()
+			return status.Errorf(codes.Unknown, "StreamSet receives following error: %s", err.Error())
 		}
 
-		vlength += len(value)
+		vlength += n
 		if vlength > stream.MaxTxValueLen {
+			tx.Cancel()
 			return stream.ErrMaxTxValuesLenExceeded
 		}
-
-		kvs = append(kvs, &schema.KeyValue{Key: key, Value: value})
 	}
 
-	txMeta, err := s.dbList.GetByIndex(ind).Set(&schema.SetRequest{KVs: kvs})
-	if err == store.ErrorMaxValueLenExceeded {
-		return stream.ErrMaxValueLenExceeded
-	}
+	txMeta, err := tx.Commit(false)
 	if err != nil {
 		return status.Errorf(codes.Unknown, "StreamSet receives following error: %s", err.Error())
 	}
@@ -112,14 +119,15 @@ func (s *ImmuServer) StreamSet(str schema.ImmuService_StreamSetServer) error {
 
 // StreamVerifiableGet ...
 func (s *ImmuServer) StreamVerifiableGet(req *schema.VerifiableGetRequest, str schema.ImmuService_StreamVerifiableGetServer) error {
-	ind, err := s.getDbIndexFromCtx(str.Context(), "StreamVerifiableGet")
+	db, err := s.getDbFromCtx(str.Context(), "StreamVerifiableGet")
 	if err != nil {
 		return err
 	}
+	defer s.dbList.Release(db)
 
 	vess := s.StreamServiceFactory.NewVEntryStreamSender(s.StreamServiceFactory.NewMsgSender(str))
 
-	vEntry, err := s.dbList.GetByIndex(ind).VerifiableGet(req)
+	vEntry, err := db.VerifiableGet(str.Context(), req)
 	if err != nil {
 		return err
 	}
@@ -129,7 +137,7 @@ func (s *ImmuServer) StreamVerifiableGet(req *schema.VerifiableGetRequest, str s
 		alh := md.Alh()
 
 		newState := &schema.ImmutableState{
-			Db:     s.dbList.GetByIndex(ind).GetOptions().GetDbName(),
+			Db:     db.GetOptions().GetDbName(),
 			TxId:   md.ID,
 			TxHash: alh[:],
 		}
@@ -151,6 +159,7 @@ func (s *ImmuServer) StreamVerifiableGet(req *schema.VerifiableGetRequest, str s
 		Tx:           vEntry.GetEntry().GetTx(),
 		Key:          vEntry.GetEntry().GetKey(),
 		ReferencedBy: vEntry.GetEntry().GetReferencedBy(),
+		Metadata:     vEntry.GetEntry().GetMetadata(),
 	}
 
 	entryWithoutValueProto, err := proto.Marshal(&entryWithoutValue)
@@ -189,10 +198,11 @@ func (s *ImmuServer) StreamVerifiableGet(req *schema.VerifiableGetRequest, str s
 
 // StreamVerifiableSet ...
 func (s *ImmuServer) StreamVerifiableSet(str schema.ImmuService_StreamVerifiableSetServer) error {
-	ind, err := s.getDbIndexFromCtx(str.Context(), "StreamVerifiableSet")
+	db, err := s.getDbFromCtx(str.Context(), "StreamVerifiableSet")
 	if err != nil {
 		return err
 	}
+	defer s.dbList.Release(db)
 
 	sr := s.StreamServiceFactory.NewMsgReceiver(str)
 	kvsr := s.StreamServiceFactory.NewKvStreamReceiver(sr)
@@ -245,8 +255,8 @@ func (s *ImmuServer) StreamVerifiableSet(str schema.ImmuService_StreamVerifiable
 		SetRequest:   &schema.SetRequest{KVs: kvs},
 		ProveSinceTx: proveSinceTx,
 	}
-	verifiableTx, err := s.dbList.GetByIndex(ind).VerifiableSet(&vSetReq)
-	if err == store.ErrorMaxValueLenExceeded {
+	verifiableTx, err := db.VerifiableSet(str.Context(), &vSetReq)
+	if errors.Is(err, store.ErrorMaxValueLenExceeded) {
 		return stream.ErrMaxValueLenExceeded
 	}
 	if err != nil {
@@ -260,7 +270,7 @@ func (s *ImmuServer) StreamVerifiableSet(str schema.ImmuService_StreamVerifiable
 		alh := md.Alh()
 
 		newState := &schema.ImmutableState{
-			Db:     s.dbList.GetByIndex(ind).GetOptions().GetDbName(),
+			Db:     db.GetOptions().GetDbName(),
 			TxId:   md.ID,
 			TxHash: alh[:],
 		}
@@ -282,12 +292,13 @@ func (s *ImmuServer) StreamVerifiableSet(str schema.ImmuService_StreamVerifiable
 }
 
 func (s *ImmuServer) StreamScan(req *schema.ScanRequest, str schema.ImmuService_StreamScanServer) error {
-	ind, err := s.getDbIndexFromCtx(str.Context(), "Scan")
+	db, err := s.getDbFromCtx(str.Context(), "Scan")
 	if err != nil {
 		return err
 	}
+	defer s.dbList.Release(db)
 
-	r, err := s.dbList.GetByIndex(ind).Scan(req)
+	r, err := db.Scan(str.Context(), req)
 	if err != nil {
 		return err
 	}
@@ -317,12 +328,13 @@ func (s *ImmuServer) StreamScan(req *schema.ScanRequest, str schema.ImmuService_
 
 // StreamZScan ...
 func (s *ImmuServer) StreamZScan(request *schema.ZScanRequest, server schema.ImmuService_StreamZScanServer) error {
-	ind, err := s.getDbIndexFromCtx(server.Context(), "ZScan")
+	db, err := s.getDbFromCtx(server.Context(), "ZScan")
 	if err != nil {
 		return err
 	}
+	defer s.dbList.Release(db)
 
-	r, err := s.dbList.GetByIndex(ind).ZScan(request)
+	r, err := db.ZScan(server.Context(), request)
 	if err != nil {
 		return err
 	}
@@ -374,12 +386,13 @@ func (s *ImmuServer) StreamZScan(request *schema.ZScanRequest, server schema.Imm
 }
 
 func (s *ImmuServer) StreamHistory(request *schema.HistoryRequest, server schema.ImmuService_StreamHistoryServer) error {
-	ind, err := s.getDbIndexFromCtx(server.Context(), "History")
+	db, err := s.getDbFromCtx(server.Context(), "History")
 	if err != nil {
 		return err
 	}
+	defer s.dbList.Release(db)
 
-	r, err := s.dbList.GetByIndex(ind).History(request)
+	r, err := db.History(server.Context(), request)
 	if err != nil {
 		return err
 	}
@@ -408,10 +421,11 @@ func (s *ImmuServer) StreamHistory(request *schema.HistoryRequest, server schema
 }
 
 func (s *ImmuServer) StreamExecAll(str schema.ImmuService_StreamExecAllServer) error {
-	ind, err := s.getDbIndexFromCtx(str.Context(), "StreamSet")
+	db, err := s.getDbFromCtx(str.Context(), "StreamExecAll")
 	if err != nil {
 		return err
 	}
+	defer s.dbList.Release(db)
 
 	sops := []*schema.Op{}
 	eas := s.StreamServiceFactory.NewExecAllStreamReceiver(s.StreamServiceFactory.NewMsgReceiver(str))
@@ -453,7 +467,7 @@ func (s *ImmuServer) StreamExecAll(str schema.ImmuService_StreamExecAllServer) e
 		}
 	}
 
-	txMeta, err := s.dbList.GetByIndex(ind).ExecAll(&schema.ExecAllRequest{Operations: sops})
+	txMeta, err := db.ExecAll(str.Context(), &schema.ExecAllRequest{Operations: sops})
 	if err != nil {
 		return err
 	}
@@ -465,3 +479,19 @@ func (s *ImmuServer) StreamExecAll(str schema.ImmuService_StreamExecAllServer) e
 
 	return nil
 }
+
+// Subscribe streams committed transactions to the client as soon as they are committed
+func (s *ImmuServer) Subscribe(req *schema.SubscribeRequest, str schema.ImmuService_SubscribeServer) error {
+	db, err := s.getDbFromCtx(str.Context(), "Subscribe")
+	if err != nil {
+		return err
+	}
+	defer s.dbList.Release(db)
+
+	err = db.Subscribe(req, str.Send, str.Context().Done())
+	if err == store.ErrCancellationRequested {
+		return str.Context().Err()
+	}
+
+	return err
+}
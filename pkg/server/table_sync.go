@@ -0,0 +1,1153 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/codenotary/immudb/embedded/sql"
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/database"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// tableSyncStatesFile is the file, inside the folder of the server, holding the latest verified state
+// of the source database of each table sync job, so it's not trusted again on first use after a restart
+const tableSyncStatesFile = "table_syncs.json"
+
+// tableSyncPageSize is the number of rows, or keys, compared at once between the source and target databases
+const tableSyncPageSize = 100
+
+// tableSync mirrors a SQL table, or the keys under a prefix, from a source database into a local target database.
+// Each round the job proves the history of the source database extends the one verified in the previous round,
+// then rows and keys differing from the ones held by the target are verified against it before being mirrored.
+// Rows and keys no longer held by the source are deleted from the target.
+type tableSync struct {
+	s      *ImmuServer
+	opts   *TableSyncOptions
+	source tableSyncSource
+
+	// state is the latest state of the source database verified by the job
+	state *schema.ImmutableState
+	// syncedTx is the tx of the source database the target was lastly synced with
+	syncedTx uint64
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newTableSync(s *ImmuServer, opts *TableSyncOptions, dialOpts ...grpc.DialOption) (*tableSync, error) {
+	err := opts.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	var source tableSyncSource
+
+	if opts.IsRemote() {
+		source, err = newRemoteTableSyncSource(opts, dialOpts...)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		source = &localTableSyncSource{s: s, dbName: opts.SourceDatabase}
+	}
+
+	state, err := s.readTableSyncState(opts.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return &tableSync{
+		s:      s,
+		opts:   opts,
+		source: source,
+		state:  state,
+	}, nil
+}
+
+func (j *tableSync) start() {
+	j.ctx, j.cancel = context.WithCancel(context.Background())
+
+	j.wg.Add(1)
+	go j.run()
+}
+
+func (j *tableSync) stop() error {
+	j.cancel()
+	j.wg.Wait()
+
+	return j.source.close()
+}
+
+func (j *tableSync) run() {
+	defer j.wg.Done()
+
+	for {
+		err := j.sync()
+		if err != nil && j.ctx.Err() == nil {
+			j.s.Logger.Warningf("Unable to sync '%s': %v", j.opts, err)
+			// the source is accessed again from scratch as the session may have expired
+			j.source.reset()
+		}
+
+		select {
+		case <-j.ctx.Done():
+			return
+		case <-time.After(j.opts.PollInterval):
+		}
+	}
+}
+
+// sync makes a round, the target is synced only if the source database changed since the previous one
+func (j *tableSync) sync() error {
+	state, err := j.source.CurrentState(j.ctx)
+	if err != nil {
+		return err
+	}
+
+	if state.TxId == 0 || state.TxId == j.syncedTx {
+		return nil
+	}
+
+	err = j.verifyState(state)
+	if err != nil {
+		return err
+	}
+
+	target, err := j.s.dbList.GetByName(j.opts.TargetDatabase)
+	if err != nil {
+		return err
+	}
+
+	if j.opts.Table != "" {
+		err = j.syncTable(target)
+	} else {
+		err = j.syncPrefix(target)
+	}
+	if err != nil {
+		return err
+	}
+
+	j.syncedTx = state.TxId
+
+	return j.s.writeTableSyncState(j.opts.String(), j.state)
+}
+
+// verifyState proves the history of the source database up to the given state extends the verified one.
+// The first state of the source database is trusted, as done by clients and replicas
+func (j *tableSync) verifyState(state *schema.ImmutableState) error {
+	if state.TxId < j.state.TxId {
+		return fmt.Errorf("%w: the source database holds less txs than already verified", ErrSourceDiverged)
+	}
+
+	vtx, err := j.source.VerifiableTxByID(j.ctx, &schema.VerifiableTxRequest{
+		Tx:           state.TxId,
+		ProveSinceTx: j.state.TxId,
+	})
+	if err != nil {
+		return err
+	}
+
+	if vtx.DualProof == nil || vtx.DualProof.TargetTxMetadata == nil || vtx.DualProof.TargetTxMetadata.Id != state.TxId {
+		return store.ErrCorruptedData
+	}
+
+	dualProof := schema.DualProofFrom(vtx.DualProof)
+	targetAlh := dualProof.TargetTxMetadata.Alh()
+
+	if j.state.TxId > 0 {
+		verifies := store.VerifyDualProof(
+			dualProof,
+			j.state.TxId,
+			state.TxId,
+			schema.DigestFrom(j.state.TxHash),
+			targetAlh,
+		)
+		if !verifies {
+			return ErrSourceDiverged
+		}
+	}
+
+	j.state = &schema.ImmutableState{TxId: state.TxId, TxHash: targetAlh[:]}
+
+	return nil
+}
+
+// verifyEntry verifies the entry committed in tx vTx is included in the history of the source database
+// proven up to the verified state, which is moved forward when the entry was committed afterwards
+func (j *tableSync) verifyEntry(vTx uint64, kv *store.KV, vtx *schema.VerifiableTx, proof *schema.InclusionProof) error {
+	if vtx == nil || vtx.DualProof == nil || vtx.DualProof.SourceTxMetadata == nil || vtx.DualProof.TargetTxMetadata == nil || proof == nil {
+		return store.ErrCorruptedData
+	}
+
+	dualProof := schema.DualProofFrom(vtx.DualProof)
+
+	var eh [sha256.Size]byte
+
+	var sourceID, targetID uint64
+	var sourceAlh, targetAlh [sha256.Size]byte
+
+	if j.state.TxId <= vTx {
+		eh = schema.DigestFrom(vtx.DualProof.TargetTxMetadata.EH)
+
+		sourceID = j.state.TxId
+		sourceAlh = schema.DigestFrom(j.state.TxHash)
+		targetID = vTx
+		targetAlh = dualProof.TargetTxMetadata.Alh()
+	} else {
+		eh = schema.DigestFrom(vtx.DualProof.SourceTxMetadata.EH)
+
+		sourceID = vTx
+		sourceAlh = dualProof.SourceTxMetadata.Alh()
+		targetID = j.state.TxId
+		targetAlh = schema.DigestFrom(j.state.TxHash)
+	}
+
+	if !store.VerifyInclusion(schema.InclusionProofFrom(proof), kv, eh) {
+		return store.ErrCorruptedData
+	}
+
+	if !store.VerifyDualProof(dualProof, sourceID, targetID, sourceAlh, targetAlh) {
+		return store.ErrCorruptedData
+	}
+
+	j.state = &schema.ImmutableState{TxId: targetID, TxHash: targetAlh[:]}
+
+	return nil
+}
+
+type tableSyncColumn struct {
+	name     string
+	colType  string
+	nullable bool
+	index    string
+}
+
+// sourceColumns returns the columns of the source table, in the order they were defined, and the position of the primary key
+func (j *tableSync) sourceColumns() ([]*tableSyncColumn, int, error) {
+	res, err := j.source.DescribeTable(j.ctx, j.opts.Table)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	cols := make([]*tableSyncColumn, len(res.Rows))
+	pkPos := -1
+
+	for i, row := range res.Rows {
+		if len(row.Values) < 4 {
+			return nil, 0, sql.ErrCorruptedData
+		}
+
+		cols[i] = &tableSyncColumn{
+			name:     row.Values[0].GetS(),
+			colType:  row.Values[1].GetS(),
+			nullable: row.Values[2].GetB(),
+			index:    row.Values[3].GetS(),
+		}
+
+		if cols[i].index == "PRIMARY KEY" {
+			pkPos = i
+		}
+	}
+
+	if pkPos < 0 {
+		return nil, 0, sql.ErrCorruptedData
+	}
+
+	return cols, pkPos, nil
+}
+
+// createTargetTable creates the table in the target database with the same columns and indexes of the source table
+func (j *tableSync) createTargetTable(target database.DB, cols []*tableSyncColumn, pkPos int) error {
+	_, err := target.DescribeTable(j.opts.Table)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, sql.ErrTableDoesNotExist) {
+		return err
+	}
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "CREATE TABLE %s (", j.opts.Table)
+
+	for _, c := range cols {
+		fmt.Fprintf(&b, "%s %s", c.name, c.colType)
+
+		if !c.nullable {
+			b.WriteString(" NOT NULL")
+		}
+
+		if c.index == "UNIQUE" {
+			b.WriteString(" UNIQUE")
+		}
+
+		b.WriteString(", ")
+	}
+
+	fmt.Fprintf(&b, "PRIMARY KEY %s);", cols[pkPos].name)
+
+	for _, c := range cols {
+		if c.index == "YES" {
+			fmt.Fprintf(&b, "CREATE INDEX ON %s(%s);", j.opts.Table, c.name)
+		}
+	}
+
+	_, err = target.SQLExec(j.ctx, &schema.SQLExecRequest{Sql: b.String()})
+	if err != nil {
+		return err
+	}
+
+	j.s.Logger.Infof("Table '%s' created in database '%s' to be synced with '%s'", j.opts.Table, j.opts.TargetDatabase, j.opts)
+
+	return nil
+}
+
+func (j *tableSync) syncTable(target database.DB) error {
+	cols, pkPos, err := j.sourceColumns()
+	if err != nil {
+		return err
+	}
+
+	err = j.createTargetTable(target, cols, pkPos)
+	if err != nil {
+		return err
+	}
+
+	colNames := make([]string, len(cols))
+	for i, c := range cols {
+		colNames[i] = c.name
+	}
+
+	selectCols := strings.Join(colNames, ", ")
+	pkName := cols[pkPos].name
+
+	// rows held by the source are mirrored when missing or different in the target
+	var last *schema.SQLValue
+
+	for {
+		page, err := j.source.SQLQuery(j.ctx, pageQuery(selectCols, j.opts.Table, pkName, last))
+		if err != nil {
+			return err
+		}
+
+		if len(page.Rows) == 0 {
+			break
+		}
+
+		pks := make([]*schema.SQLValue, len(page.Rows))
+		for i, row := range page.Rows {
+			pks[i] = row.Values[pkPos]
+		}
+
+		current, err := target.SQLQuery(j.ctx, inListQuery(selectCols, j.opts.Table, pkName, pks))
+		if err != nil {
+			return err
+		}
+
+		currentRows := make(map[string][]*schema.SQLValue, len(current.Rows))
+		for _, row := range current.Rows {
+			currentRows[schema.RenderValue(row.Values[pkPos].Value)] = row.Values
+		}
+
+		var changed [][]*schema.SQLValue
+
+		for _, row := range page.Rows {
+			equal, err := equalRowValues(row.Values, currentRows[schema.RenderValue(row.Values[pkPos].Value)])
+			if err != nil {
+				return err
+			}
+			if equal {
+				continue
+			}
+
+			values, err := j.verifiedRow(row.Values[pkPos], cols)
+			if err != nil {
+				return err
+			}
+
+			changed = append(changed, values)
+		}
+
+		if len(changed) > 0 {
+			_, err = target.SQLExec(j.ctx, upsertStmt(j.opts.Table, colNames, changed))
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(page.Rows) < tableSyncPageSize {
+			break
+		}
+
+		last = page.Rows[len(page.Rows)-1].Values[pkPos]
+	}
+
+	// rows no longer held by the source are deleted from the target
+	last = nil
+
+	for {
+		page, err := target.SQLQuery(j.ctx, pageQuery(pkName, j.opts.Table, pkName, last))
+		if err != nil {
+			return err
+		}
+
+		if len(page.Rows) == 0 {
+			break
+		}
+
+		pks := make([]*schema.SQLValue, len(page.Rows))
+		for i, row := range page.Rows {
+			pks[i] = row.Values[0]
+		}
+
+		held, err := j.source.SQLQuery(j.ctx, inListQuery(pkName, j.opts.Table, pkName, pks))
+		if err != nil {
+			return err
+		}
+
+		heldPKs := make(map[string]struct{}, len(held.Rows))
+		for _, row := range held.Rows {
+			heldPKs[schema.RenderValue(row.Values[0].Value)] = struct{}{}
+		}
+
+		var deleted []*schema.SQLValue
+
+		for _, pk := range pks {
+			_, ok := heldPKs[schema.RenderValue(pk.Value)]
+			if !ok {
+				deleted = append(deleted, pk)
+			}
+		}
+
+		if len(deleted) > 0 {
+			cond, params := pkInCondition(pkName, deleted)
+
+			_, err = target.SQLExec(j.ctx, &schema.SQLExecRequest{
+				Sql:    fmt.Sprintf("DELETE FROM %s WHERE %s", j.opts.Table, cond),
+				Params: params,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(page.Rows) < tableSyncPageSize {
+			break
+		}
+
+		last = pks[len(pks)-1]
+	}
+
+	return nil
+}
+
+// verifiedRow returns the values of the row of the source table with the given primary key, in the order of the columns,
+// once the encoded row is verified to be included in the history of the source database
+func (j *tableSync) verifiedRow(pkVal *schema.SQLValue, cols []*tableSyncColumn) ([]*schema.SQLValue, error) {
+	vEntry, err := j.source.VerifiableSQLGet(j.ctx, &schema.VerifiableSQLGetRequest{
+		SqlGetRequest: &schema.SQLGetRequest{Table: j.opts.Table, PkValue: pkVal},
+		ProveSinceTx:  j.state.TxId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if vEntry.SqlEntry == nil {
+		return nil, sql.ErrCorruptedData
+	}
+
+	pkID, ok := vEntry.ColIdsByName[sql.EncodeSelector("", j.opts.SourceDatabase, j.opts.Table, vEntry.PKName)]
+	if !ok {
+		return nil, sql.ErrCorruptedData
+	}
+
+	pkEncVal, err := sql.EncodeRawValue(schema.RawValue(pkVal), vEntry.ColTypesById[pkID], true)
+	if err != nil {
+		return nil, err
+	}
+
+	pkKey := sql.MapKey([]byte{database.SQLPrefix}, sql.RowPrefix, sql.EncodeID(vEntry.DatabaseId), sql.EncodeID(vEntry.TableId), sql.EncodeID(pkID), pkEncVal)
+
+	err = j.verifyEntry(vEntry.SqlEntry.Tx, &store.KV{Key: pkKey, Value: vEntry.SqlEntry.Value}, vEntry.VerifiableTx, vEntry.InclusionProof)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded, err := decodeRowValues(vEntry.SqlEntry.Value, vEntry.ColTypesById)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]*schema.SQLValue, len(cols))
+
+	for i, c := range cols {
+		colID, ok := vEntry.ColIdsByName[sql.EncodeSelector("", j.opts.SourceDatabase, j.opts.Table, c.name)]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", sql.ErrColumnDoesNotExist, c.name)
+		}
+
+		v, ok := decoded[colID]
+		if !ok {
+			v = &schema.SQLValue{Value: &schema.SQLValue_Null{}}
+		}
+
+		values[i] = v
+	}
+
+	return values, nil
+}
+
+func (j *tableSync) syncPrefix(target database.DB) error {
+	// keys held by the source are mirrored when missing or different in the target
+	var seekKey []byte
+
+	for {
+		page, err := j.source.Scan(j.ctx, &schema.ScanRequest{
+			Prefix:  j.opts.Prefix,
+			SeekKey: seekKey,
+			Limit:   tableSyncPageSize,
+			SinceTx: j.state.TxId,
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(page.Entries) == 0 {
+			break
+		}
+
+		keys := make([][]byte, len(page.Entries))
+		for i, e := range page.Entries {
+			keys[i] = entryKey(e)
+		}
+
+		current, err := target.GetAll(j.ctx, &schema.KeyListRequest{Keys: keys})
+		if err != nil {
+			return err
+		}
+
+		currentValues := make(map[string][]byte, len(current.Entries))
+		for _, e := range current.Entries {
+			currentValues[string(entryKey(e))] = e.Value
+		}
+
+		var kvs []*schema.KeyValue
+
+		for i, e := range page.Entries {
+			v, ok := currentValues[string(keys[i])]
+			if ok && bytes.Equal(v, e.Value) {
+				continue
+			}
+
+			ve, err := j.verifiedEntry(keys[i])
+			if err != nil {
+				return err
+			}
+
+			kvs = append(kvs, &schema.KeyValue{Key: keys[i], Value: ve.Value})
+		}
+
+		if len(kvs) > 0 {
+			_, err = target.Set(j.ctx, &schema.SetRequest{KVs: kvs})
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(page.Entries) < tableSyncPageSize {
+			break
+		}
+
+		seekKey = keys[len(keys)-1]
+	}
+
+	// keys no longer held by the source are deleted from the target
+	seekKey = nil
+
+	for {
+		page, err := target.Scan(j.ctx, &schema.ScanRequest{
+			Prefix:  j.opts.Prefix,
+			SeekKey: seekKey,
+			Limit:   tableSyncPageSize,
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(page.Entries) == 0 {
+			break
+		}
+
+		keys := make([][]byte, len(page.Entries))
+		for i, e := range page.Entries {
+			keys[i] = entryKey(e)
+		}
+
+		held, err := j.source.GetAll(j.ctx, &schema.KeyListRequest{Keys: keys, SinceTx: j.state.TxId})
+		if err != nil {
+			return err
+		}
+
+		heldKeys := make(map[string]struct{}, len(held.Entries))
+		for _, e := range held.Entries {
+			heldKeys[string(entryKey(e))] = struct{}{}
+		}
+
+		var kvs []*schema.KeyValue
+
+		for _, key := range keys {
+			_, ok := heldKeys[string(key)]
+			if !ok {
+				kvs = append(kvs, &schema.KeyValue{Key: key, Metadata: &schema.KVMetadata{Deleted: true}})
+			}
+		}
+
+		if len(kvs) > 0 {
+			_, err = target.Set(j.ctx, &schema.SetRequest{KVs: kvs})
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(page.Entries) < tableSyncPageSize {
+			break
+		}
+
+		seekKey = keys[len(keys)-1]
+	}
+
+	return nil
+}
+
+// verifiedEntry returns the entry of the source database the key resolves to, once it's verified to be included
+// in the history of the source database
+func (j *tableSync) verifiedEntry(key []byte) (*schema.Entry, error) {
+	vEntry, err := j.source.VerifiableGet(j.ctx, &schema.VerifiableGetRequest{
+		KeyRequest:   &schema.KeyRequest{Key: key},
+		ProveSinceTx: j.state.TxId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if vEntry.Entry == nil {
+		return nil, store.ErrCorruptedData
+	}
+
+	var vTx uint64
+	var kv *store.KV
+
+	if vEntry.Entry.ReferencedBy == nil {
+		vTx = vEntry.Entry.Tx
+		kv = database.EncodeKVWithMetadata(key, schema.KVMetadataFrom(vEntry.Entry.Metadata), vEntry.Entry.Value)
+	} else {
+		vTx = vEntry.Entry.ReferencedBy.Tx
+		kv = database.EncodeReference(vEntry.Entry.ReferencedBy.Key, vEntry.Entry.Key, vEntry.Entry.ReferencedBy.AtTx)
+	}
+
+	err = j.verifyEntry(vTx, kv, vEntry.VerifiableTx, vEntry.InclusionProof)
+	if err != nil {
+		return nil, err
+	}
+
+	return vEntry.Entry, nil
+}
+
+// entryKey returns the key the entry was read with, references are mirrored with the value they resolve to
+func entryKey(e *schema.Entry) []byte {
+	if e.ReferencedBy != nil {
+		return e.ReferencedBy.Key
+	}
+
+	return e.Key
+}
+
+// pageQuery returns the query selecting the rows following the given primary key, or the first ones when nil
+func pageQuery(cols, table, pkName string, last *schema.SQLValue) *schema.SQLQueryRequest {
+	if last == nil {
+		return &schema.SQLQueryRequest{Sql: fmt.Sprintf("SELECT %s FROM %s LIMIT %d", cols, table, tableSyncPageSize)}
+	}
+
+	return &schema.SQLQueryRequest{
+		Sql:    fmt.Sprintf("SELECT %s FROM %s WHERE %s > @last LIMIT %d", cols, table, pkName, tableSyncPageSize),
+		Params: []*schema.NamedParam{{Name: "last", Value: last}},
+	}
+}
+
+// inListQuery returns the query selecting the rows with the given primary keys
+func inListQuery(cols, table, pkName string, pks []*schema.SQLValue) *schema.SQLQueryRequest {
+	cond, params := pkInCondition(pkName, pks)
+
+	return &schema.SQLQueryRequest{
+		Sql:    fmt.Sprintf("SELECT %s FROM %s WHERE %s", cols, table, cond),
+		Params: params,
+	}
+}
+
+// pkInCondition returns the condition matching the rows with the given primary keys
+func pkInCondition(pkName string, pks []*schema.SQLValue) (string, []*schema.NamedParam) {
+	names := make([]string, len(pks))
+	params := make([]*schema.NamedParam, len(pks))
+
+	for i, pk := range pks {
+		params[i] = &schema.NamedParam{Name: fmt.Sprintf("pk%d", i), Value: pk}
+		names[i] = "@" + params[i].Name
+	}
+
+	return fmt.Sprintf("%s IN (%s)", pkName, strings.Join(names, ", ")), params
+}
+
+func upsertStmt(table string, cols []string, rows [][]*schema.SQLValue) *schema.SQLExecRequest {
+	var params []*schema.NamedParam
+
+	rowSpecs := make([]string, len(rows))
+
+	for i, row := range rows {
+		names := make([]string, len(row))
+
+		for j, v := range row {
+			p := &schema.NamedParam{Name: fmt.Sprintf("r%dc%d", i, j), Value: v}
+			params = append(params, p)
+			names[j] = "@" + p.Name
+		}
+
+		rowSpecs[i] = fmt.Sprintf("(%s)", strings.Join(names, ", "))
+	}
+
+	return &schema.SQLExecRequest{
+		Sql:    fmt.Sprintf("UPSERT INTO %s (%s) VALUES %s", table, strings.Join(cols, ", "), strings.Join(rowSpecs, ", ")),
+		Params: params,
+	}
+}
+
+func equalRowValues(values, others []*schema.SQLValue) (bool, error) {
+	if len(values) != len(others) {
+		return false, nil
+	}
+
+	for i, v := range values {
+		sv, ok := v.Value.(schema.SqlValue)
+		if !ok {
+			return false, sql.ErrCorruptedData
+		}
+
+		so, ok := others[i].Value.(schema.SqlValue)
+		if !ok {
+			return false, sql.ErrCorruptedData
+		}
+
+		equal, err := sv.Equal(so)
+		if err != nil || !equal {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// decodeRowValues returns the values of an encoded row by column id, null values are not included
+func decodeRowValues(encodedRow []byte, colTypes map[uint64]string) (map[uint64]*schema.SQLValue, error) {
+	off := 0
+
+	if len(encodedRow) < off+sql.EncLenLen {
+		return nil, sql.ErrCorruptedData
+	}
+
+	colsCount := binary.BigEndian.Uint32(encodedRow[off:])
+	off += sql.EncLenLen
+
+	values := make(map[uint64]*schema.SQLValue, colsCount)
+
+	for i := 0; i < int(colsCount); i++ {
+		if len(encodedRow) < off+sql.EncIDLen {
+			return nil, sql.ErrCorruptedData
+		}
+
+		colID := binary.BigEndian.Uint64(encodedRow[off:])
+		off += sql.EncIDLen
+
+		colType, ok := colTypes[colID]
+		if !ok {
+			return nil, sql.ErrCorruptedData
+		}
+
+		val, n, err := sql.DecodeValue(encodedRow[off:], colType)
+		if err != nil {
+			return nil, err
+		}
+
+		values[colID] = database.TypedValueToRowValue(val)
+		off += n
+	}
+
+	return values, nil
+}
+
+// tableSyncSource is the database a table sync job reads from, held either by the local server or by a remote one
+type tableSyncSource interface {
+	CurrentState(ctx context.Context) (*schema.ImmutableState, error)
+	VerifiableTxByID(ctx context.Context, req *schema.VerifiableTxRequest) (*schema.VerifiableTx, error)
+	Scan(ctx context.Context, req *schema.ScanRequest) (*schema.Entries, error)
+	GetAll(ctx context.Context, req *schema.KeyListRequest) (*schema.Entries, error)
+	VerifiableGet(ctx context.Context, req *schema.VerifiableGetRequest) (*schema.VerifiableEntry, error)
+	DescribeTable(ctx context.Context, table string) (*schema.SQLQueryResult, error)
+	SQLQuery(ctx context.Context, req *schema.SQLQueryRequest) (*schema.SQLQueryResult, error)
+	VerifiableSQLGet(ctx context.Context, req *schema.VerifiableSQLGetRequest) (*schema.VerifiableSQLEntry, error)
+	// reset drops the session with the source, if any
+	reset()
+	close() error
+}
+
+// localTableSyncSource reads from a database of the local server, it's resolved by name
+// on every access as databases may be reopened e.g. when their settings change
+type localTableSyncSource struct {
+	s      *ImmuServer
+	dbName string
+}
+
+func (src *localTableSyncSource) CurrentState(ctx context.Context) (*schema.ImmutableState, error) {
+	db, err := src.s.dbList.GetByName(src.dbName)
+	if err != nil {
+		return nil, err
+	}
+
+	return db.CurrentState()
+}
+
+func (src *localTableSyncSource) VerifiableTxByID(ctx context.Context, req *schema.VerifiableTxRequest) (*schema.VerifiableTx, error) {
+	db, err := src.s.dbList.GetByName(src.dbName)
+	if err != nil {
+		return nil, err
+	}
+
+	return db.VerifiableTxByID(req)
+}
+
+func (src *localTableSyncSource) Scan(ctx context.Context, req *schema.ScanRequest) (*schema.Entries, error) {
+	db, err := src.s.dbList.GetByName(src.dbName)
+	if err != nil {
+		return nil, err
+	}
+
+	return db.Scan(ctx, req)
+}
+
+func (src *localTableSyncSource) GetAll(ctx context.Context, req *schema.KeyListRequest) (*schema.Entries, error) {
+	db, err := src.s.dbList.GetByName(src.dbName)
+	if err != nil {
+		return nil, err
+	}
+
+	return db.GetAll(ctx, req)
+}
+
+func (src *localTableSyncSource) VerifiableGet(ctx context.Context, req *schema.VerifiableGetRequest) (*schema.VerifiableEntry, error) {
+	db, err := src.s.dbList.GetByName(src.dbName)
+	if err != nil {
+		return nil, err
+	}
+
+	return db.VerifiableGet(ctx, req)
+}
+
+func (src *localTableSyncSource) DescribeTable(ctx context.Context, table string) (*schema.SQLQueryResult, error) {
+	db, err := src.s.dbList.GetByName(src.dbName)
+	if err != nil {
+		return nil, err
+	}
+
+	return db.DescribeTable(table)
+}
+
+func (src *localTableSyncSource) SQLQuery(ctx context.Context, req *schema.SQLQueryRequest) (*schema.SQLQueryResult, error) {
+	db, err := src.s.dbList.GetByName(src.dbName)
+	if err != nil {
+		return nil, err
+	}
+
+	return db.SQLQuery(ctx, req)
+}
+
+func (src *localTableSyncSource) VerifiableSQLGet(ctx context.Context, req *schema.VerifiableSQLGetRequest) (*schema.VerifiableSQLEntry, error) {
+	db, err := src.s.dbList.GetByName(src.dbName)
+	if err != nil {
+		return nil, err
+	}
+
+	return db.VerifiableSQLGet(ctx, req)
+}
+
+func (src *localTableSyncSource) reset() {
+}
+
+func (src *localTableSyncSource) close() error {
+	return nil
+}
+
+// remoteTableSyncSource reads from a database of a remote server, the job logs in and selects
+// the source database the first time it's accessed and after each failed round
+type remoteTableSyncSource struct {
+	opts   *TableSyncOptions
+	conn   *grpc.ClientConn
+	client schema.ImmuServiceClient
+	token  string
+}
+
+func newRemoteTableSyncSource(opts *TableSyncOptions, dialOpts ...grpc.DialOption) (*remoteTableSyncSource, error) {
+	conn, err := grpc.Dial(fmt.Sprintf("%s:%d", opts.SourceAddress, opts.SourcePort), append([]grpc.DialOption{grpc.WithInsecure()}, dialOpts...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &remoteTableSyncSource{
+		opts:   opts,
+		conn:   conn,
+		client: schema.NewImmuServiceClient(conn),
+	}, nil
+}
+
+func (src *remoteTableSyncSource) contextFor(ctx context.Context) (context.Context, error) {
+	if src.opts.SourceUsername == "" {
+		// authentication is disabled in the remote server
+		return ctx, nil
+	}
+
+	if src.token == "" {
+		lr, err := src.client.Login(ctx, &schema.LoginRequest{
+			User:     []byte(src.opts.SourceUsername),
+			Password: []byte(src.opts.SourcePassword),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		ur, err := src.client.UseDatabase(
+			metadata.NewOutgoingContext(ctx, metadata.Pairs("authorization", lr.Token)),
+			&schema.Database{DatabaseName: src.opts.SourceDatabase},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		src.token = ur.Token
+	}
+
+	return metadata.NewOutgoingContext(ctx, metadata.Pairs("authorization", src.token)), nil
+}
+
+func (src *remoteTableSyncSource) CurrentState(ctx context.Context) (*schema.ImmutableState, error) {
+	ctx, err := src.contextFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return src.client.CurrentState(ctx, &empty.Empty{})
+}
+
+func (src *remoteTableSyncSource) VerifiableTxByID(ctx context.Context, req *schema.VerifiableTxRequest) (*schema.VerifiableTx, error) {
+	ctx, err := src.contextFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return src.client.VerifiableTxById(ctx, req)
+}
+
+func (src *remoteTableSyncSource) Scan(ctx context.Context, req *schema.ScanRequest) (*schema.Entries, error) {
+	ctx, err := src.contextFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return src.client.Scan(ctx, req)
+}
+
+func (src *remoteTableSyncSource) GetAll(ctx context.Context, req *schema.KeyListRequest) (*schema.Entries, error) {
+	ctx, err := src.contextFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return src.client.GetAll(ctx, req)
+}
+
+func (src *remoteTableSyncSource) VerifiableGet(ctx context.Context, req *schema.VerifiableGetRequest) (*schema.VerifiableEntry, error) {
+	ctx, err := src.contextFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return src.client.VerifiableGet(ctx, req)
+}
+
+func (src *remoteTableSyncSource) DescribeTable(ctx context.Context, table string) (*schema.SQLQueryResult, error) {
+	ctx, err := src.contextFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return src.client.DescribeTable(ctx, &schema.Table{TableName: table})
+}
+
+func (src *remoteTableSyncSource) SQLQuery(ctx context.Context, req *schema.SQLQueryRequest) (*schema.SQLQueryResult, error) {
+	ctx, err := src.contextFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return src.client.SQLQuery(ctx, req)
+}
+
+func (src *remoteTableSyncSource) VerifiableSQLGet(ctx context.Context, req *schema.VerifiableSQLGetRequest) (*schema.VerifiableSQLEntry, error) {
+	ctx, err := src.contextFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return src.client.VerifiableSQLGet(ctx, req)
+}
+
+func (src *remoteTableSyncSource) reset() {
+	src.token = ""
+}
+
+func (src *remoteTableSyncSource) close() error {
+	return src.conn.Close()
+}
+
+type tableSyncState struct {
+	TxID   uint64 `json:"txId"`
+	TxHash []byte `json:"txHash"`
+}
+
+func (s *ImmuServer) readTableSyncStates() (map[string]*tableSyncState, error) {
+	states := make(map[string]*tableSyncState)
+
+	content, err := ioutil.ReadFile(s.OS.Join(s.Options.Dir, tableSyncStatesFile))
+	if os.IsNotExist(err) {
+		return states, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(content, &states)
+	if err != nil {
+		return nil, fmt.Errorf("invalid table sync states at '%s': %v", s.Options.Dir, err)
+	}
+
+	return states, nil
+}
+
+// readTableSyncState returns the latest state of the source database verified by the job, an empty state is returned
+// when the job never verified the source database
+func (s *ImmuServer) readTableSyncState(job string) (*schema.ImmutableState, error) {
+	s.tableSyncStatesMux.Lock()
+	defer s.tableSyncStatesMux.Unlock()
+
+	states, err := s.readTableSyncStates()
+	if err != nil {
+		return nil, err
+	}
+
+	state, ok := states[job]
+	if !ok {
+		return &schema.ImmutableState{}, nil
+	}
+
+	return &schema.ImmutableState{TxId: state.TxID, TxHash: state.TxHash}, nil
+}
+
+func (s *ImmuServer) writeTableSyncState(job string, state *schema.ImmutableState) error {
+	s.tableSyncStatesMux.Lock()
+	defer s.tableSyncStatesMux.Unlock()
+
+	states, err := s.readTableSyncStates()
+	if err != nil {
+		return err
+	}
+
+	states[job] = &tableSyncState{TxID: state.TxId, TxHash: state.TxHash}
+
+	content, err := json.Marshal(states)
+	if err != nil {
+		return err
+	}
+
+	statesPath := s.OS.Join(s.Options.Dir, tableSyncStatesFile)
+	tmpPath := statesPath + ".tmp"
+
+	err = ioutil.WriteFile(tmpPath, content, 0600)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, statesPath)
+}
+
+func (s *ImmuServer) startTableSyncs() error {
+	for _, opts := range s.Options.TableSyncs {
+		j, err := newTableSync(s, opts)
+		if err != nil {
+			return logErr(s.Logger, "Unable to start table sync: %v", fmt.Errorf("'%s': %w", opts, err))
+		}
+
+		j.start()
+
+		s.tableSyncs = append(s.tableSyncs, j)
+
+		s.Logger.Infof("Syncing %s", opts)
+	}
+
+	return nil
+}
+
+func (s *ImmuServer) stopTableSyncs() error {
+	var errs []string
+
+	for _, j := range s.tableSyncs {
+		err := j.stop()
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	s.tableSyncs = nil
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,376 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newTableSyncTestServer(t *testing.T, dir string) (*ImmuServer, *bufconn.Listener) {
+	listener := bufconn.Listen(1024 * 1024)
+
+	s := DefaultServer().WithOptions(DefaultOptions().
+		WithDir(dir).
+		WithListener(listener).
+		WithTLS(nil).
+		WithMetricsServer(false).
+		WithAdminPassword(auth.SysAdminPassword),
+	).(*ImmuServer)
+
+	err := s.Initialize()
+	require.NoError(t, err)
+
+	go s.GrpcServer.Serve(listener)
+
+	return s, listener
+}
+
+func queryTableSyncRows(t *testing.T, s *ImmuServer, ctx context.Context, sql string) []string {
+	res, err := s.SQLQuery(ctx, &schema.SQLQueryRequest{Sql: sql})
+	require.NoError(t, err)
+
+	rows := make([]string, len(res.Rows))
+
+	for i, row := range res.Rows {
+		for j, v := range row.Values {
+			if j > 0 {
+				rows[i] += ","
+			}
+			rows[i] += schema.RenderValue(v.Value)
+		}
+	}
+
+	return rows
+}
+
+func TestTableSyncOptions(t *testing.T) {
+	opts := DefaultTableSyncOptions().
+		WithSourceDatabase("db1").
+		WithTable("table1").
+		WithTargetDatabase("db2")
+
+	require.NoError(t, opts.Validate())
+	require.False(t, opts.IsRemote())
+	require.Equal(t, "db1/table1 -> db2", opts.String())
+
+	opts.WithSourceAddress("10.0.0.1").WithSourcePort(3323)
+	require.True(t, opts.IsRemote())
+	require.Equal(t, "10.0.0.1:3323/db1/table1 -> db2", opts.String())
+
+	prefixOpts := DefaultTableSyncOptions().
+		WithSourceDatabase("db1").
+		WithPrefix([]byte("orders.")).
+		WithTargetDatabase("db2")
+	require.NoError(t, prefixOpts.Validate())
+	require.Equal(t, "db1?prefix=orders. -> db2", prefixOpts.String())
+
+	for _, invalid := range []*TableSyncOptions{
+		DefaultTableSyncOptions().WithSourceDatabase("db1").WithTable("table1"),
+		DefaultTableSyncOptions().WithSourceDatabase("db1").WithTargetDatabase("db2"),
+		DefaultTableSyncOptions().WithSourceDatabase("db1").WithTable("table1").WithPrefix([]byte("a")).WithTargetDatabase("db2"),
+		DefaultTableSyncOptions().WithSourceDatabase("db1").WithTable("table1").WithTargetDatabase("db1"),
+		DefaultTableSyncOptions().WithSourceDatabase("db1").WithTable("table1").WithTargetDatabase(SystemdbName),
+		DefaultTableSyncOptions().WithSourceDatabase("db1").WithTable("table1").WithTargetDatabase("db2").WithPollInterval(0),
+	} {
+		require.True(t, errors.Is(invalid.Validate(), ErrIllegalArguments), invalid.String())
+	}
+}
+
+func TestTableSync(t *testing.T) {
+	dir := "data_table_sync"
+	defer os.RemoveAll(dir)
+
+	s, _ := newTableSyncTestServer(t, dir)
+	defer s.GrpcServer.Stop()
+	defer s.CloseDatabases()
+
+	ctx := loginForReplication(t, s, DefaultdbName)
+
+	for _, db := range []string{"db1", "db2"} {
+		_, err := s.CreateDatabase(ctx, &schema.Database{DatabaseName: db})
+		require.NoError(t, err)
+	}
+
+	db1Ctx := loginForReplication(t, s, "db1")
+	db2Ctx := loginForReplication(t, s, "db2")
+
+	_, err := s.SQLExec(db1Ctx, &schema.SQLExecRequest{Sql: `
+		CREATE TABLE customers (id INTEGER, email VARCHAR NOT NULL UNIQUE, country VARCHAR, active BOOLEAN, PRIMARY KEY id);
+		CREATE INDEX ON customers(country);
+		CREATE TABLE secrets (id INTEGER, secret VARCHAR, PRIMARY KEY id);
+	`})
+	require.NoError(t, err)
+
+	// more rows than the size of a page
+	for i := 0; i < 2*tableSyncPageSize+10; i++ {
+		_, err = s.SQLExec(db1Ctx, &schema.SQLExecRequest{Sql: fmt.Sprintf(
+			"INSERT INTO customers (id, email, country, active) VALUES (%d, 'customer%d@example.com', '%s', %v)",
+			i, i, []string{"ES", "IT", "FR"}[i%3], i%2 == 0,
+		)})
+		require.NoError(t, err)
+	}
+
+	_, err = s.SQLExec(db1Ctx, &schema.SQLExecRequest{Sql: "INSERT INTO customers (id, email, country) VALUES (1000, 'noactive@example.com', 'NL')"})
+	require.NoError(t, err)
+
+	_, err = s.SQLExec(db1Ctx, &schema.SQLExecRequest{Sql: "INSERT INTO secrets (id, secret) VALUES (1, 'not shared')"})
+	require.NoError(t, err)
+
+	opts := DefaultTableSyncOptions().
+		WithSourceDatabase("db1").
+		WithTable("customers").
+		WithTargetDatabase("db2")
+
+	j, err := newTableSync(s, opts)
+	require.NoError(t, err)
+
+	j.ctx = context.Background()
+
+	err = j.sync()
+	require.NoError(t, err)
+
+	const selectAll = "SELECT id, email, country, active FROM customers"
+
+	require.Equal(t, queryTableSyncRows(t, s, db1Ctx, selectAll), queryTableSyncRows(t, s, db2Ctx, selectAll))
+	require.Len(t, queryTableSyncRows(t, s, db2Ctx, selectAll), 2*tableSyncPageSize+11)
+
+	// the table is created with the same columns and indexes
+	source, err := s.DescribeTable(db1Ctx, &schema.Table{TableName: "customers"})
+	require.NoError(t, err)
+
+	target, err := s.DescribeTable(db2Ctx, &schema.Table{TableName: "customers"})
+	require.NoError(t, err)
+	require.Equal(t, source.Rows, target.Rows)
+
+	// only the synced table is shared
+	_, err = s.SQLQuery(db2Ctx, &schema.SQLQueryRequest{Sql: "SELECT id FROM secrets"})
+	require.Error(t, err)
+
+	// updates and deletions are mirrored in the following rounds
+	_, err = s.SQLExec(db1Ctx, &schema.SQLExecRequest{Sql: `
+		UPSERT INTO customers (id, email, country, active) VALUES (3, 'moved@example.com', 'DE', false);
+		DELETE FROM customers WHERE id < 3 OR id = 1000;
+		INSERT INTO customers (id, email, country) VALUES (5000, 'new@example.com', 'NL');
+	`})
+	require.NoError(t, err)
+
+	err = j.sync()
+	require.NoError(t, err)
+
+	require.Equal(t, queryTableSyncRows(t, s, db1Ctx, selectAll), queryTableSyncRows(t, s, db2Ctx, selectAll))
+	require.Equal(t, []string{"3,\"moved@example.com\",\"DE\",false"}, queryTableSyncRows(t, s, db2Ctx, selectAll+" WHERE id <= 3"))
+
+	// rounds are skipped while the source is not changed
+	targetState, err := s.dbList.GetByName("db2")
+	require.NoError(t, err)
+
+	stateBefore, err := targetState.CurrentState()
+	require.NoError(t, err)
+
+	err = j.sync()
+	require.NoError(t, err)
+
+	stateAfter, err := targetState.CurrentState()
+	require.NoError(t, err)
+	require.Equal(t, stateBefore.TxId, stateAfter.TxId)
+
+	// the verified state of the source is kept across restarts
+	restarted, err := newTableSync(s, opts)
+	require.NoError(t, err)
+	require.Equal(t, j.state, restarted.state)
+
+	// a source with a different history is detected
+	restarted.ctx = context.Background()
+	restarted.state = &schema.ImmutableState{TxId: j.state.TxId, TxHash: make([]byte, len(j.state.TxHash))}
+
+	_, err = s.SQLExec(db1Ctx, &schema.SQLExecRequest{Sql: "INSERT INTO customers (id, email, country) VALUES (6000, 'other@example.com', 'NL')"})
+	require.NoError(t, err)
+
+	err = restarted.sync()
+	require.True(t, errors.Is(err, ErrSourceDiverged))
+
+	// jobs are run in the background once started
+	s.Options.WithTableSyncs(opts.WithPollInterval(10 * time.Millisecond))
+
+	err = s.startTableSyncs()
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		rows := queryTableSyncRows(t, s, db2Ctx, "SELECT id FROM customers WHERE id = 6000")
+		return len(rows) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	err = s.stopTableSyncs()
+	require.NoError(t, err)
+}
+
+func TestTableSyncPrefix(t *testing.T) {
+	dir := "data_table_sync_prefix"
+	defer os.RemoveAll(dir)
+
+	s, _ := newTableSyncTestServer(t, dir)
+	defer s.GrpcServer.Stop()
+	defer s.CloseDatabases()
+
+	ctx := loginForReplication(t, s, DefaultdbName)
+
+	_, err := s.CreateDatabase(ctx, &schema.Database{DatabaseName: "db1"})
+	require.NoError(t, err)
+
+	db1Ctx := loginForReplication(t, s, "db1")
+
+	for i := 0; i < tableSyncPageSize+10; i++ {
+		_, err = s.Set(ctx, &schema.SetRequest{KVs: []*schema.KeyValue{
+			{Key: []byte(fmt.Sprintf("orders.%03d", i)), Value: []byte(fmt.Sprintf("order%d", i))},
+			{Key: []byte(fmt.Sprintf("invoices.%03d", i)), Value: []byte(fmt.Sprintf("invoice%d", i))},
+		}})
+		require.NoError(t, err)
+	}
+
+	_, err = s.SetReference(ctx, &schema.ReferenceRequest{Key: []byte("orders.latest"), ReferencedKey: []byte("orders.007")})
+	require.NoError(t, err)
+
+	j, err := newTableSync(s, DefaultTableSyncOptions().
+		WithSourceDatabase(DefaultdbName).
+		WithPrefix([]byte("orders.")).
+		WithTargetDatabase("db1"))
+	require.NoError(t, err)
+
+	j.ctx = context.Background()
+
+	err = j.sync()
+	require.NoError(t, err)
+
+	scan := func(ctx context.Context, prefix string) *schema.Entries {
+		entries, err := s.Scan(ctx, &schema.ScanRequest{Prefix: []byte(prefix), Limit: 1000})
+		require.NoError(t, err)
+		return entries
+	}
+
+	entries := scan(db1Ctx, "orders.")
+	require.Len(t, entries.Entries, tableSyncPageSize+11)
+	require.Empty(t, scan(db1Ctx, "invoices.").Entries)
+
+	// references are mirrored with the value they resolve to
+	e, err := s.Get(db1Ctx, &schema.KeyRequest{Key: []byte("orders.latest")})
+	require.NoError(t, err)
+	require.Equal(t, []byte("order7"), e.Value)
+	require.Nil(t, e.ReferencedBy)
+
+	_, err = s.Set(ctx, &schema.SetRequest{KVs: []*schema.KeyValue{
+		{Key: []byte("orders.001"), Value: []byte("updated")},
+		{Key: []byte("orders.002"), Metadata: &schema.KVMetadata{Deleted: true}},
+	}})
+	require.NoError(t, err)
+
+	err = j.sync()
+	require.NoError(t, err)
+
+	e, err = s.Get(db1Ctx, &schema.KeyRequest{Key: []byte("orders.001")})
+	require.NoError(t, err)
+	require.Equal(t, []byte("updated"), e.Value)
+
+	_, err = s.Get(db1Ctx, &schema.KeyRequest{Key: []byte("orders.002")})
+	require.Error(t, err)
+
+	require.Len(t, scan(db1Ctx, "orders.").Entries, tableSyncPageSize+10)
+}
+
+func TestTableSyncRemote(t *testing.T) {
+	sourceDir := "data_table_sync_source"
+	defer os.RemoveAll(sourceDir)
+
+	targetDir := "data_table_sync_target"
+	defer os.RemoveAll(targetDir)
+
+	source, sourceListener := newTableSyncTestServer(t, sourceDir)
+	defer source.GrpcServer.Stop()
+	defer source.CloseDatabases()
+
+	target, _ := newTableSyncTestServer(t, targetDir)
+	defer target.GrpcServer.Stop()
+	defer target.CloseDatabases()
+
+	sourceCtx := loginForReplication(t, source, DefaultdbName)
+	targetCtx := loginForReplication(t, target, DefaultdbName)
+
+	_, err := source.SQLExec(sourceCtx, &schema.SQLExecRequest{Sql: `
+		CREATE TABLE products (code VARCHAR, price INTEGER, PRIMARY KEY code);
+		INSERT INTO products (code, price) VALUES ('a', 10), ('b', 20);
+	`})
+	require.NoError(t, err)
+
+	opts := DefaultTableSyncOptions().
+		WithSourceAddress("bufnet").
+		WithSourceUsername(auth.SysAdminUsername).
+		WithSourcePassword(auth.SysAdminPassword).
+		WithSourceDatabase(DefaultdbName).
+		WithTable("products").
+		WithTargetDatabase(DefaultdbName)
+
+	j, err := newTableSync(target, opts, grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+		return sourceListener.Dial()
+	}))
+	require.NoError(t, err)
+	defer j.source.close()
+
+	j.ctx = context.Background()
+
+	err = j.sync()
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"\"a\",10", "\"b\",20"}, queryTableSyncRows(t, target, targetCtx, "SELECT code, price FROM products"))
+
+	_, err = source.SQLExec(sourceCtx, &schema.SQLExecRequest{Sql: "UPSERT INTO products (code, price) VALUES ('b', 25)"})
+	require.NoError(t, err)
+
+	// a new session is opened when the previous one is dropped
+	j.source.reset()
+
+	err = j.sync()
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"\"a\",10", "\"b\",25"}, queryTableSyncRows(t, target, targetCtx, "SELECT code, price FROM products"))
+
+	// wrong credentials are reported
+	j.source.reset()
+	j.source.(*remoteTableSyncSource).opts = DefaultTableSyncOptions().
+		WithSourceAddress("bufnet").
+		WithSourceUsername(auth.SysAdminUsername).
+		WithSourcePassword("wrong").
+		WithSourceDatabase(DefaultdbName).
+		WithTable("products").
+		WithTargetDatabase(DefaultdbName)
+
+	_, err = source.SQLExec(sourceCtx, &schema.SQLExecRequest{Sql: "UPSERT INTO products (code, price) VALUES ('c', 30)"})
+	require.NoError(t, err)
+
+	err = j.sync()
+	require.Error(t, err)
+}
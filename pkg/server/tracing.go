@@ -0,0 +1,109 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// traceparentHeader is the W3C Trace Context header identifying the incoming request in a distributed trace
+const traceparentHeader = "traceparent"
+
+const traceIDLen = 16
+const parentIDLen = 8
+
+var errInvalidTraceField = errors.New("invalid traceparent field")
+
+// traceIDFor returns the trace-id to be recorded within the tx committed by the request.
+// The trace-id from the traceparent header takes precedence over the one set in the request.
+// No trace-id is recorded unless enabled by the server options.
+func (s *ImmuServer) traceIDFor(ctx context.Context, reqTraceID []byte) []byte {
+	if !s.Options.RecordTraceIDs {
+		return nil
+	}
+
+	traceID, ok := traceIDFromContext(ctx)
+	if ok {
+		return traceID
+	}
+
+	return reqTraceID
+}
+
+func traceIDFromContext(ctx context.Context) ([]byte, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, false
+	}
+
+	values := md.Get(traceparentHeader)
+	if len(values) != 1 {
+		return nil, false
+	}
+
+	return traceIDFromTraceparent(values[0])
+}
+
+// traceIDFromTraceparent parses a traceparent header value formatted as
+// version "-" trace-id "-" parent-id "-" trace-flags. Invalid values are ignored as mandated by the spec.
+func traceIDFromTraceparent(traceparent string) ([]byte, bool) {
+	fields := strings.Split(traceparent, "-")
+	if len(fields) < 4 {
+		return nil, false
+	}
+
+	version, err := decodeTraceField(fields[0], 1)
+	if err != nil || version[0] == 0xff {
+		return nil, false
+	}
+
+	// version 00 does not define additional fields, later versions may only append them
+	if version[0] == 0 && len(fields) != 4 {
+		return nil, false
+	}
+
+	traceID, err := decodeTraceField(fields[1], traceIDLen)
+	if err != nil || bytes.Equal(traceID, make([]byte, traceIDLen)) {
+		return nil, false
+	}
+
+	parentID, err := decodeTraceField(fields[2], parentIDLen)
+	if err != nil || bytes.Equal(parentID, make([]byte, parentIDLen)) {
+		return nil, false
+	}
+
+	_, err = decodeTraceField(fields[3], 1)
+	if err != nil {
+		return nil, false
+	}
+
+	return traceID, true
+}
+
+func decodeTraceField(field string, size int) ([]byte, error) {
+	if len(field) != 2*size || strings.ToLower(field) != field {
+		return nil, errInvalidTraceField
+	}
+
+	return hex.DecodeString(field)
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"encoding/hex"
+	"os"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestTraceIDFromTraceparent(t *testing.T) {
+	traceID, _ := hex.DecodeString("4bf92f3577b34da6a3ce929d0e0e4736")
+
+	for _, c := range []struct {
+		traceparent string
+		valid       bool
+	}{
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", true},
+		{"01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-future", true},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-future", false},
+		{"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", false},
+		{"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01", false},
+		{"00-00000000000000000000000000000000-00f067aa0ba902b7-01", false},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", false},
+		{"00-4bf92f3577b34da6a3ce929d0e0e47-00f067aa0ba902b7-01", false},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7", false},
+		{"---", false},
+		{"", false},
+	} {
+		id, ok := traceIDFromTraceparent(c.traceparent)
+		require.Equal(t, c.valid, ok, c.traceparent)
+
+		if c.valid {
+			require.Equal(t, traceID, id)
+		}
+	}
+}
+
+func TestServerRecordTraceIDs(t *testing.T) {
+	dir := "data_trace_ids"
+	defer os.RemoveAll(dir)
+
+	serverOptions := DefaultOptions().
+		WithDir(dir).
+		WithListener(bufconn.Listen(1024 * 1024)).
+		WithMetricsServer(false).
+		WithAdminPassword(auth.SysAdminPassword).
+		WithRecordTraceIDs(true)
+
+	s := DefaultServer().WithOptions(serverOptions).(*ImmuServer)
+
+	err := s.Initialize()
+	require.NoError(t, err)
+
+	lr, err := s.Login(context.Background(), &schema.LoginRequest{
+		User:     []byte(auth.SysAdminUsername),
+		Password: []byte(auth.SysAdminPassword),
+	})
+	require.NoError(t, err)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", lr.Token))
+
+	_, err = s.UseDatabase(ctx, &schema.Database{DatabaseName: DefaultdbName})
+	require.NoError(t, err)
+
+	tracedCtx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"authorization", lr.Token,
+		"traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	))
+
+	traceID, _ := hex.DecodeString("4bf92f3577b34da6a3ce929d0e0e4736")
+
+	kv := &schema.KeyValue{Key: []byte("key"), Value: []byte("value")}
+
+	md, err := s.Set(tracedCtx, &schema.SetRequest{KVs: []*schema.KeyValue{kv}})
+	require.NoError(t, err)
+	require.Equal(t, traceID, md.Attributes.TraceID)
+
+	tx, err := s.TxById(ctx, &schema.TxRequest{Tx: md.Id})
+	require.NoError(t, err)
+	require.Equal(t, traceID, tx.Metadata.Attributes.TraceID)
+	require.Equal(t, schema.TxFrom(tx).Alh, schema.TxMetadataFrom(md).Alh())
+
+	md, err = s.ExecAll(tracedCtx, &schema.ExecAllRequest{
+		Operations: []*schema.Op{{Operation: &schema.Op_Kv{Kv: kv}}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, traceID, md.Attributes.TraceID)
+
+	// without the traceparent header, the trace-id set in the request is recorded
+	md, err = s.Set(ctx, &schema.SetRequest{KVs: []*schema.KeyValue{kv}, TraceID: traceID})
+	require.NoError(t, err)
+	require.Equal(t, traceID, md.Attributes.TraceID)
+
+	_, err = s.Set(ctx, &schema.SetRequest{KVs: []*schema.KeyValue{kv}, TraceID: []byte("invalid")})
+	require.Error(t, err)
+
+	md, err = s.Set(ctx, &schema.SetRequest{KVs: []*schema.KeyValue{kv}})
+	require.NoError(t, err)
+	require.Nil(t, md.Attributes)
+
+	s.Options.RecordTraceIDs = false
+
+	md, err = s.Set(tracedCtx, &schema.SetRequest{KVs: []*schema.KeyValue{kv}, TraceID: traceID})
+	require.NoError(t, err)
+	require.Nil(t, md.Attributes)
+}
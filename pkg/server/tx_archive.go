@@ -0,0 +1,80 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/stream"
+)
+
+// ExportTxRange streams an archive holding the txs committed into the database in the requested range,
+// it can be applied onto a backup holding the first tx of the range thus backups are incrementally updated
+func (s *ImmuServer) ExportTxRange(req *schema.TxRangeRequest, str schema.ImmuService_ExportTxRangeServer) error {
+	db, err := s.getDbFromCtx(str.Context(), "ExportTxRange")
+	if err != nil {
+		return err
+	}
+	defer s.dbList.Release(db)
+
+	// the archive is written into a temporary file as its size needs to be known before it's streamed
+	f, err := ioutil.TempFile("", "immudb_tx_range_")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	_, err = db.ExportTxRange(req, f)
+	if err != nil {
+		return err
+	}
+
+	size, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Seek(0, io.SeekStart)
+	if err != nil {
+		return err
+	}
+
+	return s.StreamServiceFactory.NewMsgSender(str).Send(f, int(size))
+}
+
+// ApplyTxRange commits the txs held by the streamed archive, the latest tx of the database must be
+// the one the archive was exported since
+func (s *ImmuServer) ApplyTxRange(str schema.ImmuService_ApplyTxRangeServer) error {
+	db, err := s.getDbFromCtx(str.Context(), "ApplyTxRange")
+	if err != nil {
+		return err
+	}
+	defer s.dbList.Release(db)
+
+	r := stream.NewValueReader(s.StreamServiceFactory.NewMsgReceiver(str))
+
+	txRange, err := db.ApplyTxRange(r)
+	if err != nil {
+		return err
+	}
+
+	return str.SendAndClose(txRange)
+}
@@ -25,6 +25,7 @@ import (
 	"sync"
 
 	"github.com/codenotary/immudb/pkg/database"
+	"github.com/codenotary/immudb/pkg/kms"
 	"github.com/rs/xid"
 
 	"google.golang.org/grpc"
@@ -62,9 +63,16 @@ type ImmuServer struct {
 	webServer            *http.Server
 	mux                  sync.Mutex
 	pgsqlMux             sync.Mutex
+	dbDeletionMux        sync.Mutex
+	encryptionKeysMux    sync.Mutex
+	keyProvider          kms.KeyProvider
 	StateSigner          StateSigner
 	StreamServiceFactory stream.ServiceFactory
 	PgsqlSrv             pgsqlsrv.Server
+	replicator           *replicator
+	tableSyncs           []*tableSync
+	tableSyncStatesMux   sync.Mutex
+	applications         *applications
 }
 
 // DefaultServer ...
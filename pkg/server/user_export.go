@@ -0,0 +1,339 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// time imported users have to choose their passwords
+const passwordResetTokenTTL = 72 * time.Hour
+
+const passwordResetTokenLen = 32
+
+// passwordReset is stored in the system database for each token issued to a user, only the hash of the token is kept
+type passwordReset struct {
+	HashedToken []byte    `json:"hashedtoken"` //empty once the token has been used
+	ExpiresAt   time.Time `json:"expiresat"`
+}
+
+// ExportUsers returns all the users of the server but the system admin, along with their permissions.
+// Passwords are not exported
+func (s *ImmuServer) ExportUsers(ctx context.Context, _ *empty.Empty) (*schema.UserList, error) {
+	s.Logger.Debugf("ExportUsers")
+
+	err := s.checkSysAdminFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	itemList, err := s.sysDb.Scan(ctx, &schema.ScanRequest{
+		Prefix:  []byte{KeyPrefixUser},
+		SinceTx: math.MaxUint64,
+		NoWait:  true,
+	})
+	if err != nil {
+		s.Logger.Errorf("error getting users: %v", err)
+		return nil, err
+	}
+
+	userlist := &schema.UserList{}
+
+	for _, e := range itemList.Entries {
+		var user auth.User
+
+		err = json.Unmarshal(e.Value, &user)
+		if err != nil {
+			return nil, err
+		}
+
+		if user.Username == auth.SysAdminUsername {
+			continue
+		}
+
+		permissions := make([]*schema.Permission, len(user.Permissions))
+		for i, p := range user.Permissions {
+			permissions[i] = &schema.Permission{Database: p.Database, Permission: p.Permission}
+		}
+
+		userlist.Users = append(userlist.Users, &schema.User{
+			User:        []byte(user.Username),
+			Permissions: permissions,
+			Createdby:   user.CreatedBy,
+			Createdat:   user.CreatedAt.String(),
+			Active:      user.Active,
+		})
+	}
+
+	return userlist, nil
+}
+
+// ImportUsers creates the exported users, or updates the permissions and status of the existing ones.
+// New users get a random password and a token to choose their own one, existing users keep their passwords
+func (s *ImmuServer) ImportUsers(ctx context.Context, req *schema.UserList) (*schema.ImportUsersResponse, error) {
+	s.Logger.Debugf("ImportUsers")
+
+	err := s.checkSysAdminFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req == nil {
+		return nil, ErrIllegalArguments
+	}
+
+	// the whole list is validated before any user is written
+	imported := make(map[string]struct{}, len(req.Users))
+
+	for _, u := range req.Users {
+		username := string(u.User)
+
+		if !auth.IsValidUsername(username) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid username '%s'", username)
+		}
+
+		if username == auth.SysAdminUsername {
+			return nil, status.Errorf(codes.InvalidArgument, "the system admin can not be imported")
+		}
+
+		if _, ok := imported[username]; ok {
+			return nil, status.Errorf(codes.InvalidArgument, "user %s is duplicated", username)
+		}
+		imported[username] = struct{}{}
+
+		for _, p := range u.Permissions {
+			if len(p.Database) == 0 || p.Database == SystemdbName {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid database '%s' for user %s", p.Database, username)
+			}
+
+			if p.Permission != auth.PermissionR &&
+				p.Permission != auth.PermissionRW &&
+				p.Permission != auth.PermissionAdmin {
+				return nil, status.Errorf(codes.InvalidArgument, "unrecognized permission for user %s", username)
+			}
+		}
+	}
+
+	_, loggedInUser, err := s.getLoggedInUserdataFromCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &schema.ImportUsersResponse{}
+
+	for _, u := range req.Users {
+		user, err := s.getUser(u.User, true)
+		isNew := err != nil
+
+		if isNew {
+			password := make([]byte, passwordResetTokenLen)
+
+			_, err = rand.Read(password)
+			if err != nil {
+				return nil, err
+			}
+
+			user = &auth.User{Username: string(u.User)}
+
+			_, err = user.SetPassword([]byte(hex.EncodeToString(password)))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		user.Permissions = make([]auth.Permission, len(u.Permissions))
+		for i, p := range u.Permissions {
+			user.Permissions[i] = auth.Permission{Database: p.Database, Permission: p.Permission}
+		}
+
+		user.Active = u.Active
+		user.CreatedBy = loggedInUser.Username
+		user.CreatedAt = time.Now()
+
+		err = s.saveUser(user)
+		if err != nil {
+			return nil, err
+		}
+
+		s.removeUserFromLoginList(user.Username)
+
+		if !isNew {
+			continue
+		}
+
+		token, err := s.issuePasswordResetToken(user.Username)
+		if err != nil {
+			return nil, err
+		}
+
+		res.Tokens = append(res.Tokens, token)
+	}
+
+	return res, nil
+}
+
+// ResetPassword sets the password of a user holding a valid password reset token, thus no login is required.
+// Tokens can be used only once
+func (s *ImmuServer) ResetPassword(ctx context.Context, r *schema.ResetPasswordRequest) (*empty.Empty, error) {
+	s.Logger.Debugf("ResetPassword")
+
+	if !s.Options.GetAuth() {
+		return nil, fmt.Errorf("this command is available only with authentication on")
+	}
+
+	if r == nil || len(r.User) == 0 {
+		return nil, fmt.Errorf("username can not be empty")
+	}
+
+	user, err := s.getUser(r.User, true)
+	if err != nil {
+		return nil, ErrInvalidResetToken
+	}
+
+	reset, err := s.getPasswordReset(user.Username)
+	if err != nil {
+		return nil, ErrInvalidResetToken
+	}
+
+	hashedToken := sha256.Sum256([]byte(r.Token))
+
+	if len(reset.HashedToken) == 0 ||
+		subtle.ConstantTimeCompare(hashedToken[:], reset.HashedToken) != 1 ||
+		time.Now().After(reset.ExpiresAt) {
+		return nil, ErrInvalidResetToken
+	}
+
+	if err := auth.IsStrongPassword(string(r.NewPassword)); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	_, err = user.SetPassword(r.NewPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	user.CreatedAt = time.Now()
+
+	err = s.saveUser(user)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.savePasswordReset(user.Username, &passwordReset{})
+	if err != nil {
+		return nil, err
+	}
+
+	s.removeUserFromLoginList(user.Username)
+	auth.DropTokenKeys(user.Username)
+
+	return new(empty.Empty), nil
+}
+
+func (s *ImmuServer) checkSysAdminFromCtx(ctx context.Context) error {
+	if !s.Options.GetAuth() {
+		return fmt.Errorf("this command is available only with authentication on")
+	}
+
+	_, user, err := s.getLoggedInUserdataFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf("please login first")
+	}
+
+	if !user.IsSysAdmin {
+		return fmt.Errorf("Logged In user does not have permissions for this operation")
+	}
+
+	return nil
+}
+
+func (s *ImmuServer) issuePasswordResetToken(username string) (*schema.PasswordResetToken, error) {
+	b := make([]byte, passwordResetTokenLen)
+
+	_, err := rand.Read(b)
+	if err != nil {
+		return nil, err
+	}
+
+	token := hex.EncodeToString(b)
+	hashedToken := sha256.Sum256([]byte(token))
+
+	reset := &passwordReset{
+		HashedToken: hashedToken[:],
+		ExpiresAt:   time.Now().Add(passwordResetTokenTTL),
+	}
+
+	err = s.savePasswordReset(username, reset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &schema.PasswordResetToken{
+		User:      []byte(username),
+		Token:     token,
+		ExpiresAt: reset.ExpiresAt.Unix(),
+	}, nil
+}
+
+func passwordResetKey(username string) []byte {
+	key := make([]byte, 1+len(username))
+	key[0] = KeyPrefixPasswordReset
+	copy(key[1:], username)
+	return key
+}
+
+func (s *ImmuServer) getPasswordReset(username string) (*passwordReset, error) {
+	item, err := s.sysDb.Get(context.Background(), &schema.KeyRequest{Key: passwordResetKey(username)})
+	if err != nil {
+		return nil, err
+	}
+
+	var reset passwordReset
+
+	err = json.Unmarshal(item.Value, &reset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &reset, nil
+}
+
+func (s *ImmuServer) savePasswordReset(username string, reset *passwordReset) error {
+	resetData, err := json.Marshal(reset)
+	if err != nil {
+		return logErr(s.Logger, "error saving password reset token: %v", err)
+	}
+
+	kv := &schema.KeyValue{Key: passwordResetKey(username), Value: resetData}
+	_, err = s.sysDb.Set(context.Background(), &schema.SetRequest{KVs: []*schema.KeyValue{kv}})
+
+	return logErr(s.Logger, "error saving password reset token: %v", err)
+}
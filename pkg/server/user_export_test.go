@@ -0,0 +1,210 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func loggedInServer(t *testing.T, dir string) (*ImmuServer, context.Context) {
+	serverOptions := DefaultOptions().WithDir(dir).WithListener(bufconn.Listen(1024 * 1024)).WithMetricsServer(false).WithAdminPassword(auth.SysAdminPassword)
+	s := DefaultServer().WithOptions(serverOptions).(*ImmuServer)
+
+	err := s.Initialize()
+	require.NoError(t, err)
+
+	lr, err := s.Login(context.Background(), &schema.LoginRequest{
+		User:     []byte(auth.SysAdminUsername),
+		Password: []byte(auth.SysAdminPassword),
+	})
+	require.NoError(t, err)
+
+	md := metadata.Pairs("authorization", lr.Token)
+
+	return s, metadata.NewIncomingContext(context.Background(), md)
+}
+
+func TestExportImportUsers(t *testing.T) {
+	defer os.RemoveAll("data_users_src")
+	defer os.RemoveAll("data_users_dst")
+
+	src, srcCtx := loggedInServer(t, "data_users_src")
+	defer src.CloseDatabases()
+
+	_, err := src.ExportUsers(context.Background(), &emptypb.Empty{})
+	require.Error(t, err)
+
+	_, err = src.CreateUser(srcCtx, &schema.CreateUserRequest{
+		User:       []byte("user1"),
+		Password:   []byte("Password1!"),
+		Permission: auth.PermissionRW,
+		Database:   DefaultdbName,
+	})
+	require.NoError(t, err)
+
+	_, err = src.CreateUser(srcCtx, &schema.CreateUserRequest{
+		User:       []byte("user2"),
+		Password:   []byte("Password2!"),
+		Permission: auth.PermissionAdmin,
+		Database:   DefaultdbName,
+	})
+	require.NoError(t, err)
+
+	_, err = src.SetActiveUser(srcCtx, &schema.SetActiveUserRequest{Username: "user2", Active: false})
+	require.NoError(t, err)
+
+	users, err := src.ExportUsers(srcCtx, &emptypb.Empty{})
+	require.NoError(t, err)
+	require.Len(t, users.Users, 2)
+	require.Equal(t, []byte("user1"), users.Users[0].User)
+	require.True(t, users.Users[0].Active)
+	require.Equal(t, []*schema.Permission{{Database: DefaultdbName, Permission: auth.PermissionRW}}, users.Users[0].Permissions)
+	require.Equal(t, []byte("user2"), users.Users[1].User)
+	require.False(t, users.Users[1].Active)
+
+	dst, dstCtx := loggedInServer(t, "data_users_dst")
+	defer dst.CloseDatabases()
+
+	t.Run("invalid imports should fail without importing any user", func(t *testing.T) {
+		_, err = dst.ImportUsers(dstCtx, nil)
+		require.Equal(t, ErrIllegalArguments, err)
+
+		_, err = dst.ImportUsers(dstCtx, &schema.UserList{Users: []*schema.User{users.Users[0], users.Users[0]}})
+		require.Error(t, err)
+
+		_, err = dst.ImportUsers(dstCtx, &schema.UserList{Users: []*schema.User{
+			users.Users[0],
+			{User: []byte(auth.SysAdminUsername)},
+		}})
+		require.Error(t, err)
+
+		_, err = dst.ImportUsers(dstCtx, &schema.UserList{Users: []*schema.User{
+			users.Users[0],
+			{User: []byte("user3"), Permissions: []*schema.Permission{{Database: SystemdbName, Permission: auth.PermissionR}}},
+		}})
+		require.Error(t, err)
+
+		_, err = dst.ImportUsers(dstCtx, &schema.UserList{Users: []*schema.User{
+			{User: []byte("user3"), Permissions: []*schema.Permission{{Database: DefaultdbName, Permission: auth.PermissionSysAdmin}}},
+		}})
+		require.Error(t, err)
+
+		exported, err := dst.ExportUsers(dstCtx, &emptypb.Empty{})
+		require.NoError(t, err)
+		require.Empty(t, exported.Users)
+	})
+
+	res, err := dst.ImportUsers(dstCtx, users)
+	require.NoError(t, err)
+	require.Len(t, res.Tokens, 2)
+	require.Equal(t, []byte("user1"), res.Tokens[0].User)
+	require.Greater(t, res.Tokens[0].ExpiresAt, time.Now().Unix())
+
+	imported, err := dst.ExportUsers(dstCtx, &emptypb.Empty{})
+	require.NoError(t, err)
+	require.Len(t, imported.Users, 2)
+
+	for i, u := range imported.Users {
+		require.Equal(t, users.Users[i].User, u.User)
+		require.Equal(t, users.Users[i].Active, u.Active)
+		require.Equal(t, users.Users[i].Permissions, u.Permissions)
+		require.Equal(t, auth.SysAdminUsername, u.Createdby)
+	}
+
+	// passwords are not exported
+	_, err = dst.Login(context.Background(), &schema.LoginRequest{User: []byte("user1"), Password: []byte("Password1!")})
+	require.Error(t, err)
+
+	_, err = dst.ResetPassword(context.Background(), &schema.ResetPasswordRequest{
+		User:        []byte("user1"),
+		Token:       res.Tokens[1].Token,
+		NewPassword: []byte("Password3!"),
+	})
+	require.Equal(t, ErrInvalidResetToken, err)
+
+	_, err = dst.ResetPassword(context.Background(), &schema.ResetPasswordRequest{
+		User:        []byte("unknown"),
+		Token:       res.Tokens[0].Token,
+		NewPassword: []byte("Password3!"),
+	})
+	require.Equal(t, ErrInvalidResetToken, err)
+
+	_, err = dst.ResetPassword(context.Background(), &schema.ResetPasswordRequest{
+		User:        []byte("user1"),
+		Token:       res.Tokens[0].Token,
+		NewPassword: []byte("weak"),
+	})
+	require.Error(t, err)
+
+	_, err = dst.ResetPassword(context.Background(), &schema.ResetPasswordRequest{
+		User:        []byte("user1"),
+		Token:       res.Tokens[0].Token,
+		NewPassword: []byte("Password3!"),
+	})
+	require.NoError(t, err)
+
+	_, err = dst.Login(context.Background(), &schema.LoginRequest{User: []byte("user1"), Password: []byte("Password3!")})
+	require.NoError(t, err)
+
+	// tokens can be used only once
+	_, err = dst.ResetPassword(context.Background(), &schema.ResetPasswordRequest{
+		User:        []byte("user1"),
+		Token:       res.Tokens[0].Token,
+		NewPassword: []byte("Password4!"),
+	})
+	require.Equal(t, ErrInvalidResetToken, err)
+
+	t.Run("importing existing users should only update their permissions and status", func(t *testing.T) {
+		users.Users[0].Permissions = []*schema.Permission{{Database: DefaultdbName, Permission: auth.PermissionR}}
+
+		res, err := dst.ImportUsers(dstCtx, &schema.UserList{Users: users.Users[:1]})
+		require.NoError(t, err)
+		require.Empty(t, res.Tokens)
+
+		user, err := dst.getUser([]byte("user1"), true)
+		require.NoError(t, err)
+		require.Equal(t, []auth.Permission{{Database: DefaultdbName, Permission: auth.PermissionR}}, user.Permissions)
+
+		_, err = dst.Login(context.Background(), &schema.LoginRequest{User: []byte("user1"), Password: []byte("Password3!")})
+		require.NoError(t, err)
+	})
+
+	t.Run("expired tokens should be rejected", func(t *testing.T) {
+		token, err := dst.issuePasswordResetToken("user2")
+		require.NoError(t, err)
+
+		err = dst.savePasswordReset("user2", &passwordReset{HashedToken: []byte("expired"), ExpiresAt: time.Now().Add(-time.Second)})
+		require.NoError(t, err)
+
+		_, err = dst.ResetPassword(context.Background(), &schema.ResetPasswordRequest{
+			User:        []byte("user2"),
+			Token:       token.Token,
+			NewPassword: []byte("Password4!"),
+		})
+		require.Equal(t, ErrInvalidResetToken, err)
+	})
+}
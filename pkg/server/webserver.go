@@ -19,6 +19,7 @@ func StartWebServer(addr string, tlsConfig *tls.Config, s schema.ImmuServiceServ
 
 	webMux := http.NewServeMux()
 	webMux.Handle("/api/", http.StripPrefix("/api", proxyMux))
+	webMux.Handle(SQLQueryHTTPPath, sqlQueryHTTPHandler(s))
 
 	err = webconsole.SetupWebconsole(webMux, l, addr)
 	if err != nil {
@@ -35,3 +35,32 @@ func ReadValue(vr io.Reader, chunkSize int) (value []byte, err error) {
 	}
 	return value, err
 }
+
+// NewValueReader returns a reader of a single message, io.EOF is returned once the message is fully read
+// thus the value can be consumed in chunks without being held in memory
+func NewValueReader(vr io.Reader) io.Reader {
+	return &valueReader{vr: vr}
+}
+
+type valueReader struct {
+	vr  io.Reader
+	eof bool
+}
+
+func (r *valueReader) Read(b []byte) (int, error) {
+	if r.eof {
+		return 0, io.EOF
+	}
+
+	n, err := r.vr.Read(b)
+	if err == io.EOF || (err == nil && n == 0) {
+		// a message is completed when no data is returned
+		r.eof = true
+		if n > 0 {
+			return n, nil
+		}
+		return 0, io.EOF
+	}
+
+	return n, err
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/codenotary/immudb/pkg/stream/streamtest"
 	"github.com/stretchr/testify/require"
 	"io"
+	"io/ioutil"
 	"testing"
 )
 
@@ -56,3 +57,28 @@ func TestParseEmptyContent(t *testing.T) {
 	require.Equal(t, io.EOF, err)
 	require.Nil(t, value)
 }
+
+func TestValueReader(t *testing.T) {
+	reads := []struct {
+		n   int
+		err error
+	}{{4, nil}, {2, nil}, {0, nil}, {4, nil}}
+
+	b := &streamtest.ErrReader{ReadF: func(i []byte) (int, error) {
+		r := reads[0]
+		reads = reads[1:]
+		return r.n, r.err
+	}}
+
+	vr := NewValueReader(b)
+
+	value, err := ioutil.ReadAll(vr)
+	require.NoError(t, err)
+	require.Len(t, value, 6)
+
+	// following messages are not consumed
+	require.Len(t, reads, 1)
+
+	_, err = vr.Read(make([]byte, 4))
+	require.Equal(t, io.EOF, err)
+}
@@ -0,0 +1,318 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package testvectors generates deterministic test vectors out of a store filled with seeded random entries,
+// so implementations of the verification algorithms in other languages can be checked against the Go reference.
+package testvectors
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"time"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/logger"
+)
+
+// ErrIllegalArguments is returned when the generation options are not valid
+var ErrIllegalArguments = errors.New("illegal arguments")
+
+// Options test vectors generation options
+type Options struct {
+	// Seed initializes the generator of keys and values, the same seed always produces the same vectors
+	Seed int64
+	// TxCount is the number of committed transactions, dual proofs are generated for every pair of them
+	TxCount int
+	// MaxEntriesPerTx and MaxKeyLen and MaxValueLen bound the size of the random transactions
+	MaxEntriesPerTx int
+	MaxKeyLen       int
+	MaxValueLen     int
+	// FirstTs is the timestamp of the first transaction, each following one is a second later
+	FirstTs int64
+}
+
+// DefaultOptions returns default test vectors generation options
+func DefaultOptions() *Options {
+	return &Options{
+		Seed:            0,
+		TxCount:         8,
+		MaxEntriesPerTx: 4,
+		MaxKeyLen:       16,
+		MaxValueLen:     32,
+		FirstTs:         1609459200, // 2021-01-01T00:00:00Z
+	}
+}
+
+// WithSeed sets the seed of the generator of keys and values
+func (o *Options) WithSeed(seed int64) *Options {
+	o.Seed = seed
+	return o
+}
+
+// WithTxCount sets the number of committed transactions
+func (o *Options) WithTxCount(txCount int) *Options {
+	o.TxCount = txCount
+	return o
+}
+
+// WithMaxEntriesPerTx sets the max number of entries of each transaction
+func (o *Options) WithMaxEntriesPerTx(maxEntriesPerTx int) *Options {
+	o.MaxEntriesPerTx = maxEntriesPerTx
+	return o
+}
+
+// WithMaxKeyLen sets the max length of the generated keys
+func (o *Options) WithMaxKeyLen(maxKeyLen int) *Options {
+	o.MaxKeyLen = maxKeyLen
+	return o
+}
+
+// WithMaxValueLen sets the max length of the generated values
+func (o *Options) WithMaxValueLen(maxValueLen int) *Options {
+	o.MaxValueLen = maxValueLen
+	return o
+}
+
+// WithFirstTs sets the timestamp of the first transaction
+func (o *Options) WithFirstTs(firstTs int64) *Options {
+	o.FirstTs = firstTs
+	return o
+}
+
+func (o *Options) valid() bool {
+	return o != nil &&
+		o.TxCount > 0 &&
+		o.MaxEntriesPerTx > 0 &&
+		o.MaxEntriesPerTx <= store.MaxTxEntries &&
+		o.MaxKeyLen > 0 &&
+		o.MaxKeyLen <= store.MaxKeyLen &&
+		o.MaxValueLen >= 0 &&
+		o.FirstTs >= 0
+}
+
+// TestVectors holds the generated vectors, byte strings and digests are hex encoded
+// and proofs use the canonical JSON encoding of schema.ProofFormatVersion
+type TestVectors struct {
+	ProofFormatVersion int          `json:"proofFormatVersion"`
+	Seed               int64        `json:"seed"`
+	Txs                []*Tx        `json:"txs"`
+	DualProofs         []*DualProof `json:"dualProofs"`
+}
+
+// Tx holds a committed transaction, its entries verify against EH and its Alh against the dual proofs
+type Tx struct {
+	ID      uint64   `json:"id"`
+	Ts      int64    `json:"ts"`
+	Alh     string   `json:"alh"`
+	EH      string   `json:"eh"`
+	Entries []*Entry `json:"entries"`
+}
+
+// Entry holds a committed entry, Digest is the leaf proven by InclusionProof to be part of the tx
+type Entry struct {
+	Key            string          `json:"key"`
+	Value          string          `json:"value"`
+	Digest         string          `json:"digest"`
+	InclusionProof json.RawMessage `json:"inclusionProof"`
+}
+
+// DualProof proves the tx TargetTxID, with alh TargetAlh, extends the tx SourceTxID with alh SourceAlh
+type DualProof struct {
+	SourceTxID uint64          `json:"sourceTxId"`
+	SourceAlh  string          `json:"sourceAlh"`
+	TargetTxID uint64          `json:"targetTxId"`
+	TargetAlh  string          `json:"targetAlh"`
+	Proof      json.RawMessage `json:"proof"`
+}
+
+// Generate commits seeded random transactions into a temporary store and returns the vectors derived from them
+func Generate(opts *Options) (*TestVectors, error) {
+	if !opts.valid() {
+		return nil, ErrIllegalArguments
+	}
+
+	dir, err := ioutil.TempDir("", "immudb_testvectors")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	ts := opts.FirstTs
+
+	stOpts := store.DefaultOptions().
+		WithSynced(false).
+		WithMaxTxEntries(opts.MaxEntriesPerTx).
+		WithMaxKeyLen(opts.MaxKeyLen).
+		WithMaxValueLen(maxInt(opts.MaxValueLen, 1)).
+		// binary linking is done at commit time so each tx links to all its predecessors
+		WithMaxLinearProofLen(0).
+		WithTimeFunc(func() time.Time {
+			t := time.Unix(ts, 0)
+			ts++
+			return t
+		}).
+		WithLog(logger.NewSimpleLogger("testvectors ", ioutil.Discard))
+
+	st, err := store.Open(dir, stOpts)
+	if err != nil {
+		return nil, err
+	}
+	defer st.Close()
+
+	rnd := rand.New(rand.NewSource(opts.Seed))
+
+	values := make(map[uint64]map[string][]byte, opts.TxCount)
+
+	for i := 0; i < opts.TxCount; i++ {
+		kvs := randomKVs(rnd, opts)
+
+		txMetadata, err := st.Commit(kvs, false)
+		if err != nil {
+			return nil, err
+		}
+
+		values[txMetadata.ID] = make(map[string][]byte, len(kvs))
+		for _, kv := range kvs {
+			values[txMetadata.ID][string(kv.Key)] = kv.Value
+		}
+	}
+
+	vectors := &TestVectors{
+		ProofFormatVersion: schema.ProofFormatVersion,
+		Seed:               opts.Seed,
+		Txs:                make([]*Tx, opts.TxCount),
+	}
+
+	txs := make([]*store.Tx, opts.TxCount)
+
+	for i := range txs {
+		txs[i] = st.NewTx()
+
+		err = st.ReadTx(uint64(i+1), txs[i])
+		if err != nil {
+			return nil, err
+		}
+
+		vectors.Txs[i], err = txVectorFor(txs[i], values[txs[i].ID])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range txs {
+		for j := i; j < len(txs); j++ {
+			dproof, err := st.DualProof(txs[i], txs[j])
+			if err != nil {
+				return nil, err
+			}
+
+			proof, err := schema.EncodeDualProofJSON(schema.DualProofTo(dproof))
+			if err != nil {
+				return nil, err
+			}
+
+			vectors.DualProofs = append(vectors.DualProofs, &DualProof{
+				SourceTxID: txs[i].ID,
+				SourceAlh:  hex.EncodeToString(txs[i].Alh[:]),
+				TargetTxID: txs[j].ID,
+				TargetAlh:  hex.EncodeToString(txs[j].Alh[:]),
+				Proof:      proof,
+			})
+		}
+	}
+
+	return vectors, nil
+}
+
+func txVectorFor(tx *store.Tx, values map[string][]byte) (*Tx, error) {
+	eh := tx.Eh()
+
+	txVector := &Tx{
+		ID:      tx.ID,
+		Ts:      tx.Ts,
+		Alh:     hex.EncodeToString(tx.Alh[:]),
+		EH:      hex.EncodeToString(eh[:]),
+		Entries: make([]*Entry, len(tx.Entries())),
+	}
+
+	for i, e := range tx.Entries() {
+		iproof, err := tx.Proof(e.Key())
+		if err != nil {
+			return nil, err
+		}
+
+		proof, err := schema.EncodeInclusionProofJSON(schema.InclusionProofTo(iproof))
+		if err != nil {
+			return nil, err
+		}
+
+		digest := e.Digest()
+
+		txVector.Entries[i] = &Entry{
+			Key:            hex.EncodeToString(e.Key()),
+			Value:          hex.EncodeToString(values[string(e.Key())]),
+			Digest:         hex.EncodeToString(digest[:]),
+			InclusionProof: proof,
+		}
+	}
+
+	return txVector, nil
+}
+
+func randomKVs(rnd *rand.Rand, opts *Options) []*store.KV {
+	kvs := make([]*store.KV, 1+rnd.Intn(opts.MaxEntriesPerTx))
+	keys := make(map[string]struct{}, len(kvs))
+
+	for i := range kvs {
+		var key []byte
+
+		// keys must be unique within a tx
+		for {
+			key = randomBytes(rnd, 1+rnd.Intn(opts.MaxKeyLen))
+
+			_, found := keys[string(key)]
+			if !found {
+				break
+			}
+		}
+		keys[string(key)] = struct{}{}
+
+		kvs[i] = &store.KV{
+			Key:   key,
+			Value: randomBytes(rnd, rnd.Intn(opts.MaxValueLen+1)),
+		}
+	}
+
+	return kvs
+}
+
+func randomBytes(rnd *rand.Rand, n int) []byte {
+	b := make([]byte, n)
+	rnd.Read(b)
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testvectors
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/codenotary/immudb/embedded/htree"
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateInvalidOptions(t *testing.T) {
+	_, err := Generate(nil)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = Generate(DefaultOptions().WithTxCount(0))
+	require.Equal(t, ErrIllegalArguments, err)
+
+	_, err = Generate(DefaultOptions().WithMaxKeyLen(store.MaxKeyLen + 1))
+	require.Equal(t, ErrIllegalArguments, err)
+}
+
+func TestGenerate(t *testing.T) {
+	opts := DefaultOptions().WithSeed(42).WithTxCount(5)
+
+	vectors, err := Generate(opts)
+	require.NoError(t, err)
+	require.Equal(t, schema.ProofFormatVersion, vectors.ProofFormatVersion)
+	require.Len(t, vectors.Txs, 5)
+	require.Len(t, vectors.DualProofs, 5*6/2)
+
+	b1, err := json.Marshal(vectors)
+	require.NoError(t, err)
+
+	vectors, err = Generate(opts)
+	require.NoError(t, err)
+
+	b2, err := json.Marshal(vectors)
+	require.NoError(t, err)
+	require.Equal(t, b1, b2)
+
+	vectors, err = Generate(DefaultOptions().WithSeed(43).WithTxCount(5))
+	require.NoError(t, err)
+
+	b3, err := json.Marshal(vectors)
+	require.NoError(t, err)
+	require.NotEqual(t, b1, b3)
+
+	alhs := make(map[uint64][32]byte)
+
+	for i, tx := range vectors.Txs {
+		require.Equal(t, uint64(i+1), tx.ID)
+		require.Equal(t, opts.FirstTs+int64(i), tx.Ts)
+
+		alhs[tx.ID] = digestFrom(t, tx.Alh)
+		eh := digestFrom(t, tx.EH)
+
+		for _, e := range tx.Entries {
+			key, err := hex.DecodeString(e.Key)
+			require.NoError(t, err)
+
+			value, err := hex.DecodeString(e.Value)
+			require.NoError(t, err)
+
+			kv := &store.KV{Key: key, Value: value}
+			require.Equal(t, digestFrom(t, e.Digest), kv.Digest())
+
+			iproof, err := schema.DecodeInclusionProofJSON(e.InclusionProof)
+			require.NoError(t, err)
+			require.True(t, htree.VerifyInclusion(schema.InclusionProofFrom(iproof), kv.Digest(), eh))
+		}
+	}
+
+	for _, dp := range vectors.DualProofs {
+		require.Equal(t, alhs[dp.SourceTxID], digestFrom(t, dp.SourceAlh))
+		require.Equal(t, alhs[dp.TargetTxID], digestFrom(t, dp.TargetAlh))
+
+		dproof, err := schema.DecodeDualProofJSON(dp.Proof)
+		require.NoError(t, err)
+
+		verifies := store.VerifyDualProof(schema.DualProofFrom(dproof), dp.SourceTxID, dp.TargetTxID, alhs[dp.SourceTxID], alhs[dp.TargetTxID])
+		require.True(t, verifies)
+	}
+}
+
+func digestFrom(t *testing.T, s string) [32]byte {
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	require.Len(t, b, 32)
+
+	var d [32]byte
+	copy(d[:], b)
+
+	return d
+}
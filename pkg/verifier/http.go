@@ -0,0 +1,141 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package verifier
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+)
+
+// State is the JSON representation of the state of a database
+type State struct {
+	Db     string `json:"db"`
+	TxID   uint64 `json:"txId"`
+	TxHash string `json:"txHash"`
+}
+
+// VerificationResult is the JSON representation of the result of a verification request
+type VerificationResult struct {
+	Verified bool `json:"verified"`
+	// State is the verified state the requested state was checked against
+	State *State `json:"state,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+func stateTo(st *schema.ImmutableState) *State {
+	if st == nil {
+		return nil
+	}
+
+	return &State{
+		Db:     st.Db,
+		TxID:   st.TxId,
+		TxHash: hex.EncodeToString(st.TxHash),
+	}
+}
+
+// Handler serves the verification requests of clients:
+//
+//	GET  /state?db=<db>  returns the latest verified state of the database
+//	POST /verify         verifies the State sent in the body against the verified state of its database
+func (v *Verifier) Handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/state", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		db := r.URL.Query().Get("db")
+		if db == "" {
+			http.Error(w, ErrIllegalArguments.Error(), http.StatusBadRequest)
+			return
+		}
+
+		st, err := v.State(db)
+		if err != nil {
+			http.Error(w, err.Error(), statusFor(err))
+			return
+		}
+
+		writeJSON(w, stateTo(st))
+	})
+
+	mux.HandleFunc("/verify", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var req State
+
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		txHash, err := hex.DecodeString(req.TxHash)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		verifiedState, err := v.VerifyState(r.Context(), &schema.ImmutableState{
+			Db:     req.Db,
+			TxId:   req.TxID,
+			TxHash: txHash,
+		})
+		if err != nil && !errors.Is(err, ErrInconsistentState) {
+			http.Error(w, err.Error(), statusFor(err))
+			return
+		}
+
+		res := &VerificationResult{
+			Verified: err == nil,
+			State:    stateTo(verifiedState),
+		}
+		if err != nil {
+			res.Error = err.Error()
+		}
+
+		writeJSON(w, res)
+	})
+
+	return mux
+}
+
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err, ErrIllegalArguments):
+		return http.StatusBadRequest
+	case errors.Is(err, ErrDatabaseNotVerified):
+		return http.StatusNotFound
+	}
+	// the immudb server could not be reached or failed to serve the request
+	return http.StatusBadGateway
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
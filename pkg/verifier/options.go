@@ -0,0 +1,125 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package verifier
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"time"
+)
+
+// Options verifier options
+type Options struct {
+	// ServerAddress and ServerPort locate the immudb server being verified
+	ServerAddress string
+	ServerPort    int
+	Username      string
+	Password      string `json:"-"`
+	// Databases lists the verified databases, all the databases the user has access to when empty
+	Databases []string
+	// Dir holds the latest verified state of each database
+	Dir string
+	// SyncInterval is the time between two consecutive verifications of the databases
+	SyncInterval time.Duration
+	// ServerSigningPubKey is used to check the signature of the states of the server when set
+	ServerSigningPubKey *ecdsa.PublicKey
+	// Address and Port are used to serve verification requests
+	Address string
+	Port    int
+}
+
+// DefaultOptions returns default verifier options
+func DefaultOptions() *Options {
+	return &Options{
+		ServerAddress: "127.0.0.1",
+		ServerPort:    3322,
+		Dir:           "./verifier",
+		SyncInterval:  time.Minute,
+		Address:       "0.0.0.0",
+		Port:          3325,
+	}
+}
+
+// WithServerAddress sets the address of the immudb server
+func (o *Options) WithServerAddress(address string) *Options {
+	o.ServerAddress = address
+	return o
+}
+
+// WithServerPort sets the port of the immudb server
+func (o *Options) WithServerPort(port int) *Options {
+	o.ServerPort = port
+	return o
+}
+
+// WithUsername sets the user the verifier logs in with
+func (o *Options) WithUsername(username string) *Options {
+	o.Username = username
+	return o
+}
+
+// WithPassword sets the password of the user the verifier logs in with
+func (o *Options) WithPassword(password string) *Options {
+	o.Password = password
+	return o
+}
+
+// WithDatabases sets the verified databases
+func (o *Options) WithDatabases(databases ...string) *Options {
+	o.Databases = databases
+	return o
+}
+
+// WithDir sets the folder holding the verified states
+func (o *Options) WithDir(dir string) *Options {
+	o.Dir = dir
+	return o
+}
+
+// WithSyncInterval sets the time between two consecutive verifications of the databases
+func (o *Options) WithSyncInterval(syncInterval time.Duration) *Options {
+	o.SyncInterval = syncInterval
+	return o
+}
+
+// WithServerSigningPubKey sets the public key used to check the signature of the states of the server
+func (o *Options) WithServerSigningPubKey(pubKey *ecdsa.PublicKey) *Options {
+	o.ServerSigningPubKey = pubKey
+	return o
+}
+
+// WithAddress sets the address verification requests are served on
+func (o *Options) WithAddress(address string) *Options {
+	o.Address = address
+	return o
+}
+
+// WithPort sets the port verification requests are served on
+func (o *Options) WithPort(port int) *Options {
+	o.Port = port
+	return o
+}
+
+// ServerBind returns the address of the immudb server
+func (o *Options) ServerBind() string {
+	return fmt.Sprintf("%s:%d", o.ServerAddress, o.ServerPort)
+}
+
+// Bind returns the address verification requests are served on
+func (o *Options) Bind() string {
+	return fmt.Sprintf("%s:%d", o.Address, o.Port)
+}
@@ -0,0 +1,337 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package verifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/codenotary/immudb/embedded/store"
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/client/cache"
+	"github.com/codenotary/immudb/pkg/client/state"
+	"github.com/codenotary/immudb/pkg/logger"
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/metadata"
+)
+
+var ErrIllegalArguments = errors.New("illegal arguments")
+var ErrDatabaseNotVerified = errors.New("database is not verified by this node")
+var ErrInvalidSignature = errors.New("signature of the server state could not be verified")
+var ErrInconsistentState = errors.New("state is not consistent with the verified one")
+
+// Verifier keeps the latest verified state of each database of an immudb server. Every new state of the server
+// is accepted only if it's proven to extend the verified one, and it's then used to verify the states held by clients.
+// Neither keys nor values are kept, thus verifiers are cheap to run as independent verification nodes.
+type Verifier struct {
+	opts   *Options
+	client schema.ImmuServiceClient
+	states cache.Cache
+	log    logger.Logger
+
+	serverID string
+
+	// tokens holds the token used to access each database
+	tokens map[string]string
+
+	mutex sync.Mutex
+}
+
+// NewVerifier returns a verifier of the databases served by client, the verified states are kept in states
+func NewVerifier(client schema.ImmuServiceClient, states cache.Cache, opts *Options, log logger.Logger) (*Verifier, error) {
+	if client == nil || states == nil || opts == nil || log == nil {
+		return nil, ErrIllegalArguments
+	}
+
+	return &Verifier{
+		opts:   opts,
+		client: client,
+		states: states,
+		log:    log,
+		tokens: make(map[string]string),
+	}, nil
+}
+
+// Open identifies the immudb server and locks its verified states
+func (v *Verifier) Open(ctx context.Context) error {
+	serverID, err := state.NewUUIDProvider(v.client).CurrentUUID(ctx)
+	if err == state.ErrNoServerUuid {
+		v.log.Warningf(err.Error())
+
+		serverID = regexp.MustCompile(`[^a-zA-Z0-9\-_]+`).ReplaceAllString(v.opts.ServerBind(), "_")
+	} else if err != nil {
+		return err
+	}
+
+	err = v.states.Lock(serverID)
+	if err != nil {
+		return err
+	}
+
+	v.serverID = serverID
+
+	return nil
+}
+
+// Close releases the verified states
+func (v *Verifier) Close() error {
+	return v.states.Unlock()
+}
+
+// Run verifies the databases every sync interval until stopc is closed
+func (v *Verifier) Run(stopc <-chan struct{}) {
+	for {
+		v.SyncAll(context.Background())
+
+		select {
+		case <-stopc:
+			return
+		case <-time.After(v.opts.SyncInterval):
+		}
+	}
+}
+
+// SyncAll verifies the current state of all the databases, errors are logged
+func (v *Verifier) SyncAll(ctx context.Context) {
+	dbs, err := v.databases(ctx)
+	if err != nil {
+		v.log.Errorf("Unable to list databases: %v", err)
+		return
+	}
+
+	for _, db := range dbs {
+		st, err := v.Sync(ctx, db)
+		if err != nil {
+			v.log.Errorf("Unable to verify database '%s': %v", db, err)
+			continue
+		}
+
+		v.log.Infof("Database '%s' verified at tx %d with hash %x", db, st.TxId, st.TxHash)
+	}
+}
+
+// Sync fetches the current state of the database from the server, it's accepted as the new verified state
+// only if the server proves it extends the verified one. The first state is trusted as is.
+func (v *Verifier) Sync(ctx context.Context, db string) (*schema.ImmutableState, error) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	return v.sync(ctx, db)
+}
+
+func (v *Verifier) sync(ctx context.Context, db string) (*schema.ImmutableState, error) {
+	ctx, err := v.contextFor(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	serverState, err := v.client.CurrentState(ctx, &empty.Empty{})
+	if err != nil {
+		// the token is renewed as it may have expired
+		delete(v.tokens, db)
+		return nil, err
+	}
+
+	serverState.Db = db
+
+	if v.opts.ServerSigningPubKey != nil {
+		ok, err := serverState.CheckSignature(v.opts.ServerSigningPubKey)
+		if err != nil || !ok {
+			return nil, ErrInvalidSignature
+		}
+	}
+
+	verifiedState, err := v.state(db)
+	if err == ErrDatabaseNotVerified {
+		// trust on first use
+		return serverState, v.states.Set(v.serverID, db, serverState)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if serverState.TxId < verifiedState.TxId {
+		return nil, fmt.Errorf("%w: the server state at tx %d is behind the verified one at tx %d", ErrInconsistentState, serverState.TxId, verifiedState.TxId)
+	}
+
+	if serverState.TxId == verifiedState.TxId {
+		if !bytes.Equal(serverState.TxHash, verifiedState.TxHash) {
+			return nil, fmt.Errorf("%w: the server state at tx %d has a different hash", ErrInconsistentState, serverState.TxId)
+		}
+		return verifiedState, nil
+	}
+
+	err = v.verifyConsistency(ctx, verifiedState, serverState)
+	if err != nil {
+		return nil, err
+	}
+
+	return serverState, v.states.Set(v.serverID, db, serverState)
+}
+
+// State returns the latest verified state of the database
+func (v *Verifier) State(db string) (*schema.ImmutableState, error) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	return v.state(db)
+}
+
+func (v *Verifier) state(db string) (*schema.ImmutableState, error) {
+	st, err := v.states.Get(v.serverID, db)
+	if err == cache.ErrPrevStateNotFound {
+		return nil, ErrDatabaseNotVerified
+	}
+	return st, err
+}
+
+// VerifyState checks the state is consistent with the verified state of its database i.e. both belong to the
+// same history, the verified state is returned along with ErrInconsistentState if they are not.
+// The verified state is synced with the server when the given state is ahead of it.
+func (v *Verifier) VerifyState(ctx context.Context, st *schema.ImmutableState) (*schema.ImmutableState, error) {
+	if st == nil || st.Db == "" || st.TxId == 0 || len(st.TxHash) != sha256.Size {
+		return nil, ErrIllegalArguments
+	}
+
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	verifiedState, err := v.state(st.Db)
+	if err != nil {
+		return nil, err
+	}
+
+	if st.TxId > verifiedState.TxId {
+		verifiedState, err = v.sync(ctx, st.Db)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if st.TxId > verifiedState.TxId {
+		return verifiedState, fmt.Errorf("%w: tx %d was not committed into the server", ErrInconsistentState, st.TxId)
+	}
+
+	if st.TxId == verifiedState.TxId {
+		if !bytes.Equal(st.TxHash, verifiedState.TxHash) {
+			return verifiedState, fmt.Errorf("%w: the state at tx %d has a different hash", ErrInconsistentState, st.TxId)
+		}
+		return verifiedState, nil
+	}
+
+	ctx, err = v.contextFor(ctx, st.Db)
+	if err != nil {
+		return nil, err
+	}
+
+	return verifiedState, v.verifyConsistency(ctx, st, verifiedState)
+}
+
+// verifyConsistency asks the server to prove target extends source
+func (v *Verifier) verifyConsistency(ctx context.Context, source, target *schema.ImmutableState) error {
+	vtx, err := v.client.VerifiableTxById(ctx, &schema.VerifiableTxRequest{
+		Tx:           source.TxId,
+		ProveSinceTx: target.TxId,
+	})
+	if err != nil {
+		return err
+	}
+
+	if vtx.DualProof == nil {
+		return store.ErrCorruptedData
+	}
+
+	verifies := store.VerifyDualProof(
+		schema.DualProofFrom(vtx.DualProof),
+		source.TxId,
+		target.TxId,
+		schema.DigestFrom(source.TxHash),
+		schema.DigestFrom(target.TxHash),
+	)
+	if !verifies {
+		return fmt.Errorf("%w: the state at tx %d is not included in the state at tx %d", ErrInconsistentState, source.TxId, target.TxId)
+	}
+
+	return nil
+}
+
+func (v *Verifier) databases(ctx context.Context) ([]string, error) {
+	if len(v.opts.Databases) > 0 {
+		return v.opts.Databases, nil
+	}
+
+	ctx, err := v.login(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := v.client.DatabaseList(ctx, &empty.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	dbs := make([]string, len(res.Databases))
+	for i, db := range res.Databases {
+		dbs[i] = db.DatabaseName
+	}
+
+	sort.Strings(dbs)
+
+	return dbs, nil
+}
+
+// contextFor returns the context used to access the database, the verifier logs in and
+// selects the database the first time it's accessed
+func (v *Verifier) contextFor(ctx context.Context, db string) (context.Context, error) {
+	token, ok := v.tokens[db]
+
+	if !ok {
+		loggedCtx, err := v.login(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := v.client.UseDatabase(loggedCtx, &schema.Database{DatabaseName: db})
+		if err != nil {
+			return nil, err
+		}
+
+		token = res.Token
+		v.tokens[db] = token
+	}
+
+	return metadata.NewOutgoingContext(ctx, metadata.Pairs("authorization", token)), nil
+}
+
+func (v *Verifier) login(ctx context.Context) (context.Context, error) {
+	res, err := v.client.Login(ctx, &schema.LoginRequest{
+		User:     []byte(v.opts.Username),
+		Password: []byte(v.opts.Password),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return metadata.NewOutgoingContext(ctx, metadata.Pairs("authorization", res.Token)), nil
+}
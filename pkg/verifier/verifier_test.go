@@ -0,0 +1,208 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package verifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/codenotary/immudb/pkg/api/schema"
+	"github.com/codenotary/immudb/pkg/auth"
+	"github.com/codenotary/immudb/pkg/client/cache"
+	"github.com/codenotary/immudb/pkg/logger"
+	"github.com/codenotary/immudb/pkg/server"
+	"github.com/codenotary/immudb/pkg/server/servertest"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestVerifier(t *testing.T) {
+	serverDir := "data_verifier_server"
+	defer os.RemoveAll(serverDir)
+
+	statesDir := "data_verifier_states"
+	defer os.RemoveAll(statesDir)
+
+	require.NoError(t, os.MkdirAll(statesDir, 0755))
+
+	bs := servertest.NewBufconnServer(server.DefaultOptions().WithDir(serverDir).WithAuth(true).WithAdminPassword(auth.SysAdminPassword))
+	bs.Start()
+	defer bs.Stop()
+
+	conn, err := grpc.Dial("verifier", grpc.WithContextDialer(bs.Dialer), grpc.WithInsecure())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	client := schema.NewImmuServiceClient(conn)
+
+	lr, err := client.Login(context.Background(), &schema.LoginRequest{
+		User:     []byte(auth.SysAdminUsername),
+		Password: []byte(auth.SysAdminPassword),
+	})
+	require.NoError(t, err)
+
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs("authorization", lr.Token))
+
+	set := func(value string) *schema.ImmutableState {
+		_, err := client.Set(ctx, &schema.SetRequest{KVs: []*schema.KeyValue{{Key: []byte("key"), Value: []byte(value)}}})
+		require.NoError(t, err)
+
+		st, err := client.CurrentState(ctx, &empty.Empty{})
+		require.NoError(t, err)
+		st.Db = "defaultdb"
+
+		return st
+	}
+
+	opts := DefaultOptions().
+		WithUsername(auth.SysAdminUsername).
+		WithPassword(auth.SysAdminPassword).
+		WithDir(statesDir)
+
+	log := logger.NewSimpleLogger("immuverifier ", os.Stderr)
+
+	_, err = NewVerifier(nil, cache.NewFileCache(statesDir), opts, log)
+	require.Equal(t, ErrIllegalArguments, err)
+
+	v, err := NewVerifier(client, cache.NewFileCache(statesDir), opts, log)
+	require.NoError(t, err)
+
+	err = v.Open(context.Background())
+	require.NoError(t, err)
+	defer v.Close()
+
+	_, err = v.State("defaultdb")
+	require.Equal(t, ErrDatabaseNotVerified, err)
+
+	oldState := set("value1")
+
+	// the first state is trusted as is
+	v.SyncAll(context.Background())
+
+	verifiedState, err := v.State("defaultdb")
+	require.NoError(t, err)
+	require.Equal(t, oldState.TxId, verifiedState.TxId)
+	require.Equal(t, oldState.TxHash, verifiedState.TxHash)
+
+	set("value2")
+	currentState := set("value3")
+
+	verifiedState, err = v.Sync(context.Background(), "defaultdb")
+	require.NoError(t, err)
+	require.Equal(t, currentState.TxId, verifiedState.TxId)
+	require.Equal(t, currentState.TxHash, verifiedState.TxHash)
+
+	t.Run("verify states", func(t *testing.T) {
+		_, err := v.VerifyState(context.Background(), &schema.ImmutableState{Db: "defaultdb"})
+		require.Equal(t, ErrIllegalArguments, err)
+
+		_, err = v.VerifyState(context.Background(), &schema.ImmutableState{Db: "db1", TxId: 1, TxHash: oldState.TxHash})
+		require.Equal(t, ErrDatabaseNotVerified, err)
+
+		_, err = v.VerifyState(context.Background(), oldState)
+		require.NoError(t, err)
+
+		_, err = v.VerifyState(context.Background(), currentState)
+		require.NoError(t, err)
+
+		// states ahead of the verified one are checked once the verifier is synced
+		aheadState := set("value4")
+
+		verifiedState, err := v.VerifyState(context.Background(), aheadState)
+		require.NoError(t, err)
+		require.Equal(t, aheadState.TxId, verifiedState.TxId)
+
+		tamperedState := &schema.ImmutableState{Db: "defaultdb", TxId: oldState.TxId, TxHash: make([]byte, len(oldState.TxHash))}
+
+		_, err = v.VerifyState(context.Background(), tamperedState)
+		require.True(t, errors.Is(err, ErrInconsistentState))
+
+		tamperedState.TxId = currentState.TxId
+
+		_, err = v.VerifyState(context.Background(), tamperedState)
+		require.True(t, errors.Is(err, ErrInconsistentState))
+
+		tamperedState.TxId = aheadState.TxId + 1
+
+		_, err = v.VerifyState(context.Background(), tamperedState)
+		require.True(t, errors.Is(err, ErrInconsistentState))
+	})
+
+	t.Run("serve verification requests", func(t *testing.T) {
+		srv := httptest.NewServer(v.Handler())
+		defer srv.Close()
+
+		res, err := http.Get(srv.URL + "/state?db=defaultdb")
+		require.NoError(t, err)
+		defer res.Body.Close()
+		require.Equal(t, http.StatusOK, res.StatusCode)
+
+		var st State
+		err = json.NewDecoder(res.Body).Decode(&st)
+		require.NoError(t, err)
+		require.Equal(t, "defaultdb", st.Db)
+
+		res, err = http.Get(srv.URL + "/state?db=db1")
+		require.NoError(t, err)
+		defer res.Body.Close()
+		require.Equal(t, http.StatusNotFound, res.StatusCode)
+
+		verify := func(st *State) (int, *VerificationResult) {
+			body, err := json.Marshal(st)
+			require.NoError(t, err)
+
+			res, err := http.Post(srv.URL+"/verify", "application/json", bytes.NewReader(body))
+			require.NoError(t, err)
+			defer res.Body.Close()
+
+			if res.StatusCode != http.StatusOK {
+				return res.StatusCode, nil
+			}
+
+			var vr VerificationResult
+			err = json.NewDecoder(res.Body).Decode(&vr)
+			require.NoError(t, err)
+
+			return res.StatusCode, &vr
+		}
+
+		status, vr := verify(&State{Db: "defaultdb", TxID: oldState.TxId, TxHash: hex.EncodeToString(oldState.TxHash)})
+		require.Equal(t, http.StatusOK, status)
+		require.True(t, vr.Verified)
+		require.Equal(t, st.TxID, vr.State.TxID)
+
+		status, vr = verify(&State{Db: "defaultdb", TxID: oldState.TxId, TxHash: hex.EncodeToString(make([]byte, 32))})
+		require.Equal(t, http.StatusOK, status)
+		require.False(t, vr.Verified)
+		require.NotEmpty(t, vr.Error)
+
+		status, _ = verify(&State{Db: "defaultdb", TxID: oldState.TxId, TxHash: "invalid"})
+		require.Equal(t, http.StatusBadRequest, status)
+
+		status, _ = verify(&State{Db: "db1", TxID: oldState.TxId, TxHash: hex.EncodeToString(oldState.TxHash)})
+		require.Equal(t, http.StatusNotFound, status)
+	})
+}
@@ -223,7 +223,7 @@ func main() {
 	client, ctx := connect(c)
 
 	log.Printf("Creating tables\r\n")
-	_, err := client.SQLExec(ctx, "CREATE TABLE IF NOT EXISTS entries (id INTEGER, value BLOB, ts INTEGER, PRIMARY KEY id);", nil)
+	_, err := client.SQLExec(ctx, "CREATE TABLE IF NOT EXISTS entries (id INTEGER, value BLOB, ts TIMESTAMP, PRIMARY KEY id);", nil)
 	if err != nil {
 		panic(err)
 	}
@@ -0,0 +1,69 @@
+/*
+Copyright 2021 CodeNotary, Inc. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// testvectors writes deterministic verification test vectors as JSON, to be used by SDKs in other languages
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"io"
+	"log"
+	"os"
+
+	"github.com/codenotary/immudb/pkg/testvectors"
+)
+
+func main() {
+	opts := testvectors.DefaultOptions()
+
+	var out string
+
+	flag.Int64Var(&opts.Seed, "seed", opts.Seed, "seed of the generated keys and values")
+	flag.IntVar(&opts.TxCount, "txCount", opts.TxCount, "number of transactions")
+	flag.IntVar(&opts.MaxEntriesPerTx, "maxEntries", opts.MaxEntriesPerTx, "max number of entries per tx")
+	flag.IntVar(&opts.MaxKeyLen, "maxKeyLen", opts.MaxKeyLen, "max key length (bytes)")
+	flag.IntVar(&opts.MaxValueLen, "maxValueLen", opts.MaxValueLen, "max value length (bytes)")
+	flag.Int64Var(&opts.FirstTs, "firstTs", opts.FirstTs, "timestamp of the first transaction (unix seconds)")
+	flag.StringVar(&out, "out", "", "output file, stdout when empty")
+
+	flag.Parse()
+
+	vectors, err := testvectors.Generate(opts)
+	if err != nil {
+		log.Fatalln("Failed to generate test vectors. Reason:", err)
+	}
+
+	var w io.Writer = os.Stdout
+
+	if out != "" {
+		f, err := os.Create(out)
+		if err != nil {
+			log.Fatalln("Failed to create output file. Reason:", err)
+		}
+		defer f.Close()
+
+		w = f
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	err = enc.Encode(vectors)
+	if err != nil {
+		log.Fatalln("Failed to write test vectors. Reason:", err)
+	}
+}